@@ -22,13 +22,15 @@ import web from "../web"
 import history from "../history"
 import AboutModal from "./AboutModal"
 import ChangePasswordModal from "./ChangePasswordModal"
+import UsageDashboardModal from "./UsageDashboardModal"
 
 export class BrowserDropdown extends React.Component {
   constructor(props) {
     super(props)
     this.state = {
       showAboutModal: false,
-      showChangePasswordModal: false
+      showChangePasswordModal: false,
+      showUsageDashboard: false
     }
   }
   showAbout(e) {
@@ -53,6 +55,17 @@ export class BrowserDropdown extends React.Component {
       showChangePasswordModal: false
     })
   }
+  showUsageDashboard(e) {
+    e.preventDefault()
+    this.setState({
+      showUsageDashboard: true
+    })
+  }
+  hideUsageDashboard() {
+    this.setState({
+      showUsageDashboard: false
+    })
+  }
   componentDidMount() {
     const { fetchServerInfo } = this.props
     fetchServerInfo()
@@ -107,6 +120,20 @@ export class BrowserDropdown extends React.Component {
                 Ask for help <i className="fas fa-question-circle" />
               </a>
             </li>
+            <li>
+              <a
+                href=""
+                id="show-usage"
+                onClick={this.showUsageDashboard.bind(this)}
+              >
+                Usage <i className="fas fa-chart-bar" />
+              </a>
+              {this.state.showUsageDashboard && (
+                <UsageDashboardModal
+                  hideUsageDashboard={this.hideUsageDashboard.bind(this)}
+                />
+              )}
+            </li>
             <li>
               <a href="" id="show-about" onClick={this.showAbout.bind(this)}>
                 About <i className="fas fa-info-circle" />
@@ -0,0 +1,184 @@
+/*
+ * MinIO Cloud Storage (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import React from "react"
+import { connect } from "react-redux"
+import { Modal, ModalHeader, ModalBody } from "react-bootstrap"
+import humanize from "humanize"
+import Moment from "moment"
+import web from "../web"
+import * as alertActions from "../alert/actions"
+
+const CHART_WIDTH = 600
+const CHART_HEIGHT = 150
+
+// usagePoints - returns the points of the line chart of the sizes of
+// the samples over time.
+export const usagePoints = (samples) => {
+  if (samples.length == 0) return ""
+  const start = new Date(samples[0].time).getTime()
+  const end = new Date(samples[samples.length - 1].time).getTime()
+  const maxSize = Math.max(...samples.map((s) => s.size)) || 1
+  return samples
+    .map((s) => {
+      const x =
+        end > start
+          ? ((new Date(s.time).getTime() - start) / (end - start)) * CHART_WIDTH
+          : 0
+      const y = CHART_HEIGHT - (s.size / maxSize) * (CHART_HEIGHT - 10)
+      return `${Math.round(x)},${Math.round(y)}`
+    })
+    .join(" ")
+}
+
+// UsageDashboardModal - shows the results of the data usage crawler,
+// the usage of the buckets over time and the state of the disks.
+export class UsageDashboardModal extends React.Component {
+  constructor(props) {
+    super(props)
+    this.state = {
+      usage: null,
+      bucket: "",
+      samples: [],
+    }
+  }
+
+  componentDidMount() {
+    return web
+      .UsageInfo()
+      .then((usage) => {
+        this.setState({ usage: usage })
+        // Only users allowed to read the totals are sent them.
+        if (usage.objectsTotalSize || !usage.buckets) {
+          return this.selectBucket("")
+        }
+        return this.selectBucket(usage.buckets[0].name)
+      })
+      .catch((err) => this.props.showAlert(err.message))
+  }
+
+  selectBucket(bucket) {
+    return web
+      .UsageHistory({ bucketName: bucket })
+      .then((res) => this.setState({ bucket: bucket, samples: res.samples || [] }))
+      .catch(() => this.setState({ bucket: bucket, samples: [] }))
+  }
+
+  render() {
+    const { hideUsageDashboard } = this.props
+    const { usage, bucket, samples } = this.state
+    const buckets = (usage && usage.buckets) || []
+    const disks = (usage && usage.disks) || []
+    const maxSize = Math.max(0, ...buckets.map((b) => b.size)) || 1
+    return (
+      <Modal
+        className="modal-usage"
+        show={true}
+        animation={false}
+        onHide={hideUsageDashboard}
+        bsSize="large"
+      >
+        <ModalHeader>Usage</ModalHeader>
+        <ModalBody>
+          {usage && (
+            <div>
+              <div className="mu-summary">
+                Last updated {Moment(usage.lastUpdate).fromNow()}
+                {usage.objectsTotalSize > 0 &&
+                  `, ${humanize.filesize(usage.objectsTotalSize)} in ${usage.objectsCount} objects`}
+              </div>
+              <h5>Buckets</h5>
+              <ul className="mu-buckets">
+                {buckets.map((b) => (
+                  <li
+                    key={b.name}
+                    className={b.name === bucket ? "active" : ""}
+                    onClick={() => this.selectBucket(b.name)}
+                  >
+                    <span className="mub-name">{b.name}</span>
+                    <span className="mub-bar">
+                      <span style={{ width: `${(b.size / maxSize) * 100}%` }} />
+                    </span>
+                    <span className="mub-size">
+                      {humanize.filesize(b.size)} / {b.objectsCount} objects
+                    </span>
+                  </li>
+                ))}
+              </ul>
+              <h5>{bucket ? `${bucket} over time` : "All buckets over time"}</h5>
+              {samples.length > 0 ? (
+                <div className="mu-chart">
+                  <svg
+                    viewBox={`0 0 ${CHART_WIDTH} ${CHART_HEIGHT}`}
+                    preserveAspectRatio="none"
+                  >
+                    <polyline points={usagePoints(samples)} />
+                  </svg>
+                  <div className="muc-legend">
+                    <span>{Moment(samples[0].time).format("ll")}</span>
+                    <span>
+                      {humanize.filesize(samples[samples.length - 1].size)}
+                    </span>
+                    <span>
+                      {Moment(samples[samples.length - 1].time).format("ll")}
+                    </span>
+                  </div>
+                </div>
+              ) : (
+                <div className="mu-empty">No usage history yet.</div>
+              )}
+              {disks.length > 0 && (
+                <div>
+                  <h5>Disks</h5>
+                  <table className="mu-disks">
+                    <tbody>
+                      {disks.map((d, idx) => (
+                        <tr key={idx}>
+                          <td>{d.endpoint || d.path}</td>
+                          <td className={d.state === "ok" ? "mud-ok" : "mud-faulty"}>
+                            {d.state}
+                          </td>
+                          <td>
+                            {humanize.filesize(d.usedSpace)} /{" "}
+                            {humanize.filesize(d.totalSpace)}
+                          </td>
+                        </tr>
+                      ))}
+                    </tbody>
+                  </table>
+                </div>
+              )}
+            </div>
+          )}
+        </ModalBody>
+        <div className="modal-footer">
+          <button className="btn btn-link" onClick={hideUsageDashboard}>
+            Close
+          </button>
+        </div>
+      </Modal>
+    )
+  }
+}
+
+const mapDispatchToProps = (dispatch) => {
+  return {
+    showAlert: (message) =>
+      dispatch(alertActions.set({ type: "danger", message: message })),
+  }
+}
+
+export default connect(undefined, mapDispatchToProps)(UsageDashboardModal)
@@ -52,6 +52,16 @@ describe("BrowserDropdown", () => {
     expect(wrapper.find("AboutModal").length).toBe(1)
   })
 
+  it("should show UsageDashboardModal when Usage link is clicked", () => {
+    const wrapper = shallow(
+      <BrowserDropdown serverInfo={serverInfo} fetchServerInfo={jest.fn()} />
+    )
+    wrapper.find("#show-usage").simulate("click", { preventDefault: jest.fn() })
+    wrapper.update()
+    expect(wrapper.state("showUsageDashboard")).toBeTruthy()
+    expect(wrapper.find("Connect(UsageDashboardModal)").length).toBe(1)
+  })
+
   it("should logout and redirect to /login when logout is clicked", () => {
     const wrapper = shallow(
       <BrowserDropdown serverInfo={serverInfo} fetchServerInfo={jest.fn()} />
@@ -0,0 +1,79 @@
+/*
+ * MinIO Cloud Storage (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import React from "react"
+import { shallow } from "enzyme"
+import { UsageDashboardModal, usagePoints } from "../UsageDashboardModal"
+
+jest.mock("../../web", () => ({
+  UsageInfo: jest.fn(() => {
+    return Promise.resolve({
+      lastUpdate: "2020-09-01T00:00:00Z",
+      buckets: [
+        { name: "bk1", size: 100, objectsCount: 1 },
+        { name: "bk2", size: 50, objectsCount: 2 }
+      ],
+      disks: [{ endpoint: "/data", state: "ok", totalSpace: 10, usedSpace: 5 }]
+    })
+  }),
+  UsageHistory: jest.fn(({ bucketName }) => {
+    return Promise.resolve({
+      samples: [
+        { time: "2020-08-01T00:00:00Z", size: 50 },
+        { time: "2020-09-01T00:00:00Z", size: 100 }
+      ]
+    })
+  })
+}))
+
+describe("UsageDashboardModal", () => {
+  it("should render the buckets and the disks", () => {
+    const wrapper = shallow(<UsageDashboardModal />)
+    return wrapper
+      .instance()
+      .componentDidMount()
+      .then(() => {
+        wrapper.update()
+        expect(wrapper.find(".mu-buckets li").length).toBe(2)
+        expect(wrapper.find(".mu-disks tr").length).toBe(1)
+        // Users not sent the totals are shown their first bucket.
+        expect(wrapper.state("bucket")).toBe("bk1")
+        expect(wrapper.find("polyline").length).toBe(1)
+      })
+  })
+
+  it("should show the history of the bucket clicked", () => {
+    const web = require("../../web")
+    const wrapper = shallow(<UsageDashboardModal />)
+    return wrapper
+      .instance()
+      .componentDidMount()
+      .then(() => wrapper.instance().selectBucket("bk2"))
+      .then(() => {
+        expect(web.UsageHistory).toHaveBeenLastCalledWith({ bucketName: "bk2" })
+        expect(wrapper.state("bucket")).toBe("bk2")
+      })
+  })
+
+  it("should compute the points of the chart", () => {
+    expect(
+      usagePoints([
+        { time: "2020-08-01T00:00:00Z", size: 0 },
+        { time: "2020-08-02T00:00:00Z", size: 100 }
+      ])
+    ).toBe("0,150 600,10")
+  })
+})
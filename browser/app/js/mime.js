@@ -142,3 +142,22 @@ export const getDataType = (name, contentType) => {
   }
   return "other"
 }
+
+// Images previewed by the server as is, other objects are previewed
+// as text.
+const previewImageTypes = [
+  "image/png",
+  "image/jpeg",
+  "image/gif",
+  "image/webp",
+  "image/bmp",
+]
+
+// getPreviewType - returns how the object is previewed, "image" or
+// "text", or an empty string if it cannot be previewed.
+export const getPreviewType = (name, contentType) => {
+  if (previewImageTypes.includes(contentType)) return "image"
+  const dataType = getDataType(name, contentType || "")
+  if (dataType == "text" || dataType == "code") return "text"
+  return ""
+}
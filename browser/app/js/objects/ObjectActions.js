@@ -20,9 +20,10 @@ import { Dropdown } from "react-bootstrap"
 import ShareObjectModal from "./ShareObjectModal"
 import DeleteObjectConfirmModal from "./DeleteObjectConfirmModal"
 import PreviewObjectModal from "./PreviewObjectModal"
+import ObjectMetadataModal from "./ObjectMetadataModal"
 
 import * as objectsActions from "./actions"
-import { getDataType } from "../mime.js"
+import { getPreviewType } from "../mime.js"
 import {
   SHARE_OBJECT_EXPIRY_DAYS,
   SHARE_OBJECT_EXPIRY_HOURS,
@@ -35,6 +36,7 @@ export class ObjectActions extends React.Component {
     this.state = {
       showDeleteConfirmation: false,
       showPreview: false,
+      showMetadata: false,
     }
   }
   shareObject(e) {
@@ -78,6 +80,15 @@ export class ObjectActions extends React.Component {
       showPreview: false,
     })
   }
+  showMetadataModal(e) {
+    e.preventDefault()
+    this.setState({ showMetadata: true })
+  }
+  hideMetadataModal() {
+    this.setState({
+      showMetadata: false,
+    })
+  }
   render() {
     const { object, showShareObjectModal, shareObjectName } = this.props
     return (
@@ -92,7 +103,7 @@ export class ObjectActions extends React.Component {
           >
             <i className="fas fa-share-alt" />
           </a>
-          {getDataType(object.name, object.contentType) == "image" && (
+          {getPreviewType(object.name, object.contentType) && (
             <a
               href=""
               className="fiad-action"
@@ -110,6 +121,14 @@ export class ObjectActions extends React.Component {
           >
             <i className="fas fa-cloud-download-alt" />
           </a>
+          <a
+            href=""
+            className="fiad-action"
+            title="Metadata"
+            onClick={this.showMetadataModal.bind(this)}
+          >
+            <i className="fas fa-info" />
+          </a>
           <a
             href=""
             className="fiad-action"
@@ -135,6 +154,14 @@ export class ObjectActions extends React.Component {
             getObjectURL={this.getObjectURL.bind(this)}
           />
         )}
+        {this.state.showMetadata && (
+          <ObjectMetadataModal
+            object={object}
+            hideMetadataModal={this.hideMetadataModal.bind(this)}
+            getObjectMetadata={this.props.getObjectMetadata}
+            setObjectMetadata={this.props.setObjectMetadata}
+          />
+        )}
       </Dropdown>
     )
   }
@@ -156,6 +183,10 @@ const mapDispatchToProps = (dispatch) => {
     deleteObject: (object) => dispatch(objectsActions.deleteObject(object)),
     getObjectURL: (object, callback) =>
       dispatch(objectsActions.getObjectURL(object, callback)),
+    getObjectMetadata: (object) =>
+      dispatch(objectsActions.getObjectMetadata(object)),
+    setObjectMetadata: (object, metadata, tags) =>
+      dispatch(objectsActions.setObjectMetadata(object, metadata, tags)),
   }
 }
 
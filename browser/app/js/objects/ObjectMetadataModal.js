@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import React from "react"
+import humanize from "humanize"
+import Moment from "moment"
+import { Modal, ModalHeader, ModalBody } from "react-bootstrap"
+
+const toRows = (map) =>
+  Object.keys(map || {})
+    .sort()
+    .map((key) => ({ key: key, value: map[key] }))
+
+const toMap = (rows) => {
+  let map = {}
+  rows.forEach(({ key, value }) => {
+    if (key.trim()) map[key.trim()] = value
+  })
+  return map
+}
+
+// ObjectMetadataModal - shows the details of an object, and lets the
+// user edit its user metadata and its tags.
+class ObjectMetadataModal extends React.Component {
+  constructor(props) {
+    super(props)
+    this.state = {
+      info: null,
+      metadata: [],
+      tags: [],
+    }
+  }
+
+  componentDidMount() {
+    const { object, getObjectMetadata } = this.props
+    return getObjectMetadata(object.name).then((res) => {
+      if (!res) return
+      this.setState({
+        info: res,
+        metadata: toRows(res.metadata),
+        tags: toRows(res.tags),
+      })
+    })
+  }
+
+  updateRow(field, idx, row) {
+    const rows = [...this.state[field]]
+    rows[idx] = { ...rows[idx], ...row }
+    this.setState({ [field]: rows })
+  }
+
+  addRow(field) {
+    this.setState({ [field]: [...this.state[field], { key: "", value: "" }] })
+  }
+
+  removeRow(field, idx) {
+    const rows = [...this.state[field]]
+    rows.splice(idx, 1)
+    this.setState({ [field]: rows })
+  }
+
+  save() {
+    const { object, setObjectMetadata, hideMetadataModal } = this.props
+    return setObjectMetadata(
+      object.name,
+      toMap(this.state.metadata),
+      toMap(this.state.tags)
+    ).then((saved) => {
+      if (saved) hideMetadataModal()
+    })
+  }
+
+  renderRows(field, label) {
+    return (
+      <div className={`om-${field}`}>
+        <label>{label}</label>
+        {this.state[field].map((row, idx) => (
+          <div className="om-row" key={idx}>
+            <input
+              type="text"
+              placeholder="Key"
+              value={row.key}
+              onChange={(e) => this.updateRow(field, idx, { key: e.target.value })}
+            />
+            <input
+              type="text"
+              placeholder="Value"
+              value={row.value}
+              onChange={(e) => this.updateRow(field, idx, { value: e.target.value })}
+            />
+            <button
+              className="btn btn-link"
+              title="Remove"
+              onClick={() => this.removeRow(field, idx)}
+            >
+              <i className="fas fa-times" />
+            </button>
+          </div>
+        ))}
+        <button className="btn btn-link" onClick={() => this.addRow(field)}>
+          Add
+        </button>
+      </div>
+    )
+  }
+
+  render() {
+    const { object, hideMetadataModal } = this.props
+    const { info } = this.state
+    return (
+      <Modal
+        show={true}
+        animation={false}
+        onHide={hideMetadataModal}
+        bsSize="large"
+      >
+        <ModalHeader>{object.name}</ModalHeader>
+        <ModalBody>
+          {info && (
+            <div className="object-metadata">
+              <div className="om-info">
+                <div>Content type: {info.contentType}</div>
+                <div>Size: {humanize.filesize(info.size)}</div>
+                <div>Last modified: {Moment(info.lastModified).format("lll")}</div>
+                <div>ETag: {info.etag}</div>
+              </div>
+              {this.renderRows("metadata", "Metadata")}
+              {this.renderRows("tags", "Tags")}
+            </div>
+          )}
+        </ModalBody>
+        <div className="modal-footer">
+          <button
+            className="btn btn-success"
+            disabled={!info}
+            onClick={this.save.bind(this)}
+          >
+            Save
+          </button>
+          <button className="btn btn-link" onClick={hideMetadataModal}>
+            Cancel
+          </button>
+        </div>
+      </Modal>
+    )
+  }
+}
+export default ObjectMetadataModal
@@ -0,0 +1,71 @@
+/*
+ * MinIO Cloud Storage (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import React from "react"
+import { connect } from "react-redux"
+import * as actionsObjects from "./actions"
+import { getCurrentPrefix } from "./selectors"
+
+// ObjectsSearch - searches the objects under the current prefix, at
+// any depth, once the query is submitted.
+export class ObjectsSearch extends React.Component {
+  constructor(props) {
+    super(props)
+    this.state = {
+      query: ""
+    }
+  }
+  componentDidUpdate(prevProps) {
+    if (prevProps.currentPrefix !== this.props.currentPrefix) {
+      this.setState({ query: "" })
+    }
+  }
+  onSubmit(e) {
+    e.preventDefault()
+    this.props.searchObjects(this.state.query.trim())
+  }
+  render() {
+    return (
+      <form
+        className="input-group ig-left ig-search ig-search-objects"
+        onSubmit={this.onSubmit.bind(this)}
+      >
+        <input
+          className="ig-text"
+          type="text"
+          value={this.state.query}
+          onChange={e => this.setState({ query: e.target.value })}
+          placeholder="Search objects in this folder..."
+        />
+        <i className="ig-helpers" />
+      </form>
+    )
+  }
+}
+
+const mapStateToProps = state => {
+  return {
+    currentPrefix: getCurrentPrefix(state)
+  }
+}
+
+const mapDispatchToProps = dispatch => {
+  return {
+    searchObjects: query => dispatch(actionsObjects.searchObjects(query))
+  }
+}
+
+export default connect(mapStateToProps, mapDispatchToProps)(ObjectsSearch)
@@ -15,11 +15,13 @@
  */
 
 import React from "react"
+import ObjectsSearch from "./ObjectsSearch"
 import ObjectsHeader from "./ObjectsHeader"
 import ObjectsListContainer from "./ObjectsListContainer"
 
 export const ObjectsSection = () => (
   <div>
+    <ObjectsSearch />
     <ObjectsHeader />
     <ObjectsListContainer />
   </div>
@@ -16,25 +16,46 @@
 
 import React from "react"
 import { Modal, ModalHeader, ModalBody } from "react-bootstrap"
+import { getPreviewType } from "../mime"
 
 class PreviewObjectModal extends React.Component {
   constructor(props) {
     super(props)
     this.state = {
       url: "",
+      text: "",
+      error: false,
     }
   }
 
   componentDidMount() {
-    this.props.getObjectURL(this.props.object.name, (url) => {
-      this.setState({
-        url: url,
-      })
+    const { object } = this.props
+    this.props.getObjectURL(object.name, (url) => {
+      // Only the beginning of large text objects is previewed.
+      url = `${url}&preview=true`
+      if (getPreviewType(object.name, object.contentType) == "image") {
+        this.setState({
+          url: url,
+        })
+        return
+      }
+      const xhr = new XMLHttpRequest()
+      xhr.open("GET", url, true)
+      xhr.onload = () => {
+        if (xhr.status == 200) {
+          this.setState({ url: url, text: xhr.responseText })
+        } else {
+          this.setState({ error: true })
+        }
+      }
+      xhr.onerror = () => this.setState({ error: true })
+      xhr.send()
     })
   }
 
   render() {
-    const { hidePreviewModal } = this.props
+    const { hidePreviewModal, object } = this.props
+    const isImage = getPreviewType(object.name, object.contentType) == "image"
     return (
       <Modal
         show={true}
@@ -45,12 +66,20 @@ class PreviewObjectModal extends React.Component {
         <ModalHeader>Preview</ModalHeader>
         <ModalBody>
           <div className="input-group">
-            {this.state.url && (
-              <object data={this.state.url}>
-                <h3 style={{ textAlign: "center", display: "block", width: "100%" }}>
-                  Do not have read permissions to preview "{this.props.object.name}"
-                </h3>
-              </object>
+            {this.state.url && isImage && (
+              <img
+                className="preview-image"
+                src={this.state.url}
+                onError={() => this.setState({ url: "", error: true })}
+              />
+            )}
+            {this.state.url && !isImage && (
+              <pre className="preview-text">{this.state.text}</pre>
+            )}
+            {this.state.error && (
+              <h3 style={{ textAlign: "center", display: "block", width: "100%" }}>
+                Do not have read permissions to preview "{object.name}"
+              </h3>
             )}
           </div>
         </ModalBody>
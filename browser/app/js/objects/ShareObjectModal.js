@@ -24,7 +24,8 @@ import * as alertActions from "../alert/actions"
 import {
   SHARE_OBJECT_EXPIRY_DAYS,
   SHARE_OBJECT_EXPIRY_HOURS,
-  SHARE_OBJECT_EXPIRY_MINUTES
+  SHARE_OBJECT_EXPIRY_MINUTES,
+  SHARE_OBJECT_MAX_DOWNLOADS
 } from "../constants"
 
 export class ShareObjectModal extends React.Component {
@@ -35,13 +36,15 @@ export class ShareObjectModal extends React.Component {
         days: SHARE_OBJECT_EXPIRY_DAYS,
         hours: SHARE_OBJECT_EXPIRY_HOURS,
         minutes: SHARE_OBJECT_EXPIRY_MINUTES
-      }
+      },
+      maxDownloads: 0
     }
     this.expiryRange = {
       days: { min: 0, max: 7 },
       hours: { min: 0, max: 23 },
       minutes: { min: 0, max: 59 }
     }
+    this.maxDownloadsRange = { min: 0, max: SHARE_OBJECT_MAX_DOWNLOADS }
   }
   updateExpireValue(param, inc) {
     let expiry = Object.assign({}, this.state.expiry)
@@ -69,7 +72,36 @@ export class ShareObjectModal extends React.Component {
     })
 
     const { object, shareObject } = this.props
-    shareObject(object.name, expiry.days, expiry.hours, expiry.minutes)
+    shareObject(
+      object.name,
+      expiry.days,
+      expiry.hours,
+      expiry.minutes,
+      this.state.maxDownloads
+    )
+  }
+  // Links limited in downloads stop working once downloaded maxDownloads
+  // times, 0 does not limit the downloads.
+  updateMaxDownloads(inc) {
+    const { min, max } = this.maxDownloadsRange
+    const maxDownloads = this.state.maxDownloads + inc
+    if (maxDownloads < min || maxDownloads > max) {
+      return
+    }
+
+    this.setState({
+      maxDownloads
+    })
+
+    const { expiry } = this.state
+    const { object, shareObject } = this.props
+    shareObject(
+      object.name,
+      expiry.days,
+      expiry.hours,
+      expiry.minutes,
+      maxDownloads
+    )
   }
   onUrlCopied() {
     const { showCopyAlert, hideShareObject } = this.props
@@ -175,6 +207,32 @@ export class ShareObjectModal extends React.Component {
                 />
               </div>
             </div>
+            <label>Max downloads (0 for unlimited)</label>
+            <div className="set-expire">
+              <div className="set-expire-item">
+                <i
+                  id="increase-downloads"
+                  className="set-expire-increase"
+                  onClick={() => this.updateMaxDownloads(1)}
+                />
+                <div className="set-expire-title">Downloads</div>
+                <div className="set-expire-value">
+                  <input
+                    ref="maxDownloads"
+                    type="number"
+                    min={0}
+                    max={SHARE_OBJECT_MAX_DOWNLOADS}
+                    value={this.state.maxDownloads}
+                    readOnly="readOnly"
+                  />
+                </div>
+                <i
+                  id="decrease-downloads"
+                  className="set-expire-decrease"
+                  onClick={() => this.updateMaxDownloads(-1)}
+                />
+              </div>
+            </div>
           </div>
           )}
         </ModalBody>
@@ -203,8 +261,10 @@ const mapStateToProps = (state, ownProps) => {
 
 const mapDispatchToProps = dispatch => {
   return {
-    shareObject: (object, days, hours, minutes) =>
-      dispatch(objectsActions.shareObject(object, days, hours, minutes)),
+    shareObject: (object, days, hours, minutes, maxDownloads) =>
+      dispatch(
+        objectsActions.shareObject(object, days, hours, minutes, maxDownloads)
+      ),
     hideShareObject: () => dispatch(objectsActions.hideShareObject()),
     showCopyAlert: message =>
       dispatch(alertActions.set({ type: "success", message: message }))
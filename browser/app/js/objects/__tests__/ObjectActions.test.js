@@ -112,7 +112,7 @@ describe("ObjectActions", () => {
     expect(wrapper.state("showPreview")).toBeFalsy()
     expect(wrapper.find("PreviewObjectModal").length).toBe(0)
   })
-  it("should not show PreviewObjectModal when preview action is clicked if object is not an image", () => {
+  it("should not show the preview action if the object cannot be previewed", () => {
     const wrapper = shallow(
       <ObjectActions 
       object={{ name: "obj1"}} 
@@ -120,7 +120,31 @@ describe("ObjectActions", () => {
     )
     expect(wrapper
       .find("a")
-      .length).toBe(3) // find only the other 2
+      .length).toBe(4) // find only the other 4
+  })
+
+  it("should show the preview action for text objects", () => {
+    const wrapper = shallow(
+      <ObjectActions
+        object={{ name: "notes.txt", contentType: "text/plain" }}
+        currentPrefix={"pre1/"} />
+    )
+    expect(wrapper.find("a").at(1).prop("title")).toBe("Preview")
+  })
+
+  it("should show ObjectMetadataModal when metadata action is clicked", () => {
+    const wrapper = shallow(
+      <ObjectActions object={{ name: "obj1" }} currentPrefix={"pre1/"} />
+    )
+    wrapper
+      .find("a")
+      .at(2)
+      .simulate("click", { preventDefault: jest.fn() })
+    expect(wrapper.state("showMetadata")).toBeTruthy()
+    expect(wrapper.find("ObjectMetadataModal").length).toBe(1)
+    wrapper.find("ObjectMetadataModal").prop("hideMetadataModal")()
+    wrapper.update()
+    expect(wrapper.find("ObjectMetadataModal").length).toBe(0)
   })
 
   it("should call shareObject with object and expiry", () => {
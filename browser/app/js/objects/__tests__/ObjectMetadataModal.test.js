@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import React from "react"
+import { shallow } from "enzyme"
+import ObjectMetadataModal from "../ObjectMetadataModal"
+
+describe("ObjectMetadataModal", () => {
+  const metadata = {
+    contentType: "image/png",
+    size: 10,
+    etag: "abc",
+    metadata: { Color: "blue" },
+    tags: { album: "2020" }
+  }
+
+  it("should render the metadata and the tags of the object", () => {
+    const getObjectMetadata = jest.fn(() => Promise.resolve(metadata))
+    const wrapper = shallow(
+      <ObjectMetadataModal
+        object={{ name: "a.png" }}
+        getObjectMetadata={getObjectMetadata}
+      />
+    )
+    expect(getObjectMetadata).toHaveBeenCalledWith("a.png")
+    return wrapper.instance().componentDidMount().then(() => {
+      expect(wrapper.state("metadata")).toEqual([{ key: "Color", value: "blue" }])
+      expect(wrapper.state("tags")).toEqual([{ key: "album", value: "2020" }])
+    })
+  })
+
+  it("should save the edited metadata and hide the modal", () => {
+    const getObjectMetadata = jest.fn(() => Promise.resolve(metadata))
+    const setObjectMetadata = jest.fn(() => Promise.resolve(true))
+    const hideMetadataModal = jest.fn()
+    const wrapper = shallow(
+      <ObjectMetadataModal
+        object={{ name: "a.png" }}
+        getObjectMetadata={getObjectMetadata}
+        setObjectMetadata={setObjectMetadata}
+        hideMetadataModal={hideMetadataModal}
+      />
+    )
+    return wrapper
+      .instance()
+      .componentDidMount()
+      .then(() => {
+        wrapper.instance().updateRow("metadata", 0, { value: "red" })
+        wrapper.instance().addRow("tags")
+        wrapper.instance().updateRow("tags", 1, { key: "k", value: "v" })
+        wrapper.instance().removeRow("tags", 0)
+        return wrapper.instance().save()
+      })
+      .then(() => {
+        expect(setObjectMetadata).toHaveBeenCalledWith(
+          "a.png",
+          { Color: "red" },
+          { k: "v" }
+        )
+        expect(hideMetadataModal).toHaveBeenCalled()
+      })
+  })
+})
@@ -0,0 +1,41 @@
+/*
+ * MinIO Cloud Storage (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+import React from "react"
+import { shallow } from "enzyme"
+import { ObjectsSearch } from "../ObjectsSearch"
+
+describe("ObjectsSearch", () => {
+  it("should render without crashing", () => {
+    shallow(<ObjectsSearch />)
+  })
+
+  it("should call searchObjects with the query once submitted", () => {
+    const searchObjects = jest.fn()
+    const wrapper = shallow(<ObjectsSearch searchObjects={searchObjects} />)
+    wrapper.find("input").simulate("change", { target: { value: " photo " } })
+    expect(searchObjects).not.toHaveBeenCalled()
+    wrapper.find("form").simulate("submit", { preventDefault: jest.fn() })
+    expect(searchObjects).toHaveBeenCalledWith("photo")
+  })
+
+  it("should clear the query when the prefix changes", () => {
+    const wrapper = shallow(<ObjectsSearch currentPrefix="a/" />)
+    wrapper.find("input").simulate("change", { target: { value: "photo" } })
+    wrapper.setProps({ currentPrefix: "b/" })
+    expect(wrapper.state("query")).toBe("")
+  })
+})
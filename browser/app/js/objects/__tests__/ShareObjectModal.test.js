@@ -205,7 +205,26 @@ describe("ShareObjectModal", () => {
         hours: 0,
         minutes: 0
       })
-      expect(shareObject).toHaveBeenCalledWith("obj1", 7, 0, 0)
+      expect(shareObject).toHaveBeenCalledWith("obj1", 7, 0, 0, 0)
+    })
+
+    it("should share the object again when max downloads changes", () => {
+      const shareObject = jest.fn()
+      const wrapper = shallow(
+        <ShareObjectModal {...props} shareObject={shareObject} />
+      )
+      wrapper.find("#decrease-downloads").simulate("click")
+      expect(wrapper.state("maxDownloads")).toBe(0)
+      expect(shareObject).not.toHaveBeenCalled()
+      wrapper.find("#increase-downloads").simulate("click")
+      expect(wrapper.state("maxDownloads")).toBe(1)
+      expect(shareObject).toHaveBeenCalledWith(
+        "obj1",
+        SHARE_OBJECT_EXPIRY_DAYS,
+        SHARE_OBJECT_EXPIRY_HOURS,
+        SHARE_OBJECT_EXPIRY_MINUTES,
+        1
+      )
     })
   })
 })
@@ -74,6 +74,27 @@ jest.mock("../../web", () => ({
     .mockImplementationOnce(() => {
       return Promise.resolve({ token: "test" })
     }),
+  SearchObjects: jest.fn(({ bucketName }) => {
+    if (!bucketName) {
+      return Promise.reject({ message: "Invalid bucket" })
+    }
+    return Promise.resolve({
+      objects: [{ name: "pre1/b/photo.png" }, { name: "pre1/a-photo.jpg" }],
+      isTruncated: false
+    })
+  }),
+  GetObjectMetadata: jest.fn(({ bucketName }) => {
+    if (!bucketName) {
+      return Promise.reject({ message: "Invalid bucket" })
+    }
+    return Promise.resolve({ metadata: { Color: "blue" }, tags: {} })
+  }),
+  SetObjectMetadata: jest.fn(({ bucketName }) => {
+    if (!bucketName) {
+      return Promise.reject({ message: "Invalid bucket" })
+    }
+    return Promise.resolve({})
+  }),
   GetBucketPolicy: jest.fn(({ bucketName, prefix }) => {
     if (!bucketName) {
       return Promise.reject({ message: "Invalid bucket" })
@@ -581,4 +602,92 @@ describe("Objects actions", () => {
       )
     })
   })
+
+  it("creates objects/SET_SHARE_OBJECT when object is shared with limited downloads", () => {
+    const store = mockStore({
+      buckets: { currentBucket: "bk1" },
+      objects: { currentPrefix: "pre1/" },
+      browser: { serverInfo: {} },
+    })
+    const web = require("../../web")
+    return store
+      .dispatch(actionsObjects.shareObject("a.txt", 1, 0, 0, 3))
+      .then(() => {
+        expect(web.PresignedGet).toHaveBeenLastCalledWith({
+          host: location.host,
+          bucket: "bk1",
+          object: "pre1/a.txt",
+          expiry: 24 * 60 * 60,
+          maxDownloads: 3
+        })
+        const actions = store.getActions()
+        expect(actions[1].alert.message).toBe(
+          "Object shared. Expires in 1 days 0 hours 0 minutes or after 3 downloads"
+        )
+      })
+  })
+
+  it("creates objects/SET_LIST with the objects found by a search", () => {
+    const store = mockStore({
+      buckets: { currentBucket: "bk1" },
+      objects: { currentPrefix: "pre1/" }
+    })
+    const web = require("../../web")
+    return store.dispatch(actionsObjects.searchObjects("photo")).then(() => {
+      expect(web.SearchObjects).toHaveBeenLastCalledWith({
+        bucketName: "bk1",
+        prefix: "pre1/",
+        query: "photo"
+      })
+      const actions = store.getActions()
+      expect(actions).toContainEqual({
+        type: "objects/SET_LIST",
+        objects: [{ name: "a-photo.jpg" }, { name: "b/photo.png" }]
+      })
+      expect(actions).toContainEqual({
+        type: "objects/SET_SEARCH_QUERY",
+        searchQuery: "photo"
+      })
+    })
+  })
+
+  it("resolves to the metadata of the object", () => {
+    const store = mockStore({
+      buckets: { currentBucket: "bk1" },
+      objects: { currentPrefix: "pre1/" }
+    })
+    const web = require("../../web")
+    return store
+      .dispatch(actionsObjects.getObjectMetadata("a.txt"))
+      .then(res => {
+        expect(web.GetObjectMetadata).toHaveBeenLastCalledWith({
+          bucketName: "bk1",
+          objectName: "pre1/a.txt"
+        })
+        expect(res.metadata).toEqual({ Color: "blue" })
+      })
+  })
+
+  it("saves the metadata and the tags of the object", () => {
+    const store = mockStore({
+      buckets: { currentBucket: "bk1" },
+      objects: { currentPrefix: "pre1/" }
+    })
+    const web = require("../../web")
+    return store
+      .dispatch(
+        actionsObjects.setObjectMetadata("a.txt", { Color: "red" }, { k: "v" })
+      )
+      .then(saved => {
+        expect(saved).toBe(true)
+        expect(web.SetObjectMetadata).toHaveBeenLastCalledWith({
+          bucketName: "bk1",
+          objectName: "pre1/a.txt",
+          metadata: { Color: "red" },
+          tags: { k: "v" }
+        })
+        const actions = store.getActions()
+        expect(actions[0].alert.message).toBe("Object metadata saved.")
+      })
+  })
 })
@@ -27,6 +27,7 @@ describe("objects reducer", () => {
       sortBy: "",
       sortOrder: SORT_ORDER_ASC,
       currentPrefix: "",
+      searchQuery: "",
       prefixWritable: false,
       shareObject: {
         show: false,
@@ -37,6 +38,23 @@ describe("objects reducer", () => {
     })
   })
 
+  it("should handle SET_SEARCH_QUERY", () => {
+    const newState = reducer(undefined, {
+      type: actions.SET_SEARCH_QUERY,
+      searchQuery: "photo"
+    })
+    expect(newState.searchQuery).toEqual("photo")
+  })
+
+  it("should clear the search query on RESET_LIST", () => {
+    const newState = reducer(
+      { list: [{ name: "obj1" }], searchQuery: "photo" },
+      { type: actions.RESET_LIST }
+    )
+    expect(newState.list).toEqual([])
+    expect(newState.searchQuery).toEqual("")
+  })
+
   it("should handle SET_LIST", () => {
     const newState = reducer(undefined, {
       type: actions.SET_LIST,
@@ -47,6 +47,7 @@ export const CHECKED_LIST_ADD = "objects/CHECKED_LIST_ADD"
 export const CHECKED_LIST_REMOVE = "objects/CHECKED_LIST_REMOVE"
 export const CHECKED_LIST_RESET = "objects/CHECKED_LIST_RESET"
 export const SET_LIST_LOADING = "objects/SET_LIST_LOADING"
+export const SET_SEARCH_QUERY = "objects/SET_SEARCH_QUERY"
 
 export const setList = (objects) => ({
   type: SET_LIST,
@@ -123,6 +124,71 @@ export const fetchObjects = () => {
   }
 }
 
+export const setSearchQuery = (searchQuery) => ({
+  type: SET_SEARCH_QUERY,
+  searchQuery,
+})
+
+// searchObjects - lists the objects under the current prefix, at any
+// depth, whose name contains query. The objects of the current prefix
+// are listed again when query is empty.
+export const searchObjects = (query) => {
+  return function (dispatch, getState) {
+    if (!query) {
+      return dispatch(fetchObjects())
+    }
+    dispatch(resetList())
+    const currentBucket = getCurrentBucket(getState())
+    const currentPrefix = getCurrentPrefix(getState())
+    dispatch(setListLoading(true))
+    return web
+      .SearchObjects({
+        bucketName: currentBucket,
+        prefix: currentPrefix,
+        query: query,
+      })
+      .then((res) => {
+        if (
+          currentBucket !== getCurrentBucket(getState()) ||
+          currentPrefix !== getCurrentPrefix(getState())
+        ) {
+          return
+        }
+        let objects = []
+        if (res.objects) {
+          objects = res.objects.map((object) => {
+            return {
+              ...object,
+              name: object.name.replace(currentPrefix, ""),
+            }
+          })
+        }
+        dispatch(setSortBy(SORT_BY_NAME))
+        dispatch(setSortOrder(SORT_ORDER_ASC))
+        dispatch(setList(sortObjectsList(objects, SORT_BY_NAME, SORT_ORDER_ASC)))
+        dispatch(setSearchQuery(query))
+        dispatch(setListLoading(false))
+        if (res.isTruncated) {
+          dispatch(
+            alertActions.set({
+              type: "info",
+              message: "Only the first matching objects are shown, refine the search to find more.",
+            })
+          )
+        }
+      })
+      .catch((err) => {
+        dispatch(
+          alertActions.set({
+            type: "danger",
+            message: err.message,
+          })
+        )
+        dispatch(setListLoading(false))
+      })
+  }
+}
+
 export const sortObjects = (sortBy) => {
   return function (dispatch, getState) {
     const { objects } = getState()
@@ -220,7 +286,7 @@ export const deleteCheckedObjects = () => {
   }
 }
 
-export const shareObject = (object, days, hours, minutes) => {
+export const shareObject = (object, days, hours, minutes, maxDownloads = 0) => {
   return function (dispatch, getState) {
     const hasServerDomain = hasServerPublicDomain(getState())
     const currentBucket = getCurrentBucket(getState())
@@ -232,7 +298,12 @@ export const shareObject = (object, days, hours, minutes) => {
         .GetBucketPolicy({ bucketName: currentBucket, prefix: currentPrefix })
         .catch(() => ({ policy: null }))
         .then(({ policy }) => {
-          if (hasServerDomain && ['readonly', 'readwrite'].includes(policy)) {
+          // Downloads of public links cannot be limited.
+          if (
+            hasServerDomain &&
+            ['readonly', 'readwrite'].includes(policy) &&
+            !maxDownloads
+          ) {
             const domain = getServerInfo(getState()).info.domains[0]
             const url = `${domain}/${currentBucket}/${encodeURI(objectName)}`
             dispatch(showShareObject(object, url, false))
@@ -248,17 +319,22 @@ export const shareObject = (object, days, hours, minutes) => {
                 host: location.host,
                 bucket: currentBucket,
                 object: objectName,
-                expiry: expiry
+                expiry: expiry,
+                maxDownloads: maxDownloads
               })
           }
         })
         .then((obj) => {
           if (!obj) return
           dispatch(showShareObject(object, obj.url))
+          let message = `Object shared. Expires in ${days} days ${hours} hours ${minutes} minutes`
+          if (maxDownloads > 0) {
+            message += ` or after ${maxDownloads} downloads`
+          }
           dispatch(
             alertActions.set({
               type: "success",
-              message: `Object shared. Expires in ${days} days ${hours} hours ${minutes} minutes`,
+              message: message,
             })
           )
         })
@@ -305,6 +381,63 @@ export const hideShareObject = (object, url) => ({
   object: "",
   url: "",
 })
+// getObjectMetadata - resolves to the metadata and the tags of the
+// object, or to null if they cannot be read.
+export const getObjectMetadata = (object) => {
+  return function (dispatch, getState) {
+    const currentBucket = getCurrentBucket(getState())
+    const currentPrefix = getCurrentPrefix(getState())
+    return web
+      .GetObjectMetadata({
+        bucketName: currentBucket,
+        objectName: `${currentPrefix}${object}`,
+      })
+      .catch((err) => {
+        dispatch(
+          alertActions.set({
+            type: "danger",
+            message: err.message,
+          })
+        )
+        return null
+      })
+  }
+}
+
+// setObjectMetadata - replaces the user metadata and the tags of the
+// object, resolves to true once saved.
+export const setObjectMetadata = (object, metadata, tags) => {
+  return function (dispatch, getState) {
+    const currentBucket = getCurrentBucket(getState())
+    const currentPrefix = getCurrentPrefix(getState())
+    return web
+      .SetObjectMetadata({
+        bucketName: currentBucket,
+        objectName: `${currentPrefix}${object}`,
+        metadata: metadata,
+        tags: tags,
+      })
+      .then(() => {
+        dispatch(
+          alertActions.set({
+            type: "success",
+            message: "Object metadata saved.",
+          })
+        )
+        return true
+      })
+      .catch((err) => {
+        dispatch(
+          alertActions.set({
+            type: "danger",
+            message: err.message,
+          })
+        )
+        return false
+      })
+  }
+}
+
 export const getObjectURL = (object, callback) => {
   return function (dispatch, getState) {
     const currentBucket = getCurrentBucket(getState())
@@ -32,6 +32,7 @@ export default (
     sortBy: "",
     sortOrder: SORT_ORDER_ASC,
     currentPrefix: "",
+    searchQuery: "",
     prefixWritable: false,
     shareObject: {
       show: false,
@@ -51,7 +52,13 @@ export default (
     case actionsObjects.RESET_LIST:
       return {
         ...state,
-        list: []
+        list: [],
+        searchQuery: ""
+      }
+    case actionsObjects.SET_SEARCH_QUERY:
+      return {
+        ...state,
+        searchQuery: action.searchQuery
       }
     case actionsObjects.SET_LIST_LOADING:
       return {
@@ -47,6 +47,25 @@ export class UploadModal extends React.Component {
 
     let percent = totalLoaded / totalSize * 100
 
+    // Parts of multipart uploads being uploaded.
+    let parts = []
+    for (var slug in uploads) {
+      let upload = uploads[slug]
+      for (var partNumber in upload.parts || {}) {
+        let part = upload.parts[partNumber]
+        if (part.loaded < part.size) {
+          parts.push({
+            key: `${slug}-${partNumber}`,
+            text:
+              numberUploading == 1
+                ? `Part ${partNumber}`
+                : `'${upload.name}' part ${partNumber}`,
+            percent: part.loaded / part.size * 100
+          })
+        }
+      }
+    }
+
     // If more than one: "Uploading files (5)..."
     // If only one: "Uploading myfile.txt..."
     let text =
@@ -70,6 +89,12 @@ export class UploadModal extends React.Component {
             {humanize.filesize(totalLoaded)} ({percent.toFixed(2)} %)
           </small>
         </div>
+        {parts.map(part => (
+          <div key={part.key} className="upload-part">
+            <small>{part.text}</small>
+            <ProgressBar now={part.percent} />
+          </div>
+        ))}
       </div>
     )
   }
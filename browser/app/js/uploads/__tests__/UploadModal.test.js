@@ -42,6 +42,25 @@ describe("UploadModal", () => {
     expect(wrapper.find("ProgressBar").length).toBe(1)
   })
 
+  it("should show the progress of the parts being uploaded", () => {
+    const wrapper = shallow(
+      <UploadModal
+        uploads={{
+          "a-b/-test": {
+            size: 100,
+            loaded: 70,
+            name: "test",
+            parts: {
+              1: { size: 50, loaded: 50 },
+              2: { size: 50, loaded: 20 }
+            }
+          }
+        }}
+      />
+    )
+    expect(wrapper.find("ProgressBar").length).toBe(2)
+  })
+
   it("should call showAbortModal when close button is clicked", () => {
     const showAbortModal = jest.fn()
     const wrapper = shallow(
@@ -18,6 +18,11 @@ import configureStore from "redux-mock-store"
 import thunk from "redux-thunk"
 import * as uploadsActions from "../actions"
 
+jest.mock("../../web", () => ({
+  NewMultipartUpload: jest.fn(() => Promise.resolve({ uploadId: "upload1" })),
+  ListObjectParts: jest.fn(() => Promise.resolve({ parts: [] }))
+}))
+
 const middlewares = [thunk]
 const mockStore = configureStore(middlewares)
 
@@ -145,6 +150,43 @@ describe("Uploads actions", () => {
       )
       expect(send).toHaveBeenCalledWith(file)
     })
+
+    it("should start a multipart upload for large files", () => {
+      const web = require("../../web")
+      const largeFile = {
+        name: "large",
+        size: uploadsActions.multipartThreshold + 1,
+        type: "video/mp4",
+        lastModified: 1,
+        slice: jest.fn(() => new Blob(["part"]))
+      }
+      const store = mockStore({
+        buckets: { currentBucket: "test1" },
+        objects: { currentPrefix: "pre1/" }
+      })
+      store.dispatch(uploadsActions.uploadFile(largeFile))
+      expect(store.getActions()).toEqual([
+        {
+          type: "uploads/ADD",
+          slug: "test1-pre1/-large",
+          size: largeFile.size,
+          name: largeFile.name
+        }
+      ])
+      return Promise.resolve().then(() => {
+        expect(web.NewMultipartUpload).toHaveBeenCalledWith({
+          bucketName: "test1",
+          objectName: "pre1/large",
+          contentType: "video/mp4"
+        })
+      })
+    })
+
+    it("should use bigger parts for files needing too many parts", () => {
+      const size = 10000 * 16 * 1024 * 1024 + 1
+      expect(uploadsActions.partSize(100)).toBe(16 * 1024 * 1024)
+      expect(Math.ceil(size / uploadsActions.partSize(size))).toBe(10000)
+    })
   })
 
   it("creates uploads/STOP and uploads/SHOW_ABORT_MODAL after abortUpload", () => {
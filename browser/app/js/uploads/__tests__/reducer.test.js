@@ -54,6 +54,36 @@ describe("uploads reducer", () => {
     })
   })
 
+  it("should handle UPDATE_PART_PROGRESS", () => {
+    const newState = reducer(
+      {
+        files: {
+          "a-b-c": {
+            loaded: 10,
+            size: 100,
+            name: "test",
+            parts: { 1: { size: 50, loaded: 10 } }
+          }
+        }
+      },
+      {
+        type: actions.UPDATE_PART_PROGRESS,
+        slug: "a-b-c",
+        partNumber: 2,
+        size: 50,
+        loaded: 20
+      }
+    )
+    expect(newState.files).toEqual({
+      "a-b-c": {
+        loaded: 30,
+        size: 100,
+        name: "test",
+        parts: { 1: { size: 50, loaded: 10 }, 2: { size: 50, loaded: 20 } }
+      }
+    })
+  })
+
   it("should handle STOP", () => {
     const newState = reducer(
       {
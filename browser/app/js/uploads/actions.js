@@ -21,11 +21,22 @@ import * as objectsActions from "../objects/actions"
 import { getCurrentBucket } from "../buckets/selectors"
 import { getCurrentPrefix } from "../objects/selectors"
 import { minioBrowserPrefix } from "../constants"
+import web from "../web"
 
 export const ADD = "uploads/ADD"
 export const UPDATE_PROGRESS = "uploads/UPDATE_PROGRESS"
 export const STOP = "uploads/STOP"
 export const SHOW_ABORT_MODAL = "uploads/SHOW_ABORT_MODAL"
+export const UPDATE_PART_PROGRESS = "uploads/UPDATE_PART_PROGRESS"
+
+// Files bigger than the threshold are uploaded in parts, a few parts
+// at once, and the upload resumes when the same file is dropped again
+// after a reload of the page.
+export const multipartThreshold = 64 * 1024 * 1024
+const minPartSize = 16 * 1024 * 1024
+const maxParts = 10000
+const parallelParts = 4
+const partAttempts = 3
 
 export const add = (slug, size, name) => ({
   type: ADD,
@@ -40,6 +51,14 @@ export const updateProgress = (slug, loaded) => ({
   loaded
 })
 
+export const updatePartProgress = (slug, partNumber, size, loaded) => ({
+  type: UPDATE_PART_PROGRESS,
+  slug,
+  partNumber,
+  size,
+  loaded
+})
+
 export const stop = slug => ({
   type: STOP,
   slug
@@ -75,6 +94,31 @@ export const abortUpload = slug => {
   }
 }
 
+// Returns the size of the parts of a file, parts are bigger for
+// files which would otherwise need more than maxParts parts.
+export const partSize = size =>
+  Math.max(minPartSize, Math.ceil(size / maxParts))
+
+const newUploadRequest = url => {
+  let xhr = new XMLHttpRequest()
+  xhr.open("PUT", url, true)
+  xhr.withCredentials = false
+  const token = storage.getItem("token")
+  if (token) {
+    xhr.setRequestHeader(
+      "Authorization",
+      "Bearer " + storage.getItem("token")
+    )
+  }
+  xhr.setRequestHeader(
+    "x-amz-date",
+    Moment()
+      .utc()
+      .format("YYYYMMDDTHHmmss") + "Z"
+  )
+  return xhr
+}
+
 export const uploadFile = file => {
   return function(dispatch, getState) {
     const state = getState()
@@ -99,23 +143,28 @@ export const uploadFile = file => {
       window.location.origin
     }${minioBrowserPrefix}/upload/${currentBucket}/${objectName}`
     const slug = `${currentBucket}-${currentPrefix}-${filePath}`
+    const upload = {
+      bucketName: currentBucket,
+      objectName,
+      currentPrefix,
+      filePath,
+      uploadUrl,
+      slug
+    }
 
-    let xhr = new XMLHttpRequest()
-    xhr.open("PUT", uploadUrl, true)
-    xhr.withCredentials = false
-    const token = storage.getItem("token")
-    if (token) {
-      xhr.setRequestHeader(
-        "Authorization",
-        "Bearer " + storage.getItem("token")
-      )
+    if (file.size > multipartThreshold) {
+      dispatch(uploadMultipart(file, upload))
+    } else {
+      dispatch(uploadSingle(file, upload))
     }
-    xhr.setRequestHeader(
-      "x-amz-date",
-      Moment()
-        .utc()
-        .format("YYYYMMDDTHHmmss") + "Z"
-    )
+  }
+}
+
+const uploadSingle = (file, upload) => {
+  return function(dispatch) {
+    const { currentPrefix, filePath, uploadUrl, slug } = upload
+
+    let xhr = newUploadRequest(uploadUrl)
 
     dispatch(addUpload(xhr, slug, file.size, file.name))
 
@@ -175,3 +224,222 @@ export const uploadFile = file => {
     xhr.send(file)
   }
 }
+
+// The upload ID of a multipart upload is kept in the local storage
+// until the upload completes, along with what identifies the file.
+const multipartKey = slug => `multipart/${slug}`
+
+// Returns the multipart upload of the file started before a reload
+// of the page, with the parts already uploaded. Parts whose size
+// does not match the file are uploaded again.
+const resumeMultipart = (file, upload) => {
+  const { bucketName, objectName, slug } = upload
+  let saved = null
+  try {
+    saved = JSON.parse(storage.getItem(multipartKey(slug)))
+  } catch (e) {}
+  if (
+    !saved ||
+    saved.size !== file.size ||
+    saved.lastModified !== file.lastModified
+  ) {
+    return Promise.resolve(null)
+  }
+  const size = partSize(file.size)
+  return web
+    .ListObjectParts({ bucketName, objectName, uploadId: saved.uploadId })
+    .then(res => {
+      const parts = {}
+      res.parts.forEach(part => {
+        const start = (part.partNumber - 1) * size
+        if (part.size === Math.min(size, file.size - start)) {
+          parts[part.partNumber] = part.etag
+        }
+      })
+      return { uploadId: saved.uploadId, parts }
+    })
+    .catch(() => {
+      storage.removeItem(multipartKey(slug))
+      return null
+    })
+}
+
+const uploadMultipart = (file, upload) => {
+  return function(dispatch) {
+    const { bucketName, objectName, currentPrefix, filePath, slug } = upload
+    const size = partSize(file.size)
+    const count = Math.ceil(file.size / size)
+    const inflight = {}
+    let uploadId = ""
+    let aborted = false
+
+    // Aborting removes the parts uploaded, the upload cannot be resumed.
+    const controller = {
+      abort: () => {
+        aborted = true
+        Object.keys(inflight).forEach(partNumber => inflight[partNumber].abort())
+        storage.removeItem(multipartKey(slug))
+        if (uploadId) {
+          web
+            .AbortMultipartUpload({ bucketName, objectName, uploadId })
+            .catch(() => {})
+        }
+      }
+    }
+    dispatch(addUpload(controller, slug, file.size, file.name))
+
+    const uploadPart = (partNumber, etags, attempt) =>
+      new Promise((resolve, reject) => {
+        const start = (partNumber - 1) * size
+        const blob = file.slice(start, Math.min(start + size, file.size))
+        const xhr = newUploadRequest(
+          `${upload.uploadUrl}?uploadId=${encodeURIComponent(
+            uploadId
+          )}&partNumber=${partNumber}`
+        )
+        inflight[partNumber] = xhr
+        const retry = message => {
+          if (!aborted && attempt < partAttempts) {
+            resolve(uploadPart(partNumber, etags, attempt + 1))
+          } else {
+            reject(new Error(message))
+          }
+        }
+        xhr.onload = () => {
+          delete inflight[partNumber]
+          if (xhr.status == 200) {
+            etags[partNumber] = xhr.getResponseHeader("ETag")
+            dispatch(updatePartProgress(slug, partNumber, blob.size, blob.size))
+            resolve()
+          } else if (xhr.status == 401 || xhr.status == 403) {
+            reject(new Error("Unauthorized request."))
+          } else {
+            retry(xhr.responseText)
+          }
+        }
+        xhr.onerror = () => {
+          delete inflight[partNumber]
+          retry("Error occurred uploading '" + filePath + "'.")
+        }
+        xhr.upload.addEventListener("progress", event => {
+          if (event.lengthComputable) {
+            dispatch(
+              updatePartProgress(slug, partNumber, blob.size, event.loaded)
+            )
+          }
+        })
+        xhr.send(blob)
+      })
+
+    const uploadParts = etags => {
+      const pending = []
+      for (let partNumber = 1; partNumber <= count; partNumber++) {
+        if (etags[partNumber]) {
+          const start = (partNumber - 1) * size
+          const uploaded = Math.min(size, file.size - start)
+          dispatch(updatePartProgress(slug, partNumber, uploaded, uploaded))
+        } else {
+          pending.push(partNumber)
+        }
+      }
+      const next = () => {
+        const partNumber = pending.shift()
+        if (aborted || partNumber === undefined) {
+          return Promise.resolve()
+        }
+        return uploadPart(partNumber, etags, 1).then(next)
+      }
+      const workers = []
+      for (let i = 0; i < parallelParts; i++) {
+        workers.push(next())
+      }
+      return Promise.all(workers)
+    }
+
+    const fail = message => {
+      Object.keys(inflight).forEach(partNumber => inflight[partNumber].abort())
+      dispatch(hideAbortModal())
+      dispatch(stop(slug))
+      dispatch(alertActions.set({ type: "danger", message }))
+    }
+
+    resumeMultipart(file, upload)
+      .then(resumed => {
+        if (resumed) {
+          return resumed
+        }
+        return web
+          .NewMultipartUpload({
+            bucketName,
+            objectName,
+            contentType: file.type
+          })
+          .then(res => {
+            storage.setItem(
+              multipartKey(slug),
+              JSON.stringify({
+                uploadId: res.uploadId,
+                size: file.size,
+                lastModified: file.lastModified
+              })
+            )
+            return { uploadId: res.uploadId, parts: {} }
+          })
+      })
+      .then(
+        ({ uploadId: id, parts: etags }) => {
+          uploadId = id
+          if (aborted) {
+            controller.abort()
+            return
+          }
+          return uploadParts(etags)
+            .then(() => {
+              if (aborted) {
+                return
+              }
+              const parts = []
+              for (let partNumber = 1; partNumber <= count; partNumber++) {
+                parts.push({ partNumber, etag: etags[partNumber] })
+              }
+              return web
+                .CompleteMultipartUpload({
+                  bucketName,
+                  objectName,
+                  uploadId,
+                  parts
+                })
+                .then(() => {
+                  storage.removeItem(multipartKey(slug))
+                  dispatch(hideAbortModal())
+                  dispatch(stop(slug))
+                  dispatch(
+                    alertActions.set({
+                      type: "success",
+                      message: "File '" + filePath + "' uploaded successfully."
+                    })
+                  )
+                  dispatch(objectsActions.selectPrefix(currentPrefix))
+                })
+            })
+            .catch(err => {
+              if (!aborted) {
+                fail(
+                  err.message +
+                    " Upload '" +
+                    filePath +
+                    "' again to resume it."
+                )
+              }
+            })
+        },
+        // Encrypted objects, for instance, are uploaded at once.
+        () => {
+          if (!aborted) {
+            dispatch(stop(slug))
+            dispatch(uploadSingle(file, upload))
+          }
+        }
+      )
+  }
+}
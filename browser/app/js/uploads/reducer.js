@@ -33,6 +33,31 @@ const updateProgress = (files, action) => ({
   }
 })
 
+// Parts of multipart uploads report their progress separately, the
+// upload has loaded what all of its parts loaded.
+const updatePartProgress = (files, action) => {
+  const file = files[action.slug]
+  if (!file) {
+    return files
+  }
+  const parts = {
+    ...file.parts,
+    [action.partNumber]: { size: action.size, loaded: action.loaded }
+  }
+  const loaded = Object.keys(parts).reduce(
+    (total, partNumber) => total + parts[partNumber].loaded,
+    0
+  )
+  return {
+    ...files,
+    [action.slug]: {
+      ...file,
+      loaded,
+      parts
+    }
+  }
+}
+
 const stop = (files, action) => {
   const newFiles = Object.assign({}, files)
   delete newFiles[action.slug]
@@ -51,6 +76,11 @@ export default (state = { files: {}, showAbortModal: false }, action) => {
         ...state,
         files: updateProgress(state.files, action)
       }
+    case uploadsActions.UPDATE_PART_PROGRESS:
+      return {
+        ...state,
+        files: updatePartProgress(state.files, action)
+      }
     case uploadsActions.STOP:
       return {
         ...state,
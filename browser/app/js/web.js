@@ -91,6 +91,12 @@ class Web {
   StorageInfo() {
     return this.makeCall('StorageInfo')
   }
+  UsageInfo() {
+    return this.makeCall('UsageInfo')
+  }
+  UsageHistory(args) {
+    return this.makeCall('UsageHistory', args)
+  }
   ListBuckets() {
     return this.makeCall('ListBuckets')
   }
@@ -103,6 +109,15 @@ class Web {
   ListObjects(args) {
     return this.makeCall('ListObjects', args)
   }
+  SearchObjects(args) {
+    return this.makeCall('SearchObjects', args)
+  }
+  GetObjectMetadata(args) {
+    return this.makeCall('GetObjectMetadata', args)
+  }
+  SetObjectMetadata(args) {
+    return this.makeCall('SetObjectMetadata', args)
+  }
   PresignedGet(args) {
     return this.makeCall('PresignedGet', args)
   }
@@ -112,6 +127,18 @@ class Web {
   RemoveObject(args) {
     return this.makeCall('RemoveObject', args)
   }
+  NewMultipartUpload(args) {
+    return this.makeCall('NewMultipartUpload', args)
+  }
+  ListObjectParts(args) {
+    return this.makeCall('ListObjectParts', args)
+  }
+  CompleteMultipartUpload(args) {
+    return this.makeCall('CompleteMultipartUpload', args)
+  }
+  AbortMultipartUpload(args) {
+    return this.makeCall('AbortMultipartUpload', args)
+  }
   SetAuth(args) {
     return this.makeCall('SetAuth', args)
       .then(res => {
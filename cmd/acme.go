@@ -0,0 +1,83 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	xhttp "github.com/minio/minio/cmd/http"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// Directory of the certificates provisioned with ACME, in the certs directory.
+const acmeCertsDir = "acme"
+
+var (
+	// Provisions the certificates of the server with ACME, nil if disabled.
+	globalACMEManager *autocert.Manager
+
+	// Host names of the certificates provisioned with ACME.
+	globalACMEDomains set.StringSet
+)
+
+// newACMEManager - returns a manager provisioning the certificates
+// of domains with ACME, from Let's Encrypt unless directoryURL is set,
+// and caching them in cacheDir.
+func newACMEManager(domains []string, email, directoryURL, cacheDir string) *autocert.Manager {
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(cacheDir),
+		HostPolicy: autocert.HostWhitelist(domains...),
+		Email:      email,
+	}
+	if directoryURL != "" {
+		m.Client = &acme.Client{DirectoryURL: directoryURL}
+	}
+	return m
+}
+
+// isACMEHello - returns true if the client requests a host name whose
+// certificate is provisioned with ACME, or answers an ACME challenge.
+func isACMEHello(hello *tls.ClientHelloInfo) bool {
+	for _, proto := range hello.SupportedProtos {
+		if proto == acme.ALPNProto {
+			return true
+		}
+	}
+	return globalACMEDomains.Contains(strings.TrimSuffix(strings.ToLower(hello.ServerName), "."))
+}
+
+// getServerCertificate - returns the certificate served to the client,
+// provisioned with ACME for its host names, else the one loaded from
+// the certs directory matching its SNI.
+func getServerCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if globalACMEManager != nil && (globalTLSCerts == nil || isACMEHello(hello)) {
+		return globalACMEManager.GetCertificate(hello)
+	}
+	return globalTLSCerts.GetCertificate(hello)
+}
+
+// enableACMEChallenges - lets the server answer the TLS-ALPN challenges
+// of ACME, if enabled.
+func enableACMEChallenges(httpServer *xhttp.Server) {
+	if globalACMEManager != nil && httpServer.TLSConfig != nil {
+		httpServer.TLSConfig.NextProtos = append(httpServer.TLSConfig.NextProtos, acme.ALPNProto)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+	"golang.org/x/crypto/acme"
+)
+
+func TestIsACMEHello(t *testing.T) {
+	defer func(domains set.StringSet) { globalACMEDomains = domains }(globalACMEDomains)
+	globalACMEDomains = set.CreateStringSet("minio.example.com")
+
+	testCases := []struct {
+		hello    *tls.ClientHelloInfo
+		expected bool
+	}{
+		{&tls.ClientHelloInfo{ServerName: "minio.example.com"}, true},
+		{&tls.ClientHelloInfo{ServerName: "MinIO.Example.com."}, true},
+		{&tls.ClientHelloInfo{ServerName: "other.example.com"}, false},
+		{&tls.ClientHelloInfo{}, false},
+		{&tls.ClientHelloInfo{SupportedProtos: []string{acme.ALPNProto}}, true},
+	}
+	for i, testCase := range testCases {
+		if got := isACMEHello(testCase.hello); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
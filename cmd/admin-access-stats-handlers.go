@@ -0,0 +1,78 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// TopObjectsHandler - GET /minio/admin/v3/top-objects?bucket={bucket}&count={count}
+// ----------
+// Returns the most downloaded objects and prefixes of the cluster, of
+// the bucket or of all the buckets when it is empty.
+func (a adminAPIHandlers) TopObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TopObjects")
+
+	defer logger.AuditLog(w, r, "TopObjects", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TopObjectsAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if bucket != "" {
+		if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+			writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	count := defaultTopObjectsCount
+	if v := r.URL.Query().Get("count"); v != "" {
+		var err error
+		count, err = strconv.Atoi(v)
+		if err != nil || count <= 0 || count > accessStatsCapacity {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+			return
+		}
+	}
+
+	var objects, prefixes []madmin.AccessStat
+	reports := append(globalNotificationSys.AccessStats(ctx, bucket), globalAccessStats.report(bucket))
+	for _, report := range reports {
+		objects = append(objects, report.Objects...)
+		prefixes = append(prefixes, report.Prefixes...)
+	}
+
+	jsonBytes, err := json.Marshal(madmin.AccessReport{
+		Objects:  mergeAccessStats(objects, count),
+		Prefixes: mergeAccessStats(prefixes, count),
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// GetAuditTrailHandler - GET /minio/admin/v3/audit-trail?node={node}&date={date}
+// ----------
+// Returns the audit trail of a day of a server node, of this node when
+// the node is empty, one JSON record per line.
+func (a adminAPIHandlers) GetAuditTrailHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetAuditTrail")
+
+	defer logger.AuditLog(w, r, "GetAuditTrail", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AuditTrailAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	node := r.URL.Query().Get("node")
+	if node == "" {
+		node = GetLocalPeer(globalEndpoints)
+	}
+	if _, err := parseAuditTrailDate(date); err != nil || strings.Contains(node, SlashSeparator) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	data, err := readAuditTrail(ctx, objectAPI, date, node)
+	if err == errConfigNotFound {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminNoSuchAuditTrail), r.URL)
+		return
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentType, "application/x-ndjson")
+	writeResponse(w, http.StatusOK, data, mimeNone)
+}
+
+// VerifyAuditTrailHandler - GET /minio/admin/v3/audit-trail/verify?date={date}
+// ----------
+// Verifies the hash chains of the audit trails of a day of all the
+// server nodes.
+func (a adminAPIHandlers) VerifyAuditTrailHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VerifyAuditTrail")
+
+	defer logger.AuditLog(w, r, "VerifyAuditTrail", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AuditTrailAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	date := r.URL.Query().Get("date")
+	if _, err := parseAuditTrailDate(date); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	verifications, err := verifyAuditTrails(ctx, objectAPI, date)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(verifications)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
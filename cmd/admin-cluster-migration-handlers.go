@@ -0,0 +1,208 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// StartClusterMigrationHandler - PUT /minio/admin/v3/migrate-cluster
+// ----------
+// Starts migrating the buckets of a remote S3 compatible cluster into
+// the server, the job is described by the encrypted body of the request.
+func (a adminAPIHandlers) StartClusterMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartClusterMigration")
+
+	defer logger.AuditLog(w, r, "StartClusterMigration", mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ClusterMigrationAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	// The body holds the credentials of the source.
+	jobBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	var job madmin.ClusterMigrationJob
+	if err = json.Unmarshal(jobBytes, &job); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	// Migration outlives this request.
+	id, err := globalClusterMigrations.Start(GlobalContext, objectAPI, job)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toClusterMigrationAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ResumeClusterMigrationHandler - POST /minio/admin/v3/migrate-cluster/resume?id=
+// ----------
+// Resumes the cluster migration job id after its last checkpoint.
+func (a adminAPIHandlers) ResumeClusterMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ResumeClusterMigration")
+
+	defer logger.AuditLog(w, r, "ResumeClusterMigration", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ClusterMigrationAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	// Migration outlives this request.
+	if err := globalClusterMigrations.Resume(GlobalContext, objectAPI, id); err != nil {
+		writeErrorResponseJSON(ctx, w, toClusterMigrationAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelClusterMigrationHandler - POST /minio/admin/v3/migrate-cluster/cancel?id=
+// ----------
+// Stops the running cluster migration job id, it can be resumed later on.
+func (a adminAPIHandlers) CancelClusterMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelClusterMigration")
+
+	defer logger.AuditLog(w, r, "CancelClusterMigration", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ClusterMigrationAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if err := globalClusterMigrations.Cancel(r.URL.Query().Get("id")); err != nil {
+		writeErrorResponseJSON(ctx, w, toClusterMigrationAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ClusterMigrationStatusHandler - GET /minio/admin/v3/migrate-cluster
+// ----------
+// Returns the progress of all the cluster migration jobs.
+func (a adminAPIHandlers) ClusterMigrationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClusterMigrationStatus")
+
+	defer logger.AuditLog(w, r, "ClusterMigrationStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ClusterMigrationAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	statuses, err := globalClusterMigrations.Status(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ClusterMigrationReportHandler - GET /minio/admin/v3/migrate-cluster/report?id=
+// ----------
+// Returns the reconciliation report of the complete cluster migration job id.
+func (a adminAPIHandlers) ClusterMigrationReportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClusterMigrationReport")
+
+	defer logger.AuditLog(w, r, "ClusterMigrationReport", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ClusterMigrationAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	report, err := globalClusterMigrations.Report(ctx, objectAPI, r.URL.Query().Get("id"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toClusterMigrationAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+func toClusterMigrationAPIErr(ctx context.Context, err error) APIError {
+	switch err {
+	case errClusterMigrationInProgress:
+		return errorCodes.ToAPIErr(ErrAdminClusterMigrationInProgress)
+	case errClusterMigrationNotFound:
+		return errorCodes.ToAPIErr(ErrAdminNoSuchClusterMigration)
+	case errClusterMigrationInvalidSource:
+		return errorCodes.ToAPIErr(ErrAdminClusterMigrationInvalidSource)
+	}
+	return toAdminAPIErr(ctx, err)
+}
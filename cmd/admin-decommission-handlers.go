@@ -0,0 +1,164 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// validateDecommissionReq - validates the admin request and returns the
+// zones of the server with the index of the zone of the request, zones
+// are numbered from 1 in the order of the server command line, or the
+// drive of the request.
+func validateDecommissionReq(ctx context.Context, w http.ResponseWriter, r *http.Request, withZone bool) (*erasureZones, int, string) {
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return nil, -1, ""
+	}
+
+	z, ok := objectAPI.(*erasureZones)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1, ""
+	}
+
+	if !withZone {
+		return z, -1, ""
+	}
+
+	if drive := r.URL.Query().Get("drive"); drive != "" {
+		return z, -1, drive
+	}
+	zone, err := strconv.Atoi(r.URL.Query().Get("zone"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminDecommissionInvalidZone), r.URL)
+		return nil, -1, ""
+	}
+	return z, zone - 1, ""
+}
+
+// StartDecommissionHandler - PUT /minio/admin/v3/decommission?zone=
+// PUT /minio/admin/v3/decommission?drive=
+// ----------
+// Marks the zone, or the erasure set of the drive, as draining and moves
+// its objects to the other zones, starting the decommission of a draining
+// zone or drive resumes moving its objects.
+func (a adminAPIHandlers) StartDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(w, r, "StartDecommission", mustGetClaimsFromToken(r))
+
+	z, idx, drive := validateDecommissionReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	// Decommission outlives this request.
+	var err error
+	if drive != "" {
+		err = z.StartDriveDecommission(GlobalContext, drive)
+	} else {
+		err = z.StartDecommission(GlobalContext, idx)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toDecommissionAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelDecommissionHandler - POST /minio/admin/v3/decommission/cancel?zone=
+// POST /minio/admin/v3/decommission/cancel?drive=
+// ----------
+// Stops moving the objects of the zone, or of the erasure set of the
+// drive, which takes new objects again.
+func (a adminAPIHandlers) CancelDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(w, r, "CancelDecommission", mustGetClaimsFromToken(r))
+
+	z, idx, drive := validateDecommissionReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	var err error
+	if drive != "" {
+		err = z.CancelDriveDecommission(ctx, drive)
+	} else {
+		err = z.CancelDecommission(ctx, idx)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toDecommissionAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// DecommissionStatusHandler - GET /minio/admin/v3/decommission
+// ----------
+// Returns the progress of the zones and drives being decommissioned.
+func (a adminAPIHandlers) DecommissionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DecommissionStatus")
+
+	defer logger.AuditLog(w, r, "DecommissionStatus", mustGetClaimsFromToken(r))
+
+	z, _, _ := validateDecommissionReq(ctx, w, r, false)
+	if z == nil {
+		return
+	}
+
+	statuses, err := z.DecommissionStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+func toDecommissionAPIErr(ctx context.Context, err error) APIError {
+	switch err {
+	case errDecommissionInvalidZone:
+		return errorCodes.ToAPIErr(ErrAdminDecommissionInvalidZone)
+	case errDecommissionInvalidDrive:
+		return errorCodes.ToAPIErr(ErrAdminDecommissionInvalidDrive)
+	case errDecommissionInProgress:
+		return errorCodes.ToAPIErr(ErrAdminDecommissionInProgress)
+	case errDecommissionNotFound:
+		return errorCodes.ToAPIErr(ErrAdminNoSuchDecommission)
+	}
+	return toAdminAPIErr(ctx, err)
+}
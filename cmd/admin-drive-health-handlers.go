@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+var errDriveNotFound = AdminError{
+	Code:       "XMinioAdminDriveNotFound",
+	Message:    "The drive is not an endpoint of the servers",
+	StatusCode: http.StatusNotFound,
+}
+
+// ClearDriveReadOnlyHandler - POST /minio/admin/v3/drive-health/clear?drive={endpoint}
+// ----------
+// Clears the read-only mark of a drive predicted to fail, for example
+// once the drive is found healthy. The drive is its endpoint as reported
+// by the server info. The drive is marked read-only again if it is still
+// predicted to fail at the next check.
+func (a adminAPIHandlers) ClearDriveReadOnlyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ClearDriveReadOnly")
+
+	defer logger.AuditLog(w, r, "ClearDriveReadOnly", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	drive := r.URL.Query().Get("drive")
+	var err error = errDriveNotFound
+	for _, zone := range globalEndpoints {
+		for _, endpoint := range zone.Endpoints {
+			if endpoint.String() != drive {
+				continue
+			}
+			if endpoint.IsLocal {
+				err = clearLocalDriveReadOnly(ctx, endpoint.Path)
+			} else {
+				err = globalNotificationSys.ClearDriveReadOnly(ctx, endpoint.Host, endpoint.Path)
+			}
+		}
+	}
+	if err == errDiskNotFound {
+		err = errDriveNotFound
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Maximum size of the fault config sent to the admin API.
+const maxFaultConfigSize = 1 << 20
+
+// GetFaultsHandler - GET /minio/admin/v3/faults
+// ----------
+// Returns the faults injected by the server.
+func (a adminAPIHandlers) GetFaultsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetFaults")
+
+	defer logger.AuditLog(w, r, "GetFaults", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.FaultInjectionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if !globalFaultInjection {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminFaultInjectionDisabled), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(madmin.FaultConfig{Rules: globalFaultInjector.Rules()})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// SetFaultsHandler - PUT /minio/admin/v3/faults
+// ----------
+// Replaces the faults injected by all the servers, the faults are not
+// persisted and are removed on restart.
+func (a adminAPIHandlers) SetFaultsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetFaults")
+
+	defer logger.AuditLog(w, r, "SetFaults", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.FaultInjectionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if !globalFaultInjection {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminFaultInjectionDisabled), r.URL)
+		return
+	}
+
+	var config madmin.FaultConfig
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxFaultConfigSize)).Decode(&config); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+	if err := validateFaultRules(config.Rules); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	globalFaultInjector.Set(config.Rules)
+	for _, nerr := range globalNotificationSys.SetFaults(config.Rules) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
@@ -0,0 +1,77 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// ScanGarbageHandler - POST /minio/admin/v3/scan-garbage?older-than={duration}&remove={bool}
+// ----------
+// Scans the drives of all the servers for part files, data and tmp
+// directories left behind by interrupted operations, and removes them
+// if requested. Entries modified recently are skipped, they may belong
+// to on-going operations, removing entries of any age is rejected.
+func (a adminAPIHandlers) ScanGarbageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScanGarbage")
+
+	defer logger.AuditLog(w, r, "ScanGarbage", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	olderThan := madmin.DefaultGarbageAge
+	if v := r.URL.Query().Get("older-than"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+			return
+		}
+		olderThan = d
+	}
+	remove := r.URL.Query().Get("remove") == "true"
+	if remove && olderThan == 0 {
+		// Would remove the files of on-going operations.
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	report := scanLocalGarbage(ctx, olderThan, remove)
+	if globalIsDistErasure {
+		for _, peerReport := range globalNotificationSys.ScanGarbage(ctx, olderThan, remove) {
+			report.TotalSize += peerReport.TotalSize
+			report.Entries = append(report.Entries, peerReport.Entries...)
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
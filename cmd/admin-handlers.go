@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"crypto/subtle"
 	"crypto/tls"
@@ -24,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
@@ -328,6 +330,48 @@ func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Re
 	writeSuccessResponseJSON(w, dataUsageInfoJSON)
 }
 
+// PrefixUsageHandler - GET /minio/admin/v3/prefix-usage?bucket={bucket}&prefix={prefix}&accurate={bool}
+// ----------
+// Get the number of objects and their total size under a prefix, from
+// the data usage scanner or, when accurate is set, by listing the prefix.
+func (a adminAPIHandlers) PrefixUsageHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PrefixUsage")
+
+	defer logger.AuditLog(w, r, "PrefixUsage", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DataUsageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	prefix := r.URL.Query().Get("prefix")
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var usage madmin.PrefixUsage
+	var err error
+	if r.URL.Query().Get("accurate") == "true" {
+		usage, err = prefixUsageFromListing(ctx, objectAPI, bucket, prefix)
+	} else {
+		usage, err = prefixUsageFromCache(ctx, objectAPI, bucket, prefix)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	usageJSON, err := json.Marshal(usage)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, usageJSON)
+}
+
 func lriToLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntry {
 	entry := &madmin.LockEntry{
 		Timestamp:  l.Timestamp,
@@ -705,14 +749,14 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 					if hr.errBody == "" {
 						errorRespJSON = encodeResponseJSON(getAPIErrorResponse(ctx, hr.apiErr,
 							r.URL.Path, w.Header().Get(xhttp.AmzRequestID),
-							globalDeploymentID))
+							w.Header().Get(xhttp.AmzRequestHostID)))
 					} else {
 						errorRespJSON = encodeResponseJSON(APIErrorResponse{
 							Code:      hr.apiErr.Code,
 							Message:   hr.errBody,
 							Resource:  r.URL.Path,
 							RequestID: w.Header().Get(xhttp.AmzRequestID),
-							HostID:    globalDeploymentID,
+							HostID:    w.Header().Get(xhttp.AmzRequestHostID),
 						})
 					}
 					if !started {
@@ -1025,6 +1069,159 @@ func (a adminAPIHandlers) TraceHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// LookupRequestHandler - GET /minio/admin/v3/request?id={requestID}
+// ----------
+// Returns what every server remembers about the request with the
+// given x-amz-request-id, so that an ID reported by a client can be
+// correlated with the server logs and traces.
+func (a adminAPIHandlers) LookupRequestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "LookupRequest")
+
+	defer logger.AuditLog(w, r, "LookupRequest", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TraceAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	requestID := r.URL.Query().Get("id")
+	if requestID == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	infos := globalRequestHistory.find(requestID)
+	infos = append(infos, globalNotificationSys.LookupRequest(ctx, requestID)...)
+	if len(infos) == 0 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminNoSuchRequest), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(infos)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ServerModeHandler - GET /minio/admin/v3/server-mode
+// ----------
+// Returns the current server mode.
+func (a adminAPIHandlers) ServerModeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServerMode")
+
+	defer logger.AuditLog(w, r, "ServerMode", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	jsonBytes, err := json.Marshal(madmin.ServerModeInfo{Mode: madmin.ServerMode(getServerMode().String())})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// ExportMetadataHandler - GET /minio/admin/v3/metadata/export
+// ----------
+// Returns a signed zip archive of the config, IAM and bucket metadata.
+func (a adminAPIHandlers) ExportMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportMetadata")
+
+	defer logger.AuditLog(w, r, "ExportMetadata", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ExportMetadataAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := exportMetadata(ctx, objectAPI, &buf); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentType, "application/zip")
+	w.Header().Set(xhttp.ContentDisposition, `attachment; filename="minio-metadata.zip"`)
+	writeResponse(w, http.StatusOK, buf.Bytes(), mimeNone)
+}
+
+// ImportMetadataHandler - PUT /minio/admin/v3/metadata/import
+// ----------
+// Imports an archive returned by ExportMetadataHandler, the servers load
+// the imported config and IAM when they restart.
+func (a adminAPIHandlers) ImportMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportMetadata")
+
+	defer logger.AuditLog(w, r, "ImportMetadata", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ImportMetadataAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if r.ContentLength > maxMetadataArchiveSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	archive, err := ioutil.ReadAll(io.LimitReader(r.Body, maxMetadataArchiveSize))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	info, err := importMetadata(ctx, objectAPI, archive)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	jsonBytes, err := json.Marshal(info)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// SetServerModeHandler - PUT /minio/admin/v3/server-mode?mode={mode}
+// ----------
+// Sets the server mode on all the servers, the mode set at startup
+// with the read-only and write-once flags is restored on restart.
+func (a adminAPIHandlers) SetServerModeHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetServerMode")
+
+	defer logger.AuditLog(w, r, "SetServerMode", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	mode, err := parseServerMode(r.URL.Query().Get("mode"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+		return
+	}
+
+	setServerMode(mode)
+	for _, nerr := range globalNotificationSys.SetServerMode(mode) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
 // The handler sends console logs to the connected HTTP client.
 func (a adminAPIHandlers) ConsoleLogHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ConsoleLog")
@@ -1217,7 +1414,7 @@ func (a adminAPIHandlers) OBDInfoHandler(w http.ResponseWriter, r *http.Request)
 
 	errResp := func(err error) {
 		errorResponse := getAPIErrorResponse(ctx, toAdminAPIErr(ctx, err), r.URL.String(),
-			w.Header().Get(xhttp.AmzRequestID), globalDeploymentID)
+			w.Header().Get(xhttp.AmzRequestID), w.Header().Get(xhttp.AmzRequestHostID))
 		encodedErrorResponse := encodeResponse(errorResponse)
 		obdInfo.Error = string(encodedErrorResponse)
 		logger.LogIf(ctx, enc.Encode(obdInfo))
@@ -1339,6 +1536,11 @@ func (a adminAPIHandlers) OBDInfoHandler(w http.ResponseWriter, r *http.Request)
 			obdInfo.Perf.NetParallel = globalNotificationSys.NetOBDParallelInfo(deadlinedCtx)
 			partialWrite(obdInfo)
 		}
+
+		if object, ok := vars["perfobject"]; ok && object == "true" {
+			obdInfo.Perf.Object = getObjectOBD(deadlinedCtx, objectAPI, r)
+			partialWrite(obdInfo)
+		}
 	}()
 
 	ticker := time.NewTicker(30 * time.Second)
@@ -1429,6 +1631,9 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 			StandardSCParity: storageInfo.Backend.StandardSCParity,
 			RRSCData:         storageInfo.Backend.RRSCData,
 			RRSCParity:       storageInfo.Backend.RRSCParity,
+
+			FormatParity:        storageInfo.Backend.FormatParity,
+			FailureDomainDrives: storageInfo.Backend.FailureDomainDrives,
 		}
 	} else {
 		backend = madmin.FSBackend{
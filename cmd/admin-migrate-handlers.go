@@ -0,0 +1,101 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// StartFSMigrationHandler - POST /minio/admin/v3/migrate-fs?path=
+// ----------
+// Starts migrating all buckets and objects of the FS deployment
+// at path, local to this server, into the erasure deployment.
+func (a adminAPIHandlers) StartFSMigrationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartFSMigration")
+
+	defer logger.AuditLog(w, r, "StartFSMigration", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.MigrateFSAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	fsPath := r.URL.Query().Get("path")
+	if fsPath == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	// Migration outlives this request.
+	err := globalFSMigration.Start(GlobalContext, objectAPI, fsPath)
+	switch err {
+	case nil:
+	case errFSMigrationInProgress:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminFSMigrationInProgress), r.URL)
+		return
+	case errFSMigrationNotFS:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminFSMigrationInvalidPath), r.URL)
+		return
+	default:
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// FSMigrationStatusHandler - GET /minio/admin/v3/migrate-fs
+// ----------
+// Returns the progress of the current or the last FS migration.
+func (a adminAPIHandlers) FSMigrationStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "FSMigrationStatus")
+
+	defer logger.AuditLog(w, r, "FSMigrationStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.MigrateFSAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	status := globalFSMigration.Status()
+	if status.Path == "" {
+		// Nothing started since the server started,
+		// report the last saved checkpoint if any.
+		cp, err := loadFSMigrationCheckpoint(ctx, objectAPI)
+		if err != nil && err != errConfigNotFound {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		status = cp
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
@@ -0,0 +1,180 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// StartBucketMirrorHandler - PUT /minio/admin/v3/mirror
+// ----------
+// Starts mirroring the objects of a bucket to a remote S3 compatible
+// target, the job is described by the encrypted body of the request.
+func (a adminAPIHandlers) StartBucketMirrorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartBucketMirror")
+
+	defer logger.AuditLog(w, r, "StartBucketMirror", mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.BucketMirrorAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	// The body holds the credentials of the target.
+	jobBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	var job madmin.MirrorJob
+	if err = json.Unmarshal(jobBytes, &job); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	// Mirror outlives this request.
+	id, err := globalBucketMirrors.Start(GlobalContext, objectAPI, job)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketMirrorAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{ID: id})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ResumeBucketMirrorHandler - POST /minio/admin/v3/mirror/resume?id=
+// ----------
+// Resumes the bucket mirror job id after its last checkpoint.
+func (a adminAPIHandlers) ResumeBucketMirrorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ResumeBucketMirror")
+
+	defer logger.AuditLog(w, r, "ResumeBucketMirror", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketMirrorAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	// Mirror outlives this request.
+	if err := globalBucketMirrors.Resume(GlobalContext, objectAPI, id); err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketMirrorAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelBucketMirrorHandler - POST /minio/admin/v3/mirror/cancel?id=
+// ----------
+// Stops the running bucket mirror job id, it can be resumed later on.
+func (a adminAPIHandlers) CancelBucketMirrorHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelBucketMirror")
+
+	defer logger.AuditLog(w, r, "CancelBucketMirror", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketMirrorAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if err := globalBucketMirrors.Cancel(r.URL.Query().Get("id")); err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketMirrorAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// BucketMirrorStatusHandler - GET /minio/admin/v3/mirror
+// ----------
+// Returns the progress of all the bucket mirror jobs.
+func (a adminAPIHandlers) BucketMirrorStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "BucketMirrorStatus")
+
+	defer logger.AuditLog(w, r, "BucketMirrorStatus", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketMirrorAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	statuses, err := globalBucketMirrors.Status(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+func toBucketMirrorAPIErr(ctx context.Context, err error) APIError {
+	switch err {
+	case errBucketMirrorInProgress:
+		return errorCodes.ToAPIErr(ErrAdminBucketMirrorInProgress)
+	case errBucketMirrorNotFound:
+		return errorCodes.ToAPIErr(ErrAdminNoSuchBucketMirror)
+	case errBucketMirrorInvalidTarget:
+		return errorCodes.ToAPIErr(ErrAdminBucketMirrorInvalidTarget)
+	case errInvalidArgument:
+		return errorCodes.ToAPIErr(ErrInvalidRequest)
+	}
+	return toAdminAPIErr(ctx, err)
+}
@@ -63,6 +63,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/storageinfo").HandlerFunc(httpTraceAll(adminAPI.StorageInfoHandler))
 		// DataUsageInfo operations
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/datausageinfo").HandlerFunc(httpTraceAll(adminAPI.DataUsageInfoHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/prefix-usage").HandlerFunc(httpTraceAll(adminAPI.PrefixUsageHandler))
 
 		if globalIsDistErasure || globalIsErasure {
 			/// Heal operations
@@ -98,6 +99,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-config-history-kv").HandlerFunc(httpTraceAll(adminAPI.ListConfigHistoryKVHandler)).Queries("count", "{count:[0-9]+}")
 			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/clear-config-history-kv").HandlerFunc(httpTraceHdrs(adminAPI.ClearConfigHistoryKVHandler)).Queries("restoreId", "{restoreId:.*}")
 			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/restore-config-history-kv").HandlerFunc(httpTraceHdrs(adminAPI.RestoreConfigHistoryKVHandler)).Queries("restoreId", "{restoreId:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/diff-config-history-kv").HandlerFunc(httpTraceAll(adminAPI.DiffConfigHistoryKVHandler)).Queries("restoreId", "{restoreId:.*}")
 		}
 
 		/// Config import/export bulk operations
@@ -183,6 +185,138 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 			}
 		}
 
+		// Bucket hooks operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-hooks").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketHooksConfigHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-hooks").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketHooksConfigHandler)).Queries("bucket", "{bucket:.*}")
+		}
+
+		// Bucket bandwidth operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-bandwidth").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketBandwidthHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-bandwidth").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketBandwidthHandler)).Queries("bucket", "{bucket:.*}")
+		}
+
+		// Bucket secure erase operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-secure-erase").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketSecureEraseHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-secure-erase").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketSecureEraseHandler)).Queries("bucket", "{bucket:.*}")
+		}
+
+		// Object access statistics
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/top-objects").HandlerFunc(
+			httpTraceHdrs(adminAPI.TopObjectsHandler))
+
+		// Bucket read replica operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/get-bucket-read-replica").HandlerFunc(
+				httpTraceHdrs(adminAPI.GetBucketReadReplicaHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-bucket-read-replica").HandlerFunc(
+				httpTraceHdrs(adminAPI.PutBucketReadReplicaHandler)).Queries("bucket", "{bucket:.*}")
+		}
+
+		// Bucket snapshot operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/snapshot").HandlerFunc(
+				httpTraceHdrs(adminAPI.CreateBucketSnapshotHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/snapshot").HandlerFunc(
+				httpTraceHdrs(adminAPI.ListBucketSnapshotsHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/snapshot").HandlerFunc(
+				httpTraceHdrs(adminAPI.DeleteBucketSnapshotHandler)).Queries("bucket", "{bucket:.*}", "id", "{id:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/snapshot/clone").HandlerFunc(
+				httpTraceHdrs(adminAPI.CloneBucketSnapshotHandler)).Queries("bucket", "{bucket:.*}", "id", "{id:.*}", "target", "{target:.*}")
+		}
+
+		// Object verification operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/verify-object").HandlerFunc(
+				httpTraceHdrs(adminAPI.VerifyObjectHandler)).Queries("bucket", "{bucket:.*}", "object", "{object:.*}")
+		}
+
+		// Bucket remote target operations
+		if !globalIsGateway {
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-remote-target").HandlerFunc(
+				httpTraceHdrs(adminAPI.SetRemoteTargetHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion+"/list-remote-targets").HandlerFunc(
+				httpTraceHdrs(adminAPI.ListRemoteTargetsHandler)).Queries("bucket", "{bucket:.*}")
+			adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-remote-target").HandlerFunc(
+				httpTraceHdrs(adminAPI.RemoveRemoteTargetHandler)).Queries("bucket", "{bucket:.*}", "arn", "{arn:.*}")
+		}
+
+		// Fault injection operations
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/faults").HandlerFunc(httpTraceHdrs(adminAPI.GetFaultsHandler))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/faults").HandlerFunc(httpTraceHdrs(adminAPI.SetFaultsHandler))
+
+		// Garbage scan operations
+		if globalIsDistErasure || globalIsErasure {
+			adminRouter.Methods(http.MethodPost).Path(adminVersion + "/scan-garbage").HandlerFunc(
+				httpTraceHdrs(adminAPI.ScanGarbageHandler))
+		}
+
+		// Drive health operations
+		if globalIsDistErasure || globalIsErasure {
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/drive-health/clear").HandlerFunc(
+				httpTraceHdrs(adminAPI.ClearDriveReadOnlyHandler)).Queries("drive", "{drive:.*}")
+		}
+
+		// FS migration operations
+		if globalIsDistErasure || globalIsErasure {
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/migrate-fs").HandlerFunc(
+				httpTraceHdrs(adminAPI.StartFSMigrationHandler)).Queries("path", "{path:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/migrate-fs").HandlerFunc(
+				httpTraceHdrs(adminAPI.FSMigrationStatusHandler))
+		}
+
+		// Zone and drive decommission operations
+		if globalIsDistErasure || globalIsErasure {
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/decommission").HandlerFunc(
+				httpTraceHdrs(adminAPI.StartDecommissionHandler)).Queries("zone", "{zone:.*}")
+			adminRouter.Methods(http.MethodPut).Path(adminVersion+"/decommission").HandlerFunc(
+				httpTraceHdrs(adminAPI.StartDecommissionHandler)).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/decommission/cancel").HandlerFunc(
+				httpTraceHdrs(adminAPI.CancelDecommissionHandler)).Queries("zone", "{zone:.*}")
+			adminRouter.Methods(http.MethodPost).Path(adminVersion+"/decommission/cancel").HandlerFunc(
+				httpTraceHdrs(adminAPI.CancelDecommissionHandler)).Queries("drive", "{drive:.*}")
+			adminRouter.Methods(http.MethodGet).Path(adminVersion + "/decommission").HandlerFunc(
+				httpTraceHdrs(adminAPI.DecommissionStatusHandler))
+		}
+
+		// Tenant operations
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/set-tenant").HandlerFunc(
+			httpTraceHdrs(adminAPI.SetTenantHandler)).Queries("name", "{name:.*}")
+		adminRouter.Methods(http.MethodDelete).Path(adminVersion+"/remove-tenant").HandlerFunc(
+			httpTraceHdrs(adminAPI.RemoveTenantHandler)).Queries("name", "{name:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/list-tenants").HandlerFunc(
+			httpTraceHdrs(adminAPI.ListTenantsHandler))
+
+		// Bucket mirror operations
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/mirror").HandlerFunc(
+			httpTraceHdrs(adminAPI.StartBucketMirrorHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/mirror").HandlerFunc(
+			httpTraceHdrs(adminAPI.BucketMirrorStatusHandler))
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/mirror/resume").HandlerFunc(
+			httpTraceHdrs(adminAPI.ResumeBucketMirrorHandler)).Queries("id", "{id:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/mirror/cancel").HandlerFunc(
+			httpTraceHdrs(adminAPI.CancelBucketMirrorHandler)).Queries("id", "{id:.*}")
+
+		// Cluster migration operations
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/migrate-cluster").HandlerFunc(
+			httpTraceHdrs(adminAPI.StartClusterMigrationHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/migrate-cluster").HandlerFunc(
+			httpTraceHdrs(adminAPI.ClusterMigrationStatusHandler))
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/migrate-cluster/report").HandlerFunc(
+			httpTraceHdrs(adminAPI.ClusterMigrationReportHandler)).Queries("id", "{id:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/migrate-cluster/resume").HandlerFunc(
+			httpTraceHdrs(adminAPI.ResumeClusterMigrationHandler)).Queries("id", "{id:.*}")
+		adminRouter.Methods(http.MethodPost).Path(adminVersion+"/migrate-cluster/cancel").HandlerFunc(
+			httpTraceHdrs(adminAPI.CancelClusterMigrationHandler)).Queries("id", "{id:.*}")
+
 		// -- Top APIs --
 		// Top locks
 		if globalIsDistErasure {
@@ -192,6 +326,25 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		// HTTP Trace
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/trace").HandlerFunc(adminAPI.TraceHandler)
 
+		// Server mode
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/server-mode").HandlerFunc(httpTraceHdrs(adminAPI.ServerModeHandler))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion+"/server-mode").HandlerFunc(
+			httpTraceHdrs(adminAPI.SetServerModeHandler)).Queries("mode", "{mode:.*}")
+
+		// Metadata backup
+		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/metadata/export").HandlerFunc(httpTraceHdrs(adminAPI.ExportMetadataHandler))
+		adminRouter.Methods(http.MethodPut).Path(adminVersion + "/metadata/import").HandlerFunc(httpTraceHdrs(adminAPI.ImportMetadataHandler))
+
+		// Request ID lookup
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/request").HandlerFunc(
+			httpTraceHdrs(adminAPI.LookupRequestHandler)).Queries("id", "{id:.*}")
+
+		// Audit trail
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/audit-trail").HandlerFunc(
+			httpTraceHdrs(adminAPI.GetAuditTrailHandler)).Queries("date", "{date:.*}")
+		adminRouter.Methods(http.MethodGet).Path(adminVersion+"/audit-trail/verify").HandlerFunc(
+			httpTraceHdrs(adminAPI.VerifyAuditTrailHandler)).Queries("date", "{date:.*}")
+
 		// Console Logs
 		adminRouter.Methods(http.MethodGet).Path(adminVersion + "/log").HandlerFunc(httpTraceAll(adminAPI.ConsoleLogHandler))
 
@@ -206,6 +359,7 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 				HandlerFunc(httpTraceHdrs(adminAPI.OBDInfoHandler)).
 				Queries("perfdrive", "{perfdrive:true|false}",
 					"perfnet", "{perfnet:true|false}",
+					"perfobject", "{perfobject:true|false}",
 					"minioinfo", "{minioinfo:true|false}",
 					"minioconfig", "{minioconfig:true|false}",
 					"syscpu", "{syscpu:true|false}",
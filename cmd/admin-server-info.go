@@ -43,7 +43,9 @@ func getLocalServerProperty(endpointZones EndpointZones, r *http.Request) madmin
 			}
 			_, present := network[nodeName]
 			if !present {
-				if err := IsServerResolvable(endpoint); err == nil {
+				if !globalPeerHeartbeat.isOnline(endpoint.Host) {
+					network[nodeName] = "offline"
+				} else if err := IsServerResolvable(endpoint); err == nil {
 					network[nodeName] = "online"
 				} else {
 					network[nodeName] = "offline"
@@ -59,5 +61,6 @@ func getLocalServerProperty(endpointZones EndpointZones, r *http.Request) madmin
 		Version:  Version,
 		CommitID: CommitID,
 		Network:  network,
+		Peers:    globalPeerHeartbeat.healthMap(),
 	}
 }
@@ -0,0 +1,153 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// CreateBucketSnapshotHandler - PUT /minio/admin/v3/snapshot?bucket={bucket}
+// ----------
+// Snapshots the current versions of the objects of the bucket.
+func (a adminAPIHandlers) CreateBucketSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CreateBucketSnapshot")
+
+	defer logger.AuditLog(w, r, "CreateBucketSnapshot", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketSnapshotAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	snapshot, err := createBucketSnapshot(ctx, objectAPI, mux.Vars(r)["bucket"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// ListBucketSnapshotsHandler - GET /minio/admin/v3/snapshot?bucket={bucket}
+// ----------
+// Returns the snapshots of the bucket.
+func (a adminAPIHandlers) ListBucketSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketSnapshots")
+
+	defer logger.AuditLog(w, r, "ListBucketSnapshots", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketSnapshotAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	snapshots, err := listBucketSnapshots(ctx, objectAPI, mux.Vars(r)["bucket"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(snapshots)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// DeleteBucketSnapshotHandler - DELETE /minio/admin/v3/snapshot?bucket={bucket}&id={id}
+// ----------
+// Deletes a snapshot of the bucket.
+func (a adminAPIHandlers) DeleteBucketSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketSnapshot")
+
+	defer logger.AuditLog(w, r, "DeleteBucketSnapshot", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketSnapshotAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	if err := deleteBucketSnapshot(ctx, objectAPI, vars["bucket"], vars["id"]); err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CloneBucketSnapshotHandler - POST /minio/admin/v3/snapshot/clone?bucket={bucket}&id={id}&target={target}
+// ----------
+// Creates the target bucket with copies of the object versions of a
+// snapshot of the bucket, responds once all the objects are copied.
+func (a adminAPIHandlers) CloneBucketSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CloneBucketSnapshot")
+
+	defer logger.AuditLog(w, r, "CloneBucketSnapshot", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketSnapshotAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	result, err := cloneBucketSnapshot(ctx, objectAPI, vars["bucket"], vars["id"], vars["target"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+func toBucketSnapshotAPIErr(ctx context.Context, err error) APIError {
+	switch err {
+	case errBucketSnapshotNotFound:
+		return errorCodes.ToAPIErr(ErrAdminNoSuchBucketSnapshot)
+	case errBucketSnapshotVersioning:
+		return errorCodes.ToAPIErr(ErrAdminBucketSnapshotVersioning)
+	}
+	return toAPIError(ctx, err)
+}
@@ -0,0 +1,149 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// SetTenantHandler - PUT /minio/admin/v3/set-tenant?name=
+// ----------
+// Creates or replaces the tenant described by the body of the request.
+func (a adminAPIHandlers) SetTenantHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetTenant")
+
+	defer logger.AuditLog(w, r, "SetTenant", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TenantAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	var tenant madmin.Tenant
+	if err := json.NewDecoder(io.LimitReader(r.Body, r.ContentLength)).Decode(&tenant); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	if tenant.Name != r.URL.Query().Get("name") {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if err := globalTenantSys.Set(ctx, objectAPI, tenant); err != nil {
+		writeErrorResponseJSON(ctx, w, toTenantAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	loadTenantsOnPeers(ctx)
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RemoveTenantHandler - DELETE /minio/admin/v3/remove-tenant?name=
+// ----------
+// Removes the tenant, its users and buckets are kept.
+func (a adminAPIHandlers) RemoveTenantHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveTenant")
+
+	defer logger.AuditLog(w, r, "RemoveTenant", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TenantAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if err := globalTenantSys.Remove(ctx, objectAPI, r.URL.Query().Get("name")); err != nil {
+		writeErrorResponseJSON(ctx, w, toTenantAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	loadTenantsOnPeers(ctx)
+
+	// Write success response.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ListTenantsHandler - GET /minio/admin/v3/list-tenants
+// ----------
+// Returns the tenants and the last known usage of their buckets.
+func (a adminAPIHandlers) ListTenantsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListTenants")
+
+	defer logger.AuditLog(w, r, "ListTenants", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TenantAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	tenants, err := globalTenantSys.Info(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(tenants)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Write success response.
+	writeSuccessResponseJSON(w, data)
+}
+
+// loadTenantsOnPeers - the peers restrict the users to the buckets of
+// their tenants as soon as the tenants change.
+func loadTenantsOnPeers(ctx context.Context) {
+	for _, nerr := range globalNotificationSys.LoadTenants() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+}
+
+func toTenantAPIErr(ctx context.Context, err error) APIError {
+	switch err {
+	case errNoSuchTenant:
+		return errorCodes.ToAPIErr(ErrAdminNoSuchTenant)
+	case errInvalidTenantName:
+		return errorCodes.ToAPIErr(ErrAdminInvalidTenantName)
+	case errTenantUserInUse:
+		return errorCodes.ToAPIErr(ErrAdminTenantUserInUse)
+	}
+	return toAdminAPIErr(ctx, err)
+}
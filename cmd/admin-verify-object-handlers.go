@@ -0,0 +1,195 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// VerifyObjectHandler - GET /minio/admin/v3/verify-object?bucket={bucket}&object={object}&versionId={versionId}
+// ----------
+// Re-reads an object and verifies each of its parts against the stored
+// ETags, and in erasure mode the bitrot checksums of the parts on every
+// drive, without sending the content to the client.
+func (a adminAPIHandlers) VerifyObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "VerifyObject")
+
+	defer logger.AuditLog(w, r, "VerifyObject", mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.VerifyObjectAdminAction)
+	if objectAPI == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := normalizeObjectName(vars["object"])
+	versionID := r.URL.Query().Get("versionId")
+
+	opts := ObjectOptions{VersionID: versionID}
+	objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	report := madmin.ObjectVerifyReport{
+		Bucket:    bucket,
+		Object:    object,
+		VersionID: objInfo.VersionID,
+		Size:      objInfo.Size,
+		ETag:      objInfo.ETag,
+	}
+
+	if crypto.IsEncrypted(objInfo.UserDefined) {
+		// The stored ETags of encrypted objects are sealed and SSE-C
+		// objects can't be read without their key, only the bitrot
+		// checksums are verified.
+		verifyObjectParts(nil, objInfo, &report)
+	} else {
+		gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, opts)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		verifyObjectParts(gr, objInfo, &report)
+		gr.Close()
+	}
+
+	if globalIsErasure || globalIsDistErasure {
+		res, err := objectAPI.HealObject(ctx, bucket, object, objInfo.VersionID, madmin.HealOpts{
+			DryRun:   true,
+			ScanMode: madmin.HealDeepScan,
+		})
+		if err != nil {
+			logger.LogIf(ctx, err)
+			if report.Error == "" {
+				report.Error = err.Error()
+			}
+		}
+		report.Drives = res.Before.Drives
+		for _, drive := range report.Drives {
+			if drive.State == madmin.DriveStateCorrupt {
+				report.Status = madmin.VerifyStatusCorrupt
+			}
+		}
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// verifyObjectParts reads the content of an object from r, computes the
+// MD5 of each of its parts and of the ETag derived from them, and fills
+// the report with their status. A nil reader only lists the parts.
+func verifyObjectParts(r io.Reader, objInfo ObjectInfo, report *madmin.ObjectVerifyReport) {
+	parts := objInfo.Parts
+	if len(parts) == 0 {
+		parts = []ObjectPartInfo{{Number: 1, Size: objInfo.Size, ActualSize: objInfo.Size}}
+	}
+
+	// Objects uploaded by a single PUT have one part without an ETag,
+	// the ETag of the object is the ETag of the part.
+	singlePart := true
+	for _, part := range parts {
+		if part.ETag != "" {
+			singlePart = false
+		}
+	}
+
+	// The ETags of compressed objects are computed from the compressed
+	// stream, the content is only read back.
+	verifiable := r != nil && !objInfo.IsCompressed()
+	etagVerifiable := verifiable
+
+	report.Status = madmin.VerifyStatusOK
+	report.Parts = make([]madmin.ObjectVerifyPart, 0, len(parts))
+	completeParts := make([]CompletePart, 0, len(parts))
+	for _, part := range parts {
+		p := madmin.ObjectVerifyPart{
+			Number: part.Number,
+			Size:   part.ActualSize,
+			ETag:   part.ETag,
+			Status: madmin.VerifyStatusUnverified,
+		}
+		if p.Size <= 0 {
+			p.Size = part.Size
+		}
+		if singlePart {
+			p.ETag = objInfo.ETag
+		}
+
+		if r != nil && report.Error == "" {
+			h := md5.New()
+			if _, err := io.CopyN(h, r, p.Size); err != nil {
+				p.Status = madmin.VerifyStatusCorrupt
+				report.Error = err.Error()
+			} else {
+				p.ComputedETag = hex.EncodeToString(h.Sum(nil))
+				if verifiable && isMD5ETag(p.ETag) {
+					p.Status = madmin.VerifyStatusCorrupt
+					if isETagEqual(p.ETag, p.ComputedETag) {
+						p.Status = madmin.VerifyStatusOK
+					}
+				}
+			}
+		}
+
+		if !isMD5ETag(p.ETag) || p.ComputedETag == "" {
+			etagVerifiable = false
+		}
+		switch p.Status {
+		case madmin.VerifyStatusCorrupt:
+			report.Status = madmin.VerifyStatusCorrupt
+		case madmin.VerifyStatusUnverified:
+			if report.Status == madmin.VerifyStatusOK {
+				report.Status = madmin.VerifyStatusUnverified
+			}
+		}
+		report.Parts = append(report.Parts, p)
+		completeParts = append(completeParts, CompletePart{PartNumber: p.Number, ETag: p.ComputedETag})
+	}
+
+	if !etagVerifiable {
+		return
+	}
+
+	if singlePart {
+		report.ComputedETag = report.Parts[0].ComputedETag
+	} else {
+		report.ComputedETag = getCompleteMultipartMD5(completeParts)
+	}
+	if !isETagEqual(report.ETag, report.ComputedETag) {
+		report.Status = madmin.VerifyStatusCorrupt
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestVerifyObjectParts(t *testing.T) {
+	part1 := bytes.Repeat([]byte("a"), 1024)
+	part2 := []byte("hello world")
+	etag1 := getMD5Hash(part1)
+	etag2 := getMD5Hash(part2)
+	multipartETag := getCompleteMultipartMD5([]CompletePart{{ETag: etag1}, {ETag: etag2}})
+	content := append(append([]byte{}, part1...), part2...)
+
+	multipartInfo := func(etag, partETag1 string) ObjectInfo {
+		return ObjectInfo{
+			ETag: etag,
+			Size: int64(len(content)),
+			Parts: []ObjectPartInfo{
+				{Number: 1, ETag: partETag1, Size: int64(len(part1)), ActualSize: int64(len(part1))},
+				{Number: 2, ETag: etag2, Size: int64(len(part2)), ActualSize: int64(len(part2))},
+			},
+		}
+	}
+
+	testCases := []struct {
+		objInfo     ObjectInfo
+		reader      io.Reader
+		status      string
+		partsStatus []string
+		hasError    bool
+	}{
+		// Single part object, intact.
+		{
+			objInfo:     ObjectInfo{ETag: etag2, Size: int64(len(part2))},
+			reader:      bytes.NewReader(part2),
+			status:      madmin.VerifyStatusOK,
+			partsStatus: []string{madmin.VerifyStatusOK},
+		},
+		// Single part object, corrupted.
+		{
+			objInfo:     ObjectInfo{ETag: etag2, Size: int64(len(part2))},
+			reader:      bytes.NewReader([]byte("hello World")),
+			status:      madmin.VerifyStatusCorrupt,
+			partsStatus: []string{madmin.VerifyStatusCorrupt},
+		},
+		// Single part object with an ETag not derived from its MD5.
+		{
+			objInfo:     ObjectInfo{ETag: etag2[:32] + "-1", Size: int64(len(part2))},
+			reader:      bytes.NewReader(part2),
+			status:      madmin.VerifyStatusUnverified,
+			partsStatus: []string{madmin.VerifyStatusUnverified},
+		},
+		// Multipart object, intact.
+		{
+			objInfo:     multipartInfo(multipartETag, etag1),
+			reader:      bytes.NewReader(content),
+			status:      madmin.VerifyStatusOK,
+			partsStatus: []string{madmin.VerifyStatusOK, madmin.VerifyStatusOK},
+		},
+		// Multipart object, second part corrupted.
+		{
+			objInfo:     multipartInfo(multipartETag, etag1),
+			reader:      bytes.NewReader(append(append([]byte{}, part1...), []byte("hello World")...)),
+			status:      madmin.VerifyStatusCorrupt,
+			partsStatus: []string{madmin.VerifyStatusOK, madmin.VerifyStatusCorrupt},
+		},
+		// Multipart object with an ETag not matching its parts.
+		{
+			objInfo:     multipartInfo(etag1+"-2", etag1),
+			reader:      bytes.NewReader(content),
+			status:      madmin.VerifyStatusCorrupt,
+			partsStatus: []string{madmin.VerifyStatusOK, madmin.VerifyStatusOK},
+		},
+		// Multipart object with a part uploaded without its MD5.
+		{
+			objInfo:     multipartInfo(multipartETag, etag1[:32]+"-1"),
+			reader:      bytes.NewReader(content),
+			status:      madmin.VerifyStatusUnverified,
+			partsStatus: []string{madmin.VerifyStatusUnverified, madmin.VerifyStatusOK},
+		},
+		// Multipart object, truncated.
+		{
+			objInfo:     multipartInfo(multipartETag, etag1),
+			reader:      bytes.NewReader(part1[:512]),
+			status:      madmin.VerifyStatusCorrupt,
+			partsStatus: []string{madmin.VerifyStatusCorrupt, madmin.VerifyStatusUnverified},
+			hasError:    true,
+		},
+		// Encrypted objects are not read.
+		{
+			objInfo:     multipartInfo(multipartETag, etag1),
+			status:      madmin.VerifyStatusUnverified,
+			partsStatus: []string{madmin.VerifyStatusUnverified, madmin.VerifyStatusUnverified},
+		},
+	}
+
+	for i, testCase := range testCases {
+		report := madmin.ObjectVerifyReport{ETag: testCase.objInfo.ETag}
+		verifyObjectParts(testCase.reader, testCase.objInfo, &report)
+		if report.Status != testCase.status {
+			t.Errorf("Test %d: expected status %s, got %s", i+1, testCase.status, report.Status)
+		}
+		if len(report.Parts) != len(testCase.partsStatus) {
+			t.Fatalf("Test %d: expected %d parts, got %d", i+1, len(testCase.partsStatus), len(report.Parts))
+		}
+		for j, part := range report.Parts {
+			if part.Status != testCase.partsStatus[j] {
+				t.Errorf("Test %d: expected status %s for part %d, got %s", i+1, testCase.partsStatus[j], part.Number, part.Status)
+			}
+		}
+		if hasError := report.Error != ""; hasError != testCase.hasError {
+			t.Errorf("Test %d: expected error %t, got %q", i+1, testCase.hasError, report.Error)
+		}
+	}
+}
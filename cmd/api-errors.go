@@ -36,6 +36,7 @@ import (
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/hash"
@@ -71,6 +72,7 @@ const (
 	ErrBadDigest
 	ErrEntityTooSmall
 	ErrEntityTooLarge
+	ErrMaxMessageLengthExceeded
 	ErrPolicyTooLarge
 	ErrIncompleteBody
 	ErrInternalError
@@ -85,6 +87,7 @@ const (
 	ErrInvalidMaxUploads
 	ErrInvalidMaxParts
 	ErrInvalidPartNumberMarker
+	ErrInvalidObjectAttributes
 	ErrInvalidRequestBody
 	ErrInvalidCopySource
 	ErrInvalidMetadataDirective
@@ -107,6 +110,7 @@ const (
 	ErrNoSuchKey
 	ErrNoSuchUpload
 	ErrNoSuchVersion
+	ErrInvalidVersionID
 	ErrNotImplemented
 	ErrPreconditionFailed
 	ErrRequestTimeTooSkewed
@@ -150,6 +154,7 @@ const (
 	ErrUnsupportedMetadata
 	ErrMaximumExpires
 	ErrSlowDown
+	ErrOperationAborted
 	ErrInvalidPrefixMarker
 	ErrBadRequest
 	ErrKeyTooLongError
@@ -211,10 +216,18 @@ const (
 	ErrStorageFull
 	ErrRequestBodyParse
 	ErrObjectExistsAsDirectory
+	ErrObjectNameCaseConflict
 	ErrInvalidObjectName
 	ErrInvalidObjectNamePrefixSlash
 	ErrInvalidResourceName
 	ErrServerNotInitialized
+	ErrServerReadOnly
+	ErrServerWriteOnce
+	ErrObjectLeased
+	ErrInvalidLeaseToken
+	ErrInvalidLeaseDuration
+	ErrObjectVersionInSnapshot
+	ErrInvalidListFilter
 	ErrOperationTimedOut
 	ErrOperationMaxedOut
 	ErrInvalidRequest
@@ -240,11 +253,51 @@ const (
 	ErrAdminCredentialsMismatch
 	ErrInsecureClientRequest
 	ErrObjectTampered
+	ErrBucketHookFailed
+	ErrObjectInfected
+	ErrObjectScanFailed
 	// Bucket Quota error codes
 	ErrAdminBucketQuotaExceeded
 	ErrAdminNoSuchQuotaConfiguration
 	ErrAdminBucketQuotaDisabled
 
+	ErrAdminFSMigrationInProgress
+	ErrAdminFSMigrationInvalidPath
+
+	ErrAdminBucketMirrorInProgress
+
+	ErrAdminFaultInjectionDisabled
+	ErrAdminNoSuchBucketMirror
+	ErrAdminBucketMirrorInvalidTarget
+
+	ErrAdminNoSuchBucketSnapshot
+	ErrAdminBucketSnapshotVersioning
+
+	ErrAdminClusterMigrationInProgress
+	ErrAdminNoSuchClusterMigration
+	ErrAdminClusterMigrationInvalidSource
+
+	ErrAdminDecommissionInvalidZone
+	ErrAdminDecommissionInvalidDrive
+	ErrAdminDecommissionInProgress
+	ErrAdminNoSuchDecommission
+
+	ErrAdminNoSuchTenant
+	ErrAdminInvalidTenantName
+	ErrAdminTenantUserInUse
+
+	ErrAdminNoSuchRequest
+
+	ErrAdminNoSuchAuditTrail
+
+	ErrAdminRemoteTargetNotFound
+	ErrAdminRemoteTargetInvalid
+	ErrAdminRemoteTargetInUse
+	ErrReplicationDestinationNotFound
+	ErrBucketReadReplica
+	ErrAdminReadReplicaInvalidSource
+	ErrShareLinkExpired
+
 	ErrHealNotImplemented
 	ErrHealNoSuchProcess
 	ErrHealInvalidClientToken
@@ -418,6 +471,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Argument partNumberMarker must be an integer.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidObjectAttributes: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidPolicyDocument: {
 		Code:           "InvalidPolicyDocument",
 		Description:    "The content of the form does not meet the conditions specified in the policy document.",
@@ -448,6 +506,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Policy exceeds the maximum allowed document size.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrMaxMessageLengthExceeded: {
+		Code:           "MaxMessageLengthExceeded",
+		Description:    "Your request was too big.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrIncompleteBody: {
 		Code:           "IncompleteBody",
 		Description:    "You did not provide the number of bytes specified by the Content-Length HTTP header.",
@@ -535,10 +598,15 @@ var errorCodes = errorCodeMap{
 	},
 	ErrNoSuchUpload: {
 		Code:           "NoSuchUpload",
-		Description:    "The specified multipart upload does not exist. The upload ID may be invalid, or the upload may have been aborted or completed.",
+		Description:    "The specified upload does not exist. The upload ID may be invalid, or the upload may have been aborted or completed.",
 		HTTPStatusCode: http.StatusNotFound,
 	},
 	ErrNoSuchVersion: {
+		Code:           "NoSuchVersion",
+		Description:    "The specified version does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidVersionID: {
 		Code:           "InvalidArgument",
 		Description:    "Invalid version id specified",
 		HTTPStatusCode: http.StatusBadRequest,
@@ -737,9 +805,14 @@ var errorCodes = errorCodeMap{
 	},
 	ErrSlowDown: {
 		Code:           "SlowDown",
-		Description:    "Please reduce your request",
+		Description:    "Please reduce your request rate.",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrOperationAborted: {
+		Code:           "OperationAborted",
+		Description:    "A conflicting conditional operation is currently in progress against this resource. Please try again.",
+		HTTPStatusCode: http.StatusConflict,
+	},
 	ErrInvalidPrefixMarker: {
 		Code:           "InvalidPrefixMarker",
 		Description:    "Invalid marker prefix combination",
@@ -1022,6 +1095,11 @@ var errorCodes = errorCodeMap{
 		Description:    "Object name already exists as a directory.",
 		HTTPStatusCode: http.StatusConflict,
 	},
+	ErrObjectNameCaseConflict: {
+		Code:           "XMinioObjectNameCaseConflict",
+		Description:    "Object name conflicts with an existing object or prefix whose name differs only in case.",
+		HTTPStatusCode: http.StatusConflict,
+	},
 	ErrInvalidObjectName: {
 		Code:           "XMinioInvalidObjectName",
 		Description:    "Object name contains unsupported characters.",
@@ -1042,6 +1120,41 @@ var errorCodes = errorCodeMap{
 		Description:    "Server not initialized, please try again.",
 		HTTPStatusCode: http.StatusServiceUnavailable,
 	},
+	ErrServerReadOnly: {
+		Code:           "XMinioServerReadOnly",
+		Description:    "Server is in read-only mode, buckets and objects cannot be modified.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrServerWriteOnce: {
+		Code:           "XMinioServerWriteOnce",
+		Description:    "Server is in write-once mode, objects cannot be overwritten or deleted.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrObjectLeased: {
+		Code:           "XMinioObjectLeased",
+		Description:    "The object is leased by another client.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrObjectVersionInSnapshot: {
+		Code:           "XMinioObjectVersionInSnapshot",
+		Description:    "The object version is referenced by a bucket snapshot and cannot be deleted.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrInvalidLeaseToken: {
+		Code:           "XMinioInvalidLeaseToken",
+		Description:    "The lease token is not valid for the object or its lease expired.",
+		HTTPStatusCode: http.StatusPreconditionFailed,
+	},
+	ErrInvalidLeaseDuration: {
+		Code:           "XMinioInvalidLeaseDuration",
+		Description:    "The lease duration must be a number of seconds between 1 and 300.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidListFilter: {
+		Code:           "XMinioInvalidListFilter",
+		Description:    "The modification times of the list filter must be RFC 3339 dates and its sizes positive integers, the minimums not above the maximums.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrMalformedJSON: {
 		Code:           "XMinioMalformedJSON",
 		Description:    "The JSON you provided was not well-formed or did not validate against our published format.",
@@ -1133,6 +1246,141 @@ var errorCodes = errorCodeMap{
 		Description:    "Quota specified but disk usage crawl is disabled on MinIO server",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrAdminFaultInjectionDisabled: {
+		Code:           "XMinioAdminFaultInjectionDisabled",
+		Description:    "Fault injection is not enabled, start the servers with MINIO_FAULT_INJECTION=on",
+		HTTPStatusCode: http.StatusNotImplemented,
+	},
+	ErrAdminFSMigrationInProgress: {
+		Code:           "XMinioAdminFSMigrationInProgress",
+		Description:    "A migration of an FS deployment is already in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminFSMigrationInvalidPath: {
+		Code:           "XMinioAdminFSMigrationInvalidPath",
+		Description:    "The specified path is not an FS deployment",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminBucketMirrorInProgress: {
+		Code:           "XMinioAdminBucketMirrorInProgress",
+		Description:    "The bucket mirror is already in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminNoSuchBucketMirror: {
+		Code:           "XMinioAdminNoSuchBucketMirror",
+		Description:    "The specified bucket mirror does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminBucketMirrorInvalidTarget: {
+		Code:           "XMinioAdminBucketMirrorInvalidTarget",
+		Description:    "The mirror target bucket does not exist or is not accessible with the specified credentials",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminNoSuchBucketSnapshot: {
+		Code:           "XMinioAdminNoSuchBucketSnapshot",
+		Description:    "The specified bucket snapshot does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminBucketSnapshotVersioning: {
+		Code:           "XMinioAdminBucketSnapshotVersioning",
+		Description:    "Bucket snapshots require versioning enabled on the bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminClusterMigrationInProgress: {
+		Code:           "XMinioAdminClusterMigrationInProgress",
+		Description:    "The cluster migration is already in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminNoSuchClusterMigration: {
+		Code:           "XMinioAdminNoSuchClusterMigration",
+		Description:    "The specified cluster migration or its report does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminClusterMigrationInvalidSource: {
+		Code:           "XMinioAdminClusterMigrationInvalidSource",
+		Description:    "The migration source is not reachable or its buckets cannot be listed with the specified credentials",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminDecommissionInvalidZone: {
+		Code:           "XMinioAdminDecommissionInvalidZone",
+		Description:    "The specified zone does not exist or is the last zone taking new objects",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminDecommissionInvalidDrive: {
+		Code:           "XMinioAdminDecommissionInvalidDrive",
+		Description:    "The specified drive is not part of the server",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminDecommissionInProgress: {
+		Code:           "XMinioAdminDecommissionInProgress",
+		Description:    "The decommission of the zone is already in progress",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminNoSuchDecommission: {
+		Code:           "XMinioAdminNoSuchDecommission",
+		Description:    "The specified zone is not being decommissioned",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminNoSuchTenant: {
+		Code:           "XMinioAdminNoSuchTenant",
+		Description:    "The specified tenant does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminInvalidTenantName: {
+		Code:           "XMinioAdminInvalidTenantName",
+		Description:    "Tenant names must be 3 to 32 lowercase letters, numbers or hyphens",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminTenantUserInUse: {
+		Code:           "XMinioAdminTenantUserInUse",
+		Description:    "The user already belongs to another tenant",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrAdminNoSuchRequest: {
+		Code:           "XMinioAdminNoSuchRequest",
+		Description:    "The specified request ID was not found on any server, it may be too old",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminNoSuchAuditTrail: {
+		Code:           "XMinioAdminNoSuchAuditTrail",
+		Description:    "The server node has no audit trail for the specified date",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminRemoteTargetNotFound: {
+		Code:           "XMinioAdminRemoteTargetNotFound",
+		Description:    "The specified remote target does not exist",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrAdminRemoteTargetInvalid: {
+		Code:           "XMinioAdminRemoteTargetInvalid",
+		Description:    "The remote target bucket does not exist or is not accessible with the specified credentials",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrAdminRemoteTargetInUse: {
+		Code:           "XMinioAdminRemoteTargetInUse",
+		Description:    "The remote target is the destination of the replication configuration of the bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrReplicationDestinationNotFound: {
+		Code:           "XMinioReplicationDestinationNotFound",
+		Description:    "The destination bucket is not a remote target of the bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrBucketReadReplica: {
+		Code:           "XMinioBucketReadReplica",
+		Description:    "The bucket is a read-only replica, writes must be sent to its source",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrAdminReadReplicaInvalidSource: {
+		Code:           "XMinioAdminReadReplicaInvalidSource",
+		Description:    "The source bucket does not exist or is not accessible with the specified credentials",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrShareLinkExpired: {
+		Code:           "XMinioShareLinkExpired",
+		Description:    "The share link is no longer valid or reached its maximum number of downloads",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrInsecureClientRequest: {
 		Code:           "XMinioInsecureClientRequest",
 		Description:    "Cannot respond to plain-text request from TLS-encrypted server",
@@ -1158,6 +1406,21 @@ var errorCodes = errorCodeMap{
 		Description:    errObjectTampered.Error(),
 		HTTPStatusCode: http.StatusPartialContent,
 	},
+	ErrBucketHookFailed: {
+		Code:           "XMinioBucketHookFailed",
+		Description:    "The hook transforming the object failed",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
+	ErrObjectInfected: {
+		Code:           "XMinioObjectInfected",
+		Description:    "The uploaded content is infected and was rejected",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrObjectScanFailed: {
+		Code:           "XMinioObjectScanFailed",
+		Description:    "The uploaded content could not be scanned for viruses",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrMaximumExpires: {
 		Code:           "AuthorizationQueryParametersError",
 		Description:    "X-Amz-Expires must be less than a week (in seconds); that is, the given X-Amz-Expires must be less than 604800 seconds",
@@ -1688,8 +1951,22 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrEntityTooLarge
 	case errDataTooSmall:
 		apiErr = ErrEntityTooSmall
+	case errXMLBodyTooLarge:
+		apiErr = ErrMaxMessageLengthExceeded
 	case errAuthentication:
 		apiErr = ErrAccessDenied
+	case errServerReadOnly:
+		apiErr = ErrServerReadOnly
+	case errServerWriteOnce:
+		apiErr = ErrServerWriteOnce
+	case errObjectLeased:
+		apiErr = ErrObjectLeased
+	case errInvalidLeaseToken:
+		apiErr = ErrInvalidLeaseToken
+	case errInvalidLeaseDuration:
+		apiErr = ErrInvalidLeaseDuration
+	case errObjectVersionInSnapshot:
+		apiErr = ErrObjectVersionInSnapshot
 	case auth.ErrInvalidAccessKeyLength:
 		apiErr = ErrAdminInvalidAccessKey
 	case auth.ErrInvalidSecretKeyLength:
@@ -1743,6 +2020,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrInvalidDecompressedSize
 	}
 
+	// Antivirus errors
+	switch err {
+	case errObjectScanFailed:
+		apiErr = ErrObjectScanFailed
+	}
+
 	if apiErr != ErrNone {
 		// If there was a match in the above switch case.
 		return apiErr
@@ -1765,6 +2048,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrIncompleteBody
 	case ObjectExistsAsDirectory:
 		apiErr = ErrObjectExistsAsDirectory
+	case ObjectNameCaseConflict:
+		apiErr = ErrObjectNameCaseConflict
 	case PrefixAccessDenied:
 		apiErr = ErrAccessDenied
 	case ParentIsObject:
@@ -1785,8 +2070,16 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchKey
 	case MethodNotAllowed:
 		apiErr = ErrMethodNotAllowed
+	case ObjectMetadataConflict:
+		apiErr = ErrOperationAborted
 	case VersionNotFound:
 		apiErr = ErrNoSuchVersion
+	case InvalidVersionID:
+		apiErr = ErrInvalidVersionID
+	case InvalidObjectState:
+		apiErr = ErrInvalidObjectState
+	case ObjectInfected:
+		apiErr = ErrObjectInfected
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
 	case ObjectNameInvalid:
@@ -1819,6 +2112,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
 		apiErr = ErrEntityTooSmall
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
 	case NotImplemented:
 		apiErr = ErrNotImplemented
 	case PartTooBig:
@@ -1833,12 +2128,20 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
+	case BucketReplicationConfigNotFound:
+		apiErr = ErrReplicationConfigurationNotFoundError
+	case BucketRemoteTargetNotFound:
+		apiErr = ErrAdminRemoteTargetNotFound
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
 		apiErr = ErrAdminNoSuchQuotaConfiguration
 	case BucketQuotaExceeded:
 		apiErr = ErrAdminBucketQuotaExceeded
+	case BucketReadReplica:
+		apiErr = ErrBucketReadReplica
+	case ShareLinkExpired:
+		apiErr = ErrShareLinkExpired
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -1922,6 +2225,13 @@ func toAPIError(ctx context.Context, err error) APIError {
 					e.Error()),
 				HTTPStatusCode: errorCodes[ErrMalformedXML].HTTPStatusCode,
 			}
+		case xmlDecodeError:
+			apiErr = APIError{
+				Code: "MalformedXML",
+				Description: fmt.Sprintf("%s (%s)", errorCodes[ErrMalformedXML].Description,
+					e.Error()),
+				HTTPStatusCode: errorCodes[ErrMalformedXML].HTTPStatusCode,
+			}
 		case url.EscapeError:
 			apiErr = APIError{
 				Code: "XMinioInvalidObjectName",
@@ -1941,6 +2251,12 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case replication.Error:
+			apiErr = APIError{
+				Code:           "InvalidRequest",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case tags.Error:
 			apiErr = APIError{
 				Code:           e.Code(),
@@ -2008,8 +2324,53 @@ func getAPIErrorResponse(ctx context.Context, err APIError, resource, requestID,
 		BucketName: reqInfo.BucketName,
 		Key:        reqInfo.ObjectName,
 		Resource:   resource,
-		Region:     globalServerRegion,
+		Region:     getBucketRegion(reqInfo.BucketName),
 		RequestID:  requestID,
 		HostID:     hostID,
 	}
 }
+
+// S3 error codes returned for the MinIO specific error codes when the
+// API is configured with strict S3 errors, by HTTP status code. Any
+// other status code is returned as an InternalError.
+var strictS3ErrorCodes = map[int]string{
+	http.StatusBadRequest:         "InvalidArgument",
+	http.StatusForbidden:          "AccessDenied",
+	http.StatusNotFound:           "NoSuchKey",
+	http.StatusConflict:           "OperationAborted",
+	http.StatusServiceUnavailable: "ServiceUnavailable",
+}
+
+// toStrictS3Error - converts an error with a MinIO specific error code
+// to the closest S3 error, S3 errors are returned unchanged.
+func toStrictS3Error(err APIError) APIError {
+	if !strings.HasPrefix(err.Code, "XMinio") {
+		return err
+	}
+	code, ok := strictS3ErrorCodes[err.HTTPStatusCode]
+	if !ok {
+		return errorCodes.ToAPIErr(ErrInternalError)
+	}
+	err.Code = code
+	return err
+}
+
+// toStrictS3ErrorResponse - removes the elements of an error response
+// which S3 doesn't send for its error code, S3 only sends the bucket
+// or the object name for the errors about missing buckets or objects.
+func toStrictS3ErrorResponse(resp APIErrorResponse) APIErrorResponse {
+	switch resp.Code {
+	case "NoSuchKey":
+		resp.BucketName = ""
+	case "NoSuchBucket", "NoSuchBucketPolicy", "BucketAlreadyExists",
+		"BucketAlreadyOwnedByYou", "BucketNotEmpty":
+		resp.Key = ""
+	default:
+		resp.BucketName = ""
+		resp.Key = ""
+	}
+	if resp.Code != "AuthorizationHeaderMalformed" {
+		resp.Region = ""
+	}
+	return resp
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
@@ -40,6 +41,8 @@ var toAPIErrorTests = []struct {
 	{err: ObjectNotFound{}, errCode: ErrNoSuchKey},
 	{err: ObjectNameInvalid{}, errCode: ErrInvalidObjectName},
 	{err: InvalidUploadID{}, errCode: ErrNoSuchUpload},
+	{err: VersionNotFound{}, errCode: ErrNoSuchVersion},
+	{err: InvalidVersionID{}, errCode: ErrInvalidVersionID},
 	{err: InvalidPart{}, errCode: ErrInvalidPart},
 	{err: InsufficientReadQuorum{}, errCode: ErrSlowDown},
 	{err: InsufficientWriteQuorum{}, errCode: ErrSlowDown},
@@ -80,3 +83,35 @@ func TestAPIErrCode(t *testing.T) {
 		}
 	}
 }
+
+func TestToStrictS3Error(t *testing.T) {
+	testCases := []struct {
+		errCode APIErrorCode
+		code    string
+		status  int
+	}{
+		{ErrNoSuchKey, "NoSuchKey", http.StatusNotFound},
+		{ErrNoSuchVersion, "NoSuchVersion", http.StatusNotFound},
+		{ErrInvalidObjectName, "InvalidArgument", http.StatusBadRequest},
+		{ErrObjectExistsAsDirectory, "OperationAborted", http.StatusConflict},
+		{ErrServerNotInitialized, "ServiceUnavailable", http.StatusServiceUnavailable},
+		{ErrServerReadOnly, "AccessDenied", http.StatusForbidden},
+		{ErrStorageFull, "InternalError", http.StatusInternalServerError},
+		{ErrBucketHookFailed, "InternalError", http.StatusInternalServerError},
+	}
+	for i, testCase := range testCases {
+		apiErr := toStrictS3Error(errorCodes.ToAPIErr(testCase.errCode))
+		if apiErr.Code != testCase.code || apiErr.HTTPStatusCode != testCase.status {
+			t.Errorf("Test %d: expected %s (%d), got %s (%d)", i+1, testCase.code, testCase.status, apiErr.Code, apiErr.HTTPStatusCode)
+		}
+	}
+
+	resp := APIErrorResponse{Code: "NoSuchKey", BucketName: "bucket", Key: "object", Region: "us-east-1"}
+	if resp = toStrictS3ErrorResponse(resp); resp.Key != "object" || resp.BucketName != "" || resp.Region != "" {
+		t.Errorf("Unexpected NoSuchKey response %#v", resp)
+	}
+	resp = APIErrorResponse{Code: "NoSuchBucket", BucketName: "bucket", Key: "object", Region: "us-east-1"}
+	if resp = toStrictS3ErrorResponse(resp); resp.Key != "" || resp.BucketName != "bucket" || resp.Region != "" {
+		t.Errorf("Unexpected NoSuchBucket response %#v", resp)
+	}
+}
@@ -18,6 +18,9 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -25,6 +28,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/minio/cmd/crypto"
@@ -32,10 +36,41 @@ import (
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 )
 
+// lastRequestID is the most recently generated request ID, request IDs
+// are strictly increasing so that concurrent requests never share one.
+var lastRequestID int64
+
 // Returns a hexadecimal representation of time at the
-// time response is sent to the client.
+// time request is received, unique for this node.
 func mustGetRequestID(t time.Time) string {
-	return fmt.Sprintf("%X", t.UnixNano())
+	id := t.UnixNano()
+	for {
+		last := atomic.LoadInt64(&lastRequestID)
+		if id <= last {
+			id = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastRequestID, last, id) {
+			break
+		}
+	}
+	return fmt.Sprintf("%X", id)
+}
+
+// requestHostID caches the x-amz-id-2 value along with the
+// deployment ID it was computed for.
+var requestHostID atomic.Value
+
+// Returns the x-amz-id-2 value identifying this node, it is derived
+// from the deployment ID and the address of the local peer.
+func mustGetRequestHostID() string {
+	deploymentID := globalDeploymentID
+	if v, ok := requestHostID.Load().([2]string); ok && v[0] == deploymentID {
+		return v[1]
+	}
+	sum := sha256.Sum256([]byte(deploymentID + GetLocalPeer(globalEndpoints)))
+	hostID := base64.StdEncoding.EncodeToString(sum[:])
+	requestHostID.Store([2]string{deploymentID, hostID})
+	return hostID
 }
 
 // Write http common headers
@@ -69,11 +104,35 @@ func encodeResponseJSON(response interface{}) []byte {
 	return bytesBuffer.Bytes()
 }
 
-// Write parts count
+// setPartsCountHeaders sets the number of parts of objects uploaded by
+// multipart uploads. Objects uploaded by a single PUT, which have one
+// part without an ETag, have no parts count.
 func setPartsCountHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
-	if strings.Contains(objInfo.ETag, "-") && len(objInfo.Parts) > 0 {
-		w.Header()[xhttp.AmzMpPartsCount] = []string{strconv.Itoa(len(objInfo.Parts))}
+	if !isMultipartETag(objInfo.ETag) || len(objInfo.Parts) == 0 {
+		return
+	}
+	if len(objInfo.Parts) == 1 && objInfo.Parts[0].ETag == "" {
+		return
 	}
+	w.Header()[xhttp.AmzMpPartsCount] = []string{strconv.Itoa(len(objInfo.Parts))}
+}
+
+// setChecksumHeaders sets the Content-MD5 of whole objects whose ETag is
+// the MD5 of their content, if the client asks for checksums.
+func setChecksumHeaders(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo) {
+	if !strings.EqualFold(r.Header.Get(xhttp.AmzChecksumMode), "ENABLED") {
+		return
+	}
+	// The ETags of SSE-C objects are derived from their sealed MD5 and
+	// the ETags of compressed objects from their compressed content.
+	if crypto.SSEC.IsEncrypted(objInfo.UserDefined) || objInfo.IsCompressed() || !isMD5ETag(objInfo.ETag) {
+		return
+	}
+	md5sum, err := hex.DecodeString(canonicalizeETag(objInfo.ETag))
+	if err != nil {
+		return
+	}
+	w.Header().Set(xhttp.ContentMD5, base64.StdEncoding.EncodeToString(md5sum))
 }
 
 // Write object header
@@ -158,6 +217,14 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
 	}
 
+	// Transitioned objects are in the storage class of their remote tier.
+	if objInfo.TransitionStatus != "" {
+		w.Header()[xhttp.AmzStorageClass] = []string{objInfo.StorageClass}
+		if restore := transitionedObjectRestoreHeader(objInfo); restore != "" {
+			w.Header()[xhttp.AmzRestore] = []string{restore}
+		}
+	}
+
 	if lc, err := globalLifecycleSys.Get(objInfo.Bucket); err == nil {
 		ruleID, expiryTime := lc.PredictExpiryTime(lifecycle.ObjectOpts{
 			Name:         objInfo.Name,
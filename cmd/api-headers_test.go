@@ -38,3 +38,16 @@ func TestNewRequestID(t *testing.T) {
 		}
 	}
 }
+
+// Tests that request IDs generated at the same time are unique.
+func TestNewRequestIDUnique(t *testing.T) {
+	now := UTCNow()
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		id := mustGetRequestID(now)
+		if _, ok := seen[id]; ok {
+			t.Fatalf("Request ID %s was generated twice", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
@@ -18,8 +18,12 @@ package cmd
 
 import (
 	"encoding/base64"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Parse bucket url queries
@@ -36,8 +40,8 @@ func getListObjectsV1Args(values url.Values) (prefix, marker, delimiter string,
 		maxkeys = maxObjectList
 	}
 
-	prefix = values.Get("prefix")
-	marker = values.Get("marker")
+	prefix = normalizeObjectName(values.Get("prefix"))
+	marker = normalizeObjectName(values.Get("marker"))
 	delimiter = values.Get("delimiter")
 	encodingType = values.Get("encoding-type")
 	return
@@ -56,8 +60,8 @@ func getListBucketObjectVersionsArgs(values url.Values) (prefix, marker, delimit
 		maxkeys = maxObjectList
 	}
 
-	prefix = values.Get("prefix")
-	marker = values.Get("key-marker")
+	prefix = normalizeObjectName(values.Get("prefix"))
+	marker = normalizeObjectName(values.Get("key-marker"))
 	delimiter = values.Get("delimiter")
 	encodingType = values.Get("encoding-type")
 	versionIDMarker = values.Get("version-id-marker")
@@ -86,8 +90,8 @@ func getListObjectsV2Args(values url.Values) (prefix, token, startAfter, delimit
 		maxkeys = maxObjectList
 	}
 
-	prefix = values.Get("prefix")
-	startAfter = values.Get("start-after")
+	prefix = normalizeObjectName(values.Get("prefix"))
+	startAfter = normalizeObjectName(values.Get("start-after"))
 	delimiter = values.Get("delimiter")
 	fetchOwner = values.Get("fetch-owner") == "true"
 	encodingType = values.Get("encoding-type")
@@ -117,8 +121,8 @@ func getBucketMultipartResources(values url.Values) (prefix, keyMarker, uploadID
 		maxUploads = maxUploadsList
 	}
 
-	prefix = values.Get("prefix")
-	keyMarker = values.Get("key-marker")
+	prefix = normalizeObjectName(values.Get("prefix"))
+	keyMarker = normalizeObjectName(values.Get("key-marker"))
 	uploadIDMarker = values.Get("upload-id-marker")
 	delimiter = values.Get("delimiter")
 	encodingType = values.Get("encoding-type")
@@ -150,3 +154,45 @@ func getObjectResources(values url.Values) (uploadID string, partNumberMarker, m
 	encodingType = values.Get("encoding-type")
 	return
 }
+
+// Parse object headers for ?attributes
+func getObjectAttributesArgs(header http.Header) (attributes map[string]bool, partNumberMarker, maxParts int, errCode APIErrorCode) {
+	var err error
+	errCode = ErrNone
+
+	attributes = make(map[string]bool)
+	for _, value := range header.Values(xhttp.AmzObjectAttributes) {
+		for _, attribute := range strings.Split(value, ",") {
+			attribute = strings.TrimSpace(attribute)
+			switch attribute {
+			case objectAttributeETag, objectAttributeChecksum, objectAttributeObjectParts,
+				objectAttributeStorageClass, objectAttributeObjectSize:
+				attributes[attribute] = true
+			default:
+				errCode = ErrInvalidObjectAttributes
+				return
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		errCode = ErrInvalidObjectAttributes
+		return
+	}
+
+	if header.Get(xhttp.AmzMaxParts) != "" {
+		if maxParts, err = strconv.Atoi(header.Get(xhttp.AmzMaxParts)); err != nil || maxParts < 0 {
+			errCode = ErrInvalidMaxParts
+			return
+		}
+	} else {
+		maxParts = maxPartsList
+	}
+
+	if header.Get(xhttp.AmzPartNumberMarker) != "" {
+		if partNumberMarker, err = strconv.Atoi(header.Get(xhttp.AmzPartNumberMarker)); err != nil || partNumberMarker < 0 {
+			errCode = ErrInvalidPartNumberMarker
+			return
+		}
+	}
+	return
+}
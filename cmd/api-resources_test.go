@@ -17,8 +17,11 @@
 package cmd
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Test list objects resources V2.
@@ -219,3 +222,82 @@ func TestGetObjectsResources(t *testing.T) {
 		}
 	}
 }
+
+// Tests getObjectAttributesArgs
+func TestGetObjectAttributesArgs(t *testing.T) {
+	testCases := []struct {
+		header           http.Header
+		attributes       []string
+		partNumberMarker int
+		maxParts         int
+		errCode          APIErrorCode
+	}{
+		{
+			header: http.Header{
+				xhttp.AmzObjectAttributes: []string{"ETag, ObjectParts", "ObjectSize"},
+				xhttp.AmzMaxParts:         []string{"10"},
+				xhttp.AmzPartNumberMarker: []string{"2"},
+			},
+			attributes:       []string{"ETag", "ObjectParts", "ObjectSize"},
+			partNumberMarker: 2,
+			maxParts:         10,
+			errCode:          ErrNone,
+		},
+		{
+			header: http.Header{
+				xhttp.AmzObjectAttributes: []string{"StorageClass"},
+			},
+			attributes: []string{"StorageClass"},
+			maxParts:   maxPartsList,
+			errCode:    ErrNone,
+		},
+		{
+			header:  http.Header{},
+			errCode: ErrInvalidObjectAttributes,
+		},
+		{
+			header: http.Header{
+				xhttp.AmzObjectAttributes: []string{"ETag,Owner"},
+			},
+			errCode: ErrInvalidObjectAttributes,
+		},
+		{
+			header: http.Header{
+				xhttp.AmzObjectAttributes: []string{"ETag"},
+				xhttp.AmzMaxParts:         []string{"-1"},
+			},
+			errCode: ErrInvalidMaxParts,
+		},
+		{
+			header: http.Header{
+				xhttp.AmzObjectAttributes: []string{"ETag"},
+				xhttp.AmzPartNumberMarker: []string{"a"},
+			},
+			errCode: ErrInvalidPartNumberMarker,
+		},
+	}
+
+	for i, testCase := range testCases {
+		attributes, partNumberMarker, maxParts, errCode := getObjectAttributesArgs(testCase.header)
+		if errCode != testCase.errCode {
+			t.Fatalf("Test %d: Expected error %v, got %v", i+1, testCase.errCode, errCode)
+		}
+		if errCode != ErrNone {
+			continue
+		}
+		if len(attributes) != len(testCase.attributes) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.attributes, attributes)
+		}
+		for _, attribute := range testCase.attributes {
+			if !attributes[attribute] {
+				t.Errorf("Test %d: Expected attribute %s", i+1, attribute)
+			}
+		}
+		if partNumberMarker != testCase.partNumberMarker {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.partNumberMarker, partNumberMarker)
+		}
+		if maxParts != testCase.maxParts {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.maxParts, maxParts)
+		}
+	}
+}
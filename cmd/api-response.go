@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/xml"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
@@ -300,6 +301,9 @@ type Object struct {
 
 	// UserMetadata user-defined metadata
 	UserMetadata StringMap `xml:"UserMetadata,omitempty"`
+
+	// UserTags the tags of the object, URL encoded.
+	UserTags string `xml:"UserTags,omitempty"`
 }
 
 // CopyObjectResponse container returns ETag and LastModified of the successfully copied object
@@ -316,6 +320,45 @@ type CopyObjectPartResponse struct {
 	ETag         string   // md5sum of the copied object part.
 }
 
+// Object attributes returned by GetObjectAttributes.
+const (
+	objectAttributeETag         = "ETag"
+	objectAttributeChecksum     = "Checksum"
+	objectAttributeObjectParts  = "ObjectParts"
+	objectAttributeStorageClass = "StorageClass"
+	objectAttributeObjectSize   = "ObjectSize"
+)
+
+// GetObjectAttributesResponse container for GetObjectAttributes response.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                    `xml:",omitempty"`
+	ObjectParts  *GetObjectAttributesParts `xml:",omitempty"`
+	StorageClass string                    `xml:",omitempty"`
+	ObjectSize   *int64                    `xml:",omitempty"`
+}
+
+// GetObjectAttributesParts container for the parts of a multipart object.
+type GetObjectAttributesParts struct {
+	PartsCount           int
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+
+	// List of parts, along with their ETag which is not sent by
+	// AWS S3, the ETag of the object is the MD5 of their MD5 sums.
+	Parts []GetObjectAttributesPart `xml:"Part"`
+}
+
+// GetObjectAttributesPart container for a part of a multipart object.
+type GetObjectAttributesPart struct {
+	PartNumber int
+	Size       int64
+	ETag       string
+}
+
 // Initiator inherit from Owner struct, fields are same
 type Initiator Owner
 
@@ -395,12 +438,15 @@ func getObjectLocation(r *http.Request, domains []string, bucket, object string)
 		Scheme: proto,
 	}
 	// If domain is set then we need to use bucket DNS style.
-	for _, domain := range domains {
-		if strings.Contains(r.Host, domain) {
-			u.Host = bucket + "." + r.Host
-			u.Path = path.Join(SlashSeparator, object)
-			break
-		}
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if isDomainHost(host, domains) {
+		u.Host = bucket + "." + r.Host
+		u.Path = path.Join(SlashSeparator, object)
+	} else if _, ok := virtualHostBucket(host, domains); ok {
+		u.Path = path.Join(SlashSeparator, object)
 	}
 	return u.String()
 }
@@ -482,8 +528,24 @@ func generateListVersionsResponse(bucket, prefix, marker, versionIDMarker, delim
 	return data
 }
 
+// listObjectMetadata returns the user metadata and the tags of a listed
+// object. The listing reads them along with the rest of the object
+// metadata, clients asking for them save a HEAD request per object.
+func listObjectMetadata(object ObjectInfo) (StringMap, string) {
+	userMetadata := make(StringMap)
+	for k, v := range CleanMinioInternalMetadataKeys(object.UserDefined) {
+		if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			// Do not need to send any internal metadata
+			// values to client.
+			continue
+		}
+		userMetadata[k] = v
+	}
+	return userMetadata, object.UserTags
+}
+
 // generates an ListObjectsV1 response for the said bucket with other enumerated options.
-func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType string, maxKeys int, resp ListObjectsInfo) ListObjectsResponse {
+func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType string, maxKeys int, resp ListObjectsInfo, metadata bool) ListObjectsResponse {
 	var contents []Object
 	var prefixes []CommonPrefix
 	var owner = Owner{}
@@ -507,6 +569,9 @@ func generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingTy
 			content.StorageClass = globalMinioDefaultStorageClass
 		}
 		content.Owner = owner
+		if metadata {
+			content.UserMetadata, content.UserTags = listObjectMetadata(object)
+		}
 		contents = append(contents, content)
 	}
 	data.Name = bucket
@@ -558,15 +623,7 @@ func generateListObjectsV2Response(bucket, prefix, token, nextToken, startAfter,
 		}
 		content.Owner = owner
 		if metadata {
-			content.UserMetadata = make(StringMap)
-			for k, v := range CleanMinioInternalMetadataKeys(object.UserDefined) {
-				if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
-					// Do not need to send any internal metadata
-					// values to client.
-					continue
-				}
-				content.UserMetadata[k] = v
-			}
+			content.UserMetadata, content.UserTags = listObjectMetadata(object)
 		}
 		contents = append(contents, content)
 	}
@@ -654,6 +711,50 @@ func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) Lis
 	return listPartsResponse
 }
 
+// generates GetObjectAttributesResponse with the requested attributes of
+// the object, the parts of multipart objects are listed after partNumberMarker.
+func generateGetObjectAttributesResponse(objInfo ObjectInfo, size int64, attributes map[string]bool, partNumberMarker, maxParts int) GetObjectAttributesResponse {
+	response := GetObjectAttributesResponse{}
+	if attributes[objectAttributeETag] {
+		response.ETag = objInfo.ETag
+	}
+	if attributes[objectAttributeStorageClass] {
+		response.StorageClass = objInfo.StorageClass
+		if response.StorageClass == "" {
+			response.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+	if attributes[objectAttributeObjectSize] {
+		response.ObjectSize = &size
+	}
+	// Objects uploaded by a single PUT have no parts.
+	if !attributes[objectAttributeObjectParts] || !isMultipartETag(objInfo.ETag) || len(objInfo.Parts) == 0 {
+		return response
+	}
+
+	response.ObjectParts = &GetObjectAttributesParts{
+		PartsCount:       len(objInfo.Parts),
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+	}
+	for _, part := range objInfo.Parts {
+		if part.Number <= partNumberMarker {
+			continue
+		}
+		if len(response.ObjectParts.Parts) == maxParts {
+			response.ObjectParts.IsTruncated = true
+			break
+		}
+		response.ObjectParts.Parts = append(response.ObjectParts.Parts, GetObjectAttributesPart{
+			PartNumber: part.Number,
+			Size:       part.ActualSize,
+			ETag:       part.ETag,
+		})
+		response.ObjectParts.NextPartNumberMarker = part.Number
+	}
+	return response
+}
+
 // generates ListMultipartUploadsResponse for given bucket and ListMultipartsInfo.
 func generateListMultipartUploadsResponse(bucket string, multipartsInfo ListMultipartsInfo, encodingType string) ListMultipartUploadsResponse {
 	listMultipartUploadsResponse := ListMultipartUploadsResponse{}
@@ -755,8 +856,13 @@ func writeSuccessResponseHeadersOnly(w http.ResponseWriter) {
 
 // writeErrorRespone writes error headers
 func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL, browser bool) {
+	strict := globalAPIConfig.isStrictErrors()
+	if strict {
+		err = toStrictS3Error(err)
+	}
+
 	switch err.Code {
-	case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
+	case "SlowDown", "ServiceUnavailable", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
 		// Set retry-after header to indicate user-agents to retry request after 120secs.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
 		w.Header().Set(xhttp.RetryAfter, "120")
@@ -776,12 +882,18 @@ func writeErrorResponse(ctx context.Context, w http.ResponseWriter, err APIError
 
 	// Generate error response.
 	errorResponse := getAPIErrorResponse(ctx, err, reqURL.Path,
-		w.Header().Get(xhttp.AmzRequestID), globalDeploymentID)
+		w.Header().Get(xhttp.AmzRequestID), w.Header().Get(xhttp.AmzRequestHostID))
+	if strict {
+		errorResponse = toStrictS3ErrorResponse(errorResponse)
+	}
 	encodedErrorResponse := encodeResponse(errorResponse)
 	writeResponse(w, err.HTTPStatusCode, encodedErrorResponse, mimeXML)
 }
 
 func writeErrorResponseHeadersOnly(w http.ResponseWriter, err APIError) {
+	if globalAPIConfig.isStrictErrors() {
+		err = toStrictS3Error(err)
+	}
 	writeResponse(w, err.HTTPStatusCode, nil, mimeNone)
 }
 
@@ -794,7 +906,7 @@ func writeErrorResponseString(ctx context.Context, w http.ResponseWriter, err AP
 // useful for admin APIs.
 func writeErrorResponseJSON(ctx context.Context, w http.ResponseWriter, err APIError, reqURL *url.URL) {
 	// Generate error response.
-	errorResponse := getAPIErrorResponse(ctx, err, reqURL.Path, w.Header().Get(xhttp.AmzRequestID), globalDeploymentID)
+	errorResponse := getAPIErrorResponse(ctx, err, reqURL.Path, w.Header().Get(xhttp.AmzRequestID), w.Header().Get(xhttp.AmzRequestHostID))
 	encodedErrorResponse := encodeResponseJSON(errorResponse)
 	writeResponse(w, err.HTTPStatusCode, encodedErrorResponse, mimeJSON)
 }
@@ -813,7 +925,7 @@ func writeCustomErrorResponseJSON(ctx context.Context, w http.ResponseWriter, er
 		BucketName: reqInfo.BucketName,
 		Key:        reqInfo.ObjectName,
 		RequestID:  w.Header().Get(xhttp.AmzRequestID),
-		HostID:     globalDeploymentID,
+		HostID:     w.Header().Get(xhttp.AmzRequestHostID),
 	}
 	encodedErrorResponse := encodeResponseJSON(errorResponse)
 	writeResponse(w, err.HTTPStatusCode, encodedErrorResponse, mimeJSON)
@@ -823,9 +935,13 @@ func writeCustomErrorResponseJSON(ctx context.Context, w http.ResponseWriter, er
 // but accepts the error message directly (this allows messages to be
 // dynamically generated.)
 func writeCustomErrorResponseXML(ctx context.Context, w http.ResponseWriter, err APIError, errBody string, reqURL *url.URL, browser bool) {
+	strict := globalAPIConfig.isStrictErrors()
+	if strict {
+		err = toStrictS3Error(err)
+	}
 
 	switch err.Code {
-	case "SlowDown", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
+	case "SlowDown", "ServiceUnavailable", "XMinioServerNotInitialized", "XMinioReadQuorum", "XMinioWriteQuorum":
 		// Set retry-after header to indicate user-agents to retry request after 120secs.
 		// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Retry-After
 		w.Header().Set(xhttp.RetryAfter, "120")
@@ -847,7 +963,10 @@ func writeCustomErrorResponseXML(ctx context.Context, w http.ResponseWriter, err
 		BucketName: reqInfo.BucketName,
 		Key:        reqInfo.ObjectName,
 		RequestID:  w.Header().Get(xhttp.AmzRequestID),
-		HostID:     globalDeploymentID,
+		HostID:     w.Header().Get(xhttp.AmzRequestHostID),
+	}
+	if strict {
+		errorResponse = toStrictS3ErrorResponse(errorResponse)
 	}
 
 	encodedErrorResponse := encodeResponse(errorResponse)
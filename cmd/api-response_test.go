@@ -18,7 +18,10 @@ package cmd
 
 import (
 	"net/http"
+	"strings"
 	"testing"
+
+	humanize "github.com/dustin/go-humanize"
 )
 
 // Tests object location.
@@ -97,6 +100,28 @@ func TestObjectLocation(t *testing.T) {
 			object:           "test/1.txt",
 			expectedLocation: "https://mybucket.mys3.bucket.org/test/1.txt",
 		},
+		// Virtual-host-style request.
+		{
+			request: &http.Request{
+				Host:   "mybucket.mys3.bucket.org:9000",
+				Header: map[string][]string{},
+			},
+			domains:          []string{"mys3.bucket.org"},
+			bucket:           "mybucket",
+			object:           "test/1.txt",
+			expectedLocation: "http://mybucket.mys3.bucket.org:9000/test/1.txt",
+		},
+		// Virtual-host-style request to a wildcard domain.
+		{
+			request: &http.Request{
+				Host:   "mybucket.s3.us-east-1.bucket.org",
+				Header: map[string][]string{},
+			},
+			domains:          []string{"s3.*.bucket.org"},
+			bucket:           "mybucket",
+			object:           "test/1.txt",
+			expectedLocation: "http://mybucket.s3.us-east-1.bucket.org/test/1.txt",
+		},
 	}
 	for i, testCase := range testCases {
 		gotLocation := getObjectLocation(testCase.request, testCase.domains, testCase.bucket, testCase.object)
@@ -119,3 +144,83 @@ func TestGetURLScheme(t *testing.T) {
 		t.Errorf("Expected %s, got %s", httpsScheme, gotScheme)
 	}
 }
+
+// Tests the parts listed in GetObjectAttributes responses.
+func TestGenerateGetObjectAttributesResponse(t *testing.T) {
+	parts := []ObjectPartInfo{
+		{Number: 1, ETag: "e1fd0d8cf6b0d4a5ef8a6bd8e3e6ae25", Size: 5 * humanize.MiByte, ActualSize: 5 * humanize.MiByte},
+		{Number: 2, ETag: "7b8c1e0a0e1a4b54ffb2a9b6d1a85d8c", Size: 5 * humanize.MiByte, ActualSize: 5 * humanize.MiByte},
+		{Number: 3, ETag: "0a1c6f4e7df5e2c77e8a0d2bd1b09e3f", Size: humanize.MiByte, ActualSize: humanize.MiByte},
+	}
+	var completeParts []CompletePart
+	for _, part := range parts {
+		completeParts = append(completeParts, CompletePart{PartNumber: part.Number, ETag: part.ETag})
+	}
+	objInfo := ObjectInfo{
+		ETag:  getCompleteMultipartMD5(completeParts),
+		Size:  11 * humanize.MiByte,
+		Parts: parts,
+	}
+	attributes := map[string]bool{objectAttributeETag: true, objectAttributeObjectParts: true}
+
+	response := generateGetObjectAttributesResponse(objInfo, objInfo.Size, attributes, 0, 2)
+	if response.ETag != objInfo.ETag || !strings.HasSuffix(response.ETag, "-3") {
+		t.Fatalf("Expected ETag %s, got %s", objInfo.ETag, response.ETag)
+	}
+	if response.ObjectSize != nil || response.StorageClass != "" {
+		t.Fatalf("Expected only the requested attributes, got %+v", response)
+	}
+	if response.ObjectParts == nil || response.ObjectParts.PartsCount != 3 {
+		t.Fatalf("Expected 3 parts, got %+v", response.ObjectParts)
+	}
+	if !response.ObjectParts.IsTruncated || response.ObjectParts.NextPartNumberMarker != 2 || len(response.ObjectParts.Parts) != 2 {
+		t.Fatalf("Expected the first 2 parts, got %+v", response.ObjectParts)
+	}
+
+	response = generateGetObjectAttributesResponse(objInfo, objInfo.Size, attributes, 2, 2)
+	if response.ObjectParts.IsTruncated || len(response.ObjectParts.Parts) != 1 {
+		t.Fatalf("Expected the last part, got %+v", response.ObjectParts)
+	}
+	if part := response.ObjectParts.Parts[0]; part.PartNumber != 3 || part.ETag != parts[2].ETag || part.Size != humanize.MiByte {
+		t.Fatalf("Expected part 3, got %+v", part)
+	}
+
+	// Objects uploaded by a single PUT have no parts.
+	objInfo.ETag = parts[0].ETag
+	response = generateGetObjectAttributesResponse(objInfo, objInfo.Size, attributes, 0, 2)
+	if response.ObjectParts != nil {
+		t.Fatalf("Expected no parts, got %+v", response.ObjectParts)
+	}
+}
+
+func TestGenerateListObjectsMetadata(t *testing.T) {
+	objects := []ObjectInfo{{
+		Bucket: "bucket",
+		Name:   "object",
+		UserDefined: map[string]string{
+			"X-Amz-Meta-Color":                     "blue",
+			"Content-Type":                         "text/plain",
+			ReservedMetadataPrefix + "compression": "s2",
+		},
+		UserTags: "key=value",
+	}}
+
+	response := generateListObjectsV1Response("bucket", "", "", "", "", 1000, ListObjectsInfo{Objects: objects}, false)
+	if content := response.Contents[0]; content.UserMetadata != nil || content.UserTags != "" {
+		t.Fatalf("Expected no metadata, got %+v", content)
+	}
+
+	response = generateListObjectsV1Response("bucket", "", "", "", "", 1000, ListObjectsInfo{Objects: objects}, true)
+	content := response.Contents[0]
+	if content.UserTags != "key=value" {
+		t.Fatalf("Expected the tags, got %q", content.UserTags)
+	}
+	if len(content.UserMetadata) != 2 || content.UserMetadata["X-Amz-Meta-Color"] != "blue" {
+		t.Fatalf("Expected the user metadata only, got %v", content.UserMetadata)
+	}
+
+	responseV2 := generateListObjectsV2Response("bucket", "", "", "", "", "", "", false, false, 1000, objects, nil, true)
+	if content := responseV2.Contents[0]; content.UserTags != "key=value" || content.UserMetadata["X-Amz-Meta-Color"] != "blue" {
+		t.Fatalf("Expected the metadata, got %+v", content)
+	}
+}
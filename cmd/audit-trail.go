@@ -0,0 +1,681 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	envAuditTrail = "MINIO_AUDIT_TRAIL"
+
+	// The audit trails are saved under this prefix of the minio meta
+	// bucket, which is not reachable with the S3 API, one directory per
+	// day and per node holding a segment of the trail per append.
+	auditTrailPrefix = "audit-trail"
+
+	auditTrailDateFormat = "2006-01-02"
+
+	// The keys of the hashes of the records and the checkpoints of the
+	// trails are saved with the config, apart from the trails, and are
+	// encrypted along with the config.
+	auditTrailKeysPrefix        = minioConfigPrefix + "/audit-trail/keys"
+	auditTrailCheckpointsPrefix = minioConfigPrefix + "/audit-trail/checkpoints"
+
+	// auditTrailFlushInterval is the interval between the appends of
+	// the audit entries of a node to its trail.
+	auditTrailFlushInterval = 10 * time.Second
+
+	// auditTrailMaxPending is the number of audit entries waiting to
+	// be appended, the entries beyond are dropped and the number of
+	// dropped entries is recorded in the trail instead.
+	auditTrailMaxPending = 100000
+)
+
+var (
+	errAuditTrailFull       = errors.New("audit trail has too many entries waiting to be saved")
+	errAuditTrailMalformed  = errors.New("audit trail ends with a malformed record")
+	errAuditTrailNoKey      = errors.New("audit trail key not found")
+	errAuditTrailCheckpoint = errors.New("checkpoint was modified")
+)
+
+// auditTrailRecord is a line of an audit trail. Every record holds the
+// hash of the record before it, the first record of a day the hash of
+// the last record of the previous day, so that a record modified or
+// removed afterwards breaks the chain. The hashes are keyed with a key
+// held by the servers, the chain can't be rebuilt without it.
+type auditTrailRecord struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	Prev string    `json:"prev"`
+	// Dropped is the number of audit entries which could not be
+	// recorded, the record of a gap in the trail.
+	Dropped uint64          `json:"dropped,omitempty"`
+	Entry   json.RawMessage `json:"entry,omitempty"`
+	// Key is the ID of the key of the hash, the records saved
+	// without one are keyed with a key derived from the root
+	// credentials.
+	Key  string `json:"key,omitempty"`
+	Hash string `json:"hash"`
+}
+
+// sum returns the HMAC-SHA256 of the record, computed over its JSON
+// encoding without the hash.
+func (r auditTrailRecord) sum(key []byte) string {
+	r.Hash = ""
+	return auditTrailSum(key, r)
+}
+
+// auditTrailSum returns the HMAC-SHA256 of the JSON encoding of v.
+func auditTrailSum(key []byte, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// auditTrailKey - a key of the hashes of the records, generated by the
+// servers and kept unchanged when the root credentials are rotated.
+type auditTrailKey struct {
+	ID     string `json:"id"`
+	Secret []byte `json:"secret"`
+}
+
+// auditTrailKeyFile returns the file of a key.
+func auditTrailKeyFile(id string) string {
+	return path.Join(auditTrailKeysPrefix, id+".json")
+}
+
+// legacyAuditTrailKey returns the key of the records saved without a
+// key ID, derived from the secret key of the root credentials.
+func legacyAuditTrailKey() []byte {
+	mac := hmac.New(sha256.New, []byte(globalActiveCred.SecretKey))
+	mac.Write([]byte(auditTrailPrefix))
+	return mac.Sum(nil)
+}
+
+// newAuditTrailKey generates and saves a new key, its ID sorts after
+// the IDs of the keys generated before.
+func newAuditTrailKey(ctx context.Context, objAPI ObjectLayer) (auditTrailKey, error) {
+	key := auditTrailKey{
+		ID:     UTCNow().Format("20060102T150405Z") + "-" + mustGetUUID(),
+		Secret: make([]byte, sha256.Size),
+	}
+	if _, err := rand.Read(key.Secret); err != nil {
+		return key, err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return key, err
+	}
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return key, err
+		}
+	}
+	return key, saveConfig(ctx, objAPI, auditTrailKeyFile(key.ID), data)
+}
+
+// loadAuditTrailKey returns the key with the ID, errAuditTrailNoKey
+// if there is none.
+func loadAuditTrailKey(ctx context.Context, objAPI ObjectLayer, id string) (key auditTrailKey, err error) {
+	if id == "" || strings.Contains(id, SlashSeparator) {
+		return key, errAuditTrailNoKey
+	}
+	data, err := readConfig(ctx, objAPI, auditTrailKeyFile(id))
+	if err != nil {
+		if err == errConfigNotFound {
+			err = errAuditTrailNoKey
+		}
+		return key, err
+	}
+	if globalConfigEncrypted {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			return key, err
+		}
+	}
+	if err = json.Unmarshal(data, &key); err != nil {
+		return key, err
+	}
+	if key.ID != id {
+		return key, errAuditTrailNoKey
+	}
+	return key, nil
+}
+
+// latestAuditTrailKey returns the key generated last, a new key if
+// there is none yet.
+func latestAuditTrailKey(ctx context.Context, objAPI ObjectLayer) (auditTrailKey, error) {
+	var latest string
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, auditTrailKeysPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return auditTrailKey{}, err
+		}
+		for _, obj := range loi.Objects {
+			if id := strings.TrimSuffix(path.Base(obj.Name), ".json"); id > latest {
+				latest = id
+			}
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+	if latest == "" {
+		return newAuditTrailKey(ctx, objAPI)
+	}
+	return loadAuditTrailKey(ctx, objAPI, latest)
+}
+
+// auditTrailKeyring - the keys of a verification by ID, loaded once.
+type auditTrailKeyring struct {
+	ctx    context.Context
+	objAPI ObjectLayer
+	keys   map[string][]byte
+}
+
+func newAuditTrailKeyring(ctx context.Context, objAPI ObjectLayer) *auditTrailKeyring {
+	return &auditTrailKeyring{ctx: ctx, objAPI: objAPI, keys: make(map[string][]byte)}
+}
+
+// get returns the secret of the key with the ID.
+func (k *auditTrailKeyring) get(id string) ([]byte, error) {
+	if id == "" {
+		return legacyAuditTrailKey(), nil
+	}
+	if secret, ok := k.keys[id]; ok {
+		return secret, nil
+	}
+	key, err := loadAuditTrailKey(k.ctx, k.objAPI, id)
+	if err != nil {
+		return nil, err
+	}
+	k.keys[id] = key.Secret
+	return key.Secret, nil
+}
+
+// auditTrailCheckpoint - the end of the trail of a node for a day,
+// saved after every append apart from the trail, so that records
+// removed from the end of the trail are detected.
+type auditTrailCheckpoint struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	Hash string    `json:"hash"`
+	Key  string    `json:"key"`
+	// Sig is the HMAC-SHA256 of the checkpoint without it.
+	Sig string `json:"sig"`
+}
+
+// auditTrailCheckpointFile returns the checkpoint of the trail of a
+// node for a day.
+func auditTrailCheckpointFile(date, node string) string {
+	return path.Join(auditTrailCheckpointsPrefix, date, node+".json")
+}
+
+func saveAuditTrailCheckpoint(ctx context.Context, objAPI ObjectLayer, date, node string, key auditTrailKey, seq uint64, hash string) error {
+	checkpoint := auditTrailCheckpoint{Seq: seq, Time: UTCNow(), Hash: hash, Key: key.ID}
+	checkpoint.Sig = auditTrailSum(key.Secret, checkpoint)
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, auditTrailCheckpointFile(date, node), data)
+}
+
+// loadAuditTrailCheckpoint returns the checkpoint of the trail of a
+// node for a day, errConfigNotFound if there is none.
+func loadAuditTrailCheckpoint(ctx context.Context, objAPI ObjectLayer, date, node string) (checkpoint auditTrailCheckpoint, err error) {
+	data, err := readConfig(ctx, objAPI, auditTrailCheckpointFile(date, node))
+	if err != nil {
+		return checkpoint, err
+	}
+	// A malformed checkpoint fails its verification.
+	json.Unmarshal(data, &checkpoint)
+	return checkpoint, nil
+}
+
+// verify verifies the signature of the checkpoint.
+func (c auditTrailCheckpoint) verify(keys *auditTrailKeyring) error {
+	if c.Key == "" {
+		return errAuditTrailCheckpoint
+	}
+	secret, err := keys.get(c.Key)
+	if err != nil {
+		return err
+	}
+	sig := c.Sig
+	c.Sig = ""
+	if !hmac.Equal([]byte(auditTrailSum(secret, c)), []byte(sig)) {
+		return errAuditTrailCheckpoint
+	}
+	return nil
+}
+
+// auditTrailDir returns the directory of the segments of the trail of
+// a node for a day.
+func auditTrailDir(date, node string) string {
+	return path.Join(auditTrailPrefix, date, node) + SlashSeparator
+}
+
+// auditTrailSegment returns the segment of a trail starting with the
+// record seq, segments are listed in the order of their records.
+func auditTrailSegment(date, node string, seq uint64) string {
+	return path.Join(auditTrailPrefix, date, node, fmt.Sprintf("%020d.log", seq))
+}
+
+// listAuditTrailSegments returns the segments of the trail of a node
+// for a day, in the order of their records.
+func listAuditTrailSegments(ctx context.Context, objAPI ObjectLayer, date, node string) ([]string, error) {
+	var segments []string
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, auditTrailDir(date, node), marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range loi.Objects {
+			segments = append(segments, obj.Name)
+		}
+		if !loi.IsTruncated {
+			return segments, nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// readAuditTrail returns the trail of a node for a day, the
+// concatenation of its segments.
+func readAuditTrail(ctx context.Context, objAPI ObjectLayer, date, node string) ([]byte, error) {
+	segments, err := listAuditTrailSegments(ctx, objAPI, date, node)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, errConfigNotFound
+	}
+	var buf bytes.Buffer
+	for _, segment := range segments {
+		data, err := readConfig(ctx, objAPI, segment)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// readAuditTrailTail returns the last record of the trail of a node
+// for a day, errConfigNotFound if there is no trail. When the last
+// record is malformed, errAuditTrailMalformed is returned along with
+// its sequence number, counted from the start of its segment.
+func readAuditTrailTail(ctx context.Context, objAPI ObjectLayer, date, node string) (auditTrailRecord, error) {
+	segments, err := listAuditTrailSegments(ctx, objAPI, date, node)
+	if err != nil {
+		return auditTrailRecord{}, err
+	}
+	if len(segments) == 0 {
+		return auditTrailRecord{}, errConfigNotFound
+	}
+	segment := segments[len(segments)-1]
+	data, err := readConfig(ctx, objAPI, segment)
+	if err != nil {
+		return auditTrailRecord{}, err
+	}
+	tail, err := auditTrailTail(data)
+	if err != nil {
+		var first uint64
+		fmt.Sscanf(path.Base(segment), "%d.log", &first)
+		lines := uint64(bytes.Count(bytes.TrimRight(data, "\n"), []byte("\n")))
+		return auditTrailRecord{Seq: first + lines}, errAuditTrailMalformed
+	}
+	return tail, nil
+}
+
+// parseAuditTrailDate parses a date formatted as YYYY-MM-DD.
+func parseAuditTrailDate(date string) (time.Time, error) {
+	return time.Parse(auditTrailDateFormat, date)
+}
+
+// auditTrailTail returns the last record of a trail.
+func auditTrailTail(data []byte) (auditTrailRecord, error) {
+	var record auditTrailRecord
+	data = bytes.TrimRight(data, "\n")
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+	err := json.Unmarshal(data, &record)
+	return record, err
+}
+
+// auditTrailPending - an audit entry waiting to be appended.
+type auditTrailPending struct {
+	time    time.Time
+	entry   []byte
+	dropped uint64
+}
+
+// auditTrail is an audit target appending the audit entries of this
+// node to a hash-chained trail per day, saved in the cluster so that
+// compliance reviews don't depend on an external audit service.
+type auditTrail struct {
+	mu      sync.Mutex
+	pending []auditTrailPending
+	dropped uint64
+
+	// The end of the chain, only used by the appends.
+	date   string
+	seq    uint64
+	last   string
+	loaded bool
+	key    auditTrailKey
+}
+
+var globalAuditTrail = &auditTrail{}
+
+// Send queues an audit entry to be appended to the trail.
+func (t *auditTrail) Send(entry interface{}, errKind string) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.pending) >= auditTrailMaxPending {
+		t.dropped++
+		return errAuditTrailFull
+	}
+	t.pending = append(t.pending, auditTrailPending{time: UTCNow(), entry: data})
+	return nil
+}
+
+// flush appends the queued entries to the trails of their days, the
+// entries not appended are kept for the next flush.
+func (t *auditTrail) flush(ctx context.Context, objAPI ObjectLayer, node string) error {
+	t.mu.Lock()
+	if t.dropped > 0 {
+		t.pending = append(t.pending, auditTrailPending{time: UTCNow(), dropped: t.dropped})
+		t.dropped = 0
+	}
+	pending := t.pending
+	t.mu.Unlock()
+
+	for len(pending) > 0 {
+		date := pending[0].time.Format(auditTrailDateFormat)
+		n := 1
+		for n < len(pending) && pending[n].time.Format(auditTrailDateFormat) == date {
+			n++
+		}
+		if err := t.append(ctx, objAPI, node, date, pending[:n]); err != nil {
+			return err
+		}
+		pending = pending[n:]
+
+		t.mu.Lock()
+		t.pending = t.pending[n:]
+		t.mu.Unlock()
+	}
+	return nil
+}
+
+// append appends entries to the trail of a day, as a new segment
+// following the segments saved before.
+func (t *auditTrail) append(ctx context.Context, objAPI ObjectLayer, node, date string, entries []auditTrailPending) error {
+	if t.key.ID == "" {
+		key, err := latestAuditTrailKey(ctx, objAPI)
+		if err != nil {
+			return err
+		}
+		t.key = key
+	}
+
+	if date != t.date {
+		// The chain goes on from the end of the trail of the day,
+		// if saved before a restart, or else from the end of the
+		// trail of the previous day.
+		seq, last := uint64(0), t.last
+		tail, err := readAuditTrailTail(ctx, objAPI, date, node)
+		switch {
+		case err == nil:
+			seq, last = tail.Seq, tail.Hash
+		case err == errAuditTrailMalformed:
+			// A malformed end is kept as is, the verification
+			// of the trail reports it.
+			logger.LogIf(ctx, err)
+			seq, last = tail.Seq, ""
+		case err != errConfigNotFound:
+			return err
+		case !t.loaded:
+			day, err := parseAuditTrailDate(date)
+			if err != nil {
+				return err
+			}
+			prevDate := day.AddDate(0, 0, -1).Format(auditTrailDateFormat)
+			tail, err := readAuditTrailTail(ctx, objAPI, prevDate, node)
+			switch {
+			case err == nil:
+				last = tail.Hash
+			case err == errAuditTrailMalformed:
+				logger.LogIf(ctx, err)
+			case err != errConfigNotFound:
+				return err
+			}
+		}
+		// Records removed from the end of the trail are left
+		// as a gap, the verification of the trail reports it.
+		checkpoint, err := loadAuditTrailCheckpoint(ctx, objAPI, date, node)
+		if err != nil && err != errConfigNotFound {
+			return err
+		}
+		if checkpoint.Seq > seq {
+			logger.LogIf(ctx, fmt.Errorf("audit trail of %s ends before its checkpoint, record %d", date, checkpoint.Seq))
+			seq = checkpoint.Seq
+		}
+		t.date, t.seq, t.last, t.loaded = date, seq, last, true
+	}
+
+	seq, last := t.seq, t.last
+	var buf bytes.Buffer
+	for _, e := range entries {
+		seq++
+		record := auditTrailRecord{
+			Seq:     seq,
+			Time:    e.time,
+			Prev:    last,
+			Dropped: e.dropped,
+			Entry:   e.entry,
+			Key:     t.key.ID,
+		}
+		record.Hash = record.sum(t.key.Secret)
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+		last = record.Hash
+	}
+
+	if err := saveConfig(ctx, objAPI, auditTrailSegment(date, node, t.seq+1), buf.Bytes()); err != nil {
+		return err
+	}
+	t.seq, t.last = seq, last
+	logger.LogIf(ctx, saveAuditTrailCheckpoint(ctx, objAPI, date, node, t.key, seq, last))
+	return nil
+}
+
+// run appends the audit entries of this node to its trail periodically.
+func (t *auditTrail) run(ctx context.Context, objAPI ObjectLayer) {
+	node := GetLocalPeer(globalEndpoints)
+
+	ticker := time.NewTicker(auditTrailFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logger.LogIf(ctx, t.flush(ctx, objAPI, node))
+		}
+	}
+}
+
+// verifyAuditTrail verifies the chain of the records of a trail, the
+// first record is verified to follow prev when not empty, and the
+// trail to reach its checkpoint when not nil.
+func verifyAuditTrail(data []byte, prev string, checkpoint *auditTrailCheckpoint, keys *auditTrailKeyring) madmin.AuditTrailVerification {
+	var v madmin.AuditTrailVerification
+	if checkpoint != nil {
+		if err := checkpoint.verify(keys); err != nil {
+			v.Error = err.Error()
+			return v
+		}
+	}
+	keyed := false
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		seq := uint64(v.Records) + 1
+		var record auditTrailRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			v.InvalidSeq, v.Error = seq, "malformed record"
+			return v
+		}
+		if record.Seq != seq {
+			v.InvalidSeq, v.Error = seq, "missing or reordered records"
+			return v
+		}
+		if record.Prev != prev && (seq > 1 || prev != "") {
+			v.InvalidSeq, v.Error = seq, "record does not follow the previous record"
+			return v
+		}
+		key, err := keys.get(record.Key)
+		if err != nil {
+			v.InvalidSeq, v.Error = seq, err.Error()
+			return v
+		}
+		if record.sum(key) != record.Hash {
+			v.InvalidSeq, v.Error = seq, "record was modified"
+			return v
+		}
+		if checkpoint != nil && seq == checkpoint.Seq && record.Hash != checkpoint.Hash {
+			v.InvalidSeq, v.Error = seq, "record does not match the checkpoint"
+			return v
+		}
+		keyed = keyed || record.Key != ""
+		prev = record.Hash
+		v.Records++
+	}
+	switch {
+	case checkpoint != nil && uint64(v.Records) < checkpoint.Seq:
+		v.InvalidSeq, v.Error = uint64(v.Records)+1, "records removed from the end of the trail"
+		return v
+	case checkpoint != nil:
+		v.Checkpoint = checkpoint.Seq
+	case keyed:
+		// The trails saved with a key ID are saved along with a
+		// checkpoint.
+		v.Error = "checkpoint is missing"
+		return v
+	}
+	v.Valid = true
+	return v
+}
+
+// verifyAuditTrails verifies the trails of all the nodes for a day,
+// each chained to the trail of the previous day of its node if any.
+func verifyAuditTrails(ctx context.Context, objAPI ObjectLayer, date string) ([]madmin.AuditTrailVerification, error) {
+	day, err := parseAuditTrailDate(date)
+	if err != nil {
+		return nil, err
+	}
+	prevDate := day.AddDate(0, 0, -1).Format(auditTrailDateFormat)
+
+	var nodes []string
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, path.Join(auditTrailPrefix, date)+SlashSeparator, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, prefix := range loi.Prefixes {
+			nodes = append(nodes, path.Base(prefix))
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+	sort.Strings(nodes)
+
+	keys := newAuditTrailKeyring(ctx, objAPI)
+	verifications := make([]madmin.AuditTrailVerification, 0, len(nodes))
+	for _, node := range nodes {
+		data, err := readAuditTrail(ctx, objAPI, date, node)
+		if err == errConfigNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var prev string
+		tail, err := readAuditTrailTail(ctx, objAPI, prevDate, node)
+		switch {
+		case err == nil:
+			prev = tail.Hash
+		case err != errConfigNotFound && err != errAuditTrailMalformed:
+			// A malformed trail of the previous day is
+			// reported by its own verification.
+			return nil, err
+		}
+
+		var checkpoint *auditTrailCheckpoint
+		c, err := loadAuditTrailCheckpoint(ctx, objAPI, date, node)
+		switch {
+		case err == nil:
+			checkpoint = &c
+		case err != errConfigNotFound:
+			return nil, err
+		}
+
+		v := verifyAuditTrail(data, prev, checkpoint, keys)
+		v.Node, v.Date = node, date
+		verifications = append(verifications, v)
+	}
+	return verifications, nil
+}
@@ -0,0 +1,224 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestAuditTrail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	const node = "node1:9000"
+	now := UTCNow()
+	today := now.Format(auditTrailDateFormat)
+	yesterday := now.AddDate(0, 0, -1).Format(auditTrailDateFormat)
+
+	verify := func(date string, records int, valid bool, invalidSeq uint64) {
+		t.Helper()
+		verifications, err := verifyAuditTrails(ctx, objLayer, date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(verifications) != 1 {
+			t.Fatalf("expected the trail of a node, got %v", verifications)
+		}
+		v := verifications[0]
+		if v.Node != node || v.Records != records || v.Valid != valid || v.InvalidSeq != invalidSeq {
+			t.Fatalf("%s: unexpected verification %+v", date, v)
+		}
+	}
+
+	trail := &auditTrail{}
+	trail.pending = []auditTrailPending{
+		{time: now.AddDate(0, 0, -1), entry: []byte(`{"api":"PutObject"}`)},
+		{time: now.AddDate(0, 0, -1), entry: []byte(`{"api":"GetObject"}`)},
+	}
+	for _, api := range []string{"ListObjects", "DeleteObject"} {
+		if err = trail.Send(map[string]string{"api": api}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = trail.flush(ctx, objLayer, node); err != nil {
+		t.Fatal(err)
+	}
+	if len(trail.pending) != 0 {
+		t.Fatalf("expected all the entries to be appended, %d left", len(trail.pending))
+	}
+	verify(yesterday, 2, true, 0)
+	verify(today, 2, true, 0)
+
+	// The chain goes on after a restart, and records the dropped entries.
+	trail = &auditTrail{}
+	if err = trail.Send(map[string]string{"api": "HeadObject"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	trail.dropped = 5
+	if err = trail.flush(ctx, objLayer, node); err != nil {
+		t.Fatal(err)
+	}
+	verify(today, 4, true, 0)
+	if tail, err := readAuditTrailTail(ctx, objLayer, today, node); err != nil || tail.Seq != 4 || tail.Dropped != 5 {
+		t.Fatalf("expected the dropped entries to be recorded, got %+v, %v", tail, err)
+	}
+
+	// Every append is saved as a new segment.
+	segments, err := listAuditTrailSegments(ctx, objLayer, today, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(segments) != 2 || segments[0] != auditTrailSegment(today, node, 1) || segments[1] != auditTrailSegment(today, node, 3) {
+		t.Fatalf("unexpected segments %v", segments)
+	}
+
+	// A modified record is detected.
+	data, err := readConfig(ctx, objLayer, segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Replace(data, []byte("DeleteObject"), []byte("GetObject"), 1)
+	if err = saveConfig(ctx, objLayer, segments[0], tampered); err != nil {
+		t.Fatal(err)
+	}
+	verify(today, 1, false, 2)
+	if err = saveConfig(ctx, objLayer, segments[0], data); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key of the trail is kept when the root credentials are
+	// rotated, the config being encrypted with the new credentials.
+	tail, err := readAuditTrailTail(ctx, objLayer, today, node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := auditTrailKeyFile(tail.Key)
+	keyData, err := readConfig(ctx, objLayer, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cred := globalActiveCred
+	defer func() { globalActiveCred = cred }()
+	globalActiveCred.SecretKey = "another-secret-key"
+	if keyData, err = decryptData(keyData, cred); err != nil {
+		t.Fatal(err)
+	}
+	if keyData, err = madmin.EncryptData(globalActiveCred.String(), keyData); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, keyFile, keyData); err != nil {
+		t.Fatal(err)
+	}
+	verify(today, 4, true, 0)
+
+	// The chain can't be rebuilt without the key of the servers.
+	if err = deleteConfig(ctx, objLayer, keyFile); err != nil {
+		t.Fatal(err)
+	}
+	verifications, err := verifyAuditTrails(ctx, objLayer, today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(verifications) != 1 || verifications[0].Valid || verifications[0].Error != errAuditTrailNoKey.Error() {
+		t.Fatalf("expected the checkpoint not to be verified, got %+v", verifications)
+	}
+	if err = saveConfig(ctx, objLayer, keyFile, keyData); err != nil {
+		t.Fatal(err)
+	}
+	verify(today, 4, true, 0)
+
+	// A record removed from the end of the previous day is detected.
+	data, err = readConfig(ctx, objLayer, auditTrailSegment(yesterday, node, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if err = saveConfig(ctx, objLayer, auditTrailSegment(yesterday, node, 1), lines[0]); err != nil {
+		t.Fatal(err)
+	}
+	verify(yesterday, 1, false, 2)
+	verify(today, 0, false, 1)
+	if err = saveConfig(ctx, objLayer, auditTrailSegment(yesterday, node, 1), data); err != nil {
+		t.Fatal(err)
+	}
+
+	// A segment removed from the end of the trail is detected by the
+	// checkpoint, and so is the checkpoint removed.
+	if err = deleteConfig(ctx, objLayer, segments[1]); err != nil {
+		t.Fatal(err)
+	}
+	verify(today, 2, false, 3)
+	checkpointFile := auditTrailCheckpointFile(today, node)
+	checkpoint, err := readConfig(ctx, objLayer, checkpointFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = deleteConfig(ctx, objLayer, checkpointFile); err != nil {
+		t.Fatal(err)
+	}
+	verify(today, 2, false, 0)
+	if err = saveConfig(ctx, objLayer, checkpointFile, checkpoint); err != nil {
+		t.Fatal(err)
+	}
+
+	// The chain goes on after the checkpoint, leaving the gap.
+	trail = &auditTrail{}
+	if err = trail.Send(map[string]string{"api": "PutObject"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err = trail.flush(ctx, objLayer, node); err != nil {
+		t.Fatal(err)
+	}
+	if tail, err = readAuditTrailTail(ctx, objLayer, today, node); err != nil || tail.Seq != 5 {
+		t.Fatalf("expected the chain to go on after the checkpoint, got %+v, %v", tail, err)
+	}
+	verify(today, 2, false, 3)
+}
+
+func TestAuditTrailLegacyKey(t *testing.T) {
+	// The records saved without a key ID are keyed with the key
+	// derived from the root credentials.
+	record := auditTrailRecord{Seq: 1, Time: UTCNow(), Entry: []byte(`{"api":"PutObject"}`)}
+	record.Hash = record.sum(legacyAuditTrailKey())
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := newAuditTrailKeyring(context.Background(), nil)
+	if v := verifyAuditTrail(data, "", nil, keys); !v.Valid || v.Records != 1 {
+		t.Fatalf("unexpected verification %+v", v)
+	}
+
+	cred := globalActiveCred
+	defer func() { globalActiveCred = cred }()
+	globalActiveCred.SecretKey = "another-secret-key"
+	if v := verifyAuditTrail(data, "", nil, keys); v.Valid || v.InvalidSeq != 1 {
+		t.Fatalf("unexpected verification %+v", v)
+	}
+}
@@ -291,9 +291,11 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		}
 		cred, owner, s3Err = getReqAccessKeyV2(r)
 	case authTypeSigned, authTypePresigned:
-		region := globalServerRegion
+		region := getBucketRegion(bucketName)
 		switch action {
-		case policy.GetBucketLocationAction, policy.ListAllMyBucketsAction:
+		case policy.GetBucketLocationAction, policy.ListAllMyBucketsAction, policy.CreateBucketAction:
+			// Any region, the one of the new bucket is checked
+			// against its location constraint.
 			region = ""
 		}
 		if s3Err = isReqAuthenticated(ctx, r, region, serviceS3); s3Err != ErrNone {
@@ -311,6 +313,10 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		return accessKey, owner, s3Err
 	}
 
+	if err := checkServerMode(action); err != nil {
+		return accessKey, owner, toAPIErrorCode(ctx, err)
+	}
+
 	// LocationConstraint is valid only for CreateBucketAction.
 	var locationConstraint string
 	if action == policy.CreateBucketAction {
@@ -490,7 +496,7 @@ func validateSignature(atype authType, r *http.Request) (auth.Credentials, bool,
 		}
 		cred, owner, s3Err = getReqAccessKeyV2(r)
 	case authTypePresigned, authTypeSigned:
-		region := globalServerRegion
+		region := getRequestRegion(r)
 		if s3Err = isReqAuthenticated(GlobalContext, r, region, serviceS3); s3Err != ErrNone {
 			return cred, owner, nil, s3Err
 		}
@@ -589,7 +595,7 @@ func isPutActionAllowed(atype authType, bucketName, objectName string, r *http.R
 	case authTypeSignedV2, authTypePresignedV2:
 		cred, owner, s3Err = getReqAccessKeyV2(r)
 	case authTypeStreamingSigned, authTypePresigned, authTypeSigned:
-		region := globalServerRegion
+		region := getBucketRegion(bucketName)
 		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 	}
 	if s3Err != ErrNone {
@@ -601,6 +607,10 @@ func isPutActionAllowed(atype authType, bucketName, objectName string, r *http.R
 		return s3Err
 	}
 
+	if err := checkServerMode(policy.Action(action)); err != nil {
+		return toAPIErrorCode(GlobalContext, err)
+	}
+
 	// Do not check for PutObjectRetentionAction permission,
 	// if mode and retain until date are not set.
 	// Can happen when bucket has default lock config set
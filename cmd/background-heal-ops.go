@@ -70,6 +70,7 @@ func waitForLowHTTPReq(tolerance int32) {
 
 // Wait for heal requests and process them
 func (h *healRoutine) run(ctx context.Context, objAPI ObjectLayer) {
+	ctx = withIOClass(ctx, ioClassHeal)
 	for {
 		select {
 		case task, ok := <-h.tasks:
@@ -0,0 +1,177 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// bandwidthThrottle - limits the bytes read per second by all
+// the readers sending objects to the same remote target.
+type bandwidthThrottle struct {
+	mu        sync.Mutex
+	bandwidth int64
+	start     time.Time
+	bytes     int64
+}
+
+// wait - accounts n bytes read, and waits until reading
+// them is within the bandwidth.
+func (t *bandwidthThrottle) wait(ctx context.Context, n int) error {
+	t.mu.Lock()
+	now := time.Now()
+	expected := time.Duration(float64(t.bytes) / float64(t.bandwidth) * float64(time.Second))
+	// Idle time is not credited beyond one second.
+	if t.start.IsZero() || now.Sub(t.start) > expected+time.Second {
+		t.start, t.bytes = now, 0
+	}
+	t.bytes += int64(n)
+	delay := time.Duration(float64(t.bytes)/float64(t.bandwidth)*float64(time.Second)) - now.Sub(t.start)
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// throttledReader - reader limited to the bandwidth of its throttle.
+type throttledReader struct {
+	ctx      context.Context
+	r        io.Reader
+	throttle *bandwidthThrottle
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	// Read at most a second of data at once.
+	if int64(len(p)) > r.throttle.bandwidth {
+		p = p[:r.throttle.bandwidth]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if werr := r.throttle.wait(r.ctx, n); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// parseTransferTime - returns the minutes since midnight of a HH:MM time.
+func parseTransferTime(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errInvalidArgument
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// validateTransferWindows - checks the times of the windows,
+// windows must not be empty.
+func validateTransferWindows(windows []madmin.TransferWindow) error {
+	for _, w := range windows {
+		start, err := parseTransferTime(w.Start)
+		if err != nil {
+			return err
+		}
+		end, err := parseTransferTime(w.End)
+		if err != nil {
+			return err
+		}
+		if start == end {
+			return errInvalidArgument
+		}
+	}
+	return nil
+}
+
+// transferWindowsOpen - returns true if now is within one of the
+// windows, or if there are no windows.
+func transferWindowsOpen(windows []madmin.TransferWindow, now time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	m := now.Hour()*60 + now.Minute()
+	for _, w := range windows {
+		start, err := parseTransferTime(w.Start)
+		if err != nil {
+			continue
+		}
+		end, err := parseTransferTime(w.End)
+		if err != nil {
+			continue
+		}
+		if start < end && m >= start && m < end {
+			return true
+		}
+		// Windows spanning midnight.
+		if start > end && (m >= start || m < end) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextTransferWindow - returns the time the next of the windows opens.
+func nextTransferWindow(windows []madmin.TransferWindow, now time.Time) (next time.Time) {
+	for _, w := range windows {
+		start, err := parseTransferTime(w.Start)
+		if err != nil {
+			continue
+		}
+		t := time.Date(now.Year(), now.Month(), now.Day(), start/60, start%60, 0, 0, now.Location())
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+// waitTransferWindow - waits until one of the windows is open.
+func waitTransferWindow(ctx context.Context, windows []madmin.TransferWindow) error {
+	for {
+		now := time.Now()
+		if transferWindowsOpen(windows, now) {
+			return nil
+		}
+		next := nextTransferWindow(windows, now)
+		if next.IsZero() {
+			return errInvalidArgument
+		}
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBandwidthThrottle(t *testing.T) {
+	throttle := &bandwidthThrottle{bandwidth: 1 << 20}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := throttle.wait(context.Background(), 1<<19); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected reads to be throttled to 1MiB/s, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := throttle.wait(ctx, 1<<20); err == nil {
+		t.Fatalf("expected canceled waits to fail")
+	}
+}
+
+func TestTransferWindows(t *testing.T) {
+	windows := []madmin.TransferWindow{{Start: "22:00", End: "06:00"}, {Start: "12:00", End: "13:30"}}
+	if err := validateTransferWindows(windows); err != nil {
+		t.Fatal(err)
+	}
+	for _, invalid := range []madmin.TransferWindow{{Start: "25:00", End: "06:00"}, {Start: "22:00"}, {Start: "10:00", End: "10:00"}} {
+		if err := validateTransferWindows([]madmin.TransferWindow{invalid}); err == nil {
+			t.Errorf("expected window %v to be invalid", invalid)
+		}
+	}
+
+	day := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		at   string
+		open bool
+		next string
+	}{
+		{"23:15", true, ""},
+		{"05:59", true, ""},
+		{"06:00", false, "12:00"},
+		{"12:45", true, ""},
+		{"13:30", false, "22:00"},
+	}
+	for i, testCase := range testCases {
+		at, _ := time.Parse("15:04", testCase.at)
+		now := day.Add(time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute)
+		if open := transferWindowsOpen(windows, now); open != testCase.open {
+			t.Errorf("Test %d: expected open %v at %s, got %v", i+1, testCase.open, testCase.at, open)
+		}
+		if testCase.next == "" {
+			continue
+		}
+		if next := nextTransferWindow(windows, now).Format("15:04"); next != testCase.next {
+			t.Errorf("Test %d: expected the next window at %s, got %s", i+1, testCase.next, next)
+		}
+	}
+	if !transferWindowsOpen(nil, day) {
+		t.Errorf("expected no windows to be always open")
+	}
+}
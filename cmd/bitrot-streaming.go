@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"hash"
@@ -69,7 +70,9 @@ func (b *streamingBitrotWriter) Close() error {
 	return err
 }
 
-// Returns streaming bitrot writer implementation.
+// Returns streaming bitrot writer implementation. The shard file is
+// written with a single disk.CreateFile() call which, when the length
+// is known, preallocates the whole file along with its checksums.
 func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length int64, algo BitrotAlgorithm, shardSize int64) io.WriteCloser {
 	r, w := io.Pipe()
 	h := algo.New()
@@ -89,6 +92,7 @@ func newStreamingBitrotWriter(disk StorageAPI, volume, filePath string, length i
 
 // ReadAt() implementation which verifies the bitrot hash available as part of the stream.
 type streamingBitrotReader struct {
+	ctx        context.Context
 	disk       StorageAPI
 	rc         io.ReadCloser
 	volume     string
@@ -114,6 +118,12 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 		// Can never happen unless there are programmer bugs
 		return 0, errUnexpected
 	}
+	if b.rc != nil && offset != b.currOffset {
+		// Shards were skipped, read by other disks, the stream
+		// is opened again at offset.
+		b.rc.Close()
+		b.rc = nil
+	}
 	if b.rc == nil {
 		// For the first ReadAt() call we need to open the stream for reading.
 		b.currOffset = offset
@@ -123,10 +133,6 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 			return 0, err
 		}
 	}
-	if offset != b.currOffset {
-		// Can never happen unless there are programmer bugs
-		return 0, errUnexpected
-	}
 	b.h.Reset()
 	_, err = io.ReadFull(b.rc, b.hashBytes)
 	if err != nil {
@@ -136,12 +142,18 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	b.h.Write(buf)
+	var sum []byte
+	if err = globalCPUPool.run(b.ctx, cpuJobBitrot, func() {
+		b.h.Write(buf)
+		sum = b.h.Sum(nil)
+	}); err != nil {
+		return 0, err
+	}
 
-	if !bytes.Equal(b.h.Sum(nil), b.hashBytes) {
+	if !bytes.Equal(sum, b.hashBytes) {
 		err := &errHashMismatch{fmt.Sprintf("hashes do not match expected %s, got %s",
-			hex.EncodeToString(b.hashBytes), hex.EncodeToString(b.h.Sum(nil)))}
-		logger.LogIf(GlobalContext, err)
+			hex.EncodeToString(b.hashBytes), hex.EncodeToString(sum))}
+		logger.LogIf(b.ctx, err)
 		return 0, err
 	}
 	b.currOffset += int64(len(buf))
@@ -149,9 +161,10 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 }
 
 // Returns streaming bitrot reader implementation.
-func newStreamingBitrotReader(disk StorageAPI, volume, filePath string, tillOffset int64, algo BitrotAlgorithm, shardSize int64) *streamingBitrotReader {
+func newStreamingBitrotReader(ctx context.Context, disk StorageAPI, volume, filePath string, tillOffset int64, algo BitrotAlgorithm, shardSize int64) *streamingBitrotReader {
 	h := algo.New()
 	return &streamingBitrotReader{
+		ctx,
 		disk,
 		nil,
 		volume,
@@ -30,10 +30,28 @@ type wholeBitrotWriter struct {
 	filePath  string
 	shardSize int64 // This is the shard size of the erasure logic
 	hash.Hash       // For bitrot hash
+
+	// When the shard file size is known upfront the shard is streamed
+	// to disk.CreateFile() which preallocates the file, instead of
+	// growing it with one disk.AppendFile() call per block.
+	length   int64
+	iow      *io.PipeWriter
+	canClose chan struct{}
 }
 
 func (b *wholeBitrotWriter) Write(p []byte) (int, error) {
-	err := b.disk.AppendFile(b.volume, b.filePath, p)
+	if len(p) == 0 {
+		return 0, nil
+	}
+	var err error
+	if b.length >= 0 {
+		if b.iow == nil {
+			b.startCreateFile()
+		}
+		_, err = b.iow.Write(p)
+	} else {
+		err = b.disk.AppendFile(b.volume, b.filePath, p)
+	}
 	if err != nil {
 		logger.LogIf(GlobalContext, err)
 		return 0, err
@@ -46,13 +64,39 @@ func (b *wholeBitrotWriter) Write(p []byte) (int, error) {
 	return len(p), nil
 }
 
+// startCreateFile starts streaming the shard file to the disk.
+func (b *wholeBitrotWriter) startCreateFile() {
+	r, w := io.Pipe()
+	b.iow = w
+	b.canClose = make(chan struct{})
+	go func() {
+		err := b.disk.CreateFile(b.volume, b.filePath, b.length, r)
+		r.CloseWithError(err)
+		close(b.canClose)
+	}()
+}
+
 func (b *wholeBitrotWriter) Close() error {
-	return nil
+	if b.iow == nil {
+		return nil
+	}
+	err := b.iow.Close()
+	// Wait for all data to be written, see streamingBitrotWriter.Close()
+	<-b.canClose
+	return err
 }
 
-// Returns whole-file bitrot writer.
-func newWholeBitrotWriter(disk StorageAPI, volume, filePath string, algo BitrotAlgorithm, shardSize int64) io.WriteCloser {
-	return &wholeBitrotWriter{disk, volume, filePath, shardSize, algo.New()}
+// Returns whole-file bitrot writer, length is the expected size of
+// the shard file or -1 if unknown.
+func newWholeBitrotWriter(disk StorageAPI, volume, filePath string, algo BitrotAlgorithm, shardSize, length int64) io.WriteCloser {
+	return &wholeBitrotWriter{
+		disk:      disk,
+		volume:    volume,
+		filePath:  filePath,
+		shardSize: shardSize,
+		Hash:      algo.New(),
+		length:    length,
+	}
 }
 
 // Implementation to verify bitrot for the whole file.
@@ -63,11 +107,13 @@ type wholeBitrotReader struct {
 	verifier   *BitrotVerifier // Holds the bit-rot info
 	tillOffset int64           // Affects the length of data requested in disk.ReadFile depending on Read()'s offset
 	buf        []byte          // Holds bit-rot verified data
+	bufOffset  int64           // Offset of buf in the file
 }
 
 func (b *wholeBitrotReader) ReadAt(buf []byte, offset int64) (n int, err error) {
 	if b.buf == nil {
 		b.buf = make([]byte, b.tillOffset-offset)
+		b.bufOffset = offset
 		if _, err := b.disk.ReadFile(b.volume, b.filePath, offset, b.buf, b.verifier); err != nil {
 			ctx := GlobalContext
 			logger.GetReqInfo(ctx).AppendTags("disk", b.disk.String())
@@ -75,12 +121,26 @@ func (b *wholeBitrotReader) ReadAt(buf []byte, offset int64) (n int, err error)
 			return 0, err
 		}
 	}
+	if offset < b.bufOffset {
+		// Can never happen unless there are programmer bugs
+		return 0, errUnexpected
+	}
+	// Skip the shards read by other disks.
+	if skip := offset - b.bufOffset; skip > 0 {
+		if skip > int64(len(b.buf)) {
+			logger.LogIf(GlobalContext, errLessData)
+			return 0, errLessData
+		}
+		b.buf = b.buf[skip:]
+		b.bufOffset = offset
+	}
 	if len(b.buf) < len(buf) {
 		logger.LogIf(GlobalContext, errLessData)
 		return 0, errLessData
 	}
 	n = copy(buf, b.buf)
 	b.buf = b.buf[n:]
+	b.bufOffset += int64(n)
 	return n, nil
 }
 
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"context"
 	"io"
 	"io/ioutil"
 	"log"
@@ -24,7 +25,7 @@ import (
 	"testing"
 )
 
-func testBitrotReaderWriterAlgo(t *testing.T, bitrotAlgo BitrotAlgorithm) {
+func testBitrotReaderWriterAlgo(t *testing.T, bitrotAlgo BitrotAlgorithm, length int64) {
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		log.Fatal(err)
@@ -41,7 +42,7 @@ func testBitrotReaderWriterAlgo(t *testing.T, bitrotAlgo BitrotAlgorithm) {
 
 	disk.MakeVol(volume)
 
-	writer := newBitrotWriter(disk, volume, filePath, 35, bitrotAlgo, 10)
+	writer := newBitrotWriter(disk, volume, filePath, length, bitrotAlgo, 10)
 
 	_, err = writer.Write([]byte("aaaaaaaaaa"))
 	if err != nil {
@@ -61,7 +62,7 @@ func testBitrotReaderWriterAlgo(t *testing.T, bitrotAlgo BitrotAlgorithm) {
 	}
 	writer.(io.Closer).Close()
 
-	reader := newBitrotReader(disk, volume, filePath, 35, bitrotAlgo, bitrotWriterSum(writer), 10)
+	reader := newBitrotReader(context.Background(), disk, volume, filePath, 35, bitrotAlgo, bitrotWriterSum(writer), 10)
 	b := make([]byte, 10)
 	if _, err = reader.ReadAt(b, 0); err != nil {
 		log.Fatal(err)
@@ -77,8 +78,60 @@ func testBitrotReaderWriterAlgo(t *testing.T, bitrotAlgo BitrotAlgorithm) {
 	}
 }
 
+// createFileSizeDisk records the size of the files created on the disk.
+type createFileSizeDisk struct {
+	StorageAPI
+	fileSize int64
+}
+
+func (d *createFileSizeDisk) CreateFile(volume, path string, fileSize int64, reader io.Reader) error {
+	d.fileSize = fileSize
+	return d.StorageAPI.CreateFile(volume, path, fileSize, reader)
+}
+
+// Test that the shard files are preallocated when their length is known.
+func TestBitrotWriterPreallocate(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	xl, err := newXLStorage(tmpDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = xl.MakeVol("testvol"); err != nil {
+		t.Fatal(err)
+	}
+
+	for bitrotAlgo := range bitrotAlgorithms {
+		disk := &createFileSizeDisk{StorageAPI: xl}
+		writer := newBitrotWriter(disk, "testvol", bitrotAlgo.String(), 35, bitrotAlgo, 10)
+		for _, p := range []string{"aaaaaaaaaa", "aaaaaaaaaa", "aaaaaaaaaa", "aaaaa"} {
+			if _, err = writer.Write([]byte(p)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = writer.(io.Closer).Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := int64(35)
+		if bitrotAlgo == HighwayHash256S {
+			// The streaming checksums are stored in the shard file.
+			expected += 4 * int64(bitrotAlgo.New().Size())
+		}
+		if disk.fileSize != expected {
+			t.Fatalf("%s: expected the shard file to be preallocated with %d bytes, got %d", bitrotAlgo, expected, disk.fileSize)
+		}
+	}
+}
+
 func TestAllBitrotAlgorithms(t *testing.T) {
 	for bitrotAlgo := range bitrotAlgorithms {
-		testBitrotReaderWriterAlgo(t, bitrotAlgo)
+		testBitrotReaderWriterAlgo(t, bitrotAlgo, 35)
+		// Unknown length, as with compressed objects.
+		testBitrotReaderWriterAlgo(t, bitrotAlgo, -1)
 	}
 }
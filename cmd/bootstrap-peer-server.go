@@ -100,6 +100,12 @@ func (b *bootstrapRESTServer) HealthHandler(w http.ResponseWriter, r *http.Reque
 
 func (b *bootstrapRESTServer) VerifyHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "VerifyHandler")
+	// The configuration of the server is only sent to the nodes.
+	if err := internodeRequestValidate(r); err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(err.Error()))
+		return
+	}
 	cfg := getServerSystemCfg()
 	logger.LogIf(ctx, json.NewEncoder(w).Encode(&cfg))
 	w.(http.Flusher).Flush()
@@ -228,8 +234,14 @@ func newBootstrapRESTClient(endpoint Endpoint) *bootstrapRESTClient {
 		}
 	}
 
+	if globalInternodeTLS != nil {
+		serverURL.Scheme = "https"
+		tlsConfig = globalInternodeTLS.clientConfig(endpoint.Hostname(), tlsConfig)
+	}
+
 	trFn := newCustomHTTPTransport(tlsConfig, rest.DefaultRESTTimeout)
 	restClient := rest.NewClient(serverURL, trFn, newAuthToken)
+	restClient.FaultFn = newRPCFaultFn(serverURL.Host)
 	restClient.HealthCheckFn = func() bool {
 		ctx, cancel := context.WithTimeout(GlobalContext, restClient.HealthCheckTimeout)
 		// Instantiate a new rest client for healthcheck
@@ -0,0 +1,116 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	bucketBandwidthConfigFile = "bandwidth.json"
+)
+
+// parseBucketBandwidth parses the bandwidth limits of a bucket.
+func parseBucketBandwidth(bucket string, data []byte) (*madmin.BucketBandwidth, error) {
+	bandwidth := &madmin.BucketBandwidth{}
+	if err := json.Unmarshal(data, bandwidth); err != nil {
+		return bandwidth, err
+	}
+	if !bandwidth.IsValid() {
+		return bandwidth, fmt.Errorf("Invalid bandwidth config %#v", bandwidth)
+	}
+	return bandwidth, nil
+}
+
+// bandwidthThrottles - the throttles shared by the requests of the
+// buckets with a bandwidth limit, and by the anonymous requests.
+type bandwidthThrottles struct {
+	mu        sync.Mutex
+	buckets   map[string]*bandwidthThrottle
+	anonymous *bandwidthThrottle
+}
+
+var globalBandwidthThrottles = &bandwidthThrottles{
+	buckets: make(map[string]*bandwidthThrottle),
+}
+
+// bucket - returns the throttle shared by the requests of the bucket,
+// a new one when the bandwidth changed, nil without bandwidth.
+func (t *bandwidthThrottles) bucket(bucket string, bandwidth int64) *bandwidthThrottle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if bandwidth <= 0 {
+		delete(t.buckets, bucket)
+		return nil
+	}
+	throttle := t.buckets[bucket]
+	if throttle == nil || throttle.bandwidth != bandwidth {
+		throttle = &bandwidthThrottle{bandwidth: bandwidth}
+		t.buckets[bucket] = throttle
+	}
+	return throttle
+}
+
+// anonymousThrottle - returns the throttle shared by the anonymous
+// requests, a new one when the bandwidth changed, nil without bandwidth.
+func (t *bandwidthThrottles) anonymousThrottle(bandwidth int64) *bandwidthThrottle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if bandwidth <= 0 {
+		t.anonymous = nil
+		return nil
+	}
+	if t.anonymous == nil || t.anonymous.bandwidth != bandwidth {
+		t.anonymous = &bandwidthThrottle{bandwidth: bandwidth}
+	}
+	return t.anonymous
+}
+
+// newBandwidthReader - limits the object data of the request read from
+// reader to the bandwidth limits of the bucket, and to the bandwidth of
+// anonymous requests. The reader is returned as is without limits.
+func newBandwidthReader(ctx context.Context, r *http.Request, bucket string, reader io.Reader) io.Reader {
+	var throttles []*bandwidthThrottle
+	if getRequestAuthType(r) == authTypeAnonymous {
+		if throttle := globalBandwidthThrottles.anonymousThrottle(globalAPIConfig.getAnonymousBandwidth()); throttle != nil {
+			throttles = append(throttles, throttle)
+		}
+	}
+	if globalBucketMetadataSys != nil {
+		if bandwidth, err := globalBucketMetadataSys.GetBandwidthConfig(bucket); err == nil {
+			if throttle := globalBandwidthThrottles.bucket(bucket, bandwidth.Limit); throttle != nil {
+				throttles = append(throttles, throttle)
+			}
+			if bandwidth.RequestLimit > 0 {
+				throttles = append(throttles, &bandwidthThrottle{bandwidth: bandwidth.RequestLimit})
+			}
+		}
+	}
+	for _, throttle := range throttles {
+		reader = &throttledReader{ctx: ctx, r: reader, throttle: throttle}
+	}
+	return reader
+}
@@ -0,0 +1,101 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/api"
+)
+
+func TestParseBucketBandwidth(t *testing.T) {
+	testCases := []struct {
+		data    string
+		success bool
+	}{
+		{`{}`, true},
+		{`{"limit":1048576}`, true},
+		{`{"limit":1048576,"requestLimit":65536}`, true},
+		{`{"limit":-1}`, false},
+		{`{"requestLimit":-1}`, false},
+		{`{"limit":"1MiB"}`, false},
+	}
+
+	for i, testCase := range testCases {
+		_, err := parseBucketBandwidth("bucket", []byte(testCase.data))
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestBandwidthThrottlesBucket(t *testing.T) {
+	throttles := &bandwidthThrottles{buckets: make(map[string]*bandwidthThrottle)}
+
+	throttle := throttles.bucket("bucket", 1024)
+	if throttle == nil || throttle.bandwidth != 1024 {
+		t.Fatalf("expected a throttle of 1024 bytes per second, got %v", throttle)
+	}
+	if throttles.bucket("bucket", 1024) != throttle {
+		t.Fatal("expected the requests of the bucket to share the throttle")
+	}
+	if throttles.bucket("other", 1024) == throttle {
+		t.Fatal("expected the buckets to have their own throttle")
+	}
+	if changed := throttles.bucket("bucket", 2048); changed == throttle || changed.bandwidth != 2048 {
+		t.Fatal("expected a new throttle after a bandwidth change")
+	}
+	if throttles.bucket("bucket", 0) != nil {
+		t.Fatal("expected no throttle without bandwidth")
+	}
+}
+
+func TestBandwidthReaderAnonymous(t *testing.T) {
+	defer globalAPIConfig.init(api.Config{})
+	globalAPIConfig.init(api.Config{APIAnonymousBandwidth: 2000})
+
+	data := bytes.Repeat([]byte("a"), 1000)
+
+	// Authenticated requests are not limited by the anonymous bandwidth.
+	req := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	req.Header.Set("Authorization", signV4Algorithm+" Credential=minio/20201017/us-east-1/s3/aws4_request")
+	start := time.Now()
+	b, err := ioutil.ReadAll(newBandwidthReader(context.Background(), req, "bucket", bytes.NewReader(data)))
+	if err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("unexpected read of %d bytes: %v", len(b), err)
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Fatalf("expected an unlimited read, took %s", elapsed)
+	}
+
+	// 1000 bytes at 2000 bytes per second.
+	req = httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+	start = time.Now()
+	b, err = ioutil.ReadAll(newBandwidthReader(context.Background(), req, "bucket", bytes.NewReader(data)))
+	if err != nil || !bytes.Equal(b, data) {
+		t.Fatalf("unexpected read of %d bytes: %v", len(b), err)
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Fatalf("expected the read to be limited, took %s", elapsed)
+	}
+}
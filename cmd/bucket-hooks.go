@@ -0,0 +1,431 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	bucketHooksConfigFile = "hooks.json"
+
+	// Put hooks waiting to be invoked, beyond which
+	// new put hooks are dropped.
+	bucketHooksQueueSize = 10000
+
+	// Put hooks invoked concurrently.
+	bucketHooksWorkers = 4
+
+	// Attempts of put hooks with the retry failure policy.
+	bucketHooksRetries = 3
+)
+
+// Headers of the requests to hooks.
+const (
+	hookEventHeader     = "X-Minio-Hook-Event"
+	hookNameHeader      = "X-Minio-Hook-Name"
+	hookBucketHeader    = "X-Minio-Bucket"
+	hookObjectHeader    = "X-Minio-Object"
+	hookVersionIDHeader = "X-Minio-Version-Id"
+	hookETagHeader      = "X-Minio-Etag"
+
+	// Header of put hook responses naming the derived object,
+	// relative to the target prefix of the hook.
+	hookDerivedObjectHeader = "X-Minio-Derived-Object"
+)
+
+// BucketHooksSys - invokes the hooks of buckets.
+type BucketHooksSys struct {
+	once  sync.Once
+	queue chan hookTask
+}
+
+// hookTask - a put hook to invoke on an object.
+type hookTask struct {
+	hook    madmin.BucketHook
+	bucket  string
+	objInfo ObjectInfo
+}
+
+// NewBucketHooksSys returns initialized BucketHooksSys
+func NewBucketHooksSys() *BucketHooksSys {
+	return &BucketHooksSys{
+		queue: make(chan hookTask, bucketHooksQueueSize),
+	}
+}
+
+// Get - Get hooks configuration.
+func (sys *BucketHooksSys) Get(bucketName string) (*madmin.BucketHooks, error) {
+	if globalIsGateway {
+		return &madmin.BucketHooks{}, nil
+	}
+
+	return globalBucketMetadataSys.GetHooksConfig(bucketName)
+}
+
+// parseBucketHooks parses BucketHooks from json
+func parseBucketHooks(bucket string, data []byte) (hooksCfg *madmin.BucketHooks, err error) {
+	hooksCfg = &madmin.BucketHooks{}
+	if err = json.Unmarshal(data, hooksCfg); err != nil {
+		return hooksCfg, err
+	}
+	if err = hooksCfg.Validate(bucket); err != nil {
+		return hooksCfg, err
+	}
+	return hooksCfg, nil
+}
+
+// matching returns the hooks of the bucket invoked on event for object.
+func (sys *BucketHooksSys) matching(bucket, object string, event madmin.HookEvent) (hooks []madmin.BucketHook) {
+	cfg, err := sys.Get(bucket)
+	if err != nil {
+		return nil
+	}
+	for _, hook := range cfg.Hooks {
+		if hook.Event == event && strings.HasPrefix(object, hook.Prefix) && strings.HasSuffix(object, hook.Suffix) {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks
+}
+
+// GetHook returns the get hook transforming the object, the first
+// matching one when several hooks match, nil if there is none.
+func (sys *BucketHooksSys) GetHook(bucket, object string) *madmin.BucketHook {
+	hooks := sys.matching(bucket, object, madmin.HookGet)
+	if len(hooks) == 0 {
+		return nil
+	}
+	return &hooks[0]
+}
+
+// Transform - invokes the get hook with the content of the object,
+// the response body is the transformed content to return.
+func (sys *BucketHooksSys) Transform(ctx context.Context, hook madmin.BucketHook, bucket string, objInfo ObjectInfo, r io.Reader, size int64) (*http.Response, error) {
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = madmin.DefaultGetHookTimeout
+	}
+
+	// The timeout applies until the hook responds, the
+	// transformed content is then streamed to the client.
+	ctx, cancel := context.WithCancel(ctx)
+	timer := time.AfterFunc(timeout, cancel)
+	resp, err := invokeBucketHook(ctx, hook, bucket, objInfo, r, size)
+	if !timer.Stop() || err != nil {
+		cancel()
+		if err == nil {
+			resp.Body.Close()
+			err = context.DeadlineExceeded
+		}
+		logger.LogIf(ctx, fmt.Errorf("Hook %s of %s/%s failed: %w", hook.Name, bucket, objInfo.Name, err))
+		return nil, err
+	}
+	resp.Body = &hookResponseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type hookResponseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *hookResponseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// Derive - queues the put hooks matching the object written,
+// their responses are saved as new objects in the background.
+func (sys *BucketHooksSys) Derive(bucket string, objInfo ObjectInfo) {
+	hooks := sys.matching(bucket, objInfo.Name, madmin.HookPut)
+	if len(hooks) == 0 {
+		return
+	}
+
+	// The keys of SSE-C objects are not known to the server.
+	if crypto.SSEC.IsEncrypted(objInfo.UserDefined) {
+		return
+	}
+
+	sys.once.Do(func() {
+		for i := 0; i < bucketHooksWorkers; i++ {
+			go sys.worker(GlobalContext)
+		}
+	})
+
+	for _, hook := range hooks {
+		if isDerivedByHook(hook, bucket, objInfo.Name) {
+			// Derived objects are not derived again by their hook.
+			continue
+		}
+		select {
+		case sys.queue <- hookTask{hook: hook, bucket: bucket, objInfo: objInfo}:
+		default:
+			logger.LogIf(GlobalContext, fmt.Errorf("Dropping hook %s of %s/%s, too many hooks pending",
+				hook.Name, bucket, objInfo.Name))
+		}
+	}
+}
+
+// isDerivedByHook - returns true if the object is in the target
+// location of the put hook.
+func isDerivedByHook(hook madmin.BucketHook, bucket, object string) bool {
+	targetBucket := hook.TargetBucket
+	if targetBucket == "" {
+		targetBucket = bucket
+	}
+	return targetBucket == bucket && hook.TargetPrefix != "" && strings.HasPrefix(object, hook.TargetPrefix)
+}
+
+func (sys *BucketHooksSys) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-sys.queue:
+			attempts := 1
+			if task.hook.OnFailure == madmin.HookRetry {
+				attempts = bucketHooksRetries
+			}
+			for i := 0; i < attempts; i++ {
+				err := deriveObject(ctx, task)
+				if err == nil {
+					break
+				}
+				if i == attempts-1 {
+					logger.LogIf(ctx, fmt.Errorf("Hook %s of %s/%s failed: %w",
+						task.hook.Name, task.bucket, task.objInfo.Name, err))
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(time.Duration(i+1) * time.Second):
+				}
+			}
+		}
+	}
+}
+
+// deriveObject - invokes the put hook of the task with the content of its
+// object, and saves the response of the hook as the derived object.
+func deriveObject(ctx context.Context, task hookTask) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	timeout := task.hook.Timeout
+	if timeout == 0 {
+		timeout = madmin.DefaultPutHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	gr, err := objAPI.GetObjectNInfo(ctx, task.bucket, task.objInfo.Name, nil, http.Header{}, readLock,
+		ObjectOptions{VersionID: task.objInfo.VersionID})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// Removed since, nothing to derive.
+			return nil
+		}
+		return err
+	}
+	defer gr.Close()
+
+	objInfo := gr.ObjInfo
+	size, err := objectReadSize(objInfo)
+	if err != nil {
+		return err
+	}
+
+	resp, err := invokeBucketHook(ctx, task.hook, task.bucket, objInfo, gr, size)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	// Nothing derived from this object.
+	if resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	bucket := task.hook.TargetBucket
+	if bucket == "" {
+		bucket = task.bucket
+	}
+	object := resp.Header.Get(hookDerivedObjectHeader)
+	if object == "" {
+		object = objInfo.Name
+	}
+	object = task.hook.TargetPrefix + object
+	if bucket == task.bucket && object == objInfo.Name {
+		return fmt.Errorf("derived object %s/%s would overwrite its source", bucket, object)
+	}
+
+	// The quota of the target bucket is checked before the
+	// derived object is written, its size must be known.
+	size = resp.ContentLength
+	if size < 0 {
+		return fmt.Errorf("derived object %s/%s has no content length", bucket, object)
+	}
+	if err = checkPutObjectArgs(ctx, bucket, object, objAPI, size); err != nil {
+		return err
+	}
+	newObject, err := enforceBucketQuota(ctx, bucket, object, size)
+	if err != nil {
+		return err
+	}
+
+	hashReader, err := hash.NewReader(resp.Body, size, "", "", size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return err
+	}
+	pReader := NewPutObjReader(hashReader, nil, nil)
+	metadata := map[string]string{
+		xhttp.ContentType: resp.Header.Get(xhttp.ContentType),
+	}
+
+	// Derived objects are encrypted with SSE-S3 like their source,
+	// or as configured for the target bucket.
+	_, err = globalBucketSSEConfigSys.Get(bucket)
+	if objAPI.IsEncryptionSupported() && (globalAutoEncryption || err == nil || crypto.S3.IsEncrypted(objInfo.UserDefined)) {
+		encReader, objectEncryptionKey, err := newEncryptReader(hashReader, nil, bucket, object, metadata, true)
+		if err != nil {
+			return err
+		}
+		info := ObjectInfo{Size: size}
+		encHashReader, err := hash.NewReader(encReader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return err
+		}
+		pReader = NewPutObjReader(hashReader, encHashReader, &objectEncryptionKey)
+	}
+
+	derivedInfo, err := objAPI.PutObject(ctx, bucket, object, pReader, ObjectOptions{
+		UserDefined: metadata,
+		Versioned:   globalBucketVersioningSys.Enabled(bucket),
+	})
+	if err != nil {
+		return err
+	}
+	accountBucketQuota(bucket, size, newObject)
+
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: bucket,
+		Object:     derivedInfo,
+		Host:       "Internal: [BUCKET-HOOK]",
+	})
+	return nil
+}
+
+// invokeBucketHook - POSTs the content of the object to the hook,
+// returns the response of the hook if successful.
+func invokeBucketHook(ctx context.Context, hook madmin.BucketHook, bucket string, objInfo ObjectInfo, r io.Reader, size int64) (*http.Response, error) {
+	if size == 0 {
+		// Request bodies of length zero must be nil.
+		r = bytes.NewReader(nil)
+	}
+	req, err := http.NewRequest(http.MethodPost, hook.Endpoint, r)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.ContentLength = size
+
+	req.Header.Set(hookEventHeader, string(hook.Event))
+	req.Header.Set(hookNameHeader, hook.Name)
+	req.Header.Set(hookBucketHeader, bucket)
+	req.Header.Set(hookObjectHeader, objInfo.Name)
+	req.Header.Set(hookETagHeader, objInfo.ETag)
+	if objInfo.VersionID != "" {
+		req.Header.Set(hookVersionIDHeader, objInfo.VersionID)
+	}
+	if objInfo.ContentType != "" {
+		req.Header.Set(xhttp.ContentType, objInfo.ContentType)
+	}
+	for k, v := range objInfo.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			req.Header.Set(k, v)
+		}
+	}
+	if hook.AuthToken != "" {
+		req.Header.Set(xhttp.Authorization, "Bearer "+hook.AuthToken)
+	}
+
+	resp, err := bucketHooksClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		xhttp.DrainBody(resp.Body)
+		return nil, fmt.Errorf("hook %s returned %s", hook.Name, resp.Status)
+	}
+	if hook.Event == madmin.HookGet && resp.StatusCode != http.StatusOK {
+		xhttp.DrainBody(resp.Body)
+		return nil, fmt.Errorf("hook %s returned no content", hook.Name)
+	}
+	return resp, nil
+}
+
+var (
+	bucketHooksHTTPClient     *http.Client
+	bucketHooksHTTPClientOnce sync.Once
+)
+
+func bucketHooksClient() *http.Client {
+	bucketHooksHTTPClientOnce.Do(func() {
+		bucketHooksHTTPClient = &http.Client{Transport: NewGatewayHTTPTransport()}
+	})
+	return bucketHooksHTTPClient
+}
+
+// setTransformedObjectHeaders - replaces the headers of the object
+// describing its content with the ones of the get hook response.
+func setTransformedObjectHeaders(w http.ResponseWriter, resp *http.Response) {
+	w.Header().Del(xhttp.ContentLength)
+	if resp.ContentLength >= 0 {
+		w.Header().Set(xhttp.ContentLength, strconv.FormatInt(resp.ContentLength, 10))
+	}
+	if contentType := resp.Header.Get(xhttp.ContentType); contentType != "" {
+		w.Header().Set(xhttp.ContentType, contentType)
+	}
+	w.Header().Del(xhttp.ContentRange)
+	w.Header().Del(xhttp.ContentMD5)
+
+	// The ETag of the object does not identify the transformed
+	// content, which may change with the hook.
+	delete(w.Header(), xhttp.ETag)
+}
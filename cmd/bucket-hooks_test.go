@@ -0,0 +1,206 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseBucketHooks(t *testing.T) {
+	testCases := []struct {
+		hook    madmin.BucketHook
+		success bool
+	}{
+		{madmin.BucketHook{Name: "a", Event: madmin.HookGet, Endpoint: "http://localhost/a"}, true},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookGet, Endpoint: "http://localhost/a", OnFailure: madmin.HookIgnore}, true},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookPut, Endpoint: "https://localhost/a", TargetPrefix: "thumbs/", OnFailure: madmin.HookRetry}, true},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookPut, Endpoint: "https://localhost/a", TargetBucket: "thumbs"}, true},
+		// Derived objects overwriting their source.
+		{madmin.BucketHook{Name: "a", Event: madmin.HookPut, Endpoint: "https://localhost/a"}, false},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookPut, Endpoint: "https://localhost/a", TargetBucket: "bucket"}, false},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookGet, Endpoint: "http://localhost/a", OnFailure: madmin.HookRetry}, false},
+		{madmin.BucketHook{Name: "a", Event: "delete", Endpoint: "http://localhost/a"}, false},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookGet, Endpoint: "localhost/a"}, false},
+		{madmin.BucketHook{Name: "a", Event: madmin.HookGet, Endpoint: "http://localhost/a", Timeout: -time.Second}, false},
+		{madmin.BucketHook{Event: madmin.HookGet, Endpoint: "http://localhost/a"}, false},
+	}
+	for i, testCase := range testCases {
+		data, err := json.Marshal(madmin.BucketHooks{Hooks: []madmin.BucketHook{testCase.hook}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = parseBucketHooks("bucket", data); (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	hook := madmin.BucketHook{Name: "a", Event: madmin.HookGet, Endpoint: "http://localhost/a"}
+	data, err := json.Marshal(madmin.BucketHooks{Hooks: []madmin.BucketHook{hook, hook}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = parseBucketHooks("bucket", data); err == nil {
+		t.Errorf("expected duplicated hooks to be rejected")
+	}
+}
+
+// hookTestServer - transforms objects to upper case on
+// get hooks, and derives a suffixed object on put hooks.
+func hookTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil || r.Header.Get(hookObjectHeader) == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/upper":
+			w.Header().Set("Content-Type", "text/upper")
+			w.Write(bytes.ToUpper(data))
+		case "/derive":
+			w.Header().Set(hookDerivedObjectHeader, r.Header.Get(hookObjectHeader)+".derived")
+			w.Header().Set("Content-Type", "text/derived")
+			w.Write(append([]byte("derived "), data...))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+}
+
+func TestIsDerivedByHook(t *testing.T) {
+	testCases := []struct {
+		hook     madmin.BucketHook
+		object   string
+		expected bool
+	}{
+		{madmin.BucketHook{TargetPrefix: "thumbs/"}, "thumbs/a.jpg", true},
+		{madmin.BucketHook{TargetPrefix: "thumbs/"}, "a.jpg", false},
+		{madmin.BucketHook{TargetBucket: "other", TargetPrefix: "thumbs/"}, "thumbs/a.jpg", false},
+		{madmin.BucketHook{TargetBucket: "bucket", TargetPrefix: "thumbs/"}, "thumbs/a.jpg", true},
+		{madmin.BucketHook{TargetBucket: "other"}, "a.jpg", false},
+	}
+	for i, testCase := range testCases {
+		if got := isDerivedByHook(testCase.hook, "bucket", testCase.object); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestAPIBucketHooksHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIBucketHooksHandler, []string{"GetObject", "PutObject"})
+}
+
+func testAPIBucketHooksHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	ts := hookTestServer(t)
+	defer ts.Close()
+
+	hooks := madmin.BucketHooks{Hooks: []madmin.BucketHook{
+		{Name: "upper", Event: madmin.HookGet, Endpoint: ts.URL + "/upper", Prefix: "upper/"},
+		{Name: "ignored", Event: madmin.HookGet, Endpoint: ts.URL + "/fail", Prefix: "ignored/", OnFailure: madmin.HookIgnore},
+		{Name: "abort", Event: madmin.HookGet, Endpoint: ts.URL + "/fail", Prefix: "abort/"},
+		{Name: "derive", Event: madmin.HookPut, Endpoint: ts.URL + "/derive", Prefix: "put/", TargetPrefix: "derived/"},
+	}}
+	data, err := json.Marshal(hooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucketName, bucketHooksConfigFile, data); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	content := []byte("hello hooks")
+	testCases := []struct {
+		objectName         string
+		rangeHeader        string
+		expectedRespStatus int
+		expectedContent    string
+	}{
+		{"upper/a.txt", "", http.StatusOK, "HELLO HOOKS"},
+		// Transformed objects are returned whole.
+		{"upper/b.txt", "bytes=0-3", http.StatusOK, "HELLO HOOKS"},
+		{"ignored/c.txt", "", http.StatusOK, "hello hooks"},
+		{"abort/d.txt", "", http.StatusBadGateway, ""},
+		{"other/e.txt", "bytes=0-4", http.StatusPartialContent, "hello"},
+	}
+	for i, testCase := range testCases {
+		_, err = obj.PutObject(context.Background(), bucketName, testCase.objectName,
+			mustGetPutObjReader(t, bytes.NewReader(content), int64(len(content)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+		}
+
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("GET", getGetObjectURL("", bucketName, testCase.objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Get Object: <ERROR> %v", i+1, err)
+		}
+		if testCase.rangeHeader != "" {
+			req.Header.Set("Range", testCase.rangeHeader)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectedContent != "" && rec.Body.String() != testCase.expectedContent {
+			t.Fatalf("Test %d: %s: Expected the content %q, got %q", i+1, instanceType, testCase.expectedContent, rec.Body.String())
+		}
+		// Transformed objects are returned without the ETag of the object.
+		transformed := strings.HasPrefix(testCase.objectName, "upper/")
+		if etag := rec.Header()[xhttp.ETag]; rec.Code/100 == 2 && (len(etag) == 0) != transformed {
+			t.Fatalf("Test %d: %s: Unexpected ETag %q", i+1, instanceType, etag)
+		}
+	}
+
+	// Put hooks derive new objects in the background.
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, "put/f.txt"),
+		int64(len(content)), bytes.NewReader(content), credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request for Put Object: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	var buf bytes.Buffer
+	for i := 0; i < 100; i++ {
+		buf.Reset()
+		if err = obj.GetObject(context.Background(), bucketName, "derived/put/f.txt.derived", 0, -1, &buf, "", ObjectOptions{}); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil || buf.String() != "derived hello hooks" {
+		t.Fatalf("%s: Expected the object to be derived, got %q: %v", instanceType, buf.String(), err)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sync"
+	"time"
+)
+
+// bucketInfoCacheTTL bounds how long a bucket deleted by a peer which
+// could not notify this server is still seen as existing.
+const bucketInfoCacheTTL = time.Minute
+
+type bucketInfoCacheEntry struct {
+	info   BucketInfo
+	cached time.Time
+}
+
+// bucketInfoCache remembers the buckets known to exist, so that every
+// object operation checking its bucket doesn't stat the bucket on all
+// the drives. Only existing buckets are cached, a bucket made by a peer
+// is found on the drives the first time. The zero value is ready to use,
+// a nil cache caches nothing.
+type bucketInfoCache struct {
+	mu      sync.RWMutex
+	entries map[string]bucketInfoCacheEntry
+}
+
+// get returns the cached info of the bucket.
+func (c *bucketInfoCache) get(bucket string) (BucketInfo, bool) {
+	if c == nil {
+		return BucketInfo{}, false
+	}
+	c.mu.RLock()
+	entry, ok := c.entries[bucket]
+	c.mu.RUnlock()
+	if !ok || time.Since(entry.cached) > bucketInfoCacheTTL {
+		return BucketInfo{}, false
+	}
+	return entry.info, true
+}
+
+// set caches the info of an existing bucket.
+func (c *bucketInfoCache) set(info BucketInfo) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]bucketInfoCacheEntry)
+	}
+	c.entries[info.Name] = bucketInfoCacheEntry{info: info, cached: time.Now()}
+}
+
+// remove forgets the bucket, once deleted here or by a peer.
+func (c *bucketInfoCache) remove(bucket string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.entries, bucket)
+	c.mu.Unlock()
+}
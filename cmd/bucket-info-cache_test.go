@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestBucketInfoCache(t *testing.T) {
+	var c bucketInfoCache
+	if _, ok := c.get("bucket"); ok {
+		t.Fatal("expected an empty cache")
+	}
+	c.set(BucketInfo{Name: "bucket"})
+	if info, ok := c.get("bucket"); !ok || info.Name != "bucket" {
+		t.Fatalf("expected the cached bucket, got %v", info)
+	}
+
+	// Expired entries are looked up again.
+	c.entries["bucket"] = bucketInfoCacheEntry{info: BucketInfo{Name: "bucket"}, cached: time.Now().Add(-2 * bucketInfoCacheTTL)}
+	if _, ok := c.get("bucket"); ok {
+		t.Fatal("expected the entry to expire")
+	}
+
+	c.set(BucketInfo{Name: "bucket"})
+	c.remove("bucket")
+	if _, ok := c.get("bucket"); ok {
+		t.Fatal("expected the bucket to be removed")
+	}
+
+	var nilCache *bucketInfoCache
+	nilCache.set(BucketInfo{Name: "bucket"})
+	if _, ok := nilCache.get("bucket"); ok {
+		t.Fatal("expected a nil cache to cache nothing")
+	}
+}
+
+func TestErasureZonesBucketInfoCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureZones)
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetBucketInfo(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := z.bucketCache.get("bucket"); !ok {
+		t.Fatal("expected the bucket to be cached")
+	}
+
+	// Cached buckets are not looked up on the drives.
+	for _, dir := range fsDirs {
+		if err = os.RemoveAll(pathJoin(dir, "bucket")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = obj.GetBucketInfo(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	// The checks of the sets use the cache as well.
+	if err = checkPutObjectArgs(ctx, "bucket", "object", z.zones[0].sets[0], 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = checkListObjsArgs(ctx, "bucket", "", "", z.zones[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	z.forgetBucket("bucket")
+	if _, err = obj.GetBucketInfo(ctx, "bucket"); !isErrBucketNotFound(err) {
+		t.Fatalf("expected the bucket not to be found, got %v", err)
+	}
+
+	if err = obj.MakeBucketWithLocation(ctx, "other", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetBucketInfo(ctx, "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.DeleteBucket(ctx, "other", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetBucketInfo(ctx, "other"); !isErrBucketNotFound(err) {
+		t.Fatalf("expected the deleted bucket not to be found, got %v", err)
+	}
+}
+
+func TestErasureZonesBucketInfoCachePerZone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	endpointZones := append(mustGetZoneEndpoints(disks[:4]...), mustGetZoneEndpoints(disks[4:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	z := obj.(*erasureZones)
+
+	// The bucket is only on the first zone, as if it was not
+	// yet healed on the second one.
+	if err = z.zones[0].MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetBucketInfo(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.zones[0].GetBucketInfo(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.zones[1].GetBucketInfo(ctx, "bucket"); !isErrBucketNotFound(err) {
+		t.Fatalf("expected the bucket not to be found on the second zone, got %v", err)
+	}
+	if err = checkPutObjectArgs(ctx, "bucket", "object", z.zones[1].sets[0], 0); !isErrBucketNotFound(err) {
+		t.Fatalf("expected the bucket not to be found on the set of the second zone, got %v", err)
+	}
+
+	if err = z.zones[1].MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.zones[1].GetBucketInfo(ctx, "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.DeleteBucket(ctx, "bucket", false); err != nil {
+		t.Fatal(err)
+	}
+	for i, zone := range z.zones {
+		if _, ok := zone.bucketCache.get("bucket"); ok {
+			t.Fatalf("expected the deleted bucket to be removed from the cache of zone %d", i+1)
+		}
+	}
+}
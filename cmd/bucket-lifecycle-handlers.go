@@ -18,7 +18,6 @@ package cmd
 
 import (
 	"encoding/xml"
-	"io"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -66,7 +65,11 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
-	bucketLifecycle, err := lifecycle.ParseLifecycleConfig(io.LimitReader(r.Body, r.ContentLength))
+	// The max. XML contains 1000 rules (each at most 1 KiB long) + XML overhead
+	const maxBodySize = 2 * 1000 * 1024
+
+	bucketLifecycle := &lifecycle.Lifecycle{}
+	err := xmlDecoder(r.Body, bucketLifecycle, maxBodySize)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -78,6 +81,17 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	// Objects can only be transitioned to configured remote tiers.
+	for _, rule := range bucketLifecycle.Rules {
+		if rule.Transition.StorageClass == "" {
+			continue
+		}
+		if _, ok := globalTierConfig.Get(rule.Transition.StorageClass); !ok {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	configData, err := xml.Marshal(bucketLifecycle)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -0,0 +1,347 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/cmd/config/tier"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// Reserved metadata of the stubs of objects transitioned to a remote tier.
+const (
+	transitionStatusKey = ReservedMetadataPrefix + "transition-status"
+	transitionTierKey   = ReservedMetadataPrefix + "transition-tier"
+	transitionObjectKey = ReservedMetadataPrefix + "transition-object"
+	transitionSizeKey   = ReservedMetadataPrefix + "transition-size"
+	transitionETagKey   = ReservedMetadataPrefix + "transition-etag"
+	restoreExpiryKey    = ReservedMetadataPrefix + "restore-expiry"
+)
+
+// applyTransitionMetadata - the stub of a transitioned object holds
+// no data, its size, etag and storage class are the ones saved in its
+// metadata when it was transitioned.
+func applyTransitionMetadata(oi *ObjectInfo, metadata map[string]string) {
+	status, ok := metadata[transitionStatusKey]
+	if !ok {
+		return
+	}
+	oi.TransitionStatus = status
+	oi.StorageClass = metadata[transitionTierKey]
+	oi.ETag = metadata[transitionETagKey]
+	if size, err := strconv.ParseInt(metadata[transitionSizeKey], 10, 64); err == nil {
+		oi.Size = size
+	}
+}
+
+// removeTransitionMetadata removes the transition metadata, used
+// when the data of a transitioned object is copied back locally.
+func removeTransitionMetadata(metadata map[string]string) {
+	for _, k := range []string{transitionStatusKey, transitionTierKey, transitionObjectKey,
+		transitionSizeKey, transitionETagKey, restoreExpiryKey} {
+		delete(metadata, k)
+	}
+}
+
+// restoreExpiry returns the time until which a transitioned
+// object is restored, the zero time if it is not restored.
+func restoreExpiry(oi ObjectInfo) time.Time {
+	expiry, err := time.Parse(time.RFC3339, oi.UserDefined[restoreExpiryKey])
+	if err != nil {
+		return time.Time{}
+	}
+	return expiry
+}
+
+// isRestored returns true if the data of a transitioned object can be read.
+func isRestored(oi ObjectInfo, t tier.Tier) bool {
+	return t.Type != tier.Glacier || restoreExpiry(oi).After(UTCNow())
+}
+
+// newTierClient returns a client of the remote tier.
+func newTierClient(t tier.Tier) (*miniogo.Core, error) {
+	return newRemoteS3Client(t.Endpoint, t.AccessKey, t.SecretKey, t.Region)
+}
+
+// tierErrToObjectErr converts the errors of a remote tier.
+func tierErrToObjectErr(err error, bucket, object string) error {
+	if miniogo.ToErrorResponse(err).Code == "InvalidObjectState" {
+		return InvalidObjectState{Bucket: bucket, Object: object}
+	}
+	return ErrorRespToObjectError(err, bucket, object)
+}
+
+// transitionObject moves the data of an object to the remote tier
+// tierName, leaving an empty stub with the metadata of the object in
+// its place. The object is left untouched if it was modified while
+// its data was being uploaded.
+func transitionObject(ctx context.Context, objAPI ObjectLayer, bucket, object, versionID, tierName string) error {
+	ctx = withIOClass(ctx, ioClassLifecycle)
+	t, ok := globalTierConfig.Get(tierName)
+	if !ok {
+		return fmt.Errorf("remote tier %s of bucket %s is not configured", tierName, bucket)
+	}
+	clnt, err := newTierClient(t)
+	if err != nil {
+		return err
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	oi := gr.ObjInfo
+	// Encrypted objects can only be decrypted with their
+	// client keys, they are never transitioned.
+	if oi.TransitionStatus != "" || oi.IsDir || oi.DeleteMarker || crypto.IsEncrypted(oi.UserDefined) {
+		return nil
+	}
+	size, err := oi.GetActualSize()
+	if err != nil || size == 0 {
+		return err
+	}
+
+	remoteObject := path.Join(t.Prefix, bucket, mustGetUUID())
+	if _, err = clnt.Client.PutObject(ctx, t.Bucket, remoteObject, gr, size, miniogo.PutObjectOptions{
+		ContentType:  oi.ContentType,
+		StorageClass: t.StorageClass,
+	}); err != nil {
+		return err
+	}
+	// The object lock has to be released before writing the stub.
+	gr.Close()
+
+	metadata := cleanMetadataKeys(oi.UserDefined, ReservedMetadataPrefix+"compression",
+		ReservedMetadataPrefix+"actual-size", xhttp.AmzStorageClass)
+	metadata[transitionStatusKey] = lifecycle.TransitionComplete
+	metadata[transitionTierKey] = tierName
+	metadata[transitionObjectKey] = remoteObject
+	metadata[transitionSizeKey] = strconv.FormatInt(size, 10)
+	metadata[transitionETagKey] = oi.ETag
+	if oi.UserTags != "" {
+		metadata[xhttp.AmzObjectTagging] = oi.UserTags
+	}
+	if !oi.Expires.IsZero() {
+		metadata["expires"] = oi.Expires.Format(http.TimeFormat)
+	}
+	if _, isFS := objAPI.(*FSObjects); isFS {
+		metadata[fsModTimeKey] = oi.ModTime.Format(time.RFC3339Nano)
+	}
+
+	opts := ObjectOptions{
+		MTime:       oi.ModTime,
+		UserDefined: metadata,
+		CheckPrecondFn: func(cur ObjectInfo) bool {
+			return cur.TransitionStatus != "" || cur.ETag != oi.ETag || !cur.ModTime.Equal(oi.ModTime)
+		},
+	}
+	if oi.VersionID != "" && oi.VersionID != nullVersionID {
+		opts.Versioned = true
+		opts.VersionID = oi.VersionID
+	}
+	hr, err := hash.NewReader(bytes.NewReader(nil), 0, "", "", 0, globalCLIContext.StrictS3Compat)
+	if err == nil {
+		_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hr, nil, nil), opts)
+	}
+	if err != nil {
+		// The object is still stored locally, remove its remote copy.
+		if rerr := clnt.Client.RemoveObject(context.Background(), t.Bucket, remoteObject, miniogo.RemoveObjectOptions{}); rerr != nil {
+			logger.LogIf(ctx, rerr)
+		}
+		if isErrPreconditionFailed(err) || isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// getTransitionedObjectReader returns a reader of the data of an
+// object transitioned to a remote tier, the cleanup functions are
+// called when the reader is closed.
+func getTransitionedObjectReader(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, oi ObjectInfo, opts ObjectOptions, cleanUpFns ...func()) (gr *GetObjectReader, err error) {
+	cleanUp := func() {
+		for i := len(cleanUpFns) - 1; i >= 0; i-- {
+			cleanUpFns[i]()
+		}
+	}
+
+	t, ok := globalTierConfig.Get(oi.UserDefined[transitionTierKey])
+	if !ok {
+		cleanUp()
+		logger.LogIf(ctx, fmt.Errorf("remote tier %s of %s/%s is not configured", oi.UserDefined[transitionTierKey], bucket, object))
+		return nil, BackendDown{}
+	}
+	if !isRestored(oi, t) {
+		cleanUp()
+		return nil, InvalidObjectState{Bucket: bucket, Object: object}
+	}
+	clnt, err := newTierClient(t)
+	if err != nil {
+		cleanUp()
+		return nil, err
+	}
+
+	fn, off, length, err := NewGetObjectReader(ctx, rs, oi, opts, cleanUpFns...)
+	if err != nil {
+		return nil, err
+	}
+	gopts := miniogo.GetObjectOptions{}
+	if length > 0 {
+		if err = gopts.SetRange(off, off+length-1); err != nil {
+			cleanUp()
+			return nil, err
+		}
+	}
+	reader, _, _, err := clnt.GetObject(ctx, t.Bucket, oi.UserDefined[transitionObjectKey], gopts)
+	if err != nil {
+		cleanUp()
+		return nil, tierErrToObjectErr(err, bucket, object)
+	}
+	return fn(reader, h, opts.CheckCopyPrecondFn, func() { reader.Close() })
+}
+
+// restoreObjectRequest is the restore request forwarded to Glacier tiers.
+type restoreObjectRequest struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ RestoreRequest"`
+	Days    int      `xml:"Days"`
+}
+
+// restoreRemoteObject requests the restore of an object of a Glacier tier.
+func restoreRemoteObject(ctx context.Context, t tier.Tier, remoteObject string, days int) error {
+	clnt, err := newTierClient(t)
+	if err != nil {
+		return err
+	}
+	body, err := xml.Marshal(restoreObjectRequest{Days: days})
+	if err != nil {
+		return err
+	}
+
+	u := *clnt.EndpointURL()
+	u.Path = path.Join(SlashSeparator, t.Bucket, remoteObject)
+	u.RawQuery = "restore"
+	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	md5Sum := md5.Sum(body)
+	sha256Sum := sha256.Sum256(body)
+	req.Header.Set(xhttp.ContentMD5, base64.StdEncoding.EncodeToString(md5Sum[:]))
+	req.Header.Set(xhttp.AmzContentSha256, hex.EncodeToString(sha256Sum[:]))
+	req.ContentLength = int64(len(body))
+	region := t.Region
+	if region == "" {
+		if region, err = clnt.GetBucketLocation(ctx, t.Bucket); err != nil {
+			return err
+		}
+	}
+	req = signer.SignV4(*req, t.AccessKey, t.SecretKey, "", region)
+
+	resp, err := remoteS3Transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer xhttp.DrainBody(resp.Body)
+	// A restore already in progress is not an error.
+	if resp.StatusCode/100 == 2 || resp.StatusCode == http.StatusConflict {
+		return nil
+	}
+	return fmt.Errorf("restore of %s on remote tier %s failed: %s", remoteObject, t.Name, resp.Status)
+}
+
+// restoreTransitionedObject makes a transitioned object readable for
+// the given number of days, the restore request is forwarded to Glacier
+// tiers. Returns true if the object was already restored.
+func restoreTransitionedObject(ctx context.Context, objAPI ObjectLayer, oi ObjectInfo, days int) (restored bool, err error) {
+	t, ok := globalTierConfig.Get(oi.UserDefined[transitionTierKey])
+	if !ok || t.Type != tier.Glacier {
+		return false, InvalidObjectState{Bucket: oi.Bucket, Object: oi.Name}
+	}
+	restored = isRestored(oi, t)
+	if err = restoreRemoteObject(ctx, t, oi.UserDefined[transitionObjectKey], days); err != nil {
+		return restored, err
+	}
+
+	// Restored objects expire at midnight UTC after the restore period.
+	now := UTCNow()
+	expiry := time.Date(now.Year(), now.Month(), now.Day()+days+1, 0, 0, 0, 0, time.UTC)
+
+	srcInfo := oi
+	srcInfo.metadataOnly = true
+	srcInfo.UserDefined = make(map[string]string, len(oi.UserDefined)+3)
+	for k, v := range oi.UserDefined {
+		srcInfo.UserDefined[k] = v
+	}
+	srcInfo.UserDefined[restoreExpiryKey] = expiry.Format(time.RFC3339)
+	if oi.UserTags != "" {
+		srcInfo.UserDefined[xhttp.AmzObjectTagging] = oi.UserTags
+	}
+	if !oi.Expires.IsZero() {
+		srcInfo.UserDefined["expires"] = oi.Expires.Format(http.TimeFormat)
+	}
+	if _, isFS := objAPI.(*FSObjects); isFS {
+		srcInfo.UserDefined[fsModTimeKey] = oi.ModTime.Format(time.RFC3339Nano)
+	}
+
+	// Metadata updates of an object are not locked by the object layer.
+	lk := objAPI.NewNSLock(ctx, oi.Bucket, oi.Name)
+	if err = lk.GetLock(globalObjectTimeout); err != nil {
+		return restored, err
+	}
+	defer lk.Unlock()
+
+	opts := ObjectOptions{
+		VersionID: oi.VersionID,
+		CheckPrecondFn: func(cur ObjectInfo) bool {
+			return cur.TransitionStatus == "" || cur.ETag != oi.ETag || !cur.ModTime.Equal(oi.ModTime)
+		},
+	}
+	_, err = objAPI.CopyObject(ctx, oi.Bucket, oi.Name, oi.Bucket, oi.Name, srcInfo, opts, opts)
+	return restored, err
+}
+
+// transitionedObjectRestoreHeader returns the x-amz-restore header of a
+// transitioned object, empty if the object is not restored.
+func transitionedObjectRestoreHeader(oi ObjectInfo) string {
+	expiry := restoreExpiry(oi)
+	if expiry.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf(`ongoing-request="false", expiry-date="%s"`, expiry.Format(http.TimeFormat))
+}
@@ -0,0 +1,280 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/tier"
+)
+
+// fakeTier is a minimal S3 server storing objects in memory.
+type fakeTier struct {
+	mu       sync.Mutex
+	objects  map[string][]byte
+	headers  map[string]http.Header
+	restores int
+}
+
+func newFakeTier() *fakeTier {
+	return &fakeTier{objects: make(map[string][]byte), headers: make(map[string]http.Header)}
+}
+
+func (f *fakeTier) reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.objects = make(map[string][]byte)
+	f.headers = make(map[string]http.Header)
+	f.restores = 0
+}
+
+func (f *fakeTier) objectHeaders(w http.ResponseWriter, object string) {
+	for k, v := range f.headers[object] {
+		w.Header()[k] = v
+	}
+	w.Header().Set("ETag", `"`+getMD5Hash(f.objects[object])+`"`)
+}
+
+func (f *fakeTier) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Last-Modified", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+	switch r.Method {
+	case http.MethodPut:
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.objects[r.URL.Path] = data
+		f.headers[r.URL.Path] = http.Header{}
+		for k, v := range r.Header {
+			if k == "Content-Type" || strings.HasPrefix(k, "X-Amz-Meta-") || k == "X-Amz-Tagging" ||
+				k == "X-Amz-Replication-Status" {
+				f.headers[r.URL.Path][k] = v
+			}
+		}
+		w.Header().Set("ETag", `"`+getMD5Hash(data)+`"`)
+	case http.MethodHead:
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			// Buckets always exist.
+			if strings.Count(strings.Trim(r.URL.Path, SlashSeparator), SlashSeparator) == 0 {
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.objectHeaders(w, r.URL.Path)
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	case http.MethodGet:
+		if strings.Count(strings.Trim(r.URL.Path, SlashSeparator), SlashSeparator) == 0 {
+			f.listObjects(w, r)
+			return
+		}
+		data, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.objectHeaders(w, r.URL.Path)
+		http.ServeContent(w, r, "", time.Unix(0, 0), bytes.NewReader(data))
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		delete(f.headers, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPost:
+		f.restores++
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// listObjects - lists the objects of the bucket of the request
+// after its marker, in a single page.
+func (f *fakeTier) listObjects(w http.ResponseWriter, r *http.Request) {
+	type contents struct {
+		Key          string
+		Size         int
+		ETag         string
+		LastModified string
+	}
+	result := struct {
+		XMLName     xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+		Name        string
+		IsTruncated bool
+		Contents    []contents
+	}{Name: strings.Trim(r.URL.Path, SlashSeparator)}
+
+	prefix := SlashSeparator + result.Name + SlashSeparator
+	marker := r.URL.Query().Get("marker")
+	for name, data := range f.objects {
+		if key := strings.TrimPrefix(name, prefix); key != name && key > marker {
+			result.Contents = append(result.Contents, contents{
+				Key:          key,
+				Size:         len(data),
+				ETag:         `"` + getMD5Hash(data) + `"`,
+				LastModified: time.Unix(0, 0).UTC().Format(iso8601TimeFormat),
+			})
+		}
+	}
+	sort.Slice(result.Contents, func(i, j int) bool {
+		return result.Contents[i].Key < result.Contents[j].Key
+	})
+	data, err := xml.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.Write(data)
+}
+
+// startFakeTier - starts a fake tier over TLS trusted by the
+// remote S3 clients, returns its URL and a function stopping it.
+func startFakeTier() (*fakeTier, string, func()) {
+	remote := newFakeTier()
+	ts := httptest.NewTLSServer(remote)
+
+	oldRootCAs := globalRootCAs
+	globalRootCAs = x509.NewCertPool()
+	globalRootCAs.AddCert(ts.Certificate())
+	remoteS3TransportOnce = sync.Once{}
+	return remote, ts.URL, func() {
+		ts.Close()
+		globalRootCAs = oldRootCAs
+		remoteS3TransportOnce = sync.Once{}
+	}
+}
+
+func TestTransitionObject(t *testing.T) {
+	remote, url, stop := startFakeTier()
+	defer stop()
+
+	tiers := tier.Config{Tiers: map[string]tier.Tier{
+		"WARM":    {Name: "WARM", Type: tier.S3, Endpoint: url, AccessKey: "minio", SecretKey: "minio123", Bucket: "warm", Region: "us-east-1"},
+		"GLACIER": {Name: "GLACIER", Type: tier.Glacier, Endpoint: url, AccessKey: "minio", SecretKey: "minio123", Bucket: "cold", Region: "us-east-1"},
+	}}
+	defer func() {
+		globalTierConfig = tier.Config{}
+	}()
+
+	ExecObjectLayerTest(t, func(obj ObjectLayer, instanceType string, t TestErrHandler) {
+		globalTierConfig = tiers
+		testTransitionObject(obj, instanceType, remote, t)
+	})
+}
+
+func testTransitionObject(obj ObjectLayer, instanceType string, remote *fakeTier, t TestErrHandler) {
+	remote.reset()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	data := []byte("hello transitioned world")
+	for _, object := range []string{"warm", "cold"} {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+	before, err := obj.GetObjectInfo(ctx, bucket, "warm", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	if err = transitionObject(ctx, obj, bucket, "warm", "", "WARM"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	oi, err := obj.GetObjectInfo(ctx, bucket, "warm", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if oi.TransitionStatus == "" || oi.StorageClass != "WARM" || oi.Size != before.Size ||
+		oi.ETag != before.ETag || !oi.ModTime.Equal(before.ModTime) {
+		t.Fatalf("%s: unexpected object info of the transitioned object %#v", instanceType, oi)
+	}
+
+	// Transitioned objects are not transitioned again.
+	if err = transitionObject(ctx, obj, bucket, "warm", "", "WARM"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(remote.objects) != 1 {
+		t.Fatalf("%s: expected one remote object, got %d", instanceType, len(remote.objects))
+	}
+
+	var buf bytes.Buffer
+	gr, err := obj.GetObjectNInfo(ctx, bucket, "warm", &HTTPRangeSpec{Start: 6, End: 17}, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	_, err = buf.ReadFrom(gr)
+	gr.Close()
+	if err != nil || buf.String() != "transitioned" {
+		t.Fatalf("%s: unexpected content of the transitioned object %q: %v", instanceType, buf.String(), err)
+	}
+
+	// Objects of Glacier tiers have to be restored to be read.
+	if err = transitionObject(ctx, obj, bucket, "cold", "", "GLACIER"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err = obj.GetObjectNInfo(ctx, bucket, "cold", nil, nil, readLock, ObjectOptions{}); err == nil {
+		t.Fatalf("%s: expected the object to require a restore", instanceType)
+	} else if _, ok := err.(InvalidObjectState); !ok {
+		t.Fatalf("%s: expected InvalidObjectState, got %v", instanceType, err)
+	}
+	oi, err = obj.GetObjectInfo(ctx, bucket, "cold", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err = restoreTransitionedObject(ctx, obj, oi, 1); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	oi, err = obj.GetObjectInfo(ctx, bucket, "cold", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if restored, err := restoreTransitionedObject(ctx, obj, oi, 1); err != nil || !restored {
+		t.Fatalf("%s: expected the object to be restored: %v", instanceType, err)
+	}
+	if remote.restores != 2 {
+		t.Fatalf("%s: expected the restores to be forwarded to the tier", instanceType)
+	}
+	buf.Reset()
+	gr, err = obj.GetObjectNInfo(ctx, bucket, "cold", nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	_, err = buf.ReadFrom(gr)
+	gr.Close()
+	if err != nil || buf.String() != string(data) {
+		t.Fatalf("%s: unexpected content of the restored object %q: %v", instanceType, buf.String(), err)
+	}
+}
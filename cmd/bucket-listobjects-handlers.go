@@ -117,6 +117,20 @@ func (api objectAPIHandlers) ListObjectVersionsHandler(w http.ResponseWriter, r
 	writeSuccessResponseXML(w, encodeResponse(response))
 }
 
+// filterListedObjectTags - removes the tags of the listed objects the
+// request is not allowed to get the tags of with s3:GetObjectTagging,
+// the listing itself is only checked against s3:ListBucket.
+func filterListedObjectTags(ctx context.Context, r *http.Request, bucket string, objects []ObjectInfo) {
+	for i := range objects {
+		if objects[i].UserTags == "" {
+			continue
+		}
+		if checkRequestAuthType(ctx, r, policy.GetObjectTaggingAction, bucket, objects[i].Name) != ErrNone {
+			objects[i].UserTags = ""
+		}
+	}
+}
+
 // ListObjectsV2MHandler - GET Bucket (List Objects) Version 2 with metadata.
 // --------------------------
 // This implementation of the GET operation returns some or all (up to 10000)
@@ -160,6 +174,15 @@ func (api objectAPIHandlers) ListObjectsV2MHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// MinIO extension, min-mtime, max-mtime, min-size and max-size
+	// list only the objects modified and sized within the bounds.
+	filter, errCode := getListFilterArgs(urlValues)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	ctx = withListFilter(ctx, filter)
+
 	// Analyze continuation token and route the request accordingly
 	var success bool
 	token, success = proxyRequestByToken(ctx, w, r, token)
@@ -178,6 +201,9 @@ func (api objectAPIHandlers) ListObjectsV2MHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// The gateways don't filter the objects while listing them.
+	listObjectsV2Info.Objects = filter.filterObjects(listObjectsV2Info.Objects)
+
 	for i := range listObjectsV2Info.Objects {
 		if crypto.IsEncrypted(listObjectsV2Info.Objects[i].UserDefined) {
 			listObjectsV2Info.Objects[i].ETag = getDecryptedETag(r.Header, listObjectsV2Info.Objects[i], false)
@@ -195,6 +221,8 @@ func (api objectAPIHandlers) ListObjectsV2MHandler(w http.ResponseWriter, r *htt
 		nextContinuationToken = fmt.Sprintf("%s@%d", listObjectsV2Info.NextContinuationToken, getLocalNodeIndex())
 	}
 
+	filterListedObjectTags(ctx, r, bucket, listObjectsV2Info.Objects)
+
 	response := generateListObjectsV2Response(bucket, prefix, token, nextContinuationToken, startAfter,
 		delimiter, encodingType, fetchOwner, listObjectsV2Info.IsTruncated,
 		maxKeys, listObjectsV2Info.Objects, listObjectsV2Info.Prefixes, true)
@@ -246,6 +274,15 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// MinIO extension, min-mtime, max-mtime, min-size and max-size
+	// list only the objects modified and sized within the bounds.
+	filter, errCode := getListFilterArgs(urlValues)
+	if errCode != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(errCode), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	ctx = withListFilter(ctx, filter)
+
 	// Analyze continuation token and route the request accordingly
 	var success bool
 	token, success = proxyRequestByToken(ctx, w, r, token)
@@ -264,6 +301,9 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// The gateways don't filter the objects while listing them.
+	listObjectsV2Info.Objects = filter.filterObjects(listObjectsV2Info.Objects)
+
 	for i := range listObjectsV2Info.Objects {
 		if crypto.IsEncrypted(listObjectsV2Info.Objects[i].UserDefined) {
 			listObjectsV2Info.Objects[i].ETag = getDecryptedETag(r.Header, listObjectsV2Info.Objects[i], false)
@@ -368,8 +408,10 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	urlValues := r.URL.Query()
+
 	// Extract all the litsObjectsV1 query params to their native values.
-	prefix, marker, delimiter, maxKeys, encodingType, s3Error := getListObjectsV1Args(r.URL.Query())
+	prefix, marker, delimiter, maxKeys, encodingType, s3Error := getListObjectsV1Args(urlValues)
 	if s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
@@ -381,6 +423,15 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// MinIO extension, min-mtime, max-mtime, min-size and max-size
+	// list only the objects modified and sized within the bounds.
+	filter, s3Error := getListFilterArgs(urlValues)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	ctx = withListFilter(ctx, filter)
+
 	if proxyRequestByBucket(ctx, w, r, bucket) {
 		return
 	}
@@ -396,6 +447,9 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 		return
 	}
 
+	// The gateways don't filter the objects while listing them.
+	filter.filterListObjectsInfo(&listObjectsInfo)
+
 	for i := range listObjectsInfo.Objects {
 		if crypto.IsEncrypted(listObjectsInfo.Objects[i].UserDefined) {
 			listObjectsInfo.Objects[i].ETag = getDecryptedETag(r.Header, listObjectsInfo.Objects[i], false)
@@ -407,7 +461,14 @@ func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http
 		}
 	}
 
-	response := generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType, maxKeys, listObjectsInfo)
+	// MinIO extension, metadata=true includes the user metadata
+	// and the tags of the objects, the tags when allowed.
+	metadata := urlValues.Get("metadata") == "true"
+	if metadata {
+		filterListedObjectTags(ctx, r, bucket, listObjectsInfo.Objects)
+	}
+
+	response := generateListObjectsV1Response(bucket, prefix, marker, delimiter, encodingType, maxKeys, listObjectsInfo, metadata)
 
 	// Write success response.
 	writeSuccessResponseXML(w, encodeResponse(response))
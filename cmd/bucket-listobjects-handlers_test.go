@@ -0,0 +1,81 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/bucket/policy"
+)
+
+func TestFilterListedObjectTags(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = objLayer
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+
+	defer func(sys *BucketMetadataSys, policySys *PolicySys) {
+		globalBucketMetadataSys = sys
+		globalPolicySys = policySys
+	}(globalBucketMetadataSys, globalPolicySys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalPolicySys = NewPolicySys()
+
+	// Anonymous users may list the bucket but only get the tags
+	// of the objects under public/.
+	bucketPolicy, err := policy.ParseConfig(strings.NewReader(`{
+		"Version": "2012-10-17",
+		"Statement": [
+			{"Effect": "Allow", "Principal": {"AWS": ["*"]}, "Action": ["s3:ListBucket"], "Resource": ["arn:aws:s3:::bucket"]},
+			{"Effect": "Allow", "Principal": {"AWS": ["*"]}, "Action": ["s3:GetObjectTagging"], "Resource": ["arn:aws:s3:::bucket/public/*"]}
+		]
+	}`), "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata("bucket")
+	meta.policyConfig = bucketPolicy
+	globalBucketMetadataSys.Set("bucket", meta)
+
+	objects := []ObjectInfo{
+		{Bucket: "bucket", Name: "public/object", UserTags: "key=value"},
+		{Bucket: "bucket", Name: "private/object", UserTags: "key=value"},
+	}
+	r := httptest.NewRequest(http.MethodGet, "http://localhost/bucket?metadata=true", nil)
+	filterListedObjectTags(context.Background(), r, "bucket", objects)
+	if objects[0].UserTags != "key=value" {
+		t.Fatalf("Expected the tags of the public object, got %q", objects[0].UserTags)
+	}
+	if objects[1].UserTags != "" {
+		t.Fatalf("Expected no tags for the private object, got %q", objects[1].UserTags)
+	}
+}
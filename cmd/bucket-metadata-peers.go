@@ -0,0 +1,130 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// bucketMetadataRetryInterval - how often the bucket metadata changes
+// which did not reach a peer are sent again.
+const bucketMetadataRetryInterval = 10 * time.Second
+
+// bucketMetadataPending remembers, for every peer, the buckets whose
+// metadata changed while the peer could not be reached. They are sent
+// again until the peer gets them, instead of the peer serving the old
+// metadata until it restarts.
+type bucketMetadataPending struct {
+	mu      sync.Mutex
+	buckets map[*peerRESTClient]map[string]struct{}
+}
+
+// update records whether the bucket metadata reached the peer.
+func (p *bucketMetadataPending) update(client *peerRESTClient, bucket string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		delete(p.buckets[client], bucket)
+		if len(p.buckets[client]) == 0 {
+			delete(p.buckets, client)
+		}
+		return
+	}
+	if p.buckets == nil {
+		p.buckets = make(map[*peerRESTClient]map[string]struct{})
+	}
+	if p.buckets[client] == nil {
+		p.buckets[client] = make(map[string]struct{})
+	}
+	p.buckets[client][bucket] = struct{}{}
+}
+
+// list returns the pending buckets of every peer.
+func (p *bucketMetadataPending) list() map[*peerRESTClient][]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pending := make(map[*peerRESTClient][]string, len(p.buckets))
+	for client, buckets := range p.buckets {
+		for bucket := range buckets {
+			pending[client] = append(pending[client], bucket)
+		}
+	}
+	return pending
+}
+
+// syncPeerBucketMetadata sends the current metadata of the bucket to the
+// peer, the peer reloads it or forgets a deleted bucket. The latest
+// change is sent whichever change did not reach the peer.
+func syncPeerBucketMetadata(client *peerRESTClient, bucket string) error {
+	if _, err := globalBucketMetadataSys.Get(bucket); err == errConfigNotFound {
+		return client.DeleteBucketMetadata(bucket)
+	}
+	return client.LoadBucketMetadata(bucket)
+}
+
+// retryBucketMetadata sends the pending bucket metadata to the peers
+// until they get it.
+func (sys *NotificationSys) retryBucketMetadata(ctx context.Context) {
+	ticker := time.NewTicker(bucketMetadataRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for client, buckets := range sys.bucketMetadataPending.list() {
+				for _, bucket := range buckets {
+					err := syncPeerBucketMetadata(client, bucket)
+					sys.bucketMetadataPending.update(client, bucket, err)
+					if err != nil {
+						// The peer is still unreachable.
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// peerBucketMetadata sends the metadata change of the bucket to all the peers.
+func (sys *NotificationSys) peerBucketMetadata(ctx context.Context, bucketName string, send func(client *peerRESTClient) error) {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return send(client)
+		}, idx, *client.host)
+	}
+	for idx, nErr := range ng.Wait() {
+		if sys.peerClients[idx] == nil {
+			continue
+		}
+		sys.bucketMetadataPending.update(sys.peerClients[idx], bucketName, nErr.Err)
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
+		if nErr.Err != nil {
+			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestBucketMetadataPending(t *testing.T) {
+	var p bucketMetadataPending
+	peer1, peer2 := &peerRESTClient{}, &peerRESTClient{}
+	errOffline := errors.New("offline")
+
+	p.update(peer1, "bucket1", nil)
+	if pending := p.list(); len(pending) != 0 {
+		t.Fatalf("expected nothing pending, got %v", pending)
+	}
+
+	p.update(peer1, "bucket1", errOffline)
+	p.update(peer1, "bucket2", errOffline)
+	p.update(peer2, "bucket1", errOffline)
+	pending := p.list()
+	sort.Strings(pending[peer1])
+	if !reflect.DeepEqual(pending[peer1], []string{"bucket1", "bucket2"}) || !reflect.DeepEqual(pending[peer2], []string{"bucket1"}) {
+		t.Fatalf("unexpected pending buckets %v", pending)
+	}
+
+	// A later change reaching the peer clears the bucket.
+	p.update(peer1, "bucket1", nil)
+	p.update(peer2, "bucket1", nil)
+	pending = p.list()
+	if len(pending) != 1 || !reflect.DeepEqual(pending[peer1], []string{"bucket2"}) {
+		t.Fatalf("unexpected pending buckets %v", pending)
+	}
+}
+
+func TestDeleteBucketMetadataRemovesNotification(t *testing.T) {
+	defer func(sys *BucketMetadataSys) { globalBucketMetadataSys = sys }(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	sys := &NotificationSys{
+		targetList:                 event.NewTargetList(),
+		bucketRulesMap:             make(map[string]event.RulesMap),
+		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
+	}
+	targetID := event.TargetID{ID: "1", Name: "webhook"}
+	sys.AddRulesMap("bucket", event.NewRulesMap([]event.Name{event.ObjectCreatedAll}, "*", targetID))
+	if _, ok := sys.bucketRulesMap["bucket"]; !ok {
+		t.Fatal("expected the rules of the bucket")
+	}
+
+	sys.DeleteBucketMetadata(context.Background(), "bucket")
+	if _, ok := sys.bucketRulesMap["bucket"]; ok {
+		t.Fatal("expected the rules of the deleted bucket to be removed")
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
@@ -153,6 +154,18 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		meta.VersioningConfigXML = configData
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
+	case bucketHooksConfigFile:
+		meta.HooksConfigJSON = configData
+	case bucketReplicationConfig:
+		meta.ReplicationConfigXML = configData
+	case bucketTargetsFile:
+		meta.BucketTargetsConfigJSON = configData
+	case bucketReadReplicaConfigFile:
+		meta.ReadReplicaConfigJSON = configData
+	case bucketBandwidthConfigFile:
+		meta.BandwidthConfigJSON = configData
+	case bucketSecureEraseConfigFile:
+		meta.SecureEraseConfigJSON = configData
 	default:
 		return fmt.Errorf("Unknown bucket %s metadata update requested %s", bucket, configFile)
 	}
@@ -318,6 +331,73 @@ func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*madmin.BucketQuota
 	return meta.quotaConfig, nil
 }
 
+// GetHooksConfig returns configured bucket hooks
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetHooksConfig(bucket string) (*madmin.BucketHooks, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.hooksConfig, nil
+}
+
+// GetReplicationConfig returns configured bucket replication config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReplicationConfig(bucket string) (*replication.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketReplicationConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.replicationConfig == nil {
+		return nil, BucketReplicationConfigNotFound{Bucket: bucket}
+	}
+	return meta.replicationConfig, nil
+}
+
+// GetBucketTargetsConfig returns configured remote targets
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetBucketTargetsConfig(bucket string) (*madmin.BucketTargets, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.bucketTargetConfig, nil
+}
+
+// GetReadReplicaConfig returns the source of a read replica bucket,
+// not enabled for regular buckets.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetReadReplicaConfig(bucket string) (*madmin.BucketReadReplica, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.readReplicaConfig, nil
+}
+
+// GetBandwidthConfig returns the bandwidth limits of the bucket.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetBandwidthConfig(bucket string) (*madmin.BucketBandwidth, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.bandwidthConfig, nil
+}
+
+// GetSecureEraseConfig returns the secure erase configuration of the bucket.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetSecureEraseConfig(bucket string) (*madmin.BucketSecureErase, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.secureEraseConfig, nil
+}
+
 // GetConfig returns the current bucket metadata
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetConfig(bucket string) (BucketMetadata, error) {
@@ -32,6 +32,7 @@ import (
 	"github.com/minio/minio/pkg/bucket/lifecycle"
 	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/bucket/versioning"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/madmin"
@@ -59,17 +60,27 @@ var (
 // bucketMetadataFormat refers to the format.
 // bucketMetadataVersion can be used to track a rolling upgrade of a field.
 type BucketMetadata struct {
-	Name                  string
-	Created               time.Time
-	LockEnabled           bool // legacy not used anymore.
-	PolicyConfigJSON      []byte
-	NotificationConfigXML []byte
-	LifecycleConfigXML    []byte
-	ObjectLockConfigXML   []byte
-	VersioningConfigXML   []byte
-	EncryptionConfigXML   []byte
-	TaggingConfigXML      []byte
-	QuotaConfigJSON       []byte
+	Name                    string
+	Created                 time.Time
+	LockEnabled             bool // legacy not used anymore.
+	PolicyConfigJSON        []byte
+	NotificationConfigXML   []byte
+	LifecycleConfigXML      []byte
+	ObjectLockConfigXML     []byte
+	VersioningConfigXML     []byte
+	EncryptionConfigXML     []byte
+	TaggingConfigXML        []byte
+	QuotaConfigJSON         []byte
+	HooksConfigJSON         []byte
+	ReplicationConfigXML    []byte
+	BucketTargetsConfigJSON []byte
+	ReadReplicaConfigJSON   []byte
+	BandwidthConfigJSON     []byte
+	SecureEraseConfigJSON   []byte
+
+	// Region of the bucket if it differs from the one of the server,
+	// from the location constraint of its creation.
+	Region string
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig       *policy.Policy
@@ -80,6 +91,12 @@ type BucketMetadata struct {
 	sseConfig          *bucketsse.BucketSSEConfig
 	taggingConfig      *tags.Tags
 	quotaConfig        *madmin.BucketQuota
+	hooksConfig        *madmin.BucketHooks
+	replicationConfig  *replication.Config
+	bucketTargetConfig *madmin.BucketTargets
+	readReplicaConfig  *madmin.BucketReadReplica
+	bandwidthConfig    *madmin.BucketBandwidth
+	secureEraseConfig  *madmin.BucketSecureErase
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -90,7 +107,12 @@ func newBucketMetadata(name string) BucketMetadata {
 		notificationConfig: &event.Config{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
-		quotaConfig: &madmin.BucketQuota{},
+		quotaConfig:        &madmin.BucketQuota{},
+		hooksConfig:        &madmin.BucketHooks{},
+		bucketTargetConfig: &madmin.BucketTargets{},
+		readReplicaConfig:  &madmin.BucketReadReplica{},
+		bandwidthConfig:    &madmin.BucketBandwidth{},
+		secureEraseConfig:  &madmin.BucketSecureErase{},
 		versioningConfig: &versioning.Versioning{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
@@ -213,6 +235,58 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 		}
 	}
 
+	if len(b.HooksConfigJSON) != 0 {
+		b.hooksConfig, err = parseBucketHooks(b.Name, b.HooksConfigJSON)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.ReplicationConfigXML) != 0 {
+		b.replicationConfig, err = replication.ParseConfig(bytes.NewReader(b.ReplicationConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.replicationConfig = nil
+	}
+
+	if len(b.BucketTargetsConfigJSON) != 0 {
+		b.bucketTargetConfig, err = parseBucketTargets(b.Name, b.BucketTargetsConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.bucketTargetConfig = &madmin.BucketTargets{}
+	}
+
+	if len(b.ReadReplicaConfigJSON) != 0 {
+		b.readReplicaConfig, err = parseBucketReadReplica(b.Name, b.ReadReplicaConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.readReplicaConfig = &madmin.BucketReadReplica{}
+	}
+
+	if len(b.BandwidthConfigJSON) != 0 {
+		b.bandwidthConfig, err = parseBucketBandwidth(b.Name, b.BandwidthConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.bandwidthConfig = &madmin.BucketBandwidth{}
+	}
+
+	if len(b.SecureEraseConfigJSON) != 0 {
+		b.secureEraseConfig, err = parseBucketSecureErase(b.Name, b.SecureEraseConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.secureEraseConfig = &madmin.BucketSecureErase{}
+	}
+
 	return nil
 }
 
@@ -335,3 +409,12 @@ func deleteBucketMetadata(ctx context.Context, obj ObjectLayer, bucket string) e
 	}
 	return nil
 }
+
+// bucketRegion - returns the region recorded in the metadata of a
+// bucket created in location, empty for the region of the server.
+func bucketRegion(location string) string {
+	if globalServerRegion == "" || location == globalServerRegion {
+		return ""
+	}
+	return location
+}
@@ -0,0 +1,449 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+const (
+	// Bucket mirror checkpoints, one per mirror job.
+	bucketMirrorPrefix = minioConfigPrefix + "/mirror"
+
+	// User metadata of the copies on the target holding the ETag of the
+	// mirrored object, ETags differ for instance for encrypted objects.
+	mirrorSourceETagKey = "Minio-Mirror-Source-Etag"
+
+	// Number of objects mirrored between two checkpoints.
+	bucketMirrorCheckpointInterval = 100
+)
+
+var (
+	errBucketMirrorInProgress    = errors.New("bucket mirror already in progress")
+	errBucketMirrorNotFound      = errors.New("bucket mirror not found")
+	errBucketMirrorInvalidTarget = errors.New("bucket mirror target is invalid")
+	errBucketMirrorCanceled      = errors.New("bucket mirror canceled")
+)
+
+// bucketMirrors - bucket mirror jobs running on this server, each
+// copies the objects of a bucket to a remote S3 compatible target.
+type bucketMirrors struct {
+	mu   sync.Mutex
+	jobs map[string]*bucketMirror
+}
+
+// bucketMirror - a running bucket mirror job.
+type bucketMirror struct {
+	mu     sync.Mutex
+	status madmin.MirrorStatus
+	cancel context.CancelFunc
+}
+
+func newBucketMirrors() *bucketMirrors {
+	return &bucketMirrors{jobs: make(map[string]*bucketMirror)}
+}
+
+// Start - validates the job and starts mirroring in the
+// background, returns the ID of the new job.
+func (m *bucketMirrors) Start(ctx context.Context, objAPI ObjectLayer, job madmin.MirrorJob) (string, error) {
+	if _, err := objAPI.GetBucketInfo(ctx, job.Bucket); err != nil {
+		return "", err
+	}
+	if err := validateMirrorTarget(ctx, job.Target); err != nil {
+		return "", err
+	}
+	if job.Bandwidth < 0 {
+		return "", errInvalidArgument
+	}
+	if err := validateTransferWindows(job.Windows); err != nil {
+		return "", err
+	}
+
+	status := madmin.MirrorStatus{
+		ID:  mustGetUUID(),
+		Job: job,
+	}
+	if err := m.run(ctx, objAPI, status); err != nil {
+		return "", err
+	}
+	return status.ID, nil
+}
+
+// Resume - resumes the job id from its last checkpoint, complete
+// jobs mirror the bucket again from the start.
+func (m *bucketMirrors) Resume(ctx context.Context, objAPI ObjectLayer, id string) error {
+	status, err := loadBucketMirrorCheckpoint(ctx, objAPI, id)
+	if err != nil {
+		if err == errConfigNotFound {
+			return errBucketMirrorNotFound
+		}
+		return err
+	}
+	if status.Complete {
+		status.Object = ""
+		status.Objects, status.Bytes, status.Skipped, status.Failed = 0, 0, 0, 0
+		status.Complete = false
+	}
+	return m.run(ctx, objAPI, status)
+}
+
+// Cancel - stops the job id, its checkpoint is saved and
+// the job can be resumed later on.
+func (m *bucketMirrors) Cancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return errBucketMirrorNotFound
+	}
+	job.cancel()
+	return nil
+}
+
+// Status - returns the status of all the jobs, the jobs not
+// running on this server are reported from their checkpoints.
+func (m *bucketMirrors) Status(ctx context.Context, objAPI ObjectLayer) ([]madmin.MirrorStatus, error) {
+	statuses := make(map[string]madmin.MirrorStatus)
+
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, bucketMirrorPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range loi.Objects {
+			id := strings.TrimSuffix(path.Base(obj.Name), ".json")
+			status, err := loadBucketMirrorCheckpoint(ctx, objAPI, id)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			statuses[id] = status
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+
+	m.mu.Lock()
+	for id, job := range m.jobs {
+		statuses[id] = job.Status()
+	}
+	m.mu.Unlock()
+
+	result := make([]madmin.MirrorStatus, 0, len(statuses))
+	for _, status := range statuses {
+		status.Job.Target.SecretKey = ""
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result, nil
+}
+
+// run - starts the job of status in the background.
+func (m *bucketMirrors) run(ctx context.Context, objAPI ObjectLayer, status madmin.MirrorStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.jobs[status.ID]; ok {
+		return errBucketMirrorInProgress
+	}
+
+	clnt, err := newRemoteS3Client(status.Job.Target.Endpoint, status.Job.Target.AccessKey,
+		status.Job.Target.SecretKey, status.Job.Target.Region)
+	if err != nil {
+		return err
+	}
+
+	status.Running = true
+	status.StartTime = UTCNow()
+	status.EndTime = time.Time{}
+	status.Error = ""
+	if err = saveBucketMirrorCheckpoint(ctx, objAPI, status); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(withIOClass(ctx, ioClassReplication))
+	job := &bucketMirror{status: status, cancel: cancel}
+	m.jobs[status.ID] = job
+
+	go func() {
+		err := job.mirror(ctx, objAPI, clnt)
+		if err != nil && ctx.Err() == nil {
+			logger.LogIf(ctx, err)
+		}
+
+		// The job is reported as finished, and can be resumed,
+		// only once its final checkpoint is saved.
+		m.mu.Lock()
+		status := job.update(func(s *madmin.MirrorStatus) {
+			s.Running = false
+			s.EndTime = UTCNow()
+			switch {
+			case err == nil:
+				s.Complete = true
+			case ctx.Err() != nil:
+				s.Error = errBucketMirrorCanceled.Error()
+			default:
+				s.Error = err.Error()
+			}
+		})
+		logger.LogIf(GlobalContext, saveBucketMirrorCheckpoint(GlobalContext, objAPI, status))
+		delete(m.jobs, status.ID)
+		m.mu.Unlock()
+		cancel()
+	}()
+	return nil
+}
+
+// Status - returns the progress of the job.
+func (j *bucketMirror) Status() madmin.MirrorStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+func (j *bucketMirror) update(fn func(s *madmin.MirrorStatus)) madmin.MirrorStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.status)
+	return j.status
+}
+
+// mirror - copies all the objects of the job after its last
+// checkpoint, in lexical order.
+func (j *bucketMirror) mirror(ctx context.Context, objAPI ObjectLayer, clnt *miniogo.Core) error {
+	status := j.Status()
+	job := status.Job
+
+	var throttle *bandwidthThrottle
+	if job.Bandwidth > 0 {
+		throttle = &bandwidthThrottle{bandwidth: job.Bandwidth}
+	}
+
+	marker := status.Object
+	for {
+		loi, err := objAPI.ListObjects(ctx, job.Bucket, job.Prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range loi.Objects {
+			// Waits for the next window, or returns once canceled.
+			if err = waitTransferWindow(ctx, job.Windows); err != nil {
+				return err
+			}
+
+			var copied bool
+			if mirrorObjectIncluded(job, obj.Name) {
+				copied, err = mirrorObject(ctx, objAPI, clnt, job, obj, throttle)
+				if err != nil && ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if err != nil {
+					logger.LogIf(ctx, fmt.Errorf("Unable to mirror %s/%s: %w", job.Bucket, obj.Name, err))
+				}
+			}
+			status := j.update(func(s *madmin.MirrorStatus) {
+				s.Object = obj.Name
+				switch {
+				case err != nil:
+					s.Failed++
+				case copied:
+					s.Objects++
+					s.Bytes += obj.Size
+				default:
+					s.Skipped++
+				}
+			})
+			if (status.Objects+status.Skipped+status.Failed)%bucketMirrorCheckpointInterval == 0 {
+				logger.LogIf(ctx, saveBucketMirrorCheckpoint(ctx, objAPI, status))
+			}
+		}
+
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// mirrorObjectIncluded - returns true if the object name matches the
+// include patterns, if any, and none of the exclude patterns.
+func mirrorObjectIncluded(job madmin.MirrorJob, object string) bool {
+	for _, pattern := range job.Exclude {
+		if wildcard.MatchSimple(pattern, object) {
+			return false
+		}
+	}
+	if len(job.Include) == 0 {
+		return true
+	}
+	for _, pattern := range job.Include {
+		if wildcard.MatchSimple(pattern, object) {
+			return true
+		}
+	}
+	return false
+}
+
+// mirrorObjectName - returns the name of the copy of the object on
+// the target, relative to the prefixes of the job and the target.
+func mirrorObjectName(job madmin.MirrorJob, object string) string {
+	return job.Target.Prefix + strings.TrimPrefix(object, job.Prefix)
+}
+
+// mirrorObject - copies the object to the target unless the copy on
+// the target has the same size and ETag, or was mirrored from an object
+// with the same ETag. Returns false if the object was skipped.
+func mirrorObject(ctx context.Context, objAPI ObjectLayer, clnt *miniogo.Core, job madmin.MirrorJob, obj ObjectInfo, throttle *bandwidthThrottle) (bool, error) {
+	target := mirrorObjectName(job, obj.Name)
+	size, err := objectReadSize(obj)
+	if err != nil {
+		return false, err
+	}
+	if oi, err := clnt.StatObject(ctx, job.Target.Bucket, target, miniogo.StatObjectOptions{}); err == nil &&
+		oi.Size == size && (oi.ETag == obj.ETag || oi.UserMetadata[mirrorSourceETagKey] == obj.ETag) {
+		return false, nil
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, job.Bucket, obj.Name, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		return false, err
+	}
+	defer gr.Close()
+
+	oi := gr.ObjInfo
+	if size, err = objectReadSize(oi); err != nil {
+		return false, err
+	}
+
+	opts := miniogo.PutObjectOptions{
+		ContentType:     oi.ContentType,
+		ContentEncoding: oi.ContentEncoding,
+		UserMetadata: map[string]string{
+			mirrorSourceETagKey: oi.ETag,
+		},
+	}
+	for k, v := range oi.UserDefined {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			opts.UserMetadata[k[len("x-amz-meta-"):]] = v
+		}
+	}
+	if oi.UserTags != "" {
+		t, err := tags.ParseObjectTags(oi.UserTags)
+		if err != nil {
+			return false, err
+		}
+		opts.UserTags = t.ToMap()
+	}
+
+	var r io.Reader = gr
+	if throttle != nil {
+		r = &throttledReader{ctx: ctx, r: gr, throttle: throttle}
+	}
+	if HasSuffix(obj.Name, SlashSeparator) {
+		r, size = bytes.NewReader(nil), 0
+	}
+	if _, err = clnt.Client.PutObject(ctx, job.Target.Bucket, target, r, size, opts); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// objectReadSize - returns the size of the object as read
+// by clients, that is decompressed and decrypted.
+func objectReadSize(oi ObjectInfo) (int64, error) {
+	if crypto.IsEncrypted(oi.UserDefined) {
+		return oi.DecryptedSize()
+	}
+	return oi.GetActualSize()
+}
+
+// validateMirrorTarget - checks that the target bucket
+// exists with the credentials of the target.
+func validateMirrorTarget(ctx context.Context, target madmin.MirrorTarget) error {
+	if target.Endpoint == "" || target.AccessKey == "" || target.SecretKey == "" || target.Bucket == "" {
+		return errBucketMirrorInvalidTarget
+	}
+	clnt, err := newRemoteS3Client(target.Endpoint, target.AccessKey, target.SecretKey, target.Region)
+	if err != nil {
+		return errBucketMirrorInvalidTarget
+	}
+	ok, err := clnt.BucketExists(ctx, target.Bucket)
+	if err != nil || !ok {
+		return errBucketMirrorInvalidTarget
+	}
+	return nil
+}
+
+func bucketMirrorCheckpointFile(id string) string {
+	return path.Join(bucketMirrorPrefix, id+".json")
+}
+
+func loadBucketMirrorCheckpoint(ctx context.Context, objAPI ObjectLayer, id string) (status madmin.MirrorStatus, err error) {
+	data, err := readConfig(ctx, objAPI, bucketMirrorCheckpointFile(id))
+	if err != nil {
+		return status, err
+	}
+	// Checkpoints hold the credentials of the target.
+	if globalConfigEncrypted {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			return status, err
+		}
+	}
+	if err = json.Unmarshal(data, &status); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+func saveBucketMirrorCheckpoint(ctx context.Context, objAPI ObjectLayer, status madmin.MirrorStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, bucketMirrorCheckpointFile(status.ID), data)
+}
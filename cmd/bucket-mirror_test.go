@@ -0,0 +1,171 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestMirrorObjectIncluded(t *testing.T) {
+	job := madmin.MirrorJob{
+		Include: []string{"docs/*.txt", "*.md"},
+		Exclude: []string{"docs/tmp/*"},
+	}
+	testCases := []struct {
+		object   string
+		included bool
+	}{
+		{"docs/a.txt", true},
+		{"docs/sub/b.txt", true},
+		{"README.md", true},
+		{"docs/a.log", false},
+		{"docs/tmp/c.txt", false},
+	}
+	for i, testCase := range testCases {
+		if included := mirrorObjectIncluded(job, testCase.object); included != testCase.included {
+			t.Errorf("Test %d: expected %v for %s, got %v", i+1, testCase.included, testCase.object, included)
+		}
+	}
+	if !mirrorObjectIncluded(madmin.MirrorJob{}, "any/object") {
+		t.Errorf("expected all objects to be included without filters")
+	}
+}
+
+func TestBucketMirror(t *testing.T) {
+	remote, url, stop := startFakeTier()
+	defer stop()
+
+	ExecObjectLayerTest(t, func(obj ObjectLayer, instanceType string, t TestErrHandler) {
+		testBucketMirror(obj, instanceType, remote, url, t)
+	})
+}
+
+func waitBucketMirror(ctx context.Context, mirrors *bucketMirrors, obj ObjectLayer, id string, t TestErrHandler) madmin.MirrorStatus {
+	for i := 0; i < 100; i++ {
+		statuses, err := mirrors.Status(ctx, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, status := range statuses {
+			if status.ID == id && !status.Running {
+				if status.Job.Target.SecretKey != "" {
+					t.Fatalf("expected the secret key of the target to be redacted")
+				}
+				return status
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("bucket mirror %s did not complete", id)
+	return madmin.MirrorStatus{}
+}
+
+func testBucketMirror(obj ObjectLayer, instanceType string, remote *fakeTier, url string, t TestErrHandler) {
+	remote.reset()
+
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	data := []byte("mirrored object")
+	for _, object := range []string{"docs/a.txt", "docs/b.log", "docs/sub/c.txt", "docs/tmp/d.txt", "other/e.txt"} {
+		opts := ObjectOptions{UserDefined: map[string]string{"X-Amz-Meta-Origin": object, "content-type": "text/plain"}}
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	mirrors := newBucketMirrors()
+	job := madmin.MirrorJob{
+		Bucket:  bucket,
+		Prefix:  "docs/",
+		Include: []string{"docs/*.txt"},
+		Exclude: []string{"docs/tmp/*"},
+		Target: madmin.MirrorTarget{
+			Endpoint:  url,
+			AccessKey: "minio",
+			SecretKey: "minio123",
+			Bucket:    "mirror",
+			Prefix:    "backup/",
+			Region:    "us-east-1",
+		},
+	}
+
+	invalid := job
+	invalid.Target.Bucket = ""
+	if _, err := mirrors.Start(ctx, obj, invalid); err != errBucketMirrorInvalidTarget {
+		t.Fatalf("%s: expected an invalid target error, got %v", instanceType, err)
+	}
+
+	id, err := mirrors.Start(ctx, obj, job)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	status := waitBucketMirror(ctx, mirrors, obj, id, t)
+	if !status.Complete || status.Objects != 2 || status.Skipped != 2 || status.Failed != 0 {
+		t.Fatalf("%s: unexpected status of the mirror %#v", instanceType, status)
+	}
+	for _, object := range []string{"/mirror/backup/a.txt", "/mirror/backup/sub/c.txt"} {
+		if !bytes.Equal(remote.objects[object], data) {
+			t.Fatalf("%s: expected %s to be mirrored", instanceType, object)
+		}
+		if remote.headers[object].Get("X-Amz-Meta-Origin") == "" || remote.headers[object].Get("Content-Type") != "text/plain" {
+			t.Fatalf("%s: expected the metadata of %s to be mirrored, got %v", instanceType, object, remote.headers[object])
+		}
+	}
+	if len(remote.objects) != 2 {
+		t.Fatalf("%s: expected 2 mirrored objects, got %d", instanceType, len(remote.objects))
+	}
+
+	// Mirroring again skips the objects already on the target.
+	if err = mirrors.Resume(ctx, obj, id); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	status = waitBucketMirror(ctx, mirrors, obj, id, t)
+	if !status.Complete || status.Objects != 0 || status.Skipped != 4 {
+		t.Fatalf("%s: unexpected status of the mirror %#v", instanceType, status)
+	}
+
+	// Interrupted mirrors resume after their last checkpoint.
+	remote.reset()
+	status.Complete = false
+	status.Object = "docs/a.txt"
+	if err = saveBucketMirrorCheckpoint(ctx, obj, status); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = mirrors.Resume(ctx, obj, id); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	status = waitBucketMirror(ctx, mirrors, obj, id, t)
+	if !status.Complete || len(remote.objects) != 1 || remote.objects["/mirror/backup/sub/c.txt"] == nil {
+		t.Fatalf("%s: expected only the objects after the checkpoint to be mirrored, got %#v", instanceType, status)
+	}
+
+	if err = mirrors.Cancel(id); err != errBucketMirrorNotFound {
+		t.Fatalf("%s: expected the complete mirror not to be canceled, got %v", instanceType, err)
+	}
+	if err = mirrors.Resume(ctx, obj, "unknown"); err != errBucketMirrorNotFound {
+		t.Fatalf("%s: expected an unknown mirror not to be resumed, got %v", instanceType, err)
+	}
+}
@@ -240,8 +240,8 @@ func enforceRetentionBypassForPut(ctx context.Context, r *http.Request, bucket,
 // See https://docs.aws.amazon.com/AmazonS3/latest/dev/object-lock-managing.html for the spec.
 // For non-existing objects with object retention headers set, this method returns ErrNone if bucket has
 // locking enabled and user has requisite permissions (s3:PutObjectRetention)
-// If object exists on object store and site wide WORM enabled - this method
-// returns an error. For objects in "Governance" mode, overwrite is allowed if the retention date has expired.
+// If object exists on object store and the server is in write-once mode - this
+// method returns an error. For objects in "Governance" mode, overwrite is allowed if the retention date has expired.
 // For objects in "Compliance" mode, retention date cannot be shortened, and mode cannot be altered.
 // For objects with legal hold header set, the s3:PutObjectLegalHold permission is expected to be set
 // Both legal hold and retention can be applied independently on an object
@@ -250,6 +250,11 @@ func checkPutObjectLockAllowed(ctx context.Context, r *http.Request, bucket, obj
 	var retainDate objectlock.RetentionDate
 	var legalHold objectlock.ObjectLegalHold
 
+	// Objects cannot be overwritten in write-once mode.
+	if err := checkServerModeOverwrite(ctx, bucket, object, getObjectInfoFn); err != nil {
+		return mode, retainDate, legalHold, toAPIErrorCode(ctx, err)
+	}
+
 	retentionRequested := objectlock.IsObjectLockRetentionRequested(r.Header)
 	legalHoldRequested := objectlock.IsObjectLockLegalHoldRequested(r.Header)
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/minio/minio/cmd/config"
@@ -32,6 +33,12 @@ import (
 // BucketQuotaSys - map of bucket and quota configuration.
 type BucketQuotaSys struct {
 	bucketStorageCache timedValue
+
+	// Usage written to buckets with a quota since the
+	// data usage info last updated at pendingSince.
+	mu           sync.Mutex
+	pendingSince time.Time
+	pending      map[string]BucketUsageInfo
 }
 
 // Get - Get quota configuration.
@@ -49,7 +56,9 @@ func (sys *BucketQuotaSys) Get(bucketName string) (*madmin.BucketQuota, error) {
 
 // NewBucketQuotaSys returns initialized BucketQuotaSys
 func NewBucketQuotaSys() *BucketQuotaSys {
-	return &BucketQuotaSys{}
+	return &BucketQuotaSys{
+		pending: make(map[string]BucketUsageInfo),
+	}
 }
 
 // parseBucketQuota parses BucketQuota from json
@@ -64,12 +73,9 @@ func parseBucketQuota(bucket string, data []byte) (quotaCfg *madmin.BucketQuota,
 	return
 }
 
-func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64) error {
-	objAPI := newObjectLayerWithoutSafeModeFn()
-	if objAPI == nil {
-		return errServerNotInitialized
-	}
-
+// hardQuota returns the hard quota configuration of the bucket,
+// nil if the bucket has no hard quota.
+func (sys *BucketQuotaSys) hardQuota(bucket string) *madmin.BucketQuota {
 	q, err := sys.Get(bucket)
 	if err != nil {
 		return nil
@@ -79,11 +85,26 @@ func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64)
 		return nil
 	}
 
-	if q.Quota == 0 {
+	if q.Quota == 0 && q.Objects == 0 {
 		// No quota set return quickly.
 		return nil
 	}
 
+	return q
+}
+
+// usage returns the last known usage of the bucket, including writes
+// accounted since the data usage info was last updated.
+func (sys *BucketQuotaSys) usage(ctx context.Context, objAPI ObjectLayer, bucket string) (bui BucketUsageInfo, err error) {
+	return sys.bucketsUsage(ctx, objAPI, func(b string) bool {
+		return b == bucket
+	})
+}
+
+// bucketsUsage returns the sum of the last known usage of the buckets
+// matching, including writes accounted since the data usage info was
+// last updated.
+func (sys *BucketQuotaSys) bucketsUsage(ctx context.Context, objAPI ObjectLayer, match func(bucket string) bool) (bui BucketUsageInfo, err error) {
 	sys.bucketStorageCache.Once.Do(func() {
 		sys.bucketStorageCache.TTL = 10 * time.Second
 		sys.bucketStorageCache.Update = func() (interface{}, error) {
@@ -93,31 +114,177 @@ func (sys *BucketQuotaSys) check(ctx context.Context, bucket string, size int64)
 
 	v, err := sys.bucketStorageCache.Get()
 	if err != nil {
-		return err
+		return bui, err
 	}
 
 	dui := v.(DataUsageInfo)
 
-	bui, ok := dui.BucketsUsage[bucket]
-	if !ok {
-		// bucket not found, cannot enforce quota
-		// call will fail anyways later.
+	// Buckets not crawled yet only have the
+	// usage accounted since their creation.
+	for bucket, usage := range dui.BucketsUsage {
+		if match(bucket) {
+			bui.Size += usage.Size
+			bui.ObjectsCount += usage.ObjectsCount
+		}
+	}
+
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+
+	// Data usage info has been updated, it accounts for
+	// all the writes recorded so far.
+	if !dui.LastUpdate.Equal(sys.pendingSince) {
+		sys.pendingSince = dui.LastUpdate
+		sys.pending = make(map[string]BucketUsageInfo)
+	}
+
+	for bucket, pending := range sys.pending {
+		if match(bucket) {
+			bui.Size += pending.Size
+			bui.ObjectsCount += pending.ObjectsCount
+		}
+	}
+	return bui, nil
+}
+
+// accounted returns whether the writes to the bucket are accounted,
+// for its hard quota or the quota of its tenant.
+func (sys *BucketQuotaSys) accounted(bucket string) bool {
+	tenant, _ := globalTenantSys.tenantOfBucket(bucket)
+	return tenant.Quota != 0 || sys.hardQuota(bucket) != nil
+}
+
+// isNewObject returns whether writing the object adds an object to the
+// bucket instead of overwriting an existing one. An empty object is a
+// part of a multipart upload, which adds no object.
+func isNewObject(ctx context.Context, objAPI ObjectLayer, bucket, object string) bool {
+	if object == "" {
+		return false
+	}
+	// When the object can't be read it is counted as new.
+	_, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	return err != nil
+}
+
+// account records a successful write of an object of size bytes
+// into a bucket with a hard quota, or of a tenant with a quota, until
+// the data usage info is next updated. Overwrites of existing objects
+// don't count as new objects.
+func (sys *BucketQuotaSys) account(bucket string, size int64, newObject bool) {
+	if size < 0 || !sys.accounted(bucket) {
+		return
+	}
+
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+
+	if sys.pending == nil {
+		sys.pending = make(map[string]BucketUsageInfo)
+	}
+	pending := sys.pending[bucket]
+	pending.Size += uint64(size)
+	if newObject {
+		pending.ObjectsCount++
+	}
+	sys.pending[bucket] = pending
+}
+
+// check returns an error if writing size bytes to the object exceeds
+// the quotas of the bucket, and whether the write adds a new object to
+// a bucket whose writes are accounted.
+func (sys *BucketQuotaSys) check(ctx context.Context, bucket, object string, size int64) (newObject bool, err error) {
+	objAPI := newObjectLayerWithoutSafeModeFn()
+	if objAPI == nil {
+		return false, errServerNotInitialized
+	}
+
+	if !sys.accounted(bucket) {
+		return false, nil
+	}
+	newObject = isNewObject(ctx, objAPI, bucket, object)
+
+	var newObjects uint64
+	if newObject {
+		newObjects = 1
+	}
+	if err = sys.checkUsage(ctx, objAPI, bucket, size, newObjects); err != nil {
+		return false, err
+	}
+	return newObject, nil
+}
+
+// checkObjects returns an error if writing size bytes to the objects
+// at once exceeds the quotas of the bucket.
+func (sys *BucketQuotaSys) checkObjects(ctx context.Context, bucket string, objects []string, size int64) error {
+	objAPI := newObjectLayerWithoutSafeModeFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	if !sys.accounted(bucket) {
+		return nil
+	}
+
+	var newObjects uint64
+	for _, object := range objects {
+		if isNewObject(ctx, objAPI, bucket, object) {
+			newObjects++
+		}
+	}
+	return sys.checkUsage(ctx, objAPI, bucket, size, newObjects)
+}
+
+// checkUsage returns an error if adding size bytes and newObjects
+// objects to the bucket exceeds its quotas or the quota of its tenant.
+func (sys *BucketQuotaSys) checkUsage(ctx context.Context, objAPI ObjectLayer, bucket string, size int64, newObjects uint64) error {
+	if err := globalTenantSys.checkQuota(ctx, objAPI, bucket, size); err != nil {
+		return err
+	}
+
+	q := sys.hardQuota(bucket)
+	if q == nil {
 		return nil
 	}
 
-	if (bui.Size + uint64(size)) > q.Quota {
+	bui, err := sys.usage(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+
+	if q.Quota > 0 && (bui.Size+uint64(size)) > q.Quota {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+
+	// Overwrites are allowed in a bucket at its object count limit.
+	if q.Objects > 0 && newObjects > 0 && bui.ObjectsCount+newObjects > q.Objects {
 		return BucketQuotaExceeded{Bucket: bucket}
 	}
 
 	return nil
 }
 
-func enforceBucketQuota(ctx context.Context, bucket string, size int64) error {
+// enforceBucketQuota returns an error if writing size bytes to the
+// object exceeds the quotas of the bucket, an empty object is a part
+// of a multipart upload. It also returns whether the write adds a new
+// object, to be passed to accountBucketQuota once written.
+func enforceBucketQuota(ctx context.Context, bucket, object string, size int64) (newObject bool, err error) {
 	if size < 0 {
-		return nil
+		return false, nil
 	}
 
-	return globalBucketQuotaSys.check(ctx, bucket, size)
+	return globalBucketQuotaSys.check(ctx, bucket, object, size)
+}
+
+// enforceBucketQuotaObjects returns an error if writing size bytes
+// to the objects at once exceeds the quotas of the bucket.
+func enforceBucketQuotaObjects(ctx context.Context, bucket string, objects []string, size int64) error {
+	return globalBucketQuotaSys.checkObjects(ctx, bucket, objects, size)
+}
+
+// accountBucketQuota records a successful write for
+// quota enforcement until the next data usage update.
+func accountBucketQuota(bucket string, size int64, newObject bool) {
+	globalBucketQuotaSys.account(bucket, size, newObject)
 }
 
 const (
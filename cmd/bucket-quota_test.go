@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBucketQuotaLiveAccounting(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = objLayer
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+
+	defer func(sys *BucketMetadataSys) {
+		globalBucketMetadataSys = sys
+	}(globalBucketMetadataSys)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "crawled", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = objLayer.PutObject(ctx, "crawled", "existing", mustGetPutObjReader(t, bytes.NewReader([]byte("a")), 1, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bucket := range []string{"crawled", "new"} {
+		meta := newBucketMetadata(bucket)
+		meta.quotaConfig = &madmin.BucketQuota{Quota: 10, Type: madmin.HardQuota, Objects: 2}
+		globalBucketMetadataSys.Set(bucket, meta)
+	}
+
+	dui := DataUsageInfo{
+		LastUpdate: time.Now(),
+		BucketsUsage: map[string]BucketUsageInfo{
+			"crawled": {Size: 4, ObjectsCount: 1},
+		},
+	}
+	sys := NewBucketQuotaSys()
+	sys.bucketStorageCache.Once.Do(func() {})
+	sys.bucketStorageCache.TTL = time.Nanosecond
+	sys.bucketStorageCache.Update = func() (interface{}, error) {
+		return dui, nil
+	}
+
+	expectQuota := func(bucket, object string, size int64, exceeded bool) {
+		t.Helper()
+		_, err := sys.check(ctx, bucket, object, size)
+		if _, ok := err.(BucketQuotaExceeded); ok != exceeded {
+			t.Fatalf("%s: unexpected quota check result for %d bytes: %v", bucket, size, err)
+		}
+	}
+
+	expectQuota("crawled", "object", 6, false)
+	expectQuota("crawled", "object", 7, true)
+
+	// Writes since the last data usage update count against the quota.
+	sys.account("crawled", 5, true)
+	expectQuota("crawled", "object", 1, true)
+	expectQuota("new", "object", 10, false)
+	sys.account("new", 3, true)
+	sys.account("new", 3, true)
+	expectQuota("new", "object", 1, true)
+
+	// Overwrites don't count as new objects, and are allowed in a
+	// bucket at its object count limit.
+	sys.account("crawled", 0, false)
+	expectQuota("crawled", "object", 0, true)
+	expectQuota("crawled", "existing", 0, false)
+	if newObject, err := sys.check(ctx, "crawled", "existing", 0); err != nil || newObject {
+		t.Fatalf("expected an overwrite, got %v %v", newObject, err)
+	}
+
+	// A data usage update accounts for all the writes so far.
+	dui = DataUsageInfo{
+		LastUpdate: dui.LastUpdate.Add(time.Minute),
+		BucketsUsage: map[string]BucketUsageInfo{
+			"crawled": {Size: 5, ObjectsCount: 1},
+		},
+	}
+	expectQuota("crawled", "object", 5, false)
+	expectQuota("new", "object", 10, false)
+
+	// Several objects written at once are checked together.
+	testCases := []struct {
+		objects  []string
+		size     int64
+		exceeded bool
+	}{
+		{[]string{"existing", "a"}, 5, false},
+		{[]string{"a", "b"}, 1, true},
+		{[]string{"existing"}, 6, true},
+	}
+	for i, testCase := range testCases {
+		err := sys.checkObjects(ctx, "crawled", testCase.objects, testCase.size)
+		if _, ok := err.(BucketQuotaExceeded); ok != testCase.exceeded {
+			t.Fatalf("Test %d: unexpected quota check result: %v", i+1, err)
+		}
+	}
+}
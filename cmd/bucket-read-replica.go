@@ -0,0 +1,225 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	bucketReadReplicaConfigFile = "read-replica.json"
+
+	// Interval between two checks of the read replicas due
+	// for synchronization.
+	readReplicaCheckInterval = time.Minute
+)
+
+var errReadReplicaInvalidSource = errors.New("read replica source is invalid")
+
+// parseBucketReadReplica parses the source of a read replica bucket from json
+func parseBucketReadReplica(bucket string, data []byte) (*madmin.BucketReadReplica, error) {
+	replica := &madmin.BucketReadReplica{}
+	if err := json.Unmarshal(data, replica); err != nil {
+		return replica, err
+	}
+	if err := replica.Validate(); err != nil {
+		return replica, err
+	}
+	return replica, nil
+}
+
+// readReplicaSource - returns the source of the bucket if
+// the bucket is a read replica.
+func readReplicaSource(bucket string) (*madmin.BucketReadReplica, bool) {
+	if globalIsGateway || globalBucketMetadataSys == nil || bucket == "" {
+		return nil, false
+	}
+	replica, err := globalBucketMetadataSys.GetReadReplicaConfig(bucket)
+	if err != nil || !replica.Enabled() {
+		return nil, false
+	}
+	return replica, true
+}
+
+// initReadReplicaSync - synchronizes the read replicas with their
+// sources in the background, only on the leader.
+func initReadReplicaSync(ctx context.Context, objAPI ObjectLayer) {
+	ctx = withIOClass(ctx, ioClassReplication)
+	go func() {
+		lastSync := make(map[string]time.Time)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.NewTimer(readReplicaCheckInterval).C:
+				syncReadReplicas(ctx, objAPI, lastSync)
+			}
+		}
+	}()
+}
+
+// syncReadReplicas - synchronizes the read replicas whose interval
+// elapsed since their last synchronization.
+func syncReadReplicas(ctx context.Context, objAPI ObjectLayer, lastSync map[string]time.Time) {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, binfo := range buckets {
+		replica, ok := readReplicaSource(binfo.Name)
+		if !ok {
+			delete(lastSync, binfo.Name)
+			continue
+		}
+		interval := replica.Interval
+		if interval == 0 {
+			interval = madmin.DefaultReadReplicaInterval
+		}
+		if time.Since(lastSync[binfo.Name]) < interval {
+			continue
+		}
+		if err = syncReadReplica(ctx, objAPI, binfo.Name, *replica); err != nil {
+			logger.LogIf(ctx, fmt.Errorf("Unable to synchronize the read replica %s: %w", binfo.Name, err))
+		}
+		lastSync[binfo.Name] = time.Now()
+	}
+}
+
+// syncReadReplica - copies the objects of the source missing or
+// different in the bucket, and removes the objects of the bucket
+// not in the source. Both buckets are listed in lexical order.
+func syncReadReplica(ctx context.Context, objAPI ObjectLayer, bucket string, replica madmin.BucketReadReplica) error {
+	clnt, err := newRemoteS3Client(replica.Endpoint, replica.AccessKey, replica.SecretKey, replica.Region)
+	if err != nil {
+		return err
+	}
+
+	local := &bucketObjectWalker{objAPI: objAPI, bucket: bucket}
+	opts := ObjectOptions{Versioned: globalBucketVersioningSys.Enabled(bucket)}
+
+	// removeUntil - removes the objects of the bucket before
+	// key, all of the remaining objects if key is empty.
+	removeUntil := func(key string) error {
+		for {
+			oi, ok, err := local.peek(ctx)
+			if err != nil || !ok {
+				return err
+			}
+			if key != "" && oi.Name >= key {
+				if oi.Name == key {
+					local.pop()
+				}
+				return nil
+			}
+			if _, err = objAPI.DeleteObject(ctx, bucket, oi.Name, opts); err != nil && !isErrObjectNotFound(err) {
+				return err
+			}
+			local.pop()
+		}
+	}
+
+	marker := ""
+	for {
+		lbr, err := clnt.ListObjects(replica.Bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, src := range lbr.Contents {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			if err = removeUntil(src.Key); err != nil {
+				return err
+			}
+			if _, err = copyRemoteObject(ctx, objAPI, clnt, replica.Bucket, bucket, src); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				logger.LogIf(ctx, fmt.Errorf("Unable to synchronize %s/%s: %w", bucket, src.Key, err))
+			}
+			marker = src.Key
+		}
+		if !lbr.IsTruncated {
+			break
+		}
+		if lbr.NextMarker != "" {
+			marker = lbr.NextMarker
+		}
+	}
+	return removeUntil("")
+}
+
+// bucketObjectWalker - lists the objects of a bucket in
+// lexical order, one object at a time.
+type bucketObjectWalker struct {
+	objAPI    ObjectLayer
+	bucket    string
+	marker    string
+	objects   []ObjectInfo
+	listed    bool
+	truncated bool
+}
+
+// peek - returns the next object, false once all the
+// objects are listed.
+func (w *bucketObjectWalker) peek(ctx context.Context) (ObjectInfo, bool, error) {
+	for len(w.objects) == 0 {
+		if w.listed && !w.truncated {
+			return ObjectInfo{}, false, nil
+		}
+		loi, err := w.objAPI.ListObjects(ctx, w.bucket, "", w.marker, "", maxObjectList)
+		if err != nil {
+			return ObjectInfo{}, false, err
+		}
+		w.listed, w.truncated = true, loi.IsTruncated && len(loi.Objects) > 0
+		w.objects = loi.Objects
+		w.marker = loi.NextMarker
+		if w.marker == "" && len(loi.Objects) > 0 {
+			w.marker = loi.Objects[len(loi.Objects)-1].Name
+		}
+	}
+	return w.objects[0], true, nil
+}
+
+// pop - skips the object returned by peek.
+func (w *bucketObjectWalker) pop() {
+	w.objects = w.objects[1:]
+}
+
+// validateReadReplicaSource - checks that the source bucket
+// exists with the credentials of the source.
+func validateReadReplicaSource(ctx context.Context, replica madmin.BucketReadReplica) error {
+	clnt, err := newRemoteS3Client(replica.Endpoint, replica.AccessKey, replica.SecretKey, replica.Region)
+	if err != nil {
+		return errReadReplicaInvalidSource
+	}
+	ok, err := clnt.BucketExists(ctx, replica.Bucket)
+	if err != nil || !ok {
+		return errReadReplicaInvalidSource
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBucketReadReplica(t *testing.T) {
+	remote, url, stop := startFakeTier()
+	defer stop()
+
+	ExecObjectLayerTest(t, func(obj ObjectLayer, instanceType string, t TestErrHandler) {
+		testBucketReadReplica(obj, instanceType, remote, url, t)
+	})
+}
+
+func testBucketReadReplica(obj ObjectLayer, instanceType string, remote *fakeTier, url string, t TestErrHandler) {
+	remote.reset()
+	ctx := context.Background()
+
+	// Bucket metadata is updated through the global object layer.
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	bucket := "replica"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	for object, data := range map[string]string{"b.txt": "stale", "c.txt": "removed from the source"} {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+	remote.objects["/source/a.txt"] = []byte("object a")
+	remote.objects["/source/b.txt"] = []byte("object b")
+
+	replica := madmin.BucketReadReplica{
+		Endpoint:  url,
+		AccessKey: "minio",
+		SecretKey: "minio123",
+		Bucket:    "source",
+		Region:    "us-east-1",
+	}
+	data, err := json.Marshal(replica)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketReadReplicaConfigFile, data); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, ok := readReplicaSource(bucket); !ok {
+		t.Fatalf("%s: expected %s to be a read replica", instanceType, bucket)
+	}
+
+	if err = syncReadReplica(ctx, obj, bucket, replica); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	for _, object := range []string{"a.txt", "b.txt"} {
+		var buf bytes.Buffer
+		if err = obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if !bytes.Equal(buf.Bytes(), remote.objects["/source/"+object]) {
+			t.Fatalf("%s: expected %s to be synchronized, got %q", instanceType, object, buf.String())
+		}
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "c.txt", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected c.txt to be removed, got %v", instanceType, err)
+	}
+
+	// Writes are rejected, reads and bucket configurations are not.
+	handler := setReadReplicaHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	testCases := []struct {
+		method, target string
+		status         int
+	}{
+		{http.MethodGet, "/replica/a.txt", http.StatusOK},
+		{http.MethodPut, "/replica/a.txt", http.StatusForbidden},
+		{http.MethodDelete, "/replica/a.txt", http.StatusForbidden},
+		{http.MethodPost, "/replica?delete", http.StatusForbidden},
+		{http.MethodPut, "/replica?policy", http.StatusOK},
+		{http.MethodPut, "/other/a.txt", http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(testCase.method, testCase.target, nil))
+		if rec.Code != testCase.status {
+			t.Fatalf("%s: test %d: expected status %d, got %d", instanceType, i+1, testCase.status, rec.Code)
+		}
+		if rec.Code == http.StatusForbidden && rec.Header().Get(xhttp.MinIOReadReplicaSource) != url+"/source" {
+			t.Fatalf("%s: test %d: expected the source as hint, got %v", instanceType, i+1, rec.Header())
+		}
+	}
+
+	// Regular buckets accept writes again.
+	if err = globalBucketMetadataSys.Update(bucket, bucketReadReplicaConfigFile, nil); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, ok := readReplicaSource(bucket); ok {
+		t.Fatalf("%s: expected %s not to be a read replica", instanceType, bucket)
+	}
+}
+
+func TestParseBucketReadReplica(t *testing.T) {
+	testCases := []struct {
+		config string
+		valid  bool
+	}{
+		{`{}`, true},
+		{`{"endpoint":"https://source:9000","accessKey":"minio","secretKey":"minio123","bucket":"data"}`, true},
+		{`{"endpoint":"https://source:9000","accessKey":"minio","secretKey":"minio123"}`, false},
+		{`{"endpoint":"source:9000","accessKey":"minio","secretKey":"minio123","bucket":"data"}`, false},
+		{`{"endpoint":"https://source:9000","accessKey":"minio","secretKey":"minio123","bucket":"data","interval":1000}`, false},
+	}
+	for i, testCase := range testCases {
+		if _, err := parseBucketReadReplica("replica", []byte(testCase.config)); (err == nil) != testCase.valid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, testCase.valid, err)
+		}
+	}
+}
@@ -0,0 +1,558 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	objectlock "github.com/minio/minio/pkg/bucket/object/lock"
+	"github.com/minio/minio/pkg/bucket/replication"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Replications waiting to be processed, beyond which new
+	// replications are dropped and left to the data crawler.
+	replicationQueueSize = 10000
+
+	// Replications processed concurrently.
+	replicationWorkers = 4
+
+	// Attempts of a replication before the object
+	// is marked as failed.
+	replicationRetries = 3
+)
+
+// ReplicationSys - replicates the objects of buckets
+// to the remote target of their replication configuration.
+type ReplicationSys struct {
+	once  sync.Once
+	queue chan replicationTask
+
+	// Replications queued, not yet processed.
+	mu     sync.Mutex
+	queued map[replicationTask]struct{}
+
+	// Bandwidth of the remote targets, by ARN.
+	throttleMu sync.Mutex
+	throttles  map[string]*bandwidthThrottle
+}
+
+// replicationTask - an object, or the deletion of
+// an object, to replicate.
+type replicationTask struct {
+	bucket    string
+	object    string
+	versionID string
+	delete    bool
+
+	// Time of the deletion, for deletions.
+	modTime time.Time
+}
+
+// NewReplicationSys returns initialized ReplicationSys
+func NewReplicationSys() *ReplicationSys {
+	return &ReplicationSys{
+		queue:     make(chan replicationTask, replicationQueueSize),
+		queued:    make(map[replicationTask]struct{}),
+		throttles: make(map[string]*bandwidthThrottle),
+	}
+}
+
+// throttle - returns the throttle shared by the replications to
+// the target, nil if the bandwidth of the target is unlimited.
+func (sys *ReplicationSys) throttle(target madmin.BucketTarget) *bandwidthThrottle {
+	sys.throttleMu.Lock()
+	defer sys.throttleMu.Unlock()
+	if target.Bandwidth <= 0 {
+		delete(sys.throttles, target.Arn)
+		return nil
+	}
+	t, ok := sys.throttles[target.Arn]
+	if !ok || t.bandwidth != target.Bandwidth {
+		t = &bandwidthThrottle{bandwidth: target.Bandwidth}
+		sys.throttles[target.Arn] = t
+	}
+	return t
+}
+
+// Get - returns the replication configuration of the bucket.
+func (sys *ReplicationSys) Get(bucket string) (*replication.Config, error) {
+	if globalIsGateway {
+		return nil, BucketReplicationConfigNotFound{Bucket: bucket}
+	}
+	return globalBucketMetadataSys.GetReplicationConfig(bucket)
+}
+
+// ObjectCreated - queues the replication of the object
+// written if it is pending replication.
+func (sys *ReplicationSys) ObjectCreated(bucket string, objInfo ObjectInfo) {
+	if objInfo.ReplicationStatus != replication.Pending {
+		return
+	}
+	sys.enqueue(replicationTask{bucket: bucket, object: objInfo.Name, versionID: objInfo.VersionID})
+}
+
+// ObjectDeleted - queues the replication of the deletion of the
+// object, deletions of specific versions and deletions replicated
+// from a source are not replicated.
+func (sys *ReplicationSys) ObjectDeleted(bucket string, objInfo ObjectInfo) {
+	if objInfo.VersionID != "" && !objInfo.DeleteMarker {
+		return
+	}
+	if objInfo.ReplicationStatus == replication.Replica {
+		return
+	}
+	cfg, err := sys.Get(bucket)
+	if err != nil || !cfg.ReplicateDelete(objInfo.Name) {
+		return
+	}
+	sys.enqueue(replicationTask{bucket: bucket, object: objInfo.Name, delete: true, modTime: UTCNow()})
+}
+
+// Requeue - queues again the replication of an object
+// still pending or which failed to replicate.
+func (sys *ReplicationSys) Requeue(bucket string, objInfo ObjectInfo) {
+	if objInfo.DeleteMarker {
+		return
+	}
+	switch objInfo.ReplicationStatus {
+	case replication.Pending, replication.Failed:
+		sys.enqueue(replicationTask{bucket: bucket, object: objInfo.Name, versionID: objInfo.VersionID})
+	}
+}
+
+func (sys *ReplicationSys) enqueue(task replicationTask) {
+	sys.once.Do(func() {
+		for i := 0; i < replicationWorkers; i++ {
+			go sys.worker(GlobalContext)
+		}
+	})
+
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+	if _, ok := sys.queued[task]; ok {
+		return
+	}
+	select {
+	case sys.queue <- task:
+		sys.queued[task] = struct{}{}
+	default:
+		logger.LogIf(GlobalContext, fmt.Errorf("Dropping replication of %s/%s, too many replications pending",
+			task.bucket, task.object))
+	}
+}
+
+func (sys *ReplicationSys) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-sys.queue:
+			sys.mu.Lock()
+			delete(sys.queued, task)
+			sys.mu.Unlock()
+
+			sys.replicate(ctx, task)
+		}
+	}
+}
+
+// replicate - replicates the task, retrying on failures. Objects
+// which could not be replicated are marked as failed, the data
+// crawler queues them again.
+func (sys *ReplicationSys) replicate(ctx context.Context, task replicationTask) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return
+	}
+
+	var err error
+	for i := 0; i < replicationRetries; i++ {
+		if task.delete {
+			err = replicateDelete(ctx, task)
+		} else {
+			err = replicateObject(ctx, objAPI, task)
+		}
+		if err == nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Duration(i+1) * time.Second):
+		}
+	}
+	logger.LogIf(ctx, fmt.Errorf("Replication of %s/%s failed: %w", task.bucket, task.object, err))
+
+	if task.delete {
+		return
+	}
+	oi, err := objAPI.GetObjectInfo(ctx, task.bucket, task.object, ObjectOptions{VersionID: task.versionID})
+	if err != nil || oi.ReplicationStatus != replication.Pending {
+		return
+	}
+	logger.LogIf(ctx, setObjectReplicationStatus(ctx, objAPI, oi, replication.Failed))
+}
+
+// replicationTarget - returns the remote target the
+// objects of the bucket are replicated to.
+func replicationTarget(bucket string) (*replication.Config, *miniogo.Core, madmin.BucketTarget, error) {
+	cfg, err := globalReplicationSys.Get(bucket)
+	if err != nil {
+		return nil, nil, madmin.BucketTarget{}, err
+	}
+	target, err := getBucketTarget(bucket, cfg.Destination().Bucket)
+	if err != nil {
+		return nil, nil, madmin.BucketTarget{}, err
+	}
+	clnt, err := newBucketTargetClient(target)
+	if err != nil {
+		return nil, nil, madmin.BucketTarget{}, err
+	}
+	return cfg, clnt, target, nil
+}
+
+// replicateObject - copies the object of the task, with its
+// metadata, to the remote target and marks it as completed.
+func replicateObject(ctx context.Context, objAPI ObjectLayer, task replicationTask) error {
+	ctx = withIOClass(ctx, ioClassReplication)
+	cfg, clnt, target, err := replicationTarget(task.bucket)
+	if err != nil {
+		if _, ok := err.(BucketReplicationConfigNotFound); ok {
+			// Replication disabled since, nothing to replicate.
+			return nil
+		}
+		return err
+	}
+	if !transferWindowsOpen(target.Windows, time.Now()) {
+		// Left pending, the data crawler queues it again.
+		return nil
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, task.bucket, task.object, nil, http.Header{}, readLock,
+		ObjectOptions{VersionID: task.versionID})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			// Removed since, nothing to replicate.
+			return nil
+		}
+		return err
+	}
+	oi := gr.ObjInfo
+	switch oi.ReplicationStatus {
+	case replication.Pending, replication.Failed:
+	default:
+		gr.Close()
+		return nil
+	}
+
+	size, err := objectReadSize(oi)
+	if err != nil {
+		gr.Close()
+		return err
+	}
+	opts, err := replicationPutOptions(oi, cfg.Destination().StorageClass)
+	if err != nil {
+		gr.Close()
+		return err
+	}
+
+	var r io.Reader = gr
+	if throttle := globalReplicationSys.throttle(target); throttle != nil {
+		r = &throttledReader{ctx: ctx, r: gr, throttle: throttle}
+	}
+	if HasSuffix(oi.Name, SlashSeparator) {
+		r, size = bytes.NewReader(nil), 0
+	}
+	// The modification time and ETag of the source resolve
+	// conflicting writes of the same object on both sites.
+	h := http.Header{}
+	h.Set(xhttp.MinIOSourceMTime, oi.ModTime.Format(time.RFC3339Nano))
+	h.Set(xhttp.MinIOSourceETag, oi.ETag)
+	err = putReplica(withReplicationHeaders(ctx, h), clnt, target.TargetBucket, oi.Name, r, size, opts)
+	// The read lock must be released before updating the status.
+	gr.Close()
+	if err != nil && !isReplicaOutdated(err) {
+		return err
+	}
+	// Objects superseded by a newer object on the target are done,
+	// the newer object is replicated back from the target.
+	return setObjectReplicationStatus(ctx, objAPI, oi, replication.Completed)
+}
+
+// putReplica - uploads the replica with a single PUT, or as a multipart
+// upload beyond the maximum size of a single PUT. The client API rejects
+// the replication status in the metadata, the core API is used instead.
+func putReplica(ctx context.Context, clnt *miniogo.Core, bucket, object string, r io.Reader, size int64, opts miniogo.PutObjectOptions) error {
+	if size <= globalMaxPartSize {
+		_, err := clnt.PutObject(ctx, bucket, object, r, size, "", "", opts)
+		return err
+	}
+
+	uploadID, err := clnt.NewMultipartUpload(ctx, bucket, object, opts)
+	if err != nil {
+		return err
+	}
+	var parts []miniogo.CompletePart
+	for partID := 1; size > 0; partID++ {
+		partSize := size
+		if partSize > globalMaxPartSize {
+			partSize = globalMaxPartSize
+		}
+		part, err := clnt.PutObjectPart(ctx, bucket, object, uploadID, partID, io.LimitReader(r, partSize), partSize, "", "", nil)
+		if err != nil {
+			logger.LogIf(ctx, clnt.AbortMultipartUpload(ctx, bucket, object, uploadID))
+			return err
+		}
+		parts = append(parts, miniogo.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+		size -= partSize
+	}
+	_, err = clnt.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts)
+	return err
+}
+
+// replicateDelete - removes the object of the task from the
+// remote target, deletions are replicated outside of the
+// windows of the target.
+func replicateDelete(ctx context.Context, task replicationTask) error {
+	_, clnt, target, err := replicationTarget(task.bucket)
+	if err != nil {
+		if _, ok := err.(BucketReplicationConfigNotFound); ok {
+			return nil
+		}
+		return err
+	}
+	h := http.Header{}
+	h.Set(xhttp.MinIOSourceReplicationRequest, "true")
+	h.Set(xhttp.MinIOSourceMTime, task.modTime.Format(time.RFC3339Nano))
+	err = clnt.Client.RemoveObject(withReplicationHeaders(ctx, h), target.TargetBucket, task.object, miniogo.RemoveObjectOptions{})
+	if err != nil && !isReplicaOutdated(err) {
+		return err
+	}
+	return nil
+}
+
+// isReplicaOutdated - returns true if the target rejected the
+// replication since its object is newer than the source.
+func isReplicaOutdated(err error) bool {
+	return miniogo.ToErrorResponse(err).StatusCode == http.StatusPreconditionFailed
+}
+
+// replicationPutOptions - returns the options uploading the
+// replica of the object, replicas keep the user metadata, tags,
+// retention and legal hold of their source.
+func replicationPutOptions(oi ObjectInfo, storageClass string) (opts miniogo.PutObjectOptions, err error) {
+	opts = miniogo.PutObjectOptions{
+		ContentType:     oi.ContentType,
+		ContentEncoding: oi.ContentEncoding,
+		StorageClass:    storageClass,
+		UserMetadata: map[string]string{
+			xhttp.AmzBucketReplicationStatus: replication.Replica.String(),
+		},
+	}
+	for k, v := range oi.UserDefined {
+		switch {
+		case strings.HasPrefix(strings.ToLower(k), "x-amz-meta-"):
+			opts.UserMetadata[k[len("x-amz-meta-"):]] = v
+		case strings.EqualFold(k, xhttp.CacheControl):
+			opts.CacheControl = v
+		case strings.EqualFold(k, xhttp.ContentDisposition):
+			opts.ContentDisposition = v
+		case strings.EqualFold(k, xhttp.ContentLanguage):
+			opts.ContentLanguage = v
+		}
+	}
+	if oi.UserTags != "" {
+		t, err := tags.ParseObjectTags(oi.UserTags)
+		if err != nil {
+			return opts, err
+		}
+		opts.UserTags = t.ToMap()
+	}
+	if ret := objectlock.GetObjectRetentionMeta(oi.UserDefined); ret.Mode.Valid() {
+		opts.Mode = miniogo.RetentionMode(ret.Mode)
+		opts.RetainUntilDate = ret.RetainUntilDate.Time
+	}
+	if hold := objectlock.GetObjectLegalHoldMeta(oi.UserDefined); hold.Status.Valid() {
+		opts.LegalHold = miniogo.LegalHoldStatus(hold.Status)
+	}
+	if crypto.S3.IsEncrypted(oi.UserDefined) {
+		opts.ServerSideEncryption = encrypt.NewSSE()
+	}
+	return opts, nil
+}
+
+// metadataUpdate - returns the source of a metadata only copy of
+// the object, its metadata are changed before the copy.
+func metadataUpdate(objAPI ObjectLayer, oi ObjectInfo) ObjectInfo {
+	srcInfo := oi
+	srcInfo.metadataOnly = true
+	srcInfo.UserDefined = make(map[string]string, len(oi.UserDefined)+3)
+	for k, v := range oi.UserDefined {
+		srcInfo.UserDefined[k] = v
+	}
+	if oi.UserTags != "" {
+		srcInfo.UserDefined[xhttp.AmzObjectTagging] = oi.UserTags
+	}
+	if !oi.Expires.IsZero() {
+		srcInfo.UserDefined["expires"] = oi.Expires.Format(http.TimeFormat)
+	}
+	if _, isFS := objAPI.(*FSObjects); isFS {
+		srcInfo.UserDefined[fsModTimeKey] = oi.ModTime.Format(time.RFC3339Nano)
+	}
+	return srcInfo
+}
+
+// copyObjectMetadata - writes srcInfo, a metadata update of the
+// object oi, in place of the metadata of the object.
+func copyObjectMetadata(ctx context.Context, objAPI ObjectLayer, oi, srcInfo ObjectInfo, opts ObjectOptions) error {
+	// Metadata updates of an object are not locked by the object layer.
+	lk := objAPI.NewNSLock(ctx, oi.Bucket, oi.Name)
+	if err := lk.GetLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	_, err := objAPI.CopyObject(ctx, oi.Bucket, oi.Name, oi.Bucket, oi.Name, srcInfo, opts, opts)
+	return err
+}
+
+// setObjectReplicationStatus - updates the replication status of
+// the object unless the object was overwritten in the meantime.
+func setObjectReplicationStatus(ctx context.Context, objAPI ObjectLayer, oi ObjectInfo, status replication.StatusType) error {
+	srcInfo := metadataUpdate(objAPI, oi)
+	srcInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = status.String()
+
+	opts := ObjectOptions{
+		VersionID: oi.VersionID,
+		CheckPrecondFn: func(cur ObjectInfo) bool {
+			return cur.ETag != oi.ETag || !cur.ModTime.Equal(oi.ModTime)
+		},
+	}
+	err := copyObjectMetadata(ctx, objAPI, oi, srcInfo, opts)
+	if _, ok := err.(PreConditionFailed); ok {
+		// Overwritten since, the new object has its own status.
+		return nil
+	}
+	return err
+}
+
+// setReplicationStatus - sets the replication status in the metadata
+// of an object being written. Replicas written by a source with the
+// s3:ReplicateObject permission are marked as replicas, objects
+// matching the replication configuration of the bucket as pending.
+func setReplicationStatus(r *http.Request, bucket, object, userTags string, metadata map[string]string) APIErrorCode {
+	delete(metadata, xhttp.AmzBucketReplicationStatus)
+
+	if replication.StatusType(r.Header.Get(xhttp.AmzBucketReplicationStatus)) == replication.Replica {
+		if s3Err := isPutActionAllowed(getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction); s3Err != ErrNone {
+			return s3Err
+		}
+		metadata[xhttp.AmzBucketReplicationStatus] = replication.Replica.String()
+		return ErrNone
+	}
+
+	// The keys of SSE-C objects are not known to the server.
+	if crypto.SSEC.IsRequested(r.Header) {
+		return ErrNone
+	}
+	cfg, err := globalReplicationSys.Get(bucket)
+	if err != nil {
+		return ErrNone
+	}
+	if cfg.Replicate(replication.ObjectOpts{Name: object, UserTags: userTags}) {
+		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
+	}
+	return ErrNone
+}
+
+// replicaOutdated - returns true if the object modified at curMTime
+// is newer than the replica, the last writer wins between sites.
+// Objects modified at the same time are ordered by ETag.
+func replicaOutdated(curMTime time.Time, curETag string, mtime time.Time, etag string) bool {
+	if curMTime.IsZero() {
+		return false
+	}
+	if curMTime.Equal(mtime) {
+		return curETag >= etag
+	}
+	return curMTime.After(mtime)
+}
+
+// setReplicaOpts - sets the options writing a replica sent by a
+// source with its modification time: the replica keeps the time
+// of its source and is rejected if the object it overwrites is
+// newer.
+func setReplicaOpts(objAPI ObjectLayer, r *http.Request, opts *ObjectOptions) {
+	if opts.UserDefined[xhttp.AmzBucketReplicationStatus] != replication.Replica.String() {
+		return
+	}
+	mtime, err := time.Parse(time.RFC3339Nano, r.Header.Get(xhttp.MinIOSourceMTime))
+	if err != nil || mtime.IsZero() {
+		return
+	}
+	etag := r.Header.Get(xhttp.MinIOSourceETag)
+
+	opts.MTime = mtime
+	if _, isFS := objAPI.(*FSObjects); isFS {
+		opts.UserDefined[fsModTimeKey] = mtime.Format(time.RFC3339Nano)
+	}
+	opts.CheckPrecondFn = func(cur ObjectInfo) bool {
+		return replicaOutdated(cur.ModTime, cur.ETag, mtime, etag)
+	}
+}
+
+// checkReplicaDelete - checks a deletion replicated from a source,
+// which requires the s3:ReplicateDelete permission. Deletions older
+// than the object they remove are rejected.
+func checkReplicaDelete(ctx context.Context, r *http.Request, bucket, object string, getObjectInfo GetObjectInfoFn) APIErrorCode {
+	if s3Err := isPutActionAllowed(getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateDeleteAction); s3Err != ErrNone {
+		return s3Err
+	}
+	mtime, err := time.Parse(time.RFC3339Nano, r.Header.Get(xhttp.MinIOSourceMTime))
+	if err != nil {
+		return ErrNone
+	}
+	if oi, err := getObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil && oi.ModTime.After(mtime) {
+		return ErrPreconditionFailed
+	}
+	return ErrNone
+}
+
+// isReplicaDeleteRequest - returns true if the request is a deletion
+// replicated from a source with the s3:ReplicateDelete permission.
+func isReplicaDeleteRequest(r *http.Request, bucket, object string) bool {
+	if r == nil || r.Header.Get(xhttp.MinIOSourceReplicationRequest) != "true" {
+		return false
+	}
+	return isPutActionAllowed(getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateDeleteAction) == ErrNone
+}
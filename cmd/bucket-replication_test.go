@@ -0,0 +1,284 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestBucketReplication(t *testing.T) {
+	remote, url, stop := startFakeTier()
+	defer stop()
+
+	ExecObjectLayerAPITest(t, func(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+		credentials auth.Credentials, t *testing.T) {
+		testBucketReplication(obj, instanceType, bucketName, apiRouter, credentials, remote, url, t)
+	}, []string{"PutBucketReplication", "PutObject", "DeleteObject"})
+}
+
+// waitReplicationStatus - waits for the replication status of the object.
+func waitReplicationStatus(obj ObjectLayer, bucket, object string, status replication.StatusType) (ObjectInfo, error) {
+	var oi ObjectInfo
+	var err error
+	for i := 0; i < 100; i++ {
+		oi, err = obj.GetObjectInfo(context.Background(), bucket, object, ObjectOptions{})
+		if err == nil && oi.ReplicationStatus == status {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return oi, err
+}
+
+func testBucketReplication(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, remote *fakeTier, endpoint string, t *testing.T) {
+	remote.reset()
+	ctx := context.Background()
+
+	putReplicationConfig := func(arn string) int {
+		config := []byte(`<ReplicationConfiguration><Rule><ID>docs</ID><Status>Enabled</Status><Priority>1</Priority>` +
+			`<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>` +
+			`<Filter><Prefix>docs/</Prefix></Filter><Destination><Bucket>` + arn + `</Bucket></Destination></Rule></ReplicationConfiguration>`)
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, makeTestTargetURL("", bucketName, "", url.Values{"replication": {""}}),
+			int64(len(config)), bytes.NewReader(config), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{"Content-Md5": getMD5HashBase64(config)})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Objects can only be replicated to remote targets of the bucket.
+	unknown := replication.ARN{Region: "us-east-1", ID: mustGetUUID(), Bucket: "replica"}.String()
+	if code := putReplicationConfig(unknown); code != http.StatusBadRequest {
+		t.Fatalf("%s: expected the replication to an unknown target to fail, got %d", instanceType, code)
+	}
+
+	arn, err := setBucketTarget(ctx, obj, bucketName, madmin.BucketTarget{
+		Endpoint:     endpoint,
+		AccessKey:    "minio",
+		SecretKey:    "minio123",
+		TargetBucket: "replica",
+		Region:       "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if code := putReplicationConfig(arn); code != http.StatusOK {
+		t.Fatalf("%s: expected the replication configuration to be set, got %d", instanceType, code)
+	}
+	if err = removeBucketTarget(ctx, obj, bucketName, arn); err != errRemoteTargetInUse {
+		t.Fatalf("%s: expected the target to be in use, got %v", instanceType, err)
+	}
+
+	data := []byte("hello replicated world")
+	for _, object := range []string{"docs/readme", "tmp/scratch"} {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, object),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{"X-Amz-Meta-Owner": "docs-team", "Content-Type": "text/plain"})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected the object to be written, got %d: %s", instanceType, rec.Code, rec.Body.String())
+		}
+	}
+
+	oi, err := waitReplicationStatus(obj, bucketName, "docs/readme", replication.Completed)
+	if err != nil || oi.ReplicationStatus != replication.Completed {
+		t.Fatalf("%s: expected the object to be replicated, got %q: %v", instanceType, oi.ReplicationStatus, err)
+	}
+	if oi.UserDefined[http.CanonicalHeaderKey("X-Amz-Replication-Status")] != replication.Completed.String() {
+		t.Fatalf("%s: expected the replication status in the metadata, got %v", instanceType, oi.UserDefined)
+	}
+	if oi, err = obj.GetObjectInfo(ctx, bucketName, "tmp/scratch", ObjectOptions{}); err != nil || !oi.ReplicationStatus.Empty() {
+		t.Fatalf("%s: expected the object not to be replicated, got %q: %v", instanceType, oi.ReplicationStatus, err)
+	}
+
+	remote.mu.Lock()
+	replica, ok := remote.objects["/replica/docs/readme"]
+	headers := remote.headers["/replica/docs/readme"]
+	_, scratch := remote.objects["/replica/tmp/scratch"]
+	remote.mu.Unlock()
+	if !ok || !bytes.Equal(replica, data) || scratch {
+		t.Fatalf("%s: unexpected replicas %q", instanceType, replica)
+	}
+	if headers.Get("X-Amz-Meta-Owner") != "docs-team" || headers.Get("Content-Type") != "text/plain" ||
+		headers.Get("X-Amz-Replication-Status") != replication.Replica.String() {
+		t.Fatalf("%s: unexpected metadata of the replica %v", instanceType, headers)
+	}
+
+	// Deletes are replicated as well.
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodDelete, getDeleteObjectURL("", bucketName, "docs/readme"),
+		0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("%s: expected the object to be deleted, got %d", instanceType, rec.Code)
+	}
+	for i := 0; i < 100; i++ {
+		remote.mu.Lock()
+		_, ok = remote.objects["/replica/docs/readme"]
+		remote.mu.Unlock()
+		if !ok {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if ok {
+		t.Fatalf("%s: expected the deletion to be replicated", instanceType)
+	}
+}
+
+func TestReplicaOutdated(t *testing.T) {
+	now := UTCNow()
+	testCases := []struct {
+		curMTime time.Time
+		curETag  string
+		mtime    time.Time
+		etag     string
+		outdated bool
+	}{
+		// No object to overwrite.
+		{time.Time{}, "", now, "b", false},
+		{now.Add(-time.Second), "a", now, "b", false},
+		{now.Add(time.Second), "a", now, "b", true},
+		// Writes at the same time are ordered by ETag.
+		{now, "a", now, "b", false},
+		{now, "c", now, "b", true},
+		{now, "b", now, "b", true},
+	}
+	for i, testCase := range testCases {
+		if outdated := replicaOutdated(testCase.curMTime, testCase.curETag, testCase.mtime, testCase.etag); outdated != testCase.outdated {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.outdated, outdated)
+		}
+	}
+}
+
+func TestReplicaConflicts(t *testing.T) {
+	ExecObjectLayerAPITest(t, testReplicaConflicts, []string{"PutObject", "DeleteObject"})
+}
+
+func testReplicaConflicts(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	ctx := context.Background()
+	object := "conflict"
+
+	put := func(data []byte, mtime time.Time) int {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, object),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+			map[string]string{
+				"X-Amz-Replication-Status": replication.Replica.String(),
+				"X-Minio-Source-Mtime":     mtime.Format(time.RFC3339Nano),
+				"X-Minio-Source-Etag":      getMD5Hash(data),
+			})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	local := []byte("written locally")
+	if _, err := obj.PutObject(ctx, bucketName, object, mustGetPutObjReader(t, bytes.NewReader(local), int64(len(local)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	oi, err := obj.GetObjectInfo(ctx, bucketName, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	// Replicas older than the local object are rejected.
+	if code := put([]byte("older replica"), oi.ModTime.Add(-time.Minute)); code != http.StatusPreconditionFailed {
+		t.Fatalf("%s: expected the older replica to be rejected, got %d", instanceType, code)
+	}
+
+	// Newer replicas overwrite the object, and keep the time of their source.
+	mtime := oi.ModTime.Add(time.Minute).Truncate(time.Millisecond)
+	if code := put([]byte("newer replica"), mtime); code != http.StatusOK {
+		t.Fatalf("%s: expected the newer replica to be written, got %d", instanceType, code)
+	}
+	oi, err = obj.GetObjectInfo(ctx, bucketName, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if !oi.ModTime.Equal(mtime) || oi.ReplicationStatus != replication.Replica || oi.Size != int64(len("newer replica")) {
+		t.Fatalf("%s: unexpected replica %v %q %d", instanceType, oi.ModTime, oi.ReplicationStatus, oi.Size)
+	}
+
+	del := func(mtime time.Time) int {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodDelete, getDeleteObjectURL("", bucketName, object),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{
+				"X-Minio-Source-Replication-Request": "true",
+				"X-Minio-Source-Mtime":               mtime.Format(time.RFC3339Nano),
+			})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Deletions older than the object are rejected.
+	if code := del(mtime.Add(-time.Second)); code != http.StatusPreconditionFailed {
+		t.Fatalf("%s: expected the older deletion to be rejected, got %d", instanceType, code)
+	}
+	if code := del(mtime.Add(time.Second)); code != http.StatusNoContent {
+		t.Fatalf("%s: expected the newer deletion to succeed, got %d", instanceType, code)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucketName, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected the object to be deleted, got %v", instanceType, err)
+	}
+}
+
+func TestReplicationThrottle(t *testing.T) {
+	sys := NewReplicationSys()
+	target := madmin.BucketTarget{Arn: "arn:minio:replication::1:replica"}
+	if sys.throttle(target) != nil {
+		t.Fatalf("expected no throttle without bandwidth")
+	}
+
+	target.Bandwidth = 1 << 20
+	throttle := sys.throttle(target)
+	if throttle == nil || sys.throttle(target) != throttle {
+		t.Fatalf("expected the replications to the target to share a throttle")
+	}
+
+	target.Bandwidth = 2 << 20
+	if updated := sys.throttle(target); updated == throttle || updated.bandwidth != target.Bandwidth {
+		t.Fatalf("expected a new throttle once the bandwidth changes")
+	}
+}
@@ -0,0 +1,206 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+const (
+	bucketSecureEraseConfigFile = "secure-erase.json"
+
+	// The data is overwritten once with zeros and synced to the drive.
+	secureEraseMethod = "zero-overwrite"
+)
+
+// parseBucketSecureErase parses the secure erase configuration of a bucket.
+func parseBucketSecureErase(bucket string, data []byte) (*madmin.BucketSecureErase, error) {
+	secureErase := &madmin.BucketSecureErase{}
+	if err := json.Unmarshal(data, secureErase); err != nil {
+		return secureErase, err
+	}
+	return secureErase, nil
+}
+
+// isSecureEraseEnabled - returns true if the data of the deleted
+// objects of the bucket must be erased.
+func isSecureEraseEnabled(bucket string) bool {
+	if globalBucketMetadataSys == nil || bucket == minioMetaBucket {
+		return false
+	}
+	secureErase, err := globalBucketMetadataSys.GetSecureEraseConfig(bucket)
+	return err == nil && secureErase.Enabled
+}
+
+// secureEraseZeros - the data overwriting the erased files.
+var secureEraseZeros = make([]byte, 1<<20)
+
+// secureErase - overwrites the regular files under filePath with zeros
+// and syncs them to the drive, before they are removed. It returns the
+// number of files and bytes overwritten.
+func secureErase(filePath string) (files int, bytes int64, err error) {
+	err = filepath.Walk(filePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if err = overwriteFile(path, info.Size()); err != nil {
+			return err
+		}
+		files++
+		bytes += info.Size()
+		return nil
+	})
+	return files, bytes, osErrToFileErr(err)
+}
+
+func overwriteFile(filePath string, size int64) error {
+	f, err := os.OpenFile(filePath, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for size > 0 {
+		n := int64(len(secureEraseZeros))
+		if size < n {
+			n = size
+		}
+		if _, err = f.Write(secureEraseZeros[:n]); err != nil {
+			return err
+		}
+		size -= n
+	}
+	return f.Sync()
+}
+
+// secureErase - erases the data of the object of the bucket, and logs
+// the certificate of the erasure.
+func (fs *FSObjects) secureErase(bucket, object string) error {
+	filePath := pathJoin(fs.fsPath, bucket, object)
+	if HasSuffix(object, SlashSeparator) {
+		// Directory objects have no data.
+		return nil
+	}
+	files, bytes, err := secureErase(filePath)
+	if err != nil {
+		if err == errFileNotFound {
+			return nil
+		}
+		return err
+	}
+	logger.AuditErasure(bucket, object, audit.Erasure{
+		Drive:  fs.fsPath,
+		Method: secureEraseMethod,
+		Files:  files,
+		Bytes:  bytes,
+	})
+	return nil
+}
+
+// SecureErase - erases the data under path of the volume, the
+// certificate names the object version of fi.
+func (s *xlStorage) SecureErase(volume, path string, fi FileInfo) error {
+	atomic.AddInt32(&s.activeIOCount, 1)
+	defer func() {
+		atomic.AddInt32(&s.activeIOCount, -1)
+	}()
+
+	volumeDir, err := s.getVolDir(volume)
+	if err != nil {
+		return err
+	}
+	filePath := pathJoin(volumeDir, path)
+	if err = checkPathLength(filePath); err != nil {
+		return err
+	}
+	files, bytes, err := secureErase(filePath)
+	if err != nil || files == 0 {
+		// Nothing erased, no certificate is logged.
+		return err
+	}
+	logger.AuditErasure(fi.Volume, fi.Name, audit.Erasure{
+		Drive:     s.hostname + s.diskPath,
+		VersionID: fi.VersionID,
+		Method:    secureEraseMethod,
+		Files:     files,
+		Bytes:     bytes,
+	})
+	return nil
+}
+
+// eraseData - erases the data of the object version of the bucket
+// at filePath, and logs the certificate of the erasure.
+func (s *xlStorage) eraseData(bucket, object, versionID, filePath string) error {
+	files, bytes, err := secureErase(filePath)
+	if err != nil {
+		return err
+	}
+	logger.AuditErasure(bucket, object, audit.Erasure{
+		Drive:     s.hostname + s.diskPath,
+		VersionID: versionID,
+		Method:    secureEraseMethod,
+		Files:     files,
+		Bytes:     bytes,
+	})
+	return nil
+}
+
+// secureErase - erases the data under path of the volume on all the
+// disks of the set, the certificates name the object version of fi.
+func (er erasureObjects) secureErase(ctx context.Context, volume, path string, fi FileInfo) []error {
+	disks := er.getDisks()
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			return disks[index].SecureErase(volume, path, fi)
+		}, index)
+	}
+	return g.Wait()
+}
+
+// deleteTmpObject - deletes the temporary object written for the
+// object of the bucket, the data left by a failed write is erased
+// first when required for the bucket.
+func (er erasureObjects) deleteTmpObject(ctx context.Context, bucket, object, tmpObj string, writeQuorum int) {
+	if isSecureEraseEnabled(bucket) {
+		for _, err := range er.secureErase(ctx, minioMetaTmpBucket, tmpObj, FileInfo{Volume: bucket, Name: object}) {
+			if err != nil && err != errDiskNotFound {
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+	er.deleteObject(ctx, minioMetaTmpBucket, tmpObj, writeQuorum)
+}
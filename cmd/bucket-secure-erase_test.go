@@ -0,0 +1,185 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/audit"
+)
+
+func TestSecureErase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secure-erase-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := bytes.Repeat([]byte("a"), len(secureEraseZeros)+10)
+	for _, name := range []string{"part.1", "part.2"} {
+		if err = ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, n, err := secureErase(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files != 2 || n != int64(2*len(data)) {
+		t.Fatalf("expected 2 files of %d bytes, got %d files of %d bytes", len(data), files, n)
+	}
+	for _, name := range []string{"part.1", "part.2"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, make([]byte, len(data))) {
+			t.Fatalf("expected %s to be overwritten with zeros", name)
+		}
+	}
+
+	if files, _, err = secureErase(filepath.Join(dir, "missing")); err != nil || files != 0 {
+		t.Fatalf("expected nothing to erase, got %d files: %v", files, err)
+	}
+}
+
+// auditRecorder - an audit target recording the erasure certificates.
+type auditRecorder struct {
+	mu       sync.Mutex
+	erasures map[string][]audit.Erasure
+}
+
+func (a *auditRecorder) Send(entry interface{}, errKind string) error {
+	if e, ok := entry.(audit.Entry); ok && e.Erasure != nil {
+		a.mu.Lock()
+		a.erasures[e.API.Object] = append(a.erasures[e.API.Object], *e.Erasure)
+		a.mu.Unlock()
+	}
+	return nil
+}
+
+func TestBucketSecureErase(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketSecureErase)
+}
+
+func testBucketSecureErase(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	// Bucket metadata is updated through the global object layer.
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	recorder := &auditRecorder{erasures: make(map[string][]audit.Erasure)}
+	oldTargets := logger.AuditTargets
+	logger.AuditTargets = []logger.Target{recorder}
+	defer func() {
+		logger.AuditTargets = oldTargets
+	}()
+
+	bucket := "secure-erase"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	data := []byte("sensitive data")
+	put := func(object string) {
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	put("plain")
+	if _, err := obj.DeleteObject(ctx, bucket, "plain", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(recorder.erasures) != 0 {
+		t.Fatalf("%s: expected no erasure without secure erase, got %v", instanceType, recorder.erasures)
+	}
+
+	if err := globalBucketMetadataSys.Update(bucket, bucketSecureEraseConfigFile, []byte(`{"enabled":true}`)); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	put("single")
+	if _, err := obj.DeleteObject(ctx, bucket, "single", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	put("bulk")
+	if _, errs := obj.DeleteObjects(ctx, bucket, []ObjectToDelete{{ObjectName: "bulk"}}, ObjectOptions{}); errs[0] != nil {
+		t.Fatalf("%s: %v", instanceType, errs[0])
+	}
+
+	objects := []string{"single", "bulk"}
+	if instanceType == ErasureTestStr {
+		// The data replaced by an overwrite and the parts of
+		// aborted uploads are erased too.
+		put("overwritten")
+		put("overwritten")
+		res, err := obj.NewMultipartUpload(ctx, bucket, "aborted", ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		_, err = obj.PutObjectPart(ctx, bucket, "aborted", res, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if err = obj.AbortMultipartUpload(ctx, bucket, "aborted", res); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		objects = append(objects, "overwritten", "aborted")
+
+		// Successful writes leave nothing to erase.
+		put("written")
+		if erasures := recorder.erasures["written"]; len(erasures) != 0 {
+			t.Fatalf("%s: unexpected erasure of written %v", instanceType, erasures)
+		}
+	}
+
+	// A certificate per drive holding the data of the objects.
+	for _, object := range objects {
+		erasures := recorder.erasures[object]
+		if len(erasures) == 0 {
+			t.Fatalf("%s: expected %s to be erased", instanceType, object)
+		}
+		var bytesErased int64
+		drives := make(map[string]bool)
+		for _, erasure := range erasures {
+			if erasure.Method != secureEraseMethod || erasure.Files == 0 || drives[erasure.Drive] {
+				t.Fatalf("%s: unexpected erasure of %s %+v", instanceType, object, erasure)
+			}
+			drives[erasure.Drive] = true
+			bytesErased += erasure.Bytes
+		}
+		if bytesErased < int64(len(data)) {
+			t.Fatalf("%s: expected the data of %s to be erased, got %v", instanceType, object, erasures)
+		}
+	}
+}
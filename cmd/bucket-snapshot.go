@@ -0,0 +1,474 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Bucket snapshots, a directory per bucket and snapshot holding
+	// the description of the snapshot and the versions of its objects.
+	bucketSnapshotPrefix = minioConfigPrefix + "/snapshots"
+
+	bucketSnapshotFile = "snapshot.json"
+
+	// Number of object versions per part of a snapshot.
+	bucketSnapshotPartSize = 10000
+)
+
+var (
+	errBucketSnapshotNotFound   = errors.New("bucket snapshot not found")
+	errBucketSnapshotVersioning = errors.New("bucket snapshots require versioning enabled")
+	errObjectVersionInSnapshot  = errors.New("object version is referenced by a bucket snapshot")
+)
+
+// bucketSnapshotInfo - the description of a snapshot, along with the
+// number of parts holding the versions of its objects.
+type bucketSnapshotInfo struct {
+	madmin.BucketSnapshot
+	Parts int `json:"parts"`
+}
+
+// bucketSnapshotEntry - the version of an object of a snapshot.
+type bucketSnapshotEntry struct {
+	Name      string `json:"name"`
+	VersionID string `json:"versionId"`
+	Size      int64  `json:"size"`
+	ETag      string `json:"etag"`
+}
+
+func bucketSnapshotDir(bucket, id string) string {
+	return path.Join(bucketSnapshotPrefix, bucket, id)
+}
+
+func bucketSnapshotPartFile(bucket, id string, part int) string {
+	return path.Join(bucketSnapshotDir(bucket, id), "part."+strconv.Itoa(part)+".json")
+}
+
+// createBucketSnapshot - records the versions of the objects of the
+// bucket current at this time. Versions are immutable, the snapshot
+// only refers to them and doesn't copy any data.
+func createBucketSnapshot(ctx context.Context, objAPI ObjectLayer, bucket string) (madmin.BucketSnapshot, error) {
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return madmin.BucketSnapshot{}, err
+	}
+	if !globalBucketVersioningSys.Enabled(bucket) {
+		return madmin.BucketSnapshot{}, errBucketSnapshotVersioning
+	}
+
+	info := bucketSnapshotInfo{
+		BucketSnapshot: madmin.BucketSnapshot{
+			ID:      mustGetUUID(),
+			Bucket:  bucket,
+			Created: UTCNow(),
+		},
+	}
+
+	var entries []bucketSnapshotEntry
+	savePart := func() error {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		info.Parts++
+		entries = entries[:0]
+		return saveConfig(ctx, objAPI, bucketSnapshotPartFile(bucket, info.ID, info.Parts), data)
+	}
+
+	err := snapshotObjectVersions(ctx, objAPI, bucket, info.Created, func(oi ObjectInfo) error {
+		entries = append(entries, bucketSnapshotEntry{
+			Name:      oi.Name,
+			VersionID: oi.VersionID,
+			Size:      oi.Size,
+			ETag:      oi.ETag,
+		})
+		info.Objects++
+		info.Size += oi.Size
+		if len(entries) < bucketSnapshotPartSize {
+			return nil
+		}
+		return savePart()
+	})
+	if err == nil && (len(entries) > 0 || info.Parts == 0) {
+		err = savePart()
+	}
+
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(info)
+	}
+	if err == nil {
+		// The snapshot exists once its description is saved.
+		err = saveConfig(ctx, objAPI, path.Join(bucketSnapshotDir(bucket, info.ID), bucketSnapshotFile), data)
+	}
+	if err != nil {
+		for part := 1; part <= info.Parts; part++ {
+			deleteConfig(ctx, objAPI, bucketSnapshotPartFile(bucket, info.ID, part))
+		}
+		return madmin.BucketSnapshot{}, err
+	}
+	return info.BucketSnapshot, nil
+}
+
+// snapshotObjectVersions - calls fn with the version of every object
+// of the bucket which was the latest one at the time, objects deleted
+// at the time are skipped.
+func snapshotObjectVersions(ctx context.Context, objAPI ObjectLayer, bucket string, at time.Time, fn func(ObjectInfo) error) error {
+	var marker string
+	for {
+		loi, err := objAPI.ListObjectVersions(ctx, bucket, "", marker, "", "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		// All the versions of an object are listed together.
+		latest := make(map[string]ObjectInfo)
+		var names []string
+		for _, oi := range loi.Objects {
+			if oi.ModTime.After(at) {
+				continue
+			}
+			cur, ok := latest[oi.Name]
+			if !ok {
+				names = append(names, oi.Name)
+			}
+			if !ok || oi.ModTime.After(cur.ModTime) {
+				latest[oi.Name] = oi
+			}
+		}
+		for _, name := range names {
+			if oi := latest[name]; !oi.DeleteMarker {
+				if err = fn(oi); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// loadBucketSnapshot - returns the description of the snapshot id.
+func loadBucketSnapshot(ctx context.Context, objAPI ObjectLayer, bucket, id string) (info bucketSnapshotInfo, err error) {
+	data, err := readConfig(ctx, objAPI, path.Join(bucketSnapshotDir(bucket, id), bucketSnapshotFile))
+	if err != nil {
+		if err == errConfigNotFound {
+			err = errBucketSnapshotNotFound
+		}
+		return info, err
+	}
+	err = json.Unmarshal(data, &info)
+	return info, err
+}
+
+// bucketSnapshotIDs - returns the ids of the snapshots of the bucket,
+// including the snapshots being created or deleted.
+func bucketSnapshotIDs(ctx context.Context, objAPI ObjectLayer, bucket string) ([]string, error) {
+	var ids []string
+	prefix := bucketSnapshotPrefix + SlashSeparator + bucket + SlashSeparator
+	var marker string
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range loi.Prefixes {
+			ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(dir, prefix), SlashSeparator))
+		}
+		if !loi.IsTruncated {
+			return ids, nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// listBucketSnapshots - returns the snapshots of the bucket, oldest first.
+func listBucketSnapshots(ctx context.Context, objAPI ObjectLayer, bucket string) ([]madmin.BucketSnapshot, error) {
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return nil, err
+	}
+
+	ids, err := bucketSnapshotIDs(ctx, objAPI, bucket)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := []madmin.BucketSnapshot{}
+	for _, id := range ids {
+		info, err := loadBucketSnapshot(ctx, objAPI, bucket, id)
+		if err != nil {
+			// Snapshots being created or deleted.
+			if err == errBucketSnapshotNotFound {
+				continue
+			}
+			return nil, err
+		}
+		snapshots = append(snapshots, info.BucketSnapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.Before(snapshots[j].Created)
+	})
+	return snapshots, nil
+}
+
+// deleteBucketSnapshots - deletes all the snapshots of the bucket,
+// when the bucket is deleted.
+func deleteBucketSnapshots(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	prefix := bucketSnapshotPrefix + SlashSeparator + bucket + SlashSeparator
+	var marker string
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, oi := range loi.Objects {
+			if err = deleteConfig(ctx, objAPI, oi.Name); err != nil && err != errConfigNotFound {
+				return err
+			}
+		}
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// bucketSnapshotVersions - the object versions referenced by the
+// snapshots, by bucket and snapshot. Snapshots don't change once
+// created, their versions are loaded once.
+type bucketSnapshotVersions struct {
+	mu       sync.Mutex
+	versions map[string]map[string]map[string]struct{}
+}
+
+var globalBucketSnapshotVersions = &bucketSnapshotVersions{
+	versions: make(map[string]map[string]map[string]struct{}),
+}
+
+func bucketSnapshotVersionKey(object, versionID string) string {
+	if versionID == nullVersionID {
+		versionID = ""
+	}
+	return object + SlashSeparator + versionID
+}
+
+// load - returns the versions of the snapshot id, nil for a snapshot
+// being created or deleted.
+func (s *bucketSnapshotVersions) load(ctx context.Context, objAPI ObjectLayer, bucket, id string) (map[string]struct{}, error) {
+	info, err := loadBucketSnapshot(ctx, objAPI, bucket, id)
+	if err != nil {
+		if err == errBucketSnapshotNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	versions := make(map[string]struct{}, info.Objects)
+	for part := 1; part <= info.Parts; part++ {
+		data, err := readConfig(ctx, objAPI, bucketSnapshotPartFile(bucket, id, part))
+		if err != nil {
+			return nil, err
+		}
+		var entries []bucketSnapshotEntry
+		if err = json.Unmarshal(data, &entries); err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			versions[bucketSnapshotVersionKey(entry.Name, entry.VersionID)] = struct{}{}
+		}
+	}
+	return versions, nil
+}
+
+// check - returns errObjectVersionInSnapshot when a snapshot of the
+// bucket refers to the object version. The snapshots are listed at
+// every check, so that the snapshots created or deleted by the other
+// servers are taken into account.
+func (s *bucketSnapshotVersions) check(ctx context.Context, objAPI ObjectLayer, bucket, object, versionID string) error {
+	ids, err := bucketSnapshotIDs(ctx, objAPI, bucket)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := make(map[string]map[string]struct{}, len(ids))
+	for _, id := range ids {
+		versions, ok := s.versions[bucket][id]
+		if !ok {
+			if versions, err = s.load(ctx, objAPI, bucket, id); err != nil {
+				return err
+			}
+			if versions == nil {
+				continue
+			}
+		}
+		snapshots[id] = versions
+	}
+	// Drop the deleted snapshots.
+	s.versions[bucket] = snapshots
+	if len(snapshots) == 0 {
+		delete(s.versions, bucket)
+	}
+
+	key := bucketSnapshotVersionKey(object, versionID)
+	for _, versions := range snapshots {
+		if _, ok := versions[key]; ok {
+			return errObjectVersionInSnapshot
+		}
+	}
+	return nil
+}
+
+// checkBucketSnapshotVersion - returns errObjectVersionInSnapshot when
+// the object version, about to be permanently deleted, is referenced
+// by a snapshot of the bucket.
+func checkBucketSnapshotVersion(ctx context.Context, objAPI ObjectLayer, bucket, object, versionID string) error {
+	return globalBucketSnapshotVersions.check(ctx, objAPI, bucket, object, versionID)
+}
+
+// deleteBucketSnapshot - deletes the snapshot id, the object versions
+// it refers to can be deleted again.
+func deleteBucketSnapshot(ctx context.Context, objAPI ObjectLayer, bucket, id string) error {
+	info, err := loadBucketSnapshot(ctx, objAPI, bucket, id)
+	if err != nil {
+		return err
+	}
+	if err = deleteConfig(ctx, objAPI, path.Join(bucketSnapshotDir(bucket, id), bucketSnapshotFile)); err != nil {
+		return err
+	}
+	for part := 1; part <= info.Parts; part++ {
+		if err = deleteConfig(ctx, objAPI, bucketSnapshotPartFile(bucket, id, part)); err != nil && err != errConfigNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// cloneBucketSnapshot - creates the target bucket, and copies the object
+// versions of the snapshot id into it.
+func cloneBucketSnapshot(ctx context.Context, objAPI ObjectLayer, bucket, id, target string) (madmin.BucketCloneResult, error) {
+	result := madmin.BucketCloneResult{Bucket: target}
+
+	info, err := loadBucketSnapshot(ctx, objAPI, bucket, id)
+	if err != nil {
+		return result, err
+	}
+
+	if err = objAPI.MakeBucketWithLocation(ctx, target, BucketOptions{}); err != nil {
+		return result, err
+	}
+	globalNotificationSys.LoadBucketMetadata(ctx, target)
+
+	for part := 1; part <= info.Parts; part++ {
+		data, err := readConfig(ctx, objAPI, bucketSnapshotPartFile(bucket, id, part))
+		if err != nil {
+			return result, err
+		}
+		var entries []bucketSnapshotEntry
+		if err = json.Unmarshal(data, &entries); err != nil {
+			return result, err
+		}
+		for _, entry := range entries {
+			size, err := cloneObjectVersion(ctx, objAPI, bucket, entry, target)
+			if err != nil {
+				if err == errObjectCloneSkipped {
+					result.Skipped++
+					continue
+				}
+				return result, err
+			}
+			result.Objects++
+			result.Size += size
+		}
+	}
+	return result, nil
+}
+
+var errObjectCloneSkipped = errors.New("object version skipped")
+
+// cloneObjectVersion - copies the object version of a snapshot to the
+// target bucket, with its metadata and tags. Encrypted objects, and
+// versions permanently deleted since the snapshot are skipped.
+func cloneObjectVersion(ctx context.Context, objAPI ObjectLayer, bucket string, entry bucketSnapshotEntry, target string) (int64, error) {
+	gr, err := objAPI.GetObjectNInfo(ctx, bucket, entry.Name, nil, http.Header{}, readLock, ObjectOptions{VersionID: entry.VersionID})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+			return 0, errObjectCloneSkipped
+		}
+		return 0, err
+	}
+	defer gr.Close()
+
+	oi := gr.ObjInfo
+	// The keys of encrypted objects are bound to their bucket.
+	if crypto.IsEncrypted(oi.UserDefined) {
+		return 0, errObjectCloneSkipped
+	}
+
+	metadata := make(map[string]string)
+	for k, v := range cleanMetadata(oi.UserDefined) {
+		if !strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
+			metadata[k] = v
+		}
+	}
+	if oi.UserTags != "" {
+		metadata[xhttp.AmzObjectTagging] = oi.UserTags
+	}
+	if !oi.Expires.IsZero() {
+		metadata["expires"] = oi.Expires.Format(http.TimeFormat)
+	}
+
+	size, err := oi.GetActualSize()
+	if err != nil {
+		return 0, err
+	}
+	// Multipart objects don't have the MD5 as ETag.
+	var md5Hex string
+	if len(oi.ETag) == 32 && !strings.Contains(oi.ETag, "-") && !oi.IsCompressed() {
+		md5Hex = oi.ETag
+	}
+	hr, err := hash.NewReader(gr, size, md5Hex, "", size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = objAPI.PutObject(ctx, target, entry.Name, NewPutObjReader(hr, nil, nil), ObjectOptions{
+		MTime:       oi.ModTime,
+		UserDefined: metadata,
+	}); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestBucketSnapshot(t *testing.T) {
+	ExecObjectLayerTest(t, testBucketSnapshot)
+}
+
+func testBucketSnapshot(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+
+	// Bucket metadata is updated through the global object layer.
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	bucket := "snapshot"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err := createBucketSnapshot(ctx, obj, bucket); err != errBucketSnapshotVersioning {
+		t.Fatalf("%s: expected %v, got %v", instanceType, errBucketSnapshotVersioning, err)
+	}
+	if instanceType == FSTestStr {
+		// FS doesn't support versioning.
+		return
+	}
+	if err := globalBucketMetadataSys.Update(bucket, bucketVersioningConfig, enabledBucketVersioningConfig); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	put := func(object, data string) ObjectInfo {
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		return objInfo
+	}
+	first := put("a.txt", "first version")
+	put("dir/b.txt", "deleted after the snapshot")
+
+	snapshot, err := createBucketSnapshot(ctx, obj, bucket)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if snapshot.Objects != 2 || snapshot.Size != int64(len("first version")+len("deleted after the snapshot")) {
+		t.Fatalf("%s: unexpected snapshot %+v", instanceType, snapshot)
+	}
+
+	second := put("a.txt", "second version")
+	put("c.txt", "created after the snapshot")
+
+	// The versions of the snapshot can't be deleted.
+	if err = checkBucketSnapshotVersion(ctx, obj, bucket, "a.txt", first.VersionID); err != errObjectVersionInSnapshot {
+		t.Fatalf("%s: expected %v, got %v", instanceType, errObjectVersionInSnapshot, err)
+	}
+	if err = checkBucketSnapshotVersion(ctx, obj, bucket, "a.txt", second.VersionID); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err = obj.DeleteObject(ctx, bucket, "dir/b.txt", ObjectOptions{Versioned: true}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	snapshots, err := listBucketSnapshots(ctx, obj, bucket)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(snapshots) != 1 || snapshots[0].ID != snapshot.ID {
+		t.Fatalf("%s: unexpected snapshots %+v", instanceType, snapshots)
+	}
+
+	result, err := cloneBucketSnapshot(ctx, obj, bucket, snapshot.ID, "clone")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if result.Objects != 2 || result.Skipped != 0 {
+		t.Fatalf("%s: unexpected clone %+v", instanceType, result)
+	}
+	for object, data := range map[string]string{"a.txt": "first version", "dir/b.txt": "deleted after the snapshot"} {
+		var buf bytes.Buffer
+		if err = obj.GetObject(ctx, "clone", object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("%s: %s: %v", instanceType, object, err)
+		}
+		if buf.String() != data {
+			t.Fatalf("%s: %s: expected %q, got %q", instanceType, object, data, buf.String())
+		}
+	}
+	if _, err = obj.GetObjectInfo(ctx, "clone", "c.txt", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("%s: expected objects created after the snapshot not to be cloned, got %v", instanceType, err)
+	}
+
+	// Clones are new buckets.
+	if _, err = cloneBucketSnapshot(ctx, obj, bucket, snapshot.ID, "clone"); err == nil {
+		t.Fatalf("%s: expected the clone to fail on an existing bucket", instanceType)
+	}
+
+	if err = deleteBucketSnapshot(ctx, obj, bucket, snapshot.ID); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = deleteBucketSnapshot(ctx, obj, bucket, snapshot.ID); err != errBucketSnapshotNotFound {
+		t.Fatalf("%s: expected %v, got %v", instanceType, errBucketSnapshotNotFound, err)
+	}
+	if snapshots, err = listBucketSnapshots(ctx, obj, bucket); err != nil || len(snapshots) != 0 {
+		t.Fatalf("%s: expected no snapshots, got %v %v", instanceType, snapshots, err)
+	}
+	if err = checkBucketSnapshotVersion(ctx, obj, bucket, "a.txt", first.VersionID); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	// The snapshots are deleted with their bucket.
+	if _, err = createBucketSnapshot(ctx, obj, bucket); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = obj.DeleteBucket(ctx, bucket, true); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if ids, err := bucketSnapshotIDs(ctx, obj, bucket); err != nil || len(ids) != 0 {
+		t.Fatalf("%s: expected no snapshots, got %v %v", instanceType, ids, err)
+	}
+}
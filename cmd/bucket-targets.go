@@ -0,0 +1,204 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const bucketTargetsFile = "bucket-targets.json"
+
+var (
+	errRemoteTargetInvalid = errors.New("remote target is invalid")
+	errRemoteTargetInUse   = errors.New("remote target is used by the replication configuration")
+)
+
+// parseBucketTargets parses the remote targets of a bucket from json
+func parseBucketTargets(bucket string, data []byte) (*madmin.BucketTargets, error) {
+	targets := &madmin.BucketTargets{}
+	if err := json.Unmarshal(data, targets); err != nil {
+		return targets, err
+	}
+	return targets, nil
+}
+
+// getBucketTarget returns the remote target arn of bucket.
+func getBucketTarget(bucket, arn string) (madmin.BucketTarget, error) {
+	targets, err := globalBucketMetadataSys.GetBucketTargetsConfig(bucket)
+	if err != nil {
+		return madmin.BucketTarget{}, err
+	}
+	for _, t := range targets.Targets {
+		if t.Arn == arn {
+			return t, nil
+		}
+	}
+	return madmin.BucketTarget{}, BucketRemoteTargetNotFound{Bucket: bucket, Arn: arn}
+}
+
+// listBucketTargets returns the remote targets of bucket,
+// without their secret keys.
+func listBucketTargets(bucket string) ([]madmin.BucketTarget, error) {
+	targets, err := globalBucketMetadataSys.GetBucketTargetsConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]madmin.BucketTarget, 0, len(targets.Targets))
+	for _, t := range targets.Targets {
+		t.SecretKey = ""
+		list = append(list, t)
+	}
+	return list, nil
+}
+
+// newBucketTargetClient returns a client of the remote target,
+// its requests carry the replication headers of their context.
+func newBucketTargetClient(t madmin.BucketTarget) (*miniogo.Core, error) {
+	clnt, err := newRemoteS3Client(t.Endpoint, t.AccessKey, t.SecretKey, t.Region)
+	if err != nil {
+		return nil, err
+	}
+	clnt.SetCustomTransport(replicationTransport{remoteS3Transport})
+	return clnt, nil
+}
+
+type replicationHeadersKey struct{}
+
+// withReplicationHeaders - returns a context whose requests to
+// remote targets are sent with the headers h.
+func withReplicationHeaders(ctx context.Context, h http.Header) context.Context {
+	return context.WithValue(ctx, replicationHeadersKey{}, h)
+}
+
+// replicationTransport - sets the replication headers of the
+// context of requests, which the client API does not support.
+type replicationTransport struct {
+	http.RoundTripper
+}
+
+func (t replicationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h, ok := req.Context().Value(replicationHeadersKey{}).(http.Header)
+	if !ok {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	for k, v := range h {
+		req.Header[k] = v
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// setBucketTarget - adds the remote target to bucket once its bucket
+// is found with its credentials, targets with an ARN of the bucket are
+// updated. Returns the ARN of the target.
+func setBucketTarget(ctx context.Context, objAPI ObjectLayer, bucket string, target madmin.BucketTarget) (string, error) {
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return "", err
+	}
+	if target.Endpoint == "" || target.AccessKey == "" || target.SecretKey == "" || target.TargetBucket == "" {
+		return "", errRemoteTargetInvalid
+	}
+	if target.Bandwidth < 0 || validateTransferWindows(target.Windows) != nil {
+		return "", errRemoteTargetInvalid
+	}
+	clnt, err := newBucketTargetClient(target)
+	if err != nil {
+		return "", errRemoteTargetInvalid
+	}
+	if ok, err := clnt.BucketExists(ctx, target.TargetBucket); err != nil || !ok {
+		return "", errRemoteTargetInvalid
+	}
+
+	targets, err := globalBucketMetadataSys.GetBucketTargetsConfig(bucket)
+	if err != nil {
+		return "", err
+	}
+	target.SourceBucket = bucket
+	updated := madmin.BucketTargets{Targets: make([]madmin.BucketTarget, 0, len(targets.Targets)+1)}
+	found := false
+	for _, t := range targets.Targets {
+		if target.Arn != "" && t.Arn == target.Arn {
+			// The ARN names the target bucket, it can not change.
+			if t.TargetBucket != target.TargetBucket {
+				return "", errRemoteTargetInvalid
+			}
+			t = target
+			found = true
+		}
+		updated.Targets = append(updated.Targets, t)
+	}
+	if target.Arn != "" && !found {
+		return "", BucketRemoteTargetNotFound{Bucket: bucket, Arn: target.Arn}
+	}
+	if !found {
+		target.Arn = replication.ARN{
+			Region: target.Region,
+			ID:     mustGetUUID(),
+			Bucket: target.TargetBucket,
+		}.String()
+		updated.Targets = append(updated.Targets, target)
+	}
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		return "", err
+	}
+	if err = globalBucketMetadataSys.Update(bucket, bucketTargetsFile, data); err != nil {
+		return "", err
+	}
+	return target.Arn, nil
+}
+
+// removeBucketTarget - removes the remote target arn of bucket,
+// targets the bucket replicates to are not removed.
+func removeBucketTarget(ctx context.Context, objAPI ObjectLayer, bucket, arn string) error {
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return err
+	}
+	if cfg, err := globalBucketMetadataSys.GetReplicationConfig(bucket); err == nil && cfg.Destination().Bucket == arn {
+		return errRemoteTargetInUse
+	}
+
+	targets, err := globalBucketMetadataSys.GetBucketTargetsConfig(bucket)
+	if err != nil {
+		return err
+	}
+	updated := madmin.BucketTargets{}
+	for _, t := range targets.Targets {
+		if t.Arn != arn {
+			updated.Targets = append(updated.Targets, t)
+		}
+	}
+	if len(updated.Targets) == len(targets.Targets) {
+		return BucketRemoteTargetNotFound{Bucket: bucket, Arn: arn}
+	}
+
+	var data []byte
+	if len(updated.Targets) > 0 {
+		if data, err = json.Marshal(updated); err != nil {
+			return err
+		}
+	}
+	return globalBucketMetadataSys.Update(bucket, bucketTargetsFile, data)
+}
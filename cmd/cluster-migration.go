@@ -0,0 +1,544 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Cluster migration checkpoints, one per migration job.
+	clusterMigrationPrefix = minioConfigPrefix + "/cluster-migration"
+
+	// Reconciliation reports of the complete migration jobs.
+	clusterMigrationReportPrefix = minioConfigPrefix + "/cluster-migration-reports"
+
+	// Metadata of the migrated objects holding the ETag of the object
+	// on the source, ETags differ for multipart or encrypted objects.
+	clusterMigrationSourceETagKey = ReservedMetadataPrefix + "migration-source-etag"
+
+	// Number of objects migrated between two checkpoints.
+	clusterMigrationCheckpointInterval = 100
+
+	// Number of objects named in each list of a report.
+	clusterMigrationReportMaxNames = 1000
+)
+
+var (
+	errClusterMigrationInProgress    = errors.New("cluster migration already in progress")
+	errClusterMigrationNotFound      = errors.New("cluster migration not found")
+	errClusterMigrationInvalidSource = errors.New("cluster migration source is invalid")
+	errClusterMigrationCanceled      = errors.New("cluster migration canceled")
+)
+
+// clusterMigrations - cluster migration jobs running on this server,
+// each copies the buckets of a remote S3 compatible cluster into the
+// server, verifies them and reports the differences.
+type clusterMigrations struct {
+	mu   sync.Mutex
+	jobs map[string]*clusterMigration
+}
+
+// clusterMigration - a running cluster migration job.
+type clusterMigration struct {
+	mu     sync.Mutex
+	status madmin.ClusterMigrationStatus
+	cancel context.CancelFunc
+}
+
+func newClusterMigrations() *clusterMigrations {
+	return &clusterMigrations{jobs: make(map[string]*clusterMigration)}
+}
+
+// Start - validates the job and starts migrating in the
+// background, returns the ID of the new job.
+func (m *clusterMigrations) Start(ctx context.Context, objAPI ObjectLayer, job madmin.ClusterMigrationJob) (string, error) {
+	if err := validateClusterMigrationSource(ctx, job.Source); err != nil {
+		return "", err
+	}
+
+	status := madmin.ClusterMigrationStatus{
+		ID:  mustGetUUID(),
+		Job: job,
+	}
+	if err := m.run(ctx, objAPI, status); err != nil {
+		return "", err
+	}
+	return status.ID, nil
+}
+
+// Resume - resumes the job id from its last checkpoint, complete
+// jobs migrate and verify all the buckets again.
+func (m *clusterMigrations) Resume(ctx context.Context, objAPI ObjectLayer, id string) error {
+	status, err := loadClusterMigrationCheckpoint(ctx, objAPI, id)
+	if err != nil {
+		if err == errConfigNotFound {
+			return errClusterMigrationNotFound
+		}
+		return err
+	}
+	if status.Complete {
+		status.Bucket, status.Object = "", ""
+		status.Objects, status.Bytes, status.Skipped, status.Failed = 0, 0, 0, 0
+		status.Complete = false
+	}
+	return m.run(ctx, objAPI, status)
+}
+
+// Cancel - stops the job id, its checkpoint is saved and
+// the job can be resumed later on.
+func (m *clusterMigrations) Cancel(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return errClusterMigrationNotFound
+	}
+	job.cancel()
+	return nil
+}
+
+// Status - returns the status of all the jobs, the jobs not
+// running on this server are reported from their checkpoints.
+func (m *clusterMigrations) Status(ctx context.Context, objAPI ObjectLayer) ([]madmin.ClusterMigrationStatus, error) {
+	statuses := make(map[string]madmin.ClusterMigrationStatus)
+
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, clusterMigrationPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range loi.Objects {
+			id := strings.TrimSuffix(path.Base(obj.Name), ".json")
+			status, err := loadClusterMigrationCheckpoint(ctx, objAPI, id)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			statuses[id] = status
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+
+	m.mu.Lock()
+	for id, job := range m.jobs {
+		statuses[id] = job.Status()
+	}
+	m.mu.Unlock()
+
+	result := make([]madmin.ClusterMigrationStatus, 0, len(statuses))
+	for _, status := range statuses {
+		status.Job.Source.SecretKey = ""
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartTime.Before(result[j].StartTime)
+	})
+	return result, nil
+}
+
+// Report - returns the reconciliation report of the complete job id.
+func (m *clusterMigrations) Report(ctx context.Context, objAPI ObjectLayer, id string) (report madmin.ClusterMigrationReport, err error) {
+	data, err := readConfig(ctx, objAPI, clusterMigrationReportFile(id))
+	if err != nil {
+		if err == errConfigNotFound {
+			return report, errClusterMigrationNotFound
+		}
+		return report, err
+	}
+	if err = json.Unmarshal(data, &report); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// run - starts the job of status in the background.
+func (m *clusterMigrations) run(ctx context.Context, objAPI ObjectLayer, status madmin.ClusterMigrationStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.jobs[status.ID]; ok {
+		return errClusterMigrationInProgress
+	}
+
+	source := status.Job.Source
+	clnt, err := newRemoteS3Client(source.Endpoint, source.AccessKey, source.SecretKey, source.Region)
+	if err != nil {
+		return err
+	}
+
+	status.Running = true
+	status.StartTime = UTCNow()
+	status.EndTime = time.Time{}
+	status.Error = ""
+	if err = saveClusterMigrationCheckpoint(ctx, objAPI, status); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	job := &clusterMigration{status: status, cancel: cancel}
+	m.jobs[status.ID] = job
+
+	go func() {
+		err := job.migrate(ctx, objAPI, clnt)
+		if err == nil {
+			// Complete jobs are reconciled with the source.
+			err = job.reconcile(ctx, objAPI, clnt)
+		}
+		if err != nil && ctx.Err() == nil {
+			logger.LogIf(ctx, err)
+		}
+
+		// The job is reported as finished, and can be resumed,
+		// only once its final checkpoint is saved.
+		m.mu.Lock()
+		status := job.update(func(s *madmin.ClusterMigrationStatus) {
+			s.Running = false
+			s.EndTime = UTCNow()
+			switch {
+			case err == nil:
+				s.Complete = true
+			case ctx.Err() != nil:
+				s.Error = errClusterMigrationCanceled.Error()
+			default:
+				s.Error = err.Error()
+			}
+		})
+		logger.LogIf(GlobalContext, saveClusterMigrationCheckpoint(GlobalContext, objAPI, status))
+		delete(m.jobs, status.ID)
+		m.mu.Unlock()
+		cancel()
+	}()
+	return nil
+}
+
+// Status - returns the progress of the job.
+func (j *clusterMigration) Status() madmin.ClusterMigrationStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+func (j *clusterMigration) update(fn func(s *madmin.ClusterMigrationStatus)) madmin.ClusterMigrationStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.status)
+	return j.status
+}
+
+// buckets - returns the buckets of the job in lexical order, the job
+// migrates them in this order.
+func (j *clusterMigration) buckets(ctx context.Context, clnt *miniogo.Core) ([]string, error) {
+	buckets := append([]string(nil), j.Status().Job.Buckets...)
+	if len(buckets) == 0 {
+		infos, err := clnt.ListBuckets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			buckets = append(buckets, info.Name)
+		}
+	}
+	sort.Strings(buckets)
+	return buckets, nil
+}
+
+// migrate - copies all the objects of the buckets of the job after
+// its last checkpoint.
+func (j *clusterMigration) migrate(ctx context.Context, objAPI ObjectLayer, clnt *miniogo.Core) error {
+	buckets, err := j.buckets(ctx, clnt)
+	if err != nil {
+		return err
+	}
+
+	resume := j.Status()
+	for _, bucket := range buckets {
+		if bucket < resume.Bucket {
+			continue
+		}
+		var marker string
+		if bucket == resume.Bucket {
+			marker = resume.Object
+		}
+		if err = j.migrateBucket(ctx, objAPI, clnt, bucket, marker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateBucket - creates the bucket and copies all its objects after
+// marker.
+func (j *clusterMigration) migrateBucket(ctx context.Context, objAPI ObjectLayer, clnt *miniogo.Core, bucket, marker string) error {
+	if err := objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		if _, ok := err.(BucketExists); !ok {
+			return err
+		}
+	} else {
+		globalNotificationSys.LoadBucketMetadata(ctx, bucket)
+	}
+
+	j.update(func(s *madmin.ClusterMigrationStatus) {
+		s.Bucket = bucket
+		s.Object = marker
+	})
+
+	for {
+		lbr, err := clnt.ListObjects(bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range lbr.Contents {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			var copied bool
+			copied, err = copyRemoteObject(ctx, objAPI, clnt, bucket, bucket, obj)
+			if err != nil && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to migrate %s/%s: %w", bucket, obj.Key, err))
+			}
+			status := j.update(func(s *madmin.ClusterMigrationStatus) {
+				s.Object = obj.Key
+				switch {
+				case err != nil:
+					s.Failed++
+				case copied:
+					s.Objects++
+					s.Bytes += obj.Size
+				default:
+					s.Skipped++
+				}
+			})
+			if (status.Objects+status.Skipped+status.Failed)%clusterMigrationCheckpointInterval == 0 {
+				logger.LogIf(ctx, saveClusterMigrationCheckpoint(ctx, objAPI, status))
+			}
+			marker = obj.Key
+		}
+
+		if !lbr.IsTruncated {
+			return nil
+		}
+		if lbr.NextMarker != "" {
+			marker = lbr.NextMarker
+		}
+	}
+}
+
+// migratedObjectMatches - returns true if the object on the server is
+// a verified copy of the object of the source.
+func migratedObjectMatches(oi ObjectInfo, src miniogo.ObjectInfo) bool {
+	etag := canonicalizeETag(src.ETag)
+	return oi.Size == src.Size && (oi.ETag == etag || oi.UserDefined[clusterMigrationSourceETagKey] == etag)
+}
+
+// copyRemoteObject - copies the object of the source bucket into the
+// bucket unless the server has a verified copy of it. The content of
+// the objects with an MD5 ETag is verified while it is copied, the
+// size of the others. Returns false if the object was skipped.
+func copyRemoteObject(ctx context.Context, objAPI ObjectLayer, clnt *miniogo.Core, srcBucket, bucket string, src miniogo.ObjectInfo) (bool, error) {
+	if oi, err := objAPI.GetObjectInfo(ctx, bucket, src.Key, ObjectOptions{}); err == nil && migratedObjectMatches(oi, src) {
+		return false, nil
+	}
+
+	body, info, _, err := clnt.GetObject(ctx, srcBucket, src.Key, miniogo.GetObjectOptions{})
+	if err != nil {
+		return false, err
+	}
+	defer body.Close()
+
+	metadata := make(map[string]string)
+	if err = extractMetadataFromMap(ctx, info.Metadata, metadata); err != nil {
+		return false, err
+	}
+	etag := canonicalizeETag(info.ETag)
+	metadata[clusterMigrationSourceETagKey] = etag
+
+	if len(info.Metadata.Get(xhttp.AmzTagCount)) > 0 {
+		tagMap, err := clnt.Client.GetObjectTagging(ctx, srcBucket, src.Key, miniogo.GetObjectTaggingOptions{})
+		if err != nil {
+			return false, err
+		}
+		t, err := tags.NewTags(tagMap, true)
+		if err != nil {
+			return false, err
+		}
+		metadata[xhttp.AmzObjectTagging] = t.String()
+	}
+
+	// Multipart and encrypted objects don't have the MD5 as ETag.
+	var md5Hex string
+	if len(etag) == 32 && !strings.Contains(etag, "-") {
+		md5Hex = etag
+	}
+	hr, err := hash.NewReader(body, info.Size, md5Hex, "", info.Size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return false, err
+	}
+	oi, err := objAPI.PutObject(ctx, bucket, src.Key, NewPutObjReader(hr, nil, nil), ObjectOptions{UserDefined: metadata})
+	if err != nil {
+		return false, err
+	}
+	if oi.Size != info.Size {
+		return false, IncompleteBody{Bucket: bucket, Object: src.Key}
+	}
+	return true, nil
+}
+
+// reconcile - compares all the objects of the source with their
+// copies, and saves the report of the job.
+func (j *clusterMigration) reconcile(ctx context.Context, objAPI ObjectLayer, clnt *miniogo.Core) error {
+	buckets, err := j.buckets(ctx, clnt)
+	if err != nil {
+		return err
+	}
+
+	report := madmin.ClusterMigrationReport{ID: j.Status().ID}
+	for _, bucket := range buckets {
+		br := madmin.ClusterMigrationBucketReport{Bucket: bucket}
+		marker := ""
+		for {
+			lbr, err := clnt.ListObjects(bucket, "", marker, "", maxObjectList)
+			if err != nil {
+				return err
+			}
+			for _, obj := range lbr.Contents {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				default:
+				}
+				br.SourceObjects++
+				br.SourceBytes += obj.Size
+				oi, err := objAPI.GetObjectInfo(ctx, bucket, obj.Key, ObjectOptions{})
+				switch {
+				case isErrObjectNotFound(err):
+					br.MissingCount++
+					if len(br.Missing) < clusterMigrationReportMaxNames {
+						br.Missing = append(br.Missing, obj.Key)
+					}
+				case err != nil:
+					return err
+				case !migratedObjectMatches(oi, obj):
+					br.MismatchedCount++
+					if len(br.Mismatched) < clusterMigrationReportMaxNames {
+						br.Mismatched = append(br.Mismatched, obj.Key)
+					}
+				default:
+					br.Verified++
+				}
+				marker = obj.Key
+			}
+			if !lbr.IsTruncated {
+				break
+			}
+			if lbr.NextMarker != "" {
+				marker = lbr.NextMarker
+			}
+		}
+		report.Buckets = append(report.Buckets, br)
+	}
+
+	report.Time = UTCNow()
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, clusterMigrationReportFile(report.ID), data)
+}
+
+// validateClusterMigrationSource - checks that the buckets of the
+// source can be listed with its credentials.
+func validateClusterMigrationSource(ctx context.Context, source madmin.ClusterMigrationSource) error {
+	if source.Endpoint == "" || source.AccessKey == "" || source.SecretKey == "" {
+		return errClusterMigrationInvalidSource
+	}
+	clnt, err := newRemoteS3Client(source.Endpoint, source.AccessKey, source.SecretKey, source.Region)
+	if err != nil {
+		return errClusterMigrationInvalidSource
+	}
+	if _, err = clnt.ListBuckets(ctx); err != nil {
+		return errClusterMigrationInvalidSource
+	}
+	return nil
+}
+
+func clusterMigrationCheckpointFile(id string) string {
+	return path.Join(clusterMigrationPrefix, id+".json")
+}
+
+func clusterMigrationReportFile(id string) string {
+	return path.Join(clusterMigrationReportPrefix, id+".json")
+}
+
+func loadClusterMigrationCheckpoint(ctx context.Context, objAPI ObjectLayer, id string) (status madmin.ClusterMigrationStatus, err error) {
+	data, err := readConfig(ctx, objAPI, clusterMigrationCheckpointFile(id))
+	if err != nil {
+		return status, err
+	}
+	// Checkpoints hold the credentials of the source.
+	if globalConfigEncrypted {
+		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			return status, err
+		}
+	}
+	if err = json.Unmarshal(data, &status); err != nil {
+		return status, err
+	}
+	return status, nil
+}
+
+func saveClusterMigrationCheckpoint(ctx context.Context, objAPI ObjectLayer, status madmin.ClusterMigrationStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if globalConfigEncrypted {
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
+		if err != nil {
+			return err
+		}
+	}
+	return saveConfig(ctx, objAPI, clusterMigrationCheckpointFile(status.ID), data)
+}
@@ -0,0 +1,114 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestMigratedObjectMatches(t *testing.T) {
+	src := miniogo.ObjectInfo{Key: "object", Size: 10, ETag: `"d41d8cd98f00b204e9800998ecf8427e"`}
+	testCases := []struct {
+		oi      ObjectInfo
+		matches bool
+	}{
+		{ObjectInfo{Size: 10, ETag: "d41d8cd98f00b204e9800998ecf8427e"}, true},
+		{ObjectInfo{Size: 10, ETag: "abc-2", UserDefined: map[string]string{clusterMigrationSourceETagKey: "d41d8cd98f00b204e9800998ecf8427e"}}, true},
+		{ObjectInfo{Size: 10, ETag: "abc-2"}, false},
+		{ObjectInfo{Size: 11, ETag: "d41d8cd98f00b204e9800998ecf8427e"}, false},
+	}
+	for i, testCase := range testCases {
+		if matches := migratedObjectMatches(testCase.oi, src); matches != testCase.matches {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.matches, matches)
+		}
+	}
+}
+
+func TestClusterMigrationCheckpoint(t *testing.T) {
+	ExecObjectLayerTest(t, testClusterMigrationCheckpoint)
+}
+
+func testClusterMigrationCheckpoint(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	migrations := newClusterMigrations()
+
+	status := madmin.ClusterMigrationStatus{
+		ID: mustGetUUID(),
+		Job: madmin.ClusterMigrationJob{
+			Source: madmin.ClusterMigrationSource{Endpoint: "https://old.example.com", AccessKey: "minio", SecretKey: "minio123"},
+		},
+		Bucket:  "bucket",
+		Object:  "docs/a.txt",
+		Objects: 10,
+	}
+	if err := saveClusterMigrationCheckpoint(ctx, obj, status); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	loaded, err := loadClusterMigrationCheckpoint(ctx, obj, status.ID)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if loaded.Job.Source.SecretKey != "minio123" || loaded.Object != status.Object || loaded.Objects != status.Objects {
+		t.Fatalf("%s: unexpected checkpoint %#v", instanceType, loaded)
+	}
+
+	statuses, err := migrations.Status(ctx, obj)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(statuses) != 1 || statuses[0].ID != status.ID || statuses[0].Job.Source.SecretKey != "" {
+		t.Fatalf("%s: unexpected statuses %#v", instanceType, statuses)
+	}
+
+	if _, err = migrations.Report(ctx, obj, status.ID); err != errClusterMigrationNotFound {
+		t.Fatalf("%s: expected no report before the migration completes, got %v", instanceType, err)
+	}
+	report := madmin.ClusterMigrationReport{
+		ID:      status.ID,
+		Buckets: []madmin.ClusterMigrationBucketReport{{Bucket: "bucket", SourceObjects: 2, Verified: 1, MissingCount: 1, Missing: []string{"b"}}},
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, obj, clusterMigrationReportFile(status.ID), data); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	loadedReport, err := migrations.Report(ctx, obj, status.ID)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(loadedReport.Buckets) != 1 || loadedReport.Buckets[0].MissingCount != 1 {
+		t.Fatalf("%s: unexpected report %#v", instanceType, loadedReport)
+	}
+
+	if err = migrations.Resume(ctx, obj, "unknown"); err != errClusterMigrationNotFound {
+		t.Fatalf("%s: expected an unknown migration not to be resumed, got %v", instanceType, err)
+	}
+	if err = migrations.Cancel(status.ID); err != errClusterMigrationNotFound {
+		t.Fatalf("%s: expected a migration not running not to be canceled, got %v", instanceType, err)
+	}
+	if _, err = migrations.Start(ctx, obj, madmin.ClusterMigrationJob{}); err != errClusterMigrationInvalidSource {
+		t.Fatalf("%s: expected an invalid source error, got %v", instanceType, err)
+	}
+}
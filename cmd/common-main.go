@@ -17,12 +17,17 @@
 package cmd
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/gob"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,10 +35,12 @@ import (
 	"github.com/minio/cli"
 	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/cmd/config"
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/certs"
 	"github.com/minio/minio/pkg/env"
+	"github.com/minio/minio/pkg/handlers"
 )
 
 func init() {
@@ -149,6 +156,28 @@ func handleCommonCmdArgs(ctx *cli.Context) {
 		globalCLIContext.Addr = ctx.String("address")
 	}
 
+	// The first of several addresses is the one of the server endpoints.
+	addrs := strings.Split(globalCLIContext.Addr, config.ValueSeparator)
+	globalCLIContext.Addr, globalCLIContext.ExtraAddrs = addrs[0], addrs[1:]
+
+	// Fetch admin-address option
+	globalCLIContext.AdminAddr = ctx.GlobalString("admin-address")
+	if globalCLIContext.AdminAddr == "" {
+		globalCLIContext.AdminAddr = ctx.String("admin-address")
+	}
+
+	// Check "read-only" and "write-once" flags from command line argument.
+	readOnly := ctx.IsSet("read-only") || ctx.GlobalIsSet("read-only")
+	writeOnce := ctx.IsSet("write-once") || ctx.GlobalIsSet("write-once")
+	switch {
+	case readOnly && writeOnce:
+		logger.FatalIf(errInvalidArgument, "--read-only and --write-once cannot be used together")
+	case readOnly:
+		setServerMode(serverModeReadOnly)
+	case writeOnce:
+		setServerMode(serverModeWriteOnce)
+	}
+
 	// Check "no-compat" flag from command line argument.
 	globalCLIContext.StrictS3Compat = true
 	if ctx.IsSet("no-compat") || ctx.GlobalIsSet("no-compat") {
@@ -170,6 +199,26 @@ func handleCommonCmdArgs(ctx *cli.Context) {
 	globalCertsCADir = &ConfigDir{path: filepath.Join(globalCertsDir.Get(), certsCADir)}
 
 	logger.FatalIf(mkdirAllIgnorePerm(globalCertsCADir.Get()), "Unable to create certs CA directory at %s", globalCertsCADir.Get())
+
+	// Load the config file, its settings apply below the ones
+	// inherited from the shell environment.
+	configFile := ctx.GlobalString("config-file")
+	if configFile == "" {
+		configFile = ctx.String("config-file")
+	}
+	if configFile == "" {
+		configFile = env.Get(config.EnvConfigFile, "")
+	}
+	if configFile != "" {
+		cf, err := loadServerConfigFile(configFile)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidConfigFile(err), "Unable to load the config file %s", configFile)
+		}
+		globalServerConfigFile = cf
+		if cf.Erasure.SetDriveCount > 0 && !env.IsSet(EnvErasureSetDriveCount) {
+			os.Setenv(EnvErasureSetDriveCount, strconv.Itoa(cf.Erasure.SetDriveCount))
+		}
+	}
 }
 
 func handleCommonEnvVars() {
@@ -191,15 +240,78 @@ func handleCommonEnvVars() {
 		logger.Fatal(config.ErrInvalidFSOSyncValue(err), "Invalid MINIO_FS_OSYNC value in environment variable")
 	}
 
+	globalFSXattr, err = config.ParseBool(env.Get(config.EnvFSXattr, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidFSXattrValue(err), "Invalid MINIO_FS_XATTR value in environment variable")
+	}
+
+	globalDiskReserve, err = parseDiskReserve(env.Get(config.EnvDiskReserve, ""))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidDiskReserveValue(err), "Invalid MINIO_DISK_RESERVE value in environment variable")
+	}
+
+	if v := env.Get(config.EnvShutdownTimeout, ""); v != "" {
+		globalShutdownTimeout, err = time.ParseDuration(v)
+		if err == nil && globalShutdownTimeout <= 0 {
+			err = fmt.Errorf("shutdown timeout must be positive, got %s", v)
+		}
+		if err != nil {
+			logger.Fatal(config.ErrInvalidShutdownTimeoutValue(err), "Invalid MINIO_SHUTDOWN_TIMEOUT value in environment variable")
+		}
+	}
+
+	if proxies := env.Get(config.EnvTrustedProxies, ""); proxies != "" {
+		trustedProxies, err := handlers.ParseTrustedProxies(strings.Split(proxies, config.ValueSeparator))
+		if err != nil {
+			logger.Fatal(config.ErrInvalidTrustedProxiesValue(err), "Invalid MINIO_TRUSTED_PROXIES value in environment variable")
+		}
+		handlers.SetTrustedProxies(trustedProxies)
+	}
+
+	globalProxyProtocol, err = config.ParseBool(env.Get(config.EnvProxyProtocol, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidProxyProtocolValue(err), "Invalid MINIO_PROXY_PROTOCOL value in environment variable")
+	}
+	if globalProxyProtocol && !handlers.HasTrustedProxies() {
+		logger.Fatal(config.ErrInvalidProxyProtocolValue(nil).Msg("MINIO_TRUSTED_PROXIES is required"),
+			"Invalid MINIO_PROXY_PROTOCOL value in environment variable")
+	}
+
+	globalDebugSignature, err = config.ParseBool(env.Get(config.EnvDebugSignature, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidDebugSignatureValue(err), "Invalid MINIO_DEBUG_SIGNATURE value in environment variable")
+	}
+
+	globalFaultInjection, err = config.ParseBool(env.Get(config.EnvFaultInjection, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidFaultInjectionValue(err), "Invalid MINIO_FAULT_INJECTION value in environment variable")
+	}
+
+	if domains := env.Get(config.EnvACMEDomains, ""); domains != "" {
+		var acmeDomains []string
+		for _, domainName := range strings.Split(domains, config.ValueSeparator) {
+			if _, ok := dns2.IsDomainName(domainName); !ok || strings.Contains(domainName, "*") {
+				logger.Fatal(config.ErrInvalidACMEDomainValue(nil).Msg("Unknown value `%s`", domainName),
+					"Invalid MINIO_ACME_DOMAINS value in environment variable")
+			}
+			acmeDomains = append(acmeDomains, strings.TrimSuffix(strings.ToLower(domainName), "."))
+		}
+		globalACMEDomains = set.CreateStringSet(acmeDomains...)
+		globalACMEManager = newACMEManager(acmeDomains, env.Get(config.EnvACMEEmail, ""),
+			env.Get(config.EnvACMEDirectory, ""), filepath.Join(globalCertsDir.Get(), acmeCertsDir))
+	}
+
 	domains := env.Get(config.EnvDomain, "")
 	if len(domains) != 0 {
 		for _, domainName := range strings.Split(domains, config.ValueSeparator) {
-			if _, ok := dns2.IsDomainName(domainName); !ok {
+			name, ok := parseDomainName(domainName)
+			if !ok {
 				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
 					"Invalid MINIO_DOMAIN value in environment variable")
 			}
-			globalDomainNames = append(globalDomainNames, domainName)
+			globalDomainNames = append(globalDomainNames, name)
 		}
+		sortDomainNames(globalDomainNames)
 	}
 
 	publicIPs := env.Get(config.EnvPublicIPs, "")
@@ -239,6 +351,15 @@ func handleCommonEnvVars() {
 		}
 		globalActiveCred = cred
 		globalConfigEncrypted = true
+	} else if globalServerConfigFile != nil && globalServerConfigFile.Credentials.AccessKey != "" {
+		cred, err := auth.CreateCredentials(globalServerConfigFile.Credentials.AccessKey,
+			globalServerConfigFile.Credentials.SecretKey)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidCredentials(err),
+				"Unable to validate credentials of the config file")
+		}
+		globalActiveCred = cred
+		globalConfigEncrypted = true
 	}
 
 	if env.IsSet(config.EnvAccessKeyOld) && env.IsSet(config.EnvSecretKeyOld) {
@@ -262,7 +383,8 @@ func logStartupMessage(msg string) {
 
 func getTLSConfig() (x509Certs []*x509.Certificate, c *certs.Certs, secureConn bool, err error) {
 	if !(isFile(getPublicCertFile()) && isFile(getPrivateKeyFile())) {
-		return nil, nil, false, nil
+		// Certificates provisioned with ACME only, if enabled.
+		return nil, nil, globalACMEManager != nil, nil
 	}
 
 	if x509Certs, err = config.ParsePublicCertFile(getPublicCertFile()); err != nil {
@@ -274,6 +396,81 @@ func getTLSConfig() (x509Certs []*x509.Certificate, c *certs.Certs, secureConn b
 		return nil, nil, false, err
 	}
 
+	// Certificates of other host names, served to the clients requesting
+	// them with SNI, are in the sub-directories of the certs directory.
+	entries, err := ioutil.ReadDir(globalCertsDir.Get())
+	if err != nil {
+		c.Stop()
+		return nil, nil, false, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == certsCADir || entry.Name() == acmeCertsDir {
+			continue
+		}
+		certFile := filepath.Join(globalCertsDir.Get(), entry.Name(), publicCertFile)
+		keyFile := filepath.Join(globalCertsDir.Get(), entry.Name(), privateKeyFile)
+		if !(isFile(certFile) && isFile(keyFile)) {
+			continue
+		}
+		var sniCerts []*x509.Certificate
+		if sniCerts, err = config.ParsePublicCertFile(certFile); err == nil {
+			err = c.AddCertificate(certFile, keyFile)
+		}
+		if err != nil {
+			c.Stop()
+			return nil, nil, false, err
+		}
+		x509Certs = append(x509Certs, sniCerts...)
+	}
+
 	secureConn = true
 	return x509Certs, c, secureConn, nil
 }
+
+// checkExtraServerAddrs - validates the addresses the server listens
+// on besides the one of its endpoints.
+func checkExtraServerAddrs() error {
+	addrs := append([]string{}, globalCLIContext.ExtraAddrs...)
+	if globalCLIContext.AdminAddr != "" {
+		addrs = append(addrs, globalCLIContext.AdminAddr)
+	}
+	seen := set.CreateStringSet(globalCLIContext.Addr)
+	for _, addr := range addrs {
+		if seen.Contains(addr) {
+			return config.ErrInvalidAddressFlag(nil).Msg("address %s is set more than once", addr)
+		}
+		seen.Add(addr)
+		if err := CheckLocalServerAddr(addr); err != nil {
+			return err
+		}
+		if xhttp.IsInherited(addr) {
+			continue
+		}
+		host, port := mustSplitHostPort(addr)
+		if err := checkPortAvailability(host, port); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startAdminHTTPServer - serves handler on the admin address, if set.
+func startAdminHTTPServer(handler http.Handler, getCert certs.GetCertificateFunc) {
+	if globalCLIContext.AdminAddr == "" {
+		return
+	}
+
+	adminServer := xhttp.NewServer([]string{globalCLIContext.AdminAddr}, criticalErrorHandler{handler}, getCert)
+	adminServer.ShutdownTimeout = globalShutdownTimeout
+	enableACMEChallenges(adminServer)
+	adminServer.BaseContext = func(listener net.Listener) context.Context {
+		return GlobalContext
+	}
+	go func() {
+		globalHTTPServerErrorCh <- adminServer.Start()
+	}()
+
+	globalObjLayerMutex.Lock()
+	globalAdminHTTPServer = adminServer
+	globalObjLayerMutex.Unlock()
+}
@@ -22,6 +22,7 @@ import (
 	"sync"
 
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/antivirus"
 	"github.com/minio/minio/cmd/config/api"
 	"github.com/minio/minio/cmd/config/cache"
 	"github.com/minio/minio/cmd/config/compress"
@@ -32,6 +33,7 @@ import (
 	"github.com/minio/minio/cmd/config/notify"
 	"github.com/minio/minio/cmd/config/policy/opa"
 	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/config/tier"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -55,6 +57,8 @@ func initHelp() {
 		config.KmsKesSubSys:         crypto.DefaultKesKVS,
 		config.LoggerWebhookSubSys:  logger.DefaultKVS,
 		config.AuditWebhookSubSys:   logger.DefaultAuditKVS,
+		config.TierSubSys:           tier.DefaultKVS,
+		config.AntivirusSubSys:      antivirus.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
 		kvs[k] = v
@@ -116,6 +120,15 @@ func initHelp() {
 			Description:     "send audit logs to webhook endpoints",
 			MultipleTargets: true,
 		},
+		config.HelpKV{
+			Key:             config.TierSubSys,
+			Description:     "add remote tiers for lifecycle transitions",
+			MultipleTargets: true,
+		},
+		config.HelpKV{
+			Key:         config.AntivirusSubSys,
+			Description: "scan uploads with a clamd or ICAP antivirus service",
+		},
 		config.HelpKV{
 			Key:             config.NotifyWebhookSubSys,
 			Description:     "publish bucket notifications to webhook endpoints",
@@ -192,6 +205,8 @@ func initHelp() {
 		config.KmsKesSubSys:         crypto.HelpKes,
 		config.LoggerWebhookSubSys:  logger.Help,
 		config.AuditWebhookSubSys:   logger.HelpAudit,
+		config.TierSubSys:           tier.Help,
+		config.AntivirusSubSys:      antivirus.Help,
 		config.NotifyAMQPSubSys:     notify.HelpAMQP,
 		config.NotifyKafkaSubSys:    notify.HelpKafka,
 		config.NotifyMQTTSubSys:     notify.HelpMQTT,
@@ -234,7 +249,7 @@ func validateConfig(s config.Config) error {
 
 	if globalIsErasure {
 		if _, err := storageclass.LookupConfig(s[config.StorageClassSubSys][config.Default],
-			globalErasureSetDriveCount); err != nil {
+			globalErasureSetDriveCount, globalErasureFormatParity); err != nil {
 			return err
 		}
 	}
@@ -307,6 +322,14 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err := tier.LookupConfig(s); err != nil {
+		return err
+	}
+
+	if _, err := antivirus.LookupConfig(s[config.AntivirusSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	return notify.TestNotificationTargets(s, GlobalContext.Done(), NewGatewayHTTPTransport(),
 		globalNotificationSys.ConfiguredTargetIDs())
 }
@@ -344,9 +367,9 @@ func lookupConfigs(s config.Config) {
 			}
 		}
 
-		if len(globalDomainNames) != 0 && !globalDomainIPs.IsEmpty() && globalEtcdClient != nil && globalDNSConfig == nil {
+		if len(federatedDomainNames(globalDomainNames)) != 0 && !globalDomainIPs.IsEmpty() && globalEtcdClient != nil && globalDNSConfig == nil {
 			globalDNSConfig, err = dns.NewCoreDNS(etcdCfg.Config,
-				dns.DomainNames(globalDomainNames),
+				dns.DomainNames(federatedDomainNames(globalDomainNames)),
 				dns.DomainIPs(globalDomainIPs),
 				dns.DomainPort(globalMinioPort),
 				dns.CoreDNSPath(etcdCfg.CoreDNSPath),
@@ -383,7 +406,7 @@ func lookupConfigs(s config.Config) {
 
 	if globalIsErasure {
 		globalStorageClass, err = storageclass.LookupConfig(s[config.StorageClassSubSys][config.Default],
-			globalErasureSetDriveCount)
+			globalErasureSetDriveCount, globalErasureFormatParity)
 		if err != nil {
 			logger.LogIf(ctx, fmt.Errorf("Unable to initialize storage class config: %w", err))
 		}
@@ -482,6 +505,16 @@ func lookupConfigs(s config.Config) {
 		}
 	}
 
+	globalTierConfig, err = tier.LookupConfig(s)
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to initialize remote tiers: %w", err))
+	}
+
+	globalAntivirusConfig, err = antivirus.LookupConfig(s[config.AntivirusSubSys][config.Default])
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to setup antivirus scanning: %w", err))
+	}
+
 	globalConfigTargetList, err = notify.GetNotificationTargets(s, GlobalContext.Done(), NewGatewayHTTPTransport(), false)
 	if err != nil {
 		logger.LogIf(ctx, fmt.Errorf("Unable to initialize notification target(s): %w", err))
@@ -600,6 +633,9 @@ func loadConfig(objAPI ObjectLayer) error {
 		return err
 	}
 
+	// Override values from the config file, if any.
+	applyServerConfigFile(srvCfg)
+
 	// Override any values from ENVs.
 	lookupConfigs(srvCfg)
 
@@ -17,11 +17,13 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"testing"
 
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 func TestServerConfig(t *testing.T) {
@@ -58,3 +60,74 @@ func TestServerConfig(t *testing.T) {
 		t.Fatalf("Unable to initialize from updated config file %s", err)
 	}
 }
+
+func TestServerConfigHistory(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("Init Test config failed")
+	}
+
+	ctx := context.Background()
+	kv := []byte("region name=us-west-1")
+	cfg := globalServerConfig.Clone()
+	if _, err = cfg.ReadFrom(bytes.NewReader(kv)); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveServerConfigHistory(ctx, objLayer, "minio-admin", kv, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// Entry saved before revisions were introduced.
+	data, err := madmin.EncryptData(globalActiveCred.String(), kv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saveConfig(ctx, objLayer, pathJoin(minioConfigHistoryPrefix, "legacy"+kvPrefix), data); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := listServerConfigHistory(ctx, objLayer, true, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 config history entries, found %d", len(entries))
+	}
+
+	for _, entry := range entries {
+		if entry.Data != string(kv) {
+			t.Errorf("Expected data %q, found %q", string(kv), entry.Data)
+		}
+		rev, err := readServerConfigHistory(ctx, objLayer, entry.RestoreID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.RestoreID == "legacy" {
+			if entry.Author != "" || rev.Config != nil {
+				t.Errorf("Expected no author and config for legacy entry, found %q, %v", entry.Author, rev.Config)
+			}
+			continue
+		}
+		if entry.Author != "minio-admin" {
+			t.Errorf("Expected author minio-admin, found %q", entry.Author)
+		}
+
+		diffs := diffServerConfig(rev.Config.Merge(), globalServerConfig)
+		if len(diffs) != 1 {
+			t.Fatalf("Expected 1 difference, found %v", diffs)
+		}
+		diff := diffs[0]
+		if diff.SubSys != config.RegionSubSys || diff.Key != config.RegionName ||
+			diff.Revision != "us-west-1" || diff.Current != globalMinioDefaultRegion {
+			t.Errorf("Unexpected difference %v", diff)
+		}
+		if diffs = diffServerConfig(rev.Config, rev.Config); len(diffs) != 0 {
+			t.Errorf("Expected no difference with itself, found %v", diffs)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"gopkg.in/yaml.v2"
+)
+
+// serverConfigFile - declarative configuration of the server, read at
+// startup from the file set with --config-file or MINIO_CONFIG_FILE.
+// The environment takes precedence over the file, and the file over
+// the configuration stored in the backend.
+type serverConfigFile struct {
+	Credentials struct {
+		AccessKey string `json:"access_key" yaml:"access_key"`
+		SecretKey string `json:"secret_key" yaml:"secret_key"`
+	} `json:"credentials" yaml:"credentials"`
+
+	Region string `json:"region" yaml:"region"`
+
+	Erasure struct {
+		SetDriveCount int `json:"set_drive_count" yaml:"set_drive_count"`
+	} `json:"erasure" yaml:"erasure"`
+
+	// Sub-systems of the server configuration, holding either their
+	// keys, or their targets for the sub-systems with several targets.
+	Config map[string]map[string]interface{} `json:"config" yaml:"config"`
+
+	// Config as "sub-system[:target] key=value..." inputs of SetKVS.
+	kvs []string
+}
+
+// globalServerConfigFile - config file of the server, nil if not set.
+var globalServerConfigFile *serverConfigFile
+
+// loadServerConfigFile - reads and validates a YAML config file, or a
+// JSON config file if its extension is ".json".
+func loadServerConfigFile(filePath string) (*serverConfigFile, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cf := &serverConfigFile{}
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(cf)
+	} else {
+		err = yaml.UnmarshalStrict(data, cf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = cf.validate(); err != nil {
+		return nil, err
+	}
+	return cf, nil
+}
+
+// configFileValue - returns a scalar value of the config file as
+// a config value, YAML booleans are "on" or "off".
+func configFileValue(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		if v {
+			return config.EnableOn, true
+		}
+		return config.EnableOff, true
+	case int, int64, uint64, float64:
+		return fmt.Sprint(v), true
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			s, ok := configFileValue(e)
+			if !ok {
+				return "", false
+			}
+			values = append(values, s)
+		}
+		return strings.Join(values, config.ValueSeparator), true
+	}
+	return "", false
+}
+
+// configFileTarget - returns the keys of a target of the config file.
+func configFileTarget(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		target := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			target[fmt.Sprint(k)] = e
+		}
+		return target, true
+	}
+	return nil, false
+}
+
+// validate - checks the credentials, the erasure options and the
+// sub-systems of the config file, and builds its SetKVS inputs.
+func (cf *serverConfigFile) validate() error {
+	if cf.Credentials.AccessKey != "" || cf.Credentials.SecretKey != "" {
+		if _, err := auth.CreateCredentials(cf.Credentials.AccessKey, cf.Credentials.SecretKey); err != nil {
+			return fmt.Errorf("credentials: %w", err)
+		}
+	}
+	if cf.Erasure.SetDriveCount < 0 {
+		return fmt.Errorf("erasure: invalid set_drive_count %d", cf.Erasure.SetDriveCount)
+	}
+
+	var kvs []string
+	if cf.Region != "" {
+		kvs = append(kvs, fmt.Sprintf("%s %s=%q", config.RegionSubSys, config.RegionName, cf.Region))
+	}
+
+	subSystems := make([]string, 0, len(cf.Config))
+	for subSys := range cf.Config {
+		subSystems = append(subSystems, subSys)
+	}
+	sort.Strings(subSystems)
+	for _, subSys := range subSystems {
+		if subSys == config.CredentialsSubSys {
+			return fmt.Errorf("config: credentials are set in the credentials section")
+		}
+		if !config.SubSystems.Contains(subSys) {
+			return fmt.Errorf("config: unknown sub-system '%s'", subSys)
+		}
+
+		targets := make(map[string]map[string]string)
+		addKey := func(target, key string, v interface{}) error {
+			if _, ok := config.DefaultKVS[subSys].Lookup(key); !ok {
+				return fmt.Errorf("config: unknown key '%s' for sub-system '%s'", key, subSys)
+			}
+			value, ok := configFileValue(v)
+			if !ok {
+				return fmt.Errorf("config: invalid value of key '%s' for sub-system '%s'", key, subSys)
+			}
+			if targets[target] == nil {
+				targets[target] = make(map[string]string)
+			}
+			targets[target][key] = value
+			return nil
+		}
+		for k, v := range cf.Config[subSys] {
+			target, ok := configFileTarget(v)
+			if !ok {
+				if err := addKey(config.Default, k, v); err != nil {
+					return err
+				}
+				continue
+			}
+			if config.SubSystemsSingleTargets.Contains(subSys) {
+				return fmt.Errorf("config: sub-system '%s' only supports a single target", subSys)
+			}
+			for key, v := range target {
+				if err := addKey(k, key, v); err != nil {
+					return err
+				}
+			}
+		}
+
+		for target, keys := range targets {
+			input := subSys
+			if target != config.Default {
+				input += config.SubSystemSeparator + target
+			}
+			for _, key := range config.DefaultKVS[subSys].Keys() {
+				if value, ok := keys[key]; ok {
+					input += fmt.Sprintf(" %s=%q", key, value)
+				}
+			}
+			kvs = append(kvs, input)
+		}
+	}
+
+	// Check that the sub-systems can be set, their values are
+	// validated once the configuration is looked up.
+	cfg := newServerConfig()
+	for _, input := range kvs {
+		if err := cfg.SetKVS(input, config.DefaultKVS); err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+	}
+	cf.kvs = kvs
+	return nil
+}
+
+// applyServerConfigFile - sets the sub-systems of the config file,
+// if any, in cfg.
+func applyServerConfigFile(cfg config.Config) {
+	if globalServerConfigFile == nil {
+		return
+	}
+	for _, input := range globalServerConfigFile.kvs {
+		logger.LogIf(GlobalContext, cfg.SetKVS(input, config.DefaultKVS))
+	}
+}
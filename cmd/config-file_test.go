@@ -0,0 +1,104 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/cmd/config"
+)
+
+func TestServerConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-config-file-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		name   string
+		data   string
+		errMsg string
+	}{
+		{"valid.yaml", `
+credentials:
+  access_key: minio-admin
+  secret_key: minio-secret-key
+region: eu-west-1
+erasure:
+  set_drive_count: 4
+config:
+  compression:
+    enable: on
+    extensions: [".txt", ".log"]
+  notify_webhook:
+    primary:
+      enable: on
+      endpoint: http://localhost:8080
+`, ""},
+		{"valid.json", `{"region": "eu-west-1", "config": {"api": {"requests_max": 10}}}`, ""},
+		{"unknown-section.yaml", "regions: eu-west-1\n", "regions"},
+		{"unknown-field.json", `{"regions": "eu-west-1"}`, "regions"},
+		{"unknown-subsys.yaml", "config:\n  foo:\n    bar: baz\n", "unknown sub-system 'foo'"},
+		{"unknown-key.yaml", "config:\n  api:\n    foo: bar\n", "unknown key 'foo' for sub-system 'api'"},
+		{"credentials-subsys.yaml", "config:\n  credentials:\n    access_key: minio\n", "credentials section"},
+		{"single-target.yaml", "config:\n  api:\n    primary:\n      requests_max: 10\n", "single target"},
+		{"short-secret.yaml", "credentials:\n  access_key: minio-admin\n  secret_key: short\n", "credentials"},
+	}
+
+	for _, testCase := range testCases {
+		filePath := filepath.Join(dir, testCase.name)
+		if err = ioutil.WriteFile(filePath, []byte(testCase.data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err = loadServerConfigFile(filePath)
+		if testCase.errMsg == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.name, err)
+		}
+		if testCase.errMsg != "" && (err == nil || !strings.Contains(err.Error(), testCase.errMsg)) {
+			t.Errorf("%s: expected error containing %q, got %v", testCase.name, testCase.errMsg, err)
+		}
+	}
+
+	cf, err := loadServerConfigFile(filepath.Join(dir, "valid.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cf.Erasure.SetDriveCount != 4 {
+		t.Errorf("expected set_drive_count 4, got %d", cf.Erasure.SetDriveCount)
+	}
+
+	globalServerConfigFile = cf
+	defer func() { globalServerConfigFile = nil }()
+
+	cfg := newServerConfig()
+	applyServerConfigFile(cfg)
+
+	if region := cfg[config.RegionSubSys][config.Default].Get(config.RegionName); region != "eu-west-1" {
+		t.Errorf("expected region eu-west-1, got %q", region)
+	}
+	if extensions := cfg[config.CompressionSubSys][config.Default].Get("extensions"); extensions != ".txt,.log" {
+		t.Errorf("expected extensions .txt,.log, got %q", extensions)
+	}
+	if endpoint := cfg[config.NotifyWebhookSubSys]["primary"].Get("endpoint"); endpoint != "http://localhost:8080" {
+		t.Errorf("expected webhook endpoint http://localhost:8080, got %q", endpoint)
+	}
+}
@@ -20,11 +20,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/http"
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/minio-go/v7/pkg/set"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -41,6 +44,30 @@ const (
 	minioConfigFile = "config.json"
 )
 
+// configHistoryRevisionVersion is the current version of the
+// revisions saved under minioConfigHistoryPrefix, entries written
+// before revisions were introduced only carry the input KV.
+const configHistoryRevisionVersion = 1
+
+// errConfigHistoryNoRevision - the config history entry was saved
+// before revisions were introduced and has no config to compare with.
+var errConfigHistoryNoRevision = AdminError{
+	Code:       "XMinioAdminConfigHistoryNoRevision",
+	Message:    "Config history entry does not have a saved config",
+	StatusCode: http.StatusBadRequest,
+}
+
+// configHistoryRevision - immutable record of a single admin config
+// change, captures who made the change, the input KV and the full
+// server config as it was after the change.
+type configHistoryRevision struct {
+	Version int           `json:"version"`
+	Author  string        `json:"author"`
+	Time    time.Time     `json:"time"`
+	Data    string        `json:"data"`
+	Config  config.Config `json:"config"`
+}
+
 func listServerConfigHistory(ctx context.Context, objAPI ObjectLayer, withData bool, count int) (
 	[]madmin.ConfigHistoryEntry, error) {
 
@@ -59,17 +86,15 @@ func listServerConfigHistory(ctx context.Context, objAPI ObjectLayer, withData b
 				CreateTime: obj.ModTime, // ModTime is createTime for config history entries.
 			}
 			if withData {
-				data, err := readConfig(ctx, objAPI, obj.Name)
+				rev, err := readServerConfigHistory(ctx, objAPI, cfgEntry.RestoreID)
 				if err != nil {
 					return nil, err
 				}
-				if globalConfigEncrypted {
-					data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
-					if err != nil {
-						return nil, err
-					}
+				if !rev.Time.IsZero() {
+					cfgEntry.CreateTime = rev.Time
 				}
-				cfgEntry.Data = string(data)
+				cfgEntry.Author = rev.Author
+				cfgEntry.Data = rev.Data
 			}
 			configHistory = append(configHistory, cfgEntry)
 			count--
@@ -95,34 +120,112 @@ func delServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV stri
 	return err
 }
 
-func readServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV string) ([]byte, error) {
+// readServerConfigHistory - reads the revision saved for uuidKV,
+// entries without a revision header are returned with only Data
+// set and a nil Config.
+func readServerConfigHistory(ctx context.Context, objAPI ObjectLayer, uuidKV string) (rev configHistoryRevision, err error) {
 	historyFile := pathJoin(minioConfigHistoryPrefix, uuidKV+kvPrefix)
 	data, err := readConfig(ctx, objAPI, historyFile)
 	if err != nil {
-		return nil, err
+		return rev, err
 	}
 
 	if globalConfigEncrypted {
 		data, err = madmin.DecryptData(globalActiveCred.String(), bytes.NewReader(data))
+		if err != nil {
+			return rev, err
+		}
 	}
 
-	return data, err
+	// KV input never starts with '{', use it to tell the
+	// revisions apart from the older entries.
+	if len(data) > 0 && data[0] == '{' {
+		var json = jsoniter.ConfigCompatibleWithStandardLibrary
+		if err = json.Unmarshal(data, &rev); err == nil && rev.Version > 0 {
+			return rev, nil
+		}
+	}
+
+	return configHistoryRevision{Data: string(data)}, nil
 }
 
-func saveServerConfigHistory(ctx context.Context, objAPI ObjectLayer, kv []byte) error {
+// saveServerConfigHistory - saves a new revision for a config change
+// made by author, kv is the input of the change and cfg is the server
+// config after the change. Revisions are never overwritten.
+func saveServerConfigHistory(ctx context.Context, objAPI ObjectLayer, author string, kv []byte, cfg config.Config) error {
 	uuidKV := mustGetUUID() + kvPrefix
 	historyFile := pathJoin(minioConfigHistoryPrefix, uuidKV)
 
-	var err error
+	data, err := json.Marshal(configHistoryRevision{
+		Version: configHistoryRevisionVersion,
+		Author:  author,
+		Time:    UTCNow(),
+		Data:    string(kv),
+		Config:  cfg,
+	})
+	if err != nil {
+		return err
+	}
+
 	if globalConfigEncrypted {
-		kv, err = madmin.EncryptData(globalActiveCred.String(), kv)
+		data, err = madmin.EncryptData(globalActiveCred.String(), data)
 		if err != nil {
 			return err
 		}
 	}
 
 	// Save the new config KV settings into the history path.
-	return saveConfig(ctx, objAPI, historyFile, kv)
+	return saveConfig(ctx, objAPI, historyFile, data)
+}
+
+// diffServerConfig - returns all the keys with a different value
+// between the revision and the current config, sorted by sub-system,
+// target and key.
+func diffServerConfig(revision, current config.Config) []madmin.ConfigHistoryDiff {
+	var diffs []madmin.ConfigHistoryDiff
+	seen := make(map[string]map[string]bool)
+	compare := func(subSys, target string) {
+		if seen[subSys] == nil {
+			seen[subSys] = make(map[string]bool)
+		}
+		if seen[subSys][target] {
+			return
+		}
+		seen[subSys][target] = true
+
+		rkvs, ckvs := revision[subSys][target], current[subSys][target]
+		keys := set.CreateStringSet(rkvs.Keys()...).Union(set.CreateStringSet(ckvs.Keys()...))
+		for _, key := range keys.ToSlice() {
+			rv, cv := rkvs.Get(key), ckvs.Get(key)
+			if rv == cv {
+				continue
+			}
+			diffs = append(diffs, madmin.ConfigHistoryDiff{
+				SubSys:   subSys,
+				Target:   target,
+				Key:      key,
+				Revision: rv,
+				Current:  cv,
+			})
+		}
+	}
+	for _, cfg := range []config.Config{revision, current} {
+		for subSys, targets := range cfg {
+			for target := range targets {
+				compare(subSys, target)
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].SubSys != diffs[j].SubSys {
+			return diffs[i].SubSys < diffs[j].SubSys
+		}
+		if diffs[i].Target != diffs[j].Target {
+			return diffs[i].Target < diffs[j].Target
+		}
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs
 }
 
 func saveServerConfig(ctx context.Context, objAPI ObjectLayer, config interface{}) error {
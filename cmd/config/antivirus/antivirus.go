@@ -0,0 +1,175 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package antivirus
+
+import (
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/antivirus"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Protocol of the scanning service.
+type Protocol string
+
+// Supported protocols.
+const (
+	Clamd Protocol = "clamd"
+	ICAP  Protocol = "icap"
+)
+
+// Action taken on infected uploads.
+type Action string
+
+// Supported actions.
+const (
+	// Reject fails infected uploads.
+	Reject Action = "reject"
+	// Quarantine fails infected uploads, their content
+	// is saved in the quarantine bucket.
+	Quarantine Action = "quarantine"
+)
+
+// Config represents the antivirus settings.
+type Config struct {
+	Enabled          bool          `json:"enabled"`
+	Protocol         Protocol      `json:"protocol"`
+	Endpoint         string        `json:"endpoint"`
+	Action           Action        `json:"action"`
+	QuarantineBucket string        `json:"quarantineBucket"`
+	Timeout          time.Duration `json:"timeout"`
+}
+
+// Antivirus configuration keys and environment variables.
+const (
+	ProtocolKey      = "protocol"
+	Endpoint         = "endpoint"
+	ActionKey        = "action"
+	QuarantineBucket = "quarantine_bucket"
+	Timeout          = "timeout"
+
+	EnvAntivirusEnable           = "MINIO_ANTIVIRUS_ENABLE"
+	EnvAntivirusProtocol         = "MINIO_ANTIVIRUS_PROTOCOL"
+	EnvAntivirusEndpoint         = "MINIO_ANTIVIRUS_ENDPOINT"
+	EnvAntivirusAction           = "MINIO_ANTIVIRUS_ACTION"
+	EnvAntivirusQuarantineBucket = "MINIO_ANTIVIRUS_QUARANTINE_BUCKET"
+	EnvAntivirusTimeout          = "MINIO_ANTIVIRUS_TIMEOUT"
+
+	defaultTimeout = "30s"
+)
+
+// DefaultKVS - default KV config for antivirus settings
+var (
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   ProtocolKey,
+			Value: string(Clamd),
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   ActionKey,
+			Value: string(Reject),
+		},
+		config.KV{
+			Key:   QuarantineBucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   Timeout,
+			Value: defaultTimeout,
+		},
+	}
+)
+
+// NewScanner returns a scanner of the configured service.
+func (cfg Config) NewScanner() (antivirus.Scanner, error) {
+	switch cfg.Protocol {
+	case ICAP:
+		return antivirus.NewICAP(cfg.Endpoint, cfg.Timeout)
+	default:
+		return antivirus.NewClamd(cfg.Endpoint, cfg.Timeout), nil
+	}
+}
+
+// LookupConfig - lookup antivirus config.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.AntivirusSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvAntivirusEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		// Parsing failures happen due to empty KVS, ignore it.
+		if kvs.Empty() {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if !cfg.Enabled {
+		return cfg, nil
+	}
+
+	cfg.Protocol = Protocol(env.Get(EnvAntivirusProtocol, kvs.Get(ProtocolKey)))
+	if cfg.Protocol == "" {
+		cfg.Protocol = Clamd
+	}
+	if cfg.Protocol != Clamd && cfg.Protocol != ICAP {
+		return cfg, config.Errorf("antivirus: invalid protocol %q", cfg.Protocol)
+	}
+
+	cfg.Endpoint = env.Get(EnvAntivirusEndpoint, kvs.Get(Endpoint))
+	if cfg.Endpoint == "" {
+		return cfg, config.Errorf("antivirus: endpoint is missing")
+	}
+
+	cfg.Action = Action(env.Get(EnvAntivirusAction, kvs.Get(ActionKey)))
+	if cfg.Action == "" {
+		cfg.Action = Reject
+	}
+	switch cfg.Action {
+	case Reject:
+	case Quarantine:
+		cfg.QuarantineBucket = env.Get(EnvAntivirusQuarantineBucket, kvs.Get(QuarantineBucket))
+		if cfg.QuarantineBucket == "" {
+			return cfg, config.Errorf("antivirus: quarantine bucket is missing")
+		}
+	default:
+		return cfg, config.Errorf("antivirus: invalid action %q", cfg.Action)
+	}
+
+	timeout := env.Get(EnvAntivirusTimeout, kvs.Get(Timeout))
+	if timeout == "" {
+		timeout = defaultTimeout
+	}
+	cfg.Timeout, err = time.ParseDuration(timeout)
+	if err != nil {
+		return cfg, config.Errorf("antivirus: invalid timeout %q: %v", timeout, err)
+	}
+
+	if _, err = cfg.NewScanner(); err != nil {
+		return cfg, config.Errorf("antivirus: %v", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,60 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package antivirus
+
+import "github.com/minio/minio/cmd/config"
+
+// Help template for antivirus feature.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         ProtocolKey,
+			Description: `protocol of the scanning service "clamd" or "icap", defaults to "clamd"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `clamd "host:port" or unix socket path, or ICAP service URL e.g. "icap://localhost:1344/avscan"`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         ActionKey,
+			Description: `action on infected uploads "reject" or "quarantine", defaults to "reject"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         QuarantineBucket,
+			Description: `bucket the content of infected uploads is saved to with the "quarantine" action`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Timeout,
+			Description: `timeout to connect and to get the verdict of scans, defaults to "30s"`,
+			Optional:    true,
+			Type:        "duration",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
@@ -19,10 +19,13 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -33,11 +36,74 @@ const (
 	apiRequestsDeadline = "requests_deadline"
 	apiReadyDeadline    = "ready_deadline"
 	apiCorsAllowOrigin  = "cors_allow_origin"
+	apiETagMode         = "etag_mode"
+	apiStrictErrors     = "strict_errors"
+	apiMaxObjectSize    = "max_object_size"
+	apiMaxPartSize      = "max_part_size"
+	apiMaxParts         = "max_parts"
+
+	apiObjectNameNormalization   = "object_name_normalization"
+	apiObjectNameDisallowedChars = "object_name_disallowed_chars"
+	apiSniffContentType          = "sniff_content_type"
+	apiGzipResponses             = "gzip_responses"
+	apiAnonymousBandwidth        = "anonymous_bandwidth"
 
 	EnvAPIRequestsMax      = "MINIO_API_REQUESTS_MAX"
 	EnvAPIRequestsDeadline = "MINIO_API_REQUESTS_DEADLINE"
 	EnvAPIReadyDeadline    = "MINIO_API_READY_DEADLINE"
 	EnvAPICorsAllowOrigin  = "MINIO_API_CORS_ALLOW_ORIGIN"
+	EnvAPIETagMode         = "MINIO_API_ETAG_MODE"
+	EnvAPIStrictErrors     = "MINIO_API_STRICT_ERRORS"
+	EnvAPIMaxObjectSize    = "MINIO_API_MAX_OBJECT_SIZE"
+	EnvAPIMaxPartSize      = "MINIO_API_MAX_PART_SIZE"
+	EnvAPIMaxParts         = "MINIO_API_MAX_PARTS"
+
+	EnvAPIObjectNameNormalization   = "MINIO_API_OBJECT_NAME_NORMALIZATION"
+	EnvAPIObjectNameDisallowedChars = "MINIO_API_OBJECT_NAME_DISALLOWED_CHARS"
+	EnvAPISniffContentType          = "MINIO_API_SNIFF_CONTENT_TYPE"
+	EnvAPIGzipResponses             = "MINIO_API_GZIP_RESPONSES"
+	EnvAPIAnonymousBandwidth        = "MINIO_API_ANONYMOUS_BANDWIDTH"
+)
+
+// Upload limits, the defaults are the limits of S3.
+const (
+	// DefaultMaxObjectSize is the default maximum size of an object
+	// uploaded by a single PUT or assembled from its parts.
+	DefaultMaxObjectSize = 5 * humanize.TiByte
+	// DefaultMaxPartSize is the default maximum size of a part.
+	DefaultMaxPartSize = 5 * humanize.GiByte
+	// DefaultMaxParts is the default maximum number of parts of an upload.
+	DefaultMaxParts = 10000
+	// MaxParts is the largest configurable number of parts of an
+	// upload, larger values are clamped to it.
+	MaxParts = 100000
+
+	// MinPartSize is the minimum size of all the parts of an upload
+	// but the last one, the maximum part size can't be smaller.
+	MinPartSize = 5 * humanize.MiByte
+)
+
+// ETag computation modes.
+const (
+	// ETagModeMD5 computes the MD5 of every upload, as per S3.
+	ETagModeMD5 = "md5"
+	// ETagModeSHA256 derives the ETag from the payload SHA256 already
+	// verified for signature V4 requests, when the client didn't
+	// send a Content-MD5, instead of computing the MD5 in addition.
+	ETagModeSHA256 = "sha256"
+)
+
+// Unicode normalization forms of object names.
+const (
+	// ObjectNameNormalizationOff keeps object names as sent by clients,
+	// names in different normalization forms are distinct objects.
+	ObjectNameNormalizationOff = "off"
+	// ObjectNameNormalizationNFC normalizes object names to the
+	// composed form, as sent by most Linux and Windows clients.
+	ObjectNameNormalizationNFC = "nfc"
+	// ObjectNameNormalizationNFD normalizes object names to the
+	// decomposed form, as sent by some macOS clients.
+	ObjectNameNormalizationNFD = "nfd"
 )
 
 // DefaultKVS - default storage class config
@@ -59,6 +125,46 @@ var (
 			Key:   apiCorsAllowOrigin,
 			Value: "*",
 		},
+		config.KV{
+			Key:   apiETagMode,
+			Value: ETagModeMD5,
+		},
+		config.KV{
+			Key:   apiStrictErrors,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiMaxObjectSize,
+			Value: "5TiB",
+		},
+		config.KV{
+			Key:   apiMaxPartSize,
+			Value: "5GiB",
+		},
+		config.KV{
+			Key:   apiMaxParts,
+			Value: "10000",
+		},
+		config.KV{
+			Key:   apiObjectNameNormalization,
+			Value: ObjectNameNormalizationOff,
+		},
+		config.KV{
+			Key:   apiObjectNameDisallowedChars,
+			Value: "",
+		},
+		config.KV{
+			Key:   apiSniffContentType,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiGzipResponses,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   apiAnonymousBandwidth,
+			Value: "",
+		},
 	}
 )
 
@@ -68,6 +174,17 @@ type Config struct {
 	APIRequestsDeadline time.Duration `json:"requests_deadline"`
 	APIReadyDeadline    time.Duration `json:"ready_deadline"`
 	APICorsAllowOrigin  []string      `json:"cors_allow_origin"`
+	APIETagMode         string        `json:"etag_mode"`
+	APIStrictErrors     bool          `json:"strict_errors"`
+	APIMaxObjectSize    int64         `json:"max_object_size"`
+	APIMaxPartSize      int64         `json:"max_part_size"`
+	APIMaxParts         int           `json:"max_parts"`
+
+	APIObjectNameNormalization   string `json:"object_name_normalization"`
+	APIObjectNameDisallowedChars string `json:"object_name_disallowed_chars"`
+	APISniffContentType          bool   `json:"sniff_content_type"`
+	APIGzipResponses             bool   `json:"gzip_responses"`
+	APIAnonymousBandwidth        int64  `json:"anonymous_bandwidth"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -108,10 +225,116 @@ func LookupConfig(kvs config.KVS) (cfg Config, err error) {
 	}
 
 	corsAllowOrigin := strings.Split(env.Get(EnvAPICorsAllowOrigin, kvs.Get(apiCorsAllowOrigin)), ",")
+
+	etagMode := env.Get(EnvAPIETagMode, kvs.Get(apiETagMode))
+	switch etagMode {
+	case "":
+		etagMode = ETagModeMD5
+	case ETagModeMD5, ETagModeSHA256:
+	default:
+		return cfg, errors.New("invalid API etag mode value, must be one of 'md5' or 'sha256'")
+	}
+
+	strictErrors, err := config.ParseBool(env.Get(EnvAPIStrictErrors, kvs.Get(apiStrictErrors)))
+	if err != nil {
+		return cfg, err
+	}
+
+	maxObjectSize, err := parseSize(env.Get(EnvAPIMaxObjectSize, kvs.Get(apiMaxObjectSize)), DefaultMaxObjectSize)
+	if err != nil {
+		return cfg, err
+	}
+
+	maxPartSize, err := parseSize(env.Get(EnvAPIMaxPartSize, kvs.Get(apiMaxPartSize)), DefaultMaxPartSize)
+	if err != nil {
+		return cfg, err
+	}
+
+	if maxPartSize < MinPartSize {
+		return cfg, errors.New("invalid API max part size value, must be at least 5MiB")
+	}
+
+	if maxPartSize > maxObjectSize {
+		return cfg, errors.New("invalid API max part size value, must not be larger than the max object size")
+	}
+
+	maxParts := DefaultMaxParts
+	if v := env.Get(EnvAPIMaxParts, kvs.Get(apiMaxParts)); v != "" {
+		maxParts, err = strconv.Atoi(v)
+		if err != nil {
+			return cfg, err
+		}
+	}
+
+	if maxParts <= 0 {
+		return cfg, errors.New("invalid API max parts value")
+	}
+
+	if maxParts > MaxParts {
+		maxParts = MaxParts
+	}
+
+	objectNameNormalization := env.Get(EnvAPIObjectNameNormalization, kvs.Get(apiObjectNameNormalization))
+	switch objectNameNormalization {
+	case "":
+		objectNameNormalization = ObjectNameNormalizationOff
+	case ObjectNameNormalizationOff, ObjectNameNormalizationNFC, ObjectNameNormalizationNFD:
+	default:
+		return cfg, errors.New("invalid API object name normalization value, must be one of 'off', 'nfc' or 'nfd'")
+	}
+
+	objectNameDisallowedChars := env.Get(EnvAPIObjectNameDisallowedChars, kvs.Get(apiObjectNameDisallowedChars))
+	if strings.Contains(objectNameDisallowedChars, "/") {
+		return cfg, errors.New("invalid API object name disallowed characters value, '/' separates prefixes")
+	}
+
+	sniffContentType, err := config.ParseBool(env.Get(EnvAPISniffContentType, kvs.Get(apiSniffContentType)))
+	if err != nil {
+		return cfg, err
+	}
+
+	gzipResponses, err := config.ParseBool(env.Get(EnvAPIGzipResponses, kvs.Get(apiGzipResponses)))
+	if err != nil {
+		return cfg, err
+	}
+
+	// The bandwidth is a size per second, anonymous requests are not
+	// limited when empty.
+	anonymousBandwidth, err := parseSize(env.Get(EnvAPIAnonymousBandwidth, kvs.Get(apiAnonymousBandwidth)), 0)
+	if err != nil {
+		return cfg, err
+	}
+
 	return Config{
 		APIRequestsMax:      requestsMax,
 		APIRequestsDeadline: requestsDeadline,
 		APIReadyDeadline:    readyDeadline,
 		APICorsAllowOrigin:  corsAllowOrigin,
+		APIETagMode:         etagMode,
+		APIStrictErrors:     strictErrors,
+		APIMaxObjectSize:    maxObjectSize,
+		APIMaxPartSize:      maxPartSize,
+		APIMaxParts:         maxParts,
+
+		APIObjectNameNormalization:   objectNameNormalization,
+		APIObjectNameDisallowedChars: objectNameDisallowedChars,
+		APISniffContentType:          sniffContentType,
+		APIGzipResponses:             gzipResponses,
+		APIAnonymousBandwidth:        anonymousBandwidth,
 	}, nil
 }
+
+// parseSize parses a size such as "5TiB", an empty value is the default size.
+func parseSize(s string, defaultSize int64) (int64, error) {
+	if s == "" {
+		return defaultSize, nil
+	}
+	size, err := humanize.ParseBytes(s)
+	if err != nil {
+		return 0, err
+	}
+	if size == 0 || size > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %s", s)
+	}
+	return int64(size), nil
+}
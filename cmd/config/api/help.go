@@ -39,5 +39,65 @@ var (
 			Optional:    true,
 			Type:        "csv",
 		},
+		config.HelpKV{
+			Key:         apiETagMode,
+			Description: `set to "sha256" to derive ETags of uploads without Content-MD5 from the verified payload SHA256 instead of computing MD5, e.g. "md5"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiStrictErrors,
+			Description: `set to "on" to only return S3 error codes, with the same status codes and XML elements as AWS S3, e.g. "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiMaxObjectSize,
+			Description: `set the maximum size of an object, uploaded by a single PUT or assembled from its parts, e.g. "5TiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         apiMaxPartSize,
+			Description: `set the maximum size of a part of a multipart upload, at least "5MiB", e.g. "5GiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
+		config.HelpKV{
+			Key:         apiMaxParts,
+			Description: `set the maximum number of parts of a multipart upload, at most "100000", e.g. "10000"`,
+			Optional:    true,
+			Type:        "number",
+		},
+		config.HelpKV{
+			Key:         apiObjectNameNormalization,
+			Description: `set to "nfc" or "nfd" to normalize object names to that Unicode normalization form, e.g. "off"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiObjectNameDisallowedChars,
+			Description: `set the characters rejected in object names, e.g. ":*?<>|"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         apiSniffContentType,
+			Description: `set to "on" to detect the content-type of objects uploaded without one from their extension and first 512 bytes, e.g. "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiGzipResponses,
+			Description: `set to "on" to gzip text objects downloaded by clients accepting gzip responses, e.g. "off"`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         apiAnonymousBandwidth,
+			Description: `set the bandwidth per second shared by the object downloads and uploads of anonymous requests, e.g. "10MiB"`,
+			Optional:    true,
+			Type:        "size",
+		},
 	}
 )
@@ -76,6 +76,8 @@ const (
 	KmsKesSubSys         = "kms_kes"
 	LoggerWebhookSubSys  = "logger_webhook"
 	AuditWebhookSubSys   = "audit_webhook"
+	TierSubSys           = "tier"
+	AntivirusSubSys      = "antivirus"
 
 	// Add new constants here if you add new fields to config.
 )
@@ -109,6 +111,8 @@ var SubSystems = set.CreateStringSet([]string{
 	KmsKesSubSys,
 	LoggerWebhookSubSys,
 	AuditWebhookSubSys,
+	TierSubSys,
+	AntivirusSubSys,
 	PolicyOPASubSys,
 	IdentityLDAPSubSys,
 	IdentityOpenIDSubSys,
@@ -387,6 +391,11 @@ func LookupCreds(kv KVS) (auth.Credentials, error) {
 
 var validRegionRegex = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-_-]+$")
 
+// IsValidRegion - returns true if region is a valid region name.
+func IsValidRegion(region string) bool {
+	return validRegionRegex.MatchString(region)
+}
+
 // LookupRegion - get current region.
 func LookupRegion(kv KVS) (string, error) {
 	if err := CheckValidKeys(RegionSubSys, kv, DefaultRegionKVS); err != nil {
@@ -459,7 +468,8 @@ func New() Config {
 	srvCfg := make(Config)
 	for _, k := range SubSystems.ToSlice() {
 		srvCfg[k] = map[string]KVS{}
-		srvCfg[k][Default] = DefaultKVS[k]
+		// Copy the defaults, setting keys must not modify them.
+		srvCfg[k][Default] = append(KVS{}, DefaultKVS[k]...)
 	}
 	return srvCfg
 }
@@ -665,7 +675,7 @@ func (c Config) SetKVS(s string, defaultKVS map[string]KVS) error {
 
 	currKVS, ok := c[subSys][tgt]
 	if !ok {
-		currKVS = defaultKVS[subSys]
+		currKVS = append(KVS{}, defaultKVS[subSys]...)
 	} else {
 		for _, kv := range defaultKVS[subSys] {
 			if _, ok = currKVS.Lookup(kv.Key); !ok {
@@ -23,16 +23,27 @@ const (
 
 // Top level common ENVs
 const (
-	EnvAccessKey    = "MINIO_ACCESS_KEY"
-	EnvSecretKey    = "MINIO_SECRET_KEY"
-	EnvAccessKeyOld = "MINIO_ACCESS_KEY_OLD"
-	EnvSecretKeyOld = "MINIO_SECRET_KEY_OLD"
-	EnvBrowser      = "MINIO_BROWSER"
-	EnvDomain       = "MINIO_DOMAIN"
-	EnvRegionName   = "MINIO_REGION_NAME"
-	EnvPublicIPs    = "MINIO_PUBLIC_IPS"
-	EnvEndpoints    = "MINIO_ENDPOINTS"
-	EnvFSOSync      = "MINIO_FS_OSYNC"
+	EnvAccessKey       = "MINIO_ACCESS_KEY"
+	EnvSecretKey       = "MINIO_SECRET_KEY"
+	EnvAccessKeyOld    = "MINIO_ACCESS_KEY_OLD"
+	EnvSecretKeyOld    = "MINIO_SECRET_KEY_OLD"
+	EnvBrowser         = "MINIO_BROWSER"
+	EnvDomain          = "MINIO_DOMAIN"
+	EnvRegionName      = "MINIO_REGION_NAME"
+	EnvPublicIPs       = "MINIO_PUBLIC_IPS"
+	EnvEndpoints       = "MINIO_ENDPOINTS"
+	EnvFSOSync         = "MINIO_FS_OSYNC"
+	EnvFSXattr         = "MINIO_FS_XATTR"
+	EnvDiskReserve     = "MINIO_DISK_RESERVE"
+	EnvConfigFile      = "MINIO_CONFIG_FILE"
+	EnvShutdownTimeout = "MINIO_SHUTDOWN_TIMEOUT"
+	EnvACMEDomains     = "MINIO_ACME_DOMAINS"
+	EnvACMEEmail       = "MINIO_ACME_EMAIL"
+	EnvACMEDirectory   = "MINIO_ACME_DIRECTORY"
+	EnvTrustedProxies  = "MINIO_TRUSTED_PROXIES"
+	EnvProxyProtocol   = "MINIO_PROXY_PROTOCOL"
+	EnvDebugSignature  = "MINIO_DEBUG_SIGNATURE"
+	EnvFaultInjection  = "MINIO_FAULT_INJECTION"
 
 	EnvUpdate = "MINIO_UPDATE"
 
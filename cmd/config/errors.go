@@ -30,6 +30,66 @@ var (
 		"Can only accept `on` and `off` values. To enable O_SYNC for fs backend, set this value to `on`",
 	)
 
+	ErrInvalidFSXattrValue = newErrFn(
+		"Invalid xattr value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To save object metadata in extended attributes for fs backend, set this value to `on`",
+	)
+
+	ErrInvalidAuditTrailValue = newErrFn(
+		"Invalid audit trail value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To keep the audit entries in the cluster, set this value to `on`",
+	)
+
+	ErrInvalidDriveHealthValue = newErrFn(
+		"Invalid drive health value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To mark the drives predicted to fail read-only, set this value to `on`",
+	)
+
+	ErrInvalidShutdownTimeoutValue = newErrFn(
+		"Invalid shutdown timeout value",
+		"Please check the passed value",
+		"Shutdown timeout is a positive duration such as `30s` or `5m`",
+	)
+
+	ErrInvalidDiskReserveValue = newErrFn(
+		"Invalid disk reserve value",
+		"Please check the passed value",
+		"Disk reserve can be a size such as `10GiB` or a percentage of the disk size such as `5%`",
+	)
+
+	ErrInvalidTrustedProxiesValue = newErrFn(
+		"Invalid trusted proxies value",
+		"Please check the passed value",
+		"Trusted proxies are a comma separated list of IP addresses and CIDR networks such as `10.0.0.0/8`",
+	)
+
+	ErrInvalidProxyProtocolValue = newErrFn(
+		"Invalid PROXY protocol value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To read the PROXY protocol header sent by the trusted proxies, set this value to `on`",
+	)
+
+	ErrInvalidDebugSignatureValue = newErrFn(
+		"Invalid signature debugging value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To log the canonical request and string to sign of the requests with a mismatching signature, set this value to `on`",
+	)
+
+	ErrInvalidFaultInjectionValue = newErrFn(
+		"Invalid fault injection value",
+		"Please check the passed value",
+		"Can only accept `on` and `off` values. To inject the faults set with the admin API in test clusters, set this value to `on`",
+	)
+
+	ErrInvalidACMEDomainValue = newErrFn(
+		"Invalid ACME domain value",
+		"Please check the passed value",
+		"ACME domains are DNS compatible host names without wildcards, reachable from the ACME server on port 443",
+	)
+
 	ErrInvalidDomainValue = newErrFn(
 		"Invalid domain value",
 		"Please check the passed value",
@@ -42,6 +102,18 @@ var (
 		"Erasure set can only accept any of [4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16] values",
 	)
 
+	ErrInvalidErasureFailureDomains = newErrFn(
+		"Invalid erasure failure domains",
+		"Please check the passed value",
+		"MINIO_ERASURE_FAILURE_DOMAINS: list the hosts of each failure domain as `domain=host,host`, delimit the domains by `;`, hosts accept ellipses e.g. `rack1=node{1...4}`",
+	)
+
+	ErrInvalidErasureAutoParity = newErrFn(
+		"Invalid erasure auto parity value",
+		"Please check the passed value",
+		"MINIO_ERASURE_AUTO_PARITY: valid values are 'on' or 'off'",
+	)
+
 	ErrInvalidWormValue = newErrFn(
 		"Invalid WORM value",
 		"Please check the passed value",
@@ -282,4 +354,10 @@ Example 1:
 		"",
 		"Refer to https://docs.min.io/docs/minio-kms-quickstart-guide.html for setting up SSE",
 	)
+
+	ErrInvalidConfigFile = newErrFn(
+		"Invalid config file",
+		"Please check the config file",
+		"The config file is YAML or JSON with the optional sections `credentials`, `region`, `erasure` and `config`, `config` holds the sub-systems of `mc admin config set`",
+	)
 )
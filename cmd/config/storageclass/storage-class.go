@@ -220,9 +220,13 @@ func Enabled(kvs config.KVS) bool {
 }
 
 // LookupConfig - lookup storage class config and override with valid environment settings if any.
-func LookupConfig(kvs config.KVS, drivesPerSet int) (cfg Config, err error) {
+// The standard storage class defaults to defaultParity, or to half the drives per set when zero.
+func LookupConfig(kvs config.KVS, drivesPerSet, defaultParity int) (cfg Config, err error) {
+	if defaultParity == 0 {
+		defaultParity = drivesPerSet / 2
+	}
 	cfg = Config{}
-	cfg.Standard.Parity = drivesPerSet / 2
+	cfg.Standard.Parity = defaultParity
 	cfg.RRS.Parity = defaultRRSParity
 
 	if err = config.CheckValidKeys(config.StorageClassSubSys, kvs, DefaultKVS); err != nil {
@@ -239,7 +243,7 @@ func LookupConfig(kvs config.KVS, drivesPerSet int) (cfg Config, err error) {
 		}
 	}
 	if cfg.Standard.Parity == 0 {
-		cfg.Standard.Parity = drivesPerSet / 2
+		cfg.Standard.Parity = defaultParity
 	}
 
 	if rrsc != "" {
@@ -0,0 +1,75 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tier
+
+import "github.com/minio/minio/cmd/config"
+
+// Help template for remote tiers.
+var (
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         TierType,
+			Description: `tier type "s3" for objects read through the server, "glacier" for objects to be restored before reading`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Endpoint,
+			Description: `S3 endpoint URL of the tier e.g. "https://s3.amazonaws.com"`,
+			Type:        "url",
+		},
+		config.HelpKV{
+			Key:         config.AccessKey,
+			Description: `access key of the tier`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         config.SecretKey,
+			Description: `secret key of the tier`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Bucket,
+			Description: `bucket of the tier objects are transitioned to`,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Prefix,
+			Description: `prefix of the transitioned objects in the bucket`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Region,
+			Description: `region of the tier bucket`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         StorageClass,
+			Description: `storage class of the transitioned objects on the tier e.g. "GLACIER"`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         config.Comment,
+			Description: config.DefaultComment,
+			Optional:    true,
+			Type:        "sentence",
+		},
+	}
+)
@@ -0,0 +1,244 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tier
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Type of a remote tier.
+type Type string
+
+// Supported remote tier types.
+const (
+	// S3 tiers are S3 compatible object stores, objects
+	// transitioned to them are read through the server.
+	S3 Type = "s3"
+
+	// Glacier tiers are archival object stores, objects
+	// transitioned to them have to be restored before
+	// they can be read.
+	Glacier Type = "glacier"
+)
+
+// Tier is a remote tier objects are transitioned to.
+type Tier struct {
+	Name         string `json:"name"`
+	Type         Type   `json:"type"`
+	Endpoint     string `json:"endpoint"`
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey"`
+	Bucket       string `json:"bucket"`
+	Prefix       string `json:"prefix"`
+	Region       string `json:"region"`
+	StorageClass string `json:"storageClass"`
+}
+
+// Config - remote tiers configuration, the tiers
+// are referenced by their name in the StorageClass
+// of lifecycle transition rules.
+type Config struct {
+	Tiers map[string]Tier `json:"tiers"`
+}
+
+// Get returns the tier with the given name.
+func (c Config) Get(name string) (Tier, bool) {
+	t, ok := c.Tiers[name]
+	return t, ok
+}
+
+// Tier config constants.
+const (
+	TierType     = "type"
+	Endpoint     = "endpoint"
+	Bucket       = "bucket"
+	Prefix       = "prefix"
+	Region       = "region"
+	StorageClass = "storage_class"
+
+	EnvTierEnable       = "MINIO_TIER_ENABLE"
+	EnvTierType         = "MINIO_TIER_TYPE"
+	EnvTierEndpoint     = "MINIO_TIER_ENDPOINT"
+	EnvTierAccessKey    = "MINIO_TIER_ACCESS_KEY"
+	EnvTierSecretKey    = "MINIO_TIER_SECRET_KEY"
+	EnvTierBucket       = "MINIO_TIER_BUCKET"
+	EnvTierPrefix       = "MINIO_TIER_PREFIX"
+	EnvTierRegion       = "MINIO_TIER_REGION"
+	EnvTierStorageClass = "MINIO_TIER_STORAGE_CLASS"
+)
+
+// DefaultKVS - default KV config for remote tiers
+var (
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   TierType,
+			Value: string(S3),
+		},
+		config.KV{
+			Key:   Endpoint,
+			Value: "",
+		},
+		config.KV{
+			Key:   config.AccessKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   config.SecretKey,
+			Value: "",
+		},
+		config.KV{
+			Key:   Bucket,
+			Value: "",
+		},
+		config.KV{
+			Key:   Prefix,
+			Value: "",
+		},
+		config.KV{
+			Key:   Region,
+			Value: "",
+		},
+		config.KV{
+			Key:   StorageClass,
+			Value: "",
+		},
+	}
+)
+
+// Validate - validates the tier configuration.
+func (t Tier) Validate() error {
+	if t.Name == config.Default {
+		return config.Errorf("remote tiers must be named, e.g. '%s%sWARM'", config.TierSubSys, config.SubSystemSeparator)
+	}
+	if t.Name == storageclass.STANDARD || t.Name == storageclass.RRS {
+		return config.Errorf("tier name '%s' is reserved", t.Name)
+	}
+	switch t.Type {
+	case S3, Glacier:
+	default:
+		return config.Errorf("unsupported tier type '%s', expected '%s' or '%s'", t.Type, S3, Glacier)
+	}
+	u, err := url.Parse(t.Endpoint)
+	if err != nil {
+		return config.Errorf("invalid tier endpoint '%s': %s", t.Endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return config.Errorf("tier endpoint should be an http or https URL: '%s'", t.Endpoint)
+	}
+	if t.AccessKey == "" || t.SecretKey == "" {
+		return config.Errorf("tier '%s' requires an access key and a secret key", t.Name)
+	}
+	if t.Bucket == "" {
+		return config.Errorf("tier '%s' requires a bucket", t.Name)
+	}
+	return nil
+}
+
+// Lookup the environment value for the key of the target.
+func envTarget(key, target string) string {
+	if target != config.Default {
+		return key + config.Default + target
+	}
+	return key
+}
+
+// LookupConfig - lookup remote tiers config, override with ENVs if set.
+func LookupConfig(scfg config.Config) (Config, error) {
+	cfg := Config{
+		Tiers: make(map[string]Tier),
+	}
+
+	var envTargets []string
+	for _, k := range env.List(EnvTierEndpoint) {
+		target := strings.TrimPrefix(k, EnvTierEndpoint+config.Default)
+		if target == EnvTierEndpoint {
+			target = config.Default
+		}
+		envTargets = append(envTargets, target)
+	}
+
+	// Load the tiers from the environment if found
+	for _, target := range envTargets {
+		enable, err := config.ParseBool(env.Get(envTarget(EnvTierEnable, target), ""))
+		if err != nil || !enable {
+			continue
+		}
+		t := Tier{
+			Name:         target,
+			Type:         Type(env.Get(envTarget(EnvTierType, target), string(S3))),
+			Endpoint:     env.Get(envTarget(EnvTierEndpoint, target), ""),
+			AccessKey:    env.Get(envTarget(EnvTierAccessKey, target), ""),
+			SecretKey:    env.Get(envTarget(EnvTierSecretKey, target), ""),
+			Bucket:       env.Get(envTarget(EnvTierBucket, target), ""),
+			Prefix:       env.Get(envTarget(EnvTierPrefix, target), ""),
+			Region:       env.Get(envTarget(EnvTierRegion, target), ""),
+			StorageClass: env.Get(envTarget(EnvTierStorageClass, target), ""),
+		}
+		if err = t.Validate(); err != nil {
+			return cfg, err
+		}
+		cfg.Tiers[target] = t
+	}
+
+	for starget, kv := range scfg[config.TierSubSys] {
+		if _, ok := cfg.Tiers[starget]; ok {
+			// Ignore this tier since a tier with the same
+			// name is already loaded from the environment.
+			continue
+		}
+		subSysTarget := config.TierSubSys
+		if starget != config.Default {
+			subSysTarget = config.TierSubSys + config.SubSystemSeparator + starget
+		}
+		if err := config.CheckValidKeys(subSysTarget, kv, DefaultKVS); err != nil {
+			return cfg, err
+		}
+		enabled, err := config.ParseBool(kv.Get(config.Enable))
+		if err != nil {
+			return cfg, err
+		}
+		if !enabled {
+			continue
+		}
+		t := Tier{
+			Name:         starget,
+			Type:         Type(kv.Get(TierType)),
+			Endpoint:     kv.Get(Endpoint),
+			AccessKey:    kv.Get(config.AccessKey),
+			SecretKey:    kv.Get(config.SecretKey),
+			Bucket:       kv.Get(Bucket),
+			Prefix:       kv.Get(Prefix),
+			Region:       kv.Get(Region),
+			StorageClass: kv.Get(StorageClass),
+		}
+		if err = t.Validate(); err != nil {
+			return cfg, err
+		}
+		cfg.Tiers[starget] = t
+	}
+
+	return cfg, nil
+}
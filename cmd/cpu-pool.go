@@ -0,0 +1,273 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// cpuJobKind is the kind of the CPU heavy work of a request.
+type cpuJobKind int
+
+const (
+	cpuJobBitrot cpuJobKind = iota
+	cpuJobReconstruct
+	cpuJobDecrypt
+	cpuJobDecompress
+
+	cpuJobKindCount
+)
+
+func (k cpuJobKind) String() string {
+	switch k {
+	case cpuJobBitrot:
+		return "bitrot"
+	case cpuJobReconstruct:
+		return "reconstruct"
+	case cpuJobDecrypt:
+		return "decrypt"
+	case cpuJobDecompress:
+		return "decompress"
+	}
+	return "unknown"
+}
+
+// cpuJob - a function run by a worker of the pool.
+type cpuJob struct {
+	kind   cpuJobKind
+	fn     func()
+	queued time.Time
+	done   chan struct{}
+	// panicked holds the value fn panicked with, to
+	// panic again in the goroutine of the request.
+	panicked interface{}
+}
+
+// cpuJobStats - the statistics of the jobs of a kind.
+type cpuJobStats struct {
+	jobs      uint64
+	waitNanos uint64
+	busyNanos uint64
+	queued    int64
+}
+
+// cpuPool runs the CPU heavy work of the requests, the bitrot
+// verification, erasure reconstruction, decryption and decompression,
+// on a fixed number of workers apart from the goroutines waiting on the
+// network and the drives. A saturated CPU queues the work instead of
+// having ever more goroutines compete for it.
+type cpuPool struct {
+	workers int
+	jobs    chan *cpuJob
+	busy    int64
+
+	stats [cpuJobKindCount]cpuJobStats
+}
+
+func newCPUPool(workers int) *cpuPool {
+	p := &cpuPool{
+		workers: workers,
+		jobs:    make(chan *cpuJob),
+	}
+	for i := 0; i < workers; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+var globalCPUPool = newCPUPool(runtime.GOMAXPROCS(0))
+
+func (p *cpuPool) worker() {
+	for job := range p.jobs {
+		p.do(job)
+	}
+}
+
+func (p *cpuPool) do(job *cpuJob) {
+	stats := &p.stats[job.kind]
+	atomic.AddInt64(&stats.queued, -1)
+	atomic.AddUint64(&stats.waitNanos, uint64(time.Since(job.queued)))
+	atomic.AddInt64(&p.busy, 1)
+	start := time.Now()
+	defer func() {
+		job.panicked = recover()
+		atomic.AddUint64(&stats.busyNanos, uint64(time.Since(start)))
+		atomic.AddUint64(&stats.jobs, 1)
+		atomic.AddInt64(&p.busy, -1)
+		close(job.done)
+	}()
+	job.fn()
+}
+
+// run runs fn on a worker of the pool and waits for it to return,
+// or returns the error of ctx if it is done before a worker is free.
+func (p *cpuPool) run(ctx context.Context, kind cpuJobKind, fn func()) error {
+	job := &cpuJob{
+		kind:   kind,
+		fn:     fn,
+		queued: time.Now(),
+		done:   make(chan struct{}),
+	}
+	atomic.AddInt64(&p.stats[kind].queued, 1)
+	select {
+	case p.jobs <- job:
+	case <-ctx.Done():
+		atomic.AddInt64(&p.stats[kind].queued, -1)
+		return ctx.Err()
+	}
+	<-job.done
+	if job.panicked != nil {
+		panic(job.panicked)
+	}
+	return nil
+}
+
+// handoff runs fn, which blocks, on the worker running the current
+// job while another goroutine takes the jobs in its place, so that
+// the jobs fn waits on are not queued behind it.
+func (p *cpuPool) handoff(fn func()) {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case job := <-p.jobs:
+				p.do(job)
+			case <-done:
+				return
+			}
+		}
+	}()
+	fn()
+}
+
+// cpuJobMetrics - the statistics of a kind of jobs.
+type cpuJobMetrics struct {
+	Jobs   uint64
+	Wait   time.Duration
+	Busy   time.Duration
+	Queued int64
+}
+
+// metrics returns the statistics of the kinds of jobs, and the
+// number of busy workers.
+func (p *cpuPool) metrics() (m map[cpuJobKind]cpuJobMetrics, busy int64) {
+	m = make(map[cpuJobKind]cpuJobMetrics, cpuJobKindCount)
+	for kind := cpuJobBitrot; kind < cpuJobKindCount; kind++ {
+		stats := &p.stats[kind]
+		m[kind] = cpuJobMetrics{
+			Jobs:   atomic.LoadUint64(&stats.jobs),
+			Wait:   time.Duration(atomic.LoadUint64(&stats.waitNanos)),
+			Busy:   time.Duration(atomic.LoadUint64(&stats.busyNanos)),
+			Queued: atomic.LoadInt64(&stats.queued),
+		}
+	}
+	return m, atomic.LoadInt64(&p.busy)
+}
+
+// cpuSource is the input of a cpuReader, it is read ahead
+// outside the pool by chunks. The chunk covers the input consumed
+// by a read of the cpuReader, so that the jobs don't wait on the
+// network or the drives.
+type cpuSource struct {
+	r     io.Reader
+	chunk int
+	mem   []byte
+	buf   []byte
+	err   error
+	// inJob is set while a job of the cpuReader reads.
+	inJob bool
+}
+
+func newCPUSource(r io.Reader, chunk int) *cpuSource {
+	return &cpuSource{r: r, chunk: chunk}
+}
+
+// fill reads ahead until a chunk is buffered.
+func (s *cpuSource) fill() {
+	if s.err != nil || len(s.buf) >= s.chunk {
+		return
+	}
+	if s.mem == nil {
+		s.mem = make([]byte, s.chunk)
+	}
+	n := copy(s.mem, s.buf)
+	m, err := io.ReadFull(s.r, s.mem[n:])
+	s.buf = s.mem[:n+m]
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	s.err = err
+}
+
+// Read returns the data read ahead, or reads in place once it is
+// consumed. A job reading past the chunk hands its worker over while
+// it reads, the input may be written by jobs of the pool such as the
+// bitrot verification and erasure reconstruction of the object.
+func (s *cpuSource) Read(p []byte) (n int, err error) {
+	if len(s.buf) > 0 {
+		n = copy(p, s.buf)
+		s.buf = s.buf[n:]
+		return n, nil
+	}
+	if s.err != nil {
+		return 0, s.err
+	}
+	if !s.inJob {
+		return s.r.Read(p)
+	}
+	globalCPUPool.handoff(func() {
+		n, err = s.r.Read(p)
+	})
+	return n, err
+}
+
+// cpuReader reads from a decrypting or decompressing reader on the
+// CPU pool, its input is read ahead so that the workers don't wait
+// on the network or the drives.
+type cpuReader struct {
+	ctx  context.Context
+	kind cpuJobKind
+	src  *cpuSource
+	r    io.Reader
+	// max bounds the reads so that a read consumes no more than
+	// a chunk of the input, zero when the reader does on its own.
+	max int
+}
+
+func newCPUReader(ctx context.Context, kind cpuJobKind, src *cpuSource, r io.Reader, max int) *cpuReader {
+	return &cpuReader{ctx: ctx, kind: kind, src: src, r: r, max: max}
+}
+
+func (r *cpuReader) Read(p []byte) (n int, err error) {
+	if r.max > 0 && len(p) > r.max {
+		p = p[:r.max]
+	}
+	r.src.fill()
+	r.src.inJob = true
+	defer func() { r.src.inJob = false }()
+	if rerr := globalCPUPool.run(r.ctx, r.kind, func() {
+		n, err = r.r.Read(p)
+	}); rerr != nil {
+		return 0, rerr
+	}
+	return n, err
+}
@@ -0,0 +1,249 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+)
+
+func TestCPUPoolRun(t *testing.T) {
+	p := newCPUPool(2)
+
+	// No more jobs than workers run at once.
+	var running, most int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.run(context.Background(), cpuJobBitrot, func() {
+				n := atomic.AddInt64(&running, 1)
+				for {
+					m := atomic.LoadInt64(&most)
+					if n <= m || atomic.CompareAndSwapInt64(&most, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt64(&running, -1)
+			})
+		}()
+	}
+	wg.Wait()
+	if most > 2 {
+		t.Fatalf("expected at most 2 jobs at once, got %d", most)
+	}
+
+	metrics, busy := p.metrics()
+	if m := metrics[cpuJobBitrot]; m.Jobs != 8 || m.Queued != 0 || m.Busy == 0 {
+		t.Fatalf("unexpected metrics %+v", m)
+	}
+	if m := metrics[cpuJobDecrypt]; m.Jobs != 0 {
+		t.Fatalf("unexpected metrics %+v", m)
+	}
+	if busy != 0 {
+		t.Fatalf("expected no busy workers, got %d", busy)
+	}
+}
+
+func TestCPUPoolPanic(t *testing.T) {
+	p := newCPUPool(1)
+	defer func() {
+		if r := recover(); r != "job" {
+			t.Fatalf("expected the panic of the job, got %v", r)
+		}
+		// The worker survives the panic.
+		done := false
+		p.run(context.Background(), cpuJobDecompress, func() { done = true })
+		if !done {
+			t.Fatal("expected the job to run")
+		}
+	}()
+	p.run(context.Background(), cpuJobDecompress, func() { panic("job") })
+}
+
+func TestCPUReader(t *testing.T) {
+	data := bytes.Repeat([]byte("minio object data "), 200000)
+	var compressed bytes.Buffer
+	w := s2.NewWriter(&compressed)
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, chunk := range []int{1, 100, compMaxFrameSize} {
+		src := newCPUSource(bytes.NewReader(compressed.Bytes()), chunk)
+		r := newCPUReader(context.Background(), cpuJobDecompress, src, s2.NewReader(src), 0)
+		got, err := ioutil.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("chunk %d: decompressed data differs", chunk)
+		}
+	}
+
+	// Reads are bounded to the chunk of the input.
+	src := newCPUSource(bytes.NewReader(data), 1000)
+	r := newCPUReader(context.Background(), cpuJobDecrypt, src, src, 100)
+	n, err := r.Read(make([]byte, 1000))
+	if err != nil || n != 100 {
+		t.Fatalf("expected 100 bytes, got %d: %v", n, err)
+	}
+}
+
+func TestCPUPoolRunCanceled(t *testing.T) {
+	p := newCPUPool(1)
+	release := make(chan struct{})
+	go p.run(context.Background(), cpuJobBitrot, func() { <-release })
+	defer close(release)
+
+	// Wait for the worker to be busy.
+	for i := 0; i < 100; i++ {
+		if _, busy := p.metrics(); busy == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := p.run(ctx, cpuJobDecrypt, func() {}); err != context.DeadlineExceeded {
+		t.Fatalf("expected the wait for a worker to stop, got %v", err)
+	}
+	if metrics, _ := p.metrics(); metrics[cpuJobDecrypt].Queued != 0 {
+		t.Fatalf("expected no queued jobs, got %d", metrics[cpuJobDecrypt].Queued)
+	}
+}
+
+// cpuPoolWriter writes its input through a job of the pool, as the
+// bitrot verification and erasure reconstruction write the input of
+// the decryption and decompression.
+type cpuPoolWriter struct {
+	r io.Reader
+}
+
+func (w cpuPoolWriter) Read(p []byte) (n int, err error) {
+	globalCPUPool.run(context.Background(), cpuJobBitrot, func() {
+		n, err = w.r.Read(p)
+	})
+	return n, err
+}
+
+func TestCPUReaderPastChunk(t *testing.T) {
+	defaultPool := globalCPUPool
+	defer func() { globalCPUPool = defaultPool }()
+	globalCPUPool = newCPUPool(1)
+
+	data := bytes.Repeat([]byte("a"), 1000)
+	src := newCPUSource(cpuPoolWriter{bytes.NewReader(data)}, 10)
+	// Reads more than the chunk read ahead, the job waits on the
+	// jobs writing its input.
+	r := newCPUReader(context.Background(), cpuJobDecrypt, src, readFullReader{src}, 0)
+
+	done := make(chan error, 1)
+	go func() {
+		got, err := ioutil.ReadAll(r)
+		if err == nil && !bytes.Equal(got, data) {
+			t.Error("read data differs")
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the reader not to wait on the pool")
+	}
+}
+
+// Test that the readers reading past their chunk don't deadlock once
+// they hold every worker, while their input is written by jobs too.
+func TestCPUReaderPastChunkSaturated(t *testing.T) {
+	defaultPool := globalCPUPool
+	defer func() { globalCPUPool = defaultPool }()
+	const workers = 2
+	globalCPUPool = newCPUPool(workers)
+
+	data := bytes.Repeat([]byte("a"), 1000)
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*workers)
+	for i := 0; i < 4*workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			src := newCPUSource(cpuPoolWriter{bytes.NewReader(data)}, 10)
+			r := newCPUReader(context.Background(), cpuJobDecrypt, src, readFullReader{src}, 0)
+			got, err := ioutil.ReadAll(r)
+			if err == nil && !bytes.Equal(got, data) {
+				err = errors.New("read data differs")
+			}
+			errs <- err
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the readers not to wait on the pool")
+	}
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, busy := globalCPUPool.metrics(); busy != 0 {
+		t.Fatalf("expected no busy workers, got %d", busy)
+	}
+}
+
+// readFullReader reads up to 100 bytes of its input per read, at
+// once.
+type readFullReader struct {
+	r io.Reader
+}
+
+func (r readFullReader) Read(p []byte) (int, error) {
+	if len(p) > 100 {
+		p = p[:100]
+	}
+	n, err := io.ReadFull(r.r, p)
+	if err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return n, err
+}
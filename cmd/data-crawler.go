@@ -42,6 +42,7 @@ const (
 	dataCrawlSleepDefMult    = 10.0             // Default multiplier for waits between operations.
 	dataCrawlStartDelay      = 5 * time.Minute  // Time to wait on startup and between cycles.
 	dataUsageUpdateDirCycles = 16               // Visit all folders every n cycles.
+	dataUsageFlattenLevels   = 2                // Levels of folders kept in the cache, deeper ones are flattened.
 
 )
 
@@ -56,6 +57,8 @@ func initDataCrawler(ctx context.Context, objAPI ObjectLayer) {
 // The function will block until the context is canceled.
 // There should only ever be one crawler running per cluster.
 func runDataCrawler(ctx context.Context, objAPI ObjectLayer) {
+	ctx = withIOClass(ctx, ioClassScanner)
+
 	// Load current bloom cycle
 	nextBloomCycle := intDataUpdateTracker.current() + 1
 	var buf bytes.Buffer
@@ -156,12 +159,21 @@ func crawlDataFolder(ctx context.Context, basePath string, cache dataUsageCache,
 		delayMult = dataCrawlSleepDefMult
 	}
 
+	// Client requests go first, the scanner waits for its turn
+	// before each operation.
+	waitForIO := func() {
+		if release, err := globalIOScheduler.acquire(ctx, ioClassScanner); err == nil {
+			release()
+		}
+		waitForLowActiveIO()
+	}
+
 	s := folderScanner{
 		root:                basePath,
 		getSize:             getSize,
 		oldCache:            cache,
 		newCache:            dataUsageCache{Info: cache.Info},
-		waitForLowActiveIO:  waitForLowActiveIO,
+		waitForLowActiveIO:  waitForIO,
 		newFolders:          nil,
 		existingFolders:     nil,
 		dataUsageCrawlMult:  delayMult,
@@ -181,7 +193,7 @@ func crawlDataFolder(ctx context.Context, basePath string, cache dataUsageCache,
 	}
 
 	done := ctx.Done()
-	var flattenLevels = 2
+	var flattenLevels = dataUsageFlattenLevels
 
 	if s.dataUsageCrawlDebug {
 		logger.Info(logPrefix+"Cycle: %v, Entries: %v"+logSuffix, cache.Info.NextCycle, len(cache.Cache))
@@ -519,14 +531,22 @@ type actionMeta struct {
 // The metadata will be compared to consensus on the object layer before any changes are applied.
 // If no metadata is supplied, -1 is returned if no action is taken.
 func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta actionMeta) (size int64) {
+	ctx = withIOClass(ctx, ioClassLifecycle)
 	size, err := meta.oi.GetActualSize()
 	if i.debug {
 		logger.LogIf(ctx, err)
 	}
+
+	// Queue again objects which are still pending or failed to replicate.
+	if globalReplicationSys != nil {
+		globalReplicationSys.Requeue(i.bucket, meta.oi)
+	}
+
 	if i.lifeCycle == nil {
 		return size
 	}
 
+	oi := meta.oi
 	versionID := meta.oi.VersionID
 	action := i.lifeCycle.ComputeAction(
 		lifecycle.ObjectOpts{
@@ -537,12 +557,14 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 			DeleteMarker: meta.oi.DeleteMarker,
 			IsLatest:     meta.oi.IsLatest,
 			NumVersions:  meta.numVersions,
+
+			TransitionStatus: meta.oi.TransitionStatus,
 		})
 	if i.debug {
 		logger.Info(color.Green("applyActions:")+" lifecycle: %q, Initial scan: %v", i.objectPath(), action)
 	}
 	switch action {
-	case lifecycle.DeleteAction, lifecycle.DeleteVersionAction:
+	case lifecycle.DeleteAction, lifecycle.DeleteVersionAction, lifecycle.TransitionAction:
 	default:
 		// No action.
 		return size
@@ -583,23 +605,49 @@ func (i *crawlItem) applyActions(ctx context.Context, o ObjectLayer, meta action
 				DeleteMarker: obj.DeleteMarker,
 				IsLatest:     obj.IsLatest,
 				NumVersions:  meta.numVersions,
+
+				TransitionStatus: obj.TransitionStatus,
 			})
 		if i.debug {
 			logger.Info(color.Green("applyActions:")+" lifecycle: Secondary scan: %v", action)
 		}
 		versionID = obj.VersionID
+		oi = obj
 		switch action {
-		case lifecycle.DeleteAction, lifecycle.DeleteVersionAction:
+		case lifecycle.DeleteAction, lifecycle.DeleteVersionAction, lifecycle.TransitionAction:
 		default:
 			// No action.
 			return size
 		}
 	}
 
+	if action == lifecycle.TransitionAction {
+		tierName := i.lifeCycle.TransitionStorageClass(lifecycle.ObjectOpts{
+			Name:     i.objectPath(),
+			UserTags: oi.UserTags,
+			ModTime:  oi.ModTime,
+			IsLatest: oi.IsLatest,
+		})
+		if tierName == "" {
+			return size
+		}
+		if err := transitionObject(ctx, o, i.bucket, i.objectPath(), versionID, tierName); err != nil {
+			logger.LogIf(ctx, err)
+		}
+		return size
+	}
+
 	opts := ObjectOptions{}
 	switch action {
 	case lifecycle.DeleteVersionAction:
 		opts.VersionID = versionID
+		// The versions referenced by a snapshot are kept.
+		if err := checkBucketSnapshotVersion(ctx, o, i.bucket, i.objectPath(), versionID); err != nil {
+			if err != errObjectVersionInSnapshot {
+				logger.LogIf(ctx, err)
+			}
+			return size
+		}
 	case lifecycle.DeleteAction:
 		opts.Versioned = globalBucketVersioningSys.Enabled(i.bucket)
 	}
@@ -345,6 +345,34 @@ func (d *dataUsageCache) sizeRecursive(path string) *dataUsageEntry {
 	return &flat
 }
 
+// prefixSize returns the usage of all the entries of the bucket under
+// the prefix as a flattened entry. A prefix that does not end at a
+// folder boundary matches the entries of its parent folder starting
+// with it.
+func (d *dataUsageCache) prefixSize(bucket, prefix string) dataUsageEntry {
+	full := hashPath(path.Join(bucket, prefix)).Key()
+	if prefix == "" || strings.HasSuffix(prefix, SlashSeparator) {
+		if e := d.sizeRecursive(full); e != nil {
+			return *e
+		}
+		return dataUsageEntry{}
+	}
+	var total dataUsageEntry
+	parent := d.find(path.Dir(full))
+	if parent == nil {
+		return total
+	}
+	for id := range parent.Children {
+		if !strings.HasPrefix(id, full) {
+			continue
+		}
+		if e := d.sizeRecursive(id); e != nil {
+			total.merge(*e)
+		}
+	}
+	return total
+}
+
 // root returns the root of the cache.
 func (d *dataUsageCache) root() *dataUsageEntry {
 	return d.find(d.Info.Name)
@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/minio/minio/pkg/hash"
+)
+
+const (
+	dataUsageHistoryObjName = ".usage-history.json"
+
+	// Minimum interval between two samples of the data usage history.
+	dataUsageHistoryInterval = 6 * time.Hour
+
+	// Samples kept in the data usage history, 30 days.
+	dataUsageHistoryMaxSamples = 30 * 4
+)
+
+// dataUsageSample - usage of the buckets at the time of a
+// data usage update.
+type dataUsageSample struct {
+	Time             time.Time                        `json:"time"`
+	ObjectsCount     uint64                           `json:"objectsCount"`
+	ObjectsTotalSize uint64                           `json:"objectsTotalSize"`
+	Buckets          map[string]dataUsageBucketSample `json:"buckets"`
+}
+
+// dataUsageBucketSample - usage of a bucket in a data usage sample.
+type dataUsageBucketSample struct {
+	Size         uint64 `json:"size"`
+	ObjectsCount uint64 `json:"objectsCount"`
+}
+
+// loadDataUsageHistory - returns the samples of the data usage
+// history, oldest first.
+func loadDataUsageHistory(ctx context.Context, objAPI ObjectLayer) ([]dataUsageSample, error) {
+	var historyJSON bytes.Buffer
+	err := objAPI.GetObject(ctx, dataUsageBucket, dataUsageHistoryObjName, 0, -1, &historyJSON, "", ObjectOptions{})
+	if err != nil {
+		if isErrObjectNotFound(err) || isErrBucketNotFound(err) {
+			return nil, nil
+		}
+		return nil, toObjectErr(err, dataUsageBucket, dataUsageHistoryObjName)
+	}
+
+	var history []dataUsageSample
+	if err = json.Unmarshal(historyJSON.Bytes(), &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// updateDataUsageHistory - adds a sample of the data usage to the
+// history, unless the last sample is more recent than the interval
+// between two samples. Only the most recent samples are kept.
+func updateDataUsageHistory(ctx context.Context, objAPI ObjectLayer, dataUsageInfo DataUsageInfo) error {
+	history, err := loadDataUsageHistory(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if n := len(history); n > 0 && dataUsageInfo.LastUpdate.Sub(history[n-1].Time) < dataUsageHistoryInterval {
+		return nil
+	}
+
+	sample := dataUsageSample{
+		Time:             dataUsageInfo.LastUpdate,
+		ObjectsCount:     dataUsageInfo.ObjectsTotalCount,
+		ObjectsTotalSize: dataUsageInfo.ObjectsTotalSize,
+		Buckets:          make(map[string]dataUsageBucketSample, len(dataUsageInfo.BucketsUsage)),
+	}
+	for bucket, usage := range dataUsageInfo.BucketsUsage {
+		sample.Buckets[bucket] = dataUsageBucketSample{
+			Size:         usage.Size,
+			ObjectsCount: usage.ObjectsCount,
+		}
+	}
+	history = append(history, sample)
+	if len(history) > dataUsageHistoryMaxSamples {
+		history = history[len(history)-dataUsageHistoryMaxSamples:]
+	}
+
+	historyJSON, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	size := int64(len(historyJSON))
+	r, err := hash.NewReader(bytes.NewReader(historyJSON), size, "", "", size, false)
+	if err != nil {
+		return err
+	}
+	_, err = objAPI.PutObject(ctx, dataUsageBucket, dataUsageHistoryObjName, NewPutObjReader(r, nil, nil), ObjectOptions{})
+	return err
+}
@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// errPrefixUsageTooDeep - the usage cache doesn't keep the folders of
+// the prefix, they are flattened into their parent by the scanner.
+var errPrefixUsageTooDeep = AdminError{
+	Code: "XMinioAdminPrefixUsageTooDeep",
+	Message: fmt.Sprintf("The data usage scanner only keeps the usage of the first %d levels of folders, use accurate=true for deeper prefixes",
+		dataUsageFlattenLevels),
+	StatusCode: http.StatusBadRequest,
+}
+
+// prefixUsageFromCache returns the usage under the prefix found by the
+// last data usage scan. Every erasure set keeps its own usage cache of
+// the bucket, the caches of all the sets are added up.
+func prefixUsageFromCache(ctx context.Context, objAPI ObjectLayer, bucket, prefix string) (madmin.PrefixUsage, error) {
+	usage := madmin.PrefixUsage{
+		Bucket: bucket,
+		Prefix: prefix,
+	}
+
+	// A partial prefix matches the folders within its parent folder.
+	depth := strings.Count(prefix, SlashSeparator)
+	if prefix != "" && !strings.HasSuffix(prefix, SlashSeparator) {
+		depth++
+	}
+	if depth > dataUsageFlattenLevels {
+		return usage, errPrefixUsageTooDeep
+	}
+
+	var stores []ObjectLayer
+	switch z := objAPI.(type) {
+	case *erasureZones:
+		for _, zone := range z.zones {
+			for _, set := range zone.sets {
+				stores = append(stores, set)
+			}
+		}
+	case *FSObjects:
+		stores = append(stores, z)
+	default:
+		return usage, NotImplemented{}
+	}
+
+	cacheName := pathJoin(bucket, dataUsageCacheName)
+	var total dataUsageEntry
+	var lastUpdate time.Time
+	for i, store := range stores {
+		var cache dataUsageCache
+		if err := cache.load(ctx, store, cacheName); err != nil {
+			return usage, err
+		}
+		total.merge(cache.prefixSize(bucket, prefix))
+		// Report the oldest scan, a zero time means
+		// that a set has not scanned the bucket yet.
+		if i == 0 || cache.Info.LastUpdate.Before(lastUpdate) {
+			lastUpdate = cache.Info.LastUpdate
+		}
+	}
+
+	usage.Objects = total.Objects
+	usage.Size = uint64(total.Size)
+	usage.LastUpdate = lastUpdate
+	return usage, nil
+}
+
+// prefixUsageFromListing returns the usage under the prefix by listing
+// all the object versions under it, the delete markers have no content
+// and are left out.
+func prefixUsageFromListing(ctx context.Context, objAPI ObjectLayer, bucket, prefix string) (madmin.PrefixUsage, error) {
+	usage := madmin.PrefixUsage{
+		Bucket:   bucket,
+		Prefix:   prefix,
+		Accurate: true,
+	}
+
+	versioned := true
+	var marker, versionMarker, lastObject string
+	for {
+		var loi ListObjectVersionsInfo
+		var err error
+		if versioned {
+			loi, err = objAPI.ListObjectVersions(ctx, bucket, prefix, marker, versionMarker, "", maxObjectList)
+			if _, ok := err.(NotImplemented); ok && marker == "" {
+				// Backends without versions only list the objects.
+				versioned = false
+				continue
+			}
+		} else {
+			var oi ListObjectsInfo
+			oi, err = objAPI.ListObjects(ctx, bucket, prefix, marker, "", maxObjectList)
+			loi = ListObjectVersionsInfo{
+				IsTruncated: oi.IsTruncated,
+				NextMarker:  oi.NextMarker,
+				Objects:     oi.Objects,
+			}
+		}
+		if err != nil {
+			return usage, err
+		}
+		for _, obj := range loi.Objects {
+			if obj.DeleteMarker {
+				continue
+			}
+			// Versions of an object are listed together,
+			// possibly across two pages.
+			if obj.Name != lastObject {
+				usage.Objects++
+				lastObject = obj.Name
+			}
+			usage.Size += uint64(obj.Size)
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker, versionMarker = loi.NextMarker, loi.NextVersionIDMarker
+	}
+
+	usage.LastUpdate = UTCNow()
+	return usage, nil
+}
@@ -0,0 +1,183 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func newPrefixUsageTestCache(bucket string) dataUsageCache {
+	cache := dataUsageCache{Info: dataUsageCacheInfo{Name: bucket, LastUpdate: UTCNow()}}
+	cache.replace(bucket, "", dataUsageEntry{Size: 1, Objects: 1})
+	cache.replace(bucket+"/logs", bucket, dataUsageEntry{Size: 10, Objects: 1})
+	cache.replace(bucket+"/logs/2020", bucket+"/logs", dataUsageEntry{Size: 100, Objects: 2})
+	cache.replace(bucket+"/logs/2021", bucket+"/logs", dataUsageEntry{Size: 1000, Objects: 3})
+	cache.replace(bucket+"/logs/2021/01", bucket+"/logs/2021", dataUsageEntry{Size: 10000, Objects: 4})
+	cache.replace(bucket+"/images", bucket, dataUsageEntry{Size: 100000, Objects: 5})
+	return cache
+}
+
+func TestDataUsageCachePrefixSize(t *testing.T) {
+	cache := newPrefixUsageTestCache("bucket")
+
+	testCases := []struct {
+		prefix  string
+		size    int64
+		objects uint64
+	}{
+		{"", 111111, 16},
+		{"logs/", 11110, 10},
+		{"logs/2021/", 11000, 7},
+		{"logs/2021/01/", 10000, 4},
+		// Partial prefixes match the entries of the parent folder.
+		{"logs", 11110, 10},
+		{"logs/20", 11100, 9},
+		{"logs/2021", 11000, 7},
+		{"i", 100000, 5},
+		{"l", 11110, 10},
+		{"logs/2022", 0, 0},
+		{"missing/", 0, 0},
+		{"missing/a", 0, 0},
+	}
+
+	for i, testCase := range testCases {
+		e := cache.prefixSize("bucket", testCase.prefix)
+		if e.Size != testCase.size || e.Objects != testCase.objects {
+			t.Errorf("Test %d: %q: expected %d objects of %d bytes, got %d objects of %d bytes",
+				i+1, testCase.prefix, testCase.objects, testCase.size, e.Objects, e.Size)
+		}
+	}
+}
+
+func TestPrefixUsage(t *testing.T) {
+	ExecObjectLayerTest(t, testPrefixUsage)
+}
+
+func testPrefixUsage(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	const bucket = "prefix-usage"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	objects := map[string]int64{
+		"logs/2020/a":  10,
+		"logs/2020/b":  20,
+		"logs/2021/a":  30,
+		"logs2/a":      40,
+		"images/a.png": 50,
+	}
+	for name, size := range objects {
+		data := bytes.Repeat([]byte("a"), int(size))
+		_, err := obj.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader(data), size, "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	listingCases := []struct {
+		prefix  string
+		size    uint64
+		objects uint64
+	}{
+		{"", 150, 5},
+		{"logs/", 60, 3},
+		{"logs", 100, 4},
+		{"logs/2020/", 30, 2},
+		{"missing/", 0, 0},
+	}
+	for i, testCase := range listingCases {
+		usage, err := prefixUsageFromListing(ctx, obj, bucket, testCase.prefix)
+		if err != nil {
+			t.Fatalf("%s: Test %d: %v", instanceType, i+1, err)
+		}
+		if !usage.Accurate || usage.Size != testCase.size || usage.Objects != testCase.objects {
+			t.Errorf("%s: Test %d: %q: expected %d objects of %d bytes, got %+v",
+				instanceType, i+1, testCase.prefix, testCase.objects, testCase.size, usage)
+		}
+	}
+
+	if _, ok := obj.(*erasureZones); ok {
+		// The delete markers are not counted, leave only a delete
+		// marker of logs/2020/c.
+		data := []byte("deleted")
+		objInfo, err := obj.PutObject(ctx, bucket, "logs/2020/c", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if _, err = obj.DeleteObject(ctx, bucket, "logs/2020/c", ObjectOptions{Versioned: true}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if _, err = obj.DeleteObject(ctx, bucket, "logs/2020/c", ObjectOptions{VersionID: objInfo.VersionID}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		loi, err := obj.ListObjectVersions(ctx, bucket, "logs/2020/", "", "", "", maxObjectList)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if len(loi.Objects) != 3 {
+			t.Fatalf("%s: expected a delete marker, got %v", instanceType, loi.Objects)
+		}
+		usage, err := prefixUsageFromListing(ctx, obj, bucket, "logs/2020/")
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if usage.Size != 30 || usage.Objects != 2 {
+			t.Errorf("%s: expected 2 objects of 30 bytes, got %+v", instanceType, usage)
+		}
+	}
+
+	// Every erasure set keeps a usage cache of the bucket.
+	var stores []ObjectLayer
+	switch z := obj.(type) {
+	case *erasureZones:
+		for _, zone := range z.zones {
+			for _, set := range zone.sets {
+				stores = append(stores, set)
+			}
+		}
+	case *FSObjects:
+		stores = append(stores, z)
+	}
+	cache := newPrefixUsageTestCache(bucket)
+	for _, store := range stores {
+		if err := cache.save(ctx, store, pathJoin(bucket, dataUsageCacheName)); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	usage, err := prefixUsageFromCache(ctx, obj, bucket, "logs/2021/")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	n := uint64(len(stores))
+	if usage.Accurate || usage.Size != 11000*n || usage.Objects != 7*n {
+		t.Errorf("%s: expected %d objects of %d bytes, got %+v", instanceType, 7*n, 11000*n, usage)
+	}
+	if usage.LastUpdate.IsZero() {
+		t.Errorf("%s: expected the last update of the scan", instanceType)
+	}
+
+	// The scanner flattens the deeper folders.
+	for _, prefix := range []string{"logs/2021/01/", "logs/2021/0"} {
+		if _, err = prefixUsageFromCache(ctx, obj, bucket, prefix); err != errPrefixUsageTooDeep {
+			t.Errorf("%s: %q: expected %v, got %v", instanceType, prefix, errPrefixUsageTooDeep, err)
+		}
+	}
+}
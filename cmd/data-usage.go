@@ -58,6 +58,9 @@ func storeDataUsageInBackend(ctx context.Context, objAPI ObjectLayer, gui <-chan
 		if !isErrBucketNotFound(err) {
 			logger.LogIf(ctx, err)
 		}
+		if err == nil {
+			logger.LogIf(ctx, updateDataUsageHistory(ctx, objAPI, dataUsageInfo))
+		}
 	}
 }
 
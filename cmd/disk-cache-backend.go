@@ -135,7 +135,8 @@ type diskCache struct {
 	stats         CacheDiskStats // disk cache stats for prometheus
 	quotaPct      int            // max usage in %
 	pool          sync.Pool
-	after         int // minimum accesses before an object is cached.
+	after         int           // minimum accesses before an object is cached.
+	expiry        time.Duration // entries not accessed within expiry are purged.
 	lowWatermark  int
 	highWatermark int
 	enableRange   bool
@@ -152,6 +153,11 @@ func newDiskCache(ctx context.Context, dir string, config cache.Config) (*diskCa
 		quotaPct = config.Quota
 	}
 
+	expiry := cacheExpiryDays
+	if config.Expiry > 0 {
+		expiry = time.Duration(config.Expiry) * time.Hour * 24
+	}
+
 	if err := os.MkdirAll(dir, 0777); err != nil {
 		return nil, fmt.Errorf("Unable to initialize '%s' dir, %w", dir, err)
 	}
@@ -161,6 +167,7 @@ func newDiskCache(ctx context.Context, dir string, config cache.Config) (*diskCa
 		stats:         CacheDiskStats{Dir: dir},
 		quotaPct:      quotaPct,
 		after:         config.After,
+		expiry:        expiry,
 		lowWatermark:  config.WatermarkLow,
 		highWatermark: config.WatermarkHigh,
 		enableRange:   config.Range,
@@ -258,8 +265,10 @@ func (c *diskCache) purgeWait(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
+			return
 		case <-c.triggerGC: // wait here until someone triggers.
 			c.purge(ctx)
+			c.purgeExpired(ctx)
 		}
 	}
 }
@@ -280,7 +289,7 @@ func (c *diskCache) purge(ctx context.Context) {
 
 	// expiry for cleaning up old cache.json files that
 	// need to be cleaned up.
-	expiry := UTCNow().Add(-cacheExpiryDays)
+	expiry := UTCNow().Add(-c.expiry)
 	// defaulting max hits count to 100
 	// ignore error we know what value we are passing.
 	scorer, _ := newFileScorer(toFree, time.Now().Unix(), 100)
@@ -378,6 +387,54 @@ func (c *diskCache) purge(ctx context.Context) {
 	scorer.reset()
 }
 
+// Purge cache entries that were not accessed within the configured
+// expiry, irrespective of the current cache disk usage.
+func (c *diskCache) purgeExpired(ctx context.Context) {
+	if atomic.LoadInt32(&c.purgeRunning) == 1 {
+		return
+	}
+
+	atomic.StoreInt32(&c.purgeRunning, 1) // do not run concurrent purge()
+	defer atomic.StoreInt32(&c.purgeRunning, 0)
+
+	expiry := UTCNow().Add(-c.expiry)
+	filterFn := func(name string, typ os.FileMode) error {
+		if name == minioMetaBucket {
+			// Proceed to next file.
+			return nil
+		}
+
+		cacheDir := pathJoin(c.dir, name)
+		if c.lastAccessTime(cacheDir).Before(expiry) {
+			if err := c.delete(ctx, cacheDir); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+
+		// Proceed to next file.
+		return nil
+	}
+
+	if err := readDirFilterFn(c.dir, filterFn); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// lastAccessTime returns the most recent time a cache entry was read
+// or had its metadata updated, returns zero time if the entry is not
+// readable.
+func (c *diskCache) lastAccessTime(cacheObjPath string) (t time.Time) {
+	if fi, err := os.Stat(pathJoin(cacheObjPath, cacheMetaJSONFile)); err == nil {
+		t = fi.ModTime()
+	}
+	if fi, err := os.Stat(pathJoin(cacheObjPath, cacheDataFile)); err == nil {
+		if at := atime.Get(fi); at.After(t) {
+			t = at
+		}
+	}
+	return t
+}
+
 // sets cache drive status
 func (c *diskCache) setOffline() {
 	atomic.StoreUint32(&c.online, 0)
@@ -899,7 +956,7 @@ func (c *diskCache) Get(ctx context.Context, bucket, object string, rs *HTTPRang
 		return gr, numHits, gerr
 	}
 
-	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, opts, nsUnlocker)
+	fn, off, length, nErr := NewGetObjectReader(ctx, rs, objInfo, opts, nsUnlocker)
 	if nErr != nil {
 		return nil, numHits, nErr
 	}
@@ -17,7 +17,14 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
 	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/cache"
 )
 
 // Tests ToObjectInfo function.
@@ -70,3 +77,47 @@ func TestCacheExclusion(t *testing.T) {
 		}
 	}
 }
+
+// Tests that cache entries not accessed within expiry are purged.
+func TestCachePurgeExpired(t *testing.T) {
+	dir, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dcache, err := newDiskCache(ctx, dir, cache.Config{Expiry: 1, MaxUse: 80, WatermarkLow: 70, WatermarkHigh: 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dcache.expiry != 24*time.Hour {
+		t.Fatalf("expected expiry of 24h, got %s", dcache.expiry)
+	}
+
+	data := []byte("hello")
+	for _, object := range []string{"fresh", "stale"} {
+		if err = dcache.Put(ctx, "bucket", object, bytes.NewReader(data), int64(len(data)), nil, ObjectOptions{}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	old := UTCNow().Add(-48 * time.Hour)
+	staleDir := getCacheSHADir(dir, "bucket", "stale")
+	for _, fname := range []string{cacheMetaJSONFile, cacheDataFile} {
+		if err = os.Chtimes(pathJoin(staleDir, fname), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dcache.purgeExpired(ctx)
+
+	if !dcache.Exists(ctx, "bucket", "fresh") {
+		t.Fatal("expected recently accessed entry to be retained")
+	}
+	if dcache.Exists(ctx, "bucket", "stale") {
+		t.Fatal("expected expired entry to be purged")
+	}
+}
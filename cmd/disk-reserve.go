@@ -0,0 +1,77 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// diskReserve is the space kept free on every disk, writes are
+// rejected with errDiskFull once the free space drops below it.
+type diskReserve struct {
+	// Absolute space to keep free.
+	bytes uint64
+	// Fraction of the total space to keep free.
+	fraction float64
+}
+
+// parseDiskReserve parses a reserve given either as a size
+// such as "10GiB" or as a percentage such as "5%".
+func parseDiskReserve(s string) (r diskReserve, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return r, nil
+	}
+	if strings.HasSuffix(s, "%") {
+		pct, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil || pct < 0 || pct >= 100 {
+			return r, fmt.Errorf("invalid disk reserve percentage `%s`", s)
+		}
+		r.fraction = pct / 100
+		return r, nil
+	}
+	r.bytes, err = humanize.ParseBytes(s)
+	return r, err
+}
+
+// reserved returns the space to keep free on a disk of given total size.
+func (r diskReserve) reserved(total uint64) uint64 {
+	reserved := uint64(float64(total) * r.fraction)
+	if r.bytes > reserved {
+		reserved = r.bytes
+	}
+	return reserved
+}
+
+// isFull returns if the free space of a disk is below the reserve.
+func (r diskReserve) isFull(total, free uint64) bool {
+	reserved := r.reserved(total)
+	return reserved > 0 && free <= reserved
+}
+
+// available returns the space which can still be written to a disk.
+func (r diskReserve) available(total, free uint64) uint64 {
+	reserved := r.reserved(total)
+	if free <= reserved {
+		return 0
+	}
+	return free - reserved
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestParseDiskReserve(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  diskReserve
+		shouldErr bool
+	}{
+		{"", diskReserve{}, false},
+		{"10GiB", diskReserve{bytes: 10 * humanize.GiByte}, false},
+		{"500MB", diskReserve{bytes: 500 * humanize.MByte}, false},
+		{"5%", diskReserve{fraction: 0.05}, false},
+		{"0.5%", diskReserve{fraction: 0.005}, false},
+		{"100%", diskReserve{}, true},
+		{"-1%", diskReserve{}, true},
+		{"ten", diskReserve{}, true},
+	}
+
+	for i, testCase := range testCases {
+		r, err := parseDiskReserve(testCase.value)
+		if testCase.shouldErr && err == nil {
+			t.Errorf("Test %d: expected error for %s", i+1, testCase.value)
+		}
+		if !testCase.shouldErr && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.shouldErr && r != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, r)
+		}
+	}
+}
+
+func TestDiskReserve(t *testing.T) {
+	total := uint64(100 * humanize.GiByte)
+	testCases := []struct {
+		reserve   diskReserve
+		free      uint64
+		full      bool
+		available uint64
+	}{
+		{diskReserve{}, 0, false, 0},
+		{diskReserve{}, 10 * humanize.GiByte, false, 10 * humanize.GiByte},
+		{diskReserve{bytes: 10 * humanize.GiByte}, 11 * humanize.GiByte, false, humanize.GiByte},
+		{diskReserve{bytes: 10 * humanize.GiByte}, 10 * humanize.GiByte, true, 0},
+		{diskReserve{fraction: 0.05}, 6 * humanize.GiByte, false, humanize.GiByte},
+		{diskReserve{fraction: 0.05}, 4 * humanize.GiByte, true, 0},
+		// Largest of both applies.
+		{diskReserve{bytes: humanize.GiByte, fraction: 0.05}, 4 * humanize.GiByte, true, 0},
+		{diskReserve{bytes: 8 * humanize.GiByte, fraction: 0.05}, 6 * humanize.GiByte, true, 0},
+	}
+
+	for i, testCase := range testCases {
+		if full := testCase.reserve.isFull(total, testCase.free); full != testCase.full {
+			t.Errorf("Test %d: expected full %v, got %v", i+1, testCase.full, full)
+		}
+		if available := testCase.reserve.available(total, testCase.free); available != testCase.available {
+			t.Errorf("Test %d: expected available %d, got %d", i+1, testCase.available, available)
+		}
+	}
+}
+
+func TestCheckDiskFreeReserve(t *testing.T) {
+	defer func(r diskReserve) { globalDiskReserve = r }(globalDiskReserve)
+
+	globalDiskReserve = diskReserve{}
+	if err := checkDiskFree(globalTestTmpDir, humanize.MiByte); err != nil {
+		t.Skip("not enough free space for the test:", err)
+	}
+
+	globalDiskReserve = diskReserve{fraction: 0.9999}
+	if err := checkDiskFree(globalTestTmpDir, humanize.MiByte); err != errDiskFull {
+		t.Fatalf("Expected errDiskFull, got %v", err)
+	}
+}
@@ -0,0 +1,475 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/smart"
+)
+
+const (
+	envDriveHealth = "MINIO_DRIVE_HEALTH"
+
+	// driveHealthCheckInterval is the interval between the checks of
+	// the health of the local drives.
+	driveHealthCheckInterval = 10 * time.Minute
+
+	// driveHealthIOErrorsLimit is the number of I/O errors of a drive
+	// between two checks predicting its failure.
+	driveHealthIOErrorsLimit = 100
+)
+
+// driveHealth - the health of a local drive, shared by the storage
+// of the object layer and the storage serving the other servers.
+type driveHealth struct {
+	ioErrors     uint64
+	bitrotErrors uint64
+	readOnly     int32
+
+	// Only used by the monitor.
+	lastIOErrors uint64
+	device       string
+	noSMART      bool
+
+	mu     sync.Mutex
+	reason string
+	smart  *smart.Info
+}
+
+// record counts the I/O and bitrot errors returned by the drive.
+func (h *driveHealth) record(err error) error {
+	switch err {
+	case errFaultyDisk:
+		atomic.AddUint64(&h.ioErrors, 1)
+	case errFileCorrupt:
+		atomic.AddUint64(&h.bitrotErrors, 1)
+	}
+	return err
+}
+
+// checkWritable returns errDiskReadOnly once the drive is read-only.
+func (h *driveHealth) checkWritable() error {
+	if atomic.LoadInt32(&h.readOnly) == 1 {
+		return errDiskReadOnly
+	}
+	return nil
+}
+
+// setReadOnly marks the drive read-only, returns false if it already is.
+func (h *driveHealth) setReadOnly(reason string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !atomic.CompareAndSwapInt32(&h.readOnly, 0, 1) {
+		return false
+	}
+	h.reason = reason
+	return true
+}
+
+// clearReadOnly clears the read-only mark of the drive, returns false
+// if it is not read-only.
+func (h *driveHealth) clearReadOnly() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !atomic.CompareAndSwapInt32(&h.readOnly, 1, 0) {
+		return false
+	}
+	h.reason = ""
+	return true
+}
+
+func (h *driveHealth) info() *madmin.DriveHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return &madmin.DriveHealth{
+		ReadOnly:     atomic.LoadInt32(&h.readOnly) == 1,
+		Reason:       h.reason,
+		IOErrors:     atomic.LoadUint64(&h.ioErrors),
+		BitrotErrors: atomic.LoadUint64(&h.bitrotErrors),
+		SMART:        h.smart,
+	}
+}
+
+// readSMART reads the SMART health of the drive holding path. Drives
+// whose health cannot be read, such as virtual drives or servers
+// without smartctl, are not read again.
+func (h *driveHealth) readSMART(ctx context.Context, path string) *smart.Info {
+	if h.noSMART {
+		return nil
+	}
+	if h.device == "" {
+		device, err := smart.Device(path)
+		if err != nil {
+			if err != smart.ErrNotSupported {
+				logger.LogIf(ctx, err)
+			}
+			h.noSMART = true
+			return nil
+		}
+		h.device = device
+	}
+	info, err := smart.Read(ctx, h.device)
+	if err != nil {
+		if err != smart.ErrNotSupported {
+			logger.LogIf(ctx, err)
+		}
+		h.noSMART = true
+		return nil
+	}
+
+	h.mu.Lock()
+	h.smart = &info
+	h.mu.Unlock()
+	return &info
+}
+
+// check returns why the drive is predicted to fail, from its SMART
+// health or its I/O errors since the last check, empty if it is not.
+func (h *driveHealth) check(info *smart.Info) string {
+	ioErrors := atomic.LoadUint64(&h.ioErrors)
+	errs := ioErrors - h.lastIOErrors
+	h.lastIOErrors = ioErrors
+
+	if info != nil {
+		if reason := info.Failing(); reason != "" {
+			return reason
+		}
+	}
+	if errs >= driveHealthIOErrorsLimit {
+		return fmt.Sprintf("%d I/O errors in %s", errs, driveHealthCheckInterval)
+	}
+	return ""
+}
+
+// driveHealthRegistry - the health of the local drives by path.
+type driveHealthRegistry struct {
+	mu     sync.Mutex
+	drives map[string]*driveHealth
+}
+
+var globalDriveHealth driveHealthRegistry
+
+func (r *driveHealthRegistry) get(path string) *driveHealth {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.drives == nil {
+		r.drives = make(map[string]*driveHealth)
+	}
+	h, ok := r.drives[path]
+	if !ok {
+		h = &driveHealth{}
+		r.drives[path] = h
+	}
+	return h
+}
+
+// lookup returns the health of the local drive at path, if any.
+func (r *driveHealthRegistry) lookup(path string) (*driveHealth, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.drives[path]
+	return h, ok
+}
+
+func (r *driveHealthRegistry) list() map[string]*driveHealth {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	drives := make(map[string]*driveHealth, len(r.drives))
+	for path, h := range r.drives {
+		drives[path] = h
+	}
+	return drives
+}
+
+// driveHealthStorage wraps a local disk to count its I/O and bitrot
+// errors, and to refuse the writes of new data once it is read-only.
+// Deletes are still allowed so that the drive does not keep stale
+// objects.
+type driveHealthStorage struct {
+	StorageAPI
+	health *driveHealth
+}
+
+func newDriveHealthStorage(disk StorageAPI) StorageAPI {
+	return &driveHealthStorage{StorageAPI: disk, health: globalDriveHealth.get(disk.String())}
+}
+
+func (d *driveHealthStorage) DiskInfo() (info DiskInfo, err error) {
+	info, err = d.StorageAPI.DiskInfo()
+	if err == nil {
+		info.Health = d.health.info()
+	}
+	return info, d.health.record(err)
+}
+
+func (d *driveHealthStorage) MakeVolBulk(volumes ...string) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.MakeVolBulk(volumes...))
+}
+
+func (d *driveHealthStorage) MakeVol(volume string) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.MakeVol(volume))
+}
+
+func (d *driveHealthStorage) AppendFile(volume string, path string, buf []byte) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.AppendFile(volume, path, buf))
+}
+
+func (d *driveHealthStorage) CreateFile(volume, path string, size int64, reader io.Reader) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.CreateFile(volume, path, size, reader))
+}
+
+func (d *driveHealthStorage) RenameFile(srcVolume, srcPath, dstVolume, dstPath string) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.RenameFile(srcVolume, srcPath, dstVolume, dstPath))
+}
+
+func (d *driveHealthStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath string) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath))
+}
+
+func (d *driveHealthStorage) WriteAll(volume string, path string, reader io.Reader) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.WriteAll(volume, path, reader))
+}
+
+func (d *driveHealthStorage) WriteMetadata(volume, path string, fi FileInfo) error {
+	if err := d.health.checkWritable(); err != nil {
+		return err
+	}
+	return d.health.record(d.StorageAPI.WriteMetadata(volume, path, fi))
+}
+
+func (d *driveHealthStorage) ReadFile(volume string, path string, offset int64, buf []byte, verifier *BitrotVerifier) (int64, error) {
+	n, err := d.StorageAPI.ReadFile(volume, path, offset, buf, verifier)
+	return n, d.health.record(err)
+}
+
+func (d *driveHealthStorage) ReadFileStream(volume, path string, offset, length int64) (io.ReadCloser, error) {
+	r, err := d.StorageAPI.ReadFileStream(volume, path, offset, length)
+	return r, d.health.record(err)
+}
+
+func (d *driveHealthStorage) ReadAll(volume string, path string) ([]byte, error) {
+	buf, err := d.StorageAPI.ReadAll(volume, path)
+	return buf, d.health.record(err)
+}
+
+func (d *driveHealthStorage) ReadVersion(volume, path, versionID string) (FileInfo, error) {
+	fi, err := d.StorageAPI.ReadVersion(volume, path, versionID)
+	return fi, d.health.record(err)
+}
+
+func (d *driveHealthStorage) CheckParts(volume string, path string, fi FileInfo) error {
+	return d.health.record(d.StorageAPI.CheckParts(volume, path, fi))
+}
+
+func (d *driveHealthStorage) VerifyFile(volume, path string, fi FileInfo) error {
+	return d.health.record(d.StorageAPI.VerifyFile(volume, path, fi))
+}
+
+func (d *driveHealthStorage) DeleteVersion(volume, path string, fi FileInfo) error {
+	return d.health.record(d.StorageAPI.DeleteVersion(volume, path, fi))
+}
+
+func (d *driveHealthStorage) DeleteFile(volume string, path string) error {
+	return d.health.record(d.StorageAPI.DeleteFile(volume, path))
+}
+
+func (d *driveHealthStorage) SecureErase(volume, path string, fi FileInfo) error {
+	return d.health.record(d.StorageAPI.SecureErase(volume, path, fi))
+}
+
+// monitorDriveHealth checks the health of the local drives periodically,
+// a drive predicted to fail is marked read-only.
+func monitorDriveHealth(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureZones)
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(driveHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for path, h := range globalDriveHealth.list() {
+				reason := h.check(h.readSMART(ctx, path))
+				if reason == "" || h.checkWritable() != nil {
+					continue
+				}
+				markDriveReadOnly(ctx, z, path, h, reason)
+			}
+		}
+	}
+}
+
+// driveHealthMaxUnwritable returns the number of drives of an erasure
+// set which may be read-only or offline while the set still reaches the
+// write quorum of the objects of all the storage classes.
+func driveHealthMaxUnwritable(drivesPerSet int) int {
+	parity := globalStorageClass.GetParityForSC(storageclass.STANDARD)
+	if parity == 0 {
+		parity = getDefaultParityBlocks(drivesPerSet)
+	}
+	if rrs := globalStorageClass.GetParityForSC(storageclass.RRS); rrs > 0 && rrs < parity {
+		parity = rrs
+	}
+	dataDrives := drivesPerSet - parity
+	writeQuorum := dataDrives
+	if dataDrives == parity {
+		writeQuorum++
+	}
+	return drivesPerSet - writeQuorum
+}
+
+// markDriveReadOnly marks the local drive at path read-only, unless
+// its erasure set would then lose its write quorum: the drive is left
+// writable, and checked again later, while as many drives of the set
+// are read-only or offline as it may lose. The drives of a set are
+// marked one at a time, under a lock of the set.
+func markDriveReadOnly(ctx context.Context, z *erasureZones, path string, h *driveHealth, reason string) {
+	for zoneIdx, zone := range z.zones {
+		for setIdx, set := range zone.sets {
+			disks := set.getDisks()
+			var found bool
+			for _, disk := range disks {
+				if disk != nil && disk.IsLocal() && disk.String() == path {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+
+			lk := z.NewNSLock(ctx, minioMetaBucket, pathJoin("drive-health", strconv.Itoa(zoneIdx), strconv.Itoa(setIdx)))
+			if err := lk.GetLock(globalOperationTimeout); err != nil {
+				logger.LogIf(ctx, err)
+				return
+			}
+			var unwritable int
+			for _, disk := range disks {
+				if disk == nil {
+					unwritable++
+					continue
+				}
+				info, err := disk.DiskInfo()
+				if err != nil || (info.Health != nil && info.Health.ReadOnly) {
+					unwritable++
+				}
+			}
+			if max := driveHealthMaxUnwritable(len(disks)); unwritable >= max {
+				lk.Unlock()
+				reqInfo := (&logger.ReqInfo{}).AppendTags("disk", path)
+				logger.LogAlwaysIf(logger.SetReqInfo(ctx, reqInfo),
+					fmt.Errorf("Drive %s is predicted to fail but is not marked read-only, %d drives of its erasure set are read-only or offline: %s",
+						path, unwritable, reason))
+				return
+			}
+			marked := h.setReadOnly(reason)
+			lk.Unlock()
+			if marked {
+				driveFailing(ctx, path, reason, setIdx, set, zone.drivesPerSet)
+			}
+			return
+		}
+	}
+}
+
+// driveFailing raises the alert of a drive marked read-only, and heals
+// its erasure set while the drive can still be read so that the other
+// drives of the set hold all their data before the drive fails.
+func driveFailing(ctx context.Context, path, reason string, setIndex int, set *erasureObjects, drivesPerSet int) {
+	reqInfo := (&logger.ReqInfo{}).AppendTags("disk", path)
+	logger.LogAlwaysIf(logger.SetReqInfo(ctx, reqInfo),
+		fmt.Errorf("Drive %s is predicted to fail and is marked read-only: %s", path, reason))
+
+	if globalNotificationSys != nil {
+		globalNotificationSys.SendServerEvent(newDriveEvent(event.DriveReadOnly, path, reason))
+	}
+
+	go func() {
+		logger.LogIf(ctx, healErasureSet(ctx, setIndex, set, drivesPerSet))
+	}()
+}
+
+// clearLocalDriveReadOnly clears the read-only mark of the local drive
+// at path, returns errDiskNotFound if the drive is not local.
+func clearLocalDriveReadOnly(ctx context.Context, path string) error {
+	h, ok := globalDriveHealth.lookup(path)
+	if !ok {
+		return errDiskNotFound
+	}
+	if h.clearReadOnly() {
+		logger.Info("Drive %s is no longer marked read-only", path)
+	}
+	return nil
+}
+
+// newDriveEvent returns the event of a drive of this server, the drive
+// and the reason of the event are its request parameters.
+func newDriveEvent(name event.Name, path, reason string) event.Event {
+	respElements := map[string]string{
+		"x-minio-origin-endpoint": globalMinioEndpoint,
+	}
+	if globalDeploymentID != "" {
+		respElements["x-minio-deployment-id"] = globalDeploymentID
+	}
+	return event.Event{
+		EventVersion: "2.0",
+		EventSource:  "minio:server",
+		EventTime:    UTCNow().Format(event.AMZTimeFormat),
+		EventName:    name,
+		RequestParameters: map[string]string{
+			"drive":  path,
+			"reason": reason,
+		},
+		ResponseElements: respElements,
+		Source:           event.Source{Host: GetLocalPeer(globalEndpoints)},
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/smart"
+)
+
+func TestDriveHealthCheck(t *testing.T) {
+	h := &driveHealth{}
+
+	if reason := h.check(&smart.Info{Passed: true}); reason != "" {
+		t.Fatalf("expected a healthy drive, got %q", reason)
+	}
+	if reason := h.check(&smart.Info{Passed: false}); reason == "" {
+		t.Fatal("expected the drive failing its SMART self-assessment to be failing")
+	}
+
+	for i := 0; i < driveHealthIOErrorsLimit-1; i++ {
+		h.record(errFaultyDisk)
+	}
+	h.record(errFileCorrupt)
+	h.record(errFileNotFound)
+	if reason := h.check(nil); reason != "" {
+		t.Fatalf("expected a healthy drive below the I/O errors limit, got %q", reason)
+	}
+	for i := 0; i < driveHealthIOErrorsLimit; i++ {
+		h.record(errFaultyDisk)
+	}
+	if reason := h.check(nil); reason == "" {
+		t.Fatal("expected the drive with too many I/O errors to be failing")
+	}
+	// The I/O errors are counted since the last check.
+	if reason := h.check(nil); reason != "" {
+		t.Fatalf("expected a healthy drive without new I/O errors, got %q", reason)
+	}
+
+	info := h.info()
+	if info.IOErrors != 2*driveHealthIOErrorsLimit-1 || info.BitrotErrors != 1 {
+		t.Fatalf("unexpected errors counted %+v", info)
+	}
+
+	if !h.setReadOnly("failing") {
+		t.Fatal("expected the drive to be marked read-only")
+	}
+	if h.setReadOnly("failing again") {
+		t.Fatal("expected the drive to be read-only already")
+	}
+	if info = h.info(); !info.ReadOnly || info.Reason != "failing" {
+		t.Fatalf("unexpected health %+v", info)
+	}
+
+	if !h.clearReadOnly() {
+		t.Fatal("expected the read-only mark to be cleared")
+	}
+	if h.clearReadOnly() {
+		t.Fatal("expected the drive to be writable already")
+	}
+	if info = h.info(); info.ReadOnly || info.Reason != "" || h.checkWritable() != nil {
+		t.Fatalf("unexpected health %+v", info)
+	}
+}
+
+func TestMarkDriveReadOnly(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	defer removeRoots(fsDirs)
+	z := obj.(*erasureZones)
+
+	// The reduced redundancy parity of 2 drives is the most
+	// a set of 16 drives may lose.
+	max := driveHealthMaxUnwritable(len(fsDirs))
+	if max != 2 {
+		t.Fatalf("expected at most 2 unwritable drives, got %d", max)
+	}
+
+	var drives []*driveHealth
+	for _, disk := range z.zones[0].sets[0].getDisks() {
+		drives = append(drives, globalDriveHealth.get(disk.String()))
+	}
+	defer func() {
+		for _, h := range drives {
+			h.clearReadOnly()
+		}
+	}()
+
+	for i := 0; i <= max; i++ {
+		markDriveReadOnly(ctx, z, z.zones[0].sets[0].getDisks()[i].String(), drives[i], "failing")
+	}
+	for i, h := range drives {
+		if readOnly := h.checkWritable() != nil; readOnly != (i < max) {
+			t.Fatalf("drive %d: expected read-only %v, got %v", i, i < max, readOnly)
+		}
+	}
+
+	// Clearing a mark lets another drive of the set be marked.
+	if err = clearLocalDriveReadOnly(ctx, z.zones[0].sets[0].getDisks()[0].String()); err != nil {
+		t.Fatal(err)
+	}
+	markDriveReadOnly(ctx, z, z.zones[0].sets[0].getDisks()[max].String(), drives[max], "failing")
+	if drives[max].checkWritable() == nil {
+		t.Fatal("expected the drive to be marked read-only")
+	}
+	if err = clearLocalDriveReadOnly(ctx, pathJoin(fsDirs[0], "unknown")); err != errDiskNotFound {
+		t.Fatalf("expected %v, got %v", errDiskNotFound, err)
+	}
+}
+
+func TestDriveHealthStorageReadOnly(t *testing.T) {
+	disk, path, err := newXLStorageTestSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	storage := newDriveHealthStorage(disk)
+	if err = storage.MakeVol("bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if err = storage.WriteAll("bucket", "object", bytes.NewReader([]byte("data"))); err != nil {
+		t.Fatal(err)
+	}
+
+	// The health is shared with the other storage of the same drive.
+	globalDriveHealth.get(disk.String()).setReadOnly("failing")
+
+	info, err := newDriveHealthStorage(disk).DiskInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Health == nil || !info.Health.ReadOnly {
+		t.Fatalf("expected the drive to be reported read-only, got %+v", info.Health)
+	}
+
+	if err = storage.MakeVol("other"); err != errDiskReadOnly {
+		t.Fatalf("expected %v, got %v", errDiskReadOnly, err)
+	}
+	if err = storage.WriteAll("bucket", "new-object", bytes.NewReader([]byte("data"))); err != errDiskReadOnly {
+		t.Fatalf("expected %v, got %v", errDiskReadOnly, err)
+	}
+	if err = storage.AppendFile("bucket", "object", []byte("data")); err != errDiskReadOnly {
+		t.Fatalf("expected %v, got %v", errDiskReadOnly, err)
+	}
+
+	// Reads and deletes are still allowed.
+	data, err := storage.ReadAll("bucket", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "data" {
+		t.Fatalf("expected %q, got %q", "data", data)
+	}
+	if err = storage.DeleteFile("bucket", "object"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClearDriveReadOnlyHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminTestBed.TearDown()
+
+	drive := globalEndpoints[0].Endpoints[0].String()
+	h := globalDriveHealth.get(globalEndpoints[0].Endpoints[0].Path)
+	h.setReadOnly("failing")
+
+	for i, testCase := range []struct {
+		drive          string
+		expectedStatus int
+	}{
+		{drive, http.StatusOK},
+		// Clearing a writable drive succeeds.
+		{drive, http.StatusOK},
+		{"http://unknown:9000/drive", http.StatusNotFound},
+	} {
+		queryVal := url.Values{}
+		queryVal.Set("drive", testCase.drive)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/drive-health/clear", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+	}
+	if h.checkWritable() != nil {
+		t.Fatal("expected the drive to be writable")
+	}
+}
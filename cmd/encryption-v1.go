@@ -601,7 +601,8 @@ func getDecryptedETag(headers http.Header, objInfo ObjectInfo, copySource bool)
 		return objInfo.ETag
 	}
 
-	if crypto.IsMultiPart(objInfo.UserDefined) {
+	// The ETag of multipart objects is not encrypted.
+	if crypto.IsMultiPart(objInfo.UserDefined) || isMultipartETag(objInfo.ETag) {
 		return objInfo.ETag
 	}
 	if crypto.SSECopy.IsRequested(headers) {
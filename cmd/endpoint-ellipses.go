@@ -343,6 +343,18 @@ func createServerEndpoints(serverAddr string, args ...string) (
 		}
 	}
 
+	if v := env.Get(EnvErasureFailureDomains, ""); v != "" {
+		globalFailureDomains, err = parseFailureDomains(v)
+		if err != nil {
+			return nil, -1, -1, config.ErrInvalidErasureFailureDomains(err)
+		}
+	}
+
+	globalErasureAutoParity, err = config.ParseBool(env.Get(EnvErasureAutoParity, config.EnableOff))
+	if err != nil {
+		return nil, -1, -1, config.ErrInvalidErasureAutoParity(err)
+	}
+
 	if !ellipses.HasEllipses(args...) {
 		setArgs, err := GetAllSets(uint64(setDriveCount), args...)
 		if err != nil {
@@ -358,6 +370,7 @@ func createServerEndpoints(serverAddr string, args ...string) (
 			Endpoints:    endpointList,
 		})
 		setupType = newSetupType
+		spreadZonesFailureDomains(endpointZones)
 		return endpointZones, len(setArgs[0]), setupType, nil
 	}
 
@@ -393,5 +406,6 @@ func createServerEndpoints(serverAddr string, args ...string) (
 		prevSetupType = setupType
 	}
 
+	spreadZonesFailureDomains(endpointZones)
 	return endpointZones, setDriveCount, setupType, nil
 }
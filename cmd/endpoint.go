@@ -253,6 +253,19 @@ func (l EndpointZones) Hosts() []string {
 	return foundSet.ToSlice()
 }
 
+// LocalHostnames - returns list of unique host names of the local endpoints
+func (l EndpointZones) LocalHostnames() []string {
+	foundSet := set.NewStringSet()
+	for _, ep := range l {
+		for _, endpoint := range ep.Endpoints {
+			if endpoint.IsLocal && endpoint.Type() == URLEndpointType {
+				foundSet.Add(endpoint.Hostname())
+			}
+		}
+	}
+	return foundSet.ToSlice()
+}
+
 // Endpoints - list of same type of endpoint.
 type Endpoints []Endpoint
 
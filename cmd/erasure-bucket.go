@@ -115,10 +115,14 @@ func (er erasureObjects) getBucketInfo(ctx context.Context, bucketName string) (
 
 // GetBucketInfo - returns BucketInfo for a bucket.
 func (er erasureObjects) GetBucketInfo(ctx context.Context, bucket string) (bi BucketInfo, e error) {
+	if bucketInfo, ok := er.bucketCache.get(bucket); ok {
+		return bucketInfo, nil
+	}
 	bucketInfo, err := er.getBucketInfo(ctx, bucket)
 	if err != nil {
 		return bi, toObjectErr(err, bucket)
 	}
+	er.bucketCache.set(bucketInfo)
 	return bucketInfo, nil
 }
 
@@ -196,6 +200,8 @@ func deleteDanglingBucket(ctx context.Context, storageDisks []StorageAPI, dErrs
 
 // DeleteBucket - deletes a bucket.
 func (er erasureObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	defer er.bucketCache.remove(bucket)
+
 	// Collect if all disks report volume not found.
 	storageDisks := er.getDisks()
 
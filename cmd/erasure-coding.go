@@ -85,8 +85,9 @@ func (e *Erasure) EncodeData(ctx context.Context, data []byte) ([][]byte, error)
 
 // DecodeDataBlocks decodes the given erasure-coded data.
 // It only decodes the data blocks but does not verify them.
-// It returns an error if the decoding failed.
-func (e *Erasure) DecodeDataBlocks(data [][]byte) error {
+// It returns an error if the decoding failed, or if ctx is done
+// before the decoding is started.
+func (e *Erasure) DecodeDataBlocks(ctx context.Context, data [][]byte) error {
 	var isZero = 0
 	for _, b := range data[:] {
 		if len(b) == 0 {
@@ -98,7 +99,13 @@ func (e *Erasure) DecodeDataBlocks(data [][]byte) error {
 		// If all are zero, payload is 0 bytes.
 		return nil
 	}
-	return e.encoder().ReconstructData(data)
+	var err error
+	if rerr := globalCPUPool.run(ctx, cpuJobReconstruct, func() {
+		err = e.encoder().ReconstructData(data)
+	}); rerr != nil {
+		return rerr
+	}
+	return err
 }
 
 // DecodeDataAndParityBlocks decodes the given erasure-coded data and verifies it.
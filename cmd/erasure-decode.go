@@ -20,14 +20,29 @@ import (
 	"context"
 	"errors"
 	"io"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/minio/minio/cmd/logger"
 )
 
 var errHealRequired = errors.New("heal required")
 
+const (
+	// Shard reads outstanding for longer than hedgeReadFactor times
+	// the typical shard read latency trigger a read of another shard.
+	hedgeReadFactor = 3
+
+	// Minimum time to wait for outstanding shard reads before
+	// issuing a hedged read.
+	hedgeReadMinDelay = 20 * time.Millisecond
+
+	// Weight of the latest sample in the per disk latency EWMA.
+	readLatencyEWMAWeight = 0.3
+)
+
 // Reads in parallel from readers.
 type parallelReader struct {
 	readers       []io.ReaderAt
@@ -38,6 +53,14 @@ type parallelReader struct {
 	shardFileSize int64
 	buf           [][]byte
 	readerToBuf   []int
+	latency       []time.Duration // latency EWMA of each reader.
+
+	// Reads of each buffer, closed once the read returns. Reads
+	// hedged and lost are still outstanding when Read returns,
+	// the next read of their reader waits for them and fails
+	// with their error.
+	inflight    []chan struct{}
+	inflightErr []error
 }
 
 // newParallelReader returns parallelReader.
@@ -55,9 +78,77 @@ func newParallelReader(readers []io.ReaderAt, e Erasure, offset, totalLength int
 		shardFileSize: e.ShardFileSize(totalLength),
 		buf:           make([][]byte, len(readers)),
 		readerToBuf:   r2b,
+		latency:       make([]time.Duration, len(readers)),
+		inflight:      make([]chan struct{}, len(readers)),
+		inflightErr:   make([]error, len(readers)),
+	}
+}
+
+// release returns the readers with reads hedged and lost still
+// outstanding to the caller wrapped, to be closed once the read
+// returns.
+func (p *parallelReader) release() {
+	for i, ch := range p.inflight {
+		if ch == nil || p.orgReaders[i] == nil {
+			continue
+		}
+		select {
+		case <-ch:
+		default:
+			p.orgReaders[i] = &hedgedReaderAt{ReaderAt: p.orgReaders[i], doneCh: ch}
+		}
 	}
 }
 
+// hedgedReaderAt - a reader whose read was hedged and lost.
+type hedgedReaderAt struct {
+	io.ReaderAt
+	doneCh <-chan struct{}
+}
+
+// Close closes the reader once its outstanding read returns.
+func (r *hedgedReaderAt) Close() error {
+	c, ok := r.ReaderAt.(io.Closer)
+	if !ok {
+		return nil
+	}
+	go func() {
+		<-r.doneCh
+		c.Close()
+	}()
+	return nil
+}
+
+// updateLatency adds a new read latency sample for reader i.
+func (p *parallelReader) updateLatency(i int, d time.Duration) {
+	if p.latency[i] == 0 {
+		p.latency[i] = d
+		return
+	}
+	p.latency[i] = time.Duration(readLatencyEWMAWeight*float64(d) + (1-readLatencyEWMAWeight)*float64(p.latency[i]))
+}
+
+// hedgeDelay returns how long to wait for outstanding reads before
+// reading another shard, returns 0 if there aren't enough latency
+// samples yet to tell an outlier apart.
+func (p *parallelReader) hedgeDelay() time.Duration {
+	var samples []time.Duration
+	for _, d := range p.latency {
+		if d > 0 {
+			samples = append(samples, d)
+		}
+	}
+	if len(samples) < p.dataBlocks {
+		return 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	delay := hedgeReadFactor * samples[len(samples)/2]
+	if delay < hedgeReadMinDelay {
+		delay = hedgeReadMinDelay
+	}
+	return delay
+}
+
 // preferReaders can mark readers as preferred.
 // These will be chosen before others.
 func (p *parallelReader) preferReaders(prefer []bool) {
@@ -108,7 +199,7 @@ func (p *parallelReader) Read(dst [][]byte) ([][]byte, error) {
 			newBuf[i] = newBuf[i][:0]
 		}
 	}
-	var newBufLK sync.RWMutex
+	var newBufLK sync.Mutex
 
 	if p.offset+p.shardSize > p.shardFileSize {
 		p.shardSize = p.shardFileSize - p.offset
@@ -117,50 +208,106 @@ func (p *parallelReader) Read(dst [][]byte) ([][]byte, error) {
 		return newBuf, nil
 	}
 
-	readTriggerCh := make(chan bool, len(p.readers))
+	readTriggerCh := make(chan bool, len(p.readers)+p.dataBlocks)
 	for i := 0; i < p.dataBlocks; i++ {
 		// Setup read triggers for p.dataBlocks number of reads so that it reads in parallel.
 		readTriggerCh <- true
 	}
 
+	// Once a disk is known to be slower than the others, hedge the
+	// outstanding reads by reading an additional shard and use
+	// whichever shards arrive first.
+	var hedgeCh <-chan time.Time
+	if delay := p.hedgeDelay(); delay > 0 {
+		hedgeTimer := time.NewTimer(delay)
+		defer hedgeTimer.Stop()
+		hedgeCh = hedgeTimer.C
+	}
+
 	healRequired := int32(0) // Atomic bool flag.
 	readerIndex := 0
-	var wg sync.WaitGroup
+	pending := make([]bool, len(p.readers))
+	var pendingCount int
+	var done bool
 	// if readTrigger is true, it implies next disk.ReadAt() should be tried
 	// if readTrigger is false, it implies previous disk.ReadAt() was successful and there is no need
 	// to try reading the next disk.
-	for readTrigger := range readTriggerCh {
-		newBufLK.RLock()
+	for {
+		var readTrigger bool
+		select {
+		case readTrigger = <-readTriggerCh:
+		case <-hedgeCh:
+			hedgeCh = nil
+			readTrigger = true
+		}
+		newBufLK.Lock()
 		canDecode := p.canDecode(newBuf)
-		newBufLK.RUnlock()
+		outstanding := pendingCount
+		newBufLK.Unlock()
 		if canDecode {
 			break
 		}
 		if readerIndex == len(p.readers) {
-			break
+			if outstanding == 0 {
+				break
+			}
+			continue
 		}
 		if !readTrigger {
 			continue
 		}
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			rr := p.readers[i]
+		newBufLK.Lock()
+		pending[readerIndex] = true
+		pendingCount++
+		newBufLK.Unlock()
+		bufIdx := p.readerToBuf[readerIndex]
+		prevCh := p.inflight[bufIdx]
+		doneCh := make(chan struct{})
+		p.inflight[bufIdx] = doneCh
+		go func(i, bufIdx int, rr io.ReaderAt, offset, shardSize int64) {
+			defer close(doneCh)
 			if rr == nil {
-				// Since reader is nil, trigger another read.
-				readTriggerCh <- true
+				newBufLK.Lock()
+				pending[i] = false
+				pendingCount--
+				if !done {
+					// Since reader is nil, trigger another read.
+					readTriggerCh <- true
+				}
+				newBufLK.Unlock()
 				return
 			}
-			bufIdx := p.readerToBuf[i]
+			var err error
+			if prevCh != nil {
+				// The buffer is in use until the previous read,
+				// which may have been hedged and lost, returns.
+				<-prevCh
+				err, p.inflightErr[bufIdx] = p.inflightErr[bufIdx], nil
+			}
 			if p.buf[bufIdx] == nil {
 				// Reading first time on this disk, hence the buffer needs to be allocated.
 				// Subsequent reads will re-use this buffer.
-				p.buf[bufIdx] = make([]byte, p.shardSize)
+				p.buf[bufIdx] = make([]byte, shardSize)
 			}
 			// For the last shard, the shardsize might be less than previous shard sizes.
 			// Hence the following statement ensures that the buffer size is reset to the right size.
-			p.buf[bufIdx] = p.buf[bufIdx][:p.shardSize]
-			_, err := rr.ReadAt(p.buf[bufIdx], p.offset)
+			p.buf[bufIdx] = p.buf[bufIdx][:shardSize]
+			start := time.Now()
+			if err == nil {
+				_, err = rr.ReadAt(p.buf[bufIdx], offset)
+			}
+
+			newBufLK.Lock()
+			defer newBufLK.Unlock()
+			pending[i] = false
+			pendingCount--
+			if done {
+				// This read was hedged and lost, the reader is still
+				// used for the next shards, an error is reported by
+				// its next read.
+				p.inflightErr[bufIdx] = err
+				return
+			}
 			if err != nil {
 				if _, ok := err.(*errHashMismatch); ok {
 					atomic.StoreInt32(&healRequired, 1)
@@ -173,15 +320,24 @@ func (p *parallelReader) Read(dst [][]byte) ([][]byte, error) {
 				readTriggerCh <- true
 				return
 			}
-			newBufLK.Lock()
+			p.updateLatency(i, time.Since(start))
 			newBuf[bufIdx] = p.buf[bufIdx]
-			newBufLK.Unlock()
 			// Since ReadAt returned success, there is no need to trigger another read.
 			readTriggerCh <- false
-		}(readerIndex)
+		}(readerIndex, bufIdx, p.readers[readerIndex], p.offset, p.shardSize)
 		readerIndex++
 	}
-	wg.Wait()
+
+	newBufLK.Lock()
+	done = true
+	for i := range pending {
+		if pending[i] {
+			// The buffer of the outstanding read is still in use,
+			// it must not be reused to reconstruct the shard.
+			newBuf[p.readerToBuf[i]] = nil
+		}
+	}
+	newBufLK.Unlock()
 
 	if p.canDecode(newBuf) {
 		p.offset += p.shardSize
@@ -235,6 +391,8 @@ func (e Erasure) decode(ctx context.Context, writer io.Writer, readers []io.Read
 	if len(prefer) == len(readers) {
 		reader.preferReaders(prefer)
 	}
+	// The readers are closed by the caller.
+	defer reader.release()
 
 	startBlock := offset / e.blockSize
 	endBlock := (offset + length) / e.blockSize
@@ -271,7 +429,7 @@ func (e Erasure) decode(ctx context.Context, writer io.Writer, readers []io.Read
 				return healRequired, err
 			}
 		}
-		if err = e.DecodeDataBlocks(bufs); err != nil {
+		if err = e.DecodeDataBlocks(ctx, bufs); err != nil {
 			logger.LogIf(ctx, err)
 			return healRequired, err
 		}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"math/rand"
 	"testing"
+	"time"
 
 	crand "crypto/rand"
 
@@ -134,7 +135,7 @@ func TestErasureDecode(t *testing.T) {
 			}
 			tillOffset := erasure.ShardFileOffset(test.offset, test.length, test.data)
 
-			bitrotReaders[index] = newBitrotReader(disk, "testbucket", "object", tillOffset, writeAlgorithm, bitrotWriterSum(writers[index]), erasure.ShardSize())
+			bitrotReaders[index] = newBitrotReader(context.Background(), disk, "testbucket", "object", tillOffset, writeAlgorithm, bitrotWriterSum(writers[index]), erasure.ShardSize())
 		}
 
 		writer := bytes.NewBuffer(nil)
@@ -164,7 +165,7 @@ func TestErasureDecode(t *testing.T) {
 					continue
 				}
 				tillOffset := erasure.ShardFileOffset(test.offset, test.length, test.data)
-				bitrotReaders[index] = newBitrotReader(disk, "testbucket", "object", tillOffset, writeAlgorithm, bitrotWriterSum(writers[index]), erasure.ShardSize())
+				bitrotReaders[index] = newBitrotReader(context.Background(), disk, "testbucket", "object", tillOffset, writeAlgorithm, bitrotWriterSum(writers[index]), erasure.ShardSize())
 			}
 			for j := range disks[:test.offDisks] {
 				if bitrotReaders[j] == nil {
@@ -199,6 +200,144 @@ func TestErasureDecode(t *testing.T) {
 	}
 }
 
+type slowReaderAt struct {
+	io.ReaderAt
+	reads int
+	delay time.Duration
+}
+
+// ReadAt serves the first read immediately and delays all others.
+func (r *slowReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if r.reads > 0 {
+		time.Sleep(r.delay)
+	}
+	r.reads++
+	return r.ReaderAt.ReadAt(p, off)
+}
+
+func (r *slowReaderAt) Close() error {
+	if c, ok := r.ReaderAt.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Tests that a single slow disk doesn't cap decode latency once
+// its latency sets it apart from the other disks.
+func TestErasureDecodeHedgedRead(t *testing.T) {
+	dataBlocks, parityBlocks := 4, 4
+	blockSize := int64(256 * humanize.KiByte)
+	setup, err := newErasureTestSetup(dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer setup.Remove()
+	disks := setup.disks
+	erasure, err := NewErasure(context.Background(), dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatalf("failed to create ErasureStorage: %v", err)
+	}
+
+	data := make([]byte, 8*blockSize)
+	if _, err = io.ReadFull(crand.Reader, data); err != nil {
+		t.Fatal(err)
+	}
+	length := int64(len(data))
+
+	writers := make([]io.Writer, len(disks))
+	for i, disk := range disks {
+		writers[i] = newBitrotWriter(disk, "testbucket", "object", erasure.ShardFileSize(length), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+	buffer := make([]byte, blockSize, 2*blockSize)
+	if _, err = erasure.Encode(context.Background(), bytes.NewReader(data), writers, buffer, erasure.dataBlocks+1); err != nil {
+		t.Fatal(err)
+	}
+	closeBitrotWriters(writers)
+
+	readers := make([]io.ReaderAt, len(disks))
+	for i, disk := range disks {
+		readers[i] = newStreamingBitrotReader(context.Background(), disk, "testbucket", "object", erasure.ShardFileOffset(0, length, length), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+	readers[0] = &slowReaderAt{ReaderAt: readers[0], delay: 2 * time.Second}
+
+	start := time.Now()
+	buf := &bytes.Buffer{}
+	err = erasure.Decode(context.Background(), buf, readers, 0, length, length, nil)
+	elapsed := time.Since(start)
+	closeBitrotReaders(readers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("read data is different from what was expected")
+	}
+	if elapsed >= 2*time.Second {
+		t.Fatalf("expected slow disk to be hedged, decode took %s", elapsed)
+	}
+	// The slow disk is not reported as offline.
+	if readers[0] == nil {
+		t.Fatal("expected the hedged reader to be kept")
+	}
+}
+
+// Tests that the disks hedged out of reads are still read for the
+// next shards, more slow disks than parity disks are still read.
+func TestErasureDecodeHedgedReadSlowDisks(t *testing.T) {
+	dataBlocks, parityBlocks := 4, 2
+	blockSize := int64(64 * humanize.KiByte)
+	setup, err := newErasureTestSetup(dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer setup.Remove()
+	disks := setup.disks
+	erasure, err := NewErasure(context.Background(), dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatalf("failed to create ErasureStorage: %v", err)
+	}
+
+	data := make([]byte, 16*blockSize)
+	if _, err = io.ReadFull(crand.Reader, data); err != nil {
+		t.Fatal(err)
+	}
+	length := int64(len(data))
+
+	writers := make([]io.Writer, len(disks))
+	for i, disk := range disks {
+		writers[i] = newBitrotWriter(disk, "testbucket", "object", erasure.ShardFileSize(length), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+	buffer := make([]byte, blockSize, 2*blockSize)
+	if _, err = erasure.Encode(context.Background(), bytes.NewReader(data), writers, buffer, erasure.dataBlocks+1); err != nil {
+		t.Fatal(err)
+	}
+	closeBitrotWriters(writers)
+
+	// Each disk is slow in turn, every disk is hedged out of
+	// some reads while all the shards are read.
+	readers := make([]io.ReaderAt, len(disks))
+	for i, disk := range disks {
+		readers[i] = &slowReaderAt{
+			ReaderAt: newStreamingBitrotReader(context.Background(), disk, "testbucket", "object", erasure.ShardFileOffset(0, length, length), DefaultBitrotAlgorithm, erasure.ShardSize()),
+			delay:    time.Duration(i%3) * 50 * time.Millisecond,
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	err = erasure.Decode(context.Background(), buf, readers, 0, length, length, nil)
+	closeBitrotReaders(readers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("read data is different from what was expected")
+	}
+	for i, r := range readers {
+		if r == nil {
+			t.Fatalf("expected the reader of disk %d to be kept", i)
+		}
+	}
+}
+
 // Test erasureDecode with random offset and lengths.
 // This test is t.Skip()ed as it a long time to run, hence should be run
 // explicitly after commenting out t.Skip()
@@ -269,7 +408,7 @@ func TestErasureDecodeRandomOffsetLength(t *testing.T) {
 				continue
 			}
 			tillOffset := erasure.ShardFileOffset(offset, readLen, length)
-			bitrotReaders[index] = newStreamingBitrotReader(disk, "testbucket", "object", tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
+			bitrotReaders[index] = newStreamingBitrotReader(context.Background(), disk, "testbucket", "object", tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
 		}
 		err = erasure.Decode(context.Background(), buf, bitrotReaders, offset, readLen, length, nil)
 		closeBitrotReaders(bitrotReaders)
@@ -331,7 +470,7 @@ func benchmarkErasureDecode(data, parity, dataDown, parityDown int, size int64,
 				continue
 			}
 			tillOffset := erasure.ShardFileOffset(0, size, size)
-			bitrotReaders[index] = newStreamingBitrotReader(disk, "testbucket", "object", tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
+			bitrotReaders[index] = newStreamingBitrotReader(context.Background(), disk, "testbucket", "object", tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
 		}
 		if err = erasure.Decode(context.Background(), bytes.NewBuffer(content[:0]), bitrotReaders, 0, size, size, nil); err != nil {
 			panic(err)
@@ -0,0 +1,222 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/minio/minio/pkg/ellipses"
+)
+
+const (
+	// Failure domains of the hosts, e.g. "rack1=node{1...4};rack2=node{5...8}".
+	EnvErasureFailureDomains = "MINIO_ERASURE_FAILURE_DOMAINS"
+	// Set to "on" to choose the parity from the drives per set and
+	// their failure domains when the drives are formatted, instead
+	// of half the drives per set.
+	EnvErasureAutoParity = "MINIO_ERASURE_AUTO_PARITY"
+)
+
+// failureDomains maps the hosts to the failure domain, a rack for
+// instance, they belong to. The hosts which are not listed are a
+// failure domain on their own.
+type failureDomains map[string]string
+
+// parseFailureDomains parses the failure domains delimited by `;`, each
+// of the form `domain=host,host`, the hosts accept ellipses.
+func parseFailureDomains(s string) (failureDomains, error) {
+	domains := make(failureDomains)
+	for _, domain := range strings.Split(s, ";") {
+		domain = strings.TrimSpace(domain)
+		if domain == "" {
+			continue
+		}
+		kv := strings.SplitN(domain, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid failure domain %q", domain)
+		}
+		name := kv[0]
+		for _, arg := range strings.Split(kv[1], ",") {
+			hosts := []string{arg}
+			if ellipses.HasEllipses(arg) {
+				patterns, err := ellipses.FindEllipsesPatterns(arg)
+				if err != nil {
+					return nil, err
+				}
+				hosts = hosts[:0]
+				for _, lbls := range patterns.Expand() {
+					hosts = append(hosts, strings.Join(lbls, ""))
+				}
+			}
+			for _, host := range hosts {
+				if other, ok := domains[host]; ok && other != name {
+					return nil, fmt.Errorf("host %s is in the failure domains %s and %s", host, other, name)
+				}
+				domains[host] = name
+			}
+		}
+	}
+	return domains, nil
+}
+
+// domain returns the failure domain of the host, which may carry a port.
+func (d failureDomains) domain(host string) string {
+	if name, ok := d[host]; ok {
+		return name
+	}
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		if name, ok := d[h]; ok {
+			return name
+		}
+	}
+	return host
+}
+
+// failureDomainDrives returns the largest number of drives of a set in
+// a single failure domain, hosts holds the host of every drive ordered
+// by set.
+func failureDomainDrives(hosts []string, drivesPerSet int) int {
+	var most int
+	for i := 0; i+drivesPerSet <= len(hosts); i += drivesPerSet {
+		count := make(map[string]int, drivesPerSet)
+		for _, host := range hosts[i : i+drivesPerSet] {
+			domain := globalFailureDomains.domain(host)
+			count[domain]++
+			if count[domain] > most {
+				most = count[domain]
+			}
+		}
+	}
+	return most
+}
+
+// autoParity returns the parity of the standard storage class chosen
+// when the drives are formatted, from the number of drives per set and
+// high enough for the objects to stay readable when the drives of a
+// whole failure domain are lost.
+func autoParity(drivesPerSet, domainDrives int) int {
+	parity := 2
+	switch {
+	case drivesPerSet >= 8:
+		parity = 4
+	case drivesPerSet >= 6:
+		parity = 3
+	}
+	// A failure domain holding more than half the drives
+	// of a set cannot be lost, whatever the parity.
+	if domainDrives > parity && domainDrives <= drivesPerSet/2 {
+		parity = domainDrives
+	}
+	if parity > drivesPerSet/2 {
+		parity = drivesPerSet / 2
+	}
+	return parity
+}
+
+// formatErasureParity returns the parity chosen when the drives of the
+// zones were formatted. The first zone sets it, zero when it predates
+// the parity being recorded, the later zones can only raise it.
+func formatErasureParity(formats []*formatErasureV3) int {
+	if len(formats) == 0 || formats[0].Erasure.Parity == 0 {
+		return 0
+	}
+	parity := formats[0].Erasure.Parity
+	for _, format := range formats[1:] {
+		if format.Erasure.Parity > parity {
+			parity = format.Erasure.Parity
+		}
+	}
+	return parity
+}
+
+// equal returns whether both describe the same failure domains.
+func (d failureDomains) equal(other failureDomains) bool {
+	if len(d) != len(other) {
+		return false
+	}
+	for host, name := range d {
+		if other[host] != name {
+			return false
+		}
+	}
+	return true
+}
+
+// spreadFailureDomains orders the endpoints of a zone so that every
+// erasure set takes its drives evenly from the failure domains. Every
+// drive of a set holds one shard of an object, a set with no more than
+// parity drives in a failure domain keeps its objects readable when the
+// whole domain is down.
+func spreadFailureDomains(endpoints Endpoints, setCount, drivesPerSet int) Endpoints {
+	var order []string
+	queues := make(map[string]Endpoints)
+	for _, endpoint := range endpoints {
+		domain := globalFailureDomains.domain(endpoint.Host)
+		if _, ok := queues[domain]; !ok {
+			order = append(order, domain)
+		}
+		queues[domain] = append(queues[domain], endpoint)
+	}
+
+	spread := make(Endpoints, 0, len(endpoints))
+	for i := 0; i < setCount; i++ {
+		// Every set takes its share of the drives left in
+		// each domain, the share is scaled by the sets left.
+		setsLeft := setCount - i
+		left := make(map[string]int, len(order))
+		for _, domain := range order {
+			left[domain] = len(queues[domain])
+		}
+		inSet := make(map[string]int, len(order))
+		for j := 0; j < drivesPerSet; j++ {
+			var best string
+			var found bool
+			for _, domain := range order {
+				if len(queues[domain]) == 0 {
+					continue
+				}
+				if !found || left[domain]-inSet[domain]*setsLeft > left[best]-inSet[best]*setsLeft {
+					best, found = domain, true
+				}
+			}
+			if !found {
+				return endpoints
+			}
+			spread = append(spread, queues[best][0])
+			queues[best] = queues[best][1:]
+			inSet[best]++
+		}
+	}
+	if len(spread) != len(endpoints) {
+		return endpoints
+	}
+	return spread
+}
+
+// spreadZonesFailureDomains orders the endpoints of every zone when
+// failure domains are configured.
+func spreadZonesFailureDomains(endpointZones EndpointZones) {
+	if globalFailureDomains == nil {
+		return
+	}
+	for i := range endpointZones {
+		ep := &endpointZones[i]
+		ep.Endpoints = spreadFailureDomains(ep.Endpoints, ep.SetCount, ep.DrivesPerSet)
+	}
+}
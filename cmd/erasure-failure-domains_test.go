@@ -0,0 +1,263 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseFailureDomains(t *testing.T) {
+	testCases := []struct {
+		value   string
+		domains failureDomains
+		success bool
+	}{
+		{"", failureDomains{}, true},
+		{"rack1=node1,node2", failureDomains{"node1": "rack1", "node2": "rack1"}, true},
+		{
+			"rack1=node{1...2}; rack2=node{3...4},node9:9000",
+			failureDomains{"node1": "rack1", "node2": "rack1", "node3": "rack2", "node4": "rack2", "node9:9000": "rack2"},
+			true,
+		},
+		{"rack1=node1;rack1=node1", failureDomains{"node1": "rack1"}, true},
+		{"rack1", nil, false},
+		{"=node1", nil, false},
+		{"rack1=", nil, false},
+		{"rack1=node1;rack2=node1", nil, false},
+		{"rack1=node{1...", nil, false},
+	}
+
+	for i, testCase := range testCases {
+		domains, err := parseFailureDomains(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if testCase.success && !reflect.DeepEqual(domains, testCase.domains) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.domains, domains)
+		}
+	}
+}
+
+func TestFailureDomainDrives(t *testing.T) {
+	defer func(domains failureDomains) { globalFailureDomains = domains }(globalFailureDomains)
+
+	hosts := []string{
+		"node1:9000", "node2:9000", "node3:9000", "node4:9000",
+		"node1:9000", "node2:9000", "node3:9000", "node4:9000",
+	}
+
+	globalFailureDomains = nil
+	if n := failureDomainDrives(hosts, 8); n != 2 {
+		t.Errorf("expected 2 drives per host, got %d", n)
+	}
+	if n := failureDomainDrives(hosts, 4); n != 1 {
+		t.Errorf("expected 1 drive per host, got %d", n)
+	}
+
+	var err error
+	globalFailureDomains, err = parseFailureDomains("rack1=node1,node2;rack2=node3,node4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := failureDomainDrives(hosts, 8); n != 4 {
+		t.Errorf("expected 4 drives per rack, got %d", n)
+	}
+	if n := failureDomainDrives(hosts, 4); n != 2 {
+		t.Errorf("expected 2 drives per rack, got %d", n)
+	}
+}
+
+func TestAutoParity(t *testing.T) {
+	testCases := []struct {
+		drivesPerSet, domainDrives int
+		parity                     int
+	}{
+		// Single host, the host cannot be lost.
+		{4, 4, 2},
+		{6, 6, 3},
+		{16, 16, 4},
+		// One drive per host.
+		{4, 1, 2},
+		{8, 1, 4},
+		{16, 1, 4},
+		// Several drives per host or rack.
+		{16, 4, 4},
+		{16, 6, 6},
+		{16, 8, 8},
+		{12, 5, 5},
+		{12, 7, 4},
+	}
+
+	for i, testCase := range testCases {
+		if parity := autoParity(testCase.drivesPerSet, testCase.domainDrives); parity != testCase.parity {
+			t.Errorf("Test %d: expected parity %d, got %d", i+1, testCase.parity, parity)
+		}
+	}
+}
+
+func TestFormatErasureParity(t *testing.T) {
+	newFormat := func(parity int) *formatErasureV3 {
+		format := newFormatErasureV3(1, 16)
+		format.Erasure.Parity = parity
+		return format
+	}
+
+	testCases := []struct {
+		formats []*formatErasureV3
+		parity  int
+	}{
+		{nil, 0},
+		{[]*formatErasureV3{newFormat(0)}, 0},
+		{[]*formatErasureV3{newFormat(0), newFormat(4)}, 0},
+		{[]*formatErasureV3{newFormat(4)}, 4},
+		{[]*formatErasureV3{newFormat(4), newFormat(6)}, 6},
+		{[]*formatErasureV3{newFormat(6), newFormat(4)}, 6},
+	}
+
+	for i, testCase := range testCases {
+		if parity := formatErasureParity(testCase.formats); parity != testCase.parity {
+			t.Errorf("Test %d: expected parity %d, got %d", i+1, testCase.parity, parity)
+		}
+	}
+}
+
+func TestSpreadFailureDomains(t *testing.T) {
+	defer func(domains failureDomains) { globalFailureDomains = domains }(globalFailureDomains)
+
+	var err error
+	globalFailureDomains, err = parseFailureDomains("rack1=node{1...2};rack2=node{3...4};rack3=node{5...6};rack4=node{7...8}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ellipses order, the hosts vary first.
+	var endpoints Endpoints
+	for disk := 1; disk <= 2; disk++ {
+		for node := 1; node <= 8; node++ {
+			endpoints = append(endpoints, Endpoint{URL: &url.URL{
+				Scheme: "http",
+				Host:   fmt.Sprintf("node%d:9000", node),
+				Path:   fmt.Sprintf("/disk%d", disk),
+			}})
+		}
+	}
+
+	hosts := func(endpoints Endpoints) []string {
+		hosts := make([]string, len(endpoints))
+		for i, endpoint := range endpoints {
+			hosts[i] = endpoint.Host
+		}
+		return hosts
+	}
+
+	// Sets of 4 drives from consecutive hosts hold 2 drives of a rack.
+	if n := failureDomainDrives(hosts(endpoints), 4); n != 2 {
+		t.Fatalf("expected 2 drives per rack, got %d", n)
+	}
+
+	spread := spreadFailureDomains(endpoints, 4, 4)
+	if len(spread) != len(endpoints) {
+		t.Fatalf("expected %d endpoints, got %d", len(endpoints), len(spread))
+	}
+	seen := make(map[string]bool, len(spread))
+	for _, endpoint := range spread {
+		seen[endpoint.String()] = true
+	}
+	if len(seen) != len(endpoints) {
+		t.Fatalf("expected every endpoint once, got %v", spread)
+	}
+	if n := failureDomainDrives(hosts(spread), 4); n != 1 {
+		t.Errorf("expected 1 drive per rack, got %d: %v", n, spread)
+	}
+
+	// Uneven racks are spread as evenly as possible.
+	globalFailureDomains, err = parseFailureDomains("rack1=node{1...4};rack2=node{5...6};rack3=node{7...8}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spread = spreadFailureDomains(endpoints, 2, 8)
+	if n := failureDomainDrives(hosts(spread), 8); n != 4 {
+		t.Errorf("expected 4 drives per rack, got %d: %v", n, spread)
+	}
+}
+
+func TestCheckFormatErasureFailureDomains(t *testing.T) {
+	defer func(domains failureDomains) { globalFailureDomains = domains }(globalFailureDomains)
+
+	domains, err := parseFailureDomains("rack1=node{1...2};rack2=node{3...4}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	format := newFormatErasureV3(1, 4)
+	format.Erasure.FailureDomains = domains
+	formats := []*formatErasureV3{format, format, format, format}
+
+	globalFailureDomains = nil
+	if err = checkFormatErasureValues(formats, 4); err == nil {
+		t.Error("expected the removed failure domains to be refused")
+	}
+	globalFailureDomains = domains
+	if err = checkFormatErasureValues(formats, 4); err != nil {
+		t.Error(err)
+	}
+
+	format.Erasure.FailureDomains = nil
+	if err = checkFormatErasureValues(formats, 4); err == nil {
+		t.Error("expected the added failure domains to be refused")
+	}
+}
+
+func TestInitFormatErasureAutoParity(t *testing.T) {
+	storageDisks := make([]StorageAPI, 16)
+	for i := range storageDisks {
+		diskPath, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(diskPath)
+		if storageDisks[i], err = newXLStorage(diskPath, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	globalFailureDomains = nil
+	defer func() { globalErasureAutoParity = false }()
+	for _, testCase := range []struct {
+		autoParity bool
+		parity     int
+	}{
+		// Half the drives per set unless enabled.
+		{false, 0},
+		{true, 4},
+	} {
+		globalErasureAutoParity = testCase.autoParity
+		format, err := initFormatErasure(context.Background(), storageDisks, 1, 16, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if format.Erasure.Parity != testCase.parity {
+			t.Errorf("auto parity %v: expected parity %d, got %d", testCase.autoParity, testCase.parity, format.Erasure.Parity)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// garbageScanner finds, on a local drive, the files left behind by
+// interrupted operations: entries of the tmp directory, data directories
+// and part files the metadata of their object or multipart upload doesn't
+// refer to, and multipart uploads without metadata. Entries modified
+// after the cutoff may belong to on-going operations and are skipped.
+type garbageScanner struct {
+	drive  string
+	cutoff time.Time
+	remove bool
+
+	entries []madmin.GarbageEntry
+}
+
+// scanLocalGarbage scans the local drives of the server for garbage
+// older than olderThan, removed if requested.
+func scanLocalGarbage(ctx context.Context, olderThan time.Duration, remove bool) madmin.GarbageReport {
+	cutoff := UTCNow().Add(-olderThan)
+	report := madmin.GarbageReport{Entries: []madmin.GarbageEntry{}}
+	for _, zone := range globalEndpoints {
+		for _, endpoint := range zone.Endpoints {
+			if !endpoint.IsLocal {
+				continue
+			}
+			for _, entry := range scanGarbage(ctx, endpoint.Path, cutoff, remove) {
+				// Tell apart the drives of the servers.
+				entry.Drive = endpoint.String()
+				report.TotalSize += entry.Size
+				report.Entries = append(report.Entries, entry)
+			}
+		}
+	}
+	return report
+}
+
+// scanGarbage scans the drive at drivePath and returns the garbage found,
+// removed if requested.
+func scanGarbage(ctx context.Context, drivePath string, cutoff time.Time, remove bool) []madmin.GarbageEntry {
+	s := &garbageScanner{drive: drivePath, cutoff: cutoff, remove: remove}
+
+	entries, _ := readDir(pathJoin(drivePath, minioMetaTmpBucket))
+	for _, entry := range entries {
+		s.add(pathJoin(minioMetaTmpBucket, strings.TrimSuffix(entry, SlashSeparator)), madmin.GarbageTmp)
+	}
+
+	s.scanMultipart(ctx)
+
+	buckets, _ := readDir(drivePath)
+	for _, bucket := range buckets {
+		if !HasSuffix(bucket, SlashSeparator) || strings.HasPrefix(bucket, ".") {
+			continue
+		}
+		s.scanDir(ctx, strings.TrimSuffix(bucket, SlashSeparator))
+	}
+	return s.entries
+}
+
+// scanMultipart scans the uploads in
+// '.minio.sys/multipart/<sha256(bucket/object)>/<uploadID>/'.
+func (s *garbageScanner) scanMultipart(ctx context.Context) {
+	shaDirs, _ := readDir(pathJoin(s.drive, minioMetaMultipartBucket))
+	for _, shaDir := range shaDirs {
+		uploadIDs, _ := readDir(pathJoin(s.drive, minioMetaMultipartBucket, shaDir))
+		for _, uploadID := range uploadIDs {
+			if ctx.Err() != nil {
+				return
+			}
+			uploadIDPath := pathJoin(minioMetaMultipartBucket, shaDir, strings.TrimSuffix(uploadID, SlashSeparator))
+			buf, err := ioutil.ReadFile(pathJoin(s.drive, uploadIDPath, xlStorageFormatFile))
+			if os.IsNotExist(err) {
+				s.add(uploadIDPath, madmin.GarbageUpload)
+				continue
+			}
+			if err != nil {
+				continue
+			}
+			fi, err := getFileInfo(buf, minioMetaMultipartBucket, uploadIDPath, "")
+			if err != nil {
+				continue
+			}
+			entries, err := readDir(pathJoin(s.drive, uploadIDPath))
+			if err != nil {
+				continue
+			}
+			s.scanDataDirs(uploadIDPath, entries, []FileInfo{fi})
+		}
+	}
+}
+
+// scanDir scans a directory of a bucket, the directories holding
+// the metadata of an object are objects, the others prefixes. The
+// objects may also be prefixes of other objects.
+func (s *garbageScanner) scanDir(ctx context.Context, dir string) {
+	if ctx.Err() != nil {
+		return
+	}
+	entries, err := readDir(pathJoin(s.drive, dir))
+	if err != nil {
+		return
+	}
+	prefixes := entries
+	for _, entry := range entries {
+		if entry == xlStorageFormatFile || entry == xlStorageFormatFileV1 {
+			prefixes = s.scanObject(dir, entry, entries)
+			break
+		}
+	}
+	for _, entry := range prefixes {
+		if HasSuffix(entry, SlashSeparator) {
+			s.scanDir(ctx, pathJoin(dir, entry))
+		}
+	}
+}
+
+// scanObject scans the data directories and parts of an object, and
+// returns the entries of dir which are not data directories.
+func (s *garbageScanner) scanObject(dir, metaFile string, entries []string) []string {
+	buf, err := ioutil.ReadFile(pathJoin(s.drive, dir, metaFile))
+	if err != nil {
+		return entries
+	}
+	fivs, err := getFileInfoVersions(buf, "", dir)
+	if err != nil {
+		// Corrupted metadata is for healing to repair.
+		return entries
+	}
+	return s.scanDataDirs(dir, entries, fivs.Versions)
+}
+
+// scanDataDirs reports the data directories and the part files in the
+// entries of dir that none of the versions refers to, the parts of
+// versions without a data directory are in dir. Returns the entries
+// which are not data directories, the prefixes of other objects.
+func (s *garbageScanner) scanDataDirs(dir string, entries []string, versions []FileInfo) (prefixes []string) {
+	parts := make(map[string]map[int]struct{})
+	for _, fi := range versions {
+		if fi.Deleted {
+			continue
+		}
+		if parts[fi.DataDir] == nil {
+			parts[fi.DataDir] = make(map[int]struct{})
+		}
+		for _, part := range fi.Parts {
+			parts[fi.DataDir][part.Number] = struct{}{}
+		}
+	}
+
+	for _, entry := range entries {
+		if !HasSuffix(entry, SlashSeparator) {
+			if _, ok := parsePartFileName(entry); ok {
+				s.scanPart(dir, entry, parts[""])
+			}
+			continue
+		}
+		dataDir := strings.TrimSuffix(entry, SlashSeparator)
+		if partNumbers, ok := parts[dataDir]; ok {
+			partEntries, err := readDir(pathJoin(s.drive, dir, dataDir))
+			if err != nil {
+				continue
+			}
+			for _, partEntry := range partEntries {
+				if _, ok := parsePartFileName(partEntry); ok {
+					s.scanPart(pathJoin(dir, dataDir), partEntry, partNumbers)
+				}
+			}
+			continue
+		}
+		if _, err := uuid.Parse(dataDir); err != nil && dataDir != legacyDataDir {
+			prefixes = append(prefixes, entry)
+			continue
+		}
+		// Directories holding metadata or other directories are
+		// objects or prefixes named as data directories.
+		if !s.isDataDir(pathJoin(dir, dataDir)) {
+			prefixes = append(prefixes, entry)
+			continue
+		}
+		s.add(pathJoin(dir, dataDir), madmin.GarbageDataDir)
+	}
+	return prefixes
+}
+
+// isDataDir returns whether the directory at path holds nothing but
+// part files.
+func (s *garbageScanner) isDataDir(path string) bool {
+	entries, err := readDir(pathJoin(s.drive, path))
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if _, ok := parsePartFileName(entry); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// scanPart reports the part file if its number isn't a known part.
+func (s *garbageScanner) scanPart(dir, name string, partNumbers map[int]struct{}) {
+	n, _ := parsePartFileName(name)
+	if _, ok := partNumbers[n]; !ok {
+		s.add(pathJoin(dir, name), madmin.GarbagePart)
+	}
+}
+
+// add reports the file or directory at path, relative to the drive,
+// unless it was modified after the cutoff, and removes it if requested.
+func (s *garbageScanner) add(path, kind string) {
+	fullPath := pathJoin(s.drive, path)
+	size, modTime, err := garbageStat(fullPath)
+	if err != nil || modTime.After(s.cutoff) {
+		return
+	}
+	entry := madmin.GarbageEntry{
+		Drive:   s.drive,
+		Path:    path,
+		Kind:    kind,
+		Size:    size,
+		ModTime: modTime,
+	}
+	if s.remove {
+		if err = removeAll(fullPath); err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Removed = true
+		}
+	}
+	s.entries = append(s.entries, entry)
+}
+
+// garbageStat returns the size of the files under path and the
+// most recent modification time of path and the files under it.
+func garbageStat(path string) (size int64, modTime time.Time, err error) {
+	err = filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+		return nil
+	})
+	return size, modTime, err
+}
+
+// parsePartFileName returns the number of a part file named 'part.N'.
+func parsePartFileName(name string) (int, bool) {
+	if !strings.HasPrefix(name, "part.") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(name, "part."))
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
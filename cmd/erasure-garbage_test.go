@@ -0,0 +1,200 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestScanGarbage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	defer removeRoots(fsDirs)
+
+	bucket, object := "bucket", "dir/object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	// Objects below the object, written before it, one of them below
+	// a prefix named as a data directory.
+	nested, uuidNested := pathJoin(object, "nested"), pathJoin(object, mustGetUUID(), "nested")
+	for _, name := range []string{nested, uuidNested} {
+		if _, err = obj.PutObject(ctx, bucket, name, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	part, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	drive := fsDirs[0]
+	shaDir := pathJoin(minioMetaMultipartBucket, getSHA256Hash([]byte(pathJoin(bucket, object))))
+	entries, err := readDir(pathJoin(drive, shaDir, uploadID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uploadDataDir string
+	for _, entry := range entries {
+		if HasSuffix(entry, SlashSeparator) {
+			uploadDataDir = strings.TrimSuffix(entry, SlashSeparator)
+		}
+	}
+	if uploadDataDir == "" {
+		t.Fatal("Expected the data directory of the upload")
+	}
+
+	garbage := map[string]string{
+		pathJoin(minioMetaTmpBucket, mustGetUUID()):                       madmin.GarbageTmp,
+		pathJoin(bucket, object, mustGetUUID()):                           madmin.GarbageDataDir,
+		pathJoin(bucket, nested, mustGetUUID()):                           madmin.GarbageDataDir,
+		pathJoin(bucket, object, "part.1"):                                madmin.GarbagePart,
+		pathJoin(shaDir, uploadID, mustGetUUID()):                         madmin.GarbageDataDir,
+		pathJoin(shaDir, uploadID, uploadDataDir, "part.2"):               madmin.GarbagePart,
+		pathJoin(shaDir, mustGetUUID()):                                   madmin.GarbageUpload,
+		pathJoin(minioMetaMultipartBucket, mustGetUUID(), mustGetUUID()):  madmin.GarbageUpload,
+		pathJoin(bucket, "other", mustGetUUID(), mustGetUUID(), "part.1"): "",
+	}
+	var expected []string
+	for path, kind := range garbage {
+		switch kind {
+		case madmin.GarbagePart:
+			err = ioutil.WriteFile(pathJoin(drive, path), data, 0644)
+		case "":
+			// Files outside of objects are not garbage.
+			err = os.MkdirAll(pathJoin(drive, path), 0755)
+		default:
+			if err = os.MkdirAll(pathJoin(drive, path), 0755); err == nil {
+				err = ioutil.WriteFile(pathJoin(drive, path, "part.1"), data, 0644)
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != "" {
+			expected = append(expected, kind+":"+path)
+		}
+	}
+	sort.Strings(expected)
+
+	scan := func(cutoff time.Time, remove bool) []string {
+		var found []string
+		for _, entry := range scanGarbage(ctx, drive, cutoff, remove) {
+			found = append(found, entry.Kind+":"+entry.Path)
+			if remove && !entry.Removed {
+				t.Errorf("Expected %s to be removed: %s", entry.Path, entry.Error)
+			}
+		}
+		sort.Strings(found)
+		return found
+	}
+
+	// Recent entries may belong to on-going operations.
+	if found := scan(time.Now().Add(-time.Hour), false); len(found) != 0 {
+		t.Fatalf("Expected no garbage older than an hour, got %v", found)
+	}
+
+	cutoff := time.Now().Add(time.Hour)
+	if found := scan(cutoff, false); strings.Join(found, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Expected garbage %v, got %v", expected, found)
+	}
+	if found := scan(cutoff, true); len(found) != len(expected) {
+		t.Fatalf("Expected %d garbage entries to be removed, got %v", len(expected), found)
+	}
+	if found := scan(cutoff, false); len(found) != 0 {
+		t.Fatalf("Expected no garbage after removal, got %v", found)
+	}
+
+	// The objects and the upload are intact.
+	for _, name := range []string{object, nested, uuidNested} {
+		var buf bytes.Buffer
+		if err = obj.GetObject(ctx, bucket, name, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("Expected %s to be intact", name)
+		}
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanGarbageHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminTestBed.TearDown()
+
+	for i, testCase := range []struct {
+		olderThan      string
+		remove         string
+		expectedStatus int
+	}{
+		{"", "false", http.StatusOK},
+		{"48h", "true", http.StatusOK},
+		{"0s", "false", http.StatusOK},
+		// Would remove the files of on-going operations.
+		{"0s", "true", http.StatusBadRequest},
+		{"-1h", "false", http.StatusBadRequest},
+	} {
+		queryVal := url.Values{}
+		if testCase.olderThan != "" {
+			queryVal.Set("older-than", testCase.olderThan)
+		}
+		queryVal.Set("remove", testCase.remove)
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/scan-garbage", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, testCase.expectedStatus, rec.Code)
+		}
+	}
+}
@@ -396,7 +396,7 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				}
 				checksumInfo := partsMetadata[i].Erasure.GetChecksumInfo(partNumber)
 				partPath := pathJoin(object, latestMeta.DataDir, fmt.Sprintf("part.%d", partNumber))
-				readers[i] = newBitrotReader(disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
+				readers[i] = newBitrotReader(ctx, disk, bucket, partPath, tillOffset, checksumAlgo, checksumInfo.Hash, erasure.ShardSize())
 			}
 			writers := make([]io.Writer, len(outDatedDisks))
 			for i, disk := range outDatedDisks {
@@ -442,7 +442,7 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 		}
 	}
 
-	defer er.deleteObject(ctx, minioMetaTmpBucket, tmpID, len(storageDisks)/2+1)
+	defer er.deleteTmpObject(ctx, bucket, object, tmpID, len(storageDisks)/2+1)
 
 	// Generate and write `xl.meta` generated from other disks.
 	outDatedDisks, err = writeUniqueFileInfo(ctx, outDatedDisks, minioMetaTmpBucket, tmpID,
@@ -724,8 +724,14 @@ func (er erasureObjects) HealObject(ctx context.Context, bucket, object, version
 	}
 	healCtx := logger.SetReqInfo(GlobalContext, newReqInfo)
 
-	// Healing directories handle it separately.
+	// Healing directories handle it separately, after healing
+	// the directory object stored inside the directory if any.
 	if HasSuffix(object, SlashSeparator) {
+		hr, err = er.HealObject(ctx, bucket, encodeDirObject(object), versionID, opts)
+		if err == nil || !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			hr.Object = object
+			return hr, toObjectErr(err, bucket, object)
+		}
 		return er.healObjectDir(healCtx, bucket, object, opts.DryRun, opts.Remove)
 	}
 
@@ -403,18 +403,17 @@ func TestHealEmptyDirectoryErasure(t *testing.T) {
 
 	bucket := "bucket"
 	object := "empty-dir/"
-	var opts ObjectOptions
 
 	err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{})
 	if err != nil {
 		t.Fatalf("Failed to make a bucket - %v", err)
 	}
 
-	// Upload an empty directory
-	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t,
-		bytes.NewReader([]byte{}), 0, "", ""), opts)
-	if err != nil {
-		t.Fatal(err)
+	// Create an empty directory, as saved before directory objects
+	for _, fsDir := range fsDirs {
+		if err = os.MkdirAll(pathJoin(fsDir, bucket, object), 0777); err != nil {
+			t.Fatal(err)
+		}
 	}
 
 	// Remove the object backend files from the first disk.
@@ -462,4 +461,28 @@ func TestHealEmptyDirectoryErasure(t *testing.T) {
 			t.Fatalf("Unexpected drive state (%d): %v", i+1, h.State)
 		}
 	}
+
+	// Upload a directory object
+	dirObject := "dir-object/"
+	_, err = obj.PutObject(ctx, bucket, dirObject, mustGetPutObjReader(t,
+		bytes.NewReader([]byte{}), 0, "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Remove the directory object metadata from the first disk.
+	err = firstDisk.DeleteFile(bucket, pathJoin(encodeDirObject(dirObject), xlStorageFormatFile))
+	if err != nil {
+		t.Fatalf("Failed to delete a file - %v", err)
+	}
+
+	// Heal the directory object
+	if _, err = obj.HealObject(ctx, bucket, dirObject, "", madmin.HealOpts{ScanMode: madmin.HealNormalScan}); err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+
+	// Check if the directory object is restored in the first disk
+	if _, err = firstDisk.ReadVersion(bucket, encodeDirObject(dirObject), ""); err != nil {
+		t.Fatalf("Expected object to be present but read failed - %v", err)
+	}
 }
@@ -27,7 +27,9 @@ import (
 // as healing should continue even if it has been successful healing only one shard file.
 func (e Erasure) Heal(ctx context.Context, readers []io.ReaderAt, writers []io.Writer, size int64) error {
 	r, w := io.Pipe()
+	decodeDone := make(chan struct{})
 	go func() {
+		defer close(decodeDone)
 		if err := e.Decode(ctx, w, readers, 0, size, size, nil); err != nil {
 			w.CloseWithError(err)
 			return
@@ -37,6 +39,11 @@ func (e Erasure) Heal(ctx context.Context, readers []io.ReaderAt, writers []io.W
 	buf := make([]byte, e.blockSize)
 	// quorum is 1 because CreateFile should continue writing as long as we are writing to even 1 disk.
 	n, err := e.Encode(ctx, r, writers, buf, 1)
+
+	// Wait for decoding to stop before the caller closes the readers.
+	r.Close()
+	<-decodeDone
+
 	if err != nil {
 		return err
 	}
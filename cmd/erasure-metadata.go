@@ -26,12 +26,72 @@ import (
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/sync/errgroup"
 	"github.com/minio/sha256-simd"
 )
 
 const erasureAlgorithm = "rs-vandermonde"
 
+const (
+	// metaGenerationKey - internal metadata identifying the last write
+	// of an object version or of its metadata.
+	metaGenerationKey = ReservedMetadataPrefix + "generation"
+
+	// metaExpectedGenerationKey - internal metadata of a metadata update
+	// holding the generation it was read from, it is not saved.
+	metaExpectedGenerationKey = ReservedMetadataPrefix + "expected-generation"
+)
+
+// metaGenerations - returns the generations of the metadata read from
+// each disk.
+func metaGenerations(metaArr []FileInfo) []string {
+	generations := make([]string, len(metaArr))
+	for i := range metaArr {
+		generations[i] = metaArr[i].Metadata[metaGenerationKey]
+	}
+	return generations
+}
+
+// newMetaGeneration - returns a new generation for a write of an object
+// version or of its metadata. Generations are unique rather than counted,
+// so that a version overwritten between the read and the rename of a
+// metadata update never has the generation the update was read from.
+func newMetaGeneration() string {
+	return mustGetUUID()
+}
+
+// copyFileInfos - returns a copy of the metadata read from each disk,
+// kept to undo an update of the metadata.
+func copyFileInfos(metaArr []FileInfo) []FileInfo {
+	orig := make([]FileInfo, len(metaArr))
+	for i, fi := range metaArr {
+		orig[i] = fi
+		if fi.Metadata == nil {
+			continue
+		}
+		orig[i].Metadata = make(map[string]string, len(fi.Metadata))
+		for k, v := range fi.Metadata {
+			orig[i].Metadata[k] = v
+		}
+	}
+	return orig
+}
+
+// setNextMetaGenerations - sets a new generation of the metadata updated
+// on each disk, renaming the update fails with errMetadataConflict when
+// the disk metadata is no longer at the generation it was read from.
+func setNextMetaGenerations(metaArr []FileInfo, generations []string) {
+	generation := newMetaGeneration()
+	for i := range metaArr {
+		if metaArr[i].Metadata == nil {
+			continue
+		}
+		metaArr[i].Metadata[metaExpectedGenerationKey] = generations[i]
+		metaArr[i].Metadata[metaGenerationKey] = generation
+	}
+}
+
 // byObjectPartNumber is a collection satisfying sort.Interface.
 type byObjectPartNumber []ObjectPartInfo
 
@@ -94,15 +154,20 @@ func (fi FileInfo) IsValid() bool {
 
 // ToObjectInfo - Converts metadata to object info.
 func (fi FileInfo) ToObjectInfo(bucket, object string) ObjectInfo {
-	if HasSuffix(object, SlashSeparator) {
+	object = decodeDirObject(object)
+	if HasSuffix(object, SlashSeparator) && fi.Mode.IsDir() {
+		// Directory without a directory object, listed as a prefix
+		// or as an empty directory object created by older releases.
 		return ObjectInfo{
-			Bucket: bucket,
-			Name:   object,
-			IsDir:  true,
+			Bucket:      bucket,
+			Name:        object,
+			IsDir:       true,
+			ETag:        emptyETag,
+			ContentType: "application/octet-stream",
 		}
 	}
 	objInfo := ObjectInfo{
-		IsDir:           false,
+		IsDir:           HasSuffix(object, SlashSeparator),
 		Bucket:          bucket,
 		Name:            object,
 		VersionID:       fi.VersionID,
@@ -131,6 +196,9 @@ func (fi FileInfo) ToObjectInfo(bucket, object string) ObjectInfo {
 	// Add user tags to the object info
 	objInfo.UserTags = fi.Metadata[xhttp.AmzObjectTagging]
 
+	// Add replication status to the object info
+	objInfo.ReplicationStatus = replication.StatusType(fi.Metadata[xhttp.AmzBucketReplicationStatus])
+
 	// etag/md5Sum has already been extracted. We need to
 	// remove to avoid it from appearing as part of
 	// response headers. e.g, X-Minio-* or X-Amz-*.
@@ -147,6 +215,9 @@ func (fi FileInfo) ToObjectInfo(bucket, object string) ObjectInfo {
 		objInfo.StorageClass = globalMinioDefaultStorageClass
 	}
 
+	// Update size, etag and storage class of transitioned objects.
+	applyTransitionMetadata(&objInfo, fi.Metadata)
+
 	// Success.
 	return objInfo
 }
@@ -257,6 +328,64 @@ func pickValidFileInfo(ctx context.Context, metaArr []FileInfo, modTime time.Tim
 
 // Rename metadata content to destination location for each disk concurrently.
 func renameFileInfo(ctx context.Context, disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string, quorum int) ([]StorageAPI, error) {
+	errs := renameAllFileInfo(disks, srcBucket, srcEntry, dstBucket, dstEntry)
+
+	// We can safely allow RenameData errors up to len(er.getDisks()) - writeQuorum
+	// otherwise return failure. Cleanup successful renames.
+	err := reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, quorum)
+	return evalDisks(disks, errs), err
+}
+
+// renameFileInfoUpdate - renames a metadata update as renameFileInfo
+// does, the update is applied to all the disks or to none of them: if
+// the metadata of any disk was written after it was read for the
+// update, the update is undone on the disks it was renamed on, their
+// metadata orig is restored, and errMetadataConflict is returned.
+func renameFileInfoUpdate(ctx context.Context, disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string, metaArr, orig []FileInfo, quorum int) ([]StorageAPI, error) {
+	errs := renameAllFileInfo(disks, srcBucket, srcEntry, dstBucket, dstEntry)
+
+	var conflict bool
+	for _, err := range errs {
+		if err == errMetadataConflict {
+			conflict = true
+			break
+		}
+	}
+	if !conflict {
+		err := reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, quorum)
+		return evalDisks(disks, errs), err
+	}
+
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		if disks[index] == nil || errs[index] != nil || orig[index].Metadata == nil {
+			continue
+		}
+		g.Go(func() error {
+			// The metadata is restored unless it was written again
+			// since the update.
+			fi := orig[index]
+			fi.Metadata[metaExpectedGenerationKey] = metaArr[index].Metadata[metaGenerationKey]
+			fi.Erasure.Index = index + 1
+			tmpEntry := mustGetUUID()
+			if err := disks[index].WriteMetadata(minioMetaTmpBucket, tmpEntry, fi); err != nil {
+				return err
+			}
+			return disks[index].RenameData(minioMetaTmpBucket, tmpEntry, "", dstBucket, dstEntry)
+		}, index)
+	}
+	for _, err := range g.Wait() {
+		if err != nil && err != errMetadataConflict {
+			logger.LogIf(ctx, fmt.Errorf("Unable to undo the metadata update of %s/%s: %w", dstBucket, dstEntry, err))
+		}
+	}
+	return evalDisks(disks, errs), errMetadataConflict
+}
+
+// renameAllFileInfo - renames the metadata on each disk concurrently,
+// returns the error of each disk.
+func renameAllFileInfo(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string) []error {
 	ignoredErr := []error{errFileNotFound}
 
 	g := errgroup.WithNErrs(len(disks))
@@ -278,12 +407,7 @@ func renameFileInfo(ctx context.Context, disks []StorageAPI, srcBucket, srcEntry
 	}
 
 	// Wait for all renames to finish.
-	errs := g.Wait()
-
-	// We can safely allow RenameData errors up to len(er.getDisks()) - writeQuorum
-	// otherwise return failure. Cleanup successful renames.
-	err := reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, quorum)
-	return evalDisks(disks, errs), err
+	return g.Wait()
 }
 
 // writeUniqueFileInfo - writes unique `xl.meta` content for each disk concurrently.
@@ -69,6 +69,70 @@ func (er erasureObjects) removeObjectPart(bucket, object, uploadID, dataDir stri
 	g.Wait()
 }
 
+// listUploadIDs - returns the upload IDs of an object found on any of the
+// disks, the upload directories missing on some disks are listed as well.
+func (er erasureObjects) listUploadIDs(ctx context.Context, bucket, object string) ([]string, error) {
+	disks := er.getDisks()
+	entries := make([][]string, len(disks))
+
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() (err error) {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			entries[index], err = disks[index].ListDir(minioMetaMultipartBucket, er.getMultipartSHADir(bucket, object), -1)
+			return err
+		}, index)
+	}
+
+	uploadIDs := set.NewStringSet()
+	var listed bool
+	for index, err := range g.Wait() {
+		switch err {
+		case nil:
+			listed = true
+		case errFileNotFound:
+			listed = true
+			continue
+		case errDiskNotFound:
+			continue
+		default:
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, entry := range entries[index] {
+			uploadIDs.Add(strings.TrimSuffix(entry, SlashSeparator))
+		}
+	}
+	if !listed {
+		return nil, errDiskNotFound
+	}
+	return uploadIDs.ToSlice(), nil
+}
+
+// readUploadFileInfo - returns the metadata of an upload, read from one
+// disk and from the quorum of the disks when the copy of the disk is
+// missing or corrupted.
+func (er erasureObjects) readUploadFileInfo(ctx context.Context, bucket, object, uploadID string) (FileInfo, error) {
+	uploadIDDir := er.getUploadIDDir(bucket, object, uploadID)
+	for _, disk := range er.getLoadBalancedDisks() {
+		if disk == nil {
+			continue
+		}
+		fi, err := disk.ReadVersion(minioMetaMultipartBucket, uploadIDDir, "")
+		if err == nil {
+			return fi, nil
+		}
+		if err != errDiskNotFound {
+			break
+		}
+	}
+	fi, _, _, err := er.getObjectFileInfo(ctx, minioMetaMultipartBucket, uploadIDDir, ObjectOptions{})
+	return fi, err
+}
+
 // ListMultipartUploads - lists all the pending multipart
 // uploads for a particular object in a bucket.
 //
@@ -82,59 +146,30 @@ func (er erasureObjects) ListMultipartUploads(ctx context.Context, bucket, objec
 	result.Prefix = object
 	result.Delimiter = delimiter
 
-	var uploadIDs []string
-	for _, disk := range er.getLoadBalancedDisks() {
-		if disk == nil {
-			continue
-		}
-		uploadIDs, err = disk.ListDir(minioMetaMultipartBucket, er.getMultipartSHADir(bucket, object), -1)
-		if err != nil {
-			if err == errDiskNotFound {
-				continue
-			}
-			if err == errFileNotFound {
-				return result, nil
-			}
-			logger.LogIf(ctx, err)
-			return result, toObjectErr(err, bucket, object)
-		}
-		break
-	}
-
-	for i := range uploadIDs {
-		uploadIDs[i] = strings.TrimSuffix(uploadIDs[i], SlashSeparator)
+	uploadIDs, err := er.listUploadIDs(ctx, bucket, object)
+	if err != nil {
+		return result, toObjectErr(err, bucket, object)
 	}
 
 	// S3 spec says uploadIDs should be sorted based on initiated time, we need
 	// to read the metadata entry.
 	var uploads []MultipartInfo
-
-	populatedUploadIds := set.NewStringSet()
-
-retry:
-	for _, disk := range er.getLoadBalancedDisks() {
-		if disk == nil {
-			continue
-		}
-		for _, uploadID := range uploadIDs {
-			if populatedUploadIds.Contains(uploadID) {
+	for _, uploadID := range uploadIDs {
+		fi, err := er.readUploadFileInfo(ctx, bucket, object, uploadID)
+		if err != nil {
+			switch toObjectErr(err, minioMetaMultipartBucket, er.getUploadIDDir(bucket, object, uploadID)).(type) {
+			case ObjectNotFound, InsufficientReadQuorum:
+				// Leftovers of uploads which did not reach quorum,
+				// or were aborted meanwhile, are not listed.
 				continue
 			}
-			fi, err := disk.ReadVersion(minioMetaMultipartBucket, pathJoin(er.getUploadIDDir(bucket, object, uploadID)), "")
-			if err != nil {
-				if err == errDiskNotFound || err == errFileNotFound {
-					goto retry
-				}
-				return result, toObjectErr(err, bucket, object)
-			}
-			populatedUploadIds.Add(uploadID)
-			uploads = append(uploads, MultipartInfo{
-				Object:    object,
-				UploadID:  uploadID,
-				Initiated: fi.ModTime,
-			})
+			return result, toObjectErr(err, bucket, object)
 		}
-		break
+		uploads = append(uploads, MultipartInfo{
+			Object:    object,
+			UploadID:  uploadID,
+			Initiated: fi.ModTime,
+		})
 	}
 
 	sort.Slice(uploads, func(i int, j int) bool {
@@ -177,11 +212,10 @@ retry:
 // newMultipartUpload - wrapper for initializing a new multipart
 // request; returns a unique upload id.
 //
-// Internally this function creates 'uploads.json' associated for the
-// incoming object at
-// '.minio.sys/multipart/bucket/object/uploads.json' on all the
-// disks. `uploads.json` carries metadata regarding on-going multipart
-// operation(s) on the object.
+// Internally this function creates the `xl.meta` of the upload at
+// '.minio.sys/multipart/<sha256(bucket/object)>/<uploadID>/' on all
+// the disks, the on-going multipart uploads of an object are the
+// upload directories found there.
 func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string, object string, opts ObjectOptions) (string, error) {
 
 	onlineDisks := er.getDisks()
@@ -336,7 +370,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	tmpPartPath := pathJoin(tmpPart, partSuffix)
 
 	// Delete the temporary object part. If PutObjectPart succeeds there would be nothing to delete.
-	defer er.deleteObject(ctx, minioMetaTmpBucket, tmpPart, writeQuorum)
+	defer er.deleteTmpObject(ctx, bucket, object, tmpPart, writeQuorum)
 
 	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
 	if err != nil {
@@ -598,6 +632,16 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 		return oi, toObjectErr(errFileParentIsFile, bucket, object)
 	}
 
+	if opts.CheckPrecondFn != nil {
+		curInfo, err := er.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return oi, err
+		}
+		if opts.CheckPrecondFn(curInfo) {
+			return oi, PreConditionFailed{}
+		}
+	}
+
 	defer ObjectPathUpdated(path.Join(bucket, object))
 
 	// Calculate s3 compatible md5sum for complete multipart.
@@ -687,12 +731,17 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 
 		// Add incoming parts.
 		fi.Parts[i] = ObjectPartInfo{
+			ETag:       part.ETag,
 			Number:     part.PartNumber,
 			Size:       currentFI.Parts[partIdx].Size,
 			ActualSize: currentFI.Parts[partIdx].ActualSize,
 		}
 	}
 
+	if isMaxObjectSize(objectActualSize) {
+		return oi, ObjectTooLarge{Bucket: bucket, Object: object}
+	}
+
 	// Save the final object size and modtime.
 	fi.Size = objectSize
 	fi.ModTime = opts.MTime
@@ -705,6 +754,7 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 
 	// Save the consolidated actual size.
 	fi.Metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+	fi.Metadata[metaGenerationKey] = newMetaGeneration()
 
 	// Update all erasure metadata, make sure to not modify fields like
 	// checksum which are different on each disks.
@@ -789,6 +839,15 @@ func (er erasureObjects) AbortMultipartUpload(ctx context.Context, bucket, objec
 		return toObjectErr(err, bucket, object, uploadID)
 	}
 
+	// The data of the uploaded parts is erased first
+	// when required for the bucket.
+	if isSecureEraseEnabled(bucket) {
+		errs = er.secureErase(ctx, minioMetaMultipartBucket, uploadIDPath, FileInfo{Volume: bucket, Name: object})
+		if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+			return toObjectErr(err, bucket, object, uploadID)
+		}
+	}
+
 	// Cleanup all uploaded parts.
 	if err = er.deleteObject(ctx, minioMetaMultipartBucket, uploadIDPath, writeQuorum); err != nil {
 		return toObjectErr(err, bucket, object, uploadID)
@@ -25,6 +25,7 @@ import (
 	"path"
 	"sync"
 
+	"github.com/klauspost/readahead"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -32,6 +33,12 @@ import (
 	"github.com/minio/minio/pkg/sync/errgroup"
 )
 
+const (
+	// Number of erasure blocks decoded ahead of the client
+	// for sequential whole object reads.
+	getObjectReadAheadBlocks = 2
+)
+
 // list all errors which can be ignored in object operations.
 var objectOpIgnoredErrs = append(baseIgnoredErrs, errDiskAccessDenied)
 
@@ -75,7 +82,7 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 	// Read metadata associated with the object from all disks.
 	storageDisks := er.getDisks()
 
-	metaArr, errs := readAllFileInfo(ctx, storageDisks, srcBucket, srcObject, srcOpts.VersionID)
+	metaArr, errs := readAllFileInfo(ctx, storageDisks, srcBucket, encodeDirObject(srcObject), srcOpts.VersionID)
 
 	// get Quorum for this object
 	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, er, metaArr, errs)
@@ -99,11 +106,22 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 		return fi.ToObjectInfo(srcBucket, srcObject), toObjectErr(errMethodNotAllowed, srcBucket, srcObject)
 	}
 
+	if srcOpts.CheckPrecondFn != nil && srcOpts.CheckPrecondFn(fi.ToObjectInfo(srcBucket, srcObject)) {
+		return oi, PreConditionFailed{}
+	}
+
 	// Update `xl.meta` content on each disks.
+	orig := copyFileInfos(metaArr)
+	generations := metaGenerations(metaArr)
 	for index := range metaArr {
-		metaArr[index].Metadata = srcInfo.UserDefined
-		metaArr[index].Metadata["etag"] = srcInfo.ETag
+		metadata := make(map[string]string, len(srcInfo.UserDefined)+1)
+		for k, v := range srcInfo.UserDefined {
+			metadata[k] = v
+		}
+		metadata["etag"] = srcInfo.ETag
+		metaArr[index].Metadata = metadata
 	}
+	setNextMetaGenerations(metaArr, generations)
 
 	tempObj := mustGetUUID()
 
@@ -116,7 +134,7 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 	}
 
 	// Rename atomically `xl.meta` from tmp location to destination for each disk.
-	if _, err = renameFileInfo(ctx, onlineDisks, minioMetaTmpBucket, tempObj, srcBucket, srcObject, writeQuorum); err != nil {
+	if _, err = renameFileInfoUpdate(ctx, onlineDisks, minioMetaTmpBucket, tempObj, srcBucket, encodeDirObject(srcObject), metaArr, orig, writeQuorum); err != nil {
 		return oi, toObjectErr(err, srcBucket, srcObject)
 	}
 
@@ -134,7 +152,7 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 	// returns no bytes.
 	if HasSuffix(object, SlashSeparator) {
 		var objInfo ObjectInfo
-		if objInfo, err = er.getObjectInfoDir(ctx, bucket, object); err != nil {
+		if objInfo, err = er.getObjectInfoDir(ctx, bucket, object, opts); err != nil {
 			return nil, toObjectErr(err, bucket, object)
 		}
 		return NewGetObjectReaderFromReader(bytes.NewBuffer(nil), objInfo, opts)
@@ -158,7 +176,12 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 		}, toObjectErr(errMethodNotAllowed, bucket, object)
 	}
 
-	fn, off, length, nErr := NewGetObjectReader(rs, objInfo, opts)
+	// The data of transitioned objects is read from the remote tier.
+	if objInfo.TransitionStatus != "" {
+		return getTransitionedObjectReader(ctx, bucket, object, rs, h, objInfo, opts)
+	}
+
+	fn, off, length, nErr := NewGetObjectReader(ctx, rs, objInfo, opts)
 	if nErr != nil {
 		return nil, nErr
 	}
@@ -169,11 +192,24 @@ func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object stri
 		pw.CloseWithError(err)
 	}()
 
+	// Sequential whole object reads spanning several erasure blocks
+	// prefetch the next blocks while the current ones are being
+	// written to the client.
+	var rc io.ReadCloser = pr
+	if rs == nil && fi.Erasure.BlockSize > 0 && length > getObjectReadAheadBlocks*fi.Erasure.BlockSize {
+		rah, err := readahead.NewReadCloserSize(pr, getObjectReadAheadBlocks, int(fi.Erasure.BlockSize))
+		if err != nil {
+			pr.Close()
+			return nil, err
+		}
+		rc = rah
+	}
+
 	// Cleanup function to cause the go routine above to exit, in
 	// case of incomplete read.
-	pipeCloser := func() { pr.Close() }
+	pipeCloser := func() { rc.Close() }
 
-	return fn(pr, h, opts.CheckCopyPrecondFn, pipeCloser)
+	return fn(rc, h, opts.CheckCopyPrecondFn, pipeCloser)
 }
 
 // GetObject - reads an object erasured coded across multiple
@@ -278,7 +314,7 @@ func (er erasureObjects) getObjectWithFileInfo(ctx context.Context, bucket, obje
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partNumber)
 			partPath := pathJoin(object, metaArr[index].DataDir, fmt.Sprintf("part.%d", partNumber))
-			readers[index] = newBitrotReader(disk, bucket, partPath, tillOffset,
+			readers[index] = newBitrotReader(ctx, disk, bucket, partPath, tillOffset,
 				checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 
 			// Prefer local disks
@@ -335,7 +371,14 @@ func (er erasureObjects) getObject(ctx context.Context, bucket, object string, s
 }
 
 // getObjectInfoDir - This getObjectInfo is specific to object directory lookup.
-func (er erasureObjects) getObjectInfoDir(ctx context.Context, bucket, object string) (ObjectInfo, error) {
+func (er erasureObjects) getObjectInfoDir(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	// Read the directory object stored inside the directory.
+	objInfo, err := er.getObjectInfo(ctx, bucket, object, opts)
+	if !isErrObjectNotFound(err) || objInfo.DeleteMarker {
+		return objInfo, err
+	}
+
+	// Empty directories are the directory objects of older releases.
 	storageDisks := er.getDisks()
 
 	g := errgroup.WithNErrs(len(storageDisks))
@@ -361,7 +404,7 @@ func (er erasureObjects) getObjectInfoDir(ctx context.Context, bucket, object st
 	}
 
 	readQuorum := getReadQuorum(len(storageDisks))
-	err := reduceReadQuorumErrs(ctx, g.Wait(), objectOpIgnoredErrs, readQuorum)
+	err = reduceReadQuorumErrs(ctx, g.Wait(), objectOpIgnoredErrs, readQuorum)
 	return dirObjectInfo(bucket, object, 0, map[string]string{}), err
 }
 
@@ -372,7 +415,7 @@ func (er erasureObjects) GetObjectInfo(ctx context.Context, bucket, object strin
 	}
 
 	if HasSuffix(object, SlashSeparator) {
-		info, err = er.getObjectInfoDir(ctx, bucket, object)
+		info, err = er.getObjectInfoDir(ctx, bucket, object, opts)
 		if err != nil {
 			return info, toObjectErr(err, bucket, object)
 		}
@@ -386,7 +429,7 @@ func (er erasureObjects) getObjectFileInfo(ctx context.Context, bucket, object s
 	disks := er.getDisks()
 
 	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllFileInfo(ctx, disks, bucket, object, opts.VersionID)
+	metaArr, errs := readAllFileInfo(ctx, disks, bucket, encodeDirObject(object), opts.VersionID)
 
 	readQuorum, _, err := objectQuorumFromMeta(ctx, er, metaArr, errs)
 	if err != nil {
@@ -559,6 +602,16 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 
 	data := r.Reader
 
+	if opts.CheckPrecondFn != nil {
+		oi, err := er.getObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: opts.VersionID})
+		if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+			return ObjectInfo{}, err
+		}
+		if opts.CheckPrecondFn(oi) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+	}
+
 	uniqueID := mustGetUUID()
 	tempObj := uniqueID
 	// No metadata is set, allocate a new one.
@@ -585,24 +638,14 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	// Delete temporary object in the event of failure.
 	// If PutObject succeeded there would be no temporary
 	// object to delete.
-	defer er.deleteObject(ctx, minioMetaTmpBucket, tempObj, writeQuorum)
+	defer er.deleteTmpObject(ctx, bucket, object, tempObj, writeQuorum)
 
 	// This is a special case with size as '0' and object ends with
-	// a slash separator, we treat it like a valid operation and
-	// return success.
-	if isObjectDir(object, data.Size()) {
-		// Check if an object is present as one of the parent dir.
-		// -- FIXME. (needs a new kind of lock).
-		// -- FIXME (this also causes performance issue when disks are down).
-		if er.parentDirIsObject(ctx, bucket, path.Dir(object)) {
-			return ObjectInfo{}, toObjectErr(errFileParentIsFile, bucket, object)
-		}
-
-		if err = er.putObjectDir(ctx, bucket, object, writeQuorum); err != nil {
-			return ObjectInfo{}, toObjectErr(err, bucket, object)
-		}
-
-		return dirObjectInfo(bucket, object, data.Size(), opts.UserDefined), nil
+	// a slash separator, the directory object is stored inside the
+	// directory so that it outlives the objects created under it.
+	dirObject := isObjectDir(object, data.Size())
+	if dirObject {
+		object = encodeDirObject(object)
 	}
 
 	// Validate input data size and it can never be less than zero.
@@ -699,11 +742,16 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	}
 
 	opts.UserDefined["etag"] = r.MD5CurrentHexString()
+	if dirObject {
+		// For directories etag is d41d8cd98f00b204e9800998ecf8427e
+		opts.UserDefined["etag"] = emptyETag
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
 		opts.UserDefined["content-type"] = mimedb.TypeByExtension(path.Ext(object))
 	}
+	opts.UserDefined[metaGenerationKey] = newMetaGeneration()
 
 	modTime := opts.MTime
 	if opts.MTime.IsZero() {
@@ -843,12 +891,13 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 		writeQuorums[i] = getWriteQuorum(len(storageDisks))
 	}
 
+	secureErase := isSecureEraseEnabled(bucket)
 	versions := make([]FileInfo, len(objects))
 	for i := range objects {
 		if objects[i].VersionID == "" {
-			if opts.Versioned && !HasSuffix(objects[i].ObjectName, SlashSeparator) {
+			if opts.Versioned {
 				versions[i] = FileInfo{
-					Name:      objects[i].ObjectName,
+					Name:      encodeDirObject(objects[i].ObjectName),
 					VersionID: mustGetUUID(),
 					ModTime:   UTCNow(),
 					Deleted:   true, // delete marker
@@ -857,8 +906,9 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 			}
 		}
 		versions[i] = FileInfo{
-			Name:      objects[i].ObjectName,
-			VersionID: objects[i].VersionID,
+			Name:        encodeDirObject(objects[i].ObjectName),
+			VersionID:   objects[i].VersionID,
+			SecureErase: secureErase,
 		}
 	}
 
@@ -904,17 +954,24 @@ func (er erasureObjects) DeleteObjects(ctx context.Context, bucket string, objec
 				dobjects[objIndex] = DeletedObject{
 					DeleteMarker:          versions[objIndex].Deleted,
 					DeleteMarkerVersionID: versions[objIndex].VersionID,
-					ObjectName:            versions[objIndex].Name,
+					ObjectName:            objects[objIndex].ObjectName,
 				}
 			} else {
 				dobjects[objIndex] = DeletedObject{
-					ObjectName: versions[objIndex].Name,
+					ObjectName: objects[objIndex].ObjectName,
 					VersionID:  versions[objIndex].VersionID,
 				}
 			}
 		}
 	}
 
+	// Delete the empty directories created as directory objects by older releases.
+	for i := range objects {
+		if errs[i] == nil && !versions[i].Deleted && objects[i].VersionID == "" && HasSuffix(objects[i].ObjectName, SlashSeparator) {
+			er.deleteObjectVersion(ctx, bucket, objects[i].ObjectName, writeQuorums[i], FileInfo{Name: objects[i].ObjectName})
+		}
+	}
+
 	// Check failed deletes across multiple objects
 	for _, version := range versions {
 		// Check if there is any offline disk and add it to the MRF list
@@ -943,6 +1000,26 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 		return objInfo, err
 	}
 
+	if HasSuffix(object, SlashSeparator) {
+		// Delete the directory object stored inside the directory,
+		// or the empty directory created by older releases.
+		objInfo, err = er.DeleteObject(ctx, bucket, encodeDirObject(object), opts)
+		if err == nil || !isErrObjectNotFound(err) {
+			objInfo.Name = object
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+	}
+
+	if opts.CheckPrecondFn != nil {
+		oi, err := er.getObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: opts.VersionID})
+		if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) && !isErrMethodNotAllowed(err) {
+			return objInfo, err
+		}
+		if opts.CheckPrecondFn(oi) {
+			return objInfo, PreConditionFailed{}
+		}
+	}
+
 	storageDisks := er.getDisks()
 	writeQuorum := len(storageDisks)/2 + 1
 
@@ -964,8 +1041,9 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 
 	// Delete the object version on all disks.
 	if err = er.deleteObjectVersion(ctx, bucket, object, writeQuorum, FileInfo{
-		Name:      object,
-		VersionID: opts.VersionID,
+		Name:        object,
+		VersionID:   opts.VersionID,
+		SecureErase: isSecureEraseEnabled(bucket),
 	}); err != nil {
 		return objInfo, toObjectErr(err, bucket, object)
 	}
@@ -994,7 +1072,7 @@ func (er erasureObjects) PutObjectTags(ctx context.Context, bucket, object strin
 	disks := er.getDisks()
 
 	// Read metadata associated with the object from all disks.
-	metaArr, errs := readAllFileInfo(ctx, disks, bucket, object, opts.VersionID)
+	metaArr, errs := readAllFileInfo(ctx, disks, bucket, encodeDirObject(object), opts.VersionID)
 
 	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, er, metaArr, errs)
 	if err != nil {
@@ -1017,6 +1095,7 @@ func (er erasureObjects) PutObjectTags(ctx context.Context, bucket, object strin
 		return toObjectErr(errMethodNotAllowed, bucket, object)
 	}
 
+	orig := copyFileInfos(metaArr)
 	for i, fi := range metaArr {
 		if errs[i] != nil {
 			// Avoid disks where loading metadata fail
@@ -1031,6 +1110,7 @@ func (er erasureObjects) PutObjectTags(ctx context.Context, bucket, object strin
 		}
 		metaArr[i].Metadata = fi.Metadata
 	}
+	setNextMetaGenerations(metaArr, metaGenerations(metaArr))
 
 	tempObj := mustGetUUID()
 
@@ -1040,7 +1120,7 @@ func (er erasureObjects) PutObjectTags(ctx context.Context, bucket, object strin
 	}
 
 	// Atomically rename metadata from tmp location to destination for each disk.
-	if _, err = renameFileInfo(ctx, disks, minioMetaTmpBucket, tempObj, bucket, object, writeQuorum); err != nil {
+	if _, err = renameFileInfoUpdate(ctx, disks, minioMetaTmpBucket, tempObj, bucket, encodeDirObject(object), metaArr, orig, writeQuorum); err != nil {
 		return toObjectErr(err, bucket, object)
 	}
 
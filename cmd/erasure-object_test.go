@@ -19,12 +19,14 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"io"
 	"io/ioutil"
 	"os"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 func TestRepeatPutObjectPart(t *testing.T) {
@@ -320,6 +322,57 @@ func TestGetObjectNoQuorum(t *testing.T) {
 	}
 }
 
+// Tests sequential whole object reads through the readahead pipeline,
+// including closing the reader before the object is fully consumed.
+func TestGetObjectReadAhead(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Create an instance of xl backend.
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cleanup backend directories.
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	object := "object"
+	opts := ObjectOptions{}
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), int(3*blockSizeV1+humanize.KiByte))
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("Expected %d bytes of object data, got %d", len(data), len(got))
+	}
+
+	// Partial read followed by an early close must not block.
+	gr, err = obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = io.ReadFull(gr, make([]byte, humanize.KiByte)); err != nil {
+		t.Fatal(err)
+	}
+	gr.Close()
+}
+
 func TestPutObjectNoQuorum(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -546,3 +599,209 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 		})
 	}
 }
+
+// Tests metadata updates read before a concurrent update fail.
+func TestPutObjectTagsMetadataConflict(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureZones)
+	xl := z.zones[0].sets[0]
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disks := xl.getDisks()
+	metaArr, errs := readAllFileInfo(ctx, disks, "bucket", "object", "")
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	setNextMetaGenerations(metaArr, metaGenerations(metaArr))
+
+	// Concurrent update of the metadata.
+	if err = xl.PutObjectTags(ctx, "bucket", "object", "key=value", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tempObj := mustGetUUID()
+	if _, err = writeUniqueFileInfo(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, len(disks)/2+1); err != nil {
+		t.Fatal(err)
+	}
+	_, err = renameFileInfo(ctx, disks, minioMetaTmpBucket, tempObj, "bucket", "object", len(disks)/2+1)
+	if err != errMetadataConflict {
+		t.Fatalf("expected %v, got %v", errMetadataConflict, err)
+	}
+	if _, ok := toObjectErr(err, "bucket", "object").(ObjectMetadataConflict); !ok {
+		t.Fatalf("expected ObjectMetadataConflict, got %v", toObjectErr(err, "bucket", "object"))
+	}
+
+	// Updates of the latest generation succeed.
+	if err = xl.PutObjectTags(ctx, "bucket", "object", "key=other", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	oi, err := xl.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.UserTags != "key=other" {
+		t.Fatalf("expected tags key=other, got %s", oi.UserTags)
+	}
+	if generation := oi.UserDefined[metaGenerationKey]; generation == "" || generation == metaArr[0].Metadata[metaGenerationKey] {
+		t.Fatalf("expected a new generation, got %q", generation)
+	}
+
+	// Updates read before an overwrite of the version fail, even
+	// when the version was read before any metadata update.
+	_, err = obj.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	metaArr, _ = readAllFileInfo(ctx, disks, "bucket", "object", "")
+	setNextMetaGenerations(metaArr, metaGenerations(metaArr))
+
+	_, err = obj.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader([]byte("efgh")), int64(len("efgh")), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tempObj = mustGetUUID()
+	if _, err = writeUniqueFileInfo(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, len(disks)/2+1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = renameFileInfo(ctx, disks, minioMetaTmpBucket, tempObj, "bucket", "object", len(disks)/2+1); err != errMetadataConflict {
+		t.Fatalf("expected %v after an overwrite, got %v", errMetadataConflict, err)
+	}
+}
+
+// Tests metadata updates conflicting on some disks only are undone
+// on the other disks.
+func TestPutObjectTagsMetadataConflictSomeDisks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureZones)
+	xl := z.zones[0].sets[0]
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = obj.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disks := xl.getDisks()
+	metaArr, errs := readAllFileInfo(ctx, disks, "bucket", "object", "")
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig := copyFileInfos(metaArr)
+	for i := range metaArr {
+		metaArr[i].Metadata[xhttp.AmzObjectTagging] = "key=value"
+	}
+	setNextMetaGenerations(metaArr, metaGenerations(metaArr))
+
+	// A concurrent write of the metadata reached two disks only.
+	for _, disk := range disks[:2] {
+		fi, err := disk.ReadVersion("bucket", "object", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fi.Metadata[metaGenerationKey] = newMetaGeneration()
+		if err = disk.WriteMetadata("bucket", "object", fi); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tempObj := mustGetUUID()
+	if _, err = writeUniqueFileInfo(ctx, disks, minioMetaTmpBucket, tempObj, metaArr, len(disks)/2+1); err != nil {
+		t.Fatal(err)
+	}
+	_, err = renameFileInfoUpdate(ctx, disks, minioMetaTmpBucket, tempObj, "bucket", "object", metaArr, orig, len(disks)/2+1)
+	if err != errMetadataConflict {
+		t.Fatalf("expected %v, got %v", errMetadataConflict, err)
+	}
+
+	// The disks not written concurrently are back to the metadata
+	// they had before the update.
+	for i, disk := range disks[2:] {
+		fi, err := disk.ReadVersion("bucket", "object", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := fi.Metadata[xhttp.AmzObjectTagging]; ok {
+			t.Fatalf("disk %d: expected the update to be undone, got tags %s", i+2, fi.Metadata[xhttp.AmzObjectTagging])
+		}
+		if fi.Metadata[metaGenerationKey] != orig[i+2].Metadata[metaGenerationKey] {
+			t.Fatalf("disk %d: expected generation %s, got %s", i+2, orig[i+2].Metadata[metaGenerationKey], fi.Metadata[metaGenerationKey])
+		}
+	}
+}
+
+// Tests listing the uploads whose metadata is missing or corrupted on
+// some disks.
+func TestListMultipartUploadsDamagedMetadata(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureZones)
+	xl := z.zones[0].sets[0]
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := obj.NewMultipartUpload(ctx, "bucket", "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shaDir := xl.getMultipartSHADir("bucket", "object")
+	// Missing metadata on the first disk, truncated on the second one.
+	if err = os.Remove(pathJoin(fsDirs[0], minioMetaMultipartBucket, shaDir, uploadID, xlStorageFormatFile)); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Truncate(pathJoin(fsDirs[1], minioMetaMultipartBucket, shaDir, uploadID, xlStorageFormatFile), 10); err != nil {
+		t.Fatal(err)
+	}
+	// Leftover of an upload which did not reach quorum.
+	if err = os.MkdirAll(pathJoin(fsDirs[2], minioMetaMultipartBucket, shaDir, mustGetUUID()), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 10; i++ {
+		result, err := xl.ListMultipartUploads(ctx, "bucket", "object", "", "", "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Uploads) != 1 || result.Uploads[0].UploadID != uploadID {
+			t.Fatalf("expected upload %s, got %v", uploadID, result.Uploads)
+		}
+	}
+}
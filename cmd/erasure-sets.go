@@ -53,6 +53,10 @@ type erasureSets struct {
 
 	sets []*erasureObjects
 
+	// bucketCache is shared by the sets of the zone, a bucket
+	// may exist on a zone and not yet on another.
+	bucketCache *bucketInfoCache
+
 	// Reference format.
 	format *formatErasureV3
 
@@ -76,6 +80,9 @@ type erasureSets struct {
 	// Total number of sets and the number of disks per set.
 	setCount, drivesPerSet int
 
+	// Largest number of disks of a set in a single failure domain.
+	failureDomainDrives int
+
 	disksConnectEvent chan diskConnectInfo
 
 	// Done channel to control monitoring loop.
@@ -290,6 +297,10 @@ func newErasureSets(ctx context.Context, endpoints Endpoints, storageDisks []Sto
 	drivesPerSet := len(format.Erasure.Sets[0])
 
 	endpointStrings := make([]string, len(endpoints))
+	hosts := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		hosts[i] = endpoint.Host
+	}
 	// Initialize the erasure sets instance.
 	s := &erasureSets{
 		sets:               make([]*erasureObjects, setCount),
@@ -308,6 +319,9 @@ func newErasureSets(ctx context.Context, endpoints Endpoints, storageDisks []Sto
 		poolSplunk:         NewMergeWalkPool(globalMergeLookupTimeout),
 		poolVersions:       NewMergeWalkVersionsPool(globalMergeLookupTimeout),
 		mrfOperations:      make(map[healSource]int),
+		bucketCache:        &bucketInfoCache{},
+
+		failureDomainDrives: failureDomainDrives(hosts, drivesPerSet),
 	}
 
 	mutex := newNSLock(globalIsDistErasure)
@@ -352,6 +366,7 @@ func newErasureSets(ctx context.Context, endpoints Endpoints, storageDisks []Sto
 			nsMutex:      mutex,
 			bp:           bp,
 			mrfOpCh:      make(chan partialOperation, 10000),
+			bucketCache:  s.bucketCache,
 		}
 	}
 
@@ -441,6 +456,9 @@ func (s *erasureSets) StorageInfo(ctx context.Context, local bool) (StorageInfo,
 	}
 
 	scParity := globalStorageClass.GetParityForSC(storageclass.STANDARD)
+	if scParity == 0 {
+		scParity = globalErasureFormatParity
+	}
 	if scParity == 0 {
 		scParity = s.drivesPerSet / 2
 	}
@@ -451,6 +469,9 @@ func (s *erasureSets) StorageInfo(ctx context.Context, local bool) (StorageInfo,
 	storageInfo.Backend.RRSCData = s.drivesPerSet - rrSCParity
 	storageInfo.Backend.RRSCParity = rrSCParity
 
+	storageInfo.Backend.FormatParity = s.format.Erasure.Parity
+	storageInfo.Backend.FailureDomainDrives = s.failureDomainDrives
+
 	if local {
 		// if local is true, we are not interested in the drive UUID info.
 		// this is called primarily by prometheus
@@ -553,7 +574,8 @@ func hashKey(algo string, key string, cardinality int, id [16]byte) int {
 
 // Returns always a same erasure coded set for a given input.
 func (s *erasureSets) getHashedSetIndex(input string) int {
-	return hashKey(s.distributionAlgo, input, len(s.sets), s.deploymentID)
+	// The directory object is stored in the set of the directory.
+	return hashKey(s.distributionAlgo, decodeDirObject(input), len(s.sets), s.deploymentID)
 }
 
 // Returns always a same erasure coded set for a given input.
@@ -561,6 +583,12 @@ func (s *erasureSets) getHashedSet(input string) (set *erasureObjects) {
 	return s.sets[s.getHashedSetIndex(input)]
 }
 
+// getHashedSetWithIOClass - returns the set of the input whose remote
+// drives send the I/O class of the context with their requests.
+func (s *erasureSets) getHashedSetWithIOClass(ctx context.Context, input string) *erasureObjects {
+	return s.getHashedSet(input).withIOClass(ioClassFromContext(ctx, ioClassForeground))
+}
+
 // GetBucketInfo - returns bucket info from one of the erasure coded set.
 func (s *erasureSets) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
 	return s.getHashedSet("").GetBucketInfo(ctx, bucket)
@@ -656,29 +684,33 @@ func (s *erasureSets) ListBuckets(ctx context.Context) (buckets []BucketInfo, er
 
 // GetObjectNInfo - returns object info and locked object ReadCloser
 func (s *erasureSets) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
-	return s.getHashedSet(object).GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+	return s.getHashedSetWithIOClass(ctx, object).GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
 }
 
 // GetObject - reads an object from the hashedSet based on the object name.
 func (s *erasureSets) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) error {
-	return s.getHashedSet(object).GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
+	return s.getHashedSetWithIOClass(ctx, object).GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
 }
 
 // PutObject - writes an object to hashedSet based on the object name.
 func (s *erasureSets) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).PutObject(ctx, bucket, object, data, opts)
+	return s.getHashedSetWithIOClass(ctx, object).PutObject(ctx, bucket, object, data, opts)
 }
 
 // GetObjectInfo - reads object metadata from the hashedSet based on the object name.
 func (s *erasureSets) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).GetObjectInfo(ctx, bucket, object, opts)
+	return s.getHashedSetWithIOClass(ctx, object).GetObjectInfo(ctx, bucket, object, opts)
 }
 
 // DeleteObject - deletes an object from the hashedSet based on the object name.
 func (s *erasureSets) DeleteObject(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).DeleteObject(ctx, bucket, object, opts)
+	return s.getHashedSetWithIOClass(ctx, object).DeleteObject(ctx, bucket, object, opts)
 }
 
+// deleteObjectsMaxSets bounds the sets deleting the objects of a bulk
+// delete at once, every set sends a bulk delete to each of its drives.
+const deleteObjectsMaxSets = 16
+
 // DeleteObjects - bulk delete of objects
 // Bulk delete is only possible within one set. For that purpose
 // objects are group by set first, and then bulk delete is invoked
@@ -719,27 +751,39 @@ func (s *erasureSets) DeleteObjects(ctx context.Context, bucket string, objects
 	}
 
 	// Invoke bulk delete on objects per set and save
-	// the result of the delete operation
-	for _, objsGroup := range objSetMap {
-		dobjects, errs := s.getHashedSet(objsGroup[0].object.ObjectName).DeleteObjects(ctx, bucket, toNames(objsGroup), opts)
-		for i, obj := range objsGroup {
-			delErrs[obj.origIndex] = errs[i]
-			if delErrs[obj.origIndex] == nil {
-				delObjects[obj.origIndex] = dobjects[i]
+	// the result of the delete operation, the sets
+	// delete their objects in parallel.
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, deleteObjectsMaxSets)
+	for setIndex, objsGroup := range objSetMap {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(set *erasureObjects, objsGroup []delObj) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			dobjects, errs := set.DeleteObjects(ctx, bucket, toNames(objsGroup), opts)
+			for i, obj := range objsGroup {
+				delErrs[obj.origIndex] = errs[i]
+				if delErrs[obj.origIndex] == nil {
+					delObjects[obj.origIndex] = dobjects[i]
+				}
 			}
-		}
+		}(s.sets[setIndex].withIOClass(ioClassFromContext(ctx, ioClassForeground)), objsGroup)
 	}
+	wg.Wait()
 
 	return delObjects, delErrs
 }
 
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
-	srcSet := s.getHashedSet(srcObject)
-	dstSet := s.getHashedSet(dstObject)
+	srcSet := s.getHashedSetWithIOClass(ctx, srcObject)
+	dstSet := s.getHashedSetWithIOClass(ctx, dstObject)
 
 	// Check if this request is only metadata update.
-	if srcSet == dstSet && srcInfo.metadataOnly {
+	if s.getHashedSetIndex(srcObject) == s.getHashedSetIndex(dstObject) && srcInfo.metadataOnly {
 		if dstOpts.VersionID != "" && srcOpts.VersionID == dstOpts.VersionID {
 			return srcSet.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		}
@@ -753,6 +797,7 @@ func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstB
 		UserDefined:          srcInfo.UserDefined,
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
+		CheckPrecondFn:       dstOpts.CheckPrecondFn,
 	}
 
 	return dstSet.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
@@ -1055,40 +1100,40 @@ func (s *erasureSets) ListMultipartUploads(ctx context.Context, bucket, prefix,
 
 // Initiate a new multipart upload on a hashedSet based on object name.
 func (s *erasureSets) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
-	return s.getHashedSet(object).NewMultipartUpload(ctx, bucket, object, opts)
+	return s.getHashedSetWithIOClass(ctx, object).NewMultipartUpload(ctx, bucket, object, opts)
 }
 
 // Copies a part of an object from source hashedSet to destination hashedSet.
 func (s *erasureSets) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int,
 	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (partInfo PartInfo, err error) {
-	destSet := s.getHashedSet(destObject)
+	destSet := s.getHashedSetWithIOClass(ctx, destObject)
 
 	return destSet.PutObjectPart(ctx, destBucket, destObject, uploadID, partID, NewPutObjReader(srcInfo.Reader, nil, nil), dstOpts)
 }
 
 // PutObjectPart - writes part of an object to hashedSet based on the object name.
 func (s *erasureSets) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (info PartInfo, err error) {
-	return s.getHashedSet(object).PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
+	return s.getHashedSetWithIOClass(ctx, object).PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
 }
 
 // GetMultipartInfo - return multipart metadata info uploaded at hashedSet.
 func (s *erasureSets) GetMultipartInfo(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (result MultipartInfo, err error) {
-	return s.getHashedSet(object).GetMultipartInfo(ctx, bucket, object, uploadID, opts)
+	return s.getHashedSetWithIOClass(ctx, object).GetMultipartInfo(ctx, bucket, object, uploadID, opts)
 }
 
 // ListObjectParts - lists all uploaded parts to an object in hashedSet.
 func (s *erasureSets) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
-	return s.getHashedSet(object).ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
+	return s.getHashedSetWithIOClass(ctx, object).ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
 }
 
 // Aborts an in-progress multipart operation on hashedSet based on the object name.
 func (s *erasureSets) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
-	return s.getHashedSet(object).AbortMultipartUpload(ctx, bucket, object, uploadID)
+	return s.getHashedSetWithIOClass(ctx, object).AbortMultipartUpload(ctx, bucket, object, uploadID)
 }
 
 // CompleteMultipartUpload - completes a pending multipart transaction, on hashedSet based on object name.
 func (s *erasureSets) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+	return s.getHashedSetWithIOClass(ctx, object).CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 }
 
 /*
@@ -1496,7 +1541,8 @@ func (s *erasureSets) HealBucket(ctx context.Context, bucket string, dryRun, rem
 
 // HealObject - heals inconsistent object on a hashedSet based on object name.
 func (s *erasureSets) HealObject(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts) (madmin.HealResultItem, error) {
-	return s.getHashedSet(object).HealObject(ctx, bucket, object, versionID, opts)
+	// Heals are background operations unless told otherwise.
+	return s.getHashedSet(object).withIOClass(ioClassFromContext(ctx, ioClassHeal)).HealObject(ctx, bucket, object, versionID, opts)
 }
 
 // Lists all buckets which need healing.
@@ -1617,17 +1663,17 @@ func (s *erasureSets) HealObjects(ctx context.Context, bucket, prefix string, op
 
 // PutObjectTags - replace or add tags to an existing object
 func (s *erasureSets) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
-	return s.getHashedSet(object).PutObjectTags(ctx, bucket, object, tags, opts)
+	return s.getHashedSetWithIOClass(ctx, object).PutObjectTags(ctx, bucket, object, tags, opts)
 }
 
 // DeleteObjectTags - delete object tags from an existing object
 func (s *erasureSets) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error {
-	return s.getHashedSet(object).DeleteObjectTags(ctx, bucket, object, opts)
+	return s.getHashedSetWithIOClass(ctx, object).DeleteObjectTags(ctx, bucket, object, opts)
 }
 
 // GetObjectTags - get object tags from an existing object
 func (s *erasureSets) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tags.Tags, error) {
-	return s.getHashedSet(object).GetObjectTags(ctx, bucket, object, opts)
+	return s.getHashedSetWithIOClass(ctx, object).GetObjectTags(ctx, bucket, object, opts)
 }
 
 // GetMetrics - no op
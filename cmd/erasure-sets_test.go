@@ -17,7 +17,9 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -243,3 +245,48 @@ func TestHashedLayer(t *testing.T) {
 		}
 	}
 }
+
+// Tests bulk deletes spanning more sets than are deleted at once.
+func TestErasureSetsDeleteObjectsParallel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sets []*erasureObjects
+	for i := 0; i < deleteObjectsMaxSets+4; i++ {
+		obj, fsDirs, err := prepareErasure(ctx, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(fsDirs)
+		sets = append(sets, obj.(*erasureZones).zones[0].sets[0])
+	}
+	s := &erasureSets{sets: sets, distributionAlgo: formatErasureVersionV3DistributionAlgo}
+
+	bucket := "bucket"
+	if err := s.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var objects []ObjectToDelete
+	for i := 0; i < 200; i++ {
+		object := fmt.Sprintf("dir%d/object%d", i%7, i)
+		_, err := s.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, ObjectToDelete{ObjectName: object})
+	}
+
+	deleted, errs := s.DeleteObjects(ctx, bucket, objects, ObjectOptions{})
+	for i, object := range objects {
+		if errs[i] != nil {
+			t.Fatalf("%s: %v", object.ObjectName, errs[i])
+		}
+		if deleted[i].ObjectName != object.ObjectName {
+			t.Fatalf("expected %s to be deleted, got %v", object.ObjectName, deleted[i])
+		}
+		if _, err := s.GetObjectInfo(ctx, bucket, object.ObjectName, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("expected %s to be deleted, got %v", object.ObjectName, err)
+		}
+	}
+}
@@ -0,0 +1,866 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+const (
+	// Decommission status of the zones, the zones listed are draining.
+	zoneDecommissionFile = minioConfigPrefix + "/decommission.json"
+
+	// Lock serializing the updates of the decommission status.
+	zoneDecommissionLock = minioConfigPrefix + "/decommission.lock"
+
+	// Number of objects moved between two checkpoints.
+	zoneDecommissionCheckpointInterval = 100
+)
+
+var (
+	errDecommissionInvalidZone  = errors.New("zone does not exist or is the last zone taking new objects")
+	errDecommissionInvalidDrive = errors.New("drive is not part of the server")
+	errDecommissionInProgress   = errors.New("zone decommission already in progress")
+	errDecommissionNotFound     = errors.New("zone is not being decommissioned")
+	errDecommissionCanceled     = errors.New("zone decommission canceled")
+)
+
+// Server metadata moved along with the objects of the buckets,
+// temporary files and multipart uploads stay where they are.
+var zoneDecommissionMetaPrefixes = []string{minioConfigPrefix + SlashSeparator, bucketMetaPrefix + SlashSeparator}
+
+// decommissionTarget - a zone being decommissioned, or the erasure set
+// of a zone holding a drive being drained.
+type decommissionTarget struct {
+	zone int
+	// set is -1 when the whole zone is decommissioned.
+	set int
+}
+
+// zonesDecommission - decommission state of the zones, draining zones
+// and sets take no new objects while their objects are moved to the
+// other zones.
+type zonesDecommission struct {
+	mu       sync.RWMutex
+	draining map[decommissionTarget]bool
+	jobs     map[decommissionTarget]*zoneDecommissionJob
+}
+
+// zoneDecommissionJob - a decommission running on this server.
+type zoneDecommissionJob struct {
+	mu     sync.Mutex
+	status madmin.DecommissionStatus
+	cancel context.CancelFunc
+}
+
+// Status - returns the progress of the job.
+func (j *zoneDecommissionJob) Status() madmin.DecommissionStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+func (j *zoneDecommissionJob) update(fn func(s *madmin.DecommissionStatus)) madmin.DecommissionStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	fn(&j.status)
+	return j.status
+}
+
+// isDraining - returns true if the zone idx takes no new objects.
+func (z *erasureZones) isDraining(idx int) bool {
+	z.decommission.mu.RLock()
+	defer z.decommission.mu.RUnlock()
+	return z.decommission.draining[decommissionTarget{zone: idx, set: -1}]
+}
+
+// isDrainingObject - returns true if the zone idx takes no new objects,
+// or the set of the zone the object is hashed to.
+func (z *erasureZones) isDrainingObject(idx int, object string) bool {
+	z.decommission.mu.RLock()
+	defer z.decommission.mu.RUnlock()
+	if z.decommission.draining[decommissionTarget{zone: idx, set: -1}] {
+		return true
+	}
+	if object == "" || len(z.decommission.draining) == 0 {
+		return false
+	}
+	set := z.zones[idx].getHashedSetIndex(object)
+	return z.decommission.draining[decommissionTarget{zone: idx, set: set}]
+}
+
+// zoneEndpoints - returns the endpoints of the zone idx, which identify
+// the zone when zones are added or removed from the command line.
+func (z *erasureZones) zoneEndpoints(idx int) []string {
+	endpoints := make([]string, len(z.zones[idx].endpoints))
+	for i, endpoint := range z.zones[idx].endpoints {
+		endpoints[i] = endpoint.String()
+	}
+	return endpoints
+}
+
+// zoneIndex - returns the index of the zone of the status, -1 if
+// the zone is no longer part of the server.
+func (z *erasureZones) zoneIndex(status madmin.DecommissionStatus) int {
+	for idx := range z.zones {
+		endpoints := z.zoneEndpoints(idx)
+		if len(endpoints) != len(status.Endpoints) {
+			continue
+		}
+		found := true
+		for i := range endpoints {
+			if endpoints[i] != status.Endpoints[i] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return idx
+		}
+	}
+	return -1
+}
+
+// decommissionTarget - returns the zone or the set of the status, false
+// if the zone or the drive is no longer part of the server.
+func (z *erasureZones) decommissionTarget(status madmin.DecommissionStatus) (decommissionTarget, bool) {
+	if status.Drive != "" {
+		return z.driveTarget(status.Drive)
+	}
+	idx := z.zoneIndex(status)
+	return decommissionTarget{zone: idx, set: -1}, idx >= 0
+}
+
+// driveTarget - returns the set of the drive, the drives of a zone
+// form its sets in the order of the server command line.
+func (z *erasureZones) driveTarget(drive string) (decommissionTarget, bool) {
+	for idx, zone := range z.zones {
+		for i, endpoint := range zone.endpoints {
+			if endpoint.String() == drive {
+				return decommissionTarget{zone: idx, set: i / zone.drivesPerSet}, true
+			}
+		}
+	}
+	return decommissionTarget{}, false
+}
+
+// loadDecommission - loads the zones and sets being decommissioned,
+// the jobs running on this server for zones and sets no longer
+// draining are stopped.
+func (z *erasureZones) loadDecommission(ctx context.Context) error {
+	statuses, err := loadZoneDecommission(ctx, z)
+	if err != nil {
+		return err
+	}
+
+	draining := make(map[decommissionTarget]bool)
+	for _, status := range statuses {
+		if target, ok := z.decommissionTarget(status); ok {
+			draining[target] = true
+		}
+	}
+
+	z.decommission.mu.Lock()
+	defer z.decommission.mu.Unlock()
+	z.decommission.draining = draining
+	for target, job := range z.decommission.jobs {
+		if !draining[target] {
+			job.cancel()
+		}
+	}
+	return nil
+}
+
+// StartDecommission - marks the zone idx as draining and moves its
+// objects to the other zones in the background, starting the
+// decommission of a draining zone resumes moving its objects.
+func (z *erasureZones) StartDecommission(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.zones) {
+		return errDecommissionInvalidZone
+	}
+	return z.startDecommission(ctx, decommissionTarget{zone: idx, set: -1}, madmin.DecommissionStatus{
+		Zone:      idx + 1,
+		Endpoints: z.zoneEndpoints(idx),
+	})
+}
+
+// StartDriveDecommission - marks the erasure set of the drive as
+// draining and moves its objects to the other zones in the background,
+// the objects of a set can't be placed on the other sets of its zone.
+// Once complete, the drive holds no objects and can be removed.
+func (z *erasureZones) StartDriveDecommission(ctx context.Context, drive string) error {
+	target, ok := z.driveTarget(drive)
+	if !ok {
+		return errDecommissionInvalidDrive
+	}
+	return z.startDecommission(ctx, target, madmin.DecommissionStatus{
+		Zone:      target.zone + 1,
+		Endpoints: z.zoneEndpoints(target.zone),
+		Drive:     drive,
+		Set:       target.set + 1,
+	})
+}
+
+func (z *erasureZones) startDecommission(ctx context.Context, target decommissionTarget, status madmin.DecommissionStatus) error {
+	z.decommission.mu.Lock()
+	if _, ok := z.decommission.jobs[target]; ok {
+		z.decommission.mu.Unlock()
+		return errDecommissionInProgress
+	}
+	available := 0
+	for i := range z.zones {
+		if i != target.zone && !z.decommission.draining[decommissionTarget{zone: i, set: -1}] {
+			available++
+		}
+	}
+	if available == 0 {
+		z.decommission.mu.Unlock()
+		return errDecommissionInvalidZone
+	}
+
+	status.Running = true
+	status.StartTime = UTCNow()
+	if z.decommission.jobs == nil {
+		z.decommission.jobs = make(map[decommissionTarget]*zoneDecommissionJob)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	job := &zoneDecommissionJob{status: status, cancel: cancel}
+	z.decommission.jobs[target] = job
+	z.decommission.mu.Unlock()
+
+	// The status is saved without holding the decommission state,
+	// saving it places an object on the zones.
+	if err := z.updateDecommission(ctx, status, true); err != nil {
+		z.decommission.mu.Lock()
+		delete(z.decommission.jobs, target)
+		z.decommission.mu.Unlock()
+		cancel()
+		return err
+	}
+
+	z.decommission.mu.Lock()
+	if z.decommission.draining == nil {
+		z.decommission.draining = make(map[decommissionTarget]bool)
+	}
+	z.decommission.draining[target] = true
+	z.decommission.mu.Unlock()
+
+	// All the servers stop placing new objects on the zone.
+	for _, nerr := range globalNotificationSys.LoadDecommission() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	go func() {
+		err := z.decommissionZone(ctx, target, job)
+		if err != nil && ctx.Err() == nil {
+			logger.LogIf(ctx, err)
+		}
+
+		status := job.update(func(s *madmin.DecommissionStatus) {
+			s.Running = false
+			s.EndTime = UTCNow()
+			switch {
+			case err == nil:
+				s.Complete = true
+			case ctx.Err() != nil:
+				s.Error = errDecommissionCanceled.Error()
+			default:
+				s.Error = err.Error()
+			}
+		})
+		if err = z.updateDecommission(GlobalContext, status, false); err != nil && err != errDecommissionNotFound {
+			logger.LogIf(GlobalContext, err)
+		}
+
+		z.decommission.mu.Lock()
+		delete(z.decommission.jobs, target)
+		z.decommission.mu.Unlock()
+		cancel()
+	}()
+	return nil
+}
+
+// CancelDecommission - stops moving the objects of the zone idx,
+// the zone takes new objects again.
+func (z *erasureZones) CancelDecommission(ctx context.Context, idx int) error {
+	if idx < 0 || idx >= len(z.zones) {
+		return errDecommissionInvalidZone
+	}
+	return z.cancelDecommission(ctx, decommissionTarget{zone: idx, set: -1})
+}
+
+// CancelDriveDecommission - stops moving the objects of the erasure
+// set of the drive, the set takes new objects again.
+func (z *erasureZones) CancelDriveDecommission(ctx context.Context, drive string) error {
+	target, ok := z.driveTarget(drive)
+	if !ok {
+		return errDecommissionInvalidDrive
+	}
+	return z.cancelDecommission(ctx, target)
+}
+
+func (z *erasureZones) cancelDecommission(ctx context.Context, target decommissionTarget) error {
+	z.decommission.mu.RLock()
+	draining := z.decommission.draining[target]
+	z.decommission.mu.RUnlock()
+	if !draining {
+		return errDecommissionNotFound
+	}
+
+	lk := z.NewNSLock(ctx, minioMetaBucket, zoneDecommissionLock)
+	if err := lk.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	statuses, err := loadZoneDecommission(ctx, z)
+	if err == nil {
+		remaining := statuses[:0]
+		for _, status := range statuses {
+			if t, ok := z.decommissionTarget(status); !ok || t != target {
+				remaining = append(remaining, status)
+			}
+		}
+		err = saveZoneDecommission(ctx, z, remaining)
+	}
+	lk.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err = z.loadDecommission(ctx); err != nil {
+		return err
+	}
+	for _, nerr := range globalNotificationSys.LoadDecommission() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	return nil
+}
+
+// DecommissionStatus - returns the status of the zones and drives being
+// decommissioned, jobs running on this server report their current
+// progress.
+func (z *erasureZones) DecommissionStatus(ctx context.Context) ([]madmin.DecommissionStatus, error) {
+	statuses, err := loadZoneDecommission(ctx, z)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]madmin.DecommissionStatus, 0, len(statuses))
+	z.decommission.mu.RLock()
+	for _, status := range statuses {
+		target, ok := z.decommissionTarget(status)
+		if !ok {
+			continue
+		}
+		if job, ok := z.decommission.jobs[target]; ok {
+			status = job.Status()
+		}
+		status.Zone = target.zone + 1
+		status.Set = target.set + 1
+		result = append(result, status)
+	}
+	z.decommission.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Zone != result[j].Zone {
+			return result[i].Zone < result[j].Zone
+		}
+		return result[i].Set < result[j].Set
+	})
+	return result, nil
+}
+
+// updateDecommission - saves the status of a zone or a drive, the
+// status is only added if add is true, otherwise errDecommissionNotFound
+// is returned when the decommission was canceled.
+func (z *erasureZones) updateDecommission(ctx context.Context, status madmin.DecommissionStatus, add bool) error {
+	lk := z.NewNSLock(ctx, minioMetaBucket, zoneDecommissionLock)
+	if err := lk.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	statuses, err := loadZoneDecommission(ctx, z)
+	if err != nil {
+		return err
+	}
+	target, _ := z.decommissionTarget(status)
+	found := false
+	for i := range statuses {
+		if t, ok := z.decommissionTarget(statuses[i]); ok && t == target {
+			statuses[i] = status
+			found = true
+		}
+	}
+	if !found {
+		if !add {
+			return errDecommissionNotFound
+		}
+		statuses = append(statuses, status)
+	}
+	return saveZoneDecommission(ctx, z, statuses)
+}
+
+// decommissionZone - moves the objects of the zone, or of the set of
+// the zone, of the target to the other zones, until it holds no
+// objects. Objects written to the zone while it is walked are moved by
+// the next walk.
+func (z *erasureZones) decommissionZone(ctx context.Context, target decommissionTarget, job *zoneDecommissionJob) error {
+	idx := target.zone
+	for {
+		buckets, err := z.zones[idx].ListBuckets(ctx)
+		if err != nil {
+			return err
+		}
+
+		var found, failed int64
+		walk := func(bucket, prefix string) error {
+			n, f, err := z.decommissionPrefix(ctx, target, job, bucket, prefix)
+			found += n
+			failed += f
+			return err
+		}
+		for _, bucket := range buckets {
+			if err = walk(bucket.Name, ""); err != nil {
+				return err
+			}
+		}
+		for _, prefix := range zoneDecommissionMetaPrefixes {
+			if err = walk(minioMetaBucket, prefix); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case found == 0:
+			return nil
+		case found == failed && target.set >= 0:
+			return fmt.Errorf("Unable to move %d objects out of set %d of zone %d", failed, target.set+1, idx+1)
+		case found == failed:
+			return fmt.Errorf("Unable to move %d objects out of zone %d", failed, idx+1)
+		}
+	}
+}
+
+// decommissionPrefix - moves the objects of the bucket under prefix out
+// of the zone, or the set of the zone, of the target, returns the number
+// of objects found and of objects which could not be moved. The set of a
+// zone is walked along with the other sets of the zone.
+func (z *erasureZones) decommissionPrefix(ctx context.Context, target decommissionTarget, job *zoneDecommissionJob, bucket, prefix string) (found, failed int64, err error) {
+	idx := target.zone
+	ctx, cancel := context.WithCancel(ctx)
+
+	results := make(chan ObjectInfo)
+	if err = z.zones[idx].Walk(ctx, bucket, prefix, results, ObjectOptions{WalkVersions: true}); err != nil {
+		cancel()
+		if isErrBucketNotFound(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	defer func() {
+		// Stop the walk and wait for it to be done.
+		cancel()
+		for range results {
+		}
+	}()
+
+	var object string
+	for oi := range results {
+		// Versions of an object are walked together,
+		// all of them are moved at once.
+		if object != "" && oi.Name == object {
+			continue
+		}
+		object = oi.Name
+		if target.set >= 0 && z.zones[idx].getHashedSetIndex(object) != target.set {
+			continue
+		}
+		found++
+
+		size, err := z.decommissionObject(ctx, idx, bucket, object)
+		if err != nil && ctx.Err() != nil {
+			return found, failed, ctx.Err()
+		}
+		if err != nil {
+			failed++
+			logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s out of zone %d: %w", bucket, object, idx+1, err))
+		}
+		status := job.update(func(s *madmin.DecommissionStatus) {
+			s.Bucket = bucket
+			s.Object = object
+			if err != nil {
+				s.Failed++
+				return
+			}
+			s.Objects++
+			s.Bytes += size
+		})
+		if (status.Objects+status.Failed)%zoneDecommissionCheckpointInterval == 0 {
+			if err = z.updateDecommission(ctx, status, false); err == errDecommissionNotFound {
+				return found, failed, errDecommissionCanceled
+			}
+			logger.LogIf(ctx, err)
+		}
+	}
+	return found, failed, ctx.Err()
+}
+
+// decommissionObject - moves all the versions of the object out of the
+// zone idx, holding the lock of the object. Returns the size of the
+// versions moved. The object is only deleted from the zone once all its
+// versions are read back from the destination, an object which can't be
+// read, or with versions found on too few disks to be read, is left in
+// place and fails until it is healed.
+func (z *erasureZones) decommissionObject(ctx context.Context, idx int, bucket, object string) (int64, error) {
+	lk := z.NewNSLock(ctx, bucket, object)
+	if err := lk.GetLock(globalOperationTimeout); err != nil {
+		return 0, err
+	}
+	defer lk.Unlock()
+
+	src := z.zones[idx].getHashedSet(object)
+	srcWriteQuorum := getWriteQuorum(len(src.getDisks()))
+
+	// Move the directory object stored inside the directory like any
+	// other object, only empty directories of older releases are
+	// recreated as is.
+	if HasSuffix(object, SlashSeparator) {
+		if _, _, err := src.objectVersions(ctx, bucket, encodeDirObject(object)); err == nil {
+			object = encodeDirObject(object)
+		}
+	}
+
+	if HasSuffix(object, SlashSeparator) {
+		dst := z.getAvailableZoneIdx(ctx, object, 0)
+		if dst < 0 {
+			return 0, toObjectErr(errDiskFull)
+		}
+		set := z.zones[dst].getHashedSet(object)
+		if err := set.putObjectDir(ctx, bucket, object, getWriteQuorum(len(set.getDisks()))); err != nil {
+			return 0, toObjectErr(err, bucket, object)
+		}
+		return 0, src.deleteObject(ctx, bucket, object, srcWriteQuorum)
+	}
+
+	versions, partial, err := src.objectVersions(ctx, bucket, object)
+	if err == nil && len(partial) > 0 {
+		// Deleting the object deletes all its versions, those which
+		// can't be read are healed first, or the object stays.
+		for _, versionID := range partial {
+			if versionID == "" {
+				versionID = nullVersionID
+			}
+			_, herr := src.HealObject(ctx, bucket, object, versionID, madmin.HealOpts{ScanMode: madmin.HealNormalScan})
+			logger.LogIf(ctx, herr)
+		}
+		versions, partial, err = src.objectVersions(ctx, bucket, object)
+		if err == nil && len(partial) > 0 {
+			return 0, fmt.Errorf("Versions %q of %s/%s are found on too few disks to be moved", partial, bucket, object)
+		}
+	}
+	if err != nil {
+		return 0, toObjectErr(err, bucket, object)
+	}
+
+	var size int64
+	for _, version := range versions {
+		size += version.Size
+	}
+	// We multiply the size by 2 to account for erasure coding.
+	dst := z.getAvailableZoneIdx(ctx, object, size*2)
+	if dst < 0 {
+		return 0, toObjectErr(errDiskFull)
+	}
+	set := z.zones[dst].getHashedSet(object)
+
+	for _, version := range versions {
+		if err = set.copyObjectVersion(ctx, src, bucket, object, version); err != nil {
+			return 0, err
+		}
+	}
+	if err = set.verifyObjectVersions(ctx, bucket, object, versions); err != nil {
+		return 0, err
+	}
+	return size, src.deleteObject(ctx, bucket, object, srcWriteQuorum)
+}
+
+// verifyObjectVersions - returns an error unless all the versions are
+// found on a read quorum of disks, with the same modification time and
+// size, and the parts of the versions which are not delete markers are
+// found on a read quorum of disks.
+func (er erasureObjects) verifyObjectVersions(ctx context.Context, bucket, object string, versions []FileInfo) error {
+	found, _, err := er.objectVersions(ctx, bucket, object)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	foundVersions := make(map[string]FileInfo, len(found))
+	for _, fi := range found {
+		foundVersions[fi.VersionID] = fi
+	}
+	for _, version := range versions {
+		fi, ok := foundVersions[version.VersionID]
+		if !ok || fi.Deleted != version.Deleted || fi.Size != version.Size || !fi.ModTime.Equal(version.ModTime) {
+			return fmt.Errorf("Version %q of %s/%s was not copied", version.VersionID, bucket, object)
+		}
+		if version.Deleted {
+			continue
+		}
+		versionID := version.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		fi, metaArr, onlineDisks, err := er.getObjectFileInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			return toObjectErr(err, bucket, object)
+		}
+		var online int
+		for i, disk := range onlineDisks {
+			if disk != nil && disk.CheckParts(bucket, object, metaArr[i]) == nil {
+				online++
+			}
+		}
+		if online < fi.Erasure.DataBlocks {
+			return toObjectErr(errErasureReadQuorum, bucket, object)
+		}
+	}
+	return nil
+}
+
+// objectVersions - returns the versions of the object found on a
+// read quorum of disks, and the IDs of the versions found on fewer
+// disks.
+func (er erasureObjects) objectVersions(ctx context.Context, bucket, object string) ([]FileInfo, []string, error) {
+	disks := er.getDisks()
+
+	diskVersions := make([][]FileInfo, len(disks))
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			buf, err := disks[index].ReadAll(bucket, pathJoin(object, xlStorageFormatFile))
+			if err == errFileNotFound {
+				buf, err = disks[index].ReadAll(bucket, pathJoin(object, xlStorageFormatFileV1))
+			}
+			if err != nil {
+				return err
+			}
+			fivs, err := getFileInfoVersions(buf, bucket, object)
+			if err != nil {
+				return err
+			}
+			diskVersions[index] = fivs.Versions
+			return nil
+		}, index)
+	}
+
+	readQuorum := getReadQuorum(len(disks))
+	if err := reduceReadQuorumErrs(ctx, g.Wait(), objectOpIgnoredErrs, readQuorum); err != nil {
+		return nil, nil, err
+	}
+
+	var versions []FileInfo
+	var versionIDs []string
+	count := make(map[string]int)
+	for _, fivs := range diskVersions {
+		for _, fi := range fivs {
+			if count[fi.VersionID] == 0 {
+				versionIDs = append(versionIDs, fi.VersionID)
+			}
+			count[fi.VersionID]++
+			if count[fi.VersionID] == readQuorum {
+				versions = append(versions, fi)
+			}
+		}
+	}
+	var partial []string
+	for _, versionID := range versionIDs {
+		if count[versionID] < readQuorum {
+			partial = append(partial, versionID)
+		}
+	}
+	if len(versions) == 0 && len(partial) == 0 {
+		return nil, nil, errFileNotFound
+	}
+	return versions, partial, nil
+}
+
+// copyObjectVersion - writes the version of the object stored by src,
+// the parts are copied as stored, encrypted or compressed, and keep
+// the metadata, version ID and modification time of the version.
+func (er erasureObjects) copyObjectVersion(ctx context.Context, src *erasureObjects, bucket, object string, version FileInfo) error {
+	storageDisks := er.getDisks()
+
+	if version.Deleted {
+		return toObjectErr(er.deleteObjectVersion(ctx, bucket, object, getWriteQuorum(len(storageDisks)), FileInfo{
+			Name:      object,
+			VersionID: version.VersionID,
+			Deleted:   true,
+			ModTime:   version.ModTime,
+		}), bucket, object)
+	}
+
+	versionID := version.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	opts := ObjectOptions{VersionID: versionID}
+	fi, metaArr, onlineDisks, err := src.getObjectFileInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Keep the parity of the version when the erasure sets have the
+	// same number of disks.
+	parityDrives := fi.Erasure.ParityBlocks
+	if parityDrives == 0 || parityDrives > len(storageDisks)/2 {
+		parityDrives = getDefaultParityBlocks(len(storageDisks))
+	}
+	dataDrives := len(storageDisks) - parityDrives
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum = dataDrives + 1
+	}
+
+	tempObj := mustGetUUID()
+	defer er.deleteTmpObject(ctx, bucket, object, tempObj, writeQuorum)
+
+	nfi := newFileInfo(object, dataDrives, parityDrives)
+	nfi.VersionID = fi.VersionID
+	nfi.DataDir = mustGetUUID()
+
+	partsMetadata := make([]FileInfo, len(storageDisks))
+	for index := range partsMetadata {
+		partsMetadata[index] = nfi
+	}
+	dstDisks := shuffleDisks(storageDisks, nfi.Erasure.Distribution)
+
+	erasure, err := NewErasure(ctx, nfi.Erasure.DataBlocks, nfi.Erasure.ParityBlocks, nfi.Erasure.BlockSize)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	buffer := er.bp.Get()
+	defer er.bp.Put(buffer)
+	if len(buffer) > int(nfi.Erasure.BlockSize) {
+		buffer = buffer[:nfi.Erasure.BlockSize]
+	}
+
+	var offset int64
+	for _, part := range fi.Parts {
+		pr, pw := io.Pipe()
+		go func(offset, length int64) {
+			pw.CloseWithError(src.getObjectWithFileInfo(ctx, bucket, object, offset, length, pw, "", opts, fi, metaArr, onlineDisks))
+		}(offset, part.Size)
+
+		tempErasureObj := pathJoin(tempObj, nfi.DataDir, fmt.Sprintf("part.%d", part.Number))
+		writers := make([]io.Writer, len(dstDisks))
+		for i, disk := range dstDisks {
+			if disk == nil {
+				continue
+			}
+			writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, erasure.ShardFileSize(part.Size), DefaultBitrotAlgorithm, erasure.ShardSize())
+		}
+
+		n, err := erasure.Encode(ctx, pr, writers, buffer, writeQuorum)
+		closeBitrotWriters(writers)
+		pr.CloseWithError(err)
+		if err != nil {
+			return toObjectErr(err, minioMetaTmpBucket, tempErasureObj)
+		}
+		if n < part.Size {
+			return IncompleteBody{}
+		}
+
+		for i, w := range writers {
+			if w == nil {
+				dstDisks[i] = nil
+				continue
+			}
+			partsMetadata[i].AddObjectPart(part.Number, part.ETag, part.Size, part.ActualSize)
+			partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
+				PartNumber: part.Number,
+				Algorithm:  DefaultBitrotAlgorithm,
+				Hash:       bitrotWriterSum(w),
+			})
+		}
+		offset += part.Size
+	}
+
+	for index := range partsMetadata {
+		partsMetadata[index].Metadata = fi.Metadata
+		partsMetadata[index].Size = fi.Size
+		partsMetadata[index].ModTime = fi.ModTime
+	}
+
+	if dstDisks, err = writeUniqueFileInfo(ctx, dstDisks, minioMetaTmpBucket, tempObj, partsMetadata, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	if dstDisks, err = renameData(ctx, dstDisks, minioMetaTmpBucket, tempObj, nfi.DataDir, bucket, object, writeQuorum, nil); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
+	// Heal the version on the disks which were offline.
+	for i := range dstDisks {
+		if dstDisks[i] == nil || storageDisks[i] == nil {
+			er.addPartial(bucket, object, fi.VersionID)
+			break
+		}
+	}
+	return nil
+}
+
+func loadZoneDecommission(ctx context.Context, objAPI ObjectLayer) ([]madmin.DecommissionStatus, error) {
+	data, err := readConfig(ctx, objAPI, zoneDecommissionFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var statuses []madmin.DecommissionStatus
+	if err = json.Unmarshal(data, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func saveZoneDecommission(ctx context.Context, objAPI ObjectLayer, statuses []madmin.DecommissionStatus) error {
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, zoneDecommissionFile, data)
+}
@@ -0,0 +1,337 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestZoneDecommission(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	endpointZones := append(mustGetZoneEndpoints(disks[:4]...), mustGetZoneEndpoints(disks[4:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureZones)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Objects stored on the first zone.
+	objects := make(map[string]ObjectInfo)
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("dir/object-%d", i)
+		data := bytes.Repeat([]byte{byte(i)}, 1024*(i+1))
+		oi, err := z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects[object] = oi
+	}
+
+	if err = z.StartDecommission(ctx, 2); err != errDecommissionInvalidZone {
+		t.Fatalf("expected an invalid zone, got %v", err)
+	}
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.StartDecommission(ctx, 1); err != errDecommissionInvalidZone {
+		t.Fatalf("expected the last zone not to be decommissioned, got %v", err)
+	}
+
+	var complete bool
+	for i := 0; i < 200 && !complete; i++ {
+		statuses, err := z.DecommissionStatus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(statuses) != 1 || statuses[0].Zone != 1 || statuses[0].Error != "" {
+			t.Fatalf("unexpected status %v", statuses)
+		}
+		complete = statuses[0].Complete
+		if complete && statuses[0].Objects < int64(len(objects)) {
+			t.Fatalf("expected %d objects to be moved, got %d", len(objects), statuses[0].Objects)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !complete {
+		t.Fatal("expected the decommission to complete")
+	}
+
+	for object, want := range objects {
+		if _, err = z.zones[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("expected %s to be moved, got %v", object, err)
+		}
+		oi, err := z.zones[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oi.ETag != want.ETag || oi.Size != want.Size || !oi.ModTime.Equal(want.ModTime) {
+			t.Fatalf("%s: expected %s %d %v, got %s %d %v", object, want.ETag, want.Size, want.ModTime, oi.ETag, oi.Size, oi.ModTime)
+		}
+		var buf bytes.Buffer
+		if err = obj.GetObject(ctx, bucket, object, 0, oi.Size, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != int(want.Size) {
+			t.Fatalf("%s: expected %d bytes, got %d", object, want.Size, buf.Len())
+		}
+	}
+
+	// No new objects are placed on the draining zone.
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("new/object-%d", i)
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("new")), 3, "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = z.zones[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatalf("expected %s to be placed on the second zone, got %v", object, err)
+		}
+	}
+
+	if err = z.CancelDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if z.isDraining(0) {
+		t.Fatal("expected the zone to take new objects")
+	}
+	if err = z.CancelDecommission(ctx, 0); err != errDecommissionNotFound {
+		t.Fatalf("expected no decommission, got %v", err)
+	}
+}
+
+func TestZoneDecommissionObjectWithoutReadQuorum(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	endpointZones := append(mustGetZoneEndpoints(disks[:4]...), mustGetZoneEndpoints(disks[4:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureZones)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 1024)
+	if _, err = z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lose the read quorum of the object.
+	for _, disk := range disks[:3] {
+		if err = os.RemoveAll(pathJoin(disk, bucket, object)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err = z.decommissionObject(ctx, 0, bucket, object); err == nil {
+		t.Fatal("expected the object not to be moved")
+	}
+	if _, err = os.Stat(pathJoin(disks[3], bucket, object, xlStorageFormatFile)); err != nil {
+		t.Fatalf("expected the object to be left in place, got %v", err)
+	}
+	if _, err = z.zones[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected the object not to be copied, got %v", err)
+	}
+}
+
+func TestZoneDecommissionVersionWithoutReadQuorum(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	endpointZones := append(mustGetZoneEndpoints(disks[:4]...), mustGetZoneEndpoints(disks[4:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureZones)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	var versions []ObjectInfo
+	for i := 0; i < 2; i++ {
+		data := bytes.Repeat([]byte{byte(i)}, 1024)
+		oi, err := z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, oi)
+	}
+
+	// The first version is only left on a disk.
+	src := z.zones[0].getHashedSet(object)
+	srcDisks := src.getDisks()
+	for _, disk := range srcDisks[1:] {
+		if err = disk.DeleteVersion(bucket, object, FileInfo{Name: object, VersionID: versions[0].VersionID}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err = z.decommissionObject(ctx, 0, bucket, object); err == nil {
+		t.Fatal("expected the object not to be moved")
+	}
+	if _, err = srcDisks[0].ReadVersion(bucket, object, versions[0].VersionID); err != nil {
+		t.Fatalf("expected the version to be left in place, got %v", err)
+	}
+	if _, err = z.zones[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versions[1].VersionID}); err != nil {
+		t.Fatalf("expected the object to be left in place, got %v", err)
+	}
+}
+
+func TestDriveDecommission(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	// A zone of two sets, and a zone of a set.
+	endpointZones := append(EndpointZones{{
+		SetCount:     2,
+		DrivesPerSet: 4,
+		Endpoints:    mustGetNewEndpoints(disks[:8]...),
+	}}, mustGetZoneEndpoints(disks[8:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureZones)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects := make(map[string]int)
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		data := bytes.Repeat([]byte{byte(i)}, 1024)
+		if _, err = z.zones[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		objects[object] = z.zones[0].getHashedSetIndex(object)
+	}
+
+	if err = z.StartDriveDecommission(ctx, "/no/such/drive"); err != errDecommissionInvalidDrive {
+		t.Fatalf("expected an invalid drive, got %v", err)
+	}
+	drive := z.zones[0].endpoints[5].String()
+	if err = z.StartDriveDecommission(ctx, drive); err != nil {
+		t.Fatal(err)
+	}
+
+	var complete bool
+	for i := 0; i < 200 && !complete; i++ {
+		statuses, err := z.DecommissionStatus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(statuses) != 1 || statuses[0].Zone != 1 || statuses[0].Drive != drive || statuses[0].Set != 2 || statuses[0].Error != "" {
+			t.Fatalf("unexpected status %v", statuses)
+		}
+		complete = statuses[0].Complete
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !complete {
+		t.Fatal("expected the decommission to complete")
+	}
+
+	// Only the objects of the set of the drive are moved.
+	for object, set := range objects {
+		_, err = z.zones[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		switch {
+		case set == 1 && !isErrObjectNotFound(err):
+			t.Fatalf("expected %s to be moved, got %v", object, err)
+		case set == 0 && err != nil:
+			t.Fatalf("expected %s to be left in place, got %v", object, err)
+		}
+		if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := ioutil.ReadDir(pathJoin(disks[5], bucket))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected the drive to hold no objects, got %d", len(entries))
+	}
+
+	// No new objects are placed on the draining set.
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("new/object-%d", i)
+		if z.zones[0].getHashedSetIndex(object) != 1 {
+			continue
+		}
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("new")), 3, "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = z.zones[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatalf("expected %s to be placed on the second zone, got %v", object, err)
+		}
+	}
+	if z.isDraining(0) {
+		t.Fatal("expected the zone to take new objects")
+	}
+
+	if err = z.CancelDriveDecommission(ctx, drive); err != nil {
+		t.Fatal(err)
+	}
+	if z.isDrainingObject(0, "new/object-0") || z.isDrainingObject(0, "new/object-1") {
+		t.Fatal("expected the set to take new objects")
+	}
+	if err = z.CancelDriveDecommission(ctx, drive); err != errDecommissionNotFound {
+		t.Fatalf("expected no decommission, got %v", err)
+	}
+}
@@ -39,6 +39,12 @@ type erasureZones struct {
 	GatewayUnsupported
 
 	zones []*erasureSets
+
+	decommission zonesDecommission
+
+	// bucketCache holds the buckets found on any zone, the zones
+	// cache the buckets found on their own drives.
+	bucketCache *bucketInfoCache
 }
 
 func (z *erasureZones) SingleZone() bool {
@@ -53,7 +59,7 @@ func newErasureZones(ctx context.Context, endpointZones EndpointZones) (ObjectLa
 
 		formats      = make([]*formatErasureV3, len(endpointZones))
 		storageDisks = make([][]StorageAPI, len(endpointZones))
-		z            = &erasureZones{zones: make([]*erasureSets, len(endpointZones))}
+		z            = &erasureZones{zones: make([]*erasureSets, len(endpointZones)), bucketCache: &bucketInfoCache{}}
 	)
 
 	var localDrives []string
@@ -79,6 +85,8 @@ func newErasureZones(ctx context.Context, endpointZones EndpointZones) (ObjectLa
 		}
 	}
 
+	globalErasureFormatParity = formatErasureParity(formats)
+
 	go intDataUpdateTracker.start(GlobalContext, localDrives...)
 	return z, nil
 }
@@ -103,10 +111,11 @@ func (p zonesAvailableSpace) TotalAvailable() uint64 {
 	return total
 }
 
-// getAvailableZoneIdx will return an index that can hold size bytes.
-// -1 is returned if no zones have available space for the size given.
-func (z *erasureZones) getAvailableZoneIdx(ctx context.Context, size int64) int {
-	zones := z.getZonesAvailableSpace(ctx, size)
+// getAvailableZoneIdx will return an index that can hold size bytes of
+// the object. -1 is returned if no zones have available space for the
+// size given.
+func (z *erasureZones) getAvailableZoneIdx(ctx context.Context, object string, size int64) int {
+	zones := z.getZonesAvailableSpace(ctx, object, size)
 	total := zones.TotalAvailable()
 	if total == 0 {
 		return -1
@@ -128,7 +137,7 @@ func (z *erasureZones) getAvailableZoneIdx(ctx context.Context, size int64) int
 // getZonesAvailableSpace will return the available space of each zone after storing the content.
 // If there is not enough space the zone will return 0 bytes available.
 // Negative sizes are seen as 0 bytes.
-func (z *erasureZones) getZonesAvailableSpace(ctx context.Context, size int64) zonesAvailableSpace {
+func (z *erasureZones) getZonesAvailableSpace(ctx context.Context, object string, size int64) zonesAvailableSpace {
 	if size < 0 {
 		size = 0
 	}
@@ -158,6 +167,10 @@ func (z *erasureZones) getZonesAvailableSpace(ctx context.Context, size int64) z
 		if available < uint64(size) {
 			available = 0
 		}
+		// Draining zones and sets take no new objects.
+		if z.isDrainingObject(i, object) {
+			available = 0
+		}
 		if available > 0 {
 			// How much will be left after adding the file.
 			available -= -uint64(size)
@@ -205,7 +218,7 @@ func (z *erasureZones) getZoneIdx(ctx context.Context, bucket, object string, op
 	}
 
 	// We multiply the size by 2 to account for erasure coding.
-	idx = z.getAvailableZoneIdx(ctx, size*2)
+	idx = z.getAvailableZoneIdx(ctx, object, size*2)
 	if idx < 0 {
 		return -1, toObjectErr(errDiskFull)
 	}
@@ -268,6 +281,12 @@ func (z *erasureZones) StorageInfo(ctx context.Context, local bool) (StorageInfo
 	storageInfo.Backend.StandardSCParity = storageInfos[0].Backend.StandardSCParity
 	storageInfo.Backend.RRSCData = storageInfos[0].Backend.RRSCData
 	storageInfo.Backend.RRSCParity = storageInfos[0].Backend.RRSCParity
+	storageInfo.Backend.FormatParity = globalErasureFormatParity
+	for _, lstorageInfo := range storageInfos {
+		if lstorageInfo.Backend.FailureDomainDrives > storageInfo.Backend.FailureDomainDrives {
+			storageInfo.Backend.FailureDomainDrives = lstorageInfo.Backend.FailureDomainDrives
+		}
+	}
 
 	var errs []error
 	for i := range z.zones {
@@ -391,6 +410,7 @@ func (z *erasureZones) MakeBucketWithLocation(ctx context.Context, bucket string
 
 		// If it doesn't exist we get a new, so ignore errors
 		meta := newBucketMetadata(bucket)
+		meta.Region = bucketRegion(opts.Location)
 		if opts.LockEnabled {
 			meta.VersioningConfigXML = enabledBucketVersioningConfig
 			meta.ObjectLockConfigXML = enabledBucketObjectLockConfig
@@ -422,6 +442,7 @@ func (z *erasureZones) MakeBucketWithLocation(ctx context.Context, bucket string
 
 	// If it doesn't exist we get a new, so ignore errors
 	meta := newBucketMetadata(bucket)
+	meta.Region = bucketRegion(opts.Location)
 	if opts.LockEnabled {
 		meta.VersioningConfigXML = enabledBucketVersioningConfig
 		meta.ObjectLockConfigXML = enabledBucketObjectLockConfig
@@ -439,7 +460,17 @@ func (z *erasureZones) MakeBucketWithLocation(ctx context.Context, bucket string
 }
 
 func (z *erasureZones) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	// The read is in progress until the reader is closed.
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return nil, err
+	}
 	var nsUnlocker = func() {}
+	defer func() {
+		if err != nil {
+			release()
+		}
+	}()
 
 	// Acquire lock
 	if lockType != noLock {
@@ -467,7 +498,7 @@ func (z *erasureZones) GetObjectNInfo(ctx context.Context, bucket, object string
 			nsUnlocker()
 			return gr, err
 		}
-		gr.cleanUpFns = append(gr.cleanUpFns, nsUnlocker)
+		gr.cleanUpFns = append(gr.cleanUpFns, nsUnlocker, release)
 		return gr, nil
 	}
 	nsUnlocker()
@@ -478,6 +509,12 @@ func (z *erasureZones) GetObjectNInfo(ctx context.Context, bucket, object string
 }
 
 func (z *erasureZones) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) error {
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Lock the object before reading.
 	lk := z.NewNSLock(ctx, bucket, object)
 	if err := lk.GetRLock(globalObjectTimeout); err != nil {
@@ -530,6 +567,12 @@ func (z *erasureZones) GetObjectInfo(ctx context.Context, bucket, object string,
 
 // PutObject - writes an object to least used erasure zone.
 func (z *erasureZones) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer release()
+
 	// Lock the object.
 	lk := z.NewNSLock(ctx, bucket, object)
 	if err := lk.GetLock(globalObjectTimeout); err != nil {
@@ -551,6 +594,12 @@ func (z *erasureZones) PutObject(ctx context.Context, bucket string, object stri
 }
 
 func (z *erasureZones) DeleteObject(ctx context.Context, bucket string, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	defer release()
+
 	// Acquire a write lock before deleting the object.
 	lk := z.NewNSLock(ctx, bucket, object)
 	if err = lk.GetLock(globalOperationTimeout); err != nil {
@@ -582,6 +631,15 @@ func (z *erasureZones) DeleteObjects(ctx context.Context, bucket string, objects
 		objSets.Add(objects[i].ObjectName)
 	}
 
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		for i := range derrs {
+			derrs[i] = err
+		}
+		return nil, derrs
+	}
+	defer release()
+
 	// Acquire a bulk write lock across 'objects'
 	multiDeleteLock := z.NewNSLock(ctx, bucket, objSets.ToSlice()...)
 	if err := multiDeleteLock.GetLock(globalOperationTimeout); err != nil {
@@ -592,16 +650,28 @@ func (z *erasureZones) DeleteObjects(ctx context.Context, bucket string, objects
 	}
 	defer multiDeleteLock.Unlock()
 
-	for _, zone := range z.zones {
-		deletedObjects, errs := zone.DeleteObjects(ctx, bucket, objects, opts)
-		for i, derr := range errs {
+	// Delete the objects on all zones in parallel.
+	zonesDeleted := make([][]DeletedObject, len(z.zones))
+	zonesErrs := make([][]error, len(z.zones))
+	var wg sync.WaitGroup
+	for index, zone := range z.zones {
+		wg.Add(1)
+		go func(index int, zone *erasureSets) {
+			defer wg.Done()
+			zonesDeleted[index], zonesErrs[index] = zone.DeleteObjects(ctx, bucket, objects, opts)
+		}(index, zone)
+	}
+	wg.Wait()
+
+	for index := range z.zones {
+		for i, derr := range zonesErrs[index] {
 			if derrs[i] == nil {
 				if derr != nil && !isErrObjectNotFound(derr) && !isErrVersionNotFound(derr) {
 					derrs[i] = derr
 				}
 			}
 			if derrs[i] == nil {
-				dobjects[i] = deletedObjects[i]
+				dobjects[i] = zonesDeleted[index][i]
 			}
 		}
 	}
@@ -609,6 +679,12 @@ func (z *erasureZones) DeleteObjects(ctx context.Context, bucket string, objects
 }
 
 func (z *erasureZones) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return objInfo, err
+	}
+	defer release()
+
 	// Check if this request is only metadata update.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 	if !cpSrcDstSame {
@@ -642,6 +718,7 @@ func (z *erasureZones) CopyObject(ctx context.Context, srcBucket, srcObject, dst
 		UserDefined:          srcInfo.UserDefined,
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
+		CheckPrecondFn:       dstOpts.CheckPrecondFn,
 	}
 
 	return z.zones[zoneIdx].PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
@@ -684,6 +761,9 @@ func (z *erasureZones) listObjectsNonSlash(ctx context.Context, bucket, prefix,
 	var objInfos []ObjectInfo
 	var eof bool
 	var prevPrefix string
+	filter := listFilterFromContext(ctx)
+	var skipped int
+	var lastSkipped string
 
 	var zonesEntriesInfos [][]FileInfo
 	var zonesEntriesValid [][]bool
@@ -711,6 +791,7 @@ func (z *erasureZones) listObjectsNonSlash(ctx context.Context, bucket, prefix,
 		var objInfo ObjectInfo
 
 		index := strings.Index(strings.TrimPrefix(result.Name, prefix), delimiter)
+		isPrefix := index != -1
 		if index == -1 {
 			objInfo = ObjectInfo{
 				IsDir:           false,
@@ -758,6 +839,16 @@ func (z *erasureZones) listObjectsNonSlash(ctx context.Context, bucket, prefix,
 			continue
 		}
 
+		// The common prefixes are never filtered.
+		if !isPrefix && !filter.matchFileInfo(bucket, result) {
+			lastSkipped = objInfo.Name
+			skipped++
+			if skipped == listFilterMaxSkipped {
+				break
+			}
+			continue
+		}
+
 		objInfos = append(objInfos, objInfo)
 	}
 
@@ -772,7 +863,10 @@ func (z *erasureZones) listObjectsNonSlash(ctx context.Context, bucket, prefix,
 
 	if !eof {
 		result.IsTruncated = true
-		if len(objInfos) > 0 {
+		if skipped == listFilterMaxSkipped {
+			// Cut short by the filter.
+			result.NextMarker = lastSkipped
+		} else if len(objInfos) > 0 {
 			result.NextMarker = objInfos[len(objInfos)-1].Name
 		}
 	}
@@ -802,7 +896,8 @@ func (z *erasureZones) listObjectsSplunk(ctx context.Context, bucket, prefix, ma
 		zonesEndWalkCh = append(zonesEndWalkCh, endWalkCh)
 	}
 
-	entries := mergeZonesEntriesCh(zonesEntryChs, maxKeys, ndisks)
+	// Splunk listings are listed as common prefixes, never filtered.
+	entries, _ := mergeZonesEntriesCh(zonesEntryChs, maxKeys, ndisks, nil)
 	if len(entries.Files) == 0 {
 		return loi, nil
 	}
@@ -893,19 +988,34 @@ func (z *erasureZones) listObjects(ctx context.Context, bucket, prefix, marker,
 		zonesEndWalkCh = append(zonesEndWalkCh, endWalkCh)
 	}
 
-	entries := mergeZonesEntriesCh(zonesEntryChs, maxKeys, ndisks)
-	if len(entries.Files) == 0 {
+	var match func(FileInfo) bool
+	if filter := listFilterFromContext(ctx); filter != nil {
+		match = func(fi FileInfo) bool {
+			objInfo := fi.ToObjectInfo(bucket, fi.Name)
+			// Common prefixes are never filtered.
+			if HasSuffix(objInfo.Name, SlashSeparator) && objInfo.Name != prefix && !recursive {
+				return true
+			}
+			return filter.match(objInfo)
+		}
+	}
+
+	entries, lastName := mergeZonesEntriesCh(zonesEntryChs, maxKeys, ndisks, match)
+	if len(entries.Files) == 0 && !entries.IsTruncated {
 		return loi, nil
 	}
 
 	loi.IsTruncated = entries.IsTruncated
 	if loi.IsTruncated {
-		loi.NextMarker = entries.Files[len(entries.Files)-1].Name
+		// The last entry left out by the filter when the page has
+		// been cut short.
+		loi.NextMarker = lastName
 	}
 
 	for _, entry := range entries.Files {
 		objInfo := entry.ToObjectInfo(entry.Volume, entry.Name)
-		if HasSuffix(objInfo.Name, SlashSeparator) && !recursive {
+		// The directory object of the prefix is listed as an object.
+		if HasSuffix(objInfo.Name, SlashSeparator) && objInfo.Name != prefix && !recursive {
 			loi.Prefixes = append(loi.Prefixes, objInfo.Name)
 			continue
 		}
@@ -986,17 +1096,43 @@ func lexicallySortedEntryZone(zoneEntryChs [][]FileInfoCh, zoneEntries [][]FileI
 		return lentry, 0, zoneIndex, isTruncated
 	}
 
+	// Entries are duplicated across disks, and an object may be found
+	// on several zones while it is moved between zones. Each name is
+	// returned only once, from the first zone having it as reads do,
+	// the copy found on most disks of the zone is returned.
 	lexicallySortedEntryCount := 0
 	for i, entriesValid := range zoneEntriesValid {
+		var copies []FileInfo
+		var counts []int
 		for j, valid := range entriesValid {
-			if !valid {
+			if !valid || zoneEntries[i][j].Name != lentry.Name {
 				continue
 			}
+			k := 0
+			for k < len(copies) && !copies[k].ModTime.Equal(zoneEntries[i][j].ModTime) {
+				k++
+			}
+			if k == len(copies) {
+				copies = append(copies, zoneEntries[i][j])
+				counts = append(counts, 0)
+			}
+			counts[k]++
+		}
+		for k := range copies {
+			if counts[k] > lexicallySortedEntryCount {
+				lentry = copies[k]
+				zoneIndex = i
+				lexicallySortedEntryCount = counts[k]
+			}
+		}
+		if len(copies) > 0 {
+			break
+		}
+	}
 
-			// Entries are duplicated across disks,
-			// we should simply skip such entries.
-			if lentry.Name == zoneEntries[i][j].Name && lentry.ModTime.Equal(zoneEntries[i][j].ModTime) {
-				lexicallySortedEntryCount++
+	for i, entriesValid := range zoneEntriesValid {
+		for j, valid := range entriesValid {
+			if !valid || zoneEntries[i][j].Name == lentry.Name {
 				continue
 			}
 
@@ -1073,17 +1209,43 @@ func lexicallySortedEntryZoneVersions(zoneEntryChs [][]FileInfoVersionsCh, zoneE
 		return lentry, 0, zoneIndex, isTruncated
 	}
 
+	// Entries are duplicated across disks, and an object may be found
+	// on several zones while it is moved between zones. Each name is
+	// returned only once, from the first zone having it as reads do,
+	// the copy found on most disks of the zone is returned.
 	lexicallySortedEntryCount := 0
 	for i, entriesValid := range zoneEntriesValid {
+		var copies []FileInfoVersions
+		var counts []int
 		for j, valid := range entriesValid {
-			if !valid {
+			if !valid || zoneEntries[i][j].Name != lentry.Name {
 				continue
 			}
+			k := 0
+			for k < len(copies) && !copies[k].LatestModTime.Equal(zoneEntries[i][j].LatestModTime) {
+				k++
+			}
+			if k == len(copies) {
+				copies = append(copies, zoneEntries[i][j])
+				counts = append(counts, 0)
+			}
+			counts[k]++
+		}
+		for k := range copies {
+			if counts[k] > lexicallySortedEntryCount {
+				lentry = copies[k]
+				zoneIndex = i
+				lexicallySortedEntryCount = counts[k]
+			}
+		}
+		if len(copies) > 0 {
+			break
+		}
+	}
 
-			// Entries are duplicated across disks,
-			// we should simply skip such entries.
-			if lentry.Name == zoneEntries[i][j].Name && lentry.LatestModTime.Equal(zoneEntries[i][j].LatestModTime) {
-				lexicallySortedEntryCount++
+	for i, entriesValid := range zoneEntriesValid {
+		for j, valid := range entriesValid {
+			if !valid || zoneEntries[i][j].Name == lentry.Name {
 				continue
 			}
 
@@ -1127,9 +1289,13 @@ func mergeZonesEntriesVersionsCh(zonesEntryChs [][]FileInfoVersionsCh, maxKeys i
 	return entries
 }
 
-// mergeZonesEntriesCh - merges FileInfo channel to entries upto maxKeys.
-func mergeZonesEntriesCh(zonesEntryChs [][]FileInfoCh, maxKeys int, ndisks int) (entries FilesInfo) {
+// mergeZonesEntriesCh - merges FileInfo channel to entries upto maxKeys,
+// the entries match returns false for are skipped, a nil match skips
+// none. The merge stops early after skipping listFilterMaxSkipped
+// entries, lastName is the name of the last entry merged or skipped.
+func mergeZonesEntriesCh(zonesEntryChs [][]FileInfoCh, maxKeys int, ndisks int, match func(FileInfo) bool) (entries FilesInfo, lastName string) {
 	var i = 0
+	var skipped int
 	var zonesEntriesInfos [][]FileInfo
 	var zonesEntriesValid [][]bool
 	for _, entryChs := range zonesEntryChs {
@@ -1148,6 +1314,16 @@ func mergeZonesEntriesCh(zonesEntryChs [][]FileInfoCh, maxKeys int, ndisks int)
 			continue
 		}
 
+		lastName = fi.Name
+		if match != nil && !match(fi) {
+			skipped++
+			if skipped == listFilterMaxSkipped {
+				entries.IsTruncated = isTruncatedZones(zonesEntryChs, zonesEntriesInfos, zonesEntriesValid)
+				break
+			}
+			continue
+		}
+
 		entries.Files = append(entries.Files, fi)
 		i++
 		if i == maxKeys {
@@ -1155,7 +1331,7 @@ func mergeZonesEntriesCh(zonesEntryChs [][]FileInfoCh, maxKeys int, ndisks int)
 			break
 		}
 	}
-	return entries
+	return entries, lastName
 }
 
 func isTruncatedZones(zoneEntryChs [][]FileInfoCh, zoneEntries [][]FileInfo, zoneEntriesValid [][]bool) bool {
@@ -1310,7 +1486,8 @@ func (z *erasureZones) listObjectVersions(ctx context.Context, bucket, prefix, m
 	for _, entry := range entries.FilesVersions {
 		for _, version := range entry.Versions {
 			objInfo := version.ToObjectInfo(bucket, entry.Name)
-			if HasSuffix(objInfo.Name, SlashSeparator) && !recursive {
+			// The directory object of the prefix is listed as an object.
+			if HasSuffix(objInfo.Name, SlashSeparator) && objInfo.Name != prefix && !recursive {
 				loi.Prefixes = append(loi.Prefixes, objInfo.Name)
 				continue
 			}
@@ -1380,7 +1557,7 @@ func (z *erasureZones) NewMultipartUpload(ctx context.Context, bucket, object st
 
 // Copies a part of an object from source hashedSet to destination hashedSet.
 func (z *erasureZones) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (PartInfo, error) {
-	if err := checkNewMultipartArgs(ctx, srcBucket, srcObject, z); err != nil {
+	if err := checkObjectArgs(ctx, srcBucket, srcObject, z); err != nil {
 		return PartInfo{}, err
 	}
 
@@ -1394,6 +1571,12 @@ func (z *erasureZones) PutObjectPart(ctx context.Context, bucket, object, upload
 		return PartInfo{}, err
 	}
 
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return PartInfo{}, err
+	}
+	defer release()
+
 	uploadIDLock := z.NewNSLock(ctx, bucket, pathJoin(object, uploadID))
 	if err := uploadIDLock.GetLock(globalOperationTimeout); err != nil {
 		return PartInfo{}, err
@@ -1534,6 +1717,12 @@ func (z *erasureZones) CompleteMultipartUpload(ctx context.Context, bucket, obje
 		return objInfo, err
 	}
 
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassForeground))
+	if err != nil {
+		return objInfo, err
+	}
+	defer release()
+
 	// Hold read-locks to verify uploaded parts, also disallows
 	// parallel part uploads as well.
 	uploadIDLock := z.NewNSLock(ctx, bucket, pathJoin(object, uploadID))
@@ -1577,16 +1766,34 @@ func (z *erasureZones) CompleteMultipartUpload(ctx context.Context, bucket, obje
 
 // GetBucketInfo - returns bucket info from one of the erasure coded zones.
 func (z *erasureZones) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
-	if z.SingleZone() {
-		bucketInfo, err = z.zones[0].GetBucketInfo(ctx, bucket)
+	bucketInfo, ok := z.bucketCache.get(bucket)
+	if !ok {
+		bucketInfo, err = z.getBucketInfo(ctx, bucket)
 		if err != nil {
 			return bucketInfo, err
 		}
-		meta, err := globalBucketMetadataSys.Get(bucket)
-		if err == nil {
-			bucketInfo.Created = meta.Created
-		}
-		return bucketInfo, nil
+		z.bucketCache.set(bucketInfo)
+	}
+	meta, err := globalBucketMetadataSys.Get(bucket)
+	if err == nil {
+		bucketInfo.Created = meta.Created
+	}
+	return bucketInfo, nil
+}
+
+// forgetBucket - removes the bucket from the caches of all the zones,
+// once deleted here or by a peer.
+func (z *erasureZones) forgetBucket(bucket string) {
+	z.bucketCache.remove(bucket)
+	for _, zone := range z.zones {
+		zone.bucketCache.remove(bucket)
+	}
+}
+
+// getBucketInfo - returns the bucket info found on the drives.
+func (z *erasureZones) getBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
+	if z.SingleZone() {
+		return z.zones[0].GetBucketInfo(ctx, bucket)
 	}
 	for _, zone := range z.zones {
 		bucketInfo, err = zone.GetBucketInfo(ctx, bucket)
@@ -1596,10 +1803,6 @@ func (z *erasureZones) GetBucketInfo(ctx context.Context, bucket string) (bucket
 			}
 			return bucketInfo, err
 		}
-		meta, err := globalBucketMetadataSys.Get(bucket)
-		if err == nil {
-			bucketInfo.Created = meta.Created
-		}
 		return bucketInfo, nil
 	}
 	return bucketInfo, BucketNotFound{
@@ -1635,8 +1838,16 @@ func (z *erasureZones) IsTaggingSupported() bool {
 // even if one of the zones fail to delete buckets, we proceed to
 // undo a successful operation.
 func (z *erasureZones) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	// Forget the bucket even when the delete fails part
+	// way, it is looked up on the drives again.
+	defer z.forgetBucket(bucket)
+
 	if z.SingleZone() {
-		return z.zones[0].DeleteBucket(ctx, bucket, forceDelete)
+		if err := z.zones[0].DeleteBucket(ctx, bucket, forceDelete); err != nil {
+			return err
+		}
+		logger.LogIf(ctx, deleteBucketSnapshots(ctx, z, bucket))
+		return nil
 	}
 	g := errgroup.WithNErrs(len(z.zones))
 
@@ -1662,6 +1873,9 @@ func (z *erasureZones) DeleteBucket(ctx context.Context, bucket string, forceDel
 		}
 	}
 
+	// The snapshots are listed across the zones.
+	logger.LogIf(ctx, deleteBucketSnapshots(ctx, z, bucket))
+
 	// Success.
 	return nil
 }
@@ -1931,6 +2145,13 @@ func (z *erasureZones) HealObjects(ctx context.Context, bucket, prefix string, o
 }
 
 func (z *erasureZones) HealObject(ctx context.Context, bucket, object, versionID string, opts madmin.HealOpts) (madmin.HealResultItem, error) {
+	// Healing is a background operation, unless requested otherwise.
+	release, err := globalIOScheduler.acquire(ctx, ioClassFromContext(ctx, ioClassHeal))
+	if err != nil {
+		return madmin.HealResultItem{}, err
+	}
+	defer release()
+
 	// Lock the object before healing. Use read lock since healing
 	// will only regenerate parts & xl.meta of outdated disks.
 	lk := z.NewNSLock(ctx, bucket, object)
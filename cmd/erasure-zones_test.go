@@ -0,0 +1,213 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+)
+
+// Tests listing objects stored on several zones, every name is listed
+// once whatever the number of keys per page.
+func TestZonesListObjectsMerge(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	endpointZones := append(mustGetZoneEndpoints(disks[:4]...), mustGetZoneEndpoints(disks[4:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureZones)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(zone int, object string, data []byte) {
+		if _, err := z.zones[zone].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	for i := 0; i < 20; i++ {
+		object := fmt.Sprintf("dir%d/object-%02d", i%3, i)
+		put(i%2, object, []byte(object))
+		names = append(names, object)
+	}
+
+	// Objects on both zones, with different modification times.
+	var duplicates []string
+	for i := 0; i < 5; i++ {
+		object := fmt.Sprintf("dir%d/copy-%d", i%3, i)
+		put(0, object, []byte("first zone"))
+		time.Sleep(10 * time.Millisecond)
+		put(1, object, []byte("second zone, newer"))
+		names = append(names, object)
+		duplicates = append(duplicates, object)
+	}
+	sort.Strings(names)
+
+	for _, maxKeys := range []int{1, 2, 3, 7, 1000} {
+		var listed []string
+		var marker string
+		for {
+			loi, err := obj.ListObjects(ctx, bucket, "", marker, "", maxKeys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, oi := range loi.Objects {
+				listed = append(listed, oi.Name)
+				for _, object := range duplicates {
+					// The copy of the first zone is listed, like it is read.
+					if oi.Name == object && oi.Size != int64(len("first zone")) {
+						t.Fatalf("maxKeys %d: expected the copy of the first zone for %s, got %d bytes", maxKeys, object, oi.Size)
+					}
+				}
+			}
+			if !loi.IsTruncated {
+				break
+			}
+			marker = loi.NextMarker
+		}
+		if fmt.Sprint(listed) != fmt.Sprint(names) {
+			t.Fatalf("maxKeys %d: expected %v, got %v", maxKeys, names, listed)
+		}
+
+		var prefixes []string
+		marker = ""
+		for {
+			loi, err := obj.ListObjects(ctx, bucket, "", marker, SlashSeparator, maxKeys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prefixes = append(prefixes, loi.Prefixes...)
+			if !loi.IsTruncated {
+				break
+			}
+			marker = loi.NextMarker
+		}
+		if fmt.Sprint(prefixes) != "[dir0/ dir1/ dir2/]" {
+			t.Fatalf("maxKeys %d: unexpected prefixes %v", maxKeys, prefixes)
+		}
+	}
+}
+
+// Tests that an object found on zones of different sizes is listed
+// from the zone it is read from, not the zone with the most disks.
+func TestZonesListObjectsDuplicatesOfDifferentSizes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disks, err := getRandomDisks(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(disks)
+
+	endpointZones := append(mustGetZoneEndpoints(disks[:4]...), mustGetZoneEndpoints(disks[4:]...)...)
+	obj, _, err := initObjectLayer(ctx, endpointZones)
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*erasureZones)
+
+	bucket, object := "bucket", "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for i, data := range []string{"first zone", "second zone, larger"} {
+		if _, err = z.zones[i].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	oi, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loi, err := obj.ListObjects(ctx, bucket, "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 1 || loi.Objects[0].ETag != oi.ETag || loi.Objects[0].Size != oi.Size {
+		t.Fatalf("expected the listed object to be the object read %v, got %v", oi, loi.Objects)
+	}
+	versions, err := obj.ListObjectVersions(ctx, bucket, "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions.Objects) != 1 || versions.Objects[0].ETag != oi.ETag {
+		t.Fatalf("expected the listed version to be the object read %v, got %v", oi, versions.Objects)
+	}
+}
+
+// Tests that a name found on several zones is returned from the first
+// zone having it, even when a later zone has it on more disks.
+func TestLexicallySortedEntryZoneFirstZone(t *testing.T) {
+	now := UTCNow()
+	zoneCopies := [][]time.Time{
+		{now, now, time.Time{}},
+		{now.Add(time.Hour), now.Add(time.Hour), now.Add(time.Hour)},
+	}
+
+	zoneEntryChs := make([][]FileInfoCh, len(zoneCopies))
+	zoneEntries := make([][]FileInfo, len(zoneCopies))
+	zoneEntriesValid := make([][]bool, len(zoneCopies))
+	versionsEntryChs := make([][]FileInfoVersionsCh, len(zoneCopies))
+	versionsEntries := make([][]FileInfoVersions, len(zoneCopies))
+	versionsEntriesValid := make([][]bool, len(zoneCopies))
+	for i, modTimes := range zoneCopies {
+		for _, modTime := range modTimes {
+			ch := make(chan FileInfo, 1)
+			vch := make(chan FileInfoVersions, 1)
+			if !modTime.IsZero() {
+				ch <- FileInfo{Volume: "bucket", Name: "object", ModTime: modTime}
+				vch <- FileInfoVersions{Volume: "bucket", Name: "object", LatestModTime: modTime}
+			}
+			close(ch)
+			close(vch)
+			zoneEntryChs[i] = append(zoneEntryChs[i], FileInfoCh{Ch: ch})
+			versionsEntryChs[i] = append(versionsEntryChs[i], FileInfoVersionsCh{Ch: vch})
+		}
+		zoneEntries[i] = make([]FileInfo, len(modTimes))
+		zoneEntriesValid[i] = make([]bool, len(modTimes))
+		versionsEntries[i] = make([]FileInfoVersions, len(modTimes))
+		versionsEntriesValid[i] = make([]bool, len(modTimes))
+	}
+
+	entry, count, zoneIndex, _ := lexicallySortedEntryZone(zoneEntryChs, zoneEntries, zoneEntriesValid)
+	if zoneIndex != 0 || count != 2 || !entry.ModTime.Equal(now) {
+		t.Fatalf("expected the entry of zone 0 found 2 times, got zone %d found %d times", zoneIndex, count)
+	}
+	ventry, count, zoneIndex, _ := lexicallySortedEntryZoneVersions(versionsEntryChs, versionsEntries, versionsEntriesValid)
+	if zoneIndex != 0 || count != 2 || !ventry.LatestModTime.Equal(now) {
+		t.Fatalf("expected the versions of zone 0 found 2 times, got zone %d found %d times", zoneIndex, count)
+	}
+}
@@ -64,6 +64,27 @@ type erasureObjects struct {
 	bp *bpool.BytePoolCap
 
 	mrfOpCh chan partialOperation
+
+	// Existing buckets, shared by all the sets.
+	bucketCache *bucketInfoCache
+}
+
+// withIOClass returns a copy of the set whose remote drives send the
+// I/O class with their requests.
+func (er erasureObjects) withIOClass(class ioClass) *erasureObjects {
+	getDisks := er.getDisks
+	er.getDisks = func() []StorageAPI {
+		disks := getDisks()
+		classDisks := make([]StorageAPI, len(disks))
+		for i, disk := range disks {
+			if client, ok := disk.(*storageRESTClient); ok {
+				disk = client.withIOClass(class)
+			}
+			classDisks[i] = disk
+		}
+		return classDisks
+	}
+	return &er
 }
 
 // NewNSLock - initialize a new namespace RWLocker instance.
@@ -148,6 +169,7 @@ func getDisksInfo(disks []StorageAPI, endpoints []string) (disksInfo []madmin.Di
 				UsedSpace:  info.Used,
 				UUID:       info.ID,
 				State:      diskErrToDriveState(err),
+				Health:     info.Health,
 			}
 			if info.Total > 0 {
 				di.Utilization = float64(info.Used / info.Total * 100)
@@ -71,7 +71,7 @@ func TestErasureEncodeDecode(t *testing.T) {
 		if test.reconstructParity {
 			err = erasure.DecodeDataAndParityBlocks(context.Background(), encoded)
 		} else {
-			err = erasure.DecodeDataBlocks(encoded)
+			err = erasure.DecodeDataBlocks(context.Background(), encoded)
 		}
 
 		if err == nil && test.shouldFail {
@@ -0,0 +1,171 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// errRPCDropped is returned by the inter-node calls dropped by the
+// fault injector.
+var errRPCDropped = errors.New("fault injection: call dropped")
+
+// Errors returned by the faulted drive operations.
+var faultStorageErrs = map[string]error{
+	madmin.FaultErrFaultyDisk:   errFaultyDisk,
+	madmin.FaultErrDiskNotFound: errDiskNotFound,
+	madmin.FaultErrDiskFull:     errDiskFull,
+	madmin.FaultErrFileNotFound: errFileNotFound,
+	madmin.FaultErrFileCorrupt:  errFileCorrupt,
+}
+
+// faultInjector injects the faults set with the admin API in the
+// drive operations and the inter-node calls, it is only used when the
+// server is started with MINIO_FAULT_INJECTION=on.
+type faultInjector struct {
+	mu    sync.RWMutex
+	rules []madmin.FaultRule
+}
+
+var globalFaultInjector = &faultInjector{}
+
+// validateFaultRules returns an error if one of the rules is invalid.
+func validateFaultRules(rules []madmin.FaultRule) error {
+	for _, rule := range rules {
+		if rule.Probability < 0 || rule.Probability > 1 || rule.Latency < 0 {
+			return errInvalidArgument
+		}
+		switch rule.Layer {
+		case madmin.FaultLayerStorage:
+			if _, ok := faultStorageErrs[rule.Error]; !ok && rule.Error != "" {
+				return errInvalidArgument
+			}
+		case madmin.FaultLayerRPC:
+			if rule.PartialWrite || (rule.Error != "" && rule.Error != madmin.FaultErrDrop) {
+				return errInvalidArgument
+			}
+		default:
+			return errInvalidArgument
+		}
+	}
+	return nil
+}
+
+// Set replaces the rules of the injector.
+func (f *faultInjector) Set(rules []madmin.FaultRule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rules = rules
+}
+
+// Rules returns the rules of the injector.
+func (f *faultInjector) Rules() []madmin.FaultRule {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return append([]madmin.FaultRule{}, f.rules...)
+}
+
+// match returns the first rule of the layer matching the operation on
+// the target, rules whose probability did not hit are skipped.
+func (f *faultInjector) match(layer, target, op string) (madmin.FaultRule, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, rule := range f.rules {
+		if rule.Layer != layer || !strings.Contains(target, rule.Target) {
+			continue
+		}
+		if len(rule.Operations) > 0 && !faultOpMatch(rule.Operations, op) {
+			continue
+		}
+		if rule.Probability > 0 && rand.Float64() >= rule.Probability {
+			continue
+		}
+		return rule, true
+	}
+	return madmin.FaultRule{}, false
+}
+
+// faultOpMatch returns true if op is one of ops, the leading slash
+// of the RPC methods is optional.
+func faultOpMatch(ops []string, op string) bool {
+	op = strings.TrimPrefix(op, SlashSeparator)
+	for _, o := range ops {
+		if strings.EqualFold(strings.TrimPrefix(o, SlashSeparator), op) {
+			return true
+		}
+	}
+	return false
+}
+
+// storageFault applies the rule matching the operation of the drive,
+// it sleeps for the latency of the rule and returns whether the writes
+// must be partial and the error to return.
+func (f *faultInjector) storageFault(drive, op string) (partial bool, err error) {
+	rule, ok := f.match(madmin.FaultLayerStorage, drive, op)
+	if !ok {
+		return false, nil
+	}
+	if rule.Latency > 0 {
+		time.Sleep(rule.Latency)
+	}
+	return rule.PartialWrite, faultStorageErrs[rule.Error]
+}
+
+// rpcFault applies the rule matching the call to the server, it sleeps
+// for the latency of the rule and returns errRPCDropped if the call
+// must be dropped.
+func (f *faultInjector) rpcFault(ctx context.Context, host, method string) error {
+	rule, ok := f.match(madmin.FaultLayerRPC, host, method)
+	if !ok {
+		return nil
+	}
+	if rule.Latency > 0 {
+		timer := time.NewTimer(rule.Latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if rule.Error == madmin.FaultErrDrop {
+		return errRPCDropped
+	}
+	return nil
+}
+
+// newRPCFaultFn returns the fault function of the REST clients of the
+// given host, nil when fault injection is disabled. The calls setting
+// the faults are never faulted, so the faults can always be removed.
+func newRPCFaultFn(host string) func(ctx context.Context, method string) error {
+	if !globalFaultInjection {
+		return nil
+	}
+	return func(ctx context.Context, method string) error {
+		if method == peerRESTMethodSetFaults {
+			return nil
+		}
+		return globalFaultInjector.rpcFault(ctx, host, method)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestValidateFaultRules(t *testing.T) {
+	testCases := []struct {
+		rule    madmin.FaultRule
+		success bool
+	}{
+		{madmin.FaultRule{Layer: madmin.FaultLayerStorage, Error: madmin.FaultErrFaultyDisk}, true},
+		{madmin.FaultRule{Layer: madmin.FaultLayerStorage, PartialWrite: true}, true},
+		{madmin.FaultRule{Layer: madmin.FaultLayerRPC, Error: madmin.FaultErrDrop, Probability: 0.5}, true},
+		{madmin.FaultRule{Layer: madmin.FaultLayerRPC, Latency: time.Second}, true},
+		{madmin.FaultRule{Layer: "network"}, false},
+		{madmin.FaultRule{Layer: madmin.FaultLayerStorage, Error: madmin.FaultErrDrop}, false},
+		{madmin.FaultRule{Layer: madmin.FaultLayerRPC, Error: madmin.FaultErrDiskFull}, false},
+		{madmin.FaultRule{Layer: madmin.FaultLayerRPC, PartialWrite: true}, false},
+		{madmin.FaultRule{Layer: madmin.FaultLayerStorage, Probability: 2}, false},
+		{madmin.FaultRule{Layer: madmin.FaultLayerStorage, Latency: -time.Second}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := validateFaultRules([]madmin.FaultRule{testCase.rule})
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestFaultInjectorRPC(t *testing.T) {
+	f := &faultInjector{}
+	f.Set([]madmin.FaultRule{
+		{Layer: madmin.FaultLayerRPC, Target: "node2", Operations: []string{"lock"}, Error: madmin.FaultErrDrop},
+		{Layer: madmin.FaultLayerRPC, Target: "node3", Latency: time.Hour},
+	})
+
+	ctx := context.Background()
+	if err := f.rpcFault(ctx, "node2:9000", lockRESTMethodLock); err != errRPCDropped {
+		t.Fatalf("expected %v, got %v", errRPCDropped, err)
+	}
+	if err := f.rpcFault(ctx, "node2:9000", lockRESTMethodUnlock); err != nil {
+		t.Fatalf("expected no fault for another method, got %v", err)
+	}
+	if err := f.rpcFault(ctx, "node1:9000", lockRESTMethodLock); err != nil {
+		t.Fatalf("expected no fault for another host, got %v", err)
+	}
+
+	// The latency is interrupted by the context.
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := f.rpcFault(ctx, "node3:9000", lockRESTMethodLock); err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestFaultyStorage(t *testing.T) {
+	disk, diskPath, err := newXLStorageTestSetup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(diskPath)
+
+	defer func(enabled bool) { globalFaultInjection = enabled }(globalFaultInjection)
+	globalFaultInjection = true
+	defer globalFaultInjector.Set(nil)
+
+	endpoint, err := NewEndpoint(diskPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storage := newFaultyStorage(disk, endpoint)
+	if err = storage.MakeVol("bucket"); err != nil {
+		t.Fatal(err)
+	}
+
+	globalFaultInjector.Set([]madmin.FaultRule{
+		{Layer: madmin.FaultLayerStorage, Operations: []string{"ReadAll"}, Error: madmin.FaultErrFaultyDisk},
+		{Layer: madmin.FaultLayerStorage, Operations: []string{"WriteAll", "AppendFile"}, PartialWrite: true},
+	})
+
+	// Partial writes are silent without an error.
+	if err = storage.WriteAll("bucket", "object", bytes.NewReader([]byte("abcdef"))); err != nil {
+		t.Fatal(err)
+	}
+	if err = storage.AppendFile("bucket", "object", []byte("ghij")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = storage.ReadAll("bucket", "object"); err != errFaultyDisk {
+		t.Fatalf("expected %v, got %v", errFaultyDisk, err)
+	}
+
+	globalFaultInjector.Set(nil)
+	data, err := storage.ReadAll("bucket", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "abcgh" {
+		t.Fatalf("expected the first half of the writes, got %q", data)
+	}
+
+	// Rules of other drives are not applied.
+	globalFaultInjector.Set([]madmin.FaultRule{
+		{Layer: madmin.FaultLayerStorage, Target: "/not-this-drive", Error: madmin.FaultErrDiskNotFound},
+	})
+	if _, err = storage.ReadAll("bucket", "object"); err != nil {
+		t.Fatal(err)
+	}
+}
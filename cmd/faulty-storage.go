@@ -0,0 +1,250 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+)
+
+// faultyStorage wraps a local disk and injects the faults of
+// globalFaultInjector in its operations. The operations not
+// overridden here are never faulted.
+type faultyStorage struct {
+	StorageAPI
+	endpoint string
+}
+
+// newFaultyStorage wraps the disk of the endpoint when fault injection
+// is enabled, the disk is returned as is otherwise.
+func newFaultyStorage(disk StorageAPI, endpoint Endpoint) StorageAPI {
+	if !globalFaultInjection {
+		return disk
+	}
+	return &faultyStorage{StorageAPI: disk, endpoint: endpoint.String()}
+}
+
+func (d *faultyStorage) fault(op string) error {
+	_, err := globalFaultInjector.storageFault(d.endpoint, op)
+	return err
+}
+
+func (d *faultyStorage) DiskInfo() (info DiskInfo, err error) {
+	if err = d.fault("DiskInfo"); err != nil {
+		return info, err
+	}
+	return d.StorageAPI.DiskInfo()
+}
+
+func (d *faultyStorage) MakeVol(volume string) (err error) {
+	if err = d.fault("MakeVol"); err != nil {
+		return err
+	}
+	return d.StorageAPI.MakeVol(volume)
+}
+
+func (d *faultyStorage) MakeVolBulk(volumes ...string) (err error) {
+	if err = d.fault("MakeVolBulk"); err != nil {
+		return err
+	}
+	return d.StorageAPI.MakeVolBulk(volumes...)
+}
+
+func (d *faultyStorage) ListVols() (vols []VolInfo, err error) {
+	if err = d.fault("ListVols"); err != nil {
+		return nil, err
+	}
+	return d.StorageAPI.ListVols()
+}
+
+func (d *faultyStorage) StatVol(volume string) (vol VolInfo, err error) {
+	if err = d.fault("StatVol"); err != nil {
+		return vol, err
+	}
+	return d.StorageAPI.StatVol(volume)
+}
+
+func (d *faultyStorage) DeleteVol(volume string, forceDelete bool) (err error) {
+	if err = d.fault("DeleteVol"); err != nil {
+		return err
+	}
+	return d.StorageAPI.DeleteVol(volume, forceDelete)
+}
+
+func (d *faultyStorage) DeleteVersion(volume, path string, fi FileInfo) (err error) {
+	if err = d.fault("DeleteVersion"); err != nil {
+		return err
+	}
+	return d.StorageAPI.DeleteVersion(volume, path, fi)
+}
+
+func (d *faultyStorage) DeleteVersions(volume string, versions []FileInfo) []error {
+	if err := d.fault("DeleteVersions"); err != nil {
+		errs := make([]error, len(versions))
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	return d.StorageAPI.DeleteVersions(volume, versions)
+}
+
+func (d *faultyStorage) WriteMetadata(volume, path string, fi FileInfo) (err error) {
+	if err = d.fault("WriteMetadata"); err != nil {
+		return err
+	}
+	return d.StorageAPI.WriteMetadata(volume, path, fi)
+}
+
+func (d *faultyStorage) ReadVersion(volume, path, versionID string) (fi FileInfo, err error) {
+	if err = d.fault("ReadVersion"); err != nil {
+		return fi, err
+	}
+	return d.StorageAPI.ReadVersion(volume, path, versionID)
+}
+
+func (d *faultyStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath string) (err error) {
+	if err = d.fault("RenameData"); err != nil {
+		return err
+	}
+	return d.StorageAPI.RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath)
+}
+
+func (d *faultyStorage) ListDir(volume, dirPath string, count int) (entries []string, err error) {
+	if err = d.fault("ListDir"); err != nil {
+		return nil, err
+	}
+	return d.StorageAPI.ListDir(volume, dirPath, count)
+}
+
+func (d *faultyStorage) ReadFile(volume string, path string, offset int64, buf []byte, verifier *BitrotVerifier) (n int64, err error) {
+	if err = d.fault("ReadFile"); err != nil {
+		return 0, err
+	}
+	return d.StorageAPI.ReadFile(volume, path, offset, buf, verifier)
+}
+
+func (d *faultyStorage) ReadFileStream(volume, path string, offset, length int64) (io.ReadCloser, error) {
+	if err := d.fault("ReadFileStream"); err != nil {
+		return nil, err
+	}
+	return d.StorageAPI.ReadFileStream(volume, path, offset, length)
+}
+
+func (d *faultyStorage) RenameFile(srcVolume, srcPath, dstVolume, dstPath string) (err error) {
+	if err = d.fault("RenameFile"); err != nil {
+		return err
+	}
+	return d.StorageAPI.RenameFile(srcVolume, srcPath, dstVolume, dstPath)
+}
+
+func (d *faultyStorage) CheckParts(volume string, path string, fi FileInfo) (err error) {
+	if err = d.fault("CheckParts"); err != nil {
+		return err
+	}
+	return d.StorageAPI.CheckParts(volume, path, fi)
+}
+
+func (d *faultyStorage) CheckFile(volume string, path string) (err error) {
+	if err = d.fault("CheckFile"); err != nil {
+		return err
+	}
+	return d.StorageAPI.CheckFile(volume, path)
+}
+
+func (d *faultyStorage) DeleteFile(volume string, path string) (err error) {
+	if err = d.fault("DeleteFile"); err != nil {
+		return err
+	}
+	return d.StorageAPI.DeleteFile(volume, path)
+}
+
+func (d *faultyStorage) SecureErase(volume, path string, fi FileInfo) (err error) {
+	if err = d.fault("SecureErase"); err != nil {
+		return err
+	}
+	return d.StorageAPI.SecureErase(volume, path, fi)
+}
+
+func (d *faultyStorage) VerifyFile(volume, path string, fi FileInfo) (err error) {
+	if err = d.fault("VerifyFile"); err != nil {
+		return err
+	}
+	return d.StorageAPI.VerifyFile(volume, path, fi)
+}
+
+func (d *faultyStorage) ReadAll(volume string, path string) (buf []byte, err error) {
+	if err = d.fault("ReadAll"); err != nil {
+		return nil, err
+	}
+	return d.StorageAPI.ReadAll(volume, path)
+}
+
+// The writes below only write the first half of the data when the
+// rule asks for partial writes, and then return the error of the rule,
+// nil for silently torn writes.
+
+func (d *faultyStorage) AppendFile(volume string, path string, buf []byte) (err error) {
+	partial, ferr := globalFaultInjector.storageFault(d.endpoint, "AppendFile")
+	if !partial {
+		if ferr != nil {
+			return ferr
+		}
+		return d.StorageAPI.AppendFile(volume, path, buf)
+	}
+	if err = d.StorageAPI.AppendFile(volume, path, buf[:len(buf)/2]); err != nil {
+		return err
+	}
+	return ferr
+}
+
+func (d *faultyStorage) CreateFile(volume, path string, size int64, reader io.Reader) (err error) {
+	partial, ferr := globalFaultInjector.storageFault(d.endpoint, "CreateFile")
+	if !partial || size <= 0 {
+		if ferr != nil {
+			return ferr
+		}
+		return d.StorageAPI.CreateFile(volume, path, size, reader)
+	}
+	if err = d.StorageAPI.CreateFile(volume, path, size/2, io.LimitReader(reader, size/2)); err != nil {
+		return err
+	}
+	// Consume the rest of the data, the writer would fail otherwise.
+	if _, err = io.Copy(ioutil.Discard, reader); err != nil {
+		return err
+	}
+	return ferr
+}
+
+func (d *faultyStorage) WriteAll(volume string, path string, reader io.Reader) (err error) {
+	partial, ferr := globalFaultInjector.storageFault(d.endpoint, "WriteAll")
+	if !partial {
+		if ferr != nil {
+			return ferr
+		}
+		return d.StorageAPI.WriteAll(volume, path, reader)
+	}
+	buf, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	if err = d.StorageAPI.WriteAll(volume, path, bytes.NewReader(buf[:len(buf)/2])); err != nil {
+		return err
+	}
+	return ferr
+}
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"reflect"
+	"strconv"
 	"sync"
 
 	humanize "github.com/dustin/go-humanize"
@@ -127,6 +128,12 @@ type formatErasureV3 struct {
 		// Distribution algorithm represents the hashing algorithm
 		// to pick the right set index for an object.
 		DistributionAlgo string `json:"distributionAlgo"`
+		// Parity of the standard storage class chosen from the
+		// drives per set and their failure domains at format time.
+		Parity int `json:"parity,omitempty"`
+		// Failure domains of the hosts the drives of the sets
+		// were spread across at format time.
+		FailureDomains failureDomains `json:"failureDomains,omitempty"`
 	} `json:"xl"`
 }
 
@@ -186,35 +193,79 @@ func formatGetBackendErasureVersion(formatPath string) (string, error) {
 	return format.Erasure.Version, nil
 }
 
+// formatErasureVersionLatest - version of `format.json` written by this
+// release, the disks with previous versions are migrated at startup.
+const formatErasureVersionLatest = formatErasureVersionV3
+
+// formatErasureMigration - migrates `format.json` of a disk, and the
+// layout it describes, from one version to the next one.
+type formatErasureMigration struct {
+	from, to string
+	migrate  func(export, version string) error
+}
+
+// formatErasureMigrations - registry of the `format.json` migrations in
+// version order. Layout changes add their migration here and update
+// formatErasureVersionLatest.
+var formatErasureMigrations = []formatErasureMigration{
+	{formatErasureVersionV1, formatErasureVersionV2, formatErasureMigrateV1ToV2},
+	{formatErasureVersionV2, formatErasureVersionV3, formatErasureMigrateV2ToV3},
+}
+
+// formatErasureMigrationPlan - returns the migrations upgrading a disk
+// from version to formatErasureVersionLatest, the pre-flight check
+// refuses versions it can not upgrade, including the versions written
+// by newer releases.
+func formatErasureMigrationPlan(export, version string) ([]formatErasureMigration, error) {
+	var plan []formatErasureMigration
+	for _, m := range formatErasureMigrations {
+		if m.from == version {
+			plan = append(plan, m)
+			version = m.to
+		}
+	}
+	if version == formatErasureVersionLatest {
+		return plan, nil
+	}
+	if v, err := strconv.Atoi(version); err == nil {
+		if latest, _ := strconv.Atoi(formatErasureVersionLatest); v > latest {
+			return nil, fmt.Errorf(`%s: format version %s was written by a newer release, expected %s or older, downgrading is not supported`,
+				export, version, formatErasureVersionLatest)
+		}
+	}
+	return nil, fmt.Errorf(`%s: unknown format version %s`, export, version)
+}
+
 // Migrates all previous versions to latest version of `format.json`,
 // this code calls migration in sequence, such as V1 is migrated to V2
-// first before it V2 migrates to V3.
+// first before it V2 migrates to V3. The previous `format.json` is
+// restored when a migration fails.
 func formatErasureMigrate(export string) error {
 	formatPath := pathJoin(export, minioMetaBucket, formatConfigFile)
 	version, err := formatGetBackendErasureVersion(formatPath)
 	if err != nil {
 		return err
 	}
-	switch version {
-	case formatErasureVersionV1:
-		if err = formatErasureMigrateV1ToV2(export, version); err != nil {
-			return err
-		}
-		// Migrate successful v1 => v2, proceed to v2 => v3
-		version = formatErasureVersionV2
-		fallthrough
-	case formatErasureVersionV2:
-		if err = formatErasureMigrateV2ToV3(export, version); err != nil {
-			return err
+	plan, err := formatErasureMigrationPlan(export, version)
+	if err != nil || len(plan) == 0 {
+		return err
+	}
+
+	b, err := ioutil.ReadFile(formatPath)
+	if err != nil {
+		return err
+	}
+	for _, m := range plan {
+		if err = m.migrate(export, version); err != nil {
+			if rerr := ioutil.WriteFile(formatPath, b, 0644); rerr != nil {
+				return fmt.Errorf(`%s: unable to migrate format version %s to %s: %w, unable to restore format version %s: %v`,
+					export, m.from, m.to, err, plan[0].from, rerr)
+			}
+			return fmt.Errorf(`%s: unable to migrate format version %s to %s: %w`, export, m.from, m.to, err)
 		}
-		// Migrate successful v2 => v3, v3 is latest
-		// version = formatXLVersionV3
-		fallthrough
-	case formatErasureVersionV3:
-		// v3 is the latest version, return.
-		return nil
+		version = m.to
 	}
-	return fmt.Errorf(`%s: unknown format version %s`, export, version)
+	return nil
 }
 
 // Migrates version V1 of format.json to version V2 of format.json,
@@ -283,7 +334,9 @@ func formatErasureMigrateV2ToV3(export, version string) error {
 
 	formatV3.Version = formatV2.Version
 	formatV3.Format = formatV2.Format
-	formatV3.Erasure = formatV2.Erasure
+	formatV3.Erasure.This = formatV2.Erasure.This
+	formatV3.Erasure.Sets = formatV2.Erasure.Sets
+	formatV3.Erasure.DistributionAlgo = formatV2.Erasure.DistributionAlgo
 
 	formatV3.Erasure.Version = formatErasureVersionV3
 
@@ -467,6 +520,11 @@ func checkFormatErasureValues(formats []*formatErasureV3, drivesPerSet int) erro
 		if globalCustomErasureDriveCount && len(formatErasure.Erasure.Sets[0]) != drivesPerSet {
 			return fmt.Errorf("%s disk is already formatted with %d drives per erasure set. This cannot be changed to %d, please revert your MINIO_ERASURE_SET_DRIVE_COUNT setting", humanize.Ordinal(i+1), len(formatErasure.Erasure.Sets[0]), drivesPerSet)
 		}
+		// The sets were laid out across the failure domains,
+		// the drives would be out of place with other ones.
+		if !formatErasure.Erasure.FailureDomains.equal(globalFailureDomains) {
+			return fmt.Errorf("%s disk is already formatted with other failure domains, please revert your MINIO_ERASURE_FAILURE_DOMAINS setting", humanize.Ordinal(i+1))
+		}
 	}
 	return nil
 }
@@ -807,10 +865,19 @@ func fixFormatErasureV3(storageDisks []StorageAPI, endpoints Endpoints, formats
 func initFormatErasure(ctx context.Context, storageDisks []StorageAPI, setCount, drivesPerSet int, deploymentID string) (*formatErasureV3, error) {
 	format := newFormatErasureV3(setCount, drivesPerSet)
 	formats := make([]*formatErasureV3, len(storageDisks))
-	wantAtMost := ecDrivesNoConfig(drivesPerSet)
+
+	if globalErasureAutoParity {
+		hosts := make([]string, len(storageDisks))
+		for i, disk := range storageDisks {
+			hosts[i] = disk.Hostname()
+		}
+		format.Erasure.Parity = autoParity(drivesPerSet, failureDomainDrives(hosts, drivesPerSet))
+	}
+	format.Erasure.FailureDomains = globalFailureDomains
+	wantAtMost := ecDrivesNoConfig(drivesPerSet, format.Erasure.Parity)
 
 	for i := 0; i < setCount; i++ {
-		hostCount := make(map[string]int, drivesPerSet)
+		domainCount := make(map[string]int, drivesPerSet)
 		for j := 0; j < drivesPerSet; j++ {
 			disk := storageDisks[i*drivesPerSet+j]
 			newFormat := format.Clone()
@@ -818,18 +885,18 @@ func initFormatErasure(ctx context.Context, storageDisks []StorageAPI, setCount,
 			if deploymentID != "" {
 				newFormat.ID = deploymentID
 			}
-			hostCount[disk.Hostname()]++
+			domainCount[globalFailureDomains.domain(disk.Hostname())]++
 			formats[i*drivesPerSet+j] = newFormat
 		}
-		if len(hostCount) > 0 {
+		if len(domainCount) > 0 {
 			var once sync.Once
-			for host, count := range hostCount {
+			for domain, count := range domainCount {
 				if count > wantAtMost {
-					if host == "" {
-						host = "local"
+					if domain == "" {
+						domain = "local"
 					}
 					once.Do(func() {
-						if len(hostCount) == 1 {
+						if len(domainCount) == 1 {
 							return
 						}
 						logger.Info(" * Set %v:", i+1)
@@ -838,8 +905,8 @@ func initFormatErasure(ctx context.Context, storageDisks []StorageAPI, setCount,
 							logger.Info("   - Drive: %s", disk.String())
 						}
 					})
-					logger.Info(color.Yellow("WARNING:")+" Host %v has more than %v drives of set. "+
-						"A host failure will result in data becoming unavailable.", host, wantAtMost)
+					logger.Info(color.Yellow("WARNING:")+" Failure domain %v has more than %v drives of set. "+
+						"A failure of the domain will result in data becoming unavailable.", domain, wantAtMost)
 				}
 			}
 		}
@@ -854,11 +921,11 @@ func initFormatErasure(ctx context.Context, storageDisks []StorageAPI, setCount,
 }
 
 // ecDrivesNoConfig returns the erasure coded drives in a set if no config has been set.
-// It will attempt to read it from env variable and fall back to drives/2.
-func ecDrivesNoConfig(drivesPerSet int) int {
+// It will attempt to read it from env variable and fall back to the default parity.
+func ecDrivesNoConfig(drivesPerSet, defaultParity int) int {
 	ecDrives := globalStorageClass.GetParityForSC(storageclass.STANDARD)
 	if ecDrives == 0 {
-		cfg, err := storageclass.LookupConfig(nil, drivesPerSet)
+		cfg, err := storageclass.LookupConfig(nil, drivesPerSet, defaultParity)
 		if err == nil {
 			ecDrives = cfg.Standard.Parity
 		}
@@ -947,6 +1014,8 @@ func newHealFormatSets(refFormat *formatErasureV3, setCount, drivesPerSet int, f
 				newFormats[i][j].Format = refFormat.Format
 				newFormats[i][j].Erasure.Version = refFormat.Erasure.Version
 				newFormats[i][j].Erasure.DistributionAlgo = refFormat.Erasure.DistributionAlgo
+				newFormats[i][j].Erasure.Parity = refFormat.Erasure.Parity
+				newFormats[i][j].Erasure.FailureDomains = refFormat.Erasure.FailureDomains
 			}
 			if errs[i*drivesPerSet+j] == errUnformattedDisk {
 				newFormats[i][j].Erasure.This = ""
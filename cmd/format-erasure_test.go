@@ -250,6 +250,74 @@ func TestFormatErasureMigrate(t *testing.T) {
 	}
 }
 
+// Tests the pre-flight check and the rollback of format migrations.
+func TestFormatErasureMigrationPlan(t *testing.T) {
+	testCases := []struct {
+		version string
+		steps   int
+		success bool
+	}{
+		{formatErasureVersionV1, 2, true},
+		{formatErasureVersionV2, 1, true},
+		{formatErasureVersionV3, 0, true},
+		{"4", 0, false},
+		{"unknown", 0, false},
+	}
+	for i, testCase := range testCases {
+		plan, err := formatErasureMigrationPlan("/export", testCase.version)
+		if testCase.success != (err == nil) {
+			t.Errorf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if len(plan) != testCase.steps {
+			t.Errorf("Test %d: expected %d migrations, got %d", i+1, testCase.steps, len(plan))
+		}
+	}
+
+	rootPath, err := getTestRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootPath)
+
+	m := &formatErasureV1{}
+	m.Format = formatBackendErasure
+	m.Version = formatMetaVersionV1
+	m.Erasure.Version = formatErasureVersionV1
+	m.Erasure.Disk = mustGetUUID()
+	m.Erasure.JBOD = []string{m.Erasure.Disk, mustGetUUID()}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	formatPath := pathJoin(rootPath, minioMetaBucket, formatConfigFile)
+	if err = os.MkdirAll(pathJoin(rootPath, minioMetaBucket), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(formatPath, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failing migrations restore the previous format.
+	migrations := formatErasureMigrations
+	defer func() { formatErasureMigrations = migrations }()
+	formatErasureMigrations = []formatErasureMigration{
+		migrations[0],
+		{formatErasureVersionV2, formatErasureVersionV3, func(export, version string) error {
+			return errUnexpected
+		}},
+	}
+	if err = formatErasureMigrate(rootPath); err == nil {
+		t.Fatal("Expected the migration to fail")
+	}
+	version, err := formatGetBackendErasureVersion(formatPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != formatErasureVersionV1 {
+		t.Fatalf("expected version %s to be restored, got %s", formatErasureVersionV1, version)
+	}
+}
+
 // Tests check format xl value.
 func TestCheckFormatErasureValue(t *testing.T) {
 	testCases := []struct {
@@ -264,10 +332,12 @@ func TestCheckFormatErasureValue(t *testing.T) {
 					Format:  "Erasure",
 				},
 				Erasure: struct {
-					Version          string     `json:"version"`
-					This             string     `json:"this"`
-					Sets             [][]string `json:"sets"`
-					DistributionAlgo string     `json:"distributionAlgo"`
+					Version          string         `json:"version"`
+					This             string         `json:"this"`
+					Sets             [][]string     `json:"sets"`
+					DistributionAlgo string         `json:"distributionAlgo"`
+					Parity           int            `json:"parity,omitempty"`
+					FailureDomains   failureDomains `json:"failureDomains,omitempty"`
 				}{
 					Version: "2",
 				},
@@ -282,10 +352,12 @@ func TestCheckFormatErasureValue(t *testing.T) {
 					Format:  "Unknown",
 				},
 				Erasure: struct {
-					Version          string     `json:"version"`
-					This             string     `json:"this"`
-					Sets             [][]string `json:"sets"`
-					DistributionAlgo string     `json:"distributionAlgo"`
+					Version          string         `json:"version"`
+					This             string         `json:"this"`
+					Sets             [][]string     `json:"sets"`
+					DistributionAlgo string         `json:"distributionAlgo"`
+					Parity           int            `json:"parity,omitempty"`
+					FailureDomains   failureDomains `json:"failureDomains,omitempty"`
 				}{
 					Version: "2",
 				},
@@ -300,10 +372,12 @@ func TestCheckFormatErasureValue(t *testing.T) {
 					Format:  "Erasure",
 				},
 				Erasure: struct {
-					Version          string     `json:"version"`
-					This             string     `json:"this"`
-					Sets             [][]string `json:"sets"`
-					DistributionAlgo string     `json:"distributionAlgo"`
+					Version          string         `json:"version"`
+					This             string         `json:"this"`
+					Sets             [][]string     `json:"sets"`
+					DistributionAlgo string         `json:"distributionAlgo"`
+					Parity           int            `json:"parity,omitempty"`
+					FailureDomains   failureDomains `json:"failureDomains,omitempty"`
 				}{
 					Version: "0",
 				},
@@ -447,6 +521,7 @@ func TestNewFormatSets(t *testing.T) {
 	drivesPerSet := 16
 
 	format := newFormatErasureV3(setCount, drivesPerSet)
+	format.Erasure.Parity = 4
 	formats := make([]*formatErasureV3, 32)
 	errs := make([]error, 32)
 
@@ -471,12 +546,15 @@ func TestNewFormatSets(t *testing.T) {
 		t.Fatal("Unexpected failure")
 	}
 
-	// Check if deployment IDs are preserved.
+	// Check if deployment IDs and parity are preserved.
 	for i := range newFormats {
 		for j := range newFormats[i] {
 			if newFormats[i][j].ID != quorumFormat.ID {
 				t.Fatal("Deployment id in the new format is lost")
 			}
+			if newFormats[i][j].Erasure.Parity != quorumFormat.Erasure.Parity {
+				t.Fatal("Parity in the new format is lost")
+			}
 		}
 	}
 }
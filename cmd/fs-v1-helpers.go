@@ -22,6 +22,7 @@ import (
 	"os"
 	pathutil "path"
 	"runtime"
+	"strings"
 
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/lock"
@@ -379,6 +380,68 @@ func fsSimpleRenameFile(ctx context.Context, sourcePath, destPath string) error
 	return nil
 }
 
+// fsIsCaseInsensitive - returns true if the filesystem at dirPath
+// resolves names differing only in case to the same file, as
+// commonly configured on macOS and Windows.
+func fsIsCaseInsensitive(dirPath string) (bool, error) {
+	probePath := pathJoin(dirPath, "case-probe")
+	f, err := os.OpenFile(probePath, os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return false, osErrToFileErr(err)
+	}
+	f.Close()
+	defer os.Remove(probePath)
+
+	_, err = os.Lstat(pathJoin(dirPath, "CASE-PROBE"))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, osErrToFileErr(err)
+}
+
+// checkCaseConflict - on case insensitive filesystems returns an error
+// if the object or one of its parent prefixes already exists under
+// a name differing only in case, writing it would silently update
+// the existing object or prefix.
+func (fs *FSObjects) checkCaseConflict(bucket, object string) error {
+	if !fs.caseInsensitive {
+		return nil
+	}
+
+	dirPath := pathJoin(fs.fsPath, bucket)
+	for _, name := range strings.Split(strings.TrimSuffix(object, SlashSeparator), SlashSeparator) {
+		entries, err := readDir(dirPath)
+		if err != nil {
+			return nil
+		}
+
+		var exact, folded bool
+		for _, entry := range entries {
+			entry = strings.TrimSuffix(entry, SlashSeparator)
+			if entry == name {
+				exact = true
+				break
+			}
+			if strings.EqualFold(entry, name) {
+				folded = true
+			}
+		}
+		if !exact {
+			if folded {
+				return ObjectNameCaseConflict{Bucket: bucket, Object: object}
+			}
+			// Nothing exists with this name, names which only differ
+			// in their unicode normalization form are not reported.
+			return nil
+		}
+		dirPath = pathJoin(dirPath, name)
+	}
+	return nil
+}
+
 // Renames source path to destination path, creates all the
 // missing parents if they don't exist.
 func fsRenameFile(ctx context.Context, sourcePath, destPath string) error {
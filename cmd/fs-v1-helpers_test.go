@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"runtime"
 	"testing"
 
 	"github.com/minio/minio/pkg/lock"
@@ -564,3 +565,28 @@ func TestFSIsFile(t *testing.T) {
 		t.Fatalf("Expected %s to be a file", filePath)
 	}
 }
+
+func TestFSIsCaseInsensitive(t *testing.T) {
+	dir, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	caseInsensitive, err := fsIsCaseInsensitive(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Probe files are always removed.
+	entries, err := readDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("Unexpected entries %v", entries)
+	}
+
+	if runtime.GOOS == "linux" && caseInsensitive {
+		t.Fatal("Expected a case sensitive filesystem")
+	}
+}
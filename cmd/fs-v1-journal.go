@@ -0,0 +1,197 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/lock"
+)
+
+// Updates of `fs.json` are journaled, a journal entry is saved before
+// the object is renamed into place or its `fs.json` is rewritten and
+// removed once `fs.json` is written. Entries left behind by a crash are
+// replayed on startup, restoring the `fs.json` of objects whose data
+// was committed.
+//
+// Each server writes its journal under its own directory, locked for
+// its lifetime, so that servers sharing a backend only replay the
+// journals of servers which are no longer running.
+//
+//   .minio.sys/journal/<fsUUID>/lock
+//   .minio.sys/journal/<fsUUID>/<entry-uuid>.json
+const (
+	fsJournalDir      = "journal"
+	fsJournalLockFile = "lock"
+
+	fsJournalVersion1 = "1"
+)
+
+// fsJournalEntry - a pending update of `fs.json`, applied only if the
+// object data file still has the recorded size and modification time.
+type fsJournalEntry struct {
+	Version string   `json:"version"`
+	Bucket  string   `json:"bucket"`
+	Object  string   `json:"object"`
+	Size    int64    `json:"size"`
+	ModTime int64    `json:"modTime"`
+	Meta    fsMetaV1 `json:"meta"`
+}
+
+func (e fsJournalEntry) matches(fi os.FileInfo) bool {
+	return fi.Size() == e.Size && fi.ModTime().UnixNano() == e.ModTime
+}
+
+// initJournal - locks the journal of this server and replays the
+// journals left behind by servers which are no longer running.
+func (fs *FSObjects) initJournal(ctx context.Context) error {
+	journalDir := pathJoin(fs.fsPath, minioMetaBucket, fsJournalDir)
+	if err := os.MkdirAll(pathJoin(journalDir, fs.fsUUID), 0777); err != nil {
+		return err
+	}
+
+	lk, err := lock.TryLockedOpenFile(pathJoin(journalDir, fs.fsUUID, fsJournalLockFile), os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	fs.journalLk = lk
+
+	entries, err := readDir(journalDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		uuid := strings.TrimSuffix(entry, SlashSeparator)
+		if uuid == fs.fsUUID {
+			continue
+		}
+		fs.replayJournal(ctx, pathJoin(journalDir, uuid))
+	}
+	return nil
+}
+
+// replayJournal - replays all the entries of the journal at dirPath
+// and removes it, unless its server is still running.
+func (fs *FSObjects) replayJournal(ctx context.Context, dirPath string) {
+	lk, err := lock.TryLockedOpenFile(pathJoin(dirPath, fsJournalLockFile), os.O_RDWR|os.O_CREATE, 0666)
+	if err != nil {
+		// Journal still in use or not a journal.
+		return
+	}
+	defer lk.Close()
+
+	entries, err := readDir(dirPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	for _, entry := range entries {
+		if entry == fsJournalLockFile {
+			continue
+		}
+		fs.replayJournalEntry(ctx, pathJoin(dirPath, entry))
+	}
+
+	// Remove the lock file while still holding the lock.
+	fsRemoveAll(ctx, dirPath)
+}
+
+func (fs *FSObjects) replayJournalEntry(ctx context.Context, entryPath string) {
+	data, err := ioutil.ReadFile(entryPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	var e fsJournalEntry
+	if err = json.Unmarshal(data, &e); err != nil || e.Version != fsJournalVersion1 {
+		// Partially written entry, the object
+		// was not updated ignore it.
+		return
+	}
+
+	fi, err := os.Stat(pathJoin(fs.fsPath, e.Bucket, e.Object))
+	if err != nil || !e.matches(fi) {
+		// Object was not committed or was
+		// updated since, nothing to restore.
+		return
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, e.Bucket, e.Object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	defer wlk.Close()
+
+	if _, err = e.Meta.WriteTo(wlk); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// journalBegin - saves a journal entry for writing fsMeta as the
+// `fs.json` of the object whose data file is described by fi, returns
+// the path of the entry to be passed to journalEnd.
+func (fs *FSObjects) journalBegin(ctx context.Context, bucket, object string, fi os.FileInfo, fsMeta fsMetaV1) (string, error) {
+	data, err := json.Marshal(fsJournalEntry{
+		Version: fsJournalVersion1,
+		Bucket:  bucket,
+		Object:  object,
+		Size:    fi.Size(),
+		ModTime: fi.ModTime().UnixNano(),
+		Meta:    fsMeta,
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return "", err
+	}
+
+	entryPath := pathJoin(fs.fsPath, minioMetaBucket, fsJournalDir, fs.fsUUID, mustGetUUID()+".json")
+	f, err := os.OpenFile(entryPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0666)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return "", osErrToFileErr(err)
+	}
+	if _, err = f.Write(data); err == nil && globalFSOSync {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		logger.LogIf(ctx, err)
+		os.Remove(entryPath)
+		return "", err
+	}
+	return entryPath, nil
+}
+
+// journalEnd - removes the journal entry once `fs.json` is written.
+func (fs *FSObjects) journalEnd(ctx context.Context, entryPath string) {
+	if entryPath == "" {
+		return
+	}
+	if err := os.Remove(entryPath); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFSJournalReplay - `fs.json` updates interrupted by a crash are
+// restored on startup, journals of running servers are not replayed.
+func TestFSJournalReplay(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	bucketName := "bucket"
+	objectName := "object"
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Completed updates leave no journal entries.
+	journalDir := filepath.Join(disk, minioMetaBucket, fsJournalDir, fs.fsUUID)
+	entries, err := readDir(journalDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0] != fsJournalLockFile {
+		t.Fatalf("Unexpected journal entries %v", entries)
+	}
+
+	fi, err := fsStatFile(GlobalContext, filepath.Join(disk, bucketName, objectName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fsMeta := newFSMetaV1()
+	fsMeta.Meta = map[string]string{"etag": "abcdef", "content-type": "application/x-test"}
+	entryPath, err := fs.journalBegin(GlobalContext, bucketName, objectName, fi, fsMeta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Entry for an object which was never committed.
+	if _, err = fs.journalBegin(GlobalContext, bucketName, "uncommitted", fi, fsMeta); err != nil {
+		t.Fatal(err)
+	}
+
+	// Torn write of `fs.json`.
+	fsMetaPath := filepath.Join(disk, minioMetaBucket, bucketMetaPrefix, bucketName, objectName, fs.metaJSONFile)
+	if err = os.Truncate(fsMetaPath, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// The journal of a running server is left untouched.
+	obj2, err := NewFSObjectLayer(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj2.Shutdown(GlobalContext)
+	if _, err = os.Stat(entryPath); err != nil {
+		t.Fatal(err)
+	}
+
+	// Crash of the first server.
+	fs.journalLk.Close()
+
+	obj3, err := NewFSObjectLayer(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj3.Shutdown(GlobalContext)
+	if _, err = os.Stat(journalDir); !os.IsNotExist(err) {
+		t.Fatalf("Expected journal to be removed, got %v", err)
+	}
+
+	objInfo, err := obj3.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag != "abcdef" || objInfo.ContentType != "application/x-test" {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+	if _, err = os.Stat(filepath.Join(disk, minioMetaBucket, bucketMetaPrefix, bucketName, "uncommitted")); !os.IsNotExist(err) {
+		t.Fatalf("Expected no metadata for an uncommitted object, got %v", err)
+	}
+}
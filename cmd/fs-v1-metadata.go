@@ -30,6 +30,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/lock"
 	"github.com/minio/minio/pkg/mimedb"
 )
@@ -143,9 +144,8 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 		m.Meta["content-type"] = mimedb.TypeByExtension(pathutil.Ext(object))
 	}
 
-	if HasSuffix(object, SlashSeparator) {
+	if HasSuffix(object, SlashSeparator) && m.Meta["etag"] == "" {
 		m.Meta["etag"] = emptyETag // For directories etag is d41d8cd98f00b204e9800998ecf8427e
-		m.Meta["content-type"] = "application/octet-stream"
 	}
 
 	objInfo := ObjectInfo{
@@ -165,6 +165,14 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 		}
 	}
 
+	// Hard linked objects share their modification time
+	// with the source object, use the saved one instead.
+	if modTime, ok := m.Meta[fsModTimeKey]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, modTime); err == nil {
+			objInfo.ModTime = t
+		}
+	}
+
 	objInfo.ETag = extractETag(m.Meta)
 	objInfo.ContentType = m.Meta["content-type"]
 	objInfo.ContentEncoding = m.Meta["content-encoding"]
@@ -186,15 +194,21 @@ func (m fsMetaV1) ToObjectInfo(bucket, object string, fi os.FileInfo) ObjectInfo
 	// Add user tags to the object info
 	objInfo.UserTags = m.Meta[xhttp.AmzObjectTagging]
 
+	// Add replication status to the object info
+	objInfo.ReplicationStatus = replication.StatusType(m.Meta[xhttp.AmzBucketReplicationStatus])
+
 	// etag/md5Sum has already been extracted. We need to
 	// remove to avoid it from appearing as part of
 	// response headers. e.g, X-Minio-* or X-Amz-*.
 	// Tags have also been extracted, we remove that as well.
-	objInfo.UserDefined = cleanMetadata(m.Meta)
+	objInfo.UserDefined = cleanMetadataKeys(cleanMetadata(m.Meta), fsModTimeKey)
 
 	// All the parts per object.
 	objInfo.Parts = m.Parts
 
+	// Update size, etag and storage class of transitioned objects.
+	applyTransitionMetadata(&objInfo, m.Meta)
+
 	// Success..
 	return objInfo
 }
@@ -0,0 +1,359 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// FS migration checkpoint, saved in the erasure deployment.
+	fsMigrationCheckpointFile = minioConfigPrefix + "/migrate-fs.json"
+
+	// Number of objects migrated between two checkpoints.
+	fsMigrationCheckpointInterval = 100
+)
+
+var (
+	errFSMigrationInProgress = errors.New("FS migration already in progress")
+	errFSMigrationNotFS      = errors.New("path is not an FS deployment")
+)
+
+// fsMigration - copies all buckets and objects of an FS deployment
+// into the erasure deployment, only one migration runs at a time.
+type fsMigration struct {
+	mu     sync.Mutex
+	status madmin.FSMigrationStatus
+}
+
+// Status - returns the progress of the current or the last migration.
+func (m *fsMigration) Status() madmin.FSMigrationStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+func (m *fsMigration) update(fn func(s *madmin.FSMigrationStatus)) madmin.FSMigrationStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn(&m.status)
+	return m.status
+}
+
+// Start - opens the FS deployment at fsPath and migrates it into
+// objAPI in the background. An unfinished migration of the same
+// path is resumed from its last saved checkpoint.
+func (m *fsMigration) Start(ctx context.Context, objAPI ObjectLayer, fsPath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.status.Running {
+		return errFSMigrationInProgress
+	}
+
+	fs, err := openFSForMigration(fsPath)
+	if err != nil {
+		return err
+	}
+
+	status := madmin.FSMigrationStatus{Path: fsPath}
+	cp, err := loadFSMigrationCheckpoint(ctx, objAPI)
+	switch {
+	case err == nil:
+		if cp.Path == fsPath && !cp.Complete {
+			status = cp
+		}
+	case err != errConfigNotFound:
+		fs.Shutdown(ctx)
+		return err
+	}
+
+	status.Running = true
+	status.StartTime = UTCNow()
+	status.EndTime = time.Time{}
+	status.Error = ""
+	m.status = status
+
+	go m.run(ctx, fs, objAPI)
+	return nil
+}
+
+func (m *fsMigration) run(ctx context.Context, fs *FSObjects, objAPI ObjectLayer) {
+	defer fs.Shutdown(ctx)
+
+	err := m.migrate(ctx, fs, objAPI)
+	status := m.update(func(s *madmin.FSMigrationStatus) {
+		s.Running = false
+		s.EndTime = UTCNow()
+		if err != nil {
+			s.Error = err.Error()
+		} else {
+			s.Complete = true
+		}
+	})
+	logger.LogIf(ctx, err)
+	logger.LogIf(ctx, saveFSMigrationCheckpoint(ctx, objAPI, status))
+}
+
+func (m *fsMigration) migrate(ctx context.Context, fs *FSObjects, objAPI ObjectLayer) error {
+	buckets, err := fs.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	resume := m.Status()
+	for _, bucket := range buckets {
+		if bucket.Name < resume.Bucket {
+			continue
+		}
+		var marker string
+		if bucket.Name == resume.Bucket {
+			marker = resume.Object
+		}
+		if err = m.migrateBucket(ctx, fs, objAPI, bucket.Name, marker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateBucket - creates the bucket along with its metadata and
+// copies all the objects after marker.
+func (m *fsMigration) migrateBucket(ctx context.Context, fs *FSObjects, objAPI ObjectLayer, bucket, marker string) error {
+	meta, err := loadBucketMetadata(ctx, fs, bucket)
+	if err != nil {
+		return err
+	}
+
+	if err = objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		if _, ok := err.(BucketExists); !ok {
+			return err
+		}
+	}
+
+	if err = meta.Save(ctx, objAPI); err != nil {
+		return err
+	}
+	globalBucketMetadataSys.Set(bucket, meta)
+	globalNotificationSys.LoadBucketMetadata(ctx, bucket)
+
+	m.update(func(s *madmin.FSMigrationStatus) {
+		s.Bucket = bucket
+		s.Object = marker
+	})
+
+	for {
+		loi, err := fs.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+
+		for _, obj := range loi.Objects {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+
+			err = migrateFSObject(ctx, fs, objAPI, bucket, obj.Name)
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to migrate %s/%s: %w", bucket, obj.Name, err))
+			}
+			status := m.update(func(s *madmin.FSMigrationStatus) {
+				s.Object = obj.Name
+				if err != nil {
+					s.Failed++
+					return
+				}
+				s.Objects++
+				s.Bytes += obj.Size
+			})
+			if (status.Objects+status.Failed)%fsMigrationCheckpointInterval == 0 {
+				logger.LogIf(ctx, saveFSMigrationCheckpoint(ctx, objAPI, status))
+			}
+		}
+
+		if !loi.IsTruncated {
+			return nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// migrateFSObject - copies the object as stored on disk along with its
+// metadata, parts layout and ETag. Encrypted and compressed objects are
+// copied as-is so that they remain readable with the same metadata.
+func migrateFSObject(ctx context.Context, fs *FSObjects, objAPI ObjectLayer, bucket, object string) error {
+	if HasSuffix(object, SlashSeparator) {
+		hr, err := hash.NewReader(bytes.NewReader(nil), 0, "", "", 0, false)
+		if err != nil {
+			return err
+		}
+		_, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hr, nil, nil), ObjectOptions{})
+		return err
+	}
+
+	lk := fs.NewNSLock(ctx, bucket, object)
+	if err := lk.GetRLock(globalObjectTimeout); err != nil {
+		return err
+	}
+	defer lk.RUnlock()
+
+	fsMeta, fi, err := fs.getObjectMeta(ctx, bucket, object)
+	if err != nil {
+		return err
+	}
+
+	meta := make(map[string]string, len(fsMeta.Meta))
+	for k, v := range fsMeta.Meta {
+		meta[k] = v
+	}
+	etag := meta["etag"]
+	delete(meta, "etag")
+
+	f, err := os.Open(pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return osErrToFileErr(err)
+	}
+	defer f.Close()
+
+	var objInfo ObjectInfo
+	if len(fsMeta.Parts) > 1 {
+		objInfo, err = migrateFSMultipartObject(ctx, objAPI, bucket, object, f, fsMeta.Parts, meta)
+	} else {
+		actualSize := fi.Size()
+		if v, ok := meta[ReservedMetadataPrefix+"actual-size"]; ok {
+			if actualSize, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return err
+			}
+		}
+		var hr *hash.Reader
+		hr, err = hash.NewReader(f, fi.Size(), "", "", actualSize, false)
+		if err != nil {
+			return err
+		}
+		objInfo, err = objAPI.PutObject(ctx, bucket, object, NewPutObjReader(hr, nil, nil), ObjectOptions{UserDefined: meta})
+	}
+	if err != nil {
+		return err
+	}
+
+	if etag == "" || objInfo.ETag == etag {
+		return nil
+	}
+
+	// Preserve the original ETag, needed for encrypted objects
+	// and for ETags which were not computed from the content.
+	srcInfo := ObjectInfo{
+		UserDefined:  meta,
+		ETag:         etag,
+		metadataOnly: true,
+	}
+	_, err = objAPI.CopyObject(ctx, bucket, object, bucket, object, srcInfo, ObjectOptions{}, ObjectOptions{})
+	return err
+}
+
+func migrateFSMultipartObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, r io.Reader, parts []ObjectPartInfo, meta map[string]string) (ObjectInfo, error) {
+	uploadID, err := objAPI.NewMultipartUpload(ctx, bucket, object, ObjectOptions{UserDefined: meta})
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+
+	completeParts := make([]CompletePart, 0, len(parts))
+	for _, part := range parts {
+		var hr *hash.Reader
+		hr, err = hash.NewReader(io.LimitReader(r, part.Size), part.Size, "", "", part.ActualSize, false)
+		if err != nil {
+			break
+		}
+		var pi PartInfo
+		pi, err = objAPI.PutObjectPart(ctx, bucket, object, uploadID, part.Number, NewPutObjReader(hr, nil, nil), ObjectOptions{})
+		if err != nil {
+			break
+		}
+		completeParts = append(completeParts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+
+	var objInfo ObjectInfo
+	if err == nil {
+		objInfo, err = objAPI.CompleteMultipartUpload(ctx, bucket, object, uploadID, completeParts, ObjectOptions{})
+	}
+	if err != nil {
+		logger.LogIf(ctx, objAPI.AbortMultipartUpload(ctx, bucket, object, uploadID))
+		return ObjectInfo{}, err
+	}
+	return objInfo, nil
+}
+
+// openFSForMigration - opens an existing FS deployment, without
+// initializing a new one when fsPath is not an FS deployment.
+func openFSForMigration(fsPath string) (*FSObjects, error) {
+	f, err := os.Open(pathJoin(fsPath, minioMetaBucket, formatConfigFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errFSMigrationNotFS
+		}
+		return nil, err
+	}
+	backend, err := formatMetaGetFormatBackendFS(f)
+	f.Close()
+	if err != nil || backend != formatBackendFS {
+		return nil, errFSMigrationNotFS
+	}
+
+	obj, err := NewFSObjectLayer(fsPath)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*FSObjects), nil
+}
+
+func loadFSMigrationCheckpoint(ctx context.Context, objAPI ObjectLayer) (cp madmin.FSMigrationStatus, err error) {
+	data, err := readConfig(ctx, objAPI, fsMigrationCheckpointFile)
+	if err != nil {
+		return cp, err
+	}
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return cp, err
+	}
+	return cp, nil
+}
+
+func saveFSMigrationCheckpoint(ctx context.Context, objAPI ObjectLayer, cp madmin.FSMigrationStatus) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, fsMigrationCheckpointFile, data)
+}
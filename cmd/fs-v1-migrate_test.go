@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestFSMigrate(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	src := initFSObjects(disk, t)
+	fs := src.(*FSObjects)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dst, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Shutdown(ctx)
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = src.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("hello, world")
+	opts := ObjectOptions{UserDefined: map[string]string{
+		"content-type":    "text/plain",
+		"x-amz-meta-name": "value",
+	}}
+	for _, object := range []string{"a", "dir/b"} {
+		if _, err = src.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	uploadID, err := src.NewMultipartUpload(ctx, bucket, "multipart", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partData := [][]byte{bytes.Repeat([]byte("a"), 5*humanize.MiByte), []byte("b")}
+	var parts []CompletePart
+	for i, p := range partData {
+		pi, perr := src.PutObjectPart(ctx, bucket, "multipart", uploadID, i+1, mustGetPutObjReader(t, bytes.NewReader(p), int64(len(p)), "", ""), ObjectOptions{})
+		if perr != nil {
+			t.Fatal(perr)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	if _, err = src.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, parts, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &fsMigration{}
+	if err = m.migrate(ctx, fs, dst); err != nil {
+		t.Fatal(err)
+	}
+	status := m.Status()
+	if status.Objects != 3 || status.Failed != 0 || status.Bucket != bucket || status.Object != "multipart" {
+		t.Fatalf("Unexpected migration status %#v", status)
+	}
+
+	for _, object := range []string{"a", "dir/b", "multipart"} {
+		srcInfo, err := src.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dstInfo, err := dst.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dstInfo.ETag != srcInfo.ETag || dstInfo.Size != srcInfo.Size || dstInfo.ContentType != "text/plain" ||
+			dstInfo.UserDefined["x-amz-meta-name"] != "value" {
+			t.Fatalf("%s: expected %#v, got %#v", object, srcInfo, dstInfo)
+		}
+		var buf bytes.Buffer
+		if err = dst.GetObject(ctx, bucket, object, 0, dstInfo.Size, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if object != "multipart" && !bytes.Equal(buf.Bytes(), data) {
+			t.Fatalf("%s: unexpected content %q", object, buf.Bytes())
+		}
+	}
+
+	// Resuming after "dir/b" only migrates the remaining objects.
+	for _, object := range []string{"a", "multipart"} {
+		if _, err = dst.DeleteObject(ctx, bucket, object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m = &fsMigration{status: madmin.FSMigrationStatus{Bucket: bucket, Object: "dir/b"}}
+	if err = m.migrate(ctx, fs, dst); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dst.GetObjectInfo(ctx, bucket, "a", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found, got %v", err)
+	}
+	if _, err = dst.GetObjectInfo(ctx, bucket, "multipart", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = m.Start(ctx, dst, fsDirs[0]); err != errFSMigrationNotFS {
+		t.Fatalf("Expected %v, got %v", errFSMigrationNotFS, err)
+	}
+}
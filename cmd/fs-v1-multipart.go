@@ -31,6 +31,7 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/minio/cmd/logger"
 	mioutil "github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/lock"
 )
 
 // Returns EXPORT/.minio.sys/multipart/SHA256/UPLOADID
@@ -219,6 +220,10 @@ func (fs *FSObjects) NewMultipartUpload(ctx context.Context, bucket, object stri
 		return "", toObjectErr(err, bucket)
 	}
 
+	if err := fs.checkCaseConflict(bucket, object); err != nil {
+		return "", err
+	}
+
 	uploadID := mustGetUUID()
 	uploadIDDir := fs.getUploadIDDir(bucket, object, uploadID)
 
@@ -260,7 +265,7 @@ func (fs *FSObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, d
 		}
 	}
 
-	if err := checkNewMultipartArgs(ctx, srcBucket, srcObject, fs); err != nil {
+	if err := checkObjectArgs(ctx, srcBucket, srcObject, fs); err != nil {
 		return pi, toObjectErr(err)
 	}
 
@@ -564,6 +569,10 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
 		return oi, toObjectErr(err, bucket)
 	}
+
+	if err := fs.checkCaseConflict(bucket, object); err != nil {
+		return oi, err
+	}
 	defer ObjectPathUpdated(pathutil.Join(bucket, object))
 
 	uploadIDDir := fs.getUploadIDDir(bucket, object, uploadID)
@@ -635,6 +644,7 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		}
 
 		fsMeta.Parts[i] = ObjectPartInfo{
+			ETag:       part.ETag,
 			Number:     part.PartNumber,
 			Size:       fi.Size(),
 			ActualSize: actualSize,
@@ -657,6 +667,10 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		}
 	}
 
+	if isMaxObjectSize(objectActualSize) {
+		return oi, ObjectTooLarge{Bucket: bucket, Object: object}
+	}
+
 	appendFallback := true // In case background-append did not append the required parts.
 	appendFilePath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, fmt.Sprintf("%s.%s", uploadID, mustGetUUID()))
 
@@ -672,31 +686,43 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	delete(fs.appendFileMap, uploadID)
 	fs.appendFileMapMu.Unlock()
 
+	// Number of leading parts already present in appendFile.
+	appendedParts := 0
 	if file != nil {
 		file.Lock()
 		defer file.Unlock()
-		// Verify that appendFile has all the parts.
-		if len(file.parts) == len(parts) {
-			for i := range parts {
+		// Verify which of the parts appendFile already has, all of them
+		// in the common case, only the remaining ones need to be appended.
+		if len(file.parts) <= len(parts) {
+			var appendedSize int64
+			for i := range file.parts {
 				if parts[i].ETag != file.parts[i].ETag {
 					break
 				}
 				if parts[i].PartNumber != file.parts[i].PartNumber {
 					break
 				}
-				if i == len(parts)-1 {
-					appendFilePath = file.filePath
-					appendFallback = false
-				}
+				appendedSize += fsMeta.Parts[i].Size
+				appendedParts = i + 1
 			}
+			if appendedParts != len(file.parts) {
+				appendedParts = 0
+			} else if fi, serr := fsStatFile(ctx, file.filePath); serr != nil || fi.Size() != appendedSize {
+				// appendFile may have a partially appended part.
+				appendedParts = 0
+			}
+		}
+		if appendedParts > 0 {
+			appendFilePath = file.filePath
+			appendFallback = appendedParts < len(parts)
 		}
 	}
 
 	if appendFallback {
-		if file != nil {
+		if file != nil && appendedParts == 0 {
 			fsRemoveFile(ctx, file.filePath)
 		}
-		for _, part := range parts {
+		for _, part := range parts[appendedParts:] {
 			partPath := getPartFile(entries, part.PartNumber, part.ETag)
 			if err = mioutil.AppendFile(appendFilePath, pathJoin(uploadIDDir, partPath), globalFSOSync); err != nil {
 				logger.LogIf(ctx, err)
@@ -711,13 +737,35 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, err
 	}
 	defer destLock.Unlock()
-	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
-	metaFile, err := fs.rwPool.Create(fsMetaPath)
-	if err != nil {
-		logger.LogIf(ctx, err)
-		return oi, toObjectErr(err, bucket, object)
+
+	if opts.CheckPrecondFn != nil {
+		curInfo, err := fs.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+				return oi, err
+			}
+		}
+		if opts.CheckPrecondFn(curInfo) {
+			return oi, PreConditionFailed{}
+		}
+	}
+
+	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
+	fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
+	xattrMeta := fs.xattrMeta
+	var metaFile *lock.LockedFile
+	defer func() {
+		if metaFile != nil {
+			metaFile.Close()
+		}
+	}()
+	if !xattrMeta {
+		metaFile, err = fs.rwPool.Create(fsMetaPath)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return oi, toObjectErr(err, bucket, object)
+		}
 	}
-	defer metaFile.Close()
 
 	// Read saved fs metadata for ongoing multipart.
 	fsMetaBuf, err := ioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
@@ -737,17 +785,54 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	fsMeta.Meta["etag"] = s3MD5
 	// Save consolidated actual size.
 	fsMeta.Meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
-	if _, err = fsMeta.WriteTo(metaFile); err != nil {
-		logger.LogIf(ctx, err)
-		return oi, toObjectErr(err, bucket, object)
+	if xattrMeta {
+		// Save FS metadata along with the object, metadata
+		// too large for extended attributes goes to `fs.json`.
+		if err = fsWriteMetaXattr(appendFilePath, fsMeta); err != nil {
+			if err != errXattrTooLarge {
+				logger.LogIf(ctx, err)
+				return oi, toObjectErr(err, bucket, object)
+			}
+			xattrMeta = false
+			metaFile, err = fs.rwPool.Create(fsMetaPath)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				return oi, toObjectErr(err, bucket, object)
+			}
+		}
+	}
+	var journalEntry string
+	if !xattrMeta {
+		// Journal `fs.json` for the object about to be committed.
+		var fi os.FileInfo
+		if fi, err = fsStatFile(ctx, appendFilePath); err != nil {
+			return oi, toObjectErr(err, bucket, object)
+		}
+		if journalEntry, err = fs.journalBegin(ctx, bucket, object, fi, fsMeta); err != nil {
+			return oi, toObjectErr(err, bucket, object)
+		}
 	}
 
 	err = fsRenameFile(ctx, appendFilePath, pathJoin(fs.fsPath, bucket, object))
 	if err != nil {
+		fs.journalEnd(ctx, journalEntry)
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
 	}
 
+	if xattrMeta {
+		// Remove `fs.json` of a previous version of the object, if any.
+		fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
+	} else {
+		// Write FS metadata after a successful namespace operation,
+		// on failure the journal entry restores it on restart.
+		if _, err = fsMeta.WriteTo(metaFile); err != nil {
+			logger.LogIf(ctx, err)
+			return oi, toObjectErr(err, bucket, object)
+		}
+		fs.journalEnd(ctx, journalEntry)
+	}
+
 	// Purge multipart folders
 	{
 		fsTmpObjPath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, mustGetUUID())
@@ -24,6 +24,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	humanize "github.com/dustin/go-humanize"
 )
 
 // Tests cleanup multipart uploads for filesystem backend.
@@ -193,6 +195,57 @@ func TestCompleteMultipartUpload(t *testing.T) {
 	}
 }
 
+// TestCompleteMultipartUploadAppendedPrefix - test CompleteMultipartUpload
+// when the background append only has the leading parts.
+func TestCompleteMultipartUploadAppendedPrefix(t *testing.T) {
+	// Prepare for tests
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+	obj := initFSObjects(disk, t)
+
+	bucketName := "bucket"
+	objectName := "object"
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal("Cannot create bucket, err: ", err)
+	}
+
+	uploadID, err := obj.NewMultipartUpload(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal("Unexpected error ", err)
+	}
+
+	// Part 3 is never uploaded, background append stops after part 2.
+	partsData := map[int][]byte{
+		1: bytes.Repeat([]byte("a"), 5*humanize.MiByte),
+		2: bytes.Repeat([]byte("b"), 5*humanize.MiByte),
+		4: []byte("dddd"),
+	}
+	var parts []CompletePart
+	var expected []byte
+	for _, partNumber := range []int{1, 2, 4} {
+		data := partsData[partNumber]
+		md5Hex := getMD5Hash(data)
+		if _, err = obj.PutObjectPart(GlobalContext, bucketName, objectName, uploadID, partNumber, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), ObjectOptions{}); err != nil {
+			t.Fatal("Unexpected error ", err)
+		}
+		parts = append(parts, CompletePart{PartNumber: partNumber, ETag: md5Hex})
+		expected = append(expected, data...)
+	}
+
+	if _, err = obj.CompleteMultipartUpload(GlobalContext, bucketName, objectName, uploadID, parts, ObjectOptions{}); err != nil {
+		t.Fatal("Unexpected error ", err)
+	}
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(GlobalContext, bucketName, objectName, 0, int64(len(expected)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal("Unexpected error ", err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Fatalf("Unexpected object content, expected %d bytes, got %d bytes", len(expected), buf.Len())
+	}
+}
+
 // TestCompleteMultipartUpload - test CompleteMultipartUpload
 func TestAbortMultipartUpload(t *testing.T) {
 	// Prepare for tests
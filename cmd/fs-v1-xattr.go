@@ -0,0 +1,114 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Extended attribute holding the object metadata, instead of `fs.json`,
+// when MINIO_FS_XATTR is enabled.
+const fsMetaXattr = "user.minio.fs.json"
+
+// errXattrNotSupported - extended attributes are not supported by the filesystem.
+var errXattrNotSupported = errors.New("extended attributes are not supported")
+
+// errXattrTooLarge - value doesn't fit in the extended attributes of the file.
+var errXattrTooLarge = errors.New("extended attribute value too large")
+
+// fsReadMetaXattr reads the object metadata saved in
+// the extended attributes of the object file.
+func fsReadMetaXattr(ctx context.Context, filePath string) (fsMeta fsMetaV1, err error) {
+	buf, err := getXattr(filePath, fsMetaXattr)
+	if err != nil {
+		return fsMeta, err
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	if err = json.Unmarshal(buf, &fsMeta); err != nil {
+		return fsMeta, err
+	}
+
+	// Verify if the format is valid, return corrupted format
+	// for unrecognized formats.
+	if !isFSMetaValid(fsMeta.Version) {
+		logger.GetReqInfo(ctx).AppendTags("file", filePath)
+		logger.LogIf(ctx, errCorruptedFormat)
+		return fsMeta, errCorruptedFormat
+	}
+
+	return fsMeta, nil
+}
+
+// fsWriteMetaXattr saves the object metadata in the
+// extended attributes of the object file.
+func fsWriteMetaXattr(filePath string, fsMeta fsMetaV1) error {
+	buf, err := json.Marshal(fsMeta)
+	if err != nil {
+		return err
+	}
+	return setXattr(filePath, fsMetaXattr, buf)
+}
+
+// checkXattrSupport verifies that the filesystem at dirPath
+// supports saving object metadata in extended attributes.
+func checkXattrSupport(dirPath string) error {
+	f, err := ioutil.TempFile(dirPath, "xattr-")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	return fsWriteMetaXattr(f.Name(), newFSMetaV1())
+}
+
+// Returns the metadata of an object without `fs.json`, read from the
+// extended attributes of the object file if present, default otherwise.
+func (fs *FSObjects) readMetaXattr(ctx context.Context, bucket, object string, fi os.FileInfo) fsMetaV1 {
+	fsMeta, err := fsReadMetaXattr(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return fs.defaultFsJSON(object, fi)
+	}
+	return fsMeta
+}
+
+// Updates the metadata of an object saved in the extended attributes
+// of the object file, for objects without `fs.json`.
+func (fs *FSObjects) updateMetaXattr(ctx context.Context, bucket, object string, updateFn func(*fsMetaV1)) (fsMeta fsMetaV1, fi os.FileInfo, err error) {
+	fsObjPath := pathJoin(fs.fsPath, bucket, object)
+	if fi, err = fsStatFile(ctx, fsObjPath); err != nil {
+		return fsMeta, nil, err
+	}
+	fsMeta = fs.readMetaXattr(ctx, bucket, object, fi)
+	if fsMeta.Meta == nil {
+		fsMeta.Meta = make(map[string]string)
+	}
+	updateFn(&fsMeta)
+	if err = fsWriteMetaXattr(fsObjPath, fsMeta); err != nil {
+		logger.LogIf(ctx, err)
+		return fsMeta, nil, err
+	}
+	return fsMeta, fi, nil
+}
@@ -0,0 +1,60 @@
+// +build linux
+
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "golang.org/x/sys/unix"
+
+func getXattr(filePath, name string) ([]byte, error) {
+	for {
+		size, err := unix.Getxattr(filePath, name, nil)
+		if err != nil {
+			return nil, osXattrErrToErr(err)
+		}
+		buf := make([]byte, size)
+		n, err := unix.Getxattr(filePath, name, buf)
+		if err == unix.ERANGE {
+			// Attribute grew in between, retry.
+			continue
+		}
+		if err != nil {
+			return nil, osXattrErrToErr(err)
+		}
+		return buf[:n], nil
+	}
+}
+
+func setXattr(filePath, name string, value []byte) error {
+	return osXattrErrToErr(unix.Setxattr(filePath, name, value, 0))
+}
+
+func osXattrErrToErr(err error) error {
+	switch err {
+	case nil:
+		return nil
+	case unix.ENODATA, unix.ENOENT:
+		return errFileNotFound
+	case unix.ENOTSUP:
+		return errXattrNotSupported
+	case unix.E2BIG, unix.ERANGE, unix.ENOSPC:
+		return errXattrTooLarge
+	case unix.EACCES, unix.EPERM:
+		return errFileAccessDenied
+	}
+	return err
+}
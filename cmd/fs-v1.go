@@ -19,6 +19,8 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -36,17 +38,20 @@ import (
 	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/bucket/policy"
 	"github.com/minio/minio/pkg/color"
+	mioutil "github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/lock"
 	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/mimedb"
 	"github.com/minio/minio/pkg/mountinfo"
 )
 
-// Default etag is used for pre-existing objects.
+// Default etag is used for pre-existing objects whose file
+// info is not known, see fsDerivedETag().
 var defaultEtag = "00000000000000000000000000000000-1"
 
 // FSObjects - Implements fs object layer.
@@ -69,6 +74,9 @@ type FSObjects struct {
 	// This value shouldn't be touched, once initialized.
 	fsFormatRlk *lock.RLockedFile // Is a read lock on `format.json`.
 
+	// Lock on the `fs.json` journal of this server.
+	journalLk *lock.LockedFile
+
 	// FS rw pool.
 	rwPool *fsIOPool
 
@@ -77,6 +85,14 @@ type FSObjects struct {
 
 	diskMount bool
 
+	// Object metadata is saved in extended attributes
+	// of the object files instead of `fs.json`.
+	xattrMeta bool
+
+	// Backend filesystem resolves names differing
+	// only in case to the same file.
+	caseInsensitive bool
+
 	appendFileMap   map[string]*fsAppendFile
 	appendFileMapMu sync.Mutex
 
@@ -168,10 +184,29 @@ func NewFSObjectLayer(fsPath string) (ObjectLayer, error) {
 		listPool:      NewTreeWalkPool(globalLookupTimeout),
 		appendFileMap: make(map[string]*fsAppendFile),
 		diskMount:     mountinfo.IsLikelyMountPoint(fsPath),
+		xattrMeta:     globalFSXattr,
 
 		maxActiveIOCount: 10,
 	}
 
+	if fs.xattrMeta {
+		if err = checkXattrSupport(pathJoin(fsPath, minioMetaTmpBucket, fsUUID)); err != nil {
+			rlk.Close()
+			return nil, config.ErrUnableToWriteInBackend(err).Hint("Set MINIO_FS_XATTR to `off` on filesystems without extended attributes support")
+		}
+	}
+
+	if fs.caseInsensitive, err = fsIsCaseInsensitive(pathJoin(fsPath, minioMetaTmpBucket, fsUUID)); err != nil {
+		rlk.Close()
+		return nil, err
+	}
+
+	// Restore `fs.json` updates interrupted by a crash.
+	if err = fs.initJournal(ctx); err != nil {
+		rlk.Close()
+		return nil, err
+	}
+
 	// Once the filesystem has initialized hold the read lock for
 	// the life time of the server. This is done to ensure that under
 	// shared backend mode for FS, remote servers do not migrate
@@ -195,6 +230,12 @@ func (fs *FSObjects) NewNSLock(ctx context.Context, bucket string, objects ...st
 func (fs *FSObjects) Shutdown(ctx context.Context) error {
 	fs.fsFormatRlk.Close()
 
+	// Remove the journal, all updates are complete.
+	if fs.journalLk != nil {
+		fs.journalLk.Close()
+	}
+	fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaBucket, fsJournalDir, fs.fsUUID))
+
 	// Cleanup and delete tmp uuid.
 	return fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
 }
@@ -336,16 +377,16 @@ func (fs *FSObjects) crawlBucket(ctx context.Context, bucket string, cache dataU
 				metaOk = true
 			}
 		}
-		if !metaOk {
-			fsMeta = fs.defaultFsJSON(object)
-		}
-
 		// Stat the file.
 		fi, fiErr := os.Stat(item.Path)
 		if fiErr != nil {
 			return 0, errSkipFile
 		}
 
+		if !metaOk {
+			fsMeta = fs.readMetaXattr(ctx, bucket, object, fi)
+		}
+
 		oi := fsMeta.ToObjectInfo(bucket, object, fi)
 		sz := item.applyActions(ctx, fs, actionMeta{oi: oi})
 		if sz >= 0 {
@@ -409,6 +450,7 @@ func (fs *FSObjects) MakeBucketWithLocation(ctx context.Context, bucket string,
 	}
 
 	meta := newBucketMetadata(bucket)
+	meta.Region = bucketRegion(opts.Location)
 	if err := meta.Save(ctx, fs); err != nil {
 		return toObjectErr(err, bucket)
 	}
@@ -571,6 +613,7 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string, forceDelet
 
 	// Delete all bucket metadata.
 	deleteBucketMetadata(ctx, fs, bucket)
+	logger.LogIf(ctx, deleteBucketSnapshots(ctx, fs, bucket))
 
 	return nil
 }
@@ -610,8 +653,33 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 	}
 
 	if cpSrcDstSame && srcInfo.metadataOnly {
+		if srcOpts.CheckPrecondFn != nil {
+			curInfo, err := fs.getObjectInfo(ctx, srcBucket, srcObject)
+			if err != nil {
+				return oi, toObjectErr(err, srcBucket, srcObject)
+			}
+			if srcOpts.CheckPrecondFn(curInfo) {
+				return oi, PreConditionFailed{}
+			}
+		}
+
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, srcBucket, srcObject, fs.metaJSONFile)
 		wlk, err := fs.rwPool.Write(fsMetaPath)
+		if err == errFileNotFound && fs.xattrMeta {
+			// Save objects' metadata in extended attributes.
+			fsMeta, fi, xerr := fs.updateMetaXattr(ctx, srcBucket, srcObject, func(fsMeta *fsMetaV1) {
+				fsMeta.Meta = srcInfo.UserDefined
+				fsMeta.Meta["etag"] = srcInfo.ETag
+			})
+			if xerr != nil {
+				return oi, toObjectErr(xerr, srcBucket, srcObject)
+			}
+			return fsMeta.ToObjectInfo(srcBucket, srcObject, fi), nil
+		}
+		if err == errFileNotFound {
+			// Pre-existing object without `fs.json`.
+			wlk, err = fs.rwPool.Create(fsMetaPath)
+		}
 		if err != nil {
 			logger.LogIf(ctx, err)
 			return oi, toObjectErr(err, srcBucket, srcObject)
@@ -619,24 +687,39 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		// This close will allow for locks to be synchronized on `fs.json`.
 		defer wlk.Close()
 
+		// Stat the file to get file size.
+		fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, srcBucket, srcObject))
+		if err != nil {
+			return oi, toObjectErr(err, srcBucket, srcObject)
+		}
+
 		// Save objects' metadata in `fs.json`.
 		fsMeta := newFSMetaV1()
 		if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
-			// For any error to read fsMeta, set default ETag and proceed.
-			fsMeta = fs.defaultFsJSON(srcObject)
+			// For any error to read fsMeta, set the derived ETag and proceed.
+			fsMeta = fs.defaultFsJSON(srcObject, fi)
 		}
 
+		// The ETag of the source is carried over.
+		etag := srcInfo.ETag
+		if etag == "" {
+			etag = fsMeta.Meta["etag"]
+		}
+		modTime, linked := fsMeta.Meta[fsModTimeKey]
 		fsMeta.Meta = srcInfo.UserDefined
-		fsMeta.Meta["etag"] = srcInfo.ETag
-		if _, err = fsMeta.WriteTo(wlk); err != nil {
-			return oi, toObjectErr(err, srcBucket, srcObject)
+		fsMeta.Meta["etag"] = etag
+		if linked {
+			fsMeta.Meta[fsModTimeKey] = modTime
 		}
 
-		// Stat the file to get file size.
-		fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, srcBucket, srcObject))
+		journalEntry, err := fs.journalBegin(ctx, srcBucket, srcObject, fi, fsMeta)
 		if err != nil {
 			return oi, toObjectErr(err, srcBucket, srcObject)
 		}
+		if _, err = fsMeta.WriteTo(wlk); err != nil {
+			return oi, toObjectErr(err, srcBucket, srcObject)
+		}
+		fs.journalEnd(ctx, journalEntry)
 
 		// Return the new object info.
 		return fsMeta.ToObjectInfo(srcBucket, srcObject, fi), nil
@@ -646,6 +729,27 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return ObjectInfo{}, err
 	}
 
+	if err := fs.checkCaseConflict(dstBucket, dstObject); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	if dstOpts.CheckPrecondFn != nil {
+		curInfo, err := fs.getObjectInfo(ctx, dstBucket, dstObject)
+		if err != nil {
+			if err = toObjectErr(err, dstBucket, dstObject); !isErrObjectNotFound(err) {
+				return ObjectInfo{}, err
+			}
+		}
+		if dstOpts.CheckPrecondFn(curInfo) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+	}
+
+	// Data is copied unmodified, link it instead of streaming it.
+	if objInfo, ok, err := fs.linkObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo); ok || err != nil {
+		return objInfo, err
+	}
+
 	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined})
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
@@ -654,6 +758,130 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 	return objInfo, nil
 }
 
+// Modification time of objects copied with a hard link.
+const fsModTimeKey = ReservedMetadataPrefix + "mod-time"
+
+// linkObject - copies the source object to the destination with a
+// hard link, or a reflink when object metadata is saved in extended
+// attributes, when the object data is not transformed by the copy
+// i.e neither compressed nor encrypted. Returns false if the object
+// has to be copied by streaming its data instead.
+func (fs *FSObjects) linkObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo) (oi ObjectInfo, ok bool, err error) {
+	if dstBucket == minioMetaBucket || HasSuffix(dstObject, SlashSeparator) {
+		return oi, false, nil
+	}
+
+	// Destination data must be stored as is.
+	if srcInfo.PutObjReader.sealMD5Fn != nil || crypto.IsEncrypted(srcInfo.UserDefined) ||
+		srcInfo.UserDefined[ReservedMetadataPrefix+"compression"] != "" {
+		return oi, false, nil
+	}
+
+	// Source data must be stored as is.
+	srcObjInfo, err := fs.getObjectInfo(ctx, srcBucket, srcObject)
+	if err != nil {
+		return oi, false, nil
+	}
+	if crypto.IsEncrypted(srcObjInfo.UserDefined) || srcObjInfo.IsCompressed() || srcObjInfo.Size != srcInfo.Size {
+		return oi, false, nil
+	}
+
+	if _, err = fs.statBucketDir(ctx, dstBucket); err != nil {
+		return oi, false, nil
+	}
+
+	// Check if an object is present as one of the parent dir.
+	if fs.parentDirIsObject(ctx, dstBucket, path.Dir(dstObject)) {
+		return oi, false, toObjectErr(errFileParentIsFile, dstBucket, dstObject)
+	}
+
+	fsSrcObjPath := pathJoin(fs.fsPath, srcBucket, srcObject)
+	fsTmpObjPath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, mustGetUUID())
+	if fs.xattrMeta {
+		// Extended attributes are shared by hard links, copy
+		// the data instead which only shares the extents on
+		// filesystems supporting reflinks.
+		err = mioutil.AppendFile(fsTmpObjPath, fsSrcObjPath, globalFSOSync)
+	} else {
+		err = os.Link(fsSrcObjPath, fsTmpObjPath)
+	}
+	if err != nil {
+		// Links not supported, fallback to a regular copy.
+		fsRemoveFile(ctx, fsTmpObjPath)
+		return oi, false, nil
+	}
+	defer fsRemoveFile(ctx, fsTmpObjPath)
+
+	fsMeta := newFSMetaV1()
+	fsMeta.Meta = make(map[string]string, len(srcInfo.UserDefined)+1)
+	for k, v := range srcInfo.UserDefined {
+		fsMeta.Meta[k] = v
+	}
+	fsMeta.Meta["etag"] = srcInfo.ETag
+	if !fs.xattrMeta {
+		fsMeta.Meta[fsModTimeKey] = UTCNow().Format(time.RFC3339Nano)
+	}
+
+	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
+	fsMetaPath := pathJoin(bucketMetaDir, dstBucket, dstObject, fs.metaJSONFile)
+	var wlk *lock.LockedFile
+	if fs.xattrMeta {
+		if err = fsWriteMetaXattr(fsTmpObjPath, fsMeta); err != nil {
+			if err == errXattrTooLarge {
+				return oi, false, nil
+			}
+			logger.LogIf(ctx, err)
+			return oi, false, toObjectErr(err, dstBucket, dstObject)
+		}
+	}
+
+	var journalEntry string
+	if !fs.xattrMeta {
+		wlk, err = fs.rwPool.Create(fsMetaPath)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return oi, false, toObjectErr(err, dstBucket, dstObject)
+		}
+		// This close will allow for locks to be synchronized on `fs.json`.
+		defer wlk.Close()
+
+		// Journal `fs.json` for the object about to be committed.
+		var fi os.FileInfo
+		if fi, err = fsStatFile(ctx, fsTmpObjPath); err != nil {
+			return oi, false, toObjectErr(err, dstBucket, dstObject)
+		}
+		if journalEntry, err = fs.journalBegin(ctx, dstBucket, dstObject, fi, fsMeta); err != nil {
+			return oi, false, toObjectErr(err, dstBucket, dstObject)
+		}
+	}
+
+	fsDstObjPath := pathJoin(fs.fsPath, dstBucket, dstObject)
+	if err = fsRenameFile(ctx, fsTmpObjPath, fsDstObjPath); err != nil {
+		if wlk != nil {
+			fs.journalEnd(ctx, journalEntry)
+			fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
+		}
+		return oi, false, toObjectErr(err, dstBucket, dstObject)
+	}
+
+	if fs.xattrMeta {
+		// Remove `fs.json` of a previous version of the object, if any.
+		fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
+	} else {
+		if _, err = fsMeta.WriteTo(wlk); err != nil {
+			return oi, false, toObjectErr(err, dstBucket, dstObject)
+		}
+		fs.journalEnd(ctx, journalEntry)
+	}
+
+	fi, err := fsStatFile(ctx, fsDstObjPath)
+	if err != nil {
+		return oi, false, toObjectErr(err, dstBucket, dstObject)
+	}
+
+	return fsMeta.ToObjectInfo(dstBucket, dstObject, fi), true, nil
+}
+
 // GetObjectNInfo - returns object info and a reader for object
 // content.
 func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
@@ -708,6 +936,10 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 		// objReader.Close() is called by the caller.
 		return NewGetObjectReaderFromReader(bytes.NewBuffer(nil), objInfo, opts, nsUnlocker)
 	}
+	// The data of transitioned objects is read from the remote tier.
+	if objInfo.TransitionStatus != "" {
+		return getTransitionedObjectReader(ctx, bucket, object, rs, h, objInfo, opts, nsUnlocker)
+	}
 	// Take a rwPool lock for NFS gateway type deployment
 	rwPoolUnlocker := func() {}
 	if bucket != minioMetaBucket && lockType != noLock {
@@ -723,7 +955,7 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 		rwPoolUnlocker = func() { fs.rwPool.Close(fsMetaPath) }
 	}
 
-	objReaderFn, off, length, rErr := NewGetObjectReader(rs, objInfo, opts, nsUnlocker, rwPoolUnlocker)
+	objReaderFn, off, length, rErr := NewGetObjectReader(ctx, rs, objInfo, opts, nsUnlocker, rwPoolUnlocker)
 	if rErr != nil {
 		return nil, rErr
 	}
@@ -831,6 +1063,14 @@ func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offse
 		if perr != nil {
 			return toObjectErr(perr, bucket, object)
 		}
+		if objEtag == "" {
+			// Object without `fs.json`.
+			fi, serr := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+			if serr != nil {
+				return toObjectErr(serr, bucket, object)
+			}
+			objEtag = extractETag(fs.readMetaXattr(ctx, bucket, object, fi).Meta)
+		}
 		if objEtag != etag {
 			logger.LogIf(ctx, InvalidETag{}, logger.Application)
 			return toObjectErr(InvalidETag{}, bucket, object)
@@ -889,11 +1129,23 @@ func (fs *FSObjects) createFsJSON(object, fsMetaPath string) error {
 	return werr
 }
 
+// fsDerivedETag returns the etag of a pre-existing object without
+// `fs.json`, derived from its size and modification time so that
+// it is stable across requests and changes when the file does.
+func fsDerivedETag(fi os.FileInfo) string {
+	if fi == nil {
+		return defaultEtag
+	}
+	h := md5.New()
+	fmt.Fprintf(h, "%d.%d", fi.Size(), fi.ModTime().UnixNano())
+	return hex.EncodeToString(h.Sum(nil)) + "-1"
+}
+
 // Used to return default etag values when a pre-existing object's meta data is queried.
-func (fs *FSObjects) defaultFsJSON(object string) fsMetaV1 {
+func (fs *FSObjects) defaultFsJSON(object string, fi os.FileInfo) fsMetaV1 {
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = make(map[string]string)
-	fsMeta.Meta["etag"] = defaultEtag
+	fsMeta.Meta["etag"] = fsDerivedETag(fi)
 	contentType := mimedb.TypeByExtension(path.Ext(object))
 	fsMeta.Meta["content-type"] = contentType
 	return fsMeta
@@ -907,9 +1159,36 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 		if err != nil {
 			return oi, err
 		}
+		// Read the metadata of the directory object, if any.
+		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, encodeDirObject(object), fs.metaJSONFile)
+		rlk, err := fs.rwPool.Open(fsMetaPath)
+		if err == nil {
+			_, err = fsMeta.ReadFrom(ctx, rlk.LockedFile)
+			fs.rwPool.Close(fsMetaPath)
+		}
+		if err != nil && err != errFileNotFound {
+			logger.LogIf(ctx, err)
+			return oi, err
+		}
 		return fsMeta.ToObjectInfo(bucket, object, fi), nil
 	}
 
+	fsMeta, fi, err := fs.getObjectMeta(ctx, bucket, object)
+	if err != nil {
+		return oi, err
+	}
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
+
+// getObjectMeta - reads the raw metadata of a regular object along
+// with its file info, falling back to defaults for pre-existing data.
+func (fs *FSObjects) getObjectMeta(ctx context.Context, bucket, object string) (fsMeta fsMetaV1, fi os.FileInfo, err error) {
+	// Stat the file to get file size.
+	fi, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return fsMeta, nil, err
+	}
+
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	// Read `fs.json` to perhaps contend with
 	// parallel Put() operations.
@@ -921,28 +1200,23 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 		fs.rwPool.Close(fsMetaPath)
 		if rerr != nil {
 			// For any error to read fsMeta, set default ETag and proceed.
-			fsMeta = fs.defaultFsJSON(object)
+			fsMeta = fs.defaultFsJSON(object, fi)
 		}
 	}
 
-	// Return a default etag and content-type based on the object's extension.
+	// Return the metadata saved in extended attributes if any, otherwise
+	// a default etag and content-type based on the object's extension.
 	if err == errFileNotFound {
-		fsMeta = fs.defaultFsJSON(object)
+		fsMeta = fs.readMetaXattr(ctx, bucket, object, fi)
 	}
 
 	// Ignore if `fs.json` is not available, this is true for pre-existing data.
 	if err != nil && err != errFileNotFound {
 		logger.LogIf(ctx, err)
-		return oi, err
-	}
-
-	// Stat the file to get file size.
-	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
-	if err != nil {
-		return oi, err
+		return fsMeta, nil, err
 	}
 
-	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+	return fsMeta, fi, nil
 }
 
 // getObjectInfoWithLock - reads object metadata and replies back ObjectInfo.
@@ -962,7 +1236,7 @@ func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object s
 		return oi, err
 	}
 
-	if strings.HasSuffix(object, SlashSeparator) && !fs.isObjectDir(bucket, object) {
+	if strings.HasSuffix(object, SlashSeparator) && !fs.isObjectDir(bucket, object) && !fs.hasDirObjectMeta(ctx, bucket, object) {
 		return oi, errFileNotFound
 	}
 
@@ -1057,6 +1331,18 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, retErr error) {
 	data := r.Reader
 
+	if opts.CheckPrecondFn != nil {
+		oi, err := fs.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+				return ObjectInfo{}, err
+			}
+		}
+		if opts.CheckPrecondFn(oi) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+	}
+
 	// No metadata is set, allocate a new one.
 	meta := make(map[string]string)
 	for k, v := range opts.UserDefined {
@@ -1069,6 +1355,10 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket)
 	}
 
+	if err = fs.checkCaseConflict(bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	fsMeta := newFSMetaV1()
 	fsMeta.Meta = meta
 
@@ -1088,6 +1378,23 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		if fi, err = fsStatDir(ctx, pathJoin(fs.fsPath, bucket, object)); err != nil {
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
+		if bucket != minioMetaBucket {
+			// Save the metadata of the directory object, along with its
+			// modification time as the directory changes with its content.
+			fsMeta.Meta["etag"] = emptyETag
+			fsMeta.Meta[fsModTimeKey] = UTCNow().Format(time.RFC3339Nano)
+			fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, encodeDirObject(object), fs.metaJSONFile)
+			var dirlk *lock.LockedFile
+			if dirlk, err = fs.rwPool.Create(fsMetaPath); err != nil {
+				logger.LogIf(ctx, err)
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+			_, err = fsMeta.WriteTo(dirlk)
+			dirlk.Close()
+			if err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+		}
 		return fsMeta.ToObjectInfo(bucket, object, fi), nil
 	}
 
@@ -1102,25 +1409,36 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, errInvalidArgument
 	}
 
-	var wlk *lock.LockedFile
-	if bucket != minioMetaBucket {
-		bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
+	bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
+	fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
+	xattrMeta := fs.xattrMeta && bucket != minioMetaBucket
 
-		fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
+	var wlk *lock.LockedFile
+	defer func() {
+		// This close will allow for locks to be synchronized on `fs.json`.
+		if wlk != nil {
+			wlk.Close()
+		}
+	}()
+	createMetaFile := func() error {
 		wlk, err = fs.rwPool.Create(fsMetaPath)
 		if err != nil {
 			logger.LogIf(ctx, err)
+			return err
+		}
+		return nil
+	}
+	defer func() {
+		// Remove meta file when PutObject encounters any error
+		if retErr != nil && wlk != nil {
+			tmpDir := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID)
+			fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, tmpDir)
+		}
+	}()
+	if bucket != minioMetaBucket && !xattrMeta {
+		if err = createMetaFile(); err != nil {
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
-		// This close will allow for locks to be synchronized on `fs.json`.
-		defer wlk.Close()
-		defer func() {
-			// Remove meta file when PutObject encounters any error
-			if retErr != nil {
-				tmpDir := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID)
-				fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, tmpDir)
-			}
-		}()
 	}
 
 	// Uploaded object will first be written to the temporary location which will eventually
@@ -1155,17 +1473,50 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	// nothing to delete.
 	defer fsRemoveFile(ctx, fsTmpObjPath)
 
+	if xattrMeta {
+		// Save FS metadata along with the object, metadata
+		// too large for extended attributes goes to `fs.json`.
+		if err = fsWriteMetaXattr(fsTmpObjPath, fsMeta); err != nil {
+			if err != errXattrTooLarge {
+				logger.LogIf(ctx, err)
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+			xattrMeta = false
+			if err = createMetaFile(); err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+		}
+	}
+
+	var journalEntry string
+	if !xattrMeta && bucket != minioMetaBucket {
+		// Journal `fs.json` for the object about to be committed.
+		var fi os.FileInfo
+		if fi, err = fsStatFile(ctx, fsTmpObjPath); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+		if journalEntry, err = fs.journalBegin(ctx, bucket, object, fi, fsMeta); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+
 	// Entire object was written to the temp location, now it's safe to rename it to the actual location.
 	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
 	if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
+		fs.journalEnd(ctx, journalEntry)
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 
-	if bucket != minioMetaBucket {
-		// Write FS metadata after a successful namespace operation.
+	if xattrMeta {
+		// Remove `fs.json` of a previous version of the object, if any.
+		fsRemoveMeta(ctx, bucketMetaDir, fsMetaPath, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
+	} else if bucket != minioMetaBucket {
+		// Write FS metadata after a successful namespace operation,
+		// on failure the journal entry restores it on restart.
 		if _, err = fsMeta.WriteTo(wlk); err != nil {
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
+		fs.journalEnd(ctx, journalEntry)
 	}
 
 	// Stat the file to fetch timestamp, size.
@@ -1236,13 +1587,27 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string, op
 		return objInfo, toObjectErr(err, bucket)
 	}
 
+	if opts.CheckPrecondFn != nil {
+		curInfo, err := fs.getObjectInfo(ctx, bucket, object)
+		if err != nil {
+			if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+				return objInfo, err
+			}
+		}
+		if opts.CheckPrecondFn(curInfo) {
+			return objInfo, PreConditionFailed{}
+		}
+	}
+
 	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
-	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, encodeDirObject(object), fs.metaJSONFile)
+	var hasMeta bool
 	if bucket != minioMetaBucket {
 		rwlk, lerr := fs.rwPool.Write(fsMetaPath)
 		if lerr == nil {
 			// This close will allow for fs locks to be synchronized on `fs.json`.
 			defer rwlk.Close()
+			hasMeta = true
 		}
 		if lerr != nil && lerr != errFileNotFound {
 			logger.LogIf(ctx, lerr)
@@ -1250,9 +1615,18 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string, op
 		}
 	}
 
+	if isSecureEraseEnabled(bucket) {
+		if err = fs.secureErase(bucket, object); err != nil {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
+	}
+
 	// Delete the object.
 	if err = fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), pathJoin(fs.fsPath, bucket, object)); err != nil {
-		return objInfo, toObjectErr(err, bucket, object)
+		// Only the metadata of a directory object with content is deleted.
+		if err != errFileNotFound || !hasMeta || !HasSuffix(object, SlashSeparator) {
+			return objInfo, toObjectErr(err, bucket, object)
+		}
 	}
 
 	if bucket != minioMetaBucket {
@@ -1277,6 +1651,10 @@ func (fs *FSObjects) listDirFactory() ListDirFunc {
 			logger.LogIf(GlobalContext, err)
 			return false, nil
 		}
+		if prefixDir != "" && fs.hasDirObjectMeta(GlobalContext, bucket, prefixDir) {
+			// The directory object is listed before its content.
+			entries = append(entries, "")
+		}
 		if len(entries) == 0 {
 			return true, nil
 		}
@@ -1299,6 +1677,12 @@ func (fs *FSObjects) isObjectDir(bucket, prefix string) bool {
 	return len(entries) == 0
 }
 
+// hasDirObjectMeta - returns if the metadata of a directory object
+// is saved for the directory.
+func (fs *FSObjects) hasDirObjectMeta(ctx context.Context, bucket, dir string) bool {
+	return fsIsFile(ctx, pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, encodeDirObject(dir), fs.metaJSONFile))
+}
+
 // getObjectETag is a helper function, which returns only the md5sum
 // of the file on the disk.
 func (fs *FSObjects) getObjectETag(ctx context.Context, bucket, entry string, lock bool) (string, error) {
@@ -1418,6 +1802,16 @@ func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object string, t
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	fsMeta := fsMetaV1{}
 	wlk, err := fs.rwPool.Write(fsMetaPath)
+	if err == errFileNotFound && fs.xattrMeta {
+		// Update objects' metadata in extended attributes.
+		_, _, err = fs.updateMetaXattr(ctx, bucket, object, func(fsMeta *fsMetaV1) {
+			delete(fsMeta.Meta, xhttp.AmzObjectTagging)
+			if tags != "" {
+				fsMeta.Meta[xhttp.AmzObjectTagging] = tags
+			}
+		})
+		return toObjectErr(err, bucket, object)
+	}
 	if err != nil {
 		logger.LogIf(ctx, err)
 		return toObjectErr(err, bucket, object)
@@ -1425,10 +1819,16 @@ func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object string, t
 	// This close will allow for locks to be synchronized on `fs.json`.
 	defer wlk.Close()
 
+	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+
 	// Read objects' metadata in `fs.json`.
 	if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
-		// For any error to read fsMeta, set default ETag and proceed.
-		fsMeta = fs.defaultFsJSON(object)
+		// For any error to read fsMeta, set default ETag and proceed,
+		// preserving the etag of pre-existing objects.
+		fsMeta = fs.defaultFsJSON(object, fi)
 	}
 
 	// clean fsMeta.Meta of tag key, before updating the new tags
@@ -1439,9 +1839,14 @@ func (fs *FSObjects) PutObjectTags(ctx context.Context, bucket, object string, t
 		fsMeta.Meta[xhttp.AmzObjectTagging] = tags
 	}
 
+	journalEntry, err := fs.journalBegin(ctx, bucket, object, fi, fsMeta)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
 	if _, err = fsMeta.WriteTo(wlk); err != nil {
 		return toObjectErr(err, bucket, object)
 	}
+	fs.journalEnd(ctx, journalEntry)
 	return nil
 }
 
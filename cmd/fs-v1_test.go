@@ -18,9 +18,12 @@ package cmd
 
 import (
 	"bytes"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -256,6 +259,284 @@ func TestFSPutObject(t *testing.T) {
 	}
 }
 
+// TestFSPreExistingObject - test serving objects written directly to the
+// backend directory, without `fs.json`.
+func TestFSPreExistingObject(t *testing.T) {
+	// Prepare for tests
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	bucketName := "bucket"
+	objectName := "dir/photo.jpg"
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	objPath := filepath.Join(disk, bucketName, "dir", "photo.jpg")
+	if err := os.MkdirAll(filepath.Dir(objPath), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(objPath, []byte("abcd"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag == defaultEtag || !strings.HasSuffix(objInfo.ETag, "-1") {
+		t.Fatalf("Unexpected etag %s", objInfo.ETag)
+	}
+	if objInfo.ContentType != "image/jpeg" {
+		t.Fatalf("Expected content-type image/jpeg, got %s", objInfo.ContentType)
+	}
+
+	// The etag must be stable and valid for conditional reads.
+	objInfo2, err := obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo2.ETag != objInfo.ETag {
+		t.Fatalf("Expected etag %s, got %s", objInfo.ETag, objInfo2.ETag)
+	}
+	var buf bytes.Buffer
+	if err = obj.GetObject(GlobalContext, bucketName, objectName, 0, objInfo.Size, &buf, objInfo.ETag, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "abcd" {
+		t.Fatalf("Expected abcd, got %s", buf.String())
+	}
+
+	// Modifying the file changes the etag.
+	mtime := objInfo.ModTime.Add(time.Minute)
+	if err = os.Chtimes(objPath, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	objInfo2, err = obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo2.ETag == objInfo.ETag {
+		t.Fatalf("Expected etag to change after modification")
+	}
+	if err = obj.GetObject(GlobalContext, bucketName, objectName, 0, objInfo.Size, &buf, objInfo.ETag, ObjectOptions{}); !isSameType(err, InvalidETag{}) {
+		t.Fatalf("Expected InvalidETag, got %v", err)
+	}
+
+	// A metadata only copy keeps the etag.
+	objInfo2.metadataOnly = true
+	objInfo2.UserDefined = map[string]string{"x-amz-meta-color": "blue"}
+	copyInfo, err := obj.CopyObject(GlobalContext, bucketName, objectName, bucketName, objectName, objInfo2, ObjectOptions{}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copyInfo.ETag != objInfo2.ETag {
+		t.Fatalf("Expected etag %s, got %s", objInfo2.ETag, copyInfo.ETag)
+	}
+	objInfo, err = obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag != objInfo2.ETag || objInfo.UserDefined["x-amz-meta-color"] != "blue" {
+		t.Fatalf("Expected etag %s and the new metadata, got %s %v", objInfo2.ETag, objInfo.ETag, objInfo.UserDefined)
+	}
+}
+
+// TestFSXattrMetadata - test saving object metadata in extended attributes.
+func TestFSXattrMetadata(t *testing.T) {
+	// Prepare for tests
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	if err := checkXattrSupport(disk); err != nil {
+		t.Skip("extended attributes not supported:", err)
+	}
+	fs.xattrMeta = true
+
+	bucketName := "bucket"
+	objectName := "object"
+	fsMetaPath := pathJoin(disk, minioMetaBucket, bucketMetaPrefix, bucketName, objectName, fs.metaJSONFile)
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("abcd")
+	md5Hex := getMD5Hash(data)
+	opts := ObjectOptions{UserDefined: map[string]string{"content-type": "text/plain", "x-amz-meta-key": "value"}}
+	if _, err := obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fsMetaPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no fs.json, got %v", err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag != md5Hex || objInfo.ContentType != "text/plain" || objInfo.UserDefined["x-amz-meta-key"] != "value" {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+
+	if err = obj.PutObjectTags(GlobalContext, bucketName, objectName, "k=v", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	tags, err := obj.GetObjectTags(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.String() != "k=v" {
+		t.Fatalf("Expected tags k=v, got %s", tags.String())
+	}
+
+	// Metadata too large for extended attributes is saved in fs.json.
+	opts = ObjectOptions{UserDefined: map[string]string{"x-amz-meta-key": strings.Repeat("v", 64*1024)}}
+	if _, err = obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(fsMetaPath); err != nil {
+		t.Fatalf("Expected fs.json, got %v", err)
+	}
+	objInfo, err = obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag != md5Hex || len(objInfo.UserDefined["x-amz-meta-key"]) != 64*1024 {
+		t.Fatalf("Unexpected object info etag %s", objInfo.ETag)
+	}
+
+	// Overwriting with small metadata removes the stale fs.json.
+	if _, err = obj.PutObject(GlobalContext, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = os.Stat(fsMetaPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected no fs.json, got %v", err)
+	}
+	objInfo, err = obj.GetObjectInfo(GlobalContext, bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag != md5Hex || objInfo.UserDefined["x-amz-meta-key"] != "" {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+}
+
+// TestFSCopyObjectLink - test copying objects with hard links.
+func TestFSCopyObjectLink(t *testing.T) {
+	// Prepare for tests
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	bucketName := "bucket"
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("abcd")
+	md5Hex := getMD5Hash(data)
+	if _, err := obj.PutObject(GlobalContext, bucketName, "src", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	srcPath := filepath.Join(disk, bucketName, "src")
+	srcModTime := time.Now().Add(-time.Hour).Round(time.Second)
+	if err := os.Chtimes(srcPath, srcModTime, srcModTime); err != nil {
+		t.Fatal(err)
+	}
+
+	copyObject := func(dstObject string, userDefined map[string]string) ObjectInfo {
+		srcInfo, err := obj.GetObjectInfo(GlobalContext, bucketName, "src", ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcInfo.UserDefined = userDefined
+		srcInfo.PutObjReader = mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", "")
+		objInfo, err := obj.CopyObject(GlobalContext, bucketName, "src", bucketName, dstObject, srcInfo, ObjectOptions{}, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objInfo.ETag != md5Hex || !objInfo.ModTime.After(srcModTime) {
+			t.Fatalf("Unexpected object info %#v", objInfo)
+		}
+		return objInfo
+	}
+
+	copyObject("dst", map[string]string{"content-type": "text/plain"})
+	srcFi, err := os.Stat(srcPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dstFi, err := os.Stat(filepath.Join(disk, bucketName, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !os.SameFile(srcFi, dstFi) {
+		t.Fatal("Expected the copy to be a hard link")
+	}
+	if !srcFi.ModTime().Equal(srcModTime) {
+		t.Fatalf("Expected source modification time to be unchanged, got %s", srcFi.ModTime())
+	}
+
+	objInfo, err := obj.GetObjectInfo(GlobalContext, bucketName, "dst", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ContentType != "text/plain" || !objInfo.ModTime.After(srcModTime) {
+		t.Fatalf("Unexpected object info %#v", objInfo)
+	}
+	if _, ok := objInfo.UserDefined[fsModTimeKey]; ok {
+		t.Fatal("Unexpected internal modification time in user metadata")
+	}
+
+	// Overwriting the source must not modify the copy.
+	if _, err = obj.PutObject(GlobalContext, bucketName, "src", mustGetPutObjReader(t, bytes.NewReader([]byte("efgh")), 4, "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = obj.GetObject(GlobalContext, bucketName, "dst", 0, objInfo.Size, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "abcd" {
+		t.Fatalf("Expected abcd, got %s", buf.String())
+	}
+
+	// Extended attributes are per inode, copies must not be hard links.
+	if err = checkXattrSupport(disk); err != nil {
+		return
+	}
+	fs.xattrMeta = true
+	if _, err = obj.PutObject(GlobalContext, bucketName, "src", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chtimes(srcPath, srcModTime, srcModTime); err != nil {
+		t.Fatal(err)
+	}
+	copyObject("dst-xattr", map[string]string{"content-type": "text/plain"})
+	if srcFi, err = os.Stat(srcPath); err != nil {
+		t.Fatal(err)
+	}
+	if dstFi, err = os.Stat(filepath.Join(disk, bucketName, "dst-xattr")); err != nil {
+		t.Fatal(err)
+	}
+	if os.SameFile(srcFi, dstFi) {
+		t.Fatal("Expected the copy not to be a hard link")
+	}
+	objInfo, err = obj.GetObjectInfo(GlobalContext, bucketName, "src", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ContentType == "text/plain" {
+		t.Fatal("Expected source metadata to be unchanged")
+	}
+}
+
 // TestFSDeleteObject - test fs.DeleteObject() with healthy and corrupted disks
 func TestFSDeleteObject(t *testing.T) {
 	// Prepare for tests
@@ -406,3 +687,47 @@ func TestFSHealObjects(t *testing.T) {
 		t.Fatalf("Heal Object should return NotImplemented error ")
 	}
 }
+
+// TestFSCaseConflict - writes conflicting in case only with existing
+// objects or prefixes are rejected on case insensitive filesystems.
+func TestFSCaseConflict(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	bucketName := "bucket"
+
+	if err := obj.MakeBucketWithLocation(GlobalContext, bucketName, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Tests run on case sensitive filesystems, emulate
+	// a case insensitive one to check names are compared.
+	fs.caseInsensitive = true
+
+	putObject := func(object string) error {
+		_, err := obj.PutObject(GlobalContext, bucketName, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""), ObjectOptions{})
+		return err
+	}
+
+	if err := putObject("Foo/bar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := putObject("Foo/bar"); err != nil {
+		t.Fatal(err)
+	}
+	if err := putObject("Foo/baz"); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"foo/bar", "FOO/new", "Foo/BAR"} {
+		if _, ok := putObject(object).(ObjectNameCaseConflict); !ok {
+			t.Fatalf("%s: expected case conflict", object)
+		}
+	}
+	if _, err := obj.NewMultipartUpload(GlobalContext, bucketName, "foo/multipart", ObjectOptions{}); err == nil {
+		t.Fatal("Expected case conflict")
+	} else if _, ok := err.(ObjectNameCaseConflict); !ok {
+		t.Fatalf("Expected case conflict, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/minio/minio/pkg/event"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/pkg/sftp"
+	ftp "goftp.io/server/v2"
+)
+
+var (
+	errFTPOpUnsupported = errors.New("operation not supported")
+	errFTPAccessDenied  = errors.New("access denied")
+)
+
+// ftpDriver - serves the FTP commands of the authenticated users, the
+// namespace is mapped onto the object layer the same way as for the
+// SFTP server.
+type ftpDriver struct{}
+
+// CheckPasswd - authenticates users with their access key and secret
+// key, temporary credentials and service accounts are not allowed
+// since they can not be presented along with their token.
+func (f *ftpDriver) CheckPasswd(ctx *ftp.Context, user, pass string) (bool, error) {
+	cred, _, s3Err := checkKeyValid(user)
+	if s3Err != ErrNone {
+		return false, nil
+	}
+	if !cred.IsValid() || cred.IsTemp() || cred.IsServiceAccount() {
+		return false, nil
+	}
+	return subtle.ConstantTimeCompare([]byte(cred.SecretKey), []byte(pass)) == 1, nil
+}
+
+// session - returns the driver of the user logged in the session of
+// the request, the credentials are looked up again on every request
+// so that disabled or removed users are refused.
+func (f *ftpDriver) session(ctx *ftp.Context) (*sftpDriver, ObjectLayer, error) {
+	cred, owner, s3Err := checkKeyValid(ctx.Sess.LoginUser())
+	if s3Err != ErrNone || !cred.IsValid() {
+		return nil, nil, errFTPAccessDenied
+	}
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, nil, errServerNotInitialized
+	}
+	// The TLS state of the session is not exposed, the connection
+	// is not reported secure to the policies of the user.
+	return &sftpDriver{
+		accessKey: cred.AccessKey,
+		owner:     owner,
+		remoteIP:  sftpRemoteIP(ctx.Sess.RemoteAddr()),
+	}, objAPI, nil
+}
+
+// toFTPError - converts the errors of the SFTP driver to errors
+// readable in FTP replies.
+func toFTPError(err error) error {
+	switch err {
+	case os.ErrNotExist:
+		return errFileNotFound
+	case sftp.ErrSSHFxPermissionDenied:
+		return errFTPAccessDenied
+	case sftp.ErrSSHFxOpUnsupported:
+		return errFTPOpUnsupported
+	}
+	return err
+}
+
+// Stat - returns the file info of a bucket, an object or a directory.
+func (f *ftpDriver) Stat(ctx *ftp.Context, p string) (os.FileInfo, error) {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bucket, object := splitSFTPPath(p)
+	fi, err := d.stat(objAPI, bucket, object)
+	if err != nil {
+		return nil, toFTPError(err)
+	}
+	return fi, nil
+}
+
+// ListDir - lists the buckets, or a directory of a bucket.
+func (f *ftpDriver) ListDir(ctx *ftp.Context, p string, callback func(os.FileInfo) error) error {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return err
+	}
+
+	var fis sftpFileInfos
+	bucket, object := splitSFTPPath(p)
+	if bucket == "" {
+		fis, err = d.listBuckets(objAPI)
+	} else {
+		fis, err = d.listDir(objAPI, bucket, object)
+	}
+	if err != nil {
+		return toFTPError(err)
+	}
+	for _, fi := range fis {
+		if err = callback(fi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteDir - removes an empty bucket or directory.
+func (f *ftpDriver) DeleteDir(ctx *ftp.Context, p string) error {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, object := splitSFTPPath(p)
+	return toFTPError(d.rmdir(objAPI, bucket, object))
+}
+
+// DeleteFile - removes an object.
+func (f *ftpDriver) DeleteFile(ctx *ftp.Context, p string) error {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, object := splitSFTPPath(p)
+	return toFTPError(d.remove(objAPI, bucket, object))
+}
+
+// Rename - renames an object.
+func (f *ftpDriver) Rename(ctx *ftp.Context, from, to string) error {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return err
+	}
+	srcBucket, srcObject := splitSFTPPath(from)
+	dstBucket, dstObject := splitSFTPPath(to)
+	return toFTPError(d.rename(objAPI, srcBucket, srcObject, dstBucket, dstObject))
+}
+
+// MakeDir - creates a bucket, or a directory object in a bucket.
+func (f *ftpDriver) MakeDir(ctx *ftp.Context, p string) error {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, object := splitSFTPPath(p)
+	return toFTPError(d.mkdir(objAPI, bucket, object))
+}
+
+// GetFile - reads an object from offset, set by a preceding REST
+// command to resume downloads.
+func (f *ftpDriver) GetFile(ctx *ftp.Context, p string, offset int64) (int64, io.ReadCloser, error) {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	bucket, object := splitSFTPPath(p)
+	if object == "" {
+		return 0, nil, errFTPOpUnsupported
+	}
+	if !d.isAllowed(iampolicy.GetObjectAction, bucket, object) {
+		return 0, nil, errFTPAccessDenied
+	}
+
+	reqCtx := d.context("FTPGetObject", bucket, object)
+	objInfo, err := objAPI.GetObjectInfo(reqCtx, bucket, object, ObjectOptions{})
+	if err != nil {
+		return 0, nil, toFTPError(toSFTPError(err))
+	}
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		return 0, nil, err
+	}
+	if offset >= size {
+		return 0, ioutil.NopCloser(bytes.NewReader(nil)), nil
+	}
+
+	rs := &HTTPRangeSpec{Start: offset, End: size - 1}
+	gr, err := objAPI.GetObjectNInfo(reqCtx, bucket, object, rs, nil, readLock, ObjectOptions{})
+	if err != nil {
+		return 0, nil, toFTPError(toSFTPError(err))
+	}
+	d.sendEvent(event.ObjectAccessedGet, bucket, gr.ObjInfo)
+	return size - offset, gr, nil
+}
+
+// PutFile - uploads an object from the data connection, replacing
+// any existing object. Objects can not be appended to, nor can
+// uploads be resumed.
+func (f *ftpDriver) PutFile(ctx *ftp.Context, p string, data io.Reader, offset int64) (int64, error) {
+	d, objAPI, err := f.session(ctx)
+	if err != nil {
+		return 0, err
+	}
+	bucket, object := splitSFTPPath(p)
+	if object == "" || HasSuffix(object, SlashSeparator) || offset > 0 || ctx.Cmd == "APPE" {
+		return 0, errFTPOpUnsupported
+	}
+	if !d.isAllowed(iampolicy.PutObjectAction, bucket, object) || isWORMBucket(bucket) {
+		return 0, errFTPAccessDenied
+	}
+
+	reqCtx := d.context("FTPPutObject", bucket, object)
+	newObject, err := enforceBucketQuota(reqCtx, bucket, object, 0)
+	if err != nil {
+		return 0, err
+	}
+	objInfo, err := putObjectContent(reqCtx, objAPI, nil, bucket, object, nil, data, -1, "", map[string]string{})
+	if err != nil {
+		return 0, toFTPError(toSFTPError(err))
+	}
+	accountBucketQuota(bucket, objInfo.Size, newObject)
+	d.sendEvent(event.ObjectCreatedPut, bucket, objInfo)
+	return objInfo.Size, nil
+}
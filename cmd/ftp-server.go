@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger"
+	ftp "goftp.io/server/v2"
+)
+
+// ftpServerConfig - configuration of the FTP server.
+type ftpServerConfig struct {
+	// Address the FTP server listens on.
+	Addr string
+
+	// Range of the ports of passive data connections,
+	// as "start-end", any free port is used if empty.
+	PassivePorts string
+
+	// IP address sent to clients in passive mode replies,
+	// defaults to the address of the control connection.
+	PublicIP string
+
+	// Certificate and private key of explicit FTPS (AUTH TLS),
+	// TLS is disabled when not set.
+	CertFile, KeyFile string
+}
+
+// startFTPServer - starts the FTP server serving the buckets of the
+// object layer to the users of the server.
+func startFTPServer(cfg ftpServerConfig) error {
+	server, err := newFTPServer(cfg)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && err != ftp.ErrServerClosed {
+			logger.LogIf(GlobalContext, err)
+		}
+	}()
+	return nil
+}
+
+func newFTPServer(cfg ftpServerConfig) (*ftp.Server, error) {
+	host, portStr, err := net.SplitHostPort(cfg.Addr)
+	if err != nil {
+		return nil, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 {
+		return nil, fmt.Errorf("invalid FTP port '%s'", portStr)
+	}
+	if cfg.PassivePorts != "" {
+		if err = validateFTPPassivePorts(cfg.PassivePorts); err != nil {
+			return nil, err
+		}
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, errors.New("both the certificate and the private key of FTPS must be set")
+	}
+
+	driver := &ftpDriver{}
+	return ftp.NewServer(&ftp.Options{
+		Name:           "MinIO FTP Server",
+		WelcomeMessage: "Welcome to MinIO FTP Server",
+		Driver:         driver,
+		Auth:           driver,
+		Perm:           ftp.NewSimplePerm("minio", "minio"),
+		Hostname:       host,
+		Port:           port,
+		PublicIP:       cfg.PublicIP,
+		PassivePorts:   cfg.PassivePorts,
+		TLS:            cfg.CertFile != "",
+		CertFile:       cfg.CertFile,
+		KeyFile:        cfg.KeyFile,
+		ExplicitFTPS:   true,
+		Logger:         &ftp.DiscardLogger{},
+	})
+}
+
+// validateFTPPassivePorts - validates a "start-end" port range.
+func validateFTPPassivePorts(ports string) error {
+	invalidErr := fmt.Errorf("invalid FTP passive port range '%s', expected START-END", ports)
+
+	s := strings.SplitN(ports, "-", 2)
+	if len(s) != 2 {
+		return invalidErr
+	}
+	startPort, err := strconv.Atoi(s[0])
+	if err != nil {
+		return invalidErr
+	}
+	endPort, err := strconv.Atoi(s[1])
+	if err != nil {
+		return invalidErr
+	}
+	if startPort <= 0 || endPort > 65535 || startPort > endPort {
+		return invalidErr
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+)
+
+func TestValidateFTPPassivePorts(t *testing.T) {
+	testCases := []struct {
+		ports   string
+		success bool
+	}{
+		{"30000-30100", true},
+		{"30000-30000", true},
+		{"30000", false},
+		{"30100-30000", false},
+		{"0-100", false},
+		{"30000-70000", false},
+		{"a-b", false},
+		{"", false},
+	}
+	for i, testCase := range testCases {
+		err := validateFTPPassivePorts(testCase.ports)
+		if err != nil && testCase.success {
+			t.Errorf("Test %d: expected success, got %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Errorf("Test %d: expected failure", i+1)
+		}
+	}
+}
+
+func TestFTPServer(t *testing.T) {
+	ExecObjectLayerTest(t, testFTPServer)
+}
+
+func testFTPServer(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := newFTPServer(ftpServerConfig{Addr: l.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(l)
+	defer server.Shutdown()
+
+	client, err := ftp.Dial(l.Addr().String(), ftp.DialWithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Quit()
+
+	cred := globalActiveCred
+	if err = client.Login(cred.AccessKey, "invalid-secret"); err == nil {
+		t.Fatalf("%s: expected authentication to fail", instanceType)
+	}
+	if err = client.Login(cred.AccessKey, cred.SecretKey); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	if err = client.MakeDir("/bucket"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = client.MakeDir("/bucket/dir"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	data := bytes.Repeat([]byte("abcdefgh"), 64*1024)
+	if err = client.Stor("/bucket/dir/object", bytes.NewReader(data)); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	objInfo, err := obj.GetObjectInfo(context.Background(), "bucket", "dir/object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if objInfo.Size != int64(len(data)) {
+		t.Fatalf("%s: expected size %d, got %d", instanceType, len(data), objInfo.Size)
+	}
+
+	size, err := client.FileSize("/bucket/dir/object")
+	if err != nil || size != int64(len(data)) {
+		t.Fatalf("%s: unexpected size %d of /bucket/dir/object: %v", instanceType, size, err)
+	}
+
+	r, err := client.RetrFrom("/bucket/dir/object", 8)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	got, err := ioutil.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if !bytes.Equal(got, data[8:]) {
+		t.Fatalf("%s: read data does not match written data", instanceType)
+	}
+
+	if err = client.Rename("/bucket/dir/object", "/bucket/dir/renamed"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	entries, err := client.List("/bucket/dir")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(entries) != 1 || entries[0].Name != "renamed" || entries[0].Size != uint64(len(data)) {
+		t.Fatalf("%s: unexpected listing %v", instanceType, entries)
+	}
+
+	if err = client.RemoveDir("/bucket/dir"); err == nil {
+		t.Fatalf("%s: expected non empty directory removal to fail", instanceType)
+	}
+	if err = client.Delete("/bucket/dir/renamed"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	// FS removes empty parent directories along with the object.
+	client.RemoveDir("/bucket/dir")
+	if err = client.RemoveDir("/bucket"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err = obj.GetBucketInfo(context.Background(), "bucket"); err == nil {
+		t.Fatalf("%s: expected bucket to be removed", instanceType)
+	}
+}
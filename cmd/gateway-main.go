@@ -179,7 +179,10 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	// to IPv6 address ie minio will start listening on IPv6 address whereas another
 	// (non-)minio process is listening on IPv4 of given port.
 	// To avoid this error situation we check for port availability.
-	logger.FatalIf(checkPortAvailability(globalMinioHost, globalMinioPort), "Unable to start the gateway")
+	if !xhttp.IsInherited(globalCLIContext.Addr) {
+		logger.FatalIf(checkPortAvailability(globalMinioHost, globalMinioPort), "Unable to start the gateway")
+	}
+	logger.FatalIf(checkExtraServerAddrs(), "Unable to start the gateway")
 
 	// Check and load TLS certificates.
 	var err error
@@ -216,6 +219,9 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	// Initialize server config.
 	srvCfg := newServerConfig()
 
+	// Override values from the config file, if any.
+	applyServerConfigFile(srvCfg)
+
 	// Override any values from ENVs.
 	lookupConfigs(srvCfg)
 
@@ -237,14 +243,19 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	enableIAMOps := globalEtcdClient != nil
 
 	// Enable IAM admin APIs if etcd is enabled, if not just enable basic
-	// operations such as profiling, server info etc.
-	registerAdminRouter(router, enableConfigOps, enableIAMOps)
+	// operations such as profiling, server info etc. They are served on
+	// the admin address instead, if set.
+	if globalCLIContext.AdminAddr == "" {
+		registerAdminRouter(router, enableConfigOps, enableIAMOps)
+	}
 
 	// Add healthcheck router
 	registerHealthCheckRouter(router)
 
 	// Add server metrics router
-	registerMetricsRouter(router)
+	if globalCLIContext.AdminAddr == "" {
+		registerMetricsRouter(router)
+	}
 
 	// Register web router when its enabled.
 	if globalBrowserEnabled {
@@ -262,12 +273,15 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	router.Use(registerMiddlewares)
 
 	var getCert certs.GetCertificateFunc
-	if globalTLSCerts != nil {
-		getCert = globalTLSCerts.GetCertificate
+	if globalTLSCerts != nil || globalACMEManager != nil {
+		getCert = getServerCertificate
 	}
 
-	httpServer := xhttp.NewServer([]string{globalCLIContext.Addr},
+	httpServer := xhttp.NewServer(append([]string{globalCLIContext.Addr}, globalCLIContext.ExtraAddrs...),
 		criticalErrorHandler{corsHandler(router)}, getCert)
+	httpServer.ShutdownTimeout = globalShutdownTimeout
+	httpServer.ProxyProtocol = globalProxyProtocol
+	enableACMEChallenges(httpServer)
 	httpServer.BaseContext = func(listener net.Listener) context.Context {
 		return GlobalContext
 	}
@@ -279,6 +293,8 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	globalHTTPServer = httpServer
 	globalObjLayerMutex.Unlock()
 
+	startAdminHTTPServer(configureAdminHandler(enableConfigOps, enableIAMOps), getCert)
+
 	signal.Notify(globalOSSignalCh, os.Interrupt, syscall.SIGTERM)
 
 	newObject, err := gw.NewGatewayLayer(globalActiveCred)
@@ -77,6 +77,9 @@ const (
 	azureMarkerPrefix          = "{minio}"
 	metadataPartNamePrefix     = minio.GatewayMinioSysTmp + "multipart/v1/%s.%x"
 	maxPartsCount              = 10000
+
+	// Interval between two polls of the status of a pending copy.
+	azureCopyPollInterval = 100 * time.Millisecond
 )
 
 func init() {
@@ -931,13 +934,27 @@ func (a *azureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, des
 	}
 	// StartCopyFromURL is an asynchronous operation so need to poll for completion,
 	// see https://docs.microsoft.com/en-us/rest/api/storageservices/copy-blob#remarks.
-	copyStatus := res.CopyStatus()
-	for copyStatus != azblob.CopyStatusSuccess {
+	copyStatus, copyDesc := res.CopyStatus(), ""
+	for {
+		done, err := checkAzureCopyStatus(copyStatus, copyDesc)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return objInfo, err
+		}
+		if done {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			destBlob.AbortCopyFromURL(context.Background(), res.CopyID(), azblob.LeaseAccessConditions{})
+			return objInfo, ctx.Err()
+		case <-time.After(azureCopyPollInterval):
+		}
 		destProps, err := destBlob.GetProperties(ctx, azblob.BlobAccessConditions{})
 		if err != nil {
 			return objInfo, azureToObjectError(err, srcBucket, srcObject)
 		}
-		copyStatus = destProps.CopyStatus()
+		copyStatus, copyDesc = destProps.CopyStatus(), destProps.CopyStatusDescription()
 	}
 
 	// Azure will copy metadata from the source object when an empty metadata map is provided.
@@ -966,6 +983,18 @@ func (a *azureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, des
 	return a.GetObjectInfo(ctx, destBucket, destObject, dstOpts)
 }
 
+// checkAzureCopyStatus - returns true once a copy is complete and an
+// error if it failed or was aborted.
+func checkAzureCopyStatus(status azblob.CopyStatusType, description string) (bool, error) {
+	switch status {
+	case azblob.CopyStatusSuccess:
+		return true, nil
+	case azblob.CopyStatusFailed, azblob.CopyStatusAborted:
+		return false, fmt.Errorf("azure copy %s: %s", status, description)
+	}
+	return false, nil
+}
+
 // DeleteObject - Deletes a blob on azure container, uses Azure
 // equivalent `BlobURL.Delete`.
 func (a *azureObjects) DeleteObject(ctx context.Context, bucket, object string, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
@@ -1062,6 +1091,8 @@ func (a *azureObjects) NewMultipartUpload(ctx context.Context, bucket, object st
 	return uploadID, nil
 }
 
+// CopyObjectPart - Stages the copied data as the blocks of the part,
+// same as PutObjectPart.
 func (a *azureObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, uploadID string, partID int,
 	startOffset int64, length int64, srcInfo minio.ObjectInfo, srcOpts, dstOpts minio.ObjectOptions) (info minio.PartInfo, err error) {
 	return a.PutObjectPart(ctx, dstBucket, dstObject, uploadID, partID, srcInfo.PutObjReader, dstOpts)
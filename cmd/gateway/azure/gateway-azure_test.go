@@ -301,3 +301,26 @@ func TestParsingUploadChunkSize(t *testing.T) {
 	}
 
 }
+
+func TestCheckAzureCopyStatus(t *testing.T) {
+	testCases := []struct {
+		status    azblob.CopyStatusType
+		done      bool
+		shouldErr bool
+	}{
+		{azblob.CopyStatusNone, false, false},
+		{azblob.CopyStatusPending, false, false},
+		{azblob.CopyStatusSuccess, true, false},
+		{azblob.CopyStatusFailed, false, true},
+		{azblob.CopyStatusAborted, false, true},
+	}
+	for i, testCase := range testCases {
+		done, err := checkAzureCopyStatus(testCase.status, "")
+		if done != testCase.done {
+			t.Errorf("Test %d: expected: %t, got: %t", i+1, testCase.done, done)
+		}
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Test %d: expected error: %t, got: %v", i+1, testCase.shouldErr, err)
+		}
+	}
+}
@@ -233,6 +233,12 @@ func gcsMultipartDataName(uploadID string, partNumber int, etag string) string {
 	return fmt.Sprintf("%s/%s/%05d.%s", gcsMinioMultipartPathV1, uploadID, partNumber, etag)
 }
 
+// Returns name of the intermediate object composed while completing
+// a multipart upload.
+func gcsMultipartComposeName(uploadID string, level, composeNumber int) string {
+	return fmt.Sprintf("%stmp/%s/composed-object-%d-%05d", minio.GatewayMinioSysTmp, uploadID, level, composeNumber)
+}
+
 // Convert MinIO errors to minio object layer errors.
 func gcsToObjectError(err error, params ...string) error {
 	if err == nil {
@@ -1331,18 +1337,22 @@ func (l *gcsGateway) CompleteMultipartUpload(ctx context.Context, bucket string,
 		}
 	}
 
-	// Returns name of the composed object.
-	gcsMultipartComposeName := func(uploadID string, composeNumber int) string {
-		return fmt.Sprintf("%s/tmp/%s/composed-object-%05d", minio.GatewayMinioSysTmp, uploadID, composeNumber)
-	}
-
-	composeCount := int(math.Ceil(float64(len(parts)) / float64(gcsMaxComponents)))
-	if composeCount > 1 {
-		// Create composes of every 32 parts.
+	// GCS composes at most 32 components at once, compose every 32
+	// parts into an intermediate object until the remaining objects
+	// can be composed into the final object.
+	var composed []*storage.ObjectHandle
+	defer func() {
+		for _, composePart := range composed {
+			// Ignore the error, CleanupGCSMinioSysTmp removes leftovers.
+			composePart.Delete(ctx)
+		}
+	}()
+	for level := 0; len(parts) > gcsMaxComponents; level++ {
+		composeCount := int(math.Ceil(float64(len(parts)) / float64(gcsMaxComponents)))
 		composeParts := make([]*storage.ObjectHandle, composeCount)
 		for i := 0; i < composeCount; i++ {
 			// Create 'composed-object-N' using next 32 parts.
-			composeParts[i] = l.client.Bucket(bucket).Object(gcsMultipartComposeName(uploadID, i))
+			composeParts[i] = l.client.Bucket(bucket).Object(gcsMultipartComposeName(uploadID, level, i))
 			start := i * gcsMaxComponents
 			end := start + gcsMaxComponents
 			if end > len(parts) {
@@ -1357,9 +1367,10 @@ func (l *gcsGateway) CompleteMultipartUpload(ctx context.Context, bucket string,
 				logger.LogIf(ctx, err)
 				return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key)
 			}
+			composed = append(composed, composeParts[i])
 		}
 
-		// As composes are successfully created, final object needs to be created using composes.
+		// As composes are successfully created, next level needs to be created using composes.
 		parts = composeParts
 	}
 
@@ -165,6 +165,20 @@ func TestGCSMultipartDataName(t *testing.T) {
 	}
 }
 
+// Test for gcsMultipartComposeName.
+func TestGCSMultipartComposeName(t *testing.T) {
+	var (
+		uploadID      = "a"
+		level         = 1
+		composeNumber = 2
+	)
+	expected := path.Join(minio.GatewayMinioSysTmp, "tmp", uploadID, "composed-object-1-00002")
+	got := gcsMultipartComposeName(uploadID, level, composeNumber)
+	if expected != got {
+		t.Errorf("expected: %s, got: %s", expected, got)
+	}
+}
+
 func TestFromMinioClientListBucketResultToV2Info(t *testing.T) {
 
 	listBucketResult := miniogo.ListBucketResult{
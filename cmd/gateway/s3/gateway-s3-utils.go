@@ -17,9 +17,78 @@
 package s3
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
 	minio "github.com/minio/minio/cmd"
 )
 
+// Maps the buckets served by the gateway to upstream buckets, e.g.
+// "photos:prod-photos,logs:prod-logs".
+const s3BucketMapEnv = "MINIO_S3_BUCKET_MAP"
+
+// s3BucketMap - local bucket name to upstream bucket name.
+type s3BucketMap map[string]string
+
+// parseS3BucketMap - parses a comma separated list of
+// "local:remote" bucket pairs.
+func parseS3BucketMap(s string) (s3BucketMap, error) {
+	if s == "" {
+		return nil, nil
+	}
+	m := make(s3BucketMap)
+	remotes := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid bucket mapping %q, expected local:remote", pair)
+		}
+		local, remote := kv[0], kv[1]
+		if s3utils.CheckValidBucketName(local) != nil || s3utils.CheckValidBucketName(remote) != nil {
+			return nil, fmt.Errorf("invalid bucket mapping %q, invalid bucket name", pair)
+		}
+		if _, ok := m[local]; ok {
+			return nil, fmt.Errorf("bucket %s is mapped more than once", local)
+		}
+		if other, ok := remotes[remote]; ok {
+			return nil, fmt.Errorf("buckets %s and %s are both mapped to %s", other, local, remote)
+		}
+		m[local] = remote
+		remotes[remote] = local
+	}
+	return m, nil
+}
+
+// remoteBucket - returns the upstream name of bucket.
+func (l *s3Objects) remoteBucket(bucket string) string {
+	if remote, ok := l.BucketMap[bucket]; ok {
+		return remote
+	}
+	return bucket
+}
+
+// localBucket - returns the name under which the upstream bucket is
+// served, false if it is hidden by a mapping of another bucket.
+func (l *s3Objects) localBucket(remote string) (string, bool) {
+	for local, r := range l.BucketMap {
+		if r == remote {
+			return local, true
+		}
+	}
+	if _, ok := l.BucketMap[remote]; ok {
+		return "", false
+	}
+	return remote, true
+}
+
+// isMappedBucket - returns true if bucket is served under another
+// name upstream.
+func (l *s3Objects) isMappedBucket(bucket string) bool {
+	remote, ok := l.BucketMap[bucket]
+	return ok && remote != bucket
+}
+
 // List of header keys to be filtered, usually
 // from all S3 API http responses.
 var defaultFilterKeys = []string{
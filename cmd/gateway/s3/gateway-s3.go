@@ -38,6 +38,7 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/env"
 )
 
 const (
@@ -73,6 +74,12 @@ EXAMPLES:
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_CACHE_WATERMARK_LOW{{.AssignmentOperator}}75
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_CACHE_WATERMARK_HIGH{{.AssignmentOperator}}85
      {{.Prompt}} {{.HelpName}}
+
+  3. Start minio gateway server for AWS S3 backend serving the upstream buckets 'prod-photos' and 'prod-logs' as 'photos' and 'logs'
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_S3_BUCKET_MAP{{.AssignmentOperator}}"photos:prod-photos,logs:prod-logs"
+     {{.Prompt}} {{.HelpName}}
 `
 
 	minio.RegisterGatewayCommand(cli.Command{
@@ -234,12 +241,18 @@ func (g *S3) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error)
 		}
 	}
 
+	bucketMap, err := parseS3BucketMap(env.Get(s3BucketMapEnv, ""))
+	if err != nil {
+		return nil, err
+	}
+
 	s := s3Objects{
 		Client:  clnt,
 		Metrics: metrics,
 		HTTPClient: &http.Client{
 			Transport: t,
 		},
+		BucketMap: bucketMap,
 	}
 
 	// Enables single encryption of KMS is configured.
@@ -266,6 +279,9 @@ type s3Objects struct {
 	Client     *miniogo.Core
 	HTTPClient *http.Client
 	Metrics    *minio.Metrics
+
+	// Maps local bucket names to upstream bucket names.
+	BucketMap s3BucketMap
 }
 
 // GetMetrics returns this gateway's metrics
@@ -302,7 +318,7 @@ func (l *s3Objects) MakeBucketWithLocation(ctx context.Context, bucket string, o
 	if s3utils.CheckValidBucketName(bucket) != nil {
 		return minio.BucketNameInvalid{Bucket: bucket}
 	}
-	err := l.Client.MakeBucket(ctx, bucket, miniogo.MakeBucketOptions{Region: opts.Location})
+	err := l.Client.MakeBucket(ctx, l.remoteBucket(bucket), miniogo.MakeBucketOptions{Region: opts.Location})
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -316,7 +332,7 @@ func (l *s3Objects) GetBucketInfo(ctx context.Context, bucket string) (bi minio.
 		// Listbuckets may be disallowed, proceed to check if
 		// bucket indeed exists, if yes return success.
 		var ok bool
-		if ok, err = l.Client.BucketExists(ctx, bucket); err != nil {
+		if ok, err = l.Client.BucketExists(ctx, l.remoteBucket(bucket)); err != nil {
 			return bi, minio.ErrorRespToObjectError(err, bucket)
 		}
 		if !ok {
@@ -329,12 +345,12 @@ func (l *s3Objects) GetBucketInfo(ctx context.Context, bucket string) (bi minio.
 	}
 
 	for _, bi := range buckets {
-		if bi.Name != bucket {
+		if bi.Name != l.remoteBucket(bucket) {
 			continue
 		}
 
 		return minio.BucketInfo{
-			Name:    bi.Name,
+			Name:    bucket,
 			Created: bi.CreationDate,
 		}, nil
 	}
@@ -349,12 +365,16 @@ func (l *s3Objects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 		return nil, minio.ErrorRespToObjectError(err)
 	}
 
-	b := make([]minio.BucketInfo, len(buckets))
-	for i, bi := range buckets {
-		b[i] = minio.BucketInfo{
-			Name:    bi.Name,
-			Created: bi.CreationDate,
+	b := make([]minio.BucketInfo, 0, len(buckets))
+	for _, bi := range buckets {
+		bucket, ok := l.localBucket(bi.Name)
+		if !ok {
+			continue
 		}
+		b = append(b, minio.BucketInfo{
+			Name:    bucket,
+			Created: bi.CreationDate,
+		})
 	}
 
 	return b, err
@@ -362,7 +382,7 @@ func (l *s3Objects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 
 // DeleteBucket deletes a bucket on S3
 func (l *s3Objects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
-	err := l.Client.RemoveBucket(ctx, bucket)
+	err := l.Client.RemoveBucket(ctx, l.remoteBucket(bucket))
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -371,7 +391,7 @@ func (l *s3Objects) DeleteBucket(ctx context.Context, bucket string, forceDelete
 
 // ListObjects lists all blobs in S3 bucket filtered by prefix
 func (l *s3Objects) ListObjects(ctx context.Context, bucket string, prefix string, marker string, delimiter string, maxKeys int) (loi minio.ListObjectsInfo, e error) {
-	result, err := l.Client.ListObjects(bucket, prefix, marker, delimiter, maxKeys)
+	result, err := l.Client.ListObjects(l.remoteBucket(bucket), prefix, marker, delimiter, maxKeys)
 	if err != nil {
 		return loi, minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -381,7 +401,7 @@ func (l *s3Objects) ListObjects(ctx context.Context, bucket string, prefix strin
 
 // ListObjectsV2 lists all blobs in S3 bucket filtered by prefix
 func (l *s3Objects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (loi minio.ListObjectsV2Info, e error) {
-	result, err := l.Client.ListObjectsV2(bucket, prefix, continuationToken, fetchOwner, delimiter, maxKeys)
+	result, err := l.Client.ListObjectsV2(l.remoteBucket(bucket), prefix, continuationToken, fetchOwner, delimiter, maxKeys)
 	if err != nil {
 		return loi, minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -433,7 +453,7 @@ func (l *s3Objects) GetObject(ctx context.Context, bucket string, key string, st
 			return minio.ErrorRespToObjectError(err, bucket, key)
 		}
 	}
-	object, _, _, err := l.Client.GetObject(ctx, bucket, key, opts)
+	object, _, _, err := l.Client.GetObject(ctx, l.remoteBucket(bucket), key, opts)
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, key)
 	}
@@ -446,7 +466,7 @@ func (l *s3Objects) GetObject(ctx context.Context, bucket string, key string, st
 
 // GetObjectInfo reads object info and replies back ObjectInfo
 func (l *s3Objects) GetObjectInfo(ctx context.Context, bucket string, object string, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
-	oi, err := l.Client.StatObject(ctx, bucket, object, miniogo.StatObjectOptions{
+	oi, err := l.Client.StatObject(ctx, l.remoteBucket(bucket), object, miniogo.StatObjectOptions{
 		ServerSideEncryption: opts.ServerSideEncryption,
 	})
 	if err != nil {
@@ -474,7 +494,7 @@ func (l *s3Objects) PutObject(ctx context.Context, bucket string, object string,
 		UserTags:             tagMap,
 	}
 
-	ui, err := l.Client.PutObject(ctx, bucket, object, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), putOpts)
+	ui, err := l.Client.PutObject(ctx, l.remoteBucket(bucket), object, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), putOpts)
 	if err != nil {
 		return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -514,7 +534,7 @@ func (l *s3Objects) CopyObject(ctx context.Context, srcBucket string, srcObject
 		srcInfo.UserDefined[k] = v[0]
 	}
 
-	if _, err = l.Client.CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo.UserDefined); err != nil {
+	if _, err = l.Client.CopyObject(ctx, l.remoteBucket(srcBucket), srcObject, l.remoteBucket(dstBucket), dstObject, srcInfo.UserDefined); err != nil {
 		return objInfo, minio.ErrorRespToObjectError(err, srcBucket, srcObject)
 	}
 	return l.GetObjectInfo(ctx, dstBucket, dstObject, dstOpts)
@@ -522,7 +542,7 @@ func (l *s3Objects) CopyObject(ctx context.Context, srcBucket string, srcObject
 
 // DeleteObject deletes a blob in bucket
 func (l *s3Objects) DeleteObject(ctx context.Context, bucket string, object string, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
-	err := l.Client.RemoveObject(ctx, bucket, object, miniogo.RemoveObjectOptions{})
+	err := l.Client.RemoveObject(ctx, l.remoteBucket(bucket), object, miniogo.RemoveObjectOptions{})
 	if err != nil {
 		return minio.ObjectInfo{}, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -549,7 +569,7 @@ func (l *s3Objects) DeleteObjects(ctx context.Context, bucket string, objects []
 
 // ListMultipartUploads lists all multipart uploads.
 func (l *s3Objects) ListMultipartUploads(ctx context.Context, bucket string, prefix string, keyMarker string, uploadIDMarker string, delimiter string, maxUploads int) (lmi minio.ListMultipartsInfo, e error) {
-	result, err := l.Client.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	result, err := l.Client.ListMultipartUploads(ctx, l.remoteBucket(bucket), prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
 	if err != nil {
 		return lmi, err
 	}
@@ -574,7 +594,7 @@ func (l *s3Objects) NewMultipartUpload(ctx context.Context, bucket string, objec
 		ServerSideEncryption: o.ServerSideEncryption,
 		UserTags:             tagMap,
 	}
-	uploadID, err = l.Client.NewMultipartUpload(ctx, bucket, object, opts)
+	uploadID, err = l.Client.NewMultipartUpload(ctx, l.remoteBucket(bucket), object, opts)
 	if err != nil {
 		return uploadID, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -584,7 +604,7 @@ func (l *s3Objects) NewMultipartUpload(ctx context.Context, bucket string, objec
 // PutObjectPart puts a part of object in bucket
 func (l *s3Objects) PutObjectPart(ctx context.Context, bucket string, object string, uploadID string, partID int, r *minio.PutObjReader, opts minio.ObjectOptions) (pi minio.PartInfo, e error) {
 	data := r.Reader
-	info, err := l.Client.PutObjectPart(ctx, bucket, object, uploadID, partID, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), opts.ServerSideEncryption)
+	info, err := l.Client.PutObjectPart(ctx, l.remoteBucket(bucket), object, uploadID, partID, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), opts.ServerSideEncryption)
 	if err != nil {
 		return pi, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -614,7 +634,7 @@ func (l *s3Objects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, de
 		srcInfo.UserDefined[k] = v[0]
 	}
 
-	completePart, err := l.Client.CopyObjectPart(ctx, srcBucket, srcObject, destBucket, destObject,
+	completePart, err := l.Client.CopyObjectPart(ctx, l.remoteBucket(srcBucket), srcObject, l.remoteBucket(destBucket), destObject,
 		uploadID, partID, startOffset, length, srcInfo.UserDefined)
 	if err != nil {
 		return p, minio.ErrorRespToObjectError(err, srcBucket, srcObject)
@@ -634,7 +654,7 @@ func (l *s3Objects) GetMultipartInfo(ctx context.Context, bucket, object, upload
 
 // ListObjectParts returns all object parts for specified object in specified bucket
 func (l *s3Objects) ListObjectParts(ctx context.Context, bucket string, object string, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (lpi minio.ListPartsInfo, e error) {
-	result, err := l.Client.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts)
+	result, err := l.Client.ListObjectParts(ctx, l.remoteBucket(bucket), object, uploadID, partNumberMarker, maxParts)
 	if err != nil {
 		return lpi, err
 	}
@@ -642,7 +662,7 @@ func (l *s3Objects) ListObjectParts(ctx context.Context, bucket string, object s
 	if lpi.IsTruncated && maxParts > len(lpi.Parts) {
 		partNumberMarker = lpi.NextPartNumberMarker
 		for {
-			result, err = l.Client.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts)
+			result, err = l.Client.ListObjectParts(ctx, l.remoteBucket(bucket), object, uploadID, partNumberMarker, maxParts)
 			if err != nil {
 				return lpi, err
 			}
@@ -662,13 +682,13 @@ func (l *s3Objects) ListObjectParts(ctx context.Context, bucket string, object s
 
 // AbortMultipartUpload aborts a ongoing multipart upload
 func (l *s3Objects) AbortMultipartUpload(ctx context.Context, bucket string, object string, uploadID string) error {
-	err := l.Client.AbortMultipartUpload(ctx, bucket, object, uploadID)
+	err := l.Client.AbortMultipartUpload(ctx, l.remoteBucket(bucket), object, uploadID)
 	return minio.ErrorRespToObjectError(err, bucket, object)
 }
 
 // CompleteMultipartUpload completes ongoing multipart upload and finalizes object
 func (l *s3Objects) CompleteMultipartUpload(ctx context.Context, bucket string, object string, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (oi minio.ObjectInfo, e error) {
-	etag, err := l.Client.CompleteMultipartUpload(ctx, bucket, object, uploadID, minio.ToMinioClientCompleteParts(uploadedParts))
+	etag, err := l.Client.CompleteMultipartUpload(ctx, l.remoteBucket(bucket), object, uploadID, minio.ToMinioClientCompleteParts(uploadedParts))
 	if err != nil {
 		return oi, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -678,6 +698,10 @@ func (l *s3Objects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 // SetBucketPolicy sets policy on bucket
 func (l *s3Objects) SetBucketPolicy(ctx context.Context, bucket string, bucketPolicy *policy.Policy) error {
+	if l.isMappedBucket(bucket) {
+		// Policy resources name the bucket, which differs upstream.
+		return minio.NotImplemented{}
+	}
 	data, err := json.Marshal(bucketPolicy)
 	if err != nil {
 		// This should not happen.
@@ -694,6 +718,9 @@ func (l *s3Objects) SetBucketPolicy(ctx context.Context, bucket string, bucketPo
 
 // GetBucketPolicy will get policy on bucket
 func (l *s3Objects) GetBucketPolicy(ctx context.Context, bucket string) (*policy.Policy, error) {
+	if l.isMappedBucket(bucket) {
+		return nil, minio.BucketPolicyNotFound{Bucket: bucket}
+	}
 	data, err := l.Client.GetBucketPolicy(ctx, bucket)
 	if err != nil {
 		return nil, minio.ErrorRespToObjectError(err, bucket)
@@ -705,6 +732,9 @@ func (l *s3Objects) GetBucketPolicy(ctx context.Context, bucket string) (*policy
 
 // DeleteBucketPolicy deletes all policies on bucket
 func (l *s3Objects) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	if l.isMappedBucket(bucket) {
+		return minio.NotImplemented{}
+	}
 	if err := l.Client.SetBucketPolicy(ctx, bucket, ""); err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, "")
 	}
@@ -719,7 +749,7 @@ func (l *s3Objects) GetObjectTags(ctx context.Context, bucket string, object str
 		return nil, minio.ErrorRespToObjectError(err, bucket, object)
 	}
 
-	tagsMap, err := l.Client.GetObjectTagging(ctx, bucket, object, miniogo.GetObjectTaggingOptions{})
+	tagsMap, err := l.Client.GetObjectTagging(ctx, l.remoteBucket(bucket), object, miniogo.GetObjectTaggingOptions{})
 	if err != nil {
 		return nil, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -738,7 +768,7 @@ func (l *s3Objects) PutObjectTags(ctx context.Context, bucket, object string, ta
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, object)
 	}
-	if err = l.Client.PutObjectTagging(ctx, bucket, object, tagObj.ToMap(), miniogo.PutObjectTaggingOptions{}); err != nil {
+	if err = l.Client.PutObjectTagging(ctx, l.remoteBucket(bucket), object, tagObj.ToMap(), miniogo.PutObjectTaggingOptions{}); err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, object)
 	}
 	return nil
@@ -746,7 +776,7 @@ func (l *s3Objects) PutObjectTags(ctx context.Context, bucket, object string, ta
 
 // DeleteObjectTags removes the tags attached to the object
 func (l *s3Objects) DeleteObjectTags(ctx context.Context, bucket, object string, opts minio.ObjectOptions) error {
-	if err := l.Client.RemoveObjectTagging(ctx, bucket, object, miniogo.RemoveObjectTaggingOptions{}); err != nil {
+	if err := l.Client.RemoveObjectTagging(ctx, l.remoteBucket(bucket), object, miniogo.RemoveObjectTaggingOptions{}); err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, object)
 	}
 	return nil
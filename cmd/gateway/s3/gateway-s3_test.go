@@ -122,3 +122,49 @@ func TestS3ToObjectError(t *testing.T) {
 		}
 	}
 }
+
+func TestParseS3BucketMap(t *testing.T) {
+	testCases := []struct {
+		input     string
+		expected  s3BucketMap
+		shouldErr bool
+	}{
+		{"", nil, false},
+		{"photos:prod-photos", s3BucketMap{"photos": "prod-photos"}, false},
+		{"photos:prod-photos, logs:prod-logs", s3BucketMap{"photos": "prod-photos", "logs": "prod-logs"}, false},
+		{"photos", nil, true},
+		{"photos:", nil, true},
+		{"photos:prod-photos,photos:prod-logs", nil, true},
+		{"photos:prod-photos,logs:prod-photos", nil, true},
+	}
+	for i, testCase := range testCases {
+		m, err := parseS3BucketMap(testCase.input)
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Test %d: expected error: %t, got: %v", i+1, testCase.shouldErr, err)
+		}
+		if err == nil && fmt.Sprint(m) != fmt.Sprint(testCase.expected) {
+			t.Errorf("Test %d: expected: %v, got: %v", i+1, testCase.expected, m)
+		}
+	}
+}
+
+func TestS3BucketMapping(t *testing.T) {
+	l := &s3Objects{BucketMap: s3BucketMap{"photos": "prod-photos", "logs": "logs-2020"}}
+
+	if remote := l.remoteBucket("photos"); remote != "prod-photos" {
+		t.Errorf("expected: prod-photos, got: %s", remote)
+	}
+	if remote := l.remoteBucket("other"); remote != "other" {
+		t.Errorf("expected: other, got: %s", remote)
+	}
+	if local, ok := l.localBucket("prod-photos"); !ok || local != "photos" {
+		t.Errorf("expected: photos, got: %s", local)
+	}
+	// Upstream 'logs' is hidden by the mapping of the local 'logs'.
+	if _, ok := l.localBucket("logs"); ok {
+		t.Errorf("expected upstream bucket logs to be hidden")
+	}
+	if !l.isMappedBucket("photos") || l.isMappedBucket("other") {
+		t.Errorf("unexpected isMappedBucket result")
+	}
+}
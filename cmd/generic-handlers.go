@@ -48,22 +48,23 @@ func registerMiddlewares(next http.Handler) http.Handler {
 // which is more than enough to accommodate any form data fields and headers.
 const requestFormDataSize = 64 * humanize.MiByte
 
-// For any HTTP request, request body should be not more than 16GiB + requestFormDataSize
-// where, 16GiB is the maximum allowed object size for object upload.
-const requestMaxBodySize = globalMaxObjectSize + requestFormDataSize
+// For any HTTP request, request body should be not more than the maximum
+// allowed object size for object upload + requestFormDataSize.
+func requestMaxBodySize() int64 {
+	return globalAPIConfig.getMaxObjectSize() + requestFormDataSize
+}
 
 type requestSizeLimitHandler struct {
-	handler     http.Handler
-	maxBodySize int64
+	handler http.Handler
 }
 
 func setRequestSizeLimitHandler(h http.Handler) http.Handler {
-	return requestSizeLimitHandler{handler: h, maxBodySize: requestMaxBodySize}
+	return requestSizeLimitHandler{handler: h}
 }
 
 func (h requestSizeLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Restricting read data to a given maximum length
-	r.Body = http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	r.Body = http.MaxBytesReader(w, r.Body, requestMaxBodySize())
 	h.handler.ServeHTTP(w, r)
 }
 
@@ -304,7 +305,7 @@ func setReservedBucketHandler(h http.Handler) http.Handler {
 
 func (h minioReservedBucketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch {
-	case guessIsRPCReq(r), guessIsBrowserReq(r), guessIsHealthCheckReq(r), guessIsMetricsReq(r), isAdminReq(r):
+	case guessIsRPCReq(r), guessIsBrowserReq(r), guessIsHealthCheckReq(r), guessIsMetricsReq(r), guessIsSwiftReq(r), isAdminReq(r):
 		// Allow access to reserved buckets
 	default:
 		// For all other requests reject access to reserved buckets
@@ -318,6 +319,34 @@ func (h minioReservedBucketHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	h.handler.ServeHTTP(w, r)
 }
 
+// readReplicaHandler - rejects the writes of objects to read
+// replica buckets, with the source of the bucket as hint.
+type readReplicaHandler struct {
+	handler http.Handler
+}
+
+func setReadReplicaHandler(h http.Handler) http.Handler {
+	return readReplicaHandler{h}
+}
+
+func (h readReplicaHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut, http.MethodPost, http.MethodDelete:
+		// Bucket configurations, and the bucket, can still be
+		// changed. POST on buckets uploads or deletes objects.
+		bucket, object := request2BucketObjectName(r)
+		if object == "" && r.Method != http.MethodPost {
+			break
+		}
+		if replica, ok := readReplicaSource(bucket); ok {
+			w.Header().Set(xhttp.MinIOReadReplicaSource, strings.TrimSuffix(replica.Endpoint, SlashSeparator)+SlashSeparator+replica.Bucket)
+			writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrBucketReadReplica), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
 type timeValidityHandler struct {
 	handler http.Handler
 }
@@ -406,7 +435,6 @@ var supportedDummyBucketAPIs = map[string][]string{
 	"website":        {http.MethodGet, http.MethodDelete},
 	"logging":        {http.MethodGet},
 	"accelerate":     {http.MethodGet},
-	"replication":    {http.MethodGet},
 	"requestPayment": {http.MethodGet},
 }
 
@@ -418,7 +446,6 @@ var notImplementedBucketResourceNames = map[string]struct{}{
 	"logging":        {},
 	"inventory":      {},
 	"accelerate":     {},
-	"replication":    {},
 	"requestPayment": {},
 }
 
@@ -595,7 +622,7 @@ type bucketForwardingHandler struct {
 func (f bucketForwardingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if globalDNSConfig == nil || len(globalDomainNames) == 0 ||
 		guessIsHealthCheckReq(r) || guessIsMetricsReq(r) ||
-		guessIsRPCReq(r) || guessIsLoginSTSReq(r) || isAdminReq(r) ||
+		guessIsRPCReq(r) || guessIsLoginSTSReq(r) || guessIsSwiftReq(r) || isAdminReq(r) ||
 		!globalBucketFederation {
 		f.handler.ServeHTTP(w, r)
 		return
@@ -708,7 +735,7 @@ func setBucketForwardingHandler(h http.Handler) http.Handler {
 	return bucketForwardingHandler{fwd, h}
 }
 
-// customHeaderHandler sets x-amz-request-id header.
+// customHeaderHandler sets x-amz-request-id and x-amz-id-2 headers.
 // Previously, this value was set right before a response was sent to
 // the client. So, logger and Error response XML were not using this
 // value. This is set here so that this header can be logged as
@@ -724,7 +751,10 @@ func addCustomHeaders(h http.Handler) http.Handler {
 func (s customHeaderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Set custom headers such as x-amz-request-id for each request.
 	w.Header().Set(xhttp.AmzRequestID, mustGetRequestID(UTCNow()))
-	s.handler.ServeHTTP(logger.NewResponseWriter(w), r)
+	w.Header().Set(xhttp.AmzRequestHostID, mustGetRequestHostID())
+	rw := logger.NewResponseWriter(w)
+	s.handler.ServeHTTP(rw, r)
+	rememberRequest(rw, r)
 }
 
 type securityHeaderHandler struct {
@@ -24,6 +24,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/set"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config/antivirus"
 	"github.com/minio/minio/cmd/config/cache"
 	"github.com/minio/minio/cmd/config/compress"
 	"github.com/minio/minio/cmd/config/etcd/dns"
@@ -31,6 +32,7 @@ import (
 	"github.com/minio/minio/cmd/config/identity/openid"
 	"github.com/minio/minio/cmd/config/policy/opa"
 	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio/cmd/config/tier"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
@@ -105,13 +107,33 @@ var globalCLIContext = struct {
 	JSON, Quiet    bool
 	Anonymous      bool
 	Addr           string
+	ExtraAddrs     []string
+	AdminAddr      string
 	StrictS3Compat bool
+	SFTPAddr       string
+	SFTPHostKey    string
+	FTP            ftpServerConfig
+	Swift          bool
+	Backend        string
+	MemoryMaxSize  int64
 }{}
 
 var (
 	// Indicates set drive count.
 	globalErasureSetDriveCount int
 
+	// Failure domains of the hosts, the hosts not listed
+	// are a failure domain on their own.
+	globalFailureDomains failureDomains
+
+	// Whether the parity is chosen from the drives per set and
+	// their failure domains when the drives are formatted.
+	globalErasureAutoParity bool
+
+	// Standard storage class parity chosen when the drives were
+	// formatted, zero for the drives formatted before it was recorded.
+	globalErasureFormatParity int
+
 	// Indicates if the running minio server is distributed setup.
 	globalIsDistErasure = false
 
@@ -173,7 +195,12 @@ var (
 
 	globalTLSCerts *certs.Certs
 
+	// Certificate of this node for the TLS connections between the nodes,
+	// nil unless enabled with MINIO_INTERNODE_TLS.
+	globalInternodeTLS *internodeTLS
+
 	globalHTTPServer        *xhttp.Server
+	globalAdminHTTPServer   *xhttp.Server
 	globalHTTPServerErrorCh = make(chan error)
 	globalOSSignalCh        = make(chan os.Signal, 1)
 
@@ -218,7 +245,10 @@ var (
 
 	globalBucketObjectLockSys *BucketObjectLockSys
 	globalBucketQuotaSys      *BucketQuotaSys
+	globalBucketHooksSys      *BucketHooksSys
 	globalBucketVersioningSys *BucketVersioningSys
+	globalReplicationSys      *ReplicationSys
+	globalTenantSys           *TenantSys
 
 	// Disk cache drives
 	globalCacheConfig cache.Config
@@ -247,6 +277,12 @@ var (
 	// Is compression enabled?
 	globalCompressConfig compress.Config
 
+	// Remote tiers objects are transitioned to by lifecycle rules.
+	globalTierConfig tier.Config
+
+	// Antivirus service uploads are scanned with.
+	globalAntivirusConfig antivirus.Config
+
 	// Some standard object extensions which we strictly dis-allow for compression.
 	standardExcludeCompressExtensions = []string{".gz", ".bz2", ".rar", ".zip", ".7z", ".xz", ".mp4", ".mkv", ".mov"}
 
@@ -279,7 +315,40 @@ var (
 	// If writes to FS backend should be O_SYNC.
 	globalFSOSync bool
 
+	// If FS backend should save object metadata in
+	// extended attributes instead of `fs.json`.
+	globalFSXattr bool
+
+	// Space to keep free on every disk.
+	globalDiskReserve diskReserve
+
+	// Grace period of the requests in progress on shutdown.
+	globalShutdownTimeout = xhttp.DefaultShutdownTimeout
+
+	// If the PROXY protocol header sent by the trusted proxies is read.
+	globalProxyProtocol bool
+
+	// If the canonical request and string to sign of the requests
+	// with a mismatching signature are logged.
+	globalDebugSignature bool
+
+	// If the faults set with the admin API are injected in the
+	// drive operations and the calls to the other servers.
+	globalFaultInjection bool
+
+	// Migration of an FS deployment into erasure mode.
+	globalFSMigration = &fsMigration{}
+
+	// Bucket mirror jobs running on this server.
+	globalBucketMirrors = newBucketMirrors()
+
+	// Cluster migration jobs running on this server.
+	globalClusterMigrations = newClusterMigrations()
+
 	globalProxyEndpoints []ProxyEndpoint
+
+	// Liveness of the peers, tracked in distributed mode.
+	globalPeerHeartbeat = newPeerHeartbeat()
 	// Add new variable global values here.
 )
 
@@ -31,6 +31,17 @@ type apiConfig struct {
 	requestsPool     chan struct{}
 	readyDeadline    time.Duration
 	corsAllowOrigins []string
+	etagMode         string
+	strictErrors     bool
+	maxObjectSize    int64
+	maxPartSize      int64
+	maxParts         int
+
+	objectNameNormalization   string
+	objectNameDisallowedChars string
+	sniffContentType          bool
+	gzipResponses             bool
+	anonymousBandwidth        int64
 }
 
 func (t *apiConfig) init(cfg api.Config) {
@@ -39,6 +50,16 @@ func (t *apiConfig) init(cfg api.Config) {
 
 	t.readyDeadline = cfg.APIReadyDeadline
 	t.corsAllowOrigins = cfg.APICorsAllowOrigin
+	t.etagMode = cfg.APIETagMode
+	t.strictErrors = cfg.APIStrictErrors
+	t.maxObjectSize = cfg.APIMaxObjectSize
+	t.maxPartSize = cfg.APIMaxPartSize
+	t.maxParts = cfg.APIMaxParts
+	t.objectNameNormalization = cfg.APIObjectNameNormalization
+	t.objectNameDisallowedChars = cfg.APIObjectNameDisallowedChars
+	t.sniffContentType = cfg.APISniffContentType
+	t.gzipResponses = cfg.APIGzipResponses
+	t.anonymousBandwidth = cfg.APIAnonymousBandwidth
 	if cfg.APIRequestsMax <= 0 {
 		return
 	}
@@ -70,6 +91,106 @@ func (t *apiConfig) getReadyDeadline() time.Duration {
 	return t.readyDeadline
 }
 
+func (t *apiConfig) getETagMode() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.etagMode == "" {
+		return api.ETagModeMD5
+	}
+
+	return t.etagMode
+}
+
+func (t *apiConfig) isStrictErrors() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.strictErrors
+}
+
+func (t *apiConfig) getMaxObjectSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.maxObjectSize == 0 {
+		return globalMaxObjectSize
+	}
+
+	return t.maxObjectSize
+}
+
+func (t *apiConfig) getMaxPartSize() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.maxPartSize == 0 {
+		return globalMaxPartSize
+	}
+
+	return t.maxPartSize
+}
+
+func (t *apiConfig) getMaxParts() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.maxParts == 0 {
+		return globalMaxPartID
+	}
+
+	return t.maxParts
+}
+
+func (t *apiConfig) getObjectNameNormalization() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.objectNameNormalization == "" {
+		return api.ObjectNameNormalizationOff
+	}
+
+	return t.objectNameNormalization
+}
+
+func (t *apiConfig) getObjectNameDisallowedChars() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.objectNameDisallowedChars
+}
+
+func (t *apiConfig) isSniffContentType() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.sniffContentType
+}
+
+func (t *apiConfig) isGzipResponses() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.gzipResponses
+}
+
+func (t *apiConfig) getAnonymousBandwidth() int64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.anonymousBandwidth
+}
+
+// computeMD5 returns whether the MD5 of an upload needs to be computed
+// to generate its ETag, it may be skipped if the client did not send
+// a Content-MD5 and the payload SHA256 is already being verified.
+func computeMD5(md5hex, sha256hex string) bool {
+	if md5hex == "" && sha256hex != "" && globalAPIConfig.getETagMode() == api.ETagModeSHA256 {
+		return false
+	}
+	return globalCLIContext.StrictS3Compat
+}
+
 func (t *apiConfig) getRequestsPool() (chan struct{}, <-chan time.Time) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -26,15 +27,23 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/klauspost/compress/gzip"
+	dns2 "github.com/miekg/dns"
+	"github.com/minio/minio/cmd/config"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/mimedb"
 )
 
 const (
@@ -42,6 +51,9 @@ const (
 	replaceDirective = "REPLACE"
 )
 
+// sniffLen is the number of bytes used to detect the content-type.
+const sniffLen = 512
+
 // Parses location constraint from the incoming reader.
 func parseLocationConstraint(r *http.Request) (location string, s3Error APIErrorCode) {
 	// If the request has no body with content-length set to 0,
@@ -62,9 +74,30 @@ func parseLocationConstraint(r *http.Request) (location string, s3Error APIError
 }
 
 // Validates input location is same as configured region
-// of MinIO server.
+// of MinIO server, or a region of its own for the bucket,
+// recorded in its metadata except in gateway mode.
 func isValidLocation(location string) bool {
-	return globalServerRegion == "" || globalServerRegion == location
+	if globalServerRegion == "" || globalServerRegion == location {
+		return true
+	}
+	return !globalIsGateway && config.IsValidRegion(location)
+}
+
+// getBucketRegion - returns the region of bucket, its own if it
+// was created in another region than the one of the server.
+func getBucketRegion(bucket string) string {
+	if bucket != "" && globalBucketMetadataSys != nil && !globalIsGateway {
+		if meta, err := globalBucketMetadataSys.GetConfig(bucket); err == nil && meta.Region != "" {
+			return meta.Region
+		}
+	}
+	return globalServerRegion
+}
+
+// getRequestRegion - returns the region of the bucket of r, requests
+// are signed for it.
+func getRequestRegion(r *http.Request) string {
+	return getBucketRegion(mux.Vars(r)["bucket"])
 }
 
 // Supported headers that needs to be extracted.
@@ -152,6 +185,110 @@ func extractMetadata(ctx context.Context, r *http.Request) (metadata map[string]
 	return metadata, nil
 }
 
+// sniffContentType - sets the content-type of an object uploaded without
+// one, when enabled, from the extension of its name or otherwise from its
+// first 512 bytes as described at https://mimesniff.spec.whatwg.org/.
+// Returns the reader to read the whole object content from.
+func sniffContentType(r *http.Request, object string, reader io.Reader, metadata map[string]string) io.Reader {
+	if !globalAPIConfig.isSniffContentType() {
+		return reader
+	}
+	if r.Header.Get(xhttp.ContentType) != "" || r.URL.Query().Get(strings.ToLower(xhttp.ContentType)) != "" {
+		return reader
+	}
+
+	contentType := mimedb.TypeByExtension(path.Ext(object))
+	if contentType == "application/octet-stream" {
+		br := bufio.NewReaderSize(reader, sniffLen)
+		// Errors are returned again by the next read of the content.
+		if data, _ := br.Peek(sniffLen); len(data) > 0 {
+			contentType = http.DetectContentType(data)
+		}
+		reader = br
+	}
+	metadata[strings.ToLower(xhttp.ContentType)] = contentType
+	return reader
+}
+
+// gzipMinSize - objects smaller than this are not worth compressing.
+const gzipMinSize = 1024
+
+// Content-types of the objects compressed with gzip on GET.
+var gzipContentTypes = []string{
+	"text/*",
+	"application/json",
+	"application/javascript",
+	"application/x-javascript",
+	"application/xml",
+	"application/xhtml+xml",
+	"application/x-ndjson",
+	"image/svg+xml",
+}
+
+// isGzipEligible - returns true if the object is compressed with gzip,
+// when enabled, when downloaded whole by clients accepting gzip. Objects
+// already stored with a content-encoding are returned as is.
+func isGzipEligible(objInfo ObjectInfo) bool {
+	if !globalAPIConfig.isGzipResponses() || objInfo.ContentEncoding != "" {
+		return false
+	}
+	if size, err := objInfo.GetActualSize(); err != nil || size < gzipMinSize {
+		return false
+	}
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(objInfo.ContentType, ";")[0]))
+	return hasPattern(gzipContentTypes, contentType)
+}
+
+// acceptsGzip - returns true if the Accept-Encoding of the request
+// accepts gzip, explicitly or with "*", with a non zero quality.
+func acceptsGzip(r *http.Request) bool {
+	accepted := false
+	for _, value := range r.Header.Values(xhttp.AcceptEncoding) {
+		for _, coding := range strings.Split(value, ",") {
+			params := strings.Split(coding, ";")
+			name := strings.ToLower(strings.TrimSpace(params[0]))
+			if name != "gzip" && name != "*" {
+				continue
+			}
+			q := 1.0
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "q=") {
+					if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+						q = v
+					}
+				}
+			}
+			if name == "gzip" {
+				return q > 0
+			}
+			accepted = q > 0
+		}
+	}
+	return accepted
+}
+
+// setGzipHeaders - sets the headers of a response compressed with gzip,
+// its length is unknown and its ETag weak as its content differs from
+// the content of the object.
+func setGzipHeaders(w http.ResponseWriter, objInfo ObjectInfo) {
+	w.Header().Del(xhttp.ContentLength)
+	w.Header().Set(xhttp.ContentEncoding, "gzip")
+	if objInfo.ETag != "" {
+		w.Header()[xhttp.ETag] = []string{"W/\"" + objInfo.ETag + "\""}
+	}
+}
+
+// gzipCopy - copies the content of src compressed with gzip to dst.
+func gzipCopy(dst io.Writer, src io.Reader) (int64, error) {
+	gw := gzip.NewWriter(dst)
+	n, err := io.Copy(gw, src)
+	if err != nil {
+		return n, err
+	}
+	return n, gw.Close()
+}
+
 // extractMetadata extracts metadata from map values.
 func extractMetadataFromMap(ctx context.Context, v map[string][]string, m map[string]string) error {
 	if v == nil {
@@ -215,7 +352,7 @@ func extractReqParams(r *http.Request) map[string]string {
 		return nil
 	}
 
-	region := globalServerRegion
+	region := getRequestRegion(r)
 	cred := getReqAccessCred(r, region)
 
 	// Success.
@@ -394,14 +531,93 @@ func getResource(path string, host string, domains []string) (string, error) {
 			return "", err
 		}
 	}
+	if bucket, ok := virtualHostBucket(host, domains); ok {
+		return SlashSeparator + pathJoin(bucket, path), nil
+	}
+	return path, nil
+}
+
+// matchDomainLabels - returns true if the host labels match the domain
+// labels, a `*` domain label matches any single host label.
+func matchDomainLabels(hostLabels, domainLabels []string) bool {
+	if len(hostLabels) != len(domainLabels) {
+		return false
+	}
+	for i := range domainLabels {
+		if domainLabels[i] != "*" && !strings.EqualFold(hostLabels[i], domainLabels[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// virtualHostBucket - returns the bucket of a virtual-host-style request
+// to a host, without port, of the form `bucket.domain`. The domains are
+// matched in order, they may contain `*` labels such as `s3.*.mydomain.com`.
+func virtualHostBucket(host string, domains []string) (bucket string, ok bool) {
+	hostLabels := strings.Split(host, ".")
 	for _, domain := range domains {
-		if !strings.HasSuffix(host, "."+domain) {
+		domainLabels := strings.Split(domain, ".")
+		n := len(hostLabels) - len(domainLabels)
+		if n <= 0 {
 			continue
 		}
-		bucket := strings.TrimSuffix(host, "."+domain)
-		return SlashSeparator + pathJoin(bucket, path), nil
+		if matchDomainLabels(hostLabels[n:], domainLabels) {
+			return strings.Join(hostLabels[:n], "."), true
+		}
 	}
-	return path, nil
+	return "", false
+}
+
+// isDomainHost - returns true if the host, without port, is one of the
+// domains of virtual-host-style requests.
+func isDomainHost(host string, domains []string) bool {
+	hostLabels := strings.Split(host, ".")
+	for _, domain := range domains {
+		if matchDomainLabels(hostLabels, strings.Split(domain, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDomainName - validates and normalizes a domain of virtual-host-style
+// requests. Labels may be `*` to match any single label, a leading `*.`
+// as found in wildcard DNS records is removed.
+func parseDomainName(domainName string) (string, bool) {
+	domainName = strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(domainName), "."), "*.")
+	labels := strings.Split(domainName, ".")
+	for i, label := range labels {
+		if label == "*" {
+			labels[i] = "x"
+		} else if strings.Contains(label, "*") {
+			return "", false
+		}
+	}
+	if _, ok := dns2.IsDomainName(strings.Join(labels, ".")); !ok {
+		return "", false
+	}
+	return domainName, true
+}
+
+// sortDomainNames - orders the domains with the most labels first, so the
+// most specific domain matches the host of a request.
+func sortDomainNames(domains []string) {
+	sort.SliceStable(domains, func(i, j int) bool {
+		return strings.Count(domains[i], ".") > strings.Count(domains[j], ".")
+	})
+}
+
+// federatedDomainNames - returns the domains whose bucket DNS records are
+// saved for bucket federation, excluding the domains with `*` labels.
+func federatedDomainNames(domains []string) []string {
+	var names []string
+	for _, domain := range domains {
+		if !strings.Contains(domain, "*") {
+			names = append(names, domain)
+		}
+	}
+	return names
 }
 
 var regexVersion = regexp.MustCompile(`(\w\d+)`)
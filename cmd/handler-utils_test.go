@@ -22,12 +22,16 @@ import (
 	"encoding/xml"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/api"
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Tests validate bucket LocationConstraint.
@@ -91,6 +95,72 @@ func TestIsValidLocationContraint(t *testing.T) {
 	}
 }
 
+// Tests the regions of buckets created in another region than the
+// one of the server.
+func TestGetBucketRegion(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+
+	defer func(sys *BucketMetadataSys, region string) {
+		globalBucketMetadataSys = sys
+		globalServerRegion = region
+	}(globalBucketMetadataSys, globalServerRegion)
+	globalBucketMetadataSys = NewBucketMetadataSys()
+	globalServerRegion = "us-east-1"
+
+	if !isValidLocation("eu-central-1") || isValidLocation("1-central") {
+		t.Fatal("Unexpected validation of the location of a bucket")
+	}
+
+	ctx := context.Background()
+	for bucket, location := range map[string]string{
+		"default":  "",
+		"server":   "us-east-1",
+		"override": "eu-central-1",
+	} {
+		if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{Location: location}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		bucket         string
+		expectedRegion string
+	}{
+		{"", "us-east-1"},
+		{"default", "us-east-1"},
+		{"server", "us-east-1"},
+		{"override", "eu-central-1"},
+		{"missing", "us-east-1"},
+	}
+	for i, testCase := range testCases {
+		if region := getBucketRegion(testCase.bucket); region != testCase.expectedRegion {
+			t.Errorf("Test %d: Expected region %s, got %s", i+1, testCase.expectedRegion, region)
+		}
+	}
+
+	// The region is kept in the bucket metadata.
+	meta, err := loadBucketMetadata(ctx, obj, "override")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Region != "eu-central-1" {
+		t.Errorf("Expected region eu-central-1 in the bucket metadata, got %s", meta.Region)
+	}
+}
+
 // Test validate form field size.
 func TestValidateFormFieldSize(t *testing.T) {
 	testCases := []struct {
@@ -129,6 +199,53 @@ func TestValidateFormFieldSize(t *testing.T) {
 	}
 }
 
+// Tests the content-type detection of objects uploaded without one.
+func TestSniffContentType(t *testing.T) {
+	pngData := append([]byte("\x89PNG\x0D\x0A\x1A\x0A"), bytes.Repeat([]byte{0}, 1024)...)
+	testCases := []struct {
+		object      string
+		contentType string
+		data        []byte
+		sniff       bool
+		expected    string
+	}{
+		// Content-type is not sniffed by default.
+		{"image", "", pngData, false, ""},
+		// Content-type sent by the client is kept.
+		{"image", "application/x-custom", pngData, true, ""},
+		// Content-type is looked up from the extension first.
+		{"style.css", "", []byte("<html>"), true, "text/css"},
+		// Content-type is sniffed from the content otherwise.
+		{"image", "", pngData, true, "image/png"},
+		{"notes", "", []byte("hello world"), true, "text/plain; charset=utf-8"},
+		{"empty", "", []byte{}, true, "application/octet-stream"},
+	}
+
+	defer globalAPIConfig.init(api.Config{})
+	for i, testCase := range testCases {
+		globalAPIConfig.init(api.Config{APISniffContentType: testCase.sniff})
+
+		req := httptest.NewRequest(http.MethodPut, "/bucket/"+testCase.object, nil)
+		if testCase.contentType != "" {
+			req.Header.Set(xhttp.ContentType, testCase.contentType)
+		}
+		metadata := make(map[string]string)
+		reader := sniffContentType(req, testCase.object, bytes.NewReader(testCase.data), metadata)
+		if contentType := metadata["content-type"]; contentType != testCase.expected {
+			t.Errorf("Test %d: expected content-type %q, got %q", i+1, testCase.expected, contentType)
+		}
+
+		// The content is read in full from the returned reader.
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error reading content: %v", i+1, err)
+		}
+		if !bytes.Equal(data, testCase.data) {
+			t.Errorf("Test %d: content was modified by sniffing", i+1)
+		}
+	}
+}
+
 // Tests validate metadata extraction from http headers.
 func TestExtractMetadataHeaders(t *testing.T) {
 	testCases := []struct {
@@ -221,6 +338,13 @@ func TestGetResource(t *testing.T) {
 		{"/a/b/c", "test.mydomain.com", []string{"mydomain.com"}, "/test/a/b/c"},
 		{"/a/b/c", "test.mydomain.com", []string{"notmydomain.com"}, "/a/b/c"},
 		{"/a/b/c", "test.mydomain.com", nil, "/a/b/c"},
+		{"/a/b/c", "test.mydomain.com:9000", []string{"mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "mydomain.com", []string{"mydomain.com"}, "/a/b/c"},
+		{"/a/b/c", "test.sub.mydomain.com", []string{"sub.mydomain.com", "mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "test.s3.us-east-1.mydomain.com", []string{"s3.*.mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "my.test.s3.us-east-1.mydomain.com", []string{"s3.*.mydomain.com"}, "/my.test/a/b/c"},
+		{"/a/b/c", "s3.us-east-1.mydomain.com", []string{"s3.*.mydomain.com"}, "/a/b/c"},
+		{"/a/b/c", "test.s3.mydomain.com", []string{"s3.*.mydomain.com"}, "/a/b/c"},
 	}
 	for i, test := range testCases {
 		gotResource, err := getResource(test.p, test.host, test.domains)
@@ -232,3 +356,111 @@ func TestGetResource(t *testing.T) {
 		}
 	}
 }
+
+// Test parseDomainName() and sortDomainNames()
+func TestParseDomainNames(t *testing.T) {
+	testCases := []struct {
+		domain   string
+		expected string
+		ok       bool
+	}{
+		{"mydomain.com", "mydomain.com", true},
+		{"MyDomain.com.", "mydomain.com", true},
+		{"*.mydomain.com", "mydomain.com", true},
+		{"s3.*.mydomain.com", "s3.*.mydomain.com", true},
+		{"s3-*.mydomain.com", "", false},
+		{"my..domain.com", "", false},
+	}
+	for i, test := range testCases {
+		domain, ok := parseDomainName(test.domain)
+		if ok != test.ok || domain != test.expected {
+			t.Errorf("test %d: expected %s %t got %s %t", i+1, test.expected, test.ok, domain, ok)
+		}
+	}
+
+	domains := []string{"mydomain.com", "s3.*.mydomain.com", "sub.mydomain.com"}
+	sortDomainNames(domains)
+	expected := []string{"s3.*.mydomain.com", "sub.mydomain.com", "mydomain.com"}
+	if !reflect.DeepEqual(domains, expected) {
+		t.Errorf("expected %v got %v", expected, domains)
+	}
+}
+
+// Test the routes of virtual-host-style requests to wildcard domains.
+func TestDomainHostTemplate(t *testing.T) {
+	router := mux.NewRouter()
+	var bucket string
+	router.Host(domainHostTemplate("s3.*.mydomain.com")).Path("/{object:.+}").HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			bucket = mux.Vars(r)["bucket"]
+		})
+
+	testCases := []struct {
+		host     string
+		expected string
+	}{
+		{"test.s3.us-east-1.mydomain.com", "test"},
+		{"my.test.s3.eu-west-1.mydomain.com:9000", "my.test"},
+		{"test.s3.mydomain.com", ""},
+	}
+	for i, test := range testCases {
+		bucket = ""
+		req := httptest.NewRequest(http.MethodGet, "http://"+test.host+"/object", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		if bucket != test.expected {
+			t.Errorf("test %d: expected bucket %q got %q", i+1, test.expected, bucket)
+		}
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	testCases := []struct {
+		acceptEncoding string
+		expected       bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=1.0, *;q=0.5", true},
+		{"br, GZIP", true},
+		{"gzip;q=0", false},
+		{"*", true},
+		{"*;q=0", false},
+		{"gzip;q=0, *", false},
+		{"identity", false},
+		{"x-gzip", false},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/bucket/object", nil)
+		if testCase.acceptEncoding != "" {
+			req.Header.Set(xhttp.AcceptEncoding, testCase.acceptEncoding)
+		}
+		if accepted := acceptsGzip(req); accepted != testCase.expected {
+			t.Errorf("Test %d: expected %v for %q, got %v", i+1, testCase.expected, testCase.acceptEncoding, accepted)
+		}
+	}
+}
+
+func TestIsGzipEligible(t *testing.T) {
+	testCases := []struct {
+		objInfo  ObjectInfo
+		enabled  bool
+		expected bool
+	}{
+		{ObjectInfo{ContentType: "text/html", Size: gzipMinSize}, false, false},
+		{ObjectInfo{ContentType: "text/html", Size: gzipMinSize}, true, true},
+		{ObjectInfo{ContentType: "application/json; charset=utf-8", Size: gzipMinSize}, true, true},
+		{ObjectInfo{ContentType: "image/svg+xml", Size: gzipMinSize}, true, true},
+		{ObjectInfo{ContentType: "text/html", Size: gzipMinSize - 1}, true, false},
+		{ObjectInfo{ContentType: "text/html", ContentEncoding: "gzip", Size: gzipMinSize}, true, false},
+		{ObjectInfo{ContentType: "image/png", Size: gzipMinSize}, true, false},
+		{ObjectInfo{ContentType: "application/octet-stream", Size: gzipMinSize}, true, false},
+	}
+
+	defer globalAPIConfig.init(api.Config{})
+	for i, testCase := range testCases {
+		globalAPIConfig.init(api.Config{APIGzipResponses: testCase.enabled})
+		if eligible := isGzipEligible(testCase.objInfo); eligible != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, eligible)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EnvListenFDs - environment variable holding the descriptors of the
+// listening sockets handed over to the restarted server process.
+const EnvListenFDs = "_MINIO_LISTEN_FDS"
+
+var (
+	inheritedOnce      sync.Once
+	inheritedMutex     sync.Mutex
+	inheritedListeners []*net.TCPListener
+
+	// Descriptors handed over, kept referenced to not be closed
+	// by their finalizer before the process is executed.
+	handoffFiles []*os.File
+)
+
+// loadInheritedListeners - loads the listening sockets handed over
+// by the previous server process.
+func loadInheritedListeners() {
+	fds := os.Getenv(EnvListenFDs)
+	if fds == "" {
+		return
+	}
+	os.Unsetenv(EnvListenFDs)
+
+	for _, v := range strings.Split(fds, ",") {
+		fd, err := strconv.Atoi(v)
+		if err != nil || fd < 0 {
+			continue
+		}
+		f := os.NewFile(uintptr(fd), "listener-"+v)
+		l, err := net.FileListener(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		tcpListener, ok := l.(*net.TCPListener)
+		if !ok {
+			l.Close()
+			continue
+		}
+		inheritedListeners = append(inheritedListeners, tcpListener)
+	}
+}
+
+// IsInherited - returns true if the listening socket of serverAddr
+// was handed over by the previous server process, its port is then
+// in use until the server starts.
+func IsInherited(serverAddr string) bool {
+	return findInheritedListener(serverAddr, false) != nil
+}
+
+// inheritedListener - returns the listening socket of serverAddr
+// handed over by the previous server process, if any.
+func inheritedListener(serverAddr string) *net.TCPListener {
+	return findInheritedListener(serverAddr, true)
+}
+
+func findInheritedListener(serverAddr string, remove bool) *net.TCPListener {
+	inheritedOnce.Do(loadInheritedListeners)
+
+	addr, err := net.ResolveTCPAddr("tcp", serverAddr)
+	if err != nil {
+		return nil
+	}
+	unspecified := func(ip net.IP) bool {
+		return ip == nil || ip.IsUnspecified()
+	}
+
+	inheritedMutex.Lock()
+	defer inheritedMutex.Unlock()
+	for i, tcpListener := range inheritedListeners {
+		la := tcpListener.Addr().(*net.TCPAddr)
+		if la.Port != addr.Port {
+			continue
+		}
+		if la.IP.Equal(addr.IP) || (unspecified(la.IP) && unspecified(addr.IP)) {
+			if remove {
+				inheritedListeners = append(inheritedListeners[:i], inheritedListeners[i+1:]...)
+			}
+			return tcpListener
+		}
+	}
+	return nil
+}
+
+// HandoffListeners - hands the listening sockets of the server over
+// to the process executed next, which serves the connections queued
+// meanwhile by the kernel. Must be called before Shutdown.
+func (srv *Server) HandoffListeners() (err error) {
+	srv.listenerMutex.Lock()
+	defer srv.listenerMutex.Unlock()
+	if srv.listener == nil {
+		return http.ErrServerClosed
+	}
+
+	var files []*os.File
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	fds := make([]string, 0, len(srv.listener.tcpListeners))
+	for _, tcpListener := range srv.listener.tcpListeners {
+		var f *os.File
+		if f, err = tcpListener.File(); err != nil {
+			return err
+		}
+		files = append(files, f)
+		if err = setInheritable(f.Fd()); err != nil {
+			return err
+		}
+		fds = append(fds, strconv.Itoa(int(f.Fd())))
+	}
+
+	// Several servers may hand their sockets over.
+	if handedOver := os.Getenv(EnvListenFDs); handedOver != "" {
+		fds = append([]string{handedOver}, fds...)
+	}
+	if err = os.Setenv(EnvListenFDs, strings.Join(fds, ",")); err != nil {
+		return err
+	}
+	handoffFiles = append(handoffFiles, files...)
+	return nil
+}
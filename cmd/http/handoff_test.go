@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestInheritedListener(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" || runtime.GOOS == "solaris" {
+		t.Skip("handing over listeners is not supported on this platform")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	f, err := l.(*net.TCPListener).File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	inheritedOnce = sync.Once{}
+	os.Setenv(EnvListenFDs, strconv.Itoa(int(f.Fd())))
+	defer os.Unsetenv(EnvListenFDs)
+
+	// The address is in use, it is served by the inherited socket.
+	addr := l.Addr().String()
+	if !IsInherited(addr) {
+		t.Fatalf("expected %s to be inherited", addr)
+	}
+	listener, err := newHTTPListener([]string{addr}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	serverConn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	serverConn.Close()
+
+	if os.Getenv(EnvListenFDs) != "" {
+		t.Fatalf("expected %s to be unset once loaded", EnvListenFDs)
+	}
+	if inheritedListener(addr) != nil {
+		t.Fatalf("expected the inherited socket to be served once")
+	}
+}
@@ -24,6 +24,8 @@ const (
 	ContentType        = "Content-Type"
 	ContentMD5         = "Content-Md5"
 	ContentEncoding    = "Content-Encoding"
+	AcceptEncoding     = "Accept-Encoding"
+	Vary               = "Vary"
 	Expires            = "Expires"
 	ContentLength      = "Content-Length"
 	ContentLanguage    = "Content-Language"
@@ -53,10 +55,17 @@ const (
 	IfUnmodifiedSince = "If-Unmodified-Since"
 	IfMatch           = "If-Match"
 	IfNoneMatch       = "If-None-Match"
+	IfRange           = "If-Range"
 
 	// S3 storage class
 	AmzStorageClass = "x-amz-storage-class"
 
+	// S3 restore status of archived objects
+	AmzRestore = "x-amz-restore"
+
+	// S3 replication status of objects
+	AmzBucketReplicationStatus = "X-Amz-Replication-Status"
+
 	// S3 object version ID
 	AmzVersionID    = "x-amz-version-id"
 	AmzDeleteMarker = "x-amz-delete-marker"
@@ -85,6 +94,14 @@ const (
 	// Multipart parts count
 	AmzMpPartsCount = "x-amz-mp-parts-count"
 
+	// Checksums of objects sent on GET and HEAD
+	AmzChecksumMode = "x-amz-checksum-mode"
+
+	// S3 object attributes
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
+	AmzMaxParts         = "X-Amz-Max-Parts"
+	AmzPartNumberMarker = "X-Amz-Part-Number-Marker"
+
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
@@ -109,6 +126,9 @@ const (
 	// Response request id.
 	AmzRequestID = "x-amz-request-id"
 
+	// Response host id, identifies the node which served the request.
+	AmzRequestHostID = "x-amz-id-2"
+
 	// Deployment id.
 	MinioDeploymentID = "x-minio-deployment-id"
 
@@ -120,6 +140,25 @@ const (
 
 	// Header indicates if the mtime should be preserved by client
 	MinIOSourceMTime = "x-minio-source-mtime"
+
+	// Header carrying the ETag of the source of a replica
+	MinIOSourceETag = "x-minio-source-etag"
+
+	// Header indicates that the request is replicated from a source
+	MinIOSourceReplicationRequest = "x-minio-source-replication-request"
+
+	// Header indicates that the uploaded archive is extracted into objects
+	MinIOExtract = "x-minio-extract"
+
+	// Header of the writes rejected on read replica buckets, naming
+	// the source bucket writes must be sent to
+	MinIOReadReplicaSource = "x-minio-read-replica-source"
+
+	// Headers of the advisory object leases, the token of a lease is
+	// sent back on the writes of the lease holder
+	MinIOLeaseToken    = "x-minio-lease-token"
+	MinIOLeaseDuration = "x-minio-lease-duration"
+	MinIOLeaseExpiry   = "x-minio-lease-expiry"
 )
 
 // Common http query params S3 API
@@ -0,0 +1,119 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// First byte of a TLS handshake record.
+const tlsRecordTypeHandshake = 0x16
+
+// internodeListener - serves plain HTTP connections along with TLS
+// connections of the other nodes, on the same addresses.
+type internodeListener struct {
+	net.Listener
+	config *tls.Config
+}
+
+// Accept - returns the next connection, it is only known to be a TLS
+// connection when the first bytes are read from it.
+func (listener *internodeListener) Accept() (net.Conn, error) {
+	conn, err := listener.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &internodeConn{Conn: conn, config: listener.config}, nil
+}
+
+// internodeConn - connection which turns into a TLS connection when
+// the peer starts a TLS handshake.
+type internodeConn struct {
+	net.Conn
+	config *tls.Config
+
+	once sync.Once
+	conn net.Conn
+	tls  *tls.Conn
+}
+
+// bufferedConn - connection whose first bytes were peeked.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *internodeConn) init() {
+	c.once.Do(func() {
+		conn := &bufferedConn{Conn: c.Conn, r: bufio.NewReader(c.Conn)}
+		c.conn = conn
+		if b, err := conn.r.Peek(1); err == nil && b[0] == tlsRecordTypeHandshake {
+			c.tls = tls.Server(conn, c.config)
+			c.conn = c.tls
+		}
+	})
+}
+
+func (c *internodeConn) Read(b []byte) (int, error) {
+	c.init()
+	return c.conn.Read(b)
+}
+
+func (c *internodeConn) Write(b []byte) (int, error) {
+	c.init()
+	return c.conn.Write(b)
+}
+
+// connectionState - returns the TLS state of the connection, nil
+// for plain HTTP connections.
+func (c *internodeConn) connectionState() *tls.ConnectionState {
+	c.init()
+	if c.tls == nil {
+		return nil
+	}
+	state := c.tls.ConnectionState()
+	return &state
+}
+
+type connContextKey struct{}
+
+// connContext - saves the connection of the requests in their context.
+func connContext(ctx context.Context, c net.Conn) context.Context {
+	return context.WithValue(ctx, connContextKey{}, c)
+}
+
+// ConnectionState - returns the TLS state of the connection of the
+// request, including the TLS connections of the other nodes to a
+// server serving plain HTTP. nil is returned for plain HTTP requests.
+func ConnectionState(r *http.Request) *tls.ConnectionState {
+	if r.TLS != nil {
+		return r.TLS
+	}
+	if c, ok := r.Context().Value(connContextKey{}).(*internodeConn); ok {
+		return c.connectionState()
+	}
+	return nil
+}
@@ -20,6 +20,7 @@ package http
 
 import (
 	"net"
+	"syscall"
 
 	"github.com/valyala/tcplisten"
 )
@@ -35,3 +36,11 @@ var cfg = &tcplisten.Config{
 // Unix listener with special TCP options.
 var listen = cfg.NewListener
 var fallbackListen = net.Listen
+
+// setInheritable - clears the close-on-exec flag of fd.
+func setInheritable(fd uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_FCNTL, fd, syscall.F_SETFD, 0); errno != 0 {
+		return errno
+	}
+	return nil
+}
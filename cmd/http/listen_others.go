@@ -18,8 +18,16 @@
 
 package http
 
-import "net"
+import (
+	"errors"
+	"net"
+)
 
 // Windows, plan9 specific listener.
 var listen = net.Listen
 var fallbackListen = net.Listen
+
+// setInheritable - handing over listeners is not supported.
+func setInheritable(fd uintptr) error {
+	return errors.New("handing over listeners is not supported on this platform")
+}
@@ -32,10 +32,11 @@ type acceptResult struct {
 
 // httpListener - HTTP listener capable of handling multiple server addresses.
 type httpListener struct {
-	mutex        sync.Mutex         // to guard Close() method.
-	tcpListeners []*net.TCPListener // underlaying TCP listeners.
-	acceptCh     chan acceptResult  // channel where all TCP listeners write accepted connection.
-	doneCh       chan struct{}      // done channel for TCP listener goroutines.
+	mutex         sync.Mutex         // to guard Close() method.
+	tcpListeners  []*net.TCPListener // underlaying TCP listeners.
+	acceptCh      chan acceptResult  // channel where all TCP listeners write accepted connection.
+	doneCh        chan struct{}      // done channel for TCP listener goroutines.
+	proxyProtocol bool               // read the PROXY protocol header of the trusted proxies.
 }
 
 // isRoutineNetErr returns true if error is due to a network timeout,
@@ -85,7 +86,15 @@ func (listener *httpListener) start() {
 		if err == nil {
 			setTCPParameters(rawConn)
 		}
-		send(acceptResult{tcpConn, nil}, doneCh)
+		var conn net.Conn = tcpConn
+		if listener.proxyProtocol {
+			if conn, err = readProxyHeader(tcpConn); err != nil {
+				// Drop the connections with an invalid header.
+				tcpConn.Close()
+				return
+			}
+		}
+		send(acceptResult{conn, nil}, doneCh)
 	}
 
 	// Closure to handle TCPListener until done channel is closed.
@@ -165,7 +174,7 @@ func (listener *httpListener) Addrs() (addrs []net.Addr) {
 // httpListener is capable to
 // * listen to multiple addresses
 // * controls incoming connections only doing HTTP protocol
-func newHTTPListener(serverAddrs []string) (listener *httpListener, err error) {
+func newHTTPListener(serverAddrs []string, proxyProtocol bool) (listener *httpListener, err error) {
 
 	var tcpListeners []*net.TCPListener
 
@@ -182,6 +191,12 @@ func newHTTPListener(serverAddrs []string) (listener *httpListener, err error) {
 	}()
 
 	for _, serverAddr := range serverAddrs {
+		// Serve the socket handed over by the previous process, if any.
+		if tcpListener := inheritedListener(serverAddr); tcpListener != nil {
+			tcpListeners = append(tcpListeners, tcpListener)
+			continue
+		}
+
 		var l net.Listener
 		if l, err = listen("tcp", serverAddr); err != nil {
 			if l, err = fallbackListen("tcp", serverAddr); err != nil {
@@ -198,7 +213,8 @@ func newHTTPListener(serverAddrs []string) (listener *httpListener, err error) {
 	}
 
 	listener = &httpListener{
-		tcpListeners: tcpListeners,
+		tcpListeners:  tcpListeners,
+		proxyProtocol: proxyProtocol,
 	}
 	listener.start()
 
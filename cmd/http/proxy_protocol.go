@@ -0,0 +1,179 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/pkg/handlers"
+)
+
+// proxyHeaderTimeout - time given to the proxies to send the PROXY
+// protocol header of a new connection.
+var proxyHeaderTimeout = 5 * time.Second
+
+const (
+	// Maximum length of a PROXY protocol v1 header, including CRLF.
+	proxyV1MaxLen = 107
+	// Length of the fixed part of a PROXY protocol v2 header.
+	proxyV2HeaderLen = 16
+)
+
+var (
+	proxyV1Prefix    = []byte("PROXY ")
+	proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+	errInvalidProxyHeader = errors.New("invalid PROXY protocol header")
+)
+
+// proxyConn - connection accepted from a proxy, whose remote address
+// is the address of the client of the proxy.
+type proxyConn struct {
+	bufferedConn
+	remoteAddr net.Addr
+}
+
+func (c *proxyConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
+
+// readProxyHeader - reads the PROXY protocol v1 or v2 header sent by a
+// trusted proxy at the start of the connection. Connections of other
+// peers and connections without a header are returned unchanged.
+func readProxyHeader(conn net.Conn) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil || !handlers.HasTrustedProxies() || !handlers.IsTrustedProxy(host) {
+		return conn, nil
+	}
+
+	if err = conn.SetReadDeadline(time.Now().Add(proxyHeaderTimeout)); err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(conn)
+	remoteAddr := conn.RemoteAddr()
+	b, err := r.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	switch b[0] {
+	case proxyV1Prefix[0]:
+		if b, err = r.Peek(len(proxyV1Prefix)); err == nil && bytes.Equal(b, proxyV1Prefix) {
+			remoteAddr, err = parseProxyV1Header(r, remoteAddr)
+		}
+	case proxyV2Signature[0]:
+		if b, err = r.Peek(len(proxyV2Signature)); err == nil && bytes.Equal(b, proxyV2Signature) {
+			remoteAddr, err = parseProxyV2Header(r, remoteAddr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	return &proxyConn{bufferedConn: bufferedConn{Conn: conn, r: r}, remoteAddr: remoteAddr}, nil
+}
+
+// parseProxyV1Header - parses a human-readable header such as
+// "PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n".
+func parseProxyV1Header(r *bufio.Reader, remoteAddr net.Addr) (net.Addr, error) {
+	var line []byte
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if len(line) >= proxyV1MaxLen {
+			return nil, errInvalidProxyHeader
+		}
+		c, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, c)
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		// The proxy does not know the client, keep the proxy address.
+		return remoteAddr, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, errInvalidProxyHeader
+	}
+	ip := net.ParseIP(fields[2])
+	if ip == nil || (ip.To4() != nil) != (fields[1] == "TCP4") {
+		return nil, errInvalidProxyHeader
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, errInvalidProxyHeader
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// parseProxyV2Header - parses a binary header, only the addresses of
+// TCP over IPv4 and IPv6 are used, the other families keep the proxy
+// address.
+func parseProxyV2Header(r *bufio.Reader, remoteAddr net.Addr) (net.Addr, error) {
+	header := make([]byte, proxyV2HeaderLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	if header[12]>>4 != 2 {
+		return nil, errInvalidProxyHeader
+	}
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+
+	switch header[12] & 0x0f {
+	case 0x0:
+		// LOCAL command, the connection was made by the proxy itself.
+		return remoteAddr, nil
+	case 0x1:
+	default:
+		return nil, errInvalidProxyHeader
+	}
+
+	switch header[13] {
+	case 0x11: // TCP over IPv4
+		if len(payload) < 12 {
+			return nil, errInvalidProxyHeader
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(payload[0:4]),
+			Port: int(binary.BigEndian.Uint16(payload[8:10])),
+		}, nil
+	case 0x21: // TCP over IPv6
+		if len(payload) < 36 {
+			return nil, errInvalidProxyHeader
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(payload[0:16]),
+			Port: int(binary.BigEndian.Uint16(payload[32:34])),
+		}, nil
+	}
+	return remoteAddr, nil
+}
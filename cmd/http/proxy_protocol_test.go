@@ -0,0 +1,104 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/minio/minio/pkg/handlers"
+)
+
+func TestReadProxyHeader(t *testing.T) {
+	nets, err := handlers.ParseTrustedProxies([]string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	handlers.SetTrustedProxies(nets)
+	defer handlers.SetTrustedProxies(nil)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	const body = "GET / HTTP/1.1\r\n\r\n"
+	v2TCP4 := "\r\n\r\n\x00\r\nQUIT\n\x21\x11\x00\x0c" +
+		"\xc0\x00\x02\x01" + "\xc6\x33\x64\x01" + "\xdc\x04" + "\x01\xbb"
+	v2Local := "\r\n\r\n\x00\r\nQUIT\n\x20\x00\x00\x00"
+
+	testCases := []struct {
+		header      string
+		expectedIP  string
+		expectedErr bool
+	}{
+		{"PROXY TCP4 192.0.2.1 198.51.100.1 56324 443\r\n", "192.0.2.1:56324", false},
+		{"PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n", "[2001:db8::1]:56324", false},
+		{"PROXY UNKNOWN\r\n", "127.0.0.1", false},
+		{v2TCP4, "192.0.2.1:56324", false},
+		{v2Local, "127.0.0.1", false},
+		{"", "127.0.0.1", false},
+		{"PROXY TCP4 192.0.2.1\r\n", "", true},
+		{"PROXY TCP4 2001:db8::1 2001:db8::2 56324 443\r\n", "", true},
+		{"\r\n\r\n\x00\r\nQUIT\n\x11\x11\x00\x00", "", true},
+	}
+
+	for i, testCase := range testCases {
+		client, err := net.Dial("tcp", l.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = client.Write([]byte(testCase.header + body)); err != nil {
+			t.Fatal(err)
+		}
+		client.Close()
+
+		conn, err := l.Accept()
+		if err != nil {
+			t.Fatal(err)
+		}
+		proxied, err := readProxyHeader(conn)
+		if testCase.expectedErr {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			conn.Close()
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+
+		addr := proxied.RemoteAddr().String()
+		if host, _, _ := net.SplitHostPort(addr); host == "127.0.0.1" {
+			addr = host
+		}
+		if addr != testCase.expectedIP {
+			t.Errorf("Test %d: expected remote address %s, got %s", i+1, testCase.expectedIP, addr)
+		}
+		data, err := ioutil.ReadAll(proxied)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if string(data) != body {
+			t.Errorf("Test %d: expected %q to follow the header, got %q", i+1, body, data)
+		}
+		proxied.Close()
+	}
+}
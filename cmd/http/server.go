@@ -53,6 +53,15 @@ type Server struct {
 	listener        *httpListener // HTTP listener for all 'Addrs' field.
 	inShutdown      uint32        // indicates whether the server is in shutdown or not
 	requestCount    int32         // counter holds no. of request in progress.
+
+	// TLS configuration of the connections between the nodes, which
+	// require a client certificate. Plain HTTP servers also serve TLS
+	// connections of the other nodes when set.
+	InternodeTLSConfig *tls.Config
+
+	// Read the PROXY protocol header sent by the trusted proxies at
+	// the start of their connections.
+	ProxyProtocol bool
 }
 
 // GetRequestCount - returns number of request in progress.
@@ -75,6 +84,7 @@ func (srv *Server) Start() (err error) {
 	var listener *httpListener
 	listener, err = newHTTPListener(
 		addrs,
+		srv.ProxyProtocol,
 	)
 	if err != nil {
 		return err
@@ -85,9 +95,11 @@ func (srv *Server) Start() (err error) {
 	wrappedHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// If server is in shutdown.
 		if atomic.LoadUint32(&srv.inShutdown) != 0 {
-			// To indicate disable keep-alives
+			// To indicate disable keep-alives, clients retry
+			// the request once the server is back.
 			w.Header().Set("Connection", "close")
-			w.WriteHeader(http.StatusForbidden)
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
 			w.Write([]byte(http.ErrServerClosed.Error()))
 			w.(http.Flusher).Flush()
 			return
@@ -107,8 +119,17 @@ func (srv *Server) Start() (err error) {
 
 	// Start servicing with listener.
 	if tlsConfig != nil {
+		if srv.InternodeTLSConfig != nil {
+			// Clients other than the nodes do not send certificates.
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+			tlsConfig.ClientCAs = srv.InternodeTLSConfig.ClientCAs
+		}
 		return srv.Server.Serve(tls.NewListener(listener, tlsConfig))
 	}
+	if srv.InternodeTLSConfig != nil {
+		srv.Server.ConnContext = connContext
+		return srv.Server.Serve(&internodeListener{Listener: listener, config: srv.InternodeTLSConfig})
+	}
 	return srv.Server.Serve(listener)
 }
 
@@ -126,10 +147,12 @@ func (srv *Server) Shutdown() error {
 		return http.ErrServerClosed
 	}
 
-	// Close underneath HTTP listener.
+	// Close underneath HTTP listener, and the idle connections
+	// and the busy ones once their request is served.
 	srv.listenerMutex.Lock()
 	err := srv.listener.Close()
 	srv.listenerMutex.Unlock()
+	srv.SetKeepAlivesEnabled(false)
 
 	// Wait for opened connection to be closed up to Shutdown timeout.
 	shutdownTimeout := srv.ShutdownTimeout
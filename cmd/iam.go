@@ -995,6 +995,23 @@ func (sys *IAMSys) ListServiceAccounts(ctx context.Context, accessKey string) ([
 	return serviceAccounts, nil
 }
 
+// GetParentUser - returns the parent user of the service account or
+// temporary credentials accessKey, empty for any other credentials.
+func (sys *IAMSys) GetParentUser(accessKey string) string {
+	if sys == nil || sys.store == nil {
+		return ""
+	}
+
+	sys.store.rlock()
+	defer sys.store.runlock()
+
+	cred, ok := sys.iamUsersMap[accessKey]
+	if !ok {
+		return ""
+	}
+	return cred.ParentUser
+}
+
 // GetServiceAccountParent - gets information about a service account
 func (sys *IAMSys) GetServiceAccountParent(ctx context.Context, accessKey string) (string, error) {
 	objectAPI := newObjectLayerWithoutSafeModeFn()
@@ -1804,6 +1821,11 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 		return true
 	}
 
+	// Users of a tenant only access the buckets of the tenant.
+	if !globalTenantSys.IsAllowed(args.AccountName, args.BucketName) {
+		return false
+	}
+
 	// If the credential is temporary, perform STS related checks.
 	ok, err := sys.IsTempUser(args.AccountName)
 	if err != nil {
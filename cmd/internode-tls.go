@@ -0,0 +1,160 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+const (
+	// Enables TLS with certificates generated from the
+	// credentials between the nodes.
+	envInternodeTLS = "MINIO_INTERNODE_TLS"
+
+	internodeCAName = "MinIO internode CA"
+)
+
+var errInternodeTLSRequired = errors.New("TLS connection with a certificate of a node is required")
+
+// internodeTLS - certificate of this node, issued by a CA derived from
+// the secret key of the cluster. All the nodes derive the same CA, and
+// verify each other with it without any certificate to distribute.
+type internodeTLS struct {
+	rootCAs *x509.CertPool
+	cert    tls.Certificate
+}
+
+// newInternodeTLS - derives the internode CA from the secret key, and
+// issues a certificate for the host names of this node.
+func newInternodeTLS(secretKey string, hosts []string) (*internodeTLS, error) {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(internodeCAName))
+	caKey := ed25519.NewKeyFromSeed(mac.Sum(nil))
+
+	// The CA certificate is the same on all the nodes.
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: internodeCAName},
+		NotBefore:             time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2120, time.January, 1, 0, 0, 0, 0, time.UTC),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "MinIO node"},
+		NotBefore:    UTCNow().Add(-time.Hour),
+		NotAfter:     UTCNow().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca)
+	return &internodeTLS{
+		rootCAs: rootCAs,
+		cert: tls.Certificate{
+			Certificate: [][]byte{certDER},
+			PrivateKey:  key,
+		},
+	}, nil
+}
+
+// serverConfig - returns the TLS configuration of the connections from
+// the other nodes, only nodes with a certificate of the CA connect.
+func (t *internodeTLS) serverConfig() *tls.Config {
+	return &tls.Config{
+		Certificates: []tls.Certificate{t.cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    t.rootCAs,
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"http/1.1"},
+	}
+}
+
+// clientConfig - returns the TLS configuration of the connections to
+// the node serverName, config is the TLS configuration of the cluster
+// when it is served with HTTPS.
+func (t *internodeTLS) clientConfig(serverName string, config *tls.Config) *tls.Config {
+	if config == nil {
+		config = &tls.Config{
+			ServerName: serverName,
+			RootCAs:    t.rootCAs,
+			MinVersion: tls.VersionTLS12,
+			NextProtos: []string{"http/1.1"}, // Force http1.1
+		}
+	}
+	config.Certificates = []tls.Certificate{t.cert}
+	return config
+}
+
+// internodeRequestValidate - returns an error if the request of a node
+// is not sent over TLS with the certificate of a node.
+func internodeRequestValidate(r *http.Request) error {
+	if globalInternodeTLS == nil {
+		return nil
+	}
+	state := xhttp.ConnectionState(r)
+	if state == nil || len(state.VerifiedChains) == 0 {
+		return errInternodeTLSRequired
+	}
+	return nil
+}
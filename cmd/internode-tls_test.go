@@ -0,0 +1,127 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+func TestInternodeTLS(t *testing.T) {
+	server, err := newInternodeTLS("minio123", []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := newInternodeTLS("minio123", []string{"127.0.0.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := newInternodeTLS("other-secret", []string{"127.0.0.2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prevInternodeTLS := globalInternodeTLS
+	globalInternodeTLS = server
+	defer func() { globalInternodeTLS = prevInternodeTLS }()
+
+	addr := net.JoinHostPort("127.0.0.1", getFreePort())
+	httpServer := xhttp.NewServer([]string{addr}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := internodeRequestValidate(r); err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}), nil)
+	httpServer.InternodeTLSConfig = server.serverConfig()
+	go httpServer.Start()
+	defer httpServer.Shutdown()
+
+	get := func(scheme string, tlsConfig *tls.Config) (int, error) {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		defer client.CloseIdleConnections()
+		var resp *http.Response
+		var err error
+		for i := 0; i < 50; i++ {
+			if resp, err = client.Get(scheme + "://" + addr + "/"); err == nil {
+				resp.Body.Close()
+				return resp.StatusCode, nil
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		return 0, err
+	}
+
+	// Plain HTTP clients are still served, but not as nodes.
+	if code, err := get("http", nil); err != nil || code != http.StatusForbidden {
+		t.Fatalf("expected plain HTTP requests to be rejected, got %d: %v", code, err)
+	}
+	if code, err := get("https", node.clientConfig("127.0.0.1", nil)); err != nil || code != http.StatusOK {
+		t.Fatalf("expected the requests of a node to be accepted, got %d: %v", code, err)
+	}
+
+	// Nodes of other clusters have certificates of another CA.
+	if _, err := get("https", other.clientConfig("127.0.0.1", nil)); err == nil {
+		t.Fatal("expected the certificate of another cluster to be rejected")
+	}
+	// Nodes verify the host names of each other.
+	if _, err := get("https", node.clientConfig("127.0.0.3", nil)); err == nil {
+		t.Fatal("expected the certificate of another node to be rejected")
+	}
+}
+
+func TestInternodeRequestValidateHandlers(t *testing.T) {
+	server, err := newInternodeTLS("minio123", []string{"127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevInternodeTLS := globalInternodeTLS
+	globalInternodeTLS = server
+	defer func() { globalInternodeTLS = prevInternodeTLS }()
+
+	// Requests not sent over TLS by a node are rejected before
+	// their authentication token is checked.
+	testCases := []struct {
+		name    string
+		isValid func(w http.ResponseWriter, r *http.Request) bool
+	}{
+		{"storage", (&storageRESTServer{}).IsValid},
+		{"lock", (&lockRESTServer{}).IsValid},
+		{"peer", (&peerRESTServer{}).IsValid},
+	}
+	for _, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "http://127.0.0.1/", nil)
+		if testCase.isValid(rec, r) {
+			t.Fatalf("%s: expected the request to be rejected", testCase.name)
+		}
+		if rec.Code != http.StatusForbidden || rec.Body.String() != errInternodeTLSRequired.Error() {
+			t.Fatalf("%s: expected %v, got %d %q", testCase.name, errInternodeTLSRequired, rec.Code, rec.Body.String())
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	(&bootstrapRESTServer{}).VerifyHandler(rec, httptest.NewRequest(http.MethodPost, "http://127.0.0.1/", nil))
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("bootstrap: expected the request to be rejected, got %d", rec.Code)
+	}
+}
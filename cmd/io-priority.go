@@ -0,0 +1,239 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ioClass is the priority class of the object operations, the
+// operations of the background classes take turns while foreground
+// operations are in progress.
+type ioClass int
+
+const (
+	// The operations of the client requests, and of the contexts
+	// without a class.
+	ioClassForeground ioClass = iota
+	ioClassHeal
+	ioClassScanner
+	ioClassReplication
+	ioClassLifecycle
+
+	ioClassCount
+)
+
+func (c ioClass) String() string {
+	switch c {
+	case ioClassForeground:
+		return "foreground"
+	case ioClassHeal:
+		return "heal"
+	case ioClassScanner:
+		return "scanner"
+	case ioClassReplication:
+		return "replication"
+	case ioClassLifecycle:
+		return "lifecycle"
+	}
+	return "unknown"
+}
+
+// parseIOClass returns the class named s.
+func parseIOClass(s string) (ioClass, bool) {
+	for class := ioClassForeground; class < ioClassCount; class++ {
+		if class.String() == s {
+			return class, true
+		}
+	}
+	return 0, false
+}
+
+// ioBackgroundMaxActive - the number of background operations in
+// progress at once while foreground operations are in progress, the
+// background jobs always progress under a steady client load.
+const ioBackgroundMaxActive = 2
+
+type ioClassContextKey struct{}
+
+// withIOClass returns a context whose object operations have the class.
+func withIOClass(ctx context.Context, class ioClass) context.Context {
+	return context.WithValue(ctx, ioClassContextKey{}, class)
+}
+
+// ioClassFromContext returns the class of the context, def without one.
+func ioClassFromContext(ctx context.Context, def ioClass) ioClass {
+	if class, ok := ctx.Value(ioClassContextKey{}).(ioClass); ok {
+		return class
+	}
+	return def
+}
+
+// ioClassStats - the statistics of the operations of a class.
+type ioClassStats struct {
+	operations uint64
+	waitNanos  uint64
+	waiting    int64
+}
+
+// ioScheduler - orders the object operations by their class. While
+// foreground operations are in progress, ioBackgroundMaxActive
+// background operations run at once, the others are queued by class
+// and the classes take turns.
+type ioScheduler struct {
+	mu         sync.Mutex
+	foreground int
+	background int
+	queues     [ioClassCount][]chan struct{}
+	// next is the class taking the next turn.
+	next ioClass
+
+	stats [ioClassCount]ioClassStats
+}
+
+func newIOScheduler() *ioScheduler {
+	return &ioScheduler{next: ioClassForeground + 1}
+}
+
+var globalIOScheduler = newIOScheduler()
+
+// acquire waits for the turn of an operation of the class, the
+// returned function must be called once the operation is done.
+// Foreground operations never wait.
+func (s *ioScheduler) acquire(ctx context.Context, class ioClass) (release func(), err error) {
+	if class == ioClassForeground {
+		s.mu.Lock()
+		s.foreground++
+		s.mu.Unlock()
+		atomic.AddUint64(&s.stats[class].operations, 1)
+
+		var once sync.Once
+		return func() {
+			once.Do(func() {
+				s.mu.Lock()
+				s.foreground--
+				s.dispatch()
+				s.mu.Unlock()
+			})
+		}, nil
+	}
+
+	if err = s.waitTurn(ctx, class); err != nil {
+		return nil, err
+	}
+	var once sync.Once
+	return func() {
+		once.Do(s.done)
+	}, nil
+}
+
+// waitTurn waits for the turn of a background operation of the
+// class, which is in progress until done is called, and accounts it.
+func (s *ioScheduler) waitTurn(ctx context.Context, class ioClass) error {
+	stats := &s.stats[class]
+	start := time.Now()
+	atomic.AddInt64(&stats.waiting, 1)
+	defer func() {
+		atomic.AddInt64(&stats.waiting, -1)
+		atomic.AddUint64(&stats.waitNanos, uint64(time.Since(start)))
+		atomic.AddUint64(&stats.operations, 1)
+	}()
+
+	s.mu.Lock()
+	if s.foreground == 0 || s.background < ioBackgroundMaxActive {
+		s.background++
+		s.mu.Unlock()
+		return nil
+	}
+	turn := make(chan struct{})
+	s.queues[class] = append(s.queues[class], turn)
+	s.mu.Unlock()
+
+	select {
+	case <-turn:
+		return nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, ch := range s.queues[class] {
+		if ch == turn {
+			s.queues[class] = append(s.queues[class][:i], s.queues[class][i+1:]...)
+			return ctx.Err()
+		}
+	}
+	// The turn was given meanwhile, pass it on.
+	s.background--
+	s.dispatch()
+	return ctx.Err()
+}
+
+// done ends a background operation.
+func (s *ioScheduler) done() {
+	s.mu.Lock()
+	s.background--
+	s.dispatch()
+	s.mu.Unlock()
+}
+
+// dispatch gives their turn to the queued background operations,
+// as many as allowed, the classes taking turns. Called with s.mu held.
+func (s *ioScheduler) dispatch() {
+	for s.foreground == 0 || s.background < ioBackgroundMaxActive {
+		var turn chan struct{}
+		for i := ioClassForeground + 1; i < ioClassCount && turn == nil; i++ {
+			class := s.next
+			if s.next++; s.next == ioClassCount {
+				s.next = ioClassForeground + 1
+			}
+			if len(s.queues[class]) > 0 {
+				turn = s.queues[class][0]
+				s.queues[class] = s.queues[class][1:]
+			}
+		}
+		if turn == nil {
+			return
+		}
+		s.background++
+		close(turn)
+	}
+}
+
+// ioClassMetrics - the statistics of a class.
+type ioClassMetrics struct {
+	Operations uint64
+	Wait       time.Duration
+	Waiting    int64
+}
+
+// metrics returns the statistics of the classes.
+func (s *ioScheduler) metrics() map[ioClass]ioClassMetrics {
+	m := make(map[ioClass]ioClassMetrics, ioClassCount)
+	for class := ioClassForeground; class < ioClassCount; class++ {
+		stats := &s.stats[class]
+		m[class] = ioClassMetrics{
+			Operations: atomic.LoadUint64(&stats.operations),
+			Wait:       time.Duration(atomic.LoadUint64(&stats.waitNanos)),
+			Waiting:    atomic.LoadInt64(&stats.waiting),
+		}
+	}
+	return m
+}
@@ -0,0 +1,306 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIOClassFromContext(t *testing.T) {
+	ctx := context.Background()
+	if class := ioClassFromContext(ctx, ioClassHeal); class != ioClassHeal {
+		t.Fatalf("expected the default class, got %s", class)
+	}
+	ctx = withIOClass(ctx, ioClassReplication)
+	if class := ioClassFromContext(ctx, ioClassForeground); class != ioClassReplication {
+		t.Fatalf("expected the class of the context, got %s", class)
+	}
+}
+
+func TestIOSchedulerForeground(t *testing.T) {
+	s := newIOScheduler()
+	ctx := context.Background()
+
+	// Foreground operations never wait, even for each other.
+	release1, err := s.acquire(ctx, ioClassForeground)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	release2, err := s.acquire(ctx, ioClassForeground)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected the foreground operation not to wait, took %s", elapsed)
+	}
+
+	// Fill the background turns.
+	var releases []func()
+	for i := 0; i < ioBackgroundMaxActive; i++ {
+		release, err := s.acquire(ctx, ioClassScanner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		release, err := s.acquire(ctx, ioClassHeal)
+		if err == nil {
+			release()
+		}
+		done <- err
+	}()
+
+	release1()
+	// Releasing twice has no effect.
+	release1()
+	select {
+	case <-done:
+		t.Fatal("expected the background operation to wait for its turn")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	release2()
+	select {
+	case err = <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the background operation to start once the foreground operations are done")
+	}
+	for _, release := range releases {
+		release()
+	}
+
+	m := s.metrics()
+	if m[ioClassForeground].Operations != 2 || m[ioClassHeal].Operations != 1 {
+		t.Fatalf("unexpected operations %v", m)
+	}
+	if m[ioClassHeal].Wait < 100*time.Millisecond || m[ioClassHeal].Waiting != 0 {
+		t.Fatalf("unexpected wait %v", m[ioClassHeal])
+	}
+}
+
+func TestIOSchedulerBackground(t *testing.T) {
+	s := newIOScheduler()
+	ctx := context.Background()
+
+	// Without foreground operations there is nothing to wait for.
+	var releases []func()
+	start := time.Now()
+	for i := 0; i < 2*ioBackgroundMaxActive; i++ {
+		release, err := s.acquire(ctx, ioClassScanner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("expected the background operations not to wait, took %s", elapsed)
+	}
+	for _, release := range releases {
+		release()
+	}
+
+	release, err := s.acquire(ctx, ioClassForeground)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	// Under foreground operations the first ones still run right away.
+	releases = releases[:0]
+	for i := 0; i < ioBackgroundMaxActive; i++ {
+		release, err := s.acquire(ctx, ioClassLifecycle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+	if m := s.metrics(); m[ioClassLifecycle].Wait > 100*time.Millisecond {
+		t.Fatalf("expected the background operations not to wait, took %s", m[ioClassLifecycle].Wait)
+	}
+
+	// The others are queued, until canceled.
+	ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err = s.acquire(ctx, ioClassReplication); err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if m := s.metrics(); m[ioClassReplication].Waiting != 0 {
+		t.Fatalf("expected no waiting operation, got %d", m[ioClassReplication].Waiting)
+	}
+	if len(s.queues[ioClassReplication]) != 0 {
+		t.Fatal("expected the canceled operation to leave the queue")
+	}
+	for _, release := range releases {
+		release()
+	}
+}
+
+func TestIOSchedulerBackgroundProgress(t *testing.T) {
+	s := newIOScheduler()
+	ctx := context.Background()
+
+	// A continuous client load, a foreground operation is always in progress.
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				release, _ := s.acquire(ctx, ioClassForeground)
+				time.Sleep(time.Millisecond)
+				release()
+			}
+		}()
+	}
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+	release, err := s.acquire(ctx, ioClassForeground)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	// The background jobs of all the classes progress, by turns.
+	const ops = 50
+	var counts [ioClassCount]int32
+	var bg sync.WaitGroup
+	start := time.Now()
+	for class := ioClassHeal; class < ioClassCount; class++ {
+		for i := 0; i < 3; i++ {
+			bg.Add(1)
+			go func(class ioClass) {
+				defer bg.Done()
+				for j := 0; j < ops; j++ {
+					release, err := s.acquire(ctx, class)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if active := atomic.AddInt32(&counts[ioClassForeground], 1); active > ioBackgroundMaxActive {
+						t.Errorf("expected %d background operations at most, got %d", ioBackgroundMaxActive, active)
+					}
+					time.Sleep(100 * time.Microsecond)
+					atomic.AddInt32(&counts[ioClassForeground], -1)
+					atomic.AddInt32(&counts[class], 1)
+					release()
+				}
+			}(class)
+		}
+	}
+	bg.Wait()
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("expected the background operations to progress, took %s", elapsed)
+	}
+	for class := ioClassHeal; class < ioClassCount; class++ {
+		if n := atomic.LoadInt32(&counts[class]); n != 3*ops {
+			t.Fatalf("expected %d operations of %s, got %d", 3*ops, class, n)
+		}
+	}
+}
+
+func TestIOSchedulerTurns(t *testing.T) {
+	s := newIOScheduler()
+	ctx := context.Background()
+
+	release, err := s.acquire(ctx, ioClassForeground)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	var releases []func()
+	for i := 0; i < ioBackgroundMaxActive; i++ {
+		release, err := s.acquire(ctx, ioClassScanner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		releases = append(releases, release)
+	}
+
+	queued := func(class ioClass, n int) {
+		for {
+			s.mu.Lock()
+			l := len(s.queues[class])
+			s.mu.Unlock()
+			if l == n {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Many queued scanner operations do not hold back a heal operation.
+	started := make(chan ioClass, 4)
+	acquire := func(class ioClass) {
+		release, err := s.acquire(ctx, class)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		started <- class
+		release()
+	}
+	for i := 0; i < 3; i++ {
+		go acquire(ioClassScanner)
+	}
+	queued(ioClassScanner, 3)
+	go acquire(ioClassHeal)
+	queued(ioClassHeal, 1)
+
+	releases[0]()
+	if class := <-started; class != ioClassHeal {
+		t.Fatalf("expected the heal operation to take the first turn, got %s", class)
+	}
+	releases[1]()
+	for i := 0; i < 3; i++ {
+		if class := <-started; class != ioClassScanner {
+			t.Fatalf("expected a scanner operation, got %s", class)
+		}
+	}
+}
+
+func TestParseIOClass(t *testing.T) {
+	for class := ioClassForeground; class < ioClassCount; class++ {
+		if parsed, ok := parseIOClass(class.String()); !ok || parsed != class {
+			t.Fatalf("expected %s, got %s", class, parsed)
+		}
+	}
+	if _, ok := parseIOClass(""); ok {
+		t.Fatal("expected no class")
+	}
+	if _, ok := parseIOClass("unknown"); ok {
+		t.Fatal("expected no class")
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// listFilter - MinIO extension selecting the listed objects by their
+// modification time and size, both bounds inclusive. The filter is
+// evaluated while walking the namespace, so that the objects left out
+// don't count towards max-keys. Common prefixes are never filtered.
+type listFilter struct {
+	minModTime, maxModTime time.Time
+	minSize                int64
+	// -1 without a maximum size.
+	maxSize int64
+}
+
+// listFilterMaxSkipped - the number of objects left out by the filter
+// after which a page is returned truncated, so that a selective filter
+// doesn't walk the whole namespace within a single request. The page
+// may then have no objects, its next marker is the last object left out.
+var listFilterMaxSkipped = 10 * maxObjectList
+
+// getListFilterArgs returns the list filter of the query parameters
+// min-mtime, max-mtime, min-size and max-size, nil without any.
+func getListFilterArgs(values url.Values) (*listFilter, APIErrorCode) {
+	if values.Get("min-mtime") == "" && values.Get("max-mtime") == "" &&
+		values.Get("min-size") == "" && values.Get("max-size") == "" {
+		return nil, ErrNone
+	}
+
+	filter := &listFilter{maxSize: -1}
+	var err error
+	if v := values.Get("min-mtime"); v != "" {
+		if filter.minModTime, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, ErrInvalidListFilter
+		}
+	}
+	if v := values.Get("max-mtime"); v != "" {
+		if filter.maxModTime, err = time.Parse(time.RFC3339, v); err != nil {
+			return nil, ErrInvalidListFilter
+		}
+	}
+	if v := values.Get("min-size"); v != "" {
+		if filter.minSize, err = strconv.ParseInt(v, 10, 64); err != nil || filter.minSize < 0 {
+			return nil, ErrInvalidListFilter
+		}
+	}
+	if v := values.Get("max-size"); v != "" {
+		if filter.maxSize, err = strconv.ParseInt(v, 10, 64); err != nil || filter.maxSize < 0 {
+			return nil, ErrInvalidListFilter
+		}
+	}
+
+	if !filter.minModTime.IsZero() && !filter.maxModTime.IsZero() && filter.minModTime.After(filter.maxModTime) {
+		return nil, ErrInvalidListFilter
+	}
+	if filter.maxSize >= 0 && filter.minSize > filter.maxSize {
+		return nil, ErrInvalidListFilter
+	}
+	return filter, ErrNone
+}
+
+// match returns whether the object is listed, a nil filter lists all
+// the objects. The size compared is the size of the object as read by
+// the clients, before compression or encryption. The directory objects
+// are filtered as the other objects, the callers don't match the common
+// prefixes.
+func (f *listFilter) match(objInfo ObjectInfo) bool {
+	if f == nil {
+		return true
+	}
+	if !f.minModTime.IsZero() && objInfo.ModTime.Before(f.minModTime) {
+		return false
+	}
+	if !f.maxModTime.IsZero() && objInfo.ModTime.After(f.maxModTime) {
+		return false
+	}
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		size = objInfo.Size
+	}
+	return size >= f.minSize && (f.maxSize < 0 || size <= f.maxSize)
+}
+
+// matchFileInfo returns whether the object of the entry of a walk of
+// the erasure coded namespace is listed.
+func (f *listFilter) matchFileInfo(bucket string, fi FileInfo) bool {
+	if f == nil {
+		return true
+	}
+	return f.match(fi.ToObjectInfo(bucket, fi.Name))
+}
+
+// filterObjects returns the objects the filter lists, for the object
+// layers which can't filter them while walking the namespace such as
+// the gateways.
+func (f *listFilter) filterObjects(objects []ObjectInfo) []ObjectInfo {
+	if f == nil {
+		return objects
+	}
+	filtered := objects[:0]
+	for _, objInfo := range objects {
+		if f.match(objInfo) {
+			filtered = append(filtered, objInfo)
+		}
+	}
+	return filtered
+}
+
+// filterListObjectsInfo filters the objects of a page of ListObjects
+// V1, its NextMarker is set to the last object before filtering so that
+// the clients continue the listing after the objects left out.
+func (f *listFilter) filterListObjectsInfo(loi *ListObjectsInfo) {
+	if f == nil {
+		return
+	}
+	if loi.IsTruncated && loi.NextMarker == "" && len(loi.Objects) > 0 {
+		loi.NextMarker = loi.Objects[len(loi.Objects)-1].Name
+	}
+	loi.Objects = f.filterObjects(loi.Objects)
+}
+
+type listFilterContextKey struct{}
+
+// withListFilter returns a context whose listings are filtered.
+func withListFilter(ctx context.Context, filter *listFilter) context.Context {
+	if filter == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, listFilterContextKey{}, filter)
+}
+
+// listFilterFromContext returns the list filter of the context, nil
+// without one.
+func listFilterFromContext(ctx context.Context) *listFilter {
+	filter, _ := ctx.Value(listFilterContextKey{}).(*listFilter)
+	return filter
+}
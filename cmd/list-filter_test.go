@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetListFilterArgs(t *testing.T) {
+	testCases := []struct {
+		query   string
+		filter  *listFilter
+		errCode APIErrorCode
+	}{
+		{"", nil, ErrNone},
+		{"prefix=a&max-keys=10", nil, ErrNone},
+		{"min-size=10", &listFilter{minSize: 10, maxSize: -1}, ErrNone},
+		{"min-size=10&max-size=10", &listFilter{minSize: 10, maxSize: 10}, ErrNone},
+		{"max-size=0", &listFilter{maxSize: 0}, ErrNone},
+		{"min-mtime=2020-01-02T15:04:05Z&max-mtime=2020-02-02T15:04:05Z", &listFilter{
+			minModTime: time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC),
+			maxModTime: time.Date(2020, 2, 2, 15, 4, 5, 0, time.UTC),
+			maxSize:    -1,
+		}, ErrNone},
+		{"min-size=-1", nil, ErrInvalidListFilter},
+		{"max-size=ten", nil, ErrInvalidListFilter},
+		{"min-size=11&max-size=10", nil, ErrInvalidListFilter},
+		{"min-mtime=2020-01-02", nil, ErrInvalidListFilter},
+		{"min-mtime=2020-02-02T15:04:05Z&max-mtime=2020-01-02T15:04:05Z", nil, ErrInvalidListFilter},
+	}
+
+	for i, testCase := range testCases {
+		values, err := url.ParseQuery(testCase.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		filter, errCode := getListFilterArgs(values)
+		if errCode != testCase.errCode {
+			t.Fatalf("test %d: expected error code %d, got %d", i+1, testCase.errCode, errCode)
+		}
+		if filter != nil && testCase.filter != nil {
+			if !filter.minModTime.Equal(testCase.filter.minModTime) || !filter.maxModTime.Equal(testCase.filter.maxModTime) {
+				t.Fatalf("test %d: expected filter %+v, got %+v", i+1, testCase.filter, filter)
+			}
+			filter.minModTime, filter.maxModTime = testCase.filter.minModTime, testCase.filter.maxModTime
+		}
+		if !reflect.DeepEqual(filter, testCase.filter) {
+			t.Fatalf("test %d: expected filter %+v, got %+v", i+1, testCase.filter, filter)
+		}
+	}
+}
+
+func TestListObjectsFilter(t *testing.T) {
+	ExecObjectLayerTest(t, testListObjectsFilter)
+}
+
+func testListObjectsFilter(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(context.Background(), bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	objects := []struct {
+		name string
+		size int
+	}{
+		{"a", 1},
+		{"b", 10},
+		{"c/d", 10},
+		{"e", 100},
+		{"f", 1000},
+		// A directory object.
+		{"g/", 0},
+	}
+	for _, object := range objects {
+		data := bytes.Repeat([]byte("a"), object.size)
+		_, err := obj.PutObject(context.Background(), bucket, object.name,
+			mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	now := UTCNow()
+	testCases := []struct {
+		filter     *listFilter
+		marker     string
+		delimiter  string
+		maxKeys    int
+		objects    []string
+		prefixes   []string
+		nextMarker string
+		// The objects left out before cutting the page short,
+		// listFilterMaxSkipped when 0.
+		maxSkipped int
+	}{
+		// The objects left out don't count towards max-keys.
+		{&listFilter{minSize: 10, maxSize: 100}, "", "", 2, []string{"b", "c/d"}, nil, "c/d", 0},
+		{&listFilter{minSize: 10, maxSize: 100}, "c/d", "", 2, []string{"e"}, nil, "", 0},
+		{&listFilter{minSize: 1000, maxSize: -1}, "", "", 1, []string{"f"}, nil, "f", 0},
+		// Directory objects are filtered as the other objects.
+		{&listFilter{maxSize: 0}, "", "", 10, []string{"g/"}, nil, "", 0},
+		// Common prefixes are never filtered.
+		{&listFilter{minSize: 100, maxSize: -1}, "", SlashSeparator, 10, []string{"e", "f"}, []string{"c/", "g/"}, "", 0},
+		{&listFilter{minSize: 100, maxSize: -1}, "", "d", 10, []string{"e", "f"}, []string{"c/d"}, "", 0},
+		{&listFilter{minModTime: now.Add(time.Hour), maxSize: -1}, "", "", 10, nil, nil, "", 0},
+		{&listFilter{maxModTime: now.Add(time.Hour), maxSize: -1}, "", "", 10, []string{"a", "b", "c/d", "e", "f", "g/"}, nil, "", 0},
+		{nil, "", "", 10, []string{"a", "b", "c/d", "e", "f", "g/"}, nil, "", 0},
+		// The pages cut short have the last object left out as their
+		// next marker.
+		{&listFilter{minSize: 1000, maxSize: -1}, "", "", 10, nil, nil, "b", 2},
+		{&listFilter{minSize: 1000, maxSize: -1}, "b", "", 10, nil, nil, "e", 2},
+		{&listFilter{minSize: 1000, maxSize: -1}, "e", "", 10, []string{"f"}, nil, "", 2},
+		{&listFilter{minSize: 1000, maxSize: -1}, "", "d", 10, nil, nil, "b", 2},
+	}
+
+	defaultMaxSkipped := listFilterMaxSkipped
+	defer func() { listFilterMaxSkipped = defaultMaxSkipped }()
+
+	for i, testCase := range testCases {
+		listFilterMaxSkipped = defaultMaxSkipped
+		if testCase.maxSkipped > 0 {
+			listFilterMaxSkipped = testCase.maxSkipped
+		}
+		ctx := withListFilter(context.Background(), testCase.filter)
+		result, err := obj.ListObjects(ctx, bucket, "", testCase.marker, testCase.delimiter, testCase.maxKeys)
+		if err != nil {
+			t.Fatalf("%s: test %d: %v", instanceType, i+1, err)
+		}
+		var names []string
+		for _, objInfo := range result.Objects {
+			names = append(names, objInfo.Name)
+		}
+		if !reflect.DeepEqual(names, testCase.objects) {
+			t.Fatalf("%s: test %d: expected objects %v, got %v", instanceType, i+1, testCase.objects, names)
+		}
+		if !reflect.DeepEqual(result.Prefixes, testCase.prefixes) {
+			t.Fatalf("%s: test %d: expected prefixes %v, got %v", instanceType, i+1, testCase.prefixes, result.Prefixes)
+		}
+		if result.IsTruncated != (testCase.nextMarker != "") || result.NextMarker != testCase.nextMarker {
+			t.Fatalf("%s: test %d: expected next marker %q, got %q (truncated %v)", instanceType, i+1,
+				testCase.nextMarker, result.NextMarker, result.IsTruncated)
+		}
+	}
+}
@@ -153,8 +153,15 @@ func newlockRESTClient(endpoint Endpoint) *lockRESTClient {
 		}
 	}
 
+	if globalInternodeTLS != nil {
+		serverURL.Scheme = "https"
+		tlsConfig = globalInternodeTLS.clientConfig(endpoint.Hostname(), tlsConfig)
+	}
+
 	trFn := newCustomHTTPTransport(tlsConfig, rest.DefaultRESTTimeout)
 	restClient := rest.NewClient(serverURL, trFn, newAuthToken)
+	restClient.FaultFn = newRPCFaultFn(serverURL.Host)
+	restClient.Versions = lockRESTVersions
 	restClient.HealthCheckFn = func() bool {
 		ctx, cancel := context.WithTimeout(GlobalContext, restClient.HealthCheckTimeout)
 		// Instantiate a new rest client for healthcheck
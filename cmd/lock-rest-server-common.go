@@ -21,11 +21,14 @@ import (
 )
 
 const (
-	lockRESTVersion       = "v3"
-	lockRESTVersionPrefix = SlashSeparator + lockRESTVersion
-	lockRESTPrefix        = minioReservedBucketPath + "/lock"
+	lockRESTVersion = "v3"
+	lockRESTPrefix  = minioReservedBucketPath + "/lock"
 )
 
+// Versions of the lock REST API served and spoken, newest first, see
+// storageRESTVersions.
+var lockRESTVersions = []string{lockRESTVersion}
+
 const (
 	lockRESTMethodHealth  = "/health"
 	lockRESTMethodLock    = "/lock"
@@ -184,3 +184,26 @@ func AuditLog(w http.ResponseWriter, r *http.Request, api string, reqClaims map[
 		_ = t.Send(entry, string(All))
 	}
 }
+
+// AuditErasure - logs the certificate of the erasure of the data of
+// the object version of a bucket from a drive to all audit targets.
+func AuditErasure(bucket, object string, erasure audit.Erasure) {
+	// Fast exit if there is not audit target configured
+	if len(AuditTargets) == 0 {
+		return
+	}
+
+	entry := audit.Entry{
+		Version:      audit.Version,
+		DeploymentID: globalDeploymentID,
+		Time:         time.Now().UTC().Format(time.RFC3339Nano),
+		Erasure:      &erasure,
+	}
+	entry.API.Name = "SecureErase"
+	entry.API.Bucket = bucket
+	entry.API.Object = object
+
+	for _, t := range AuditTargets {
+		_ = t.Send(entry, string(All))
+	}
+}
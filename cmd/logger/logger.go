@@ -351,6 +351,7 @@ func logIf(ctx context.Context, err error, errKind ...interface{}) {
 		RemoteHost:   req.RemoteHost,
 		Host:         req.Host,
 		RequestID:    req.RequestID,
+		HostID:       req.HostID,
 		UserAgent:    req.UserAgent,
 		Time:         time.Now().UTC().Format(time.RFC3339Nano),
 		API: &log.API{
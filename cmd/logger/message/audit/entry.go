@@ -44,11 +44,23 @@ type Entry struct {
 	} `json:"api"`
 	RemoteHost string                 `json:"remotehost,omitempty"`
 	RequestID  string                 `json:"requestID,omitempty"`
+	HostID     string                 `json:"hostID,omitempty"`
 	UserAgent  string                 `json:"userAgent,omitempty"`
 	ReqClaims  map[string]interface{} `json:"requestClaims,omitempty"`
 	ReqQuery   map[string]string      `json:"requestQuery,omitempty"`
 	ReqHeader  map[string]string      `json:"requestHeader,omitempty"`
 	RespHeader map[string]string      `json:"responseHeader,omitempty"`
+	Erasure    *Erasure               `json:"erasure,omitempty"`
+}
+
+// Erasure - certifies that the data of an object version was erased
+// from a drive before its deletion.
+type Erasure struct {
+	Drive     string `json:"drive"`
+	VersionID string `json:"versionId,omitempty"`
+	Method    string `json:"method"`
+	Files     int    `json:"files"`
+	Bytes     int64  `json:"bytes"`
 }
 
 // ToEntry - constructs an audit entry object.
@@ -72,6 +84,7 @@ func ToEntry(w http.ResponseWriter, r *http.Request, reqClaims map[string]interf
 		DeploymentID: deploymentID,
 		RemoteHost:   handlers.GetSourceIP(r),
 		RequestID:    w.Header().Get(xhttp.AmzRequestID),
+		HostID:       w.Header().Get(xhttp.AmzRequestHostID),
 		UserAgent:    r.UserAgent(),
 		Time:         time.Now().UTC().Format(time.RFC3339Nano),
 		ReqQuery:     reqQuery,
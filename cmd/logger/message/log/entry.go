@@ -48,6 +48,7 @@ type Entry struct {
 	RemoteHost   string `json:"remotehost,omitempty"`
 	Host         string `json:"host,omitempty"`
 	RequestID    string `json:"requestID,omitempty"`
+	HostID       string `json:"hostID,omitempty"`
 	UserAgent    string `json:"userAgent,omitempty"`
 	Message      string `json:"message,omitempty"`
 	Trace        *Trace `json:"error,omitempty"`
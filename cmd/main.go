@@ -41,6 +41,10 @@ var GlobalFlags = []cli.Flag{
 		Value: defaultCertsDir.Get(),
 		Usage: "path to certs directory",
 	},
+	cli.StringFlag{
+		Name:  "config-file",
+		Usage: "path to a YAML or JSON file with the configuration of the server",
+	},
 	cli.BoolFlag{
 		Name:  "quiet",
 		Usage: "disable startup information",
@@ -53,6 +57,14 @@ var GlobalFlags = []cli.Flag{
 		Name:  "json",
 		Usage: "output server logs and startup information in json format",
 	},
+	cli.BoolFlag{
+		Name:  "read-only",
+		Usage: "reject all the requests modifying buckets or objects",
+	},
+	cli.BoolFlag{
+		Name:  "write-once",
+		Usage: "reject the requests overwriting or deleting objects",
+	},
 	// Deprecated flag, so its hidden now, existing deployments will keep working.
 	cli.BoolFlag{
 		Name:   "compat",
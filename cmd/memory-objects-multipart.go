@@ -0,0 +1,477 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/mimedb"
+)
+
+// memUpload - an on-going multipart upload and its parts by number.
+type memUpload struct {
+	bucket    string
+	object    string
+	initiated time.Time
+	meta      map[string]string
+	parts     map[int]*memPart
+}
+
+// memPart - the content of an uploaded part.
+type memPart struct {
+	data       []byte
+	etag       string
+	actualSize int64
+	modTime    time.Time
+}
+
+// size - returns the size of the content of the parts.
+func (u *memUpload) size() (size int64) {
+	for _, part := range u.parts {
+		size += int64(len(part.data))
+	}
+	return size
+}
+
+// getUpload - returns the upload of the object, a read lock
+// on m.mu must be held.
+func (m *MemObjects) getUpload(bucket, object, uploadID string) (*memUpload, error) {
+	upload, ok := m.uploads[uploadID]
+	if !ok || upload.bucket != bucket || upload.object != object {
+		return nil, InvalidUploadID{Bucket: bucket, Object: object, UploadID: uploadID}
+	}
+	return upload, nil
+}
+
+// ListMultipartUploads - lists the on-going multipart uploads of the
+// object, sorted by initiation time, like erasure mode does.
+func (m *MemObjects) ListMultipartUploads(ctx context.Context, bucket, object, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
+	if err = checkListMultipartArgs(ctx, bucket, object, keyMarker, uploadIDMarker, delimiter, m); err != nil {
+		return result, err
+	}
+
+	result.MaxUploads = maxUploads
+	result.KeyMarker = keyMarker
+	result.Prefix = object
+	result.Delimiter = delimiter
+
+	var uploads []MultipartInfo
+	m.mu.RLock()
+	for uploadID, upload := range m.uploads {
+		if upload.bucket != bucket || upload.object != object {
+			continue
+		}
+		uploads = append(uploads, MultipartInfo{
+			Object:    object,
+			UploadID:  uploadID,
+			Initiated: upload.initiated,
+		})
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(uploads, func(i int, j int) bool {
+		return uploads[i].Initiated.Before(uploads[j].Initiated)
+	})
+
+	uploadIndex := 0
+	if uploadIDMarker != "" {
+		for uploadIndex < len(uploads) {
+			uploadIndex++
+			if uploads[uploadIndex-1].UploadID == uploadIDMarker {
+				break
+			}
+		}
+	}
+	for uploadIndex < len(uploads) {
+		result.Uploads = append(result.Uploads, uploads[uploadIndex])
+		result.NextUploadIDMarker = uploads[uploadIndex].UploadID
+		uploadIndex++
+		if len(result.Uploads) == maxUploads {
+			break
+		}
+	}
+
+	result.IsTruncated = uploadIndex < len(uploads)
+
+	if !result.IsTruncated {
+		result.NextKeyMarker = ""
+		result.NextUploadIDMarker = ""
+	}
+
+	return result, nil
+}
+
+// NewMultipartUpload - initialize a new multipart upload, returns
+// a unique id.
+func (m *MemObjects) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (string, error) {
+	if opts.Versioned {
+		return "", NotImplemented{}
+	}
+
+	if err := checkNewMultipartArgs(ctx, bucket, object, m); err != nil {
+		return "", err
+	}
+
+	meta := make(map[string]string, len(opts.UserDefined)+1)
+	for k, v := range opts.UserDefined {
+		meta[k] = v
+	}
+
+	// Guess content-type from the extension if possible.
+	if meta["content-type"] == "" {
+		meta["content-type"] = mimedb.TypeByExtension(path.Ext(object))
+	}
+
+	uploadID := mustGetUUID()
+
+	m.mu.Lock()
+	m.uploads[uploadID] = &memUpload{
+		bucket:    bucket,
+		object:    object,
+		initiated: UTCNow(),
+		meta:      meta,
+		parts:     make(map[int]*memPart),
+	}
+	m.mu.Unlock()
+
+	return uploadID, nil
+}
+
+// CopyObjectPart - similar to PutObjectPart but reading data from
+// the source object.
+func (m *MemObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject, uploadID string, partID int,
+	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (pi PartInfo, err error) {
+	if srcOpts.VersionID != "" && srcOpts.VersionID != nullVersionID {
+		return pi, VersionNotFound{
+			Bucket:    srcBucket,
+			Object:    srcObject,
+			VersionID: srcOpts.VersionID,
+		}
+	}
+
+	if err = checkObjectArgs(ctx, srcBucket, srcObject, m); err != nil {
+		return pi, err
+	}
+
+	return m.PutObjectPart(ctx, dstBucket, dstObject, uploadID, partID, srcInfo.PutObjReader, dstOpts)
+}
+
+// PutObjectPart - reads the content of a part of a multipart upload,
+// replacing a part uploaded before with the same number.
+func (m *MemObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, r *PutObjReader, opts ObjectOptions) (pi PartInfo, err error) {
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return pi, VersionNotFound{
+			Bucket:    bucket,
+			Object:    object,
+			VersionID: opts.VersionID,
+		}
+	}
+
+	if err = checkPutObjectPartArgs(ctx, bucket, object, m); err != nil {
+		return pi, err
+	}
+
+	data := r.Reader
+
+	// Validate input data size and it can never be less than -1.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return pi, toObjectErr(errInvalidArgument)
+	}
+
+	// Just check if the uploadID exists to avoid reading the part if it doesn't.
+	m.mu.RLock()
+	_, err = m.getUpload(bucket, object, uploadID)
+	m.mu.RUnlock()
+	if err != nil {
+		return pi, err
+	}
+
+	buf, err := m.readData(data)
+	if err != nil {
+		return pi, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if int64(len(buf)) < data.Size() {
+		m.release(int64(len(buf)))
+		return pi, IncompleteBody{}
+	}
+
+	etag := r.MD5CurrentHexString()
+	if etag == "" {
+		etag = GenETag()
+	}
+
+	part := &memPart{
+		data:       buf,
+		etag:       etag,
+		actualSize: data.ActualSize(),
+		modTime:    UTCNow(),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The upload may have been aborted or completed meanwhile.
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		m.used -= int64(len(buf))
+		return pi, err
+	}
+	if old, ok := upload.parts[partID]; ok {
+		m.used -= int64(len(old.data))
+	}
+	upload.parts[partID] = part
+
+	return PartInfo{
+		PartNumber:   partID,
+		LastModified: part.modTime,
+		ETag:         etag,
+		Size:         int64(len(buf)),
+		ActualSize:   part.actualSize,
+	}, nil
+}
+
+// GetMultipartInfo returns multipart metadata uploaded during
+// NewMultipartUpload, used by callers to verify object states.
+func (m *MemObjects) GetMultipartInfo(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) (MultipartInfo, error) {
+	minfo := MultipartInfo{
+		Bucket:   bucket,
+		Object:   object,
+		UploadID: uploadID,
+	}
+
+	if err := checkListPartsArgs(ctx, bucket, object, m); err != nil {
+		return minfo, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		return minfo, err
+	}
+	minfo.Initiated = upload.initiated
+	minfo.UserDefined = make(map[string]string, len(upload.meta))
+	for k, v := range upload.meta {
+		minfo.UserDefined[k] = v
+	}
+	return minfo, nil
+}
+
+// ListObjectParts - lists the uploaded parts of a multipart upload
+// sorted by number, after partNumberMarker, upto maxParts.
+func (m *MemObjects) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
+	if err = checkListPartsArgs(ctx, bucket, object, m); err != nil {
+		return result, err
+	}
+
+	result.Bucket = bucket
+	result.Object = object
+	result.UploadID = uploadID
+	result.MaxParts = maxParts
+	result.PartNumberMarker = partNumberMarker
+
+	m.mu.RLock()
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		m.mu.RUnlock()
+		return result, err
+	}
+	result.UserDefined = make(map[string]string, len(upload.meta))
+	for k, v := range upload.meta {
+		result.UserDefined[k] = v
+	}
+	var parts []PartInfo
+	for number, part := range upload.parts {
+		if number <= partNumberMarker {
+			continue
+		}
+		parts = append(parts, PartInfo{
+			PartNumber:   number,
+			LastModified: part.modTime,
+			ETag:         part.etag,
+			Size:         int64(len(part.data)),
+			ActualSize:   part.actualSize,
+		})
+	}
+	m.mu.RUnlock()
+
+	// For empty number of parts or maxParts as zero, return right here.
+	if len(parts) == 0 || maxParts == 0 {
+		return result, nil
+	}
+
+	// Limit output to maxPartsList.
+	if maxParts > maxPartsList {
+		maxParts = maxPartsList
+	}
+
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].PartNumber < parts[j].PartNumber
+	})
+	if len(parts) > maxParts {
+		parts = parts[:maxParts]
+		result.IsTruncated = true
+		result.NextPartNumberMarker = parts[maxParts-1].PartNumber
+	}
+	result.Parts = parts
+	return result, nil
+}
+
+// AbortMultipartUpload - aborts an on-going multipart upload, its
+// parts are dropped.
+func (m *MemObjects) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
+	if err := checkAbortMultipartArgs(ctx, bucket, object, m); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		return err
+	}
+	m.used -= upload.size()
+	delete(m.uploads, uploadID)
+	return nil
+}
+
+// CompleteMultipartUpload - completes an on-going multipart upload
+// with the parts indicated by the client, the ETag of the object is
+// computed from the ETags of the parts.
+func (m *MemObjects) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, parts []CompletePart, opts ObjectOptions) (oi ObjectInfo, err error) {
+	if err = checkCompleteMultipartArgs(ctx, bucket, object, m); err != nil {
+		return oi, err
+	}
+
+	// Hold write lock on the object.
+	destLock := m.NewNSLock(ctx, bucket, object)
+	if err = destLock.GetLock(globalObjectTimeout); err != nil {
+		return oi, err
+	}
+	defer destLock.Unlock()
+	defer ObjectPathUpdated(path.Join(bucket, object))
+
+	if opts.CheckPrecondFn != nil {
+		curInfo, err := m.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil && !isErrObjectNotFound(err) {
+			return oi, err
+		}
+		if opts.CheckPrecondFn(curInfo) {
+			return oi, PreConditionFailed{}
+		}
+	}
+
+	// Calculate s3 compatible md5sum for complete multipart.
+	s3MD5 := getCompleteMultipartMD5(parts)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		return oi, err
+	}
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return oi, BucketNotFound{Bucket: bucket}
+	}
+
+	var objectSize, objectActualSize int64
+	objParts := make([]ObjectPartInfo, len(parts))
+	for i, part := range parts {
+		uploaded, ok := upload.parts[part.PartNumber]
+		if !ok {
+			return oi, InvalidPart{
+				PartNumber: part.PartNumber,
+				GotETag:    part.ETag,
+			}
+		}
+
+		// ensure that part ETag is canonicalized to strip off extraneous quotes
+		part.ETag = canonicalizeETag(part.ETag)
+		if uploaded.etag != part.ETag {
+			return oi, InvalidPart{
+				PartNumber: part.PartNumber,
+				ExpETag:    uploaded.etag,
+				GotETag:    part.ETag,
+			}
+		}
+
+		// All parts except the last part has to be atleast 5MB.
+		if (i < len(parts)-1) && !isMinAllowedPartSize(uploaded.actualSize) {
+			return oi, PartTooSmall{
+				PartNumber: part.PartNumber,
+				PartSize:   uploaded.actualSize,
+				PartETag:   part.ETag,
+			}
+		}
+
+		objectSize += int64(len(uploaded.data))
+		objectActualSize += uploaded.actualSize
+		objParts[i] = ObjectPartInfo{
+			ETag:       part.ETag,
+			Number:     part.PartNumber,
+			Size:       int64(len(uploaded.data)),
+			ActualSize: uploaded.actualSize,
+		}
+	}
+
+	if isMaxObjectSize(objectActualSize) {
+		return oi, ObjectTooLarge{Bucket: bucket, Object: object}
+	}
+
+	data := make([]byte, 0, objectSize)
+	for _, part := range parts {
+		data = append(data, upload.parts[part.PartNumber].data...)
+	}
+
+	meta := upload.meta
+	meta["etag"] = s3MD5
+	// Save consolidated actual size.
+	meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+
+	o := &memObject{
+		data:    data,
+		meta:    meta,
+		parts:   objParts,
+		modTime: opts.MTime,
+	}
+	if o.modTime.IsZero() {
+		o.modTime = UTCNow()
+	}
+	// The content of the parts becomes the content of the object,
+	// the parts left out and the replaced object are dropped.
+	m.used += objectSize - upload.size()
+	if old, ok := b.objects[object]; ok {
+		m.used -= int64(len(old.data))
+	}
+	b.objects[object] = o
+	delete(m.uploads, uploadID)
+
+	return o.toObjectInfo(bucket, object), nil
+}
@@ -0,0 +1,1033 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/bucket/replication"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/mimedb"
+	"github.com/minio/minio/pkg/sys"
+)
+
+// MemObjects - implements the object layer in memory, buckets and
+// objects are lost when the process exits. Meant for tests and
+// ephemeral deployments, versioning is not supported like in FS mode.
+type MemObjects struct {
+	GatewayUnsupported
+
+	// Protects the buckets and the uploads, the content of stored
+	// objects and parts is never modified, only replaced.
+	mu      sync.RWMutex
+	buckets map[string]*memBucket
+	uploads map[string]*memUpload
+
+	// The size of the content of the objects and parts, and its
+	// maximum, 0 without a maximum. Protected by mu.
+	used    int64
+	maxSize int64
+
+	nsMutex *nsLockMap
+}
+
+// memBucket - a bucket and its objects by name.
+type memBucket struct {
+	created time.Time
+	objects map[string]*memObject
+}
+
+// memObject - the content and metadata of an object.
+type memObject struct {
+	data    []byte
+	meta    map[string]string
+	parts   []ObjectPartInfo
+	modTime time.Time
+}
+
+// NewMemObjectLayer - initialize a new in-memory object layer, the
+// size of its content is limited by the memory of the host only.
+func NewMemObjectLayer() ObjectLayer {
+	return NewMemObjectLayerWithMaxSize(0)
+}
+
+// NewMemObjectLayerWithMaxSize - initialize a new in-memory object
+// layer holding up to maxSize bytes of content, writes above it fail
+// with StorageFull. A maxSize of 0 sets no maximum.
+func NewMemObjectLayerWithMaxSize(maxSize int64) ObjectLayer {
+	return &MemObjects{
+		buckets: map[string]*memBucket{
+			// Config, IAM and bucket metadata are saved here.
+			minioMetaBucket: {created: UTCNow(), objects: make(map[string]*memObject)},
+		},
+		uploads: make(map[string]*memUpload),
+		maxSize: maxSize,
+		nsMutex: newNSLock(false),
+	}
+}
+
+// getMemoryMaxSize - returns the maximum size of the content of the
+// in-memory backend set by --memory-max-size, half the memory of the
+// host by default.
+func getMemoryMaxSize(s string) (int64, error) {
+	if s != "" {
+		size, err := humanize.ParseBytes(s)
+		if err != nil {
+			return 0, err
+		}
+		if size == 0 || size > math.MaxInt64 {
+			return 0, fmt.Errorf("invalid size %s", s)
+		}
+		return int64(size), nil
+	}
+	stats, err := sys.GetStats()
+	if err != nil || stats.TotalRAM == 0 {
+		// Unknown memory of the host, no maximum.
+		return 0, nil
+	}
+	return int64(stats.TotalRAM / 2), nil
+}
+
+// reserve - accounts n more bytes of content, fails with StorageFull
+// when the maximum size would be exceeded.
+func (m *MemObjects) reserve(n int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.maxSize > 0 && m.used+n > m.maxSize {
+		return StorageFull{}
+	}
+	m.used += n
+	return nil
+}
+
+// release - accounts n bytes of content dropped.
+func (m *MemObjects) release(n int64) {
+	m.mu.Lock()
+	m.used -= n
+	m.mu.Unlock()
+}
+
+// readData - reads the content of an object or a part, its size is
+// reserved before it is read, or while it is read when unknown, so
+// that no write exceeds the maximum size. On success the size of the
+// content returned stays reserved, the caller releases it when the
+// content isn't stored.
+func (m *MemObjects) readData(data *hash.Reader) ([]byte, error) {
+	var reserved int64
+	if size := data.Size(); size > 0 {
+		if err := m.reserve(size); err != nil {
+			return nil, err
+		}
+		reserved = size
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, reserved))
+	chunk := make([]byte, 32*humanize.KiByte)
+	for {
+		n, err := data.Read(chunk)
+		if n > 0 {
+			if extra := int64(buf.Len()+n) - reserved; extra > 0 {
+				if rerr := m.reserve(extra); rerr != nil {
+					m.release(reserved)
+					return nil, rerr
+				}
+				reserved += extra
+			}
+			buf.Write(chunk[:n])
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			m.release(reserved)
+			return nil, err
+		}
+	}
+	// Release the size reserved for a shorter content.
+	if unused := reserved - int64(buf.Len()); unused > 0 {
+		m.release(unused)
+	}
+	return buf.Bytes(), nil
+}
+
+// NewNSLock - initialize a new namespace RWLocker instance.
+func (m *MemObjects) NewNSLock(ctx context.Context, bucket string, objects ...string) RWLocker {
+	return m.nsMutex.NewNSLock(ctx, nil, bucket, objects...)
+}
+
+// Shutdown - nothing to release, the content is dropped with the layer.
+func (m *MemObjects) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// StorageInfo - returns the memory used by the objects and the parts,
+// and what remains under the maximum size.
+func (m *MemObjects) StorageInfo(ctx context.Context, _ bool) (StorageInfo, []error) {
+	m.mu.RLock()
+	used, total := uint64(m.used), uint64(m.maxSize)
+	m.mu.RUnlock()
+
+	if total == 0 {
+		// No maximum, the content can grow as much as it is used.
+		total = used
+	}
+	var available uint64
+	if total > used {
+		available = total - used
+	}
+
+	storageInfo := StorageInfo{
+		Disks: []madmin.Disk{
+			{
+				TotalSpace:     total,
+				UsedSpace:      used,
+				AvailableSpace: available,
+				DrivePath:      "memory",
+				State:          madmin.DriveStateOk,
+			},
+		},
+	}
+	storageInfo.Backend.Type = BackendMemory
+	return storageInfo, nil
+}
+
+// CrawlAndGetDataUsage - the usage is computed from the objects in
+// memory, there is nothing to crawl.
+func (m *MemObjects) CrawlAndGetDataUsage(ctx context.Context, bf *bloomFilter, updates chan<- DataUsageInfo) error {
+	usage := DataUsageInfo{
+		LastUpdate:   UTCNow(),
+		BucketsUsage: make(map[string]BucketUsageInfo),
+		BucketSizes:  make(map[string]uint64),
+	}
+
+	m.mu.RLock()
+	for name, b := range m.buckets {
+		if isReservedOrInvalidBucket(name, false) {
+			continue
+		}
+		var bui BucketUsageInfo
+		var histogram sizeHistogram
+		for _, o := range b.objects {
+			bui.Size += uint64(len(o.data))
+			bui.ObjectsCount++
+			histogram.add(int64(len(o.data)))
+		}
+		bui.ObjectSizesHistogram = histogram.toMap()
+		usage.BucketsUsage[name] = bui
+		usage.BucketSizes[name] = bui.Size
+		usage.BucketsCount++
+		usage.ObjectsTotalCount += bui.ObjectsCount
+		usage.ObjectsTotalSize += bui.Size
+	}
+	m.mu.RUnlock()
+
+	select {
+	case updates <- usage:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+/// Bucket operations
+
+// MakeBucketWithLocation - create a new bucket.
+func (m *MemObjects) MakeBucketWithLocation(ctx context.Context, bucket string, opts BucketOptions) error {
+	if opts.LockEnabled || opts.VersioningEnabled {
+		return NotImplemented{}
+	}
+
+	// Verify if bucket is valid.
+	if s3utils.CheckValidBucketNameStrict(bucket) != nil {
+		return BucketNameInvalid{Bucket: bucket}
+	}
+
+	m.mu.Lock()
+	if _, ok := m.buckets[bucket]; ok {
+		m.mu.Unlock()
+		return BucketExists{Bucket: bucket}
+	}
+	m.buckets[bucket] = &memBucket{created: UTCNow(), objects: make(map[string]*memObject)}
+	m.mu.Unlock()
+
+	meta := newBucketMetadata(bucket)
+	meta.Region = bucketRegion(opts.Location)
+	if err := meta.Save(ctx, m); err != nil {
+		return toObjectErr(err, bucket)
+	}
+
+	globalBucketMetadataSys.Set(bucket, meta)
+
+	return nil
+}
+
+// GetBucketInfo - fetch bucket metadata info.
+func (m *MemObjects) GetBucketInfo(ctx context.Context, bucket string) (bi BucketInfo, e error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return bi, BucketNotFound{Bucket: bucket}
+	}
+	return BucketInfo{
+		Name:    bucket,
+		Created: b.created,
+	}, nil
+}
+
+// ListBuckets - list all buckets sorted by name.
+func (m *MemObjects) ListBuckets(ctx context.Context) ([]BucketInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bucketInfos := []BucketInfo{}
+	for name, b := range m.buckets {
+		// Ignore all reserved bucket names.
+		if isReservedOrInvalidBucket(name, false) {
+			continue
+		}
+		bucketInfos = append(bucketInfos, BucketInfo{
+			Name:    name,
+			Created: b.created,
+		})
+	}
+	sort.Sort(byBucketName(bucketInfos))
+	return bucketInfos, nil
+}
+
+// DeleteBucket - delete a bucket, its on-going multipart uploads,
+// and with forceDelete its objects.
+func (m *MemObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	m.mu.Lock()
+	b, ok := m.buckets[bucket]
+	if !ok {
+		m.mu.Unlock()
+		return BucketNotFound{Bucket: bucket}
+	}
+	if !forceDelete && len(b.objects) > 0 {
+		m.mu.Unlock()
+		return BucketNotEmpty{Bucket: bucket}
+	}
+	delete(m.buckets, bucket)
+	for _, o := range b.objects {
+		m.used -= int64(len(o.data))
+	}
+	for uploadID, upload := range m.uploads {
+		if upload.bucket == bucket {
+			m.used -= upload.size()
+			delete(m.uploads, uploadID)
+		}
+	}
+	m.mu.Unlock()
+
+	// Delete all bucket metadata.
+	deleteBucketMetadata(ctx, m, bucket)
+	logger.LogIf(ctx, deleteBucketSnapshots(ctx, m, bucket))
+
+	return nil
+}
+
+// GetBucketPolicy - get the policy of a bucket from its metadata.
+func (m *MemObjects) GetBucketPolicy(ctx context.Context, bucket string) (*policy.Policy, error) {
+	meta, err := loadBucketMetadata(ctx, m, bucket)
+	if err != nil {
+		return nil, BucketPolicyNotFound{Bucket: bucket}
+	}
+	if meta.policyConfig == nil {
+		return nil, BucketPolicyNotFound{Bucket: bucket}
+	}
+	return meta.policyConfig, nil
+}
+
+// SetBucketPolicy - save the policy in the bucket metadata.
+func (m *MemObjects) SetBucketPolicy(ctx context.Context, bucket string, p *policy.Policy) error {
+	meta, err := loadBucketMetadata(ctx, m, bucket)
+	if err != nil {
+		return err
+	}
+
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	configData, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	meta.PolicyConfigJSON = configData
+
+	return meta.Save(ctx, m)
+}
+
+// DeleteBucketPolicy - remove the policy from the bucket metadata.
+func (m *MemObjects) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	meta, err := loadBucketMetadata(ctx, m, bucket)
+	if err != nil {
+		return err
+	}
+	meta.PolicyConfigJSON = nil
+	return meta.Save(ctx, m)
+}
+
+/// Object operations
+
+// getObject - returns the object, a read lock on m.mu must be held.
+func (m *MemObjects) getObject(bucket, object string) (*memObject, error) {
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return nil, BucketNotFound{Bucket: bucket}
+	}
+	o, ok := b.objects[object]
+	if !ok {
+		return nil, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	return o, nil
+}
+
+// getObjectInfo - returns the object and its info.
+func (m *MemObjects) getObjectInfo(bucket, object string) (*memObject, ObjectInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	o, err := m.getObject(bucket, object)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return o, o.toObjectInfo(bucket, object), nil
+}
+
+// toObjectInfo - converts the metadata of an object to ObjectInfo.
+func (o *memObject) toObjectInfo(bucket, object string) ObjectInfo {
+	objInfo := ObjectInfo{
+		Bucket:          bucket,
+		Name:            object,
+		ModTime:         o.modTime,
+		Size:            int64(len(o.data)),
+		IsDir:           HasSuffix(object, SlashSeparator),
+		IsLatest:        true,
+		ETag:            extractETag(o.meta),
+		ContentType:     o.meta["content-type"],
+		ContentEncoding: o.meta["content-encoding"],
+		StorageClass:    globalMinioDefaultStorageClass,
+		UserTags:        o.meta[xhttp.AmzObjectTagging],
+		Parts:           o.parts,
+	}
+	if storageClass, ok := o.meta[xhttp.AmzStorageClass]; ok {
+		objInfo.StorageClass = storageClass
+	}
+	if exp, ok := o.meta["expires"]; ok {
+		if t, err := time.Parse(http.TimeFormat, exp); err == nil {
+			objInfo.Expires = t.UTC()
+		}
+	}
+	objInfo.ReplicationStatus = replication.StatusType(o.meta[xhttp.AmzBucketReplicationStatus])
+
+	// etag and tags have been extracted, remove them
+	// from the metadata returned to the client.
+	objInfo.UserDefined = cleanMetadata(o.meta)
+	return objInfo
+}
+
+// GetObjectNInfo - returns object info and a reader for object content.
+func (m *MemObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return nil, VersionNotFound{
+			Bucket:    bucket,
+			Object:    object,
+			VersionID: opts.VersionID,
+		}
+	}
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return nil, err
+	}
+
+	var nsUnlocker = func() {}
+
+	if lockType != noLock {
+		// Lock the object before reading.
+		lock := m.NewNSLock(ctx, bucket, object)
+		switch lockType {
+		case writeLock:
+			if err = lock.GetLock(globalObjectTimeout); err != nil {
+				return nil, err
+			}
+			nsUnlocker = lock.Unlock
+		case readLock:
+			if err = lock.GetRLock(globalObjectTimeout); err != nil {
+				return nil, err
+			}
+			nsUnlocker = lock.RUnlock
+		}
+	}
+
+	o, objInfo, err := m.getObjectInfo(bucket, object)
+	if err != nil {
+		nsUnlocker()
+		return nil, err
+	}
+
+	objReaderFn, off, length, err := NewGetObjectReader(ctx, rs, objInfo, opts, nsUnlocker)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if range is valid
+	size := int64(len(o.data))
+	if off > size || off+length > size {
+		err = InvalidRange{off, length, size}
+		logger.LogIf(ctx, err, logger.Application)
+		nsUnlocker()
+		return nil, err
+	}
+
+	return objReaderFn(bytes.NewReader(o.data[off:off+length]), h, opts.CheckCopyPrecondFn)
+}
+
+// GetObject - writes length bytes of the object from offset to writer.
+func (m *MemObjects) GetObject(ctx context.Context, bucket, object string, offset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) error {
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return VersionNotFound{
+			Bucket:    bucket,
+			Object:    object,
+			VersionID: opts.VersionID,
+		}
+	}
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return err
+	}
+
+	if offset < 0 || writer == nil {
+		logger.LogIf(ctx, errUnexpected, logger.Application)
+		return toObjectErr(errUnexpected, bucket, object)
+	}
+
+	o, objInfo, err := m.getObjectInfo(bucket, object)
+	if err != nil {
+		return err
+	}
+
+	if etag != "" && etag != defaultEtag && etag != objInfo.ETag {
+		logger.LogIf(ctx, InvalidETag{}, logger.Application)
+		return InvalidETag{}
+	}
+
+	size := int64(len(o.data))
+	if length < 0 {
+		length = size - offset
+	}
+	if offset > size || offset+length > size {
+		err = InvalidRange{offset, length, size}
+		logger.LogIf(ctx, err, logger.Application)
+		return err
+	}
+
+	bufSize := int64(readSizeV1)
+	if length > 0 && bufSize > length {
+		bufSize = length
+	}
+	buf := make([]byte, int(bufSize))
+
+	_, err = io.CopyBuffer(writer, io.LimitReader(bytes.NewReader(o.data[offset:]), length), buf)
+	if err == io.ErrClosedPipe {
+		err = nil
+	}
+	return toObjectErr(err, bucket, object)
+}
+
+// GetObjectInfo - reads object metadata and replies back ObjectInfo.
+func (m *MemObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return ObjectInfo{}, VersionNotFound{
+			Bucket:    bucket,
+			Object:    object,
+			VersionID: opts.VersionID,
+		}
+	}
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	_, objInfo, err := m.getObjectInfo(bucket, object)
+	return objInfo, err
+}
+
+// PutObject - creates an object, replacing an existing one.
+func (m *MemObjects) PutObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.Versioned {
+		return objInfo, NotImplemented{}
+	}
+
+	if err = checkPutObjectArgs(ctx, bucket, object, m, r.Size()); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	// Lock the object.
+	lk := m.NewNSLock(ctx, bucket, object)
+	if err = lk.GetLock(globalObjectTimeout); err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, err
+	}
+	defer lk.Unlock()
+	defer ObjectPathUpdated(path.Join(bucket, object))
+
+	return m.putObject(ctx, bucket, object, r, opts)
+}
+
+// putObject - reads the content of the object and saves it, the
+// object must be locked by the caller.
+func (m *MemObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	if opts.CheckPrecondFn != nil {
+		oi, err := m.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil && !isErrObjectNotFound(err) {
+			return ObjectInfo{}, err
+		}
+		if opts.CheckPrecondFn(oi) {
+			return ObjectInfo{}, PreConditionFailed{}
+		}
+	}
+
+	data := r.Reader
+
+	// Validate input data size and it can never be less than zero.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return ObjectInfo{}, errInvalidArgument
+	}
+
+	buf, err := m.readData(data)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if int64(len(buf)) < data.Size() {
+		m.release(int64(len(buf)))
+		return ObjectInfo{}, IncompleteBody{}
+	}
+
+	meta := make(map[string]string, len(opts.UserDefined)+2)
+	for k, v := range opts.UserDefined {
+		meta[k] = v
+	}
+	meta["etag"] = r.MD5CurrentHexString()
+	if HasSuffix(object, SlashSeparator) {
+		// For directories etag is d41d8cd98f00b204e9800998ecf8427e
+		meta["etag"] = emptyETag
+	}
+
+	// Guess content-type from the extension if possible.
+	if meta["content-type"] == "" {
+		meta["content-type"] = mimedb.TypeByExtension(path.Ext(object))
+	}
+
+	o := &memObject{
+		data:    buf,
+		meta:    meta,
+		parts:   []ObjectPartInfo{{Number: 1, Size: int64(len(buf)), ActualSize: data.ActualSize()}},
+		modTime: opts.MTime,
+	}
+	if o.modTime.IsZero() {
+		o.modTime = UTCNow()
+	}
+	return m.storeObject(bucket, object, o)
+}
+
+// storeObject - saves the object, replacing an existing one, the size
+// of its content is already reserved.
+func (m *MemObjects) storeObject(bucket, object string, o *memObject) (ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		m.used -= int64(len(o.data))
+		return ObjectInfo{}, BucketNotFound{Bucket: bucket}
+	}
+	if old, ok := b.objects[object]; ok {
+		m.used -= int64(len(old.data))
+	}
+	b.objects[object] = o
+	return o.toObjectInfo(bucket, object), nil
+}
+
+// updateObjectMeta - replaces the metadata of an existing object,
+// update is called with a copy of the current metadata.
+func (m *MemObjects) updateObjectMeta(bucket, object string, update func(meta map[string]string)) (ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	o, err := m.getObject(bucket, object)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	meta := make(map[string]string, len(o.meta))
+	for k, v := range o.meta {
+		meta[k] = v
+	}
+	update(meta)
+
+	no := *o
+	no.meta = meta
+	m.buckets[bucket].objects[object] = &no
+	return no.toObjectInfo(bucket, object), nil
+}
+
+// CopyObject - copy object source object to destination object.
+// if source object and destination object are same we only
+// update metadata.
+func (m *MemObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, err error) {
+	if srcOpts.VersionID != "" && srcOpts.VersionID != nullVersionID {
+		return oi, VersionNotFound{
+			Bucket:    srcBucket,
+			Object:    srcObject,
+			VersionID: srcOpts.VersionID,
+		}
+	}
+
+	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
+	defer ObjectPathUpdated(path.Join(dstBucket, dstObject))
+
+	if !cpSrcDstSame {
+		objectDWLock := m.NewNSLock(ctx, dstBucket, dstObject)
+		if err = objectDWLock.GetLock(globalObjectTimeout); err != nil {
+			return oi, err
+		}
+		defer objectDWLock.Unlock()
+	}
+
+	if cpSrcDstSame && srcInfo.metadataOnly {
+		if srcOpts.CheckPrecondFn != nil {
+			curInfo, err := m.GetObjectInfo(ctx, srcBucket, srcObject, ObjectOptions{})
+			if err != nil {
+				return oi, err
+			}
+			if srcOpts.CheckPrecondFn(curInfo) {
+				return oi, PreConditionFailed{}
+			}
+		}
+		return m.updateObjectMeta(srcBucket, srcObject, func(meta map[string]string) {
+			for k := range meta {
+				delete(meta, k)
+			}
+			for k, v := range srcInfo.UserDefined {
+				meta[k] = v
+			}
+			meta["etag"] = srcInfo.ETag
+		})
+	}
+
+	if err = checkPutObjectArgs(ctx, dstBucket, dstObject, m, srcInfo.PutObjReader.Size()); err != nil {
+		return oi, err
+	}
+
+	return m.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+		MTime:                dstOpts.MTime,
+		CheckPrecondFn:       dstOpts.CheckPrecondFn,
+	})
+}
+
+// DeleteObject - deletes an object from a bucket.
+func (m *MemObjects) DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return objInfo, VersionNotFound{
+			Bucket:    bucket,
+			Object:    object,
+			VersionID: opts.VersionID,
+		}
+	}
+
+	if err = checkDelObjArgs(ctx, bucket, object); err != nil {
+		return objInfo, err
+	}
+
+	// Acquire a write lock before deleting the object.
+	lk := m.NewNSLock(ctx, bucket, object)
+	if err = lk.GetLock(globalOperationTimeout); err != nil {
+		return objInfo, err
+	}
+	defer lk.Unlock()
+	defer ObjectPathUpdated(path.Join(bucket, object))
+
+	if opts.CheckPrecondFn != nil {
+		curInfo, err := m.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil && !isErrObjectNotFound(err) {
+			return objInfo, err
+		}
+		if opts.CheckPrecondFn(curInfo) {
+			return objInfo, PreConditionFailed{}
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	o, err := m.getObject(bucket, object)
+	if err != nil {
+		return objInfo, err
+	}
+	m.used -= int64(len(o.data))
+	delete(m.buckets[bucket].objects, object)
+	return ObjectInfo{Bucket: bucket, Name: object}, nil
+}
+
+// DeleteObjects - deletes several objects of a bucket, objects not
+// found are reported deleted.
+func (m *MemObjects) DeleteObjects(ctx context.Context, bucket string, objects []ObjectToDelete, opts ObjectOptions) ([]DeletedObject, []error) {
+	errs := make([]error, len(objects))
+	dobjects := make([]DeletedObject, len(objects))
+	for idx, object := range objects {
+		if object.VersionID != "" {
+			errs[idx] = VersionNotFound{
+				Bucket:    bucket,
+				Object:    object.ObjectName,
+				VersionID: object.VersionID,
+			}
+			continue
+		}
+		_, errs[idx] = m.DeleteObject(ctx, bucket, object.ObjectName, opts)
+		if errs[idx] == nil || isErrObjectNotFound(errs[idx]) {
+			dobjects[idx] = DeletedObject{
+				ObjectName: object.ObjectName,
+			}
+			errs[idx] = nil
+		}
+	}
+	return dobjects, errs
+}
+
+/// Listing
+
+// sortedObjects - returns the names of the objects of a bucket
+// starting with prefix, sorted.
+func (m *MemObjects) sortedObjects(bucket, prefix string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return nil, BucketNotFound{Bucket: bucket}
+	}
+	names := make([]string, 0, len(b.objects))
+	for name := range b.objects {
+		if HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ListObjects - list all objects at prefix upto maxKeys, optionally
+// delimited by delimiter, after marker.
+func (m *MemObjects) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, err error) {
+	if err = checkListObjsArgs(ctx, bucket, prefix, marker, m); err != nil {
+		return loi, err
+	}
+
+	names, err := m.sortedObjects(bucket, prefix)
+	if err != nil {
+		return loi, err
+	}
+	if maxKeys == 0 {
+		return loi, nil
+	}
+
+	var entries int
+	for _, name := range names {
+		if name <= marker {
+			continue
+		}
+		entry := name
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				entry = name[:len(prefix)+i+len(delimiter)]
+			}
+		}
+		if entry <= marker {
+			// Common prefix listed by a previous page.
+			continue
+		}
+		if n := len(loi.Prefixes); entry != name && n > 0 && loi.Prefixes[n-1] == entry {
+			continue
+		}
+		if entries == maxKeys {
+			loi.IsTruncated = true
+			break
+		}
+		entries++
+		loi.NextMarker = entry
+		if entry != name {
+			loi.Prefixes = append(loi.Prefixes, entry)
+			continue
+		}
+		m.mu.RLock()
+		o, err := m.getObject(bucket, name)
+		if err == nil {
+			loi.Objects = append(loi.Objects, o.toObjectInfo(bucket, name))
+		}
+		m.mu.RUnlock()
+	}
+	if !loi.IsTruncated {
+		loi.NextMarker = ""
+	}
+	return loi, nil
+}
+
+// ListObjectsV2 - list all objects at prefix upto maxKeys, optionally
+// delimited by delimiter, after the continuation token or startAfter.
+func (m *MemObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
+	marker := continuationToken
+	if marker == "" {
+		marker = startAfter
+	}
+
+	loi, err := m.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	if err != nil {
+		return result, err
+	}
+
+	return ListObjectsV2Info{
+		IsTruncated:           loi.IsTruncated,
+		ContinuationToken:     continuationToken,
+		NextContinuationToken: loi.NextMarker,
+		Objects:               loi.Objects,
+		Prefixes:              loi.Prefixes,
+	}, nil
+}
+
+// Walk - sends the info of all the objects at prefix to results,
+// sorted by name, and closes it.
+func (m *MemObjects) Walk(ctx context.Context, bucket, prefix string, results chan<- ObjectInfo, opts ObjectOptions) error {
+	if err := checkListObjsArgs(ctx, bucket, prefix, "", m); err != nil {
+		// Upon error close the channel.
+		close(results)
+		return err
+	}
+
+	names, err := m.sortedObjects(bucket, prefix)
+	if err != nil {
+		close(results)
+		return err
+	}
+
+	go func() {
+		defer close(results)
+
+		for _, name := range names {
+			m.mu.RLock()
+			o, err := m.getObject(bucket, name)
+			m.mu.RUnlock()
+			if err != nil {
+				// Deleted meanwhile.
+				continue
+			}
+			select {
+			case results <- o.toObjectInfo(bucket, name):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+/// Object tagging
+
+// GetObjectTags - get object tags from an existing object
+func (m *MemObjects) GetObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) (*tags.Tags, error) {
+	oi, err := m.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags.ParseObjectTags(oi.UserTags)
+}
+
+// PutObjectTags - replace or add tags to an existing object
+func (m *MemObjects) PutObjectTags(ctx context.Context, bucket, object string, tags string, opts ObjectOptions) error {
+	if opts.VersionID != "" && opts.VersionID != nullVersionID {
+		return VersionNotFound{
+			Bucket:    bucket,
+			Object:    object,
+			VersionID: opts.VersionID,
+		}
+	}
+
+	_, err := m.updateObjectMeta(bucket, object, func(meta map[string]string) {
+		delete(meta, xhttp.AmzObjectTagging)
+		if tags != "" {
+			meta[xhttp.AmzObjectTagging] = tags
+		}
+	})
+	return err
+}
+
+// DeleteObjectTags - delete object tags from an existing object
+func (m *MemObjects) DeleteObjectTags(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return m.PutObjectTags(ctx, bucket, object, "", opts)
+}
+
+// IsNotificationSupported returns whether bucket notification is applicable for this layer.
+func (m *MemObjects) IsNotificationSupported() bool {
+	return true
+}
+
+// IsListenBucketSupported returns whether listen bucket notification is applicable for this layer.
+func (m *MemObjects) IsListenBucketSupported() bool {
+	return true
+}
+
+// IsEncryptionSupported returns whether server side encryption is implemented for this layer.
+func (m *MemObjects) IsEncryptionSupported() bool {
+	return true
+}
+
+// IsCompressionSupported returns whether compression is applicable for this layer.
+func (m *MemObjects) IsCompressionSupported() bool {
+	return true
+}
+
+// IsTaggingSupported returns true, object tagging is supported in memory.
+func (m *MemObjects) IsTaggingSupported() bool {
+	return true
+}
+
+// IsReady - the layer is always ready to take requests.
+func (m *MemObjects) IsReady(_ context.Context) bool {
+	return true
+}
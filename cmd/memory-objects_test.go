@@ -0,0 +1,184 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// execMemObjectLayerTest - runs an object layer test against a
+// new in-memory object layer.
+func execMemObjectLayerTest(t TestErrHandler, objTest objTestType) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer := NewMemObjectLayer()
+	defer objLayer.Shutdown(ctx)
+
+	newAllSubsystems()
+
+	if err := newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	initAllSubsystems(ctx, objLayer)
+
+	objTest(objLayer, "Memory", t)
+}
+
+// The generic object layer tests pass against the in-memory layer.
+func TestMemObjects(t *testing.T) {
+	for _, objTest := range []objTestType{
+		testMakeBucket,
+		testMultipartObjectCreation,
+		testMultipartObjectAbort,
+		testMultipleObjectCreation,
+		testPaging,
+		testObjectOverwriteWorks,
+		testNonExistantBucketOperations,
+		testBucketRecreateFails,
+		testPutObject,
+		testPutObjectInSubdir,
+		testListBuckets,
+		testListBucketsOrder,
+		testListObjectsTestsForNonExistantBucket,
+		testContentType,
+		testGetObject,
+		testGetObjectInfo,
+		testDeleteObject,
+		testObjectAPIPutObject,
+		testObjectNewMultipartUpload,
+		testObjectAbortMultipartUpload,
+		testObjectAPIPutObjectPart,
+		testListObjectParts,
+		testObjectCompleteMultipartUpload,
+	} {
+		execMemObjectLayerTest(t, objTest)
+	}
+}
+
+func TestMemObjectsDeleteBucket(t *testing.T) {
+	execMemObjectLayerTest(t, func(obj ObjectLayer, instanceType string, t TestErrHandler) {
+		ctx := context.Background()
+		if err := obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		data := []byte("hello")
+		if _, err := obj.PutObject(ctx, "bucket", "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := obj.NewMultipartUpload(ctx, "bucket", "upload", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := obj.DeleteBucket(ctx, "bucket", false); err == nil {
+			t.Fatal("Expected a non-empty bucket not to be deleted")
+		} else if _, ok := err.(BucketNotEmpty); !ok {
+			t.Fatalf("Expected BucketNotEmpty, got %v", err)
+		}
+		if err := obj.DeleteBucket(ctx, "bucket", true); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := obj.GetBucketInfo(ctx, "bucket"); err == nil {
+			t.Fatal("Expected the bucket to be deleted")
+		}
+
+		// The bucket is recreated empty.
+		if err := obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := obj.GetObjectInfo(ctx, "bucket", "object", ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("Expected ObjectNotFound, got %v", err)
+		}
+		lmi, err := obj.ListMultipartUploads(ctx, "bucket", "upload", "", "", "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lmi.Uploads) != 0 {
+			t.Fatalf("Expected no multipart uploads, got %v", lmi.Uploads)
+		}
+	})
+}
+
+func TestMemObjectsMaxSize(t *testing.T) {
+	ctx := context.Background()
+	obj := NewMemObjectLayerWithMaxSize(humanize.KiByte).(*MemObjects)
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// Leave 10 bytes for the objects after the bucket metadata.
+	obj.maxSize = obj.used + 10
+
+	put := func(object string, size, readerSize int64) error {
+		data := bytes.Repeat([]byte("a"), int(size))
+		_, err := obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), readerSize, "", ""), ObjectOptions{})
+		return err
+	}
+	expectAvailable := func(available uint64) {
+		t.Helper()
+		storageInfo, _ := obj.StorageInfo(ctx, false)
+		if disk := storageInfo.Disks[0]; disk.TotalSpace != uint64(obj.maxSize) || disk.AvailableSpace != available {
+			t.Fatalf("Expected %d bytes available out of %d, got %d out of %d", available, obj.maxSize, disk.AvailableSpace, disk.TotalSpace)
+		}
+	}
+
+	if err := put("object", 6, 6); err != nil {
+		t.Fatal(err)
+	}
+	expectAvailable(4)
+	if err := put("other", 6, 6); err != (StorageFull{}) {
+		t.Fatalf("Expected StorageFull, got %v", err)
+	}
+	// The content of unknown size is limited as it is read.
+	if err := put("other", 6, -1); err != (StorageFull{}) {
+		t.Fatalf("Expected StorageFull, got %v", err)
+	}
+	expectAvailable(4)
+	if err := put("other", 4, -1); err != nil {
+		t.Fatal(err)
+	}
+	expectAvailable(0)
+
+	if _, err := obj.DeleteObject(ctx, "bucket", "object", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expectAvailable(6)
+
+	// The parts count until the upload is aborted.
+	uploadID, err := obj.NewMultipartUpload(ctx, "bucket", "upload", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("part")
+	if _, err = obj.PutObjectPart(ctx, "bucket", "upload", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expectAvailable(2)
+	if err = obj.AbortMultipartUpload(ctx, "bucket", "upload", uploadID); err != nil {
+		t.Fatal(err)
+	}
+	expectAvailable(6)
+
+	if _, err = obj.DeleteObject(ctx, "bucket", "other", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expectAvailable(10)
+}
@@ -0,0 +1,269 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/sha256-simd"
+)
+
+const (
+	// Entries of a metadata archive holding the list of the exported
+	// files and its signature.
+	metadataManifestFile  = "manifest.json"
+	metadataSignatureFile = "manifest.sig"
+
+	metadataManifestVersion = 1
+
+	// Maximum size of an imported metadata archive.
+	maxMetadataArchiveSize = 256 << 20
+)
+
+var (
+	errInvalidMetadataArchive = AdminError{
+		Code:       "XMinioAdminInvalidMetadataArchive",
+		Message:    "Invalid metadata archive",
+		StatusCode: http.StatusBadRequest,
+	}
+	errMetadataArchiveSignature = AdminError{
+		Code:       "XMinioAdminInvalidMetadataArchive",
+		Message:    "Metadata archive signature does not match, it was exported with other credentials or modified",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// metadataManifest - lists the files of a metadata archive with their
+// SHA-256 checksums, the manifest is signed with the credentials of the
+// cluster which also encrypt the IAM and config files of the archive.
+type metadataManifest struct {
+	Version      int               `json:"version"`
+	DeploymentID string            `json:"deploymentID"`
+	Created      time.Time         `json:"created"`
+	Files        map[string]string `json:"files"`
+}
+
+// signMetadataManifest - returns the HMAC-SHA256 of the manifest.
+func signMetadataManifest(manifest []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(globalActiveCred.SecretKey))
+	mac.Write(manifest)
+	return mac.Sum(nil)
+}
+
+// isMetadataBackupFile - returns true if the file of minioMetaBucket is
+// exported, the usage and crawler caches are not.
+func isMetadataBackupFile(name string) bool {
+	if strings.HasPrefix(name, minioConfigPrefix+SlashSeparator) {
+		return true
+	}
+	return strings.HasPrefix(name, bucketMetaPrefix+SlashSeparator) &&
+		path.Base(name) == bucketMetadataFile
+}
+
+// listMetadataBackupFiles - returns the exported files of minioMetaBucket.
+func listMetadataBackupFiles(ctx context.Context, objAPI ObjectLayer) ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, minioConfigPrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range loi.Objects {
+			names = append(names, obj.Name)
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bucket := range buckets {
+		names = append(names, path.Join(bucketMetaPrefix, bucket.Name, bucketMetadataFile))
+	}
+	return names, nil
+}
+
+// exportMetadata - writes the config, IAM and bucket metadata of the
+// cluster to a signed zip archive.
+func exportMetadata(ctx context.Context, objAPI ObjectLayer, w io.Writer) error {
+	names, err := listMetadataBackupFiles(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	manifest := metadataManifest{
+		Version:      metadataManifestVersion,
+		DeploymentID: globalDeploymentID,
+		Created:      UTCNow(),
+		Files:        make(map[string]string, len(names)),
+	}
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		data, err := readConfig(ctx, objAPI, name)
+		if err != nil {
+			if err == errConfigNotFound {
+				// Removed meanwhile.
+				continue
+			}
+			return err
+		}
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(data); err != nil {
+			return err
+		}
+		manifest.Files[name] = getSHA256Hash(data)
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	for name, data := range map[string][]byte{
+		metadataManifestFile:  manifestBytes,
+		metadataSignatureFile: []byte(hex.EncodeToString(signMetadataManifest(manifestBytes))),
+	} {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(data); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// readMetadataArchive - returns the files of a metadata archive after
+// verifying its signature and the checksums of the files.
+func readMetadataArchive(archive []byte) (map[string][]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, errInvalidMetadataArchive
+	}
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errInvalidMetadataArchive
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(rc, maxMetadataArchiveSize))
+		rc.Close()
+		if err != nil {
+			return nil, errInvalidMetadataArchive
+		}
+		files[f.Name] = data
+	}
+
+	manifestBytes, signature := files[metadataManifestFile], files[metadataSignatureFile]
+	if manifestBytes == nil || signature == nil {
+		return nil, errInvalidMetadataArchive
+	}
+	mac, err := hex.DecodeString(string(signature))
+	if err != nil || !hmac.Equal(mac, signMetadataManifest(manifestBytes)) {
+		return nil, errMetadataArchiveSignature
+	}
+	delete(files, metadataManifestFile)
+	delete(files, metadataSignatureFile)
+
+	var manifest metadataManifest
+	if err = json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, errInvalidMetadataArchive
+	}
+	if manifest.Version != metadataManifestVersion {
+		return nil, AdminError{
+			Code:       "XMinioAdminInvalidMetadataArchive",
+			Message:    fmt.Sprintf("Unsupported metadata archive version %d", manifest.Version),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if len(files) != len(manifest.Files) {
+		return nil, errInvalidMetadataArchive
+	}
+	for name, data := range files {
+		if !isMetadataBackupFile(name) || manifest.Files[name] != getSHA256Hash(data) {
+			return nil, errInvalidMetadataArchive
+		}
+	}
+	return files, nil
+}
+
+// importMetadata - saves the files of a metadata archive, the missing
+// buckets are created. The IAM and config files are loaded when the
+// servers restart.
+func importMetadata(ctx context.Context, objAPI ObjectLayer, archive []byte) (madmin.MetadataImportInfo, error) {
+	var info madmin.MetadataImportInfo
+
+	files, err := readMetadataArchive(archive)
+	if err != nil {
+		return info, err
+	}
+
+	for name, data := range files {
+		bucket := ""
+		if strings.HasPrefix(name, bucketMetaPrefix+SlashSeparator) {
+			bucket = path.Base(path.Dir(name))
+			err = objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{})
+			if err != nil {
+				if _, ok := err.(BucketExists); !ok {
+					return info, err
+				}
+			}
+		}
+		if err = saveConfig(ctx, objAPI, name, data); err != nil {
+			return info, err
+		}
+		if bucket == "" {
+			info.Files++
+			continue
+		}
+
+		meta, err := loadBucketMetadata(ctx, objAPI, bucket)
+		if err != nil {
+			logger.LogIf(ctx, err)
+		} else {
+			globalBucketMetadataSys.Set(bucket, meta)
+			globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
+		}
+		info.Buckets = append(info.Buckets, bucket)
+		info.Files++
+	}
+	return info, nil
+}
@@ -0,0 +1,73 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestMetadataBackup(t *testing.T) {
+	ExecObjectLayerTest(t, testMetadataBackup)
+}
+
+func testMetadataBackup(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	configFile := minioConfigPrefix + "/test.json"
+	if err := saveConfig(ctx, obj, configFile, []byte("config")); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	var archive bytes.Buffer
+	if err := exportMetadata(ctx, obj, &archive); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	if err := deleteConfig(ctx, obj, configFile); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	info, err := importMetadata(ctx, obj, archive.Bytes())
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(info.Buckets) != 1 || info.Buckets[0] != "bucket" {
+		t.Errorf("%s: expected the metadata of bucket to be imported, got %v", instanceType, info.Buckets)
+	}
+	data, err := readConfig(ctx, obj, configFile)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if string(data) != "config" {
+		t.Errorf("%s: expected imported config, got %s", instanceType, data)
+	}
+
+	// Archives of other credentials are rejected.
+	defer func(cred auth.Credentials) { globalActiveCred = cred }(globalActiveCred)
+	globalActiveCred.SecretKey = "other-secret-key"
+	if _, err = importMetadata(ctx, obj, archive.Bytes()); err != errMetadataArchiveSignature {
+		t.Errorf("%s: expected %v, got %v", instanceType, errMetadataArchiveSignature, err)
+	}
+	if _, err = importMetadata(ctx, obj, []byte("archive")); err != errInvalidMetadataArchive {
+		t.Errorf("%s: expected %v, got %v", instanceType, errInvalidMetadataArchive, err)
+	}
+}
@@ -92,6 +92,98 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 	cacheMetricsPrometheus(ch)
 	gatewayMetricsPrometheus(ch)
 	healingMetricsPrometheus(ch)
+	ioSchedulerMetricsPrometheus(ch)
+	cpuPoolMetricsPrometheus(ch)
+}
+
+// collects the operations of the I/O priority classes, and the time
+// the background classes waited for their turn.
+func ioSchedulerMetricsPrometheus(ch chan<- prometheus.Metric) {
+	if !globalIsErasure {
+		return
+	}
+	for class, m := range globalIOScheduler.metrics() {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("io", "operations", "total"),
+				"Total number of object operations of the I/O class",
+				[]string{"class"}, nil),
+			prometheus.CounterValue,
+			float64(m.Operations), class.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("io", "queue_wait", "seconds_total"),
+				"Total time the operations of the I/O class waited for their turn",
+				[]string{"class"}, nil),
+			prometheus.CounterValue,
+			m.Wait.Seconds(), class.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("io", "queue", "waiting"),
+				"Number of operations of the I/O class waiting for their turn",
+				[]string{"class"}, nil),
+			prometheus.GaugeValue,
+			float64(m.Waiting), class.String(),
+		)
+	}
+}
+
+// collects the jobs of the CPU pool, the time they waited for a worker
+// and the time they kept it busy
+func cpuPoolMetricsPrometheus(ch chan<- prometheus.Metric) {
+	jobs, busy := globalCPUPool.metrics()
+	for kind, m := range jobs {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("cpu", "jobs", "total"),
+				"Total number of jobs of the kind run by the CPU pool",
+				[]string{"kind"}, nil),
+			prometheus.CounterValue,
+			float64(m.Jobs), kind.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("cpu", "queue_wait", "seconds_total"),
+				"Total time the jobs of the kind waited for a worker of the CPU pool",
+				[]string{"kind"}, nil),
+			prometheus.CounterValue,
+			m.Wait.Seconds(), kind.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("cpu", "busy", "seconds_total"),
+				"Total time the jobs of the kind ran on the CPU pool",
+				[]string{"kind"}, nil),
+			prometheus.CounterValue,
+			m.Busy.Seconds(), kind.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("cpu", "queue", "waiting"),
+				"Number of jobs of the kind waiting for a worker of the CPU pool",
+				[]string{"kind"}, nil),
+			prometheus.GaugeValue,
+			float64(m.Queued), kind.String(),
+		)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("cpu", "workers", "busy"),
+			"Number of busy workers of the CPU pool",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(busy),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		prometheus.NewDesc(
+			prometheus.BuildFQName("cpu", "workers", "total"),
+			"Number of workers of the CPU pool",
+			nil, nil),
+		prometheus.GaugeValue,
+		float64(globalCPUPool.workers),
+	)
 }
 
 // collects healing specific metrics for MinIO instance in Prometheus specific format
@@ -497,6 +589,62 @@ func storageMetricsPrometheus(ch chan<- prometheus.Metric) {
 			float64(disk.TotalSpace),
 			disk.DrivePath,
 		)
+
+		// Whether the disk reached its free space reserve
+		var full float64
+		if disk.TotalSpace > 0 && globalDiskReserve.isFull(disk.TotalSpace, disk.AvailableSpace) {
+			full = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("disk", "storage", "full"),
+				"Whether the free space on the disk is below the configured reserve",
+				[]string{"disk"}, nil),
+			prometheus.GaugeValue,
+			full,
+			disk.DrivePath,
+		)
+
+		if disk.Health == nil {
+			continue
+		}
+
+		// Whether the disk is read-only as it is predicted to fail
+		var readOnly float64
+		if disk.Health.ReadOnly {
+			readOnly = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("disk", "health", "readonly"),
+				"Whether the disk is marked read-only as it is predicted to fail",
+				[]string{"disk"}, nil),
+			prometheus.GaugeValue,
+			readOnly,
+			disk.DrivePath,
+		)
+
+		// I/O errors of the disk
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("disk", "health", "io_errors_total"),
+				"Total number of I/O errors of the disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(disk.Health.IOErrors),
+			disk.DrivePath,
+		)
+
+		// Bitrot errors of the disk
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("disk", "health", "bitrot_errors_total"),
+				"Total number of corrupted data read from the disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(disk.Health.BitrotErrors),
+			disk.DrivePath,
+		)
 	}
 }
 
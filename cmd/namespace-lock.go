@@ -28,6 +28,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/dsync"
 	"github.com/minio/minio/pkg/lsync"
@@ -44,6 +45,11 @@ type RWLocker interface {
 	RUnlock()
 }
 
+// nsLockShards - number of independent shards the local namespace
+// lock map is split into, reduces contention on the map mutex when
+// many unrelated objects are being locked concurrently.
+const nsLockShards = 64
+
 // newNSLock - return a new name space lock map.
 func newNSLock(isDistErasure bool) *nsLockMap {
 	nsMutex := nsLockMap{
@@ -52,7 +58,11 @@ func newNSLock(isDistErasure bool) *nsLockMap {
 	if isDistErasure {
 		return &nsMutex
 	}
-	nsMutex.lockMap = make(map[string]*nsLock)
+	for i := range nsMutex.shards {
+		nsMutex.shards[i] = &nsLockShard{
+			lockMap: make(map[string]*nsLock),
+		}
+	}
 	return &nsMutex
 }
 
@@ -62,21 +72,33 @@ type nsLock struct {
 	*lsync.LRWMutex
 }
 
+// nsLockShard - a single shard of the namespace lock map, guarded
+// by its own mutex.
+type nsLockShard struct {
+	lockMapMutex sync.Mutex
+	lockMap      map[string]*nsLock
+}
+
 // nsLockMap - namespace lock map, provides primitives to Lock,
 // Unlock, RLock and RUnlock.
 type nsLockMap struct {
 	// Indicates if namespace is part of a distributed setup.
 	isDistErasure bool
-	lockMap       map[string]*nsLock
-	lockMapMutex  sync.Mutex
+	shards        [nsLockShards]*nsLockShard
+}
+
+// getShard - returns the shard responsible for the given resource.
+func (n *nsLockMap) getShard(resource string) *nsLockShard {
+	return n.shards[xxhash.Sum64String(resource)%nsLockShards]
 }
 
 // Lock the namespace resource.
 func (n *nsLockMap) lock(ctx context.Context, volume string, path string, lockSource, opsID string, readLock bool, timeout time.Duration) (locked bool) {
 	resource := pathJoin(volume, path)
+	shard := n.getShard(resource)
 
-	n.lockMapMutex.Lock()
-	nsLk, found := n.lockMap[resource]
+	shard.lockMapMutex.Lock()
+	nsLk, found := shard.lockMap[resource]
 	if !found {
 		nsLk = &nsLock{
 			LRWMutex: lsync.NewLRWMutex(),
@@ -84,10 +106,10 @@ func (n *nsLockMap) lock(ctx context.Context, volume string, path string, lockSo
 		// Add a count to indicate that a parallel unlock doesn't clear this entry.
 	}
 	nsLk.ref++
-	n.lockMap[resource] = nsLk
-	n.lockMapMutex.Unlock()
+	shard.lockMap[resource] = nsLk
+	shard.lockMapMutex.Unlock()
 
-	// Locking here will block (until timeout).
+	// Locking here will block (until timeout or context deadline).
 	if readLock {
 		locked = nsLk.GetRLock(ctx, opsID, lockSource, timeout)
 	} else {
@@ -96,16 +118,16 @@ func (n *nsLockMap) lock(ctx context.Context, volume string, path string, lockSo
 
 	if !locked { // We failed to get the lock
 		// Decrement ref count since we failed to get the lock
-		n.lockMapMutex.Lock()
-		n.lockMap[resource].ref--
-		if n.lockMap[resource].ref < 0 {
+		shard.lockMapMutex.Lock()
+		shard.lockMap[resource].ref--
+		if shard.lockMap[resource].ref < 0 {
 			logger.CriticalIf(GlobalContext, errors.New("resource reference count was lower than 0"))
 		}
-		if n.lockMap[resource].ref == 0 {
+		if shard.lockMap[resource].ref == 0 {
 			// Remove from the map if there are no more references.
-			delete(n.lockMap, resource)
+			delete(shard.lockMap, resource)
 		}
-		n.lockMapMutex.Unlock()
+		shard.lockMapMutex.Unlock()
 	}
 
 	return
@@ -114,24 +136,25 @@ func (n *nsLockMap) lock(ctx context.Context, volume string, path string, lockSo
 // Unlock the namespace resource.
 func (n *nsLockMap) unlock(volume string, path string, readLock bool) {
 	resource := pathJoin(volume, path)
+	shard := n.getShard(resource)
 
-	n.lockMapMutex.Lock()
-	defer n.lockMapMutex.Unlock()
-	if _, found := n.lockMap[resource]; !found {
+	shard.lockMapMutex.Lock()
+	defer shard.lockMapMutex.Unlock()
+	if _, found := shard.lockMap[resource]; !found {
 		return
 	}
 	if readLock {
-		n.lockMap[resource].RUnlock()
+		shard.lockMap[resource].RUnlock()
 	} else {
-		n.lockMap[resource].Unlock()
+		shard.lockMap[resource].Unlock()
 	}
-	n.lockMap[resource].ref--
-	if n.lockMap[resource].ref < 0 {
+	shard.lockMap[resource].ref--
+	if shard.lockMap[resource].ref < 0 {
 		logger.CriticalIf(GlobalContext, errors.New("resource reference count was lower than 0"))
 	}
-	if n.lockMap[resource].ref == 0 {
+	if shard.lockMap[resource].ref == 0 {
 		// Remove from the map if there are no more references.
-		delete(n.lockMap, resource)
+		delete(shard.lockMap, resource)
 	}
 }
 
@@ -64,7 +64,8 @@ func TestNSLockRace(t *testing.T) {
 
 		// Taking another lockMapMutex here allows queuing up additional lockers. This should
 		// not be required but makes reproduction much easier.
-		nsLk.lockMapMutex.Lock()
+		shard := nsLk.getShard(pathJoin("volume", "path"))
+		shard.lockMapMutex.Lock()
 
 		// lk3 blocks.
 		lk3ch := make(chan bool)
@@ -80,7 +81,7 @@ func TestNSLockRace(t *testing.T) {
 		runtime.Gosched()
 
 		// unlock the manual lock
-		nsLk.lockMapMutex.Unlock()
+		shard.lockMapMutex.Unlock()
 
 		// To trigger the race:
 		// 1) lk3 or lk4 need to advance and increment the ref on the existing resource,
@@ -98,3 +99,46 @@ func TestNSLockRace(t *testing.T) {
 		}
 	}
 }
+
+// Tests that locks on different resources don't block each other
+// and that a held write lock times out a competing reader.
+func TestNSLockShards(t *testing.T) {
+	ctx := context.Background()
+	nsLk := newNSLock(false)
+
+	paths := make([]string, 2*nsLockShards)
+	for i := range paths {
+		paths[i] = mustGetUUID()
+		if !nsLk.lock(ctx, "volume", paths[i], "source", "opsID", false, time.Second) {
+			t.Fatalf("failed to acquire lock on %s", paths[i])
+		}
+	}
+
+	if nsLk.lock(ctx, "volume", paths[0], "source", "opsID", true, 10*time.Millisecond) {
+		t.Fatal("read lock must not be granted while a write lock is held")
+	}
+
+	for _, path := range paths {
+		nsLk.unlock("volume", path, false)
+	}
+
+	for _, shard := range nsLk.shards {
+		if len(shard.lockMap) != 0 {
+			t.Fatalf("expected empty lock map after unlock, found %d entries", len(shard.lockMap))
+		}
+	}
+
+	// Multiple readers are allowed on the same resource.
+	for i := 0; i < 3; i++ {
+		if !nsLk.lock(ctx, "volume", "path", "source", "opsID", true, time.Second) {
+			t.Fatal("failed to acquire read lock")
+		}
+	}
+
+	// A cancelled context must abort a blocked writer.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if nsLk.lock(cctx, "volume", "path", "source", "opsID", false, time.Minute) {
+		t.Fatal("write lock must not be granted with a cancelled context")
+	}
+}
@@ -226,6 +226,13 @@ func (d *naughtyDisk) DeleteFile(volume string, path string) (err error) {
 	return d.disk.DeleteFile(volume, path)
 }
 
+func (d *naughtyDisk) SecureErase(volume, path string, fi FileInfo) (err error) {
+	if err := d.calcError(); err != nil {
+		return err
+	}
+	return d.disk.SecureErase(volume, path, fi)
+}
+
 func (d *naughtyDisk) DeleteVersions(volume string, versions []FileInfo) []error {
 	if err := d.calcError(); err != nil {
 		errs := make([]error, len(versions))
@@ -48,6 +48,7 @@ type NotificationSys struct {
 	bucketRulesMap             map[string]event.RulesMap
 	bucketRemoteTargetRulesMap map[string]map[event.TargetID]event.RulesMap
 	peerClients                []*peerRESTClient
+	bucketMetadataPending      bucketMetadataPending
 }
 
 // GetARNList - returns available ARNs.
@@ -150,6 +151,66 @@ func (sys *NotificationSys) ReloadFormat(dryRun bool) []NotificationPeerErr {
 	return ng.Wait()
 }
 
+// LoadDecommission - reloads the zones being decommissioned on all peers.
+func (sys *NotificationSys) LoadDecommission() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(GlobalContext, func() error {
+			return client.LoadDecommission()
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// LoadTenants - reloads the tenants on all peers.
+func (sys *NotificationSys) LoadTenants() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(GlobalContext, func() error {
+			return client.LoadTenants()
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// SetServerMode - sets the server mode on all peers.
+func (sys *NotificationSys) SetServerMode(mode serverMode) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(GlobalContext, func() error {
+			return client.SetServerMode(mode)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// SetFaults - replaces the faults injected by all peers.
+func (sys *NotificationSys) SetFaults(rules []madmin.FaultRule) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(GlobalContext, func() error {
+			return client.SetFaults(rules)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // DeletePolicy - deletes policy across all peers.
 func (sys *NotificationSys) DeletePolicy(policyName string) []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -541,46 +602,119 @@ func (sys *NotificationSys) GetLocks(ctx context.Context) []*PeerLocks {
 	return locksResp
 }
 
-// LoadBucketMetadata - calls LoadBucketMetadata call on all peers
-func (sys *NotificationSys) LoadBucketMetadata(ctx context.Context, bucketName string) {
-	ng := WithNPeers(len(sys.peerClients))
-	for idx, client := range sys.peerClients {
+// LookupRequest - returns the requests with the given request ID
+// served by any of the peers.
+func (sys *NotificationSys) LookupRequest(ctx context.Context, requestID string) []madmin.RequestInfo {
+	peerInfos := make([][]madmin.RequestInfo, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index, client := range sys.peerClients {
 		if client == nil {
 			continue
 		}
-		client := client
-		ng.Go(ctx, func() error {
-			return client.LoadBucketMetadata(bucketName)
-		}, idx, *client.host)
+		index := index
+		g.Go(func() error {
+			var err error
+			peerInfos[index], err = sys.peerClients[index].LookupRequest(requestID)
+			return err
+		}, index)
 	}
-	for _, nErr := range ng.Wait() {
-		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
-		if nErr.Err != nil {
-			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+
+	var infos []madmin.RequestInfo
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogIf(ctx, err)
+			continue
 		}
+		infos = append(infos, peerInfos[index]...)
 	}
+	return infos
 }
 
-// DeleteBucketMetadata - calls DeleteBucketMetadata call on all peers
-func (sys *NotificationSys) DeleteBucketMetadata(ctx context.Context, bucketName string) {
-	globalBucketMetadataSys.Remove(bucketName)
+// AccessStats - returns the downloads of the objects of the bucket
+// served by the peers, of all the buckets if empty.
+func (sys *NotificationSys) AccessStats(ctx context.Context, bucket string) []madmin.AccessReport {
+	reports := make([]madmin.AccessReport, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].AccessStats(bucket)
+			return err
+		}, index)
+	}
 
-	ng := WithNPeers(len(sys.peerClients))
-	for idx, client := range sys.peerClients {
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogIf(ctx, err)
+		}
+	}
+	return reports
+}
+
+// ScanGarbage - scans the local drives of the peers for garbage older
+// than olderThan, and removes it if requested.
+func (sys *NotificationSys) ScanGarbage(ctx context.Context, olderThan time.Duration, remove bool) []madmin.GarbageReport {
+	reports := make([]madmin.GarbageReport, len(sys.peerClients))
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index, client := range sys.peerClients {
 		if client == nil {
 			continue
 		}
-		client := client
-		ng.Go(ctx, func() error {
-			return client.DeleteBucketMetadata(bucketName)
-		}, idx, *client.host)
+		index := index
+		g.Go(func() error {
+			var err error
+			reports[index], err = sys.peerClients[index].ScanGarbage(ctx, olderThan, remove)
+			return err
+		}, index)
+	}
+
+	for index, err := range g.Wait() {
+		if err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress",
+				sys.peerClients[index].host.String())
+			ctx := logger.SetReqInfo(ctx, reqInfo)
+			logger.LogIf(ctx, err)
+		}
 	}
-	for _, nErr := range ng.Wait() {
-		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", nErr.Host.String())
-		if nErr.Err != nil {
-			logger.LogIf(logger.SetReqInfo(ctx, reqInfo), nErr.Err)
+	return reports
+}
+
+// ClearDriveReadOnly - clears the read-only mark of the drive at path
+// of the peer host.
+func (sys *NotificationSys) ClearDriveReadOnly(ctx context.Context, host, path string) error {
+	for _, client := range sys.peerClients {
+		if client != nil && client.host.String() == host {
+			return client.ClearDriveReadOnly(ctx, path)
 		}
 	}
+	return errDiskNotFound
+}
+
+// LoadBucketMetadata - calls LoadBucketMetadata call on all peers
+func (sys *NotificationSys) LoadBucketMetadata(ctx context.Context, bucketName string) {
+	sys.peerBucketMetadata(ctx, bucketName, func(client *peerRESTClient) error {
+		return client.LoadBucketMetadata(bucketName)
+	})
+}
+
+// DeleteBucketMetadata - calls DeleteBucketMetadata call on all peers
+func (sys *NotificationSys) DeleteBucketMetadata(ctx context.Context, bucketName string) {
+	globalBucketMetadataSys.Remove(bucketName)
+	sys.RemoveNotification(bucketName)
+
+	sys.peerBucketMetadata(ctx, bucketName, func(client *peerRESTClient) error {
+		return client.DeleteBucketMetadata(bucketName)
+	})
 }
 
 // AddRemoteTarget - adds event rules map, HTTP/PeerRPC client target to bucket name.
@@ -658,6 +792,10 @@ func (sys *NotificationSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error
 		}
 	}()
 
+	if len(sys.peerClients) > 0 {
+		go sys.retryBucketMetadata(GlobalContext)
+	}
+
 	return sys.load(buckets, objAPI)
 }
 
@@ -751,6 +889,12 @@ func (sys *NotificationSys) RemoveAllRemoteTargets() {
 	}
 }
 
+// Flush - waits for the events in progress to be saved to
+// their targets, or until ctx is done.
+func (sys *NotificationSys) Flush(ctx context.Context) error {
+	return sys.targetList.Flush(ctx)
+}
+
 // Send - sends event data to all matching targets.
 func (sys *NotificationSys) Send(args eventArgs) {
 	sys.RLock()
@@ -764,6 +908,24 @@ func (sys *NotificationSys) Send(args eventArgs) {
 	sys.targetList.Send(args.ToEvent(true), targetIDSet, sys.targetResCh)
 }
 
+// SendServerEvent - sends an event of the server, not bound to a bucket
+// or an object, to the targets of the rules of all the buckets matching
+// its name.
+func (sys *NotificationSys) SendServerEvent(ev event.Event) {
+	targetIDSet := event.NewTargetIDSet()
+	sys.RLock()
+	for _, rulesMap := range sys.bucketRulesMap {
+		targetIDSet = targetIDSet.Union(rulesMap.Match(ev.EventName, ""))
+	}
+	sys.RUnlock()
+
+	if len(targetIDSet) == 0 {
+		return
+	}
+
+	sys.targetList.Send(ev, targetIDSet, sys.targetResCh)
+}
+
 // NetOBDInfo - Net OBD information
 func (sys *NotificationSys) NetOBDInfo(ctx context.Context) madmin.ServerNetOBDInfo {
 	var sortedGlobalEndpoints []string
@@ -1268,6 +1430,22 @@ func (args eventArgs) ToEvent(escape bool) event.Event {
 }
 
 func sendEvent(args eventArgs) {
+	// Invoke the put hooks and replicate the objects written or deleted.
+	switch args.EventName {
+	case event.ObjectCreatedPut, event.ObjectCreatedPost, event.ObjectCreatedCopy,
+		event.ObjectCreatedCompleteMultipartUpload:
+		if globalBucketHooksSys != nil {
+			globalBucketHooksSys.Derive(args.BucketName, args.Object)
+		}
+		if globalReplicationSys != nil {
+			globalReplicationSys.ObjectCreated(args.BucketName, args.Object)
+		}
+	case event.ObjectRemovedDelete, event.ObjectRemovedDeleteMarkerCreated:
+		if globalReplicationSys != nil {
+			globalReplicationSys.ObjectDeleted(args.BucketName, args.Object)
+		}
+	}
+
 	args.Object.Size, _ = args.Object.GetActualSize()
 
 	// remove sensitive encryption entries in metadata.
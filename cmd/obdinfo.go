@@ -18,14 +18,24 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/disk"
+	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/sync/errgroup"
 	cpuhw "github.com/shirou/gopsutil/cpu"
 	memhw "github.com/shirou/gopsutil/mem"
 	"github.com/shirou/gopsutil/process"
@@ -368,3 +378,147 @@ func getLocalProcOBD(ctx context.Context, r *http.Request) madmin.ServerProcOBDI
 		Processes: sysProcs,
 	}
 }
+
+// Object perf parameters, the object perf runs concurrent PUTs of
+// objectOBDSize bytes objects for objectOBDDuration and then reads
+// the uploaded objects back for objectOBDDuration.
+var (
+	objectOBDSize        int64 = 16 * humanize.MiByte
+	objectOBDConcurrency       = 32
+	objectOBDDuration          = 10 * time.Second
+)
+
+// objectOBDPrefix is where the object perf uploads its objects
+// in the MinIO meta bucket.
+const objectOBDPrefix = "obd/perf"
+
+// runObjectOBD - runs op on concurrent workers, op returns the latency
+// in seconds of every operation of the worker.
+func runObjectOBD(concurrency int, size int64, op func(worker int) ([]float64, error)) madmin.ObjectOBDInfo {
+	workerLatencies := make([][]float64, concurrency)
+	g := errgroup.WithNErrs(concurrency)
+	start := time.Now()
+	for worker := 0; worker < concurrency; worker++ {
+		worker := worker
+		g.Go(func() (err error) {
+			workerLatencies[worker], err = op(worker)
+			return err
+		}, worker)
+	}
+	errs := g.Wait()
+	elapsed := time.Since(start)
+
+	var objectOBDInfo madmin.ObjectOBDInfo
+	for _, err := range errs {
+		if err != nil {
+			objectOBDInfo.Error = err.Error()
+			return objectOBDInfo
+		}
+	}
+
+	var latencies, throughputs []float64
+	for _, wl := range workerLatencies {
+		for _, latency := range wl {
+			latencies = append(latencies, latency)
+			throughputs = append(throughputs, float64(size)/latency)
+		}
+	}
+	if len(latencies) == 0 {
+		return objectOBDInfo
+	}
+
+	objectOBDInfo.Count = len(latencies)
+	objectOBDInfo.Bytes = int64(len(latencies)) * size
+	objectOBDInfo.BytesPerSec = float64(objectOBDInfo.Bytes) / elapsed.Seconds()
+
+	latency, throughput, err := net.ComputeOBDStats(latencies, throughputs)
+	if err != nil {
+		objectOBDInfo.Error = err.Error()
+		return objectOBDInfo
+	}
+	objectOBDInfo.Latency = latency
+	objectOBDInfo.Throughput = throughput
+	return objectOBDInfo
+}
+
+// getObjectOBD - measures end-to-end object PUT and GET performance of
+// the cluster through the object layer, the uploaded objects are removed
+// once done.
+func getObjectOBD(ctx context.Context, objAPI ObjectLayer, r *http.Request) madmin.ServerObjectOBDInfo {
+	addr := r.Host
+	if globalIsDistErasure {
+		addr = GetLocalPeer(globalEndpoints)
+	}
+	objectOBDInfo := madmin.ServerObjectOBDInfo{
+		Addr:        addr,
+		ObjectSize:  objectOBDSize,
+		Concurrency: objectOBDConcurrency,
+	}
+
+	data := make([]byte, objectOBDSize)
+	if _, err := rand.Read(data); err != nil {
+		objectOBDInfo.Error = err.Error()
+		return objectOBDInfo
+	}
+
+	prefix := pathJoin(objectOBDPrefix, mustGetUUID())
+	objects := make([][]string, objectOBDConcurrency)
+	defer func() {
+		for _, workerObjects := range objects {
+			for _, object := range workerObjects {
+				_, err := objAPI.DeleteObject(GlobalContext, minioMetaBucket, object, ObjectOptions{})
+				logger.LogIf(GlobalContext, err)
+			}
+		}
+	}()
+
+	put := func(worker int) ([]float64, error) {
+		var latencies []float64
+		deadline := time.Now().Add(objectOBDDuration)
+		for i := 0; time.Now().Before(deadline); i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			object := pathJoin(prefix, strconv.Itoa(worker), strconv.Itoa(i))
+			hashReader, err := hash.NewReader(bytes.NewReader(data), objectOBDSize, "", "", objectOBDSize, globalCLIContext.StrictS3Compat)
+			if err != nil {
+				return nil, err
+			}
+			start := time.Now()
+			if _, err = objAPI.PutObject(ctx, minioMetaBucket, object, NewPutObjReader(hashReader, nil, nil), ObjectOptions{}); err != nil {
+				return nil, err
+			}
+			latencies = append(latencies, time.Since(start).Seconds())
+			objects[worker] = append(objects[worker], object)
+		}
+		return latencies, nil
+	}
+
+	get := func(worker int) ([]float64, error) {
+		var latencies []float64
+		deadline := time.Now().Add(objectOBDDuration)
+		for i := 0; time.Now().Before(deadline) && len(objects[worker]) > 0; i++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			object := objects[worker][i%len(objects[worker])]
+			start := time.Now()
+			if err := objAPI.GetObject(ctx, minioMetaBucket, object, 0, objectOBDSize, ioutil.Discard, "", ObjectOptions{}); err != nil {
+				return nil, err
+			}
+			latencies = append(latencies, time.Since(start).Seconds())
+		}
+		return latencies, nil
+	}
+
+	objectOBDInfo.Put = runObjectOBD(objectOBDConcurrency, objectOBDSize, put)
+	if objectOBDInfo.Put.Error != "" {
+		objectOBDInfo.Error = "put: " + objectOBDInfo.Put.Error
+		return objectOBDInfo
+	}
+	objectOBDInfo.Get = runObjectOBD(objectOBDConcurrency, objectOBDSize, get)
+	if objectOBDInfo.Get.Error != "" {
+		objectOBDInfo.Error = "get: " + objectOBDInfo.Get.Error
+	}
+	return objectOBDInfo
+}
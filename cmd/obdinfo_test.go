@@ -0,0 +1,63 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+func TestGetObjectOBD(t *testing.T) {
+	defer func(size int64, concurrency int, duration time.Duration) {
+		objectOBDSize, objectOBDConcurrency, objectOBDDuration = size, concurrency, duration
+	}(objectOBDSize, objectOBDConcurrency, objectOBDDuration)
+	objectOBDSize, objectOBDConcurrency, objectOBDDuration = humanize.KiByte, 2, 100*time.Millisecond
+
+	ExecObjectLayerTest(t, testGetObjectOBD)
+}
+
+func testGetObjectOBD(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	info := getObjectOBD(context.Background(), obj, r)
+	if info.Error != "" {
+		t.Fatalf("%s: unexpected error: %s", instanceType, info.Error)
+	}
+	if info.Put.Count == 0 || info.Get.Count == 0 {
+		t.Fatalf("%s: expected PUT and GET operations, got %d and %d", instanceType, info.Put.Count, info.Get.Count)
+	}
+	if info.Put.Bytes != int64(info.Put.Count)*objectOBDSize {
+		t.Errorf("%s: expected %d bytes uploaded, got %d", instanceType, int64(info.Put.Count)*objectOBDSize, info.Put.Bytes)
+	}
+	if info.Get.Latency.Max < info.Get.Latency.Min {
+		t.Errorf("%s: unexpected GET latency %v", instanceType, info.Get.Latency)
+	}
+
+	// The uploaded objects must be removed.
+	result, err := obj.ListObjects(context.Background(), minioMetaBucket, objectOBDPrefix+SlashSeparator, "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(result.Objects) != 0 {
+		t.Errorf("%s: expected perf objects to be removed, found %d", instanceType, len(result.Objects))
+	}
+}
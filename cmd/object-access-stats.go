@@ -0,0 +1,348 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// accessStatsCapacity is the number of objects, and of prefixes,
+	// whose downloads are counted by every node.
+	accessStatsCapacity = 1000
+
+	// accessStatsSaveInterval is the interval between the saves of
+	// the access statistics of a node.
+	accessStatsSaveInterval = 5 * time.Minute
+
+	// accessStatsDecayInterval is the interval after which the counts
+	// are halved, so that the reports favor the recent downloads.
+	accessStatsDecayInterval = 24 * time.Hour
+
+	accessStatsPrefix = minioConfigPrefix + "/access-stats"
+
+	// defaultTopObjectsCount is the number of objects and prefixes
+	// of the reports without a count.
+	defaultTopObjectsCount = 10
+)
+
+// accessEntry - the downloads of an object or prefix, its key is
+// the bucket and the name separated by a slash.
+type accessEntry struct {
+	key   string
+	reads uint64
+	bytes uint64
+	index int
+}
+
+// accessHeap - a min-heap of entries ordered by reads.
+type accessHeap []*accessEntry
+
+func (h accessHeap) Len() int           { return len(h) }
+func (h accessHeap) Less(i, j int) bool { return h[i].reads < h[j].reads }
+func (h accessHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *accessHeap) Push(x interface{}) {
+	e := x.(*accessEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *accessHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return e
+}
+
+// accessCounter - counts the downloads of the most downloaded keys in
+// a bounded memory, with the space-saving algorithm: once full, a new
+// key replaces the least downloaded one and inherits its counts. The
+// counts of the most downloaded keys are exact or close to.
+type accessCounter struct {
+	capacity int
+	entries  map[string]*accessEntry
+	heap     accessHeap
+}
+
+func newAccessCounter(capacity int) *accessCounter {
+	return &accessCounter{
+		capacity: capacity,
+		entries:  make(map[string]*accessEntry, capacity),
+	}
+}
+
+// add - counts reads and bytes to the key.
+func (c *accessCounter) add(key string, reads, bytes uint64) {
+	if e, ok := c.entries[key]; ok {
+		e.reads += reads
+		e.bytes += bytes
+		heap.Fix(&c.heap, e.index)
+		return
+	}
+	if len(c.heap) < c.capacity {
+		e := &accessEntry{key: key, reads: reads, bytes: bytes}
+		c.entries[key] = e
+		heap.Push(&c.heap, e)
+		return
+	}
+	e := c.heap[0]
+	delete(c.entries, e.key)
+	e.key = key
+	e.reads += reads
+	e.bytes += bytes
+	c.entries[key] = e
+	heap.Fix(&c.heap, 0)
+}
+
+// decay - halves the counts, the keys without reads left are removed.
+func (c *accessCounter) decay() {
+	entries := c.heap[:0]
+	for _, e := range c.heap {
+		e.reads /= 2
+		e.bytes /= 2
+		if e.reads == 0 {
+			delete(c.entries, e.key)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	for i := len(entries); i < len(c.heap); i++ {
+		c.heap[i] = nil
+	}
+	c.heap = entries
+	for i, e := range c.heap {
+		e.index = i
+	}
+	heap.Init(&c.heap)
+}
+
+// stats - returns the counts of the keys of the bucket, of all the
+// buckets if empty.
+func (c *accessCounter) stats(bucket string) []madmin.AccessStat {
+	stats := make([]madmin.AccessStat, 0, len(c.heap))
+	for _, e := range c.heap {
+		i := strings.Index(e.key, SlashSeparator)
+		if bucket != "" && e.key[:i] != bucket {
+			continue
+		}
+		stats = append(stats, madmin.AccessStat{
+			Bucket: e.key[:i],
+			Name:   e.key[i+1:],
+			Reads:  e.reads,
+			Bytes:  e.bytes,
+		})
+	}
+	return stats
+}
+
+// accessStatsSnapshot - the saved access statistics of a node.
+type accessStatsSnapshot struct {
+	Objects   []madmin.AccessStat `json:"objects"`
+	Prefixes  []madmin.AccessStat `json:"prefixes"`
+	LastDecay time.Time           `json:"lastDecay"`
+}
+
+// accessStats - the downloads of the objects served by this node, and
+// of the prefixes they are directly under.
+type accessStats struct {
+	mu        sync.Mutex
+	objects   *accessCounter
+	prefixes  *accessCounter
+	lastDecay time.Time
+	loaded    bool
+}
+
+func newAccessStats(capacity int) *accessStats {
+	return &accessStats{
+		objects:   newAccessCounter(capacity),
+		prefixes:  newAccessCounter(capacity),
+		lastDecay: UTCNow(),
+	}
+}
+
+var globalAccessStats = newAccessStats(accessStatsCapacity)
+
+// objectPrefix - returns the prefix the object is directly under.
+func objectPrefix(object string) string {
+	if dir := path.Dir(object); dir != "." {
+		return dir + SlashSeparator
+	}
+	return ""
+}
+
+// record - counts a download of bytes of the object.
+func (s *accessStats) record(bucket, object string, bytes int64) {
+	if bytes < 0 {
+		bytes = 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects.add(bucket+SlashSeparator+object, 1, uint64(bytes))
+	s.prefixes.add(bucket+SlashSeparator+objectPrefix(object), 1, uint64(bytes))
+}
+
+// report - returns the counts of the objects and prefixes of the
+// bucket, of all the buckets if empty.
+func (s *accessStats) report(bucket string) madmin.AccessReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return madmin.AccessReport{
+		Objects:  s.objects.stats(bucket),
+		Prefixes: s.prefixes.stats(bucket),
+	}
+}
+
+// snapshot - returns the counts to be saved.
+func (s *accessStats) snapshot() accessStatsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return accessStatsSnapshot{
+		Objects:   s.objects.stats(""),
+		Prefixes:  s.prefixes.stats(""),
+		LastDecay: s.lastDecay,
+	}
+}
+
+// merge - adds the counts of a saved snapshot to the counts.
+func (s *accessStats) merge(snapshot accessStatsSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stat := range snapshot.Objects {
+		s.objects.add(stat.Bucket+SlashSeparator+stat.Name, stat.Reads, stat.Bytes)
+	}
+	for _, stat := range snapshot.Prefixes {
+		s.prefixes.add(stat.Bucket+SlashSeparator+stat.Name, stat.Reads, stat.Bytes)
+	}
+	if !snapshot.LastDecay.IsZero() {
+		s.lastDecay = snapshot.LastDecay
+	}
+}
+
+// decay - halves the counts once per accessStatsDecayInterval.
+func (s *accessStats) decay(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastDecay) < accessStatsDecayInterval {
+		return
+	}
+	s.objects.decay()
+	s.prefixes.decay()
+	s.lastDecay = now
+}
+
+func accessStatsFile() string {
+	return path.Join(accessStatsPrefix, GetLocalPeer(globalEndpoints)+".json")
+}
+
+// load - adds the counts saved by this node to the counts, once.
+func (s *accessStats) load(ctx context.Context, objAPI ObjectLayer) error {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.mu.Unlock()
+	if loaded {
+		return nil
+	}
+
+	data, err := readConfig(ctx, objAPI, accessStatsFile())
+	if err != nil && err != errConfigNotFound {
+		return err
+	}
+	if err == nil {
+		var snapshot accessStatsSnapshot
+		if err = json.Unmarshal(data, &snapshot); err != nil {
+			return err
+		}
+		s.merge(snapshot)
+	}
+
+	s.mu.Lock()
+	s.loaded = true
+	s.mu.Unlock()
+	return nil
+}
+
+// save - saves the counts of this node, which are loaded at startup.
+func (s *accessStats) save(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := json.Marshal(s.snapshot())
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, accessStatsFile(), data)
+}
+
+// run - saves the counts periodically, the saved counts are loaded
+// first so that they survive restarts.
+func (s *accessStats) run(ctx context.Context, objAPI ObjectLayer) {
+	ticker := time.NewTicker(accessStatsSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.load(ctx, objAPI); err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			s.decay(UTCNow())
+			logger.LogIf(ctx, s.save(ctx, objAPI))
+		}
+	}
+}
+
+// mergeAccessStats - sums the counts of the same objects or prefixes
+// reported by the nodes, and returns the count most downloaded.
+func mergeAccessStats(stats []madmin.AccessStat, count int) []madmin.AccessStat {
+	index := make(map[madmin.AccessStat]int, len(stats))
+	merged := make([]madmin.AccessStat, 0, len(stats))
+	for _, stat := range stats {
+		key := madmin.AccessStat{Bucket: stat.Bucket, Name: stat.Name}
+		if i, ok := index[key]; ok {
+			merged[i].Reads += stat.Reads
+			merged[i].Bytes += stat.Bytes
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, stat)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Reads != merged[j].Reads {
+			return merged[i].Reads > merged[j].Reads
+		}
+		return merged[i].Bytes > merged[j].Bytes
+	})
+	if len(merged) > count {
+		merged = merged[:count]
+	}
+	return merged
+}
@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestAccessCounter(t *testing.T) {
+	c := newAccessCounter(3)
+	for i := 0; i < 10; i++ {
+		c.add("bucket/hot", 1, 100)
+	}
+	for i := 0; i < 5; i++ {
+		c.add("bucket/warm", 1, 10)
+	}
+	c.add("bucket/cold1", 1, 1)
+	// A new key replaces the least downloaded one and inherits its counts.
+	c.add("other/cold2", 1, 1)
+
+	if len(c.entries) != 3 || len(c.heap) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(c.entries))
+	}
+	if _, ok := c.entries["bucket/cold1"]; ok {
+		t.Fatal("expected the least downloaded key to be replaced")
+	}
+	if e := c.entries["other/cold2"]; e == nil || e.reads != 2 || e.bytes != 2 {
+		t.Fatalf("unexpected counts of the new key %v", e)
+	}
+
+	stats := mergeAccessStats(c.stats("bucket"), 10)
+	expected := []madmin.AccessStat{
+		{Bucket: "bucket", Name: "hot", Reads: 10, Bytes: 1000},
+		{Bucket: "bucket", Name: "warm", Reads: 5, Bytes: 50},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("expected %v, got %v", expected, stats)
+	}
+
+	c.decay()
+	if e := c.entries["bucket/hot"]; e.reads != 5 || e.bytes != 500 {
+		t.Fatalf("expected the counts to be halved, got %v", e)
+	}
+	c.decay()
+	c.decay()
+	if len(c.entries) != 1 || len(c.heap) != 1 || c.heap[0].key != "bucket/hot" {
+		t.Fatalf("expected the keys without reads to be removed, got %v", c.entries)
+	}
+}
+
+func TestAccessStatsRecord(t *testing.T) {
+	s := newAccessStats(10)
+	s.record("bucket", "dir/a", 10)
+	s.record("bucket", "dir/b", 20)
+	s.record("bucket", "c", 30)
+	s.record("other", "dir/a", 40)
+
+	report := s.report("bucket")
+	prefixes := mergeAccessStats(report.Prefixes, 10)
+	expected := []madmin.AccessStat{
+		{Bucket: "bucket", Name: "dir/", Reads: 2, Bytes: 30},
+		{Bucket: "bucket", Name: "", Reads: 1, Bytes: 30},
+	}
+	if !reflect.DeepEqual(prefixes, expected) {
+		t.Fatalf("expected %v, got %v", expected, prefixes)
+	}
+	if len(report.Objects) != 3 {
+		t.Fatalf("expected the 3 objects of the bucket, got %v", report.Objects)
+	}
+
+	// The counts of the nodes are summed.
+	merged := mergeAccessStats(append(report.Objects, s.report("bucket").Objects...), 1)
+	if len(merged) != 1 || merged[0].Reads != 2 {
+		t.Fatalf("unexpected merged counts %v", merged)
+	}
+
+	// Counts are halved once per interval.
+	now := s.lastDecay.Add(accessStatsDecayInterval / 2)
+	s.decay(now)
+	if s.report("other").Objects[0].Reads != 1 {
+		t.Fatal("expected no decay before the interval")
+	}
+	s.decay(now.Add(accessStatsDecayInterval))
+	if len(s.report("other").Objects) != 0 {
+		t.Fatal("expected the counts to be halved")
+	}
+}
+
+func TestAccessStatsSaveLoad(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newAccessStats(10)
+	for i := 0; i < 3; i++ {
+		s.record("bucket", fmt.Sprintf("object%d", i), 100)
+	}
+	s.lastDecay = time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	if err = s.save(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	// The saved counts are added to the counts since startup.
+	loaded := newAccessStats(10)
+	loaded.record("bucket", "object0", 100)
+	if err = loaded.load(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if err = loaded.load(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	stats := mergeAccessStats(loaded.report("").Objects, 1)
+	if len(stats) != 1 || stats[0].Name != "object0" || stats[0].Reads != 2 || stats[0].Bytes != 200 {
+		t.Fatalf("unexpected counts %v", stats)
+	}
+	if !loaded.lastDecay.Equal(s.lastDecay) {
+		t.Fatalf("expected the last decay %s, got %s", s.lastDecay, loaded.lastDecay)
+	}
+}
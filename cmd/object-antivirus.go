@@ -0,0 +1,271 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/minio/minio/cmd/config/antivirus"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+)
+
+var errObjectScanFailed = errors.New("unable to scan the uploaded content")
+
+// errObjectClean aborts the quarantine copy of clean uploads.
+var errObjectClean = errors.New("uploaded content is clean")
+
+// scanReader - streams the data read to the antivirus service, and
+// fails the last read if the service reports the data as
+// infected so that the object layer never commits it. With the
+// quarantine action the data is also copied to the quarantine bucket
+// as it is read, the copy is only committed for infected data.
+type scanReader struct {
+	ctx            context.Context
+	bucket, object string
+	r              io.Reader
+	size, read     int64
+	timeout        time.Duration
+	cancel         context.CancelFunc
+
+	pw       *io.PipeWriter
+	resultCh chan scanResult
+
+	qw     *io.PipeWriter
+	qErrCh chan error
+
+	eof  bool
+	err  error
+	done bool
+}
+
+type scanResult struct {
+	infected bool
+	virus    string
+	err      error
+}
+
+// newScanReader - returns r of size bytes, -1 if unknown, scanned by
+// the antivirus service if it is enabled, quarantined is the name of the copy of infected data
+// in the quarantine bucket. The returned reader must be closed.
+func newScanReader(ctx context.Context, objAPI ObjectLayer, r io.Reader, size int64, bucket, object, quarantined string) (io.ReadCloser, error) {
+	cfg := globalAntivirusConfig
+	if !cfg.Enabled {
+		return ioutil.NopCloser(r), nil
+	}
+	scanner, err := cfg.NewScanner()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil, errObjectScanFailed
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+	s := &scanReader{
+		ctx:      ctx,
+		cancel:   cancel,
+		bucket:   bucket,
+		object:   object,
+		r:        r,
+		size:     size,
+		timeout:  cfg.Timeout,
+		pw:       pw,
+		resultCh: make(chan scanResult, 1),
+	}
+	go func() {
+		result, err := scanner.Scan(ctx, pr)
+		// Unblock writes of the data not read by the service.
+		pr.CloseWithError(errObjectScanFailed)
+		s.resultCh <- scanResult{infected: result.Infected, virus: result.Virus, err: err}
+	}()
+
+	if cfg.Action == antivirus.Quarantine {
+		qr, qw := io.Pipe()
+		s.qw = qw
+		s.qErrCh = make(chan error, 1)
+		go func() {
+			hashReader, err := hash.NewReader(qr, -1, "", "", -1, globalCLIContext.StrictS3Compat)
+			if err == nil {
+				_, err = objAPI.PutObject(ctx, cfg.QuarantineBucket, quarantined,
+					NewPutObjReader(hashReader, nil, nil), ObjectOptions{})
+			}
+			qr.CloseWithError(err)
+			s.qErrCh <- err
+		}()
+	}
+	return s, nil
+}
+
+// quarantineObjectName - returns the name of the copy of infected
+// uploads in the quarantine bucket.
+func quarantineObjectName(bucket, object string) string {
+	return path.Join(bucket, object, UTCNow().Format(time.RFC3339Nano))
+}
+
+// scanObject - scans an object assembled from parts, if the antivirus
+// service is enabled, since the content spanning several parts is not
+// seen by the scans of the parts. The object is removed if it is
+// infected or can not be scanned. Objects encrypted with a key not
+// given in h are left to the scans of their parts.
+func scanObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, objInfo ObjectInfo, h http.Header) error {
+	if !globalAntivirusConfig.Enabled {
+		return nil
+	}
+	if crypto.SSEC.IsEncrypted(objInfo.UserDefined) && !crypto.SSEC.IsRequested(h) {
+		return nil
+	}
+
+	opts := ObjectOptions{VersionID: objInfo.VersionID}
+	err := func() error {
+		gr, err := objAPI.GetObjectNInfo(ctx, bucket, object, nil, h, readLock, opts)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		scanned, err := newScanReader(ctx, objAPI, gr, -1, bucket, object, quarantineObjectName(bucket, object))
+		if err != nil {
+			return err
+		}
+		defer scanned.Close()
+		_, err = io.Copy(ioutil.Discard, scanned)
+		return err
+	}()
+	if err == nil {
+		return nil
+	}
+
+	// The read lock of the object is released, remove it.
+	opts.Versioned = globalBucketVersioningSys.Enabled(bucket)
+	if _, derr := objAPI.DeleteObject(ctx, bucket, object, opts); derr != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to remove %s/%s not scanned: %w", bucket, object, derr))
+	}
+	return err
+}
+
+func (s *scanReader) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	if s.eof {
+		return 0, io.EOF
+	}
+
+	n, err := s.r.Read(p)
+	if n > 0 {
+		s.read += int64(n)
+		if _, werr := s.pw.Write(p[:n]); werr != nil {
+			// The service replied before the end of the data.
+			s.err = s.verdict()
+			if s.err == nil {
+				s.err = errObjectScanFailed
+			}
+			return 0, s.err
+		}
+		if s.qw != nil {
+			if _, werr := s.qw.Write(p[:n]); werr != nil {
+				s.abort(werr)
+				return 0, s.err
+			}
+		}
+	}
+	if err != nil && err != io.EOF {
+		s.abort(err)
+		return n, err
+	}
+
+	// Readers of the object layer stop at the size of the data
+	// without reading io.EOF, the verdict is awaited along the last
+	// bytes which are dropped if the data is infected.
+	if err == io.EOF || (s.size >= 0 && s.read >= s.size) {
+		s.eof = true
+		if s.err = s.verdict(); s.err != nil {
+			return 0, s.err
+		}
+		if n == 0 {
+			return 0, io.EOF
+		}
+	}
+	return n, nil
+}
+
+// verdict - waits for the verdict of the service on all
+// the data read, returns ObjectInfected if it is infected.
+func (s *scanReader) verdict() error {
+	s.done = true
+	s.pw.Close()
+	defer s.cancel()
+
+	var result scanResult
+	select {
+	case result = <-s.resultCh:
+	case <-time.After(s.timeout):
+		result.err = fmt.Errorf("antivirus: no verdict after %s", s.timeout)
+	}
+
+	switch {
+	case result.err != nil:
+		logger.LogIf(s.ctx, fmt.Errorf("Unable to scan %s/%s: %w", s.bucket, s.object, result.err))
+		s.closeQuarantine(errObjectScanFailed)
+		return errObjectScanFailed
+	case result.infected:
+		if s.qw != nil {
+			s.qw.Close()
+			if err := <-s.qErrCh; err != nil {
+				logger.LogIf(s.ctx, fmt.Errorf("Unable to quarantine %s/%s: %w", s.bucket, s.object, err))
+			}
+		}
+		return ObjectInfected{Bucket: s.bucket, Object: s.object, Virus: result.virus}
+	}
+	s.closeQuarantine(errObjectClean)
+	return nil
+}
+
+// abort - stops the scan of data not read until io.EOF.
+func (s *scanReader) abort(err error) {
+	s.err = err
+	if s.done {
+		return
+	}
+	s.done = true
+	s.pw.CloseWithError(err)
+	s.cancel()
+	s.closeQuarantine(err)
+}
+
+func (s *scanReader) closeQuarantine(err error) {
+	if s.qw != nil {
+		s.qw.CloseWithError(err)
+		<-s.qErrCh
+		s.qw = nil
+	}
+}
+
+// Close - aborts the scan if the data was not read until io.EOF.
+func (s *scanReader) Close() error {
+	if !s.done {
+		s.abort(errObjectScanFailed)
+	}
+	return nil
+}
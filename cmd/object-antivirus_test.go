@@ -0,0 +1,254 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config/antivirus"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// startFakeClamd - starts a clamd reporting the data containing
+// "EICAR" as infected, returns its address and a function stopping it.
+func startFakeClamd(t *testing.T) (string, func()) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				br := bufio.NewReader(conn)
+				if _, err := br.ReadString(0); err != nil {
+					return
+				}
+				var data bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&data, br, int64(size)); err != nil {
+						return
+					}
+				}
+				if bytes.Contains(data.Bytes(), []byte("EICAR")) {
+					io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+					return
+				}
+				io.WriteString(conn, "stream: OK\x00")
+			}()
+		}
+	}()
+	return l.Addr().String(), func() { l.Close() }
+}
+
+func TestAPIPutObjectAntivirus(t *testing.T) {
+	address, stop := startFakeClamd(t)
+	defer stop()
+	defer func() {
+		globalAntivirusConfig = antivirus.Config{}
+	}()
+
+	for _, action := range []antivirus.Action{antivirus.Reject, antivirus.Quarantine} {
+		ExecObjectLayerAPITest(t, func(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+			credentials auth.Credentials, t *testing.T) {
+			globalAntivirusConfig = antivirus.Config{
+				Enabled:          true,
+				Protocol:         antivirus.Clamd,
+				Endpoint:         address,
+				Action:           action,
+				QuarantineBucket: "quarantine",
+				Timeout:          10 * time.Second,
+			}
+			testAPIPutObjectAntivirus(obj, instanceType, bucketName, apiRouter, credentials, action, t)
+		}, []string{"PutObject"})
+	}
+}
+
+func testAPIPutObjectAntivirus(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, action antivirus.Action, t *testing.T) {
+	ctx := context.Background()
+	if action == antivirus.Quarantine {
+		if err := obj.MakeBucketWithLocation(ctx, "quarantine", BucketOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	testCases := []struct {
+		objectName     string
+		data           []byte
+		expectedStatus int
+	}{
+		{"clean", bytes.Repeat([]byte("a"), 1<<20), http.StatusOK},
+		{"infected", append(bytes.Repeat([]byte("a"), 1<<20), []byte("EICAR")...), http.StatusForbidden},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("Test %d: %s: expected status %d, got %d: %s", i+1, instanceType, testCase.expectedStatus, rec.Code, rec.Body.String())
+		}
+
+		_, err = obj.GetObjectInfo(ctx, bucketName, testCase.objectName, ObjectOptions{})
+		if testCase.expectedStatus == http.StatusOK && err != nil {
+			t.Fatalf("Test %d: %s: expected the clean object to be stored: %v", i+1, instanceType, err)
+		}
+		if testCase.expectedStatus != http.StatusOK && err == nil {
+			t.Fatalf("Test %d: %s: expected the infected object not to be stored", i+1, instanceType)
+		}
+	}
+
+	if action != antivirus.Quarantine {
+		return
+	}
+	result, err := obj.ListObjects(ctx, "quarantine", "", "", "", 1000)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 || !HasPrefix(result.Objects[0].Name, pathJoin(bucketName, "infected")+SlashSeparator) {
+		t.Fatalf("%s: expected the infected object to be quarantined, got %#v", instanceType, result.Objects)
+	}
+	if result.Objects[0].Size != int64(len(testCases[1].data)) {
+		t.Fatalf("%s: unexpected size of the quarantined object %d", instanceType, result.Objects[0].Size)
+	}
+}
+
+// Tests that the objects uploaded through the front-ends other
+// than S3 are scanned too.
+func TestPutObjectContentAntivirus(t *testing.T) {
+	address, stop := startFakeClamd(t)
+	defer stop()
+	defer func() {
+		globalAntivirusConfig = antivirus.Config{}
+	}()
+
+	ExecObjectLayerTest(t, func(obj ObjectLayer, instanceType string, t TestErrHandler) {
+		globalAntivirusConfig = antivirus.Config{
+			Enabled:  true,
+			Protocol: antivirus.Clamd,
+			Endpoint: address,
+			Action:   antivirus.Reject,
+			Timeout:  10 * time.Second,
+		}
+		ctx := context.Background()
+		if err := obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+
+		clean := bytes.Repeat([]byte("a"), 1<<20)
+		metadata := map[string]string{"content-type": "text/plain"}
+		objInfo, err := putObjectContent(ctx, obj, nil, "bucket", "clean", nil, bytes.NewReader(clean), -1, "", metadata)
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+		if objInfo.ContentType != "text/plain" {
+			t.Fatalf("%s: expected the content-type to be kept, got %s", instanceType, objInfo.ContentType)
+		}
+
+		infected := append(bytes.Repeat([]byte("a"), 1<<20), []byte("EICAR")...)
+		if _, err = putObjectContent(ctx, obj, nil, "bucket", "infected", nil, bytes.NewReader(infected), -1, "", map[string]string{}); err == nil {
+			t.Fatalf("%s: expected the infected object to be rejected", instanceType)
+		}
+		if _, err = obj.GetObjectInfo(ctx, "bucket", "infected", ObjectOptions{}); err == nil {
+			t.Fatalf("%s: expected the infected object not to be stored", instanceType)
+		}
+	})
+}
+
+// Tests that the objects assembled from parts are scanned whole,
+// the signature spanning two parts is not seen by the scans of the
+// parts.
+func TestScanObjectMultipart(t *testing.T) {
+	address, stop := startFakeClamd(t)
+	defer stop()
+	defer func() {
+		globalAntivirusConfig = antivirus.Config{}
+	}()
+
+	ExecObjectLayerTest(t, func(obj ObjectLayer, instanceType string, t TestErrHandler) {
+		globalAntivirusConfig = antivirus.Config{
+			Enabled:  true,
+			Protocol: antivirus.Clamd,
+			Endpoint: address,
+			Action:   antivirus.Reject,
+			Timeout:  10 * time.Second,
+		}
+		ctx := context.Background()
+		if err := obj.MakeBucketWithLocation(ctx, "bucket", BucketOptions{}); err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+
+		for i, testCase := range []struct {
+			object   string
+			parts    [][]byte
+			infected bool
+		}{
+			{"clean", [][]byte{bytes.Repeat([]byte("a"), 5*humanize.MiByte), []byte("b")}, false},
+			{"infected", [][]byte{append(bytes.Repeat([]byte("a"), 5*humanize.MiByte), []byte("EIC")...), []byte("AR")}, true},
+		} {
+			uploadID, err := obj.NewMultipartUpload(ctx, "bucket", testCase.object, ObjectOptions{})
+			if err != nil {
+				t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+			}
+			var parts []CompletePart
+			for j, data := range testCase.parts {
+				pi, err := obj.PutObjectPart(ctx, "bucket", testCase.object, uploadID, j+1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+				if err != nil {
+					t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+				}
+				parts = append(parts, CompletePart{PartNumber: j + 1, ETag: pi.ETag})
+			}
+			objInfo, err := obj.CompleteMultipartUpload(ctx, "bucket", testCase.object, uploadID, parts, ObjectOptions{})
+			if err != nil {
+				t.Fatalf("Test %d: %s: %v", i+1, instanceType, err)
+			}
+
+			err = scanObject(ctx, obj, "bucket", testCase.object, objInfo, http.Header{})
+			if _, infected := err.(ObjectInfected); infected != testCase.infected {
+				t.Fatalf("Test %d: %s: expected infected %v, got %v", i+1, instanceType, testCase.infected, err)
+			}
+			if _, err = obj.GetObjectInfo(ctx, "bucket", testCase.object, ObjectOptions{}); (err == nil) == testCase.infected {
+				t.Fatalf("Test %d: %s: expected the object to be kept only if clean, got %v", i+1, instanceType, err)
+			}
+		}
+	})
+}
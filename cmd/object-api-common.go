@@ -56,6 +56,29 @@ func isObjectDir(object string, size int64) bool {
 	return HasSuffix(object, SlashSeparator) && size == 0
 }
 
+// dirObjectMarker is the name of the entry holding the metadata of
+// a directory object inside the directory, so that the directory
+// object outlives the objects created under its prefix.
+const dirObjectMarker = "__XLDIR__"
+
+// encodeDirObject - returns the name of the entry holding the metadata
+// of the directory object, other object names are returned as is.
+func encodeDirObject(object string) string {
+	if HasSuffix(object, SlashSeparator) {
+		return object + dirObjectMarker
+	}
+	return object
+}
+
+// decodeDirObject - returns the name of the directory object from
+// the name of the entry holding its metadata.
+func decodeDirObject(object string) string {
+	if HasSuffix(object, SlashSeparator+dirObjectMarker) {
+		return strings.TrimSuffix(object, dirObjectMarker)
+	}
+	return object
+}
+
 // Converts just bucket, object metadata into ObjectInfo datatype.
 func dirObjectInfo(bucket, object string, size int64, metadata map[string]string) ObjectInfo {
 	// This is a special case with size as '0' and object ends with
@@ -86,7 +109,7 @@ func newStorageAPI(endpoint Endpoint) (storage StorageAPI, err error) {
 		if err != nil {
 			return nil, err
 		}
-		return &xlStorageDiskIDCheck{storage: storage}, nil
+		return newFaultyStorage(newDriveHealthStorage(&xlStorageDiskIDCheck{storage: storage}), endpoint), nil
 	}
 
 	return newStorageRESTClient(endpoint), nil
@@ -142,6 +165,9 @@ func listObjectsNonSlash(ctx context.Context, bucket, prefix, marker, delimiter
 	var objInfos []ObjectInfo
 	var eof bool
 	var prevPrefix string
+	filter := listFilterFromContext(ctx)
+	var skipped int
+	var lastSkipped string
 
 	for {
 		if len(objInfos) == maxKeys {
@@ -157,6 +183,7 @@ func listObjectsNonSlash(ctx context.Context, bucket, prefix, marker, delimiter
 		var err error
 
 		index := strings.Index(strings.TrimPrefix(result.entry, prefix), delimiter)
+		isPrefix := index != -1
 		if index == -1 {
 			objInfo, err = getObjInfo(ctx, bucket, result.entry)
 			if err != nil {
@@ -190,6 +217,20 @@ func listObjectsNonSlash(ctx context.Context, bucket, prefix, marker, delimiter
 			continue
 		}
 
+		// The common prefixes are never filtered.
+		if !isPrefix && !filter.match(objInfo) {
+			if result.end {
+				eof = true
+				break
+			}
+			lastSkipped = objInfo.Name
+			skipped++
+			if skipped == listFilterMaxSkipped {
+				break
+			}
+			continue
+		}
+
 		objInfos = append(objInfos, objInfo)
 		if result.end {
 			eof = true
@@ -208,7 +249,10 @@ func listObjectsNonSlash(ctx context.Context, bucket, prefix, marker, delimiter
 
 	if !eof {
 		result.IsTruncated = true
-		if len(objInfos) > 0 {
+		if skipped == listFilterMaxSkipped {
+			// Cut short by the filter.
+			result.NextMarker = lastSkipped
+		} else if len(objInfos) > 0 {
 			result.NextMarker = objInfos[len(objInfos)-1].Name
 		}
 	}
@@ -319,11 +363,14 @@ func listObjects(ctx context.Context, obj ObjectLayer, bucket, prefix, marker, d
 		walkResultCh = startTreeWalk(ctx, bucket, prefix, marker, recursive, listDir, endWalkCh)
 	}
 
-	var objInfos []ObjectInfo
+	var result ListObjectsInfo
 	var eof bool
 	var nextMarker string
+	filter := listFilterFromContext(ctx)
+	var skipped int
 
-	// List until maxKeys requested.
+	// List until maxKeys requested, entries are consumed from the
+	// walker as they are produced.
 	for i := 0; i < maxKeys; {
 		walkResult, ok := <-walkResultCh
 		if !ok {
@@ -365,7 +412,25 @@ func listObjects(ctx context.Context, obj ObjectLayer, bucket, prefix, marker, d
 			return loi, toObjectErr(err, bucket, prefix)
 		}
 		nextMarker = objInfo.Name
-		objInfos = append(objInfos, objInfo)
+		// The directory object of the prefix itself is listed as an object.
+		if objInfo.IsDir && delimiter == SlashSeparator && objInfo.Name != prefix {
+			result.Prefixes = append(result.Prefixes, objInfo.Name)
+		} else if filter.match(objInfo) {
+			result.Objects = append(result.Objects, objInfo)
+		} else {
+			// The objects left out by the filter don't count
+			// towards maxKeys, the page is cut short after
+			// leaving out too many of them.
+			if walkResult.end {
+				eof = true
+				break
+			}
+			skipped++
+			if skipped == listFilterMaxSkipped {
+				break
+			}
+			continue
+		}
 		if walkResult.end {
 			eof = true
 			break
@@ -379,20 +444,9 @@ func listObjects(ctx context.Context, obj ObjectLayer, bucket, prefix, marker, d
 		tpool.Set(params, walkResultCh, endWalkCh)
 	}
 
-	result := ListObjectsInfo{}
-	for _, objInfo := range objInfos {
-		if objInfo.IsDir && delimiter == SlashSeparator {
-			result.Prefixes = append(result.Prefixes, objInfo.Name)
-			continue
-		}
-		result.Objects = append(result.Objects, objInfo)
-	}
-
 	if !eof {
 		result.IsTruncated = true
-		if len(objInfos) > 0 {
-			result.NextMarker = objInfos[len(objInfos)-1].Name
-		}
+		result.NextMarker = nextMarker
 	}
 
 	// Success.
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/madmin"
 )
@@ -38,6 +39,8 @@ const (
 	BackendErasure
 	// Gateway backend.
 	BackendGateway
+	// In-memory backend.
+	BackendMemory
 	// Add your own backend.
 )
 
@@ -60,6 +63,11 @@ type StorageInfo struct {
 		StandardSCParity int                 // Parity disks for currently configured Standard storage class.
 		RRSCData         int                 // Data disks for currently configured Reduced Redundancy storage class.
 		RRSCParity       int                 // Parity disks for currently configured Reduced Redundancy storage class.
+
+		// Standard storage class parity chosen when the drives were formatted.
+		FormatParity int
+		// Largest number of drives of a set in a single failure domain.
+		FailureDomainDrives int
 	}
 }
 
@@ -181,6 +189,14 @@ type ObjectInfo struct {
 	// Specify object storage class
 	StorageClass string
 
+	// TransitionStatus is set once the object data has been
+	// moved to a remote tier by a lifecycle transition rule.
+	TransitionStatus string
+
+	// ReplicationStatus of the object, set on objects
+	// replicated to or from a remote target.
+	ReplicationStatus replication.StatusType
+
 	// User-Defined metadata
 	UserDefined map[string]string
 
@@ -80,6 +80,13 @@ func toObjectErr(err error, params ...string) error {
 				VersionID: params[2],
 			}
 		}
+	case errMetadataConflict:
+		if len(params) >= 2 {
+			err = ObjectMetadataConflict{
+				Bucket: params[0],
+				Object: params[1],
+			}
+		}
 	case errMethodNotAllowed:
 		switch len(params) {
 		case 2:
@@ -223,6 +230,13 @@ func (e VersionNotFound) Error() string {
 	return "Version not found: " + e.Bucket + "/" + e.Object + "(" + e.VersionID + ")"
 }
 
+// InvalidVersionID version id is not a valid version id.
+type InvalidVersionID GenericError
+
+func (e InvalidVersionID) Error() string {
+	return "Invalid version id: " + e.Bucket + "/" + e.Object + "(" + e.VersionID + ")"
+}
+
 // ObjectNotFound object does not exist.
 type ObjectNotFound GenericError
 
@@ -244,6 +258,14 @@ func (e ObjectAlreadyExists) Error() string {
 	return "Object: " + e.Bucket + "/" + e.Object + " already exists"
 }
 
+// ObjectMetadataConflict object metadata was updated concurrently,
+// the update can be retried.
+type ObjectMetadataConflict GenericError
+
+func (e ObjectMetadataConflict) Error() string {
+	return "Object: " + e.Bucket + "/" + e.Object + " metadata was updated concurrently"
+}
+
 // ObjectExistsAsDirectory object already exists as a directory.
 type ObjectExistsAsDirectory GenericError
 
@@ -251,6 +273,14 @@ func (e ObjectExistsAsDirectory) Error() string {
 	return "Object exists on : " + e.Bucket + " as directory " + e.Object
 }
 
+// ObjectNameCaseConflict object name conflicts with an existing
+// object or prefix whose name differs only in case.
+type ObjectNameCaseConflict GenericError
+
+func (e ObjectNameCaseConflict) Error() string {
+	return "Object: " + e.Bucket + "/" + e.Object + " conflicts with an existing name differing only in case"
+}
+
 //PrefixAccessDenied object access is denied.
 type PrefixAccessDenied GenericError
 
@@ -320,6 +350,23 @@ func (e BucketSSEConfigNotFound) Error() string {
 	return "No bucket encryption configuration found for bucket: " + e.Bucket
 }
 
+// BucketReplicationConfigNotFound - no bucket replication config found
+type BucketReplicationConfigNotFound GenericError
+
+func (e BucketReplicationConfigNotFound) Error() string {
+	return "No bucket replication configuration found for bucket: " + e.Bucket
+}
+
+// BucketRemoteTargetNotFound - the remote target of a bucket is not found
+type BucketRemoteTargetNotFound struct {
+	Bucket string
+	Arn    string
+}
+
+func (e BucketRemoteTargetNotFound) Error() string {
+	return "Remote target " + e.Arn + " not found for bucket: " + e.Bucket
+}
+
 // BucketTaggingNotFound - no bucket tags found
 type BucketTaggingNotFound GenericError
 
@@ -348,6 +395,21 @@ func (e BucketQuotaExceeded) Error() string {
 	return "Bucket quota exceeded for bucket: " + e.Bucket
 }
 
+// BucketReadReplica - bucket is a read-only replica.
+type BucketReadReplica GenericError
+
+func (e BucketReadReplica) Error() string {
+	return "Bucket is a read-only replica: " + e.Bucket
+}
+
+// ShareLinkExpired - share link of the object expired or reached
+// its maximum number of downloads.
+type ShareLinkExpired GenericError
+
+func (e ShareLinkExpired) Error() string {
+	return "Share link is no longer valid: " + e.Bucket + "/" + e.Object
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
@@ -411,11 +473,11 @@ func (e InvalidRange) Error() string {
 	return fmt.Sprintf("The requested range \"bytes %d-%d/%d\" is not satisfiable.", e.OffsetBegin, e.OffsetEnd, e.ResourceSize)
 }
 
-// ObjectTooLarge error returned when the size of the object > max object size allowed (5G) per request.
+// ObjectTooLarge error returned when the size of the object > max object size allowed.
 type ObjectTooLarge GenericError
 
 func (e ObjectTooLarge) Error() string {
-	return "size of the object greater than what is allowed(5G)"
+	return "size of the object greater than what is allowed"
 }
 
 // ObjectTooSmall error returned when the size of the object < what is expected.
@@ -536,6 +598,12 @@ func isErrVersionNotFound(err error) bool {
 	return errors.As(err, &versionNotFound)
 }
 
+// isErrMethodNotAllowed - Check if error type is MethodNotAllowed.
+func isErrMethodNotAllowed(err error) bool {
+	var methodNotAllowed MethodNotAllowed
+	return errors.As(err, &methodNotAllowed)
+}
+
 // PreConditionFailed - Check if copy precondition failed
 type PreConditionFailed struct{}
 
@@ -547,3 +615,23 @@ func isErrPreconditionFailed(err error) bool {
 	_, ok := err.(PreConditionFailed)
 	return ok
 }
+
+// InvalidObjectState - object data is not readable in its current
+// storage class, it has to be restored first.
+type InvalidObjectState GenericError
+
+func (e InvalidObjectState) Error() string {
+	return "The operation is not valid for the current state of the object " + e.Bucket + "/" + e.Object
+}
+
+// ObjectInfected - the content uploaded is infected
+// according to the antivirus service.
+type ObjectInfected struct {
+	Bucket string
+	Object string
+	Virus  string
+}
+
+func (e ObjectInfected) Error() string {
+	return "The content of " + e.Bucket + "/" + e.Object + " is infected: " + e.Virus
+}
@@ -123,7 +123,16 @@ func checkListMultipartArgs(ctx context.Context, bucket, prefix, keyMarker, uplo
 
 // Checks for NewMultipartUpload arguments validity, also validates if bucket exists.
 func checkNewMultipartArgs(ctx context.Context, bucket, object string, obj ObjectLayer) error {
-	return checkObjectArgs(ctx, bucket, object, obj)
+	if err := checkObjectArgs(ctx, bucket, object, obj); err != nil {
+		return err
+	}
+	if hasDisallowedObjectNameChars(object) {
+		return ObjectNameInvalid{
+			Bucket: bucket,
+			Object: object,
+		}
+	}
+	return nil
 }
 
 // Checks for PutObjectPart arguments validity, also validates if bucket exists.
@@ -188,7 +197,7 @@ func checkPutObjectArgs(ctx context.Context, bucket, object string, obj ObjectLa
 	}
 	if len(object) == 0 ||
 		(HasSuffix(object, SlashSeparator) && size != 0) ||
-		!IsValidObjectPrefix(object) {
+		!IsValidObjectPrefix(object) || hasDisallowedObjectNameChars(object) {
 		return ObjectNameInvalid{
 			Bucket: bucket,
 			Object: object,
@@ -31,6 +31,11 @@ import (
 // CheckCopyPreconditionFn returns true if copy precondition check failed.
 type CheckCopyPreconditionFn func(o ObjectInfo, encETag string) bool
 
+// CheckPreconditionFn returns true if the precondition on the
+// existing object failed, the object info is empty when the
+// object does not exist.
+type CheckPreconditionFn func(o ObjectInfo) bool
+
 // GetObjectInfoFn is the signature of GetObjectInfo function.
 type GetObjectInfoFn func(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error)
 
@@ -44,6 +49,7 @@ type ObjectOptions struct {
 	UserDefined          map[string]string       // only set in case of POST/PUT operations
 	PartNumber           int                     // only useful in case of GetObject/HeadObject
 	CheckCopyPrecondFn   CheckCopyPreconditionFn // only set during CopyObject preconditional valuation
+	CheckPrecondFn       CheckPreconditionFn     // only set by internal writers which must verify the existing object under the write lock
 }
 
 // BucketOptions represents bucket options for ObjectLayer bucket operations
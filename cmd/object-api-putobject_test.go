@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"reflect"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
@@ -580,3 +581,72 @@ func BenchmarkParallelPutObject25MbFS(b *testing.B) {
 func BenchmarkParallelPutObject25MbErasure(b *testing.B) {
 	benchmarkPutObjectParallel(b, "Erasure", 25*humanize.MiByte)
 }
+
+// Wrapper for calling directory object tests for both Erasure multiple disks and single node setup.
+func TestObjectAPIPutDirObject(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectAPIPutDirObject)
+}
+
+// Tests validate that directory objects keep their metadata, are
+// listed along with their content and are deleted without it.
+func testObjectAPIPutDirObject(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket := "minio-bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	opts := ObjectOptions{UserDefined: map[string]string{
+		"content-type": "application/x-directory",
+		"x-amz-meta-a": "b",
+	}}
+	if _, err := obj.PutObject(ctx, bucket, "dir/", mustGetPutObjReader(t, bytes.NewReader(nil), 0, "", ""), opts); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+	data := []byte("hello, world")
+	if _, err := obj.PutObject(ctx, bucket, "dir/object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	objInfo, err := obj.GetObjectInfo(ctx, bucket, "dir/", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+	if !objInfo.IsDir || objInfo.ContentType != "application/x-directory" || objInfo.UserDefined["x-amz-meta-a"] != "b" {
+		t.Errorf("%s: unexpected directory object info %#v", instanceType, objInfo)
+	}
+
+	listCases := []struct {
+		prefix, delimiter string
+		objects           []string
+		prefixes          []string
+	}{
+		{"", "", []string{"dir/", "dir/object"}, nil},
+		{"", SlashSeparator, nil, []string{"dir/"}},
+		{"dir/", SlashSeparator, []string{"dir/", "dir/object"}, nil},
+	}
+	for i, testCase := range listCases {
+		result, err := obj.ListObjects(ctx, bucket, testCase.prefix, "", testCase.delimiter, 100)
+		if err != nil {
+			t.Fatalf("Test %d: %s : %s", i+1, instanceType, err.Error())
+		}
+		var objects []string
+		for _, objInfo := range result.Objects {
+			objects = append(objects, objInfo.Name)
+		}
+		if !reflect.DeepEqual(objects, testCase.objects) || !reflect.DeepEqual(result.Prefixes, testCase.prefixes) {
+			t.Errorf("Test %d: %s: expected %v %v, got %v %v", i+1, instanceType,
+				testCase.objects, testCase.prefixes, objects, result.Prefixes)
+		}
+	}
+
+	if _, err = obj.DeleteObject(ctx, bucket, "dir/", ObjectOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "dir/", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Errorf("%s: expected directory object to be deleted, got %v", instanceType, err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "dir/object", ObjectOptions{}); err != nil {
+		t.Errorf("%s: expected directory content to be kept, got %s", instanceType, err)
+	}
+}
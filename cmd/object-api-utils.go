@@ -25,6 +25,7 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
+	"net/url"
 	"path"
 	"runtime"
 	"strconv"
@@ -37,6 +38,7 @@ import (
 	"github.com/klauspost/compress/s2"
 	"github.com/klauspost/readahead"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio/cmd/config/api"
 	"github.com/minio/minio/cmd/config/compress"
 	"github.com/minio/minio/cmd/config/etcd/dns"
 	"github.com/minio/minio/cmd/config/storageclass"
@@ -46,6 +48,7 @@ import (
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/wildcard"
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
@@ -65,6 +68,12 @@ const (
 	compReadAheadBuffers = 5
 	// Size of each buffer.
 	compReadAheadBufSize = 1 << 20
+	// Size of the s2 blocks of compressed objects.
+	compBlockSize = 1 << 20
+	// The most input consumed by a read of the s2 stream of a
+	// compressed object: the 10 bytes stream identifier, and the
+	// chunk of a block with its header and checksum.
+	compMaxFrameSize = 10 + 8 + compBlockSize
 )
 
 // isMinioBucket returns true if given bucket is a MinIO internal
@@ -167,6 +176,38 @@ func IsValidObjectPrefix(object string) bool {
 	return true
 }
 
+// hasDisallowedObjectNameChars - returns whether the object name has
+// any of the characters disallowed in the names of the objects written,
+// the objects written before the characters were disallowed are still
+// read, listed and deleted.
+func hasDisallowedObjectNameChars(object string) bool {
+	chars := globalAPIConfig.getObjectNameDisallowedChars()
+	return chars != "" && strings.ContainsAny(object, chars)
+}
+
+// normalizeObjectName - returns the object name in the Unicode
+// normalization form configured for object names, so that names sent
+// composed or decomposed by different clients are the same object.
+func normalizeObjectName(object string) string {
+	switch globalAPIConfig.getObjectNameNormalization() {
+	case api.ObjectNameNormalizationNFC:
+		return norm.NFC.String(object)
+	case api.ObjectNameNormalizationNFD:
+		return norm.NFD.String(object)
+	}
+	return object
+}
+
+// unescapeObjectName - unescapes the object name of a request path
+// and normalizes it, see normalizeObjectName().
+func unescapeObjectName(object string) (string, error) {
+	object, err := url.PathUnescape(object)
+	if err != nil {
+		return "", err
+	}
+	return normalizeObjectName(object), nil
+}
+
 // checkObjectNameForLengthAndSlash -check for the validity of object name length and prefis as slash
 func checkObjectNameForLengthAndSlash(bucket, object string) error {
 	// Check for the length of object name
@@ -186,7 +227,7 @@ func checkObjectNameForLengthAndSlash(bucket, object string) error {
 	if runtime.GOOS == globalWindowsOSName {
 		// Explicitly disallowed characters on windows.
 		// Avoids most problematic names.
-		if strings.ContainsAny(object, `:*?"|<>`) {
+		if strings.ContainsAny(object, `:*?"|<>`) || hasWindowsReservedPathComponent(object) {
 			return ObjectNameInvalid{
 				Bucket: bucket,
 				Object: object,
@@ -196,6 +237,35 @@ func checkObjectNameForLengthAndSlash(bucket, object string) error {
 	return nil
 }
 
+// Windows device names, reserved with or without an extension.
+var windowsReservedNames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// hasWindowsReservedPathComponent returns true if a path component
+// names a Windows device or ends with a '.' or ' ', which Windows
+// silently strips, such names would not map to a regular file of
+// the same name.
+func hasWindowsReservedPathComponent(object string) bool {
+	for _, p := range strings.Split(object, SlashSeparator) {
+		if p == "" {
+			continue
+		}
+		if strings.HasSuffix(p, ".") || strings.HasSuffix(p, " ") {
+			return true
+		}
+		if i := strings.IndexByte(p, '.'); i >= 0 {
+			p = p[:i]
+		}
+		if _, ok := windowsReservedNames[strings.ToUpper(strings.TrimRight(p, " "))]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // SlashSeparator - slash separator.
 const SlashSeparator = "/"
 
@@ -535,8 +605,9 @@ type ObjReaderFn func(inputReader io.Reader, h http.Header, pcfn CheckCopyPrecon
 // NewGetObjectReader creates a new GetObjectReader. The cleanUpFns
 // are called on Close() in reverse order as passed here. NOTE: It is
 // assumed that clean up functions do not panic (otherwise, they may
-// not all run!).
-func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cleanUpFns ...func()) (
+// not all run!). The decryption and decompression stop waiting for
+// the CPU pool once ctx is done.
+func NewGetObjectReader(ctx context.Context, rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cleanUpFns ...func()) (
 	fn ObjReaderFn, off, length int64, err error) {
 
 	// Call the clean-up functions immediately in case of exit
@@ -587,7 +658,8 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 			cFns = append(cleanUpFns, cFns...)
 			// Attach decrypter on inputReader
 			var decReader io.Reader
-			decReader, err = DecryptBlocksRequestR(inputReader, h,
+			src := newCPUSource(inputReader, SSEDAREPackageBlockSize+SSEDAREPackageMetaSize)
+			decReader, err = DecryptBlocksRequestR(src, h,
 				off, length, seqNumber, partStart, oi, copySource)
 			if err != nil {
 				// Call the cleanup funcs
@@ -596,6 +668,8 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 				}
 				return nil, err
 			}
+			// Decrypt a package at most per read on the CPU pool.
+			decReader = newCPUReader(ctx, cpuJobDecrypt, src, decReader, SSEDAREPackageBlockSize)
 			encETag := oi.ETag
 			oi.ETag = getDecryptedETag(h, oi, copySource) // Decrypt the ETag before top layer consumes this value.
 
@@ -662,7 +736,8 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 				}
 			}
 			// Decompression reader.
-			s2Reader := s2.NewReader(inputReader)
+			src := newCPUSource(inputReader, compMaxFrameSize)
+			s2Reader := s2.NewReader(src)
 			// Apply the skipLen and limit on the decompressed stream.
 			err = s2Reader.Skip(decOff)
 			if err != nil {
@@ -673,7 +748,8 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions, cl
 				return nil, err
 			}
 
-			decReader := io.LimitReader(s2Reader, decLength)
+			// Decompress a block per read on the CPU pool.
+			decReader := io.LimitReader(newCPUReader(ctx, cpuJobDecompress, src, s2Reader, 0), decLength)
 			if decLength > compReadAheadSize {
 				rah, err := readahead.NewReaderSize(decReader, compReadAheadBuffers, compReadAheadBufSize)
 				if err == nil {
@@ -775,9 +851,15 @@ func (p *PutObjReader) MD5CurrentHexString() string {
 	// - client set Content-Md5 during PUT operation
 	if len(md5sumCurr) == 0 {
 		// md5sumCurr is only empty when we are running
-		// in non-compatibility mode.
-		md5sumCurr = make([]byte, 16)
-		rand.Read(md5sumCurr)
+		// in non-compatibility mode or deriving the ETag
+		// from the verified payload SHA256.
+		sha256sum := p.rawReader.SHA256()
+		if globalAPIConfig.getETagMode() == api.ETagModeSHA256 && len(sha256sum) >= 16 {
+			md5sumCurr = sha256sum[:16]
+		} else {
+			md5sumCurr = make([]byte, 16)
+			rand.Read(md5sumCurr)
+		}
 		appendHyphen = true
 	}
 	if p.sealMD5Fn != nil {
@@ -838,7 +920,7 @@ func CleanMinioInternalMetadataKeys(metadata map[string]string) map[string]strin
 // Use Close to ensure resources are released on incomplete streams.
 func newS2CompressReader(r io.Reader) io.ReadCloser {
 	pr, pw := io.Pipe()
-	comp := s2.NewWriter(pw)
+	comp := s2.NewWriter(pw, s2.WriterBlockSize(compBlockSize))
 	// Copy input to compressor
 	go func() {
 		_, err := io.Copy(comp, r)
@@ -18,15 +18,21 @@ package cmd
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/klauspost/compress/s2"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/api"
 	"github.com/minio/minio/cmd/config/compress"
 	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/hash"
 )
 
 // Tests validate bucket name.
@@ -89,6 +95,32 @@ func TestIsValidBucketName(t *testing.T) {
 	}
 }
 
+// Tests for names which cannot be stored as is on windows.
+func TestHasWindowsReservedPathComponent(t *testing.T) {
+	testCases := []struct {
+		objectName string
+		reserved   bool
+	}{
+		{"object", false},
+		{"prefix/object.txt", false},
+		{"prefix/", false},
+		{"console/connection", false},
+		{"nul", true},
+		{"prefix/CON", true},
+		{"aux.txt", true},
+		{"Com1.tar.gz/object", true},
+		{"lpt9 /object", true},
+		{"object.", true},
+		{"prefix /object", true},
+	}
+
+	for i, testCase := range testCases {
+		if reserved := hasWindowsReservedPathComponent(testCase.objectName); reserved != testCase.reserved {
+			t.Errorf("Test case %d: Expected %t for \"%s\", got %t", i+1, testCase.reserved, testCase.objectName, reserved)
+		}
+	}
+}
+
 // Tests for validate object name.
 func TestIsValidObjectName(t *testing.T) {
 	testCases := []struct {
@@ -149,6 +181,54 @@ func TestIsValidObjectName(t *testing.T) {
 	}
 }
 
+// Tests object names normalization and disallowed characters.
+func TestObjectNamePolicy(t *testing.T) {
+	const (
+		composed   = "caf\u00e9.txt"
+		decomposed = "cafe\u0301.txt"
+	)
+
+	if normalizeObjectName(decomposed) != decomposed {
+		t.Errorf("Expected object name to be kept by default")
+	}
+
+	for i, testCase := range []struct {
+		normalization string
+		object        string
+		expected      string
+	}{
+		{api.ObjectNameNormalizationNFC, decomposed, composed},
+		{api.ObjectNameNormalizationNFC, composed, composed},
+		{api.ObjectNameNormalizationNFD, composed, decomposed},
+		{api.ObjectNameNormalizationNFD, decomposed, decomposed},
+	} {
+		globalAPIConfig.init(api.Config{APIObjectNameNormalization: testCase.normalization})
+		if object := normalizeObjectName(testCase.object); object != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, object)
+		}
+	}
+
+	globalAPIConfig.init(api.Config{APIObjectNameDisallowedChars: ":*?"})
+	defer globalAPIConfig.init(api.Config{})
+	// The objects written before the characters were disallowed
+	// are still read.
+	if !IsValidObjectName("dir/file:name") {
+		t.Errorf("Expected object name with a disallowed character to be valid for reads")
+	}
+	if !hasDisallowedObjectNameChars("dir/file:name") {
+		t.Errorf("Expected object name with a disallowed character to be rejected for writes")
+	}
+	if hasDisallowedObjectNameChars("dir/file-name") {
+		t.Errorf("Expected object name without disallowed characters to be accepted for writes")
+	}
+
+	kvs := append(config.KVS{}, api.DefaultKVS...)
+	kvs.Set("object_name_normalization", "nfkc")
+	if _, err := api.LookupConfig(kvs); err == nil {
+		t.Errorf("Expected an error for an invalid normalization form")
+	}
+}
+
 // Tests getCompleteMultipartMD5
 func TestGetCompleteMultipartMD5(t *testing.T) {
 	testCases := []struct {
@@ -656,3 +736,44 @@ func TestS2CompressReader(t *testing.T) {
 		})
 	}
 }
+
+// Tests ETag generation when the MD5 of an upload is not computed.
+func TestPutObjReaderMD5CurrentHexString(t *testing.T) {
+	data := []byte("hello world")
+	sum := sha256.Sum256(data)
+	sha256hex := hex.EncodeToString(sum[:])
+
+	newReader := func(sha256hex string) *PutObjReader {
+		hr, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", sha256hex, int64(len(data)), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.Copy(ioutil.Discard, hr); err != nil {
+			t.Fatal(err)
+		}
+		return NewPutObjReader(hr, nil, nil)
+	}
+
+	defer func(etagMode string) { globalAPIConfig.etagMode = etagMode }(globalAPIConfig.etagMode)
+
+	// The payload SHA256 is only used in the sha256 ETag mode.
+	globalAPIConfig.etagMode = api.ETagModeMD5
+	want := hex.EncodeToString(sum[:16]) + "-1"
+	if got := newReader(sha256hex).MD5CurrentHexString(); got == want {
+		t.Fatalf("expected a random ETag, got %s", got)
+	}
+
+	globalAPIConfig.etagMode = api.ETagModeSHA256
+	for i := 0; i < 2; i++ {
+		if got := newReader(sha256hex).MD5CurrentHexString(); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+
+	// Without a payload SHA256 the ETag is random.
+	etag1 := newReader("").MD5CurrentHexString()
+	etag2 := newReader("").MD5CurrentHexString()
+	if etag1 == etag2 || etag1 == want {
+		t.Fatalf("expected random ETags, got %s and %s", etag1, etag2)
+	}
+}
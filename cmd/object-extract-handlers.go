@@ -0,0 +1,543 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zip"
+	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// PutObjectExtractHandler - PUT Object extract, an extension of PUT
+// Object which expands the uploaded tar, gzip compressed tar or zip
+// archive into one object per archived file, named after the file
+// prefixed with the directory of the object name of the request.
+// ----------
+// This implementation of the PUT operation is selected by the
+// x-minio-extract: true header, the archive itself is not stored.
+func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutObjectExtract")
+	defer logger.AuditLog(w, r, "PutObjectExtract", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if crypto.S3KMS.IsRequested(r.Header) && !api.AllowSSEKMS() {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r)) // SSE-KMS is not supported
+		return
+	}
+	if !api.EncryptionEnabled() && crypto.IsRequested(r.Header) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapeObjectName(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// To detect if the client has disconnected.
+	r.Body = &contextReader{r.Body, r.Context()}
+
+	// Get Content-Md5 sent by client and verify if valid
+	md5Bytes, err := checkValidMD5(r.Header)
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidDigest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	/// if Content-Length is unknown/missing, deny the request
+	size := r.ContentLength
+	rAuthType := getRequestAuthType(r)
+	if rAuthType == authTypeStreamingSigned {
+		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
+			if sizeStr[0] == "" {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			size, err = strconv.ParseInt(sizeStr[0], 10, 64)
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+	if size == -1 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	metadata, err := extractMetadata(ctx, r)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	// The content type of the request is the type of the
+	// archive, the type of each object is set from its name.
+	delete(metadata, strings.ToLower(xhttp.ContentType))
+
+	if objTags := r.Header.Get(xhttp.AmzObjectTagging); objTags != "" {
+		if !objectAPI.IsTaggingSupported() {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		if _, err := tags.ParseObjectTags(objTags); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		metadata[xhttp.AmzObjectTagging] = objTags
+	}
+
+	var (
+		md5hex    = hex.EncodeToString(md5Bytes)
+		sha256hex = ""
+		reader    io.Reader
+		s3Err     APIErrorCode
+		putObject = objectAPI.PutObject
+	)
+	reader = r.Body
+
+	// Check if put is allowed
+	if s3Err = isPutActionAllowed(rAuthType, bucket, object, r, iampolicy.PutObjectAction); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	switch rAuthType {
+	case authTypeStreamingSigned:
+		// Initialize stream signature verifier.
+		reader, s3Err = newSignV4ChunkedReader(r)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	case authTypeSignedV2, authTypePresignedV2:
+		s3Err = isReqAuthenticatedV2(r)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+	case authTypePresigned, authTypeSigned:
+		if s3Err = reqSignatureV4Verify(r, getBucketRegion(bucket), serviceS3); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if !skipContentSha256Cksum(r) {
+			sha256hex = getContentSha256Cksum(r, serviceS3)
+		}
+	}
+
+	if _, err := enforceBucketQuota(ctx, bucket, "", size); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket encryption is enabled
+	_, err = globalBucketSSEConfigSys.Get(bucket)
+	// This request header needs to be set prior to setting ObjectOptions
+	if (globalAutoEncryption || err == nil) && !crypto.SSEC.IsRequested(r.Header) && !crypto.S3KMS.IsRequested(r.Header) {
+		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+	}
+	if crypto.SSECopy.IsRequested(r.Header) {
+		writeErrorResponse(ctx, w, toAPIError(ctx, errInvalidEncryptionParameters), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The archive is spooled first, its checksums are verified
+	// once the whole archive has been read.
+	archiveReader, err := hash.NewReader(reader, size, md5hex, sha256hex, size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	spool, err := newExtractSpool(archiveReader)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer spool.Close()
+
+	prefix := path.Dir(object)
+	if prefix == "." {
+		prefix = ""
+	}
+
+	// The quotas are checked for all the archived files
+	// at once, not to stop halfway through the extraction.
+	entryObjects, entriesSize, err := listExtractArchive(spool, prefix)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if err = enforceBucketQuotaObjects(ctx, bucket, entryObjects, entriesSize); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	archive, err := newExtractArchive(spool)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer archive.Close()
+
+	if api.CacheAPI() != nil {
+		putObject = api.CacheAPI().PutObject
+	}
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	// Stores the object of an archived file.
+	putEntry := func(entryObject string, entry extractArchiveEntry) (ObjectInfo, APIErrorCode, error) {
+		if s3Err := isPutActionAllowed(rAuthType, bucket, entryObject, r, iampolicy.PutObjectAction); s3Err != ErrNone {
+			return ObjectInfo{}, s3Err, nil
+		}
+		if isMaxObjectSize(entry.size) {
+			return ObjectInfo{}, ErrEntityTooLarge, nil
+		}
+
+		entryMetadata := make(map[string]string, len(metadata))
+		for k, v := range metadata {
+			entryMetadata[k] = v
+		}
+
+		// Scan the content of the archived file with the antivirus
+		// service, if enabled.
+		scanned, err := newScanReader(ctx, objectAPI, entry.reader, entry.size, bucket, entryObject, quarantineObjectName(bucket, entryObject))
+		if err != nil {
+			return ObjectInfo{}, ErrNone, err
+		}
+		defer scanned.Close()
+
+		var (
+			entryReader io.Reader = scanned
+			entrySize             = entry.size
+			actualSize            = entry.size
+		)
+		if objectAPI.IsCompressionSupported() && isCompressible(r.Header, entryObject) && entrySize > 0 {
+			// Storing the compression metadata.
+			entryMetadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+			entryMetadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(entrySize, 10)
+
+			actualReader, err := hash.NewReader(entryReader, entrySize, "", "", actualSize, globalCLIContext.StrictS3Compat)
+			if err != nil {
+				return ObjectInfo{}, ErrNone, err
+			}
+
+			s2c := newS2CompressReader(actualReader)
+			defer s2c.Close()
+			entryReader = s2c
+			entrySize = -1 // Since compressed size is un-predictable.
+		}
+
+		hashReader, err := hash.NewReader(entryReader, entrySize, "", "", actualSize, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return ObjectInfo{}, ErrNone, err
+		}
+		rawReader := hashReader
+		pReader := NewPutObjReader(rawReader, nil, nil)
+
+		opts, err := putOpts(ctx, r, bucket, entryObject, entryMetadata)
+		if err != nil {
+			return ObjectInfo{}, ErrNone, err
+		}
+
+		retPerms := isPutActionAllowed(rAuthType, bucket, entryObject, r, iampolicy.PutObjectRetentionAction)
+		holdPerms := isPutActionAllowed(rAuthType, bucket, entryObject, r, iampolicy.PutObjectLegalHoldAction)
+		retentionMode, retentionDate, legalHold, s3Err := checkPutObjectLockAllowed(ctx, r, bucket, entryObject, getObjectInfo, retPerms, holdPerms)
+		if s3Err != ErrNone {
+			return ObjectInfo{}, s3Err, nil
+		}
+		if retentionMode.Valid() {
+			entryMetadata[strings.ToLower(xhttp.AmzObjectLockMode)] = string(retentionMode)
+			entryMetadata[strings.ToLower(xhttp.AmzObjectLockRetainUntilDate)] = retentionDate.UTC().Format(iso8601TimeFormat)
+		}
+		if legalHold.Status.Valid() {
+			entryMetadata[strings.ToLower(xhttp.AmzObjectLockLegalHold)] = string(legalHold.Status)
+		}
+
+		if objectAPI.IsEncryptionSupported() && crypto.IsRequested(r.Header) {
+			var objectEncryptionKey crypto.ObjectKey
+			entryReader, objectEncryptionKey, err = EncryptRequest(hashReader, r, bucket, entryObject, entryMetadata)
+			if err != nil {
+				return ObjectInfo{}, ErrNone, err
+			}
+			info := ObjectInfo{Size: entrySize}
+
+			// do not try to verify encrypted content
+			hashReader, err = hash.NewReader(entryReader, info.EncryptedSize(), "", "", entrySize, globalCLIContext.StrictS3Compat)
+			if err != nil {
+				return ObjectInfo{}, ErrNone, err
+			}
+			pReader = NewPutObjReader(rawReader, hashReader, &objectEncryptionKey)
+		}
+
+		// Ensure that metadata does not contain sensitive information
+		crypto.RemoveSensitiveEntries(entryMetadata)
+
+		objInfo, err := putObject(ctx, bucket, entryObject, pReader, opts)
+		return objInfo, ErrNone, err
+	}
+
+	for {
+		entry, err := archive.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+		entryObject := pathJoin(prefix, entry.name)
+		newObject, err := enforceBucketQuota(ctx, bucket, entryObject, entry.size)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		objInfo, s3Err, err := putEntry(entryObject, entry)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		accountBucketQuota(bucket, entry.size, newObject)
+
+		// Notify object created event.
+		sendEvent(eventArgs{
+			EventName:    event.ObjectCreatedPut,
+			BucketName:   bucket,
+			Object:       objInfo,
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// extractSpool - an uploaded archive spooled to a temporary file.
+type extractSpool struct {
+	*os.File
+	size int64
+}
+
+// newExtractSpool - spools the archive to the temporary directory of
+// a local drive, to the one of the system in gateway mode.
+func newExtractSpool(r io.Reader) (*extractSpool, error) {
+	var tmpDir string
+	for _, zone := range globalEndpoints {
+		for _, endpoint := range zone.Endpoints {
+			if endpoint.IsLocal && tmpDir == "" {
+				tmpDir = pathJoin(endpoint.Path, minioMetaTmpBucket)
+			}
+		}
+	}
+	if tmpDir != "" {
+		if err := os.MkdirAll(tmpDir, 0777); err != nil {
+			return nil, osErrToFileErr(err)
+		}
+	}
+	tmpFile, err := ioutil.TempFile(tmpDir, "extract-")
+	if err != nil {
+		return nil, osErrToFileErr(err)
+	}
+	s := &extractSpool{File: tmpFile}
+	if s.size, err = io.Copy(tmpFile, r); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *extractSpool) Close() error {
+	s.File.Close()
+	return os.Remove(s.Name())
+}
+
+// listExtractArchive - returns the objects of the archived
+// files and their total size.
+func listExtractArchive(s *extractSpool, prefix string) (objects []string, size int64, err error) {
+	archive, err := newExtractArchive(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer archive.Close()
+	for {
+		entry, err := archive.next()
+		if err == io.EOF {
+			return objects, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		objects = append(objects, pathJoin(prefix, entry.name))
+		size += entry.size
+	}
+}
+
+// extractArchiveEntry - a regular file of an extracted archive.
+type extractArchiveEntry struct {
+	name   string
+	size   int64
+	reader io.Reader
+}
+
+// extractArchive - reads the regular files of an archive,
+// next returns io.EOF after the last file.
+type extractArchive interface {
+	next() (extractArchiveEntry, error)
+	Close() error
+}
+
+var zipLocalFileSignature = []byte("PK\x03\x04")
+
+// newExtractArchive - returns the reader of a spooled tar, gzip
+// compressed tar or zip archive, the format is detected from the
+// content.
+func newExtractArchive(s *extractSpool) (extractArchive, error) {
+	magic := make([]byte, 4)
+	n, _ := s.ReadAt(magic, 0)
+	magic = magic[:n]
+
+	br := bufio.NewReader(io.NewSectionReader(s, 0, s.size))
+	switch {
+	case bytes.HasPrefix(magic, []byte{0x1f, 0x8b}):
+		gzr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, errInvalidArgument
+		}
+		return &tarExtractArchive{tr: tar.NewReader(gzr), closer: gzr}, nil
+	case bytes.Equal(magic, zipLocalFileSignature):
+		zr, err := zip.NewReader(s, s.size)
+		if err != nil {
+			return nil, errInvalidArgument
+		}
+		return &zipExtractArchive{files: zr.File}, nil
+	}
+	return &tarExtractArchive{tr: tar.NewReader(br), closer: ioutil.NopCloser(nil)}, nil
+}
+
+type tarExtractArchive struct {
+	tr     *tar.Reader
+	closer io.Closer
+}
+
+func (t *tarExtractArchive) next() (extractArchiveEntry, error) {
+	for {
+		header, err := t.tr.Next()
+		if err == io.EOF {
+			return extractArchiveEntry{}, err
+		}
+		if err != nil {
+			return extractArchiveEntry{}, errInvalidArgument
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			// Directories, links and special files are skipped.
+			continue
+		}
+		name := extractArchiveEntryName(header.Name)
+		if name == "" {
+			continue
+		}
+		return extractArchiveEntry{name: name, size: header.Size, reader: t.tr}, nil
+	}
+}
+
+func (t *tarExtractArchive) Close() error {
+	return t.closer.Close()
+}
+
+// zipExtractArchive - reads a zip archive from its central
+// directory, at the end of the archive.
+type zipExtractArchive struct {
+	files   []*zip.File
+	current io.ReadCloser
+}
+
+func (z *zipExtractArchive) next() (extractArchiveEntry, error) {
+	if z.current != nil {
+		z.current.Close()
+		z.current = nil
+	}
+	for len(z.files) > 0 {
+		file := z.files[0]
+		z.files = z.files[1:]
+		if !file.Mode().IsRegular() {
+			continue
+		}
+		name := extractArchiveEntryName(file.Name)
+		if name == "" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return extractArchiveEntry{}, errInvalidArgument
+		}
+		z.current = rc
+		return extractArchiveEntry{name: name, size: int64(file.UncompressedSize64), reader: rc}, nil
+	}
+	return extractArchiveEntry{}, io.EOF
+}
+
+func (z *zipExtractArchive) Close() error {
+	if z.current != nil {
+		return z.current.Close()
+	}
+	return nil
+}
+
+// extractArchiveEntryName - returns the relative object name of an
+// archived file, names can not escape the prefix of the extraction.
+func extractArchiveEntryName(name string) string {
+	name = path.Clean(SlashSeparator + strings.Replace(name, `\`, SlashSeparator, -1))
+	return strings.TrimPrefix(name, SlashSeparator)
+}
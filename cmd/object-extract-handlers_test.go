@@ -0,0 +1,159 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zip"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Files of the archives uploaded by the tests.
+var extractTestFiles = []struct {
+	name, object, data string
+}{
+	{"a.txt", "a.txt", "aaaaaaaa"},
+	{"dir/b.txt", "dir/b.txt", "bbbbbbbbbbbbbbbb"},
+	{"../c.txt", "c.txt", ""},
+}
+
+func newExtractTestTar(t *testing.T, compress bool) []byte {
+	var buf bytes.Buffer
+	var gzw *gzip.Writer
+	tw := tar.NewWriter(&buf)
+	if compress {
+		gzw = gzip.NewWriter(&buf)
+		tw = tar.NewWriter(gzw)
+	}
+	if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: "dir/", Mode: 0755}); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range extractTestFiles {
+		if err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: file.name, Size: int64(len(file.data)), Mode: 0644}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(file.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if gzw != nil {
+		if err := gzw.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func newExtractTestZip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if _, err := zw.Create("dir/"); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range extractTestFiles {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(file.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractArchiveEntryName(t *testing.T) {
+	testCases := []struct {
+		name, expected string
+	}{
+		{"a", "a"},
+		{"/a/b", "a/b"},
+		{"../../a", "a"},
+		{"a/../../b", "b"},
+		{`a\b`, "a/b"},
+		{"./", ""},
+	}
+	for i, testCase := range testCases {
+		if name := extractArchiveEntryName(testCase.name); name != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, name)
+		}
+	}
+}
+
+func TestAPIPutObjectExtractHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectExtractHandler, []string{"PutObjectExtract"})
+}
+
+func testAPIPutObjectExtractHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	testCases := []struct {
+		objectName         string
+		data               []byte
+		prefix             string
+		expectedRespStatus int
+	}{
+		{"archive.tar", newExtractTestTar(t, false), "", http.StatusOK},
+		{"tgz/archive.tar.gz", newExtractTestTar(t, true), "tgz/", http.StatusOK},
+		{"zip/a/archive.zip", newExtractTestZip(t), "zip/a/", http.StatusOK},
+		{"invalid.tar", []byte("not an archive"), "", http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("PUT", getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for Put Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set("X-Minio-Extract", "true")
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectedRespStatus != http.StatusOK {
+			continue
+		}
+
+		if _, err = obj.GetObjectInfo(context.Background(), bucketName, testCase.objectName, ObjectOptions{}); err == nil {
+			t.Fatalf("Test %d: %s: Expected the archive not to be stored", i+1, instanceType)
+		}
+		for _, file := range extractTestFiles {
+			var buf bytes.Buffer
+			err = obj.GetObject(context.Background(), bucketName, testCase.prefix+file.object, 0, -1, &buf, "", ObjectOptions{})
+			if err != nil {
+				t.Fatalf("Test %d: %s: Failed to fetch the extracted object %s: <ERROR> %s", i+1, instanceType, file.object, err)
+			}
+			if buf.String() != file.data {
+				t.Fatalf("Test %d: %s: Extracted object %s does not match the archived file", i+1, instanceType, file.object)
+			}
+		}
+	}
+}
@@ -18,17 +18,23 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/bucket/lifecycle"
+	"github.com/minio/minio/pkg/bucket/replication"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
 )
 
 var (
@@ -222,10 +228,11 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	}
 
 	// If-None-Match : Return the object only if its entity tag (ETag) is different from the
-	// one specified otherwise, return a 304 (not modified).
+	// one specified otherwise, return a 304 (not modified). The weak ETags of responses
+	// compressed with gzip match.
 	ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch)
 	if ifNoneMatchETagHeader != "" {
-		if isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+		if isETagEqual(objInfo.ETag, strings.TrimPrefix(ifNoneMatchETagHeader, "W/")) {
 			// If the object ETag matches with the specified ETag.
 			writeHeaders()
 			w.WriteHeader(http.StatusNotModified)
@@ -249,6 +256,51 @@ func canonicalizeETag(etag string) string {
 	return etagRegex.ReplaceAllString(etag, "$1")
 }
 
+// isMultipartETag returns true if the ETag has the form <hex>-N of the
+// ETag of an object uploaded in N parts, the <hex> being the MD5 of the
+// concatenated MD5 sums of its parts.
+func isMultipartETag(etag string) bool {
+	etag = canonicalizeETag(etag)
+	i := strings.LastIndexByte(etag, '-')
+	if i != 32 {
+		return false
+	}
+	if _, err := hex.DecodeString(etag[:i]); err != nil {
+		return false
+	}
+	n, err := strconv.Atoi(etag[i+1:])
+	return err == nil && n > 0
+}
+
+// checkIfRange returns true if the range of a request is to be returned,
+// that is if the request has no If-Range header or if its validator, an
+// ETag or a date, still matches the object. Weak ETags never match and
+// dates only match the modification time of the object.
+func checkIfRange(r *http.Request, objInfo ObjectInfo) bool {
+	ifRange := r.Header.Get(xhttp.IfRange)
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, "W/") {
+		return false
+	}
+	if givenTime, err := http.ParseTime(ifRange); err == nil {
+		return objInfo.ModTime.UTC().Truncate(time.Second).Equal(givenTime)
+	}
+	return objInfo.ETag != "" && isETagEqual(objInfo.ETag, ifRange)
+}
+
+// isMD5ETag returns true if the ETag is the hex encoded MD5 of the
+// content, not a multipart ETag or an ETag generated without the MD5.
+func isMD5ETag(etag string) bool {
+	etag = canonicalizeETag(etag)
+	if len(etag) != 32 {
+		return false
+	}
+	_, err := hex.DecodeString(etag)
+	return err == nil
+}
+
 // isETagEqual return true if the canonical representations of two ETag strings
 // are equal, false otherwise
 func isETagEqual(left, right string) bool {
@@ -305,6 +357,11 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 		return objInfo, err
 	}
 
+	// Deletions replicated from a source are not replicated again.
+	if isReplicaDeleteRequest(r, bucket, object) {
+		objInfo.ReplicationStatus = replication.Replica
+	}
+
 	// Requesting only a delete marker which was successfully attempted.
 	if objInfo.DeleteMarker {
 		// Notify object deleted marker event.
@@ -329,3 +386,96 @@ func deleteObject(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer,
 	}
 	return objInfo, nil
 }
+
+// putObjectContent stores an object as PutObjectHandler stores it, this
+// is a common function to be called from the front-ends other than S3:
+// the content is scanned by the antivirus service, given a content-type,
+// compressed, encrypted and marked for replication as configured for
+// the server and the bucket. r is nil for the front-ends not serving
+// HTTP requests, size is -1 if unknown.
+func putObjectContent(ctx context.Context, obj ObjectLayer, cache CacheObjectLayer, bucket, object string, r *http.Request, reader io.Reader, size int64, md5hex string, metadata map[string]string) (objInfo ObjectInfo, err error) {
+	if r == nil {
+		r = &http.Request{Method: http.MethodPut, URL: &url.URL{}, Header: http.Header{}}
+		r = r.WithContext(ctx)
+		if contentType := metadata[strings.ToLower(xhttp.ContentType)]; contentType != "" {
+			r.Header.Set(xhttp.ContentType, contentType)
+		}
+	}
+
+	// Check if bucket encryption is enabled
+	_, err = globalBucketSSEConfigSys.Get(bucket)
+	if (globalAutoEncryption || err == nil) && !crypto.SSEC.IsRequested(r.Header) && !crypto.S3KMS.IsRequested(r.Header) {
+		r.Header.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+	}
+
+	// Scan the content with the antivirus service, if enabled.
+	scanned, err := newScanReader(ctx, obj, reader, size, bucket, object, quarantineObjectName(bucket, object))
+	if err != nil {
+		return objInfo, err
+	}
+	defer scanned.Close()
+
+	// Detect the content-type of objects uploaded without one, if enabled.
+	reader = sniffContentType(r, object, scanned, metadata)
+
+	actualSize := size
+	if obj.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+		// Storing the compression metadata.
+		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
+
+		actualReader, err := hash.NewReader(reader, size, md5hex, "", actualSize, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return objInfo, err
+		}
+		s2c := newS2CompressReader(actualReader)
+		defer s2c.Close()
+		reader = s2c
+		size = -1   // Since compressed size is un-predictable.
+		md5hex = "" // Do not try to verify the content.
+	}
+
+	hashReader, err := hash.NewReader(reader, size, md5hex, "", actualSize, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return objInfo, err
+	}
+	rawReader := hashReader
+	pReader := NewPutObjReader(rawReader, nil, nil)
+
+	opts, err := putOpts(ctx, r, bucket, object, metadata)
+	if err != nil {
+		return objInfo, err
+	}
+	// Replicas are written by sources allowed to replicate only.
+	if setReplicationStatus(r, bucket, object, metadata[xhttp.AmzObjectTagging], metadata) != ErrNone {
+		return objInfo, errAccessDenied
+	}
+	setReplicaOpts(obj, r, &opts)
+
+	if obj.IsEncryptionSupported() && crypto.IsRequested(r.Header) && !HasSuffix(object, SlashSeparator) {
+		encReader, objectEncryptionKey, err := EncryptRequest(hashReader, r, bucket, object, metadata)
+		if err != nil {
+			return objInfo, err
+		}
+		encSize := int64(-1)
+		if size >= 0 {
+			info := ObjectInfo{Size: size}
+			encSize = info.EncryptedSize()
+		}
+		// do not try to verify encrypted content
+		hashReader, err = hash.NewReader(encReader, encSize, "", "", size, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return objInfo, err
+		}
+		pReader = NewPutObjReader(rawReader, hashReader, &objectEncryptionKey)
+	}
+
+	// Ensure that metadata does not contain sensitive information
+	crypto.RemoveSensitiveEntries(metadata)
+
+	putObject := obj.PutObject
+	if cache != nil {
+		putObject = cache.PutObject
+	}
+	return putObject(ctx, bucket, object, pReader, opts)
+}
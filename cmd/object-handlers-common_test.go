@@ -17,7 +17,11 @@
 package cmd
 
 import (
+	"net/http"
 	"testing"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Tests - canonicalizeETag()
@@ -51,3 +55,54 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+// Tests - isMultipartETag()
+func TestIsMultipartETag(t *testing.T) {
+	testCases := []struct {
+		etag      string
+		multipart bool
+	}{
+		{"\"3858f62230ac3c915f300c664312c11f-9\"", true},
+		{"3858f62230ac3c915f300c664312c11f-10000", true},
+		{"3858f62230ac3c915f300c664312c11f", false},
+		{"3858f62230ac3c915f300c664312c11f-0", false},
+		{"3858f62230ac3c915f300c664312c11f-x", false},
+		{"zz58f62230ac3c915f300c664312c11f-2", false},
+		{"58f62230ac3c915f300c664312c11f-2", false},
+		{"", false},
+	}
+	for i, test := range testCases {
+		if multipart := isMultipartETag(test.etag); multipart != test.multipart {
+			t.Errorf("Test %d: expected %v for %q, got %v", i+1, test.multipart, test.etag, multipart)
+		}
+	}
+}
+
+// Tests - checkIfRange()
+func TestCheckIfRange(t *testing.T) {
+	modTime := time.Date(2020, time.June, 1, 10, 20, 30, 400, time.UTC)
+	objInfo := ObjectInfo{ETag: "3858f62230ac3c915f300c664312c11f", ModTime: modTime}
+	testCases := []struct {
+		ifRange string
+		ok      bool
+	}{
+		{"", true},
+		{"\"3858f62230ac3c915f300c664312c11f\"", true},
+		{"3858f62230ac3c915f300c664312c11f", true},
+		{"\"3858f62230ac3c915f300c664312c11e\"", false},
+		{"W/\"3858f62230ac3c915f300c664312c11f\"", false},
+		{modTime.Format(http.TimeFormat), true},
+		{modTime.Add(time.Second).Format(http.TimeFormat), false},
+		{modTime.Add(-time.Second).Format(http.TimeFormat), false},
+		{"Monday, 01-Jun-20 10:20:30 GMT", true},
+	}
+	for i, test := range testCases {
+		r := &http.Request{Header: http.Header{}}
+		if test.ifRange != "" {
+			r.Header.Set(xhttp.IfRange, test.ifRange)
+		}
+		if ok := checkIfRange(r, objInfo); ok != test.ok {
+			t.Errorf("Test %d: expected %v for %q, got %v", i+1, test.ok, test.ifRange, ok)
+		}
+	}
+}
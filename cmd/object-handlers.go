@@ -48,6 +48,7 @@ import (
 	"github.com/minio/minio/pkg/hash"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/s3select"
 	"github.com/minio/sio"
 )
@@ -107,7 +108,7 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -228,7 +229,7 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 				Key:        object,
 				Resource:   r.URL.Path,
 				RequestID:  w.Header().Get(xhttp.AmzRequestID),
-				HostID:     globalDeploymentID,
+				HostID:     w.Header().Get(xhttp.AmzRequestHostID),
 			})
 			writeResponse(w, serr.HTTPStatusCode(), encodedErrorResponse, mimeXML)
 		} else {
@@ -246,7 +247,7 @@ func (api objectAPIHandlers) SelectObjectContentHandler(w http.ResponseWriter, r
 				Key:        object,
 				Resource:   r.URL.Path,
 				RequestID:  w.Header().Get(xhttp.AmzRequestID),
-				HostID:     globalDeploymentID,
+				HostID:     w.Header().Get(xhttp.AmzRequestHostID),
 			})
 			writeResponse(w, serr.HTTPStatusCode(), encodedErrorResponse, mimeXML)
 		} else {
@@ -312,7 +313,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -385,7 +386,30 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	// Objects transformed by a hook are always returned whole.
+	hook := globalBucketHooksSys.GetHook(bucket, object)
+	if hook != nil {
+		rs = nil
+	}
+
+	// Every GET of a presigned URL of a share link limited in
+	// downloads counts a download, ranged requests included.
+	if shareID := r.URL.Query().Get(shareLinkQueryKey); shareID != "" && getRequestAuthType(r) == authTypePresigned {
+		if err = checkShareLink(ctx, objectAPI, bucket, object, shareID); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	gr, err := getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, opts)
+	if err == nil && rs != nil && !checkIfRange(r, gr.ObjInfo) {
+		// The object changed since the client got the If-Range
+		// validator, the whole object is returned instead of the
+		// range, not to mix the content of different objects.
+		gr.Close()
+		rs = nil
+		gr, err = getObjectNInfo(ctx, bucket, object, nil, r.Header, readLock, opts)
+	}
 	if err != nil {
 		if globalBucketVersioningSys.Enabled(bucket) && gr != nil {
 			// Versioning enabled quite possibly object is deleted might be delete-marker
@@ -433,14 +457,59 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	var body io.Reader = gr
+	var hookResp *http.Response
+	if hook != nil {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		hookResp, err = globalBucketHooksSys.Transform(ctx, *hook, bucket, objInfo, gr, size)
+		if err != nil {
+			if hook.OnFailure != madmin.HookIgnore {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBucketHookFailed), r.URL, guessIsBrowserReq(r))
+				return
+			}
+
+			// The hook may have consumed part of the object,
+			// the object is read again to return it as is.
+			ogr, err := getObjectNInfo(ctx, bucket, object, nil, r.Header, readLock, opts)
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			defer ogr.Close()
+			body = ogr
+		} else {
+			defer hookResp.Body.Close()
+			body = hookResp.Body
+		}
+	}
+
 	if err = setObjectHeaders(w, objInfo, rs); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
 	// Set Parts Count Header
-	if opts.PartNumber > 0 && len(objInfo.Parts) > 0 {
-		setPartsCountHeaders(w, objInfo)
+	setPartsCountHeaders(w, objInfo)
+
+	// Objects eligible to gzip responses are compressed for clients
+	// accepting gzip, unless transformed by a hook or partially read.
+	gzipResponse := false
+	if hookResp == nil && isGzipEligible(objInfo) {
+		w.Header().Add(xhttp.Vary, xhttp.AcceptEncoding)
+		gzipResponse = rs == nil && opts.PartNumber == 0 && acceptsGzip(r)
+	}
+
+	switch {
+	case hookResp != nil:
+		setTransformedObjectHeaders(w, hookResp)
+	case gzipResponse:
+		setGzipHeaders(w, objInfo)
+	case rs == nil && opts.PartNumber == 0:
+		setChecksumHeaders(w, r, objInfo)
 	}
 
 	setHeadGetRespHeaders(w, r.URL.Query())
@@ -452,8 +521,18 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		w.WriteHeader(http.StatusPartialContent)
 	}
 
+	// Limit the download to the bandwidth of the bucket.
+	body = newBandwidthReader(ctx, r, bucket, body)
+
+	copyFn := io.Copy
+	if gzipResponse {
+		copyFn = gzipCopy
+	}
+
 	// Write object content to response body
-	if _, err = io.Copy(httpWriter, gr); err != nil {
+	n, err := copyFn(httpWriter, body)
+	globalAccessStats.record(bucket, object, n)
+	if err != nil {
 		if !httpWriter.HasWritten() && !statusCodeWritten { // write error response only if no data or headers has been written to client yet
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		}
@@ -502,7 +581,7 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -618,6 +697,12 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// The whole object is returned if it changed since the client
+	// got the If-Range validator.
+	if rs != nil && !checkIfRange(r, objInfo) {
+		rs = nil
+	}
+
 	// Set standard object headers.
 	if err = setObjectHeaders(w, objInfo, rs); err != nil {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
@@ -625,8 +710,22 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	// Set Parts Count Header
-	if opts.PartNumber > 0 && len(objInfo.Parts) > 0 {
-		setPartsCountHeaders(w, objInfo)
+	setPartsCountHeaders(w, objInfo)
+
+	// The headers of the GET response of the same request, objects
+	// eligible to gzip responses are compressed for clients accepting
+	// gzip, unless transformed by a hook or partially read.
+	gzipResponse := false
+	if globalBucketHooksSys.GetHook(bucket, object) == nil && isGzipEligible(objInfo) {
+		w.Header().Add(xhttp.Vary, xhttp.AcceptEncoding)
+		gzipResponse = rs == nil && opts.PartNumber == 0 && acceptsGzip(r)
+	}
+
+	switch {
+	case gzipResponse:
+		setGzipHeaders(w, objInfo)
+	case rs == nil && opts.PartNumber == 0:
+		setChecksumHeaders(w, r, objInfo)
 	}
 
 	// Set any additional requested response headers.
@@ -651,16 +750,132 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	})
 }
 
+// GetObjectAttributesHandler - GET Object?attributes
+// ----------
+// This implementation of the GET operation retrieves the attributes of an
+// object listed in the X-Amz-Object-Attributes header. The parts of multipart
+// objects are returned along with their ETag, the ETag of the object being
+// the MD5 of the MD5 sums of its parts followed by the number of parts.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	defer logger.AuditLog(w, r, "GetObjectAttributes", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header) { // If SSE-S3 or SSE-KMS present -> AWS fails with undefined error
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if !api.EncryptionEnabled() && crypto.IsRequested(r.Header) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapeObjectName(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	attributes, partNumberMarker, maxParts, s3Error := getObjectAttributesArgs(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if maxParts > maxPartsList {
+		maxParts = maxPartsList
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r.Header); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	// The ETags of the parts of encrypted objects are stored
+	// encrypted, return them as sent in the UploadPart responses.
+	if objectAPI.IsEncryptionSupported() && crypto.IsEncrypted(objInfo.UserDefined) {
+		var (
+			objectEncryptionKey []byte
+			ssec                bool
+		)
+		switch {
+		case crypto.SSEC.IsEncrypted(objInfo.UserDefined):
+			// Validate the SSE-C Key set in the header.
+			if _, err = crypto.SSEC.UnsealObjectKey(r.Header, objInfo.UserDefined, bucket, object); err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			ssec = true
+		case crypto.S3.IsEncrypted(objInfo.UserDefined):
+			objectEncryptionKey, err = decryptObjectInfo(nil, bucket, object, objInfo.UserDefined)
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+		parts := make([]ObjectPartInfo, len(objInfo.Parts))
+		for i, part := range objInfo.Parts {
+			part.ETag = tryDecryptETag(objectEncryptionKey, part.ETag, ssec)
+			parts[i] = part
+		}
+		objInfo.Parts = parts
+	}
+
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+
+	response := generateGetObjectAttributesResponse(objInfo, size, attributes, partNumberMarker, maxParts)
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
-func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
+func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, srcInfo ObjectInfo) (map[string]string, error) {
 	// Make a copy of the supplied metadata to avoid
 	// to change the original one.
-	defaultMeta := make(map[string]string, len(userMeta))
-	for k, v := range userMeta {
+	defaultMeta := make(map[string]string, len(srcInfo.UserDefined)+1)
+	for k, v := range srcInfo.UserDefined {
 		defaultMeta[k] = v
 	}
 
+	// Expires is removed from the user defined metadata
+	// when reading the source object, copy it back.
+	if !srcInfo.Expires.IsZero() {
+		defaultMeta["expires"] = srcInfo.Expires.UTC().Format(http.TimeFormat)
+	}
+
 	// remove SSE Headers from source info
 	crypto.RemoveSSEHeaders(defaultMeta)
 
@@ -795,7 +1010,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 	vars := mux.Vars(r)
 	dstBucket := vars["bucket"]
-	dstObject, err := url.PathUnescape(vars["object"])
+	dstObject, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -816,6 +1031,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	srcBucket, srcObject := path2BucketObject(cpSrcPath)
+	srcObject = normalizeObjectName(srcObject)
 	// If source object is empty or bucket is empty, reply back invalid copy source.
 	if srcObject == "" || srcBucket == "" {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCopySource), r.URL, guessIsBrowserReq(r))
@@ -825,7 +1041,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	if vid != "" && vid != nullVersionID {
 		_, err := uuid.Parse(vid)
 		if err != nil {
-			writeErrorResponse(ctx, w, toAPIError(ctx, VersionNotFound{
+			writeErrorResponse(ctx, w, toAPIError(ctx, InvalidVersionID{
 				Bucket:    srcBucket,
 				Object:    srcObject,
 				VersionID: vid,
@@ -890,6 +1106,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
+	// The lease is checked under the lock of the destination, the
+	// metadata only copies check the source which is the destination.
+	leaseCheck := newObjectLeaseCheck(ctx, objectAPI, dstBucket, dstObject, r.Header.Get(xhttp.MinIOLeaseToken))
+	dstOpts.CheckPrecondFn = leaseCheck.precondFn(dstOpts.CheckPrecondFn)
+	if cpSrcDstSame {
+		srcOpts.CheckPrecondFn = leaseCheck.precondFn(srcOpts.CheckPrecondFn)
+	}
+
 	getObjectNInfo := objectAPI.GetObjectNInfo
 
 	var lock = noLock
@@ -929,10 +1153,16 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// We have to copy metadata only if source and destination are same.
 	// this changes for encryption which can be observed below.
-	if cpSrcDstSame {
+	if cpSrcDstSame && srcInfo.TransitionStatus == "" {
 		srcInfo.metadataOnly = true
 	}
 
+	// Copies of transitioned objects are stored locally, their
+	// data is read from the remote tier.
+	if srcInfo.TransitionStatus != "" {
+		removeTransitionMetadata(srcInfo.UserDefined)
+	}
+
 	var chStorageClass bool
 	if dstSc != "" {
 		chStorageClass = true
@@ -952,13 +1182,23 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		}
 		length = actualSize
 	}
+	var newObject bool
 	if !cpSrcDstSame {
-		if err := enforceBucketQuota(ctx, dstBucket, actualSize); err != nil {
+		if newObject, err = enforceBucketQuota(ctx, dstBucket, dstObject, actualSize); err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
 	}
 
+	// Scan the copied content with the antivirus service, if enabled,
+	// the source may have been stored before the service was enabled.
+	scanned, err := newScanReader(ctx, objectAPI, gr, actualSize, dstBucket, dstObject, quarantineObjectName(dstBucket, dstObject))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer scanned.Close()
+
 	var compressMetadata map[string]string
 	// No need to compress for remote etcd calls
 	// Pass the decompressed stream to such calls.
@@ -972,7 +1212,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		// avoid copying them in target object.
 		crypto.RemoveInternalEntries(srcInfo.UserDefined)
 
-		s2c := newS2CompressReader(gr)
+		s2c := newS2CompressReader(scanned)
 		defer s2c.Close()
 		reader = s2c
 		length = -1
@@ -980,7 +1220,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		// Remove the metadata for remote calls.
 		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"compression")
 		delete(srcInfo.UserDefined, ReservedMetadataPrefix+"actual-size")
-		reader = gr
+		reader = scanned
 	}
 
 	srcInfo.Reader, err = hash.NewReader(reader, length, "", "", actualSize, globalCLIContext.StrictS3Compat)
@@ -1104,7 +1344,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	srcInfo.PutObjReader = pReader
 
-	srcInfo.UserDefined, err = getCpObjMetadataFromHeader(ctx, r, srcInfo.UserDefined)
+	srcInfo.UserDefined, err = getCpObjMetadataFromHeader(ctx, r, srcInfo)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -1150,6 +1390,11 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if s3Err = setReplicationStatus(r, dstBucket, dstObject, objTags, srcInfo.UserDefined); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Store the preserved compression metadata.
 	for k, v := range compressMetadata {
 		srcInfo.UserDefined[k] = v
@@ -1219,9 +1464,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		// object is same then only metadata is updated.
 		objInfo, err = copyObjectFn(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
 		if err != nil {
+			err = leaseCheck.objectErr(err)
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 			return
 		}
+		if !cpSrcDstSame {
+			accountBucketQuota(dstBucket, actualSize, newObject)
+		}
 	}
 
 	objInfo.ETag = getDecryptedETag(r.Header, objInfo, false)
@@ -1278,7 +1527,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -1386,7 +1635,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 
 	case authTypePresigned, authTypeSigned:
-		if s3Err = reqSignatureV4Verify(r, globalServerRegion, serviceS3); s3Err != ErrNone {
+		if s3Err = reqSignatureV4Verify(r, getBucketRegion(bucket), serviceS3); s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -1395,11 +1644,20 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+	if err := checkObjectLease(ctx, objectAPI, bucket, object, r.Header.Get(xhttp.MinIOLeaseToken)); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	newObject, err := enforceBucketQuota(ctx, bucket, object, size)
+	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// Limit the upload to the bandwidth of the bucket.
+	reader = newBandwidthReader(ctx, r, bucket, reader)
+
 	// Check if bucket encryption is enabled
 	_, err = globalBucketSSEConfigSys.Get(bucket)
 	// This request header needs to be set prior to setting ObjectOptions
@@ -1407,6 +1665,18 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
 
+	// Scan the content with the antivirus service, if enabled.
+	scanned, err := newScanReader(ctx, objectAPI, reader, size, bucket, object, quarantineObjectName(bucket, object))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer scanned.Close()
+	reader = scanned
+
+	// Detect the content-type of objects uploaded without one, if enabled.
+	reader = sniffContentType(r, object, reader, metadata)
+
 	actualSize := size
 
 	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
@@ -1429,7 +1699,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		sha256hex = ""
 	}
 
-	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
+	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, computeMD5(md5hex, sha256hex))
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -1446,6 +1716,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Check the lease again under the lock of the object.
+	leaseCheck := newObjectLeaseCheck(ctx, objectAPI, bucket, object, r.Header.Get(xhttp.MinIOLeaseToken))
+	opts.CheckPrecondFn = leaseCheck.precondFn(opts.CheckPrecondFn)
+
 	if api.CacheAPI() != nil {
 		putObject = api.CacheAPI().PutObject
 	}
@@ -1471,6 +1745,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if s3Err = setReplicationStatus(r, bucket, object, metadata[xhttp.AmzObjectTagging], metadata); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setReplicaOpts(objectAPI, r, &opts)
+
 	var objectEncryptionKey crypto.ObjectKey
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsRequested(r.Header) && !HasSuffix(object, SlashSeparator) { // handle SSE requests
@@ -1502,9 +1782,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	// Create the object..
 	objInfo, err := putObject(ctx, bucket, object, pReader, opts)
 	if err != nil {
-		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		writeErrorResponse(ctx, w, toAPIError(ctx, leaseCheck.objectErr(err)), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	accountBucketQuota(bucket, size, newObject)
 
 	switch {
 	case objInfo.IsCompressed():
@@ -1520,7 +1801,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			w.Header().Set(crypto.SSECAlgorithm, r.Header.Get(crypto.SSECAlgorithm))
 			w.Header().Set(crypto.SSECKeyMD5, r.Header.Get(crypto.SSECKeyMD5))
 
-			if len(objInfo.ETag) >= 32 && strings.Count(objInfo.ETag, "-") != 1 {
+			if len(objInfo.ETag) >= 32 && !isMultipartETag(objInfo.ETag) {
 				objInfo.ETag = objInfo.ETag[len(objInfo.ETag)-32:]
 			}
 		}
@@ -1570,7 +1851,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -1638,6 +1919,11 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if s3Err = setReplicationStatus(r, bucket, object, r.Header.Get(xhttp.AmzObjectTagging), metadata); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -1694,7 +1980,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 
 	vars := mux.Vars(r)
 	dstBucket := vars["bucket"]
-	dstObject, err := url.PathUnescape(vars["object"])
+	dstObject, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -1705,6 +1991,13 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// The parts are not visible until the upload is completed, the
+	// lease is checked again under the lock of the object then.
+	if err := checkObjectLease(ctx, objectAPI, dstBucket, dstObject, r.Header.Get(xhttp.MinIOLeaseToken)); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Read escaped copy source path to check for parameters.
 	cpSrcPath := r.Header.Get(xhttp.AmzCopySource)
 	var vid string
@@ -1718,6 +2011,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	srcBucket, srcObject := path2BucketObject(cpSrcPath)
+	srcObject = normalizeObjectName(srcObject)
 	// If source object is empty or bucket is empty, reply back invalid copy source.
 	if srcObject == "" || srcBucket == "" {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCopySource), r.URL, guessIsBrowserReq(r))
@@ -1727,7 +2021,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	if vid != "" && vid != nullVersionID {
 		_, err := uuid.Parse(vid)
 		if err != nil {
-			writeErrorResponse(ctx, w, toAPIError(ctx, VersionNotFound{
+			writeErrorResponse(ctx, w, toAPIError(ctx, InvalidVersionID{
 				Bucket:    srcBucket,
 				Object:    srcObject,
 				VersionID: vid,
@@ -1827,7 +2121,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 			return
 		}
 	}
-	if err := enforceBucketQuota(ctx, dstBucket, actualPartSize); err != nil {
+	if _, err := enforceBucketQuota(ctx, dstBucket, "", actualPartSize); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -1889,16 +2183,26 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Scan the copied content with the antivirus service, if enabled,
+	// the source may have been stored before the service was enabled.
+	scanned, err := newScanReader(ctx, objectAPI, gr, length, dstBucket, dstObject,
+		quarantineObjectName(dstBucket, pathJoin(dstObject, uploadID, strconv.Itoa(partID))))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer scanned.Close()
+
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 	// Compress only if the compression is enabled during initial multipart.
 	if isCompressed {
-		s2c := newS2CompressReader(gr)
+		s2c := newS2CompressReader(scanned)
 		defer s2c.Close()
 		reader = s2c
 		length = -1
 	} else {
-		reader = gr
+		reader = scanned
 	}
 
 	srcInfo.Reader, err = hash.NewReader(reader, length, "", "", actualPartSize, globalCLIContext.StrictS3Compat)
@@ -2000,7 +2304,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	}
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2092,7 +2396,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 			return
 		}
 	case authTypePresigned, authTypeSigned:
-		if s3Error = reqSignatureV4Verify(r, globalServerRegion, serviceS3); s3Error != ErrNone {
+		if s3Error = reqSignatureV4Verify(r, getBucketRegion(bucket), serviceS3); s3Error != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -2102,11 +2406,31 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		}
 	}
 
-	if err := enforceBucketQuota(ctx, bucket, size); err != nil {
+	// The parts are not visible until the upload is completed, the
+	// lease is checked again under the lock of the object then.
+	if err := checkObjectLease(ctx, objectAPI, bucket, object, r.Header.Get(xhttp.MinIOLeaseToken)); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if _, err := enforceBucketQuota(ctx, bucket, "", size); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// Limit the upload to the bandwidth of the bucket.
+	reader = newBandwidthReader(ctx, r, bucket, reader)
+
+	// Scan the content with the antivirus service, if enabled.
+	scanned, err := newScanReader(ctx, objectAPI, reader, size, bucket, object,
+		quarantineObjectName(bucket, pathJoin(object, uploadID, strconv.Itoa(partID))))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer scanned.Close()
+	reader = scanned
+
 	actualSize := size
 
 	// get encryption options
@@ -2144,7 +2468,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		sha256hex = ""
 	}
 
-	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
+	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, computeMD5(md5hex, sha256hex))
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2235,7 +2559,7 @@ func (api objectAPIHandlers) AbortMultipartUploadHandler(w http.ResponseWriter,
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2274,7 +2598,7 @@ func (api objectAPIHandlers) ListObjectPartsHandler(w http.ResponseWriter, r *ht
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2413,7 +2737,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2443,8 +2767,11 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	// The max. XML contains the max. number of parts (each at most 1 KiB long) + XML overhead
+	maxBodySize := 2 * int64(globalAPIConfig.getMaxParts()) * 1024
+
 	complMultipartUpload := &CompleteMultipartUpload{}
-	if err = xmlDecoder(r.Body, complMultipartUpload, r.ContentLength); err != nil {
+	if err = xmlDecoder(r.Body, complMultipartUpload, maxBodySize); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
@@ -2456,6 +2783,11 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidPartOrder), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	// Parts are sorted, checking the last part ID is enough.
+	if isMaxPartID(complMultipartUpload.Parts[len(complMultipartUpload.Parts)-1].PartNumber) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidMaxParts), r.URL, guessIsBrowserReq(r))
+		return
+	}
 
 	// Reject retention or governance headers if set, CompleteMultipartUpload spec
 	// does not use these headers, and should not be passed down to checkPutObjectLockAllowed
@@ -2469,6 +2801,15 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		return
 	}
 
+	// The parts are checked against the size quota when uploaded but
+	// only accounted with the completed object, only check whether the
+	// bucket has room for the object unless it overwrites one.
+	newObject, err := enforceBucketQuota(ctx, bucket, object, 0)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	var objectEncryptionKey []byte
 	var isEncrypted, ssec bool
 	var opts ObjectOptions
@@ -2525,6 +2866,9 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		completeParts = append(completeParts, part)
 	}
 
+	leaseCheck := newObjectLeaseCheck(ctx, objectAPI, bucket, object, r.Header.Get(xhttp.MinIOLeaseToken))
+	opts.CheckPrecondFn = leaseCheck.precondFn(opts.CheckPrecondFn)
+
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 
 	// This code is specifically to handle the requirements for slow
@@ -2539,7 +2883,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 
 		// Generate error response.
 		errorResponse := getAPIErrorResponse(ctx, err, reqURL.Path,
-			w.Header().Get(xhttp.AmzRequestID), globalDeploymentID)
+			w.Header().Get(xhttp.AmzRequestID), w.Header().Get(xhttp.AmzRequestHostID))
 		encodedErrorResponse, _ := xml.Marshal(errorResponse)
 		setCommonHeaders(w)
 		w.Header().Set(xhttp.ContentType, string(mimeXML))
@@ -2550,10 +2894,14 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	w = &whiteSpaceWriter{ResponseWriter: w, Flusher: w.(http.Flusher)}
 	completeDoneCh := sendWhiteSpace(w)
 	objInfo, err := completeMultiPartUpload(ctx, bucket, object, uploadID, completeParts, opts)
+	if err == nil {
+		err = scanObject(ctx, objectAPI, bucket, object, objInfo, r.Header)
+	}
 	// Stop writing white spaces to the client. Note that close(doneCh) style is not used as it
 	// can cause white space to be written after we send XML response in a race condition.
 	headerWritten := <-completeDoneCh
 	if err != nil {
+		err = leaseCheck.objectErr(err)
 		if headerWritten {
 			writeErrorResponseWithoutXMLHeader(ctx, w, toAPIError(ctx, err), r.URL)
 		} else {
@@ -2561,6 +2909,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		}
 		return
 	}
+	accountBucketQuota(bucket, objInfo.Size, newObject)
 
 	// Get object location.
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
@@ -2604,7 +2953,7 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2640,6 +2989,23 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
+	leaseCheck := newObjectLeaseCheck(ctx, objectAPI, bucket, object, r.Header.Get(xhttp.MinIOLeaseToken))
+	opts.CheckPrecondFn = leaseCheck.precondFn(opts.CheckPrecondFn)
+
+	if r.Header.Get(xhttp.MinIOSourceReplicationRequest) == "true" {
+		if s3Err := checkReplicaDelete(ctx, r, bucket, object, getObjectInfo); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	if opts.VersionID != "" {
+		if err = checkBucketSnapshotVersion(ctx, objectAPI, bucket, object, opts.VersionID); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
 	apiErr := ErrNone
 	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled {
 		if opts.VersionID != "" {
@@ -2663,6 +3029,10 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 				// When bucket doesn't exist specially handle it.
 				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 				return
+			case PreConditionFailed:
+				// The lease token of the delete is not valid.
+				writeErrorResponse(ctx, w, toAPIError(ctx, leaseCheck.objectErr(err)), r.URL, guessIsBrowserReq(r))
+				return
 			}
 			// Ignore delete object errors while replying to client, since we are suppposed to reply only 204.
 		}
@@ -2680,7 +3050,7 @@ func (api objectAPIHandlers) PutObjectLegalHoldHandler(w http.ResponseWriter, r
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2770,7 +3140,7 @@ func (api objectAPIHandlers) GetObjectLegalHoldHandler(w http.ResponseWriter, r
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2835,7 +3205,7 @@ func (api objectAPIHandlers) PutObjectRetentionHandler(w http.ResponseWriter, r
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2933,7 +3303,7 @@ func (api objectAPIHandlers) GetObjectRetentionHandler(w http.ResponseWriter, r
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -2993,7 +3363,7 @@ func (api objectAPIHandlers) GetObjectTaggingHandler(w http.ResponseWriter, r *h
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -3038,7 +3408,7 @@ func (api objectAPIHandlers) PutObjectTaggingHandler(w http.ResponseWriter, r *h
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -3099,7 +3469,7 @@ func (api objectAPIHandlers) DeleteObjectTaggingHandler(w http.ResponseWriter, r
 
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
@@ -3125,3 +3495,84 @@ func (api objectAPIHandlers) DeleteObjectTaggingHandler(w http.ResponseWriter, r
 
 	writeSuccessNoContent(w)
 }
+
+// RestoreObjectRequest - restore request of an object
+// transitioned to an archival remote tier.
+type RestoreObjectRequest struct {
+	XMLName xml.Name `xml:"RestoreRequest"`
+	Days    int      `xml:"Days"`
+	Type    string   `xml:"Type,omitempty"`
+}
+
+// PostRestoreObjectHandler - POST restore object handler.
+// ----------
+// Makes the data of an object transitioned to a Glacier
+// remote tier readable for the requested number of days.
+func (api objectAPIHandlers) PostRestoreObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PostRestoreObject")
+	defer logger.AuditLog(w, r, "PostRestoreObject", mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapeObjectName(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Allow restoreObject if policy action is set.
+	if s3Error := checkRequestAuthType(ctx, r, policy.RestoreObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	var rreq RestoreObjectRequest
+	if err = xmlDecoder(r.Body, &rreq, r.ContentLength); err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	// Restores for S3 Select are not supported.
+	if rreq.Type != "" {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if rreq.Days <= 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	// Only objects transitioned to archival tiers can be restored.
+	if objInfo.TransitionStatus == "" {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidObjectState), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	restored, err := restoreTransitionedObject(ctx, objAPI, objInfo, rreq.Days)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if restored {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+	writeResponse(w, http.StatusAccepted, nil, mimeNone)
+}
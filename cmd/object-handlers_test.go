@@ -35,8 +35,11 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/klauspost/compress/gzip"
+	"github.com/minio/minio/cmd/config/api"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
@@ -323,6 +326,275 @@ func testAPIHeadObjectHandlerWithEncryption(obj ObjectLayer, instanceType, bucke
 }
 
 // Wrapper for calling GetObject API handler tests for both Erasure multiple disks and FS single drive setup.
+// Wrapper for calling GetObjectAttributes API handler tests for both Erasure multiple disks and FS single drive setup.
+func TestAPIGetObjectAttributesHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testAPIGetObjectAttributesHandler, []string{"GetObjectAttributes"})
+}
+
+func testAPIGetObjectAttributesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "test-object"
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to create a new multipart upload: <ERROR> %v", instanceType, err)
+	}
+	var completeParts []CompletePart
+	for i, data := range [][]byte{generateBytesData(5 * humanize.MiByte), generateBytesData(humanize.MiByte)} {
+		md5hex := getMD5Hash(data)
+		_, err = obj.PutObjectPart(context.Background(), bucketName, objectName, uploadID, i+1,
+			mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5hex, ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Failed to upload part %d: <ERROR> %v", instanceType, i+1, err)
+		}
+		completeParts = append(completeParts, CompletePart{PartNumber: i + 1, ETag: md5hex})
+	}
+	objInfo, err := obj.CompleteMultipartUpload(context.Background(), bucketName, objectName, uploadID, completeParts, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to complete the multipart upload: <ERROR> %v", instanceType, err)
+	}
+	if objInfo.ETag != getCompleteMultipartMD5(completeParts) {
+		t.Fatalf("%s: Expected ETag %s, got %s", instanceType, getCompleteMultipartMD5(completeParts), objInfo.ETag)
+	}
+
+	testCases := []struct {
+		attributes         string
+		expectedRespStatus int
+	}{
+		{"ETag,ObjectParts,ObjectSize", http.StatusOK},
+		{"Owner", http.StatusBadRequest},
+		{"", http.StatusBadRequest},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("GET", getObjectAttributesURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzObjectAttributes: testCase.attributes})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for Get Object Attributes: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var response GetObjectAttributesResponse
+		if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Test %d: %s: Failed to parse the response: <ERROR> %v", i+1, instanceType, err)
+		}
+		if response.ETag != objInfo.ETag {
+			t.Errorf("Test %d: %s: Expected ETag %s, got %s", i+1, instanceType, objInfo.ETag, response.ETag)
+		}
+		if response.ObjectSize == nil || *response.ObjectSize != 6*humanize.MiByte {
+			t.Errorf("Test %d: %s: Expected object size %d, got %v", i+1, instanceType, 6*humanize.MiByte, response.ObjectSize)
+		}
+		if response.ObjectParts == nil || len(response.ObjectParts.Parts) != len(completeParts) {
+			t.Fatalf("Test %d: %s: Expected %d parts, got %+v", i+1, instanceType, len(completeParts), response.ObjectParts)
+		}
+		for j, part := range response.ObjectParts.Parts {
+			if part.PartNumber != completeParts[j].PartNumber || part.ETag != completeParts[j].ETag {
+				t.Errorf("Test %d: %s: Expected part %d with ETag %s, got %+v", i+1, instanceType, completeParts[j].PartNumber, completeParts[j].ETag, part)
+			}
+		}
+	}
+}
+
+// Tests GetObject and HeadObject with If-Range, the range is only returned
+// if the object still matches the validator.
+func TestAPIGetObjectIfRange(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectIfRange, []string{"GetObject", "HeadObject"})
+}
+
+func testAPIGetObjectIfRange(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "test-object"
+	data := generateBytesData(humanize.KiByte)
+	objInfo, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to put the object: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		ifRange    string
+		statusCode int
+		body       []byte
+	}{
+		{"", http.StatusPartialContent, data[10:20]},
+		{"\"" + objInfo.ETag + "\"", http.StatusPartialContent, data[10:20]},
+		{"\"3858f62230ac3c915f300c664312c11f\"", http.StatusOK, data},
+		{"W/\"" + objInfo.ETag + "\"", http.StatusOK, data},
+		{objInfo.ModTime.UTC().Format(http.TimeFormat), http.StatusPartialContent, data[10:20]},
+		{objInfo.ModTime.UTC().Add(-time.Hour).Format(http.TimeFormat), http.StatusOK, data},
+	}
+	for i, testCase := range testCases {
+		headers := map[string]string{xhttp.Range: "bytes=10-19"}
+		if testCase.ifRange != "" {
+			headers[xhttp.IfRange] = testCase.ifRange
+		}
+		for _, method := range []string{http.MethodGet, http.MethodHead} {
+			rec := httptest.NewRecorder()
+			req, err := newTestSignedRequestV4(method, getGetObjectURL("", bucketName, objectName),
+				0, nil, credentials.AccessKey, credentials.SecretKey, headers)
+			if err != nil {
+				t.Fatalf("Test %d: %s: Failed to create the request: <ERROR> %v", i+1, instanceType, err)
+			}
+			apiRouter.ServeHTTP(rec, req)
+			if rec.Code != testCase.statusCode {
+				t.Fatalf("Test %d: %s: %s: Expected the response status to be %d, got %d", i+1, instanceType, method, testCase.statusCode, rec.Code)
+			}
+			if rec.Header().Get(xhttp.ContentLength) != strconv.Itoa(len(testCase.body)) {
+				t.Errorf("Test %d: %s: %s: Expected content-length %d, got %s", i+1, instanceType, method, len(testCase.body), rec.Header().Get(xhttp.ContentLength))
+			}
+			if method == http.MethodGet && !bytes.Equal(rec.Body.Bytes(), testCase.body) {
+				t.Errorf("Test %d: %s: Expected the content of the response to be %d bytes of the object", i+1, instanceType, len(testCase.body))
+			}
+		}
+	}
+}
+
+// Tests the parts count, checksum and replication status headers of HeadObject.
+func TestAPIHeadObjectStatusHeaders(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIHeadObjectStatusHeaders, []string{"HeadObject"})
+}
+
+func testAPIHeadObjectStatusHeaders(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	ctx := context.Background()
+	data := generateBytesData(humanize.KiByte)
+	md5sum := md5.Sum(data)
+	md5Hex := hex.EncodeToString(md5sum[:])
+
+	metadata := map[string]string{xhttp.AmzBucketReplicationStatus: "COMPLETED"}
+	if _, err := obj.PutObject(ctx, bucketName, "single", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), ObjectOptions{UserDefined: metadata}); err != nil {
+		t.Fatalf("%s: Failed to put the object: <ERROR> %v", instanceType, err)
+	}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucketName, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to create the upload: <ERROR> %v", instanceType, err)
+	}
+	part, err := obj.PutObjectPart(ctx, bucketName, "multipart", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5Hex, ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to upload the part: <ERROR> %v", instanceType, err)
+	}
+	if _, err = obj.CompleteMultipartUpload(ctx, bucketName, "multipart", uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to complete the upload: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		object            string
+		headers           map[string]string
+		partsCount        string
+		contentMD5        string
+		replicationStatus string
+	}{
+		{"single", nil, "", "", "COMPLETED"},
+		{"single", map[string]string{xhttp.AmzChecksumMode: "ENABLED"}, "", base64.StdEncoding.EncodeToString(md5sum[:]), "COMPLETED"},
+		{"single", map[string]string{xhttp.AmzChecksumMode: "ENABLED", xhttp.Range: "bytes=0-9"}, "", "", "COMPLETED"},
+		{"multipart", map[string]string{xhttp.AmzChecksumMode: "ENABLED"}, "1", "", ""},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodHead, getHeadObjectURL("", bucketName, testCase.object),
+			0, nil, credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create the request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK && rec.Code != http.StatusPartialContent {
+			t.Fatalf("Test %d: %s: Expected the response status to be 200 or 206, got %d", i+1, instanceType, rec.Code)
+		}
+		if got := strings.Join(rec.Header()[xhttp.AmzMpPartsCount], ","); got != testCase.partsCount {
+			t.Errorf("Test %d: %s: Expected parts count %q, got %q", i+1, instanceType, testCase.partsCount, got)
+		}
+		if got := rec.Header().Get(xhttp.ContentMD5); got != testCase.contentMD5 {
+			t.Errorf("Test %d: %s: Expected Content-MD5 %q, got %q", i+1, instanceType, testCase.contentMD5, got)
+		}
+		if got := rec.Header().Get(xhttp.AmzBucketReplicationStatus); got != testCase.replicationStatus {
+			t.Errorf("Test %d: %s: Expected replication status %q, got %q", i+1, instanceType, testCase.replicationStatus, got)
+		}
+	}
+}
+
+// Tests GetObject and HeadObject with gzip responses enabled.
+func TestAPIGetObjectGzip(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectGzip, []string{"GetObject", "HeadObject"})
+}
+
+func testAPIGetObjectGzip(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	defer globalAPIConfig.init(api.Config{})
+	globalAPIConfig.init(api.Config{APIGzipResponses: true})
+
+	data := bytes.Repeat([]byte("hello world\n"), 1024)
+	objInfo, err := obj.PutObject(context.Background(), bucketName, "notes.txt", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""),
+		ObjectOptions{UserDefined: map[string]string{"content-type": "text/plain"}})
+	if err != nil {
+		t.Fatalf("%s: Failed to put the object: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		method     string
+		headers    map[string]string
+		statusCode int
+		gzipped    bool
+	}{
+		{http.MethodGet, nil, http.StatusOK, false},
+		{http.MethodGet, map[string]string{xhttp.AcceptEncoding: "gzip"}, http.StatusOK, true},
+		{http.MethodGet, map[string]string{xhttp.AcceptEncoding: "gzip", xhttp.Range: "bytes=0-9"}, http.StatusPartialContent, false},
+		{http.MethodGet, map[string]string{xhttp.AcceptEncoding: "gzip", xhttp.IfNoneMatch: "W/\"" + objInfo.ETag + "\""}, http.StatusNotModified, false},
+		// HEAD returns the headers of the GET response.
+		{http.MethodHead, nil, http.StatusOK, false},
+		{http.MethodHead, map[string]string{xhttp.AcceptEncoding: "gzip"}, http.StatusOK, true},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, getGetObjectURL("", bucketName, "notes.txt"),
+			0, nil, credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create the request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.statusCode {
+			t.Fatalf("Test %d: %s: Expected the response status to be %d, got %d", i+1, instanceType, testCase.statusCode, rec.Code)
+		}
+		if testCase.statusCode == http.StatusNotModified {
+			continue
+		}
+		if vary := rec.Header().Get(xhttp.Vary); vary != xhttp.AcceptEncoding {
+			t.Errorf("Test %d: %s: Expected Vary to be %s, got %q", i+1, instanceType, xhttp.AcceptEncoding, vary)
+		}
+		if !testCase.gzipped {
+			if rec.Header().Get(xhttp.ContentEncoding) != "" || strings.Join(rec.Header()[xhttp.ETag], "") != "\""+objInfo.ETag+"\"" {
+				t.Errorf("Test %d: %s: Expected an uncompressed response with a strong ETag, got %q, %q", i+1, instanceType,
+					rec.Header().Get(xhttp.ContentEncoding), rec.Header()[xhttp.ETag])
+			}
+			continue
+		}
+		if rec.Header().Get(xhttp.ContentEncoding) != "gzip" || rec.Header().Get(xhttp.ContentLength) != "" {
+			t.Errorf("Test %d: %s: Expected a gzip response without content-length, got %q, %q", i+1, instanceType,
+				rec.Header().Get(xhttp.ContentEncoding), rec.Header().Get(xhttp.ContentLength))
+		}
+		if etag := strings.Join(rec.Header()[xhttp.ETag], ""); etag != "W/\""+objInfo.ETag+"\"" {
+			t.Errorf("Test %d: %s: Expected a weak ETag, got %q", i+1, instanceType, etag)
+		}
+		if testCase.method == http.MethodHead {
+			continue
+		}
+		gr, err := gzip.NewReader(rec.Body)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to read the gzip response: <ERROR> %v", i+1, instanceType, err)
+		}
+		body, err := ioutil.ReadAll(gr)
+		if err != nil || !bytes.Equal(body, data) {
+			t.Errorf("Test %d: %s: Expected the response to decompress to the object, got %d bytes: %v", i+1, instanceType, len(body), err)
+		}
+	}
+}
+
 func TestAPIGetObjectHandler(t *testing.T) {
 	globalPolicySys = NewPolicySys()
 	defer func() { globalPolicySys = nil }()
@@ -2269,6 +2541,120 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 
 }
 
+// Wrapper for calling the tests of the metadata preserved by CopyObject and
+// CompleteMultipartUpload for both Erasure multiple disks and FS single drive setup.
+func TestAPIObjectMetadataPreserved(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIObjectMetadataPreserved, []string{"CopyObject", "NewMultipart"})
+}
+
+func testAPIObjectMetadataPreserved(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	headers := map[string]string{
+		"Content-Type":        "text/html",
+		"Content-Encoding":    "gzip",
+		"Content-Language":    "fr-CA",
+		"Content-Disposition": "attachment; filename=\"index.html\"",
+		"Cache-Control":       "max-age=3600",
+		"Expires":             "Tue, 21 Oct 2025 07:28:00 GMT",
+		"X-Amz-Meta-Owner":    "alice",
+		"X-Amz-Meta-Project":  "Minio",
+	}
+	checkMetadata := func(object string) {
+		t.Helper()
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, object, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Failed to get the info of %s: <ERROR> %v", instanceType, object, err)
+		}
+		if objInfo.ContentType != headers["Content-Type"] || objInfo.ContentEncoding != headers["Content-Encoding"] {
+			t.Errorf("%s: %s: Expected content-type and content-encoding %s, %s, got %s, %s", instanceType, object,
+				headers["Content-Type"], headers["Content-Encoding"], objInfo.ContentType, objInfo.ContentEncoding)
+		}
+		if objInfo.Expires.Format(http.TimeFormat) != headers["Expires"] {
+			t.Errorf("%s: %s: Expected expires %s, got %s", instanceType, object, headers["Expires"], objInfo.Expires.Format(http.TimeFormat))
+		}
+		rec := httptest.NewRecorder()
+		if err = setObjectHeaders(rec, objInfo, nil); err != nil {
+			t.Fatalf("%s: Failed to set the headers of %s: <ERROR> %v", instanceType, object, err)
+		}
+		for k, v := range headers {
+			got := rec.Header().Get(k)
+			if strings.HasPrefix(k, "X-Amz-Meta-") {
+				// User metadata is sent with lower case keys.
+				got = strings.Join(rec.Header()[strings.ToLower(k)], ",")
+			}
+			if got != v {
+				t.Errorf("%s: %s: Expected header %s to be %q, got %q", instanceType, object, k, v, got)
+			}
+		}
+	}
+
+	// Metadata of the source object is copied with the COPY directive.
+	metadata := make(map[string]string)
+	for k, v := range headers {
+		if strings.HasPrefix(k, "X-Amz-Meta-") {
+			metadata[k] = v
+		} else {
+			metadata[strings.ToLower(k)] = v
+		}
+	}
+	data := generateBytesData(humanize.KiByte)
+	_, err := obj.PutObject(context.Background(), bucketName, "source", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{UserDefined: metadata})
+	if err != nil {
+		t.Fatalf("%s: Failed to put the source object: <ERROR> %v", instanceType, err)
+	}
+	checkMetadata("source")
+
+	for _, directive := range []string{"", copyDirective} {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("PUT", getCopyObjectURL("", bucketName, "copy"+directive),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for Copy Object: <ERROR> %v", instanceType, err)
+		}
+		req.Header.Set(xhttp.AmzCopySource, url.QueryEscape(SlashSeparator+bucketName+SlashSeparator+"source"))
+		if directive != "" {
+			req.Header.Set(xhttp.AmzMetadataDirective, directive)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("%s: Failed to sign the Copy Object request: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		checkMetadata("copy" + directive)
+	}
+
+	// Metadata set at NewMultipartUpload is kept by CompleteMultipartUpload.
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4("POST", getNewMultipartURL("", bucketName, "multipart"),
+		0, nil, credentials.AccessKey, credentials.SecretKey, headers)
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for NewMultipart Request: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	multipartResponse := &InitiateMultipartUploadResponse{}
+	if err = xml.NewDecoder(rec.Body).Decode(multipartResponse); err != nil {
+		t.Fatalf("%s: Error decoding the recorded response Body: <ERROR> %v", instanceType, err)
+	}
+	md5hex := getMD5Hash(data)
+	_, err = obj.PutObjectPart(context.Background(), bucketName, "multipart", multipartResponse.UploadID, 1,
+		mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), md5hex, ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to upload a part: <ERROR> %v", instanceType, err)
+	}
+	_, err = obj.CompleteMultipartUpload(context.Background(), bucketName, "multipart", multipartResponse.UploadID,
+		[]CompletePart{{PartNumber: 1, ETag: md5hex}}, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: Failed to complete the multipart upload: <ERROR> %v", instanceType, err)
+	}
+	checkMetadata("multipart")
+}
+
 // Wrapper for calling NewMultipartUpload tests for both Erasure multiple disks and single node setup.
 // First register the HTTP handler for NewMutlipartUpload, then a HTTP request for NewMultipart upload is made.
 // The UploadID from the response body is parsed and its existence is asserted with an attempt to ListParts using it.
@@ -0,0 +1,130 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+)
+
+// PostObjectLeaseHandler - POST Object lease, a MinIO extension which
+// takes or renews the advisory lease of an object.
+// ----------
+// The lease lasts x-minio-lease-duration seconds, the lease is renewed
+// when the request carries the x-minio-lease-token of the active lease.
+// The token and the expiry of the lease are sent back in the
+// x-minio-lease-token and x-minio-lease-expiry headers.
+func (api objectAPIHandlers) PostObjectLeaseHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PostObjectLease")
+
+	defer logger.AuditLog(w, r, "PostObjectLease", mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapeObjectName(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Only the writers of the object coordinate with leases.
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	duration, err := parseObjectLeaseDuration(r.Header.Get(xhttp.MinIOLeaseDuration))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if _, err = objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	lease, err := acquireObjectLease(ctx, objectAPI, bucket, object, r.Header.Get(xhttp.MinIOLeaseToken), duration)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	w.Header().Set(xhttp.MinIOLeaseToken, lease.Token)
+	w.Header().Set(xhttp.MinIOLeaseExpiry, lease.Expiry.UTC().Format(time.RFC3339))
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// DeleteObjectLeaseHandler - DELETE Object lease, a MinIO extension
+// which releases the advisory lease of an object held with the
+// x-minio-lease-token of the request.
+func (api objectAPIHandlers) DeleteObjectLeaseHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteObjectLease")
+
+	defer logger.AuditLog(w, r, "DeleteObjectLease", mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapeObjectName(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	token := r.Header.Get(xhttp.MinIOLeaseToken)
+	if token == "" {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidLeaseToken), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = releaseObjectLease(ctx, objectAPI, bucket, object, token); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
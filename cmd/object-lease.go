@@ -0,0 +1,300 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Leases of the objects are saved under this prefix of the
+	// minio meta bucket, one lease per leased object.
+	objectLeasePrefix   = "leases"
+	objectLeaseFileName = "lease.json"
+
+	objectLeaseDefaultDuration = 30 * time.Second
+	objectLeaseMaxDuration     = 5 * time.Minute
+
+	// Expired leases left behind by their holders are
+	// removed at this interval.
+	objectLeaseGCInterval = 10 * time.Minute
+)
+
+var (
+	errObjectLeased         = errors.New("object is leased by another client")
+	errInvalidLeaseToken    = errors.New("lease token is not valid for the object or its lease expired")
+	errInvalidLeaseDuration = errors.New("lease duration must be a number of seconds between 1 and 300")
+)
+
+// objectLeaseLockTimeout - timeout of the lock serializing the lease
+// operations of an object.
+var objectLeaseLockTimeout = newDynamicTimeout(30*time.Second, 5*time.Second)
+
+// objectLease is an advisory lease of an object. Writers coordinating
+// with each other take the lease before writing the object and send its
+// token along with their writes, a write with the token of an expired
+// or a taken over lease is refused. Writes without a token are never
+// refused, the lease only binds the writers using it.
+type objectLease struct {
+	Token  string    `json:"token"`
+	Expiry time.Time `json:"expiry"`
+}
+
+func (l objectLease) active(now time.Time) bool {
+	return now.Before(l.Expiry)
+}
+
+func objectLeasePath(bucket, object string) string {
+	return pathJoin(objectLeasePrefix, bucket, object, objectLeaseFileName)
+}
+
+// newObjectLeaseLock returns the lock of the lease operations of the
+// object, apart from the lock of the object holding the lease.
+func newObjectLeaseLock(ctx context.Context, objAPI ObjectLayer, bucket, object string) RWLocker {
+	return objAPI.NewNSLock(ctx, minioMetaBucket, pathJoin(objectLeasePrefix, bucket, object))
+}
+
+// parseObjectLeaseDuration parses the duration in seconds of a lease,
+// the default duration when empty.
+func parseObjectLeaseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return objectLeaseDefaultDuration, nil
+	}
+	seconds, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errInvalidLeaseDuration
+	}
+	duration := time.Duration(seconds) * time.Second
+	if duration <= 0 || duration > objectLeaseMaxDuration {
+		return 0, errInvalidLeaseDuration
+	}
+	return duration, nil
+}
+
+func readObjectLease(ctx context.Context, objAPI ObjectLayer, bucket, object string) (objectLease, error) {
+	var lease objectLease
+	data, err := readConfig(ctx, objAPI, objectLeasePath(bucket, object))
+	if err != nil {
+		return lease, err
+	}
+	err = json.Unmarshal(data, &lease)
+	return lease, err
+}
+
+// acquireObjectLease takes the lease of the object for the duration, or
+// renews it when token is the token of the active lease. The operations
+// on the lease of an object are serialized by the namespace lock, the
+// distributed lock of the cluster in distributed mode.
+func acquireObjectLease(ctx context.Context, objAPI ObjectLayer, bucket, object, token string, duration time.Duration) (objectLease, error) {
+	lk := newObjectLeaseLock(ctx, objAPI, bucket, object)
+	if err := lk.GetLock(objectLeaseLockTimeout); err != nil {
+		return objectLease{}, err
+	}
+	defer lk.Unlock()
+
+	now := UTCNow()
+	lease, err := readObjectLease(ctx, objAPI, bucket, object)
+	switch {
+	case err == errConfigNotFound || (err == nil && !lease.active(now)):
+		if token != "" {
+			// Expired leases cannot be renewed, the
+			// holder may have missed writes of others.
+			return objectLease{}, errInvalidLeaseToken
+		}
+		lease.Token = mustGetUUID()
+	case err != nil:
+		return objectLease{}, err
+	case token == "":
+		return objectLease{}, errObjectLeased
+	case token != lease.Token:
+		return objectLease{}, errInvalidLeaseToken
+	}
+
+	lease.Expiry = now.Add(duration)
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return objectLease{}, err
+	}
+	if err = saveConfig(ctx, objAPI, objectLeasePath(bucket, object), data); err != nil {
+		return objectLease{}, err
+	}
+	return lease, nil
+}
+
+// releaseObjectLease releases the lease of the object held with token.
+func releaseObjectLease(ctx context.Context, objAPI ObjectLayer, bucket, object, token string) error {
+	lk := newObjectLeaseLock(ctx, objAPI, bucket, object)
+	if err := lk.GetLock(objectLeaseLockTimeout); err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	lease, err := readObjectLease(ctx, objAPI, bucket, object)
+	if err == errConfigNotFound {
+		return errInvalidLeaseToken
+	}
+	if err != nil {
+		return err
+	}
+	if token != lease.Token {
+		return errInvalidLeaseToken
+	}
+	err = deleteConfig(ctx, objAPI, objectLeasePath(bucket, object))
+	if err == errConfigNotFound {
+		err = nil
+	}
+	return err
+}
+
+// checkObjectLease validates the lease token sent along with a write,
+// the writes without a token are not checked.
+func checkObjectLease(ctx context.Context, objAPI ObjectLayer, bucket, object, token string) error {
+	if token == "" {
+		return nil
+	}
+	lk := newObjectLeaseLock(ctx, objAPI, bucket, object)
+	if err := lk.GetRLock(objectLeaseLockTimeout); err != nil {
+		return err
+	}
+	defer lk.RUnlock()
+
+	lease, err := readObjectLease(ctx, objAPI, bucket, object)
+	if err == errConfigNotFound {
+		return errInvalidLeaseToken
+	}
+	if err != nil {
+		return err
+	}
+	if token != lease.Token || !lease.active(UTCNow()) {
+		return errInvalidLeaseToken
+	}
+	return nil
+}
+
+// objectLeaseCheck checks the lease token of a write under the write
+// lock of the object, a lease cannot expire and be taken over by
+// another writer between the check and the write.
+type objectLeaseCheck struct {
+	ctx    context.Context
+	objAPI ObjectLayer
+	bucket string
+	object string
+	token  string
+	err    error
+}
+
+func newObjectLeaseCheck(ctx context.Context, objAPI ObjectLayer, bucket, object, token string) *objectLeaseCheck {
+	return &objectLeaseCheck{
+		ctx:    ctx,
+		objAPI: objAPI,
+		bucket: bucket,
+		object: object,
+		token:  token,
+	}
+}
+
+// precondFn returns the precondition of the write checking the lease
+// token before checkFn, checkFn alone when the write has no token.
+func (c *objectLeaseCheck) precondFn(checkFn CheckPreconditionFn) CheckPreconditionFn {
+	if c.token == "" {
+		return checkFn
+	}
+	return func(oi ObjectInfo) bool {
+		if c.err = checkObjectLease(c.ctx, c.objAPI, c.bucket, c.object, c.token); c.err != nil {
+			return true
+		}
+		return checkFn != nil && checkFn(oi)
+	}
+}
+
+// objectErr returns the lease error of the write refused by the
+// lease check, err otherwise.
+func (c *objectLeaseCheck) objectErr(err error) error {
+	if isErrPreconditionFailed(err) && c.err != nil {
+		return c.err
+	}
+	return err
+}
+
+// initObjectLeaseGC - removes the expired leases in the background,
+// only on the leader.
+func initObjectLeaseGC(ctx context.Context, objAPI ObjectLayer) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.NewTimer(objectLeaseGCInterval).C:
+				deleteExpiredObjectLeases(ctx, objAPI)
+			}
+		}
+	}()
+}
+
+// deleteExpiredObjectLeases - removes the leases which expired and
+// were neither released nor taken over.
+func deleteExpiredObjectLeases(ctx context.Context, objAPI ObjectLayer) {
+	marker := ""
+	for {
+		loi, err := objAPI.ListObjects(ctx, minioMetaBucket, objectLeasePrefix+SlashSeparator, marker, "", maxObjectList)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return
+		}
+		for _, oi := range loi.Objects {
+			bucket, object := path2BucketObjectWithBasePath(objectLeasePrefix, strings.TrimSuffix(oi.Name, SlashSeparator+objectLeaseFileName))
+			if bucket == "" || object == "" || objectLeasePath(bucket, object) != oi.Name {
+				continue
+			}
+			logger.LogIf(ctx, deleteExpiredObjectLease(ctx, objAPI, bucket, object))
+		}
+		if !loi.IsTruncated {
+			return
+		}
+		marker = loi.NextMarker
+	}
+}
+
+func deleteExpiredObjectLease(ctx context.Context, objAPI ObjectLayer, bucket, object string) error {
+	lk := newObjectLeaseLock(ctx, objAPI, bucket, object)
+	if err := lk.GetLock(objectLeaseLockTimeout); err != nil {
+		return err
+	}
+	defer lk.Unlock()
+
+	lease, err := readObjectLease(ctx, objAPI, bucket, object)
+	if err == errConfigNotFound {
+		return nil
+	}
+	if err == nil && lease.active(UTCNow()) {
+		return nil
+	}
+	// Unreadable leases are removed as well, they would
+	// refuse the writes of their holders anyway.
+	err = deleteConfig(ctx, objAPI, objectLeasePath(bucket, object))
+	if err == errConfigNotFound {
+		err = nil
+	}
+	return err
+}
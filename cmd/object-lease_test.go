@@ -0,0 +1,158 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseObjectLeaseDuration(t *testing.T) {
+	testCases := []struct {
+		value    string
+		duration time.Duration
+		success  bool
+	}{
+		{"", objectLeaseDefaultDuration, true},
+		{"1", time.Second, true},
+		{"300", 5 * time.Minute, true},
+		{"0", 0, false},
+		{"-1", 0, false},
+		{"301", 0, false},
+		{"1m", 0, false},
+	}
+
+	for i, testCase := range testCases {
+		duration, err := parseObjectLeaseDuration(testCase.value)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if duration != testCase.duration {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.duration, duration)
+		}
+	}
+}
+
+func TestObjectLease(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectLease)
+}
+
+func testObjectLease(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	ctx := context.Background()
+	bucket, object := "bucket", "dir/object"
+
+	// Writes without a token are not checked.
+	if err := checkObjectLease(ctx, obj, bucket, object, ""); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err := checkObjectLease(ctx, obj, bucket, object, "token"); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected an invalid token without a lease, got %v", instanceType, err)
+	}
+
+	lease, err := acquireObjectLease(ctx, obj, bucket, object, "", time.Minute)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = checkObjectLease(ctx, obj, bucket, object, lease.Token); err != nil {
+		t.Fatalf("%s: expected the token to be valid, got %v", instanceType, err)
+	}
+	if _, err = acquireObjectLease(ctx, obj, bucket, object, "", time.Minute); err != errObjectLeased {
+		t.Fatalf("%s: expected the object to be leased, got %v", instanceType, err)
+	}
+	if _, err = acquireObjectLease(ctx, obj, bucket, object, "other", time.Minute); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected an invalid token, got %v", instanceType, err)
+	}
+
+	// The holder renews the lease with its token.
+	renewed, err := acquireObjectLease(ctx, obj, bucket, object, lease.Token, 2*time.Minute)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if renewed.Token != lease.Token || !renewed.Expiry.After(lease.Expiry) {
+		t.Fatalf("%s: expected the lease to be renewed, got %v", instanceType, renewed)
+	}
+
+	if err = releaseObjectLease(ctx, obj, bucket, object, "other"); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected an invalid token, got %v", instanceType, err)
+	}
+	if err = releaseObjectLease(ctx, obj, bucket, object, lease.Token); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = checkObjectLease(ctx, obj, bucket, object, lease.Token); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected the released token to be invalid, got %v", instanceType, err)
+	}
+
+	// An expired lease is taken over and cannot be renewed.
+	lease, err = acquireObjectLease(ctx, obj, bucket, object, "", time.Millisecond)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if err = checkObjectLease(ctx, obj, bucket, object, lease.Token); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected the expired token to be invalid, got %v", instanceType, err)
+	}
+	if _, err = acquireObjectLease(ctx, obj, bucket, object, lease.Token, time.Minute); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected the expired lease not to be renewed, got %v", instanceType, err)
+	}
+	other, err := acquireObjectLease(ctx, obj, bucket, object, "", time.Minute)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if other.Token == lease.Token {
+		t.Fatalf("%s: expected a new token", instanceType)
+	}
+
+	// The writes are checked under the lock of the object.
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	leaseCheck := newObjectLeaseCheck(ctx, obj, bucket, object, lease.Token)
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""),
+		ObjectOptions{CheckPrecondFn: leaseCheck.precondFn(nil)})
+	if err = leaseCheck.objectErr(err); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected the write with the expired token to be refused, got %v", instanceType, err)
+	}
+	leaseCheck = newObjectLeaseCheck(ctx, obj, bucket, object, other.Token)
+	_, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), 4, "", ""),
+		ObjectOptions{CheckPrecondFn: leaseCheck.precondFn(nil)})
+	if err = leaseCheck.objectErr(err); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	leaseCheck = newObjectLeaseCheck(ctx, obj, bucket, object, lease.Token)
+	_, err = obj.DeleteObject(ctx, bucket, object, ObjectOptions{CheckPrecondFn: leaseCheck.precondFn(nil)})
+	if err = leaseCheck.objectErr(err); err != errInvalidLeaseToken {
+		t.Fatalf("%s: expected the delete with the expired token to be refused, got %v", instanceType, err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: expected the object to be kept, got %v", instanceType, err)
+	}
+
+	// Only the expired leases are garbage collected.
+	if _, err = acquireObjectLease(ctx, obj, bucket, "expired", "", time.Millisecond); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	deleteExpiredObjectLeases(ctx, obj)
+	if _, err = readObjectLease(ctx, obj, bucket, "expired"); err != errConfigNotFound {
+		t.Fatalf("%s: expected the expired lease to be removed, got %v", instanceType, err)
+	}
+	if _, err = readObjectLease(ctx, obj, bucket, object); err != nil {
+		t.Fatalf("%s: expected the active lease to be kept, got %v", instanceType, err)
+	}
+}
@@ -20,8 +20,13 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 )
 
+// How long a rename is retried on windows while
+// the source or destination file is in use.
+const renameSharingViolationTimeout = 2 * time.Second
+
 // Wrapper functions to os.RemoveAll, which calls reliableRemoveAll
 // this is to ensure that if there is a racy parent directory
 // create in between we can simply retry the operation.
@@ -101,7 +106,7 @@ func reliableMkdirAll(dirPath string, mode os.FileMode) (err error) {
 	i := 0
 	for {
 		// Creates all the parent directories, with mode 0777 mkdir honors system umask.
-		if err = os.MkdirAll(dirPath, mode); err != nil {
+		if err = os.MkdirAll(longPath(dirPath), mode); err != nil {
 			// Retry only for the first retryable error.
 			if os.IsNotExist(err) && i == 0 {
 				i++
@@ -164,14 +169,21 @@ func reliableRename(srcFilePath, dstFilePath string) (err error) {
 		return err
 	}
 	i := 0
+	start := time.Now()
 	for {
 		// After a successful parent directory create attempt a renameAll.
-		if err = os.Rename(srcFilePath, dstFilePath); err != nil {
+		if err = os.Rename(longPath(srcFilePath), longPath(dstFilePath)); err != nil {
 			// Retry only for the first retryable error.
 			if os.IsNotExist(err) && i == 0 {
 				i++
 				continue
 			}
+			// On windows a file cannot be replaced while it is opened
+			// without FILE_SHARE_DELETE, retry until it is closed.
+			if isSysErrSharingViolation(err) && time.Since(start) < renameSharingViolationTimeout {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
 		}
 		break
 	}
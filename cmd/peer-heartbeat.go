@@ -0,0 +1,240 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Interval between two heartbeats sent to every peer.
+	peerHeartbeatInterval = 2 * time.Second
+
+	// Number of consecutive missed heartbeats before a peer,
+	// and all its disks, are considered offline.
+	peerHeartbeatOfflineThreshold = 3
+
+	// Number of consecutive heartbeats answered before an offline
+	// peer is considered online again, this avoids flapping disks
+	// when the network of a peer is unstable.
+	peerHeartbeatOnlineThreshold = 3
+
+	// Interval between two clock skew measurements of every peer.
+	peerClockSkewInterval = time.Minute
+
+	// Maximum clock skew allowed with a peer before its disks are
+	// considered offline, the modification times of the object
+	// versions and the request signatures can't be trusted with
+	// larger skews.
+	peerClockSkewThreshold = 30 * time.Second
+)
+
+var (
+	errPeerOffline   = errors.New("peer missed too many heartbeats, marking its disks offline")
+	errPeerClockSkew = errors.New("clock of peer is too far apart from the local clock, marking its disks offline")
+)
+
+// peerHealth - liveness of a peer as seen by this server.
+type peerHealth struct {
+	online     bool
+	successes  int // consecutive answered heartbeats.
+	failures   int // consecutive missed heartbeats.
+	lastSeen   time.Time
+	lastChange time.Time
+	skew       time.Duration // last measured clock skew.
+	skewed     bool          // skew is beyond peerClockSkewThreshold.
+}
+
+// peerHeartbeat - tracks the liveness of the peers of this server,
+// keyed by the host:port of the peers.
+type peerHeartbeat struct {
+	sync.RWMutex
+	peers map[string]*peerHealth
+}
+
+func newPeerHeartbeat() *peerHeartbeat {
+	return &peerHeartbeat{peers: make(map[string]*peerHealth)}
+}
+
+// record - records the result of a heartbeat sent to host, returns
+// true when the state of the peer changed.
+func (h *peerHeartbeat) record(host string, ok bool) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	now := UTCNow()
+	p, found := h.peers[host]
+	if !found {
+		// Peers are assumed online until proven otherwise.
+		p = &peerHealth{online: true, lastChange: now}
+		h.peers[host] = p
+	}
+	if ok {
+		p.lastSeen = now
+		p.successes++
+		p.failures = 0
+		if !p.online && p.successes >= peerHeartbeatOnlineThreshold {
+			p.online = true
+			p.lastChange = now
+			return true
+		}
+		return false
+	}
+	p.failures++
+	p.successes = 0
+	if p.online && p.failures >= peerHeartbeatOfflineThreshold {
+		p.online = false
+		p.lastChange = now
+		return true
+	}
+	return false
+}
+
+// recordSkew - records the clock skew measured with host, returns
+// true when the peer crossed peerClockSkewThreshold either way.
+func (h *peerHeartbeat) recordSkew(host string, skew time.Duration) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	p, found := h.peers[host]
+	if !found {
+		p = &peerHealth{online: true, lastChange: UTCNow()}
+		h.peers[host] = p
+	}
+	p.skew = skew
+	if skew < 0 {
+		skew = -skew
+	}
+	skewed := skew > peerClockSkewThreshold
+	if skewed == p.skewed {
+		return false
+	}
+	p.skewed = skewed
+	p.lastChange = UTCNow()
+	return true
+}
+
+// isOnline - returns false only when host has missed enough
+// heartbeats or when its clock is too skewed, peers which are
+// not tracked are online.
+func (h *peerHeartbeat) isOnline(host string) bool {
+	if h == nil {
+		return true
+	}
+	h.RLock()
+	defer h.RUnlock()
+	p, ok := h.peers[host]
+	return !ok || (p.online && !p.skewed)
+}
+
+// healthMap - returns the health of all the tracked peers.
+func (h *peerHeartbeat) healthMap() map[string]madmin.PeerHealth {
+	h.RLock()
+	defer h.RUnlock()
+	healthMap := make(map[string]madmin.PeerHealth, len(h.peers))
+	for host, p := range h.peers {
+		state := "online"
+		switch {
+		case !p.online:
+			state = "offline"
+		case p.skewed:
+			state = "skewed"
+		}
+		healthMap[host] = madmin.PeerHealth{
+			State:      state,
+			LastSeen:   p.lastSeen,
+			LastChange: p.lastChange,
+			Failures:   p.failures,
+			ClockSkew:  p.skew,
+		}
+	}
+	return healthMap
+}
+
+// checkSkew - measures the clock skew with the peer of client.
+func (h *peerHeartbeat) checkSkew(ctx context.Context, client *peerRESTClient) {
+	skew, rtt, err := client.ServerTime()
+	if err != nil || rtt > peerClockSkewThreshold {
+		// Slow answers can't tell the skew apart from the
+		// network delays, wait for the next measurement.
+		return
+	}
+	host := client.host.String()
+	if h.recordSkew(host, skew) {
+		reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host)
+		reqInfo.AppendTags("clockSkew", skew.String())
+		logCtx := logger.SetReqInfo(ctx, reqInfo)
+		if h.isOnline(host) {
+			logger.Info("Clock of peer %s is back in sync (skew %s)", host, skew)
+		} else {
+			logger.LogIf(logCtx, errPeerClockSkew)
+		}
+	}
+}
+
+// run - sends heartbeats to all the peers and measures their clock
+// skew until ctx is canceled.
+func (h *peerHeartbeat) run(ctx context.Context, peerClients []*peerRESTClient) {
+	ticker := time.NewTicker(peerHeartbeatInterval)
+	defer ticker.Stop()
+
+	var lastSkewCheck time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		checkSkew := time.Since(lastSkewCheck) >= peerClockSkewInterval
+		if checkSkew {
+			lastSkewCheck = time.Now()
+		}
+
+		var wg sync.WaitGroup
+		for _, client := range peerClients {
+			if client == nil {
+				continue
+			}
+			wg.Add(1)
+			go func(client *peerRESTClient) {
+				defer wg.Done()
+				host := client.host.String()
+				ok := client.restClient.HealthCheckFn()
+				if ok && checkSkew {
+					h.checkSkew(ctx, client)
+				}
+				if h.record(host, ok) {
+					reqInfo := (&logger.ReqInfo{}).AppendTags("peerAddress", host)
+					logCtx := logger.SetReqInfo(ctx, reqInfo)
+					if h.isOnline(host) {
+						logger.Info("Peer %s is online", host)
+					} else {
+						logger.LogIf(logCtx, errPeerOffline)
+					}
+				}
+			}(client)
+		}
+		wg.Wait()
+	}
+}
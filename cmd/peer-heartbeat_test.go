@@ -0,0 +1,88 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestPeerHeartbeatHysteresis(t *testing.T) {
+	h := newPeerHeartbeat()
+	const host = "server1:9000"
+
+	if !h.isOnline(host) {
+		t.Fatal("untracked peers must be online")
+	}
+
+	// A peer missing a few heartbeats stays online.
+	for i := 1; i < peerHeartbeatOfflineThreshold; i++ {
+		if h.record(host, false) {
+			t.Fatalf("heartbeat %d: unexpected state change", i)
+		}
+	}
+	if !h.isOnline(host) {
+		t.Fatal("peer should still be online")
+	}
+	if !h.record(host, false) || h.isOnline(host) {
+		t.Fatal("peer should be offline")
+	}
+
+	// A single answered heartbeat doesn't bring it back.
+	h.record(host, true)
+	h.record(host, false)
+	if h.isOnline(host) {
+		t.Fatal("peer should still be offline")
+	}
+	for i := 1; i < peerHeartbeatOnlineThreshold; i++ {
+		if h.record(host, true) {
+			t.Fatalf("heartbeat %d: unexpected state change", i)
+		}
+	}
+	if !h.record(host, true) || !h.isOnline(host) {
+		t.Fatal("peer should be online")
+	}
+
+	health := h.healthMap()[host]
+	if health.State != "online" || health.Failures != 0 || health.LastSeen.IsZero() {
+		t.Fatalf("unexpected health %#v", health)
+	}
+}
+
+func TestPeerHeartbeatClockSkew(t *testing.T) {
+	h := newPeerHeartbeat()
+	const host = "server1:9000"
+
+	if h.recordSkew(host, peerClockSkewThreshold/2) || !h.isOnline(host) {
+		t.Fatal("peer within the skew threshold should be online")
+	}
+	if !h.recordSkew(host, -2*peerClockSkewThreshold) || h.isOnline(host) {
+		t.Fatal("skewed peer should be offline")
+	}
+	health := h.healthMap()[host]
+	if health.State != "skewed" || health.ClockSkew != -2*peerClockSkewThreshold {
+		t.Fatalf("unexpected health %#v", health)
+	}
+
+	// Answered heartbeats don't bring a skewed peer back.
+	for i := 0; i < peerHeartbeatOnlineThreshold; i++ {
+		h.record(host, true)
+	}
+	if h.isOnline(host) {
+		t.Fatal("skewed peer should still be offline")
+	}
+	if !h.recordSkew(host, 0) || !h.isOnline(host) {
+		t.Fatal("peer should be online")
+	}
+}
@@ -490,6 +490,128 @@ func (client *peerRESTClient) ReloadFormat(dryRun bool) error {
 	return nil
 }
 
+// LoadDecommission - reload the zones being decommissioned on the peer node.
+func (client *peerRESTClient) LoadDecommission() error {
+	respBody, err := client.call(peerRESTMethodLoadDecommission, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// LoadTenants - reload the tenants on the peer node.
+func (client *peerRESTClient) LoadTenants() error {
+	respBody, err := client.call(peerRESTMethodLoadTenants, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// LookupRequest - returns the requests with the given request ID
+// served by the peer node.
+func (client *peerRESTClient) LookupRequest(requestID string) (infos []madmin.RequestInfo, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTRequestID, requestID)
+	respBody, err := client.call(peerRESTMethodLookupRequest, values, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&infos)
+	return infos, err
+}
+
+// AccessStats - returns the downloads of the objects of the bucket
+// served by the peer node, of all the buckets if empty.
+func (client *peerRESTClient) AccessStats(bucket string) (report madmin.AccessReport, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodAccessStats, values, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// ScanGarbage - scans the local drives of the peer node for garbage
+// older than olderThan, and removes it if requested.
+func (client *peerRESTClient) ScanGarbage(ctx context.Context, olderThan time.Duration, remove bool) (report madmin.GarbageReport, err error) {
+	values := make(url.Values)
+	values.Set(peerRESTOlderThan, olderThan.String())
+	values.Set(peerRESTRemove, strconv.FormatBool(remove))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodScanGarbage, values, nil, -1)
+	if err != nil {
+		return report, err
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&report)
+	return report, err
+}
+
+// ClearDriveReadOnly - clears the read-only mark of the drive at path
+// of the peer node.
+func (client *peerRESTClient) ClearDriveReadOnly(ctx context.Context, path string) error {
+	values := make(url.Values)
+	values.Set(peerRESTDrive, path)
+	respBody, err := client.callWithContext(ctx, peerRESTMethodClearDriveReadOnly, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetServerMode - sets the server mode of the peer node.
+func (client *peerRESTClient) SetServerMode(mode serverMode) error {
+	values := make(url.Values)
+	values.Set(peerRESTServerMode, mode.String())
+	respBody, err := client.call(peerRESTMethodSetServerMode, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ServerTime - returns the clock skew of the peer node, positive when
+// the clock of the peer is ahead of the local clock, along with the
+// round trip time of the request.
+func (client *peerRESTClient) ServerTime() (skew, rtt time.Duration, err error) {
+	start := UTCNow()
+	respBody, err := client.call(peerRESTMethodServerTime, nil, nil, -1)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer http.DrainBody(respBody)
+	var serverTime time.Time
+	if err = gob.NewDecoder(respBody).Decode(&serverTime); err != nil {
+		return 0, 0, err
+	}
+	rtt = UTCNow().Sub(start)
+	// Assume the peer read its clock halfway through the request.
+	return serverTime.Sub(start.Add(rtt / 2)), rtt, nil
+}
+
+// SetFaults - replaces the faults injected by the peer node.
+func (client *peerRESTClient) SetFaults(rules []madmin.FaultRule) error {
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(rules)
+	if err != nil {
+		return err
+	}
+	respBody, err := client.call(peerRESTMethodSetFaults, nil, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // cycleServerBloomFilter will cycle the bloom filter to start recording to index y if not already.
 // The response will contain a bloom filter starting at index x up to, but not including index y.
 // If y is 0, the response will not update y, but return the currently recorded information
@@ -873,8 +995,14 @@ func newPeerRESTClient(peer *xnet.Host) *peerRESTClient {
 		}
 	}
 
+	if globalInternodeTLS != nil {
+		serverURL.Scheme = "https"
+		tlsConfig = globalInternodeTLS.clientConfig(peer.Name, tlsConfig)
+	}
+
 	trFn := newCustomHTTPTransport(tlsConfig, rest.DefaultRESTTimeout)
 	restClient := rest.NewClient(serverURL, trFn, newAuthToken)
+	restClient.FaultFn = newRPCFaultFn(serverURL.Host)
 
 	// Construct a new health function.
 	restClient.HealthCheckFn = func() bool {
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v9"
+	peerRESTVersion       = "v10"
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -56,6 +56,15 @@ const (
 	peerRESTMethodListen                = "/listen"
 	peerRESTMethodLog                   = "/log"
 	peerRESTMethodGetLocalDiskIDs       = "/getlocaldiskids"
+	peerRESTMethodLoadDecommission      = "/loaddecommission"
+	peerRESTMethodLoadTenants           = "/loadtenants"
+	peerRESTMethodLookupRequest         = "/lookuprequest"
+	peerRESTMethodSetServerMode         = "/setservermode"
+	peerRESTMethodServerTime            = "/servertime"
+	peerRESTMethodSetFaults             = "/setfaults"
+	peerRESTMethodAccessStats           = "/accessstats"
+	peerRESTMethodScanGarbage           = "/scangarbage"
+	peerRESTMethodClearDriveReadOnly    = "/cleardrivereadonly"
 )
 
 const (
@@ -74,6 +83,11 @@ const (
 	peerRESTDryRun        = "dry-run"
 	peerRESTTraceAll      = "all"
 	peerRESTTraceErr      = "err"
+	peerRESTRequestID     = "request-id"
+	peerRESTServerMode    = "mode"
+	peerRESTOlderThan     = "older-than"
+	peerRESTRemove        = "remove"
+	peerRESTDrive         = "drive"
 
 	peerRESTListenBucket = "bucket"
 	peerRESTListenPrefix = "prefix"
@@ -576,6 +576,10 @@ func (s *peerRESTServer) DeleteBucketMetadataHandler(w http.ResponseWriter, r *h
 	}
 
 	globalBucketMetadataSys.Remove(bucketName)
+	globalNotificationSys.RemoveNotification(bucketName)
+	if z, ok := newObjectLayerWithoutSafeModeFn().(*erasureZones); ok {
+		z.forgetBucket(bucketName)
+	}
 	w.(http.Flusher).Flush()
 }
 
@@ -607,9 +611,12 @@ func (s *peerRESTServer) LoadBucketMetadataHandler(w http.ResponseWriter, r *htt
 
 	globalBucketMetadataSys.Set(bucketName, meta)
 
+	// Replace the rules, a removed notification config has none.
+	rulesMap := make(event.RulesMap)
 	if meta.notificationConfig != nil {
-		globalNotificationSys.AddRulesMap(bucketName, meta.notificationConfig.ToRulesMap())
+		rulesMap = meta.notificationConfig.ToRulesMap()
 	}
+	globalNotificationSys.AddRulesMap(bucketName, rulesMap)
 }
 
 // ReloadFormatHandler - Reload Format.
@@ -651,6 +658,164 @@ func (s *peerRESTServer) ReloadFormatHandler(w http.ResponseWriter, r *http.Requ
 	w.(http.Flusher).Flush()
 }
 
+// LoadDecommissionHandler - reload the zones being decommissioned.
+func (s *peerRESTServer) LoadDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerWithoutSafeModeFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	z, ok := objAPI.(*erasureZones)
+	if !ok {
+		s.writeErrorResponse(w, NotImplemented{})
+		return
+	}
+
+	if err := z.loadDecommission(GlobalContext); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	w.(http.Flusher).Flush()
+}
+
+// LoadTenantsHandler - reload the tenants.
+func (s *peerRESTServer) LoadTenantsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	objAPI := newObjectLayerWithoutSafeModeFn()
+	if objAPI == nil {
+		s.writeErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if err := globalTenantSys.load(GlobalContext, objAPI); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	w.(http.Flusher).Flush()
+}
+
+// LookupRequestHandler - returns the requests with the given request ID
+// served by this node.
+func (s *peerRESTServer) LookupRequestHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	requestID := mux.Vars(r)[peerRESTRequestID]
+	if requestID == "" {
+		s.writeErrorResponse(w, errors.New("Request ID is missing"))
+		return
+	}
+
+	ctx := newContext(r, w, "LookupRequest")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalRequestHistory.find(requestID)))
+	w.(http.Flusher).Flush()
+}
+
+// AccessStatsHandler - returns the downloads of the objects of the
+// bucket served by this node.
+func (s *peerRESTServer) AccessStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "AccessStats")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(globalAccessStats.report(r.URL.Query().Get(peerRESTBucket))))
+	w.(http.Flusher).Flush()
+}
+
+// ScanGarbageHandler - scans the local drives of this node for garbage.
+func (s *peerRESTServer) ScanGarbageHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	olderThan, err := time.ParseDuration(r.URL.Query().Get(peerRESTOlderThan))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	remove, err := strconv.ParseBool(r.URL.Query().Get(peerRESTRemove))
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+
+	ctx := newContext(r, w, "ScanGarbage")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(scanLocalGarbage(ctx, olderThan, remove)))
+	w.(http.Flusher).Flush()
+}
+
+// ClearDriveReadOnlyHandler - clears the read-only mark of a drive of
+// this node.
+func (s *peerRESTServer) ClearDriveReadOnlyHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "ClearDriveReadOnly")
+	if err := clearLocalDriveReadOnly(ctx, r.URL.Query().Get(peerRESTDrive)); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	w.(http.Flusher).Flush()
+}
+
+// SetServerModeHandler - sets the server mode of this node.
+func (s *peerRESTServer) SetServerModeHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	mode, err := parseServerMode(mux.Vars(r)[peerRESTServerMode])
+	if err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	setServerMode(mode)
+	w.(http.Flusher).Flush()
+}
+
+// SetFaultsHandler - replaces the faults injected by this node.
+func (s *peerRESTServer) SetFaultsHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	if !globalFaultInjection {
+		s.writeErrorResponse(w, errors.New("fault injection is not enabled"))
+		return
+	}
+
+	var rules []madmin.FaultRule
+	if err := gob.NewDecoder(r.Body).Decode(&rules); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	if err := validateFaultRules(rules); err != nil {
+		s.writeErrorResponse(w, err)
+		return
+	}
+	globalFaultInjector.Set(rules)
+	w.(http.Flusher).Flush()
+}
+
 // CycleServerBloomFilterHandler cycles bllom filter on server.
 func (s *peerRESTServer) CycleServerBloomFilterHandler(w http.ResponseWriter, r *http.Request) {
 	if !s.IsValid(w, r) {
@@ -733,6 +898,18 @@ func getLocalDiskIDs(z *erasureZones) []string {
 	return ids
 }
 
+// ServerTimeHandler - returns the current time of this node.
+func (s *peerRESTServer) ServerTimeHandler(w http.ResponseWriter, r *http.Request) {
+	if !s.IsValid(w, r) {
+		s.writeErrorResponse(w, errors.New("Invalid request"))
+		return
+	}
+
+	ctx := newContext(r, w, "ServerTime")
+	logger.LogIf(ctx, gob.NewEncoder(w).Encode(UTCNow()))
+	w.(http.Flusher).Flush()
+}
+
 // HealthHandler - returns true of health
 func (s *peerRESTServer) HealthHandler(w http.ResponseWriter, r *http.Request) {
 	s.IsValid(w, r)
@@ -1018,6 +1195,10 @@ func (s *peerRESTServer) writeErrorResponse(w http.ResponseWriter, err error) {
 
 // IsValid - To authenticate and verify the time difference.
 func (s *peerRESTServer) IsValid(w http.ResponseWriter, r *http.Request) bool {
+	if err := internodeRequestValidate(r); err != nil {
+		s.writeErrorResponse(w, err)
+		return false
+	}
 	if err := storageServerRequestValidate(r); err != nil {
 		s.writeErrorResponse(w, err)
 		return false
@@ -1058,6 +1239,15 @@ func registerPeerRESTHandlers(router *mux.Router) {
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodStartProfiling).HandlerFunc(httpTraceAll(server.StartProfilingHandler)).Queries(restQueries(peerRESTProfiler)...)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodDownloadProfilingData).HandlerFunc(httpTraceHdrs(server.DownloadProfilingDataHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodReloadFormat).HandlerFunc(httpTraceHdrs(server.ReloadFormatHandler)).Queries(restQueries(peerRESTDryRun)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadDecommission).HandlerFunc(httpTraceHdrs(server.LoadDecommissionHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLoadTenants).HandlerFunc(httpTraceHdrs(server.LoadTenantsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodLookupRequest).HandlerFunc(httpTraceHdrs(server.LookupRequestHandler)).Queries(restQueries(peerRESTRequestID)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodSetServerMode).HandlerFunc(httpTraceHdrs(server.SetServerModeHandler)).Queries(restQueries(peerRESTServerMode)...)
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodServerTime).HandlerFunc(httpTraceHdrs(server.ServerTimeHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodSetFaults).HandlerFunc(httpTraceHdrs(server.SetFaultsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodAccessStats).HandlerFunc(httpTraceHdrs(server.AccessStatsHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodScanGarbage).HandlerFunc(httpTraceHdrs(server.ScanGarbageHandler))
+	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodClearDriveReadOnly).HandlerFunc(httpTraceHdrs(server.ClearDriveReadOnlyHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodTrace).HandlerFunc(server.TraceHandler)
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodListen).HandlerFunc(httpTraceHdrs(server.ListenHandler))
 	subrouter.Methods(http.MethodPost).Path(peerRESTVersionPrefix + peerRESTMethodBackgroundHealStatus).HandlerFunc(server.BackgroundHealStatusHandler)
@@ -58,8 +58,12 @@ var printEndpointError = func() func(Endpoint, error) {
 	}
 }()
 
-// Migrates backend format of local disks.
+// Migrates backend format of local disks, none of the disks is migrated
+// unless all of them can be.
 func formatErasureMigrateLocalEndpoints(endpoints Endpoints) error {
+	migrate := make([]bool, len(endpoints))
+
+	// Pre-flight check of the format versions.
 	g := errgroup.WithNErrs(len(endpoints))
 	for index, endpoint := range endpoints {
 		if !endpoint.IsLocal {
@@ -75,7 +79,29 @@ func formatErasureMigrateLocalEndpoints(endpoints Endpoints) error {
 				}
 				return fmt.Errorf("unable to access (%s) %w", formatPath, err)
 			}
-			return formatErasureMigrate(epPath)
+			version, err := formatGetBackendErasureVersion(formatPath)
+			if err != nil {
+				return err
+			}
+			plan, err := formatErasureMigrationPlan(epPath, version)
+			migrate[index] = len(plan) > 0
+			return err
+		}, index)
+	}
+	for _, err := range g.Wait() {
+		if err != nil {
+			return err
+		}
+	}
+
+	g = errgroup.WithNErrs(len(endpoints))
+	for index := range endpoints {
+		if !migrate[index] {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			return formatErasureMigrate(endpoints[index].Path)
 		}, index)
 	}
 	for _, err := range g.Wait() {
@@ -0,0 +1,105 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// requestHistorySize is the number of recently served requests
+// remembered by every node for request ID lookups.
+const requestHistorySize = 10000
+
+// requestHistory is a bounded ring of the requests recently served
+// by this node, so that a request ID reported by a client can be
+// correlated with the server logs and traces.
+type requestHistory struct {
+	sync.RWMutex
+	entries []madmin.RequestInfo
+	next    int
+}
+
+func newRequestHistory(size int) *requestHistory {
+	return &requestHistory{entries: make([]madmin.RequestInfo, 0, size)}
+}
+
+// add - records a served request, overwriting the oldest one when full.
+func (h *requestHistory) add(info madmin.RequestInfo) {
+	h.Lock()
+	defer h.Unlock()
+	if len(h.entries) < cap(h.entries) {
+		h.entries = append(h.entries, info)
+		return
+	}
+	h.entries[h.next] = info
+	h.next = (h.next + 1) % len(h.entries)
+}
+
+// find - returns the recorded requests with the given request ID.
+func (h *requestHistory) find(requestID string) []madmin.RequestInfo {
+	h.RLock()
+	defer h.RUnlock()
+	var infos []madmin.RequestInfo
+	for _, info := range h.entries {
+		if info.RequestID == requestID {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
+
+var globalRequestHistory = newRequestHistory(requestHistorySize)
+
+// isInternodeRequest - returns true if the request was sent by
+// another node of the cluster.
+func isInternodeRequest(r *http.Request) bool {
+	for _, prefix := range []string{storageRESTPrefix, peerRESTPrefix, lockRESTPrefix, bootstrapRESTPrefix} {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// rememberRequest - remembers a request served by this node, requests
+// between the nodes of the cluster are not recorded.
+func rememberRequest(w *logger.ResponseWriter, r *http.Request) {
+	if isInternodeRequest(r) {
+		return
+	}
+	globalRequestHistory.add(madmin.RequestInfo{
+		RequestID:  w.Header().Get(xhttp.AmzRequestID),
+		HostID:     w.Header().Get(xhttp.AmzRequestHostID),
+		NodeName:   GetLocalPeer(globalEndpoints),
+		Time:       w.StartTime,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		RawQuery:   r.URL.RawQuery,
+		StatusCode: w.StatusCode,
+		Duration:   time.Now().UTC().Sub(w.StartTime),
+		RemoteHost: handlers.GetSourceIP(r),
+		UserAgent:  r.UserAgent(),
+	})
+}
@@ -0,0 +1,74 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestRequestHistory(t *testing.T) {
+	h := newRequestHistory(2)
+	h.add(madmin.RequestInfo{RequestID: "1"})
+	h.add(madmin.RequestInfo{RequestID: "2"})
+	if infos := h.find("1"); len(infos) != 1 {
+		t.Fatalf("Expected request 1 to be found, got %v", infos)
+	}
+
+	// Adding a third request overwrites the oldest one.
+	h.add(madmin.RequestInfo{RequestID: "3"})
+	if infos := h.find("1"); len(infos) != 0 {
+		t.Fatalf("Expected request 1 to be forgotten, got %v", infos)
+	}
+	for _, id := range []string{"2", "3"} {
+		if infos := h.find(id); len(infos) != 1 {
+			t.Fatalf("Expected request %s to be found, got %v", id, infos)
+		}
+	}
+}
+
+func TestCustomHeaderHandlerRemembersRequest(t *testing.T) {
+	handler := addCustomHeaders(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+
+	requestID := w.Header().Get(xhttp.AmzRequestID)
+	if requestID == "" || w.Header().Get(xhttp.AmzRequestHostID) == "" {
+		t.Fatalf("Expected request and host IDs to be set, got %v", w.Header())
+	}
+	infos := globalRequestHistory.find(requestID)
+	if len(infos) != 1 {
+		t.Fatalf("Expected request %s to be remembered, got %v", requestID, infos)
+	}
+	if infos[0].StatusCode != http.StatusNotFound || infos[0].Path != "/bucket/object" {
+		t.Fatalf("Unexpected request info %v", infos[0])
+	}
+
+	// Requests between the nodes are not remembered.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, storageRESTPrefix+"/v1/diskinfo", nil))
+	if infos = globalRequestHistory.find(w.Header().Get(xhttp.AmzRequestID)); len(infos) != 0 {
+		t.Fatalf("Expected internode request not to be remembered, got %v", infos)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"sync/atomic"
 	"time"
 
@@ -73,11 +74,25 @@ type Client struct {
 	// Should only be modified before any calls are made.
 	MaxErrResponseSize int64
 
+	// Versions of the API supported by the client, newest first, the
+	// version is the last element of the URL path. The client steps
+	// down to the previous versions when the server does not support
+	// the version, like servers of the previous release during a
+	// rolling upgrade. The newest version is tried again after every
+	// reconnection. Should only be modified before any calls are made.
+	Versions []string
+
+	// FaultFn, when set, is called before every call with its method,
+	// the call is dropped as if the network failed when it returns an
+	// error. It is used to inject faults in test clusters.
+	FaultFn func(ctx context.Context, method string) error
+
 	httpClient          *http.Client
 	httpIdleConnsCloser func()
 	url                 *url.URL
 	newAuthToken        func(audience string) string
 	connected           int32
+	version             int32 // index of the version used in Versions.
 }
 
 // URL query separator constants
@@ -90,24 +105,26 @@ func (c *Client) CallWithContext(ctx context.Context, method string, values url.
 	if !c.IsOnline() {
 		return nil, &NetworkError{Err: errors.New("remote server offline")}
 	}
-	req, err := http.NewRequest(http.MethodPost, c.url.String()+method+querySep+values.Encode(), body)
-	if err != nil {
-		return nil, &NetworkError{err}
+	if c.FaultFn != nil {
+		if err := c.FaultFn(ctx, method); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				c.MarkOffline()
+			}
+			return nil, &NetworkError{Err: err}
+		}
 	}
-	req = req.WithContext(ctx)
-	req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.Query().Encode()))
-	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
-	if length > 0 {
-		req.ContentLength = length
+	resp, err := c.do(ctx, method, values, body, length)
+	for err == nil && resp.StatusCode == http.StatusUpgradeRequired && c.stepDownVersion() {
+		// The request is sent again with the previous version when its
+		// body can be sent again, otherwise the next requests use it.
+		if !rewind(body) {
+			break
+		}
+		xhttp.DrainBody(resp.Body)
+		resp, err = c.do(ctx, method, values, body, length)
 	}
-	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		// A canceled context doesn't always mean a network problem.
-		if !errors.Is(err, context.Canceled) {
-			// We are safe from recursion
-			c.MarkOffline()
-		}
-		return nil, &NetworkError{err}
+		return nil, err
 	}
 
 	final := resp.Trailer.Get("FinalStatus")
@@ -143,6 +160,61 @@ func (c *Client) CallWithContext(ctx context.Context, method string, values url.
 	return resp.Body, nil
 }
 
+// do - sends the request with the version of the API in use.
+func (c *Client) do(ctx context.Context, method string, values url.Values, body io.Reader, length int64) (*http.Response, error) {
+	u := *c.url
+	if len(c.Versions) > 0 {
+		u.Path = path.Join(path.Dir(u.Path), c.Versions[atomic.LoadInt32(&c.version)])
+	}
+	req, err := http.NewRequest(http.MethodPost, u.String()+method+querySep+values.Encode(), body)
+	if err != nil {
+		return nil, &NetworkError{err}
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+c.newAuthToken(req.URL.Query().Encode()))
+	req.Header.Set("X-Minio-Time", time.Now().UTC().Format(time.RFC3339))
+	if length > 0 {
+		req.ContentLength = length
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// A canceled context doesn't always mean a network problem.
+		if !errors.Is(err, context.Canceled) {
+			// We are safe from recursion
+			c.MarkOffline()
+		}
+		return nil, &NetworkError{err}
+	}
+	return resp, nil
+}
+
+// rewind - returns true if the body can be sent again.
+func rewind(body io.Reader) bool {
+	if body == nil {
+		return true
+	}
+	seeker, ok := body.(io.Seeker)
+	if !ok {
+		return false
+	}
+	_, err := seeker.Seek(0, io.SeekStart)
+	return err == nil
+}
+
+// stepDownVersion - uses the previous version of the API, returns
+// false when there is no previous version.
+func (c *Client) stepDownVersion() bool {
+	for {
+		version := atomic.LoadInt32(&c.version)
+		if int(version)+1 >= len(c.Versions) {
+			return false
+		}
+		if atomic.CompareAndSwapInt32(&c.version, version, version+1) {
+			return true
+		}
+	}
+}
+
 // Call - make a REST call.
 func (c *Client) Call(method string, values url.Values, body io.Reader, length int64) (reply io.ReadCloser, err error) {
 	ctx := context.Background()
@@ -197,6 +269,8 @@ func (c *Client) MarkOffline() {
 					return
 				}
 				if c.HealthCheckFn() {
+					// The server may have been upgraded.
+					atomic.StoreInt32(&c.version, 0)
 					atomic.CompareAndSwapInt32(&c.connected, offline, online)
 					return
 				}
@@ -75,6 +75,8 @@ var globalHandlers = []MiddlewareFunc{
 	// filters HTTP headers which are treated as metadata and are reserved
 	// for internal use only.
 	filterReservedMetadata,
+	// Rejects the writes of objects to read replica buckets.
+	setReadReplicaHandler,
 	// Add new handlers here.
 }
 
@@ -92,14 +94,24 @@ func configureServerHandler(endpointZones EndpointZones) (http.Handler, error) {
 	// Add STS router always.
 	registerSTSRouter(router)
 
-	// Add Admin router, all APIs are enabled in server mode.
-	registerAdminRouter(router, true, true)
+	// Add Admin router, all APIs are enabled in server mode. It is
+	// served on the admin address instead, if set.
+	if globalCLIContext.AdminAddr == "" {
+		registerAdminRouter(router, true, true)
+	}
 
 	// Add healthcheck router
 	registerHealthCheckRouter(router)
 
 	// Add server metrics router
-	registerMetricsRouter(router)
+	if globalCLIContext.AdminAddr == "" {
+		registerMetricsRouter(router)
+	}
+
+	// Add Swift router when its enabled.
+	if globalCLIContext.Swift {
+		registerSwiftRouter(router)
+	}
 
 	// Register web router when its enabled.
 	if globalBrowserEnabled {
@@ -116,3 +128,17 @@ func configureServerHandler(endpointZones EndpointZones) (http.Handler, error) {
 
 	return router, nil
 }
+
+// configureAdminHandler - returns the handler of the admin, metrics
+// and healthcheck APIs served on the admin address.
+func configureAdminHandler(enableConfigOps, enableIAMOps bool) http.Handler {
+	router := mux.NewRouter().SkipClean(true).UseEncodedPath()
+
+	registerAdminRouter(router, enableConfigOps, enableIAMOps)
+	registerHealthCheckRouter(router)
+	registerMetricsRouter(router)
+
+	router.Use(registerMiddlewares)
+
+	return router
+}
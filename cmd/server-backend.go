@@ -0,0 +1,98 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ObjectLayerFactory - creates the object layer of a registered
+// backend, called once at server startup.
+type ObjectLayerFactory func(ctx context.Context) (ObjectLayer, error)
+
+// memoryBackend - name of the built-in in-memory backend.
+const memoryBackend = "memory"
+
+var (
+	globalBackendsMu sync.RWMutex
+	globalBackends   = map[string]ObjectLayerFactory{}
+)
+
+func init() {
+	RegisterBackend(memoryBackend, func(ctx context.Context) (ObjectLayer, error) {
+		return NewMemObjectLayerWithMaxSize(globalCLIContext.MemoryMaxSize), nil
+	})
+}
+
+// RegisterBackend registers an object layer served by 'minio server
+// --backend NAME' instead of drives. Programs embedding MinIO register
+// their storage from an init function before calling Main, and get the
+// S3, IAM and admin APIs of a single node server over it.
+func RegisterBackend(name string, factory ObjectLayerFactory) error {
+	if name == "" || factory == nil {
+		return errInvalidArgument
+	}
+
+	globalBackendsMu.Lock()
+	defer globalBackendsMu.Unlock()
+
+	if _, ok := globalBackends[name]; ok {
+		return fmt.Errorf("backend %s is already registered", name)
+	}
+	globalBackends[name] = factory
+	return nil
+}
+
+// lookupBackend returns the factory of the backend registered as name.
+func lookupBackend(name string) (ObjectLayerFactory, error) {
+	globalBackendsMu.RLock()
+	defer globalBackendsMu.RUnlock()
+
+	factory, ok := globalBackends[name]
+	if !ok {
+		backends := make([]string, 0, len(globalBackends))
+		for backend := range globalBackends {
+			backends = append(backends, backend)
+		}
+		sort.Strings(backends)
+		return nil, fmt.Errorf("unknown backend %s, registered backends are %v", name, backends)
+	}
+	return factory, nil
+}
+
+// newBackendObjectLayer creates the object layer of a registered backend.
+func newBackendObjectLayer(ctx context.Context, name string) (ObjectLayer, error) {
+	factory, err := lookupBackend(name)
+	if err != nil {
+		return nil, err
+	}
+	newObject, err := factory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if newObject == nil {
+		return nil, fmt.Errorf("backend %s returned no object layer", name)
+	}
+
+	// Registered backends have no format to save a deployment id in,
+	// a new one is generated at every start.
+	globalDeploymentID = mustGetUUID()
+	return newObject, nil
+}
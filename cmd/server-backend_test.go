@@ -0,0 +1,75 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRegisterBackend(t *testing.T) {
+	ctx := context.Background()
+
+	objLayer := NewMemObjectLayer()
+	factory := func(ctx context.Context) (ObjectLayer, error) {
+		return objLayer, nil
+	}
+	if err := RegisterBackend("", factory); err == nil {
+		t.Fatal("Expected a backend without name to be rejected")
+	}
+	if err := RegisterBackend("test-backend", nil); err == nil {
+		t.Fatal("Expected a backend without factory to be rejected")
+	}
+	if err := RegisterBackend("test-backend", factory); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterBackend("test-backend", factory); err == nil {
+		t.Fatal("Expected a backend to be registered once")
+	}
+
+	newObject, err := newBackendObjectLayer(ctx, "test-backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newObject != objLayer {
+		t.Fatal("Expected the object layer of the registered backend")
+	}
+
+	// The in-memory backend is built-in.
+	newObject, err = newBackendObjectLayer(ctx, memoryBackend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := newObject.(*MemObjects); !ok {
+		t.Fatalf("Expected the in-memory object layer, got %T", newObject)
+	}
+
+	if _, err = newBackendObjectLayer(ctx, "unknown-backend"); err == nil {
+		t.Fatal("Expected an unknown backend to fail")
+	}
+
+	errFactory := errors.New("backend unavailable")
+	if err = RegisterBackend("test-backend-err", func(ctx context.Context) (ObjectLayer, error) {
+		return nil, errFactory
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = newBackendObjectLayer(ctx, "test-backend-err"); err != errFactory {
+		t.Fatalf("Expected the error of the factory, got %v", err)
+	}
+}
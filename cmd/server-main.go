@@ -46,7 +46,55 @@ var ServerFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "address",
 		Value: ":" + GlobalMinioDefaultPort,
-		Usage: "bind to a specific ADDRESS:PORT, ADDRESS can be an IP or hostname",
+		Usage: "bind to a specific ADDRESS:PORT, ADDRESS can be an IP or hostname, several comma separated addresses serve the same APIs",
+	},
+	cli.StringFlag{
+		Name:  "admin-address",
+		Usage: "serve the admin and metrics APIs on ADDRESS:PORT only, instead of the S3 API addresses",
+	},
+	cli.StringFlag{
+		Name:  "sftp-address",
+		Usage: "serve buckets over SFTP on ADDRESS:PORT, disabled by default",
+	},
+	cli.StringFlag{
+		Name:  "sftp-host-key",
+		Usage: "path to the SSH host private key of the SFTP server, generated in the config dir if not set",
+	},
+	cli.StringFlag{
+		Name:  "ftp-address",
+		Usage: "serve buckets over FTP on ADDRESS:PORT, disabled by default",
+	},
+	cli.StringFlag{
+		Name:  "ftp-passive-ports",
+		Usage: "range of the ports of FTP passive data connections, e.g. \"30000-30100\"",
+	},
+	cli.StringFlag{
+		Name:  "ftp-public-ip",
+		Usage: "IP address advertised to FTP clients for passive data connections",
+	},
+	cli.StringFlag{
+		Name:  "ftp-cert",
+		Usage: "path to the TLS certificate of FTPS, defaults to the server certificate if TLS is configured",
+	},
+	cli.StringFlag{
+		Name:  "ftp-key",
+		Usage: "path to the TLS private key of FTPS, defaults to the server private key if TLS is configured",
+	},
+	cli.BoolFlag{
+		Name:  "swift",
+		Usage: "serve buckets over the OpenStack Swift API at /minio/swift, disabled by default",
+	},
+	cli.BoolFlag{
+		Name:  "memory",
+		Usage: "keep buckets and objects in memory instead of DIR, all content is lost when the server stops",
+	},
+	cli.StringFlag{
+		Name:  "memory-max-size",
+		Usage: "limit the size of the content kept in memory with --memory, e.g. \"4GiB\", defaults to half the memory of the host",
+	},
+	cli.StringFlag{
+		Name:  "backend",
+		Usage: "serve buckets from the object layer registered as NAME instead of DIR, by programs embedding MinIO",
 	},
 }
 
@@ -62,6 +110,8 @@ USAGE:
   {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}DIR1 [DIR2..]
   {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}DIR{1...64}
   {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}DIR{1...64} DIR{65...128}
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}--memory
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}--backend NAME
 
 DIR:
   DIR points to a directory on a filesystem. When you want to combine
@@ -92,6 +142,9 @@ EXAMPLES:
      {{.Prompt}} {{.HelpName}} http://node{1...16}.example.com/mnt/export{1...32} \
             http://node{17...64}.example.com/mnt/export{1...64}
 
+  5. Start minio server keeping all content in memory, e.g. for tests.
+     {{.Prompt}} {{.HelpName}} --memory
+
 `,
 }
 
@@ -113,11 +166,37 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 
 	globalMinioAddr = globalCLIContext.Addr
 
+	globalCLIContext.SFTPAddr = ctx.String("sftp-address")
+	globalCLIContext.SFTPHostKey = ctx.String("sftp-host-key")
+	globalCLIContext.FTP = ftpServerConfig{
+		Addr:         ctx.String("ftp-address"),
+		PassivePorts: ctx.String("ftp-passive-ports"),
+		PublicIP:     ctx.String("ftp-public-ip"),
+		CertFile:     ctx.String("ftp-cert"),
+		KeyFile:      ctx.String("ftp-key"),
+	}
+	globalCLIContext.Swift = ctx.Bool("swift")
+	globalCLIContext.Backend = ctx.String("backend")
+	if ctx.Bool("memory") {
+		globalCLIContext.Backend = memoryBackend
+		globalCLIContext.MemoryMaxSize, err = getMemoryMaxSize(ctx.String("memory-max-size"))
+		logger.FatalIf(err, "Invalid --memory-max-size argument")
+	}
+
 	globalMinioHost, globalMinioPort = mustSplitHostPort(globalMinioAddr)
 	endpoints := strings.Fields(env.Get(config.EnvEndpoints, ""))
-	if len(endpoints) > 0 {
+	switch {
+	case globalCLIContext.Backend != "":
+		// Registered backends are single nodes without drives.
+		if len(endpoints) > 0 || ctx.Args().Present() {
+			err = errors.New("DIR arguments can't be combined with --memory or --backend")
+		} else {
+			_, err = lookupBackend(globalCLIContext.Backend)
+		}
+		setupType = FSSetupType
+	case len(endpoints) > 0:
 		globalEndpoints, globalErasureSetDriveCount, setupType, err = createServerEndpoints(globalCLIContext.Addr, endpoints...)
-	} else {
+	default:
 		globalEndpoints, globalErasureSetDriveCount, setupType, err = createServerEndpoints(globalCLIContext.Addr, ctx.Args()...)
 	}
 	logger.FatalIf(err, "Invalid command line arguments")
@@ -126,7 +205,10 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	// to IPv6 address ie minio will start listening on IPv6 address whereas another
 	// (non-)minio process is listening on IPv4 of given port.
 	// To avoid this error situation we check for port availability.
-	logger.FatalIf(checkPortAvailability(globalMinioHost, globalMinioPort), "Unable to start the server")
+	if !xhttp.IsInherited(globalMinioAddr) {
+		logger.FatalIf(checkPortAvailability(globalMinioHost, globalMinioPort), "Unable to start the server")
+	}
+	logger.FatalIf(checkExtraServerAddrs(), "Unable to start the server")
 
 	globalIsErasure = (setupType == ErasureSetupType)
 	globalIsDistErasure = (setupType == DistErasureSetupType)
@@ -168,8 +250,17 @@ func newAllSubsystems() {
 	// Create new bucket quota subsystem
 	globalBucketQuotaSys = NewBucketQuotaSys()
 
+	// Create new bucket hooks subsystem
+	globalBucketHooksSys = NewBucketHooksSys()
+
 	// Create new bucket versioning subsystem
 	globalBucketVersioningSys = NewBucketVersioningSys()
+
+	// Create new bucket replication subsystem
+	globalReplicationSys = NewReplicationSys()
+
+	// Create new tenant subsystem
+	globalTenantSys = NewTenantSys()
 }
 
 func initSafeMode(ctx context.Context, newObject ObjectLayer) (err error) {
@@ -211,6 +302,13 @@ func initSafeMode(ctx context.Context, newObject ObjectLayer) (err error) {
 	if globalIsErasure {
 		initBackgroundHealing(ctx, newObject)
 		initLocalDisksAutoHeal(ctx, newObject)
+		driveHealth, err := config.ParseBool(env.Get(envDriveHealth, config.EnableOff))
+		if err != nil {
+			logger.Fatal(config.ErrInvalidDriveHealthValue(err), "Invalid MINIO_DRIVE_HEALTH value in environment variable")
+		}
+		if driveHealth {
+			go monitorDriveHealth(ctx, newObject)
+		}
 	}
 
 	// ****  WARNING ****
@@ -323,6 +421,18 @@ func initAllSubsystems(ctx context.Context, newObject ObjectLayer) (err error) {
 		return fmt.Errorf("Unable to initialize config system: %w", err)
 	}
 
+	// Load the zones being decommissioned, no new objects are placed there.
+	if z, ok := newObject.(*erasureZones); ok {
+		if err = z.loadDecommission(ctx); err != nil {
+			return fmt.Errorf("Unable to load zones decommission: %w", err)
+		}
+	}
+
+	// Load the tenants, their users are restricted to their buckets.
+	if err = globalTenantSys.Init(ctx, newObject); err != nil {
+		return fmt.Errorf("Unable to initialize tenants: %w", err)
+	}
+
 	// Populate existing buckets to the etcd backend
 	if globalDNSConfig != nil {
 		// Background this operation.
@@ -361,11 +471,13 @@ func startBackgroundOps(ctx context.Context, objAPI ObjectLayer) {
 
 	initDataCrawler(ctx, objAPI)
 	initQuotaEnforcement(ctx, objAPI)
+	initReadReplicaSync(ctx, objAPI)
+	initObjectLeaseGC(ctx, objAPI)
 }
 
 // serverMain handler called for 'minio server' command.
 func serverMain(ctx *cli.Context) {
-	if ctx.Args().First() == "help" || !endpointsPresent(ctx) {
+	if ctx.Args().First() == "help" || (!endpointsPresent(ctx) && !ctx.Bool("memory") && ctx.String("backend") == "") {
 		cli.ShowCommandHelpAndExit(ctx, "server", 1)
 	}
 	setDefaultProfilerRates()
@@ -427,6 +539,12 @@ func serverMain(ctx *cli.Context) {
 			"Unable to initialize the server in distributed mode")
 	}
 
+	// Nodes verify each other with certificates derived from the credentials.
+	if globalIsDistErasure && env.Get(envInternodeTLS, config.EnableOff) == config.EnableOn {
+		globalInternodeTLS, err = newInternodeTLS(globalActiveCred.SecretKey, globalEndpoints.LocalHostnames())
+		logger.FatalIf(err, "Unable to generate the internode TLS certificates")
+	}
+
 	// Set system resources to maximum.
 	setMaxResources()
 
@@ -443,8 +561,8 @@ func serverMain(ctx *cli.Context) {
 	}
 
 	var getCert certs.GetCertificateFunc
-	if globalTLSCerts != nil {
-		getCert = globalTLSCerts.GetCertificate
+	if globalTLSCerts != nil || globalACMEManager != nil {
+		getCert = getServerCertificate
 	}
 
 	// Annonying hack to ensure that Go doesn't write its own logging,
@@ -466,8 +584,15 @@ func serverMain(ctx *cli.Context) {
 		}
 	}()
 
-	httpServer := xhttp.NewServer([]string{globalMinioAddr}, criticalErrorHandler{corsHandler(handler)}, getCert)
+	httpServer := xhttp.NewServer(append([]string{globalMinioAddr}, globalCLIContext.ExtraAddrs...),
+		criticalErrorHandler{corsHandler(handler)}, getCert)
 	httpServer.ErrorLog = log.New(pw, "", 0)
+	httpServer.ShutdownTimeout = globalShutdownTimeout
+	httpServer.ProxyProtocol = globalProxyProtocol
+	enableACMEChallenges(httpServer)
+	if globalInternodeTLS != nil {
+		httpServer.InternodeTLSConfig = globalInternodeTLS.serverConfig()
+	}
 	httpServer.BaseContext = func(listener net.Listener) context.Context {
 		return GlobalContext
 	}
@@ -479,6 +604,8 @@ func serverMain(ctx *cli.Context) {
 	globalHTTPServer = httpServer
 	globalObjLayerMutex.Unlock()
 
+	startAdminHTTPServer(configureAdminHandler(true, true), getCert)
+
 	if globalIsDistErasure && globalEndpoints.FirstLocal() {
 		for {
 			// Additionally in distributed setup, validate the setup and configuration.
@@ -513,8 +640,23 @@ func serverMain(ctx *cli.Context) {
 
 	newAllSubsystems()
 
+	if globalIsDistErasure {
+		go globalPeerHeartbeat.run(GlobalContext, globalNotificationSys.peerClients)
+	}
+
 	go startBackgroundOps(GlobalContext, newObject)
 
+	go globalAccessStats.run(GlobalContext, newObject)
+
+	auditTrail, err := config.ParseBool(env.Get(envAuditTrail, config.EnableOff))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidAuditTrailValue(err), "Invalid MINIO_AUDIT_TRAIL value in environment variable")
+	}
+	if auditTrail {
+		logger.AddAuditTarget(globalAuditTrail)
+		go globalAuditTrail.run(GlobalContext, newObject)
+	}
+
 	logger.FatalIf(initSafeMode(GlobalContext, newObject), "Unable to initialize server switching into safe-mode")
 
 	// Initialize users credentials and policies in background.
@@ -536,6 +678,18 @@ func serverMain(ctx *cli.Context) {
 	globalSafeMode = false
 	globalObjLayerMutex.Unlock()
 
+	if globalCLIContext.SFTPAddr != "" {
+		logger.FatalIf(startSFTPServer(globalCLIContext.SFTPAddr, globalCLIContext.SFTPHostKey), "Unable to start the SFTP server")
+	}
+
+	if globalCLIContext.FTP.Addr != "" {
+		ftpConfig := globalCLIContext.FTP
+		if ftpConfig.CertFile == "" && ftpConfig.KeyFile == "" && globalTLSCerts != nil {
+			ftpConfig.CertFile, ftpConfig.KeyFile = getPublicCertFile(), getPrivateKeyFile()
+		}
+		logger.FatalIf(startFTPServer(ftpConfig), "Unable to start the FTP server")
+	}
+
 	// Prints the formatted startup message once object layer is initialized.
 	printStartupMessage(getAPIEndpoints())
 
@@ -549,6 +703,10 @@ func serverMain(ctx *cli.Context) {
 
 // Initialize object layer with the supplied disks, objectLayer is nil upon any error.
 func newObjectLayer(ctx context.Context, endpointZones EndpointZones) (newObject ObjectLayer, err error) {
+	if globalCLIContext.Backend != "" {
+		return newBackendObjectLayer(ctx, globalCLIContext.Backend)
+	}
+
 	// For FS only, directly use the disk.
 	if endpointZones.NEndpoints() == 1 {
 		// Initialize new FS object layer.
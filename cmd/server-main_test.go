@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 )
@@ -63,3 +65,52 @@ func TestNewObjectLayer(t *testing.T) {
 		t.Fatal("Unexpected object layer detected", reflect.TypeOf(obj))
 	}
 }
+
+// Tests the validation of the extra and admin addresses.
+func TestCheckExtraServerAddrs(t *testing.T) {
+	defer func(addr string, extraAddrs []string, adminAddr string) {
+		globalCLIContext.Addr = addr
+		globalCLIContext.ExtraAddrs = extraAddrs
+		globalCLIContext.AdminAddr = adminAddr
+	}(globalCLIContext.Addr, globalCLIContext.ExtraAddrs, globalCLIContext.AdminAddr)
+
+	testCases := []struct {
+		extraAddrs []string
+		adminAddr  string
+		expectErr  bool
+	}{
+		{nil, "", false},
+		{[]string{"127.0.0.1:0"}, "127.0.0.1:0", true},
+		{[]string{":9000"}, "", true},
+		{nil, "8.8.8.8:9001", true},
+	}
+	for i, testCase := range testCases {
+		globalCLIContext.Addr = ":9000"
+		globalCLIContext.ExtraAddrs = testCase.extraAddrs
+		globalCLIContext.AdminAddr = testCase.adminAddr
+		if err := checkExtraServerAddrs(); (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+// Tests that the admin address serves the admin, metrics and
+// healthcheck APIs only.
+func TestConfigureAdminHandler(t *testing.T) {
+	handler := configureAdminHandler(true, true)
+
+	testCases := []struct {
+		path         string
+		expectedCode int
+	}{
+		{healthCheckPathPrefix + healthCheckLivenessPath, http.StatusOK},
+		{"/bucket/object", http.StatusNotFound},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, testCase.path, nil))
+		if rec.Code != testCase.expectedCode {
+			t.Errorf("Test %d: %s expected status %d, got %d", i+1, testCase.path, testCase.expectedCode, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/minio/minio/pkg/bucket/policy"
+)
+
+// serverMode restricts the operations accepted by the server.
+type serverMode int32
+
+const (
+	// serverModeNormal - all operations are accepted.
+	serverModeNormal serverMode = iota
+	// serverModeReadOnly - operations modifying buckets or objects are rejected.
+	serverModeReadOnly
+	// serverModeWriteOnce - new objects can be written, but objects
+	// cannot be overwritten and buckets and objects cannot be deleted.
+	serverModeWriteOnce
+)
+
+// String - returns the name of the server mode.
+func (m serverMode) String() string {
+	switch m {
+	case serverModeReadOnly:
+		return "read-only"
+	case serverModeWriteOnce:
+		return "write-once"
+	}
+	return "normal"
+}
+
+// parseServerMode - parses the name of a server mode.
+func parseServerMode(s string) (serverMode, error) {
+	for _, m := range []serverMode{serverModeNormal, serverModeReadOnly, serverModeWriteOnce} {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+	return serverModeNormal, fmt.Errorf("unknown server mode '%s'", s)
+}
+
+// globalServerMode is the current server mode, it is set with the
+// read-only and write-once flags and can be changed by admins.
+var globalServerMode int32
+
+func getServerMode() serverMode {
+	return serverMode(atomic.LoadInt32(&globalServerMode))
+}
+
+func setServerMode(m serverMode) {
+	atomic.StoreInt32(&globalServerMode, int32(m))
+}
+
+// errServerReadOnly - the server is in read-only mode.
+var errServerReadOnly = errors.New("Server is in read-only mode, buckets and objects cannot be modified")
+
+// errServerWriteOnce - the server is in write-once mode.
+var errServerWriteOnce = errors.New("Server is in write-once mode, objects cannot be overwritten or deleted")
+
+// isModifyingAction - returns true if the action modifies buckets or objects.
+func isModifyingAction(action policy.Action) bool {
+	for _, prefix := range []string{"s3:Put", "s3:Delete", "s3:Create", "s3:ForceDelete", "s3:Abort", "s3:Restore", "s3:Replicate"} {
+		if strings.HasPrefix(string(action), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkServerMode - returns an error if the server mode does not allow
+// the action, overwrites in write-once mode are checked separately by
+// checkServerModeOverwrite.
+func checkServerMode(action policy.Action) error {
+	switch getServerMode() {
+	case serverModeReadOnly:
+		if isModifyingAction(action) {
+			return errServerReadOnly
+		}
+	case serverModeWriteOnce:
+		switch action {
+		case policy.DeleteObjectAction, policy.DeleteObjectVersionAction,
+			policy.DeleteBucketAction, policy.ForceDeleteBucketAction,
+			policy.ReplicateDeleteAction:
+			return errServerWriteOnce
+		}
+	}
+	return nil
+}
+
+// checkServerModeOverwrite - returns an error if the object exists
+// and the server is in write-once mode.
+func checkServerModeOverwrite(ctx context.Context, bucket, object string, getObjectInfoFn GetObjectInfoFn) error {
+	if getServerMode() != serverModeWriteOnce {
+		return nil
+	}
+	oi, err := getObjectInfoFn(ctx, bucket, object, ObjectOptions{})
+	if err == nil {
+		return errServerWriteOnce
+	}
+	if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+		return nil
+	}
+	if _, ok := err.(MethodNotAllowed); ok && oi.DeleteMarker {
+		// The latest version is a delete marker.
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucket/policy"
+)
+
+func TestParseServerMode(t *testing.T) {
+	for _, mode := range []serverMode{serverModeNormal, serverModeReadOnly, serverModeWriteOnce} {
+		parsed, err := parseServerMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("expected %s, got %s (%v)", mode, parsed, err)
+		}
+	}
+	if _, err := parseServerMode("read-write"); err == nil {
+		t.Error("expected an error for an unknown server mode")
+	}
+}
+
+func TestCheckServerMode(t *testing.T) {
+	defer setServerMode(getServerMode())
+
+	testCases := []struct {
+		mode   serverMode
+		action policy.Action
+		err    error
+	}{
+		{serverModeNormal, policy.PutObjectAction, nil},
+		{serverModeNormal, policy.DeleteBucketAction, nil},
+		{serverModeReadOnly, policy.GetObjectAction, nil},
+		{serverModeReadOnly, policy.ListBucketAction, nil},
+		{serverModeReadOnly, policy.PutObjectAction, errServerReadOnly},
+		{serverModeReadOnly, policy.CreateBucketAction, errServerReadOnly},
+		{serverModeReadOnly, policy.DeleteObjectAction, errServerReadOnly},
+		{serverModeReadOnly, policy.PutBucketPolicyAction, errServerReadOnly},
+		{serverModeReadOnly, policy.AbortMultipartUploadAction, errServerReadOnly},
+		{serverModeWriteOnce, policy.PutObjectAction, nil},
+		{serverModeWriteOnce, policy.CreateBucketAction, nil},
+		{serverModeWriteOnce, policy.PutObjectTaggingAction, nil},
+		{serverModeWriteOnce, policy.DeleteObjectAction, errServerWriteOnce},
+		{serverModeWriteOnce, policy.DeleteObjectVersionAction, errServerWriteOnce},
+		{serverModeWriteOnce, policy.DeleteBucketAction, errServerWriteOnce},
+	}
+
+	for i, testCase := range testCases {
+		setServerMode(testCase.mode)
+		if err := checkServerMode(testCase.action); err != testCase.err {
+			t.Errorf("Test %d: %s %s: expected %v, got %v", i+1, testCase.mode, testCase.action, testCase.err, err)
+		}
+	}
+}
+
+func TestServerModeHandlers(t *testing.T) {
+	defer setServerMode(getServerMode())
+	ExecObjectLayerAPITest(t, testServerModeHandlers, []string{"PutObject", "GetObject", "DeleteObject"})
+}
+
+func testServerModeHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	data := []byte("hello")
+	if _, err := obj.PutObject(context.Background(), bucketName, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	do := func(method, url string) int {
+		var body []byte
+		if method == http.MethodPut {
+			body = data
+		}
+		req, err := newTestSignedRequestV4(method, url, int64(len(body)), bytes.NewReader(body),
+			credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	testCases := []struct {
+		mode           serverMode
+		method         string
+		object         string
+		expectedStatus int
+	}{
+		{serverModeReadOnly, http.MethodGet, "object", http.StatusOK},
+		{serverModeReadOnly, http.MethodPut, "new-object", http.StatusForbidden},
+		{serverModeReadOnly, http.MethodDelete, "object", http.StatusForbidden},
+		{serverModeWriteOnce, http.MethodPut, "new-object", http.StatusOK},
+		{serverModeWriteOnce, http.MethodPut, "new-object", http.StatusForbidden},
+		{serverModeWriteOnce, http.MethodPut, "object", http.StatusForbidden},
+		{serverModeWriteOnce, http.MethodDelete, "object", http.StatusForbidden},
+		{serverModeNormal, http.MethodPut, "object", http.StatusOK},
+		{serverModeNormal, http.MethodDelete, "object", http.StatusNoContent},
+	}
+
+	for i, testCase := range testCases {
+		setServerMode(testCase.mode)
+		url := getPutObjectURL("", bucketName, testCase.object)
+		if status := do(testCase.method, url); status != testCase.expectedStatus {
+			t.Errorf("%s: Test %d: %s %s in %s mode: expected %d, got %d", instanceType, i+1,
+				testCase.method, testCase.object, testCase.mode, testCase.expectedStatus, status)
+		}
+	}
+}
@@ -0,0 +1,684 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/event"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/pkg/sftp"
+)
+
+var (
+	errSFTPDirNotEmpty        = errors.New("directory not empty")
+	errSFTPIncompleteTransfer = errors.New("file was not written sequentially")
+	errSFTPTooManyPending     = errors.New("too many writes ahead of the current offset")
+)
+
+// sftpMaxPendingBytes - the writes ahead of the current offset held by
+// an upload, well above the pipelined requests of the usual clients.
+const sftpMaxPendingBytes = 16 * humanize.MiByte
+
+// sftpDriver - maps the SFTP requests of an authenticated user onto
+// the object layer, the first path component is the bucket and the
+// remaining components are the object name. Directories other than
+// buckets are prefixes of object names.
+type sftpDriver struct {
+	accessKey string
+	owner     bool
+	remoteIP  string
+
+	// Set when the connection of the user is encrypted.
+	secure bool
+}
+
+func (d *sftpDriver) context(api, bucket, object string) context.Context {
+	reqInfo := logger.NewReqInfo(d.remoteIP, "", globalDeploymentID, "", api, bucket, object)
+	reqInfo.AppendTags("accessKey", d.accessKey)
+	return logger.SetReqInfo(GlobalContext, reqInfo)
+}
+
+func (d *sftpDriver) isAllowed(action iampolicy.Action, bucket, object string) bool {
+	if checkServerMode(policy.Action(action)) != nil {
+		return false
+	}
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName: d.accessKey,
+		Action:      action,
+		BucketName:  bucket,
+		ObjectName:  object,
+		ConditionValues: map[string][]string{
+			"CurrentTime":     {UTCNow().Format(time.RFC3339)},
+			"EpochTime":       {strconv.FormatInt(UTCNow().Unix(), 10)},
+			"SecureTransport": {strconv.FormatBool(d.secure)},
+			"SourceIp":        {d.remoteIP},
+			"principaltype":   {"User"},
+			"userid":          {d.accessKey},
+			"username":        {d.accessKey},
+		},
+		IsOwner: d.owner,
+	})
+}
+
+func (d *sftpDriver) sendEvent(name event.Name, bucket string, objInfo ObjectInfo) {
+	sendEvent(eventArgs{
+		EventName:  name,
+		BucketName: bucket,
+		Object:     objInfo,
+		ReqParams: map[string]string{
+			"accessKey":       d.accessKey,
+			"sourceIPAddress": d.remoteIP,
+		},
+		Host: d.remoteIP,
+	})
+}
+
+// splitSFTPPath - returns the bucket and the object name of an
+// absolute SFTP or FTP path, the object name is normalized, see
+// normalizeObjectName().
+func splitSFTPPath(p string) (bucket, object string) {
+	p = strings.TrimPrefix(path.Clean(SlashSeparator+p), SlashSeparator)
+	if i := strings.Index(p, SlashSeparator); i >= 0 {
+		return p[:i], normalizeObjectName(p[i+1:])
+	}
+	return p, ""
+}
+
+// toSFTPError - converts object layer errors to the errors
+// understood by the SFTP request server.
+func toSFTPError(err error) error {
+	switch err.(type) {
+	case BucketNotFound, BucketNameInvalid, ObjectNotFound, ObjectNameInvalid:
+		return os.ErrNotExist
+	case PrefixAccessDenied:
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	return err
+}
+
+// isWORMBucket - objects of buckets with object locking enabled can
+// only be written and deleted through the S3 API, which accepts the
+// retention settings. The same applies to all buckets in write-once
+// mode, since overwrites are not detected here.
+func isWORMBucket(bucket string) bool {
+	return getServerMode() == serverModeWriteOnce || isObjectLockBucket(bucket)
+}
+
+// isObjectLockBucket - returns true if object locking is enabled on
+// the bucket or if its configuration cannot be read.
+func isObjectLockBucket(bucket string) bool {
+	retention, err := globalBucketObjectLockSys.Get(bucket)
+	return err != nil || retention.LockEnabled
+}
+
+// Fileread - opens an object for reading.
+func (d *sftpDriver) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	bucket, object := splitSFTPPath(r.Filepath)
+	if object == "" {
+		return nil, sftp.ErrSSHFxOpUnsupported
+	}
+	if !d.isAllowed(iampolicy.GetObjectAction, bucket, object) {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	ctx := d.context("SFTPGetObject", bucket, object)
+	objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		return nil, err
+	}
+
+	d.sendEvent(event.ObjectAccessedGet, bucket, objInfo)
+	return &sftpReaderAt{
+		ctx:    ctx,
+		objAPI: objAPI,
+		bucket: bucket,
+		object: object,
+		size:   size,
+	}, nil
+}
+
+// sftpReaderAt - reads an object, reading sequentially from a single
+// GET request as long as the reads are contiguous.
+type sftpReaderAt struct {
+	ctx            context.Context
+	objAPI         ObjectLayer
+	bucket, object string
+	size           int64
+
+	mu     sync.Mutex
+	reader *GetObjectReader
+	offset int64
+}
+
+func (r *sftpReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.reader == nil || r.offset != off {
+		if r.reader != nil {
+			r.reader.Close()
+		}
+		rs := &HTTPRangeSpec{Start: off, End: r.size - 1}
+		r.reader, err = r.objAPI.GetObjectNInfo(r.ctx, r.bucket, r.object, rs, nil, readLock, ObjectOptions{})
+		if err != nil {
+			r.reader = nil
+			return 0, toSFTPError(err)
+		}
+		r.offset = off
+	}
+
+	n, err = io.ReadFull(r.reader, p)
+	r.offset += int64(n)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (r *sftpReaderAt) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reader != nil {
+		r.reader.Close()
+		r.reader = nil
+	}
+	return nil
+}
+
+// Filewrite - opens an object for writing, the object is uploaded
+// while it is written and replaces any existing object when closed.
+func (d *sftpDriver) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	bucket, object := splitSFTPPath(r.Filepath)
+	if object == "" || HasSuffix(object, SlashSeparator) {
+		return nil, sftp.ErrSSHFxOpUnsupported
+	}
+	if r.Pflags().Append {
+		// Objects can not be appended to.
+		return nil, sftp.ErrSSHFxOpUnsupported
+	}
+	if !d.isAllowed(iampolicy.PutObjectAction, bucket, object) || isWORMBucket(bucket) {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	ctx := d.context("SFTPPutObject", bucket, object)
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		return nil, toSFTPError(err)
+	}
+	newObject, err := enforceBucketQuota(ctx, bucket, object, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	w := &sftpWriterAt{
+		pw:      pw,
+		pending: make(map[int64][]byte),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		w.objInfo, w.err = putObjectContent(ctx, objAPI, nil, bucket, object, nil, pr, -1, "", map[string]string{})
+		pr.CloseWithError(w.err)
+		if w.err != nil {
+			return
+		}
+		accountBucketQuota(bucket, w.objInfo.Size, newObject)
+		d.sendEvent(event.ObjectCreatedPut, bucket, w.objInfo)
+	}()
+	return w, nil
+}
+
+// sftpWriterAt - streams the writes of a file to the upload of the
+// object, writes ahead of the current offset are held until the
+// missing data is written, up to sftpMaxPendingBytes.
+type sftpWriterAt struct {
+	mu          sync.Mutex
+	pw          *io.PipeWriter
+	offset      int64
+	pending     map[int64][]byte
+	pendingSize int64
+
+	done    chan struct{}
+	objInfo ObjectInfo
+	err     error
+}
+
+func (w *sftpWriterAt) WriteAt(p []byte, off int64) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n = len(p)
+	if off != w.offset {
+		if off < w.offset {
+			return 0, errSFTPIncompleteTransfer
+		}
+		pendingSize := w.pendingSize + int64(len(p)) - int64(len(w.pending[off]))
+		if pendingSize > sftpMaxPendingBytes {
+			w.pw.CloseWithError(errSFTPTooManyPending)
+			return 0, errSFTPTooManyPending
+		}
+		// The request server reuses its buffers.
+		w.pending[off] = append([]byte(nil), p...)
+		w.pendingSize = pendingSize
+		return n, nil
+	}
+
+	for {
+		if _, err = w.pw.Write(p); err != nil {
+			return 0, err
+		}
+		w.offset += int64(len(p))
+
+		var ok bool
+		if p, ok = w.pending[w.offset]; !ok {
+			break
+		}
+		delete(w.pending, w.offset)
+		w.pendingSize -= int64(len(p))
+	}
+	return n, nil
+}
+
+// TransferError - aborts the upload when the transfer fails.
+func (w *sftpWriterAt) TransferError(err error) {
+	w.pw.CloseWithError(err)
+}
+
+// Close - completes the upload, returns its error.
+func (w *sftpWriterAt) Close() error {
+	w.mu.Lock()
+	if len(w.pending) > 0 {
+		w.pw.CloseWithError(errSFTPIncompleteTransfer)
+	} else {
+		w.pw.Close()
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	return toSFTPError(w.err)
+}
+
+// Filecmd - handles the requests which modify the namespace.
+func (d *sftpDriver) Filecmd(r *sftp.Request) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	bucket, object := splitSFTPPath(r.Filepath)
+	switch r.Method {
+	case "Setstat":
+		// Permissions and times are not
+		// stored, ignore them.
+		return nil
+	case "Mkdir":
+		return d.mkdir(objAPI, bucket, object)
+	case "Rmdir":
+		return d.rmdir(objAPI, bucket, object)
+	case "Remove":
+		return d.remove(objAPI, bucket, object)
+	case "Rename":
+		dstBucket, dstObject := splitSFTPPath(r.Target)
+		return d.rename(objAPI, bucket, object, dstBucket, dstObject)
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+// mkdir - creates a bucket, or a directory object in a bucket.
+func (d *sftpDriver) mkdir(objAPI ObjectLayer, bucket, object string) error {
+	if object == "" {
+		if globalDNSConfig != nil {
+			// Buckets are created through the
+			// S3 API in federated deployments.
+			return sftp.ErrSSHFxOpUnsupported
+		}
+		if !d.isAllowed(iampolicy.CreateBucketAction, bucket, "") || isReservedOrInvalidBucket(bucket, true) {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		ctx := d.context("SFTPMakeBucket", bucket, "")
+		return objAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{Location: globalServerRegion})
+	}
+
+	object += SlashSeparator
+	if !d.isAllowed(iampolicy.PutObjectAction, bucket, object) || isWORMBucket(bucket) {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	ctx := d.context("SFTPPutObject", bucket, object)
+	objInfo, err := putObjectContent(ctx, objAPI, nil, bucket, object, nil, bytes.NewReader(nil), 0, "", map[string]string{})
+	if err != nil {
+		return toSFTPError(err)
+	}
+	d.sendEvent(event.ObjectCreatedPut, bucket, objInfo)
+	return nil
+}
+
+// rmdir - removes an empty bucket, or the directory object of an
+// empty directory.
+func (d *sftpDriver) rmdir(objAPI ObjectLayer, bucket, object string) error {
+	if object == "" {
+		if globalDNSConfig != nil {
+			return sftp.ErrSSHFxOpUnsupported
+		}
+		if !d.isAllowed(iampolicy.DeleteBucketAction, bucket, "") || isReservedOrInvalidBucket(bucket, false) {
+			return sftp.ErrSSHFxPermissionDenied
+		}
+		ctx := d.context("SFTPDeleteBucket", bucket, "")
+		if err := objAPI.DeleteBucket(ctx, bucket, false); err != nil {
+			if _, ok := err.(BucketNotEmpty); ok {
+				return errSFTPDirNotEmpty
+			}
+			return toSFTPError(err)
+		}
+		globalNotificationSys.DeleteBucketMetadata(ctx, bucket)
+		return nil
+	}
+
+	object += SlashSeparator
+	if !d.isAllowed(iampolicy.DeleteObjectAction, bucket, object) || isWORMBucket(bucket) {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	ctx := d.context("SFTPDeleteObject", bucket, object)
+	loi, err := objAPI.ListObjects(ctx, bucket, object, "", SlashSeparator, 2)
+	if err != nil {
+		return toSFTPError(err)
+	}
+	if len(loi.Prefixes) > 0 || len(loi.Objects) > 1 || (len(loi.Objects) == 1 && loi.Objects[0].Name != object) {
+		return errSFTPDirNotEmpty
+	}
+	if len(loi.Objects) == 0 {
+		return os.ErrNotExist
+	}
+	objInfo, err := objAPI.DeleteObject(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		return toSFTPError(err)
+	}
+	d.sendEvent(event.ObjectRemovedDelete, bucket, objInfo)
+	return nil
+}
+
+// remove - removes an object.
+func (d *sftpDriver) remove(objAPI ObjectLayer, bucket, object string) error {
+	if object == "" {
+		return sftp.ErrSSHFxOpUnsupported
+	}
+	if !d.isAllowed(iampolicy.DeleteObjectAction, bucket, object) || isWORMBucket(bucket) {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	ctx := d.context("SFTPDeleteObject", bucket, object)
+	objInfo, err := objAPI.DeleteObject(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		return toSFTPError(err)
+	}
+	d.sendEvent(event.ObjectRemovedDelete, bucket, objInfo)
+	return nil
+}
+
+// rename - copies an object to its new name and removes it, renaming
+// directories is not supported. Encrypted and compressed objects are
+// not renamed since they are copied as read.
+func (d *sftpDriver) rename(objAPI ObjectLayer, srcBucket, srcObject, dstBucket, dstObject string) error {
+	if srcObject == "" || dstObject == "" {
+		return sftp.ErrSSHFxOpUnsupported
+	}
+	if !d.isAllowed(iampolicy.GetObjectAction, srcBucket, srcObject) ||
+		!d.isAllowed(iampolicy.DeleteObjectAction, srcBucket, srcObject) ||
+		!d.isAllowed(iampolicy.PutObjectAction, dstBucket, dstObject) ||
+		isWORMBucket(srcBucket) || isWORMBucket(dstBucket) {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+
+	ctx := d.context("SFTPRenameObject", srcBucket, srcObject)
+	objInfo, err := func() (ObjectInfo, error) {
+		gr, err := objAPI.GetObjectNInfo(ctx, srcBucket, srcObject, nil, nil, readLock, ObjectOptions{})
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		// Release the read lock of the source before it is removed.
+		defer gr.Close()
+
+		srcInfo := gr.ObjInfo
+		if crypto.IsEncrypted(srcInfo.UserDefined) || srcInfo.IsCompressed() {
+			return ObjectInfo{}, sftp.ErrSSHFxOpUnsupported
+		}
+
+		return putObjectContent(ctx, objAPI, nil, dstBucket, dstObject, nil, gr, srcInfo.Size, "", cleanMetadata(srcInfo.UserDefined))
+	}()
+	if err != nil {
+		return toSFTPError(err)
+	}
+	d.sendEvent(event.ObjectCreatedCopy, dstBucket, objInfo)
+
+	if srcBucket == dstBucket && srcObject == dstObject {
+		return nil
+	}
+	return d.remove(objAPI, srcBucket, srcObject)
+}
+
+// Filelist - lists directories and stats files.
+func (d *sftpDriver) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+
+	bucket, object := splitSFTPPath(r.Filepath)
+	switch r.Method {
+	case "List":
+		if bucket == "" {
+			return d.listBuckets(objAPI)
+		}
+		return d.listDir(objAPI, bucket, object)
+	case "Stat":
+		fi, err := d.stat(objAPI, bucket, object)
+		if err != nil {
+			return nil, err
+		}
+		return sftpFileInfos{fi}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+func (d *sftpDriver) listBuckets(objAPI ObjectLayer) (sftpFileInfos, error) {
+	if !d.isAllowed(iampolicy.ListAllMyBucketsAction, "", "") {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+	buckets, err := objAPI.ListBuckets(d.context("SFTPListBuckets", "", ""))
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+	fis := make(sftpFileInfos, 0, len(buckets))
+	for _, bucket := range buckets {
+		fis = append(fis, sftpDirInfo(bucket.Name, bucket.Created))
+	}
+	return fis, nil
+}
+
+func (d *sftpDriver) listDir(objAPI ObjectLayer, bucket, object string) (sftpFileInfos, error) {
+	if !d.isAllowed(iampolicy.ListBucketAction, bucket, "") {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	prefix := object
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+
+	ctx := d.context("SFTPListObjects", bucket, prefix)
+	var fis sftpFileInfos
+	var found bool
+	var marker string
+	for {
+		loi, err := objAPI.ListObjects(ctx, bucket, prefix, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		for _, obj := range loi.Objects {
+			found = true
+			if obj.Name == prefix {
+				// Directory object of the listed directory.
+				continue
+			}
+			name := strings.TrimPrefix(obj.Name, prefix)
+			if HasSuffix(name, SlashSeparator) {
+				fis = append(fis, sftpDirInfo(strings.TrimSuffix(name, SlashSeparator), obj.ModTime))
+				continue
+			}
+			fis = append(fis, sftpObjectInfo(name, obj))
+		}
+		for _, p := range loi.Prefixes {
+			found = true
+			fis = append(fis, sftpDirInfo(strings.TrimSuffix(strings.TrimPrefix(p, prefix), SlashSeparator), time.Time{}))
+		}
+		if !loi.IsTruncated {
+			break
+		}
+		marker = loi.NextMarker
+	}
+
+	if prefix != "" && !found {
+		return nil, os.ErrNotExist
+	}
+	return fis, nil
+}
+
+// stat - returns the file info of a bucket, an object, or of a
+// directory if objects exist under the path.
+func (d *sftpDriver) stat(objAPI ObjectLayer, bucket, object string) (os.FileInfo, error) {
+	if bucket == "" {
+		return sftpDirInfo(SlashSeparator, time.Time{}), nil
+	}
+
+	if object == "" {
+		if !d.isAllowed(iampolicy.ListBucketAction, bucket, "") {
+			return nil, sftp.ErrSSHFxPermissionDenied
+		}
+		bi, err := objAPI.GetBucketInfo(d.context("SFTPGetBucketInfo", bucket, ""), bucket)
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		return sftpDirInfo(bi.Name, bi.Created), nil
+	}
+
+	canGet := d.isAllowed(iampolicy.GetObjectAction, bucket, object)
+	canList := d.isAllowed(iampolicy.ListBucketAction, bucket, "")
+	if !canGet && !canList {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+
+	ctx := d.context("SFTPGetObjectInfo", bucket, object)
+	if canGet {
+		objInfo, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err == nil {
+			return sftpObjectInfo(path.Base(object), objInfo), nil
+		}
+		if err = toSFTPError(err); err != os.ErrNotExist {
+			return nil, err
+		}
+	}
+
+	if canList {
+		loi, err := objAPI.ListObjects(ctx, bucket, object+SlashSeparator, "", SlashSeparator, 1)
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		if len(loi.Objects) > 0 || len(loi.Prefixes) > 0 {
+			return sftpDirInfo(path.Base(object), time.Time{}), nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+// sftpFileInfo - os.FileInfo of an object or a directory.
+type sftpFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func sftpObjectInfo(name string, objInfo ObjectInfo) *sftpFileInfo {
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		size = objInfo.Size
+	}
+	return &sftpFileInfo{name: name, size: size, modTime: objInfo.ModTime}
+}
+
+func sftpDirInfo(name string, modTime time.Time) *sftpFileInfo {
+	return &sftpFileInfo{name: name, modTime: modTime, isDir: true}
+}
+
+func (fi *sftpFileInfo) Name() string       { return fi.name }
+func (fi *sftpFileInfo) Size() int64        { return fi.size }
+func (fi *sftpFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *sftpFileInfo) IsDir() bool        { return fi.isDir }
+func (fi *sftpFileInfo) Sys() interface{}   { return nil }
+
+func (fi *sftpFileInfo) Mode() os.FileMode {
+	if fi.isDir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+// sftpFileInfos - lists file infos to the request server.
+type sftpFileInfos []os.FileInfo
+
+func (fis sftpFileInfos) ListAt(ls []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(fis)) {
+		return 0, io.EOF
+	}
+	n := copy(ls, fis[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -0,0 +1,213 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// Default name of the SSH host key of the SFTP server,
+// generated in the config dir on first start.
+const sftpHostKeyFile = "sftp_host_key"
+
+// Keys of the permissions of an authenticated SFTP connection.
+const (
+	sftpPermAccessKey = "access-key"
+	sftpPermOwner     = "owner"
+)
+
+var errSFTPAuthFailed = errors.New("invalid access key or secret key")
+
+// startSFTPServer - starts the SFTP server on addr, serving the
+// buckets of the object layer to the users of the server.
+func startSFTPServer(addr, hostKeyFile string) error {
+	if hostKeyFile == "" {
+		hostKeyFile = filepath.Join(globalConfigDir.Get(), sftpHostKeyFile)
+	}
+	sshConfig, err := newSFTPServerConfig(hostKeyFile)
+	if err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	go serveSFTP(l, sshConfig)
+	return nil
+}
+
+func newSFTPServerConfig(hostKeyFile string) (*ssh.ServerConfig, error) {
+	hostKey, err := loadSFTPHostKey(hostKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sshConfig := &ssh.ServerConfig{
+		PasswordCallback: sftpPasswordCallback,
+	}
+	sshConfig.AddHostKey(hostKey)
+	return sshConfig, nil
+}
+
+// loadSFTPHostKey - loads the SSH host key, a new key is generated
+// if the key file does not exist.
+func loadSFTPHostKey(file string) (ssh.Signer, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		var key *ecdsa.PrivateKey
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		var der []byte
+		der, err = x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+		if err = os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return nil, err
+		}
+		err = ioutil.WriteFile(file, data, 0600)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(data)
+}
+
+// sftpPasswordCallback - authenticates users with their access key
+// and secret key, temporary credentials and service accounts are not
+// allowed since they can not be presented along with their token.
+func sftpPasswordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	cred, owner, s3Err := checkKeyValid(conn.User())
+	if s3Err != ErrNone {
+		return nil, errSFTPAuthFailed
+	}
+	if !cred.IsValid() || cred.IsTemp() || cred.IsServiceAccount() {
+		return nil, errSFTPAuthFailed
+	}
+	if subtle.ConstantTimeCompare([]byte(cred.SecretKey), password) != 1 {
+		return nil, errSFTPAuthFailed
+	}
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			sftpPermAccessKey: cred.AccessKey,
+			sftpPermOwner:     strconv.FormatBool(owner),
+		},
+	}, nil
+}
+
+func serveSFTP(l net.Listener, sshConfig *ssh.ServerConfig) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			select {
+			case <-GlobalContext.Done():
+			default:
+				logger.LogIf(GlobalContext, err)
+			}
+			return
+		}
+		go handleSFTPConn(conn, sshConfig)
+	}
+}
+
+func handleSFTPConn(conn net.Conn, sshConfig *ssh.ServerConfig) {
+	defer conn.Close()
+
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, sshConfig)
+	if err != nil {
+		// Failed handshake or authentication.
+		return
+	}
+	defer sconn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	d := &sftpDriver{
+		accessKey: sconn.Permissions.Extensions[sftpPermAccessKey],
+		owner:     sconn.Permissions.Extensions[sftpPermOwner] == "true",
+		remoteIP:  sftpRemoteIP(sconn.RemoteAddr()),
+		secure:    true,
+	}
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			logger.LogIf(GlobalContext, err)
+			continue
+		}
+		go handleSFTPSession(d, channel, requests)
+	}
+}
+
+// handleSFTPSession - serves the sftp subsystem of a session,
+// no other subsystem or command can be requested.
+func handleSFTPSession(d *sftpDriver, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	for req := range requests {
+		// Payload of a subsystem request is the
+		// subsystem name as an SSH string.
+		ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+		req.Reply(ok, nil)
+		if !ok {
+			continue
+		}
+
+		go ssh.DiscardRequests(requests)
+		server := sftp.NewRequestServer(channel, sftp.Handlers{
+			FileGet:  d,
+			FilePut:  d,
+			FileCmd:  d,
+			FileList: d,
+		})
+		if err := server.Serve(); err != nil && err != io.EOF {
+			logger.LogIf(GlobalContext, err)
+		}
+		server.Close()
+		return
+	}
+}
+
+func sftpRemoteIP(addr net.Addr) string {
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		return tcpAddr.IP.String()
+	}
+	return addr.String()
+}
@@ -0,0 +1,226 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSplitSFTPPath(t *testing.T) {
+	testCases := []struct {
+		path, bucket, object string
+	}{
+		{"/", "", ""},
+		{"/bucket", "bucket", ""},
+		{"/bucket/", "bucket", ""},
+		{"/bucket/a/b", "bucket", "a/b"},
+		{"bucket/a/../b", "bucket", "b"},
+		{"/../bucket/a", "bucket", "a"},
+	}
+	for i, testCase := range testCases {
+		bucket, object := splitSFTPPath(testCase.path)
+		if bucket != testCase.bucket || object != testCase.object {
+			t.Errorf("Test %d: expected: %s %s, got: %s %s", i+1, testCase.bucket, testCase.object, bucket, object)
+		}
+	}
+}
+
+func TestSFTPServer(t *testing.T) {
+	ExecObjectLayerTest(t, testSFTPServer)
+}
+
+func testSFTPServer(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	dir, err := ioutil.TempDir(globalTestTmpDir, "sftp-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	sshConfig, err := newSFTPServerConfig(filepath.Join(dir, sftpHostKeyFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go serveSFTP(l, sshConfig)
+
+	cred := globalActiveCred
+	clientConfig := &ssh.ClientConfig{
+		User:            cred.AccessKey,
+		Auth:            []ssh.AuthMethod{ssh.Password("invalid-secret")},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+	}
+	if _, err = ssh.Dial("tcp", l.Addr().String(), clientConfig); err == nil {
+		t.Fatalf("%s: expected authentication to fail", instanceType)
+	}
+
+	clientConfig.Auth = []ssh.AuthMethod{ssh.Password(cred.SecretKey)}
+	conn, err := ssh.Dial("tcp", l.Addr().String(), clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err = client.Mkdir("/bucket"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = client.Mkdir("/bucket/dir"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	data := bytes.Repeat([]byte("abcdefgh"), 64*1024)
+	f, err := client.Create("/bucket/dir/object")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err = f.Write(data); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), "bucket", "dir/object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if objInfo.Size != int64(len(data)) {
+		t.Fatalf("%s: expected size %d, got %d", instanceType, len(data), objInfo.Size)
+	}
+
+	fi, err := client.Stat("/bucket/dir")
+	if err != nil || !fi.IsDir() {
+		t.Fatalf("%s: expected /bucket/dir to be a directory: %v", instanceType, err)
+	}
+	fi, err = client.Stat("/bucket/dir/object")
+	if err != nil || fi.IsDir() || fi.Size() != int64(len(data)) {
+		t.Fatalf("%s: unexpected stat of /bucket/dir/object: %v", instanceType, err)
+	}
+	if _, err = client.Stat("/bucket/missing"); !os.IsNotExist(err) {
+		t.Fatalf("%s: expected not exist error, got %v", instanceType, err)
+	}
+
+	f, err = client.Open("/bucket/dir/object")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	got, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatalf("%s: read data does not match written data", instanceType)
+	}
+
+	if err = client.Rename("/bucket/dir/object", "/bucket/dir/renamed"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	fis, err := client.ReadDir("/bucket/dir")
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(fis) != 1 || fis[0].Name() != "renamed" || fis[0].Size() != int64(len(data)) {
+		t.Fatalf("%s: unexpected listing %v", instanceType, fis)
+	}
+
+	if err = client.RemoveDirectory("/bucket/dir"); err == nil {
+		t.Fatalf("%s: expected non empty directory removal to fail", instanceType)
+	}
+	if err = client.Remove("/bucket/dir/renamed"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	// FS removes empty parent directories along with the object.
+	if err = client.RemoveDirectory("/bucket/dir"); err != nil && !os.IsNotExist(err) {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if err = client.RemoveDirectory("/bucket"); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if _, err = obj.GetBucketInfo(context.Background(), "bucket"); err == nil {
+		t.Fatalf("%s: expected bucket to be removed", instanceType)
+	}
+}
+
+func TestSFTPWriterAtMaxPending(t *testing.T) {
+	pr, pw := io.Pipe()
+	w := &sftpWriterAt{
+		pw:      pw,
+		pending: make(map[int64][]byte),
+	}
+	data := make(chan []byte)
+	go func() {
+		b, _ := ioutil.ReadAll(pr)
+		data <- b
+	}()
+
+	chunk := bytes.Repeat([]byte("a"), 1024)
+	// The writes ahead of the offset are held until the gap is filled.
+	if _, err := w.WriteAt(chunk, 1024); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteAt(chunk, 0); err != nil {
+		t.Fatal(err)
+	}
+	if w.offset != 2048 || len(w.pending) != 0 || w.pendingSize != 0 {
+		t.Fatalf("unexpected offset %d with %d pending bytes", w.offset, w.pendingSize)
+	}
+
+	// The upload fails once too much is held.
+	off := w.offset + int64(len(chunk))
+	for ; w.pendingSize+int64(len(chunk)) <= sftpMaxPendingBytes; off += int64(len(chunk)) {
+		if _, err := w.WriteAt(chunk, off); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.WriteAt(chunk, off); err != errSFTPTooManyPending {
+		t.Fatalf("expected %v, got %v", errSFTPTooManyPending, err)
+	}
+	if b := <-data; len(b) != 2048 {
+		t.Fatalf("expected 2048 bytes written, got %d", len(b))
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	// Query parameter of the presigned URLs limited in downloads,
+	// holds the ID of the share link in the metadata of the object.
+	shareLinkQueryKey = "x-minio-share"
+
+	shareLinkMetaPrefix = ReservedMetadataPrefix + "share-"
+
+	// Maximum number of unexpired share links limited in
+	// downloads per object.
+	maxShareLinks = 100
+
+	// Attempts to update the share links of an object updated
+	// concurrently.
+	shareLinkUpdateAttempts = 5
+)
+
+var errTooManyShareLinks = errors.New("Too many share links limited in downloads for this object")
+
+// shareLink - share link limited in downloads, stored as
+// "downloads/max/expiry" in the metadata of the object.
+type shareLink struct {
+	downloads int
+	max       int
+	expiry    time.Time
+}
+
+func (l shareLink) String() string {
+	return fmt.Sprintf("%d/%d/%d", l.downloads, l.max, l.expiry.Unix())
+}
+
+func parseShareLink(s string) (l shareLink, ok bool) {
+	var expiry int64
+	if _, err := fmt.Sscanf(s, "%d/%d/%d", &l.downloads, &l.max, &expiry); err != nil {
+		return l, false
+	}
+	l.expiry = time.Unix(expiry, 0).UTC()
+	return l, true
+}
+
+// valid - returns true if the link neither expired nor
+// reached its maximum number of downloads.
+func (l shareLink) valid(now time.Time) bool {
+	return l.downloads < l.max && now.Before(l.expiry)
+}
+
+// addShareLink - adds a share link limited to max downloads to the
+// object, returns its ID. Expired links are removed.
+func addShareLink(ctx context.Context, objAPI ObjectLayer, bucket, object string, max int, expiry time.Duration) (string, error) {
+	id := mustGetUUID()
+	err := updateShareLinks(ctx, objAPI, bucket, object, func(links map[string]shareLink) error {
+		now := UTCNow()
+		for id, l := range links {
+			if !now.Before(l.expiry) {
+				delete(links, id)
+			}
+		}
+		if len(links) >= maxShareLinks {
+			return errTooManyShareLinks
+		}
+		links[id] = shareLink{max: max, expiry: now.Add(expiry)}
+		return nil
+	})
+	return id, err
+}
+
+// checkShareLink - checks that the share link id of the object is
+// valid, and counts a download.
+func checkShareLink(ctx context.Context, objAPI ObjectLayer, bucket, object, id string) error {
+	return updateShareLinks(ctx, objAPI, bucket, object, func(links map[string]shareLink) error {
+		l, ok := links[id]
+		if !ok || !l.valid(UTCNow()) {
+			return ShareLinkExpired{Bucket: bucket, Object: object}
+		}
+		l.downloads++
+		links[id] = l
+		return nil
+	})
+}
+
+// updateShareLinks - updates the share links in the metadata of the
+// object, the update is attempted again if the metadata changed
+// concurrently.
+func updateShareLinks(ctx context.Context, objAPI ObjectLayer, bucket, object string, update func(links map[string]shareLink) error) error {
+	for i := 0; i < shareLinkUpdateAttempts; i++ {
+		oi, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			return err
+		}
+
+		links := make(map[string]shareLink)
+		for k, v := range oi.UserDefined {
+			if !strings.HasPrefix(k, shareLinkMetaPrefix) {
+				continue
+			}
+			if l, ok := parseShareLink(v); ok {
+				links[strings.TrimPrefix(k, shareLinkMetaPrefix)] = l
+			}
+		}
+		if err = update(links); err != nil {
+			return err
+		}
+
+		srcInfo := metadataUpdate(objAPI, oi)
+		for k := range srcInfo.UserDefined {
+			if strings.HasPrefix(k, shareLinkMetaPrefix) {
+				delete(srcInfo.UserDefined, k)
+			}
+		}
+		for id, l := range links {
+			srcInfo.UserDefined[shareLinkMetaPrefix+id] = l.String()
+		}
+
+		opts := ObjectOptions{
+			VersionID: oi.VersionID,
+			CheckPrecondFn: func(cur ObjectInfo) bool {
+				return cur.ETag != oi.ETag || !cur.ModTime.Equal(oi.ModTime) ||
+					!reflect.DeepEqual(cur.UserDefined, oi.UserDefined)
+			},
+		}
+		if err = copyObjectMetadata(ctx, objAPI, oi, srcInfo, opts); err == nil {
+			return nil
+		}
+		if _, ok := err.(PreConditionFailed); !ok {
+			return err
+		}
+	}
+	return OperationTimedOut{}
+}
@@ -44,16 +44,26 @@ func handleSignals() {
 	stopProcess := func() bool {
 		var err, oerr error
 
-		if globalNotificationSys != nil {
-			globalNotificationSys.RemoveAllRemoteTargets()
+		// Stop accepting connections, and wait for the requests
+		// in progress such as uploads up to the shutdown timeout.
+		if httpServer := newHTTPServerFn(); httpServer != nil {
+			err = httpServer.Shutdown()
+			logger.LogIf(context.Background(), err)
+		}
+		if adminServer := newAdminHTTPServerFn(); adminServer != nil {
+			logger.LogIf(context.Background(), adminServer.Shutdown())
 		}
 
 		// Stop watching for any certificate changes.
 		globalTLSCerts.Stop()
 
-		if httpServer := newHTTPServerFn(); httpServer != nil {
-			err = httpServer.Shutdown()
-			logger.LogIf(context.Background(), err)
+		// Save the events of the last requests to their targets
+		// before closing them.
+		if globalNotificationSys != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), globalShutdownTimeout)
+			logger.LogIf(ctx, globalNotificationSys.Flush(ctx))
+			cancel()
+			globalNotificationSys.RemoveAllRemoteTargets()
 		}
 
 		// send signal to various go-routines that they need to quit.
@@ -84,6 +94,14 @@ func handleSignals() {
 			switch signal {
 			case serviceRestart:
 				logger.Info("Restarting on service signal")
+				// Hand the listening sockets over to the new process, the
+				// connections received meanwhile are queued, not refused.
+				if httpServer := newHTTPServerFn(); httpServer != nil {
+					logger.LogIf(context.Background(), httpServer.HandoffListeners())
+				}
+				if adminServer := newAdminHTTPServerFn(); adminServer != nil {
+					logger.LogIf(context.Background(), adminServer.HandoffListeners())
+				}
 				stop := stopProcess()
 				rerr := restartProcess()
 				logger.LogIf(context.Background(), rerr)
@@ -175,8 +175,10 @@ func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 		return ErrInvalidRequest
 	}
 
-	expectedSignature := preSignatureV2(cred, r.Method, encodedResource, strings.Join(filteredQueries, "&"), r.Header, expires)
+	stringToSign := getStringToSignV2(r.Method, encodedResource, strings.Join(filteredQueries, "&"), r.Header, expires)
+	expectedSignature := calculateSignatureV2(stringToSign, cred.SecretKey)
 	if !compareSignatureV2(gotSignature, expectedSignature) {
+		logSignatureMismatch(r, cred.AccessKey, "", stringToSign, gotSignature)
 		return ErrSignatureDoesNotMatch
 	}
 
@@ -274,8 +276,10 @@ func doesSignV2Match(r *http.Request) APIErrorCode {
 		return ErrSignatureDoesNotMatch
 	}
 	v2Auth = v2Auth[len(prefix):]
-	expectedAuth := signatureV2(cred, r.Method, encodedResource, strings.Join(unescapedQueries, "&"), r.Header)
+	stringToSign := getStringToSignV2(r.Method, encodedResource, strings.Join(unescapedQueries, "&"), r.Header, "")
+	expectedAuth := calculateSignatureV2(stringToSign, cred.SecretKey)
 	if !compareSignatureV2(v2Auth, expectedAuth) {
+		logSignatureMismatch(r, cred.AccessKey, "", stringToSign, v2Auth)
 		return ErrSignatureDoesNotMatch
 	}
 	return ErrNone
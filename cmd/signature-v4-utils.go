@@ -29,6 +29,7 @@ import (
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/sha256-simd"
 )
 
@@ -214,3 +215,61 @@ func signV4TrimAll(input string) string {
 	// unicode.IsSpace() internally here) to one space and return
 	return strings.Join(strings.Fields(input), " ")
 }
+
+// redactCanonicalRequest - replaces the session token of a canonical
+// request, sent as a header or as a query parameter of a presigned
+// request, the other signed headers and parameters are kept.
+func redactCanonicalRequest(canonicalRequest string) string {
+	tokenParam := xhttp.AmzSecurityToken + "="
+	lines := strings.Split(redactStringToSign(canonicalRequest), "\n")
+	if len(lines) > 2 {
+		// The third line is the canonical query string.
+		params := strings.Split(lines[2], "&")
+		for i, param := range params {
+			if strings.HasPrefix(param, tokenParam) {
+				params[i] = tokenParam + "*REDACTED*"
+			}
+		}
+		lines[2] = strings.Join(params, "&")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// redactStringToSign - replaces the session token of the canonical
+// headers of a string to sign, signature V2 signs the headers in the
+// string to sign.
+func redactStringToSign(stringToSign string) string {
+	tokenHeader := strings.ToLower(xhttp.AmzSecurityToken) + ":"
+	lines := strings.Split(stringToSign, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, tokenHeader) {
+			lines[i] = tokenHeader + "*REDACTED*"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// logSignatureMismatch - logs the canonical request and the string to
+// sign computed for a request whose signature didn't match, when
+// enabled with MINIO_DEBUG_SIGNATURE, to compare them with the ones
+// computed by the client. The session tokens are redacted.
+func logSignatureMismatch(r *http.Request, accessKey, canonicalRequest, stringToSign, signature string) {
+	if !globalDebugSignature {
+		return
+	}
+
+	reqInfo := &logger.ReqInfo{
+		DeploymentID: globalDeploymentID,
+		RemoteHost:   handlers.GetSourceIP(r),
+		Host:         getHostName(r),
+		UserAgent:    r.UserAgent(),
+	}
+	reqInfo.AppendTags("accessKey", accessKey)
+	if canonicalRequest != "" {
+		// Signature V2 has no canonical request.
+		reqInfo.AppendTags("canonicalRequest", redactCanonicalRequest(canonicalRequest))
+	}
+	reqInfo.AppendTags("stringToSign", redactStringToSign(stringToSign))
+	reqInfo.AppendTags("signatureProvided", signature)
+	logger.LogIf(logger.SetReqInfo(GlobalContext, reqInfo), errSignatureMismatch)
+}
@@ -272,3 +272,26 @@ func TestGetContentSha256Cksum(t *testing.T) {
 		}
 	}
 }
+
+func TestRedactCanonicalRequest(t *testing.T) {
+	canonicalRequest := "GET\n/bucket/object\n\nhost:localhost:9000\nx-amz-date:20200101T000000Z\nx-amz-security-token:secret-token\n\nhost;x-amz-date;x-amz-security-token\nUNSIGNED-PAYLOAD"
+	expected := "GET\n/bucket/object\n\nhost:localhost:9000\nx-amz-date:20200101T000000Z\nx-amz-security-token:*REDACTED*\n\nhost;x-amz-date;x-amz-security-token\nUNSIGNED-PAYLOAD"
+	if got := redactCanonicalRequest(canonicalRequest); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	// Presigned requests send the token in the query string.
+	canonicalRequest = "GET\n/bucket/object\nX-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Security-Token=secret-token&X-Amz-SignedHeaders=host\nhost:localhost:9000\n\nhost\nUNSIGNED-PAYLOAD"
+	expected = "GET\n/bucket/object\nX-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Security-Token=*REDACTED*&X-Amz-SignedHeaders=host\nhost:localhost:9000\n\nhost\nUNSIGNED-PAYLOAD"
+	if got := redactCanonicalRequest(canonicalRequest); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestRedactStringToSign(t *testing.T) {
+	stringToSign := "GET\n\n\n1577836800\nx-amz-date:20200101T000000Z\nx-amz-security-token:secret-token\n/bucket/object"
+	expected := "GET\n\n\n1577836800\nx-amz-date:20200101T000000Z\nx-amz-security-token:*REDACTED*\n/bucket/object"
+	if got := redactStringToSign(stringToSign); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
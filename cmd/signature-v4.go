@@ -322,6 +322,7 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 
 	// Verify signature.
 	if !compareSignatureV4(req.URL.Query().Get(xhttp.AmzSignature), newSignature) {
+		logSignatureMismatch(r, cred.AccessKey, presignedCanonicalReq, presignedStringToSign, req.URL.Query().Get(xhttp.AmzSignature))
 		return ErrSignatureDoesNotMatch
 	}
 	return ErrNone
@@ -386,6 +387,7 @@ func doesSignatureMatch(hashedPayload string, r *http.Request, region string, st
 
 	// Verify if signature match.
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
+		logSignatureMismatch(r, cred.AccessKey, canonicalRequest, stringToSign, signV4Values.Signature)
 		return ErrSignatureDoesNotMatch
 	}
 
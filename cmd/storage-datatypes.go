@@ -98,6 +98,10 @@ type FileInfo struct {
 
 	// Erasure info for all objects.
 	Erasure ErasureInfo
+
+	// SecureErase is set to overwrite the data of a deleted
+	// version before its removal.
+	SecureErase bool
 }
 
 // newFileInfo - initializes new FileInfo, allocates a fresh erasure info.
@@ -45,6 +45,9 @@ var errFaultyRemoteDisk = StorageErr("remote disk is faulty")
 // errFaultyDisk - disk is faulty.
 var errFaultyDisk = StorageErr("disk is faulty")
 
+// errDiskReadOnly - disk predicted to fail is not written to anymore.
+var errDiskReadOnly = StorageErr("disk is marked read-only as it is predicted to fail")
+
 // errDiskAccessDenied - we don't have write permissions on disk.
 var errDiskAccessDenied = StorageErr("disk access denied")
 
@@ -81,6 +84,9 @@ var errFileAccessDenied = StorageErr("file access denied")
 // errFileCorrupt - file has an unexpected size, or is not readable
 var errFileCorrupt = StorageErr("file is corrupted")
 
+// errMetadataConflict - metadata was updated after it was read for an update.
+var errMetadataConflict = StorageErr("metadata was updated concurrently")
+
 // errFileParentIsFile - cannot have overlapping objects, parent is already a file.
 var errFileParentIsFile = StorageErr("parent is a file")
 
@@ -112,6 +118,7 @@ var baseErrs = []error{
 	errDiskNotFound,
 	errFaultyDisk,
 	errFaultyRemoteDisk,
+	errDiskReadOnly,
 }
 
 var baseIgnoredErrs = baseErrs
@@ -69,6 +69,7 @@ type StorageAPI interface {
 	CheckFile(volume string, path string) (err error)
 	DeleteFile(volume string, path string) (err error)
 	VerifyFile(volume, path string, fi FileInfo) error
+	SecureErase(volume, path string, fi FileInfo) error
 
 	// Write all data, syncs the data to disk.
 	WriteAll(volume string, path string, reader io.Reader) (err error)
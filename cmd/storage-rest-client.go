@@ -65,10 +65,14 @@ func toStorageErr(err error) error {
 		return errFaultyDisk
 	case errFileCorrupt.Error():
 		return errFileCorrupt
+	case errMetadataConflict.Error():
+		return errMetadataConflict
 	case errUnexpected.Error():
 		return errUnexpected
 	case errDiskFull.Error():
 		return errDiskFull
+	case errDiskReadOnly.Error():
+		return errDiskReadOnly
 	case errVolumeNotFound.Error():
 		return errVolumeNotFound
 	case errVolumeExists.Error():
@@ -112,6 +116,17 @@ type storageRESTClient struct {
 	endpoint   Endpoint
 	restClient *rest.Client
 	diskID     string
+	// ioClass is sent with the requests for the serving node to
+	// schedule them, none when empty.
+	ioClass string
+}
+
+// withIOClass returns a client of the same drive whose requests
+// carry the I/O class.
+func (client *storageRESTClient) withIOClass(class ioClass) *storageRESTClient {
+	c := *client
+	c.ioClass = class.String()
+	return &c
 }
 
 // Wrapper to restClient.Call to handle network errors, in case of network error the connection is makred disconnected
@@ -125,6 +140,9 @@ func (client *storageRESTClient) call(method string, values url.Values, body io.
 		values = make(url.Values)
 	}
 	values.Set(storageRESTDiskID, client.diskID)
+	if client.ioClass != "" {
+		values.Set(storageRESTIOClass, client.ioClass)
+	}
 	respBody, err := client.restClient.Call(method, values, body, length)
 	if err == nil {
 		return respBody, nil
@@ -141,8 +159,9 @@ func (client *storageRESTClient) String() string {
 }
 
 // IsOnline - returns whether RPC client failed to connect or not.
+// The disk is also offline while its server misses heartbeats.
 func (client *storageRESTClient) IsOnline() bool {
-	return client.restClient.IsOnline()
+	return client.restClient.IsOnline() && globalPeerHeartbeat.isOnline(client.endpoint.Host)
 }
 
 func (client *storageRESTClient) IsLocal() bool {
@@ -551,6 +570,23 @@ func (client *storageRESTClient) DeleteFile(volume, path string) error {
 	return err
 }
 
+// SecureErase - overwrites the files under path, fi names the
+// object version erased.
+func (client *storageRESTClient) SecureErase(volume, path string, fi FileInfo) error {
+	values := make(url.Values)
+	values.Set(storageRESTVolume, volume)
+	values.Set(storageRESTFilePath, path)
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(fi); err != nil {
+		return err
+	}
+
+	respBody, err := client.call(storageRESTMethodSecureErase, values, &buffer, -1)
+	defer http.DrainBody(respBody)
+	return err
+}
+
 // DeleteVersions - deletes list of specified versions if present
 func (client *storageRESTClient) DeleteVersions(volume string, versions []FileInfo) (errs []error) {
 	if len(versions) == 0 {
@@ -665,8 +701,15 @@ func newStorageRESTClient(endpoint Endpoint) *storageRESTClient {
 		}
 	}
 
+	if globalInternodeTLS != nil {
+		serverURL.Scheme = "https"
+		tlsConfig = globalInternodeTLS.clientConfig(endpoint.Hostname(), tlsConfig)
+	}
+
 	trFn := newCustomHTTPTransport(tlsConfig, rest.DefaultRESTTimeout)
 	restClient := rest.NewClient(serverURL, trFn, newAuthToken)
+	restClient.FaultFn = newRPCFaultFn(serverURL.Host)
+	restClient.Versions = storageRESTVersions
 	restClient.HealthCheckInterval = 500 * time.Millisecond
 	restClient.HealthCheckFn = func() bool {
 		ctx, cancel := context.WithTimeout(GlobalContext, restClient.HealthCheckTimeout)
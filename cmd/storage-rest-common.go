@@ -17,11 +17,16 @@
 package cmd
 
 const (
-	storageRESTVersion       = "v20" // Re-implementation of storage layer
-	storageRESTVersionPrefix = SlashSeparator + storageRESTVersion
-	storageRESTPrefix        = minioReservedBucketPath + "/storage"
+	storageRESTVersion = "v20" // Re-implementation of storage layer
+	storageRESTPrefix  = minioReservedBucketPath + "/storage"
 )
 
+// Versions of the storage REST API served and spoken, newest first.
+// Previous versions are kept as long as their requests and responses
+// are compatible, servers can then be upgraded one at a time while the
+// servers of both releases keep talking to each other.
+var storageRESTVersions = []string{storageRESTVersion}
+
 const (
 	storageRESTMethodHealth               = "/health"
 	storageRESTMethodDiskInfo             = "/diskinfo"
@@ -52,6 +57,7 @@ const (
 	storageRESTMethodDeleteVersions = "/deleteverions"
 	storageRESTMethodRenameFile     = "/renamefile"
 	storageRESTMethodVerifyFile     = "/verifyfile"
+	storageRESTMethodSecureErase    = "/secureerase"
 )
 
 const (
@@ -75,4 +81,5 @@ const (
 	storageRESTBitrotHash    = "bitrot-hash"
 	storageRESTDiskID        = "disk-id"
 	storageRESTForceDelete   = "force-delete"
+	storageRESTIOClass       = "io-class"
 )
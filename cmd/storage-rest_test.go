@@ -17,11 +17,15 @@
 package cmd
 
 import (
+	"context"
 	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/cmd/config"
@@ -589,3 +593,85 @@ func TestStorageRESTClientRenameFile(t *testing.T) {
 
 	testStorageAPIRenameFile(t, restClient)
 }
+
+// Tests the version negotiation with the servers of a previous release.
+func TestStorageRESTClientVersionNegotiation(t *testing.T) {
+	httpServer, restClient, prevGlobalServerConfig, endpointPath := newStorageRESTHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+	defer os.RemoveAll(endpointPath)
+
+	// Routes of unknown versions are answered like the server does.
+	router := httpServer.Config.Handler.(*mux.Router)
+	router.NotFoundHandler = http.HandlerFunc(errorResponseHandler)
+
+	// The client of the next release steps down to the version of the
+	// server, calls with bodies are sent again.
+	restClient.restClient.Versions = []string{"v1000", storageRESTVersion}
+	testStorageAPIReadAll(t, restClient)
+
+	// Clients without a common version get the error of the server.
+	restClient = newStorageRESTClient(restClient.endpoint)
+	restClient.restClient.Versions = []string{"v1000"}
+	if err := restClient.MakeVol("bar"); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Fatalf("expected a version mismatch, got %v", err)
+	}
+}
+
+func TestStorageRESTClientIOClass(t *testing.T) {
+	httpServer, restClient, prevGlobalServerConfig, endpointPath := newStorageRESTHTTPServerClient(t)
+	defer httpServer.Close()
+	defer func() {
+		globalServerConfig = prevGlobalServerConfig
+	}()
+	defer os.RemoveAll(endpointPath)
+
+	er := erasureObjects{getDisks: func() []StorageAPI {
+		return []StorageAPI{restClient, nil}
+	}}
+	disks := er.withIOClass(ioClassHeal).getDisks()
+	healClient, ok := disks[0].(*storageRESTClient)
+	if !ok || healClient.ioClass != ioClassHeal.String() || disks[1] != nil {
+		t.Fatalf("expected the remote drive to send the heal class, got %v", disks)
+	}
+	if restClient.ioClass != "" {
+		t.Fatalf("expected the drive of the set to be unchanged, got class %s", restClient.ioClass)
+	}
+
+	release, err := globalIOScheduler.acquire(context.Background(), ioClassForeground)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	for i := 0; i < ioBackgroundMaxActive; i++ {
+		release, err := globalIOScheduler.acquire(context.Background(), ioClassHeal)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer release()
+	}
+
+	// Requests without a class are not scheduled.
+	start := time.Now()
+	if _, err = restClient.ListVols(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the request not to wait, took %s", elapsed)
+	}
+
+	// The heal requests are not queued again by the serving node, their
+	// object operation waited for its turn on the node running it.
+	start = time.Now()
+	if _, err = healClient.ListVols(); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the heal request not to wait, took %s", elapsed)
+	}
+	if m := globalIOScheduler.metrics(); m[ioClassHeal].Waiting != 0 {
+		t.Fatalf("expected no waiting heal operation, got %d", m[ioClassHeal].Waiting)
+	}
+}
@@ -73,7 +73,7 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	v4Auth := req.Header.Get(xhttp.Authorization)
 
 	// Parse signature version '4' header.
-	signV4Values, errCode := parseSignV4(v4Auth, globalServerRegion, serviceS3)
+	signV4Values, errCode := parseSignV4(v4Auth, getRequestRegion(r), serviceS3)
 	if errCode != ErrNone {
 		return cred, "", "", time.Time{}, errCode
 	}
@@ -132,6 +132,7 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 
 	// Verify if signature match.
 	if !compareSignatureV4(newSignature, signV4Values.Signature) {
+		logSignatureMismatch(r, cred.AccessKey, canonicalRequest, stringToSign, signV4Values.Signature)
 		return cred, "", "", time.Time{}, ErrSignatureDoesNotMatch
 	}
 
@@ -0,0 +1,1320 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	xjwt "github.com/minio/minio/cmd/jwt"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucket/policy"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/handlers"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+const (
+	// Validity of the tokens issued by the Swift authentication.
+	swiftTokenExpiry = 24 * time.Hour
+
+	// Maximum number of entries of a Swift listing.
+	swiftMaxListingLimit = 10000
+
+	// Swift request and response headers.
+	swiftAuthUser              = "X-Auth-User"
+	swiftAuthKey               = "X-Auth-Key"
+	swiftStorageUser           = "X-Storage-User"
+	swiftStoragePass           = "X-Storage-Pass"
+	swiftAuthToken             = "X-Auth-Token"
+	swiftAuthTokenExpires      = "X-Auth-Token-Expires"
+	swiftStorageToken          = "X-Storage-Token"
+	swiftStorageURL            = "X-Storage-Url"
+	swiftTimestamp             = "X-Timestamp"
+	swiftAccountContainerCount = "X-Account-Container-Count"
+	swiftAccountObjectCount    = "X-Account-Object-Count"
+	swiftAccountBytesUsed      = "X-Account-Bytes-Used"
+	swiftContainerObjectCount  = "X-Container-Object-Count"
+	swiftContainerBytesUsed    = "X-Container-Bytes-Used"
+	swiftObjectMetaPrefix      = "X-Object-Meta-"
+	swiftObjectManifest        = "X-Object-Manifest"
+	swiftStaticLargeObject     = "X-Static-Large-Object"
+	swiftCopyFrom              = "X-Copy-From"
+	swiftDestination           = "Destination"
+
+	// Metadata marking the manifests of dynamic and static large objects.
+	swiftDLOManifestKey = ReservedMetadataPrefix + "swift-object-manifest"
+	swiftSLOManifestKey = ReservedMetadataPrefix + "swift-static-large-object"
+
+	swiftLastModifiedFormat = "2006-01-02T15:04:05.000000"
+)
+
+// swiftContainerEntry - a container of a JSON account listing.
+type swiftContainerEntry struct {
+	Name         string `json:"name"`
+	Count        uint64 `json:"count"`
+	Bytes        uint64 `json:"bytes"`
+	LastModified string `json:"last_modified"`
+}
+
+// swiftObjectEntry - an object of a JSON container listing.
+type swiftObjectEntry struct {
+	Name         string `json:"name"`
+	Hash         string `json:"hash"`
+	Bytes        int64  `json:"bytes"`
+	ContentType  string `json:"content_type"`
+	LastModified string `json:"last_modified"`
+}
+
+// swiftSubdirEntry - a common prefix of a JSON container listing.
+type swiftSubdirEntry struct {
+	Subdir string `json:"subdir"`
+}
+
+// swiftSLOSegment - a segment of a static large object as uploaded
+// with multipart-manifest=put, the etag and the size are optional.
+type swiftSLOSegment struct {
+	Path      string  `json:"path"`
+	ETag      *string `json:"etag"`
+	SizeBytes *int64  `json:"size_bytes"`
+}
+
+// swiftSLOManifestEntry - a segment of a static large object as
+// stored in its manifest.
+type swiftSLOManifestEntry struct {
+	Name  string `json:"name"`
+	Hash  string `json:"hash"`
+	Bytes int64  `json:"bytes"`
+}
+
+// swiftSegment - an object holding a part of the content of a
+// Swift object.
+type swiftSegment struct {
+	bucket, object string
+	size           int64
+}
+
+// swiftObject - a Swift object, the content of large objects is the
+// concatenation of the segments referenced by their manifest.
+type swiftObject struct {
+	info     ObjectInfo
+	etag     string
+	size     int64
+	segments []swiftSegment
+}
+
+// swiftAuthenticate - returns the claims of the token of a Swift request.
+func swiftAuthenticate(r *http.Request) (*xjwt.MapClaims, bool, error) {
+	token := r.Header.Get(swiftAuthToken)
+	if token == "" {
+		token = r.Header.Get(swiftStorageToken)
+	}
+	return webTokenAuthenticate(token)
+}
+
+func isSwiftActionAllowed(r *http.Request, claims *xjwt.MapClaims, owner bool, action iampolicy.Action, bucket, object string) bool {
+	if checkServerMode(policy.Action(action)) != nil {
+		return false
+	}
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.AccessKey,
+		Action:          action,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+		IsOwner:         owner,
+		ObjectName:      object,
+		Claims:          claims.Map(),
+	})
+}
+
+// toSwiftAPIError - converts an error to the API error of a Swift
+// response, Swift reports a few errors with different status codes.
+func toSwiftAPIError(ctx context.Context, err error) APIError {
+	switch err {
+	case errNoAuthToken, errAuthentication, errInvalidAccessKeyID:
+		return APIError{
+			Code:           "Unauthorized",
+			Description:    err.Error(),
+			HTTPStatusCode: http.StatusUnauthorized,
+		}
+	}
+	apiErr := toAPIError(ctx, err)
+	if apiErr.Code == "BadDigest" {
+		// The ETag of the request does not match the content.
+		apiErr.HTTPStatusCode = http.StatusUnprocessableEntity
+	}
+	return apiErr
+}
+
+// writeSwiftErrorResponse - Swift errors are returned as plain text.
+func writeSwiftErrorResponse(w http.ResponseWriter, err APIError) {
+	w.Header().Set(xhttp.ContentType, "text/plain; charset=utf-8")
+	writeResponse(w, err.HTTPStatusCode, []byte(err.Description), mimeNone)
+}
+
+// swiftTimestampValue - returns the X-Timestamp value of a time.
+func swiftTimestampValue(t time.Time) string {
+	return fmt.Sprintf("%d.%05d", t.Unix(), t.Nanosecond()/10000)
+}
+
+// swiftListingLimit - returns the limit of the entries of a listing.
+func swiftListingLimit(values url.Values) (int, error) {
+	limit := values.Get("limit")
+	if limit == "" {
+		return swiftMaxListingLimit, nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 0 {
+		return 0, errInvalidArgument
+	}
+	if n > swiftMaxListingLimit {
+		n = swiftMaxListingLimit
+	}
+	return n, nil
+}
+
+// swiftExtractMetadata - extracts the metadata of an object from the
+// headers of a Swift request, X-Object-Meta-* headers are stored as
+// user defined metadata.
+func swiftExtractMetadata(ctx context.Context, r *http.Request) (map[string]string, error) {
+	header := make(http.Header, len(r.Header))
+	for k, v := range r.Header {
+		if strings.HasPrefix(k, swiftObjectMetaPrefix) {
+			k = "X-Amz-Meta-" + strings.TrimPrefix(k, swiftObjectMetaPrefix)
+		}
+		header[k] = v
+	}
+	metadata := make(map[string]string)
+	if err := extractMetadataFromMap(ctx, header, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// swiftBucketObject - returns the bucket and the object of the path of
+// a Swift request.
+func swiftBucketObject(r *http.Request) (bucket, object string, err error) {
+	vars := mux.Vars(r)
+	bucket = vars["bucket"]
+	object, err = unescapeObjectName(vars["object"])
+	return bucket, object, err
+}
+
+// swiftSplitPath - returns the bucket and the object of a path of the
+// form "container/object", as sent in the X-Copy-From, Destination
+// and X-Object-Manifest headers.
+func swiftSplitPath(p string) (bucket, object string, err error) {
+	if p, err = url.PathUnescape(p); err != nil {
+		return "", "", err
+	}
+	bucket, object = path2BucketObject(p)
+	if bucket == "" {
+		return "", "", errInvalidArgument
+	}
+	return bucket, normalizeObjectName(object), nil
+}
+
+// AuthHandler - authenticates a user with TempAuth compatible
+// headers, the user is the access key and the key is the secret
+// key. The account of users of the form "account:user" is ignored,
+// all users share the namespace of the server.
+func (api swiftAPIHandlers) AuthHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftAuth")
+
+	defer logger.AuditLog(w, r, "SwiftAuth", nil)
+
+	if api.ObjectAPI() == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+
+	user := r.Header.Get(swiftAuthUser)
+	if user == "" {
+		user = r.Header.Get(swiftStorageUser)
+	}
+	if i := strings.LastIndex(user, ":"); i >= 0 {
+		user = user[i+1:]
+	}
+	key := r.Header.Get(swiftAuthKey)
+	if key == "" {
+		key = r.Header.Get(swiftStoragePass)
+	}
+
+	token, err := authenticateJWTUsers(user, key, swiftTokenExpiry)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, errAuthentication))
+		return
+	}
+
+	storageURL := getURLScheme(globalIsSSL) + "://" + r.Host + swiftPathPrefix + swiftAPIVersion + "/AUTH_" + url.PathEscape(user)
+	w.Header().Set(swiftAuthToken, token)
+	w.Header().Set(swiftStorageToken, token)
+	w.Header().Set(swiftStorageURL, storageURL)
+	w.Header().Set(swiftAuthTokenExpires, strconv.Itoa(int(swiftTokenExpiry.Seconds())))
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// listContainers - returns the buckets the user can list, sorted by name.
+func (api swiftAPIHandlers) listContainers(ctx context.Context, objectAPI ObjectLayer, r *http.Request, claims *xjwt.MapClaims, owner bool) ([]BucketInfo, error) {
+	bucketsInfo, err := objectAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if isSwiftActionAllowed(r, claims, owner, iampolicy.ListAllMyBucketsAction, "", "") {
+		return bucketsInfo, nil
+	}
+	var allowed []BucketInfo
+	for _, bucketInfo := range bucketsInfo {
+		if isSwiftActionAllowed(r, claims, owner, iampolicy.ListBucketAction, bucketInfo.Name, "") {
+			allowed = append(allowed, bucketInfo)
+		}
+	}
+	if len(allowed) == 0 {
+		return nil, PrefixAccessDenied{}
+	}
+	return allowed, nil
+}
+
+// HeadAccountHandler - returns the number of containers of the account.
+func (api swiftAPIHandlers) HeadAccountHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftHeadAccount")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftHeadAccount", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	bucketsInfo, err := api.listContainers(ctx, objectAPI, r, claims, owner)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	api.setAccountHeaders(ctx, objectAPI, w, bucketsInfo)
+	writeSuccessNoContent(w)
+}
+
+// setAccountHeaders - sets the usage headers of an account, object
+// counts and sizes are the ones last computed by the data usage crawler.
+func (api swiftAPIHandlers) setAccountHeaders(ctx context.Context, objectAPI ObjectLayer, w http.ResponseWriter, bucketsInfo []BucketInfo) {
+	usage, _ := loadDataUsageFromBackend(ctx, objectAPI)
+	var objects, size uint64
+	for _, bucketInfo := range bucketsInfo {
+		objects += usage.BucketsUsage[bucketInfo.Name].ObjectsCount
+		size += usage.BucketsUsage[bucketInfo.Name].Size
+	}
+	w.Header().Set(swiftAccountContainerCount, strconv.Itoa(len(bucketsInfo)))
+	w.Header().Set(swiftAccountObjectCount, strconv.FormatUint(objects, 10))
+	w.Header().Set(swiftAccountBytesUsed, strconv.FormatUint(size, 10))
+}
+
+// ListContainersHandler - lists the containers of the account.
+func (api swiftAPIHandlers) ListContainersHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftListContainers")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftListContainers", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	values := r.URL.Query()
+	limit, err := swiftListingLimit(values)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	bucketsInfo, err := api.listContainers(ctx, objectAPI, r, claims, owner)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	api.setAccountHeaders(ctx, objectAPI, w, bucketsInfo)
+
+	usage, _ := loadDataUsageFromBackend(ctx, objectAPI)
+	prefix, marker, endMarker := values.Get("prefix"), values.Get("marker"), values.Get("end_marker")
+	containers := []swiftContainerEntry{}
+	for _, bucketInfo := range bucketsInfo {
+		if len(containers) == limit {
+			break
+		}
+		if !HasPrefix(bucketInfo.Name, prefix) || bucketInfo.Name <= marker ||
+			(endMarker != "" && bucketInfo.Name >= endMarker) {
+			continue
+		}
+		containers = append(containers, swiftContainerEntry{
+			Name:         bucketInfo.Name,
+			Count:        usage.BucketsUsage[bucketInfo.Name].ObjectsCount,
+			Bytes:        usage.BucketsUsage[bucketInfo.Name].Size,
+			LastModified: bucketInfo.Created.UTC().Format(swiftLastModifiedFormat),
+		})
+	}
+
+	if values.Get("format") == "json" {
+		writeSuccessResponseJSON(w, mustGetJSONBytes(containers))
+		return
+	}
+	names := make([]string, 0, len(containers))
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+	writeSwiftPlainListing(w, names)
+}
+
+// writeSwiftPlainListing - writes a listing with one name per line,
+// empty listings have no content.
+func writeSwiftPlainListing(w http.ResponseWriter, names []string) {
+	if len(names) == 0 {
+		writeSuccessNoContent(w)
+		return
+	}
+	var buf bytes.Buffer
+	for _, name := range names {
+		buf.WriteString(name)
+		buf.WriteByte('\n')
+	}
+	w.Header().Set(xhttp.ContentType, "text/plain; charset=utf-8")
+	writeResponse(w, http.StatusOK, buf.Bytes(), mimeNone)
+}
+
+// mustGetJSONBytes - marshals the entries of a JSON listing.
+func mustGetJSONBytes(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	logger.CriticalIf(GlobalContext, err)
+	return data
+}
+
+// PutContainerHandler - creates a container, existing containers are
+// accepted.
+func (api swiftAPIHandlers) PutContainerHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftPutContainer")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftPutContainer", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+	if globalDNSConfig != nil {
+		// Buckets of federated deployments are registered by the S3 API.
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrNotImplemented))
+		return
+	}
+
+	bucket, _, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.CreateBucketAction, bucket, "") {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	err = objectAPI.MakeBucketWithLocation(ctx, bucket, BucketOptions{Location: globalServerRegion})
+	switch err.(type) {
+	case nil:
+	case BucketExists, BucketAlreadyOwnedByYou:
+		writeResponse(w, http.StatusAccepted, nil, mimeNone)
+		return
+	default:
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	// Load updated bucket metadata into memory.
+	globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
+
+	writeResponse(w, http.StatusCreated, nil, mimeNone)
+}
+
+// HeadContainerHandler - returns the usage of a container.
+func (api swiftAPIHandlers) HeadContainerHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftHeadContainer")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftHeadContainer", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	bucket, _, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.ListBucketAction, bucket, "") {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	bucketInfo, err := objectAPI.GetBucketInfo(ctx, bucket)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	api.setContainerHeaders(ctx, objectAPI, w, bucketInfo)
+	writeSuccessNoContent(w)
+}
+
+// setContainerHeaders - sets the usage headers of a container, object
+// counts and sizes are the ones last computed by the data usage crawler.
+func (api swiftAPIHandlers) setContainerHeaders(ctx context.Context, objectAPI ObjectLayer, w http.ResponseWriter, bucketInfo BucketInfo) {
+	usage, _ := loadDataUsageFromBackend(ctx, objectAPI)
+	bucketUsage := usage.BucketsUsage[bucketInfo.Name]
+	w.Header().Set(swiftContainerObjectCount, strconv.FormatUint(bucketUsage.ObjectsCount, 10))
+	w.Header().Set(swiftContainerBytesUsed, strconv.FormatUint(bucketUsage.Size, 10))
+	w.Header().Set(swiftTimestamp, swiftTimestampValue(bucketInfo.Created))
+}
+
+// ListObjectsHandler - lists the objects of a container.
+func (api swiftAPIHandlers) ListObjectsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftListObjects")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftListObjects", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	bucket, _, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.ListBucketAction, bucket, "") {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	values := r.URL.Query()
+	limit, err := swiftListingLimit(values)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	bucketInfo, err := objectAPI.GetBucketInfo(ctx, bucket)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	endMarker := normalizeObjectName(values.Get("end_marker"))
+	loi, err := objectAPI.ListObjects(ctx, bucket, normalizeObjectName(values.Get("prefix")),
+		normalizeObjectName(values.Get("marker")), values.Get("delimiter"), limit)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	api.setContainerHeaders(ctx, objectAPI, w, bucketInfo)
+
+	// Objects and common prefixes are listed in a single sorted list.
+	var names []string
+	entries := make(map[string]interface{}, len(loi.Objects)+len(loi.Prefixes))
+	for _, objInfo := range loi.Objects {
+		if endMarker != "" && objInfo.Name >= endMarker {
+			continue
+		}
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+			return
+		}
+		etag := objInfo.ETag
+		if crypto.IsEncrypted(objInfo.UserDefined) {
+			etag = getDecryptedETag(r.Header, objInfo, false)
+		}
+		names = append(names, objInfo.Name)
+		entries[objInfo.Name] = swiftObjectEntry{
+			Name:         objInfo.Name,
+			Hash:         etag,
+			Bytes:        size,
+			ContentType:  objInfo.ContentType,
+			LastModified: objInfo.ModTime.UTC().Format(swiftLastModifiedFormat),
+		}
+	}
+	for _, prefix := range loi.Prefixes {
+		if endMarker != "" && prefix >= endMarker {
+			continue
+		}
+		names = append(names, prefix)
+		entries[prefix] = swiftSubdirEntry{Subdir: prefix}
+	}
+	sort.Strings(names)
+
+	if values.Get("format") == "json" {
+		listing := make([]interface{}, 0, len(names))
+		for _, name := range names {
+			listing = append(listing, entries[name])
+		}
+		writeSuccessResponseJSON(w, mustGetJSONBytes(listing))
+		return
+	}
+	writeSwiftPlainListing(w, names)
+}
+
+// DeleteContainerHandler - removes an empty container.
+func (api swiftAPIHandlers) DeleteContainerHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftDeleteContainer")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftDeleteContainer", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+	if globalDNSConfig != nil {
+		// Buckets of federated deployments are registered by the S3 API.
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrNotImplemented))
+		return
+	}
+
+	bucket, _, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.DeleteBucketAction, bucket, "") {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	if err = objectAPI.DeleteBucket(ctx, bucket, false); err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	globalNotificationSys.DeleteBucketMetadata(ctx, bucket)
+
+	writeSuccessNoContent(w)
+}
+
+// getObject - returns an object, the segments of large objects are
+// resolved unless the manifest itself is requested.
+func (api swiftAPIHandlers) getObject(ctx context.Context, objectAPI ObjectLayer, r *http.Request, claims *xjwt.MapClaims, owner bool, bucket, object string, manifest bool) (o swiftObject, err error) {
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+	if o.info, err = getObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		return o, err
+	}
+	if o.size, err = o.info.GetActualSize(); err != nil {
+		return o, err
+	}
+	o.etag = o.info.ETag
+	if crypto.IsEncrypted(o.info.UserDefined) {
+		o.etag = getDecryptedETag(r.Header, o.info, false)
+	}
+	o.segments = []swiftSegment{{bucket: bucket, object: object, size: o.size}}
+	if manifest {
+		return o, nil
+	}
+
+	var segments []swiftSegment
+	var etags []string
+	if dlo, ok := o.info.UserDefined[swiftDLOManifestKey]; ok {
+		segments, etags, err = api.listDLOSegments(ctx, objectAPI, r, claims, owner, dlo)
+	} else if _, ok = o.info.UserDefined[swiftSLOManifestKey]; ok {
+		segments, etags, err = api.readSLOSegments(ctx, objectAPI, r, claims, owner, bucket, object)
+	} else {
+		return o, nil
+	}
+	if err != nil {
+		return o, err
+	}
+
+	o.segments = segments
+	o.size = 0
+	for _, segment := range segments {
+		o.size += segment.size
+	}
+	// The ETag of large objects is the MD5 of the ETags of the segments.
+	sum := md5.Sum([]byte(strings.Join(etags, "")))
+	o.etag = `"` + hex.EncodeToString(sum[:]) + `"`
+	return o, nil
+}
+
+// listDLOSegments - returns the segments of a dynamic large object,
+// the objects of the container with the prefix of the manifest.
+func (api swiftAPIHandlers) listDLOSegments(ctx context.Context, objectAPI ObjectLayer, r *http.Request, claims *xjwt.MapClaims, owner bool, dlo string) (segments []swiftSegment, etags []string, err error) {
+	bucket, prefix, err := swiftSplitPath(dlo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.ListBucketAction, bucket, "") {
+		return nil, nil, PrefixAccessDenied{Bucket: bucket, Object: prefix}
+	}
+
+	marker := ""
+	for {
+		loi, err := objectAPI.ListObjects(ctx, bucket, prefix, marker, "", maxObjectList)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, objInfo := range loi.Objects {
+			if !isSwiftActionAllowed(r, claims, owner, iampolicy.GetObjectAction, bucket, objInfo.Name) {
+				return nil, nil, PrefixAccessDenied{Bucket: bucket, Object: objInfo.Name}
+			}
+			size, err := objInfo.GetActualSize()
+			if err != nil {
+				return nil, nil, err
+			}
+			etag := objInfo.ETag
+			if crypto.IsEncrypted(objInfo.UserDefined) {
+				etag = getDecryptedETag(r.Header, objInfo, false)
+			}
+			segments = append(segments, swiftSegment{bucket: bucket, object: objInfo.Name, size: size})
+			etags = append(etags, etag)
+		}
+		if !loi.IsTruncated {
+			return segments, etags, nil
+		}
+		marker = loi.NextMarker
+	}
+}
+
+// readSLOManifest - reads the manifest of a static large object.
+func readSLOManifest(ctx context.Context, objectAPI ObjectLayer, bucket, object string) ([]swiftSLOManifestEntry, error) {
+	gr, err := objectAPI.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+
+	var entries []swiftSLOManifestEntry
+	if err = json.NewDecoder(gr).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// readSLOSegments - returns the segments of a static large object.
+func (api swiftAPIHandlers) readSLOSegments(ctx context.Context, objectAPI ObjectLayer, r *http.Request, claims *xjwt.MapClaims, owner bool, bucket, object string) (segments []swiftSegment, etags []string, err error) {
+	entries, err := readSLOManifest(ctx, objectAPI, bucket, object)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		bucket, object := path2BucketObject(entry.Name)
+		if !isSwiftActionAllowed(r, claims, owner, iampolicy.GetObjectAction, bucket, object) {
+			return nil, nil, PrefixAccessDenied{Bucket: bucket, Object: object}
+		}
+		segments = append(segments, swiftSegment{bucket: bucket, object: object, size: entry.Bytes})
+		etags = append(etags, entry.Hash)
+	}
+	return segments, etags, nil
+}
+
+// writeSwiftSegments - writes length bytes of the concatenated
+// content of the segments, starting at offset.
+func writeSwiftSegments(ctx context.Context, objectAPI ObjectLayer, w io.Writer, segments []swiftSegment, offset, length int64) error {
+	for _, segment := range segments {
+		if length <= 0 {
+			break
+		}
+		if offset >= segment.size {
+			offset -= segment.size
+			continue
+		}
+		n := segment.size - offset
+		if n > length {
+			n = length
+		}
+		rs := &HTTPRangeSpec{Start: offset, End: offset + n - 1}
+		gr, err := objectAPI.GetObjectNInfo(ctx, segment.bucket, segment.object, rs, nil, readLock, ObjectOptions{})
+		if err != nil {
+			return err
+		}
+		_, err = io.CopyN(w, gr, n)
+		gr.Close()
+		if err != nil {
+			return err
+		}
+		offset = 0
+		length -= n
+	}
+	return nil
+}
+
+// setSwiftObjectHeaders - sets the headers of a Swift object.
+func setSwiftObjectHeaders(w http.ResponseWriter, o swiftObject) {
+	setCommonHeaders(w)
+
+	w.Header().Set(xhttp.ETag, o.etag)
+	w.Header().Set(xhttp.LastModified, o.info.ModTime.UTC().Format(http.TimeFormat))
+	w.Header().Set(swiftTimestamp, swiftTimestampValue(o.info.ModTime))
+	w.Header().Set(xhttp.AcceptRanges, "bytes")
+	if o.info.ContentType != "" {
+		w.Header().Set(xhttp.ContentType, o.info.ContentType)
+	}
+	if o.info.ContentEncoding != "" {
+		w.Header().Set(xhttp.ContentEncoding, o.info.ContentEncoding)
+	}
+	for k, v := range o.info.UserDefined {
+		if HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			w.Header().Set(swiftObjectMetaPrefix+k[len("x-amz-meta-"):], v)
+		}
+	}
+	if dlo, ok := o.info.UserDefined[swiftDLOManifestKey]; ok {
+		w.Header().Set(swiftObjectManifest, dlo)
+	}
+	if _, ok := o.info.UserDefined[swiftSLOManifestKey]; ok {
+		w.Header().Set(swiftStaticLargeObject, "True")
+	}
+}
+
+// HeadObjectHandler - returns the headers of an object.
+func (api swiftAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Request) {
+	api.getObjectHandler(w, r, "SwiftHeadObject", event.ObjectAccessedHead)
+}
+
+// GetObjectHandler - returns an object, the content of large objects
+// is the concatenation of their segments.
+func (api swiftAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Request) {
+	api.getObjectHandler(w, r, "SwiftGetObject", event.ObjectAccessedGet)
+}
+
+func (api swiftAPIHandlers) getObjectHandler(w http.ResponseWriter, r *http.Request, apiName string, eventName event.Name) {
+	ctx := newContext(r, w, apiName)
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, apiName, claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	bucket, object, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.GetObjectAction, bucket, object) {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	manifest := r.URL.Query().Get("multipart-manifest") == "get"
+	o, err := api.getObject(ctx, objectAPI, r, claims, owner, bucket, object, manifest)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	var rs *HTTPRangeSpec
+	if rangeHeader := r.Header.Get(xhttp.Range); rangeHeader != "" {
+		if rs, err = parseRequestRangeSpec(rangeHeader); err != nil {
+			// Handle only errInvalidRange. Ignore other
+			// parse error and treat it as regular Get request.
+			if err == errInvalidRange {
+				writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrInvalidRange))
+				return
+			}
+			rs = nil
+		}
+	}
+	offset, length, err := rs.GetOffsetLength(o.size)
+	if err != nil {
+		w.Header().Set(xhttp.ContentRange, fmt.Sprintf("bytes */%d", o.size))
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrInvalidRange))
+		return
+	}
+
+	setSwiftObjectHeaders(w, o)
+	w.Header().Set(xhttp.ContentLength, strconv.FormatInt(length, 10))
+	statusCode := http.StatusOK
+	if rs != nil {
+		w.Header().Set(xhttp.ContentRange, fmt.Sprintf("bytes %d-%d/%d", offset, offset+length-1, o.size))
+		statusCode = http.StatusPartialContent
+	}
+	w.WriteHeader(statusCode)
+
+	if r.Method != http.MethodHead {
+		if err = writeSwiftSegments(ctx, objectAPI, w, o.segments, offset, length); err != nil {
+			// The status has been sent, the client
+			// notices the content is incomplete.
+			logger.LogIf(ctx, err)
+			return
+		}
+	}
+
+	// Notify object accessed via a GET or HEAD request.
+	sendEvent(eventArgs{
+		EventName:    eventName,
+		BucketName:   bucket,
+		Object:       o.info,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
+
+// putObject - stores an object, scanning, compressing and encrypting
+// its content as configured for the server and the bucket.
+func (api swiftAPIHandlers) putObject(ctx context.Context, objectAPI ObjectLayer, r *http.Request, bucket, object string, reader io.Reader, size int64, md5hex string, metadata map[string]string) (objInfo ObjectInfo, etag string, err error) {
+	if objInfo, err = putObjectContent(ctx, objectAPI, api.CacheAPI(), bucket, object, r, reader, size, md5hex, metadata); err != nil {
+		return objInfo, "", err
+	}
+	etag = objInfo.ETag
+	if crypto.IsEncrypted(objInfo.UserDefined) {
+		etag = getDecryptedETag(r.Header, objInfo, false)
+	}
+	return objInfo, etag, nil
+}
+
+// parseSLOManifest - validates the segments of a static large object
+// uploaded with multipart-manifest=put, returns the manifest to store
+// and its ETag.
+func (api swiftAPIHandlers) parseSLOManifest(ctx context.Context, objectAPI ObjectLayer, r *http.Request, claims *xjwt.MapClaims, owner bool, body io.Reader) (manifest []byte, etag string, err error) {
+	var segments []swiftSLOSegment
+	if err = json.NewDecoder(body).Decode(&segments); err != nil || len(segments) == 0 {
+		return nil, "", errInvalidArgument
+	}
+
+	entries := make([]swiftSLOManifestEntry, 0, len(segments))
+	var etags []string
+	for _, segment := range segments {
+		bucket, object, err := swiftSplitPath(strings.TrimPrefix(segment.Path, SlashSeparator))
+		if err != nil || object == "" {
+			return nil, "", errInvalidArgument
+		}
+		if !isSwiftActionAllowed(r, claims, owner, iampolicy.GetObjectAction, bucket, object) {
+			return nil, "", PrefixAccessDenied{Bucket: bucket, Object: object}
+		}
+		o, err := api.getObject(ctx, objectAPI, r, claims, owner, bucket, object, true)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, ok := o.info.UserDefined[swiftSLOManifestKey]; ok {
+			// Nested manifests are not supported.
+			return nil, "", errInvalidArgument
+		}
+		if segment.ETag != nil && *segment.ETag != "" && *segment.ETag != o.etag {
+			return nil, "", errInvalidArgument
+		}
+		if segment.SizeBytes != nil && *segment.SizeBytes != o.size {
+			return nil, "", errInvalidArgument
+		}
+		entries = append(entries, swiftSLOManifestEntry{
+			Name:  SlashSeparator + bucket + SlashSeparator + object,
+			Hash:  o.etag,
+			Bytes: o.size,
+		})
+		etags = append(etags, o.etag)
+	}
+	sum := md5.Sum([]byte(strings.Join(etags, "")))
+	return mustGetJSONBytes(entries), hex.EncodeToString(sum[:]), nil
+}
+
+// PutObjectHandler - stores an object, copies it from the source of
+// the X-Copy-From header or stores the manifest of a large object.
+func (api swiftAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftPutObject")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftPutObject", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	bucket, object, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	if copyFrom := r.Header.Get(swiftCopyFrom); copyFrom != "" {
+		srcBucket, srcObject, err := swiftSplitPath(strings.TrimPrefix(copyFrom, SlashSeparator))
+		if err != nil {
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+			return
+		}
+		api.copyObject(ctx, objectAPI, w, r, claims, owner, srcBucket, srcObject, bucket, object)
+		return
+	}
+
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.PutObjectAction, bucket, object) || isObjectLockBucket(bucket) {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	if err := checkServerModeOverwrite(ctx, bucket, object, objectAPI.GetObjectInfo); err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	// To detect if the client has disconnected.
+	r.Body = &contextReader{r.Body, r.Context()}
+
+	size := r.ContentLength
+	if size < 0 {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrMissingContentLength))
+		return
+	}
+	if isMaxObjectSize(size) {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrEntityTooLarge))
+		return
+	}
+
+	metadata, err := swiftExtractMetadata(ctx, r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	var (
+		reader = io.Reader(r.Body)
+		md5hex = strings.ToLower(strings.Trim(r.Header.Get(xhttp.ETag), `"`))
+	)
+	if dlo := r.Header.Get(swiftObjectManifest); dlo != "" {
+		if _, _, err = swiftSplitPath(dlo); err != nil {
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+			return
+		}
+		metadata[swiftDLOManifestKey] = dlo
+	}
+	if r.URL.Query().Get("multipart-manifest") == "put" {
+		manifest, etag, err := api.parseSLOManifest(ctx, objectAPI, r, claims, owner, io.LimitReader(r.Body, size))
+		if err != nil {
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+			return
+		}
+		// The ETag of the request is the one of the concatenated segments.
+		if md5hex != "" && md5hex != etag {
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, hash.BadDigest{ExpectedMD5: md5hex, CalculatedMD5: etag}))
+			return
+		}
+		metadata[swiftSLOManifestKey] = "true"
+		reader, size, md5hex = bytes.NewReader(manifest), int64(len(manifest)), ""
+	}
+
+	newObject, err := enforceBucketQuota(ctx, bucket, object, size)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	objInfo, etag, err := api.putObject(ctx, objectAPI, r, bucket, object, reader, size, md5hex, metadata)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	accountBucketQuota(bucket, size, newObject)
+
+	w.Header().Set(xhttp.ETag, etag)
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	writeResponse(w, http.StatusCreated, nil, mimeNone)
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedPut,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
+
+// CopyObjectHandler - copies an object to the object of the
+// Destination header.
+func (api swiftAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftCopyObject")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftCopyObject", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	srcBucket, srcObject, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	dstBucket, dstObject, err := swiftSplitPath(strings.TrimPrefix(r.Header.Get(swiftDestination), SlashSeparator))
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	api.copyObject(ctx, objectAPI, w, r, claims, owner, srcBucket, srcObject, dstBucket, dstObject)
+}
+
+// copyObject - copies the content of an object, the copy of a large
+// object is the concatenation of its segments. The metadata of the
+// source is replaced by the X-Object-Meta-* headers of the request.
+func (api swiftAPIHandlers) copyObject(ctx context.Context, objectAPI ObjectLayer, w http.ResponseWriter, r *http.Request, claims *xjwt.MapClaims, owner bool, srcBucket, srcObject, dstBucket, dstObject string) {
+	if dstObject == "" {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, errInvalidArgument))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.GetObjectAction, srcBucket, srcObject) ||
+		!isSwiftActionAllowed(r, claims, owner, iampolicy.PutObjectAction, dstBucket, dstObject) ||
+		isObjectLockBucket(dstBucket) {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+	if err := checkServerModeOverwrite(ctx, dstBucket, dstObject, objectAPI.GetObjectInfo); err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	src, err := api.getObject(ctx, objectAPI, r, claims, owner, srcBucket, srcObject, r.URL.Query().Get("multipart-manifest") == "get")
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	for _, segment := range src.segments {
+		// The source is read while the destination is locked.
+		if segment.bucket == dstBucket && segment.object == dstObject {
+			writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrInvalidCopyDest))
+			return
+		}
+	}
+
+	metadata, err := swiftExtractMetadata(ctx, r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	for k, v := range src.info.UserDefined {
+		lk := strings.ToLower(k)
+		if HasPrefix(lk, "x-amz-meta-") || lk == "content-type" || lk == "content-encoding" {
+			if _, ok := metadata[k]; !ok {
+				metadata[k] = v
+			}
+		}
+	}
+	if len(src.segments) == 1 && src.segments[0].bucket == srcBucket && src.segments[0].object == srcObject {
+		// The manifest of a large object is copied as is.
+		for _, k := range []string{swiftDLOManifestKey, swiftSLOManifestKey} {
+			if v, ok := src.info.UserDefined[k]; ok {
+				metadata[k] = v
+			}
+		}
+	}
+
+	newObject, err := enforceBucketQuota(ctx, dstBucket, dstObject, src.size)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeSwiftSegments(ctx, objectAPI, pw, src.segments, 0, src.size))
+	}()
+	objInfo, etag, err := api.putObject(ctx, objectAPI, r, dstBucket, dstObject, pr, src.size, "", metadata)
+	pr.Close()
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	accountBucketQuota(dstBucket, src.size, newObject)
+
+	w.Header().Set(xhttp.ETag, etag)
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	writeResponse(w, http.StatusCreated, nil, mimeNone)
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedCopy,
+		BucketName:   dstBucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
+
+// DeleteObjectHandler - removes an object, the segments of static
+// large objects are removed along with the manifest when requested
+// with multipart-manifest=delete.
+func (api swiftAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SwiftDeleteObject")
+
+	claims, owner, authErr := swiftAuthenticate(r)
+	defer logger.AuditLog(w, r, "SwiftDeleteObject", claims.Map())
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrServerNotInitialized))
+		return
+	}
+	if authErr != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, authErr))
+		return
+	}
+
+	bucket, object, err := swiftBucketObject(r)
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+	if !isSwiftActionAllowed(r, claims, owner, iampolicy.DeleteObjectAction, bucket, object) || isObjectLockBucket(bucket) {
+		writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+		return
+	}
+
+	deleteObject := objectAPI.DeleteObject
+	if api.CacheAPI() != nil {
+		deleteObject = api.CacheAPI().DeleteObject
+	}
+
+	objInfo, err := objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	var segments []swiftSegment
+	if _, ok := objInfo.UserDefined[swiftSLOManifestKey]; ok && r.URL.Query().Get("multipart-manifest") == "delete" {
+		entries, err := readSLOManifest(ctx, objectAPI, bucket, object)
+		if err != nil {
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+			return
+		}
+		for _, entry := range entries {
+			segBucket, segObject := path2BucketObject(entry.Name)
+			if !isSwiftActionAllowed(r, claims, owner, iampolicy.DeleteObjectAction, segBucket, segObject) || isObjectLockBucket(segBucket) {
+				writeSwiftErrorResponse(w, errorCodes.ToAPIErr(ErrAccessDenied))
+				return
+			}
+			segments = append(segments, swiftSegment{bucket: segBucket, object: segObject})
+		}
+	}
+
+	for _, segment := range segments {
+		segInfo, err := deleteObject(ctx, segment.bucket, segment.object, ObjectOptions{})
+		if err != nil {
+			if _, ok := err.(ObjectNotFound); ok {
+				continue
+			}
+			writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+			return
+		}
+		sendEvent(eventArgs{
+			EventName:    event.ObjectRemovedDelete,
+			BucketName:   segment.bucket,
+			Object:       segInfo,
+			ReqParams:    extractReqParams(r),
+			RespElements: extractRespElements(w),
+			UserAgent:    r.UserAgent(),
+			Host:         handlers.GetSourceIP(r),
+		})
+	}
+
+	if objInfo, err = deleteObject(ctx, bucket, object, ObjectOptions{}); err != nil {
+		writeSwiftErrorResponse(w, toSwiftAPIError(ctx, err))
+		return
+	}
+
+	writeSuccessNoContent(w)
+
+	// Notify object deleted event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectRemovedDelete,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
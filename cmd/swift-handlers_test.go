@@ -0,0 +1,243 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+func TestSwiftListingLimit(t *testing.T) {
+	testCases := []struct {
+		query   string
+		limit   int
+		success bool
+	}{
+		{"", swiftMaxListingLimit, true},
+		{"limit=10", 10, true},
+		{"limit=0", 0, true},
+		{"limit=100000", swiftMaxListingLimit, true},
+		{"limit=-1", 0, false},
+		{"limit=a", 0, false},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/?"+testCase.query, nil)
+		limit, err := swiftListingLimit(req.URL.Query())
+		if err != nil && testCase.success {
+			t.Errorf("Test %d: expected success, got %v", i+1, err)
+		}
+		if err == nil && !testCase.success {
+			t.Errorf("Test %d: expected failure", i+1)
+		}
+		if err == nil && limit != testCase.limit {
+			t.Errorf("Test %d: expected limit %d, got %d", i+1, testCase.limit, limit)
+		}
+	}
+}
+
+func TestSwiftHandlers(t *testing.T) {
+	ExecObjectLayerTest(t, testSwiftHandlers)
+}
+
+func testSwiftHandlers(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalObjLayerMutex.Lock()
+	oldObjectAPI := globalObjectAPI
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = oldObjectAPI
+		globalObjLayerMutex.Unlock()
+	}()
+
+	router := mux.NewRouter().SkipClean(true).UseEncodedPath()
+	registerSwiftRouter(router)
+
+	const storagePath = swiftPathPrefix + swiftAPIVersion + "/AUTH_minio"
+	var token string
+	do := func(method, path string, body []byte, header map[string]string, expectedStatus int) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		if token != "" {
+			req.Header.Set(swiftAuthToken, token)
+		}
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != expectedStatus {
+			t.Fatalf("%s: %s %s: expected status %d, got %d: %s", instanceType, method, path, expectedStatus, rec.Code, rec.Body.String())
+		}
+		return rec
+	}
+
+	cred := globalActiveCred
+	do(http.MethodGet, swiftPathPrefix+swiftAuthPath, nil, map[string]string{
+		swiftAuthUser: "test:" + cred.AccessKey,
+		swiftAuthKey:  "invalid-secret",
+	}, http.StatusUnauthorized)
+	rec := do(http.MethodGet, swiftPathPrefix+swiftAuthPath, nil, map[string]string{
+		swiftAuthUser: "test:" + cred.AccessKey,
+		swiftAuthKey:  cred.SecretKey,
+	}, http.StatusOK)
+	if token = rec.Header().Get(swiftAuthToken); token == "" {
+		t.Fatalf("%s: expected an auth token", instanceType)
+	}
+	if !strings.HasSuffix(rec.Header().Get(swiftStorageURL), swiftPathPrefix+swiftAPIVersion+"/AUTH_"+cred.AccessKey) {
+		t.Fatalf("%s: unexpected storage URL %s", instanceType, rec.Header().Get(swiftStorageURL))
+	}
+
+	do(http.MethodPut, storagePath+"/container", nil, nil, http.StatusCreated)
+	do(http.MethodPut, storagePath+"/container", nil, nil, http.StatusAccepted)
+	do(http.MethodHead, storagePath+"/container", nil, nil, http.StatusNoContent)
+
+	rec = do(http.MethodGet, storagePath, nil, nil, http.StatusOK)
+	if rec.Body.String() != "container\n" {
+		t.Fatalf("%s: unexpected account listing %q", instanceType, rec.Body.String())
+	}
+
+	data := []byte("hello swift")
+	sum := md5.Sum(data)
+	etag := hex.EncodeToString(sum[:])
+	do(http.MethodPut, storagePath+"/container/dir/object", data, map[string]string{
+		xhttp.ETag: "00000000000000000000000000000000",
+	}, http.StatusUnprocessableEntity)
+	rec = do(http.MethodPut, storagePath+"/container/dir/object", data, map[string]string{
+		xhttp.ETag:                      etag,
+		xhttp.ContentType:               "text/plain",
+		swiftObjectMetaPrefix + "Color": "blue",
+	}, http.StatusCreated)
+	if rec.Header().Get(xhttp.ETag) != etag {
+		t.Fatalf("%s: expected ETag %s, got %s", instanceType, etag, rec.Header().Get(xhttp.ETag))
+	}
+
+	rec = do(http.MethodHead, storagePath+"/container/dir/object", nil, nil, http.StatusOK)
+	if rec.Header().Get(swiftObjectMetaPrefix+"Color") != "blue" || rec.Header().Get(xhttp.ContentType) != "text/plain" {
+		t.Fatalf("%s: unexpected object headers %v", instanceType, rec.Header())
+	}
+	rec = do(http.MethodGet, storagePath+"/container/dir/object", nil, map[string]string{
+		xhttp.Range: "bytes=6-",
+	}, http.StatusPartialContent)
+	if rec.Body.String() != "swift" {
+		t.Fatalf("%s: unexpected ranged content %q", instanceType, rec.Body.String())
+	}
+
+	// Copies with the COPY method and the X-Copy-From header.
+	do("COPY", storagePath+"/container/dir/object", nil, map[string]string{
+		swiftDestination: "/container/copy1",
+	}, http.StatusCreated)
+	do(http.MethodPut, storagePath+"/container/copy2", nil, map[string]string{
+		swiftCopyFrom:                   "/container/copy1",
+		swiftObjectMetaPrefix + "Color": "red",
+	}, http.StatusCreated)
+	rec = do(http.MethodGet, storagePath+"/container/copy2", nil, nil, http.StatusOK)
+	if rec.Body.String() != string(data) || rec.Header().Get(swiftObjectMetaPrefix+"Color") != "red" {
+		t.Fatalf("%s: unexpected copy %q %v", instanceType, rec.Body.String(), rec.Header())
+	}
+
+	// Dynamic large object.
+	do(http.MethodPut, storagePath+"/container/segments/1", []byte("aaaa"), nil, http.StatusCreated)
+	do(http.MethodPut, storagePath+"/container/segments/2", []byte("bbbb"), nil, http.StatusCreated)
+	do(http.MethodPut, storagePath+"/container/dlo", nil, map[string]string{
+		swiftObjectManifest: "container/segments/",
+	}, http.StatusCreated)
+	rec = do(http.MethodGet, storagePath+"/container/dlo", nil, nil, http.StatusOK)
+	if rec.Body.String() != "aaaabbbb" || rec.Header().Get(swiftObjectManifest) != "container/segments/" {
+		t.Fatalf("%s: unexpected dynamic large object %q", instanceType, rec.Body.String())
+	}
+	rec = do(http.MethodGet, storagePath+"/container/dlo", nil, map[string]string{
+		xhttp.Range: "bytes=2-5",
+	}, http.StatusPartialContent)
+	if rec.Body.String() != "aabb" {
+		t.Fatalf("%s: unexpected ranged dynamic large object %q", instanceType, rec.Body.String())
+	}
+
+	// Static large object.
+	manifest, _ := json.Marshal([]map[string]interface{}{
+		{"path": "/container/segments/2", "size_bytes": 4},
+		{"path": "/container/segments/1"},
+	})
+	do(http.MethodPut, storagePath+"/container/slo?multipart-manifest=put", manifest, nil, http.StatusCreated)
+	rec = do(http.MethodGet, storagePath+"/container/slo", nil, nil, http.StatusOK)
+	if rec.Body.String() != "bbbbaaaa" || rec.Header().Get(swiftStaticLargeObject) != "True" {
+		t.Fatalf("%s: unexpected static large object %q", instanceType, rec.Body.String())
+	}
+	rec = do(http.MethodGet, storagePath+"/container/slo?multipart-manifest=get", nil, nil, http.StatusOK)
+	var entries []swiftSLOManifestEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil || len(entries) != 2 || entries[0].Name != "/container/segments/2" {
+		t.Fatalf("%s: unexpected manifest %s: %v", instanceType, rec.Body.String(), err)
+	}
+	bad, _ := json.Marshal([]map[string]interface{}{{"path": "/container/segments/1", "size_bytes": 5}})
+	do(http.MethodPut, storagePath+"/container/bad?multipart-manifest=put", bad, nil, http.StatusBadRequest)
+
+	rec = do(http.MethodGet, storagePath+"/container?format=json&delimiter=/", nil, nil, http.StatusOK)
+	var listing []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &listing); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	var names []string
+	for _, entry := range listing {
+		if subdir, ok := entry["subdir"]; ok {
+			names = append(names, subdir.(string))
+			continue
+		}
+		names = append(names, entry["name"].(string))
+	}
+	if strings.Join(names, ",") != "copy1,copy2,dir/,dlo,segments/,slo" {
+		t.Fatalf("%s: unexpected container listing %v", instanceType, names)
+	}
+
+	// The server mode applies to the Swift API as well.
+	setServerMode(serverModeReadOnly)
+	do(http.MethodPut, storagePath+"/container/readonly", data, nil, http.StatusForbidden)
+	do(http.MethodDelete, storagePath+"/container/copy1", nil, nil, http.StatusForbidden)
+	do(http.MethodGet, storagePath+"/container/copy1", nil, nil, http.StatusOK)
+	setServerMode(serverModeWriteOnce)
+	do(http.MethodPut, storagePath+"/container/copy1", data, nil, http.StatusForbidden)
+	do("COPY", storagePath+"/container/dir/object", nil, map[string]string{
+		swiftDestination: "/container/copy2",
+	}, http.StatusForbidden)
+	do(http.MethodDelete, storagePath+"/container/copy1", nil, nil, http.StatusForbidden)
+	do(http.MethodPut, storagePath+"/container/writeonce", data, nil, http.StatusCreated)
+	setServerMode(serverModeNormal)
+	do(http.MethodDelete, storagePath+"/container/writeonce", nil, nil, http.StatusNoContent)
+
+	do(http.MethodDelete, storagePath+"/container", nil, nil, http.StatusConflict)
+	do(http.MethodDelete, storagePath+"/container/slo?multipart-manifest=delete", nil, nil, http.StatusNoContent)
+	if _, err := obj.GetObjectInfo(context.Background(), "container", "segments/1", ObjectOptions{}); err == nil {
+		t.Fatalf("%s: expected the segments to be removed", instanceType)
+	}
+	for _, object := range []string{"dir/object", "copy1", "copy2", "dlo"} {
+		do(http.MethodDelete, storagePath+"/container/"+object, nil, nil, http.StatusNoContent)
+	}
+	do(http.MethodGet, storagePath+"/container/dlo", nil, nil, http.StatusNotFound)
+	do(http.MethodDelete, storagePath+"/container", nil, nil, http.StatusNoContent)
+	do(http.MethodGet, storagePath, nil, nil, http.StatusNoContent)
+
+	token = "invalid-token"
+	do(http.MethodGet, storagePath, nil, nil, http.StatusUnauthorized)
+}
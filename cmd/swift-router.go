@@ -0,0 +1,78 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	swiftPathPrefix  = minioReservedBucketPath + "/swift"
+	swiftAuthPath    = "/auth/v1.0"
+	swiftAPIVersion  = "/v1"
+	swiftAccountPath = swiftAPIVersion + "/{account}"
+)
+
+// swiftAPIHandlers implements a subset of the OpenStack Swift
+// object storage API on top of the object layer.
+type swiftAPIHandlers struct {
+	ObjectAPI func() ObjectLayer
+	CacheAPI  func() CacheObjectLayer
+}
+
+// registerSwiftRouter - registers the Swift API router.
+func registerSwiftRouter(router *mux.Router) {
+	api := swiftAPIHandlers{
+		ObjectAPI: newObjectLayerFn,
+		CacheAPI:  newCachedObjectLayerFn,
+	}
+
+	// Swift router
+	swiftRouter := router.PathPrefix(swiftPathPrefix).Subrouter()
+
+	// TempAuth compatible authentication, returns the token and the storage URL.
+	swiftRouter.Methods(http.MethodGet).Path(swiftAuthPath).HandlerFunc(httpTraceHdrs(api.AuthHandler))
+
+	// Account
+	account := swiftRouter.PathPrefix(swiftAccountPath).Subrouter()
+	account.Methods(http.MethodHead).Path("/{bucket}/{object:.+}").HandlerFunc(httpTraceHdrs(api.HeadObjectHandler))
+	account.Methods(http.MethodGet).Path("/{bucket}/{object:.+}").HandlerFunc(httpTraceHdrs(api.GetObjectHandler))
+	account.Methods(http.MethodPut).Path("/{bucket}/{object:.+}").HandlerFunc(httpTraceHdrs(api.PutObjectHandler))
+	account.Methods("COPY").Path("/{bucket}/{object:.+}").HandlerFunc(httpTraceHdrs(api.CopyObjectHandler))
+	account.Methods(http.MethodDelete).Path("/{bucket}/{object:.+}").HandlerFunc(httpTraceHdrs(api.DeleteObjectHandler))
+
+	account.Methods(http.MethodHead).Path("/{bucket}").HandlerFunc(httpTraceAll(api.HeadContainerHandler))
+	account.Methods(http.MethodGet).Path("/{bucket}").HandlerFunc(httpTraceAll(api.ListObjectsHandler))
+	account.Methods(http.MethodPut).Path("/{bucket}").HandlerFunc(httpTraceAll(api.PutContainerHandler))
+	account.Methods(http.MethodDelete).Path("/{bucket}").HandlerFunc(httpTraceAll(api.DeleteContainerHandler))
+
+	account.Methods(http.MethodHead).Path("").HandlerFunc(httpTraceAll(api.HeadAccountHandler))
+	account.Methods(http.MethodGet).Path("").HandlerFunc(httpTraceAll(api.ListContainersHandler))
+	account.Methods(http.MethodHead).Path("/").HandlerFunc(httpTraceAll(api.HeadAccountHandler))
+	account.Methods(http.MethodGet).Path("/").HandlerFunc(httpTraceAll(api.ListContainersHandler))
+}
+
+// guessIsSwiftReq - returns true if the request is for the Swift API.
+func guessIsSwiftReq(req *http.Request) bool {
+	if req == nil || !globalCLIContext.Swift {
+		return false
+	}
+	return strings.HasPrefix(req.URL.Path, swiftPathPrefix+SlashSeparator)
+}
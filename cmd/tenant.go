@@ -0,0 +1,309 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	tenantsConfigFile = minioConfigPrefix + "/tenants.json"
+
+	// Separates the name of a tenant from the
+	// rest of the names of its buckets.
+	tenantBucketSeparator = "-"
+)
+
+var (
+	errNoSuchTenant      = errors.New("the specified tenant does not exist")
+	errInvalidTenantName = errors.New("tenant names must be 3 to 32 lowercase letters, numbers or hyphens")
+	errTenantUserInUse   = errors.New("the user already belongs to another tenant")
+)
+
+var validTenantName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,30}[a-z0-9]$`)
+
+// TenantSys - logical tenants of the server. The users of a tenant
+// only access the buckets named after the tenant, and share the quota
+// and notification targets of the tenant. The users themselves are
+// the users of the server, a user belongs to one tenant at most.
+type TenantSys struct {
+	sync.RWMutex
+	tenants map[string]madmin.Tenant
+	users   map[string]string // user to the name of its tenant.
+}
+
+// NewTenantSys - returns a tenant system without any tenant.
+func NewTenantSys() *TenantSys {
+	return &TenantSys{
+		tenants: make(map[string]madmin.Tenant),
+		users:   make(map[string]string),
+	}
+}
+
+// Init - loads the tenants from the backend.
+func (sys *TenantSys) Init(ctx context.Context, objAPI ObjectLayer) error {
+	return sys.load(ctx, objAPI)
+}
+
+func (sys *TenantSys) load(ctx context.Context, objAPI ObjectLayer) error {
+	var tenants []madmin.Tenant
+	data, err := readConfig(ctx, objAPI, tenantsConfigFile)
+	if err != nil && err != errConfigNotFound {
+		return err
+	}
+	if err == nil {
+		if err = json.Unmarshal(data, &tenants); err != nil {
+			return err
+		}
+	}
+	sys.set(tenants)
+	return nil
+}
+
+func (sys *TenantSys) set(tenants []madmin.Tenant) {
+	sys.Lock()
+	defer sys.Unlock()
+	sys.tenants = make(map[string]madmin.Tenant, len(tenants))
+	sys.users = make(map[string]string)
+	for _, tenant := range tenants {
+		sys.tenants[tenant.Name] = tenant
+		for _, user := range tenant.Users {
+			sys.users[user] = tenant.Name
+		}
+	}
+}
+
+func (sys *TenantSys) list() []madmin.Tenant {
+	sys.RLock()
+	defer sys.RUnlock()
+	tenants := make([]madmin.Tenant, 0, len(sys.tenants))
+	for _, tenant := range sys.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Slice(tenants, func(i, j int) bool {
+		return tenants[i].Name < tenants[j].Name
+	})
+	return tenants
+}
+
+// Set - creates or replaces the tenant, and saves all the tenants
+// in the backend.
+func (sys *TenantSys) Set(ctx context.Context, objAPI ObjectLayer, tenant madmin.Tenant) error {
+	if !validTenantName.MatchString(tenant.Name) {
+		return errInvalidTenantName
+	}
+	for _, arn := range tenant.NotificationTargets {
+		if !strings.HasPrefix(arn, "arn:minio:sqs:") {
+			return &event.ErrInvalidARN{ARN: arn}
+		}
+	}
+
+	tenants := sys.list()
+	replaced := false
+	for i := range tenants {
+		if tenants[i].Name == tenant.Name {
+			tenants[i] = tenant
+			replaced = true
+			continue
+		}
+		for _, user := range tenant.Users {
+			for _, u := range tenants[i].Users {
+				if u == user {
+					return errTenantUserInUse
+				}
+			}
+		}
+	}
+	if !replaced {
+		tenants = append(tenants, tenant)
+	}
+	return sys.save(ctx, objAPI, tenants)
+}
+
+// Remove - removes the tenant, its users and buckets are kept.
+func (sys *TenantSys) Remove(ctx context.Context, objAPI ObjectLayer, name string) error {
+	tenants := sys.list()
+	for i := range tenants {
+		if tenants[i].Name == name {
+			return sys.save(ctx, objAPI, append(tenants[:i], tenants[i+1:]...))
+		}
+	}
+	return errNoSuchTenant
+}
+
+func (sys *TenantSys) save(ctx context.Context, objAPI ObjectLayer, tenants []madmin.Tenant) error {
+	data, err := json.Marshal(tenants)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, tenantsConfigFile, data); err != nil {
+		return err
+	}
+	sys.set(tenants)
+	return nil
+}
+
+// tenantOfUser - returns the tenant of user, the parent user of
+// service accounts and temporary credentials is the user.
+func (sys *TenantSys) tenantOfUser(user string) (madmin.Tenant, bool) {
+	if sys == nil {
+		return madmin.Tenant{}, false
+	}
+	if parent := globalIAMSys.GetParentUser(user); parent != "" {
+		user = parent
+	}
+	sys.RLock()
+	defer sys.RUnlock()
+	name, ok := sys.users[user]
+	if !ok {
+		return madmin.Tenant{}, false
+	}
+	return sys.tenants[name], true
+}
+
+// tenantOfBucket - returns the tenant owning the bucket, the tenant
+// with the longest matching name when the names of tenants overlap,
+// like "acme" and "acme-eu".
+func (sys *TenantSys) tenantOfBucket(bucket string) (tenant madmin.Tenant, found bool) {
+	if sys == nil {
+		return tenant, false
+	}
+	sys.RLock()
+	defer sys.RUnlock()
+	for name, t := range sys.tenants {
+		if strings.HasPrefix(bucket, name+tenantBucketSeparator) && len(name) > len(tenant.Name) {
+			tenant, found = t, true
+		}
+	}
+	return tenant, found
+}
+
+// ownsBucket - returns true if the bucket belongs to the tenant.
+func (sys *TenantSys) ownsBucket(tenant madmin.Tenant, bucket string) bool {
+	owner, ok := sys.tenantOfBucket(bucket)
+	return ok && owner.Name == tenant.Name
+}
+
+// IsAllowed - returns false when user belongs to a tenant and the
+// bucket is not one of the buckets of the tenant.
+func (sys *TenantSys) IsAllowed(user, bucket string) bool {
+	if bucket == "" {
+		return true
+	}
+	tenant, ok := sys.tenantOfUser(user)
+	return !ok || sys.ownsBucket(tenant, bucket)
+}
+
+// FilterBuckets - returns the buckets of the tenant of user, all the
+// buckets when the user doesn't belong to a tenant.
+func (sys *TenantSys) FilterBuckets(user string, buckets []BucketInfo) []BucketInfo {
+	tenant, ok := sys.tenantOfUser(user)
+	if !ok {
+		return buckets
+	}
+	n := 0
+	for _, bucket := range buckets {
+		if sys.ownsBucket(tenant, bucket.Name) {
+			buckets[n] = bucket
+			n++
+		}
+	}
+	return buckets[:n]
+}
+
+// checkNotificationTargets - returns an error when the notification
+// config of the bucket sends events to targets not allowed for its
+// tenant, the buckets of a tenant only send events to the targets
+// listed for the tenant.
+func (sys *TenantSys) checkNotificationTargets(bucket string, config *event.Config) error {
+	tenant, ok := sys.tenantOfBucket(bucket)
+	if !ok {
+		return nil
+	}
+	for _, q := range config.QueueList {
+		// The region of the ARNs is not compared, it is
+		// optional in bucket notification configs.
+		allowed := false
+		for _, arn := range tenant.NotificationTargets {
+			if strings.HasSuffix(arn, ":"+q.ARN.TargetID.String()) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return &event.ErrARNNotFound{ARN: q.ARN}
+		}
+	}
+	return nil
+}
+
+// checkQuota - returns an error when writing size bytes in the bucket
+// exceeds the quota of its tenant.
+func (sys *TenantSys) checkQuota(ctx context.Context, objAPI ObjectLayer, bucket string, size int64) error {
+	tenant, ok := sys.tenantOfBucket(bucket)
+	if !ok || tenant.Quota == 0 {
+		return nil
+	}
+	usage, err := globalBucketQuotaSys.bucketsUsage(ctx, objAPI, func(bucket string) bool {
+		return sys.ownsBucket(tenant, bucket)
+	})
+	if err != nil {
+		return err
+	}
+	if usage.Size+uint64(size) > tenant.Quota {
+		return BucketQuotaExceeded{Bucket: bucket}
+	}
+	return nil
+}
+
+// Info - returns the tenants and the last known usage of their buckets.
+func (sys *TenantSys) Info(ctx context.Context, objAPI ObjectLayer) ([]madmin.TenantInfo, error) {
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := sys.list()
+	infos := make([]madmin.TenantInfo, 0, len(tenants))
+	for _, tenant := range tenants {
+		info := madmin.TenantInfo{Tenant: tenant}
+		for _, bucket := range buckets {
+			if !sys.ownsBucket(tenant, bucket.Name) {
+				continue
+			}
+			info.Buckets = append(info.Buckets, bucket.Name)
+			bui := dataUsageInfo.BucketsUsage[bucket.Name]
+			info.Size += bui.Size
+			info.ObjectsCount += bui.ObjectsCount
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
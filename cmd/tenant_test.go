@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestTenantSysIsAllowed(t *testing.T) {
+	sys := NewTenantSys()
+	sys.set([]madmin.Tenant{
+		{Name: "acme", Users: []string{"alice"}},
+		{Name: "acme-eu", Users: []string{"bob"}},
+	})
+
+	testCases := []struct {
+		user, bucket string
+		allowed      bool
+	}{
+		{"alice", "", true},
+		{"alice", "acme-logs", true},
+		{"alice", "acme-eu-logs", false},
+		{"alice", "other", false},
+		{"bob", "acme-eu-logs", true},
+		{"bob", "acme-logs", false},
+		{"carol", "acme-logs", true},
+		{"carol", "other", true},
+	}
+	for i, tc := range testCases {
+		if allowed := sys.IsAllowed(tc.user, tc.bucket); allowed != tc.allowed {
+			t.Errorf("Test %d: %s on %q expected %v, got %v", i+1, tc.user, tc.bucket, tc.allowed, allowed)
+		}
+	}
+
+	buckets := []BucketInfo{{Name: "acme-eu-logs"}, {Name: "acme-logs"}, {Name: "other"}}
+	filtered := sys.FilterBuckets("alice", buckets)
+	if !reflect.DeepEqual(filtered, []BucketInfo{{Name: "acme-logs"}}) {
+		t.Errorf("unexpected buckets %v", filtered)
+	}
+}
+
+// tenantTestTarget - notification target discarding all the events.
+type tenantTestTarget struct {
+	id event.TargetID
+}
+
+func (target tenantTestTarget) ID() event.TargetID      { return target.id }
+func (target tenantTestTarget) IsActive() (bool, error) { return true, nil }
+func (target tenantTestTarget) Save(event.Event) error  { return nil }
+func (target tenantTestTarget) Send(string) error       { return nil }
+func (target tenantTestTarget) Close() error            { return nil }
+func (target tenantTestTarget) HasQueueStore() bool     { return false }
+
+func TestTenantSysNotificationTargets(t *testing.T) {
+	sys := NewTenantSys()
+	sys.set([]madmin.Tenant{{Name: "acme", NotificationTargets: []string{"arn:minio:sqs::1:webhook"}}})
+
+	targetList := event.NewTargetList()
+	for _, id := range []event.TargetID{{ID: "1", Name: "webhook"}, {ID: "2", Name: "webhook"}} {
+		if err := targetList.Add(tenantTestTarget{id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configFor := func(arn string) *event.Config {
+		config, err := event.ParseConfig(strings.NewReader(`<NotificationConfiguration><QueueConfiguration><Id>1</Id><Queue>`+
+			arn+`</Queue><Event>s3:ObjectCreated:*</Event></QueueConfiguration></NotificationConfiguration>`), "", targetList)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+
+	if err := sys.checkNotificationTargets("acme-logs", configFor("arn:minio:sqs::1:webhook")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := sys.checkNotificationTargets("acme-logs", configFor("arn:minio:sqs::2:webhook")); err == nil {
+		t.Fatal("expected the target to be rejected")
+	}
+	if err := sys.checkNotificationTargets("other", configFor("arn:minio:sqs::2:webhook")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The buckets of a tenant without targets send no events.
+	sys.set([]madmin.Tenant{{Name: "acme"}})
+	if err := sys.checkNotificationTargets("acme-logs", configFor("arn:minio:sqs::1:webhook")); err == nil {
+		t.Fatal("expected the target to be rejected")
+	}
+}
@@ -1352,6 +1352,13 @@ func getHeadObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
 }
 
+// return URL for getting the attributes of the object.
+func getObjectAttributesURL(endPoint, bucketName, objectName string) string {
+	queryValues := url.Values{}
+	queryValues.Set("attributes", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValues)
+}
+
 // return url to be used while copying the object.
 func getCopyObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
@@ -1979,12 +1986,18 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadObject":
 			// Register HeadObject handler.
 			bucket.Methods("Head").Path("/{object:.+}").HandlerFunc(api.HeadObjectHandler)
+		case "GetObjectAttributes":
+			// Register GetObjectAttributes handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectAttributesHandler).Queries("attributes", "")
 		case "GetObject":
 			// Register GetObject handler.
 			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
 		case "PutObject":
 			// Register PutObject handler.
 			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectHandler)
+		case "PutObjectExtract":
+			// Register PutObjectExtract handler.
+			bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Minio-Extract", "(?i)^true$").HandlerFunc(api.PutObjectExtractHandler)
 		case "DeleteObject":
 			// Register Delete Object handler.
 			bucket.Methods("DELETE").Path("/{object:.+}").HandlerFunc(api.DeleteObjectHandler)
@@ -2006,6 +2019,12 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 			bucket.Methods("PUT").HandlerFunc(api.PutBucketLifecycleHandler).Queries("lifecycle", "")
 		case "DeleteBucketLifecycle":
 			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketLifecycleHandler).Queries("lifecycle", "")
+		case "GetBucketReplication":
+			bucket.Methods("GET").HandlerFunc(api.GetBucketReplicationConfigHandler).Queries("replication", "")
+		case "PutBucketReplication":
+			bucket.Methods("PUT").HandlerFunc(api.PutBucketReplicationConfigHandler).Queries("replication", "")
+		case "DeleteBucketReplication":
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketReplicationConfigHandler).Queries("replication", "")
 		case "GetBucketLocation":
 			// Register GetBucketLocation handler.
 			bucket.Methods("GET").HandlerFunc(api.GetBucketLocationHandler).Queries("location", "")
@@ -22,6 +22,11 @@ import (
 	"strings"
 )
 
+// Number of entries a tree walker can produce ahead of its consumer,
+// a slow consumer applies backpressure on the walker instead of
+// letting it buffer an entire listing page.
+const treeWalkBufferSize = 1000
+
 // TreeWalkResult - Tree walk result carries results of tree walking.
 type TreeWalkResult struct {
 	entry string
@@ -56,10 +61,29 @@ func filterMatchingPrefix(entries []string, prefixEntry string) []string {
 }
 
 // ListDirFunc - "listDir" function of type listDirFunc returned by listDirFactory() - explained below.
+// An empty entry is returned for the directory object of prefixDir, if any.
 type ListDirFunc func(bucket, prefixDir, prefixEntry string) (emptyDir bool, entries []string)
 
+// dirObjectEntries - replaces the entry holding the metadata of the directory
+// object of prefixDir by an empty entry, listed by the tree walk as prefixDir.
+// Note: input entries are expected to be sorted.
+func dirObjectEntries(prefixDir string, entries []string) []string {
+	if prefixDir == "" {
+		return entries
+	}
+	for i, entry := range entries {
+		if entry == dirObjectMarker {
+			copy(entries[1:i+1], entries[:i])
+			entries[0] = ""
+			break
+		}
+	}
+	return entries
+}
+
 // treeWalk walks directory tree recursively pushing TreeWalkResult into the channel as and when it encounters files.
-func doTreeWalk(ctx context.Context, bucket, prefixDir, entryPrefixMatch, marker string, recursive bool, listDir ListDirFunc, resultCh chan TreeWalkResult, endWalkCh <-chan struct{}, isEnd bool) (emptyDir bool, treeErr error) {
+// skipDir is set when the marker is prefixDir itself, which is then not listed again.
+func doTreeWalk(ctx context.Context, bucket, prefixDir, entryPrefixMatch, marker string, skipDir, recursive bool, listDir ListDirFunc, resultCh chan TreeWalkResult, endWalkCh <-chan struct{}, isEnd bool) (emptyDir bool, treeErr error) {
 	// Example:
 	// if prefixDir="one/two/three/" and marker="four/five.txt" treeWalk is recursively
 	// called with prefixDir="one/two/three/four/" and marker="five.txt"
@@ -94,6 +118,19 @@ func doTreeWalk(ctx context.Context, bucket, prefixDir, entryPrefixMatch, marker
 	}
 
 	for i, entry := range entries {
+		if entry == "" {
+			// The directory object of prefixDir, listed before its content.
+			if skipDir {
+				continue
+			}
+			select {
+			case <-endWalkCh:
+				return false, errWalkAbort
+			case resultCh <- TreeWalkResult{entry: prefixDir, end: i == len(entries)-1 && isEnd}:
+			}
+			continue
+		}
+
 		pentry := pathJoin(prefixDir, entry)
 		isDir := HasSuffix(pentry, SlashSeparator)
 
@@ -114,16 +151,19 @@ func doTreeWalk(ctx context.Context, bucket, prefixDir, entryPrefixMatch, marker
 		if recursive && isDir {
 			// If the entry is a directory, we will need recurse into it.
 			markerArg := ""
+			skipDirArg := false
 			if entry == markerDir {
 				// We need to pass "five.txt" as marker only if we are
 				// recursing into "four/"
 				markerArg = markerBase
+				// The marker is "four/" itself, already listed.
+				skipDirArg = markerBase == ""
 			}
 			prefixMatch := "" // Valid only for first level treeWalk and empty for subdirectories.
 			// markIsEnd is passed to this entry's treeWalk() so that treeWalker.end can be marked
 			// true at the end of the treeWalk stream.
 			markIsEnd := i == len(entries)-1 && isEnd
-			emptyDir, err := doTreeWalk(ctx, bucket, pentry, prefixMatch, markerArg, recursive,
+			emptyDir, err := doTreeWalk(ctx, bucket, pentry, prefixMatch, markerArg, skipDirArg, recursive,
 				listDir, resultCh, endWalkCh, markIsEnd)
 			if err != nil {
 				return false, err
@@ -132,7 +172,7 @@ func doTreeWalk(ctx context.Context, bucket, prefixDir, entryPrefixMatch, marker
 			// A nil totalFound means this is an empty directory that
 			// needs to be sent to the result channel, otherwise continue
 			// to the next entry.
-			if !emptyDir {
+			if !emptyDir || skipDirArg {
 				continue
 			}
 		}
@@ -162,7 +202,7 @@ func startTreeWalk(ctx context.Context, bucket, prefix, marker string, recursive
 	// treeWalk is called with prefixDir="one/two/" and marker="three/four/five.txt"
 	// and entryPrefixMatch="th"
 
-	resultCh := make(chan TreeWalkResult, maxObjectList)
+	resultCh := make(chan TreeWalkResult, treeWalkBufferSize)
 	entryPrefixMatch := prefix
 	prefixDir := ""
 	lastIndex := strings.LastIndex(prefix, SlashSeparator)
@@ -170,10 +210,11 @@ func startTreeWalk(ctx context.Context, bucket, prefix, marker string, recursive
 		entryPrefixMatch = prefix[lastIndex+1:]
 		prefixDir = prefix[:lastIndex+1]
 	}
+	skipDir := marker != "" && marker == prefixDir
 	marker = strings.TrimPrefix(marker, prefixDir)
 	go func() {
 		isEnd := true // Indication to start walking the tree with end as true.
-		doTreeWalk(ctx, bucket, prefixDir, entryPrefixMatch, marker, recursive, listDir, resultCh, endWalkCh, isEnd)
+		doTreeWalk(ctx, bucket, prefixDir, entryPrefixMatch, marker, skipDir, recursive, listDir, resultCh, endWalkCh, isEnd)
 		close(resultCh)
 	}()
 	return resultCh
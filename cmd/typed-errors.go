@@ -98,3 +98,12 @@ var errAccessDenied = errors.New("Do not have enough permissions to access this
 
 // error returned when object is locked.
 var errLockedObject = errors.New("Object is WORM protected and cannot be overwritten or deleted")
+
+// error returned when the browser uploads an encrypted object in parts.
+var errWebMultipartEncrypted = errors.New("Encrypted objects cannot be uploaded in parts from the browser")
+
+// error returned when the user metadata of an object is larger than 2 KiB.
+var errMetadataTooLarge = errors.New("User metadata of the object exceeds the maximum allowed size")
+
+// error returned when the XML body of a request exceeds the maximum size allowed for the request.
+var errXMLBodyTooLarge = errors.New("XML body of the request exceeds the maximum allowed size")
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/tls"
@@ -35,16 +36,20 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"runtime/trace"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
+	miniogo "github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio/cmd/config/api"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/handlers"
 	"github.com/minio/minio/pkg/madmin"
 
-	humanize "github.com/dustin/go-humanize"
 	"github.com/gorilla/mux"
 )
 
@@ -114,24 +119,337 @@ const (
 	httpsScheme = "https"
 )
 
-// nopCharsetConverter is a dummy charset convert which just copies input to output,
-// it is used to ignore custom encoding charset in S3 XML body.
-func nopCharsetConverter(label string, input io.Reader) (io.Reader, error) {
+// xmlCharsetReader converts the XML bodies declaring a Latin-1 encoding
+// to UTF-8 and rejects the UTF-16 and UTF-32 ones, the bodies declaring
+// any other encoding are read as UTF-8 since many clients send UTF-8
+// bodies with an arbitrary encoding.
+func xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "iso-8859-1", "iso8859-1", "latin1", "l1":
+		br, ok := input.(io.ByteReader)
+		if !ok {
+			br = bufio.NewReader(input)
+		}
+		return &latin1Reader{r: br}, nil
+	case "utf-16", "utf-16le", "utf-16be", "utf-32", "utf-32le", "utf-32be":
+		return nil, fmt.Errorf("unsupported encoding %s, the body must be encoded in UTF-8", label)
+	}
 	return input, nil
 }
 
-// xmlDecoder provide decoded value in xml.
+// latin1Reader converts its Latin-1 input to UTF-8 as it is read.
+type latin1Reader struct {
+	r io.ByteReader
+	// next is the second byte of the last converted character.
+	next    byte
+	hasNext bool
+}
+
+func (l *latin1Reader) ReadByte() (byte, error) {
+	if l.hasNext {
+		l.hasNext = false
+		return l.next, nil
+	}
+	c, err := l.r.ReadByte()
+	if err != nil || c < utf8.RuneSelf {
+		return c, err
+	}
+	var buf [2]byte
+	utf8.EncodeRune(buf[:], rune(c))
+	l.next, l.hasNext = buf[1], true
+	return buf[0], nil
+}
+
+func (l *latin1Reader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if p[n], err = l.ReadByte(); err != nil {
+			break
+		}
+		n++
+	}
+	if n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+// xmlDecodeError - the XML body of a request is malformed or doesn't
+// match the schema of the request.
+type xmlDecodeError struct {
+	Element string // path of the element being decoded.
+	Line    int
+	Err     error
+}
+
+func (e xmlDecodeError) Error() string {
+	if e.Element == "" {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("element %s, line %d: %v", e.Element, e.Line, e.Err)
+}
+
+func (e xmlDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newXMLDecodeError - returns the error of decoding the body read by
+// r with the path of the element being decoded, errors which are not
+// about the XML document itself, such as the validation errors of the
+// decoded values, are returned as is.
+func newXMLDecodeError(r *xmlBodyReader, err error) error {
+	var syntaxErr *xml.SyntaxError
+	var unmarshalErr xml.UnmarshalError
+	var numErr *strconv.NumError
+	switch {
+	case err == io.EOF:
+		return xmlDecodeError{Line: 1, Err: errors.New("empty body")}
+	case errors.As(err, &syntaxErr):
+		return xmlDecodeError{Element: r.elementPath(), Line: syntaxErr.Line, Err: errors.New(syntaxErr.Msg)}
+	case errors.As(err, &unmarshalErr), errors.As(err, &numErr), errors.Is(err, io.ErrUnexpectedEOF):
+	case strings.HasPrefix(err.Error(), "xml: "):
+		// the charset and declaration errors of encoding/xml are not typed.
+	default:
+		return err
+	}
+	return xmlDecodeError{Element: r.elementPath(), Line: r.line, Err: err}
+}
+
+// The states of the scan of the markup by xmlBodyReader.
+const (
+	xmlScanText = iota
+	xmlScanTagOpen
+	xmlScanStartName
+	xmlScanTag
+	xmlScanQuoted
+	xmlScanEmptyTag
+	xmlScanEndTag
+	xmlScanProcInst
+	xmlScanBang
+	xmlScanComment
+	xmlScanCDATA
+	xmlScanDirective
+)
+
+// xmlBodyReader reads the XML body of a request byte by byte for the
+// decoder, which then reads nothing ahead, and scans the markup read
+// to keep the path of the element being decoded and the line.
+type xmlBodyReader struct {
+	r *bufio.Reader
+	n int64
+
+	line int
+	path []string
+	// closed is the element whose end tag was just read, its value
+	// is only converted by the decoder once the end tag is read.
+	closed string
+
+	state int
+	name  []byte
+	quote byte
+	// tail holds the last bytes of a comment, a CDATA section or a
+	// processing instruction, depth the nesting of a directive.
+	tail  []byte
+	depth int
+}
+
+func newXMLBodyReader(body io.Reader) *xmlBodyReader {
+	return &xmlBodyReader{r: bufio.NewReader(body), line: 1}
+}
+
+func (x *xmlBodyReader) Read(p []byte) (n int, err error) {
+	for n < len(p) {
+		if p[n], err = x.ReadByte(); err != nil {
+			break
+		}
+		n++
+	}
+	if n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (x *xmlBodyReader) ReadByte() (byte, error) {
+	c, err := x.r.ReadByte()
+	if err != nil {
+		return c, err
+	}
+	x.n++
+	x.scan(c)
+	return c, nil
+}
+
+// elementPath returns the path of the element being decoded.
+func (x *xmlBodyReader) elementPath() string {
+	path := x.path
+	if x.closed != "" {
+		path = append(path[:len(path):len(path)], x.closed)
+	}
+	return strings.Join(path, "/")
+}
+
+func (x *xmlBodyReader) startElement() {
+	x.path = append(x.path, string(x.name))
+	x.closed = ""
+}
+
+func (x *xmlBodyReader) endElement() {
+	if len(x.path) > 0 {
+		x.closed = x.path[len(x.path)-1]
+		x.path = x.path[:len(x.path)-1]
+	}
+}
+
+// endsWith reports whether the markup read ends with the suffix.
+func (x *xmlBodyReader) endsWith(c byte, suffix string) bool {
+	x.tail = append(x.tail, c)
+	if len(x.tail) > len(suffix) {
+		x.tail = x.tail[1:]
+	}
+	return string(x.tail) == suffix
+}
+
+func (x *xmlBodyReader) scan(c byte) {
+	if c == '\n' {
+		x.line++
+	}
+	switch x.state {
+	case xmlScanText:
+		if c == '<' {
+			x.state = xmlScanTagOpen
+		} else {
+			x.closed = ""
+		}
+	case xmlScanTagOpen:
+		x.tail = x.tail[:0]
+		switch c {
+		case '/':
+			x.state = xmlScanEndTag
+		case '!':
+			x.state = xmlScanBang
+		case '?':
+			x.state = xmlScanProcInst
+		default:
+			x.name = append(x.name[:0], c)
+			x.state = xmlScanStartName
+		}
+	case xmlScanStartName:
+		switch c {
+		case '>':
+			x.startElement()
+			x.state = xmlScanText
+		case '/':
+			x.startElement()
+			x.state = xmlScanEmptyTag
+		case ' ', '\t', '\r', '\n':
+			x.startElement()
+			x.state = xmlScanTag
+		default:
+			x.name = append(x.name, c)
+		}
+	case xmlScanTag:
+		switch c {
+		case '"', '\'':
+			x.quote = c
+			x.state = xmlScanQuoted
+		case '/':
+			x.state = xmlScanEmptyTag
+		case '>':
+			x.state = xmlScanText
+		}
+	case xmlScanQuoted:
+		if c == x.quote {
+			x.state = xmlScanTag
+		}
+	case xmlScanEmptyTag:
+		if c == '>' {
+			x.endElement()
+			x.state = xmlScanText
+		} else {
+			x.state = xmlScanTag
+		}
+	case xmlScanEndTag:
+		if c == '>' {
+			x.endElement()
+			x.state = xmlScanText
+		}
+	case xmlScanProcInst:
+		if x.endsWith(c, "?>") {
+			x.state = xmlScanText
+		}
+	case xmlScanBang:
+		x.tail = append(x.tail, c)
+		switch {
+		case string(x.tail) == "--":
+			x.tail = x.tail[:0]
+			x.state = xmlScanComment
+		case string(x.tail) == "[CDATA[":
+			x.tail = x.tail[:0]
+			x.closed = ""
+			x.state = xmlScanCDATA
+		case !strings.HasPrefix("--", string(x.tail)) && !strings.HasPrefix("[CDATA[", string(x.tail)):
+			x.depth = 0
+			x.state = xmlScanDirective
+			for _, c := range x.tail {
+				x.scanDirective(c)
+			}
+		}
+	case xmlScanComment:
+		if x.endsWith(c, "-->") {
+			x.state = xmlScanText
+		}
+	case xmlScanCDATA:
+		if x.endsWith(c, "]]>") {
+			x.state = xmlScanText
+		}
+	case xmlScanDirective:
+		x.scanDirective(c)
+	}
+}
+
+// scanDirective scans a directive such as <!DOCTYPE>, which may nest
+// declarations between brackets.
+func (x *xmlBodyReader) scanDirective(c byte) {
+	switch c {
+	case '[':
+		x.depth++
+	case ']':
+		x.depth--
+	case '>':
+		if x.depth <= 0 {
+			x.state = xmlScanText
+		}
+	}
+}
+
+// maxXMLBodySize - the largest XML request body read, the largest ones
+// are the Multi-Object Delete requests of 100000 object names (each at
+// most 1024 bytes long) + XML overhead.
+const maxXMLBodySize = 2 * 100000 * 1024
+
+// xmlDecoder decodes the XML body into v as it is read, bodies larger
+// than size bytes are rejected with errXMLBodyTooLarge. The size is
+// capped to maxXMLBodySize, the unknown size (-1) of chunked requests
+// included.
 func xmlDecoder(body io.Reader, v interface{}, size int64) error {
-	var lbody io.Reader
-	if size > 0 {
-		lbody = io.LimitReader(body, size)
-	} else {
-		lbody = body
+	if size <= 0 || size > maxXMLBodySize {
+		size = maxXMLBodySize
+	}
+	r := newXMLBodyReader(io.LimitReader(body, size+1))
+	d := xml.NewDecoder(r)
+	d.CharsetReader = xmlCharsetReader
+	err := d.Decode(v)
+	if err == nil {
+		// Read the rest of the body, past the root element.
+		_, err = io.Copy(ioutil.Discard, r)
 	}
-	d := xml.NewDecoder(lbody)
-	// Ignore any encoding set in the XML body
-	d.CharsetReader = nopCharsetConverter
-	return d.Decode(v)
+	if r.n > size {
+		return errXMLBodyTooLarge
+	}
+	if err != nil {
+		return newXMLDecodeError(r, err)
+	}
+	return nil
 }
 
 // checkValidMD5 - verify if valid md5, returns md5 in bytes.
@@ -154,21 +472,24 @@ func hasContentMD5(h http.Header) bool {
 
 /// http://docs.aws.amazon.com/AmazonS3/latest/dev/UploadingObjects.html
 const (
-	// Maximum object size per PUT request is 5TB.
+	// Maximum object size per PUT request is 5TB by default,
+	// configurable with the api max_object_size setting.
 	// This is a divergence from S3 limit on purpose to support
 	// use cases where users are going to upload large files
 	// using 'curl' and presigned URL.
-	globalMaxObjectSize = 5 * humanize.TiByte
+	globalMaxObjectSize = api.DefaultMaxObjectSize
 
 	// Minimum Part size for multipart upload is 5MiB
-	globalMinPartSize = 5 * humanize.MiByte
+	globalMinPartSize = api.MinPartSize
 
-	// Maximum Part size for multipart upload is 5GiB
-	globalMaxPartSize = 5 * humanize.GiByte
+	// Maximum Part size for multipart upload is 5GiB by default,
+	// configurable with the api max_part_size setting.
+	globalMaxPartSize = api.DefaultMaxPartSize
 
-	// Maximum Part ID for multipart upload is 10000
+	// Maximum Part ID for multipart upload is 10000 by default,
+	// configurable with the api max_parts setting.
 	// (Acceptable values range from 1 to 10000 inclusive)
-	globalMaxPartID = 10000
+	globalMaxPartID = api.DefaultMaxParts
 
 	// Default values used while communicating for internode communication.
 	defaultDialTimeout = 5 * time.Second
@@ -176,12 +497,12 @@ const (
 
 // isMaxObjectSize - verify if max object size
 func isMaxObjectSize(size int64) bool {
-	return size > globalMaxObjectSize
+	return size > globalAPIConfig.getMaxObjectSize()
 }
 
 // // Check if part size is more than maximum allowed size.
 func isMaxAllowedPartSize(size int64) bool {
-	return size > globalMaxPartSize
+	return size > globalAPIConfig.getMaxPartSize()
 }
 
 // Check if part size is more than or equal to minimum allowed size.
@@ -191,7 +512,7 @@ func isMinAllowedPartSize(size int64) bool {
 
 // isMaxPartNumber - Check if part ID is greater than the maximum allowed ID.
 func isMaxPartID(partID int) bool {
-	return partID > globalMaxPartID
+	return partID > globalAPIConfig.getMaxParts()
 }
 
 func contains(slice interface{}, elem interface{}) bool {
@@ -493,6 +814,34 @@ func newGatewayHTTPTransport(timeout time.Duration) *http.Transport {
 	return tr
 }
 
+var (
+	remoteS3Transport     http.RoundTripper
+	remoteS3TransportOnce sync.Once
+)
+
+// newRemoteS3Client returns a client of a remote S3 compatible
+// endpoint, such as remote tiers and bucket mirror targets.
+func newRemoteS3Client(endpointURL, accessKey, secretKey, region string) (*miniogo.Core, error) {
+	endpoint, secure, err := ParseGatewayEndpoint(endpointURL)
+	if err != nil {
+		return nil, err
+	}
+	clnt, err := miniogo.NewWithOptions(endpoint, &miniogo.Options{
+		Creds:        credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure:       secure,
+		Region:       region,
+		BucketLookup: miniogo.BucketLookupAuto,
+	})
+	if err != nil {
+		return nil, err
+	}
+	remoteS3TransportOnce.Do(func() {
+		remoteS3Transport = newGatewayHTTPTransport(time.Hour)
+	})
+	clnt.SetCustomTransport(remoteS3Transport)
+	return &miniogo.Core{Client: clnt}, nil
+}
+
 // Load the json (typically from disk file).
 func jsonLoad(r io.ReadSeeker, data interface{}) error {
 	if _, err := r.Seek(0, io.SeekStart); err != nil {
@@ -547,7 +896,7 @@ func ceilFrac(numerator, denominator int64) (ceil int64) {
 func newContext(r *http.Request, w http.ResponseWriter, api string) context.Context {
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		object = vars["object"]
 	}
@@ -561,6 +910,7 @@ func newContext(r *http.Request, w http.ResponseWriter, api string) context.Cont
 	reqInfo := &logger.ReqInfo{
 		DeploymentID: globalDeploymentID,
 		RequestID:    w.Header().Get(xhttp.AmzRequestID),
+		HostID:       w.Header().Get(xhttp.AmzRequestHostID),
 		RemoteHost:   handlers.GetSourceIP(r),
 		Host:         getHostName(r),
 		UserAgent:    r.UserAgent(),
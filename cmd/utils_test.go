@@ -28,6 +28,10 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/api"
 )
 
 // Tests maximum object size.
@@ -107,6 +111,68 @@ func TestMaxPartID(t *testing.T) {
 	}
 }
 
+// Tests the upload limits set with the api config.
+func TestConfiguredUploadLimits(t *testing.T) {
+	lookup := func(maxObjectSize, maxPartSize, maxParts string) (api.Config, error) {
+		kvs := append(config.KVS{}, api.DefaultKVS...)
+		kvs.Set("max_object_size", maxObjectSize)
+		kvs.Set("max_part_size", maxPartSize)
+		kvs.Set("max_parts", maxParts)
+		return api.LookupConfig(kvs)
+	}
+
+	for i, testCase := range []struct {
+		maxObjectSize, maxPartSize, maxParts string
+	}{
+		// Test 1 - part size smaller than the min. part size.
+		{"5TiB", "1MiB", "10000"},
+		// Test 2 - part size larger than the object size.
+		{"1GiB", "5GiB", "10000"},
+		// Test 3 - invalid number of parts.
+		{"5TiB", "5GiB", "0"},
+		// Test 4 - invalid object size.
+		{"5TB0", "5GiB", "10000"},
+	} {
+		if _, err := lookup(testCase.maxObjectSize, testCase.maxPartSize, testCase.maxParts); err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+
+	cfg, err := lookup("50TiB", "10GiB", "20000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalAPIConfig.init(cfg)
+	defer globalAPIConfig.init(api.Config{})
+
+	if isMaxObjectSize(globalMaxObjectSize + 1) {
+		t.Errorf("Expected object size %d to be allowed", globalMaxObjectSize+1)
+	}
+	if !isMaxObjectSize(50*humanize.TiByte + 1) {
+		t.Errorf("Expected object size %d to be too large", 50*humanize.TiByte+1)
+	}
+	if isMaxAllowedPartSize(globalMaxPartSize + 1) {
+		t.Errorf("Expected part size %d to be allowed", globalMaxPartSize+1)
+	}
+	if isMaxPartID(globalMaxPartID + 1) {
+		t.Errorf("Expected part ID %d to be allowed", globalMaxPartID+1)
+	}
+	if !isMaxPartID(20001) {
+		t.Errorf("Expected part ID %d to be too large", 20001)
+	}
+	if requestMaxBodySize() != 50*humanize.TiByte+requestFormDataSize {
+		t.Errorf("Unexpected max. request body size %d", requestMaxBodySize())
+	}
+
+	// The number of parts is clamped.
+	if cfg, err = lookup("5TiB", "5GiB", "1000000"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIMaxParts != api.MaxParts {
+		t.Errorf("Expected max. parts %d, got %d", api.MaxParts, cfg.APIMaxParts)
+	}
+}
+
 // Tests extracting bucket and objectname from various types of paths.
 func TestPath2BucketObjectName(t *testing.T) {
 	testCases := []struct {
@@ -487,3 +553,115 @@ func TestGetMinioMode(t *testing.T) {
 	testMinioMode(globalMinioModeGatewayPrefix + globalGatewayName)
 
 }
+
+// Tests decoding of the XML bodies of the requests.
+func TestXMLDecoder(t *testing.T) {
+	testCases := []struct {
+		body     string
+		size     int64
+		expected []string
+		err      error
+		element  string
+		line     int
+	}{
+		// Test 1 - valid body.
+		{
+			body:     `<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>abc</ETag></Part></CompleteMultipartUpload>`,
+			expected: []string{"abc"},
+		},
+		// Test 2 - valid Latin-1 body.
+		{
+			body:     "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>\xe9</ETag></Part></CompleteMultipartUpload>",
+			expected: []string{"é"},
+		},
+		// Test 3 - body larger than the size.
+		{
+			body: `<CompleteMultipartUpload></CompleteMultipartUpload>`,
+			size: 10,
+			err:  errXMLBodyTooLarge,
+		},
+		// Test 4 - empty body.
+		{
+			body: "",
+			line: 1,
+		},
+		// Test 5 - invalid part number.
+		{
+			body:    "<CompleteMultipartUpload>\n<Part><PartNumber>one</PartNumber><ETag>abc</ETag></Part></CompleteMultipartUpload>",
+			element: "CompleteMultipartUpload/Part/PartNumber",
+			line:    2,
+		},
+		// Test 6 - unclosed element.
+		{
+			body:    "<CompleteMultipartUpload>\n<Part><PartNumber>1</PartNumber>\n</CompleteMultipartUpload>",
+			element: "CompleteMultipartUpload/Part",
+			line:    3,
+		},
+		// Test 7 - unsupported encoding.
+		{
+			body: "<?xml version=\"1.0\" encoding=\"UTF-16\"?>\n<CompleteMultipartUpload></CompleteMultipartUpload>",
+			line: 1,
+		},
+		// Test 8 - unknown size of a chunked request.
+		{
+			body:     `<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>abc</ETag></Part></CompleteMultipartUpload>`,
+			size:     -1,
+			expected: []string{"abc"},
+		},
+		// Test 9 - invalid part number of a Latin-1 body.
+		{
+			body:    "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<CompleteMultipartUpload><Part><ETag>\xe9</ETag>\n<PartNumber>\xe9</PartNumber></Part></CompleteMultipartUpload>",
+			element: "CompleteMultipartUpload/Part/PartNumber",
+			line:    3,
+		},
+		// Test 10 - comments, CDATA sections and attributes with markup.
+		{
+			body:     "<!-- <Part> --><CompleteMultipartUpload xmlns=\"a/>b\"><Part><PartNumber>1</PartNumber><ETag><![CDATA[<abc>]]></ETag></Part></CompleteMultipartUpload>",
+			expected: []string{"<abc>"},
+		},
+		// Test 11 - invalid part number after comments, CDATA sections and empty elements.
+		{
+			body:    "<!-- <Part> --><CompleteMultipartUpload xmlns=\"a/>b\"><Part><ETag><![CDATA[</Part>]]></ETag><Empty/>\n<PartNumber>one</PartNumber></Part></CompleteMultipartUpload>",
+			element: "CompleteMultipartUpload/Part/PartNumber",
+			line:    2,
+		},
+		// Test 12 - data past the root element exceeding the size.
+		{
+			body: `<CompleteMultipartUpload></CompleteMultipartUpload>` + strings.Repeat(" ", 100),
+			size: 100,
+			err:  errXMLBodyTooLarge,
+		},
+	}
+
+	for i, testCase := range testCases {
+		upload := &CompleteMultipartUpload{}
+		err := xmlDecoder(strings.NewReader(testCase.body), upload, testCase.size)
+		if testCase.expected != nil {
+			if err != nil {
+				t.Fatalf("Test %d: unexpected error %v", i+1, err)
+			}
+			var etags []string
+			for _, part := range upload.Parts {
+				etags = append(etags, part.ETag)
+			}
+			if !reflect.DeepEqual(etags, testCase.expected) {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, etags)
+			}
+			continue
+		}
+		if testCase.err != nil {
+			if err != testCase.err {
+				t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+			}
+			continue
+		}
+		decodeErr, ok := err.(xmlDecodeError)
+		if !ok {
+			t.Fatalf("Test %d: expected a decode error, got %v", i+1, err)
+		}
+		if decodeErr.Element != testCase.element || decodeErr.Line != testCase.line {
+			t.Fatalf("Test %d: expected element %q at line %d, got %q at line %d (%v)",
+				i+1, testCase.element, testCase.line, decodeErr.Element, decodeErr.Line, decodeErr)
+		}
+	}
+}
@@ -227,6 +227,45 @@ func (args *PresignedGetArgs) ToKeyValue() KeyValueMap {
 	return km
 }
 
+// ToKeyValue implementation for MultipartUploadArgs
+func (args *MultipartUploadArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	km.SetObject(args.ObjectName)
+	return km
+}
+
+// ToKeyValue implementation for SearchObjectsArgs
+func (args *SearchObjectsArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	km.SetPrefix(args.Prefix)
+	return km
+}
+
+// ToKeyValue implementation for ObjectMetadataArgs
+func (args *ObjectMetadataArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	km.SetObject(args.ObjectName)
+	return km
+}
+
+// ToKeyValue implementation for SetObjectMetadataArgs
+func (args *SetObjectMetadataArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	km.SetObject(args.ObjectName)
+	return km
+}
+
+// ToKeyValue implementation for UsageHistoryArgs
+func (args *UsageHistoryArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	return km
+}
+
 // newWebContext creates a context with ReqInfo values from the given
 // http request and api name.
 func newWebContext(r *http.Request, args ToKeyValuer, api string) context.Context {
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"archive/tar"
 	"context"
 	"encoding/json"
 	"encoding/xml"
@@ -28,16 +29,19 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/rpc/v2/json2"
 	"github.com/klauspost/compress/zip"
 	"github.com/minio/minio-go/v7"
 	miniogopolicy "github.com/minio/minio-go/v7/pkg/policy"
 	"github.com/minio/minio-go/v7/pkg/s3utils"
+	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/browser"
 	"github.com/minio/minio/cmd/config/etcd/dns"
 	"github.com/minio/minio/cmd/config/identity/openid"
@@ -52,6 +56,7 @@ import (
 	"github.com/minio/minio/pkg/hash"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/ioutil"
+	"golang.org/x/net/http/httpguts"
 )
 
 // WebGenericArgs - empty struct for calls that don't accept arguments
@@ -136,6 +141,184 @@ func (web *webAPIHandlers) StorageInfo(r *http.Request, args *WebGenericArgs, re
 	return nil
 }
 
+// WebBucketUsage - usage of a bucket.
+type WebBucketUsage struct {
+	Name         string `json:"name"`
+	Size         uint64 `json:"size"`
+	ObjectsCount uint64 `json:"objectsCount"`
+}
+
+// WebDiskInfo - capacity and state of a disk.
+type WebDiskInfo struct {
+	Endpoint       string `json:"endpoint"`
+	Path           string `json:"path"`
+	State          string `json:"state"`
+	TotalSpace     uint64 `json:"totalSpace"`
+	UsedSpace      uint64 `json:"usedSpace"`
+	AvailableSpace uint64 `json:"availableSpace"`
+}
+
+// UsageInfoRep - usage dashboard response. Only the buckets the user
+// is allowed to list are returned, the totals and the disks only to
+// users allowed the matching admin actions.
+type UsageInfoRep struct {
+	LastUpdate       time.Time        `json:"lastUpdate"`
+	ObjectsCount     uint64           `json:"objectsCount"`
+	ObjectsTotalSize uint64           `json:"objectsTotalSize"`
+	Buckets          []WebBucketUsage `json:"buckets"`
+	Disks            []WebDiskInfo    `json:"disks"`
+	UIVersion        string           `json:"uiVersion"`
+}
+
+// UsageInfo - web call to gather the latest results of the data
+// usage crawler and the state of the disks.
+func (web *webAPIHandlers) UsageInfo(r *http.Request, args *WebGenericArgs, reply *UsageInfoRep) error {
+	ctx := newWebContext(r, args, "WebUsageInfo")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	// Set prefix value for "s3:prefix" policy conditionals.
+	r.Header.Set("prefix", "")
+
+	// Set delimiter value for "s3:delimiter" policy conditionals.
+	r.Header.Set("delimiter", SlashSeparator)
+
+	isAllowed := func(action iampolicy.Action, bucket string) bool {
+		return globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     claims.AccessKey,
+			Action:          action,
+			BucketName:      bucket,
+			ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+			IsOwner:         owner,
+			Claims:          claims.Map(),
+		})
+	}
+
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objectAPI)
+	if err != nil {
+		return toJSONError(ctx, err)
+	}
+	reply.LastUpdate = dataUsageInfo.LastUpdate
+	if isAllowed(iampolicy.DataUsageInfoAdminAction, "") {
+		reply.ObjectsCount = dataUsageInfo.ObjectsTotalCount
+		reply.ObjectsTotalSize = dataUsageInfo.ObjectsTotalSize
+	}
+	for bucket, usage := range dataUsageInfo.BucketsUsage {
+		if isAllowed(iampolicy.ListBucketAction, bucket) {
+			reply.Buckets = append(reply.Buckets, WebBucketUsage{
+				Name:         bucket,
+				Size:         usage.Size,
+				ObjectsCount: usage.ObjectsCount,
+			})
+		}
+	}
+	sort.Slice(reply.Buckets, func(i, j int) bool {
+		return reply.Buckets[i].Name < reply.Buckets[j].Name
+	})
+
+	if isAllowed(iampolicy.StorageInfoAdminAction, "") {
+		storageInfo, _ := objectAPI.StorageInfo(ctx, false)
+		for _, disk := range storageInfo.Disks {
+			reply.Disks = append(reply.Disks, WebDiskInfo{
+				Endpoint:       disk.Endpoint,
+				Path:           disk.DrivePath,
+				State:          disk.State,
+				TotalSpace:     disk.TotalSpace,
+				UsedSpace:      disk.UsedSpace,
+				AvailableSpace: disk.AvailableSpace,
+			})
+		}
+	}
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
+// UsageHistoryArgs - usage history args, the history of the total
+// usage of all the buckets when BucketName is empty.
+type UsageHistoryArgs struct {
+	BucketName string `json:"bucketName"`
+}
+
+// WebUsageSample - usage at a point in time.
+type WebUsageSample struct {
+	Time         time.Time `json:"time"`
+	Size         uint64    `json:"size"`
+	ObjectsCount uint64    `json:"objectsCount"`
+}
+
+// UsageHistoryRep - usage history response, oldest sample first.
+type UsageHistoryRep struct {
+	Samples   []WebUsageSample `json:"samples"`
+	UIVersion string           `json:"uiVersion"`
+}
+
+// UsageHistory - web call to gather the usage of a bucket, or of all
+// the buckets, over time.
+func (web *webAPIHandlers) UsageHistory(r *http.Request, args *UsageHistoryArgs, reply *UsageHistoryRep) error {
+	ctx := newWebContext(r, args, "WebUsageHistory")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	action := iampolicy.Action(iampolicy.DataUsageInfoAdminAction)
+	if args.BucketName != "" {
+		// Set prefix value for "s3:prefix" policy conditionals.
+		r.Header.Set("prefix", "")
+
+		// Set delimiter value for "s3:delimiter" policy conditionals.
+		r.Header.Set("delimiter", SlashSeparator)
+
+		action = iampolicy.ListBucketAction
+	}
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.AccessKey,
+		Action:          action,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+		IsOwner:         owner,
+		Claims:          claims.Map(),
+	}) {
+		return toJSONError(ctx, errAccessDenied, args.BucketName)
+	}
+
+	history, err := loadDataUsageHistory(ctx, objectAPI)
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+	for _, sample := range history {
+		if args.BucketName == "" {
+			reply.Samples = append(reply.Samples, WebUsageSample{
+				Time:         sample.Time,
+				Size:         sample.ObjectsTotalSize,
+				ObjectsCount: sample.ObjectsCount,
+			})
+			continue
+		}
+		if usage, ok := sample.Buckets[args.BucketName]; ok {
+			reply.Samples = append(reply.Samples, WebUsageSample{
+				Time:         sample.Time,
+				Size:         usage.Size,
+				ObjectsCount: usage.ObjectsCount,
+			})
+		}
+	}
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
 // MakeBucketArgs - make bucket args.
 type MakeBucketArgs struct {
 	BucketName string `json:"bucketName"`
@@ -148,6 +331,9 @@ func (web *webAPIHandlers) MakeBucket(r *http.Request, args *MakeBucketArgs, rep
 	if objectAPI == nil {
 		return toJSONError(ctx, errServerNotInitialized)
 	}
+	if err := checkServerMode(policy.CreateBucketAction); err != nil {
+		return toJSONError(ctx, err)
+	}
 	claims, owner, authErr := webRequestAuthenticate(r)
 	if authErr != nil {
 		return toJSONError(ctx, authErr)
@@ -215,6 +401,9 @@ func (web *webAPIHandlers) DeleteBucket(r *http.Request, args *RemoveBucketArgs,
 	if objectAPI == nil {
 		return toJSONError(ctx, errServerNotInitialized)
 	}
+	if err := checkServerMode(policy.DeleteBucketAction); err != nil {
+		return toJSONError(ctx, err)
+	}
 	claims, owner, authErr := webRequestAuthenticate(r)
 	if authErr != nil {
 		return toJSONError(ctx, authErr)
@@ -565,6 +754,116 @@ func (web *webAPIHandlers) ListObjects(r *http.Request, args *ListObjectsArgs, r
 	}
 }
 
+// SearchObjectsArgs - search objects args, objects under Prefix
+// whose name contains Query are searched after Marker.
+type SearchObjectsArgs struct {
+	BucketName string `json:"bucketName"`
+	Prefix     string `json:"prefix"`
+	Query      string `json:"query"`
+	Marker     string `json:"marker"`
+}
+
+// SearchObjectsRep - search objects response, the search resumes
+// after NextMarker when it is truncated.
+type SearchObjectsRep struct {
+	Objects     []WebObjectInfo `json:"objects"`
+	NextMarker  string          `json:"nextMarker"`
+	IsTruncated bool            `json:"isTruncated"`
+	UIVersion   string          `json:"uiVersion"`
+}
+
+const (
+	// Maximum number of objects found by a single search.
+	maxSearchObjects = 1000
+
+	// Maximum number of objects listed by a single search.
+	maxSearchScanned = 10000
+)
+
+// SearchObjects - searches recursively the objects under a prefix
+// whose name, relative to the prefix, contains the query. Case is
+// ignored.
+func (web *webAPIHandlers) SearchObjects(r *http.Request, args *SearchObjectsArgs, reply *SearchObjectsRep) error {
+	ctx := newWebContext(r, args, "WebSearchObjects")
+	reply.UIVersion = browser.UIVersion
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	// Set prefix value for "s3:prefix" policy conditionals.
+	r.Header.Set("prefix", args.Prefix)
+
+	// Set delimiter value for "s3:delimiter" policy conditionals,
+	// objects are listed recursively.
+	r.Header.Set("delimiter", "")
+
+	if err := webObjectActionAllowed(r, iampolicy.ListBucketAction, args.BucketName, ""); err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName, args.BucketName)
+	}
+
+	listObjects := func(marker string) (ListObjectsInfo, error) {
+		return objectAPI.ListObjects(ctx, args.BucketName, args.Prefix, marker, "", maxObjectList)
+	}
+	if isRemoteCallRequired(ctx, args.BucketName, objectAPI) {
+		sr, err := globalDNSConfig.Get(args.BucketName)
+		if err != nil {
+			if err == dns.ErrNoEntriesFound {
+				return toJSONError(ctx, BucketNotFound{
+					Bucket: args.BucketName,
+				}, args.BucketName)
+			}
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		core, err := getRemoteInstanceClientLongTimeout(r, getHostFromSrv(sr))
+		if err != nil {
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		listObjects = func(marker string) (ListObjectsInfo, error) {
+			result, err := core.ListObjects(args.BucketName, args.Prefix, marker, "", maxObjectList)
+			if err != nil {
+				return ListObjectsInfo{}, err
+			}
+			return FromMinioClientListBucketResult(args.BucketName, result), nil
+		}
+	}
+
+	query := strings.ToLower(args.Query)
+	marker := args.Marker
+	scanned := 0
+	for {
+		loi, err := listObjects(marker)
+		if err != nil {
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		for i, obj := range loi.Objects {
+			marker = obj.Name
+			scanned++
+			if strings.Contains(strings.ToLower(strings.TrimPrefix(obj.Name, args.Prefix)), query) {
+				reply.Objects = append(reply.Objects, WebObjectInfo{
+					Key:          obj.Name,
+					LastModified: obj.ModTime,
+					Size:         obj.Size,
+					ContentType:  obj.ContentType,
+				})
+			}
+			more := i < len(loi.Objects)-1 || loi.IsTruncated
+			if more && (len(reply.Objects) >= maxSearchObjects || scanned >= maxSearchScanned) {
+				reply.NextMarker = marker
+				reply.IsTruncated = true
+				return nil
+			}
+		}
+		if !loi.IsTruncated || len(loi.Objects) == 0 {
+			return nil
+		}
+	}
+}
+
 // RemoveObjectArgs - args to remove an object, JSON will look like.
 //
 // {
@@ -587,6 +886,9 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 	if objectAPI == nil {
 		return toJSONError(ctx, errServerNotInitialized)
 	}
+	if err := checkServerMode(policy.DeleteObjectAction); err != nil {
+		return toJSONError(ctx, err)
+	}
 
 	deleteObjects := objectAPI.DeleteObjects
 	if web.CacheAPI() != nil {
@@ -622,6 +924,10 @@ func (web *webAPIHandlers) RemoveObject(r *http.Request, args *RemoveObjectArgs,
 		return toJSONError(ctx, errInvalidBucketName, args.BucketName)
 	}
 
+	if _, ok := readReplicaSource(args.BucketName); ok {
+		return toJSONError(ctx, BucketReadReplica{Bucket: args.BucketName}, args.BucketName)
+	}
+
 	reply.UIVersion = browser.UIVersion
 	if isRemoteCallRequired(ctx, args.BucketName, objectAPI) {
 		sr, err := globalDNSConfig.Get(args.BucketName)
@@ -929,9 +1235,14 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := checkServerMode(policy.PutObjectAction); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
 	vars := mux.Vars(r)
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeWebErrorResponse(w, err)
 		return
@@ -1002,6 +1313,16 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if _, ok := readReplicaSource(bucket); ok {
+		writeWebErrorResponse(w, BucketReadReplica{Bucket: bucket})
+		return
+	}
+
+	if uploadID := r.URL.Query().Get(xhttp.UploadID); uploadID != "" {
+		web.uploadPart(ctx, w, r, objectAPI, bucket, object, uploadID)
+		return
+	}
+
 	// Check if bucket encryption is enabled
 	_, err = globalBucketSSEConfigSys.Get(bucket)
 	if (globalAutoEncryption || err == nil) && !crypto.SSEC.IsRequested(r.Header) {
@@ -1022,8 +1343,16 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Scan the content with the antivirus service, if enabled.
+	scanned, err := newScanReader(ctx, objectAPI, r.Body, size, bucket, object, quarantineObjectName(bucket, object))
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+	defer scanned.Close()
+
 	var pReader *PutObjReader
-	var reader io.Reader = r.Body
+	var reader io.Reader = scanned
 	actualSize := size
 
 	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
@@ -1141,7 +1470,484 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// Download - file download handler.
+// uploadPart - uploads a part of a multipart upload started
+// with NewMultipartUpload.
+func (web *webAPIHandlers) uploadPart(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, bucket, object, uploadID string) {
+	partID, err := strconv.Atoi(r.URL.Query().Get(xhttp.PartNumber))
+	if err != nil || partID <= 0 || isMaxPartID(partID) {
+		writeWebErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	// Require Content-Length to be set in the request
+	size := r.ContentLength
+	if size < 0 {
+		writeWebErrorResponse(w, errSizeUnspecified)
+		return
+	}
+
+	mi, err := objectAPI.GetMultipartInfo(ctx, bucket, object, uploadID, ObjectOptions{})
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	// Parts of encrypted uploads are only accepted through the S3 API.
+	if crypto.IsEncrypted(mi.UserDefined) {
+		writeWebErrorResponse(w, errWebMultipartEncrypted)
+		return
+	}
+
+	// Scan the content with the antivirus service, if enabled.
+	scanned, err := newScanReader(ctx, objectAPI, r.Body, size, bucket, object,
+		quarantineObjectName(bucket, pathJoin(object, uploadID, strconv.Itoa(partID))))
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+	defer scanned.Close()
+
+	var reader io.Reader = scanned
+	actualSize := size
+
+	// Read compression metadata preserved in the init multipart for the decision.
+	if _, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]; isCompressed && objectAPI.IsCompressionSupported() {
+		actualReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			writeWebErrorResponse(w, err)
+			return
+		}
+
+		// Set compression metrics.
+		s2c := newS2CompressReader(actualReader)
+		defer s2c.Close()
+		reader = s2c
+		size = -1 // Since compressed size is un-predictable.
+	}
+
+	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	partInfo, err := objectAPI.PutObjectPart(ctx, bucket, object, uploadID, partID, NewPutObjReader(hashReader, nil, nil), ObjectOptions{})
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	w.Header()[xhttp.ETag] = []string{"\"" + partInfo.ETag + "\""}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// webObjectActionAllowed - checks that the user of the request, or
+// anonymous users if the request has no token, are allowed the
+// action on the object.
+func webObjectActionAllowed(r *http.Request, action iampolicy.Action, bucket, object string) error {
+	if err := checkServerMode(policy.Action(action)); err != nil {
+		return err
+	}
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr == errNoAuthToken {
+		if !globalPolicySys.IsAllowed(policy.Args{
+			Action:          policy.Action(action),
+			BucketName:      bucket,
+			ConditionValues: getConditionValues(r, "", "", nil),
+			IsOwner:         false,
+			ObjectName:      object,
+		}) {
+			return errAuthentication
+		}
+		return nil
+	}
+	if authErr != nil {
+		return authErr
+	}
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.AccessKey,
+		Action:          action,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", claims.AccessKey, claims.Map()),
+		IsOwner:         owner,
+		ObjectName:      object,
+		Claims:          claims.Map(),
+	}) {
+		return errAccessDenied
+	}
+	return nil
+}
+
+// MultipartUploadArgs - multipart upload API args, UploadID is
+// not set to start a multipart upload.
+type MultipartUploadArgs struct {
+	BucketName  string `json:"bucketName"`
+	ObjectName  string `json:"objectName"`
+	UploadID    string `json:"uploadId"`
+	ContentType string `json:"contentType"`
+}
+
+// checkWebMultipartArgs - validates the bucket and object of a
+// multipart upload, and that the user is allowed the action.
+func checkWebMultipartArgs(r *http.Request, action iampolicy.Action, args *MultipartUploadArgs) error {
+	if err := webObjectActionAllowed(r, action, args.BucketName, args.ObjectName); err != nil {
+		return err
+	}
+	if args.ObjectName == "" || HasSuffix(args.ObjectName, SlashSeparator) {
+		return errInvalidArgument
+	}
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return errInvalidBucketName
+	}
+	if _, ok := readReplicaSource(args.BucketName); ok {
+		return BucketReadReplica{Bucket: args.BucketName}
+	}
+	return nil
+}
+
+// NewMultipartUploadRep - new multipart upload reply.
+type NewMultipartUploadRep struct {
+	UIVersion string `json:"uiVersion"`
+	UploadID  string `json:"uploadId"`
+}
+
+// NewMultipartUpload - starts a multipart upload, its parts are
+// uploaded with the Upload handler.
+func (web *webAPIHandlers) NewMultipartUpload(r *http.Request, args *MultipartUploadArgs, reply *NewMultipartUploadRep) error {
+	ctx := newWebContext(r, args, "WebNewMultipartUpload")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	if err := checkWebMultipartArgs(r, iampolicy.PutObjectAction, args); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	// Encrypted objects are uploaded in a single request, the browser
+	// falls back to the Upload handler.
+	if _, err := globalBucketSSEConfigSys.Get(args.BucketName); globalAutoEncryption || err == nil {
+		return toJSONError(ctx, errWebMultipartEncrypted)
+	}
+
+	header := http.Header{}
+	metadata := map[string]string{}
+	if args.ContentType != "" {
+		header.Set(xhttp.ContentType, args.ContentType)
+		metadata[strings.ToLower(xhttp.ContentType)] = args.ContentType
+	}
+	if objectAPI.IsCompressionSupported() && isCompressible(header, args.ObjectName) {
+		// Storing the compression metadata.
+		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+	}
+
+	opts, err := putOpts(ctx, r, args.BucketName, args.ObjectName, metadata)
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+	uploadID, err := objectAPI.NewMultipartUpload(ctx, args.BucketName, args.ObjectName, opts)
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	reply.UploadID = uploadID
+	return nil
+}
+
+// WebObjectPart - part of a multipart upload.
+type WebObjectPart struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// ListObjectPartsRep - list object parts reply.
+type ListObjectPartsRep struct {
+	UIVersion string          `json:"uiVersion"`
+	Parts     []WebObjectPart `json:"parts"`
+}
+
+// ListObjectParts - lists all the parts uploaded for a multipart
+// upload, used by the browser to resume an interrupted upload.
+func (web *webAPIHandlers) ListObjectParts(r *http.Request, args *MultipartUploadArgs, reply *ListObjectPartsRep) error {
+	ctx := newWebContext(r, args, "WebListObjectParts")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	if err := checkWebMultipartArgs(r, iampolicy.ListMultipartUploadPartsAction, args); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	reply.Parts = []WebObjectPart{}
+	partNumberMarker := 0
+	for {
+		lpi, err := objectAPI.ListObjectParts(ctx, args.BucketName, args.ObjectName, args.UploadID, partNumberMarker, globalAPIConfig.getMaxParts(), ObjectOptions{})
+		if err != nil {
+			return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+		}
+		for _, part := range lpi.Parts {
+			// Compressed parts report their decompressed size.
+			size := part.ActualSize
+			if size <= 0 {
+				size = part.Size
+			}
+			reply.Parts = append(reply.Parts, WebObjectPart{
+				PartNumber: part.PartNumber,
+				ETag:       part.ETag,
+				Size:       size,
+			})
+		}
+		if !lpi.IsTruncated {
+			break
+		}
+		partNumberMarker = lpi.NextPartNumberMarker
+	}
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
+// CompleteMultipartUploadArgs - complete multipart upload API args.
+type CompleteMultipartUploadArgs struct {
+	MultipartUploadArgs
+	Parts []WebObjectPart `json:"parts"`
+}
+
+// CompleteMultipartUpload - assembles the uploaded parts of a
+// multipart upload into the object.
+func (web *webAPIHandlers) CompleteMultipartUpload(r *http.Request, args *CompleteMultipartUploadArgs, reply *WebGenericRep) error {
+	ctx := newWebContext(r, args, "WebCompleteMultipartUpload")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	if err := checkWebMultipartArgs(r, iampolicy.PutObjectAction, &args.MultipartUploadArgs); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+	if len(args.Parts) == 0 {
+		return toJSONError(ctx, errInvalidArgument)
+	}
+	if err := checkServerModeOverwrite(ctx, args.BucketName, args.ObjectName, objectAPI.GetObjectInfo); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	parts := make([]CompletePart, 0, len(args.Parts))
+	for _, part := range args.Parts {
+		parts = append(parts, CompletePart{
+			PartNumber: part.PartNumber,
+			ETag:       canonicalizeETag(part.ETag),
+		})
+	}
+
+	opts := ObjectOptions{
+		Versioned: globalBucketVersioningSys.Enabled(args.BucketName),
+	}
+	objInfo, err := objectAPI.CompleteMultipartUpload(ctx, args.BucketName, args.ObjectName, args.UploadID, parts, opts)
+	if err == nil {
+		err = scanObject(ctx, objectAPI, args.BucketName, args.ObjectName, objInfo, r.Header)
+	}
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedCompleteMultipartUpload,
+		BucketName: args.BucketName,
+		Object:     objInfo,
+		ReqParams:  extractReqParams(r),
+		UserAgent:  r.UserAgent(),
+		Host:       handlers.GetSourceIP(r),
+	})
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
+// AbortMultipartUpload - aborts a multipart upload and removes
+// its uploaded parts.
+func (web *webAPIHandlers) AbortMultipartUpload(r *http.Request, args *MultipartUploadArgs, reply *WebGenericRep) error {
+	ctx := newWebContext(r, args, "WebAbortMultipartUpload")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	if err := checkWebMultipartArgs(r, iampolicy.AbortMultipartUploadAction, args); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	if err := objectAPI.AbortMultipartUpload(ctx, args.BucketName, args.ObjectName, args.UploadID); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
+// ObjectMetadataArgs - object metadata args.
+type ObjectMetadataArgs struct {
+	BucketName string `json:"bucketName"`
+	ObjectName string `json:"objectName"`
+}
+
+// ObjectMetadataRep - object metadata response, Metadata holds the
+// user metadata without its "X-Amz-Meta-" prefix.
+type ObjectMetadataRep struct {
+	UIVersion    string            `json:"uiVersion"`
+	ContentType  string            `json:"contentType"`
+	Size         int64             `json:"size"`
+	LastModified time.Time         `json:"lastModified"`
+	ETag         string            `json:"etag"`
+	Metadata     map[string]string `json:"metadata"`
+	Tags         map[string]string `json:"tags"`
+}
+
+// GetObjectMetadata - returns the user metadata and the tags of an
+// object, the tags only if the user is allowed to read them.
+func (web *webAPIHandlers) GetObjectMetadata(r *http.Request, args *ObjectMetadataArgs, reply *ObjectMetadataRep) error {
+	ctx := newWebContext(r, args, "WebGetObjectMetadata")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	if err := webObjectActionAllowed(r, iampolicy.GetObjectAction, args.BucketName, args.ObjectName); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName, args.BucketName)
+	}
+
+	oi, err := objectAPI.GetObjectInfo(ctx, args.BucketName, args.ObjectName, ObjectOptions{})
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	reply.ContentType = oi.ContentType
+	reply.LastModified = oi.ModTime
+	reply.ETag = oi.ETag
+	if reply.Size, err = oi.GetActualSize(); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+	reply.Metadata = make(map[string]string)
+	for k, v := range oi.UserDefined {
+		if HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			reply.Metadata[k[len("x-amz-meta-"):]] = v
+		}
+	}
+	reply.Tags = make(map[string]string)
+	if webObjectActionAllowed(r, iampolicy.GetObjectTaggingAction, args.BucketName, args.ObjectName) == nil && oi.UserTags != "" {
+		t, err := tags.ParseObjectTags(oi.UserTags)
+		if err != nil {
+			return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+		}
+		reply.Tags = t.ToMap()
+	}
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
+// SetObjectMetadataArgs - set object metadata args, the user
+// metadata and the tags of the object are replaced.
+type SetObjectMetadataArgs struct {
+	BucketName string            `json:"bucketName"`
+	ObjectName string            `json:"objectName"`
+	Metadata   map[string]string `json:"metadata"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// SetObjectMetadata - replaces the user metadata and the tags of an
+// object, the content of the object is not rewritten.
+func (web *webAPIHandlers) SetObjectMetadata(r *http.Request, args *SetObjectMetadataArgs, reply *WebGenericRep) error {
+	ctx := newWebContext(r, args, "WebSetObjectMetadata")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+	if err := webObjectActionAllowed(r, iampolicy.PutObjectAction, args.BucketName, args.ObjectName); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+	if err := webObjectActionAllowed(r, iampolicy.PutObjectTaggingAction, args.BucketName, args.ObjectName); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName, args.BucketName)
+	}
+	if _, ok := readReplicaSource(args.BucketName); ok {
+		return toJSONError(ctx, BucketReadReplica{Bucket: args.BucketName}, args.BucketName)
+	}
+
+	metadata := make(map[string]string, len(args.Metadata))
+	size := 0
+	for k, v := range args.Metadata {
+		key := "X-Amz-Meta-" + k
+		if k == "" || !httpguts.ValidHeaderFieldName(key) || !httpguts.ValidHeaderFieldValue(v) {
+			return toJSONError(ctx, errInvalidArgument, args.BucketName, args.ObjectName)
+		}
+		metadata[http.CanonicalHeaderKey(key)] = v
+		size += len(key) + len(v)
+	}
+	if size > maxUserDataSize {
+		return toJSONError(ctx, errMetadataTooLarge, args.BucketName, args.ObjectName)
+	}
+	t, err := tags.NewTags(args.Tags, true)
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	oi, err := objectAPI.GetObjectInfo(ctx, args.BucketName, args.ObjectName, ObjectOptions{})
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	srcInfo := metadataUpdate(objectAPI, oi)
+	for k := range srcInfo.UserDefined {
+		if HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			delete(srcInfo.UserDefined, k)
+		}
+	}
+	for k, v := range metadata {
+		srcInfo.UserDefined[k] = v
+	}
+	delete(srcInfo.UserDefined, xhttp.AmzObjectTagging)
+	if len(args.Tags) > 0 {
+		srcInfo.UserDefined[xhttp.AmzObjectTagging] = t.String()
+	}
+
+	opts := ObjectOptions{
+		VersionID: oi.VersionID,
+		CheckPrecondFn: func(cur ObjectInfo) bool {
+			return cur.ETag != oi.ETag || !cur.ModTime.Equal(oi.ModTime)
+		},
+	}
+	if err = copyObjectMetadata(ctx, objectAPI, oi, srcInfo, opts); err != nil {
+		return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
+
+// Maximum size of the text previews of the objects, only
+// the beginning of larger objects is shown.
+const maxTextPreviewSize = 64 * humanize.KiByte
+
+// isPreviewImage - returns true if the browser previews objects of
+// contentType as images.
+func isPreviewImage(contentType string) bool {
+	switch contentType {
+	case "image/png", "image/jpeg", "image/gif", "image/webp", "image/bmp":
+		return true
+	}
+	return false
+}
+
+// Download - file download handler, the object is shown inline
+// with the query "preview=true".
 func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "WebDownload")
 
@@ -1157,7 +1963,7 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bucket := vars["bucket"]
-	object, err := url.PathUnescape(vars["object"])
+	object, err := unescapeObjectName(vars["object"])
 	if err != nil {
 		writeWebErrorResponse(w, err)
 		return
@@ -1295,15 +2101,32 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Add content disposition.
-	w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"", path.Base(objInfo.Name)))
+	var reader io.Reader = gr
+	if r.URL.Query().Get("preview") == "true" {
+		// Previews are shown inline by the browser, only raster
+		// images keep their content type, everything else is
+		// shown as text, never rendered on the origin of the UI.
+		w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("inline; filename=\"%s\"", path.Base(objInfo.Name)))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Security-Policy", "sandbox")
+		if !isPreviewImage(objInfo.ContentType) {
+			w.Header().Set(xhttp.ContentType, "text/plain; charset=utf-8")
+			if size, err := objInfo.GetActualSize(); err == nil && size > maxTextPreviewSize {
+				w.Header().Set(xhttp.ContentLength, strconv.Itoa(maxTextPreviewSize))
+				reader = io.LimitReader(gr, maxTextPreviewSize)
+			}
+		}
+	} else {
+		// Add content disposition.
+		w.Header().Set(xhttp.ContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"", path.Base(objInfo.Name)))
 
-	setHeadGetRespHeaders(w, r.URL.Query())
+		setHeadGetRespHeaders(w, r.URL.Query())
+	}
 
 	httpWriter := ioutil.WriteOnClose(w)
 
 	// Write object content to response body
-	if _, err = io.Copy(httpWriter, gr); err != nil {
+	if _, err = io.Copy(httpWriter, reader); err != nil {
 		if !httpWriter.HasWritten() { // write error response only if no data or headers has been written to client yet
 			writeWebErrorResponse(w, err)
 		}
@@ -1336,9 +2159,68 @@ type DownloadZipArgs struct {
 	Objects    []string `json:"objects"`    // can be files or sub-directories
 	Prefix     string   `json:"prefix"`     // current directory in the browser-ui
 	BucketName string   `json:"bucketname"` // bucket name.
+	Format     string   `json:"format"`     // archive format, "zip" (default) or "tar".
+}
+
+// Archive formats of DownloadZip.
+const (
+	webArchiveZip = "zip"
+	webArchiveTar = "tar"
+)
+
+// webArchiveWriter - writes the objects downloaded
+// by DownloadZip as the files of an archive.
+type webArchiveWriter interface {
+	// Starts the file of an object, the object content
+	// must be written to the returned writer.
+	create(name string, info ObjectInfo) (io.Writer, error)
+	Close() error
+}
+
+type webZipWriter struct {
+	*zip.Writer
 }
 
-// Takes a list of objects and creates a zip file that sent as the response body.
+func (z webZipWriter) create(name string, info ObjectInfo) (io.Writer, error) {
+	header := &zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Flags:    1 << 11,
+		Modified: info.ModTime,
+	}
+	if hasStringSuffixInSlice(info.Name, standardExcludeCompressExtensions) || hasPattern(standardExcludeCompressContentTypes, info.ContentType) {
+		// We strictly disable compression for standard extensions/content-types.
+		header.Method = zip.Store
+	}
+	return z.CreateHeader(header)
+}
+
+type webTarWriter struct {
+	*tar.Writer
+}
+
+func (t webTarWriter) create(name string, info ObjectInfo) (io.Writer, error) {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     info.Size,
+		Mode:     0644,
+		ModTime:  info.ModTime,
+	}
+	if HasSuffix(name, SlashSeparator) {
+		// Directory objects are empty.
+		header.Typeflag = tar.TypeDir
+		header.Mode = 0755
+	}
+	if err := t.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	// Hide the Close method of the archive from the
+	// writer of the file, which closes its writer.
+	return struct{ io.Writer }{t.Writer}, nil
+}
+
+// Takes a list of objects and creates a zip, or a tar, file that sent as the response body.
 func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 	host := handlers.GetSourceIP(r)
 
@@ -1466,11 +2348,22 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 		getObjectNInfo = web.CacheAPI().GetObjectNInfo
 	}
 
-	archive := zip.NewWriter(w)
+	var archive webArchiveWriter
+	switch args.Format {
+	case "", webArchiveZip:
+		w.Header().Set(xhttp.ContentType, "application/zip")
+		archive = webZipWriter{zip.NewWriter(w)}
+	case webArchiveTar:
+		w.Header().Set(xhttp.ContentType, "application/x-tar")
+		archive = webTarWriter{tar.NewWriter(w)}
+	default:
+		writeWebErrorResponse(w, errInvalidArgument)
+		return
+	}
 	defer archive.Close()
 
 	for i, object := range args.Objects {
-		// Writes compressed object file to the response.
+		// Writes the object file of the archive to the response.
 		zipit := func(objectName string) error {
 			var opts ObjectOptions
 			gr, err := getObjectNInfo(ctx, args.BucketName, objectName, nil, r.Header, readLock, opts)
@@ -1487,17 +2380,7 @@ func (web *webAPIHandlers) DownloadZip(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return err
 			}
-			header := &zip.FileHeader{
-				Name:     strings.TrimPrefix(objectName, args.Prefix),
-				Method:   zip.Deflate,
-				Flags:    1 << 11,
-				Modified: info.ModTime,
-			}
-			if hasStringSuffixInSlice(info.Name, standardExcludeCompressExtensions) || hasPattern(standardExcludeCompressContentTypes, info.ContentType) {
-				// We strictly disable compression for standard extensions/content-types.
-				header.Method = zip.Store
-			}
-			writer, err := archive.CreateHeader(header)
+			writer, err := archive.create(strings.TrimPrefix(objectName, args.Prefix), info)
 			if err != nil {
 				writeWebErrorResponse(w, errUnexpected)
 				return err
@@ -1770,6 +2653,10 @@ func (web *webAPIHandlers) SetBucketPolicy(r *http.Request, args *SetBucketPolic
 		return toJSONError(ctx, errServerNotInitialized)
 	}
 
+	if err := checkServerMode(policy.PutBucketPolicyAction); err != nil {
+		return toJSONError(ctx, err)
+	}
+
 	claims, owner, authErr := webRequestAuthenticate(r)
 	if authErr != nil {
 		return toJSONError(ctx, authErr)
@@ -1905,6 +2792,10 @@ type PresignedGetArgs struct {
 
 	// Expiry in seconds.
 	Expiry int64 `json:"expiry"`
+
+	// Maximum number of downloads of the object through the
+	// presigned URL, unlimited if zero.
+	MaxDownloads int `json:"maxDownloads"`
 }
 
 // PresignedGetRep - presigned-get URL reply.
@@ -1932,7 +2823,7 @@ func (web *webAPIHandlers) PresignedGet(r *http.Request, args *PresignedGetArgs,
 		creds = globalActiveCred
 	}
 
-	region := globalServerRegion
+	region := getBucketRegion(args.BucketName)
 	if args.BucketName == "" || args.ObjectName == "" {
 		return &json2.Error{
 			Message: "Bucket and Object are mandatory arguments.",
@@ -1958,13 +2849,42 @@ func (web *webAPIHandlers) PresignedGet(r *http.Request, args *PresignedGetArgs,
 		return toJSONError(ctx, errPresignedNotAllowed)
 	}
 
+	if args.MaxDownloads < 0 {
+		return toJSONError(ctx, errInvalidArgument)
+	}
+
+	// The downloads are counted in the metadata of the object.
+	var shareID string
+	if args.MaxDownloads > 0 {
+		objectAPI := web.ObjectAPI()
+		if objectAPI == nil {
+			return toJSONError(ctx, errServerNotInitialized)
+		}
+		var err error
+		shareID, err = addShareLink(ctx, objectAPI, args.BucketName, args.ObjectName, args.MaxDownloads,
+			time.Duration(presignedGetExpiry(args.Expiry))*time.Second)
+		if err != nil {
+			return toJSONError(ctx, err, args.BucketName, args.ObjectName)
+		}
+	}
+
 	reply.UIVersion = browser.UIVersion
-	reply.URL = presignedGet(args.HostName, args.BucketName, args.ObjectName, args.Expiry, creds, region)
+	reply.URL = presignedGet(args.HostName, args.BucketName, args.ObjectName, args.Expiry, creds, region, shareID)
 	return nil
 }
 
-// Returns presigned url for GET method.
-func presignedGet(host, bucket, object string, expiry int64, creds auth.Credentials, region string) string {
+// presignedGetExpiry - returns the expiry in seconds of presigned
+// URLs, expiry if valid or 7 days by default.
+func presignedGetExpiry(expiry int64) int64 {
+	if expiry < 604800 && expiry > 0 {
+		return expiry
+	}
+	return 604800
+}
+
+// Returns presigned url for GET method, shareID is the ID of the share
+// link of the URL if its downloads are limited.
+func presignedGet(host, bucket, object string, expiry int64, creds auth.Credentials, region, shareID string) string {
 	accessKey := creds.AccessKey
 	secretKey := creds.SecretKey
 
@@ -1972,12 +2892,12 @@ func presignedGet(host, bucket, object string, expiry int64, creds auth.Credenti
 	dateStr := date.Format(iso8601Format)
 	credential := fmt.Sprintf("%s/%s", accessKey, getScope(date, region))
 
-	var expiryStr = "604800" // Default set to be expire in 7days.
-	if expiry < 604800 && expiry > 0 {
-		expiryStr = strconv.FormatInt(expiry, 10)
-	}
+	expiryStr := strconv.FormatInt(presignedGetExpiry(expiry), 10)
 
 	query := url.Values{}
+	if shareID != "" {
+		query.Set(shareLinkQueryKey, shareID)
+	}
 	query.Set(xhttp.AmzAlgorithm, signV4Algorithm)
 	query.Set(xhttp.AmzCredential, credential)
 	query.Set(xhttp.AmzDate, dateStr)
@@ -2141,7 +3061,7 @@ func toWebAPIError(ctx context.Context, err error) APIError {
 			HTTPStatusCode: http.StatusForbidden,
 			Description:    err.Error(),
 		}
-	case errSizeUnspecified:
+	case errSizeUnspecified, errTooManyShareLinks:
 		return APIError{
 			Code:           "InvalidRequest",
 			HTTPStatusCode: http.StatusBadRequest,
@@ -2173,6 +3093,18 @@ func toWebAPIError(ctx context.Context, err error) APIError {
 		return getAPIError(ErrObjectTampered)
 	case errMethodNotAllowed:
 		return getAPIError(ErrMethodNotAllowed)
+	case errServerReadOnly:
+		return getAPIError(ErrServerReadOnly)
+	case errServerWriteOnce:
+		return getAPIError(ErrServerWriteOnce)
+	case errMetadataTooLarge:
+		return getAPIError(ErrMetadataTooLarge)
+	case errWebMultipartEncrypted:
+		return APIError{
+			Code:           "NotImplemented",
+			HTTPStatusCode: http.StatusNotImplemented,
+			Description:    err.Error(),
+		}
 	}
 
 	// Convert error type to api error code.
@@ -2181,6 +3113,8 @@ func toWebAPIError(ctx context.Context, err error) APIError {
 		return getAPIError(ErrStorageFull)
 	case BucketNotFound:
 		return getAPIError(ErrNoSuchBucket)
+	case BucketReadReplica:
+		return getAPIError(ErrBucketReadReplica)
 	case BucketNotEmpty:
 		return getAPIError(ErrBucketNotEmpty)
 	case BucketExists:
@@ -2193,6 +3127,8 @@ func toWebAPIError(ctx context.Context, err error) APIError {
 		return getAPIError(ErrIncompleteBody)
 	case ObjectExistsAsDirectory:
 		return getAPIError(ErrObjectExistsAsDirectory)
+	case ObjectNameCaseConflict:
+		return getAPIError(ErrObjectNameCaseConflict)
 	case ObjectNotFound:
 		return getAPIError(ErrNoSuchKey)
 	case ObjectNameInvalid:
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"archive/tar"
 	"archive/zip"
 	"bytes"
 	"context"
@@ -32,6 +33,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	jwtgo "github.com/dgrijalva/jwt-go"
 	humanize "github.com/dustin/go-humanize"
@@ -812,6 +814,356 @@ func testUploadWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler
 
 }
 
+// Wrapper for calling the multipart upload handlers
+func TestWebHandlerMultipartUpload(t *testing.T) {
+	ExecObjectLayerTest(t, testMultipartUploadWebHandler)
+}
+
+// testMultipartUploadWebHandler - Test the multipart upload web handlers
+func testMultipartUploadWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with Erasure/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalActiveCred
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	objectName := "dir/test.file"
+	bucketName := getRandomBucketName()
+	err = obj.MakeBucketWithLocation(context.Background(), bucketName, BucketOptions{})
+	if err != nil {
+		// failed to create newbucket, abort.
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	call := func(method string, args, reply interface{}) error {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestWebRPCRequest("Web."+method, authorization, args)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+		return getTestWebRPCResponse(rec, reply)
+	}
+
+	uploadPart := func(uploadID string, partNumber int, content []byte) (int, string) {
+		rec := httptest.NewRecorder()
+		req, rErr := http.NewRequest("PUT", fmt.Sprintf("/minio/upload/%s/%s?uploadId=%s&partNumber=%d",
+			bucketName, objectName, uploadID, partNumber), bytes.NewReader(content))
+		if rErr != nil {
+			t.Fatalf("Cannot create upload request, %v", rErr)
+		}
+		req.Header.Set("Authorization", "Bearer "+authorization)
+		req.Header.Set("User-Agent", "Mozilla")
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code, strings.Join(rec.Header()["ETag"], "")
+	}
+
+	args := MultipartUploadArgs{BucketName: bucketName, ObjectName: objectName}
+	newReply := &NewMultipartUploadRep{}
+	if err = call("NewMultipartUpload", &args, newReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	args.UploadID = newReply.UploadID
+
+	contents := [][]byte{
+		bytes.Repeat([]byte("a"), 5*humanize.MiByte),
+		[]byte("last part"),
+	}
+	for i, content := range contents {
+		if code, etag := uploadPart(args.UploadID, i+1, content); code != http.StatusOK || etag == "" {
+			t.Fatalf("Part %d: unexpected response status `%d` and ETag %q", i+1, code, etag)
+		}
+	}
+
+	// Invalid part numbers are rejected.
+	if code, _ := uploadPart(args.UploadID, 0, contents[1]); code != http.StatusBadRequest {
+		t.Fatalf("Expected the response status to be 400, but instead found `%d`", code)
+	}
+
+	// Resuming lists the parts already uploaded.
+	listReply := &ListObjectPartsRep{}
+	if err = call("ListObjectParts", &args, listReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if len(listReply.Parts) != len(contents) {
+		t.Fatalf("Expected %d parts, found %d", len(contents), len(listReply.Parts))
+	}
+	for i, part := range listReply.Parts {
+		if part.PartNumber != i+1 || part.Size != int64(len(contents[i])) {
+			t.Fatalf("Part %d: unexpected part %v", i+1, part)
+		}
+	}
+
+	completeArgs := CompleteMultipartUploadArgs{MultipartUploadArgs: args, Parts: listReply.Parts}
+	if err = call("CompleteMultipartUpload", &completeArgs, &WebGenericRep{}); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+
+	var byteBuffer bytes.Buffer
+	err = obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &byteBuffer, "", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if !bytes.Equal(byteBuffer.Bytes(), bytes.Join(contents, nil)) {
+		t.Fatalf("The uploaded file is different from the download file")
+	}
+
+	// Aborted uploads cannot be resumed.
+	newReply = &NewMultipartUploadRep{}
+	if err = call("NewMultipartUpload", &args, newReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	args.UploadID = newReply.UploadID
+	if err = call("AbortMultipartUpload", &args, &WebGenericRep{}); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if err = call("ListObjectParts", &args, &ListObjectPartsRep{}); err == nil {
+		t.Fatalf("Expected listing the parts of an aborted upload to fail")
+	}
+
+	// Objects names ending with a slash are rejected.
+	if err = call("NewMultipartUpload", &MultipartUploadArgs{BucketName: bucketName, ObjectName: "dir/"}, &NewMultipartUploadRep{}); err == nil {
+		t.Fatalf("Expected a directory upload to fail")
+	}
+}
+
+// Wrapper for calling the object browser web handlers
+func TestWebHandlerObjectBrowser(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectBrowserWebHandler)
+}
+
+// testObjectBrowserWebHandler - Test the search, preview and
+// metadata web handlers
+func testObjectBrowserWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with Erasure/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalActiveCred
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	err = obj.MakeBucketWithLocation(context.Background(), bucketName, BucketOptions{})
+	if err != nil {
+		// failed to create newbucket, abort.
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	objects := map[string][]byte{
+		"photos/2020/Beach.png": []byte("png"),
+		"photos/beach.html":     []byte("<script>alert(1)</script>"),
+		"photos/city.jpg":       []byte("jpg"),
+		"beach/large.txt":       bytes.Repeat([]byte("a"), maxTextPreviewSize+1),
+	}
+	for objectName, content := range objects {
+		metadata := map[string]string{"X-Amz-Meta-Color": "blue"}
+		if HasSuffix(objectName, ".png") {
+			metadata["content-type"] = "image/png"
+		}
+		_, err = obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(content), int64(len(content)), "", ""), ObjectOptions{UserDefined: metadata})
+		if err != nil {
+			t.Fatalf("Was not able to upload an object, %v", err)
+		}
+	}
+
+	call := func(method string, args, reply interface{}) error {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestWebRPCRequest("Web."+method, authorization, args)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+		return getTestWebRPCResponse(rec, reply)
+	}
+
+	// Objects under the prefix are searched recursively, case is ignored.
+	searchReply := &SearchObjectsRep{}
+	if err = call("SearchObjects", &SearchObjectsArgs{BucketName: bucketName, Prefix: "photos/", Query: "BEACH"}, searchReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	var found []string
+	for _, o := range searchReply.Objects {
+		found = append(found, o.Key)
+	}
+	if expected := []string{"photos/2020/Beach.png", "photos/beach.html"}; !reflect.DeepEqual(found, expected) || searchReply.IsTruncated {
+		t.Fatalf("Expected %v to be found, found %v", expected, found)
+	}
+
+	preview := func(objectName string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, rErr := http.NewRequest("GET", "/minio/download/"+bucketName+SlashSeparator+objectName+"?preview=true&token="+authorization, nil)
+		if rErr != nil {
+			t.Fatalf("Cannot create download request, %v", rErr)
+		}
+		req.Header.Set("User-Agent", "Mozilla")
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+		return rec
+	}
+
+	// Images are previewed as is, anything else as text.
+	if rec := preview("photos/2020/Beach.png"); rec.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("Unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	rec := preview("photos/beach.html")
+	if rec.Header().Get("Content-Type") != "text/plain; charset=utf-8" || !HasPrefix(rec.Header().Get("Content-Disposition"), "inline") {
+		t.Fatalf("Unexpected headers %v", rec.Header())
+	}
+	if !bytes.Equal(rec.Body.Bytes(), objects["photos/beach.html"]) {
+		t.Fatalf("Unexpected preview %q", rec.Body.String())
+	}
+	if rec = preview("beach/large.txt"); rec.Body.Len() != maxTextPreviewSize {
+		t.Fatalf("Expected the preview to be truncated to %d bytes, found %d", maxTextPreviewSize, rec.Body.Len())
+	}
+
+	objectName := "photos/city.jpg"
+	metaReply := &ObjectMetadataRep{}
+	if err = call("GetObjectMetadata", &ObjectMetadataArgs{BucketName: bucketName, ObjectName: objectName}, metaReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if metaReply.Size != 3 || metaReply.Metadata["Color"] != "blue" || len(metaReply.Tags) != 0 {
+		t.Fatalf("Unexpected metadata %v", metaReply)
+	}
+
+	setArgs := &SetObjectMetadataArgs{
+		BucketName: bucketName,
+		ObjectName: objectName,
+		Metadata:   map[string]string{"Place": "Lisbon"},
+		Tags:       map[string]string{"album": "2020"},
+	}
+	if err = call("SetObjectMetadata", setArgs, &WebGenericRep{}); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	metaReply = &ObjectMetadataRep{}
+	if err = call("GetObjectMetadata", &ObjectMetadataArgs{BucketName: bucketName, ObjectName: objectName}, metaReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if !reflect.DeepEqual(metaReply.Metadata, setArgs.Metadata) || !reflect.DeepEqual(metaReply.Tags, setArgs.Tags) {
+		t.Fatalf("Unexpected metadata %v", metaReply)
+	}
+
+	// The content of the object is unchanged.
+	var byteBuffer bytes.Buffer
+	err = obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &byteBuffer, "", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if !bytes.Equal(byteBuffer.Bytes(), objects[objectName]) {
+		t.Fatalf("The content of the object changed")
+	}
+
+	// Invalid metadata keys and too large metadata are rejected.
+	setArgs.Metadata = map[string]string{"in valid": "x"}
+	if err = call("SetObjectMetadata", setArgs, &WebGenericRep{}); err == nil {
+		t.Fatalf("Expected invalid metadata to be rejected")
+	}
+	setArgs.Metadata = map[string]string{"large": strings.Repeat("a", maxUserDataSize)}
+	if err = call("SetObjectMetadata", setArgs, &WebGenericRep{}); err == nil {
+		t.Fatalf("Expected too large metadata to be rejected")
+	}
+}
+
+// Wrapper for calling the usage web handlers
+func TestWebHandlerUsage(t *testing.T) {
+	ExecObjectLayerTest(t, testUsageWebHandler)
+}
+
+// testUsageWebHandler - Test the UsageInfo and UsageHistory web handlers
+func testUsageWebHandler(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with Erasure/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalActiveCred
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	call := func(method string, args, reply interface{}) error {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestWebRPCRequest("Web."+method, authorization, args)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", rErr)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+		return getTestWebRPCResponse(rec, reply)
+	}
+
+	// Usage updates closer than the history interval are not sampled.
+	start := UTCNow().Truncate(time.Second)
+	updates := make(chan DataUsageInfo, 3)
+	for i, d := range []time.Duration{0, time.Hour, dataUsageHistoryInterval} {
+		updates <- DataUsageInfo{
+			LastUpdate:        start.Add(d),
+			ObjectsTotalCount: uint64(3 + i),
+			ObjectsTotalSize:  uint64(300 + i),
+			BucketsUsage: map[string]BucketUsageInfo{
+				"bucket-b": {Size: 100, ObjectsCount: 1},
+				"bucket-a": {Size: uint64(200 + i), ObjectsCount: uint64(2 + i)},
+			},
+		}
+	}
+	close(updates)
+	storeDataUsageInBackend(context.Background(), obj, updates)
+
+	infoReply := &UsageInfoRep{}
+	if err = call("UsageInfo", &WebGenericArgs{}, infoReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	expectedBuckets := []WebBucketUsage{
+		{Name: "bucket-a", Size: 202, ObjectsCount: 4},
+		{Name: "bucket-b", Size: 100, ObjectsCount: 1},
+	}
+	if !reflect.DeepEqual(infoReply.Buckets, expectedBuckets) || infoReply.ObjectsTotalSize != 302 {
+		t.Fatalf("Unexpected usage %v", infoReply)
+	}
+	if len(infoReply.Disks) == 0 {
+		t.Fatalf("Expected the disks to be returned")
+	}
+
+	historyReply := &UsageHistoryRep{}
+	if err = call("UsageHistory", &UsageHistoryArgs{BucketName: "bucket-a"}, historyReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	expectedSamples := []WebUsageSample{
+		{Time: start, Size: 200, ObjectsCount: 2},
+		{Time: start.Add(dataUsageHistoryInterval), Size: 202, ObjectsCount: 4},
+	}
+	if len(historyReply.Samples) != len(expectedSamples) {
+		t.Fatalf("Expected %d samples, found %v", len(expectedSamples), historyReply.Samples)
+	}
+	for i, sample := range historyReply.Samples {
+		if !sample.Time.Equal(expectedSamples[i].Time) || sample.Size != expectedSamples[i].Size ||
+			sample.ObjectsCount != expectedSamples[i].ObjectsCount {
+			t.Fatalf("Expected sample %v, found %v", expectedSamples[i], sample)
+		}
+	}
+
+	historyReply = &UsageHistoryRep{}
+	if err = call("UsageHistory", &UsageHistoryArgs{}, historyReply); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if len(historyReply.Samples) != 2 || historyReply.Samples[1].Size != 302 {
+		t.Fatalf("Unexpected total usage history %v", historyReply.Samples)
+	}
+}
+
 // Wrapper for calling Download Handler
 func TestWebHandlerDownload(t *testing.T) {
 	ExecObjectLayerTest(t, testDownloadWebHandler)
@@ -939,7 +1291,7 @@ func testWebHandlerDownloadZip(obj ObjectLayer, instanceType string, t TestErrHa
 	obj.PutObject(context.Background(), bucket, "a/b/two", mustGetPutObjReader(t, strings.NewReader(fileTwo), int64(len(fileTwo)), "", ""), opts)
 	obj.PutObject(context.Background(), bucket, "a/c/three", mustGetPutObjReader(t, strings.NewReader(fileThree), int64(len(fileThree)), "", ""), opts)
 
-	test := func(token string) (int, []byte) {
+	test := func(token, format string) (int, []byte) {
 		rec := httptest.NewRecorder()
 		path := "/minio/zip" + "?token="
 		if token != "" {
@@ -949,6 +1301,7 @@ func testWebHandlerDownloadZip(obj ObjectLayer, instanceType string, t TestErrHa
 			Objects:    []string{"one", "b/", "c/"},
 			Prefix:     "a/",
 			BucketName: bucket,
+			Format:     format,
 		}
 
 		var argsData []byte
@@ -968,11 +1321,11 @@ func testWebHandlerDownloadZip(obj ObjectLayer, instanceType string, t TestErrHa
 		apiRouter.ServeHTTP(rec, req)
 		return rec.Code, rec.Body.Bytes()
 	}
-	code, _ := test("")
+	code, _ := test("", "")
 	if code != 403 {
 		t.Fatal("Expected to receive authentication error")
 	}
-	code, data := test(authorization)
+	code, data := test(authorization, "")
 	if code != 200 {
 		t.Fatal("web.DownloadsZip() failed")
 	}
@@ -992,6 +1345,36 @@ func testWebHandlerDownloadZip(obj ObjectLayer, instanceType string, t TestErrHa
 	if hex.EncodeToString(h.Sum(nil)) != "ac7196449b14bea42775d29e8bb29f50" {
 		t.Fatal("Incorrect zip contents")
 	}
+
+	code, data = test(authorization, "tar")
+	if code != 200 {
+		t.Fatal("web.DownloadsZip() failed")
+	}
+	tarReader := tar.NewReader(bytes.NewReader(data))
+	h = md5.New()
+	var names []string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, header.Name)
+		io.Copy(h, tarReader)
+	}
+	if !reflect.DeepEqual(names, []string{"one", "b/two", "c/three"}) {
+		t.Fatalf("Incorrect tar files %v", names)
+	}
+	if hex.EncodeToString(h.Sum(nil)) != "ac7196449b14bea42775d29e8bb29f50" {
+		t.Fatal("Incorrect tar contents")
+	}
+
+	code, _ = test(authorization, "rar")
+	if code != http.StatusBadRequest {
+		t.Fatalf("Expected the response status to be 400, but instead found `%d`", code)
+	}
 }
 
 // Wrapper for calling PresignedGet handler
@@ -1098,6 +1481,114 @@ func testWebPresignedGetHandler(obj ObjectLayer, instanceType string, t TestErrH
 	}
 }
 
+// Wrapper for calling PresignedGet handler with limited downloads
+func TestWebHandlerPresignedGetMaxDownloads(t *testing.T) {
+	ExecObjectLayerTest(t, testWebPresignedGetMaxDownloads)
+}
+
+func testWebPresignedGetMaxDownloads(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with Erasure/FS object layer.
+	webRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalActiveCred
+
+	authorization, err := getWebRPCToken(webRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	objectName := "object"
+	err = obj.MakeBucketWithLocation(context.Background(), bucketName, BucketOptions{})
+	if err != nil {
+		// failed to create newbucket, abort.
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	data := bytes.Repeat([]byte("a"), humanize.KiByte)
+	_, err = obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Was not able to upload an object, %v", err)
+	}
+
+	presign := func(maxDownloads int) string {
+		rec := httptest.NewRecorder()
+		presignGetReq := PresignedGetArgs{
+			BucketName:   bucketName,
+			ObjectName:   objectName,
+			Expiry:       1000,
+			MaxDownloads: maxDownloads,
+		}
+		req, rErr := newTestWebRPCRequest("Web.PresignedGet", authorization, presignGetReq)
+		if rErr != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", rErr)
+		}
+		webRouter.ServeHTTP(rec, req)
+		presignGetRep := &PresignedGetRep{}
+		if err = getTestWebRPCResponse(rec, &presignGetRep); err != nil {
+			t.Fatalf("Failed, %v", err)
+		}
+		return presignGetRep.URL
+	}
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"GetObject"})
+	download := func(url, rangeHeader string) int {
+		rec := httptest.NewRecorder()
+		req, rErr := newTestRequest("GET", url, 0, nil)
+		if rErr != nil {
+			t.Fatal("Failed to initialized a new request", rErr)
+		}
+		req.Header.Del("x-amz-content-sha256")
+		if rangeHeader != "" {
+			req.Header.Set("Range", rangeHeader)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	url := presign(2)
+	if !strings.Contains(url, shareLinkQueryKey+"=") {
+		t.Fatalf("Expected the share link in the presigned URL %s", url)
+	}
+	testCases := []struct {
+		rangeHeader string
+		code        int
+	}{
+		{"", http.StatusOK},
+		// Ranged requests are counted too.
+		{"bytes=10-", http.StatusPartialContent},
+		{"bytes=0-9", http.StatusForbidden},
+		{"", http.StatusForbidden},
+	}
+	for i, tc := range testCases {
+		if code := download(url, tc.rangeHeader); code != tc.code {
+			t.Fatalf("Test %d: expected the response status to be %d, but instead found `%d`", i+1, tc.code, code)
+		}
+	}
+
+	// Links are limited separately, unlimited links are not counted.
+	if code := download(presign(1), ""); code != http.StatusOK {
+		t.Fatalf("Expected the response status to be 200, but instead found `%d`", code)
+	}
+	url = presign(0)
+	if strings.Contains(url, shareLinkQueryKey+"=") {
+		t.Fatalf("Unexpected share link in the presigned URL %s", url)
+	}
+	for i := 0; i < 3; i++ {
+		if code := download(url, ""); code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", code)
+		}
+	}
+
+	// Overwriting the object invalidates its share links.
+	url = presign(5)
+	_, err = obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Was not able to upload an object, %v", err)
+	}
+	if code := download(url, ""); code != http.StatusForbidden {
+		t.Fatalf("Expected the response status to be 403, but instead found `%d`", code)
+	}
+}
+
 // TestWebCheckAuthorization - Test Authorization for all web handlers
 func TestWebCheckAuthorization(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -1129,7 +1620,10 @@ func TestWebCheckAuthorization(t *testing.T) {
 		"ListBuckets", "ListObjects", "RemoveObject",
 		"GenerateAuth", "SetAuth",
 		"GetBucketPolicy", "SetBucketPolicy", "ListAllBucketPolicies",
-		"PresignedGet",
+		"PresignedGet", "NewMultipartUpload", "ListObjectParts",
+		"CompleteMultipartUpload", "AbortMultipartUpload",
+		"SearchObjects", "GetObjectMetadata", "SetObjectMetadata",
+		"UsageInfo", "UsageHistory",
 	}
 	for _, rpcCall := range webRPCs {
 		reply := &WebGenericRep{}
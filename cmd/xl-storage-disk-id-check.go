@@ -233,6 +233,14 @@ func (p *xlStorageDiskIDCheck) DeleteFile(volume string, path string) (err error
 	return p.storage.DeleteFile(volume, path)
 }
 
+func (p *xlStorageDiskIDCheck) SecureErase(volume, path string, fi FileInfo) (err error) {
+	if err = p.checkDiskStale(); err != nil {
+		return err
+	}
+
+	return p.storage.SecureErase(volume, path, fi)
+}
+
 func (p *xlStorageDiskIDCheck) DeleteVersions(volume string, versions []FileInfo) (errs []error) {
 	if err := p.checkDiskStale(); err != nil {
 		errs = make([]error, len(versions))
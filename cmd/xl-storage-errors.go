@@ -120,6 +120,21 @@ func isSysErrHandleInvalid(err error) bool {
 	return false
 }
 
+// Check if the given error corresponds to ERROR_SHARING_VIOLATION
+// or ERROR_LOCK_VIOLATION for windows, returned while another process
+// such as an anti-virus or the indexing service briefly holds the
+// file open.
+func isSysErrSharingViolation(err error) bool {
+	if runtime.GOOS != globalWindowsOSName {
+		return false
+	}
+	var errno syscall.Errno
+	if errors.As(err, &errno) {
+		return errno == 0x20 || errno == 0x21
+	}
+	return false
+}
+
 func isSysErrCrossDevice(err error) bool {
 	return errors.Is(err, syscall.EXDEV)
 }
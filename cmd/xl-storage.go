@@ -37,12 +37,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/cespare/xxhash/v2"
 	humanize "github.com/dustin/go-humanize"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/klauspost/readahead"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/disk"
 	xioutil "github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/mountinfo"
 )
 
@@ -103,6 +105,24 @@ type xlStorage struct {
 
 	ctx context.Context
 	sync.RWMutex
+
+	// Serializes the read-modify-write updates of `xl.meta` in RenameData.
+	metaLocks [xlStorageMetaLocks]sync.Mutex
+
+	// The disk info checked by AppendFile, which is called for
+	// every block written.
+	appendDiskInfoCache timedValue
+}
+
+// Number of locks serializing the `xl.meta` updates of a disk.
+const xlStorageMetaLocks = 64
+
+// lockMeta - locks the `xl.meta` of an object on the disk, returns the
+// function unlocking it.
+func (s *xlStorage) lockMeta(volume, path string) func() {
+	mu := &s.metaLocks[xxhash.Sum64String(pathJoin(volume, path))%xlStorageMetaLocks]
+	mu.Lock()
+	return mu.Unlock
 }
 
 // checkPathLength - returns error if given path name length more than 255
@@ -302,6 +322,11 @@ func checkDiskMinFree(di disk.Info) error {
 		return errDiskFull
 	}
 
+	// Keep the configured reserve free.
+	if globalDiskReserve.isFull(di.Total, di.Free) {
+		return errDiskFull
+	}
+
 	// Success.
 	return nil
 }
@@ -322,7 +347,13 @@ func checkDiskFree(diskPath string, neededSpace int64) (err error) {
 		return err
 	}
 
-	if err = checkDiskMinFree(di); err != nil {
+	return checkDiskInfoFree(di, neededSpace)
+}
+
+// checkDiskInfoFree - checks if the disk of di has enough space for
+// neededSpace.
+func checkDiskInfoFree(di disk.Info, neededSpace int64) error {
+	if err := checkDiskMinFree(di); err != nil {
 		return err
 	}
 
@@ -331,6 +362,11 @@ func checkDiskFree(diskPath string, neededSpace int64) (err error) {
 		return errDiskFull
 	}
 
+	// Check if the data fits without using the configured reserve.
+	if neededSpace > 0 && uint64(neededSpace) > globalDiskReserve.available(di.Total, di.Free) {
+		return errDiskFull
+	}
+
 	return nil
 }
 
@@ -435,6 +471,7 @@ type DiskInfo struct {
 	Endpoint  string
 	MountPath string
 	ID        string
+	Health    *madmin.DriveHealth
 	Error     string // carries the error over the network
 }
 
@@ -818,7 +855,7 @@ func (s *xlStorage) WalkSplunk(volume, dirPath, marker string, endWalkCh <-chan
 				return true, nil
 			}
 			sort.Strings(entries)
-			return false, filterMatchingPrefix(entries, dirEntry)
+			return false, filterMatchingPrefix(dirObjectEntries(dirPath, entries), dirEntry)
 		}
 
 		walkResultCh := startTreeWalk(GlobalContext, volume, dirPath, marker, true, listDir, endWalkCh)
@@ -829,10 +866,9 @@ func (s *xlStorage) WalkSplunk(volume, dirPath, marker string, endWalkCh <-chan
 			}
 			var fi FileInfo
 			if HasSuffix(walkResult.entry, SlashSeparator) {
-				fi = FileInfo{
-					Volume: volume,
-					Name:   walkResult.entry,
-					Mode:   os.ModeDir,
+				var ok bool
+				if fi, ok = walkDirFileInfo(volumeDir, volume, walkResult.entry, true); !ok {
+					continue
 				}
 			} else {
 				var err error
@@ -861,6 +897,52 @@ func (s *xlStorage) WalkSplunk(volume, dirPath, marker string, endWalkCh <-chan
 	return ch, nil
 }
 
+// walkDirFileInfo - returns the file info of a directory listed by a walk,
+// read from its directory object if dirObject is set and the directory
+// has one. Returns false if the directory object is a delete marker.
+func walkDirFileInfo(volumeDir, volume, entry string, dirObject bool) (FileInfo, bool) {
+	if dirObject {
+		xlMetaBuf, err := ioutil.ReadFile(pathJoin(volumeDir, encodeDirObject(entry), xlStorageFormatFile))
+		if err == nil {
+			fi, err := getFileInfo(xlMetaBuf, volume, entry, "")
+			if err != nil || fi.Deleted {
+				return fi, false
+			}
+			return fi, true
+		}
+	}
+	return FileInfo{
+		Volume: volume,
+		Name:   entry,
+		Mode:   os.ModeDir,
+	}, true
+}
+
+// walkDirFileInfoVersions - returns the versions of a directory listed by
+// a walk, read from its directory object if dirObject is set and the
+// directory has one.
+func walkDirFileInfoVersions(volumeDir, volume, entry string, dirObject bool) FileInfoVersions {
+	if dirObject {
+		xlMetaBuf, err := ioutil.ReadFile(pathJoin(volumeDir, encodeDirObject(entry), xlStorageFormatFile))
+		if err == nil {
+			if fiv, err := getFileInfoVersions(xlMetaBuf, volume, entry); err == nil {
+				return fiv
+			}
+		}
+	}
+	return FileInfoVersions{
+		Volume: volume,
+		Name:   entry,
+		Versions: []FileInfo{
+			{
+				Volume: volume,
+				Name:   entry,
+				Mode:   os.ModeDir,
+			},
+		},
+	}
+}
+
 // WalkVersions - is a sorted walker which returns file entries in lexically sorted order,
 // additionally along with metadata version info about each of those entries.
 func (s *xlStorage) WalkVersions(volume, dirPath, marker string, recursive bool, endWalkCh <-chan struct{}) (ch chan FileInfoVersions, err error) {
@@ -886,8 +968,8 @@ func (s *xlStorage) WalkVersions(volume, dirPath, marker string, recursive bool,
 		return nil, err
 	}
 
-	// buffer channel matches the S3 ListObjects implementation
-	ch = make(chan FileInfoVersions, maxObjectList)
+	// buffer channel matches the tree walker implementation
+	ch = make(chan FileInfoVersions, treeWalkBufferSize)
 	go func() {
 		defer close(ch)
 		listDir := func(volume, dirPath, dirEntry string) (emptyDir bool, entries []string) {
@@ -899,22 +981,16 @@ func (s *xlStorage) WalkVersions(volume, dirPath, marker string, recursive bool,
 				return true, nil
 			}
 			sort.Strings(entries)
-			return false, filterMatchingPrefix(entries, dirEntry)
+			return false, filterMatchingPrefix(dirObjectEntries(dirPath, entries), dirEntry)
 		}
 
 		walkResultCh := startTreeWalk(GlobalContext, volume, dirPath, marker, recursive, listDir, endWalkCh)
 		for walkResult := range walkResultCh {
 			var fiv FileInfoVersions
 			if HasSuffix(walkResult.entry, SlashSeparator) {
-				fiv = FileInfoVersions{
-					Versions: []FileInfo{
-						{
-							Volume: volume,
-							Name:   walkResult.entry,
-							Mode:   os.ModeDir,
-						},
-					},
-				}
+				// Sub-directories of non recursive listings are prefixes.
+				dirObject := recursive || walkResult.entry == dirPath
+				fiv = walkDirFileInfoVersions(volumeDir, volume, walkResult.entry, dirObject)
 			} else {
 				xlMetaBuf, err := ioutil.ReadFile(pathJoin(volumeDir, walkResult.entry, xlStorageFormatFile))
 				if err != nil {
@@ -962,8 +1038,8 @@ func (s *xlStorage) Walk(volume, dirPath, marker string, recursive bool, endWalk
 		return nil, err
 	}
 
-	// buffer channel matches the S3 ListObjects implementation
-	ch = make(chan FileInfo, maxObjectList)
+	// buffer channel matches the tree walker implementation
+	ch = make(chan FileInfo, treeWalkBufferSize)
 	go func() {
 		defer close(ch)
 		listDir := func(volume, dirPath, dirEntry string) (emptyDir bool, entries []string) {
@@ -975,17 +1051,18 @@ func (s *xlStorage) Walk(volume, dirPath, marker string, recursive bool, endWalk
 				return true, nil
 			}
 			sort.Strings(entries)
-			return false, filterMatchingPrefix(entries, dirEntry)
+			return false, filterMatchingPrefix(dirObjectEntries(dirPath, entries), dirEntry)
 		}
 
 		walkResultCh := startTreeWalk(GlobalContext, volume, dirPath, marker, recursive, listDir, endWalkCh)
 		for walkResult := range walkResultCh {
 			var fi FileInfo
 			if HasSuffix(walkResult.entry, SlashSeparator) {
-				fi = FileInfo{
-					Volume: volume,
-					Name:   walkResult.entry,
-					Mode:   os.ModeDir,
+				var ok bool
+				// Sub-directories of non recursive listings are prefixes.
+				dirObject := recursive || walkResult.entry == dirPath
+				if fi, ok = walkDirFileInfo(volumeDir, volume, walkResult.entry, dirObject); !ok {
+					continue
 				}
 			} else {
 				var err error
@@ -1103,6 +1180,12 @@ func (s *xlStorage) DeleteVersion(volume, path string, fi FileInfo) error {
 	}
 
 	if !isXL2V1Format(buf) {
+		if fi.SecureErase {
+			// The parts are stored along with the meta file.
+			if err = s.eraseData(volume, path, fi.VersionID, pathJoin(volumeDir, path)); err != nil {
+				return err
+			}
+		}
 		// Delete the meta file, if there are no more versions the
 		// top level parent is automatically removed.
 		return deleteFile(volumeDir, pathJoin(volumeDir, path), true)
@@ -1141,6 +1224,12 @@ func (s *xlStorage) DeleteVersion(volume, path string, fi FileInfo) error {
 			return err
 		}
 
+		if fi.SecureErase {
+			if err = s.eraseData(volume, path, fi.VersionID, filePath); err != nil {
+				return err
+			}
+		}
+
 		if err = removeAll(filePath); err != nil {
 			return err
 		}
@@ -1698,6 +1787,9 @@ func (s *xlStorage) CreateFile(volume, path string, fileSize int64, r io.Reader)
 
 	written, err := xioutil.CopyAligned(w, r, *bufp, fileSize)
 	if err != nil {
+		if isSysErrNoSpace(err) {
+			return errDiskFull
+		}
 		return err
 	}
 
@@ -1738,6 +1830,14 @@ func (s *xlStorage) AppendFile(volume, path string, buf []byte) (err error) {
 		atomic.AddInt32(&s.activeIOCount, -1)
 	}()
 
+	// Validate if disk is indeed free.
+	if err = s.checkAppendDiskFree(int64(len(buf))); err != nil {
+		if isSysErrIO(err) {
+			return errFaultyDisk
+		}
+		return err
+	}
+
 	var w *os.File
 	// Create file if not found. Not doing O_DIRECT here to avoid the code that does buffer aligned writes.
 	// AppendFile() is only used by healing code to heal objects written in old format.
@@ -1747,12 +1847,38 @@ func (s *xlStorage) AppendFile(volume, path string, buf []byte) (err error) {
 	}
 
 	if _, err = w.Write(buf); err != nil {
+		w.Close()
+		if isSysErrNoSpace(err) {
+			return errDiskFull
+		}
 		return err
 	}
 
 	return w.Close()
 }
 
+// checkAppendDiskFree - checks if the disk has enough space for
+// neededSpace from the disk info of the last second, instead of
+// reading it on every append.
+func (s *xlStorage) checkAppendDiskFree(neededSpace int64) error {
+	if contains(ignoreDiskFreeOS, runtime.GOOS) {
+		return nil
+	}
+
+	s.appendDiskInfoCache.Once.Do(func() {
+		s.appendDiskInfoCache.TTL = time.Second
+		s.appendDiskInfoCache.Update = func() (interface{}, error) {
+			return getDiskInfo(s.diskPath)
+		}
+	})
+
+	v, err := s.appendDiskInfoCache.Get()
+	if err != nil {
+		return err
+	}
+	return checkDiskInfoFree(v.(disk.Info), neededSpace)
+}
+
 // CheckParts check if path has necessary parts available.
 func (s *xlStorage) CheckParts(volume, path string, fi FileInfo) error {
 	atomic.AddInt32(&s.activeIOCount, 1)
@@ -2038,6 +2164,8 @@ func (s *xlStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath s
 		return err
 	}
 
+	defer s.lockMeta(dstVolume, dstPath)()
+
 	dstBuf, err := ioutil.ReadFile(dstFilePath)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -2100,6 +2228,20 @@ func (s *xlStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath s
 		}
 	}
 
+	// Metadata updates only apply to the generation of the version
+	// they were read from.
+	if expected, ok := fi.Metadata[metaExpectedGenerationKey]; ok {
+		delete(fi.Metadata, metaExpectedGenerationKey)
+		versionID := fi.VersionID
+		if versionID == "" {
+			versionID = nullVersionID
+		}
+		ofi, err := xlMeta.ToFileInfo(dstVolume, dstPath, versionID)
+		if err != nil || ofi.Deleted || ofi.Metadata[metaGenerationKey] != expected {
+			return errMetadataConflict
+		}
+	}
+
 	if legacyPreserved {
 		// Preserve all the legacy data, could be slow, but at max there can be 10,000 parts.
 		currentDataPath := pathJoin(dstVolumeDir, dstPath)
@@ -2137,6 +2279,8 @@ func (s *xlStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath s
 	}
 
 	var oldDstDataPath string
+	var eraseOldDstData bool
+	var oldVersionID string
 	if fi.VersionID == "" {
 		// return the latest "null" versionId info
 		ofi, err := xlMeta.ToFileInfo(dstVolume, dstPath, nullVersionID)
@@ -2144,6 +2288,8 @@ func (s *xlStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath s
 			// Purge the destination path as we are not preserving anything
 			// versioned object was not requested.
 			oldDstDataPath = pathJoin(dstVolumeDir, dstPath, ofi.DataDir)
+			eraseOldDstData = ofi.DataDir != "" && isSecureEraseEnabled(dstVolume)
+			oldVersionID = ofi.VersionID
 		}
 	}
 
@@ -2168,6 +2314,11 @@ func (s *xlStorage) RenameData(srcVolume, srcPath, dataDir, dstVolume, dstPath s
 	}
 
 	if srcDataPath != "" {
+		if eraseOldDstData {
+			// The data of the overwritten version is erased before
+			// its removal, the new version is already in place.
+			logger.LogIf(s.ctx, s.eraseData(dstVolume, dstPath, oldVersionID, oldDstDataPath))
+		}
 		removeAll(oldDstDataPath)
 		removeAll(dstDataPath)
 		if err = renameAll(srcDataPath, dstDataPath); err != nil {
@@ -23,7 +23,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 )
 
 // Test if various paths work as expected when converted to UNC form
@@ -106,3 +110,96 @@ func TestUNCPathENOTDIR(t *testing.T) {
 		t.Errorf("expected: %s, got: %s", errFileAccessDenied, err)
 	}
 }
+
+// Test that a rename is retried while the source is held open
+// without FILE_SHARE_DELETE, as done by anti-virus scanners.
+func TestRenameAllSharingViolation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdisk-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	dst := filepath.Join(dir, "dst")
+	for _, p := range []string{src, dst} {
+		if err = ioutil.WriteFile(p, []byte(p), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pathp, err := syscall.UTF16PtrFromString(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := syscall.CreateFile(pathp, syscall.GENERIC_READ, syscall.FILE_SHARE_READ, nil,
+		syscall.OPEN_EXISTING, syscall.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		syscall.CloseHandle(h)
+	}()
+
+	if err = renameAll(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != src {
+		t.Fatalf("expected %s, got %s", src, data)
+	}
+}
+
+func TestLongPath(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		path, expected string
+	}{
+		{`C:\a\b`, `\\?\C:\a\b`},
+		{`C:/a/./b/`, `\\?\C:\a\b`},
+		{`\\server\share\a`, `\\?\UNC\server\share\a`},
+		{`\\?\C:\a`, `\\?\C:\a`},
+		{`a\b`, `\\?\` + filepath.Join(cwd, "a", "b")},
+	}
+	for i, testCase := range testCases {
+		if p := longPath(testCase.path); p != testCase.expected {
+			t.Errorf("case %d: expected %s, got %s", i+1, testCase.expected, p)
+		}
+	}
+}
+
+// Test that a rename succeeds beyond the 260 characters limit.
+func TestRenameAllLongPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testdisk-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(longPath(dir))
+
+	src := filepath.Join(dir, "src")
+	if err = ioutil.WriteFile(src, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dst := dir
+	for len(dst) < 300 {
+		dst = filepath.Join(dst, strings.Repeat("a", 64))
+	}
+	dst = filepath.Join(dst, "dst")
+	if err = renameAll(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(longPath(dst))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" {
+		t.Fatalf("expected hello, got %s", data)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package antivirus implements clients of content scanning services,
+// clamd and ICAP antivirus servers, scanning streams of data.
+package antivirus
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+)
+
+// Result is the verdict of a scan.
+type Result struct {
+	Infected bool
+	// Name of the virus found, if known.
+	Virus string
+}
+
+// Scanner scans streams of data.
+type Scanner interface {
+	// Scan reads r until io.EOF and returns the verdict
+	// of the scanning service on its content.
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// dial connects to the scanning service, the connection is
+// closed when ctx is done to interrupt pending reads and writes.
+func dial(ctx context.Context, network, address string, timeout time.Duration) (net.Conn, func(), error) {
+	d := net.Dialer{Timeout: timeout}
+	conn, err := d.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	doneCh := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-doneCh:
+		}
+	}()
+	return conn, func() {
+		close(doneCh)
+		conn.Close()
+	}, nil
+}
@@ -0,0 +1,186 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http/httputil"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// serve accepts connections on a local listener and handles
+// them with fn until the returned listener is closed.
+func serve(t *testing.T, fn func(conn net.Conn)) net.Listener {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				fn(conn)
+			}()
+		}
+	}()
+	return l
+}
+
+func fakeClamd(conn net.Conn) {
+	br := bufio.NewReader(conn)
+	if cmd, err := br.ReadString(0); err != nil || cmd != "zINSTREAM\x00" {
+		io.WriteString(conn, "UNKNOWN COMMAND\x00")
+		return
+	}
+	var data bytes.Buffer
+	for {
+		var size uint32
+		if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		if _, err := io.CopyN(&data, br, int64(size)); err != nil {
+			return
+		}
+	}
+	if bytes.Contains(data.Bytes(), []byte("EICAR")) {
+		io.WriteString(conn, "stream: Eicar-Test-Signature FOUND\x00")
+		return
+	}
+	io.WriteString(conn, "stream: OK\x00")
+}
+
+func fakeICAP(conn net.Conn) {
+	tp := textproto.NewReader(bufio.NewReader(conn))
+	if line, err := tp.ReadLine(); err != nil || !strings.HasPrefix(line, "RESPMOD ") {
+		io.WriteString(conn, "ICAP/1.0 400 Bad Request\r\n\r\n")
+		return
+	}
+	// ICAP headers, then the encapsulated HTTP response
+	// status line and headers.
+	if _, err := tp.ReadMIMEHeader(); err != nil {
+		return
+	}
+	if _, err := tp.ReadLine(); err != nil {
+		return
+	}
+	if _, err := tp.ReadMIMEHeader(); err != nil {
+		return
+	}
+	body := httputil.NewChunkedReader(tp.R)
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return
+	}
+	if bytes.Contains(data, []byte("EICAR")) {
+		io.WriteString(conn, "ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;\r\nEncapsulated: null-body=0\r\n\r\n")
+		return
+	}
+	io.WriteString(conn, "ICAP/1.0 204 No Content\r\nEncapsulated: null-body=0\r\n\r\n")
+}
+
+func testScanner(t *testing.T, scanner Scanner) {
+	testCases := []struct {
+		data     string
+		infected bool
+		virus    string
+	}{
+		{"", false, ""},
+		{"clean content", false, ""},
+		{strings.Repeat("a", 200*1024), false, ""},
+		{eicar, true, "Eicar-Test-Signature"},
+		{strings.Repeat("a", 100*1024) + eicar, true, "Eicar-Test-Signature"},
+	}
+	for i, testCase := range testCases {
+		result, err := scanner.Scan(context.Background(), strings.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if result.Infected != testCase.infected || result.Virus != testCase.virus {
+			t.Errorf("Test %d: expected %v %q, got %v %q", i+1, testCase.infected, testCase.virus, result.Infected, result.Virus)
+		}
+	}
+}
+
+func TestClamd(t *testing.T) {
+	l := serve(t, fakeClamd)
+	defer l.Close()
+	testScanner(t, NewClamd(l.Addr().String(), time.Second))
+}
+
+func TestICAP(t *testing.T) {
+	l := serve(t, fakeICAP)
+	defer l.Close()
+	scanner, err := NewICAP("icap://"+l.Addr().String()+"/avscan", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testScanner(t, scanner)
+
+	if _, err = NewICAP("http://localhost/avscan", time.Second); err == nil {
+		t.Fatal("expected non ICAP endpoints to be rejected")
+	}
+}
+
+func TestScanCanceled(t *testing.T) {
+	// A service never replying.
+	l := serve(t, func(conn net.Conn) {
+		io.Copy(ioutil.Discard, conn)
+	})
+	defer l.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := NewClamd(l.Addr().String(), time.Second).Scan(ctx, strings.NewReader("data")); err == nil {
+		t.Fatal("expected canceled scans to fail")
+	}
+}
+
+func TestParseClamdReply(t *testing.T) {
+	testCases := []struct {
+		reply    string
+		infected bool
+		success  bool
+	}{
+		{"stream: OK", false, true},
+		{"stream: Win.Test.EICAR_HDB-1 FOUND", true, true},
+		{"INSTREAM size limit exceeded. ERROR", false, false},
+	}
+	for i, testCase := range testCases {
+		result, err := parseClamdReply(testCase.reply)
+		if (err == nil) != testCase.success || result.Infected != testCase.infected {
+			t.Errorf("Test %d: unexpected result %v: %v", i+1, result, err)
+		}
+	}
+}
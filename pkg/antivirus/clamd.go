@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package antivirus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize is the size of the chunks streamed to clamd.
+const clamdChunkSize = 64 * 1024
+
+// Clamd scans streams with the INSTREAM command of a clamd server.
+type Clamd struct {
+	network, address string
+	timeout          time.Duration
+}
+
+// NewClamd returns a scanner of the clamd server at address, either
+// host:port for TCP or the path of its unix socket.
+func NewClamd(address string, timeout time.Duration) *Clamd {
+	network := "tcp"
+	if strings.HasPrefix(address, "/") {
+		network = "unix"
+	}
+	return &Clamd{network: network, address: address, timeout: timeout}
+}
+
+// Scan streams r to clamd and returns its verdict.
+func (c *Clamd) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, closeConn, err := dial(ctx, c.network, c.address, c.timeout)
+	if err != nil {
+		return Result{}, err
+	}
+	defer closeConn()
+
+	werr := c.stream(conn, r)
+
+	// clamd replies and closes the connection on errors, such as
+	// streams exceeding its size limit, the reply is read anyway.
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil {
+		if werr != nil {
+			return Result{}, werr
+		}
+		return Result{}, err
+	}
+	return parseClamdReply(string(bytes.TrimRight(reply, "\x00")))
+}
+
+func (c *Clamd) stream(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, "zINSTREAM\x00"); err != nil {
+		return err
+	}
+	buf := make([]byte, 4+clamdChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf[:4], uint32(n))
+			if _, werr := w.Write(buf[:4+n]); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// A chunk of length zero ends the stream.
+	_, err := w.Write([]byte{0, 0, 0, 0})
+	return err
+}
+
+// parseClamdReply parses replies such as "stream: OK" and
+// "stream: Eicar-Test-Signature FOUND".
+func parseClamdReply(reply string) (Result, error) {
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return Result{Infected: true, Virus: strings.TrimSuffix(reply, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamd: %s", reply)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package antivirus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// icapChunkSize is the size of the chunks streamed to ICAP servers.
+const icapChunkSize = 64 * 1024
+
+// encapsulated HTTP response headers of the content scanned.
+const icapResHdr = "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\n\r\n"
+
+// ICAP scans streams with RESPMOD requests to an ICAP antivirus service.
+type ICAP struct {
+	u       *url.URL
+	timeout time.Duration
+}
+
+// NewICAP returns a scanner of the ICAP service at endpoint,
+// such as icap://localhost:1344/avscan.
+func NewICAP(endpoint string, timeout time.Duration) (*ICAP, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "icap" || u.Host == "" {
+		return nil, fmt.Errorf("invalid ICAP endpoint %q", endpoint)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), "1344")
+	}
+	return &ICAP{u: u, timeout: timeout}, nil
+}
+
+// Scan streams r to the ICAP service and returns its verdict, the
+// service replies 204 No Content for content it does not modify.
+func (c *ICAP) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	conn, closeConn, err := dial(ctx, "tcp", c.u.Host, c.timeout)
+	if err != nil {
+		return Result{}, err
+	}
+	defer closeConn()
+
+	werr := c.stream(conn, r)
+
+	// Services may reply before the end of the stream.
+	tp := textproto.NewReader(bufio.NewReader(conn))
+	line, err := tp.ReadLine()
+	if err != nil {
+		if werr != nil {
+			return Result{}, werr
+		}
+		return Result{}, err
+	}
+	header, err := tp.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return Result{}, err
+	}
+	return parseICAPReply(line, header)
+}
+
+func (c *ICAP) stream(w io.Writer, r io.Reader) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "RESPMOD %s ICAP/1.0\r\n", c.u.String())
+	fmt.Fprintf(bw, "Host: %s\r\n", c.u.Host)
+	fmt.Fprintf(bw, "Allow: 204\r\n")
+	fmt.Fprintf(bw, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(icapResHdr))
+	bw.WriteString(icapResHdr)
+
+	buf := make([]byte, icapChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			fmt.Fprintf(bw, "%x\r\n", n)
+			bw.Write(buf[:n])
+			if _, werr := bw.WriteString("\r\n"); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	bw.WriteString("0\r\n\r\n")
+	return bw.Flush()
+}
+
+// parseICAPReply returns the verdict of the ICAP status line and headers,
+// infected content is replaced by the service so it replies 200 OK.
+func parseICAPReply(line string, header textproto.MIMEHeader) (Result, error) {
+	fields := strings.SplitN(line, " ", 3)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return Result{}, fmt.Errorf("icap: malformed status line %q", line)
+	}
+	status, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Result{}, fmt.Errorf("icap: malformed status line %q", line)
+	}
+
+	switch status {
+	case 204:
+		return Result{}, nil
+	case 200, 403:
+		virus := header.Get("X-Virus-Id")
+		if virus == "" {
+			// X-Infection-Found: Type=0; Resolution=2; Threat=<name>;
+			for _, field := range strings.Split(header.Get("X-Infection-Found"), ";") {
+				field = strings.TrimSpace(field)
+				if strings.HasPrefix(field, "Threat=") {
+					virus = strings.TrimPrefix(field, "Threat=")
+				}
+			}
+		}
+		return Result{Infected: true, Virus: virus}, nil
+	default:
+		return Result{}, fmt.Errorf("icap: %s", line)
+	}
+}
@@ -10,11 +10,13 @@ func _() {
 	var x [1]struct{}
 	_ = x[NoneAction-0]
 	_ = x[DeleteAction-1]
+	_ = x[DeleteVersionAction-2]
+	_ = x[TransitionAction-3]
 }
 
-const _Action_name = "NoneActionDeleteAction"
+const _Action_name = "NoneActionDeleteActionDeleteVersionActionTransitionAction"
 
-var _Action_index = [...]uint8{0, 10, 22}
+var _Action_index = [...]uint8{0, 10, 22, 41, 57}
 
 func (i Action) String() string {
 	if i < 0 || i >= Action(len(_Action_index)-1) {
@@ -42,6 +42,8 @@ const (
 	DeleteAction
 	// DeleteVersionAction deletes a particular version
 	DeleteVersionAction
+	// TransitionAction moves the object data to the remote tier of the rule
+	TransitionAction
 )
 
 // Lifecycle - Configuration for bucket lifecycle.
@@ -79,6 +81,11 @@ func (lc Lifecycle) HasActiveRules(prefix string, recursive bool) bool {
 		if rule.NoncurrentVersionTransition.NoncurrentDays > 0 {
 			return true
 		}
+		if !rule.Transition.IsNull() {
+			if rule.Transition.IsDateNull() || !rule.Transition.Date.After(time.Now()) {
+				return true
+			}
+		}
 		if rule.Expiration.IsNull() {
 			continue
 		}
@@ -179,6 +186,10 @@ type ObjectOpts struct {
 	IsLatest     bool
 	DeleteMarker bool
 	NumVersions  int
+
+	// TransitionStatus is set once the object
+	// has been moved to a remote tier.
+	TransitionStatus string
 }
 
 // ComputeAction returns the action to perform by evaluating all lifecycle rules
@@ -220,11 +231,31 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 					action = DeleteAction
 				}
 			}
+
+			// Objects are transitioned only once, expiration
+			// takes precedence over transition.
+			if action == NoneAction && obj.TransitionStatus == "" && rule.Transition.isDue(obj.ModTime) {
+				action = TransitionAction
+			}
 		}
 	}
 	return action
 }
 
+// TransitionStorageClass returns the storage class of the first rule
+// due for transitioning the object, empty if there is none.
+func (lc Lifecycle) TransitionStorageClass(obj ObjectOpts) string {
+	if obj.ModTime.IsZero() || !obj.IsLatest || obj.DeleteMarker || obj.TransitionStatus != "" {
+		return ""
+	}
+	for _, rule := range lc.FilterActionableRules(obj) {
+		if rule.Transition.isDue(obj.ModTime) {
+			return rule.Transition.StorageClass
+		}
+	}
+	return ""
+}
+
 // expectedExpiryTime calculates the expiry date/time based on a object modtime.
 // The expected expiry time is always a midnight time following the the object
 // modification time plus the number of expiration days.
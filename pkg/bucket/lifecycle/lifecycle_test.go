@@ -154,6 +154,11 @@ func TestMarshalLifecycleConfig(t *testing.T) {
 				Filter:     Filter{Prefix: "prefix-1"},
 				Expiration: Expiration{Date: ExpirationDate(midnightTS)},
 			},
+			{
+				Status:     "Enabled",
+				Filter:     Filter{Prefix: "prefix-2"},
+				Transition: Transition{Days: ExpirationDays(30), StorageClass: "WARM"},
+			},
 		},
 	}
 	b, err := xml.MarshalIndent(&lc, "", "\t")
@@ -216,11 +221,12 @@ func TestExpectedExpiryTime(t *testing.T) {
 
 func TestComputeActions(t *testing.T) {
 	testCases := []struct {
-		inputConfig    string
-		objectName     string
-		objectTags     string
-		objectModTime  time.Time
-		expectedAction Action
+		inputConfig      string
+		objectName       string
+		objectTags       string
+		objectModTime    time.Time
+		transitionStatus string
+		expectedAction   Action
 	}{
 		// Empty object name (unexpected case) should always return NoneAction
 		{
@@ -331,6 +337,42 @@ func TestComputeActions(t *testing.T) {
 			objectModTime:  time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
 			expectedAction: DeleteAction,
 		},
+		// Too early to transition
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>5</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-2 * 24 * time.Hour), // Created 2 days ago
+			expectedAction: NoneAction,
+		},
+		// Should transition (test Days)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>5</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: TransitionAction,
+		},
+		// Should transition (test Date)
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Date>` + time.Now().UTC().Truncate(24*time.Hour).Add(-24*time.Hour).Format(time.RFC3339) + `</Date><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-24 * time.Hour), // Created 1 day ago
+			expectedAction: TransitionAction,
+		},
+		// Should not transition again
+		{
+			inputConfig:      `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Transition><Days>5</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:       "foodir/fooobject",
+			objectModTime:    time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			transitionStatus: TransitionComplete,
+			expectedAction:   NoneAction,
+		},
+		// Should remove, expiration takes precedence over transition
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration><Transition><Days>3</Days><StorageClass>WARM</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectModTime:  time.Now().UTC().Add(-6 * 24 * time.Hour), // Created 6 days ago
+			expectedAction: DeleteAction,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -341,10 +383,11 @@ func TestComputeActions(t *testing.T) {
 				t.Fatalf("Got unexpected error: %v", err)
 			}
 			if resultAction := lc.ComputeAction(ObjectOpts{
-				Name:     tc.objectName,
-				UserTags: tc.objectTags,
-				ModTime:  tc.objectModTime,
-				IsLatest: true,
+				Name:             tc.objectName,
+				UserTags:         tc.objectTags,
+				ModTime:          tc.objectModTime,
+				IsLatest:         true,
+				TransitionStatus: tc.transitionStatus,
 			}); resultAction != tc.expectedAction {
 				t.Fatalf("Expected action: `%v`, got: `%v`", tc.expectedAction, resultAction)
 			}
@@ -47,7 +47,7 @@ var (
 	errInvalidRuleID           = Errorf("ID must be less than 255 characters")
 	errEmptyRuleStatus         = Errorf("Status should not be empty")
 	errInvalidRuleStatus       = Errorf("Status must be set to either Enabled or Disabled")
-	errMissingExpirationAction = Errorf("No expiration action found")
+	errMissingExpirationAction = Errorf("No expiration or transition action found")
 )
 
 // validateID - checks if ID is valid or not.
@@ -74,9 +74,12 @@ func (r Rule) validateStatus() error {
 }
 
 func (r Rule) validateAction() error {
-	if r.Expiration == (Expiration{}) {
+	if r.Expiration == (Expiration{}) && r.Transition == (Transition{}) {
 		return errMissingExpirationAction
 	}
+	if r.Transition != (Transition{}) {
+		return r.Transition.Validate()
+	}
 	return nil
 }
 
@@ -25,7 +25,7 @@ import (
 // TestUnsupportedRules checks if Rule xml with unsuported tags return
 // appropriate errors on parsing
 func TestUnsupportedRules(t *testing.T) {
-	// NoncurrentVersionTransition, and Transition tags aren't supported
+	// NoncurrentVersionTransition tags aren't supported
 	unsupportedTestCases := []struct {
 		inputXML    string
 		expectedErr error
@@ -36,12 +36,6 @@ func TestUnsupportedRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errNoncurrentVersionTransitionUnsupported,
 		},
-		{ // Rule with unsupported Transition action
-			inputXML: ` <Rule>
-	                     <Transition></Transition>
-	                    </Rule>`,
-			expectedErr: errTransitionUnsupported,
-		},
 	}
 
 	for i, tc := range unsupportedTestCases {
@@ -86,6 +80,27 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with transition without days or date
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition><StorageClass>WARM</StorageClass></Transition>
+	                    </Rule>`,
+			expectedErr: errLifecycleInvalidTransition,
+		},
+		{ // Rule with transition without storage class
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition><Days>30</Days></Transition>
+	                    </Rule>`,
+			expectedErr: errLifecycleMissingTransitionStorageClass,
+		},
+		{ // Rule with a valid transition
+			inputXML: ` <Rule>
+                              <Status>Enabled</Status>
+                              <Transition><Days>30</Days><StorageClass>WARM</StorageClass></Transition>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
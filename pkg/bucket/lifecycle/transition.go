@@ -18,25 +18,72 @@ package lifecycle
 
 import (
 	"encoding/xml"
+	"time"
 )
 
+var (
+	errLifecycleInvalidTransition             = Errorf("Exactly one of Days or Date should be present inside Transition")
+	errLifecycleMissingTransitionStorageClass = Errorf("StorageClass must be specified inside Transition")
+)
+
+// TransitionComplete is the transition status of objects
+// whose data has been moved to the remote tier.
+const TransitionComplete = "complete"
+
 // Transition - transition actions for a rule in lifecycle configuration.
 type Transition struct {
-	XMLName      xml.Name `xml:"Transition"`
-	Days         int      `xml:"Days,omitempty"`
-	Date         string   `xml:"Date,omitempty"`
-	StorageClass string   `xml:"StorageClass"`
+	XMLName      xml.Name       `xml:"Transition"`
+	Days         ExpirationDays `xml:"Days,omitempty"`
+	Date         ExpirationDate `xml:"Date,omitempty"`
+	StorageClass string         `xml:"StorageClass"`
 }
 
-var errTransitionUnsupported = Errorf("Specifying <Transition></Transition> tag is not supported")
-
-// UnmarshalXML is extended to indicate lack of support for Transition
-// xml tag in object lifecycle configuration
-func (t Transition) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return errTransitionUnsupported
+// MarshalXML is extended to leave out <Transition></Transition>
+// tags when no transition is configured.
+func (t Transition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.IsNull() && t.StorageClass == "" {
+		return nil
+	}
+	type transitionWrapper Transition
+	tw := transitionWrapper(t)
+	return e.EncodeElement(&tw, start)
 }
 
-// MarshalXML is extended to leave out <Transition></Transition> tags
-func (t Transition) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+// Validate - validates the "Transition" element
+func (t Transition) Validate() error {
+	// Exactly one of transition days or date is specified
+	if t.IsDaysNull() == t.IsDateNull() {
+		return errLifecycleInvalidTransition
+	}
+	if t.StorageClass == "" {
+		return errLifecycleMissingTransitionStorageClass
+	}
 	return nil
 }
+
+// IsDaysNull returns true if days field is null
+func (t Transition) IsDaysNull() bool {
+	return t.Days == ExpirationDays(0)
+}
+
+// IsDateNull returns true if date field is null
+func (t Transition) IsDateNull() bool {
+	return t.Date.Time.IsZero()
+}
+
+// IsNull returns true if both date and days fields are null
+func (t Transition) IsNull() bool {
+	return t.IsDaysNull() && t.IsDateNull()
+}
+
+// isDue returns true if an object last modified at
+// modTime has to be transitioned now.
+func (t Transition) isDue(modTime time.Time) bool {
+	switch {
+	case !t.IsDateNull():
+		return time.Now().UTC().After(t.Date.Time)
+	case !t.IsDaysNull():
+		return time.Now().UTC().After(expectedExpiryTime(modTime, t.Days))
+	}
+	return false
+}
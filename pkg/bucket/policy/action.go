@@ -145,6 +145,19 @@ const (
 
 	// PutObjectVersionTaggingAction - PutObjectVersionTagging Rest API action.
 	PutObjectVersionTaggingAction = "s3:PutObjectVersionTagging"
+
+	// RestoreObjectAction - RestoreObject Rest API action.
+	RestoreObjectAction = "s3:RestoreObject"
+
+	// GetReplicationConfigurationAction - GetBucketReplication REST API action
+	GetReplicationConfigurationAction = "s3:GetReplicationConfiguration"
+	// PutReplicationConfigurationAction - PutBucketReplication REST API action
+	PutReplicationConfigurationAction = "s3:PutReplicationConfiguration"
+
+	// ReplicateObjectAction - replicate objects to a bucket.
+	ReplicateObjectAction = "s3:ReplicateObject"
+	// ReplicateDeleteAction - replicate deletes to a bucket.
+	ReplicateDeleteAction = "s3:ReplicateDelete"
 )
 
 // List of all supported object actions.
@@ -167,6 +180,9 @@ var supportedObjectActions = map[Action]struct{}{
 	DeleteObjectVersionAction:        {},
 	DeleteObjectVersionTaggingAction: {},
 	PutObjectVersionTaggingAction:    {},
+	RestoreObjectAction:              {},
+	ReplicateObjectAction:            {},
+	ReplicateDeleteAction:            {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -211,6 +227,7 @@ var supportedActions = map[Action]struct{}{
 	DeleteObjectVersionAction:              {},
 	DeleteObjectVersionTaggingAction:       {},
 	PutObjectVersionTaggingAction:          {},
+	RestoreObjectAction:                    {},
 	BypassGovernanceRetentionAction:        {},
 	GetObjectTaggingAction:                 {},
 	PutObjectTaggingAction:                 {},
@@ -219,6 +236,10 @@ var supportedActions = map[Action]struct{}{
 	GetBucketEncryptionAction:              {},
 	PutBucketVersioningAction:              {},
 	GetBucketVersioningAction:              {},
+	GetReplicationConfigurationAction:      {},
+	PutReplicationConfigurationAction:      {},
+	ReplicateObjectAction:                  {},
+	ReplicateDeleteAction:                  {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -341,6 +362,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
 
 	PutObjectVersionTaggingAction: condition.NewKeySet(condition.CommonKeys...),
+	RestoreObjectAction:           condition.NewKeySet(condition.CommonKeys...),
 	GetObjectVersionAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3VersionID,
@@ -357,4 +379,9 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 		append([]condition.Key{
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
+
+	GetReplicationConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+	PutReplicationConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+	ReplicateObjectAction:             condition.NewKeySet(condition.CommonKeys...),
+	ReplicateDeleteAction:             condition.NewKeySet(condition.CommonKeys...),
 }
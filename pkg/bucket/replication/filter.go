@@ -0,0 +1,172 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"unicode/utf8"
+)
+
+var (
+	errInvalidFilter    = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errInvalidTagKey    = Errorf("The TagKey you have provided is invalid")
+	errInvalidTagValue  = Errorf("The TagValue you have provided is invalid")
+	errDuplicateTagKey  = Errorf("Duplicate Tag Keys are not allowed")
+	errDuplicatedXMLTag = Errorf("duplicated XML Tag")
+)
+
+// Tag - a tag for a replication configuration Rule filter.
+type Tag struct {
+	XMLName xml.Name `xml:"Tag"`
+	Key     string   `xml:"Key,omitempty"`
+	Value   string   `xml:"Value,omitempty"`
+}
+
+func (tag Tag) String() string {
+	return tag.Key + "=" + tag.Value
+}
+
+// IsEmpty returns whether this tag is empty or not.
+func (tag Tag) IsEmpty() bool {
+	return tag.Key == ""
+}
+
+// Validate checks this tag.
+func (tag Tag) Validate() error {
+	if len(tag.Key) == 0 || utf8.RuneCountInString(tag.Key) > 128 {
+		return errInvalidTagKey
+	}
+
+	if utf8.RuneCountInString(tag.Value) > 256 {
+		return errInvalidTagValue
+	}
+
+	return nil
+}
+
+// And - a tag to combine a prefix and multiple tags for replication configuration rule.
+type And struct {
+	XMLName xml.Name `xml:"And"`
+	Prefix  string   `xml:"Prefix,omitempty"`
+	Tags    []Tag    `xml:"Tag,omitempty"`
+}
+
+// isEmpty returns true if Tags field is null
+func (a And) isEmpty() bool {
+	return len(a.Tags) == 0 && a.Prefix == ""
+}
+
+// Validate - validates the And field
+func (a And) Validate() error {
+	keys := make(map[string]struct{}, len(a.Tags))
+	for _, t := range a.Tags {
+		if _, has := keys[t.Key]; has {
+			return errDuplicateTagKey
+		}
+		keys[t.Key] = struct{}{}
+		if err := t.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Filter - a filter for a replication configuration Rule.
+type Filter struct {
+	XMLName xml.Name `xml:"Filter"`
+	Prefix  string
+	And     And
+	Tag     Tag
+}
+
+// MarshalXML - produces the xml representation of the Filter struct
+// only one of Prefix, And and Tag should be present in the output.
+func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch {
+	case !f.And.isEmpty():
+		if err := e.EncodeElement(f.And, xml.StartElement{Name: xml.Name{Local: "And"}}); err != nil {
+			return err
+		}
+	case !f.Tag.IsEmpty():
+		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
+			return err
+		}
+	default:
+		// Always print Prefix field when both And & Tag are empty
+		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// Validate - validates the filter element
+func (f Filter) Validate() error {
+	// A Filter must have exactly one of Prefix, Tag, or And specified.
+	if !f.And.isEmpty() {
+		if f.Prefix != "" || !f.Tag.IsEmpty() {
+			return errInvalidFilter
+		}
+		return f.And.Validate()
+	}
+	if !f.Tag.IsEmpty() {
+		if f.Prefix != "" {
+			return errInvalidFilter
+		}
+		return f.Tag.Validate()
+	}
+	return nil
+}
+
+// prefix - returns the prefix of the filter.
+func (f Filter) prefix() string {
+	if f.And.Prefix != "" {
+		return f.And.Prefix
+	}
+	return f.Prefix
+}
+
+// tags - returns the tags of the filter.
+func (f Filter) tags() []Tag {
+	if !f.Tag.IsEmpty() {
+		return []Tag{f.Tag}
+	}
+	return f.And.Tags
+}
+
+// testTags tests if the object tags, in the format
+// tag1=value1&tag2=value2, satisfy the tags of the filter.
+func (f Filter) testTags(userTags string) bool {
+	for _, ft := range f.tags() {
+		found := false
+		for _, t := range splitTags(userTags) {
+			if t == ft.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
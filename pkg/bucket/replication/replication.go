@@ -0,0 +1,190 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	errReplicationTooManyRules        = Errorf("Replication configuration allows a maximum of 1000 rules")
+	errReplicationNoRule              = Errorf("Replication configuration should have at least one rule")
+	errReplicationUniquePriority      = Errorf("Replication configuration has duplicate priority")
+	errReplicationDestinationMismatch = Errorf("The destination bucket must be same for all rules")
+)
+
+// StatusType of the replication of an object.
+type StatusType string
+
+// Replication status of objects.
+const (
+	// Pending - the object is waiting to be replicated.
+	Pending StatusType = "PENDING"
+	// Completed - the object was replicated.
+	Completed StatusType = "COMPLETED"
+	// Failed - the object could not be replicated.
+	Failed StatusType = "FAILED"
+	// Replica - the object is a replica written by a source.
+	Replica StatusType = "REPLICA"
+)
+
+// String returns the replication status as a string.
+func (s StatusType) String() string {
+	return string(s)
+}
+
+// Empty returns true if the object has no replication status.
+func (s StatusType) Empty() bool {
+	return s == ""
+}
+
+// ARN of the remote target of a bucket, in the
+// form arn:minio:replication:region:id:bucket
+type ARN struct {
+	Region string
+	ID     string
+	Bucket string
+}
+
+const arnPrefix = "arn:minio:replication:"
+
+// String returns the ARN as a string.
+func (a ARN) String() string {
+	return arnPrefix + a.Region + ":" + a.ID + ":" + a.Bucket
+}
+
+// ParseARN - parses the ARN of a remote target.
+func ParseARN(s string) (ARN, error) {
+	if !strings.HasPrefix(s, arnPrefix) {
+		return ARN{}, errInvalidDestinationArn
+	}
+	tokens := strings.SplitN(strings.TrimPrefix(s, arnPrefix), ":", 3)
+	if len(tokens) != 3 || tokens[1] == "" || tokens[2] == "" {
+		return ARN{}, errInvalidDestinationArn
+	}
+	return ARN{Region: tokens[0], ID: tokens[1], Bucket: tokens[2]}, nil
+}
+
+// Config - replication configuration of a bucket.
+type Config struct {
+	XMLName xml.Name `xml:"ReplicationConfiguration"`
+	// Role is accepted for compatibility with S3, it is not used.
+	Role  string `xml:"Role,omitempty"`
+	Rules []Rule `xml:"Rule"`
+}
+
+// ParseConfig - parses data in given reader to replication configuration.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the replication configuration
+func (c Config) Validate() error {
+	if len(c.Rules) > 1000 {
+		return errReplicationTooManyRules
+	}
+	if len(c.Rules) == 0 {
+		return errReplicationNoRule
+	}
+	priorities := make(map[int]struct{}, len(c.Rules))
+	for _, r := range c.Rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if _, ok := priorities[r.Priority]; ok {
+			return errReplicationUniquePriority
+		}
+		priorities[r.Priority] = struct{}{}
+		// All objects are replicated to a single remote target.
+		if r.Destination.Bucket != c.Rules[0].Destination.Bucket {
+			return errReplicationDestinationMismatch
+		}
+	}
+	return nil
+}
+
+// Destination - returns the destination of the replicated objects.
+func (c Config) Destination() Destination {
+	if len(c.Rules) == 0 {
+		return Destination{}
+	}
+	return c.Rules[0].Destination
+}
+
+// ObjectOpts provides the information of an object
+// used to evaluate the rules of the configuration.
+type ObjectOpts struct {
+	Name     string
+	UserTags string
+}
+
+// matchingRule - returns the enabled rule with the
+// highest priority matching the object, if any.
+func (c Config) matchingRule(obj ObjectOpts) (Rule, bool) {
+	if obj.Name == "" {
+		return Rule{}, false
+	}
+	rules := make([]Rule, 0, len(c.Rules))
+	for _, rule := range c.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if !strings.HasPrefix(obj.Name, rule.Prefix()) {
+			continue
+		}
+		if !rule.Filter.testTags(obj.UserTags) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return Rule{}, false
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+	return rules[0], true
+}
+
+// Replicate - returns true if the object is replicated.
+func (c Config) Replicate(obj ObjectOpts) bool {
+	_, ok := c.matchingRule(obj)
+	return ok
+}
+
+// ReplicateDelete - returns true if the deletion of the
+// object is replicated, the tags of deleted objects are
+// unknown, only rules without tags are considered.
+func (c Config) ReplicateDelete(name string) bool {
+	rule, ok := c.matchingRule(ObjectOpts{Name: name})
+	return ok && rule.DeleteMarkerReplication.Status == Enabled
+}
+
+// splitTags - splits user tags in the format tag1=value1&tag2=value2.
+func splitTags(userTags string) []string {
+	if userTags == "" {
+		return nil
+	}
+	return strings.Split(userTags, "&")
+}
@@ -0,0 +1,188 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"testing"
+)
+
+const testArn = "arn:minio:replication:us-east-1:c5be6b16-769d-432a-9ef1-4567081f3566:destination"
+
+func TestParseAndValidateReplicationConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig           string
+		expectedParsingErr    error
+		expectedValidationErr error
+	}{
+		{ // Valid replication config
+			inputConfig: `<ReplicationConfiguration>
+				<Role></Role>
+				<Rule>
+				<Status>Enabled</Status>
+				<Priority>1</Priority>
+				<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication>
+				<Filter><Prefix>photos/</Prefix></Filter>
+				<Destination><Bucket>` + testArn + `</Bucket></Destination>
+				</Rule>
+				</ReplicationConfiguration>`,
+		},
+		{ // Replication config without rules
+			inputConfig:           `<ReplicationConfiguration></ReplicationConfiguration>`,
+			expectedValidationErr: errReplicationNoRule,
+		},
+		{ // Rules with the same priority
+			inputConfig: `<ReplicationConfiguration>
+				<Rule><Status>Enabled</Status><Priority>1</Priority><Destination><Bucket>` + testArn + `</Bucket></Destination></Rule>
+				<Rule><Status>Enabled</Status><Priority>1</Priority><Destination><Bucket>` + testArn + `</Bucket></Destination></Rule>
+				</ReplicationConfiguration>`,
+			expectedValidationErr: errReplicationUniquePriority,
+		},
+		{ // Rules with different destinations
+			inputConfig: `<ReplicationConfiguration>
+				<Rule><Status>Enabled</Status><Priority>1</Priority><Destination><Bucket>` + testArn + `</Bucket></Destination></Rule>
+				<Rule><Status>Enabled</Status><Priority>2</Priority><Destination><Bucket>` + testArn + `2</Bucket></Destination></Rule>
+				</ReplicationConfiguration>`,
+			expectedValidationErr: errReplicationDestinationMismatch,
+		},
+		{ // Destination which is not an ARN
+			inputConfig: `<ReplicationConfiguration>
+				<Rule><Status>Enabled</Status><Destination><Bucket>destination</Bucket></Destination></Rule>
+				</ReplicationConfiguration>`,
+			expectedValidationErr: errInvalidDestinationArn,
+		},
+		{ // Missing status
+			inputConfig: `<ReplicationConfiguration>
+				<Rule><Destination><Bucket>` + testArn + `</Bucket></Destination></Rule>
+				</ReplicationConfiguration>`,
+			expectedValidationErr: errEmptyRuleStatus,
+		},
+		{ // Filter with both a prefix and a tag
+			inputConfig: `<ReplicationConfiguration>
+				<Rule><Status>Enabled</Status>
+				<Filter><Prefix>photos/</Prefix><Tag><Key>k</Key><Value>v</Value></Tag></Filter>
+				<Destination><Bucket>` + testArn + `</Bucket></Destination></Rule>
+				</ReplicationConfiguration>`,
+			expectedValidationErr: errInvalidFilter,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			cfg, err := ParseConfig(bytes.NewReader([]byte(tc.inputConfig)))
+			if err != tc.expectedParsingErr {
+				t.Fatalf("Expected parsing error %v, got %v", tc.expectedParsingErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if err = cfg.Validate(); err != tc.expectedValidationErr {
+				t.Fatalf("Expected validation error %v, got %v", tc.expectedValidationErr, err)
+			}
+		})
+	}
+}
+
+func TestMarshalReplicationConfig(t *testing.T) {
+	cfg := Config{Rules: []Rule{{
+		Status:      Enabled,
+		Filter:      Filter{Tag: Tag{Key: "k", Value: "v"}},
+		Destination: Destination{Bucket: testArn},
+	}}}
+	data, err := xml.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = parsed.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Rules[0].Filter.Tag.String() != "k=v" || parsed.Destination().Bucket != testArn {
+		t.Fatalf("Unexpected replication config %s", data)
+	}
+}
+
+func TestReplicate(t *testing.T) {
+	cfg := Config{Rules: []Rule{
+		{
+			Status:      Enabled,
+			Priority:    1,
+			Filter:      Filter{Prefix: "photos/"},
+			Destination: Destination{Bucket: testArn},
+		},
+		{
+			Status:                  Enabled,
+			Priority:                2,
+			DeleteMarkerReplication: DeleteMarkerReplication{Status: Enabled},
+			Filter:                  Filter{And: And{Prefix: "photos/raw/", Tags: []Tag{{Key: "replicate", Value: "true"}}}},
+			Destination:             Destination{Bucket: testArn},
+		},
+		{
+			Status:                  Disabled,
+			Priority:                3,
+			DeleteMarkerReplication: DeleteMarkerReplication{Status: Enabled},
+			Filter:                  Filter{Prefix: "docs/"},
+			Destination:             Destination{Bucket: testArn},
+		},
+	}}
+
+	testCases := []struct {
+		obj             ObjectOpts
+		expectReplicate bool
+		expectDelete    bool
+	}{
+		{ObjectOpts{Name: "photos/1.jpg"}, true, false},
+		{ObjectOpts{Name: "photos/raw/1.raw"}, true, false},
+		{ObjectOpts{Name: "photos/raw/1.raw", UserTags: "a=b&replicate=true"}, true, false},
+		{ObjectOpts{Name: "docs/1.pdf"}, false, false},
+		{ObjectOpts{Name: "other"}, false, false},
+	}
+	for i, tc := range testCases {
+		if got := cfg.Replicate(tc.obj); got != tc.expectReplicate {
+			t.Errorf("Test %d: expected replicate %v, got %v", i+1, tc.expectReplicate, got)
+		}
+		if got := cfg.ReplicateDelete(tc.obj.Name); got != tc.expectDelete {
+			t.Errorf("Test %d: expected replicate delete %v, got %v", i+1, tc.expectDelete, got)
+		}
+	}
+
+	// Deletes are replicated by the highest priority matching rule.
+	cfg.Rules[1].Filter = Filter{Prefix: "photos/raw/"}
+	if !cfg.ReplicateDelete("photos/raw/1.raw") || cfg.ReplicateDelete("photos/1.jpg") {
+		t.Fatal("Unexpected delete replication")
+	}
+}
+
+func TestParseARN(t *testing.T) {
+	arn, err := ParseARN(testArn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if arn.Region != "us-east-1" || arn.Bucket != "destination" || arn.String() != testArn {
+		t.Fatalf("Unexpected ARN %#v", arn)
+	}
+	for _, s := range []string{"", "arn:aws:s3:::bucket", "arn:minio:replication:us-east-1::bucket", "arn:minio:replication:us-east-1:id"} {
+		if _, err = ParseARN(s); err == nil {
+			t.Fatalf("Expected %q to be an invalid ARN", s)
+		}
+	}
+}
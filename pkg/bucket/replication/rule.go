@@ -0,0 +1,99 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replication
+
+import (
+	"encoding/xml"
+)
+
+// Status represents the status of a replication configuration rule.
+type Status string
+
+// Supported status types
+const (
+	Enabled  Status = "Enabled"
+	Disabled Status = "Disabled"
+)
+
+// DeleteMarkerReplication - whether the deletes of objects
+// matching the rule are replicated, disabled by default.
+type DeleteMarkerReplication struct {
+	Status Status `xml:"Status"`
+}
+
+// Destination - the remote target objects matching the rule are replicated to.
+type Destination struct {
+	XMLName xml.Name `xml:"Destination"`
+	// Bucket is the ARN of the remote target of the bucket.
+	Bucket       string `xml:"Bucket"`
+	StorageClass string `xml:"StorageClass,omitempty"`
+}
+
+// Rule - a rule for replication configuration.
+type Rule struct {
+	XMLName                 xml.Name                `xml:"Rule"`
+	ID                      string                  `xml:"ID,omitempty"`
+	Status                  Status                  `xml:"Status"`
+	Priority                int                     `xml:"Priority"`
+	DeleteMarkerReplication DeleteMarkerReplication `xml:"DeleteMarkerReplication"`
+	Destination             Destination             `xml:"Destination"`
+	Filter                  Filter                  `xml:"Filter"`
+}
+
+var (
+	errInvalidRuleID                  = Errorf("ID must be less than 255 characters")
+	errEmptyRuleStatus                = Errorf("Status should not be empty")
+	errInvalidRuleStatus              = Errorf("Status must be set to either Enabled or Disabled")
+	errInvalidDeleteMarkerReplication = Errorf("DeleteMarkerReplication Status must be set to either Enabled or Disabled")
+	errDestinationMissing             = Errorf("Destination Bucket must be specified")
+	errInvalidDestinationArn          = Errorf("Destination Bucket must be the ARN of a remote target")
+	errInvalidPriority                = Errorf("Priority must be a positive integer")
+)
+
+// Validate - validates the rule element
+func (r Rule) Validate() error {
+	// cannot be longer than 255 characters
+	if len(r.ID) > 255 {
+		return errInvalidRuleID
+	}
+	if len(r.Status) == 0 {
+		return errEmptyRuleStatus
+	}
+	if r.Status != Enabled && r.Status != Disabled {
+		return errInvalidRuleStatus
+	}
+	switch r.DeleteMarkerReplication.Status {
+	case "", Enabled, Disabled:
+	default:
+		return errInvalidDeleteMarkerReplication
+	}
+	if r.Priority < 0 {
+		return errInvalidPriority
+	}
+	if r.Destination.Bucket == "" {
+		return errDestinationMissing
+	}
+	if _, err := ParseARN(r.Destination.Bucket); err != nil {
+		return err
+	}
+	return r.Filter.Validate()
+}
+
+// Prefix - returns the prefix of the objects matching the rule.
+func (r Rule) Prefix() string {
+	return r.Filter.prefix()
+}
@@ -18,6 +18,7 @@ package certs
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"os"
 	"path/filepath"
 	"sync"
@@ -27,20 +28,33 @@ import (
 )
 
 // A Certs represents a certificate manager able to watch certificate
-// and key pairs for changes.
+// and key pairs for changes, and to select the certificate served to
+// a client with SNI.
 type Certs struct {
 	sync.RWMutex
 	// user input params.
-	certFile string
-	keyFile  string
 	loadCert LoadX509KeyPairFunc
 
-	// points to the latest certificate.
-	cert *tls.Certificate
+	// certificate and key pairs, the first one is served
+	// to the clients not matching any other pair.
+	pairs []*keyPair
 
 	// internal param to track for events, also
 	// used to close the watcher.
-	e chan notify.EventInfo
+	e        chan notify.EventInfo
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// keyPair - a certificate and key pair, and the latest certificate
+// loaded from it.
+type keyPair struct {
+	certFile string
+	keyFile  string
+	isLink   bool
+
+	// points to the latest certificate.
+	cert *tls.Certificate
 }
 
 // LoadX509KeyPairFunc - provides a type for custom cert loader function.
@@ -48,33 +62,18 @@ type LoadX509KeyPairFunc func(certFile, keyFile string) (tls.Certificate, error)
 
 // New initializes a new certs monitor.
 func New(certFile, keyFile string, loadCert LoadX509KeyPairFunc) (*Certs, error) {
-	certFileIsLink, err := checkSymlink(certFile)
-	if err != nil {
-		return nil, err
-	}
-	keyFileIsLink, err := checkSymlink(keyFile)
-	if err != nil {
-		return nil, err
-	}
 	c := &Certs{
-		certFile: certFile,
-		keyFile:  keyFile,
 		loadCert: loadCert,
 		// Make the channel buffered to ensure no event is dropped. Notify will drop
 		// an event if the receiver is not able to keep up the sending pace.
-		e: make(chan notify.EventInfo, 1),
+		e:    make(chan notify.EventInfo, 1),
+		done: make(chan struct{}),
 	}
-
-	if certFileIsLink && keyFileIsLink {
-		if err := c.watchSymlinks(); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := c.watch(); err != nil {
-			return nil, err
-		}
+	if err := c.AddCertificate(certFile, keyFile); err != nil {
+		c.Stop()
+		return nil, err
 	}
-
+	go c.run()
 	return c, nil
 }
 
@@ -86,117 +85,146 @@ func checkSymlink(file string) (bool, error) {
 	return st.Mode()&os.ModeSymlink == os.ModeSymlink, nil
 }
 
-// watchSymlinks reloads symlinked files since fsnotify cannot watch
-// on symbolic links.
-func (c *Certs) watchSymlinks() (err error) {
-	cert, err := c.loadCert(c.certFile, c.keyFile)
+// AddCertificate adds a certificate and key pair served to the
+// clients requesting one of its host names with SNI, and starts
+// watching it for changes.
+func (c *Certs) AddCertificate(certFile, keyFile string) (err error) {
+	certFileIsLink, err := checkSymlink(certFile)
+	if err != nil {
+		return err
+	}
+	keyFileIsLink, err := checkSymlink(keyFile)
 	if err != nil {
 		return err
 	}
+	p := &keyPair{
+		certFile: certFile,
+		keyFile:  keyFile,
+		// fsnotify cannot watch on symbolic links, symlinked
+		// files are reloaded periodically instead.
+		isLink: certFileIsLink && keyFileIsLink,
+	}
+	if !p.isLink {
+		if err = c.watch(p); err != nil {
+			return err
+		}
+	}
+	if err = c.load(p); err != nil {
+		return err
+	}
 	c.Lock()
-	c.cert = &cert
+	c.pairs = append(c.pairs, p)
 	c.Unlock()
-	go func() {
-		for {
-			select {
-			case <-c.e:
-				// Once stopped exits this routine.
-				return
-			case <-time.After(24 * time.Hour):
-				cert, cerr := c.loadCert(c.certFile, c.keyFile)
-				if cerr != nil {
-					continue
-				}
-				c.Lock()
-				c.cert = &cert
-				c.Unlock()
-			}
-		}
-	}()
 	return nil
 }
 
 // watch starts watching for changes to the certificate
-// and key files. On any change the certificate and key
-// are reloaded. If there is an issue the loading will fail
-// and the old (if any) certificates and keys will continue
-// to be used.
-func (c *Certs) watch() (err error) {
-	defer func() {
-		if err != nil {
-			// Stop any watches previously setup after an error.
-			notify.Stop(c.e)
-		}
-	}()
-
+// and key files of p.
+func (c *Certs) watch(p *keyPair) (err error) {
 	// Windows doesn't allow for watching file changes but instead allows
 	// for directory changes only, while we can still watch for changes
 	// on files on other platforms. Watch parent directory on all platforms
 	// for simplicity.
-	if err = notify.Watch(filepath.Dir(c.certFile), c.e, eventWrite...); err != nil {
-		return err
-	}
-	if err = notify.Watch(filepath.Dir(c.keyFile), c.e, eventWrite...); err != nil {
+	if err = notify.Watch(filepath.Dir(p.certFile), c.e, eventWrite...); err != nil {
 		return err
 	}
-	cert, err := c.loadCert(c.certFile, c.keyFile)
+	return notify.Watch(filepath.Dir(p.keyFile), c.e, eventWrite...)
+}
+
+// load loads the certificate and key of p. If there is an issue
+// the loading will fail and the old (if any) certificate and key
+// will continue to be used.
+func (c *Certs) load(p *keyPair) error {
+	cert, err := c.loadCert(p.certFile, p.keyFile)
 	if err != nil {
 		return err
 	}
+	if cert.Leaf == nil && len(cert.Certificate) > 0 {
+		// The leaf certificate is used to match the SNI of clients.
+		if leaf, lerr := x509.ParseCertificate(cert.Certificate[0]); lerr == nil {
+			cert.Leaf = leaf
+		}
+	}
 	c.Lock()
-	c.cert = &cert
+	p.cert = &cert
 	c.Unlock()
-	if err != nil {
-		return err
-	}
-	go c.run()
 	return nil
 }
 
+// run reloads the pairs on any change of their certificate and key
+// files, and the symlinked ones periodically.
 func (c *Certs) run() {
-	for event := range c.e {
-		base := filepath.Base(event.Path())
-		if isWriteEvent(event.Event()) {
-			certChanged := base == filepath.Base(c.certFile)
-			keyChanged := base == filepath.Base(c.keyFile)
-			if certChanged || keyChanged {
-				cert, err := c.loadCert(c.certFile, c.keyFile)
-				if err != nil {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			// Once stopped exits this routine.
+			return
+		case event := <-c.e:
+			if !isWriteEvent(event.Event()) {
+				continue
+			}
+			base := filepath.Base(event.Path())
+			for _, p := range c.keyPairs() {
+				certChanged := base == filepath.Base(p.certFile)
+				keyChanged := base == filepath.Base(p.keyFile)
+				if !p.isLink && (certChanged || keyChanged) {
 					// ignore the error continue to use
 					// old certificates.
-					continue
+					c.load(p)
+				}
+			}
+		case <-ticker.C:
+			for _, p := range c.keyPairs() {
+				if p.isLink {
+					c.load(p)
 				}
-				c.Lock()
-				c.cert = &cert
-				c.Unlock()
 			}
 		}
 	}
 }
 
+func (c *Certs) keyPairs() []*keyPair {
+	c.RLock()
+	defer c.RUnlock()
+	return append([]*keyPair(nil), c.pairs...)
+}
+
 // GetCertificateFunc provides a GetCertificate type for custom client implementations.
 type GetCertificateFunc func(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
 
-// GetCertificate returns the loaded certificate for use by
-// the TLSConfig fields GetCertificate field in a http.Server.
+// GetCertificate returns the loaded certificate matching the SNI of
+// the client, or the first one, for use by the TLSConfig fields
+// GetCertificate field in a http.Server.
 func (c *Certs) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	c.RLock()
 	defer c.RUnlock()
-	return c.cert, nil
+	if hello != nil && hello.ServerName != "" {
+		for _, p := range c.pairs {
+			if p.cert.Leaf != nil && p.cert.Leaf.VerifyHostname(hello.ServerName) == nil {
+				return p.cert, nil
+			}
+		}
+	}
+	return c.pairs[0].cert, nil
 }
 
-// GetClientCertificate returns the loaded certificate for use by
+// GetClientCertificate returns the first loaded certificate for use by
 // the TLSConfig fields GetClientCertificate field in a http.Server.
 func (c *Certs) GetClientCertificate(_ *tls.CertificateRequestInfo) (*tls.Certificate, error) {
 	c.RLock()
 	defer c.RUnlock()
-	return c.cert, nil
+	return c.pairs[0].cert, nil
 }
 
 // Stop tells loader to stop watching for changes to the
 // certificate and key files.
 func (c *Certs) Stop() {
 	if c != nil {
-		notify.Stop(c.e)
+		c.stopOnce.Do(func() {
+			notify.Stop(c.e)
+			close(c.done)
+		})
 	}
 }
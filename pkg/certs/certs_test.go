@@ -132,3 +132,46 @@ func TestStop(t *testing.T) {
 		t.Error("certificate shouldn't match, but matched")
 	}
 }
+
+func TestCertSNI(t *testing.T) {
+	c, err := certs.New("server.crt", "server.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+	if err = c.AddCertificate("sni.crt", "sni.key"); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AddCertificate("sni.crt", "server2.key"); err == nil {
+		t.Fatal("Expected to fail but got success")
+	}
+
+	defaultCert, err := tls.LoadX509KeyPair("server.crt", "server.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sniCert, err := tls.LoadX509KeyPair("sni.crt", "sni.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		serverName   string
+		expectedCert tls.Certificate
+	}{
+		{"", defaultCert},
+		{"minio.io", defaultCert},
+		{"sni.minio.io", sniCert},
+		{"bucket.sni.minio.io", sniCert},
+		{"other.minio.io", defaultCert},
+	}
+	for i, testCase := range testCases {
+		gcert, err := c.GetCertificate(&tls.ClientHelloInfo{ServerName: testCase.serverName})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(gcert.Certificate, testCase.expectedCert.Certificate) {
+			t.Errorf("Test %d: certificate of %q doesn't match expected certificate", i+1, testCase.serverName)
+		}
+	}
+}
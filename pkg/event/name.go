@@ -42,6 +42,9 @@ const (
 	ObjectRemovedAll
 	ObjectRemovedDelete
 	ObjectRemovedDeleteMarkerCreated
+	// DriveReadOnly is raised when a failing drive is marked read-only,
+	// the event is not bound to a bucket or an object.
+	DriveReadOnly
 )
 
 // Expand - returns expanded values of abbreviated event type.
@@ -91,6 +94,8 @@ func (name Name) String() string {
 		return "s3:ObjectRemoved:Delete"
 	case ObjectRemovedDeleteMarkerCreated:
 		return "s3:ObjectRemoved:DeleteMarkerCreated"
+	case DriveReadOnly:
+		return "minio:Drive:ReadOnly"
 	}
 
 	return ""
@@ -171,6 +176,8 @@ func ParseName(s string) (Name, error) {
 		return ObjectRemovedDelete, nil
 	case "s3:ObjectRemoved:DeleteMarkerCreated":
 		return ObjectRemovedDeleteMarkerCreated, nil
+	case "minio:Drive:ReadOnly":
+		return DriveReadOnly, nil
 	default:
 		return 0, &ErrInvalidEventName{s}
 	}
@@ -64,6 +64,7 @@ func TestNameString(t *testing.T) {
 		{ObjectCreatedPutLegalHold, "s3:ObjectCreated:PutLegalHold"},
 		{ObjectAccessedGetRetention, "s3:ObjectAccessed:GetRetention"},
 		{ObjectAccessedGetLegalHold, "s3:ObjectAccessed:GetLegalHold"},
+		{DriveReadOnly, "minio:Drive:ReadOnly"},
 
 		{blankName, ""},
 	}
@@ -205,6 +206,7 @@ func TestParseName(t *testing.T) {
 	}{
 		{"s3:ObjectAccessed:*", ObjectAccessedAll, false},
 		{"s3:ObjectRemoved:Delete", ObjectRemovedDelete, false},
+		{"minio:Drive:ReadOnly", DriveReadOnly, false},
 		{"", blankName, true},
 	}
 
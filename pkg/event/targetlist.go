@@ -17,8 +17,11 @@
 package event
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Target - event target interface
@@ -35,6 +38,7 @@ type Target interface {
 type TargetList struct {
 	sync.RWMutex
 	targets map[TargetID]Target
+	pending int32 // counter of events being saved to targets.
 }
 
 // Add - adds unique target to target list.
@@ -119,7 +123,9 @@ func (list *TargetList) TargetMap() map[TargetID]Target {
 
 // Send - sends events to targets identified by target IDs.
 func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<- TargetIDResult) {
+	atomic.AddInt32(&list.pending, 1)
 	go func() {
+		defer atomic.AddInt32(&list.pending, -1)
 		var wg sync.WaitGroup
 		for id := range targetIDset {
 			list.RLock()
@@ -147,3 +153,18 @@ func (list *TargetList) Send(event Event, targetIDset TargetIDSet, resCh chan<-
 func NewTargetList() *TargetList {
 	return &TargetList{targets: make(map[TargetID]Target)}
 }
+
+// Flush - waits for the events being sent to be saved to their
+// targets, or until ctx is done.
+func (list *TargetList) Flush(ctx context.Context) error {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for atomic.LoadInt32(&list.pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
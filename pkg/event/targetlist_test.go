@@ -17,6 +17,7 @@
 package event
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"reflect"
@@ -253,6 +254,37 @@ func TestTargetListSend(t *testing.T) {
 	}
 }
 
+func TestTargetListFlush(t *testing.T) {
+	targetList := NewTargetList()
+	targetID := TargetID{"1", "testcase"}
+	if err := targetList.Add(&ExampleTarget{targetID, false, false}); err != nil {
+		panic(err)
+	}
+
+	resCh := make(chan TargetIDResult, 1)
+	targetList.Send(Event{}, map[TargetID]struct{}{targetID: {}}, resCh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := targetList.Flush(ctx); err != nil {
+		t.Fatalf("error: expected: <nil>, got: %v", err)
+	}
+	select {
+	case <-resCh:
+	default:
+		t.Fatalf("error: event not saved to target on flush")
+	}
+
+	// An event whose result is never received keeps the flush waiting.
+	targetList.Send(Event{}, map[TargetID]struct{}{targetID: {}}, make(chan TargetIDResult))
+
+	ctx, cancel = context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if err := targetList.Flush(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("error: expected: %v, got: %v", context.DeadlineExceeded, err)
+	}
+}
+
 func TestNewTargetList(t *testing.T) {
 	if result := NewTargetList(); result == nil {
 		t.Fatalf("test: result: expected: <non-nil>, got: <nil>")
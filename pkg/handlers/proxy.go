@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync/atomic"
 )
 
 var (
@@ -74,13 +75,95 @@ func GetSourceScheme(r *http.Request) string {
 	return scheme
 }
 
+// trustedProxies holds the []*net.IPNet of the proxies whose forwarding
+// headers are trusted, nil when all the senders are trusted.
+var trustedProxies atomic.Value
+
+// SetTrustedProxies limits the forwarding headers honored by GetSourceIP
+// to the requests sent by the given networks, an empty list trusts the
+// forwarding headers of all the requests.
+func SetTrustedProxies(nets []*net.IPNet) {
+	trustedProxies.Store(nets)
+}
+
+// ParseTrustedProxies parses a list of IP addresses and CIDR networks.
+func ParseTrustedProxies(values []string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if !strings.Contains(v, "/") {
+			ip := net.ParseIP(v)
+			if ip == nil {
+				return nil, &net.ParseError{Type: "IP address", Text: v}
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// HasTrustedProxies returns true when the forwarding headers are only
+// honored for the trusted proxies.
+func HasTrustedProxies() bool {
+	nets, _ := trustedProxies.Load().([]*net.IPNet)
+	return len(nets) > 0
+}
+
+// IsTrustedProxy returns true if the forwarding headers sent by the
+// given host are honored.
+func IsTrustedProxy(host string) bool {
+	nets, _ := trustedProxies.Load().([]*net.IPNet)
+	if len(nets) == 0 {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetSourceIP retrieves the IP from the X-Forwarded-For, X-Real-IP and RFC7239
 // Forwarded headers (in that order), falls back to r.RemoteAddr when all
-// else fails.
+// else fails. When trusted proxies are set, the headers are only honored
+// for the requests sent by them.
 func GetSourceIP(r *http.Request) string {
+	remoteAddr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteAddr = r.RemoteAddr
+	}
+	if !IsTrustedProxy(remoteAddr) {
+		return remoteAddr
+	}
+
 	var addr string
 
-	if fwd := r.Header.Get(xForwardedFor); fwd != "" {
+	if fwd := r.Header.Get(xForwardedFor); fwd != "" && HasTrustedProxies() {
+		// The proxies append the address of their client, the client
+		// address is the last one not sent by a trusted proxy.
+		hops := strings.Split(fwd, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr = strings.TrimSpace(hops[i])
+			if !IsTrustedProxy(addr) {
+				break
+			}
+		}
+	} else if fwd != "" {
 		// Only grab the first (client) address. Note that '192.168.0.1,
 		// 10.1.1.1' is a valid key for X-Forwarded-For where addresses after
 		// the first may represent forwarding proxies earlier in the chain.
@@ -111,6 +194,5 @@ func GetSourceIP(r *http.Request) string {
 	}
 
 	// Default to remote address if headers not set.
-	addr, _, _ = net.SplitHostPort(r.RemoteAddr)
-	return addr
+	return remoteAddr
 }
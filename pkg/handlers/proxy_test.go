@@ -81,3 +81,48 @@ func TestGetSourceIP(t *testing.T) {
 		}
 	}
 }
+
+// TestGetSourceIPTrustedProxies - check the forwarding headers are only
+// honored for the trusted proxies.
+func TestGetSourceIPTrustedProxies(t *testing.T) {
+	nets, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTrustedProxies(nets)
+	defer SetTrustedProxies(nil)
+
+	testCases := []struct {
+		remoteAddr string
+		key        string
+		val        string
+		expected   string
+	}{
+		{"10.1.2.3:9000", xForwardedFor, "8.8.8.8", "8.8.8.8"},
+		{"10.1.2.3:9000", xForwardedFor, "1.1.1.1, 8.8.8.8, 10.0.0.2", "8.8.8.8"},
+		{"10.1.2.3:9000", xForwardedFor, "10.0.0.3, 192.168.1.1", "10.0.0.3"},
+		{"192.168.1.1:9000", xRealIP, "8.8.8.8", "8.8.8.8"},
+		{"[::1]:9000", forwarded, `for=192.0.2.60;proto=http`, "192.0.2.60"},
+		{"10.1.2.3:9000", xForwardedFor, "", "10.1.2.3"},
+		{"192.168.1.2:9000", xForwardedFor, "8.8.8.8", "192.168.1.2"},
+		{"8.8.4.4:9000", xRealIP, "8.8.8.8", "8.8.4.4"},
+		{"[2001:db8::1]:9000", forwarded, `for=192.0.2.60`, "2001:db8::1"},
+	}
+	for i, testCase := range testCases {
+		req := &http.Request{
+			RemoteAddr: testCase.remoteAddr,
+			Header: http.Header{
+				testCase.key: []string{testCase.val},
+			}}
+		if res := GetSourceIP(req); res != testCase.expected {
+			t.Errorf("Test %d: got %s want %s", i+1, res, testCase.expected)
+		}
+	}
+
+	if _, err = ParseTrustedProxies([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("expected an error for an invalid network")
+	}
+	if _, err = ParseTrustedProxies([]string{"proxy.local"}); err == nil {
+		t.Error("expected an error for an invalid address")
+	}
+}
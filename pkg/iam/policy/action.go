@@ -107,6 +107,21 @@ const (
 	// PutObjectVersionTaggingAction - PutObjectVersionTagging Rest API action.
 	PutObjectVersionTaggingAction = "s3:PutObjectVersionTagging"
 
+	// RestoreObjectAction - RestoreObject Rest API action.
+	RestoreObjectAction = "s3:RestoreObject"
+
+	// GetReplicationConfigurationAction - GetBucketReplication REST API action
+	GetReplicationConfigurationAction = "s3:GetReplicationConfiguration"
+
+	// PutReplicationConfigurationAction - PutBucketReplication REST API action
+	PutReplicationConfigurationAction = "s3:PutReplicationConfiguration"
+
+	// ReplicateObjectAction - replicate objects to a bucket.
+	ReplicateObjectAction = "s3:ReplicateObject"
+
+	// ReplicateDeleteAction - replicate deletes to a bucket.
+	ReplicateDeleteAction = "s3:ReplicateDelete"
+
 	// BypassGovernanceRetentionAction - bypass governance retention for PutObjectRetention, PutObject and DeleteObject Rest API action.
 	BypassGovernanceRetentionAction = "s3:BypassGovernanceRetention"
 
@@ -196,6 +211,7 @@ var supportedActions = map[Action]struct{}{
 	DeleteObjectVersionAction:              {},
 	DeleteObjectVersionTaggingAction:       {},
 	PutObjectVersionTaggingAction:          {},
+	RestoreObjectAction:                    {},
 	GetObjectTaggingAction:                 {},
 	PutObjectTaggingAction:                 {},
 	DeleteObjectTaggingAction:              {},
@@ -203,6 +219,10 @@ var supportedActions = map[Action]struct{}{
 	GetBucketEncryptionAction:              {},
 	PutBucketVersioningAction:              {},
 	GetBucketVersioningAction:              {},
+	GetReplicationConfigurationAction:      {},
+	PutReplicationConfigurationAction:      {},
+	ReplicateObjectAction:                  {},
+	ReplicateDeleteAction:                  {},
 	AllActions:                             {},
 }
 
@@ -227,6 +247,9 @@ var supportedObjectActions = map[Action]struct{}{
 	DeleteObjectVersionAction:        {},
 	DeleteObjectVersionTaggingAction: {},
 	PutObjectVersionTaggingAction:    {},
+	RestoreObjectAction:              {},
+	ReplicateObjectAction:            {},
+	ReplicateDeleteAction:            {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -337,6 +360,7 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 	DeleteObjectTaggingAction:              condition.NewKeySet(condition.CommonKeys...),
 
 	PutObjectVersionTaggingAction: condition.NewKeySet(condition.CommonKeys...),
+	RestoreObjectAction:           condition.NewKeySet(condition.CommonKeys...),
 	GetObjectVersionAction: condition.NewKeySet(
 		append([]condition.Key{
 			condition.S3VersionID,
@@ -353,4 +377,9 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 		append([]condition.Key{
 			condition.S3VersionID,
 		}, condition.CommonKeys...)...),
+
+	GetReplicationConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+	PutReplicationConfigurationAction: condition.NewKeySet(condition.CommonKeys...),
+	ReplicateObjectAction:             condition.NewKeySet(condition.CommonKeys...),
+	ReplicateDeleteAction:             condition.NewKeySet(condition.CommonKeys...),
 }
@@ -108,46 +108,131 @@ const (
 	// GetBucketQuotaAdminAction - allow getting bucket quota
 	GetBucketQuotaAdminAction = "admin:GetBucketQuota"
 
+	// SetBucketHooksAdminAction - allow setting bucket hooks
+	SetBucketHooksAdminAction = "admin:SetBucketHooks"
+	// GetBucketHooksAdminAction - allow getting bucket hooks
+	GetBucketHooksAdminAction = "admin:GetBucketHooks"
+
+	// SetBucketTargetAdminAction - allow setting the remote targets of buckets
+	SetBucketTargetAdminAction = "admin:SetBucketTarget"
+	// GetBucketTargetAdminAction - allow listing the remote targets of buckets
+	GetBucketTargetAdminAction = "admin:GetBucketTarget"
+
+	// MigrateFSAdminAction - allow migrating an FS deployment into erasure mode
+	MigrateFSAdminAction = "admin:MigrateFS"
+
+	// BucketMirrorAdminAction - allow mirroring buckets to remote targets
+	BucketMirrorAdminAction = "admin:BucketMirror"
+
+	// DecommissionAdminAction - allow decommissioning zones of the server
+	DecommissionAdminAction = "admin:Decommission"
+
+	// TenantAdminAction - allow managing the tenants of the server
+	TenantAdminAction = "admin:Tenant"
+
+	// ClusterMigrationAdminAction - allow migrating the buckets of other clusters
+	ClusterMigrationAdminAction = "admin:ClusterMigration"
+
+	// SetBucketReadReplicaAdminAction - allow making buckets read replicas of remote sources
+	SetBucketReadReplicaAdminAction = "admin:SetBucketReadReplica"
+	// GetBucketReadReplicaAdminAction - allow getting the sources of read replica buckets
+	GetBucketReadReplicaAdminAction = "admin:GetBucketReadReplica"
+
+	// ExportMetadataAdminAction - allow exporting the metadata of the cluster
+	ExportMetadataAdminAction = "admin:ExportMetadata"
+	// ImportMetadataAdminAction - allow importing the metadata of the cluster
+	ImportMetadataAdminAction = "admin:ImportMetadata"
+
+	// VerifyObjectAdminAction - allow verifying the integrity of objects
+	VerifyObjectAdminAction = "admin:VerifyObject"
+
+	// FaultInjectionAdminAction - allow getting and setting the faults
+	// injected in test clusters
+	FaultInjectionAdminAction = "admin:FaultInjection"
+
+	// SetBucketBandwidthAdminAction - allow setting the bandwidth limits of buckets
+	SetBucketBandwidthAdminAction = "admin:SetBucketBandwidth"
+	// GetBucketBandwidthAdminAction - allow getting the bandwidth limits of buckets
+	GetBucketBandwidthAdminAction = "admin:GetBucketBandwidth"
+
+	// TopObjectsAdminAction - allow getting the most downloaded objects
+	TopObjectsAdminAction = "admin:TopObjects"
+
+	// BucketSnapshotAdminAction - allow creating, deleting and cloning
+	// bucket snapshots
+	BucketSnapshotAdminAction = "admin:BucketSnapshot"
+
+	// SetBucketSecureEraseAdminAction - allow setting the secure erase configuration of buckets
+	SetBucketSecureEraseAdminAction = "admin:SetBucketSecureErase"
+	// GetBucketSecureEraseAdminAction - allow getting the secure erase configuration of buckets
+	GetBucketSecureEraseAdminAction = "admin:GetBucketSecureErase"
+
+	// AuditTrailAdminAction - allow getting and verifying the audit trail
+	// stored in the cluster
+	AuditTrailAdminAction = "admin:AuditTrail"
+
 	// AllAdminActions - provides all admin permissions
 	AllAdminActions = "admin:*"
 )
 
 // List of all supported admin actions.
 var supportedAdminActions = map[AdminAction]struct{}{
-	HealAdminAction:                {},
-	StorageInfoAdminAction:         {},
-	DataUsageInfoAdminAction:       {},
-	TopLocksAdminAction:            {},
-	ProfilingAdminAction:           {},
-	TraceAdminAction:               {},
-	ConsoleLogAdminAction:          {},
-	KMSKeyStatusAdminAction:        {},
-	ServerInfoAdminAction:          {},
-	OBDInfoAdminAction:             {},
-	ServerUpdateAdminAction:        {},
-	ServiceRestartAdminAction:      {},
-	ServiceStopAdminAction:         {},
-	ConfigUpdateAdminAction:        {},
-	CreateUserAdminAction:          {},
-	DeleteUserAdminAction:          {},
-	ListUsersAdminAction:           {},
-	EnableUserAdminAction:          {},
-	DisableUserAdminAction:         {},
-	GetUserAdminAction:             {},
-	AddUserToGroupAdminAction:      {},
-	RemoveUserFromGroupAdminAction: {},
-	GetGroupAdminAction:            {},
-	ListGroupsAdminAction:          {},
-	EnableGroupAdminAction:         {},
-	DisableGroupAdminAction:        {},
-	CreatePolicyAdminAction:        {},
-	DeletePolicyAdminAction:        {},
-	GetPolicyAdminAction:           {},
-	AttachPolicyAdminAction:        {},
-	ListUserPoliciesAdminAction:    {},
-	SetBucketQuotaAdminAction:      {},
-	GetBucketQuotaAdminAction:      {},
-	AllAdminActions:                {},
+	HealAdminAction:                 {},
+	StorageInfoAdminAction:          {},
+	DataUsageInfoAdminAction:        {},
+	TopLocksAdminAction:             {},
+	ProfilingAdminAction:            {},
+	TraceAdminAction:                {},
+	ConsoleLogAdminAction:           {},
+	KMSKeyStatusAdminAction:         {},
+	ServerInfoAdminAction:           {},
+	OBDInfoAdminAction:              {},
+	ServerUpdateAdminAction:         {},
+	ServiceRestartAdminAction:       {},
+	ServiceStopAdminAction:          {},
+	ConfigUpdateAdminAction:         {},
+	CreateUserAdminAction:           {},
+	DeleteUserAdminAction:           {},
+	ListUsersAdminAction:            {},
+	EnableUserAdminAction:           {},
+	DisableUserAdminAction:          {},
+	GetUserAdminAction:              {},
+	AddUserToGroupAdminAction:       {},
+	RemoveUserFromGroupAdminAction:  {},
+	GetGroupAdminAction:             {},
+	ListGroupsAdminAction:           {},
+	EnableGroupAdminAction:          {},
+	DisableGroupAdminAction:         {},
+	CreatePolicyAdminAction:         {},
+	DeletePolicyAdminAction:         {},
+	GetPolicyAdminAction:            {},
+	AttachPolicyAdminAction:         {},
+	ListUserPoliciesAdminAction:     {},
+	SetBucketQuotaAdminAction:       {},
+	GetBucketQuotaAdminAction:       {},
+	SetBucketHooksAdminAction:       {},
+	GetBucketHooksAdminAction:       {},
+	SetBucketTargetAdminAction:      {},
+	GetBucketTargetAdminAction:      {},
+	MigrateFSAdminAction:            {},
+	BucketMirrorAdminAction:         {},
+	DecommissionAdminAction:         {},
+	TenantAdminAction:               {},
+	ClusterMigrationAdminAction:     {},
+	SetBucketReadReplicaAdminAction: {},
+	GetBucketReadReplicaAdminAction: {},
+	ExportMetadataAdminAction:       {},
+	ImportMetadataAdminAction:       {},
+	VerifyObjectAdminAction:         {},
+	FaultInjectionAdminAction:       {},
+	SetBucketBandwidthAdminAction:   {},
+	GetBucketBandwidthAdminAction:   {},
+	TopObjectsAdminAction:           {},
+	BucketSnapshotAdminAction:       {},
+	SetBucketSecureEraseAdminAction: {},
+	GetBucketSecureEraseAdminAction: {},
+	AuditTrailAdminAction:           {},
+	AllAdminActions:                 {},
 }
 
 // IsValid - checks if action is valid or not.
@@ -158,37 +243,59 @@ func (action AdminAction) IsValid() bool {
 
 // adminActionConditionKeyMap - holds mapping of supported condition key for an action.
 var adminActionConditionKeyMap = map[Action]condition.KeySet{
-	AllAdminActions:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	HealAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	StorageInfoAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DataUsageInfoAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	OBDInfoAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TopLocksAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ProfilingAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	TraceAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConsoleLogAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	KMSKeyStatusAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServerUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServiceRestartAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ServiceStopAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ConfigUpdateAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreateUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeleteUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUsersAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableUserAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetUserAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AddUserToGroupAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	RemoveUserFromGroupAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListGroupsAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	EnableGroupAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DisableGroupAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	CreatePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	DeletePolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetPolicyAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	AttachPolicyAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	ListUserPoliciesAdminAction:    condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	SetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
-	GetBucketQuotaAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AllAdminActions:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	HealAdminAction:                 condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	StorageInfoAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerInfoAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DataUsageInfoAdminAction:        condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	OBDInfoAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TopLocksAdminAction:             condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ProfilingAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TraceAdminAction:                condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConsoleLogAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	KMSKeyStatusAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServerUpdateAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServiceRestartAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ServiceStopAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ConfigUpdateAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreateUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeleteUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUsersAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableUserAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableUserAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetUserAdminAction:              condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AddUserToGroupAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	RemoveUserFromGroupAdminAction:  condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListGroupsAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	EnableGroupAdminAction:          condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DisableGroupAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	CreatePolicyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DeletePolicyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetPolicyAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AttachPolicyAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ListUserPoliciesAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketQuotaAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketQuotaAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketHooksAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketHooksAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketTargetAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketTargetAdminAction:      condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	MigrateFSAdminAction:            condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	BucketMirrorAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	DecommissionAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TenantAdminAction:               condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ClusterMigrationAdminAction:     condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketReadReplicaAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketReadReplicaAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ExportMetadataAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	ImportMetadataAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	VerifyObjectAdminAction:         condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	FaultInjectionAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketBandwidthAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketBandwidthAdminAction:   condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	TopObjectsAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	BucketSnapshotAdminAction:       condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	SetBucketSecureEraseAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	GetBucketSecureEraseAdminAction: condition.NewKeySet(condition.AllSupportedAdminKeys...),
+	AuditTrailAdminAction:           condition.NewKeySet(condition.AllSupportedAdminKeys...),
 }
@@ -0,0 +1,82 @@
+// +build linux
+
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ioutil
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// AppendFile - appends the file "src" to the file "dst", the data
+// is copied within the kernel using copy_file_range(2) which shares
+// the extents instead of copying them on filesystems supporting
+// reflinks (XFS, btrfs). Falls back to a regular copy otherwise.
+func AppendFile(dst string, src string, osync bool) error {
+	// copy_file_range(2) doesn't accept O_APPEND descriptors,
+	// so write explicitly at the end of the file instead.
+	flags := os.O_WRONLY | os.O_CREATE
+	if osync {
+		flags = flags | os.O_SYNC
+	}
+	appendFile, err := os.OpenFile(dst, flags, 0666)
+	if err != nil {
+		return err
+	}
+	defer appendFile.Close()
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	fi, err := appendFile.Stat()
+	if err != nil {
+		return err
+	}
+	offset := fi.Size()
+
+	for {
+		n, err := unix.CopyFileRange(int(srcFile.Fd()), nil, int(appendFile.Fd()), &offset, defaultAppendBufferSize, 0)
+		if err != nil {
+			switch err {
+			case unix.ENOSYS, unix.EXDEV, unix.EINVAL, unix.EOPNOTSUPP, unix.EPERM:
+				// Not supported for these files, copy
+				// the remaining data in user space.
+				return appendFileCopy(appendFile, srcFile, offset)
+			}
+			return &os.PathError{Op: "copy_file_range", Path: dst, Err: err}
+		}
+		if n == 0 {
+			return nil
+		}
+	}
+}
+
+func appendFileCopy(appendFile, srcFile *os.File, offset int64) error {
+	if _, err := appendFile.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	// Allocate staging buffer.
+	var buf = make([]byte, defaultAppendBufferSize)
+	_, err := io.CopyBuffer(appendFile, srcFile, buf)
+	return err
+}
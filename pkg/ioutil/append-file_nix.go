@@ -1,4 +1,4 @@
-// +build !windows
+// +build !windows,!linux
 
 /*
  * MinIO Cloud Storage, (C) 2018 MinIO, Inc.
@@ -76,6 +76,41 @@ func TestAppendFile(t *testing.T) {
 	}
 }
 
+// Test for AppendFile with sources larger than the staging buffer.
+func TestAppendFileLarge(t *testing.T) {
+	dir, err := goioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	part1 := bytes.Repeat([]byte("a"), 3*defaultAppendBufferSize+1)
+	part2 := bytes.Repeat([]byte("b"), defaultAppendBufferSize-1)
+	name1, name2 := dir+"/part.1", dir+"/part.2"
+	if err = goioutil.WriteFile(name1, part1, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err = goioutil.WriteFile(name2, part2, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := dir + "/append"
+	for _, src := range []string{name1, name2, name1} {
+		if err = AppendFile(dst, src, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := goioutil.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append(append(append([]byte{}, part1...), part2...), part1...)
+	if !bytes.Equal(b, expected) {
+		t.Errorf("AppendFile() failed, expected %d bytes, got %d bytes", len(expected), len(b))
+	}
+}
+
 func TestSkipReader(t *testing.T) {
 	testCases := []struct {
 		src      io.Reader
@@ -0,0 +1,83 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// AccessStat holds the downloads of an object, or of the objects
+// directly under a prefix. The counts are estimates, they may be
+// overestimated for the least accessed entries of a report.
+type AccessStat struct {
+	Bucket string `json:"bucket"`
+	// Name is the object name, or the prefix ending with a slash,
+	// empty for the objects at the root of the bucket.
+	Name  string `json:"name"`
+	Reads uint64 `json:"reads"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// AccessReport holds the most downloaded objects and prefixes of the
+// cluster, in decreasing order of reads.
+type AccessReport struct {
+	Objects  []AccessStat `json:"objects"`
+	Prefixes []AccessStat `json:"prefixes"`
+}
+
+// TopObjects - returns the count most downloaded objects and prefixes,
+// of the bucket or of all the buckets when bucket is empty.
+func (adm *AdminClient) TopObjects(ctx context.Context, bucket string, count int) (report AccessReport, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	if count > 0 {
+		queryValues.Set("count", strconv.Itoa(count))
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/top-objects",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/top-objects
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return report, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return report, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return report, err
+	}
+	if err = json.Unmarshal(data, &report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,107 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// AuditTrailVerification holds the result of the verification of the
+// audit trail of a day of a server node.
+type AuditTrailVerification struct {
+	Node    string `json:"node"`
+	Date    string `json:"date"`
+	Records int    `json:"records"`
+	Valid   bool   `json:"valid"`
+	// Sequence number of the first record failing the
+	// verification, when not valid.
+	InvalidSeq uint64 `json:"invalidSeq,omitempty"`
+	// Sequence number of the signed checkpoint the trail was
+	// verified to reach, none when zero.
+	Checkpoint uint64 `json:"checkpoint,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// GetAuditTrail - returns the audit trail of a day, formatted as
+// YYYY-MM-DD, of a server node, one JSON record per line.
+func (adm *AdminClient) GetAuditTrail(ctx context.Context, node, date string) (io.ReadCloser, error) {
+	queryValues := url.Values{}
+	queryValues.Set("node", node)
+	queryValues.Set("date", date)
+
+	// Execute GET on /minio/admin/v3/audit-trail
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/audit-trail",
+			queryValues: queryValues,
+		},
+	)
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	if resp.Body == nil {
+		return nil, errors.New("body is nil")
+	}
+	return resp.Body, nil
+}
+
+// VerifyAuditTrail - verifies the hash chains of the audit trails of a
+// day, formatted as YYYY-MM-DD, of all the server nodes.
+func (adm *AdminClient) VerifyAuditTrail(ctx context.Context, date string) ([]AuditTrailVerification, error) {
+	queryValues := url.Values{}
+	queryValues.Set("date", date)
+
+	// Execute GET on /minio/admin/v3/audit-trail/verify
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/audit-trail/verify",
+			queryValues: queryValues,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	response, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var verifications []AuditTrailVerification
+	err = json.Unmarshal(response, &verifications)
+	return verifications, err
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// BucketBandwidth holds the bandwidth limits of the object downloads
+// and uploads of a bucket, in bytes per second, a zero limit disables
+// it. The limits apply to each server.
+type BucketBandwidth struct {
+	// Limit is shared by all the requests of the bucket.
+	Limit int64 `json:"limit,omitempty"`
+	// RequestLimit applies to each request of the bucket.
+	RequestLimit int64 `json:"requestLimit,omitempty"`
+}
+
+// IsValid returns false if one of the limits is negative.
+func (b BucketBandwidth) IsValid() bool {
+	return b.Limit >= 0 && b.RequestLimit >= 0
+}
+
+// GetBucketBandwidth - returns the bandwidth limits of a bucket.
+func (adm *AdminClient) GetBucketBandwidth(ctx context.Context, bucket string) (b BucketBandwidth, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-bandwidth",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-bandwidth
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return b, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return b, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return b, err
+	}
+	if err = json.Unmarshal(data, &b); err != nil {
+		return b, err
+	}
+
+	return b, nil
+}
+
+// SetBucketBandwidth - sets the bandwidth limits of a bucket, zero
+// limits remove them.
+func (adm *AdminClient) SetBucketBandwidth(ctx context.Context, bucket string, bandwidth BucketBandwidth) error {
+	data, err := json.Marshal(bandwidth)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-bandwidth",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-bandwidth
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ClusterMigrationSource is the S3 compatible cluster whose buckets
+// are migrated into the server.
+type ClusterMigrationSource struct {
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
+
+// ClusterMigrationJob describes the buckets to migrate from a source.
+type ClusterMigrationJob struct {
+	Source ClusterMigrationSource `json:"source"`
+
+	// Buckets to migrate, all the buckets of the source when empty.
+	Buckets []string `json:"buckets,omitempty"`
+}
+
+// ClusterMigrationStatus holds the progress of a cluster migration job.
+type ClusterMigrationStatus struct {
+	ID        string              `json:"id"`
+	Job       ClusterMigrationJob `json:"job"`
+	Running   bool                `json:"running"`
+	Complete  bool                `json:"complete"`
+	StartTime time.Time           `json:"startTime"`
+	EndTime   time.Time           `json:"endTime"`
+
+	// Last object migrated, the job resumes right after this object.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+	Skipped int64  `json:"skipped"`
+	Failed  int64  `json:"failed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ClusterMigrationBucketReport compares a bucket of the source with
+// its copy once the migration is complete.
+type ClusterMigrationBucketReport struct {
+	Bucket        string `json:"bucket"`
+	SourceObjects int64  `json:"sourceObjects"`
+	SourceBytes   int64  `json:"sourceBytes"`
+	Verified      int64  `json:"verified"`
+
+	// Objects of the source missing on the server, or with a
+	// different size or ETag, only the first ones are listed.
+	MissingCount    int64    `json:"missingCount"`
+	Missing         []string `json:"missing,omitempty"`
+	MismatchedCount int64    `json:"mismatchedCount"`
+	Mismatched      []string `json:"mismatched,omitempty"`
+}
+
+// ClusterMigrationReport is the final reconciliation of a complete
+// cluster migration job.
+type ClusterMigrationReport struct {
+	ID      string                         `json:"id"`
+	Time    time.Time                      `json:"time"`
+	Buckets []ClusterMigrationBucketReport `json:"buckets"`
+}
+
+// startClusterMigrationResp is the response of a cluster migration start.
+type startClusterMigrationResp struct {
+	ID string `json:"id"`
+}
+
+// StartClusterMigration - starts migrating the buckets of a source
+// cluster into the server, returns the ID of the migration job.
+func (adm *AdminClient) StartClusterMigration(ctx context.Context, job ClusterMigrationJob) (string, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return "", err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/migrate-cluster",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/migrate-cluster to start the migration.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var startResp startClusterMigrationResp
+	if err = json.NewDecoder(resp.Body).Decode(&startResp); err != nil {
+		return "", err
+	}
+	return startResp.ID, nil
+}
+
+// ResumeClusterMigration - resumes an interrupted cluster migration
+// job after its last checkpoint, a complete job migrates and verifies
+// all the buckets again.
+func (adm *AdminClient) ResumeClusterMigration(ctx context.Context, id string) error {
+	return adm.clusterMigrationAction(ctx, "resume", id)
+}
+
+// CancelClusterMigration - stops a running cluster migration job, it
+// can be resumed later on.
+func (adm *AdminClient) CancelClusterMigration(ctx context.Context, id string) error {
+	return adm.clusterMigrationAction(ctx, "cancel", id)
+}
+
+func (adm *AdminClient) clusterMigrationAction(ctx context.Context, action, id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/migrate-cluster/" + action,
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/migrate-cluster/{action}
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ClusterMigrationStatus - returns the status of all the cluster
+// migration jobs, the secret keys of their sources are never returned.
+func (adm *AdminClient) ClusterMigrationStatus(ctx context.Context) (s []ClusterMigrationStatus, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/migrate-cluster",
+	}
+
+	// Execute GET on /minio/admin/v3/migrate-cluster
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// ClusterMigrationReport - returns the reconciliation report of the
+// complete cluster migration job id.
+func (adm *AdminClient) ClusterMigrationReport(ctx context.Context, id string) (r ClusterMigrationReport, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/migrate-cluster/report",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/migrate-cluster/report
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
@@ -53,7 +53,8 @@ func (adm *AdminClient) ClearConfigHistoryKV(ctx context.Context, restoreID stri
 }
 
 // RestoreConfigHistoryKV - Restore a previous config set history.
-// Input is a unique id which represents the previous setting.
+// Input is a unique id which represents the previous setting, the
+// restore is itself saved as a new config history entry.
 func (adm *AdminClient) RestoreConfigHistoryKV(ctx context.Context, restoreID string) (err error) {
 	v := url.Values{}
 	v.Set("restoreId", restoreID)
@@ -78,10 +79,11 @@ func (adm *AdminClient) RestoreConfigHistoryKV(ctx context.Context, restoreID st
 }
 
 // ConfigHistoryEntry - captures config set history with a unique
-// restore ID and createTime
+// restore ID, createTime and the author of the change
 type ConfigHistoryEntry struct {
 	RestoreID  string    `json:"restoreId"`
 	CreateTime time.Time `json:"createTime"`
+	Author     string    `json:"author,omitempty"`
 	Data       string    `json:"data"`
 }
 
@@ -125,3 +127,47 @@ func (adm *AdminClient) ListConfigHistoryKV(ctx context.Context, count int) ([]C
 
 	return chEntries, nil
 }
+
+// ConfigHistoryDiff - captures a config key which has a different
+// value in a config history entry and in the current config.
+type ConfigHistoryDiff struct {
+	SubSys   string `json:"subSys"`
+	Target   string `json:"target"`
+	Key      string `json:"key"`
+	Revision string `json:"revision"`
+	Current  string `json:"current"`
+}
+
+// DiffConfigHistoryKV - lists the config keys which differ between the
+// config history entry represented by restoreID and the current config.
+func (adm *AdminClient) DiffConfigHistoryKV(ctx context.Context, restoreID string) ([]ConfigHistoryDiff, error) {
+	v := url.Values{}
+	v.Set("restoreId", restoreID)
+
+	// Execute GET on /minio/admin/v3/diff-config-history-kv
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/diff-config-history-kv",
+			queryValues: v,
+		})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []ConfigHistoryDiff
+	if err = json.Unmarshal(data, &diffs); err != nil {
+		return nil, err
+	}
+
+	return diffs, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DecommissionStatus holds the progress of the decommission of a zone,
+// or of a drive, zones are numbered from 1 in the order of the server
+// command line.
+type DecommissionStatus struct {
+	Zone      int      `json:"zone"`
+	Endpoints []string `json:"endpoints"`
+
+	// Drive being drained along with its erasure set, numbered from
+	// 1 in the zone, empty when the whole zone is decommissioned.
+	Drive string `json:"drive,omitempty"`
+	Set   int    `json:"set,omitempty"`
+
+	Running   bool      `json:"running"`
+	Complete  bool      `json:"complete"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	// Last object moved to the other zones.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+	Failed  int64  `json:"failed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// StartDecommission - marks the zone as draining, no new objects are
+// placed on the zone while its objects are moved to the other zones.
+// Starting the decommission of a draining zone resumes moving its
+// objects.
+func (adm *AdminClient) StartDecommission(ctx context.Context, zone int) error {
+	return adm.decommissionAction(ctx, http.MethodPut, "/decommission", "zone", strconv.Itoa(zone))
+}
+
+// CancelDecommission - stops moving the objects of the zone, the zone
+// takes new objects again.
+func (adm *AdminClient) CancelDecommission(ctx context.Context, zone int) error {
+	return adm.decommissionAction(ctx, http.MethodPost, "/decommission/cancel", "zone", strconv.Itoa(zone))
+}
+
+// StartDriveDecommission - marks the erasure set of the drive, given
+// as on the server command line, as draining. No new objects are placed
+// on the set while its objects are moved to the other zones, once
+// complete the drive can be removed.
+func (adm *AdminClient) StartDriveDecommission(ctx context.Context, drive string) error {
+	return adm.decommissionAction(ctx, http.MethodPut, "/decommission", "drive", drive)
+}
+
+// CancelDriveDecommission - stops moving the objects of the erasure set
+// of the drive, the set takes new objects again.
+func (adm *AdminClient) CancelDriveDecommission(ctx context.Context, drive string) error {
+	return adm.decommissionAction(ctx, http.MethodPost, "/decommission/cancel", "drive", drive)
+}
+
+func (adm *AdminClient) decommissionAction(ctx context.Context, method, relPath, key, value string) error {
+	queryValues := url.Values{}
+	queryValues.Set(key, value)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + relPath,
+		queryValues: queryValues,
+	}
+
+	resp, err := adm.executeMethod(ctx, method, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// DecommissionStatus - returns the status of the zones being
+// decommissioned, a complete zone can be removed from the command
+// line of the servers.
+func (adm *AdminClient) DecommissionStatus(ctx context.Context) (s []DecommissionStatus, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/decommission",
+	}
+
+	// Execute GET on /minio/admin/v3/decommission
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
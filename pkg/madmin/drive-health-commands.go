@@ -0,0 +1,47 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ClearDriveReadOnly - clears the read-only mark of a drive predicted
+// to fail, the drive is its endpoint as reported by ServerInfo.
+func (adm *AdminClient) ClearDriveReadOnly(ctx context.Context, drive string) error {
+	queryValues := url.Values{}
+	queryValues.Set("drive", drive)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/drive-health/clear",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/drive-health/clear
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
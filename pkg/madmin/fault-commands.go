@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Layers faults are injected in.
+const (
+	// FaultLayerStorage - the operations of the drives.
+	FaultLayerStorage = "storage"
+	// FaultLayerRPC - the calls to the other servers.
+	FaultLayerRPC = "rpc"
+)
+
+// Errors returned by faulted operations.
+const (
+	// FaultErrFaultyDisk - the drive fails with an I/O error.
+	FaultErrFaultyDisk = "faulty-disk"
+	// FaultErrDiskNotFound - the drive is unreachable.
+	FaultErrDiskNotFound = "disk-not-found"
+	// FaultErrDiskFull - the drive is out of space.
+	FaultErrDiskFull = "disk-full"
+	// FaultErrFileNotFound - the file is missing.
+	FaultErrFileNotFound = "file-not-found"
+	// FaultErrFileCorrupt - the file is corrupted.
+	FaultErrFileCorrupt = "file-corrupt"
+	// FaultErrDrop - the call to the other server is dropped,
+	// only valid for the RPC layer.
+	FaultErrDrop = "drop"
+)
+
+// FaultRule - a fault injected in the operations of the drives or
+// in the calls to the other servers.
+type FaultRule struct {
+	// Layer is FaultLayerStorage or FaultLayerRPC.
+	Layer string `json:"layer"`
+	// Target limits the rule to the drives whose endpoint, or to the
+	// servers whose host, contains it, all when empty.
+	Target string `json:"target,omitempty"`
+	// Operations limits the rule to these drive operations, like
+	// "CreateFile", or RPC methods, like "/lock", all when empty.
+	Operations []string `json:"operations,omitempty"`
+	// Probability of a matching operation to be faulted, between 0
+	// and 1, every matching operation is faulted when zero.
+	Probability float64 `json:"probability,omitempty"`
+	// Latency added to the faulted operations.
+	Latency time.Duration `json:"latency,omitempty"`
+	// Error returned by the faulted operations, one of FaultErr*,
+	// only the latency is added when empty.
+	Error string `json:"error,omitempty"`
+	// PartialWrite writes only the first half of the data of the
+	// faulted drive writes, silently when Error is empty.
+	PartialWrite bool `json:"partialWrite,omitempty"`
+}
+
+// FaultConfig - the faults injected by the servers.
+type FaultConfig struct {
+	Rules []FaultRule `json:"rules"`
+}
+
+// GetFaults - returns the faults injected by the server.
+func (adm *AdminClient) GetFaults(ctx context.Context) (FaultConfig, error) {
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{relPath: adminAPIPrefix + "/faults"},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return FaultConfig{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return FaultConfig{}, httpRespToErrorResponse(resp)
+	}
+
+	response, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return FaultConfig{}, err
+	}
+
+	var config FaultConfig
+	err = json.Unmarshal(response, &config)
+	return config, err
+}
+
+// SetFaults - replaces the faults injected by all the servers, an
+// empty config stops injecting faults. The servers must be started
+// with MINIO_FAULT_INJECTION=on.
+func (adm *AdminClient) SetFaults(ctx context.Context, config FaultConfig) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPut,
+		requestData{
+			relPath: adminAPIPrefix + "/faults",
+			content: data,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Kinds of garbage left on drives by interrupted operations.
+const (
+	// GarbageTmp - an entry of the tmp directory of a drive.
+	GarbageTmp = "tmp"
+	// GarbageDataDir - a data directory of an object or of a
+	// multipart upload that none of its versions refers to.
+	GarbageDataDir = "data-dir"
+	// GarbagePart - a part file missing from the metadata of its
+	// object or multipart upload.
+	GarbagePart = "part"
+	// GarbageUpload - a multipart upload directory without metadata.
+	GarbageUpload = "upload"
+)
+
+// DefaultGarbageAge - garbage is only reported once older than this,
+// to skip the files of on-going operations.
+const DefaultGarbageAge = 24 * time.Hour
+
+// GarbageScanOpts - options of a garbage scan.
+type GarbageScanOpts struct {
+	// OlderThan skips entries modified more recently,
+	// DefaultGarbageAge when zero.
+	OlderThan time.Duration
+	// Remove deletes the garbage found.
+	Remove bool
+}
+
+// GarbageEntry - a file or directory found by a garbage scan, its path
+// is relative to the drive, the endpoint of the drive on its server.
+type GarbageEntry struct {
+	Drive   string    `json:"drive"`
+	Path    string    `json:"path"`
+	Kind    string    `json:"kind"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Removed bool      `json:"removed,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// GarbageReport - result of a garbage scan of the drives of the servers.
+type GarbageReport struct {
+	Entries   []GarbageEntry `json:"entries"`
+	TotalSize int64          `json:"totalSize"`
+}
+
+// ScanGarbage - scans the drives of all the servers for part files,
+// data and tmp directories left behind by interrupted operations, and
+// optionally removes them. Removing garbage requires a non-zero age,
+// see GarbageScanOpts.
+func (adm *AdminClient) ScanGarbage(ctx context.Context, opts GarbageScanOpts) (r GarbageReport, err error) {
+	queryValues := url.Values{}
+	if opts.OlderThan > 0 {
+		queryValues.Set("older-than", opts.OlderThan.String())
+	}
+	queryValues.Set("remove", strconv.FormatBool(opts.Remove))
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/scan-garbage",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/scan-garbage
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,190 @@
+/*
+ * MinIO Cloud Storage, (C) 2018 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HookEvent is the request a bucket hook is invoked on.
+type HookEvent string
+
+const (
+	// HookGet hooks are invoked synchronously on GET Object to
+	// transform the content returned to the client.
+	HookGet HookEvent = "get"
+	// HookPut hooks are invoked asynchronously once an object is
+	// written to derive a new object from its content.
+	HookPut HookEvent = "put"
+)
+
+// HookFailurePolicy is how a failure to invoke a hook is handled.
+type HookFailurePolicy string
+
+const (
+	// HookAbort fails the GET request, only for get hooks.
+	HookAbort HookFailurePolicy = "abort"
+	// HookIgnore returns the untransformed object on get hooks,
+	// and derives no object on put hooks.
+	HookIgnore HookFailurePolicy = "ignore"
+	// HookRetry retries the hook a few times, only for put hooks.
+	HookRetry HookFailurePolicy = "retry"
+)
+
+// Default timeouts of hooks, when none is configured.
+const (
+	DefaultGetHookTimeout = 10 * time.Second
+	DefaultPutHookTimeout = time.Minute
+)
+
+// BucketHook is an HTTP endpoint invoked with the content of the
+// objects of a bucket matching its prefix and suffix.
+type BucketHook struct {
+	Name      string            `json:"name"`
+	Event     HookEvent         `json:"event"`
+	Endpoint  string            `json:"endpoint"`
+	AuthToken string            `json:"authToken,omitempty"`
+	Prefix    string            `json:"prefix,omitempty"`
+	Suffix    string            `json:"suffix,omitempty"`
+	Timeout   time.Duration     `json:"timeout,omitempty"`
+	OnFailure HookFailurePolicy `json:"onFailure,omitempty"`
+
+	// Bucket and prefix of the objects derived by put hooks, the
+	// bucket of the hook when empty.
+	TargetBucket string `json:"targetBucket,omitempty"`
+	TargetPrefix string `json:"targetPrefix,omitempty"`
+}
+
+// BucketHooks holds the hooks of a bucket.
+type BucketHooks struct {
+	Hooks []BucketHook `json:"hooks"`
+}
+
+// Validate returns an error if a hook is invalid.
+func (h BucketHooks) Validate(bucket string) error {
+	names := make(map[string]struct{}, len(h.Hooks))
+	for _, hook := range h.Hooks {
+		if hook.Name == "" {
+			return fmt.Errorf("hook name is missing")
+		}
+		if _, ok := names[hook.Name]; ok {
+			return fmt.Errorf("hook %s is duplicated", hook.Name)
+		}
+		names[hook.Name] = struct{}{}
+
+		u, err := url.Parse(hook.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("hook %s has an invalid endpoint %q", hook.Name, hook.Endpoint)
+		}
+		if hook.Timeout < 0 {
+			return fmt.Errorf("hook %s has a negative timeout", hook.Name)
+		}
+
+		switch hook.Event {
+		case HookGet:
+			if hook.OnFailure != "" && hook.OnFailure != HookAbort && hook.OnFailure != HookIgnore {
+				return fmt.Errorf("hook %s has an invalid failure policy %q", hook.Name, hook.OnFailure)
+			}
+		case HookPut:
+			if hook.OnFailure != "" && hook.OnFailure != HookIgnore && hook.OnFailure != HookRetry {
+				return fmt.Errorf("hook %s has an invalid failure policy %q", hook.Name, hook.OnFailure)
+			}
+			// Derived objects would overwrite their source otherwise.
+			if (hook.TargetBucket == "" || hook.TargetBucket == bucket) && hook.TargetPrefix == "" {
+				return fmt.Errorf("hook %s derives objects in its bucket without a target prefix", hook.Name)
+			}
+		default:
+			return fmt.Errorf("hook %s has an invalid event %q", hook.Name, hook.Event)
+		}
+	}
+	return nil
+}
+
+// GetBucketHooks - returns the hooks of a bucket, incoming data is encrypted.
+func (adm *AdminClient) GetBucketHooks(ctx context.Context, bucket string) (h BucketHooks, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-hooks",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-hooks
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return h, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return h, httpRespToErrorResponse(resp)
+	}
+
+	data, err := DecryptData(adm.getSecretKey(), resp.Body)
+	if err != nil {
+		return h, err
+	}
+	if err = json.Unmarshal(data, &h); err != nil {
+		return h, err
+	}
+
+	return h, nil
+}
+
+// SetBucketHooks - sets the hooks of a bucket, replacing its previous
+// hooks. Hooks hold credentials, outgoing data is encrypted.
+func (adm *AdminClient) SetBucketHooks(ctx context.Context, bucket string, hooks BucketHooks) error {
+	data, err := json.Marshal(hooks)
+	if err != nil {
+		return err
+	}
+
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-hooks",
+		queryValues: queryValues,
+		content:     econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-hooks to set the hooks of a bucket.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
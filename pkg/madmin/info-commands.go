@@ -23,6 +23,8 @@ import (
 	"io/ioutil"
 	"net/http"
 	"time"
+
+	"github.com/minio/minio/pkg/smart"
 )
 
 // BackendType - represents different backend types.
@@ -254,6 +256,14 @@ type ErasureBackend struct {
 	RRSCData int `json:"rrSCData,omitempty"`
 	// Parity disks for currently configured Reduced Redundancy storage class.
 	RRSCParity int `json:"rrSCParity,omitempty"`
+	// Parity disks chosen for the Standard storage class when the
+	// disks were formatted, from the disks per set and their failure
+	// domains. Zero for the disks formatted before it was recorded.
+	FormatParity int `json:"formatParity,omitempty"`
+	// Largest number of disks of an erasure set in a single failure
+	// domain, a host or a configured rack. Losing a whole failure
+	// domain keeps the objects readable if it is not above the parity.
+	FailureDomainDrives int `json:"failureDomainDrives,omitempty"`
 }
 
 // ServerProperties holds server information
@@ -265,6 +275,23 @@ type ServerProperties struct {
 	CommitID string            `json:"commitID,omitempty"`
 	Network  map[string]string `json:"network,omitempty"`
 	Disks    []Disk            `json:"disks,omitempty"`
+
+	// Peers holds the liveness and the clock skew of the other
+	// servers, as tracked by the heartbeats of this server.
+	Peers map[string]PeerHealth `json:"peers,omitempty"`
+}
+
+// PeerHealth holds the liveness of a peer as seen by a server, State
+// is one of "online", "offline" or "skewed".
+type PeerHealth struct {
+	State      string    `json:"state"`
+	LastSeen   time.Time `json:"lastSeen,omitempty"`
+	LastChange time.Time `json:"lastChange,omitempty"`
+	Failures   int       `json:"failures,omitempty"`
+
+	// ClockSkew is the last measured difference between the
+	// clock of the peer and the clock of the server.
+	ClockSkew time.Duration `json:"clockSkew,omitempty"`
 }
 
 // Disk holds Disk information
@@ -282,6 +309,23 @@ type Disk struct {
 	ReadLatency     float64 `json:"readlatency,omitempty"`
 	WriteLatency    float64 `json:"writelatency,omitempty"`
 	Utilization     float64 `json:"utilization,omitempty"`
+	// Health of the drive, reported for the drives
+	// of the servers which are online.
+	Health *DriveHealth `json:"health,omitempty"`
+}
+
+// DriveHealth holds the health of a drive monitored by its server.
+type DriveHealth struct {
+	// ReadOnly is set when the drive is predicted to fail, no new
+	// data is written to it until the server restarts or the mark
+	// is cleared with ClearDriveReadOnly.
+	ReadOnly bool   `json:"readOnly,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	// I/O and bitrot errors since the server started.
+	IOErrors     uint64 `json:"ioErrors"`
+	BitrotErrors uint64 `json:"bitrotErrors"`
+	// SMART health last read, when available.
+	SMART *smart.Info `json:"smart,omitempty"`
 }
 
 // ServerInfo - Connect to a minio server and call Server Admin Info Management API
@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// MetadataImportInfo - the result of a metadata import.
+type MetadataImportInfo struct {
+	// Number of imported files.
+	Files int `json:"files"`
+	// Buckets whose metadata was imported.
+	Buckets []string `json:"buckets,omitempty"`
+}
+
+// ExportMetadata - returns a signed zip archive of the config, IAM and
+// bucket metadata of the cluster. The IAM and config files in the archive
+// are encrypted with the credentials of the cluster.
+func (adm *AdminClient) ExportMetadata(ctx context.Context) (io.ReadCloser, error) {
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{relPath: adminAPIPrefix + "/metadata/export"},
+	)
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	if resp.Body == nil {
+		return nil, errors.New("body is nil")
+	}
+	return resp.Body, nil
+}
+
+// ImportMetadata - imports a metadata archive returned by ExportMetadata
+// into a cluster with the same credentials. The bucket metadata is loaded
+// immediately, the servers must be restarted to load the config and IAM.
+func (adm *AdminClient) ImportMetadata(ctx context.Context, archive io.Reader) (MetadataImportInfo, error) {
+	content, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return MetadataImportInfo{}, err
+	}
+
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPut,
+		requestData{
+			relPath: adminAPIPrefix + "/metadata/import",
+			content: content,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return MetadataImportInfo{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return MetadataImportInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	response, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return MetadataImportInfo{}, err
+	}
+
+	var info MetadataImportInfo
+	err = json.Unmarshal(response, &info)
+	return info, err
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// FSMigrationStatus holds the progress of a migration of an
+// FS deployment into an erasure coded deployment.
+type FSMigrationStatus struct {
+	Path      string    `json:"path"`
+	Running   bool      `json:"running"`
+	Complete  bool      `json:"complete"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	// Last bucket and object migrated, migration
+	// resumes right after this object.
+	Bucket string `json:"bucket,omitempty"`
+	Object string `json:"object,omitempty"`
+
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+	Failed  int64  `json:"failed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// StartFSMigration - starts migrating all buckets and objects of the
+// FS deployment at fsPath on the server into the erasure deployment.
+// An interrupted migration of the same path resumes from its last
+// checkpoint.
+func (adm *AdminClient) StartFSMigration(ctx context.Context, fsPath string) error {
+	queryValues := url.Values{}
+	queryValues.Set("path", fsPath)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/migrate-fs",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/migrate-fs to start the migration.
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// FSMigrationStatus - returns the status of the current or the last
+// migration of an FS deployment.
+func (adm *AdminClient) FSMigrationStatus(ctx context.Context) (s FSMigrationStatus, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/migrate-fs",
+	}
+
+	// Execute GET on /minio/admin/v3/migrate-fs
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
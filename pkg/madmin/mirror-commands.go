@@ -0,0 +1,175 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// MirrorTarget is the S3 compatible endpoint a bucket is mirrored to.
+type MirrorTarget struct {
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey,omitempty"`
+	Bucket    string `json:"bucket"`
+	Prefix    string `json:"prefix,omitempty"`
+	Region    string `json:"region,omitempty"`
+}
+
+// MirrorJob describes the objects of a bucket to mirror to a target.
+type MirrorJob struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+
+	// Wildcard patterns of the object names to mirror, all objects
+	// are mirrored when empty. Excluded objects are never mirrored.
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Maximum bytes per second read from the bucket, 0 for unlimited.
+	Bandwidth int64 `json:"bandwidth,omitempty"`
+
+	// Objects are only mirrored within these windows, at any time
+	// when empty. Jobs wait for the next window outside of them.
+	Windows []TransferWindow `json:"windows,omitempty"`
+
+	Target MirrorTarget `json:"target"`
+}
+
+// MirrorStatus holds the progress of a bucket mirror job.
+type MirrorStatus struct {
+	ID        string    `json:"id"`
+	Job       MirrorJob `json:"job"`
+	Running   bool      `json:"running"`
+	Complete  bool      `json:"complete"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+
+	// Last object mirrored, the job resumes right after this object.
+	Object string `json:"object,omitempty"`
+
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+	Skipped int64  `json:"skipped"`
+	Failed  int64  `json:"failed"`
+	Error   string `json:"error,omitempty"`
+}
+
+// startMirrorResp is the response of a bucket mirror start.
+type startMirrorResp struct {
+	ID string `json:"id"`
+}
+
+// StartMirror - starts mirroring a bucket to a remote target on the
+// server, returns the ID of the mirror job.
+func (adm *AdminClient) StartMirror(ctx context.Context, job MirrorJob) (string, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return "", err
+	}
+
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/mirror",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/mirror to start the mirror job.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var startResp startMirrorResp
+	if err = json.NewDecoder(resp.Body).Decode(&startResp); err != nil {
+		return "", err
+	}
+	return startResp.ID, nil
+}
+
+// ResumeMirror - resumes an interrupted bucket mirror job after its
+// last checkpoint, a complete job mirrors the bucket again.
+func (adm *AdminClient) ResumeMirror(ctx context.Context, id string) error {
+	return adm.mirrorAction(ctx, "resume", id)
+}
+
+// CancelMirror - stops a running bucket mirror job, it can be
+// resumed later on.
+func (adm *AdminClient) CancelMirror(ctx context.Context, id string) error {
+	return adm.mirrorAction(ctx, "cancel", id)
+}
+
+func (adm *AdminClient) mirrorAction(ctx context.Context, action, id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/mirror/" + action,
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/mirror/{action}
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// MirrorStatus - returns the status of all the bucket mirror jobs,
+// the secret keys of their targets are never returned.
+func (adm *AdminClient) MirrorStatus(ctx context.Context) (s []MirrorStatus, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/mirror",
+	}
+
+	// Execute GET on /minio/admin/v3/mirror
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
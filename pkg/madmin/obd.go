@@ -129,11 +129,12 @@ type MinioOBDInfo struct {
 	Error  string      `json:"error,omitempty"`
 }
 
-// PerfOBDInfo - Includes Drive and Net perf info for the entire MinIO cluster
+// PerfOBDInfo - Includes Drive, Net and Object perf info for the entire MinIO cluster
 type PerfOBDInfo struct {
 	DriveInfo   []ServerDrivesOBDInfo `json:"drives,omitempty"`
 	Net         []ServerNetOBDInfo    `json:"net,omitempty"`
 	NetParallel ServerNetOBDInfo      `json:"net_parallel,omitempty"`
+	Object      ServerObjectOBDInfo   `json:"object,omitempty"`
 	Error       string                `json:"error,omitempty"`
 }
 
@@ -168,6 +169,27 @@ type NetOBDInfo struct {
 	Error      string         `json:"error,omitempty"`
 }
 
+// ServerObjectOBDInfo - End-to-end object PUT and GET perf info of the
+// MinIO cluster, measured from a single MinIO node
+type ServerObjectOBDInfo struct {
+	Addr        string        `json:"addr"`
+	ObjectSize  int64         `json:"objectsize,omitempty"`
+	Concurrency int           `json:"concurrency,omitempty"`
+	Put         ObjectOBDInfo `json:"put,omitempty"`
+	Get         ObjectOBDInfo `json:"get,omitempty"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// ObjectOBDInfo - Stats of all the object operations of one type
+type ObjectOBDInfo struct {
+	Count       int            `json:"count"`
+	Bytes       int64          `json:"bytes"`
+	BytesPerSec float64        `json:"bytes_per_sec"`
+	Latency     net.Latency    `json:"latency,omitempty"`
+	Throughput  net.Throughput `json:"throughput,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
 // OBDDataType - Typed OBD data types
 type OBDDataType string
 
@@ -175,6 +197,7 @@ type OBDDataType string
 const (
 	OBDDataTypePerfDrive   OBDDataType = "perfdrive"
 	OBDDataTypePerfNet     OBDDataType = "perfnet"
+	OBDDataTypePerfObject  OBDDataType = "perfobject"
 	OBDDataTypeMinioInfo   OBDDataType = "minioinfo"
 	OBDDataTypeMinioConfig OBDDataType = "minioconfig"
 	OBDDataTypeSysCPU      OBDDataType = "syscpu"
@@ -191,6 +214,7 @@ const (
 var OBDDataTypesMap = map[string]OBDDataType{
 	"perfdrive":   OBDDataTypePerfDrive,
 	"perfnet":     OBDDataTypePerfNet,
+	"perfobject":  OBDDataTypePerfObject,
 	"minioinfo":   OBDDataTypeMinioInfo,
 	"minioconfig": OBDDataTypeMinioConfig,
 	"syscpu":      OBDDataTypeSysCPU,
@@ -207,6 +231,7 @@ var OBDDataTypesMap = map[string]OBDDataType{
 var OBDDataTypesList = []OBDDataType{
 	OBDDataTypePerfDrive,
 	OBDDataTypePerfNet,
+	OBDDataTypePerfObject,
 	OBDDataTypeMinioInfo,
 	OBDDataTypeMinioConfig,
 	OBDDataTypeSysCPU,
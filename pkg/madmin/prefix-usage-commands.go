@@ -0,0 +1,81 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PrefixUsage holds the number of objects and the total size of all
+// their versions under a prefix of a bucket.
+type PrefixUsage struct {
+	Bucket  string `json:"bucket"`
+	Prefix  string `json:"prefix"`
+	Objects uint64 `json:"objects"`
+	Size    uint64 `json:"size"`
+	// Accurate is set when the usage was computed by listing the
+	// prefix, otherwise it is the usage found by the last data
+	// usage scan, finished at LastUpdate.
+	Accurate   bool      `json:"accurate"`
+	LastUpdate time.Time `json:"lastUpdate"`
+}
+
+// PrefixUsage - returns the number of objects and the total size under
+// the prefix of the bucket. The usage comes from the data usage scanner
+// unless accurate is set, in which case the server lists the prefix,
+// which may take a long time for large prefixes.
+func (adm *AdminClient) PrefixUsage(ctx context.Context, bucket, prefix string, accurate bool) (usage PrefixUsage, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("prefix", prefix)
+	if accurate {
+		queryValues.Set("accurate", "true")
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/prefix-usage",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/prefix-usage
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return usage, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return usage, httpRespToErrorResponse(resp)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return usage, err
+	}
+	if err = json.Unmarshal(data, &usage); err != nil {
+		return usage, err
+	}
+
+	return usage, nil
+}
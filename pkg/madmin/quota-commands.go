@@ -44,11 +44,18 @@ func (t QuotaType) IsValid() bool {
 type BucketQuota struct {
 	Quota uint64    `json:"quota"`
 	Type  QuotaType `json:"quotatype,omitempty"`
+
+	// Objects is the maximum number of objects in the bucket,
+	// only supported with a hard quota.
+	Objects uint64 `json:"objects,omitempty"`
 }
 
 // IsValid returns false if quota is invalid
 // empty quota when Quota == 0 is always true.
 func (q BucketQuota) IsValid() bool {
+	if q.Objects > 0 {
+		return q.Type == HardQuota
+	}
 	if q.Quota > 0 {
 		return q.Type.IsValid()
 	}
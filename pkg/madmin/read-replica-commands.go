@@ -0,0 +1,140 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Synchronization intervals of read replicas.
+const (
+	DefaultReadReplicaInterval = 5 * time.Minute
+	MinReadReplicaInterval     = time.Minute
+)
+
+// BucketReadReplica makes a bucket a read-only replica of a bucket on
+// a remote S3 compatible source, the server rejects writes to the
+// bucket and keeps its objects in sync with the source. An empty
+// Endpoint turns the bucket back into a regular bucket.
+type BucketReadReplica struct {
+	Endpoint  string `json:"endpoint,omitempty"`
+	AccessKey string `json:"accessKey,omitempty"`
+	SecretKey string `json:"secretKey,omitempty"`
+	Bucket    string `json:"bucket,omitempty"`
+	Region    string `json:"region,omitempty"`
+
+	// Interval between two synchronizations with the source,
+	// DefaultReadReplicaInterval when zero.
+	Interval time.Duration `json:"interval,omitempty"`
+}
+
+// Enabled returns true if the bucket is a read replica.
+func (r BucketReadReplica) Enabled() bool {
+	return r.Endpoint != ""
+}
+
+// Validate returns an error if the read replica is invalid.
+func (r BucketReadReplica) Validate() error {
+	if !r.Enabled() {
+		return nil
+	}
+	u, err := url.Parse(r.Endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid source endpoint %q", r.Endpoint)
+	}
+	if r.AccessKey == "" || r.SecretKey == "" || r.Bucket == "" {
+		return fmt.Errorf("source credentials or bucket are missing")
+	}
+	if r.Interval != 0 && r.Interval < MinReadReplicaInterval {
+		return fmt.Errorf("interval must be at least %s", MinReadReplicaInterval)
+	}
+	return nil
+}
+
+// GetBucketReadReplica - returns the source of a read replica bucket,
+// without its secret key.
+func (adm *AdminClient) GetBucketReadReplica(ctx context.Context, bucket string) (r BucketReadReplica, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/get-bucket-read-replica",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/get-bucket-read-replica
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// SetBucketReadReplica - makes a bucket a read replica of a source,
+// or a regular bucket again with an empty replica. The replica holds
+// credentials, outgoing data is encrypted.
+func (adm *AdminClient) SetBucketReadReplica(ctx context.Context, bucket string, replica BucketReadReplica) error {
+	data, err := json.Marshal(replica)
+	if err != nil {
+		return err
+	}
+
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-bucket-read-replica",
+		queryValues: queryValues,
+		content:     econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-bucket-read-replica
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
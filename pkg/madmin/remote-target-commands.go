@@ -0,0 +1,159 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// BucketTarget is a remote S3 compatible bucket the objects of a
+// bucket are replicated to.
+type BucketTarget struct {
+	SourceBucket string `json:"sourceBucket"`
+	Endpoint     string `json:"endpoint"`
+	AccessKey    string `json:"accessKey"`
+	SecretKey    string `json:"secretKey,omitempty"`
+	TargetBucket string `json:"targetBucket"`
+	Region       string `json:"region,omitempty"`
+	// Arn is set by the server when the target is added, it is the
+	// destination bucket of the replication configuration rules.
+	Arn string `json:"arn,omitempty"`
+
+	// Maximum bytes per second replicated to the target, 0 for unlimited.
+	Bandwidth int64 `json:"bandwidth,omitempty"`
+
+	// Objects are only replicated within these windows, at any time
+	// when empty. Deletions are always replicated.
+	Windows []TransferWindow `json:"windows,omitempty"`
+}
+
+// TransferWindow is a daily time window, in the local time of the
+// server, during which objects are sent to a remote target. Start and
+// End are formatted as HH:MM, windows ending before they start span
+// midnight, e.g. 22:00 to 06:00.
+type TransferWindow struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// BucketTargets is the list of remote targets of a bucket.
+type BucketTargets struct {
+	Targets []BucketTarget `json:"targets"`
+}
+
+// setRemoteTargetResp is the response of a remote target addition.
+type setRemoteTargetResp struct {
+	Arn string `json:"arn"`
+}
+
+// SetRemoteTarget - adds a remote target to a bucket, or updates
+// the credentials of the target if its Arn is set. Returns the ARN
+// of the target. Targets hold credentials, outgoing data is encrypted.
+func (adm *AdminClient) SetRemoteTarget(ctx context.Context, bucket string, target BucketTarget) (string, error) {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return "", err
+	}
+
+	econfigBytes, err := EncryptData(adm.getSecretKey(), data)
+	if err != nil {
+		return "", err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-remote-target",
+		queryValues: queryValues,
+		content:     econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-remote-target to add a remote target.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", httpRespToErrorResponse(resp)
+	}
+
+	var setResp setRemoteTargetResp
+	if err = json.NewDecoder(resp.Body).Decode(&setResp); err != nil {
+		return "", err
+	}
+	return setResp.Arn, nil
+}
+
+// ListRemoteTargets - returns the remote targets of a bucket,
+// their secret keys are never returned.
+func (adm *AdminClient) ListRemoteTargets(ctx context.Context, bucket string) (targets []BucketTarget, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/list-remote-targets",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/list-remote-targets
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return targets, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return targets, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&targets); err != nil {
+		return targets, err
+	}
+	return targets, nil
+}
+
+// RemoveRemoteTarget - removes the remote target of a bucket, the
+// target can not be removed while the bucket replicates to it.
+func (adm *AdminClient) RemoveRemoteTarget(ctx context.Context, bucket, arn string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("arn", arn)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/remove-remote-target",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v3/remove-remote-target
+	resp, err := adm.executeMethod(ctx, http.MethodDelete, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestInfo holds what a server node remembers about a request
+// it served, identified by its x-amz-request-id.
+type RequestInfo struct {
+	RequestID  string        `json:"requestID"`
+	HostID     string        `json:"hostID"`
+	NodeName   string        `json:"node"`
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	Path       string        `json:"path"`
+	RawQuery   string        `json:"rawQuery,omitempty"`
+	StatusCode int           `json:"statusCode"`
+	Duration   time.Duration `json:"duration"`
+	RemoteHost string        `json:"remotehost,omitempty"`
+	UserAgent  string        `json:"userAgent,omitempty"`
+}
+
+// LookupRequest - looks up a request ID reported by a client on all
+// the server nodes, returns an entry for every node which served it.
+func (adm *AdminClient) LookupRequest(ctx context.Context, requestID string) ([]RequestInfo, error) {
+	// Execute GET on /minio/admin/v3/request?id=requestID
+	queryVals := make(url.Values)
+	queryVals.Set("id", requestID)
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{
+			relPath:     adminAPIPrefix + "/request",
+			queryValues: queryVals,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	response, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var requests []RequestInfo
+	err = json.Unmarshal(response, &requests)
+	return requests, err
+}
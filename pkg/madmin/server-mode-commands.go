@@ -0,0 +1,92 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ServerMode restricts the operations accepted by the servers.
+type ServerMode string
+
+const (
+	// ServerModeNormal - all operations are accepted.
+	ServerModeNormal ServerMode = "normal"
+	// ServerModeReadOnly - operations modifying buckets or objects are rejected.
+	ServerModeReadOnly ServerMode = "read-only"
+	// ServerModeWriteOnce - objects cannot be overwritten or deleted.
+	ServerModeWriteOnce ServerMode = "write-once"
+)
+
+// ServerModeInfo - the current server mode.
+type ServerModeInfo struct {
+	Mode ServerMode `json:"mode"`
+}
+
+// GetServerMode - returns the current server mode.
+func (adm *AdminClient) GetServerMode(ctx context.Context) (ServerModeInfo, error) {
+	resp, err := adm.executeMethod(ctx,
+		http.MethodGet,
+		requestData{relPath: adminAPIPrefix + "/server-mode"},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return ServerModeInfo{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ServerModeInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	response, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ServerModeInfo{}, err
+	}
+
+	var info ServerModeInfo
+	err = json.Unmarshal(response, &info)
+	return info, err
+}
+
+// SetServerMode - sets the server mode on all the servers, the mode
+// is not persisted across restarts.
+func (adm *AdminClient) SetServerMode(ctx context.Context, mode ServerMode) error {
+	queryValues := url.Values{}
+	queryValues.Set("mode", string(mode))
+
+	resp, err := adm.executeMethod(ctx,
+		http.MethodPut,
+		requestData{
+			relPath:     adminAPIPrefix + "/server-mode",
+			queryValues: queryValues,
+		},
+	)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
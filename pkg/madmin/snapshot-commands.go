@@ -0,0 +1,153 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BucketSnapshot describes a point-in-time snapshot of a bucket, the
+// versions of its objects at that time.
+type BucketSnapshot struct {
+	ID      string    `json:"id"`
+	Bucket  string    `json:"bucket"`
+	Created time.Time `json:"created"`
+	Objects int64     `json:"objects"`
+	Size    int64     `json:"size"`
+}
+
+// BucketCloneResult holds the objects copied to a bucket cloned from
+// a snapshot.
+type BucketCloneResult struct {
+	Bucket  string `json:"bucket"`
+	Objects int64  `json:"objects"`
+	Size    int64  `json:"size"`
+	// Objects not cloned, because their version was permanently
+	// deleted since the snapshot or because they are encrypted.
+	Skipped int64 `json:"skipped"`
+}
+
+// CreateBucketSnapshot - snapshots the current versions of the objects
+// of a bucket, the bucket must have versioning enabled.
+func (adm *AdminClient) CreateBucketSnapshot(ctx context.Context, bucket string) (s BucketSnapshot, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/snapshot",
+		queryValues: queryValues,
+	}
+
+	// Execute PUT on /minio/admin/v3/snapshot to create the snapshot.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&s)
+	return s, err
+}
+
+// ListBucketSnapshots - returns the snapshots of a bucket, oldest first.
+func (adm *AdminClient) ListBucketSnapshots(ctx context.Context, bucket string) (s []BucketSnapshot, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/snapshot",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/snapshot
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return s, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return s, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&s)
+	return s, err
+}
+
+// DeleteBucketSnapshot - deletes the snapshot id of a bucket, the
+// object versions it refers to are left untouched.
+func (adm *AdminClient) DeleteBucketSnapshot(ctx context.Context, bucket, id string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("id", id)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/snapshot",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v3/snapshot
+	resp, err := adm.executeMethod(ctx, http.MethodDelete, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// CloneBucketSnapshot - creates the target bucket with copies of the
+// object versions of the snapshot id of a bucket. It returns once all
+// the objects are copied.
+func (adm *AdminClient) CloneBucketSnapshot(ctx context.Context, bucket, id, target string) (r BucketCloneResult, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("id", id)
+	queryValues.Set("target", target)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/snapshot/clone",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v3/snapshot/clone
+	resp, err := adm.executeMethod(ctx, http.MethodPost, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&r)
+	return r, err
+}
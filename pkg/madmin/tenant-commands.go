@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Tenant holds a logical tenant of the server, the users of a tenant
+// only see and create the buckets named with the prefix of the tenant,
+// "<name>-".
+type Tenant struct {
+	Name string `json:"name"`
+	// Users are the names of the users of the server belonging
+	// to the tenant, a user belongs to one tenant at most.
+	Users []string `json:"users,omitempty"`
+
+	// Quota is the maximum number of bytes in all the buckets
+	// of the tenant, 0 for no quota.
+	Quota uint64 `json:"quota,omitempty"`
+
+	// NotificationTargets are the ARNs of the notification
+	// targets the buckets of the tenant can send events to,
+	// none when empty.
+	NotificationTargets []string `json:"notificationTargets,omitempty"`
+}
+
+// TenantInfo holds a tenant and the last known usage of its buckets.
+type TenantInfo struct {
+	Tenant
+	Buckets      []string `json:"buckets,omitempty"`
+	Size         uint64   `json:"size"`
+	ObjectsCount uint64   `json:"objectsCount"`
+}
+
+// SetTenant - creates or replaces the tenant.
+func (adm *AdminClient) SetTenant(ctx context.Context, tenant Tenant) error {
+	data, err := json.Marshal(tenant)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("name", tenant.Name)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/set-tenant",
+		queryValues: queryValues,
+		content:     data,
+	}
+
+	// Execute PUT on /minio/admin/v3/set-tenant to set a tenant.
+	resp, err := adm.executeMethod(ctx, http.MethodPut, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RemoveTenant - removes the tenant, its users and buckets are kept
+// and are no longer restricted to the namespace of the tenant.
+func (adm *AdminClient) RemoveTenant(ctx context.Context, name string) error {
+	queryValues := url.Values{}
+	queryValues.Set("name", name)
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/remove-tenant",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v3/remove-tenant to remove a tenant.
+	resp, err := adm.executeMethod(ctx, http.MethodDelete, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// ListTenants - returns all the tenants and the usage of their buckets.
+func (adm *AdminClient) ListTenants(ctx context.Context) (tenants []TenantInfo, err error) {
+	reqData := requestData{
+		relPath: adminAPIPrefix + "/list-tenants",
+	}
+
+	// Execute GET on /minio/admin/v3/list-tenants
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&tenants); err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Verification states of objects and their parts.
+const (
+	// VerifyStatusOK - the content matches the stored ETags.
+	VerifyStatusOK = "ok"
+	// VerifyStatusCorrupt - the content doesn't match the stored
+	// ETags, couldn't be read or a drive holds a corrupted copy.
+	VerifyStatusCorrupt = "corrupt"
+	// VerifyStatusUnverified - the content was read but the stored
+	// ETags aren't derived from it, e.g. for encrypted, compressed
+	// or objects uploaded without computing their MD5.
+	VerifyStatusUnverified = "unverified"
+)
+
+// ObjectVerifyPart - verification result of a part of an object.
+type ObjectVerifyPart struct {
+	Number       int    `json:"number"`
+	Size         int64  `json:"size"`
+	ETag         string `json:"etag,omitempty"`
+	ComputedETag string `json:"computedETag,omitempty"`
+	Status       string `json:"status"`
+}
+
+// ObjectVerifyReport - verification result of an object, the status
+// is corrupt if any of its parts or drives is.
+type ObjectVerifyReport struct {
+	Bucket       string `json:"bucket"`
+	Object       string `json:"object"`
+	VersionID    string `json:"versionId,omitempty"`
+	Size         int64  `json:"size"`
+	ETag         string `json:"etag"`
+	ComputedETag string `json:"computedETag,omitempty"`
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+
+	Parts []ObjectVerifyPart `json:"parts"`
+
+	// Drives holds the state of the object on each drive after
+	// verifying the bitrot checksums of its parts, erasure only.
+	Drives []HealDriveInfo `json:"drives,omitempty"`
+}
+
+// VerifyObject - re-reads an object on the server and verifies its
+// parts against their stored checksums and ETags, an empty versionID
+// verifies the latest version.
+func (adm *AdminClient) VerifyObject(ctx context.Context, bucket, object, versionID string) (r ObjectVerifyReport, err error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("object", object)
+	if versionID != "" {
+		queryValues.Set("versionId", versionID)
+	}
+
+	reqData := requestData{
+		relPath:     adminAPIPrefix + "/verify-object",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v3/verify-object
+	resp, err := adm.executeMethod(ctx, http.MethodGet, reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return r, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return r, httpRespToErrorResponse(resp)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
@@ -32,6 +32,9 @@ func getColumns(
 	schemaElements []*parquet.SchemaElement,
 	getReaderFunc GetReaderFunc,
 ) (nameColumnMap map[string]*column, err error) {
+	// Not nil even without any column selected, such as when only
+	// the rows are counted.
+	nameColumnMap = make(map[string]*column)
 	nameIndexMap := make(map[string]int)
 	for colIndex, columnChunk := range rowGroup.GetColumns() {
 		meta := columnChunk.GetMetaData()
@@ -64,10 +67,6 @@ func getColumns(
 
 		thriftReader := thrift.NewTBufferedTransport(thrift.NewStreamTransportR(rc), int(size))
 
-		if nameColumnMap == nil {
-			nameColumnMap = make(map[string]*column)
-		}
-
 		nameColumnMap[columnName] = &column{
 			name:           columnName,
 			metadata:       meta,
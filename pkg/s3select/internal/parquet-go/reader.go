@@ -92,6 +92,8 @@ type Reader struct {
 	columnNames set.StringSet
 	columns     map[string]*column
 	rowIndex    int64
+
+	rowGroupFilter RowGroupFilter
 }
 
 // NewReader - creates new parquet reader. Reader calls getReaderFunc to get required data range for given columnNames. If columnNames is empty, all columns are used.
@@ -123,6 +125,14 @@ func (reader *Reader) Read() (record *Record, err error) {
 	}
 
 	if reader.columns == nil {
+		if reader.rowGroupFilter != nil {
+			statistics := getStatistics(reader.rowGroups[reader.rowGroupIndex], reader.schemaElements)
+			if !reader.rowGroupFilter(statistics) {
+				reader.rowGroupIndex++
+				return reader.Read()
+			}
+		}
+
 		reader.columns, err = getColumns(
 			reader.rowGroups[reader.rowGroupIndex],
 			reader.columnNames,
@@ -153,6 +163,13 @@ func (reader *Reader) Read() (record *Record, err error) {
 	return record, nil
 }
 
+// SetRowGroupFilter - sets the filter called with the statistics of every
+// row group before reading its columns, row groups it returns false for
+// are skipped.
+func (reader *Reader) SetRowGroupFilter(filter RowGroupFilter) {
+	reader.rowGroupFilter = filter
+}
+
 // Close - closes underneath readers.
 func (reader *Reader) Close() (err error) {
 	for _, column := range reader.columns {
@@ -0,0 +1,134 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"math"
+	"strings"
+
+	"github.com/minio/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+)
+
+// ColumnStatistics - the minimum and maximum values of a column in a row group.
+type ColumnStatistics struct {
+	Min Value
+	Max Value
+}
+
+// RowGroupFilter - returns false when none of the rows of a row group can be
+// selected from the statistics of its columns, the row group is then skipped.
+type RowGroupFilter func(statistics map[string]ColumnStatistics) bool
+
+// getStatistics returns the statistics of the columns of rowGroup whose
+// minimum and maximum values are known and ordered as their values are
+// read. Columns without usable statistics are left out.
+func getStatistics(rowGroup *parquet.RowGroup, schemaElements []*parquet.SchemaElement) map[string]ColumnStatistics {
+	statistics := make(map[string]ColumnStatistics)
+	for colIndex, columnChunk := range rowGroup.GetColumns() {
+		meta := columnChunk.GetMetaData()
+		if meta == nil || meta.GetStatistics() == nil {
+			continue
+		}
+
+		// First element of []*parquet.SchemaElement from parquet file metadata is 'schema'
+		// which is always skipped, see getColumns().
+		path := meta.GetPathInSchema()
+		if colIndex+1 >= len(schemaElements) || len(path) == 0 ||
+			schemaElements[colIndex+1].GetName() != path[len(path)-1] {
+			continue
+		}
+
+		min, max, ok := decodeStatistics(meta.GetType(), meta.GetStatistics(), schemaElements[colIndex+1])
+		if !ok {
+			continue
+		}
+		statistics[strings.Join(path, ".")] = ColumnStatistics{
+			Min: Value{min, meta.GetType()},
+			Max: Value{max, meta.GetType()},
+		}
+	}
+	return statistics
+}
+
+func decodeStatistics(valueType parquet.Type, stats *parquet.Statistics, element *parquet.SchemaElement) (min, max interface{}, ok bool) {
+	if element.IsSetConvertedType() {
+		switch element.GetConvertedType() {
+		case parquet.ConvertedType_UINT_8, parquet.ConvertedType_UINT_16,
+			parquet.ConvertedType_UINT_32, parquet.ConvertedType_UINT_64,
+			parquet.ConvertedType_DECIMAL:
+			// Unsigned values are ordered unsigned and read signed,
+			// decimals are not read as numbers.
+			return nil, nil, false
+		}
+	}
+	if logicalType := element.GetLogicalType(); logicalType != nil {
+		if logicalType.IsSetINTEGER() && !logicalType.GetINTEGER().GetIsSigned() {
+			return nil, nil, false
+		}
+		if logicalType.IsSetDECIMAL() {
+			return nil, nil, false
+		}
+	}
+
+	// min_value and max_value are ordered as their logical type, the
+	// deprecated min and max are ordered signed which is only valid
+	// for numbers.
+	minBuf, maxBuf := stats.GetMinValue(), stats.GetMaxValue()
+	if minBuf == nil || maxBuf == nil {
+		if valueType == parquet.Type_BYTE_ARRAY {
+			return nil, nil, false
+		}
+		minBuf, maxBuf = stats.GetMin(), stats.GetMax()
+	}
+	if minBuf == nil || maxBuf == nil {
+		return nil, nil, false
+	}
+
+	switch valueType {
+	case parquet.Type_INT32:
+		if len(minBuf) != 4 || len(maxBuf) != 4 {
+			return nil, nil, false
+		}
+		return int32(bytesToUint32(minBuf)), int32(bytesToUint32(maxBuf)), true
+	case parquet.Type_INT64:
+		if len(minBuf) != 8 || len(maxBuf) != 8 {
+			return nil, nil, false
+		}
+		return int64(bytesToUint64(minBuf)), int64(bytesToUint64(maxBuf)), true
+	case parquet.Type_FLOAT:
+		if len(minBuf) != 4 || len(maxBuf) != 4 {
+			return nil, nil, false
+		}
+		fmin, fmax := math.Float32frombits(bytesToUint32(minBuf)), math.Float32frombits(bytesToUint32(maxBuf))
+		if math.IsNaN(float64(fmin)) || math.IsNaN(float64(fmax)) {
+			return nil, nil, false
+		}
+		return fmin, fmax, true
+	case parquet.Type_DOUBLE:
+		if len(minBuf) != 8 || len(maxBuf) != 8 {
+			return nil, nil, false
+		}
+		fmin, fmax := math.Float64frombits(bytesToUint64(minBuf)), math.Float64frombits(bytesToUint64(maxBuf))
+		if math.IsNaN(fmin) || math.IsNaN(fmax) {
+			return nil, nil, false
+		}
+		return fmin, fmax, true
+	case parquet.Type_BYTE_ARRAY:
+		return minBuf, maxBuf, true
+	}
+	return nil, nil, false
+}
@@ -20,6 +20,7 @@ import (
 	"io"
 
 	"github.com/bcicen/jstream"
+	"github.com/minio/minio-go/v7/pkg/set"
 	parquetgo "github.com/minio/minio/pkg/s3select/internal/parquet-go"
 	parquetgen "github.com/minio/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
 	jsonfmt "github.com/minio/minio/pkg/s3select/json"
@@ -45,26 +46,8 @@ func (r *Reader) Read(dst sql.Record) (rec sql.Record, rerr error) {
 
 	kvs := jstream.KVS{}
 	f := func(name string, v parquetgo.Value) bool {
-		if v.Value == nil {
-			kvs = append(kvs, jstream.KV{Key: name, Value: nil})
-			return true
-		}
-
-		var value interface{}
-		switch v.Type {
-		case parquetgen.Type_BOOLEAN:
-			value = v.Value.(bool)
-		case parquetgen.Type_INT32:
-			value = int64(v.Value.(int32))
-		case parquetgen.Type_INT64:
-			value = int64(v.Value.(int64))
-		case parquetgen.Type_FLOAT:
-			value = float64(v.Value.(float32))
-		case parquetgen.Type_DOUBLE:
-			value = v.Value.(float64)
-		case parquetgen.Type_INT96, parquetgen.Type_BYTE_ARRAY, parquetgen.Type_FIXED_LEN_BYTE_ARRAY:
-			value = string(v.Value.([]byte))
-		default:
+		value, ok := toValue(v)
+		if !ok {
 			rerr = errParquetParsingError(nil)
 			return false
 		}
@@ -86,14 +69,79 @@ func (r *Reader) Read(dst sql.Record) (rec sql.Record, rerr error) {
 	return dstRec, nil
 }
 
+// toValue converts a parquet value to the value of the record.
+func toValue(v parquetgo.Value) (value interface{}, ok bool) {
+	if v.Value == nil {
+		return nil, true
+	}
+
+	switch v.Type {
+	case parquetgen.Type_BOOLEAN:
+		value = v.Value.(bool)
+	case parquetgen.Type_INT32:
+		value = int64(v.Value.(int32))
+	case parquetgen.Type_INT64:
+		value = int64(v.Value.(int64))
+	case parquetgen.Type_FLOAT:
+		value = float64(v.Value.(float32))
+	case parquetgen.Type_DOUBLE:
+		value = v.Value.(float64)
+	case parquetgen.Type_INT96, parquetgen.Type_BYTE_ARRAY, parquetgen.Type_FIXED_LEN_BYTE_ARRAY:
+		value = string(v.Value.([]byte))
+	default:
+		return nil, false
+	}
+	return value, true
+}
+
+// toSQLValue converts a parquet value to the value the statement
+// compares the values of the records with.
+func toSQLValue(v parquetgo.Value) *sql.Value {
+	value, _ := toValue(v)
+	switch x := value.(type) {
+	case int64:
+		return sql.FromInt(x)
+	case float64:
+		return sql.FromFloat(x)
+	case string:
+		return sql.FromString(x)
+	}
+	return nil
+}
+
+// rowGroupFilter returns the filter skipping the row groups in which
+// no row satisfies the predicates of the statement.
+func rowGroupFilter(predicates []sql.Predicate) parquetgo.RowGroupFilter {
+	return func(statistics map[string]parquetgo.ColumnStatistics) bool {
+		for _, predicate := range predicates {
+			stats, ok := statistics[predicate.Column]
+			if !ok {
+				continue
+			}
+			if !predicate.MayMatch(toSQLValue(stats.Min), toSQLValue(stats.Max)) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 // Close - closes underlying readers.
 func (r *Reader) Close() error {
 	return r.reader.Close()
 }
 
 // NewReader - creates new Parquet reader using readerFunc callback.
-func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs) (*Reader, error) {
-	reader, err := parquetgo.NewReader(getReaderFunc, nil)
+// Only the columns the statement refers to are read, and the row groups
+// whose column statistics rule out the WHERE clause of the statement
+// are skipped.
+func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error), args *ReaderArgs, stmt *sql.SelectStatement) (*Reader, error) {
+	var columnNames set.StringSet
+	if columns := stmt.Columns(); columns != nil {
+		columnNames = set.CreateStringSet(columns...)
+	}
+
+	reader, err := parquetgo.NewReader(getReaderFunc, columnNames)
 	if err != nil {
 		if err != io.EOF {
 			return nil, errParquetParsingError(err)
@@ -102,6 +150,10 @@ func NewReader(getReaderFunc func(offset, length int64) (io.ReadCloser, error),
 		return nil, err
 	}
 
+	if predicates := stmt.Predicates(); len(predicates) > 0 {
+		reader.SetRowGroupFilter(rowGroupFilter(predicates))
+	}
+
 	return &Reader{
 		args:   args,
 		reader: reader,
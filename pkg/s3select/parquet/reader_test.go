@@ -0,0 +1,166 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"git.apache.org/thrift.git/lib/go/thrift"
+	parquetgen "github.com/minio/minio/pkg/s3select/internal/parquet-go/gen-go/parquet"
+	"github.com/minio/minio/pkg/s3select/sql"
+)
+
+// twoRowGroupsTestdata returns ../testdata.parquet with its row group
+// twice, the statistics of the second one are changed to `one` from 10
+// to 20 and `two` from "x" to "z".
+func twoRowGroupsTestdata(t *testing.T) []byte {
+	file, err := ioutil.ReadFile("../testdata.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	footerSize := int(binary.LittleEndian.Uint32(file[len(file)-8:]))
+	footerStart := len(file) - 8 - footerSize
+
+	td := thrift.NewTDeserializer()
+	td.Protocol = thrift.NewTCompactProtocolFactory().GetProtocol(td.Transport)
+	fileMeta := parquetgen.NewFileMetaData()
+	if err = td.Read(fileMeta, file[footerStart:len(file)-8]); err != nil {
+		t.Fatal(err)
+	}
+	if len(fileMeta.RowGroups) != 1 {
+		t.Fatalf("expected 1 row group, got %d", len(fileMeta.RowGroups))
+	}
+
+	// Decode the footer again to get a copy of the row group.
+	copyMeta := parquetgen.NewFileMetaData()
+	if err = td.Read(copyMeta, file[footerStart:len(file)-8]); err != nil {
+		t.Fatal(err)
+	}
+	second := copyMeta.RowGroups[0]
+	for _, column := range second.Columns {
+		stats := column.MetaData.Statistics
+		switch column.MetaData.PathInSchema[0] {
+		case "one":
+			stats.MinValue = make([]byte, 8)
+			stats.MaxValue = make([]byte, 8)
+			binary.LittleEndian.PutUint64(stats.MinValue, math.Float64bits(10))
+			binary.LittleEndian.PutUint64(stats.MaxValue, math.Float64bits(20))
+			stats.Min, stats.Max = stats.MinValue, stats.MaxValue
+		case "two":
+			stats.MinValue, stats.MaxValue = []byte("x"), []byte("z")
+		}
+	}
+	fileMeta.RowGroups = append(fileMeta.RowGroups, second)
+	fileMeta.NumRows *= 2
+
+	ts := thrift.NewTSerializer()
+	ts.Protocol = thrift.NewTCompactProtocolFactory().GetProtocol(ts.Transport)
+	footer, err := ts.Write(context.Background(), fileMeta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := append([]byte{}, file[:footerStart]...)
+	data = append(data, footer...)
+	sizeBuf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sizeBuf, uint32(len(footer)))
+	data = append(data, sizeBuf...)
+	return append(data, "PAR1"...)
+}
+
+func TestReaderColumnsAndRowGroups(t *testing.T) {
+	file := twoRowGroupsTestdata(t)
+
+	testCases := []struct {
+		query string
+		// The first record read.
+		record string
+		// Number of records read.
+		records int
+		// Number of column chunks read.
+		reads int
+	}{
+		{"SELECT * FROM S3Object", `{"one":-1,"three":true,"two":"foo","__index_level_0__":"a"}`, 6, 8},
+		{"SELECT s.two FROM S3Object s", `{"two":"foo"}`, 6, 2},
+		{"SELECT COUNT(*) FROM S3Object", `{}`, 6, 0},
+		{"SELECT s.two FROM S3Object s WHERE s.one > 5", `{"one":-1,"two":"foo"}`, 3, 2},
+		{"SELECT s.two FROM S3Object s WHERE s.one BETWEEN -2 AND 3", `{"one":-1,"two":"foo"}`, 3, 2},
+		{"SELECT s.one FROM S3Object s WHERE s.two = 'foo' AND s.three", `{"one":-1,"three":true,"two":"foo"}`, 3, 3},
+		{"SELECT s.one FROM S3Object s WHERE s.one > 5 AND s.two = 'foo'", ``, 0, 0},
+		{"SELECT s.one FROM S3Object s WHERE s.one > 5 OR s.two = 'foo'", `{"one":-1,"two":"foo"}`, 6, 4},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := sql.ParseSelectStatement(testCase.query)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+
+		var reads int
+		getReader := func(offset, length int64) (io.ReadCloser, error) {
+			if offset < 0 {
+				offset = int64(len(file)) + offset
+			} else {
+				reads++
+			}
+			return ioutil.NopCloser(bytes.NewReader(file[offset : offset+length])), nil
+		}
+
+		reader, err := NewReader(getReader, &ReaderArgs{}, &stmt)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		var first string
+		var records int
+		for {
+			rec, err := reader.Read(nil)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("test %d: %v", i+1, err)
+			}
+			if records == 0 {
+				var buf bytes.Buffer
+				if err = rec.WriteJSON(&buf); err != nil {
+					t.Fatalf("test %d: %v", i+1, err)
+				}
+				first = string(bytes.TrimSpace(buf.Bytes()))
+			}
+			records++
+		}
+		if err = reader.Close(); err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+
+		if first != testCase.record {
+			t.Fatalf("test %d: expected first record %s, got %s", i+1, testCase.record, first)
+		}
+		if records != testCase.records {
+			t.Fatalf("test %d: expected %d records, got %d", i+1, testCase.records, records)
+		}
+		if reads != testCase.reads {
+			t.Fatalf("test %d: expected %d column chunks read, got %d", i+1, testCase.reads, reads)
+		}
+	}
+}
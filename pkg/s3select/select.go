@@ -335,7 +335,7 @@ func (s3Select *S3Select) Open(getReader func(offset, length int64) (io.ReadClos
 		return nil
 	case parquetFormat:
 		var err error
-		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs)
+		s3Select.recordReader, err = parquet.NewReader(getReader, &s3Select.Input.ParquetArgs, s3Select.statement)
 		return err
 	}
 
@@ -48,6 +48,11 @@ var (
 type qProp struct {
 	isAggregation, isRowFunc bool
 
+	// The columns of the input records referred to, all of them
+	// when allColumns is set.
+	columns    map[string]struct{}
+	allColumns bool
+
 	err error
 }
 
@@ -66,12 +71,24 @@ func (p *qProp) combine(q qProp) {
 		if p.isAggregation && p.isRowFunc {
 			p.err = errNestedAggregation
 		}
+		p.addColumns(q)
+	}
+}
+
+// `addColumns` adds the columns referred to by `q`.
+func (p *qProp) addColumns(q qProp) {
+	p.allColumns = p.allColumns || q.allColumns
+	for column := range q.columns {
+		if p.columns == nil {
+			p.columns = make(map[string]struct{})
+		}
+		p.columns[column] = struct{}{}
 	}
 }
 
 func (e *SelectExpression) analyze(s *Select) (result qProp) {
 	if e.All {
-		return qProp{isRowFunc: true}
+		return qProp{isRowFunc: true, allColumns: true}
 	}
 
 	for _, ex := range e.Expressions {
@@ -183,6 +200,11 @@ func (e *PrimaryTerm) analyze(s *Select) (result qProp) {
 			}
 		}
 		result = qProp{isRowFunc: true}
+		if column, ok := e.JPathExpr.columnName(); ok {
+			result.columns = map[string]struct{}{column: {}}
+		} else {
+			result.allColumns = true
+		}
 
 	case e.ListExpr != nil:
 		result = e.ListExpr.analyze(s)
@@ -244,7 +266,9 @@ func (e *FuncExpr) analyze(s *Select) (result qProp) {
 		if exprA.isAggregation {
 			return qProp{err: errNestedAggregation}
 		}
-		return qProp{isAggregation: true}
+		result = qProp{isAggregation: true}
+		result.addColumns(exprA)
+		return result
 
 	case sqlFnCoalesce:
 		if len(e.SFunc.ArgsList) == 0 {
@@ -0,0 +1,178 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+// Predicate is a comparison of a column of the input records with a
+// literal value, which every record selected by the WHERE clause
+// satisfies.
+type Predicate struct {
+	Column string
+	Op     string
+	Value  *Value
+}
+
+// Predicates returns the comparisons of columns with literal values
+// the WHERE clause requires, so that readers of formats keeping the
+// range of the values of their columns can skip the blocks of records
+// none of which can be selected. Comparisons under OR or NOT are left
+// out.
+func (e *SelectStatement) Predicates() (predicates []Predicate) {
+	where := e.selectAST.Where
+	if where == nil || len(where.And) != 1 || e.selectAST.From.HasKeypath() {
+		return nil
+	}
+	for _, condition := range where.And[0].Condition {
+		predicates = append(predicates, condition.predicates()...)
+	}
+	return predicates
+}
+
+func (e *Condition) predicates() []Predicate {
+	if e.Operand == nil || e.Operand.ConditionRHS == nil {
+		return nil
+	}
+
+	left := e.Operand.Operand
+	rhs := e.Operand.ConditionRHS
+	switch {
+	case rhs.Compare != nil:
+		op := rhs.Compare.Operator
+		switch op {
+		case opLt, opLte, opGt, opGte, opEq:
+		default:
+			return nil
+		}
+		if column, ok := left.columnName(); ok {
+			if value, ok := rhs.Compare.Operand.literal(); ok {
+				return []Predicate{{Column: column, Op: op, Value: value}}
+			}
+		}
+		if value, ok := left.literal(); ok {
+			if column, ok := rhs.Compare.Operand.columnName(); ok {
+				return []Predicate{{Column: column, Op: reverseCompareOp(op), Value: value}}
+			}
+		}
+
+	case rhs.Between != nil && !rhs.Between.Not:
+		column, ok := left.columnName()
+		if !ok {
+			return nil
+		}
+		start, ok1 := rhs.Between.Start.literal()
+		end, ok2 := rhs.Between.End.literal()
+		if ok1 && ok2 {
+			return []Predicate{
+				{Column: column, Op: opGte, Value: start},
+				{Column: column, Op: opLte, Value: end},
+			}
+		}
+	}
+	return nil
+}
+
+// reverseCompareOp returns the operator comparing the operands of op
+// the other way round.
+func reverseCompareOp(op string) string {
+	switch op {
+	case opLt:
+		return opGt
+	case opLte:
+		return opGte
+	case opGt:
+		return opLt
+	case opGte:
+		return opLte
+	}
+	return op
+}
+
+// unaryTerm returns the term of an operand without arithmetic.
+func (e *Operand) unaryTerm() *UnaryTerm {
+	if len(e.Right) > 0 || len(e.Left.Right) > 0 {
+		return nil
+	}
+	return e.Left.Left
+}
+
+// columnName returns the top level column the operand is.
+func (e *Operand) columnName() (string, bool) {
+	term := e.unaryTerm()
+	if term == nil || term.Primary == nil || term.Primary.JPathExpr == nil ||
+		len(term.Primary.JPathExpr.PathExpr) > 1 {
+		return "", false
+	}
+	return term.Primary.JPathExpr.columnName()
+}
+
+// literal returns the number or string literal the operand is.
+func (e *Operand) literal() (*Value, bool) {
+	term := e.unaryTerm()
+	switch {
+	case term == nil:
+		return nil, false
+	case term.Negated != nil:
+		if lit := term.Negated.Term.Value; lit != nil && lit.Number != nil {
+			return floatToValue(-*lit.Number), true
+		}
+	case term.Primary.Value != nil:
+		if lit := term.Primary.Value; lit.Number != nil || lit.String != nil {
+			v, err := lit.evalNode(nil)
+			return v, err == nil
+		}
+	}
+	return nil, false
+}
+
+// MayMatch returns false when none of the values of the column from
+// min to max satisfies the predicate. Values which cannot be compared
+// with the value of the predicate may match.
+func (p Predicate) MayMatch(min, max *Value) bool {
+	if !comparableValues(p.Value, min) || !comparableValues(p.Value, max) {
+		return true
+	}
+
+	switch p.Op {
+	case opEq:
+		return p.mayHold(min, opLte) && p.mayHold(max, opGte)
+	case opLt, opLte:
+		return p.mayHold(min, p.Op)
+	case opGt, opGte:
+		return p.mayHold(max, p.Op)
+	}
+	return true
+}
+
+// mayHold returns false when `v op value` is false for the value of
+// the predicate.
+func (p Predicate) mayHold(v *Value, op string) bool {
+	ok, err := v.compareOp(op, p.Value)
+	return err != nil || ok
+}
+
+// comparableValues returns whether a and b are both numbers or both
+// strings, compared the same way as the values of the records.
+func comparableValues(a, b *Value) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if a.isNumeric() && b.isNumeric() {
+		return true
+	}
+	_, okA := a.ToString()
+	_, okB := b.ToString()
+	return okA && okB
+}
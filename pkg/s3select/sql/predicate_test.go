@@ -0,0 +1,117 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStatementColumns(t *testing.T) {
+	testCases := []struct {
+		query   string
+		columns []string
+	}{
+		{"SELECT * FROM S3Object", nil},
+		{"SELECT s.* FROM S3Object s", nil},
+		{"SELECT s FROM S3Object s", []string{"s"}},
+		{"SELECT COUNT(*) FROM S3Object", []string{}},
+		{"SELECT one, two FROM S3Object", []string{"one", "two"}},
+		{"SELECT s.one FROM S3Object s WHERE s.two > 1 AND s.three.x = 'a'", []string{"one", "three", "two"}},
+		{"SELECT SUM(s.one) FROM S3Object s WHERE s.two = 'b'", []string{"one", "two"}},
+		{"SELECT LOWER(s.\"my col\") FROM S3Object s", []string{"my col"}},
+		{"SELECT s.one[0] FROM S3Object s", []string{"one"}},
+		{"SELECT s[0] FROM S3Object s", nil},
+		{"SELECT id FROM S3Object[*].items", nil},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelectStatement(testCase.query)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if columns := stmt.Columns(); !reflect.DeepEqual(columns, testCase.columns) {
+			t.Fatalf("test %d: expected columns %#v, got %#v", i+1, testCase.columns, columns)
+		}
+	}
+}
+
+func TestStatementPredicates(t *testing.T) {
+	testCases := []struct {
+		query      string
+		predicates []Predicate
+	}{
+		{"SELECT * FROM S3Object", nil},
+		{"SELECT * FROM S3Object s WHERE s.one > 10", []Predicate{
+			{Column: "one", Op: opGt, Value: FromInt(10)},
+		}},
+		{"SELECT * FROM S3Object s WHERE 10 <= s.one AND s.two = 'a' AND s.three < -2.5", []Predicate{
+			{Column: "one", Op: opGte, Value: FromInt(10)},
+			{Column: "two", Op: opEq, Value: FromString("a")},
+			{Column: "three", Op: opLt, Value: FromFloat(-2.5)},
+		}},
+		{"SELECT * FROM S3Object s WHERE s.one BETWEEN 1 AND 5", []Predicate{
+			{Column: "one", Op: opGte, Value: FromInt(1)},
+			{Column: "one", Op: opLte, Value: FromInt(5)},
+		}},
+		// Conditions which do not bound a column by a literal.
+		{"SELECT * FROM S3Object s WHERE s.one > 10 OR s.two = 'a'", nil},
+		{"SELECT * FROM S3Object s WHERE NOT s.one > 10", nil},
+		{"SELECT * FROM S3Object s WHERE s.one NOT BETWEEN 1 AND 5", nil},
+		{"SELECT * FROM S3Object s WHERE s.one + 1 > 10 AND s.one != 3 AND s.one > s.two", nil},
+		{"SELECT * FROM S3Object s WHERE s.one.x > 10 AND s.one = NULL AND s.two LIKE 'a%'", nil},
+	}
+
+	for i, testCase := range testCases {
+		stmt, err := ParseSelectStatement(testCase.query)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if predicates := stmt.Predicates(); !reflect.DeepEqual(predicates, testCase.predicates) {
+			t.Fatalf("test %d: expected predicates %v, got %v", i+1, testCase.predicates, predicates)
+		}
+	}
+}
+
+func TestPredicateMayMatch(t *testing.T) {
+	testCases := []struct {
+		predicate Predicate
+		min, max  *Value
+		mayMatch  bool
+	}{
+		{Predicate{Op: opEq, Value: FromInt(5)}, FromInt(1), FromInt(10), true},
+		{Predicate{Op: opEq, Value: FromInt(5)}, FromInt(6), FromInt(10), false},
+		{Predicate{Op: opEq, Value: FromInt(5)}, FromInt(1), FromInt(4), false},
+		{Predicate{Op: opLt, Value: FromInt(5)}, FromInt(5), FromInt(10), false},
+		{Predicate{Op: opLte, Value: FromInt(5)}, FromInt(5), FromInt(10), true},
+		{Predicate{Op: opGt, Value: FromInt(5)}, FromInt(1), FromInt(5), false},
+		{Predicate{Op: opGte, Value: FromInt(5)}, FromInt(1), FromInt(5), true},
+		{Predicate{Op: opGt, Value: FromFloat(4.5)}, FromInt(1), FromInt(4), false},
+		{Predicate{Op: opGt, Value: FromInt(4)}, FromFloat(1), FromFloat(4.5), true},
+		{Predicate{Op: opEq, Value: FromString("m")}, FromString("a"), FromString("l"), false},
+		{Predicate{Op: opEq, Value: FromString("m")}, FromString("a"), FromString("z"), true},
+		// Values compared differently, or unknown.
+		{Predicate{Op: opEq, Value: FromString("5")}, FromInt(6), FromInt(10), true},
+		{Predicate{Op: opEq, Value: FromInt(5)}, nil, nil, true},
+	}
+
+	for i, testCase := range testCases {
+		if mayMatch := testCase.predicate.MayMatch(testCase.min, testCase.max); mayMatch != testCase.mayMatch {
+			t.Fatalf("test %d: expected %v, got %v", i+1, testCase.mayMatch, mayMatch)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package sql
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/bcicen/jstream"
@@ -40,6 +41,10 @@ type SelectStatement struct {
 	// Analysis result of the statement
 	selectQProp qProp
 
+	// Columns of the input records referred to by the statement,
+	// nil when all of them are.
+	columns []string
+
 	// Result of parsing the limit clause if one is present
 	// (otherwise -1)
 	limitValue int64
@@ -82,8 +87,9 @@ func ParseSelectStatement(s string) (stmt SelectStatement, err error) {
 	}
 
 	// Analyze where clause
+	var whereQProp qProp
 	if selectAST.Where != nil {
-		whereQProp := selectAST.Where.analyze(&selectAST)
+		whereQProp = selectAST.Where.analyze(&selectAST)
 		if whereQProp.err != nil {
 			err = errQueryAnalysisFailure(fmt.Errorf("Where clause error: %w", whereQProp.err))
 			return
@@ -106,10 +112,28 @@ func ParseSelectStatement(s string) (stmt SelectStatement, err error) {
 	err = stmt.selectQProp.err
 	if err != nil {
 		err = errQueryAnalysisFailure(err)
+		return
+	}
+
+	columns := stmt.selectQProp
+	columns.addColumns(whereQProp)
+	if !columns.allColumns && !selectAST.From.HasKeypath() {
+		stmt.columns = make([]string, 0, len(columns.columns))
+		for column := range columns.columns {
+			stmt.columns = append(stmt.columns, column)
+		}
+		sort.Strings(stmt.columns)
 	}
 	return
 }
 
+// Columns returns the columns of the input records the statement
+// refers to, so that readers of columnar formats only read these. It
+// is nil when the statement refers to all of them.
+func (e *SelectStatement) Columns() []string {
+	return e.columns
+}
+
 func validateTableName(from *TableExpression) error {
 	if strings.ToLower(from.Table.BaseKey.String()) != baseTableName {
 		return errBadTableName(errors.New("table name must be `s3object`"))
@@ -105,6 +105,18 @@ func getLastKeypathComponent(e *Expression) (string, bool) {
 	return ps, true
 }
 
+// columnName returns the column of the input records the path refers
+// to, false when it refers to a whole record or to array elements.
+func (e *JSONPath) columnName() (string, bool) {
+	if len(e.PathExpr) == 0 {
+		return e.BaseKey.String(), true
+	}
+	if e.PathExpr[0].Key != nil {
+		return e.PathExpr[0].Key.keyString(), true
+	}
+	return "", false
+}
+
 // HasKeypath returns if the from clause has a key path -
 // e.g. S3object[*].id
 func (from *TableExpression) HasKeypath() bool {
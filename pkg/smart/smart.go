@@ -0,0 +1,177 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package smart reads the SMART health of the drives with smartctl.
+package smart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrNotSupported - the SMART health of the drive cannot be read on
+// this platform, or smartctl is not installed.
+var ErrNotSupported = errors.New("SMART is not supported")
+
+// The ATA attributes whose raw values are reported.
+const (
+	attrReallocatedSectors   = 5
+	attrPowerOnHours         = 9
+	attrPendingSectors       = 197
+	attrUncorrectableSectors = 198
+)
+
+// Info holds the SMART health of a drive.
+type Info struct {
+	Device string `json:"device"`
+	Model  string `json:"model,omitempty"`
+	Serial string `json:"serial,omitempty"`
+	// Passed is the overall health self-assessment of the drive.
+	Passed       bool   `json:"passed"`
+	Temperature  int    `json:"temperature,omitempty"`
+	PowerOnHours uint64 `json:"powerOnHours,omitempty"`
+
+	// ATA drives.
+	ReallocatedSectors   uint64 `json:"reallocatedSectors,omitempty"`
+	PendingSectors       uint64 `json:"pendingSectors,omitempty"`
+	UncorrectableSectors uint64 `json:"uncorrectableSectors,omitempty"`
+	// Pre-failure attributes whose values reached their thresholds.
+	FailingAttributes []string `json:"failingAttributes,omitempty"`
+
+	// NVMe drives.
+	CriticalWarning int    `json:"criticalWarning,omitempty"`
+	MediaErrors     uint64 `json:"mediaErrors,omitempty"`
+	PercentageUsed  int    `json:"percentageUsed,omitempty"`
+}
+
+// Failing returns why the drive is predicted to fail, empty when it
+// is not.
+func (i Info) Failing() string {
+	switch {
+	case !i.Passed:
+		return "SMART overall health self-assessment failed"
+	case len(i.FailingAttributes) > 0:
+		return "SMART attributes reached their thresholds: " + strings.Join(i.FailingAttributes, ", ")
+	case i.CriticalWarning != 0:
+		return fmt.Sprintf("SMART critical warning %#x", i.CriticalWarning)
+	}
+	return ""
+}
+
+// smartctlOutput - the part of the JSON output of smartctl read.
+type smartctlOutput struct {
+	Device struct {
+		Name string `json:"name"`
+	} `json:"device"`
+	ModelName    string `json:"model_name"`
+	SerialNumber string `json:"serial_number"`
+	SmartStatus  *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature struct {
+		Current int `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours uint64 `json:"hours"`
+	} `json:"power_on_time"`
+	ATASmartAttributes struct {
+		Table []struct {
+			ID     int    `json:"id"`
+			Name   string `json:"name"`
+			Value  int    `json:"value"`
+			Thresh int    `json:"thresh"`
+			Flags  struct {
+				Prefailure bool `json:"prefailure"`
+			} `json:"flags"`
+			Raw struct {
+				Value uint64 `json:"value"`
+			} `json:"raw"`
+		} `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeHealth *struct {
+		CriticalWarning int    `json:"critical_warning"`
+		MediaErrors     uint64 `json:"media_errors"`
+		PercentageUsed  int    `json:"percentage_used"`
+	} `json:"nvme_smart_health_information_log"`
+}
+
+// Parse parses the JSON output of `smartctl --json -i -H -A`.
+func Parse(data []byte) (Info, error) {
+	var out smartctlOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		return Info{}, err
+	}
+	if out.SmartStatus == nil {
+		return Info{}, fmt.Errorf("no SMART health reported for %s", out.Device.Name)
+	}
+
+	info := Info{
+		Device:       out.Device.Name,
+		Model:        out.ModelName,
+		Serial:       out.SerialNumber,
+		Passed:       out.SmartStatus.Passed,
+		Temperature:  out.Temperature.Current,
+		PowerOnHours: out.PowerOnTime.Hours,
+	}
+	for _, attr := range out.ATASmartAttributes.Table {
+		switch attr.ID {
+		case attrReallocatedSectors:
+			info.ReallocatedSectors = attr.Raw.Value
+		case attrPendingSectors:
+			info.PendingSectors = attr.Raw.Value
+		case attrUncorrectableSectors:
+			info.UncorrectableSectors = attr.Raw.Value
+		case attrPowerOnHours:
+			if info.PowerOnHours == 0 {
+				info.PowerOnHours = attr.Raw.Value
+			}
+		}
+		if attr.Flags.Prefailure && attr.Thresh > 0 && attr.Value <= attr.Thresh {
+			info.FailingAttributes = append(info.FailingAttributes, attr.Name)
+		}
+	}
+	if out.NVMeHealth != nil {
+		info.CriticalWarning = out.NVMeHealth.CriticalWarning
+		info.MediaErrors = out.NVMeHealth.MediaErrors
+		info.PercentageUsed = out.NVMeHealth.PercentageUsed
+	}
+	return info, nil
+}
+
+// Read reads the SMART health of a block device, such as `/dev/sda`,
+// with smartctl.
+func Read(ctx context.Context, device string) (Info, error) {
+	smartctl, err := exec.LookPath("smartctl")
+	if err != nil {
+		return Info{}, ErrNotSupported
+	}
+
+	data, err := exec.CommandContext(ctx, smartctl, "--json", "-i", "-H", "-A", device).Output()
+	if err != nil {
+		// The exit status of smartctl is a bit mask, the
+		// output is only missing when the command line could
+		// not be parsed or the device could not be opened.
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode()&0x3 != 0 {
+			return Info{}, fmt.Errorf("smartctl %s: %w", device, err)
+		}
+	}
+	return Parse(data)
+}
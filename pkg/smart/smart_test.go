@@ -0,0 +1,97 @@
+/*
+ * MinIO Cloud Storage, (C) 2020 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smart
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		data     string
+		info     Info
+		failing  bool
+		expectOK bool
+	}{
+		// Healthy ATA drive.
+		{
+			data: `{"device":{"name":"/dev/sda","type":"sat"},"model_name":"HDD","serial_number":"S1",
+"smart_status":{"passed":true},"temperature":{"current":35},"power_on_time":{"hours":1200},
+"ata_smart_attributes":{"table":[
+{"id":5,"name":"Reallocated_Sector_Ct","value":100,"thresh":10,"flags":{"prefailure":true},"raw":{"value":8}},
+{"id":197,"name":"Current_Pending_Sector","value":100,"thresh":0,"flags":{"prefailure":false},"raw":{"value":2}},
+{"id":198,"name":"Offline_Uncorrectable","value":100,"thresh":0,"flags":{"prefailure":false},"raw":{"value":1}}]}}`,
+			info: Info{
+				Device: "/dev/sda", Model: "HDD", Serial: "S1", Passed: true,
+				Temperature: 35, PowerOnHours: 1200,
+				ReallocatedSectors: 8, PendingSectors: 2, UncorrectableSectors: 1,
+			},
+			expectOK: true,
+		},
+		// ATA drive with a pre-failure attribute at its threshold.
+		{
+			data: `{"device":{"name":"/dev/sdb"},"smart_status":{"passed":true},
+"ata_smart_attributes":{"table":[
+{"id":5,"name":"Reallocated_Sector_Ct","value":10,"thresh":10,"flags":{"prefailure":true},"raw":{"value":2000}},
+{"id":9,"name":"Power_On_Hours","value":90,"thresh":0,"flags":{"prefailure":false},"raw":{"value":300}}]}}`,
+			info: Info{
+				Device: "/dev/sdb", Passed: true, PowerOnHours: 300,
+				ReallocatedSectors: 2000, FailingAttributes: []string{"Reallocated_Sector_Ct"},
+			},
+			failing:  true,
+			expectOK: true,
+		},
+		// NVMe drive with a critical warning.
+		{
+			data: `{"device":{"name":"/dev/nvme0"},"smart_status":{"passed":true},
+"nvme_smart_health_information_log":{"critical_warning":4,"media_errors":3,"percentage_used":7}}`,
+			info: Info{
+				Device: "/dev/nvme0", Passed: true,
+				CriticalWarning: 4, MediaErrors: 3, PercentageUsed: 7,
+			},
+			failing:  true,
+			expectOK: true,
+		},
+		// Failed self-assessment.
+		{
+			data:     `{"device":{"name":"/dev/sdc"},"smart_status":{"passed":false}}`,
+			info:     Info{Device: "/dev/sdc"},
+			failing:  true,
+			expectOK: true,
+		},
+		// No health reported, such as for a virtual drive.
+		{data: `{"device":{"name":"/dev/vda"}}`},
+		{data: `not json`},
+	}
+
+	for i, testCase := range testCases {
+		info, err := Parse([]byte(testCase.data))
+		if (err == nil) != testCase.expectOK {
+			t.Fatalf("test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.expectOK {
+			continue
+		}
+		if !reflect.DeepEqual(info, testCase.info) {
+			t.Fatalf("test %d: expected %+v, got %+v", i+1, testCase.info, info)
+		}
+		if (info.Failing() != "") != testCase.failing {
+			t.Fatalf("test %d: expected failing %v, got %q", i+1, testCase.failing, info.Failing())
+		}
+	}
+}